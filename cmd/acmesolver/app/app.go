@@ -18,22 +18,42 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 
 	"github.com/cert-manager/cert-manager/cmd/util"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http/solver"
+	tlsalpnsolver "github.com/cert-manager/cert-manager/pkg/issuer/acme/tlsalpn/solver"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+// challengeSolver is a server that solves a single ACME challenge.
+type challengeSolver interface {
+	Listen(log logr.Logger) error
+	Shutdown(ctx context.Context) error
+}
+
 func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
 	s := new(solver.HTTP01Solver)
+	var challengeType string
 
 	cmd := &cobra.Command{
 		Use:   "acmesolver",
-		Short: "HTTP server used to solve ACME challenges.",
+		Short: "HTTP or TLS server used to solve ACME challenges.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var cs challengeSolver
+			switch challengeType {
+			case "http-01":
+				cs = s
+			case "tls-alpn-01":
+				cs = &tlsalpnsolver.TLSALPN01Solver{ListenPort: s.ListenPort, Domain: s.Domain, Key: s.Key}
+			default:
+				return fmt.Errorf("unsupported challenge type %q", challengeType)
+			}
+
 			rootCtx := util.ContextWithStopCh(context.Background(), stopCh)
 			rootCtx = logf.NewContext(rootCtx, logf.Log, "acmesolver")
 			log := logf.FromContext(rootCtx)
@@ -46,12 +66,12 @@ func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
 				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
 
-				if err := s.Shutdown(ctx); err != nil {
+				if err := cs.Shutdown(ctx); err != nil {
 					log.Error(err, "error shutting down acmesolver server")
 				}
 			}()
 
-			if err := s.Listen(log); err != nil {
+			if err := cs.Listen(log); err != nil {
 				return err
 			}
 
@@ -65,6 +85,7 @@ func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
 	cmd.Flags().StringVar(&s.Domain, "domain", "", "the domain name to verify")
 	cmd.Flags().StringVar(&s.Token, "token", "", "the challenge token to verify against")
 	cmd.Flags().StringVar(&s.Key, "key", "", "the challenge key to respond with")
+	cmd.Flags().StringVar(&challengeType, "challenge-type", "http-01", "the type of challenge to solve, one of http-01 or tls-alpn-01")
 
 	return cmd
 }
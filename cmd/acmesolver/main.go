@@ -24,9 +24,9 @@ import (
 	"github.com/cert-manager/cert-manager/cmd/util"
 )
 
-// acmesolver solves ACME http-01 challenges. This is intended to run as a pod
-// in the target kubernetes cluster in order to solve challenges for
-// cert-manager.
+// acmesolver solves ACME http-01 and tls-alpn-01 challenges. This is intended
+// to run as a pod in the target kubernetes cluster in order to solve
+// challenges for cert-manager.
 
 func main() {
 	stopCh, exit := util.SetupExitHandler(util.GracefulShutdown)
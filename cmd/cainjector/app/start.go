@@ -31,6 +31,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
 	"github.com/cert-manager/cert-manager/pkg/api"
@@ -44,6 +45,7 @@ import (
 // InjectorControllerOptions is a struct having injector controller options values
 type InjectorControllerOptions struct {
 	Namespace               string
+	Namespaces              []string
 	LeaderElect             bool
 	LeaderElectionNamespace string
 	LeaseDuration           time.Duration
@@ -69,6 +71,10 @@ func (o *InjectorControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"If set, this limits the scope of cainjector to a single namespace. "+
 		"If set, cainjector will not update resources with certificates outside of the "+
 		"configured namespace.")
+	fs.StringSliceVar(&o.Namespaces, "namespaces", nil, ""+
+		"If set, this limits the scope of cainjector to the given comma separated list of namespaces, "+
+		"so that it only requires namespaced RBAC to read Certificates and Secrets in each of them. "+
+		"Cannot be used together with --namespace.")
 	fs.BoolVar(&o.LeaderElect, "leader-elect", cmdutil.DefaultLeaderElect, ""+
 		"If true, cainjector will perform leader election between instances to ensure no more "+
 		"than one instance of cainjector operates at a time")
@@ -135,9 +141,20 @@ servers and webhook servers.`,
 }
 
 func (o InjectorControllerOptions) RunInjectorController(ctx context.Context) error {
+	if len(o.Namespace) > 0 && len(o.Namespaces) > 0 {
+		return fmt.Errorf("the --namespace and --namespaces flags cannot be used together")
+	}
+
+	// only cache objects in the allow-listed namespaces if set
+	var newCache cache.NewCacheFunc
+	if len(o.Namespaces) > 0 {
+		newCache = cache.MultiNamespacedCacheBuilder(o.Namespaces)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                        api.Scheme,
 		Namespace:                     o.Namespace,
+		NewCache:                      newCache,
 		LeaderElection:                o.LeaderElect,
 		LeaderElectionNamespace:       o.LeaderElectionNamespace,
 		LeaderElectionID:              "cert-manager-cainjector-leader-election",
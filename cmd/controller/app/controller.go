@@ -26,24 +26,29 @@ import (
 	"time"
 
 	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/cmd/controller/app/options"
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
+	"github.com/cert-manager/cert-manager/internal/controller/audit"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	"github.com/cert-manager/cert-manager/pkg/acme/ratelimit"
 	"github.com/cert-manager/cert-manager/pkg/controller"
-	"github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/profiling"
 )
 
@@ -54,10 +59,21 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 	log := logf.FromContext(rootCtx)
 	g, rootCtx := errgroup.WithContext(rootCtx)
 
-	ctxFactory, err := buildControllerContextFactory(rootCtx, opts)
+	if opts.FIPSMode {
+		pki.EnableFIPSMode(!opts.FIPSDisallowP521)
+	}
+	if pki.FIPSModeEnabled() {
+		log.Info("FIPS mode is enabled, keys using algorithms which are not FIPS approved are rejected")
+	}
+
+	ctxFactories, err := buildControllerContextFactories(rootCtx, opts)
 	if err != nil {
 		return err
 	}
+	// All factories share the same clients configuration and metrics, so the
+	// first one is used to build the base controller context and to perform
+	// leader election.
+	ctxFactory := ctxFactories[0]
 
 	// Build the base controller context for the cert-manager controller manager
 	// used here.
@@ -174,48 +190,61 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		// Continue with setting up controller
 	}
 
-	for n, fn := range controller.Known() {
-		log := log.WithValues("controller", n)
-
-		// only run a controller if it's been enabled
-		if !enabledControllers.Has(n) {
-			log.V(logf.InfoLevel).Info("not starting controller as it's disabled")
-			continue
+	// When scoped to a set of namespaces, each namespace has its own
+	// informers and so its own instance of each controller.
+	for _, ctxFactory := range ctxFactories {
+		ctx, err := ctxFactory.Build()
+		if err != nil {
+			return err
 		}
 
-		// don't run clusterissuers controller if scoped to a single namespace
-		if ctx.Namespace != "" && n == clusterissuers.ControllerName {
-			log.V(logf.InfoLevel).Info("not starting controller as cert-manager has been scoped to a single namespace")
-			continue
-		}
+		for n, fn := range controller.Known() {
+			log := log.WithValues("controller", n)
+			if ctx.Namespace != "" {
+				log = log.WithValues("namespace", ctx.Namespace)
+			}
 
-		iface, err := fn(ctxFactory)
-		if err != nil {
-			err = fmt.Errorf("error starting controller: %v", err)
+			// only run a controller if it's been enabled
+			if !enabledControllers.Has(n) {
+				log.V(logf.InfoLevel).Info("not starting controller as it's disabled")
+				continue
+			}
 
-			cancelContext()
-			err2 := g.Wait() // Don't process errors, we already have an error
-			if err2 != nil {
-				return utilerrors.NewAggregate([]error{err, err2})
+			// don't run controllers of cluster scoped resources if scoped to
+			// namespaces
+			if ctx.Namespace != "" && options.IsClusterScopedController(n) {
+				log.V(logf.InfoLevel).Info("not starting controller as cert-manager has been scoped to namespaces")
+				continue
 			}
-			return err
-		}
 
-		g.Go(func() error {
-			log.V(logf.InfoLevel).Info("starting controller")
+			iface, err := fn(ctxFactory)
+			if err != nil {
+				err = fmt.Errorf("error starting controller: %v", err)
 
-			// TODO: make this either a constant or a command line flag
-			workers := 5
-			return iface.Run(workers, rootCtx.Done())
-		})
-	}
+				cancelContext()
+				err2 := g.Wait() // Don't process errors, we already have an error
+				if err2 != nil {
+					return utilerrors.NewAggregate([]error{err, err2})
+				}
+				return err
+			}
+
+			g.Go(func() error {
+				log.V(logf.InfoLevel).Info("starting controller")
+
+				// TODO: make this either a constant or a command line flag
+				workers := 5
+				return iface.Run(workers, rootCtx.Done())
+			})
+		}
 
-	log.V(logf.DebugLevel).Info("starting shared informer factories")
-	ctx.SharedInformerFactory.Start(rootCtx.Done())
-	ctx.KubeSharedInformerFactory.Start(rootCtx.Done())
+		log.V(logf.DebugLevel).Info("starting shared informer factories", "namespace", ctx.Namespace)
+		ctx.SharedInformerFactory.Start(rootCtx.Done())
+		ctx.KubeSharedInformerFactory.Start(rootCtx.Done())
 
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalGatewayAPISupport) {
-		ctx.GWShared.Start(rootCtx.Done())
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalGatewayAPISupport) {
+			ctx.GWShared.Start(rootCtx.Done())
+		}
 	}
 
 	err = g.Wait()
@@ -227,9 +256,12 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 	return nil
 }
 
-// buildControllerContextFactory builds a new controller ContextFactory which
-// can build controller contexts for each component.
-func buildControllerContextFactory(ctx context.Context, opts *options.ControllerOptions) (*controller.ContextFactory, error) {
+// buildControllerContextFactories builds the controller ContextFactories which
+// can build controller contexts for each component. A single factory is
+// returned unless cert-manager has been scoped to a set of namespaces, in
+// which case there is a factory for each namespace. All factories share the
+// same metrics, ACME accounts and rate limiters.
+func buildControllerContextFactories(ctx context.Context, opts *options.ControllerOptions) ([]*controller.ContextFactory, error) {
 	log := logf.FromContext(ctx)
 
 	nameservers := opts.DNS01RecursiveNameservers
@@ -261,20 +293,54 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		return nil, fmt.Errorf("error parsing ACMEHTTP01SolverResourceLimitsMemory: %w", err)
 	}
 
+	selfCheckPreferredIPFamily := corev1.IPFamily(opts.ACMESelfCheckPreferredIPFamily)
+	if len(selfCheckPreferredIPFamily) == 0 {
+		selfCheckPreferredIPFamily = util.DetectIPFamily()
+	}
+
+	log.V(logf.InfoLevel).WithName("build-context").
+		WithValues("ip_family", selfCheckPreferredIPFamily).
+		Info("configured preferred IP family for acme self-checks")
+
 	ACMEHTTP01SolverRunAsNonRoot := opts.ACMEHTTP01SolverRunAsNonRoot
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
-	ctxFactory, err := controller.NewContextFactory(ctx, controller.ContextOptions{
-		Kubeconfig:         opts.Kubeconfig,
-		KubernetesAPIQPS:   opts.KubernetesAPIQPS,
-		KubernetesAPIBurst: opts.KubernetesAPIBurst,
-		APIServerHost:      opts.APIServerHost,
+	controllerRateLimits, err := opts.ControllerRateLimits()
+	if err != nil {
+		return nil, err
+	}
 
-		Namespace: opts.Namespace,
+	issuerZoneRoutes, err := opts.IssuerZoneRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	var acmeHTTPRateLimiter flowcontrol.RateLimiter
+	if opts.ACMEHTTPQPS > 0 {
+		acmeHTTPRateLimiter = flowcontrol.NewTokenBucketRateLimiter(opts.ACMEHTTPQPS, opts.ACMEHTTPBurst)
+	}
+
+	auditSink, err := buildAuditSink(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxOpts := controller.ContextOptions{
+		Kubeconfig:                        opts.Kubeconfig,
+		KubernetesAPIQPS:                  opts.KubernetesAPIQPS,
+		KubernetesAPIBurst:                opts.KubernetesAPIBurst,
+		ControllerKubernetesAPIRateLimits: controllerRateLimits,
+		KubernetesAPIPriorityAndFairness:  opts.KubernetesAPIPriorityAndFairness,
+		StatusUpdateQPS:                   opts.StatusUpdateQPS,
+		StatusUpdateBurst:                 opts.StatusUpdateBurst,
+		APIServerHost:                     opts.APIServerHost,
+		ControllerClass:                   opts.ControllerClass,
 
 		Clock:   clock.RealClock{},
 		Metrics: metrics.New(log, clock.RealClock{}),
 
+		AuditSink: auditSink,
+
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverResourceRequestCPU:    http01SolverResourceRequestCPU,
 			HTTP01SolverResourceRequestMemory: http01SolverResourceRequestMemory,
@@ -282,13 +348,20 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			HTTP01SolverResourceLimitsMemory:  http01SolverResourceLimitsMemory,
 			ACMEHTTP01SolverRunAsNonRoot:      ACMEHTTP01SolverRunAsNonRoot,
 			HTTP01SolverImage:                 opts.ACMEHTTP01SolverImage,
+			HTTP01SolverWindowsImage:          opts.ACMEHTTP01SolverWindowsImage,
+			HTTP01SolverImageArchitectures:    opts.ACMEHTTP01SolverImageArchitectures,
 			// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
 			HTTP01SolverNameservers: opts.ACMEHTTP01SolverNameservers,
 
+			HTTPRateLimiter:  acmeHTTPRateLimiter,
+			RateLimitTracker: ratelimit.NewTracker(clock.RealClock{}, ratelimit.DefaultLimits),
+
 			DNS01Nameservers:        nameservers,
 			DNS01CheckRetryPeriod:   opts.DNS01CheckRetryPeriod,
 			DNS01CheckAuthoritative: !opts.DNS01RecursiveNameserversOnly,
 
+			SelfCheckPreferredIPFamily: selfCheckPreferredIPFamily,
+
 			AccountRegistry: acmeAccountRegistry,
 		},
 
@@ -296,6 +369,10 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			MaxConcurrentChallenges: opts.MaxConcurrentChallenges,
 		},
 
+		CertificateSigningRequestOptions: controller.CertificateSigningRequestOptions{
+			KubeletServingIssuer: opts.KubeletServingIssuer,
+		},
+
 		IssuerOptions: controller.IssuerOptions{
 			ClusterIssuerAmbientCredentials: opts.ClusterIssuerAmbientCredentials,
 			IssuerAmbientCredentials:        opts.IssuerAmbientCredentials,
@@ -307,18 +384,42 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			DefaultIssuerKind:                 opts.DefaultIssuerKind,
 			DefaultIssuerGroup:                opts.DefaultIssuerGroup,
 			DefaultAutoCertificateAnnotations: opts.DefaultAutoCertificateAnnotations,
+			DefaultIssuerZoneRoutes:           issuerZoneRoutes,
 		},
 
 		CertificateOptions: controller.CertificateOptions{
 			EnableOwnerRef:           opts.EnableCertificateOwnerRef,
 			CopiedAnnotationPrefixes: opts.CopiedAnnotationPrefixes,
+			TransparencyLogURL:       opts.TransparencyLogURL,
+			SecretWatchdogNamespaces: opts.SecretWatchdogNamespaces,
+			SecretWatchdogAdopt:      opts.SecretWatchdogAdopt,
+			DriftCheckInterval:       opts.DriftCheckInterval,
+
+			RevocationCheckInterval:          opts.RevocationCheckInterval,
+			CertificateRequestPendingTimeout: opts.CertificateRequestPendingTimeout,
+			SigningUsageNamespaces:           opts.SigningUsageNamespaces,
+			RestoreMode:                      opts.RestoreMode,
 		},
-	})
-	if err != nil {
-		return nil, err
 	}
 
-	return ctxFactory, nil
+	namespaces := opts.ScopedNamespaces()
+	if len(namespaces) == 0 {
+		// watch all namespaces
+		namespaces = []string{""}
+	}
+
+	var ctxFactories []*controller.ContextFactory
+	for _, namespace := range namespaces {
+		nsOpts := ctxOpts
+		nsOpts.Namespace = namespace
+		ctxFactory, err := controller.NewContextFactory(ctx, nsOpts)
+		if err != nil {
+			return nil, err
+		}
+		ctxFactories = append(ctxFactories, ctxFactory)
+	}
+
+	return ctxFactories, nil
 }
 
 func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, leaderElectionClient kubernetes.Interface, recorder record.EventRecorder, callbacks leaderelection.LeaderCallbacks) error {
@@ -329,6 +430,10 @@ func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, l
 	}
 
 	lockName := "cert-manager-controller"
+	// Installations of different classes must not contend for the same lock.
+	if opts.ControllerClass != "" {
+		lockName += "-" + opts.ControllerClass
+	}
 	lc := resourcelock.ResourceLockConfig{
 		Identity:      id + "-external-cert-manager-controller",
 		EventRecorder: recorder,
@@ -364,3 +469,30 @@ func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, l
 
 	return nil
 }
+
+// buildAuditSink returns the Sink that audit records are written to, or nil
+// if no audit log has been configured.
+func buildAuditSink(opts *options.ControllerOptions) (audit.Sink, error) {
+	var sinks audit.MultiSink
+
+	switch opts.AuditLogPath {
+	case "":
+	case "-":
+		sinks = append(sinks, audit.NewWriterSink(os.Stdout))
+	default:
+		f, err := os.OpenFile(opts.AuditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("error opening audit log file: %w", err)
+		}
+		sinks = append(sinks, audit.NewWriterSink(f))
+	}
+
+	if len(opts.AuditWebhookURL) > 0 {
+		sinks = append(sinks, audit.NewWebhookSink(opts.AuditWebhookURL, &http.Client{Timeout: 10 * time.Second}))
+	}
+
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	return sinks, nil
+}
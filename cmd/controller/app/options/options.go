@@ -20,15 +20,22 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cm "github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
 	challengescontroller "github.com/cert-manager/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/cert-manager/cert-manager/pkg/controller/acmeorders"
 	shimgatewaycontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/gateways"
@@ -36,23 +43,44 @@ import (
 	cracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/acme"
 	crapprovercontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/approver"
 	crcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/ca"
+	crdelegatecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/delegate"
+	crfakecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/fakeissuer"
+	crofflinecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/offline"
 	crselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/selfsigned"
+	crstalecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/stale"
 	crvaultcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/vault"
 	crvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/venafi"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/carotation"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/drift"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/keymanager"
 	certificatesmetricscontroller "github.com/cert-manager/cert-manager/pkg/controller/certificates/metrics"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/notifier"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/readiness"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/report"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/requestmanager"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/revisionmanager"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/revocationcheck"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/rollout"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/secretcleanup"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/secretsnapshot"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/splitissuance"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/transparencylog"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/trigger"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/vaultrevocation"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/workloadrestart"
 	csracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/acme"
 	csrcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/ca"
+	csrkubeletservingcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/kubeletserving"
 	csrselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/selfsigned"
 	csrvaultcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/vault"
 	csrvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/venafi"
 	clusterissuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
+	clusterissuersintermediatecacontroller "github.com/cert-manager/cert-manager/pkg/controller/clusterissuers/intermediateca"
+	clusterissuersusagecontroller "github.com/cert-manager/cert-manager/pkg/controller/clusterissuers/usage"
 	issuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/issuers"
+	"github.com/cert-manager/cert-manager/pkg/controller/secretwatchdog"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -64,8 +92,32 @@ type ControllerOptions struct {
 	KubernetesAPIQPS   float32
 	KubernetesAPIBurst int
 
+	// ControllerKubernetesAPIRateLimits maps controller names to rate limits
+	// in the format <qps>:<burst>.
+	ControllerKubernetesAPIRateLimits map[string]string
+	KubernetesAPIPriorityAndFairness  bool
+
+	StatusUpdateQPS   float32
+	StatusUpdateBurst int
+
+	ACMEHTTPQPS   float32
+	ACMEHTTPBurst int
+
+	// AuditLogPath is the file that audit records of issuance decisions are
+	// appended to, or "-" for stdout.
+	AuditLogPath string
+	// AuditWebhookURL is the URL that audit records of issuance decisions are
+	// sent to.
+	AuditWebhookURL string
+
 	ClusterResourceNamespace string
 	Namespace                string
+	// Namespaces is the allow-list of namespaces cert-manager is scoped to.
+	// Unlike Namespace, it may contain more than one namespace.
+	Namespaces []string
+	// ControllerClass is the class of this installation of cert-manager,
+	// matched against the spec.controllerName of Certificates and issuers.
+	ControllerClass string
 
 	LeaderElect                 bool
 	LeaderElectionNamespace     string
@@ -76,6 +128,8 @@ type ControllerOptions struct {
 	controllers []string
 
 	ACMEHTTP01SolverImage                 string
+	ACMEHTTP01SolverWindowsImage          string
+	ACMEHTTP01SolverImageArchitectures    []string
 	ACMEHTTP01SolverResourceRequestCPU    string
 	ACMEHTTP01SolverResourceRequestMemory string
 	ACMEHTTP01SolverResourceLimitsCPU     string
@@ -84,6 +138,8 @@ type ControllerOptions struct {
 	// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
 	ACMEHTTP01SolverNameservers []string
 
+	ACMESelfCheckPreferredIPFamily string
+
 	ClusterIssuerAmbientCredentials bool
 	IssuerAmbientCredentials        bool
 
@@ -92,6 +148,10 @@ type ControllerOptions struct {
 	DefaultIssuerKind                 string
 	DefaultIssuerGroup                string
 	DefaultAutoCertificateAnnotations []string
+	// DefaultIssuerZoneRoutes maps DNS zones to the issuer used by
+	// ingress-shim for the hosts in the zone, in the format
+	// <kind>[.<group>]/<name>
+	DefaultIssuerZoneRoutes map[string]string
 
 	// Allows specifying a list of custom nameservers to perform DNS checks on.
 	DNS01RecursiveNameservers []string
@@ -121,6 +181,48 @@ type ControllerOptions struct {
 	// CertificateRequest -> Order. Slice of string literals that are
 	// treated as prefixes for annotation keys.
 	CopiedAnnotationPrefixes []string
+
+	// TransparencyLogURL is the URL of a Rekor compatible transparency log
+	// that issued certificates are published to.
+	TransparencyLogURL string
+
+	// SecretWatchdogNamespaces are the namespaces whose TLS Secrets are
+	// reported by the secret watchdog controller.
+	SecretWatchdogNamespaces []string
+
+	// SecretWatchdogAdopt controls whether the secret watchdog controller
+	// adopts unmanaged TLS Secrets.
+	SecretWatchdogAdopt bool
+
+	// KubeletServingIssuer is the signer name of the CA Issuer or
+	// ClusterIssuer used to sign kubelet serving certificates.
+	KubeletServingIssuer string
+
+	// DriftCheckInterval is the interval at which the endpoints serving
+	// issued certificates are checked for stale certificates.
+	DriftCheckInterval time.Duration
+
+	// RevocationCheckInterval is the interval at which issued certificates
+	// are checked for revocation using OCSP.
+	RevocationCheckInterval time.Duration
+
+	// CertificateRequestPendingTimeout is the time after which pending
+	// CertificateRequests are marked as failed.
+	CertificateRequestPendingTimeout time.Duration
+
+	// SigningUsageNamespaces are the namespaces in which CertificateRequests
+	// for code signing or document signing certificates are approved.
+	SigningUsageNamespaces []string
+
+	// RestoreMode enables adopting the certificates in Secrets restored from
+	// a backup instead of re-issuing them.
+	RestoreMode bool
+
+	// FIPSMode rejects private keys and certificate signing requests using
+	// algorithms which are not approved in FIPS 140 mode.
+	FIPSMode bool
+	// FIPSDisallowP521 additionally rejects ECDSA P-521 keys in FIPS mode.
+	FIPSDisallowP521 bool
 }
 
 const (
@@ -129,6 +231,12 @@ const (
 	defaultKubernetesAPIQPS   float32 = 20
 	defaultKubernetesAPIBurst         = 50
 
+	defaultStatusUpdateQPS   float32 = 0
+	defaultStatusUpdateBurst         = 0
+
+	defaultACMEHTTPQPS   float32 = 0
+	defaultACMEHTTPBurst         = 0
+
 	defaultClusterResourceNamespace = "kube-system"
 	defaultNamespace                = ""
 
@@ -152,6 +260,8 @@ const (
 
 var (
 	defaultACMEHTTP01SolverImage                 = fmt.Sprintf("quay.io/jetstack/cert-manager-acmesolver:%s", util.AppVersion)
+	defaultACMEHTTP01SolverWindowsImage          = fmt.Sprintf("quay.io/jetstack/cert-manager-acmesolver-windows:%s", util.AppVersion)
+	defaultACMEHTTP01SolverImageArchitectures    = []string{"amd64", "arm64", "s390x", "ppc64le", "arm"}
 	defaultACMEHTTP01SolverResourceRequestCPU    = "10m"
 	defaultACMEHTTP01SolverResourceRequestMemory = "64Mi"
 	defaultACMEHTTP01SolverResourceLimitsCPU     = "100m"
@@ -163,6 +273,8 @@ var (
 	allControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
+		clusterissuersusagecontroller.ControllerName,
+		clusterissuersintermediatecacontroller.ControllerName,
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		shimgatewaycontroller.ControllerName,
@@ -174,6 +286,10 @@ var (
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
+		crfakecontroller.CRControllerName,
+		crdelegatecontroller.CRControllerName,
+		crofflinecontroller.CRControllerName,
+		crstalecontroller.ControllerName,
 		// certificate controllers
 		trigger.ControllerName,
 		issuing.ControllerName,
@@ -181,6 +297,20 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		transparencylog.ControllerName,
+		drift.ControllerName,
+		revocationcheck.ControllerName,
+		splitissuance.ControllerName,
+		notifier.ControllerName,
+		rollout.ControllerName,
+		workloadrestart.ControllerName,
+		vaultrevocation.ControllerName,
+		secretsnapshot.ControllerName,
+		secretwatchdog.ControllerName,
+		carotation.ControllerName,
+		secretcleanup.ControllerName,
+		report.ControllerName,
+		csrkubeletservingcontroller.CSRControllerName,
 	}
 
 	defaultEnabledControllers = []string{
@@ -212,6 +342,26 @@ var (
 		csrvenaficontroller.CSRControllerName,
 		csrvaultcontroller.CSRControllerName,
 	}
+
+	// clusterScopedControllers are the controllers which reconcile cluster
+	// scoped resources, and so are not run when cert-manager has been scoped
+	// to namespaces.
+	clusterScopedControllers = sets.NewString(
+		clusterissuerscontroller.ControllerName,
+		clusterissuersusagecontroller.ControllerName,
+		clusterissuersintermediatecacontroller.ControllerName,
+		csracmecontroller.CSRControllerName,
+		csrcacontroller.CSRControllerName,
+		csrselfsignedcontroller.CSRControllerName,
+		csrvenaficontroller.CSRControllerName,
+		csrvaultcontroller.CSRControllerName,
+		csrkubeletservingcontroller.CSRControllerName,
+		// Notifiers are cluster scoped
+		notifier.ControllerName,
+		// the delegate issuer watches Namespaces to match namespace selectors
+		crdelegatecontroller.CRControllerName,
+	)
+
 	// Annotations that will be copied from Certificate to CertificateRequest and to Order.
 	// By default, copy all annotations except for the ones applied by kubectl, fluxcd, argocd.
 	defaultCopiedAnnotationPrefixes = []string{
@@ -228,6 +378,10 @@ func NewControllerOptions() *ControllerOptions {
 		ClusterResourceNamespace:          defaultClusterResourceNamespace,
 		KubernetesAPIQPS:                  defaultKubernetesAPIQPS,
 		KubernetesAPIBurst:                defaultKubernetesAPIBurst,
+		StatusUpdateQPS:                   defaultStatusUpdateQPS,
+		StatusUpdateBurst:                 defaultStatusUpdateBurst,
+		ACMEHTTPQPS:                       defaultACMEHTTPQPS,
+		ACMEHTTPBurst:                     defaultACMEHTTPBurst,
 		Namespace:                         defaultNamespace,
 		LeaderElect:                       cmdutil.DefaultLeaderElect,
 		LeaderElectionNamespace:           cmdutil.DefaultLeaderElectionNamespace,
@@ -260,12 +414,37 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Paths to a kubeconfig. Only required if out-of-cluster.")
 	fs.Float32Var(&s.KubernetesAPIQPS, "kube-api-qps", defaultKubernetesAPIQPS, "indicates the maximum queries-per-second requests to the Kubernetes apiserver")
 	fs.IntVar(&s.KubernetesAPIBurst, "kube-api-burst", defaultKubernetesAPIBurst, "the maximum burst queries-per-second of requests sent to the Kubernetes apiserver")
+	fs.StringToStringVar(&s.ControllerKubernetesAPIRateLimits, "controller-kube-api-rate-limits", nil, "Kubernetes apiserver rate limits of individual controllers, in the format <controller>=<qps>:<burst>, for example 'challenges=5:10'. "+
+		"Controllers without a rate limit share the --kube-api-qps and --kube-api-burst rate limit.")
+	fs.BoolVar(&s.KubernetesAPIPriorityAndFairness, "kube-api-priority-and-fairness", false, "If true and the Kubernetes apiserver has API Priority and Fairness enabled, "+
+		"the shared --kube-api-qps and --kube-api-burst rate limit is disabled and the apiserver is left to share capacity between controllers. "+
+		"Rate limits set with --controller-kube-api-rate-limits still apply.")
+	fs.Float32Var(&s.StatusUpdateQPS, "status-update-qps", defaultStatusUpdateQPS, "the maximum queries-per-second of status updates sent to the Kubernetes apiserver. "+
+		"If set, status updates are rate limited separately from --kube-api-qps, and updates of the status of the same resource "+
+		"which are waiting for the rate limit are coalesced so that only the latest is sent. "+
+		"If zero, status updates share the --kube-api-qps rate limit with all other requests.")
+	fs.Float32Var(&s.ACMEHTTPQPS, "acme-http-qps", defaultACMEHTTPQPS, "the maximum queries-per-second of requests sent to ACME servers by all ACME issuers. If zero, requests to ACME servers are not rate limited.")
+	fs.IntVar(&s.ACMEHTTPBurst, "acme-http-burst", defaultACMEHTTPBurst, "the maximum burst queries-per-second of requests sent to ACME servers. Required if --acme-http-qps is set.")
+	fs.IntVar(&s.StatusUpdateBurst, "status-update-burst", defaultStatusUpdateBurst, "the maximum burst queries-per-second of status updates sent to the Kubernetes apiserver. Required if --status-update-qps is set.")
+	fs.StringVar(&s.AuditLogPath, "audit-log-path", "", "If set, a JSON record of every issuance decision made for CertificateRequests of the built-in issuers is appended to this file. "+
+		"Use '-' to write the records to stdout.")
+	fs.StringVar(&s.AuditWebhookURL, "audit-webhook-url", "", "If set, a JSON record of every issuance decision made for CertificateRequests of the built-in issuers is sent as a POST request to this URL.")
 	fs.StringVar(&s.ClusterResourceNamespace, "cluster-resource-namespace", defaultClusterResourceNamespace, ""+
 		"Namespace to store resources owned by cluster scoped resources such as ClusterIssuer in. "+
 		"This must be specified if ClusterIssuers are enabled.")
 	fs.StringVar(&s.Namespace, "namespace", defaultNamespace, ""+
 		"If set, this limits the scope of cert-manager to a single namespace and ClusterIssuers are disabled. "+
 		"If not specified, all namespaces will be watched")
+	fs.StringSliceVar(&s.Namespaces, "namespaces", nil, ""+
+		"If set, this limits the scope of cert-manager to the given comma separated list of namespaces, "+
+		"so that it only requires namespaced RBAC in each of them. ClusterIssuers and controllers of "+
+		"cluster scoped resources, such as CertificateSigningRequests, are disabled. "+
+		"Cannot be used together with --namespace.")
+	fs.StringVar(&s.ControllerClass, "controller-class", "", ""+
+		"The class of this installation of cert-manager, so that several installations can run in the same cluster. "+
+		"Only Certificates and issuers whose spec.controllerName is equal to the class are managed, and only "+
+		"Ingresses and Gateways whose cert-manager.io/controller-name annotation is equal to it are synced. "+
+		"If not specified, only resources which don't set a controller name are managed.")
 	fs.BoolVar(&s.LeaderElect, "leader-elect", cmdutil.DefaultLeaderElect, ""+
 		"If true, cert-manager will perform leader election between instances to ensure no more "+
 		"than one instance of cert-manager operates at a time")
@@ -300,6 +479,14 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.ACMEHTTP01SolverImage, "acme-http01-solver-image", defaultACMEHTTP01SolverImage, ""+
 		"The docker image to use to solve ACME HTTP01 challenges. You most likely will not "+
 		"need to change this parameter unless you are testing a new feature or developing cert-manager.")
+	fs.StringVar(&s.ACMEHTTP01SolverWindowsImage, "acme-http01-solver-windows-image", defaultACMEHTTP01SolverWindowsImage, ""+
+		"The docker image to use to solve ACME HTTP01 challenges with solver pods which are scheduled on Windows nodes, "+
+		"by setting the kubernetes.io/os node selector of the solver's pod template to 'windows'.")
+	fs.StringSliceVar(&s.ACMEHTTP01SolverImageArchitectures, "acme-http01-solver-image-architectures", defaultACMEHTTP01SolverImageArchitectures, ""+
+		"The architectures provided by the manifest list of the --acme-http01-solver-image. Solver pods are only scheduled on nodes "+
+		"of these architectures, unless the solver's pod template selects an architecture using the kubernetes.io/arch node selector, "+
+		"in which case the image configured for that architecture in the pod template's architectureImages is used. "+
+		"If empty, solver pods may be scheduled on nodes of any architecture.")
 
 	fs.StringVar(&s.ACMEHTTP01SolverResourceRequestCPU, "acme-http01-solver-resource-request-cpu", defaultACMEHTTP01SolverResourceRequestCPU, ""+
 		"Defines the resource request CPU size when spawning new ACME HTTP01 challenge solver pods.")
@@ -320,6 +507,11 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		[]string{}, "A list of comma separated dns server endpoints used for "+
 			"ACME HTTP01 check requests. This should be a list containing host and "+
 			"port, for example 8.8.8.8:53,8.8.4.4:53")
+	fs.StringVar(&s.ACMESelfCheckPreferredIPFamily, "acme-self-check-preferred-ip-family", "",
+		"The IP family (IPv4 or IPv6) whose addresses are tried first when performing ACME "+
+			"HTTP01 and DNS01 self-checks against dual-stack endpoints. If not set, IPv6 is "+
+			"preferred if the controller only has IPv6 addresses, and IPv4 otherwise. The IP "+
+			"family can be forced for an individual solver using its selfCheckIPFamily field.")
 
 	fs.BoolVar(&s.ClusterIssuerAmbientCredentials, "cluster-issuer-ambient-credentials", defaultClusterIssuerAmbientCredentials, ""+
 		"Whether a cluster-issuer may make use of ambient credentials for issuers. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the ClusterIssuer API object. "+
@@ -338,10 +530,20 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Kind of the Issuer to use when the tls is requested but issuer kind is not specified on the ingress resource.")
 	fs.StringVar(&s.DefaultIssuerGroup, "default-issuer-group", defaultTLSACMEIssuerGroup, ""+
 		"Group of the Issuer to use when the tls is requested but issuer group is not specified on the ingress resource.")
+	fs.StringToStringVar(&s.DefaultIssuerZoneRoutes, "default-issuer-zone-routes", nil, ""+
+		"DNS zones mapped to the issuer to use instead of the default issuer for the hosts in the zone or its subdomains, "+
+		"in the format <zone>=<kind>[.<group>]/<name>, for example 'example.com=ClusterIssuer/letsencrypt,corp.internal=ClusterIssuer/internal-ca'. "+
+		"Each host is routed by the longest matching zone. The routes only apply to resources which don't specify an issuer with an annotation.")
 	fs.StringSliceVar(&s.DNS01RecursiveNameservers, "dns01-recursive-nameservers",
 		[]string{}, "A list of comma separated dns server endpoints used for "+
 			"DNS01 check requests. This should be a list containing host and "+
-			"port, for example 8.8.8.8:53,8.8.4.4:53")
+			"port, for example 8.8.8.8:53,8.8.4.4:53. The host may be an in-cluster "+
+			"resolver Service, for example kube-dns.kube-system.svc.cluster.local:53. "+
+			"DNS-over-HTTPS endpoints can be given as a URL, for example "+
+			"https://dns.google/dns-query, and DNS-over-TLS servers as tls://host:port, "+
+			"for example tls://1.1.1.1:853. Combine with "+
+			"--dns01-recursive-nameservers-only in clusters which cannot query "+
+			"authoritative nameservers on port 53.")
 	fs.BoolVar(&s.DNS01RecursiveNameserversOnly, "dns01-recursive-nameservers-only",
 		defaultDNS01RecursiveNameserversOnly,
 		"When true, cert-manager will only ever query the configured DNS resolvers "+
@@ -357,11 +559,49 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"from Certificate to CertificateRequest and Order, as well as from CertificateSigningRequest to Order, by passing a list of annotation key prefixes."+
 		"A prefix starting with a dash(-) specifies an annotation that shouldn't be copied. Example: '*,-kubectl.kuberenetes.io/'- all annotations"+
 		"will be copied apart from the ones where the key is prefixed with 'kubectl.kubernetes.io/'.")
+	fs.StringVar(&s.TransparencyLogURL, "transparency-log-url", "", "If set, the certificates of Ready Certificates are published to the Rekor compatible transparency log at this URL, "+
+		"and the resulting log entry and inclusion proof are recorded in the status of the Certificate. "+
+		"Setting this flag enables the "+transparencylog.ControllerName+" controller.")
+	fs.StringSliceVar(&s.SecretWatchdogNamespaces, "secret-watchdog-namespaces", nil, "If set, the TLS Secrets in these namespaces, including Secrets "+
+		"not managed by cert-manager, are reported in a TLSSecretReport in each namespace and their expiry is exposed as a metric. "+
+		"Use '*' to select all namespaces. Setting this flag enables the "+secretwatchdog.ControllerName+" controller.")
+	fs.BoolVar(&s.SecretWatchdogAdopt, "secret-watchdog-adopt", false, "If true, the "+secretwatchdog.ControllerName+" controller creates "+
+		"Certificates for unmanaged TLS Secrets which are annotated with the issuer to use, using the "+
+		"'cert-manager.io/issuer' or 'cert-manager.io/cluster-issuer' annotations.")
+	fs.StringVar(&s.KubeletServingIssuer, "kubelet-serving-issuer", "", "If set, CertificateSigningRequests for the "+
+		"'kubernetes.io/kubelet-serving' signer are verified against the addresses of the requesting Node, approved or denied, "+
+		"and signed by this CA issuer. The issuer is given as a signer name, e.g. 'clusterissuers.cert-manager.io/<name>' or "+
+		"'issuers.cert-manager.io/<namespace>.<name>'. Setting this flag enables the "+csrkubeletservingcontroller.CSRControllerName+" controller.")
+	fs.DurationVar(&s.DriftCheckInterval, "drift-check-interval", 0, "If set, the endpoints that the certificates of Ready Certificates "+
+		"are served on are checked at this interval, and the 'Drifted' condition of a Certificate is set if any endpoint serves a stale certificate. "+
+		"Endpoints are read from the '"+cmapi.DriftCheckEndpointsAnnotationKey+"' annotation and discovered from Ingresses and Gateways using the Certificate's Secret. "+
+		"Setting this flag enables the "+drift.ControllerName+" controller.")
+	fs.DurationVar(&s.RevocationCheckInterval, "revocation-check-interval", 0, "If set, the certificates of Certificates are checked for "+
+		"revocation at this interval, using the OCSP responder named in the certificate. "+
+		"A revoked certificate is marked with the '"+cmapi.EmergencyReissuanceAnnotationKey+"' annotation, and re-issued immediately regardless of its renewal time, "+
+		"RenewalWindows and the backoff after failed issuances. For ACME issuers, an event is recorded if the ACME renewal information (ARI) of the server "+
+		"asks for the certificate to be renewed immediately. Setting this flag enables the "+revocationcheck.ControllerName+" controller.")
+	fs.DurationVar(&s.CertificateRequestPendingTimeout, "certificate-request-pending-timeout", 0, "If set, approved CertificateRequests which "+
+		"have been pending for longer than this duration, e.g. because their issuer crashed or an external signer is gone, are marked as failed "+
+		"so that the issuance of their Certificate is retried. Setting this flag enables the "+crstalecontroller.ControllerName+" controller.")
+	fs.StringSliceVar(&s.SigningUsageNamespaces, "signing-usage-namespaces", nil, "If set, CertificateRequests with the 'code signing' or "+
+		"'document signing' usages are only approved by the "+crapprovercontroller.ControllerName+" controller in these namespaces, and are denied in all others. "+
+		"If not set, these usages are not restricted.")
+	fs.BoolVar(&s.RestoreMode, "restore-mode", false, "If true, Certificates whose Secrets were restored from a backup without the "+
+		"issuer annotations written by cert-manager adopt the certificate stored in the Secret instead of being re-issued, as long as it is otherwise "+
+		"up to date and its serial number matches the '"+cmapi.SerialNumberAnnotationKey+"' annotation of the Secret and the status of the Certificate. "+
+		"Enable this flag while restoring a cluster from a backup to avoid re-issuing every certificate.")
+	fs.BoolVar(&s.FIPSMode, "fips-mode", false, "If true, private keys are only generated and certificates only signed for the "+
+		"algorithms approved in FIPS 140 mode: RSA keys of at least 2048 bits and ECDSA keys on the NIST P-256, P-384 and P-521 curves. "+
+		"Ed25519 keys are rejected. FIPS mode is always enabled for binaries built with a FIPS 140 validated crypto module which is enabled.")
+	fs.BoolVar(&s.FIPSDisallowP521, "fips-disallow-p521", false, "If true, ECDSA P-521 keys are also rejected in FIPS mode, "+
+		"for deployments whose policy only allows the P-256 and P-384 curves.")
 
 	fs.IntVar(&s.MaxConcurrentChallenges, "max-concurrent-challenges", defaultMaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
 	fs.DurationVar(&s.DNS01CheckRetryPeriod, "dns01-check-retry-period", defaultDNS01CheckRetryPeriod, ""+
-		"The duration the controller should wait between a propagation check. Despite the name, this flag is used to configure the wait period for both DNS01 and HTTP01 challenge propagation checks. For DNS01 challenges the propagation check verifies that a TXT record with the challenge token has been created. For HTTP01 challenges the propagation check verifies that the challenge token is served at the challenge URL."+
+		"The duration the controller should wait between a propagation check. Despite the name, this flag is used to configure the wait period for both DNS01 and HTTP01 challenge propagation checks. For DNS01 challenges the propagation check verifies that a TXT record with the challenge token has been created. For HTTP01 challenges the propagation check verifies that the challenge token is served at the challenge URL. "+
+		"Solvers can override this interval and back off exponentially with their selfCheck configuration. "+
 		"This should be a valid duration string, for example 180s or 1h")
 
 	fs.StringVar(&s.MetricsListenAddress, "metrics-listen-address", defaultPrometheusMetricsServerAddress, ""+
@@ -389,7 +629,98 @@ func (o *ControllerOptions) Validate() error {
 		return fmt.Errorf("invalid value for kube-api-burst: %v must be higher or equal to kube-api-qps: %v", o.KubernetesAPIQPS, o.KubernetesAPIQPS)
 	}
 
-	for _, server := range append(o.DNS01RecursiveNameservers, o.ACMEHTTP01SolverNameservers...) {
+	if len(o.Namespace) > 0 && len(o.Namespaces) > 0 {
+		return errors.New("the --namespace and --namespaces flags cannot be used together")
+	}
+
+	seenNamespaces := sets.NewString()
+	for _, namespace := range o.Namespaces {
+		if len(namespace) == 0 {
+			return errors.New("invalid value for namespaces: namespaces must not be empty")
+		}
+		if seenNamespaces.Has(namespace) {
+			return fmt.Errorf("invalid value for namespaces: namespace %q is listed more than once", namespace)
+		}
+		seenNamespaces.Insert(namespace)
+	}
+
+	// The controller class is part of the name of the leader election lease.
+	if len(o.ControllerClass) > 0 {
+		if errs := validation.IsDNS1123Label(o.ControllerClass); len(errs) > 0 {
+			return fmt.Errorf("invalid value for controller-class: %s", strings.Join(errs, ", "))
+		}
+	}
+
+	if _, err := o.ControllerRateLimits(); err != nil {
+		return err
+	}
+
+	if _, err := o.IssuerZoneRoutes(); err != nil {
+		return err
+	}
+
+	if o.ACMEHTTPQPS < 0 {
+		return fmt.Errorf("invalid value for acme-http-qps: %v must not be negative", o.ACMEHTTPQPS)
+	}
+
+	if o.ACMEHTTPQPS > 0 && float32(o.ACMEHTTPBurst) < o.ACMEHTTPQPS {
+		return fmt.Errorf("invalid value for acme-http-burst: %v must be higher or equal to acme-http-qps: %v", o.ACMEHTTPBurst, o.ACMEHTTPQPS)
+	}
+
+	if o.StatusUpdateQPS < 0 {
+		return fmt.Errorf("invalid value for status-update-qps: %v must not be negative", o.StatusUpdateQPS)
+	}
+
+	if o.StatusUpdateQPS > 0 && float32(o.StatusUpdateBurst) < o.StatusUpdateQPS {
+		return fmt.Errorf("invalid value for status-update-burst: %v must be higher or equal to status-update-qps: %v", o.StatusUpdateBurst, o.StatusUpdateQPS)
+	}
+
+	if len(o.AuditWebhookURL) > 0 {
+		u, err := url.Parse(o.AuditWebhookURL)
+		if err != nil {
+			return fmt.Errorf("invalid value for audit-webhook-url: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid value for audit-webhook-url: %q must be an http or https URL", o.AuditWebhookURL)
+		}
+	}
+
+	if len(o.TransparencyLogURL) > 0 {
+		u, err := url.Parse(o.TransparencyLogURL)
+		if err != nil {
+			return fmt.Errorf("invalid value for transparency-log-url: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid value for transparency-log-url: %q must be an http or https URL", o.TransparencyLogURL)
+		}
+	}
+
+	if len(o.KubeletServingIssuer) > 0 {
+		if _, _, err := csrkubeletservingcontroller.IssuerRefFromSignerName(o.KubeletServingIssuer); err != nil {
+			return fmt.Errorf("invalid value for kubelet-serving-issuer: %v", err)
+		}
+	}
+
+	if o.DriftCheckInterval < 0 {
+		return fmt.Errorf("invalid value for drift-check-interval: %s must not be negative", o.DriftCheckInterval)
+	}
+
+	if o.RevocationCheckInterval < 0 {
+		return fmt.Errorf("invalid value for revocation-check-interval: %s must not be negative", o.RevocationCheckInterval)
+	}
+
+	if o.CertificateRequestPendingTimeout < 0 {
+		return fmt.Errorf("invalid value for certificate-request-pending-timeout: %s must not be negative", o.CertificateRequestPendingTimeout)
+	}
+
+	for _, server := range o.DNS01RecursiveNameservers {
+		// ensure all servers have a port number, or are a DoH or DoT endpoint
+		if err := dnsutil.ValidateNameserver(server); err != nil {
+			return fmt.Errorf("invalid DNS server (%v): %v", err, server)
+		}
+	}
+
+	for _, server := range o.ACMEHTTP01SolverNameservers {
 		// ensure all servers have a port number
 		_, _, err := net.SplitHostPort(server)
 		if err != nil {
@@ -397,6 +728,10 @@ func (o *ControllerOptions) Validate() error {
 		}
 	}
 
+	if err := util.ValidateIPFamily(corev1.IPFamily(o.ACMESelfCheckPreferredIPFamily)); err != nil {
+		return fmt.Errorf("invalid value for acme-self-check-preferred-ip-family: %v", err)
+	}
+
 	errs := []error{}
 	allControllersSet := sets.NewString(allControllers...)
 	for _, controller := range o.controllers {
@@ -417,6 +752,66 @@ func (o *ControllerOptions) Validate() error {
 	return nil
 }
 
+// ScopedNamespaces returns the namespaces cert-manager has been scoped to with
+// either --namespace or --namespaces. If empty, all namespaces are watched.
+func (o *ControllerOptions) ScopedNamespaces() []string {
+	if len(o.Namespace) > 0 {
+		return []string{o.Namespace}
+	}
+	return o.Namespaces
+}
+
+// IsClusterScopedController returns true if the named controller reconciles
+// cluster scoped resources, and so can't run when cert-manager has been scoped
+// to namespaces.
+func IsClusterScopedController(name string) bool {
+	return clusterScopedControllers.Has(name)
+}
+
+// ControllerRateLimits parses the rate limits passed with
+// --controller-kube-api-rate-limits.
+func (o *ControllerOptions) ControllerRateLimits() (map[string]controller.RateLimit, error) {
+	limits := make(map[string]controller.RateLimit, len(o.ControllerKubernetesAPIRateLimits))
+	allControllersSet := sets.NewString(allControllers...)
+	for name, value := range o.ControllerKubernetesAPIRateLimits {
+		if !allControllersSet.Has(name) {
+			return nil, fmt.Errorf("invalid value for controller-kube-api-rate-limits: %q is not a known controller", name)
+		}
+		qpsStr, burstStr, ok := strings.Cut(value, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid value for controller-kube-api-rate-limits: %q for controller %q must be in the format <qps>:<burst>", value, name)
+		}
+		qps, err := strconv.ParseFloat(qpsStr, 32)
+		if err != nil || qps <= 0 {
+			return nil, fmt.Errorf("invalid value for controller-kube-api-rate-limits: QPS %q for controller %q must be a number higher than 0", qpsStr, name)
+		}
+		burst, err := strconv.Atoi(burstStr)
+		if err != nil || float64(burst) < qps {
+			return nil, fmt.Errorf("invalid value for controller-kube-api-rate-limits: burst %q for controller %q must be a whole number higher or equal to the QPS", burstStr, name)
+		}
+		limits[name] = controller.RateLimit{QPS: float32(qps), Burst: burst}
+	}
+	return limits, nil
+}
+
+// IssuerZoneRoutes parses the DNS zone routes passed with
+// --default-issuer-zone-routes into issuer references.
+func (o *ControllerOptions) IssuerZoneRoutes() (map[string]cmmeta.ObjectReference, error) {
+	routes := make(map[string]cmmeta.ObjectReference, len(o.DefaultIssuerZoneRoutes))
+	for zone, value := range o.DefaultIssuerZoneRoutes {
+		if len(zone) == 0 {
+			return nil, fmt.Errorf("invalid value for default-issuer-zone-routes: the zone of issuer %q must not be empty", value)
+		}
+		kindGroup, name, ok := strings.Cut(value, "/")
+		if !ok || len(kindGroup) == 0 || len(name) == 0 {
+			return nil, fmt.Errorf("invalid value for default-issuer-zone-routes: %q for zone %q must be in the format <kind>[.<group>]/<name>", value, zone)
+		}
+		kind, group, _ := strings.Cut(kindGroup, ".")
+		routes[zone] = cmmeta.ObjectReference{Name: name, Kind: kind, Group: group}
+	}
+	return routes, nil
+}
+
 func (o *ControllerOptions) EnabledControllers() sets.String {
 	var disabled []string
 	enabled := sets.NewString()
@@ -444,5 +839,49 @@ func (o *ControllerOptions) EnabledControllers() sets.String {
 		enabled = enabled.Insert(shimgatewaycontroller.ControllerName)
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(feature.FakeIssuer) {
+		logf.Log.Info("enabling the fake issuer")
+		enabled = enabled.Insert(crfakecontroller.CRControllerName)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.DelegateIssuer) {
+		logf.Log.Info("enabling the delegate issuer")
+		enabled = enabled.Insert(crdelegatecontroller.CRControllerName)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.OfflineIssuer) {
+		logf.Log.Info("enabling the offline issuer")
+		enabled = enabled.Insert(crofflinecontroller.CRControllerName)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.SplitIssuance) {
+		logf.Log.Info("enabling split issuance of Certificates")
+		enabled = enabled.Insert(splitissuance.ControllerName)
+	}
+
+	if len(o.TransparencyLogURL) > 0 {
+		enabled = enabled.Insert(transparencylog.ControllerName)
+	}
+
+	if len(o.SecretWatchdogNamespaces) > 0 {
+		enabled = enabled.Insert(secretwatchdog.ControllerName)
+	}
+
+	if len(o.KubeletServingIssuer) > 0 {
+		enabled = enabled.Insert(csrkubeletservingcontroller.CSRControllerName)
+	}
+
+	if o.DriftCheckInterval > 0 {
+		enabled = enabled.Insert(drift.ControllerName)
+	}
+
+	if o.RevocationCheckInterval > 0 {
+		enabled = enabled.Insert(revocationcheck.ControllerName)
+	}
+
+	if o.CertificateRequestPendingTimeout > 0 {
+		enabled = enabled.Insert(crstalecontroller.ControllerName)
+	}
+
 	return enabled
 }
@@ -17,15 +17,22 @@ limitations under the License.
 package options
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
 )
 
 func TestEnabledControllers(t *testing.T) {
 	tests := map[string]struct {
-		controllers []string
-		expEnabled  sets.String
+		controllers        []string
+		transparencyLogURL string
+		driftCheckInterval time.Duration
+		expEnabled         sets.String
 	}{
 		"if no controllers enabled, return empty": {
 			controllers: []string{},
@@ -47,12 +54,24 @@ func TestEnabledControllers(t *testing.T) {
 			controllers: []string{"*", "-clusterissuers", "-issuers"},
 			expEnabled:  sets.NewString(defaultEnabledControllers...).Delete("clusterissuers", "issuers"),
 		},
+		"if a transparency log URL is set, enable the transparency log controller": {
+			controllers:        []string{"*"},
+			transparencyLogURL: "https://rekor.example.com",
+			expEnabled:         sets.NewString(defaultEnabledControllers...).Insert("certificates-transparency-log"),
+		},
+		"if a drift check interval is set, enable the drift controller": {
+			controllers:        []string{"*"},
+			driftCheckInterval: time.Hour,
+			expEnabled:         sets.NewString(defaultEnabledControllers...).Insert("certificates-drift"),
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := ControllerOptions{
-				controllers: test.controllers,
+				controllers:        test.controllers,
+				TransparencyLogURL: test.transparencyLogURL,
+				DriftCheckInterval: test.driftCheckInterval,
 			}
 
 			got := o.EnabledControllers()
@@ -63,3 +82,157 @@ func TestEnabledControllers(t *testing.T) {
 		})
 	}
 }
+
+func TestControllerRateLimits(t *testing.T) {
+	tests := map[string]struct {
+		limits    map[string]string
+		expLimits map[string]controller.RateLimit
+		expErr    bool
+	}{
+		"if no rate limits set, return empty": {
+			expLimits: map[string]controller.RateLimit{},
+		},
+		"if rate limits set for known controllers, return them": {
+			limits: map[string]string{"challenges": "5:10", "orders": "0.5:1"},
+			expLimits: map[string]controller.RateLimit{
+				"challenges": {QPS: 5, Burst: 10},
+				"orders":     {QPS: 0.5, Burst: 1},
+			},
+		},
+		"if rate limit set for unknown controller, error": {
+			limits: map[string]string{"foo": "5:10"},
+			expErr: true,
+		},
+		"if rate limit has no burst, error": {
+			limits: map[string]string{"challenges": "5"},
+			expErr: true,
+		},
+		"if rate limit has zero QPS, error": {
+			limits: map[string]string{"challenges": "0:10"},
+			expErr: true,
+		},
+		"if rate limit has burst lower than QPS, error": {
+			limits: map[string]string{"challenges": "10:5"},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := ControllerOptions{
+				ControllerKubernetesAPIRateLimits: test.limits,
+			}
+
+			got, err := o.ControllerRateLimits()
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if !reflect.DeepEqual(got, test.expLimits) && !test.expErr {
+				t.Errorf("got unexpected rate limits, exp=%v got=%v",
+					test.expLimits, got)
+			}
+		})
+	}
+}
+
+func TestIssuerZoneRoutes(t *testing.T) {
+	tests := map[string]struct {
+		routes    map[string]string
+		expRoutes map[string]cmmeta.ObjectReference
+		expErr    bool
+	}{
+		"if no routes set, return empty": {
+			expRoutes: map[string]cmmeta.ObjectReference{},
+		},
+		"if routes set, return the issuer references": {
+			routes: map[string]string{
+				"example.com":   "ClusterIssuer/letsencrypt",
+				"corp.internal": "VaultIssuer.example.io/internal-ca",
+			},
+			expRoutes: map[string]cmmeta.ObjectReference{
+				"example.com":   {Name: "letsencrypt", Kind: "ClusterIssuer"},
+				"corp.internal": {Name: "internal-ca", Kind: "VaultIssuer", Group: "example.io"},
+			},
+		},
+		"if route has no kind, error": {
+			routes: map[string]string{"example.com": "letsencrypt"},
+			expErr: true,
+		},
+		"if route has no name, error": {
+			routes: map[string]string{"example.com": "ClusterIssuer/"},
+			expErr: true,
+		},
+		"if route has no zone, error": {
+			routes: map[string]string{"": "ClusterIssuer/letsencrypt"},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := ControllerOptions{
+				DefaultIssuerZoneRoutes: test.routes,
+			}
+
+			got, err := o.IssuerZoneRoutes()
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if !reflect.DeepEqual(got, test.expRoutes) && !test.expErr {
+				t.Errorf("got unexpected routes, exp=%v got=%v",
+					test.expRoutes, got)
+			}
+		})
+	}
+}
+
+func TestScopedNamespaces(t *testing.T) {
+	tests := map[string]struct {
+		namespace     string
+		namespaces    []string
+		expNamespaces []string
+		expErr        bool
+	}{
+		"if no namespace is set, watch all namespaces": {},
+		"if a single namespace is set, scope to that namespace": {
+			namespace:     "foo",
+			expNamespaces: []string{"foo"},
+		},
+		"if namespaces are set, scope to those namespaces": {
+			namespaces:    []string{"foo", "bar"},
+			expNamespaces: []string{"foo", "bar"},
+		},
+		"if both a namespace and namespaces are set, error": {
+			namespace:  "foo",
+			namespaces: []string{"bar"},
+			expErr:     true,
+		},
+		"if a namespace is listed twice, error": {
+			namespaces: []string{"foo", "foo"},
+			expErr:     true,
+		},
+		"if an empty namespace is listed, error": {
+			namespaces: []string{"foo", ""},
+			expErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewControllerOptions()
+			o.Namespace = test.namespace
+			o.Namespaces = test.namespaces
+
+			err := o.Validate()
+			if test.expErr != (err != nil) {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if test.expErr {
+				return
+			}
+			if got := o.ScopedNamespaces(); !reflect.DeepEqual(got, test.expNamespaces) {
+				t.Errorf("got unexpected namespaces, exp=%v got=%v", test.expNamespaces, got)
+			}
+		})
+	}
+}
@@ -30,11 +30,15 @@ import (
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/deny"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/experimental"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/generate"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/lint"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/renew"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/rollback"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/upgrade"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/version"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/why"
 )
 
 // registerCompletion gates whether the completion command is registered.
@@ -52,12 +56,16 @@ func Commands() []RegisterCommandFunc {
 		convert.NewCmdConvert,
 		create.NewCmdCreate,
 		renew.NewCmdRenew,
+		rollback.NewCmdRollback,
 		status.NewCmdStatus,
+		why.NewCmdWhy,
 		inspect.NewCmdInspect,
 		approve.NewCmdApprove,
 		deny.NewCmdDeny,
 		check.NewCmdCheck,
 		upgrade.NewCmdUpgrade,
+		generate.NewCmdGenerate,
+		lint.NewCmdLint,
 
 		// Experimental features
 		experimental.NewCmdExperimental,
@@ -25,6 +25,8 @@ import (
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create/certificatesigningrequest"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/install"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/issuerstate"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/offline"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/uninstall"
 )
 
@@ -41,6 +43,8 @@ func NewCmdExperimental(ctx context.Context, ioStreams genericclioptions.IOStrea
 	cmds.AddCommand(create)
 	cmds.AddCommand(install.NewCmdInstall(ctx, ioStreams))
 	cmds.AddCommand(uninstall.NewCmd(ctx, ioStreams))
+	cmds.AddCommand(issuerstate.NewCmdIssuerState(ctx, ioStreams))
+	cmds.AddCommand(offline.NewCmdOffline(ctx, ioStreams))
 
 	return cmds
 }
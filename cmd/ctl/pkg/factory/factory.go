@@ -18,6 +18,7 @@ package factory
 
 import (
 	"context"
+	"errors"
 
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -104,7 +105,17 @@ func (f *Factory) complete() error {
 		return err
 	}
 
-	f.CMClient, err = cmclient.NewForConfig(f.RESTConfig)
+	// Older clusters may not serve the v1 cert-manager APIs that the
+	// clientset is built against. Discovery errors are deliberately ignored
+	// here since commands such as `check api` expect to be able to run
+	// before the API server is reachable, and will surface their own errors.
+	versions, err := servedVersions(f.KubeClient.Discovery())
+	var unsupported *unsupportedVersionsError
+	if errors.As(err, &unsupported) {
+		return err
+	}
+
+	f.CMClient, err = cmclient.NewForConfig(wrapVersionSkew(f.RESTConfig, versions))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	"github.com/cert-manager/cert-manager/pkg/ctl"
+)
+
+// preferredVersion is the API version that the cmctl clientsets are built
+// against for every cert-manager API group.
+const preferredVersion = "v1"
+
+var (
+	// skewedGroups are the API groups that cmctl is able to adapt its requests
+	// for when the target cluster does not serve the preferred version.
+	skewedGroups = []string{"cert-manager.io", "acme.cert-manager.io"}
+
+	codecs         = serializer.NewCodecFactory(ctl.Scheme)
+	jsonSerializer = json.NewSerializerWithOptions(json.DefaultMetaFactory, ctl.Scheme, ctl.Scheme, json.SerializerOptions{})
+)
+
+// servedVersions uses the discovery API to find the version of each
+// cert-manager API group that requests should be sent to. Groups which serve
+// the preferred version, or which are not installed at all, are omitted from
+// the returned map. An error is returned if a group is installed but none of
+// its served versions are known to this build of cmctl.
+func servedVersions(client discovery.DiscoveryInterface) (map[string]string, error) {
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string)
+	for _, group := range groups.Groups {
+		if !isSkewedGroup(group.Name) {
+			continue
+		}
+
+		var served []string
+		for _, v := range group.Versions {
+			served = append(served, v.Version)
+		}
+		if containsVersion(served, preferredVersion) {
+			continue
+		}
+
+		// Prefer the version the API server prefers, then fall back to the
+		// first served version that cmctl knows how to convert to.
+		candidates := append([]string{group.PreferredVersion.Version}, served...)
+		var chosen string
+		for _, v := range candidates {
+			if ctl.Scheme.IsVersionRegistered(schema.GroupVersion{Group: group.Name, Version: v}) {
+				chosen = v
+				break
+			}
+		}
+		if chosen == "" {
+			return nil, &unsupportedVersionsError{group: group.Name, served: served}
+		}
+
+		versions[group.Name] = chosen
+	}
+
+	return versions, nil
+}
+
+// unsupportedVersionsError is returned when a cert-manager API group is
+// installed in the cluster, but cmctl doesn't know any of its served versions.
+type unsupportedVersionsError struct {
+	group  string
+	served []string
+}
+
+func (e *unsupportedVersionsError) Error() string {
+	return fmt.Sprintf("the cluster serves %s versions %v, none of which are supported by this version of cmctl", e.group, e.served)
+}
+
+// wrapVersionSkew returns a copy of the given REST config whose transport
+// rewrites requests for the preferred version of each group in versions to
+// the version the cluster serves, converting request and response bodies
+// along the way. The config is returned unchanged if there is nothing to
+// adapt.
+func wrapVersionSkew(config *rest.Config, versions map[string]string) *rest.Config {
+	if len(versions) == 0 {
+		return config
+	}
+
+	config = rest.CopyConfig(config)
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &versionSkewRoundTripper{next: rt, versions: versions}
+	})
+
+	return config
+}
+
+// versionSkewRoundTripper translates requests made by the v1 clientsets to an
+// older API version served by the cluster.
+type versionSkewRoundTripper struct {
+	next http.RoundTripper
+
+	// versions maps an API group to the version that should be requested
+	// instead of the preferred version.
+	versions map[string]string
+}
+
+func (v *versionSkewRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	group, ok := v.groupForPath(req.URL.Path)
+	if !ok {
+		return v.next.RoundTrip(req)
+	}
+
+	// Watch events are streamed and cannot be converted as a single body.
+	// Leave these requests untouched so that they fail loudly rather than
+	// decoding objects of the wrong version.
+	if req.URL.Query().Get("watch") == "true" {
+		return v.next.RoundTrip(req)
+	}
+
+	served := schema.GroupVersion{Group: group, Version: v.versions[group]}
+	preferred := schema.GroupVersion{Group: group, Version: preferredVersion}
+
+	req = req.Clone(req.Context())
+	req.URL.Path = strings.Replace(req.URL.Path, apiPathPrefix(preferred), apiPathPrefix(served), 1)
+
+	// Patches only contain part of an object, so can't be decoded and
+	// converted. They are sent as-is to the served version.
+	if req.Body != nil && req.Method != http.MethodPatch {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body, err = convertBody(body, served)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert request to %s: %w", served, err)
+		}
+		setBody(req, body)
+	}
+
+	resp, err := v.next.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body, err = convertBody(body, preferred)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert response from %s: %w", served, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return resp, nil
+}
+
+// groupForPath returns the API group of a request path for the preferred
+// version of a group that needs to be adapted.
+func (v *versionSkewRoundTripper) groupForPath(path string) (string, bool) {
+	for group := range v.versions {
+		if strings.HasPrefix(path, apiPathPrefix(schema.GroupVersion{Group: group, Version: preferredVersion})) {
+			return group, true
+		}
+	}
+	return "", false
+}
+
+// convertBody converts a JSON encoded cert-manager object to the given
+// version. Bodies that are not cert-manager objects of the target group, such
+// as metav1.Status or DeleteOptions, are returned unchanged.
+func convertBody(body []byte, gv schema.GroupVersion) ([]byte, error) {
+	if len(body) == 0 {
+		return body, nil
+	}
+
+	obj, gvk, err := codecs.UniversalDecoder().Decode(body, nil, nil)
+	if err != nil || gvk.Group != gv.Group {
+		return body, nil
+	}
+
+	return runtime.Encode(codecs.EncoderForVersion(jsonSerializer, gv), obj)
+}
+
+func setBody(req *http.Request, body []byte) {
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+}
+
+func apiPathPrefix(gv schema.GroupVersion) string {
+	return "/apis/" + gv.Group + "/" + gv.Version + "/"
+}
+
+func isSkewedGroup(group string) bool {
+	for _, g := range skewedGroups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+func containsVersion(versions []string, version string) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
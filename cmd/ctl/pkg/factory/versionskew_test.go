@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+)
+
+// fakeAPIServer returns a server which serves the cert-manager.io group at
+// the given versions. Requests for a Certificate are answered with a
+// v1alpha2 object, and created Certificates are echoed back.
+func fakeAPIServer(t *testing.T, versions ...string) (*httptest.Server, *[]string) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api":
+			json.NewEncoder(w).Encode(metav1.APIVersions{})
+		case "/apis":
+			group := metav1.APIGroup{Name: "cert-manager.io"}
+			for _, v := range versions {
+				group.Versions = append(group.Versions, metav1.GroupVersionForDiscovery{GroupVersion: "cert-manager.io/" + v, Version: v})
+			}
+			group.PreferredVersion = group.Versions[0]
+			json.NewEncoder(w).Encode(metav1.APIGroupList{Groups: []metav1.APIGroup{group}})
+		case "/apis/cert-manager.io/v1alpha2/namespaces/default/certificates/test":
+			io.WriteString(w, `{"apiVersion":"cert-manager.io/v1alpha2","kind":"Certificate","metadata":{"name":"test","namespace":"default"},"spec":{"secretName":"test","keyAlgorithm":"ecdsa","keySize":384,"issuerRef":{"name":"ca"}}}`)
+		case "/apis/cert-manager.io/v1alpha2/namespaces/default/certificates":
+			body, _ := io.ReadAll(r.Body)
+			var obj map[string]interface{}
+			if err := json.Unmarshal(body, &obj); err != nil || obj["apiVersion"] != "cert-manager.io/v1alpha2" {
+				t.Errorf("expected v1alpha2 request body, got %s", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &paths
+}
+
+func TestServedVersions(t *testing.T) {
+	tests := map[string]struct {
+		versions []string
+		exp      map[string]string
+		expErr   bool
+	}{
+		"if v1 is served, nothing should be adapted": {
+			versions: []string{"v1", "v1alpha2"},
+			exp:      map[string]string{},
+		},
+		"if only v1alpha2 is served, it should be used": {
+			versions: []string{"v1alpha2"},
+			exp:      map[string]string{"cert-manager.io": "v1alpha2"},
+		},
+		"if the preferred version is unknown, fall back to a known version": {
+			versions: []string{"v1alpha1", "v1beta1"},
+			exp:      map[string]string{"cert-manager.io": "v1beta1"},
+		},
+		"if no served version is known, error": {
+			versions: []string{"v1alpha1"},
+			expErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv, _ := fakeAPIServer(t, test.versions...)
+			client := discovery.NewDiscoveryClientForConfigOrDie(&rest.Config{Host: srv.URL})
+
+			versions, err := servedVersions(client)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if test.expErr {
+				return
+			}
+			if len(versions) != len(test.exp) {
+				t.Fatalf("unexpected versions, exp=%v got=%v", test.exp, versions)
+			}
+			for group, v := range test.exp {
+				if versions[group] != v {
+					t.Errorf("unexpected versions, exp=%v got=%v", test.exp, versions)
+				}
+			}
+		})
+	}
+}
+
+func TestVersionSkewRoundTripper(t *testing.T) {
+	srv, paths := fakeAPIServer(t, "v1alpha2")
+	config := wrapVersionSkew(&rest.Config{Host: srv.URL}, map[string]string{"cert-manager.io": "v1alpha2"})
+	client := cmclient.NewForConfigOrDie(config)
+	ctx := context.Background()
+
+	crt, err := client.CertmanagerV1().Certificates("default").Get(ctx, "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error getting certificate: %s", err)
+	}
+	if crt.Spec.PrivateKey == nil || crt.Spec.PrivateKey.Algorithm != cmapi.ECDSAKeyAlgorithm || crt.Spec.PrivateKey.Size != 384 {
+		t.Errorf("expected v1alpha2 key fields to be converted to spec.privateKey, got %#v", crt.Spec.PrivateKey)
+	}
+
+	created, err := client.CertmanagerV1().Certificates("default").Create(ctx, crt, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error creating certificate: %s", err)
+	}
+	if created.Spec.PrivateKey == nil || created.Spec.PrivateKey.Algorithm != cmapi.ECDSAKeyAlgorithm {
+		t.Errorf("expected created certificate to round trip, got %#v", created.Spec.PrivateKey)
+	}
+
+	_, err = client.CertmanagerV1().Certificates("default").Get(ctx, "missing", metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	exp := []string{
+		"GET /apis/cert-manager.io/v1alpha2/namespaces/default/certificates/test",
+		"POST /apis/cert-manager.io/v1alpha2/namespaces/default/certificates",
+		"GET /apis/cert-manager.io/v1alpha2/namespaces/default/certificates/missing",
+	}
+	if len(*paths) != len(exp) {
+		t.Fatalf("unexpected requests, exp=%v got=%v", exp, *paths)
+	}
+	for i := range exp {
+		if (*paths)[i] != exp[i] {
+			t.Errorf("unexpected request %d, exp=%q got=%q", i, exp[i], (*paths)[i])
+		}
+	}
+}
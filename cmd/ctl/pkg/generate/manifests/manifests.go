@@ -0,0 +1,219 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+)
+
+const (
+	releaseName      = "cert-manager"
+	defaultNamespace = "cert-manager"
+
+	namespaceManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: %s
+`
+)
+
+var (
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+		# Generate the manifests of the latest cert-manager release.
+		{{.BuildName}} generate manifests > cert-manager.yaml
+
+		# Generate the manifests of a highly available installation of cert-manager v1.10.0.
+		{{.BuildName}} generate manifests --version v1.10.0 --profile ha
+
+		# Generate the manifests without the CRDs, overriding a value of the chart.
+		{{.BuildName}} generate manifests --crds=false --set featureGates=ExperimentalGatewayAPISupport=true`)))
+
+	longDesc = templates.LongDesc(i18n.T(`
+Generate the static manifests which install cert-manager, including the CRDs,
+RBAC, Deployments and webhook configurations, without using Helm to install
+them. The manifests are rendered from the cert-manager Helm chart published in
+the "https://charts.jetstack.io" repo, and are the same for the same version,
+profile and values, so that they can be committed to a GitOps repository.
+
+The --profile flag selects the values of a kind of installation:
+
+  default    the defaults of the chart
+  ha         several replicas of each component, spread across nodes
+  minimal    a single replica of each component with small resource requests,
+             and without Prometheus metrics scraping
+  openshift  compatible with the restricted SecurityContextConstraints of
+             OpenShift, with leader election in the namespace of cert-manager
+
+Values of the chart can be overridden with the --values and --set flags.`))
+)
+
+// Options is a struct to support generate manifests command
+type Options struct {
+	settings         *cli.EnvSettings
+	chartPathOptions action.ChartPathOptions
+	valueOpts        *values.Options
+
+	Namespace string
+	Profile   string
+	CRDs      bool
+
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		settings:  cli.New(),
+		valueOpts: &values.Options{},
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdManifests returns a cobra command for generating the manifests of
+// cert-manager.
+func NewCmdManifests(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "manifests",
+		Short:                 "Generate the static manifests which install cert-manager",
+		Long:                  longDesc,
+		Example:               example,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	o.chartPathOptions.RepoURL = "https://charts.jetstack.io"
+	cmd.Flags().StringVar(&o.chartPathOptions.Version, "version", "", "specify a version constraint for the chart version to use. This constraint can be a specific tag (e.g. 1.1.1) or it may reference a valid range (e.g. ^2.0.0). If this is not specified, the latest version is used")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", defaultNamespace, "The namespace to install cert-manager into")
+	cmd.Flags().StringVar(&o.Profile, "profile", "default", fmt.Sprintf("The profile of the installation, one of %s", strings.Join(ProfileNames(), ", ")))
+	cmd.Flags().BoolVar(&o.CRDs, "crds", true, "If true, the CRDs of cert-manager are included in the manifests")
+	cmd.Flags().StringSliceVarP(&o.valueOpts.ValueFiles, "values", "f", []string{}, "Specify values in a YAML file or a URL (can specify multiple)")
+	cmd.Flags().StringArrayVar(&o.valueOpts.Values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate() error {
+	if len(o.Namespace) == 0 {
+		return fmt.Errorf("the namespace must not be empty")
+	}
+	if _, ok := Profiles[o.Profile]; !ok {
+		return fmt.Errorf("unknown profile %q, must be one of %s", o.Profile, strings.Join(ProfileNames(), ", "))
+	}
+	return nil
+}
+
+// Run executes generate manifests command
+func (o *Options) Run() error {
+	log.SetFlags(0)         // Disable prefixing logs with timestamps.
+	log.SetOutput(o.ErrOut) // Log everything to stderr so the manifests do not get corrupted.
+
+	cp, err := o.chartPathOptions.LocateChart(releaseName, o.settings)
+	if err != nil {
+		return err
+	}
+	ch, err := loader.Load(cp)
+	if err != nil {
+		return err
+	}
+
+	values, err := NewValues(o.Profile, o.Namespace, o.CRDs)
+	if err != nil {
+		return err
+	}
+	overrides, err := o.valueOpts.MergeValues(getter.All(o.settings))
+	if err != nil {
+		return err
+	}
+
+	manifests, err := Render(ch, o.Namespace, values, overrides)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(o.Out, manifests)
+	return err
+}
+
+// Render renders the manifests which install the given cert-manager chart
+// into the given namespace, with the given values and overrides of those
+// values. The manifests start with the Namespace of cert-manager.
+func Render(ch *chart.Chart, namespace string, values *Values, overrides map[string]interface{}) (string, error) {
+	chartValues, err := values.AsMap(ch.Values)
+	if err != nil {
+		return "", err
+	}
+	chartValues = mergeMaps(chartValues, overrides)
+
+	client := action.NewInstall(&action.Configuration{})
+	client.ReleaseName = releaseName
+	client.Namespace = namespace
+	client.DryRun = true
+	// Render the templates without connecting to a cluster, so that the
+	// manifests only depend on the chart and values.
+	client.ClientOnly = true
+	client.DisableHooks = true
+	rel, err := client.Run(ch, chartValues)
+	if err != nil {
+		return "", err
+	}
+
+	// Helm starts the manifest with a document separator.
+	return fmt.Sprintf(namespaceManifest, namespace) + strings.TrimSpace(rel.Manifest) + "\n", nil
+}
+
+// mergeMaps merges b into a, with the values of b taking precedence.
+func mergeMaps(a, b map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(a))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		if v, ok := v.(map[string]interface{}); ok {
+			if bv, ok := out[k]; ok {
+				if bv, ok := bv.(map[string]interface{}); ok {
+					out[k] = mergeMaps(bv, v)
+					continue
+				}
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
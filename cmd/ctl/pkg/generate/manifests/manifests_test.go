@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// testChart is a cert-manager chart reduced to the values set by the
+// profiles.
+var testChart = &chart.Chart{
+	Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "cert-manager", Version: "v1.0.0"},
+	Values: map[string]interface{}{
+		"global":       map[string]interface{}{"leaderElection": map[string]interface{}{"namespace": "kube-system"}},
+		"installCRDs":  false,
+		"replicaCount": 1,
+		"securityContext": map[string]interface{}{
+			"runAsNonRoot":   true,
+			"seccompProfile": map[string]interface{}{"type": "RuntimeDefault"},
+		},
+		"prometheus": map[string]interface{}{"enabled": true},
+	},
+	Templates: []*chart.File{
+		{
+			Name: "templates/crds.yaml",
+			Data: []byte(`{{- if .Values.installCRDs }}
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: certificates.cert-manager.io
+{{- end }}
+`),
+		},
+		{
+			Name: "templates/deployment.yaml",
+			Data: []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+  namespace: {{ .Release.Namespace }}
+  annotations:
+    creator: {{ .Values.creator }}
+    prometheus: "{{ .Values.prometheus.enabled }}"
+spec:
+  replicas: {{ .Values.replicaCount }}
+  template:
+    spec:
+      containers:
+      - name: cert-manager-controller
+        args:
+        - --leader-election-namespace={{ .Values.global.leaderElection.namespace }}
+      securityContext:
+        {{- toYaml .Values.securityContext | nindent 8 }}
+`),
+		},
+	},
+}
+
+func TestRender(t *testing.T) {
+	tests := map[string]struct {
+		profile     string
+		installCRDs bool
+		overrides   map[string]interface{}
+		exp         string
+	}{
+		"default profile with CRDs": {
+			profile:     "default",
+			installCRDs: true,
+			exp: `apiVersion: v1
+kind: Namespace
+metadata:
+  name: cert-manager
+---
+# Source: cert-manager/templates/crds.yaml
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: certificates.cert-manager.io
+---
+# Source: cert-manager/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cert-manager
+  namespace: cert-manager
+  annotations:
+    creator: static
+    prometheus: "true"
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: cert-manager-controller
+        args:
+        - --leader-election-namespace=kube-system
+      securityContext:
+        runAsNonRoot: true
+        seccompProfile:
+          type: RuntimeDefault
+`,
+		},
+		"minimal profile with overrides": {
+			profile:   "minimal",
+			overrides: map[string]interface{}{"replicaCount": 3},
+			exp: `apiVersion: v1
+kind: Namespace
+metadata:
+  name: cert-manager
+---
+# Source: cert-manager/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cert-manager
+  namespace: cert-manager
+  annotations:
+    creator: static
+    prometheus: "false"
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: cert-manager-controller
+        args:
+        - --leader-election-namespace=kube-system
+      securityContext:
+        runAsNonRoot: true
+        seccompProfile:
+          type: RuntimeDefault
+`,
+		},
+		"openshift profile": {
+			profile: "openshift",
+			exp: `apiVersion: v1
+kind: Namespace
+metadata:
+  name: cert-manager
+---
+# Source: cert-manager/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cert-manager
+  namespace: cert-manager
+  annotations:
+    creator: static
+    prometheus: "true"
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: cert-manager-controller
+        args:
+        - --leader-election-namespace=cert-manager
+      securityContext:
+        runAsNonRoot: true
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			values, err := NewValues(test.profile, "cert-manager", test.installCRDs)
+			require.NoError(t, err)
+
+			manifests, err := Render(testChart, "cert-manager", values, test.overrides)
+			require.NoError(t, err)
+			assert.Equal(t, test.exp, manifests)
+
+			// The manifests must be the same every time they are rendered.
+			again, err := Render(testChart, "cert-manager", values, test.overrides)
+			require.NoError(t, err)
+			assert.Equal(t, manifests, again)
+		})
+	}
+}
+
+func TestNewValues(t *testing.T) {
+	values, err := NewValues("ha", "cert-manager", true)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), *values.ReplicaCount)
+	assert.Equal(t, int32(3), *values.Webhook.ReplicaCount)
+	assert.Equal(t, int32(2), *values.CAInjector.ReplicaCount)
+	assert.Equal(t, "webhook", values.Webhook.TopologySpreadConstraints[0].LabelSelector.MatchLabels["app.kubernetes.io/component"])
+
+	_, err = NewValues("unknown", "cert-manager", true)
+	assert.EqualError(t, err, `unknown profile "unknown", must be one of default, ha, minimal, openshift`)
+}
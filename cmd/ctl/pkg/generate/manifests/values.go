@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifests
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// Values are the values of the cert-manager Helm chart which the manifests
+// are rendered with. Fields which are not set keep the default of the chart.
+type Values struct {
+	Global Global `json:"global"`
+
+	// InstallCRDs renders the CustomResourceDefinitions of cert-manager.
+	InstallCRDs bool `json:"installCRDs"`
+
+	// Creator is set to "static" so that the rendered resources are not
+	// labelled as managed by Helm.
+	Creator string `json:"creator"`
+
+	// Component holds the values of the controller.
+	Component
+
+	Webhook    Component       `json:"webhook"`
+	CAInjector Component       `json:"cainjector"`
+	Prometheus Prometheus      `json:"prometheus"`
+	StartupAPI StartupAPICheck `json:"startupapicheck"`
+}
+
+type Global struct {
+	LeaderElection LeaderElection `json:"leaderElection"`
+}
+
+type LeaderElection struct {
+	// Namespace is the namespace of the leases used for leader election.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Component holds the values of one of the cert-manager Deployments.
+type Component struct {
+	ReplicaCount *int32 `json:"replicaCount,omitempty"`
+
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// SecurityContext replaces the pod security context of the chart,
+	// rather than being merged with it.
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+type Prometheus struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+type StartupAPICheck struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// Profile sets the values of a kind of installation of cert-manager.
+type Profile func(namespace string, values *Values)
+
+// Profiles are the profiles which can be selected with the --profile flag.
+var Profiles = map[string]Profile{
+	"default":   func(string, *Values) {},
+	"ha":        highAvailabilityProfile,
+	"minimal":   minimalProfile,
+	"openshift": openShiftProfile,
+}
+
+// ProfileNames returns the sorted names of the profiles.
+func ProfileNames() []string {
+	var names []string
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewValues returns the values of the manifests of the given profile, which
+// install cert-manager into the given namespace.
+func NewValues(profile, namespace string, installCRDs bool) (*Values, error) {
+	p, ok := Profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q, must be one of %s", profile, strings.Join(ProfileNames(), ", "))
+	}
+
+	values := &Values{
+		InstallCRDs: installCRDs,
+		Creator:     "static",
+		// The startupapicheck Job is a Helm hook, and is not part of the
+		// manifests.
+		StartupAPI: StartupAPICheck{Enabled: pointer.Bool(false)},
+	}
+	p(namespace, values)
+	return values, nil
+}
+
+// highAvailabilityProfile runs several replicas of each component, spread
+// across nodes. Only one replica of the controller and cainjector is active
+// at a time, the others take over if it fails.
+func highAvailabilityProfile(_ string, values *Values) {
+	values.ReplicaCount = pointer.Int32(2)
+	values.TopologySpreadConstraints = spreadAcrossNodes("controller")
+	values.Webhook.ReplicaCount = pointer.Int32(3)
+	values.Webhook.TopologySpreadConstraints = spreadAcrossNodes("webhook")
+	values.CAInjector.ReplicaCount = pointer.Int32(2)
+	values.CAInjector.TopologySpreadConstraints = spreadAcrossNodes("cainjector")
+}
+
+// minimalProfile runs a single replica of each component with small
+// resource requests, and without Prometheus metrics scraping.
+func minimalProfile(_ string, values *Values) {
+	for _, c := range []*Component{&values.Component, &values.Webhook, &values.CAInjector} {
+		c.ReplicaCount = pointer.Int32(1)
+		c.Resources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+		}
+	}
+	values.Prometheus.Enabled = pointer.Bool(false)
+}
+
+// openShiftProfile runs the leader election in the namespace of
+// cert-manager, as the kube-system namespace is reserved for the platform on
+// OpenShift, and removes the seccomp profile from the pods, which the
+// restricted SecurityContextConstraints of OpenShift before 4.11 reject.
+func openShiftProfile(namespace string, values *Values) {
+	values.Global.LeaderElection.Namespace = namespace
+	for _, c := range []*Component{&values.Component, &values.Webhook, &values.CAInjector} {
+		c.SecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: pointer.Bool(true)}
+	}
+}
+
+// spreadAcrossNodes spreads the pods of the given component of cert-manager
+// across nodes, if possible.
+func spreadAcrossNodes(component string) []corev1.TopologySpreadConstraint {
+	return []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelHostname,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app.kubernetes.io/instance":  releaseName,
+				"app.kubernetes.io/component": component,
+			},
+		},
+	}}
+}
+
+// AsMap returns the values as Helm chart values, to be merged with the given
+// default values of the chart.
+func (v *Values) AsMap(defaults map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	// Helm merges maps with the defaults of the chart, so the keys of the
+	// default security contexts which are not set are removed explicitly.
+	replaceDefaults(values, defaults, "securityContext")
+	replaceDefaults(values, defaults, "webhook", "securityContext")
+	replaceDefaults(values, defaults, "cainjector", "securityContext")
+	return values, nil
+}
+
+// replaceDefaults sets the keys of the map at the given path in defaults
+// which are missing from the map at the same path in values to nil, which
+// makes Helm remove them when merging values with the defaults.
+func replaceDefaults(values, defaults map[string]interface{}, path ...string) {
+	for _, key := range path {
+		v, ok := values[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		d, ok := defaults[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		values, defaults = v, d
+	}
+	for key := range defaults {
+		if _, ok := values[key]; !ok {
+			values[key] = nil
+		}
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerstate
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// bundleVersion is the version of the encrypted bundle format written by
+	// export.
+	bundleVersion = 1
+
+	// scrypt parameters used to derive the AES-256 key from the passphrase.
+	scryptN             = 1 << 15
+	scryptR             = 8
+	scryptP             = 1
+	keyLength           = 32
+	saltLength          = 16
+	minPassphraseLength = 12
+)
+
+// Bundle is the state of a set of issuers that is required to rebuild them in
+// another cluster.
+type Bundle struct {
+	Issuers []IssuerState `json:"issuers"`
+}
+
+// IssuerState is the exported state of a single Issuer or ClusterIssuer.
+type IssuerState struct {
+	Kind        string            `json:"kind"`
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Spec        cmapi.IssuerSpec  `json:"spec"`
+
+	// ACMEAccountURI is the URI of the ACME account registered by the
+	// issuer, if any.
+	ACMEAccountURI string `json:"acmeAccountURI,omitempty"`
+
+	// Secrets are the Secrets holding the ACME account private key or CA
+	// keypair of the issuer.
+	Secrets []corev1.Secret `json:"secrets,omitempty"`
+}
+
+// encryptedBundle is the on disk format of a Bundle.
+type encryptedBundle struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// encryptBundle encodes and encrypts the bundle using AES-GCM with a key
+// derived from the passphrase.
+func encryptBundle(bundle *Bundle, passphrase []byte) ([]byte, error) {
+	if len(passphrase) < minPassphraseLength {
+		return nil, fmt.Errorf("the passphrase must be at least %d characters long", minPassphraseLength)
+	}
+
+	plaintext, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&encryptedBundle{
+		Version:    bundleVersion,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+}
+
+// decryptBundle decrypts and decodes a bundle written by encryptBundle.
+func decryptBundle(data, passphrase []byte) (*Bundle, error) {
+	var enc encryptedBundle
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, fmt.Errorf("failed to decode bundle: %w", err)
+	}
+	if enc.Version != bundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d", enc.Version)
+	}
+
+	gcm, err := newGCM(passphrase, enc.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(enc.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("bundle has an invalid nonce")
+	}
+
+	plaintext, err := gcm.Open(nil, enc.Nonce, enc.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt bundle, the passphrase may be incorrect")
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(plaintext, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode bundle contents: %w", err)
+	}
+
+	return &bundle, nil
+}
+
+func newGCM(passphrase, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
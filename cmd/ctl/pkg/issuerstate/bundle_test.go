@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerstate
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestBundleEncryption(t *testing.T) {
+	bundle := &Bundle{Issuers: []IssuerState{{
+		Kind:      cmapi.IssuerKind,
+		Name:      "ca",
+		Namespace: "default",
+		Spec:      cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{CA: &cmapi.CAIssuer{SecretName: "ca-key-pair"}}},
+		Secrets: []corev1.Secret{{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca-key-pair", Namespace: "default"},
+			Data:       map[string][]byte{"tls.key": []byte("key")},
+		}},
+	}}}
+	passphrase := []byte("correct horse battery staple")
+
+	data, err := encryptBundle(bundle, passphrase)
+	if err != nil {
+		t.Fatalf("unexpected error encrypting bundle: %s", err)
+	}
+
+	got, err := decryptBundle(data, passphrase)
+	if err != nil {
+		t.Fatalf("unexpected error decrypting bundle: %s", err)
+	}
+	if !reflect.DeepEqual(got, bundle) {
+		t.Errorf("decrypted bundle does not match, exp=%#v got=%#v", bundle, got)
+	}
+
+	if _, err := decryptBundle(data, []byte("incorrect horse battery staple")); err == nil {
+		t.Error("expected error decrypting bundle with the wrong passphrase")
+	}
+
+	if _, err := encryptBundle(bundle, []byte("short")); err == nil {
+		t.Error("expected error encrypting bundle with a short passphrase")
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var (
+	exportLong = templates.LongDesc(i18n.T(`
+Export the state of Issuers and ClusterIssuers to an encrypted bundle.
+
+The bundle contains the spec of each issuer, along with the Secrets holding
+the private keys of ACME accounts and the keypairs of CA issuers, and the URIs
+of registered ACME accounts. It is encrypted with AES-256-GCM using a key
+derived from the passphrase read from --passphrase-file.
+
+Secrets of ClusterIssuers are read from the cluster resource namespace.`))
+
+	exportExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Export the Issuers in namespace 'my-namespace' and all ClusterIssuers to 'issuers.bundle'
+{{.BuildName}} x issuer-state export --namespace my-namespace --passphrase-file passphrase.txt --output issuers.bundle
+
+# Export the Issuers in all namespaces and all ClusterIssuers
+{{.BuildName}} x issuer-state export --all-namespaces --passphrase-file passphrase.txt --output issuers.bundle
+`)))
+)
+
+// ExportOptions is a struct to support issuer-state export command
+type ExportOptions struct {
+	// OutputFilename is the file the encrypted bundle is written to.
+	OutputFilename string
+	// PassphraseFile is the file containing the passphrase used to encrypt
+	// the bundle.
+	PassphraseFile string
+	// AllNamespaces exports Issuers from all namespaces, rather than only the
+	// current namespace.
+	AllNamespaces bool
+	// ClusterResourceNamespace is the namespace that Secrets referenced by
+	// ClusterIssuers are read from.
+	ClusterResourceNamespace string
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewCmdExport returns a cobra command for issuer-state export
+func NewCmdExport(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &ExportOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export the state of issuers to an encrypted bundle",
+		Long:    exportLong,
+		Example: exportExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.Flags().StringVarP(&o.OutputFilename, "output", "o", o.OutputFilename,
+		"Path to the file that the encrypted bundle is written to")
+	cmd.Flags().StringVar(&o.PassphraseFile, "passphrase-file", o.PassphraseFile,
+		"Path to a file containing the passphrase used to encrypt the bundle")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces,
+		"If set, Issuers are exported from all namespaces")
+	cmd.Flags().StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", "cert-manager",
+		"Namespace that Secrets referenced by ClusterIssuers are stored in")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *ExportOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("export does not accept arguments")
+	}
+	if o.OutputFilename == "" {
+		return errors.New("the path to write the bundle to must be specified with --output")
+	}
+	return nil
+}
+
+// Run executes issuer-state export command
+func (o *ExportOptions) Run(ctx context.Context) error {
+	passphrase, err := readPassphrase(o.PassphraseFile)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := o.exportBundle(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := encryptBundle(bundle, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(o.OutputFilename, data, 0600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	fmt.Fprintf(o.Out, "Exported %d issuers to %s\n", len(bundle.Issuers), o.OutputFilename)
+
+	return nil
+}
+
+func (o *ExportOptions) exportBundle(ctx context.Context) (*Bundle, error) {
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	issuers, err := o.CMClient.CertmanagerV1().Issuers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Issuers: %w", err)
+	}
+	clusterIssuers, err := o.CMClient.CertmanagerV1().ClusterIssuers().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ClusterIssuers: %w", err)
+	}
+
+	bundle := new(Bundle)
+	for i := range issuers.Items {
+		state, err := o.exportIssuer(ctx, cmapi.IssuerKind, &issuers.Items[i], issuers.Items[i].Namespace)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Issuers = append(bundle.Issuers, *state)
+	}
+	for i := range clusterIssuers.Items {
+		state, err := o.exportIssuer(ctx, cmapi.ClusterIssuerKind, &clusterIssuers.Items[i], o.ClusterResourceNamespace)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Issuers = append(bundle.Issuers, *state)
+	}
+
+	return bundle, nil
+}
+
+// exportIssuer returns the state of the issuer, reading the Secrets it
+// references from secretNamespace.
+func (o *ExportOptions) exportIssuer(ctx context.Context, kind string, issuer cmapi.GenericIssuer, secretNamespace string) (*IssuerState, error) {
+	meta := issuer.GetObjectMeta()
+	state := &IssuerState{
+		Kind:        kind,
+		Name:        meta.Name,
+		Namespace:   meta.Namespace,
+		Labels:      meta.Labels,
+		Annotations: exportedAnnotations(meta.Annotations),
+		Spec:        *issuer.GetSpec(),
+	}
+
+	var secretNames []string
+	if acme := issuer.GetSpec().ACME; acme != nil {
+		secretNames = append(secretNames, acme.PrivateKey.Name)
+		if status := issuer.GetStatus().ACME; status != nil {
+			state.ACMEAccountURI = status.URI
+		}
+	}
+	if ca := issuer.GetSpec().CA; ca != nil {
+		secretNames = append(secretNames, ca.SecretName)
+	}
+
+	for _, name := range secretNames {
+		secret, err := o.KubeClient.CoreV1().Secrets(secretNamespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			fmt.Fprintf(o.ErrOut, "Warning: Secret %s/%s referenced by %s %q does not exist and will not be exported\n", secretNamespace, name, kind, meta.Name)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s/%s: %w", secretNamespace, name, err)
+		}
+
+		secret.ObjectMeta = metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			Labels:      secret.Labels,
+			Annotations: exportedAnnotations(secret.Annotations),
+		}
+		state.Secrets = append(state.Secrets, *secret)
+	}
+
+	return state, nil
+}
+
+// exportedAnnotations removes annotations which should not be restored into
+// another cluster.
+func exportedAnnotations(annotations map[string]string) map[string]string {
+	out := make(map[string]string)
+	for k, v := range annotations {
+		if k == "kubectl.kubernetes.io/last-applied-configuration" || k == cmacme.ACMEAccountURIAnnotationKey {
+			continue
+		}
+		out[k] = v
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerstate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var (
+	importLong = templates.LongDesc(i18n.T(`
+Import the state of Issuers and ClusterIssuers from an encrypted bundle written
+by 'issuer-state export'.
+
+Secrets are created before the issuers which reference them, so that ACME
+issuers reuse their existing account rather than registering a new one. ACME
+issuers are annotated with the URI of their account, which cert-manager looks
+up instead of registering the account again.
+
+Existing issuers are left untouched. Existing Secrets are only accepted if they
+hold the same data as the bundle.`))
+
+	importExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Import the issuers from 'issuers.bundle'
+{{.BuildName}} x issuer-state import --passphrase-file passphrase.txt --filename issuers.bundle
+`)))
+)
+
+// ImportOptions is a struct to support issuer-state import command
+type ImportOptions struct {
+	// InputFilename is the file the encrypted bundle is read from.
+	InputFilename string
+	// PassphraseFile is the file containing the passphrase used to decrypt
+	// the bundle.
+	PassphraseFile string
+	// ClusterResourceNamespace is the namespace that Secrets referenced by
+	// ClusterIssuers are created in.
+	ClusterResourceNamespace string
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewCmdImport returns a cobra command for issuer-state import
+func NewCmdImport(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &ImportOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:     "import",
+		Short:   "Import the state of issuers from an encrypted bundle",
+		Long:    importLong,
+		Example: importExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.Flags().StringVarP(&o.InputFilename, "filename", "f", o.InputFilename,
+		"Path to the encrypted bundle to import")
+	cmd.Flags().StringVar(&o.PassphraseFile, "passphrase-file", o.PassphraseFile,
+		"Path to a file containing the passphrase used to decrypt the bundle")
+	cmd.Flags().StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", "cert-manager",
+		"Namespace that Secrets referenced by ClusterIssuers are created in")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *ImportOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("import does not accept arguments")
+	}
+	if o.InputFilename == "" {
+		return errors.New("the path to the bundle must be specified with --filename")
+	}
+	return nil
+}
+
+// Run executes issuer-state import command
+func (o *ImportOptions) Run(ctx context.Context) error {
+	passphrase, err := readPassphrase(o.PassphraseFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(o.InputFilename)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	bundle, err := decryptBundle(data, passphrase)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for i := range bundle.Issuers {
+		state := &bundle.Issuers[i]
+		if err := o.importIssuer(ctx, state); err != nil {
+			fmt.Fprintf(o.ErrOut, "Failed to import %s %q: %v\n", state.Kind, state.Name, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d of %d issuers", failed, len(bundle.Issuers))
+	}
+
+	return nil
+}
+
+func (o *ImportOptions) importIssuer(ctx context.Context, state *IssuerState) error {
+	secretNamespace := state.Namespace
+	if state.Kind == cmapi.ClusterIssuerKind {
+		secretNamespace = o.ClusterResourceNamespace
+	}
+
+	for i := range state.Secrets {
+		secret := state.Secrets[i].DeepCopy()
+		secret.Namespace = secretNamespace
+		if err := o.importSecret(ctx, secret); err != nil {
+			return err
+		}
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:        state.Name,
+		Namespace:   state.Namespace,
+		Labels:      state.Labels,
+		Annotations: state.Annotations,
+	}
+	if state.ACMEAccountURI != "" {
+		if meta.Annotations == nil {
+			meta.Annotations = make(map[string]string)
+		}
+		meta.Annotations[cmacme.ACMEAccountURIAnnotationKey] = state.ACMEAccountURI
+	}
+
+	var err error
+	switch state.Kind {
+	case cmapi.IssuerKind:
+		_, err = o.CMClient.CertmanagerV1().Issuers(state.Namespace).Create(ctx, &cmapi.Issuer{ObjectMeta: meta, Spec: state.Spec}, metav1.CreateOptions{})
+	case cmapi.ClusterIssuerKind:
+		_, err = o.CMClient.CertmanagerV1().ClusterIssuers().Create(ctx, &cmapi.ClusterIssuer{ObjectMeta: meta, Spec: state.Spec}, metav1.CreateOptions{})
+	default:
+		return fmt.Errorf("unknown issuer kind %q", state.Kind)
+	}
+
+	switch {
+	case apierrors.IsAlreadyExists(err):
+		fmt.Fprintf(o.Out, "%s %q already exists, skipping\n", state.Kind, state.Name)
+	case err != nil:
+		return err
+	default:
+		fmt.Fprintf(o.Out, "Imported %s %q\n", state.Kind, state.Name)
+	}
+
+	return nil
+}
+
+// importSecret creates the Secret, accepting an existing Secret only if it
+// holds the same data so that an ACME account key or CA is never replaced.
+func (o *ImportOptions) importSecret(ctx context.Context, secret *corev1.Secret) error {
+	_, err := o.KubeClient.CoreV1().Secrets(secret.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing, err := o.KubeClient.CoreV1().Secrets(secret.Namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if len(existing.Data) != len(secret.Data) {
+		return fmt.Errorf("the Secret %s/%s already exists with different data", secret.Namespace, secret.Name)
+	}
+	for k, v := range secret.Data {
+		if !bytes.Equal(existing.Data[k], v) {
+			return fmt.Errorf("the Secret %s/%s already exists with different data", secret.Namespace, secret.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerstate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmdIssuerState returns a cobra command for exporting and importing the
+// state of issuers.
+func NewCmdIssuerState(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "issuer-state",
+		Short: "Export and import the state of issuers for disaster recovery",
+		Long: `Export and import the state of Issuers and ClusterIssuers for disaster recovery.
+
+The exported bundle contains the issuer specs, the private keys and URIs of their
+ACME accounts and the keypairs of CA issuers, encrypted using a passphrase.
+Importing the bundle into a new cluster rebuilds the issuers using the same ACME
+accounts and CAs.`,
+	}
+
+	cmds.AddCommand(NewCmdExport(ctx, ioStreams))
+	cmds.AddCommand(NewCmdImport(ctx, ioStreams))
+
+	return cmds
+}
+
+// readPassphrase reads the passphrase used to encrypt a bundle from a file,
+// ignoring any trailing newline.
+func readPassphrase(filename string) ([]byte, error) {
+	if filename == "" {
+		return nil, errors.New("the path to a file containing the bundle passphrase must be specified with --passphrase-file")
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase file: %w", err)
+	}
+
+	return bytes.TrimRight(data, "\r\n"), nil
+}
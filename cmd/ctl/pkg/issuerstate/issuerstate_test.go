@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerstate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	passphraseFile := filepath.Join(dir, "passphrase")
+	bundleFile := filepath.Join(dir, "issuers.bundle")
+	if err := os.WriteFile(passphraseFile, []byte("correct horse battery staple\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	acmeIssuer := &cmapi.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt"},
+		Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{
+			Server:     "https://acme-v02.api.letsencrypt.org/directory",
+			PrivateKey: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "letsencrypt-account"}},
+		}}},
+		Status: cmapi.IssuerStatus{ACME: &cmacme.ACMEIssuerStatus{URI: "https://acme-v02.api.letsencrypt.org/acme/acct/1"}},
+	}
+	caIssuer := &cmapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "default"},
+		Spec:       cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{CA: &cmapi.CAIssuer{SecretName: "ca-key-pair"}}},
+	}
+	accountSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt-account", Namespace: "cert-manager", UID: "uid-1", ResourceVersion: "1"},
+		Data:       map[string][]byte{"tls.key": []byte("account-key")},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca-key-pair", Namespace: "default", UID: "uid-2", ResourceVersion: "1"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.key": []byte("ca-key"), "tls.crt": []byte("ca-crt")},
+	}
+
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	export := &ExportOptions{
+		OutputFilename:           bundleFile,
+		PassphraseFile:           passphraseFile,
+		ClusterResourceNamespace: "cert-manager",
+		IOStreams:                streams,
+		Factory: &factory.Factory{
+			Namespace:  "default",
+			CMClient:   cmfake.NewSimpleClientset(acmeIssuer, caIssuer),
+			KubeClient: kubefake.NewSimpleClientset(accountSecret, caSecret),
+		},
+	}
+	if err := export.Run(ctx); err != nil {
+		t.Fatalf("unexpected error exporting: %s", err)
+	}
+
+	cmClient := cmfake.NewSimpleClientset()
+	// The Secret of the CA issuer already exists in the target cluster with
+	// the same data, which should be accepted.
+	kubeClient := kubefake.NewSimpleClientset(caSecret)
+	imp := &ImportOptions{
+		InputFilename:            bundleFile,
+		PassphraseFile:           passphraseFile,
+		ClusterResourceNamespace: "cert-manager-restored",
+		IOStreams:                streams,
+		Factory:                  &factory.Factory{CMClient: cmClient, KubeClient: kubeClient},
+	}
+	if err := imp.Run(ctx); err != nil {
+		t.Fatalf("unexpected error importing: %s", err)
+	}
+
+	gotACME, err := cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, "letsencrypt", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected ClusterIssuer to be imported: %s", err)
+	}
+	if uri := gotACME.Annotations[cmacme.ACMEAccountURIAnnotationKey]; uri != acmeIssuer.Status.ACME.URI {
+		t.Errorf("expected ClusterIssuer to be annotated with its ACME account URI, got %q", uri)
+	}
+	if _, err := cmClient.CertmanagerV1().Issuers("default").Get(ctx, "ca", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected Issuer to be imported: %s", err)
+	}
+
+	gotSecret, err := kubeClient.CoreV1().Secrets("cert-manager-restored").Get(ctx, "letsencrypt-account", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected ACME account Secret to be imported into the cluster resource namespace: %s", err)
+	}
+	if string(gotSecret.Data["tls.key"]) != "account-key" || gotSecret.UID != "" {
+		t.Errorf("unexpected imported Secret: %#v", gotSecret)
+	}
+
+	// Importing into a cluster where a Secret holds a different key must fail,
+	// rather than replacing the key.
+	conflicting := caSecret.DeepCopy()
+	conflicting.Data["tls.key"] = []byte("other-key")
+	imp.Factory = &factory.Factory{CMClient: cmfake.NewSimpleClientset(), KubeClient: kubefake.NewSimpleClientset(conflicting)}
+	if err := imp.Run(ctx); err == nil {
+		t.Error("expected error importing over a Secret with different data")
+	}
+}
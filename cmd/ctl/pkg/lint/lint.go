@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/ctl"
+	"github.com/cert-manager/cert-manager/pkg/lint"
+)
+
+var (
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+		# Lint the cert-manager resources in 'cert.yaml' and 'issuer.yaml'.
+		{{.BuildName}} lint -f cert.yaml -f issuer.yaml
+
+		# Lint the kustomize overlay under the current directory, failing on warnings.
+		{{.BuildName}} lint -k . --fail-on-warnings`)))
+
+	longDesc = templates.LongDesc(i18n.T(`
+Statically analyse cert-manager Certificate, Issuer and ClusterIssuer manifests
+for common misconfigurations. Both YAML and JSON formats are accepted.
+
+Certificates are checked against the Issuers and ClusterIssuers they reference
+when those are passed to the command as well, for example to find wildcard DNS
+names which would be solved using HTTP-01.
+
+Fields which are not part of the API version of a resource, such as misspelt
+fields, are reported along with the closest known field. The API server prunes
+such fields unless strict field validation is requested, for example using
+'kubectl apply --validate=strict'.
+
+The command exits with a non-zero exit code if any errors are found, so that it
+can be used in CI pipelines to catch issues before manifests are deployed.`))
+)
+
+// Options is a struct to support lint command
+type Options struct {
+	FailOnWarnings bool
+
+	resource.FilenameOptions
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdLint returns a cobra command for linting cert-manager resources
+func NewCmdLint(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "lint",
+		Short:                 "Lint cert-manager resource manifests for common misconfigurations",
+		Long:                  longDesc,
+		Example:               example,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.FailOnWarnings, "fail-on-warnings", o.FailOnWarnings, "Exit with a non-zero exit code if any warnings are found.")
+	cmdutil.AddFilenameOptionFlags(cmd, &o.FilenameOptions, "Path to a file containing cert-manager resources to be linted.")
+
+	return cmd
+}
+
+// Complete collects information required to run Lint command from command line.
+func (o *Options) Complete() error {
+	return o.FilenameOptions.RequireFilenameOrKustomize()
+}
+
+// Run executes lint command
+func (o *Options) Run() error {
+	r := new(resource.Builder).
+		Unstructured().
+		LocalParam(true).FilenameParam(false, &o.FilenameOptions).Flatten().Do()
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	infos, err := r.Infos()
+	if err != nil {
+		return err
+	}
+
+	objs, findings, err := asV1Objects(infos)
+	if err != nil {
+		return err
+	}
+
+	findings = append(findings, lint.Lint(objs)...)
+	if len(findings) == 0 {
+		fmt.Fprintln(o.Out, "No issues found")
+		return nil
+	}
+
+	var errs, warnings int
+	for _, f := range findings {
+		fmt.Fprintln(o.Out, f.String())
+		switch f.Severity {
+		case lint.SeverityError:
+			errs++
+		case lint.SeverityWarning:
+			warnings++
+		}
+	}
+
+	if errs > 0 || (o.FailOnWarnings && warnings > 0) {
+		return fmt.Errorf("found %d error(s) and %d warning(s)", errs, warnings)
+	}
+	return nil
+}
+
+// asV1Objects converts the cert-manager resources of the given infos to the
+// v1 API version, which is the version linted. Other resources are ignored.
+// Findings are returned for any fields of the resources which are unknown to
+// their API version.
+func asV1Objects(infos []*resource.Info) ([]runtime.Object, []lint.Finding, error) {
+	var objs []runtime.Object
+	var findings []lint.Finding
+	for _, info := range infos {
+		u, ok := info.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		gvk := u.GroupVersionKind()
+		if gvk.Group != cmapi.SchemeGroupVersion.Group && gvk.Group != cmacme.SchemeGroupVersion.Group {
+			continue
+		}
+
+		obj, err := ctl.Scheme.New(gvk)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode %s %q: %w", gvk.Kind, u.GetName(), err)
+		}
+		unknown, err := lint.Decode(u, obj)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode %s %q: %w", gvk.Kind, u.GetName(), err)
+		}
+		findings = append(findings, unknown...)
+		ctl.Scheme.Default(obj)
+
+		// Convert through the internal version, as there are no conversions
+		// registered directly between external versions.
+		internal, err := ctl.Scheme.ConvertToVersion(obj, runtime.InternalGroupVersioner)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert %s %q: %w", gvk.Kind, u.GetName(), err)
+		}
+		v1Obj, err := ctl.Scheme.ConvertToVersion(internal, schema.GroupVersions{cmapi.SchemeGroupVersion, cmacme.SchemeGroupVersion})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert %s %q to the v1 API: %w", gvk.Kind, u.GetName(), err)
+		}
+		objs = append(objs, v1Obj)
+	}
+
+	return objs, findings, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	wildcardCertificate = `apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: crt
+  namespace: ns
+spec:
+  secretName: crt
+  dnsNames: ["*.example.com"]
+  issuerRef:
+    name: acme
+`
+	caCertificate = `apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: ca
+  namespace: ns
+spec:
+  secretName: ca
+  isCA: true
+  commonName: ca
+  usages: ["digital signature"]
+  issuerRef:
+    name: selfsigned
+`
+	http01Issuer = `apiVersion: cert-manager.io/v1
+kind: Issuer
+metadata:
+  name: acme
+  namespace: ns
+spec:
+  acme:
+    server: https://acme.example.com
+    privateKeySecretRef:
+      name: acme
+    solvers:
+    - http01:
+        ingress: {}
+`
+	misspeltCertificate = `apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: crt
+  namespace: ns
+spec:
+  secretName: crt
+  dnsNames: ["example.com"]
+  renewBefor: 1h
+  issuerRef:
+    name: acme
+`
+	configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: ns
+`
+)
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		manifests      []string
+		failOnWarnings bool
+		expOutput      string
+		expErr         bool
+	}{
+		"no issues": {
+			manifests: []string{wildcardCertificate, configMap},
+			expOutput: "No issues found\n",
+		},
+		"error": {
+			manifests: []string{wildcardCertificate, http01Issuer, configMap},
+			expOutput: `Error Certificate ns/crt: spec.dnsNames[0]: wildcard DNS name "*.example.com" can only be validated using DNS-01, but Issuer "acme" has no DNS-01 solver matching it (wildcard-http01)` + "\n",
+			expErr:    true,
+		},
+		"warning": {
+			manifests: []string{caCertificate},
+			expOutput: `Warning Certificate ns/ca: spec.usages: CA certificates are always issued with the "cert sign" usage, which should be listed in usages (ca-usages)` + "\n",
+		},
+		"unknown field": {
+			manifests: []string{misspeltCertificate},
+			expOutput: `Error Certificate ns/crt: spec.renewBefor: unknown field, did you mean "spec.renewBefore"? (unknown-field)` + "\n",
+			expErr:    true,
+		},
+		"warning with fail on warnings": {
+			manifests:      []string{caCertificate},
+			failOnWarnings: true,
+			expOutput:      `Warning Certificate ns/ca: spec.usages: CA certificates are always issued with the "cert sign" usage, which should be listed in usages (ca-usages)` + "\n",
+			expErr:         true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var content string
+			for _, m := range test.manifests {
+				content += "---\n" + m
+			}
+			path := filepath.Join(t.TempDir(), "manifests.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := NewOptions(streams)
+			o.FailOnWarnings = test.failOnWarnings
+			o.FilenameOptions = resource.FilenameOptions{Filenames: []string{path}}
+			require.NoError(t, o.Complete())
+
+			err := o.Run()
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expOutput, out.String())
+		})
+	}
+}
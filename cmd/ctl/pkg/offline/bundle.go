@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// bundleVersion is the version of the bundle format written by export.
+const bundleVersion = 1
+
+// Bundle is the file format used to carry the CertificateRequests of offline
+// issuers to the offline CA, and their signed certificates back.
+type Bundle struct {
+	Version  int       `json:"version"`
+	Requests []Request `json:"requests"`
+}
+
+// Request is an exported CertificateRequest. The offline CA signs the CSR and
+// fills in the Certificate.
+type Request struct {
+	Namespace string                 `json:"namespace"`
+	Name      string                 `json:"name"`
+	UID       types.UID              `json:"uid"`
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+
+	// Request is the PEM encoded CSR of the CertificateRequest.
+	Request string `json:"request"`
+
+	// Duration, IsCA and Usages are the requested properties of the
+	// certificate, which are not all encoded in the CSR.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	IsCA     bool             `json:"isCA,omitempty"`
+	Usages   []cmapi.KeyUsage `json:"usages,omitempty"`
+
+	// Certificate is the PEM encoded certificate chain signed by the offline
+	// CA. It is empty until the request has been signed.
+	Certificate string `json:"certificate,omitempty"`
+}
+
+// decodeBundle decodes a bundle written by export.
+func decodeBundle(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode bundle: %w", err)
+	}
+	if bundle.Version != bundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d", bundle.Version)
+	}
+	return &bundle, nil
+}
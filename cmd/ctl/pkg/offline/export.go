@@ -0,0 +1,222 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+var (
+	exportLong = templates.LongDesc(i18n.T(`
+Export the CertificateRequests of offline issuers which are waiting to be
+signed to a bundle.
+
+Only approved CertificateRequests which are not ready and for which no
+certificate has been imported yet are exported. The bundle is a JSON document
+listing the PEM encoded CSR of each request, along with the requested
+duration, usages and whether a CA certificate is requested. The offline CA
+fills in the certificate field of each request, and the bundle is then
+imported with 'offline import'.`))
+
+	exportExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Export the pending CertificateRequests in namespace 'my-namespace' to 'requests.json'
+{{.BuildName}} x offline export --namespace my-namespace --output requests.json
+
+# Export the pending CertificateRequests in all namespaces
+{{.BuildName}} x offline export --all-namespaces --output requests.json
+`)))
+)
+
+// ExportOptions is a struct to support offline export command
+type ExportOptions struct {
+	// OutputFilename is the file the bundle is written to.
+	OutputFilename string
+	// AllNamespaces exports CertificateRequests from all namespaces, rather
+	// than only the current namespace.
+	AllNamespaces bool
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewCmdExport returns a cobra command for offline export
+func NewCmdExport(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &ExportOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export the CertificateRequests of offline issuers to a bundle",
+		Long:    exportLong,
+		Example: exportExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.Flags().StringVarP(&o.OutputFilename, "output", "o", o.OutputFilename,
+		"Path to the file that the bundle is written to")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces,
+		"If set, CertificateRequests are exported from all namespaces")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *ExportOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("export does not accept arguments")
+	}
+	if o.OutputFilename == "" {
+		return errors.New("the path to write the bundle to must be specified with --output")
+	}
+	return nil
+}
+
+// Run executes offline export command
+func (o *ExportOptions) Run(ctx context.Context) error {
+	bundle, err := o.exportBundle(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(o.OutputFilename, data, 0600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	fmt.Fprintf(o.Out, "Exported %d CertificateRequests to %s\n", len(bundle.Requests), o.OutputFilename)
+
+	return nil
+}
+
+func (o *ExportOptions) exportBundle(ctx context.Context) (*Bundle, error) {
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	crs, err := o.CMClient.CertmanagerV1().CertificateRequests(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CertificateRequests: %w", err)
+	}
+
+	// offline caches whether each referenced issuer is an offline issuer.
+	offline := make(map[cmmeta.ObjectReference]bool)
+
+	bundle := &Bundle{Version: bundleVersion}
+	for i := range crs.Items {
+		cr := &crs.Items[i]
+		if !awaitingOfflineSigning(cr) {
+			continue
+		}
+
+		ref := cr.Spec.IssuerRef
+		if ref.Kind != cmapi.ClusterIssuerKind && ref.Namespace == "" {
+			ref.Namespace = cr.Namespace
+		}
+		isOffline, ok := offline[ref]
+		if !ok {
+			isOffline, err = o.isOfflineIssuer(ctx, ref)
+			if err != nil {
+				return nil, err
+			}
+			offline[ref] = isOffline
+		}
+		if !isOffline {
+			continue
+		}
+
+		bundle.Requests = append(bundle.Requests, Request{
+			Namespace: cr.Namespace,
+			Name:      cr.Name,
+			UID:       cr.UID,
+			IssuerRef: cr.Spec.IssuerRef,
+			Request:   string(cr.Spec.Request),
+			Duration:  cr.Spec.Duration,
+			IsCA:      cr.Spec.IsCA,
+			Usages:    cr.Spec.Usages,
+		})
+	}
+
+	return bundle, nil
+}
+
+// awaitingOfflineSigning returns whether the CertificateRequest has been
+// approved and is waiting for its certificate to be imported.
+func awaitingOfflineSigning(cr *cmapi.CertificateRequest) bool {
+	if !apiutil.CertificateRequestIsApproved(cr) || apiutil.CertificateRequestIsDenied(cr) {
+		return false
+	}
+	switch apiutil.CertificateRequestReadyReason(cr) {
+	case cmapi.CertificateRequestReasonIssued, cmapi.CertificateRequestReasonFailed:
+		return false
+	}
+	_, imported := cr.Annotations[cmapi.OfflineCertificateAnnotationKey]
+	return !imported
+}
+
+// isOfflineIssuer returns whether the referenced issuer is an offline issuer.
+// References to issuers which don't exist, or of other API groups, are not.
+func (o *ExportOptions) isOfflineIssuer(ctx context.Context, ref cmmeta.ObjectReference) (bool, error) {
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return false, nil
+	}
+
+	var issuer cmapi.GenericIssuer
+	var err error
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		issuer, err = o.CMClient.CertmanagerV1().Issuers(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case cmapi.ClusterIssuerKind:
+		issuer, err = o.CMClient.CertmanagerV1().ClusterIssuers().Get(ctx, ref.Name, metav1.GetOptions{})
+	default:
+		return false, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s %q: %w", apiutil.IssuerKind(ref), ref.Name, err)
+	}
+
+	return issuer.GetSpec().Offline != nil, nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+var (
+	importLong = templates.LongDesc(i18n.T(`
+Import the certificates signed by an offline CA from a bundle written by
+'offline export'.
+
+Each certificate is imported into the CertificateRequest it was exported from,
+which must still exist with the same UID and CSR. Requests without a
+certificate in the bundle are skipped. cert-manager verifies that an imported
+certificate chains up to the CA bundle of the offline issuer before completing
+the CertificateRequest.`))
+
+	importExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Import the certificates signed for the requests in 'requests.json'
+{{.BuildName}} x offline import --filename requests.json
+`)))
+)
+
+// ImportOptions is a struct to support offline import command
+type ImportOptions struct {
+	// InputFilename is the file the bundle is read from.
+	InputFilename string
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewCmdImport returns a cobra command for offline import
+func NewCmdImport(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &ImportOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:     "import",
+		Short:   "Import the certificates signed by an offline CA from a bundle",
+		Long:    importLong,
+		Example: importExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.Flags().StringVarP(&o.InputFilename, "filename", "f", o.InputFilename,
+		"Path to the bundle to import")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *ImportOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("import does not accept arguments")
+	}
+	if o.InputFilename == "" {
+		return errors.New("the path to the bundle must be specified with --filename")
+	}
+	return nil
+}
+
+// Run executes offline import command
+func (o *ImportOptions) Run(ctx context.Context) error {
+	data, err := os.ReadFile(o.InputFilename)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	bundle, err := decodeBundle(data)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for i := range bundle.Requests {
+		req := &bundle.Requests[i]
+		if req.Certificate == "" {
+			fmt.Fprintf(o.Out, "CertificateRequest %s/%s has not been signed, skipping\n", req.Namespace, req.Name)
+			continue
+		}
+		if err := o.importCertificate(ctx, req); err != nil {
+			fmt.Fprintf(o.ErrOut, "Failed to import the certificate of CertificateRequest %s/%s: %v\n", req.Namespace, req.Name, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(o.Out, "Imported the certificate of CertificateRequest %s/%s\n", req.Namespace, req.Name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d of %d certificates", failed, len(bundle.Requests))
+	}
+
+	return nil
+}
+
+// importCertificate sets the annotation importing the certificate on the
+// CertificateRequest, after checking that it is the exported request and that
+// the certificate is for its public key.
+func (o *ImportOptions) importCertificate(ctx context.Context, req *Request) error {
+	cr, err := o.CMClient.CertmanagerV1().CertificateRequests(req.Namespace).Get(ctx, req.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return errors.New("the CertificateRequest no longer exists")
+	}
+	if err != nil {
+		return err
+	}
+	if cr.UID != req.UID || string(cr.Spec.Request) != req.Request {
+		return errors.New("the CertificateRequest has been replaced since it was exported")
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes([]byte(req.Certificate))
+	if err != nil {
+		return err
+	}
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return err
+	}
+	matches, err := pki.PublicKeyMatchesCSR(cert.PublicKey, csr)
+	if err != nil {
+		return err
+	}
+	if !matches {
+		return errors.New("the public key of the certificate does not match the request")
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				cmapi.OfflineCertificateAnnotationKey: req.Certificate,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = o.CMClient.CertmanagerV1().CertificateRequests(req.Namespace).Patch(ctx, req.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmdOffline returns a cobra command for exporting the CertificateRequests
+// of offline issuers and importing their signed certificates.
+func NewCmdOffline(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "offline",
+		Short: "Export and import CertificateRequests signed by an offline CA",
+		Long: `Export and import the CertificateRequests of offline issuers, whose CA cannot
+be reached from the cluster.
+
+The exported bundle contains the CSRs of the approved CertificateRequests which
+are waiting to be signed. It is carried across the air gap and the certificate
+signed by the offline CA is filled in for each request, before the bundle is
+carried back and imported. cert-manager then completes the CertificateRequests
+and writes the certificates to the Secrets of their Certificates.`,
+	}
+
+	cmds.AddCommand(NewCmdExport(ctx, ioStreams))
+	cmds.AddCommand(NewCmdImport(ctx, ioStreams))
+
+	return cmds
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	bundleFile := filepath.Join(t.TempDir(), "requests.json")
+
+	caKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "offline CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caPEM, caCert, err := pki.SignCertificate(caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+
+	offlineIssuer := gen.Issuer("offline",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerOffline(cmapi.OfflineIssuer{CABundle: caPEM}))
+	caIssuer := gen.Issuer("ca",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}))
+
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	require.NoError(t, err)
+	csr, err := gen.CSRWithSigner(sk, gen.SetCSRCommonName("example.com"))
+	require.NoError(t, err)
+
+	approved := cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue}
+	newCR := func(name, issuer string, mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		cr := gen.CertificateRequest(name, append([]gen.CertificateRequestModifier{
+			gen.SetCertificateRequestNamespace("default"),
+			gen.SetCertificateRequestCSR(csr),
+			gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: issuer}),
+		}, mods...)...)
+		cr.UID = types.UID("uid-" + name)
+		return cr
+	}
+	pending := newCR("pending", "offline", gen.AddCertificateRequestStatusCondition(approved))
+	unapproved := newCR("unapproved", "offline")
+	otherIssuer := newCR("other-issuer", "ca", gen.AddCertificateRequestStatusCondition(approved))
+
+	cmClient := cmfake.NewSimpleClientset(offlineIssuer, caIssuer, pending, unapproved, otherIssuer)
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	f := &factory.Factory{Namespace: "default", CMClient: cmClient}
+
+	export := &ExportOptions{OutputFilename: bundleFile, IOStreams: streams, Factory: f}
+	require.NoError(t, export.Run(ctx))
+
+	data, err := os.ReadFile(bundleFile)
+	require.NoError(t, err)
+	bundle, err := decodeBundle(data)
+	require.NoError(t, err)
+	require.Len(t, bundle.Requests, 1, "only the approved request of the offline issuer should be exported")
+	assert.Equal(t, "pending", bundle.Requests[0].Name)
+	assert.Equal(t, string(csr), bundle.Requests[0].Request)
+
+	// Sign the request with the offline CA.
+	template, err := pki.GenerateTemplateFromCSRPEM([]byte(bundle.Requests[0].Request), time.Hour, false)
+	require.NoError(t, err)
+	signed, err := pki.SignCSRTemplate([]*x509.Certificate{caCert}, caKey, template)
+	require.NoError(t, err)
+	bundle.Requests[0].Certificate = string(signed.ChainPEM)
+
+	// A request which has been replaced since it was exported is not imported.
+	replaced := bundle.Requests[0]
+	replaced.UID = "uid-replaced"
+	bundle.Requests = append(bundle.Requests, replaced)
+
+	data, err = json.Marshal(bundle)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(bundleFile, data, 0600))
+
+	imp := &ImportOptions{InputFilename: bundleFile, IOStreams: streams, Factory: f}
+	assert.EqualError(t, imp.Run(ctx), "failed to import 1 of 2 certificates")
+
+	cr, err := cmClient.CertmanagerV1().CertificateRequests("default").Get(ctx, "pending", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, string(signed.ChainPEM), cr.Annotations[cmapi.OfflineCertificateAnnotationKey])
+
+	// Requests with an imported certificate are not exported again.
+	require.NoError(t, export.Run(ctx))
+	data, err = os.ReadFile(bundleFile)
+	require.NoError(t, err)
+	bundle, err = decodeBundle(data)
+	require.NoError(t, err)
+	assert.Empty(t, bundle.Requests)
+}
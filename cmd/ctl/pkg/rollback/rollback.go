@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Roll back the Secret of a cert-manager Certificate to a previously issued revision.
+
+The Certificate must be annotated with 'cert-manager.io/secret-snapshot-limit'
+so that snapshots of its issued revisions are kept, and the
+'certificates-secret-snapshot' controller must be enabled. The rolled back
+certificate is renewed as usual; the Certificate is not re-issued.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Roll back the Secret of the Certificate named 'my-app' to revision 3.
+{{.BuildName}} rollback my-app --revision 3`)))
+)
+
+// Options is a struct to support rollback command
+type Options struct {
+	Revision int
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdRollback returns a cobra command for rolling back the Secret of a
+// Certificate
+func NewCmdRollback(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:               "rollback",
+		Short:             "Roll back the Secret of a Certificate to a previous revision",
+		Long:              long,
+		Example:           example,
+		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().IntVar(&o.Revision, "revision", o.Revision, "The revision of the Certificate to roll back to.")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("the name of a single Certificate must be specified")
+	}
+
+	if o.Revision < 1 {
+		return errors.New("--revision must be specified as a positive integer")
+	}
+
+	return nil
+}
+
+// Run executes rollback command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if _, ok := crt.Annotations[cmapi.SecretSnapshotLimitAnnotationKey]; !ok {
+		return fmt.Errorf("Certificate %s/%s does not keep snapshots of its Secret, it must be annotated with %q", crt.Namespace, crt.Name, cmapi.SecretSnapshotLimitAnnotationKey)
+	}
+
+	if crt.Annotations == nil {
+		crt.Annotations = make(map[string]string)
+	}
+	crt.Annotations[cmapi.RollbackRevisionAnnotationKey] = strconv.Itoa(o.Revision)
+
+	if _, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to request rollback of Certificate %s/%s: %v", crt.Namespace, crt.Name, err)
+	}
+	fmt.Fprintf(o.Out, "Requested rollback of Certificate %s/%s to revision %d\n", crt.Namespace, crt.Name, o.Revision)
+	return nil
+}
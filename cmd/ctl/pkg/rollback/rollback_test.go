@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollback
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		options *Options
+		args    []string
+		expErr  bool
+	}{
+		"If no Certificate is named, error": {
+			options: &Options{Revision: 1},
+			expErr:  true,
+		},
+		"If more than one Certificate is named, error": {
+			options: &Options{Revision: 1},
+			args:    []string{"abc", "def"},
+			expErr:  true,
+		},
+		"If no revision is given, error": {
+			options: &Options{},
+			args:    []string{"abc"},
+			expErr:  true,
+		},
+		"If a Certificate and revision are given, don't error": {
+			options: &Options{Revision: 2},
+			args:    []string{"abc"},
+			expErr:  false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.options.Validate(test.args)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t got=%v",
+					test.expErr, err)
+			}
+		})
+	}
+}
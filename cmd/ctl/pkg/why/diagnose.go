@@ -0,0 +1,360 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package why
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/certificate"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// Resources is the object graph of a Certificate that is diagnosed.
+type Resources struct {
+	*certificate.Data
+
+	// Certificates are the other Certificates in the namespace of the
+	// Certificate being diagnosed.
+	Certificates []cmapi.Certificate
+}
+
+// Cause is a possible root cause of a Certificate not becoming ready.
+type Cause struct {
+	// Score is how likely the Cause is the root cause. Causes which block
+	// issuance outright score higher than those which may resolve themselves.
+	Score int
+
+	// Summary is a one line description of the Cause.
+	Summary string
+
+	// Details explains how the Cause was found, usually including the message
+	// of the resource which surfaced it.
+	Details string
+
+	// Fixes are the suggested actions to resolve the Cause.
+	Fixes []string
+}
+
+// signature matches a known failure against the object graph of a
+// Certificate, returning nil if it doesn't match.
+type signature func(*Resources) *Cause
+
+// signatures are all failure signatures known to the diagnosis engine.
+var signatures = []signature{
+	issuerNotFound,
+	issuerNotReady,
+	requestDenied,
+	requestInvalid,
+	requestFailed,
+	requestPendingApproval,
+	orderFailed,
+	challengeFailed,
+	challengePending,
+	secretConflict,
+	issuingFailed,
+}
+
+// Diagnose evaluates all known failure signatures against the resources and
+// returns the matching causes, most likely first.
+func Diagnose(res *Resources) []Cause {
+	var causes []Cause
+	for _, sig := range signatures {
+		if cause := sig(res); cause != nil {
+			causes = append(causes, *cause)
+		}
+	}
+
+	sort.SliceStable(causes, func(i, j int) bool {
+		return causes[i].Score > causes[j].Score
+	})
+
+	return causes
+}
+
+func issuerNotFound(res *Resources) *Cause {
+	if res.Issuer != nil || res.IssuerError == nil {
+		return nil
+	}
+
+	ref := res.Certificate.Spec.IssuerRef
+	return &Cause{
+		Score:   100,
+		Summary: fmt.Sprintf("The issuer %q referenced by the Certificate could not be found", ref.Name),
+		Details: strings.TrimSpace(res.IssuerError.Error()),
+		Fixes: []string{
+			"Check that spec.issuerRef name, kind and group match an existing Issuer or ClusterIssuer",
+			"Issuers are namespaced, use kind ClusterIssuer to reference an issuer from another namespace",
+		},
+	}
+}
+
+func issuerNotReady(res *Resources) *Cause {
+	if res.Issuer == nil {
+		return nil
+	}
+
+	cond := issuerReadyCondition(res.Issuer)
+	if cond != nil && cond.Status == cmmeta.ConditionTrue {
+		return nil
+	}
+
+	details := "The issuer has not reported a Ready condition yet"
+	if cond != nil {
+		details = fmt.Sprintf("Ready condition is %s: %s: %s", cond.Status, cond.Reason, cond.Message)
+	}
+
+	return &Cause{
+		Score:   90,
+		Summary: fmt.Sprintf("The %s %q is not ready", res.IssuerKind, res.Issuer.GetObjectMeta().Name),
+		Details: details,
+		Fixes: []string{
+			fmt.Sprintf("Inspect the issuer with 'kubectl describe %s %s'", strings.ToLower(res.IssuerKind), res.Issuer.GetObjectMeta().Name),
+			"Check that any Secrets referenced by the issuer exist and contain the expected keys",
+		},
+	}
+}
+
+func requestDenied(res *Resources) *Cause {
+	if res.Req == nil || !apiutil.CertificateRequestIsDenied(res.Req) {
+		return nil
+	}
+
+	cond := apiutil.GetCertificateRequestCondition(res.Req, cmapi.CertificateRequestConditionDenied)
+	return &Cause{
+		Score:   95,
+		Summary: fmt.Sprintf("The CertificateRequest %q was denied", res.Req.Name),
+		Details: fmt.Sprintf("%s: %s", cond.Reason, cond.Message),
+		Fixes: []string{
+			"Check the policy of the approver which denied the request, for example approver-policy CertificateRequestPolicies",
+			fmt.Sprintf("Once the Certificate is changed to comply with the policy, trigger a new request with 'cmctl renew %s'", res.Certificate.Name),
+		},
+	}
+}
+
+func requestInvalid(res *Resources) *Cause {
+	if res.Req == nil || !apiutil.CertificateRequestHasInvalidRequest(res.Req) {
+		return nil
+	}
+
+	return &Cause{
+		Score:   95,
+		Summary: fmt.Sprintf("The CertificateRequest %q is invalid", res.Req.Name),
+		Details: apiutil.CertificateRequestInvalidRequestMessage(res.Req),
+		Fixes: []string{
+			"Change the Certificate so that the request is valid for the issuer, then trigger a new request with 'cmctl renew'",
+		},
+	}
+}
+
+func requestFailed(res *Resources) *Cause {
+	if res.Req == nil || apiutil.CertificateRequestReadyReason(res.Req) != cmapi.CertificateRequestReasonFailed {
+		return nil
+	}
+	// Denied and invalid requests are also failed, those are covered by more
+	// specific signatures.
+	if apiutil.CertificateRequestIsDenied(res.Req) || apiutil.CertificateRequestHasInvalidRequest(res.Req) {
+		return nil
+	}
+
+	cond := apiutil.GetCertificateRequestCondition(res.Req, cmapi.CertificateRequestConditionReady)
+	return &Cause{
+		Score:   80,
+		Summary: fmt.Sprintf("The issuer failed to sign the CertificateRequest %q", res.Req.Name),
+		Details: cond.Message,
+		Fixes: []string{
+			"Check the events of the CertificateRequest and the issuer for the error returned by the signer",
+			"cert-manager retries failed issuances with an exponential backoff, use 'cmctl renew' to retry immediately once fixed",
+		},
+	}
+}
+
+func requestPendingApproval(res *Resources) *Cause {
+	if res.Req == nil || apiutil.CertificateRequestIsApproved(res.Req) || apiutil.CertificateRequestIsDenied(res.Req) {
+		return nil
+	}
+
+	return &Cause{
+		Score:   70,
+		Summary: fmt.Sprintf("The CertificateRequest %q has not been approved", res.Req.Name),
+		Details: "Issuers only sign CertificateRequests which have been approved",
+		Fixes: []string{
+			"Check that an approver is running, the built in approver may have been disabled with --controllers",
+			fmt.Sprintf("Manually approve the request with 'cmctl approve %s'", res.Req.Name),
+		},
+	}
+}
+
+func orderFailed(res *Resources) *Cause {
+	if res.Order == nil {
+		return nil
+	}
+	switch res.Order.Status.State {
+	case cmacme.Errored, cmacme.Invalid, cmacme.Expired:
+	default:
+		return nil
+	}
+
+	return &Cause{
+		Score:   85,
+		Summary: fmt.Sprintf("The ACME Order %q is %s", res.Order.Name, res.Order.Status.State),
+		Details: res.Order.Status.Reason,
+		Fixes: []string{
+			"Check the Challenges of the Order for the authorization which failed",
+			"A new Order is created once the Certificate is retried, use 'cmctl renew' to retry immediately",
+		},
+	}
+}
+
+func challengeFailed(res *Resources) *Cause {
+	for _, ch := range res.Challenges {
+		switch ch.Status.State {
+		case cmacme.Errored, cmacme.Invalid, cmacme.Expired:
+		default:
+			continue
+		}
+
+		return &Cause{
+			Score:   85,
+			Summary: fmt.Sprintf("The %s challenge for %q is %s", ch.Spec.Type, ch.Spec.DNSName, ch.Status.State),
+			Details: ch.Status.Reason,
+			Fixes:   challengeFixes(ch),
+		}
+	}
+
+	return nil
+}
+
+func challengePending(res *Resources) *Cause {
+	for _, ch := range res.Challenges {
+		if ch.Status.State == cmacme.Valid || ch.Status.State == cmacme.Errored ||
+			ch.Status.State == cmacme.Invalid || ch.Status.State == cmacme.Expired {
+			continue
+		}
+
+		details := ch.Status.Reason
+		if details == "" {
+			details = fmt.Sprintf("The challenge is in state %q", ch.Status.State)
+		}
+
+		return &Cause{
+			Score:   60,
+			Summary: fmt.Sprintf("The %s challenge for %q has not completed", ch.Spec.Type, ch.Spec.DNSName),
+			Details: details,
+			Fixes:   challengeFixes(ch),
+		}
+	}
+
+	return nil
+}
+
+func challengeFixes(ch *cmacme.Challenge) []string {
+	if ch.Spec.Type == cmacme.ACMEChallengeTypeDNS01 {
+		return []string{
+			fmt.Sprintf("Check that the TXT record _acme-challenge.%s is visible from the public internet", ch.Spec.DNSName),
+			"Check that the DNS01 solver credentials allow records to be created in the zone",
+		}
+	}
+
+	return []string{
+		fmt.Sprintf("Check that http://%s/.well-known/acme-challenge/%s is reachable from the public internet", ch.Spec.DNSName, ch.Spec.Token),
+		"Check that the solver Ingress or Gateway is admitted by the ingress controller",
+	}
+}
+
+func secretConflict(res *Resources) *Cause {
+	crt := res.Certificate
+
+	for _, other := range res.Certificates {
+		if other.Name == crt.Name || other.Spec.SecretName != crt.Spec.SecretName {
+			continue
+		}
+		return &Cause{
+			Score:   75,
+			Summary: fmt.Sprintf("The Certificate %q uses the same Secret %q", other.Name, crt.Spec.SecretName),
+			Details: "Certificates sharing a Secret continuously overwrite each other's certificate, re-issuing each time",
+			Fixes: []string{
+				"Give each Certificate a unique spec.secretName",
+			},
+		}
+	}
+
+	if res.Secret == nil || res.Secret.Name == "" {
+		return nil
+	}
+	if owner, ok := res.Secret.Annotations[cmapi.CertificateNameKey]; ok && owner != crt.Name {
+		return &Cause{
+			Score:   75,
+			Summary: fmt.Sprintf("The Secret %q is annotated as belonging to the Certificate %q", res.Secret.Name, owner),
+			Details: secretEventsDetails(res.SecretEvents),
+			Fixes: []string{
+				fmt.Sprintf("If the Certificate %q no longer exists, delete the Secret so that it is re-issued", owner),
+				"Otherwise give each Certificate a unique spec.secretName",
+			},
+		}
+	}
+
+	return nil
+}
+
+func secretEventsDetails(events *corev1.EventList) string {
+	if events == nil || len(events.Items) == 0 {
+		return "Secrets may only be managed by a single Certificate"
+	}
+	last := events.Items[len(events.Items)-1]
+	return fmt.Sprintf("%s: %s", last.Reason, last.Message)
+}
+
+func issuingFailed(res *Resources) *Cause {
+	crt := res.Certificate
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	if cond == nil || cond.Status != cmmeta.ConditionFalse || crt.Status.LastFailureTime == nil {
+		return nil
+	}
+	// The Issuing condition is also set to False once a certificate has
+	// been issued successfully.
+	if cond.Reason == "Issued" {
+		return nil
+	}
+
+	details := fmt.Sprintf("%s (last failure at %s)", cond.Message, crt.Status.LastFailureTime.Format(time.RFC3339))
+
+	return &Cause{
+		Score:   50,
+		Summary: "The last issuance of the Certificate failed",
+		Details: details,
+		Fixes: []string{
+			"cert-manager retries failed issuances with an exponential backoff, use 'cmctl renew' to retry immediately once fixed",
+		},
+	}
+}
+
+func issuerReadyCondition(issuer cmapi.GenericIssuer) *cmapi.IssuerCondition {
+	for i, cond := range issuer.GetStatus().Conditions {
+		if cond.Type == cmapi.IssuerConditionReady {
+			return &issuer.GetStatus().Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package why
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/certificate"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestDiagnose(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("default"),
+		gen.SetCertificateSecretName("test-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}),
+	)
+	readyIssuer := gen.Issuer("ca",
+		gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}),
+	)
+	approvedReq := gen.CertificateRequest("test-1",
+		gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue}),
+	)
+
+	tests := map[string]struct {
+		res *Resources
+		exp []string
+	}{
+		"a healthy certificate should have no causes": {
+			res: &Resources{Data: &certificate.Data{Certificate: crt, Issuer: readyIssuer, IssuerKind: "Issuer"}},
+		},
+		"a missing issuer should be the only cause": {
+			res: &Resources{Data: &certificate.Data{Certificate: crt, IssuerError: errors.New("not found")}},
+			exp: []string{`The issuer "ca" referenced by the Certificate could not be found`},
+		},
+		"a denied request should rank above an issuer which is not ready": {
+			res: &Resources{Data: &certificate.Data{
+				Certificate: crt,
+				Issuer:      gen.Issuer("ca"),
+				IssuerKind:  "Issuer",
+				Req: gen.CertificateRequest("test-1",
+					gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue, Reason: "Policy", Message: "not allowed"}),
+				),
+			}},
+			exp: []string{
+				`The CertificateRequest "test-1" was denied`,
+				`The Issuer "ca" is not ready`,
+			},
+		},
+		"an unapproved request should be reported": {
+			res: &Resources{Data: &certificate.Data{
+				Certificate: crt,
+				Issuer:      readyIssuer,
+				IssuerKind:  "Issuer",
+				Req:         gen.CertificateRequest("test-1"),
+			}},
+			exp: []string{`The CertificateRequest "test-1" has not been approved`},
+		},
+		"a failed challenge should rank above a pending challenge": {
+			res: &Resources{Data: &certificate.Data{
+				Certificate: crt,
+				Issuer:      readyIssuer,
+				IssuerKind:  "Issuer",
+				Req:         approvedReq,
+				Challenges: []*cmacme.Challenge{
+					gen.Challenge("a", gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01), gen.SetChallengeDNSName("a.example.com"), gen.SetChallengeState(cmacme.Pending), gen.SetChallengeReason("Waiting for HTTP-01 challenge propagation")),
+					gen.Challenge("b", gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01), gen.SetChallengeDNSName("b.example.com"), gen.SetChallengeState(cmacme.Invalid)),
+				},
+			}},
+			exp: []string{
+				`The DNS-01 challenge for "b.example.com" is invalid`,
+				`The HTTP-01 challenge for "a.example.com" has not completed`,
+			},
+		},
+		"a Secret shared with another Certificate should be reported": {
+			res: &Resources{
+				Data: &certificate.Data{Certificate: crt, Issuer: readyIssuer, IssuerKind: "Issuer"},
+				Certificates: []cmapi.Certificate{
+					*crt,
+					*gen.Certificate("other", gen.SetCertificateNamespace("default"), gen.SetCertificateSecretName("test-tls")),
+				},
+			},
+			exp: []string{`The Certificate "other" uses the same Secret "test-tls"`},
+		},
+		"a Secret annotated for another Certificate should be reported": {
+			res: &Resources{Data: &certificate.Data{
+				Certificate: crt,
+				Issuer:      readyIssuer,
+				IssuerKind:  "Issuer",
+				Secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-tls",
+					Namespace:   "default",
+					Annotations: map[string]string{cmapi.CertificateNameKey: "other"},
+				}},
+			}},
+			exp: []string{`The Secret "test-tls" is annotated as belonging to the Certificate "other"`},
+		},
+		"a failed issuance should be reported": {
+			res: &Resources{Data: &certificate.Data{
+				Certificate: gen.CertificateFrom(crt,
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed", Message: "boom"}),
+					gen.SetCertificateLastFailureTime(metav1.Now()),
+				),
+				Issuer:     readyIssuer,
+				IssuerKind: "Issuer",
+			}},
+			exp: []string{"The last issuance of the Certificate failed"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			causes := Diagnose(test.res)
+			if len(causes) != len(test.exp) {
+				t.Fatalf("unexpected number of causes, exp=%d got=%#v", len(test.exp), causes)
+			}
+			for i := range test.exp {
+				if causes[i].Summary != test.exp[i] {
+					t.Errorf("unexpected cause %d, exp=%q got=%q", i, test.exp[i], causes[i].Summary)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package why
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/certificate"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Explain why a cert-manager Certificate is not ready.
+
+The Certificate and its related resources, such as the issuer, CertificateRequest,
+ACME Order and Challenges and the target Secret, are checked against known failure
+signatures. Matching causes are printed with the most likely root cause first,
+along with suggested fixes.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Explain why the Certificate with name 'my-crt' in namespace 'my-namespace' is not ready
+{{.BuildName}} why my-crt --namespace my-namespace
+`)))
+)
+
+// Options is a struct to support why command
+type Options struct {
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdWhy returns a cobra command for why
+func NewCmdWhy(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:               "why",
+		Short:             "Explain why a cert-manager Certificate is not ready",
+		Long:              long,
+		Example:           example,
+		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the Certificate has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the Certificate")
+	}
+	return nil
+}
+
+// Run executes why command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	statusOpts := &certificate.Options{IOStreams: o.IOStreams, Factory: o.Factory}
+	data, err := statusOpts.GetResources(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	crts, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+
+	res := &Resources{Data: data, Certificates: crts.Items}
+	printDiagnosis(o.Out, res.Certificate, Diagnose(res))
+
+	return nil
+}
+
+// printDiagnosis writes the causes found for the Certificate to out.
+func printDiagnosis(out io.Writer, crt *cmapi.Certificate, causes []Cause) {
+	ready := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady)
+	switch {
+	case ready == nil:
+		fmt.Fprintf(out, "Certificate %s/%s has no Ready condition yet.\n", crt.Namespace, crt.Name)
+	case ready.Status == cmmeta.ConditionTrue:
+		fmt.Fprintf(out, "Certificate %s/%s is Ready: %s\n", crt.Namespace, crt.Name, ready.Message)
+	default:
+		fmt.Fprintf(out, "Certificate %s/%s is not Ready: %s\n", crt.Namespace, crt.Name, ready.Message)
+	}
+
+	if len(causes) == 0 {
+		if ready == nil || ready.Status != cmmeta.ConditionTrue {
+			fmt.Fprintf(out, "\nNo known failure signatures matched. Run '%s status certificate %s' for more details.\n", build.Name(), crt.Name)
+		}
+		return
+	}
+
+	fmt.Fprintf(out, "\nPossible root causes, most likely first:\n")
+	for i, cause := range causes {
+		fmt.Fprintf(out, "\n%d. %s\n", i+1, cause.Summary)
+		if cause.Details != "" {
+			fmt.Fprintf(out, "   %s\n", cause.Details)
+		}
+		if len(cause.Fixes) > 0 {
+			fmt.Fprintf(out, "   Suggested fixes:\n")
+			for _, fix := range cause.Fixes {
+				fmt.Fprintf(out, "   - %s\n", fix)
+			}
+		}
+	}
+}
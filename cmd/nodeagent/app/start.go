@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmscheme "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/scheme"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/nodeagent"
+	"github.com/cert-manager/cert-manager/pkg/util"
+)
+
+// NodeAgentOptions are the options of the node agent.
+type NodeAgentOptions struct {
+	APIServerHost string
+	Kubeconfig    string
+
+	NodeName     string
+	Namespace    string
+	HostRoot     string
+	AllowedPaths []string
+	ProcPath     string
+	ResyncPeriod time.Duration
+}
+
+// AddFlags adds the flags of the node agent
+func (o *NodeAgentOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.APIServerHost, "master", "", ""+
+		"Optional apiserver host address to connect to. If not specified, autoconfiguration "+
+		"will be attempted.")
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", "", ""+
+		"Paths to a kubeconfig. Only required if out-of-cluster.")
+	fs.StringVar(&o.NodeName, "node-name", os.Getenv("NODE_NAME"), ""+
+		"Name of the node that the agent runs on. Defaults to the NODE_NAME environment variable.")
+	fs.StringVar(&o.Namespace, "namespace", "kube-system", ""+
+		"Namespace of the Certificates annotated with cert-manager.io/host-path that are written to the host. "+
+		"If empty, Certificates in all namespaces are written.")
+	fs.StringVar(&o.HostRoot, "host-root", "/host", ""+
+		"Path that the root of the host filesystem is mounted at.")
+	fs.StringSliceVar(&o.AllowedPaths, "allowed-paths", []string{"/etc/kubernetes/pki"}, ""+
+		"Directories on the host that certificates may be written to.")
+	fs.StringVar(&o.ProcPath, "proc-path", "/proc", ""+
+		"Path of the procfs of the host, used to find the processes to send SIGHUP to. "+
+		"The agent must share the PID namespace of the host for processes to be reloaded.")
+	fs.DurationVar(&o.ResyncPeriod, "resync-period", 10*time.Minute, ""+
+		"Period at which all Certificates are re-written, to restore files which have been changed on the host.")
+}
+
+// Validate validates the options of the node agent
+func (o *NodeAgentOptions) Validate() error {
+	if len(o.NodeName) == 0 {
+		return errors.New("--node-name must be set")
+	}
+	if len(o.AllowedPaths) == 0 {
+		return errors.New("--allowed-paths must not be empty")
+	}
+	return nil
+}
+
+// NewCommandStartNodeAgent returns the node agent command
+func NewCommandStartNodeAgent(ctx context.Context) *cobra.Command {
+	o := &NodeAgentOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "node-agent",
+		Short: fmt.Sprintf("Node agent for cert-manager (%s) (%s)", util.AppVersion, util.AppGitCommit),
+		Long: `
+cert-manager node agent writes the Certificates annotated with
+cert-manager.io/host-path to files on the host of the node that it runs on,
+optionally sending SIGHUP to a host process once they have been updated.
+
+It allows cert-manager to manage the certificates of etcd and other static
+pods of self-hosted control planes.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return fmt.Errorf("error validating options: %s", err)
+			}
+
+			logf.V(logf.InfoLevel).InfoS("starting node agent", "version", util.AppVersion, "revision", util.AppGitCommit)
+			return o.Run(ctx)
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Run runs the node agent until the given context is cancelled.
+func (o *NodeAgentOptions) Run(ctx context.Context) error {
+	log := logf.Log.WithName("node-agent")
+	ctx = logf.NewContext(ctx, log)
+
+	restConfig, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error creating rest config: %w", err)
+	}
+	restConfig = util.RestConfigWithUserAgent(restConfig, "node-agent")
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("error creating kubernetes client: %w", err)
+	}
+	cmClient, err := cmclient.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("error creating internal group client: %w", err)
+	}
+
+	// Add cert-manager types to the default Kubernetes Scheme so Events can be
+	// logged properly.
+	cmscheme.AddToScheme(scheme.Scheme)
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(logf.WithInfof(log.V(logf.DebugLevel)).Infof)
+	eventBroadcaster.StartRecordingToSink(&clientv1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	defer eventBroadcaster.Shutdown()
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "cert-manager-node-agent", Host: o.NodeName})
+
+	agent := nodeagent.New(kubeClient, cmClient, recorder, nodeagent.Options{
+		NodeName:     o.NodeName,
+		Namespace:    o.Namespace,
+		HostRoot:     o.HostRoot,
+		AllowedPaths: o.AllowedPaths,
+		ProcPath:     o.ProcPath,
+		ResyncPeriod: o.ResyncPeriod,
+	})
+	return agent.Run(ctx, 1)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/tsa"
+	"github.com/cert-manager/cert-manager/pkg/util"
+	servertls "github.com/cert-manager/cert-manager/pkg/webhook/server/tls"
+)
+
+// TSAOptions are the options of the time-stamping authority.
+type TSAOptions struct {
+	ListenAddress  string
+	HealthzAddress string
+
+	TLSCertFile       string
+	TLSPrivateKeyFile string
+
+	Policy   string
+	Accuracy time.Duration
+}
+
+// AddFlags adds the flags of the time-stamping authority
+func (o *TSAOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ListenAddress, "listen-address", ":8318", ""+
+		"Address that time-stamp requests are served on.")
+	fs.StringVar(&o.HealthzAddress, "healthz-address", ":6080", ""+
+		"Address that the healthz endpoint is served on.")
+	fs.StringVar(&o.TLSCertFile, "tls-cert-file", "", ""+
+		"Path to the signing certificate of the time-stamping authority, followed by its chain. "+
+		"The certificate must have the 'timestamping' usage, and is re-read when it changes.")
+	fs.StringVar(&o.TLSPrivateKeyFile, "tls-private-key-file", "", ""+
+		"Path to the private key of the signing certificate. It must be an RSA or ECDSA key.")
+	fs.StringVar(&o.Policy, "policy", "", ""+
+		"OID of the TSA policy that time-stamp tokens are issued under, in dotted decimal notation.")
+	fs.DurationVar(&o.Accuracy, "accuracy", time.Second, ""+
+		"Accuracy of the time in issued time-stamp tokens. It is omitted from the tokens if zero.")
+}
+
+// Validate validates the options of the time-stamping authority
+func (o *TSAOptions) Validate() error {
+	if len(o.TLSCertFile) == 0 || len(o.TLSPrivateKeyFile) == 0 {
+		return errors.New("--tls-cert-file and --tls-private-key-file must be set")
+	}
+	if _, err := tsa.ParsePolicy(o.Policy); err != nil {
+		return fmt.Errorf("--policy: %w", err)
+	}
+	if o.Accuracy < 0 {
+		return errors.New("--accuracy must not be negative")
+	}
+	return nil
+}
+
+// NewCommandStartTSA returns the time-stamping authority command
+func NewCommandStartTSA(ctx context.Context) *cobra.Command {
+	o := &TSAOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "tsa",
+		Short: fmt.Sprintf("RFC 3161 time-stamping authority for cert-manager (%s) (%s)", util.AppVersion, util.AppGitCommit),
+		Long: `
+cert-manager tsa is an RFC 3161 time-stamping authority which signs time-stamp
+tokens using a certificate managed by cert-manager.
+
+The certificate and private key are re-read from disk as cert-manager renews
+them, so the signing key of the authority is rotated with its certificate.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return fmt.Errorf("error validating options: %s", err)
+			}
+
+			logf.V(logf.InfoLevel).InfoS("starting time-stamping authority", "version", util.AppVersion, "revision", util.AppGitCommit)
+			return o.Run(ctx)
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Run runs the time-stamping authority until the given context is cancelled.
+func (o *TSAOptions) Run(ctx context.Context) error {
+	log := logf.Log.WithName("tsa")
+	ctx = logf.NewContext(ctx, log)
+
+	policy, err := tsa.ParsePolicy(o.Policy)
+	if err != nil {
+		return err
+	}
+	source := &servertls.FileCertificateSource{
+		CertPath: o.TLSCertFile,
+		KeyPath:  o.TLSPrivateKeyFile,
+	}
+	authority := tsa.New(tsa.Options{
+		Policy:   policy,
+		Accuracy: o.Accuracy,
+		GetCertificate: func() (*tls.Certificate, error) {
+			return source.GetCertificate(nil)
+		},
+	})
+
+	healthMux := http.NewServeMux()
+	healthMux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		if !authority.Healthy() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		if err := source.Run(gctx); err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+		return nil
+	})
+	if err := serve(gctx, g, o.HealthzAddress, healthMux); err != nil {
+		return err
+	}
+	log.V(logf.InfoLevel).Info("listening for time-stamp requests", "address", o.ListenAddress)
+	if err := serve(gctx, g, o.ListenAddress, authority); err != nil {
+		return err
+	}
+	return g.Wait()
+}
+
+// serve serves the handler on the given address until the context is
+// cancelled.
+func serve(ctx context.Context, g *errgroup.Group, address string, handler http.Handler) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+	g.Go(func() error {
+		<-ctx.Done()
+		// allow a timeout for graceful shutdown
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		return server.Shutdown(shutdownCtx)
+	})
+	g.Go(func() error {
+		if err := server.Serve(listener); err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	})
+	return nil
+}
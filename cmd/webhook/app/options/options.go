@@ -32,6 +32,30 @@ import (
 type WebhookFlags struct {
 	// Path to a file containing a WebhookConfiguration resource
 	Config string
+
+	// ClusterDomain is the DNS domain of the cluster, substituted for the
+	// ${CLUSTER_DOMAIN} variable in the dnsNames and uris of Certificates.
+	ClusterDomain string
+
+	// DefaultSubjectOrganizations and DefaultSubjectCountries are the subject
+	// organizations and countries of Certificates which do not set them.
+	DefaultSubjectOrganizations []string
+	DefaultSubjectCountries     []string
+
+	// DefaultDNSZones is the DNS zone selector of the ACME DNS01 solvers of
+	// ClusterIssuers which do not have a selector.
+	DefaultDNSZones []string
+
+	// ClusterNameLabel is the label on the kube-system Namespace holding the
+	// name of the cluster, substituted for the ${CLUSTER_NAME} variable in
+	// the default values.
+	ClusterNameLabel string
+
+	// FIPSMode rejects Certificates and CertificateRequests using private
+	// key algorithms which are not approved in FIPS 140 mode.
+	FIPSMode bool
+	// FIPSDisallowP521 additionally rejects ECDSA P-521 keys in FIPS mode.
+	FIPSDisallowP521 bool
 }
 
 func NewWebhookFlags() *WebhookFlags {
@@ -40,6 +64,22 @@ func NewWebhookFlags() *WebhookFlags {
 
 func (f *WebhookFlags) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&f.Config, "config", "", "Path to a file containing a WebhookConfiguration object used to configure the webhook")
+	fs.StringVar(&f.ClusterDomain, "cluster-domain", "cluster.local", "The DNS domain of the cluster, substituted for the ${CLUSTER_DOMAIN} variable "+
+		"in the dnsNames and uris of Certificates when the CertificateSANTemplates feature gate is enabled.")
+	fs.StringSliceVar(&f.DefaultSubjectOrganizations, "default-subject-organizations", nil, "The subject organizations of Certificates which do not set "+
+		"a subject organization or a literal subject. Values may reference the ${CLUSTER_NAME}, ${CLOUD_PROVIDER} and ${REGION} cluster "+
+		"metadata variables; values referencing metadata which cannot be discovered are ignored.")
+	fs.StringSliceVar(&f.DefaultSubjectCountries, "default-subject-countries", nil, "The subject countries of Certificates which do not set "+
+		"a subject country or a literal subject. Values may reference the same cluster metadata variables as --default-subject-organizations.")
+	fs.StringSliceVar(&f.DefaultDNSZones, "default-dns-zones", nil, "The DNS zones selected by the ACME DNS01 solvers of ClusterIssuers "+
+		"which do not have a selector, e.g. ${CLUSTER_NAME}.${REGION}.example.com. Values may reference the same cluster metadata "+
+		"variables as --default-subject-organizations.")
+	fs.StringVar(&f.ClusterNameLabel, "cluster-name-label", "", "The label on the kube-system namespace holding the name of the cluster, "+
+		"substituted for the ${CLUSTER_NAME} variable in the --default-* flags.")
+	fs.BoolVar(&f.FIPSMode, "fips-mode", false, "If true, Certificates and CertificateRequests using private key algorithms which are not "+
+		"approved in FIPS 140 mode, such as Ed25519 or RSA keys smaller than 2048 bits, are rejected. FIPS mode is always enabled for binaries "+
+		"built with a FIPS 140 validated crypto module which is enabled.")
+	fs.BoolVar(&f.FIPSDisallowP521, "fips-disallow-p521", false, "If true, ECDSA P-521 keys are also rejected in FIPS mode.")
 }
 
 func ValidateWebhookFlags(f *WebhookFlags) error {
@@ -76,10 +116,16 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.WebhookConfiguration) {
 	fs.StringVar(&c.APIServerHost, "api-server-host", c.APIServerHost, ""+
 		"Optional apiserver host address to connect to. If not specified, autoconfiguration "+
 		"will be attempted.")
+	fs.Float32Var(c.KubernetesAPIQPS, "kube-api-qps", *c.KubernetesAPIQPS, "indicates the maximum queries-per-second requests to the Kubernetes apiserver")
+	fs.IntVar(c.KubernetesAPIBurst, "kube-api-burst", *c.KubernetesAPIBurst, "the maximum burst queries-per-second of requests sent to the Kubernetes apiserver")
 	fs.BoolVar(&c.EnablePprof, "enable-profiling", c.EnablePprof, ""+
 		"Enable profiling for webhook.")
 	fs.StringVar(&c.PprofAddress, "profiler-address", c.PprofAddress,
 		"Address of the Go profiler (pprof). This should never be exposed on a public interface. If this flag is not set, the profiler is not run.")
+	fs.DurationVar(&c.ShutdownDelay.Duration, "shutdown-delay", c.ShutdownDelay.Duration, ""+
+		"How long to keep serving requests after receiving a termination signal, while reporting "+
+		"not ready on the healthz endpoint, so that the replica is removed from the Service "+
+		"endpoints before it stops serving.")
 	tlsCipherPossibleValues := cliflag.TLSCipherPossibleValues()
 	fs.StringSliceVar(&c.TLSConfig.CipherSuites, "tls-cipher-suites", c.TLSConfig.CipherSuites,
 		"Comma-separated list of cipher suites for the server. "+
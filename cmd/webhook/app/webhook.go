@@ -28,11 +28,13 @@ import (
 
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
 	"github.com/cert-manager/cert-manager/cmd/webhook/app/options"
+	internalcmapiv1 "github.com/cert-manager/cert-manager/internal/apis/certmanager/v1"
 	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
 	cmwebhook "github.com/cert-manager/cert-manager/internal/webhook"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/webhook/configfile"
 )
 
@@ -98,6 +100,10 @@ func NewServerCommand(stopCh <-chan struct{}) *cobra.Command {
 				log.Error(err, "Failed to validate webhook flags")
 				os.Exit(1)
 			}
+			internalcmapiv1.ClusterDomain = webhookFlags.ClusterDomain
+			if webhookFlags.FIPSMode {
+				pki.EnableFIPSMode(!webhookFlags.FIPSDisallowP521)
+			}
 
 			if configFile := webhookFlags.Config; len(configFile) > 0 {
 				webhookConfig, err = loadConfigFile(configFile)
@@ -106,6 +106,62 @@ Create the name of the service account to use
 {{- end -}}
 {{- end -}}
 
+{{/*
+nodeagent templates
+*/}}
+
+{{- define "nodeagent.name" -}}
+{{- printf "nodeagent" -}}
+{{- end -}}
+
+{{/*
+Create a default fully qualified app name.
+We truncate at 63 chars because some Kubernetes name fields are limited to this (by the DNS naming spec).
+*/}}
+{{- define "nodeagent.fullname" -}}
+{{- $trimmedName := printf "%s" (include "cert-manager.fullname" .) | trunc 52 | trimSuffix "-" -}}
+{{- printf "%s-nodeagent" $trimmedName | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
+{{/*
+Create the name of the service account to use
+*/}}
+{{- define "nodeagent.serviceAccountName" -}}
+{{- if .Values.nodeAgent.serviceAccount.create -}}
+    {{ default (include "nodeagent.fullname" .) .Values.nodeAgent.serviceAccount.name }}
+{{- else -}}
+    {{ default "default" .Values.nodeAgent.serviceAccount.name }}
+{{- end -}}
+{{- end -}}
+
+{{/*
+tsa templates
+*/}}
+
+{{- define "tsa.name" -}}
+{{- printf "tsa" -}}
+{{- end -}}
+
+{{/*
+Create a default fully qualified app name.
+We truncate at 63 chars because some Kubernetes name fields are limited to this (by the DNS naming spec).
+*/}}
+{{- define "tsa.fullname" -}}
+{{- $trimmedName := printf "%s" (include "cert-manager.fullname" .) | trunc 52 | trimSuffix "-" -}}
+{{- printf "%s-tsa" $trimmedName | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
+{{/*
+Create the name of the service account to use
+*/}}
+{{- define "tsa.serviceAccountName" -}}
+{{- if .Values.tsa.serviceAccount.create -}}
+    {{ default (include "tsa.fullname" .) .Values.tsa.serviceAccount.name }}
+{{- else -}}
+    {{ default "default" .Values.tsa.serviceAccount.name }}
+{{- end -}}
+{{- end -}}
+
 {{/*
 startupapicheck templates
 */}}
@@ -172,3 +228,16 @@ https://github.com/helm/helm/issues/5358
 {{- define "cert-manager.namespace" -}}
     {{ .Values.namespace | default .Release.Namespace }}
 {{- end -}}
+
+{{/*
+Namespaces that cert-manager is scoped to when namespacedScope is enabled,
+as a comma separated list. Defaults to the namespace cert-manager is installed
+into.
+*/}}
+{{- define "cert-manager.scopedNamespaces" -}}
+{{- if .Values.namespacedScope.namespaces -}}
+{{ join "," .Values.namespacedScope.namespaces }}
+{{- else -}}
+{{ include "cert-manager.namespace" . }}
+{{- end -}}
+{{- end -}}
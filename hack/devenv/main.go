@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// devenv seeds the example issuers of the local development environment
+// created by `make devenv`, and waits for them to become ready.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+)
+
+const (
+	selfSignedIssuerName = "selfsigned"
+	caIssuerName         = "ca"
+	pebbleIssuerName     = "pebble"
+
+	caSecretName            = "devenv-ca"
+	pebbleAccountSecretName = "pebble-account-key"
+)
+
+type options struct {
+	kubeConfig               string
+	kubeContext              string
+	clusterResourceNamespace string
+	pebbleURL                string
+	ingressClass             string
+	timeout                  time.Duration
+}
+
+func main() {
+	logger := log.New(os.Stderr, "", 0)
+
+	var opts options
+	flag.StringVar(&opts.kubeConfig, "kubeconfig", "", "Path to the kubeconfig file. If not specified, the default loading rules are used.")
+	flag.StringVar(&opts.kubeContext, "context", "", "The kubeconfig context to use. If not specified, the current context is used.")
+	flag.StringVar(&opts.clusterResourceNamespace, "cluster-resource-namespace", "cert-manager", "The cluster resource namespace of cert-manager, in which the CA of the ca ClusterIssuer is stored.")
+	flag.StringVar(&opts.pebbleURL, "pebble-url", "https://pebble.pebble.svc.cluster.local/dir", "The directory URL of the Pebble ACME server.")
+	flag.StringVar(&opts.ingressClass, "ingress-class", "nginx", "The ingress class used to solve HTTP-01 challenges.")
+	flag.DurationVar(&opts.timeout, "timeout", 2*time.Minute, "How long to wait for the issuers to become ready.")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		logger.Fatalf("error: %v", err)
+	}
+
+	logger.Printf("The following ClusterIssuers are ready:")
+	logger.Printf("  %-12s issues self-signed certificates", selfSignedIssuerName)
+	logger.Printf("  %-12s issues certificates signed by the CA stored in %s/%s", caIssuerName, opts.clusterResourceNamespace, caSecretName)
+	logger.Printf("  %-12s issues certificates from Pebble, solving HTTP-01 challenges for any domain", pebbleIssuerName)
+}
+
+func run(opts options) error {
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: opts.kubeConfig},
+		&clientcmd.ConfigOverrides{CurrentContext: opts.kubeContext},
+	).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	cl, err := cmclient.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to build cert-manager client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	if err := ensureClusterIssuer(ctx, cl, selfSignedIssuerName, cmapi.IssuerConfig{
+		SelfSigned: &cmapi.SelfSignedIssuer{},
+	}); err != nil {
+		return err
+	}
+
+	if err := ensureCACertificate(ctx, cl, opts.clusterResourceNamespace); err != nil {
+		return err
+	}
+
+	if err := ensureClusterIssuer(ctx, cl, caIssuerName, cmapi.IssuerConfig{
+		CA: &cmapi.CAIssuer{SecretName: caSecretName},
+	}); err != nil {
+		return err
+	}
+
+	ingressClass := opts.ingressClass
+	if err := ensureClusterIssuer(ctx, cl, pebbleIssuerName, cmapi.IssuerConfig{
+		ACME: &cmacme.ACMEIssuer{
+			Server: opts.pebbleURL,
+			Email:  "devenv@example.com",
+			// Pebble serves its API with a certificate signed by a test CA
+			// which cert-manager doesn't trust.
+			SkipTLSVerify: true,
+			PrivateKey:    cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: pebbleAccountSecretName}},
+			Solvers: []cmacme.ACMEChallengeSolver{{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{IngressClassName: &ingressClass},
+				},
+			}},
+		},
+	}); err != nil {
+		return err
+	}
+
+	for _, name := range []string{selfSignedIssuerName, caIssuerName, pebbleIssuerName} {
+		if err := waitForClusterIssuerReady(ctx, cl, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureClusterIssuer creates the named ClusterIssuer with the given config,
+// or updates its config if it already exists.
+func ensureClusterIssuer(ctx context.Context, cl cmclient.Interface, name string, config cmapi.IssuerConfig) error {
+	iss, err := cl.CertmanagerV1().ClusterIssuers().Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		iss = &cmapi.ClusterIssuer{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       cmapi.IssuerSpec{IssuerConfig: config},
+		}
+		_, err = cl.CertmanagerV1().ClusterIssuers().Create(ctx, iss, metav1.CreateOptions{})
+	} else if err == nil {
+		iss.Spec.IssuerConfig = config
+		_, err = cl.CertmanagerV1().ClusterIssuers().Update(ctx, iss, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create or update ClusterIssuer %q: %w", name, err)
+	}
+	return nil
+}
+
+// ensureCACertificate creates the self-signed CA Certificate used by the ca
+// ClusterIssuer, unless it already exists.
+func ensureCACertificate(ctx context.Context, cl cmclient.Interface, namespace string) error {
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: caSecretName, Namespace: namespace},
+		Spec: cmapi.CertificateSpec{
+			IsCA:       true,
+			CommonName: "cert-manager devenv CA",
+			SecretName: caSecretName,
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256},
+			IssuerRef: cmmeta.ObjectReference{
+				Name: selfSignedIssuerName,
+				Kind: cmapi.ClusterIssuerKind,
+			},
+		},
+	}
+	_, err := cl.CertmanagerV1().Certificates(namespace).Create(ctx, crt, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create CA Certificate: %w", err)
+	}
+	return nil
+}
+
+// waitForClusterIssuerReady waits until the named ClusterIssuer has a Ready
+// condition with status True.
+func waitForClusterIssuerReady(ctx context.Context, cl cmclient.Interface, name string) error {
+	var iss *cmapi.ClusterIssuer
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		var err error
+		iss, err = cl.CertmanagerV1().ClusterIssuers().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return apiutil.IssuerHasCondition(iss, cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}), nil
+	})
+	if err != nil && iss != nil {
+		return fmt.Errorf("ClusterIssuer %q did not become ready: %w (conditions: %v)", name, err, iss.Status.Conditions)
+	}
+	if err != nil {
+		return fmt.Errorf("ClusterIssuer %q did not become ready: %w", name, err)
+	}
+	return nil
+}
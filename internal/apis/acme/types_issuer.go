@@ -19,6 +19,7 @@ package acme
 import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
@@ -49,6 +50,14 @@ type ACMEIssuer struct {
 	// "DST Root CA X3" or "ISRG Root X1" for the newer Let's Encrypt root CA.
 	PreferredChain string
 
+	// Profile is the name of the certificate profile to request when creating
+	// new orders with ACME servers implementing the ACME profiles extension,
+	// for example "shortlived".
+	// It may be overridden for individual Certificates using the
+	// `acme.cert-manager.io/profile` annotation.
+	// If not set, the ACME server's default profile will be used.
+	Profile string
+
 	// Enables or disables validation of the ACME server TLS certificate.
 	// If true, requests to the ACME server will not have their TLS certificate
 	// validated (i.e. insecure connections will be allowed).
@@ -144,6 +153,49 @@ type ACMEChallengeSolver struct {
 	// Configures cert-manager to attempt to complete authorizations by
 	// performing the DNS01 challenge flow.
 	DNS01 *ACMEChallengeSolverDNS01
+
+	// Configures cert-manager to attempt to complete authorizations by
+	// performing the TLS-ALPN-01 challenge flow, which only requires port 443
+	// of the domain to be reachable.
+	// It is not possible to obtain certificates for wildcard domain names
+	// (e.g. `*.example.com`) using the TLS-ALPN-01 challenge mechanism.
+	TLSALPN01 *ACMEChallengeSolverTLSALPN01
+
+	// SelfCheckIPFamily forces the IP family that is used when performing
+	// the self-check for challenges solved using this solver.
+	SelfCheckIPFamily corev1.IPFamily
+
+	// SelfCheck configures how often the self check of challenges solved
+	// using this solver is retried while it fails, e.g. while waiting for a
+	// DNS record to propagate.
+	// If not set, the self check is retried at the interval configured on
+	// the controller with --dns01-check-retry-period.
+	SelfCheck *ACMEChallengeSolverSelfCheck
+}
+
+// ACMEChallengeSolverSelfCheck configures an exponential backoff between the
+// retries of a failing challenge self check.
+type ACMEChallengeSolverSelfCheck struct {
+	// InitialInterval is the time to wait before retrying the self check
+	// for the first time.
+	// Defaults to the interval configured on the controller with
+	// --dns01-check-retry-period, 10s by default.
+	InitialInterval *metav1.Duration
+
+	// Multiplier is the factor by which the interval between retries grows
+	// after each failed self check. Must be at least 1.
+	// Defaults to 1, i.e. the self check is retried at a constant interval.
+	Multiplier *int32
+
+	// MaxInterval is the maximum time to wait between two retries.
+	// If not set, the interval is not capped.
+	MaxInterval *metav1.Duration
+
+	// MaxDuration is how long to retry the self check for. Once the self
+	// check has failed for longer than this, cert-manager stops waiting for
+	// it and asks the ACME server to validate the challenge anyway.
+	// If not set, the self check is retried until it succeeds.
+	MaxDuration *metav1.Duration
 }
 
 // CertificateDomainSelector selects certificates using a label selector, and
@@ -204,9 +256,15 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 
 	// The ingress class to use when creating Ingress resources to solve ACME
 	// challenges that use this challenge solver.
-	// Only one of 'class' or 'name' may be specified.
+	// Only one of 'class', 'name' or 'ingressClassName' may be specified.
 	Class *string
 
+	// The name of the IngressClass to set in the `spec.ingressClassName`
+	// field of Ingress resources created to solve ACME challenges that use
+	// this challenge solver.
+	// Only one of 'class', 'name' or 'ingressClassName' may be specified.
+	IngressClassName *string
+
 	// The name of the ingress resource that should have ACME challenge solving
 	// routes inserted into it in order to solve HTTP01 challenges.
 	// This is typically used in conjunction with ingress controllers like
@@ -282,6 +340,14 @@ type ACMEChallengeSolverHTTP01IngressPodSpec struct {
 	// If specified, the pod's service account
 	// +optional
 	ServiceAccountName string
+
+	// If specified, the images of the solver pod to use on Linux nodes of the
+	// given architectures, keyed by the `kubernetes.io/arch` label of the nodes
+	// (e.g. `arm64`). An image is used if the `kubernetes.io/arch` node selector
+	// selects its architecture. Otherwise, the solver pod is only scheduled on
+	// nodes of the architectures provided by the default solver image.
+	// +optional
+	ArchitectureImages map[string]string
 }
 
 type ACMEChallengeSolverHTTP01IngressTemplate struct {
@@ -300,6 +366,40 @@ type ACMEChallengeSolverHTTP01IngressObjectMeta struct {
 	Labels map[string]string
 }
 
+// The ACMEChallengeSolverTLSALPN01 solver will solve TLS-ALPN-01 challenges by
+// provisioning a challenge solver pod that serves the challenge certificate
+// on port 443 of the domain being validated.
+type ACMEChallengeSolverTLSALPN01 struct {
+	// Mode selects how the challenge solver pod is exposed on port 443.
+	// `HostPort` binds port 443 on the node that the solver pod is scheduled
+	// on, and `Service` exposes the solver pod using a Service.
+	// If unset, defaults to `Service`.
+	Mode ACMEChallengeSolverTLSALPN01Mode
+
+	// Optional service type for the Kubernetes solver service when using the
+	// `Service` mode. Supported values are LoadBalancer or ClusterIP.
+	// If unset, defaults to LoadBalancer.
+	ServiceType corev1.ServiceType
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for TLS-ALPN-01 challenges.
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate
+}
+
+// ACMEChallengeSolverTLSALPN01Mode is the way that a TLS-ALPN-01 challenge
+// solver pod is exposed.
+type ACMEChallengeSolverTLSALPN01Mode string
+
+const (
+	// ACMEChallengeSolverTLSALPN01ModeHostPort binds port 443 of the node
+	// that the solver pod is scheduled on.
+	ACMEChallengeSolverTLSALPN01ModeHostPort ACMEChallengeSolverTLSALPN01Mode = "HostPort"
+
+	// ACMEChallengeSolverTLSALPN01ModeService exposes the solver pod on port
+	// 443 using a Service.
+	ACMEChallengeSolverTLSALPN01ModeService ACMEChallengeSolverTLSALPN01Mode = "Service"
+)
+
 // Used to configure a DNS01 challenge provider to be used when solving DNS01
 // challenges.
 // Only one DNS provider may be configured per solver.
@@ -326,6 +426,12 @@ type ACMEChallengeSolverDNS01 struct {
 	// Use the DigitalOcean DNS API to manage DNS01 challenge records.
 	DigitalOcean *ACMEIssuerDNS01ProviderDigitalOcean
 
+	// Use the Linode DNS API to manage DNS01 challenge records.
+	Linode *ACMEIssuerDNS01ProviderLinode
+
+	// Use the Vultr DNS API to manage DNS01 challenge records.
+	Vultr *ACMEIssuerDNS01ProviderVultr
+
 	// Use the 'ACME DNS' (https://github.com/joohoi/acme-dns) API to manage
 	// DNS01 challenge records.
 	AcmeDNS *ACMEIssuerDNS01ProviderAcmeDNS
@@ -396,6 +502,18 @@ type ACMEIssuerDNS01ProviderDigitalOcean struct {
 	Token cmmeta.SecretKeySelector
 }
 
+// ACMEIssuerDNS01ProviderLinode is a structure containing the DNS
+// configuration for Linode Domains
+type ACMEIssuerDNS01ProviderLinode struct {
+	Token cmmeta.SecretKeySelector
+}
+
+// ACMEIssuerDNS01ProviderVultr is a structure containing the DNS
+// configuration for Vultr DNS
+type ACMEIssuerDNS01ProviderVultr struct {
+	APIKey cmmeta.SecretKeySelector
+}
+
 // ACMEIssuerDNS01ProviderRoute53 is a structure containing the Route 53
 // configuration for AWS
 type ACMEIssuerDNS01ProviderRoute53 struct {
@@ -475,6 +593,10 @@ type ACMEIssuerDNS01ProviderAcmeDNS struct {
 	Host string
 
 	AccountSecret cmmeta.SecretKeySelector
+
+	// If true, an acme-dns account is registered automatically for each
+	// domain that the account Secret has no credentials for.
+	AutoRegister bool
 }
 
 // ACMEIssuerDNS01ProviderRFC2136 is a structure containing the
@@ -535,4 +657,12 @@ type ACMEIssuerStatus struct {
 	// ACME account, in order to track changes made to registered account
 	// associated with the  Issuer
 	LastRegisteredEmail string
+
+	// Contacts are the contact URIs, such as mailto URIs, currently
+	// registered with the ACME account.
+	Contacts []string
+
+	// AccountStatus is the status of the ACME account as reported by the ACME
+	// server, for example 'valid', 'deactivated' or 'revoked'.
+	AccountStatus string
 }
@@ -74,6 +74,10 @@ type OrderSpec struct {
 	// Duration is the duration for the not after date for the requested certificate.
 	// this is set on order creation as pe the ACME spec.
 	Duration *metav1.Duration
+
+	// Profile is the name of the certificate profile requested when creating
+	// the order, for ACME servers implementing the ACME profiles extension.
+	Profile string
 }
 
 type OrderStatus struct {
@@ -118,7 +122,8 @@ type ACMEAuthorization struct {
 	// URL is the URL of the Authorization that must be completed
 	URL string
 
-	// Identifier is the DNS name to be validated as part of this authorization
+	// Identifier is the DNS name or IP address to be validated as part of this
+	// authorization
 	Identifier string
 
 	// Wildcard will be true if this authorization is for a wildcard DNS name.
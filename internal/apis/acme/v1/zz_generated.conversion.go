@@ -164,6 +164,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverSelfCheck)(nil), (*acme.ACMEChallengeSolverSelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck(a.(*v1.ACMEChallengeSolverSelfCheck), b.(*acme.ACMEChallengeSolverSelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverSelfCheck)(nil), (*v1.ACMEChallengeSolverSelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverSelfCheck_To_v1_ACMEChallengeSolverSelfCheck(a.(*acme.ACMEChallengeSolverSelfCheck), b.(*v1.ACMEChallengeSolverSelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverTLSALPN01)(nil), (*acme.ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(a.(*v1.ACMEChallengeSolverTLSALPN01), b.(*acme.ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01)(nil), (*v1.ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01(a.(*acme.ACMEChallengeSolverTLSALPN01), b.(*v1.ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEExternalAccountBinding)(nil), (*acme.ACMEExternalAccountBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(a.(*v1.ACMEExternalAccountBinding), b.(*acme.ACMEExternalAccountBinding), scope)
 	}); err != nil {
@@ -234,6 +254,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderLinode)(nil), (*acme.ACMEIssuerDNS01ProviderLinode)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(a.(*v1.ACMEIssuerDNS01ProviderLinode), b.(*acme.ACMEIssuerDNS01ProviderLinode), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderLinode)(nil), (*v1.ACMEIssuerDNS01ProviderLinode)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(a.(*acme.ACMEIssuerDNS01ProviderLinode), b.(*v1.ACMEIssuerDNS01ProviderLinode), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*v1.ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -254,6 +284,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderVultr)(nil), (*acme.ACMEIssuerDNS01ProviderVultr)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(a.(*v1.ACMEIssuerDNS01ProviderVultr), b.(*acme.ACMEIssuerDNS01ProviderVultr), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderVultr)(nil), (*v1.ACMEIssuerDNS01ProviderVultr)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderVultr_To_v1_ACMEIssuerDNS01ProviderVultr(a.(*acme.ACMEIssuerDNS01ProviderVultr), b.(*v1.ACMEIssuerDNS01ProviderVultr), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderWebhook)(nil), (*acme.ACMEIssuerDNS01ProviderWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderWebhook_To_acme_ACMEIssuerDNS01ProviderWebhook(a.(*v1.ACMEIssuerDNS01ProviderWebhook), b.(*acme.ACMEIssuerDNS01ProviderWebhook), scope)
 	}); err != nil {
@@ -451,6 +491,9 @@ func autoConvert_v1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *v1.ACMEC
 	} else {
 		out.DNS01 = nil
 	}
+	out.TLSALPN01 = (*acme.ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
+	out.SelfCheckIPFamily = corev1.IPFamily(in.SelfCheckIPFamily)
+	out.SelfCheck = (*acme.ACMEChallengeSolverSelfCheck)(unsafe.Pointer(in.SelfCheck))
 	return nil
 }
 
@@ -471,6 +514,9 @@ func autoConvert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(in *acme.ACM
 	} else {
 		out.DNS01 = nil
 	}
+	out.TLSALPN01 = (*v1.ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
+	out.SelfCheckIPFamily = corev1.IPFamily(in.SelfCheckIPFamily)
+	out.SelfCheck = (*v1.ACMEChallengeSolverSelfCheck)(unsafe.Pointer(in.SelfCheck))
 	return nil
 }
 
@@ -535,6 +581,24 @@ func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Linode != nil {
+		in, out := &in.Linode, &out.Linode
+		*out = new(acme.ACMEIssuerDNS01ProviderLinode)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Linode = nil
+	}
+	if in.Vultr != nil {
+		in, out := &in.Vultr, &out.Vultr
+		*out = new(acme.ACMEIssuerDNS01ProviderVultr)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vultr = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(acme.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -618,6 +682,24 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Linode != nil {
+		in, out := &in.Linode, &out.Linode
+		*out = new(v1.ACMEIssuerDNS01ProviderLinode)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Linode = nil
+	}
+	if in.Vultr != nil {
+		in, out := &in.Vultr, &out.Vultr
+		*out = new(v1.ACMEIssuerDNS01ProviderVultr)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderVultr_To_v1_ACMEIssuerDNS01ProviderVultr(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vultr = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(v1.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -694,6 +776,7 @@ func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeS
 func autoConvert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *v1.ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -708,6 +791,7 @@ func Convert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP
 func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1_ACMEChallengeSolverHTTP01Ingress(in *acme.ACMEChallengeSolverHTTP01Ingress, out *v1.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*v1.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -769,6 +853,7 @@ func autoConvert_v1_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChalleng
 	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
+	out.ArchitectureImages = *(*map[string]string)(unsafe.Pointer(&in.ArchitectureImages))
 	return nil
 }
 
@@ -783,6 +868,7 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1_ACMEChalleng
 	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
+	out.ArchitectureImages = *(*map[string]string)(unsafe.Pointer(&in.ArchitectureImages))
 	return nil
 }
 
@@ -845,6 +931,56 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1_ACMEChallengeSo
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1_ACMEChallengeSolverHTTP01IngressTemplate(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck(in *v1.ACMEChallengeSolverSelfCheck, out *acme.ACMEChallengeSolverSelfCheck, s conversion.Scope) error {
+	out.InitialInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.Multiplier = (*int32)(unsafe.Pointer(in.Multiplier))
+	out.MaxInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.MaxDuration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxDuration))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck(in *v1.ACMEChallengeSolverSelfCheck, out *acme.ACMEChallengeSolverSelfCheck, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverSelfCheck_To_v1_ACMEChallengeSolverSelfCheck(in *acme.ACMEChallengeSolverSelfCheck, out *v1.ACMEChallengeSolverSelfCheck, s conversion.Scope) error {
+	out.InitialInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.Multiplier = (*int32)(unsafe.Pointer(in.Multiplier))
+	out.MaxInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.MaxDuration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxDuration))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverSelfCheck_To_v1_ACMEChallengeSolverSelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverSelfCheck_To_v1_ACMEChallengeSolverSelfCheck(in *acme.ACMEChallengeSolverSelfCheck, out *v1.ACMEChallengeSolverSelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverSelfCheck_To_v1_ACMEChallengeSolverSelfCheck(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *v1.ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.Mode = acme.ACMEChallengeSolverTLSALPN01Mode(in.Mode)
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *v1.ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *v1.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.Mode = v1.ACMEChallengeSolverTLSALPN01Mode(in.Mode)
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *v1.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
 func autoConvert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *v1.ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
@@ -877,6 +1013,7 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.Profile = in.Profile
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
@@ -910,6 +1047,7 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.Profile = in.Profile
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
@@ -944,6 +1082,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderAcmeDNS_To_acme_ACMEIssuerDNS01Provid
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
+	out.AutoRegister = in.AutoRegister
 	return nil
 }
 
@@ -957,6 +1096,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAcmeDNS_To_v1_ACMEIssuerDNS01Provid
 	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
+	out.AutoRegister = in.AutoRegister
 	return nil
 }
 
@@ -1173,6 +1313,30 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01Provi
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(in *v1.ACMEIssuerDNS01ProviderLinode, out *acme.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(in *v1.ACMEIssuerDNS01ProviderLinode, out *acme.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(in *acme.ACMEIssuerDNS01ProviderLinode, out *v1.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(in *acme.ACMEIssuerDNS01ProviderLinode, out *v1.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *v1.ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
@@ -1253,6 +1417,30 @@ func Convert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01ProviderRo
 	return autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01ProviderRoute53(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(in *v1.ACMEIssuerDNS01ProviderVultr, out *acme.ACMEIssuerDNS01ProviderVultr, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APIKey, &out.APIKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(in *v1.ACMEIssuerDNS01ProviderVultr, out *acme.ACMEIssuerDNS01ProviderVultr, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderVultr_To_v1_ACMEIssuerDNS01ProviderVultr(in *acme.ACMEIssuerDNS01ProviderVultr, out *v1.ACMEIssuerDNS01ProviderVultr, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APIKey, &out.APIKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderVultr_To_v1_ACMEIssuerDNS01ProviderVultr is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderVultr_To_v1_ACMEIssuerDNS01ProviderVultr(in *acme.ACMEIssuerDNS01ProviderVultr, out *v1.ACMEIssuerDNS01ProviderVultr, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderVultr_To_v1_ACMEIssuerDNS01ProviderVultr(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderWebhook_To_acme_ACMEIssuerDNS01ProviderWebhook(in *v1.ACMEIssuerDNS01ProviderWebhook, out *acme.ACMEIssuerDNS01ProviderWebhook, s conversion.Scope) error {
 	out.GroupName = in.GroupName
 	out.SolverName = in.SolverName
@@ -1280,6 +1468,8 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1_ACMEIssuerDNS01ProviderWe
 func autoConvert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.Contacts = *(*[]string)(unsafe.Pointer(&in.Contacts))
+	out.AccountStatus = in.AccountStatus
 	return nil
 }
 
@@ -1291,6 +1481,8 @@ func Convert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatu
 func autoConvert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *v1.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.Contacts = *(*[]string)(unsafe.Pointer(&in.Contacts))
+	out.AccountStatus = in.AccountStatus
 	return nil
 }
 
@@ -1572,6 +1764,7 @@ func autoConvert_v1_OrderSpec_To_acme_OrderSpec(in *v1.OrderSpec, out *acme.Orde
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Profile = in.Profile
 	return nil
 }
 
@@ -1589,6 +1782,7 @@ func autoConvert_acme_OrderSpec_To_v1_OrderSpec(in *acme.OrderSpec, out *v1.Orde
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Profile = in.Profile
 	return nil
 }
 
@@ -19,6 +19,7 @@ package v1alpha2
 import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -54,6 +55,15 @@ type ACMEIssuer struct {
 	// +kubebuilder:validation:MaxLength=64
 	PreferredChain string `json:"preferredChain"`
 
+	// Profile is the name of the certificate profile to request when creating
+	// new orders with ACME servers implementing the ACME profiles extension,
+	// for example "shortlived".
+	// It may be overridden for individual Certificates using the
+	// `acme.cert-manager.io/profile` annotation.
+	// If not set, the ACME server's default profile will be used.
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
 	// Enables or disables validation of the ACME server TLS certificate.
 	// If true, requests to the ACME server will not have their TLS certificate
 	// validated (i.e. insecure connections will be allowed).
@@ -158,6 +168,59 @@ type ACMEChallengeSolver struct {
 	// performing the DNS01 challenge flow.
 	// +optional
 	DNS01 *ACMEChallengeSolverDNS01 `json:"dns01,omitempty"`
+
+	// Configures cert-manager to attempt to complete authorizations by
+	// performing the TLS-ALPN-01 challenge flow, which only requires port 443
+	// of the domain to be reachable.
+	// It is not possible to obtain certificates for wildcard domain names
+	// (e.g. `*.example.com`) using the TLS-ALPN-01 challenge mechanism.
+	// +optional
+	TLSALPN01 *ACMEChallengeSolverTLSALPN01 `json:"tlsALPN01,omitempty"`
+
+	// SelfCheckIPFamily forces the IP family that is used when performing
+	// the self-check for challenges solved using this solver, e.g. to check
+	// that a challenge can be reached over IPv6 in a dual-stack cluster.
+	// One of `IPv4` or `IPv6`. If not set, addresses of the controller's
+	// preferred IP family are tried first before falling back to the other.
+	// +optional
+	SelfCheckIPFamily corev1.IPFamily `json:"selfCheckIPFamily,omitempty"`
+
+	// SelfCheck configures how often the self check of challenges solved
+	// using this solver is retried while it fails, e.g. while waiting for a
+	// DNS record to propagate.
+	// If not set, the self check is retried at the interval configured on
+	// the controller with --dns01-check-retry-period.
+	// +optional
+	SelfCheck *ACMEChallengeSolverSelfCheck `json:"selfCheck,omitempty"`
+}
+
+// ACMEChallengeSolverSelfCheck configures an exponential backoff between the
+// retries of a failing challenge self check.
+type ACMEChallengeSolverSelfCheck struct {
+	// InitialInterval is the time to wait before retrying the self check
+	// for the first time.
+	// Defaults to the interval configured on the controller with
+	// --dns01-check-retry-period, 10s by default.
+	// +optional
+	InitialInterval *metav1.Duration `json:"initialInterval,omitempty"`
+
+	// Multiplier is the factor by which the interval between retries grows
+	// after each failed self check. Must be at least 1.
+	// Defaults to 1, i.e. the self check is retried at a constant interval.
+	// +optional
+	Multiplier *int32 `json:"multiplier,omitempty"`
+
+	// MaxInterval is the maximum time to wait between two retries.
+	// If not set, the interval is not capped.
+	// +optional
+	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
+
+	// MaxDuration is how long to retry the self check for. Once the self
+	// check has failed for longer than this, cert-manager stops waiting for
+	// it and asks the ACME server to validate the challenge anyway.
+	// If not set, the self check is retried until it succeeds.
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
 }
 
 // CertificateDomainSelector selects certificates using a label selector, and
@@ -223,10 +286,18 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 
 	// The ingress class to use when creating Ingress resources to solve ACME
 	// challenges that use this challenge solver.
-	// Only one of 'class' or 'name' may be specified.
+	// Only one of 'class', 'name' or 'ingressClassName' may be specified.
 	// +optional
 	Class *string `json:"class,omitempty"`
 
+	// The name of the IngressClass to set in the `spec.ingressClassName`
+	// field of Ingress resources created to solve ACME challenges that use
+	// this challenge solver. This is the recommended way of selecting an
+	// ingress controller when using class-based ingress controllers.
+	// Only one of 'class', 'name' or 'ingressClassName' may be specified.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
 	// The name of the ingress resource that should have ACME challenge solving
 	// routes inserted into it in order to solve HTTP01 challenges.
 	// This is typically used in conjunction with ingress controllers like
@@ -312,6 +383,14 @@ type ACMEChallengeSolverHTTP01IngressPodSpec struct {
 	// If specified, the pod's service account
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// If specified, the images of the solver pod to use on Linux nodes of the
+	// given architectures, keyed by the `kubernetes.io/arch` label of the nodes
+	// (e.g. `arm64`). An image is used if the `kubernetes.io/arch` node selector
+	// selects its architecture. Otherwise, the solver pod is only scheduled on
+	// nodes of the architectures provided by the default solver image.
+	// +optional
+	ArchitectureImages map[string]string `json:"architectureImages,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01IngressTemplate struct {
@@ -333,6 +412,44 @@ type ACMEChallengeSolverHTTP01IngressObjectMeta struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// The ACMEChallengeSolverTLSALPN01 solver will solve TLS-ALPN-01 challenges by
+// provisioning a challenge solver pod that serves the challenge certificate
+// on port 443 of the domain being validated.
+type ACMEChallengeSolverTLSALPN01 struct {
+	// Mode selects how the challenge solver pod is exposed on port 443.
+	// `HostPort` binds port 443 on the node that the solver pod is scheduled
+	// on, and `Service` exposes the solver pod using a Service.
+	// If unset, defaults to `Service`.
+	// +optional
+	Mode ACMEChallengeSolverTLSALPN01Mode `json:"mode,omitempty"`
+
+	// Optional service type for the Kubernetes solver service when using the
+	// `Service` mode. Supported values are LoadBalancer or ClusterIP.
+	// If unset, defaults to LoadBalancer.
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for TLS-ALPN-01 challenges.
+	// +optional
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate,omitempty"`
+}
+
+// ACMEChallengeSolverTLSALPN01Mode is the way that a TLS-ALPN-01 challenge
+// solver pod is exposed.
+// +kubebuilder:validation:Enum=HostPort;Service
+type ACMEChallengeSolverTLSALPN01Mode string
+
+const (
+	// ACMEChallengeSolverTLSALPN01ModeHostPort binds port 443 of the node
+	// that the solver pod is scheduled on.
+	ACMEChallengeSolverTLSALPN01ModeHostPort ACMEChallengeSolverTLSALPN01Mode = "HostPort"
+
+	// ACMEChallengeSolverTLSALPN01ModeService exposes the solver pod on port
+	// 443 using a Service.
+	ACMEChallengeSolverTLSALPN01ModeService ACMEChallengeSolverTLSALPN01Mode = "Service"
+)
+
 // Used to configure a DNS01 challenge provider to be used when solving DNS01
 // challenges.
 // Only one DNS provider may be configured per solver.
@@ -366,6 +483,14 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	DigitalOcean *ACMEIssuerDNS01ProviderDigitalOcean `json:"digitalocean,omitempty"`
 
+	// Use the Linode DNS API to manage DNS01 challenge records.
+	// +optional
+	Linode *ACMEIssuerDNS01ProviderLinode `json:"linode,omitempty"`
+
+	// Use the Vultr DNS API to manage DNS01 challenge records.
+	// +optional
+	Vultr *ACMEIssuerDNS01ProviderVultr `json:"vultr,omitempty"`
+
 	// Use the 'ACME DNS' (https://github.com/joohoi/acme-dns) API to manage
 	// DNS01 challenge records.
 	// +optional
@@ -449,6 +574,22 @@ type ACMEIssuerDNS01ProviderDigitalOcean struct {
 	Token cmmeta.SecretKeySelector `json:"tokenSecretRef"`
 }
 
+// ACMEIssuerDNS01ProviderLinode is a structure containing the DNS
+// configuration for Linode Domains
+type ACMEIssuerDNS01ProviderLinode struct {
+	// A reference to a specific 'key' within a Secret resource, containing
+	// a Linode personal access token with the 'domains:read_write' scope.
+	Token cmmeta.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// ACMEIssuerDNS01ProviderVultr is a structure containing the DNS
+// configuration for Vultr DNS
+type ACMEIssuerDNS01ProviderVultr struct {
+	// A reference to a specific 'key' within a Secret resource, containing
+	// a Vultr API key.
+	APIKey cmmeta.SecretKeySelector `json:"apiKeySecretRef"`
+}
+
 // ACMEIssuerDNS01ProviderRoute53 is a structure containing the Route 53
 // configuration for AWS
 type ACMEIssuerDNS01ProviderRoute53 struct {
@@ -540,6 +681,15 @@ type ACMEIssuerDNS01ProviderAcmeDNS struct {
 	Host string `json:"host"`
 
 	AccountSecret cmmeta.SecretKeySelector `json:"accountSecretRef"`
+
+	// If true, an acme-dns account is registered automatically for each
+	// domain that the account Secret has no credentials for, and its
+	// credentials are added to the account Secret, which is created if it
+	// doesn't exist. A CNAME record for `_acme-challenge.<domain>` pointing to
+	// the `fulldomain` of the registered account must still be created before
+	// the challenge can succeed.
+	// +optional
+	AutoRegister bool `json:"autoRegister,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderRFC2136 is a structure containing the
@@ -606,4 +756,14 @@ type ACMEIssuerStatus struct {
 	// associated with the  Issuer
 	// +optional
 	LastRegisteredEmail string `json:"lastRegisteredEmail,omitempty"`
+
+	// Contacts are the contact URIs, such as mailto URIs, currently
+	// registered with the ACME account.
+	// +optional
+	Contacts []string `json:"contacts,omitempty"`
+
+	// AccountStatus is the status of the ACME account as reported by the ACME
+	// server, for example 'valid', 'deactivated' or 'revoked'.
+	// +optional
+	AccountStatus string `json:"accountStatus,omitempty"`
 }
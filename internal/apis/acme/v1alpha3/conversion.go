@@ -34,6 +34,8 @@ func Convert_v1alpha3_ChallengeSpec_To_acme_ChallengeSpec(in *ChallengeSpec, out
 		out.Type = acme.ACMEChallengeTypeHTTP01
 	case ACMEChallengeTypeDNS01:
 		out.Type = acme.ACMEChallengeTypeDNS01
+	case ACMEChallengeTypeTLSALPN01:
+		out.Type = acme.ACMEChallengeTypeTLSALPN01
 	default:
 		// this case should never be hit due to validation
 		out.Type = acme.ACMEChallengeType(in.Type)
@@ -54,6 +56,8 @@ func Convert_acme_ChallengeSpec_To_v1alpha3_ChallengeSpec(in *acme.ChallengeSpec
 		out.Type = ACMEChallengeTypeHTTP01
 	case acme.ACMEChallengeTypeDNS01:
 		out.Type = ACMEChallengeTypeDNS01
+	case acme.ACMEChallengeTypeTLSALPN01:
+		out.Type = ACMEChallengeTypeTLSALPN01
 	default:
 		// this case should never be hit due to validation
 		out.Type = ACMEChallengeType(in.Type)
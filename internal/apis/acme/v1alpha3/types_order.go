@@ -80,6 +80,11 @@ type OrderSpec struct {
 	// this is set on order creation as pe the ACME spec.
 	// +optional
 	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Profile is the name of the certificate profile requested when creating
+	// the order, for ACME servers implementing the ACME profiles extension.
+	// +optional
+	Profile string `json:"profile,omitempty"`
 }
 
 type OrderStatus struct {
@@ -131,7 +136,8 @@ type ACMEAuthorization struct {
 	// URL is the URL of the Authorization that must be completed
 	URL string `json:"url"`
 
-	// Identifier is the DNS name to be validated as part of this authorization
+	// Identifier is the DNS name or IP address to be validated as part of this
+	// authorization
 	// +optional
 	Identifier string `json:"identifier,omitempty"`
 
@@ -72,7 +72,7 @@ type ChallengeSpec struct {
 	Wildcard bool `json:"wildcard"`
 
 	// The type of ACME challenge this resource represents.
-	// One of "HTTP-01" or "DNS-01".
+	// One of "HTTP-01", "DNS-01" or "TLS-ALPN-01".
 	Type ACMEChallengeType `json:"type"`
 
 	// The ACME challenge token for this challenge.
@@ -86,6 +86,8 @@ type ChallengeSpec struct {
 	// For DNS01 challenges, this is the base64 encoded SHA256 sum of the
 	// `<private key JWK thumbprint>.<key from acme server for challenge>`
 	// text that must be set as the TXT record content.
+	// For TLS-ALPN-01 challenges, this is the same value as for HTTP01
+	// challenges, whose SHA256 sum must be served in the challenge certificate.
 	Key string `json:"key"`
 
 	// Contains the domain solving configuration that should be used to
@@ -100,8 +102,8 @@ type ChallengeSpec struct {
 	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
 }
 
-// The type of ACME challenge. Only HTTP-01 and DNS-01 are supported.
-// +kubebuilder:validation:Enum=HTTP-01;DNS-01
+// The type of ACME challenge. Only HTTP-01, DNS-01 and TLS-ALPN-01 are supported.
+// +kubebuilder:validation:Enum=HTTP-01;DNS-01;TLS-ALPN-01
 type ACMEChallengeType string
 
 const (
@@ -112,6 +114,10 @@ const (
 	// ACMEChallengeTypeDNS01 denotes a Challenge is of type dns-01
 	// More info: https://letsencrypt.org/docs/challenge-types/#dns-01-challenge
 	ACMEChallengeTypeDNS01 ACMEChallengeType = "DNS-01"
+
+	// ACMEChallengeTypeTLSALPN01 denotes a Challenge is of type tls-alpn-01
+	// More info: https://letsencrypt.org/docs/challenge-types/#tls-alpn-01
+	ACMEChallengeTypeTLSALPN01 ACMEChallengeType = "TLS-ALPN-01"
 )
 
 type ChallengeStatus struct {
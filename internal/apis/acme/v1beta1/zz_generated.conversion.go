@@ -163,6 +163,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverSelfCheck)(nil), (*acme.ACMEChallengeSolverSelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck(a.(*ACMEChallengeSolverSelfCheck), b.(*acme.ACMEChallengeSolverSelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverSelfCheck)(nil), (*ACMEChallengeSolverSelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverSelfCheck_To_v1beta1_ACMEChallengeSolverSelfCheck(a.(*acme.ACMEChallengeSolverSelfCheck), b.(*ACMEChallengeSolverSelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverTLSALPN01)(nil), (*acme.ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(a.(*ACMEChallengeSolverTLSALPN01), b.(*acme.ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01)(nil), (*ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01(a.(*acme.ACMEChallengeSolverTLSALPN01), b.(*ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEExternalAccountBinding)(nil), (*acme.ACMEExternalAccountBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(a.(*ACMEExternalAccountBinding), b.(*acme.ACMEExternalAccountBinding), scope)
 	}); err != nil {
@@ -233,6 +253,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderLinode)(nil), (*acme.ACMEIssuerDNS01ProviderLinode)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(a.(*ACMEIssuerDNS01ProviderLinode), b.(*acme.ACMEIssuerDNS01ProviderLinode), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderLinode)(nil), (*ACMEIssuerDNS01ProviderLinode)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1beta1_ACMEIssuerDNS01ProviderLinode(a.(*acme.ACMEIssuerDNS01ProviderLinode), b.(*ACMEIssuerDNS01ProviderLinode), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -253,6 +283,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderVultr)(nil), (*acme.ACMEIssuerDNS01ProviderVultr)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(a.(*ACMEIssuerDNS01ProviderVultr), b.(*acme.ACMEIssuerDNS01ProviderVultr), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderVultr)(nil), (*ACMEIssuerDNS01ProviderVultr)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderVultr_To_v1beta1_ACMEIssuerDNS01ProviderVultr(a.(*acme.ACMEIssuerDNS01ProviderVultr), b.(*ACMEIssuerDNS01ProviderVultr), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderWebhook)(nil), (*acme.ACMEIssuerDNS01ProviderWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEIssuerDNS01ProviderWebhook_To_acme_ACMEIssuerDNS01ProviderWebhook(a.(*ACMEIssuerDNS01ProviderWebhook), b.(*acme.ACMEIssuerDNS01ProviderWebhook), scope)
 	}); err != nil {
@@ -450,6 +490,9 @@ func autoConvert_v1beta1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *ACM
 	} else {
 		out.DNS01 = nil
 	}
+	out.TLSALPN01 = (*acme.ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
+	out.SelfCheckIPFamily = v1.IPFamily(in.SelfCheckIPFamily)
+	out.SelfCheck = (*acme.ACMEChallengeSolverSelfCheck)(unsafe.Pointer(in.SelfCheck))
 	return nil
 }
 
@@ -470,6 +513,9 @@ func autoConvert_acme_ACMEChallengeSolver_To_v1beta1_ACMEChallengeSolver(in *acm
 	} else {
 		out.DNS01 = nil
 	}
+	out.TLSALPN01 = (*ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
+	out.SelfCheckIPFamily = v1.IPFamily(in.SelfCheckIPFamily)
+	out.SelfCheck = (*ACMEChallengeSolverSelfCheck)(unsafe.Pointer(in.SelfCheck))
 	return nil
 }
 
@@ -534,6 +580,24 @@ func autoConvert_v1beta1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Linode != nil {
+		in, out := &in.Linode, &out.Linode
+		*out = new(acme.ACMEIssuerDNS01ProviderLinode)
+		if err := Convert_v1beta1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Linode = nil
+	}
+	if in.Vultr != nil {
+		in, out := &in.Vultr, &out.Vultr
+		*out = new(acme.ACMEIssuerDNS01ProviderVultr)
+		if err := Convert_v1beta1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vultr = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(acme.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -617,6 +681,24 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1beta1_ACMEChallengeSolverDNS
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Linode != nil {
+		in, out := &in.Linode, &out.Linode
+		*out = new(ACMEIssuerDNS01ProviderLinode)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1beta1_ACMEIssuerDNS01ProviderLinode(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Linode = nil
+	}
+	if in.Vultr != nil {
+		in, out := &in.Vultr, &out.Vultr
+		*out = new(ACMEIssuerDNS01ProviderVultr)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderVultr_To_v1beta1_ACMEIssuerDNS01ProviderVultr(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vultr = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(ACMEIssuerDNS01ProviderAcmeDNS)
@@ -693,6 +775,7 @@ func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1beta1_ACMEChall
 func autoConvert_v1beta1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = v1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -707,6 +790,7 @@ func Convert_v1beta1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolve
 func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1beta1_ACMEChallengeSolverHTTP01Ingress(in *acme.ACMEChallengeSolverHTTP01Ingress, out *ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = v1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -768,6 +852,7 @@ func autoConvert_v1beta1_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMECha
 	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
+	out.ArchitectureImages = *(*map[string]string)(unsafe.Pointer(&in.ArchitectureImages))
 	return nil
 }
 
@@ -782,6 +867,7 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1beta1_ACMECha
 	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
+	out.ArchitectureImages = *(*map[string]string)(unsafe.Pointer(&in.ArchitectureImages))
 	return nil
 }
 
@@ -844,6 +930,56 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1beta1_ACMEChalle
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1beta1_ACMEChallengeSolverHTTP01IngressTemplate(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck(in *ACMEChallengeSolverSelfCheck, out *acme.ACMEChallengeSolverSelfCheck, s conversion.Scope) error {
+	out.InitialInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.Multiplier = (*int32)(unsafe.Pointer(in.Multiplier))
+	out.MaxInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.MaxDuration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxDuration))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck(in *ACMEChallengeSolverSelfCheck, out *acme.ACMEChallengeSolverSelfCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverSelfCheck_To_acme_ACMEChallengeSolverSelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverSelfCheck_To_v1beta1_ACMEChallengeSolverSelfCheck(in *acme.ACMEChallengeSolverSelfCheck, out *ACMEChallengeSolverSelfCheck, s conversion.Scope) error {
+	out.InitialInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.Multiplier = (*int32)(unsafe.Pointer(in.Multiplier))
+	out.MaxInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.MaxDuration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxDuration))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverSelfCheck_To_v1beta1_ACMEChallengeSolverSelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverSelfCheck_To_v1beta1_ACMEChallengeSolverSelfCheck(in *acme.ACMEChallengeSolverSelfCheck, out *ACMEChallengeSolverSelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverSelfCheck_To_v1beta1_ACMEChallengeSolverSelfCheck(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.Mode = acme.ACMEChallengeSolverTLSALPN01Mode(in.Mode)
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.Mode = ACMEChallengeSolverTLSALPN01Mode(in.Mode)
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
@@ -876,6 +1012,7 @@ func autoConvert_v1beta1_ACMEIssuer_To_acme_ACMEIssuer(in *ACMEIssuer, out *acme
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.Profile = in.Profile
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
@@ -909,6 +1046,7 @@ func autoConvert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(in *acme.ACMEIssuer, out
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.Profile = in.Profile
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
@@ -943,6 +1081,7 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderAcmeDNS_To_acme_ACMEIssuerDNS01P
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
+	out.AutoRegister = in.AutoRegister
 	return nil
 }
 
@@ -956,6 +1095,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAcmeDNS_To_v1beta1_ACMEIssuerDNS01P
 	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
+	out.AutoRegister = in.AutoRegister
 	return nil
 }
 
@@ -1172,6 +1312,30 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1beta1_ACMEIssuerDNS01
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1beta1_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(in *ACMEIssuerDNS01ProviderLinode, out *acme.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode is an autogenerated conversion function.
+func Convert_v1beta1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(in *ACMEIssuerDNS01ProviderLinode, out *acme.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderLinode_To_v1beta1_ACMEIssuerDNS01ProviderLinode(in *acme.ACMEIssuerDNS01ProviderLinode, out *ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1beta1_ACMEIssuerDNS01ProviderLinode is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1beta1_ACMEIssuerDNS01ProviderLinode(in *acme.ACMEIssuerDNS01ProviderLinode, out *ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderLinode_To_v1beta1_ACMEIssuerDNS01ProviderLinode(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
@@ -1252,6 +1416,30 @@ func Convert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1beta1_ACMEIssuerDNS01Provi
 	return autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1beta1_ACMEIssuerDNS01ProviderRoute53(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(in *ACMEIssuerDNS01ProviderVultr, out *acme.ACMEIssuerDNS01ProviderVultr, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APIKey, &out.APIKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr is an autogenerated conversion function.
+func Convert_v1beta1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(in *ACMEIssuerDNS01ProviderVultr, out *acme.ACMEIssuerDNS01ProviderVultr, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEIssuerDNS01ProviderVultr_To_acme_ACMEIssuerDNS01ProviderVultr(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderVultr_To_v1beta1_ACMEIssuerDNS01ProviderVultr(in *acme.ACMEIssuerDNS01ProviderVultr, out *ACMEIssuerDNS01ProviderVultr, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APIKey, &out.APIKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderVultr_To_v1beta1_ACMEIssuerDNS01ProviderVultr is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderVultr_To_v1beta1_ACMEIssuerDNS01ProviderVultr(in *acme.ACMEIssuerDNS01ProviderVultr, out *ACMEIssuerDNS01ProviderVultr, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderVultr_To_v1beta1_ACMEIssuerDNS01ProviderVultr(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEIssuerDNS01ProviderWebhook_To_acme_ACMEIssuerDNS01ProviderWebhook(in *ACMEIssuerDNS01ProviderWebhook, out *acme.ACMEIssuerDNS01ProviderWebhook, s conversion.Scope) error {
 	out.GroupName = in.GroupName
 	out.SolverName = in.SolverName
@@ -1279,6 +1467,8 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1beta1_ACMEIssuerDNS01Provi
 func autoConvert_v1beta1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.Contacts = *(*[]string)(unsafe.Pointer(&in.Contacts))
+	out.AccountStatus = in.AccountStatus
 	return nil
 }
 
@@ -1290,6 +1480,8 @@ func Convert_v1beta1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *ACMEIssuerSta
 func autoConvert_acme_ACMEIssuerStatus_To_v1beta1_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.Contacts = *(*[]string)(unsafe.Pointer(&in.Contacts))
+	out.AccountStatus = in.AccountStatus
 	return nil
 }
 
@@ -1571,6 +1763,7 @@ func autoConvert_v1beta1_OrderSpec_To_acme_OrderSpec(in *OrderSpec, out *acme.Or
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Profile = in.Profile
 	return nil
 }
 
@@ -1588,6 +1781,7 @@ func autoConvert_acme_OrderSpec_To_v1beta1_OrderSpec(in *acme.OrderSpec, out *Or
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Profile = in.Profile
 	return nil
 }
 
@@ -17,6 +17,8 @@ limitations under the License.
 package fuzzer
 
 import (
+	"fmt"
+
 	fuzz "github.com/google/gofuzz"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
@@ -41,6 +43,26 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 			if s.Spec.Duration == nil {
 				s.Spec.Duration = &metav1.Duration{Duration: v1.DefaultCertificateDuration}
 			}
+			// Set the fields which are defaulted for client certificates, so
+			// that defaulting doesn't change the round tripped Certificate.
+			if id := s.Spec.ClientIdentity; id != nil && len(id.ServiceAccountName) > 0 {
+				if len(id.TrustDomain) == 0 {
+					id.TrustDomain = "cluster.local"
+				}
+				if len(s.Spec.Usages) == 0 {
+					s.Spec.Usages = []certmanager.KeyUsage{certmanager.UsageClientAuth}
+				}
+				if len(s.Namespace) > 0 {
+					s.Spec.URISANs = append(s.Spec.URISANs, fmt.Sprintf("spiffe://%s/ns/%s/sa/%s", id.TrustDomain, s.Namespace, id.ServiceAccountName))
+					if len(s.Spec.CommonName) == 0 {
+						s.Spec.CommonName = id.ServiceAccountName
+					}
+				}
+			}
+			// The usages of S/MIME certificates are defaulted too.
+			if s.Spec.SMIME != nil && len(s.Spec.Usages) == 0 {
+				s.Spec.Usages = []certmanager.KeyUsage{certmanager.UsageEmailProtection}
+			}
 		},
 		func(s *certmanager.CertificateRequest, c fuzz.Continue) {
 			c.FuzzNoCustom(s) // fuzz self without calling this function again
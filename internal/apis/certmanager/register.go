@@ -45,8 +45,22 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&IssuerList{},
 		&ClusterIssuer{},
 		&ClusterIssuerList{},
+		&ClusterCertificateReport{},
+		&ClusterCertificateReportList{},
 		&CertificateRequest{},
 		&CertificateRequestList{},
+		&CertificateQuota{},
+		&CertificateQuotaList{},
+		&IssuanceHook{},
+		&IssuanceHookList{},
+		&IssuerReferenceGrant{},
+		&IssuerReferenceGrantList{},
+		&Notifier{},
+		&NotifierList{},
+		&RenewalWindow{},
+		&RenewalWindowList{},
+		&TLSSecretReport{},
+		&TLSSecretReportList{},
 	)
 	return nil
 }
@@ -149,6 +149,7 @@ const (
 // "server auth",
 // "client auth",
 // "code signing",
+// "document signing",
 // "email protection",
 // "s/mime",
 // "ipsec end system",
@@ -175,6 +176,7 @@ const (
 	UsageServerAuth        KeyUsage = "server auth"
 	UsageClientAuth        KeyUsage = "client auth"
 	UsageCodeSigning       KeyUsage = "code signing"
+	UsageDocumentSigning   KeyUsage = "document signing"
 	UsageEmailProtection   KeyUsage = "email protection"
 	UsageSMIME             KeyUsage = "s/mime"
 	UsageIPsecEndSystem    KeyUsage = "ipsec end system"
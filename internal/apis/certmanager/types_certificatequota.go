@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A CertificateQuota limits the number of Certificates that can exist in its
+// namespace, and the rate at which certificates can be requested for them.
+// It allows cluster administrators to stop a single tenant from exhausting a
+// shared CA or ACME account.
+// The number of Certificates is enforced by the cert-manager webhook when
+// Certificates are created, and the issuance rate is enforced by the
+// cert-manager controller before creating new CertificateRequests.
+// If multiple CertificateQuotas exist in a namespace, all of them are
+// enforced.
+type CertificateQuota struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the CertificateQuota resource.
+	Spec CertificateQuotaSpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateQuotaList is a list of CertificateQuotas
+type CertificateQuotaList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []CertificateQuota
+}
+
+// CertificateQuotaSpec defines the limits of a CertificateQuota.
+type CertificateQuotaSpec struct {
+	// MaxCertificates is the maximum number of Certificates that can exist
+	// in the namespace. Creating a Certificate which would exceed this number
+	// is rejected by the cert-manager webhook. Existing Certificates are not
+	// affected if the limit is lowered.
+	// If not set, the number of Certificates is not limited.
+	MaxCertificates *int32
+
+	// IssuanceRate limits the rate at which CertificateRequests are created
+	// for the Certificates in the namespace.
+	// If not set, the issuance rate is not limited.
+	IssuanceRate *CertificateQuotaIssuanceRate
+}
+
+// CertificateQuotaIssuanceRate limits the number of CertificateRequests that
+// can be created for the Certificates in a namespace within a period of time.
+type CertificateQuotaIssuanceRate struct {
+	// MaxIssuances is the maximum number of CertificateRequests that may be
+	// created for the Certificates in the namespace within Period.
+	// Once the limit has been reached, issuance of Certificates in the
+	// namespace is delayed until the oldest CertificateRequest within the
+	// period is older than Period.
+	MaxIssuances int32
+
+	// Period is the duration over which MaxIssuances applies, for example
+	// `1h`.
+	Period metav1.Duration
+}
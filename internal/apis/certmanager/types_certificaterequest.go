@@ -132,6 +132,23 @@ type CertificateRequestStatus struct {
 	// FailureTime stores the time that this CertificateRequest failed. This is
 	// used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
+
+	// PickupID is the identifier assigned to the request by an issuer that
+	// issues certificates asynchronously, such as Venafi. It is used to
+	// retrieve the certificate once it has been issued, rather than
+	// submitting the request to the issuer again.
+	PickupID string
+
+	// NextPollTime is the time at which the issuer will next check whether
+	// the certificate requested with PickupID has been issued.
+	NextPollTime *metav1.Time
+
+	// CrossSignedChain is the PEM encoded chain from the CA of the issued
+	// certificate to another root which cross-signed it: the cross-signed
+	// certificates followed by the other root. It is set by issuers whose
+	// root is cross-signed, and written to the Secret of a Certificate
+	// depending on its `crossSignedChain` field.
+	CrossSignedChain []byte
 }
 
 // CertificateRequestCondition contains condition information for a CertificateRequest.
@@ -154,6 +171,13 @@ type CertificateRequestCondition struct {
 	// Message is a human readable description of the details of the last
 	// transition, complementing reason.
 	Message string
+
+	// If set, this represents the .metadata.generation that the condition was
+	// set based upon.
+	// For instance, if .metadata.generation is currently 12, but the
+	// .status.condition[x].observedGeneration is 9, the condition is out of date
+	// with respect to the current state of the CertificateRequest.
+	ObservedGeneration int64
 }
 
 // CertificateRequestConditionType represents an Certificate condition value.
@@ -180,4 +204,12 @@ const (
 	// denied, and must never be signed. Condition must never have a status of
 	// `False`, and cannot be modified once set.
 	CertificateRequestConditionDenied CertificateRequestConditionType = "Denied"
+
+	// CertificateRequestConditionPendingApproval indicates that a certificate
+	// request has been submitted to an issuer that issues certificates
+	// asynchronously, and is waiting for the certificate to be issued, for
+	// example because the issuer requires the request to be approved manually.
+	// Unlike `Approved`, this condition is set by the issuer rather than by an
+	// approver of the CertificateRequest.
+	CertificateRequestConditionPendingApproval CertificateRequestConditionType = "PendingApproval"
 )
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// An IssuanceHook is an HTTP endpoint which is called by the cert-manager
+// controller before or after a CertificateRequest is signed by one of the
+// built-in issuers. Hooks allow platforms to integrate systems such as
+// ticketing or configuration management databases with certificate issuance.
+// Pre-issuance hooks can deny a CertificateRequest, or override the duration
+// and key usages of the certificate that is signed. Post-issuance hooks are
+// notified of the certificate that has been issued.
+type IssuanceHook struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the IssuanceHook resource.
+	Spec IssuanceHookSpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IssuanceHookList is a list of IssuanceHooks
+type IssuanceHookList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []IssuanceHook
+}
+
+// IssuanceHookSpec defines the endpoint of an IssuanceHook and when it is
+// called.
+type IssuanceHookSpec struct {
+	// Phase is the point at which the hook is called, either `PreIssuance`
+	// or `PostIssuance`.
+	Phase IssuanceHookPhase
+
+	// URL is the HTTPS URL that the hook is sent to as a JSON encoded POST
+	// request.
+	URL string
+
+	// CABundle is a PEM encoded CA bundle which is used to verify the
+	// certificate of the hook endpoint. If not set, the system root
+	// certificates are used.
+	// +optional
+	CABundle []byte
+
+	// Namespaces restricts the hook to CertificateRequests in the given
+	// namespaces. If empty, the hook is called for CertificateRequests in all
+	// namespaces.
+	// +optional
+	Namespaces []string
+
+	// FailurePolicy defines how errors calling a `PreIssuance` hook are
+	// handled, either `Fail` or `Ignore`. With `Fail`, CertificateRequests
+	// are not signed until the hook can be called successfully. Errors
+	// calling `PostIssuance` hooks are always ignored, as the certificate has
+	// already been issued.
+	// Defaults to `Fail`.
+	// +optional
+	FailurePolicy IssuanceHookFailurePolicy
+
+	// TimeoutSeconds is the timeout of calls to the hook, between 1 and 30
+	// seconds. Defaults to 10 seconds.
+	// +optional
+	TimeoutSeconds *int32
+}
+
+// IssuanceHookPhase is the point at which an IssuanceHook is called.
+type IssuanceHookPhase string
+
+const (
+	// IssuanceHookPhasePreIssuance hooks are called before a
+	// CertificateRequest is signed.
+	IssuanceHookPhasePreIssuance IssuanceHookPhase = "PreIssuance"
+
+	// IssuanceHookPhasePostIssuance hooks are called after a certificate has
+	// been issued.
+	IssuanceHookPhasePostIssuance IssuanceHookPhase = "PostIssuance"
+)
+
+// IssuanceHookFailurePolicy defines how errors calling an IssuanceHook are
+// handled.
+type IssuanceHookFailurePolicy string
+
+const (
+	// IssuanceHookFailurePolicyFail stops CertificateRequests from being
+	// signed if the hook cannot be called.
+	IssuanceHookFailurePolicyFail IssuanceHookFailurePolicy = "Fail"
+
+	// IssuanceHookFailurePolicyIgnore signs CertificateRequests as if the hook
+	// had allowed them if the hook cannot be called.
+	IssuanceHookFailurePolicyIgnore IssuanceHookFailurePolicy = "Ignore"
+)
@@ -82,6 +82,124 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig
+
+	// HTTPClient configures the HTTP client used when communicating with the
+	// ACME server, Vault or Venafi.
+	// This can be used to connect through a proxy, or to trust a private CA,
+	// without having to change the environment of the cert-manager controller.
+	HTTPClient *IssuerHTTPClient
+
+	// ControllerName is the class of the cert-manager controller which
+	// should manage this issuer, for running several installations of
+	// cert-manager in one cluster. It is matched against the
+	// `--controller-class` flag of the controller; if unset, the issuer is
+	// only managed by controllers which have no class configured.
+	ControllerName string
+
+	// DurationTolerance configures how much shorter than requested the
+	// lifetime of certificates issued by this issuer may be, for CAs which
+	// clamp the lifetime of the certificates they issue.
+	DurationTolerance *IssuerDurationTolerance
+
+	// Capabilities declares the key usages which the CA of this issuer
+	// supports, and what to do when a Certificate requests others.
+	Capabilities *IssuerCapabilities
+
+	// CABundle is a PEM encoded bundle of root certificates which the
+	// certificates issued by this ACME, Vault or Venafi issuer must chain up
+	// to. If set, a certificate returned by the CA which doesn't chain up to
+	// one of these roots is rejected before it is written to the target
+	// Secret, which protects against a compromised or misconfigured CA
+	// endpoint returning unexpected chains.
+	CABundle []byte
+
+	// IntermediateCA configures this ClusterIssuer to provision an
+	// intermediate CA and a CA Issuer in each of the namespaces it selects.
+	IntermediateCA *IssuerIntermediateCA
+}
+
+// IssuerCapabilities declares the capabilities of the CA of an issuer.
+type IssuerCapabilities struct {
+	// Usages is the set of key usages and extended key usages which the CA of
+	// this issuer includes in the certificates it issues. If empty, all usages
+	// are assumed to be supported.
+	Usages []KeyUsage
+
+	// UnsupportedUsagesPolicy controls what happens when a Certificate
+	// requests usages which are not listed in Usages.
+	UnsupportedUsagesPolicy UnsupportedUsagesPolicy
+}
+
+type UnsupportedUsagesPolicy string
+
+const (
+	// DropUnsupportedUsages leaves usages which are not supported by the
+	// issuer out of CertificateRequests.
+	DropUnsupportedUsages UnsupportedUsagesPolicy = "Drop"
+
+	// FailUnsupportedUsages fails the issuance of Certificates which request
+	// usages which are not supported by the issuer.
+	FailUnsupportedUsages UnsupportedUsagesPolicy = "Fail"
+)
+
+// IssuerDurationTolerance configures by how much the lifetime of certificates
+// issued by an issuer may fall short of the requested duration.
+type IssuerDurationTolerance struct {
+	// MaxShortening is the maximum amount by which the lifetime of an issued
+	// certificate may be shorter than the spec.duration of its Certificate.
+	MaxShortening metav1.Duration
+}
+
+// IssuerIntermediateCA configures the intermediate CAs which a ClusterIssuer
+// provisions in the namespaces it selects.
+type IssuerIntermediateCA struct {
+	// NamespaceSelector selects the namespaces in which an intermediate CA is
+	// provisioned. If not set, an intermediate CA is provisioned in every
+	// namespace.
+	NamespaceSelector *metav1.LabelSelector
+
+	// IssuerName is the name of the CA Issuer provisioned in each namespace,
+	// which is also the name of the Certificate of its intermediate CA.
+	// Defaults to the name of the ClusterIssuer.
+	IssuerName string
+
+	// Duration is the requested lifetime of the intermediate CA certificates.
+	Duration *metav1.Duration
+
+	// RenewBefore is how long before the intermediate CA certificates expire
+	// that they are renewed.
+	RenewBefore *metav1.Duration
+}
+
+// IssuerHTTPClient configures the HTTP client used by an issuer for all
+// outbound requests.
+type IssuerHTTPClient struct {
+	// ProxyURL is the URL of the proxy to use for requests made by this
+	// issuer, e.g. 'http://proxy.example.com:3128'. If not set, the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the
+	// cert-manager controller are used.
+	ProxyURL string
+
+	// NoProxy is a list of hosts, domains, IP addresses or CIDRs for which the
+	// proxy should not be used, with the same format as the NO_PROXY
+	// environment variable. Only used if ProxyURL is set.
+	NoProxy []string
+
+	// CABundle is a PEM encoded bundle of CA certificates which are trusted, in
+	// addition to the cert-manager controller system root certificates, when
+	// connecting using HTTPS.
+	// Mutually exclusive with CABundleSecretRef.
+	// Any CA bundle configured directly on the Vault or Venafi issuer
+	// takes precedence.
+	CABundle []byte
+
+	// CABundleSecretRef is a reference to a Secret which contains a PEM encoded
+	// bundle of CA certificates which are trusted, in addition to the
+	// cert-manager controller system root certificates, when connecting using
+	// HTTPS.
+	// Mutually exclusive with CABundle.
+	// If no key for the Secret is specified, cert-manager will default to 'ca.crt'.
+	CABundleSecretRef *cmmeta.SecretKeySelector
 }
 
 // IssuerConfig is a generic wrapper around custom issuer types
@@ -106,6 +224,68 @@ type IssuerConfig struct {
 	// Venafi configures this issuer to sign certificates using a Venafi TPP
 	// or Venafi Cloud policy zone.
 	Venafi *VenafiIssuer
+
+	// Fake configures this issuer to sign certificates instantly using an
+	// ephemeral CA which is generated in memory by the controller, with
+	// optional artificial latency and failures. It is meant for testing and
+	// demos only and requires the FakeIssuer feature gate to be enabled.
+	Fake *FakeIssuer
+
+	// Delegate configures this issuer to pass CertificateRequests on to one
+	// of several backing issuers, chosen by the labels of the namespace of
+	// the request or by the DNS names it requests.
+	Delegate *DelegateIssuer
+
+	// Offline configures this issuer to have certificates signed by a CA
+	// which cannot be reached from the cluster.
+	Offline *OfflineIssuer
+}
+
+// FakeIssuer configures an issuer to sign certificates using an ephemeral CA
+// which is generated in memory by the controller.
+type FakeIssuer struct {
+	// Latency is how long the issuer waits after a CertificateRequest has been
+	// created before signing it. If not set, requests are signed straight away.
+	Latency *metav1.Duration
+
+	// FailurePercentage is the percentage of CertificateRequests, between 0
+	// and 100, which are failed instead of being signed.
+	FailurePercentage int32
+
+	// FailureMessage is the message with which failed CertificateRequests
+	// are marked as failed.
+	FailureMessage string
+}
+
+// DelegateIssuer configures an issuer to delegate signing to one of several
+// backing issuers.
+type DelegateIssuer struct {
+	// Routes are the candidate backing issuers, in order of precedence.
+	Routes []DelegateIssuerRoute
+}
+
+// DelegateIssuerRoute selects the backing issuer used for the
+// CertificateRequests it matches.
+type DelegateIssuerRoute struct {
+	// NamespaceSelector matches CertificateRequests in namespaces with
+	// matching labels.
+	NamespaceSelector *metav1.LabelSelector
+
+	// DNSZones matches CertificateRequests for which every requested DNS
+	// name is equal to, or a subdomain of, one of the listed zones.
+	DNSZones []string
+
+	// IssuerRef references the backing issuer which signs the
+	// CertificateRequests matching this route.
+	IssuerRef cmmeta.ObjectReference
+}
+
+// OfflineIssuer configures an issuer to have certificates signed by an
+// offline CA.
+type OfflineIssuer struct {
+	// CABundle is a PEM encoded bundle of the CA certificates of the offline
+	// CA.
+	CABundle []byte
 }
 
 // VenafiIssuer configures an issuer to sign certificates using a Venafi TPP
@@ -114,7 +294,8 @@ type VenafiIssuer struct {
 	// Zone is the Venafi Policy Zone to use for this issuer.
 	// All requests made to the Venafi platform will be restricted by the named
 	// zone policy.
-	// This field is required.
+	// This field is required, unless the application and issuing template of
+	// a Venafi Cloud issuer are set.
 	Zone string
 
 	// TPP specifies Trust Protection Platform configuration settings.
@@ -154,6 +335,15 @@ type VenafiCloud struct {
 
 	// APITokenSecretRef is a secret key selector for the Venafi Cloud API token.
 	APITokenSecretRef cmmeta.SecretKeySelector
+
+	// Application is the name of the Venafi Cloud application that
+	// certificates are requested for. It must be set together with
+	// IssuingTemplate, in which case the zone of the issuer must not be set.
+	Application string
+
+	// IssuingTemplate is the alias of the Venafi Cloud issuing template, of
+	// the application, used to issue certificates.
+	IssuingTemplate string
 }
 
 // SelfSignedIssuer configures an issuer to 'self sign' certificates using the
@@ -163,6 +353,22 @@ type SelfSignedIssuer struct {
 	// the location of the CRL from which the revocation of this certificate can be checked.
 	// If not set certificate will be issued without CDP. Values are strings.
 	CRLDistributionPoints []string
+
+	// NotBeforeBackdate is the amount of time by which the NotBefore of
+	// certificates issued by this issuer is set before the time of issuance,
+	// so that they aren't rejected by clients with slightly skewed clocks.
+	// It can be overridden for a Certificate using the
+	// `cert-manager.io/not-before-backdate` annotation. If not set,
+	// certificates are valid from the time of issuance.
+	NotBeforeBackdate *metav1.Duration
+
+	// KeyIdentifierMethod is the method used to derive the subject and
+	// authority key identifiers of certificates issued by this issuer. One of
+	// `SHA1`, the SHA-1 hash of the subject public key as described in RFC
+	// 5280, or `TruncatedSHA256`, the leftmost 160 bits of its SHA-256 hash
+	// as described in RFC 7093. If not set, only CA certificates get a
+	// subject key identifier.
+	KeyIdentifierMethod KeyIdentifierMethod
 }
 
 // VaultIssuer configures an issuer to sign certificates using a HashiCorp Vault
@@ -198,10 +404,39 @@ type VaultIssuer struct {
 	// If no key for the Secret is specified, cert-manager will default to 'ca.crt'.
 	// +optional
 	CABundleSecretRef *cmmeta.SecretKeySelector
+
+	// Revocation configures the revocation of certificates signed by this
+	// issuer, and the periodic tidying of the PKI backend that Path belongs
+	// to, so that Vault's CRL stays accurate.
+	// Requires the `certificates-vault-revocation` controller to be enabled.
+	// +optional
+	Revocation *VaultRevocation
+}
+
+// VaultRevocation configures the revocation of certificates signed by a Vault
+// issuer using the `revoke` and `tidy` endpoints of its PKI backend.
+// Certificates annotated with `cert-manager.io/revoke: "true"` are always
+// revoked when this is set.
+type VaultRevocation struct {
+	// RevokeOnDelete revokes the certificate stored in the Secret of a
+	// Certificate when the Certificate is deleted.
+	// +optional
+	RevokeOnDelete bool
+
+	// TidyInterval is how often the `tidy` endpoint of the PKI backend is
+	// called to remove expired certificates from its storage and CRL.
+	// If unset, cert-manager does not tidy the PKI backend.
+	// +optional
+	TidyInterval *metav1.Duration
+
+	// TidySafetyBuffer is how long after their expiry certificates are kept
+	// by the `tidy` endpoint. If unset, Vault's default of 72h is used.
+	// +optional
+	TidySafetyBuffer *metav1.Duration
 }
 
 // VaultAuth is configuration used to authenticate with a Vault server.
-// Only one of `tokenSecretRef`, `appRole` or `kubernetes` may be specified.
+// Only one of `tokenSecretRef`, `appRole`, `kubernetes` or `clientCertificate` may be specified.
 type VaultAuth struct {
 	// TokenSecretRef authenticates with Vault by presenting a token.
 	TokenSecretRef *cmmeta.SecretKeySelector
@@ -213,6 +448,12 @@ type VaultAuth struct {
 	// Kubernetes authenticates with Vault by passing the ServiceAccount
 	// token stored in the named Secret resource to the Vault server.
 	Kubernetes *VaultKubernetesAuth
+
+	// ClientCertificate authenticates with Vault by presenting a client
+	// certificate stored in a Kubernetes Secret resource, using the TLS
+	// Certificates auth method.
+	// +optional
+	ClientCertificate *VaultClientCertificateAuth
 }
 
 // VaultAppRole authenticates with Vault using the App Role auth mechanism,
@@ -252,6 +493,28 @@ type VaultKubernetesAuth struct {
 	Role string
 }
 
+// VaultClientCertificateAuth authenticates with Vault using the TLS
+// Certificates auth method, presenting the certificate and private key stored
+// in a Kubernetes Secret resource. The Secret may be managed by a cert-manager
+// Certificate, so that no static credentials are required.
+type VaultClientCertificateAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. For example, setting a value to `/v1/auth/foo`, will use the path
+	// `/v1/auth/foo/login` to authenticate with Vault. If unspecified, the
+	// default value "/v1/auth/cert" will be used.
+	// +optional
+	Path string
+
+	// SecretName is the name of a Secret of type `kubernetes.io/tls`, whose
+	// `tls.crt` and `tls.key` are used as the client certificate.
+	SecretName string
+
+	// Name of the certificate role to authenticate against. If unspecified,
+	// Vault attempts to match the client certificate against all roles.
+	// +optional
+	Name string
+}
+
 // CAIssuer configures an issuer that can issue certificates from its provided
 // CA certificate. It contains the name of the private key to sign certificates,
 // holds the location for Certificate Revocation Lists (CRL) distribution
@@ -273,8 +536,59 @@ type CAIssuer struct {
 	// certificate will be issued with no OCSP servers set. For example, an
 	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
 	OCSPServers []string
+
+	// NotBeforeBackdate is the amount of time by which the NotBefore of
+	// certificates issued by this issuer is set before the time of issuance,
+	// so that they aren't rejected by clients with slightly skewed clocks.
+	// It can be overridden for a Certificate using the
+	// `cert-manager.io/not-before-backdate` annotation. If not set,
+	// certificates are valid from the time of issuance.
+	NotBeforeBackdate *metav1.Duration
+
+	// SerialNumberBits is the number of random bits in the serial numbers of
+	// certificates issued by this issuer, between 64 and 159. If not set,
+	// serial numbers have 128 random bits.
+	SerialNumberBits int
+
+	// TrackSerialNumbers enables recording the serial numbers of the
+	// certificates issued by this issuer in a ConfigMap named
+	// `<secretName>-serial-numbers`, in the namespace of the CA Secret, so that
+	// a serial number is never issued twice. The ConfigMap also serves as a
+	// record of all issued serial numbers for audits.
+	TrackSerialNumbers bool
+
+	// KeyIdentifierMethod is the method used to derive the subject key
+	// identifier of certificates issued by this issuer, and their authority
+	// key identifier if the CA certificate doesn't have a subject key
+	// identifier. One of `SHA1`, the SHA-1 hash of the subject public key as
+	// described in RFC 5280, or `TruncatedSHA256`, the leftmost 160 bits of
+	// its SHA-256 hash as described in RFC 7093. If not set, only CA
+	// certificates get a subject key identifier.
+	KeyIdentifierMethod KeyIdentifierMethod
+
+	// CrossSignedChainSecretName is the name of a Secret, in the same namespace
+	// as the CA Secret, whose `tls.crt` holds the PEM encoded chain from the
+	// root of the CA to another root which cross-signed it: the cross-signed
+	// certificates followed by the other root. If set, the chain is returned
+	// alongside issued certificates, so that Certificates can publish a chain
+	// to either root while clients move from one root to the other.
+	CrossSignedChainSecretName string
 }
 
+// KeyIdentifierMethod is a method used to derive the key identifiers of
+// certificates from their public key.
+type KeyIdentifierMethod string
+
+const (
+	// SHA1KeyIdentifierMethod derives key identifiers from the SHA-1 hash of
+	// the subject public key.
+	SHA1KeyIdentifierMethod KeyIdentifierMethod = "SHA1"
+
+	// TruncatedSHA256KeyIdentifierMethod derives key identifiers from the
+	// leftmost 160 bits of the SHA-256 hash of the subject public key.
+	TruncatedSHA256KeyIdentifierMethod KeyIdentifierMethod = "TruncatedSHA256"
+)
+
 // IssuerStatus contains status information about an Issuer
 type IssuerStatus struct {
 	// List of status conditions to indicate the status of a CertificateRequest.
@@ -285,11 +599,66 @@ type IssuerStatus struct {
 	// This field should only be set if the Issuer is configured to use an ACME
 	// server to issue certificates.
 	ACME *cmacme.ACMEIssuerStatus
+
+	// Usage summarises the Certificates and CertificateRequests which
+	// reference this issuer, so that the impact of changing it can be judged.
+	// This field is only set on ClusterIssuers, by the clusterissuers-usage
+	// controller.
+	Usage *IssuerUsageStatus
+}
+
+// IssuerUsageStatus summarises the resources which reference a ClusterIssuer.
+// Recent counts cover the CertificateRequests which completed within the
+// last 24 hours.
+type IssuerUsageStatus struct {
+	// Certificates is the number of Certificates which reference the issuer.
+	Certificates int32
+
+	// ReadyCertificates is the number of Certificates which reference the
+	// issuer and are Ready.
+	ReadyCertificates int32
+
+	// RecentIssuances is the number of CertificateRequests referencing the
+	// issuer which were issued within the last 24 hours.
+	RecentIssuances int32
+
+	// RecentFailures is the number of CertificateRequests referencing the
+	// issuer which failed within the last 24 hours.
+	RecentFailures int32
+
+	// LastRateLimitedTime is the most recent time within the last 24 hours at
+	// which a CertificateRequest referencing the issuer failed because the
+	// issuer, such as an ACME server, rate limited it. It is unset if no
+	// request was rate limited within the last 24 hours.
+	LastRateLimitedTime *metav1.Time
+
+	// Namespaces breaks down the usage of the issuer by the namespace of the
+	// Certificates and CertificateRequests which reference it.
+	Namespaces []IssuerNamespaceUsage
+}
+
+// IssuerNamespaceUsage summarises the resources in a single namespace which
+// reference a ClusterIssuer.
+type IssuerNamespaceUsage struct {
+	// Namespace is the namespace of the resources.
+	Namespace string
+
+	// Certificates is the number of Certificates in the namespace which
+	// reference the issuer.
+	Certificates int32
+
+	// ReadyCertificates is the number of Certificates in the namespace which
+	// reference the issuer and are Ready.
+	ReadyCertificates int32
+
+	// RecentFailures is the number of CertificateRequests in the namespace
+	// referencing the issuer which failed within the last 24 hours.
+	RecentFailures int32
 }
 
 // IssuerCondition contains condition information for an Issuer.
 type IssuerCondition struct {
-	// Type of the condition, known values are (`Ready`).
+	// Type of the condition, known values are (`Ready`, `RolledOut`).
 	Type IssuerConditionType
 
 	// Status of the condition, one of (`True`, `False`, `Unknown`).
@@ -324,4 +693,18 @@ const (
 	// If the `status` of this condition is `False`, CertificateRequest controllers
 	// should prevent attempts to sign certificates.
 	IssuerConditionReady IssuerConditionType = "Ready"
+
+	// IssuerConditionRolledOut is set on Issuers which opt in to canary
+	// rollouts. If the `status` of this condition is `False`, Certificates
+	// matching the canary selector of the Issuer have been renewed after a
+	// change to the Issuer spec, and renewal of the remaining Certificates is
+	// waiting for the soak period to elapse or for manual approval.
+	IssuerConditionRolledOut IssuerConditionType = "RolledOut"
+
+	// IssuerConditionRateLimited is set on ACME Issuers once they have
+	// requested orders or certificates. If the `status` of this condition is
+	// `True`, the ACME server has throttled the account of the Issuer, or the
+	// estimated remaining budget of one of its rate limits is low enough for
+	// issuance to be throttled imminently.
+	IssuerConditionRateLimited IssuerConditionType = "RateLimited"
 )
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// An IssuerReferenceGrant allows Certificates and CertificateRequests in other
+// namespaces to reference the Issuers in its namespace, by setting the
+// `namespace` field of their `issuerRef`.
+// Cross-namespace issuer references are only allowed if the namespace of the
+// referenced Issuer contains an IssuerReferenceGrant allowing them, and the
+// IssuerReferenceGrants feature gate is enabled.
+type IssuerReferenceGrant struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the IssuerReferenceGrant resource.
+	Spec IssuerReferenceGrantSpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IssuerReferenceGrantList is a list of IssuerReferenceGrants
+type IssuerReferenceGrantList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []IssuerReferenceGrant
+}
+
+// IssuerReferenceGrantSpec defines which namespaces may reference which
+// Issuers in the namespace of an IssuerReferenceGrant.
+type IssuerReferenceGrantSpec struct {
+	// From is the list of namespaces whose resources may reference the
+	// Issuers in the namespace of this IssuerReferenceGrant.
+	From []IssuerReferenceGrantFrom
+
+	// To is the list of Issuers which may be referenced. If not set, all
+	// Issuers in the namespace of this IssuerReferenceGrant may be
+	// referenced.
+	To []IssuerReferenceGrantTo
+}
+
+// IssuerReferenceGrantFrom is a namespace whose resources may reference
+// Issuers.
+type IssuerReferenceGrantFrom struct {
+	// Namespace whose resources may reference Issuers.
+	Namespace string
+}
+
+// IssuerReferenceGrantTo is an Issuer which may be referenced.
+type IssuerReferenceGrantTo struct {
+	// Name of the Issuer which may be referenced.
+	Name string
+}
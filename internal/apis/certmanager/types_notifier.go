@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A Notifier sends alerts to Slack, an HTTP webhook or email when a
+// Certificate fails to be issued, misses its renewal time, or is close to
+// expiry without having been renewed. It is intended for teams which don't
+// alert on the metrics exposed by the cert-manager controller.
+// Secrets referenced by a Notifier are read from the cluster resource
+// namespace.
+type Notifier struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the Notifier resource.
+	Spec NotifierSpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotifierList is a list of Notifiers
+type NotifierList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []Notifier
+}
+
+// NotifierSpec defines when a Notifier sends alerts and where they are sent
+// to. Exactly one of `slack`, `webhook` or `email` must be set.
+type NotifierSpec struct {
+	// Events is the list of events that alerts are sent for. If empty,
+	// alerts are sent for all events.
+	// +optional
+	Events []NotifierEvent
+
+	// Namespaces restricts the Notifier to Certificates in the given
+	// namespaces. If empty, alerts are sent for Certificates in all
+	// namespaces.
+	// +optional
+	Namespaces []string
+
+	// ExpiryThreshold is how long before the certificate expires an
+	// `Expiring` alert is sent, if the certificate has not been renewed by
+	// then. Defaults to 7 days (`168h`).
+	// +optional
+	ExpiryThreshold *metav1.Duration
+
+	// RepeatInterval is how often an alert is repeated while the event it
+	// was sent for persists. If not set, an alert is only sent once for each
+	// occurrence of an event.
+	// +optional
+	RepeatInterval *metav1.Duration
+
+	// Template is a Go text/template which is used to render the message of
+	// alerts. The template is passed the `Event`, the `Certificate` and a
+	// default `Message` describing the event.
+	// If not set, the default message is sent.
+	// +optional
+	Template string
+
+	// Slack sends alerts to a Slack incoming webhook.
+	// +optional
+	Slack *SlackNotifier
+
+	// Webhook sends alerts as JSON encoded POST requests to an HTTP endpoint.
+	// +optional
+	Webhook *WebhookNotifier
+
+	// Email sends alerts by email using an SMTP server.
+	// +optional
+	Email *EmailNotifier
+}
+
+// NotifierEvent is an event that a Notifier sends alerts for.
+type NotifierEvent string
+
+const (
+	// NotifierEventFailed is sent when the issuance of a Certificate fails.
+	NotifierEventFailed NotifierEvent = "Failed"
+
+	// NotifierEventRenewalMissed is sent when a Certificate has not been
+	// renewed an hour after its renewal time.
+	NotifierEventRenewalMissed NotifierEvent = "RenewalMissed"
+
+	// NotifierEventExpiring is sent when the certificate of a Certificate
+	// expires within the expiry threshold of the Notifier.
+	NotifierEventExpiring NotifierEvent = "Expiring"
+)
+
+// SlackNotifier configures a Slack incoming webhook.
+type SlackNotifier struct {
+	// WebhookURLSecretRef references a key of a Secret containing the URL of
+	// the Slack incoming webhook.
+	WebhookURLSecretRef cmmeta.SecretKeySelector
+}
+
+// WebhookNotifier configures an HTTP endpoint that alerts are sent to.
+type WebhookNotifier struct {
+	// URL is the HTTP or HTTPS URL that alerts are sent to.
+	URL string
+
+	// CABundle is a PEM encoded CA bundle which is used to verify the
+	// certificate of the endpoint. If not set, the system root certificates
+	// are used.
+	// +optional
+	CABundle []byte
+}
+
+// EmailNotifier configures an SMTP server that alerts are sent through.
+type EmailNotifier struct {
+	// SMTPServer is the `host:port` address of the SMTP server.
+	SMTPServer string
+
+	// From is the address that alerts are sent from.
+	From string
+
+	// To is the list of addresses that alerts are sent to.
+	To []string
+
+	// Username is the username used to authenticate to the SMTP server. If
+	// not set, no authentication is used.
+	// +optional
+	Username string
+
+	// PasswordSecretRef references a key of a Secret containing the password
+	// used to authenticate to the SMTP server.
+	// +optional
+	PasswordSecretRef *cmmeta.SecretKeySelector
+}
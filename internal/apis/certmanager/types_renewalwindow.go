@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A RenewalWindow defines blackout periods during which the renewal of
+// Certificates is deferred, for example during a change freeze.
+// A RenewalWindow applies to the Certificates in its namespace. A
+// RenewalWindow in the cluster resource namespace applies to the Certificates
+// in all namespaces.
+// Renewals which are required for reasons other than the renewal time of the
+// certificate being reached, such as the spec of the Certificate having
+// changed or the Secret being missing, are never deferred.
+type RenewalWindow struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the RenewalWindow resource.
+	Spec RenewalWindowSpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RenewalWindowList is a list of RenewalWindows
+type RenewalWindowList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []RenewalWindow
+}
+
+// RenewalWindowSpec defines the blackout periods of a RenewalWindow.
+type RenewalWindowSpec struct {
+	// Blackouts is the list of periods during which renewals are deferred.
+	Blackouts []RenewalBlackout
+
+	// HardExpiryMargin is the margin before the expiry of a certificate
+	// within which it is renewed even during a blackout, so that freezes
+	// never cause certificates to expire. Defaults to 72 hours (`72h`).
+	// +optional
+	HardExpiryMargin *metav1.Duration
+}
+
+// RenewalBlackout is a period during which renewals are deferred.
+type RenewalBlackout struct {
+	// Start is the time at which the blackout starts.
+	Start metav1.Time
+
+	// End is the time at which the blackout ends, and deferred renewals
+	// proceed.
+	End metav1.Time
+
+	// Reason describes why renewals are deferred, and is included in the
+	// events of deferred Certificates.
+	// +optional
+	Reason string
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A TLSSecretReport lists the TLS Secrets in a namespace and when their
+// certificates expire, including Secrets which are not managed by
+// cert-manager.
+// TLSSecretReports are written by the secret watchdog controller, which
+// maintains a single TLSSecretReport named `tls-secrets` in each namespace
+// that it watches.
+type TLSSecretReport struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Summary counts the TLS Secrets in the namespace.
+	Summary TLSSecretReportSummary
+
+	// Secrets is the list of TLS Secrets in the namespace, sorted by name.
+	// +optional
+	Secrets []TLSSecretReportEntry
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TLSSecretReportList is a list of TLSSecretReports
+type TLSSecretReportList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []TLSSecretReport
+}
+
+// TLSSecretReportSummary counts the TLS Secrets in a namespace.
+type TLSSecretReportSummary struct {
+	// Total is the number of TLS Secrets.
+	Total int
+
+	// Unmanaged is the number of TLS Secrets which are not managed by a
+	// Certificate.
+	Unmanaged int
+
+	// ExpiringSoon is the number of TLS Secrets whose certificate expires
+	// within 30 days.
+	ExpiringSoon int
+
+	// Expired is the number of TLS Secrets whose certificate has expired.
+	Expired int
+
+	// Invalid is the number of TLS Secrets whose certificate could not be
+	// decoded.
+	Invalid int
+}
+
+// TLSSecretReportEntry describes the certificate of a TLS Secret.
+type TLSSecretReportEntry struct {
+	// Name of the Secret.
+	Name string
+
+	// Managed is true if the Secret is managed by a Certificate.
+	Managed bool
+
+	// CertificateName is the name of the Certificate managing the Secret.
+	// +optional
+	CertificateName string
+
+	// CommonName is the common name of the certificate.
+	// +optional
+	CommonName string
+
+	// DNSNames is the list of DNS subjectAltNames of the certificate.
+	// +optional
+	DNSNames []string
+
+	// Issuer is the distinguished name of the issuer of the certificate.
+	// +optional
+	Issuer string
+
+	// NotAfter is the time at which the certificate expires.
+	// +optional
+	NotAfter *metav1.Time
+
+	// Error describes why the certificate of the Secret could not be
+	// decoded.
+	// +optional
+	Error string
+}
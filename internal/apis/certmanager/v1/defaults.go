@@ -17,9 +17,204 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+const (
+	// defaultClientIdentityTrustDomain is the SPIFFE trust domain used for
+	// Certificates with a ClientIdentity which do not set one.
+	defaultClientIdentityTrustDomain = "cluster.local"
+
+	// maxCommonNameLength is the maximum length of a common name in a
+	// certificate, as defined in RFC 5280.
+	maxCommonNameLength = 64
 )
 
+// ClusterDomain is the DNS domain of the cluster, which is substituted for
+// the ${CLUSTER_DOMAIN} variable in the DNS names and URIs of Certificates.
+// It is set by the webhook from its --cluster-domain flag.
+var ClusterDomain = "cluster.local"
+
+// MetadataDefaults are defaults derived from the metadata of the cluster,
+// such as its name and cloud provider, so that fleets of clusters can share
+// the same Certificate and ClusterIssuer manifests.
+// It is set by the webhook from its --default-* flags.
+var MetadataDefaults ClusterMetadataDefaults
+
+// ClusterMetadataDefaults are the values used to default the fields of
+// Certificates and ClusterIssuers which are not set.
+type ClusterMetadataDefaults struct {
+	// Organizations is the default subject organizations of Certificates.
+	Organizations []string
+
+	// Countries is the default subject countries of Certificates.
+	Countries []string
+
+	// DNSZones is the default DNS zone selector of the ACME DNS01 solvers of
+	// ClusterIssuers which do not have a selector.
+	DNSZones []string
+}
+
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+// SetDefaults_Certificate sets the defaults of Certificates which have a
+// ClientIdentity or are S/MIME certificates, and substitutes the template
+// variables in their SANs.
+func SetDefaults_Certificate(obj *cmapi.Certificate) {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.CertificateSANTemplates) {
+		expandSANTemplates(obj)
+	}
+
+	setClientIdentityDefaults(obj)
+	setSMIMEDefaults(obj)
+	setSubjectDefaults(obj)
+}
+
+// SetDefaults_ClusterIssuer sets the DNS zone selector of the ACME DNS01
+// solvers of ClusterIssuers which do not have a selector.
+func SetDefaults_ClusterIssuer(obj *cmapi.ClusterIssuer) {
+	if len(MetadataDefaults.DNSZones) == 0 || obj.Spec.ACME == nil {
+		return
+	}
+
+	for i := range obj.Spec.ACME.Solvers {
+		solver := &obj.Spec.ACME.Solvers[i]
+		if solver.DNS01 == nil || solver.Selector != nil {
+			continue
+		}
+		solver.Selector = &cmacme.CertificateDNSNameSelector{
+			DNSZones: append([]string(nil), MetadataDefaults.DNSZones...),
+		}
+	}
+}
+
+// setSubjectDefaults defaults the subject organizations and countries of
+// Certificates which do not set them. Certificates with a literal subject are
+// not defaulted, as their subject is used as is.
+func setSubjectDefaults(obj *cmapi.Certificate) {
+	if len(obj.Spec.LiteralSubject) > 0 {
+		return
+	}
+	if len(MetadataDefaults.Organizations) == 0 && len(MetadataDefaults.Countries) == 0 {
+		return
+	}
+
+	if obj.Spec.Subject == nil {
+		obj.Spec.Subject = &cmapi.X509Subject{}
+	}
+	if len(obj.Spec.Subject.Organizations) == 0 {
+		obj.Spec.Subject.Organizations = append([]string(nil), MetadataDefaults.Organizations...)
+	}
+	if len(obj.Spec.Subject.Countries) == 0 {
+		obj.Spec.Subject.Countries = append([]string(nil), MetadataDefaults.Countries...)
+	}
+}
+
+// setClientIdentityDefaults defaults the usages of Certificates with a
+// ClientIdentity to those of a client certificate, adds the SPIFFE URI of the
+// ServiceAccount to the URIs, and defaults the common name to the username of
+// the ServiceAccount.
+func setClientIdentityDefaults(obj *cmapi.Certificate) {
+	identity := obj.Spec.ClientIdentity
+	if identity == nil || len(identity.ServiceAccountName) == 0 {
+		return
+	}
+
+	if len(identity.TrustDomain) == 0 {
+		identity.TrustDomain = defaultClientIdentityTrustDomain
+	}
+
+	if len(obj.Spec.Usages) == 0 {
+		obj.Spec.Usages = []cmapi.KeyUsage{
+			cmapi.UsageDigitalSignature,
+			cmapi.UsageKeyEncipherment,
+			cmapi.UsageClientAuth,
+		}
+	}
+
+	// The namespace may not be known when defaulting, in which case the
+	// ServiceAccount cannot be identified.
+	if len(obj.Namespace) == 0 {
+		return
+	}
+
+	uri := fmt.Sprintf("spiffe://%s/ns/%s/sa/%s", identity.TrustDomain, obj.Namespace, identity.ServiceAccountName)
+	if !containsString(obj.Spec.URIs, uri) {
+		obj.Spec.URIs = append(obj.Spec.URIs, uri)
+	}
+
+	username := fmt.Sprintf("system:serviceaccount:%s:%s", obj.Namespace, identity.ServiceAccountName)
+	if len(obj.Spec.CommonName) == 0 && len(obj.Spec.LiteralSubject) == 0 && len(username) <= maxCommonNameLength {
+		obj.Spec.CommonName = username
+	}
+}
+
+// setSMIMEDefaults defaults the usages of S/MIME Certificates to those of a
+// certificate used to sign and encrypt email.
+func setSMIMEDefaults(obj *cmapi.Certificate) {
+	if obj.Spec.SMIME == nil || len(obj.Spec.Usages) > 0 {
+		return
+	}
+
+	obj.Spec.Usages = []cmapi.KeyUsage{
+		cmapi.UsageDigitalSignature,
+		cmapi.UsageKeyEncipherment,
+		cmapi.UsageEmailProtection,
+	}
+}
+
+// expandSANTemplates substitutes the variables in the DNS names and URIs of
+// the given Certificate, so that a Certificate can be defined once and
+// created in many namespaces. The supported variables are:
+//
+//	${NAMESPACE}       the namespace of the Certificate
+//	${CLUSTER_DOMAIN}  the DNS domain of the cluster
+//	${SERVICE_NAME}    the name of the Service owning the Certificate
+//
+// Variables which cannot be resolved are left as is, and are rejected when
+// the Certificate is validated.
+func expandSANTemplates(obj *cmapi.Certificate) {
+	var vars []string
+	if len(obj.Namespace) > 0 {
+		vars = append(vars, "${NAMESPACE}", obj.Namespace)
+	}
+	if len(ClusterDomain) > 0 {
+		vars = append(vars, "${CLUSTER_DOMAIN}", ClusterDomain)
+	}
+	for _, ref := range obj.OwnerReferences {
+		if ref.APIVersion == "v1" && ref.Kind == "Service" {
+			vars = append(vars, "${SERVICE_NAME}", ref.Name)
+			break
+		}
+	}
+	if len(vars) == 0 {
+		return
+	}
+
+	replacer := strings.NewReplacer(vars...)
+	for i, dnsName := range obj.Spec.DNSNames {
+		obj.Spec.DNSNames[i] = replacer.Replace(dnsName)
+	}
+	for i, uri := range obj.Spec.URIs {
+		obj.Spec.URIs[i] = replacer.Replace(uri)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
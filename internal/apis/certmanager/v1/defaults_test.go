@@ -0,0 +1,258 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+func TestSetDefaults_Certificate(t *testing.T) {
+	serviceOwner := metav1.OwnerReference{APIVersion: "v1", Kind: "Service", Name: "api"}
+
+	tests := map[string]struct {
+		templatesEnabled bool
+		crt              *cmapi.Certificate
+		exp              *cmapi.Certificate
+	}{
+		"Certificate without a ClientIdentity is not defaulted": {
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       cmapi.CertificateSpec{DNSNames: []string{"example.com"}},
+			},
+			exp: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       cmapi.CertificateSpec{DNSNames: []string{"example.com"}},
+			},
+		},
+		"Certificate with a ClientIdentity has client certificate defaults": {
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: cmapi.CertificateSpec{
+					ClientIdentity: &cmapi.CertificateClientIdentity{ServiceAccountName: "client"},
+				},
+			},
+			exp: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "system:serviceaccount:default:client",
+					URIs:       []string{"spiffe://cluster.local/ns/default/sa/client"},
+					Usages:     []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageClientAuth},
+					ClientIdentity: &cmapi.CertificateClientIdentity{
+						ServiceAccountName: "client",
+						TrustDomain:        "cluster.local",
+					},
+				},
+			},
+		},
+		"S/MIME Certificate has email protection usages": {
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: cmapi.CertificateSpec{
+					EmailAddresses: []string{"alice@example.com"},
+					SMIME:          &cmapi.CertificateSMIME{},
+				},
+			},
+			exp: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: cmapi.CertificateSpec{
+					EmailAddresses: []string{"alice@example.com"},
+					Usages:         []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageEmailProtection},
+					SMIME:          &cmapi.CertificateSMIME{},
+				},
+			},
+		},
+		"S/MIME Certificate keeps the usages it sets": {
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: cmapi.CertificateSpec{
+					EmailAddresses: []string{"alice@example.com"},
+					Usages:         []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageEmailProtection},
+					SMIME:          &cmapi.CertificateSMIME{},
+				},
+			},
+			exp: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: cmapi.CertificateSpec{
+					EmailAddresses: []string{"alice@example.com"},
+					Usages:         []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageEmailProtection},
+					SMIME:          &cmapi.CertificateSMIME{},
+				},
+			},
+		},
+		"Certificate with a ClientIdentity keeps the values it sets": {
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "client",
+					URIs:       []string{"spiffe://example.com/ns/default/sa/client"},
+					Usages:     []cmapi.KeyUsage{cmapi.UsageClientAuth},
+					ClientIdentity: &cmapi.CertificateClientIdentity{
+						ServiceAccountName: "client",
+						TrustDomain:        "example.com",
+					},
+				},
+			},
+			exp: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "client",
+					URIs:       []string{"spiffe://example.com/ns/default/sa/client"},
+					Usages:     []cmapi.KeyUsage{cmapi.UsageClientAuth},
+					ClientIdentity: &cmapi.CertificateClientIdentity{
+						ServiceAccountName: "client",
+						TrustDomain:        "example.com",
+					},
+				},
+			},
+		},
+		"common name is not defaulted if the ServiceAccount username is too long": {
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "a-namespace-with-a-long-name"},
+				Spec: cmapi.CertificateSpec{
+					ClientIdentity: &cmapi.CertificateClientIdentity{ServiceAccountName: "a-service-account-with-a-long-name"},
+				},
+			},
+			exp: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "a-namespace-with-a-long-name"},
+				Spec: cmapi.CertificateSpec{
+					URIs:   []string{"spiffe://cluster.local/ns/a-namespace-with-a-long-name/sa/a-service-account-with-a-long-name"},
+					Usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageClientAuth},
+					ClientIdentity: &cmapi.CertificateClientIdentity{
+						ServiceAccountName: "a-service-account-with-a-long-name",
+						TrustDomain:        "cluster.local",
+					},
+				},
+			},
+		},
+		"template variables are not substituted without the feature gate": {
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       cmapi.CertificateSpec{DNSNames: []string{"app.${NAMESPACE}.svc"}},
+			},
+			exp: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       cmapi.CertificateSpec{DNSNames: []string{"app.${NAMESPACE}.svc"}},
+			},
+		},
+		"template variables are substituted in dnsNames and uris": {
+			templatesEnabled: true,
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", OwnerReferences: []metav1.OwnerReference{serviceOwner}},
+				Spec: cmapi.CertificateSpec{
+					DNSNames: []string{"${SERVICE_NAME}", "${SERVICE_NAME}.${NAMESPACE}.svc.${CLUSTER_DOMAIN}"},
+					URIs:     []string{"spiffe://${CLUSTER_DOMAIN}/ns/${NAMESPACE}/svc/${SERVICE_NAME}"},
+				},
+			},
+			exp: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", OwnerReferences: []metav1.OwnerReference{serviceOwner}},
+				Spec: cmapi.CertificateSpec{
+					DNSNames: []string{"api", "api.default.svc.cluster.local"},
+					URIs:     []string{"spiffe://cluster.local/ns/default/svc/api"},
+				},
+			},
+		},
+		"template variables which cannot be resolved are kept": {
+			templatesEnabled: true,
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       cmapi.CertificateSpec{DNSNames: []string{"${SERVICE_NAME}.${NAMESPACE}.svc", "${UNKNOWN}.example.com"}},
+			},
+			exp: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       cmapi.CertificateSpec{DNSNames: []string{"${SERVICE_NAME}.default.svc", "${UNKNOWN}.example.com"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.CertificateSANTemplates, test.templatesEnabled)()
+
+			SetDefaults_Certificate(test.crt)
+			assert.Equal(t, test.exp, test.crt)
+
+			// Defaulting must be idempotent.
+			SetDefaults_Certificate(test.crt)
+			assert.Equal(t, test.exp, test.crt)
+		})
+	}
+}
+
+func TestSetDefaults_CertificateSubject(t *testing.T) {
+	defer func(d ClusterMetadataDefaults) { MetadataDefaults = d }(MetadataDefaults)
+	MetadataDefaults = ClusterMetadataDefaults{Organizations: []string{"prod-eu"}, Countries: []string{"DE"}}
+
+	tests := map[string]struct {
+		spec cmapi.CertificateSpec
+		exp  cmapi.CertificateSpec
+	}{
+		"Certificate without a subject is defaulted": {
+			spec: cmapi.CertificateSpec{CommonName: "example.com"},
+			exp: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				Subject:    &cmapi.X509Subject{Organizations: []string{"prod-eu"}, Countries: []string{"DE"}},
+			},
+		},
+		"subject organizations which are set are kept": {
+			spec: cmapi.CertificateSpec{Subject: &cmapi.X509Subject{Organizations: []string{"acme"}}},
+			exp:  cmapi.CertificateSpec{Subject: &cmapi.X509Subject{Organizations: []string{"acme"}, Countries: []string{"DE"}}},
+		},
+		"Certificate with a literal subject is not defaulted": {
+			spec: cmapi.CertificateSpec{LiteralSubject: "CN=example.com"},
+			exp:  cmapi.CertificateSpec{LiteralSubject: "CN=example.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &cmapi.Certificate{Spec: test.spec}
+			SetDefaults_Certificate(crt)
+			assert.Equal(t, test.exp, crt.Spec)
+		})
+	}
+}
+
+func TestSetDefaults_ClusterIssuer(t *testing.T) {
+	defer func(d ClusterMetadataDefaults) { MetadataDefaults = d }(MetadataDefaults)
+	MetadataDefaults = ClusterMetadataDefaults{DNSZones: []string{"prod.example.com"}}
+
+	selector := &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.org"}}
+	iss := &cmapi.ClusterIssuer{
+		Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{
+			Solvers: []cmacme.ACMEChallengeSolver{
+				{DNS01: &cmacme.ACMEChallengeSolverDNS01{}},
+				{DNS01: &cmacme.ACMEChallengeSolverDNS01{}, Selector: selector},
+				{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}},
+			},
+		}}},
+	}
+	SetDefaults_ClusterIssuer(iss)
+
+	solvers := iss.Spec.ACME.Solvers
+	assert.Equal(t, &cmacme.CertificateDNSNameSelector{DNSZones: []string{"prod.example.com"}}, solvers[0].Selector)
+	assert.Equal(t, selector, solvers[1].Selector)
+	assert.Nil(t, solvers[2].Selector)
+}
@@ -44,6 +44,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1.CABundleKeySelector)(nil), (*certmanager.CABundleKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CABundleKeySelector_To_certmanager_CABundleKeySelector(a.(*v1.CABundleKeySelector), b.(*certmanager.CABundleKeySelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CABundleKeySelector)(nil), (*v1.CABundleKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CABundleKeySelector_To_v1_CABundleKeySelector(a.(*certmanager.CABundleKeySelector), b.(*v1.CABundleKeySelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CAIssuer_To_certmanager_CAIssuer(a.(*v1.CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -64,6 +74,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAdditionalCABundle)(nil), (*certmanager.CertificateAdditionalCABundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle(a.(*v1.CertificateAdditionalCABundle), b.(*certmanager.CertificateAdditionalCABundle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalCABundle)(nil), (*v1.CertificateAdditionalCABundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalCABundle_To_v1_CertificateAdditionalCABundle(a.(*certmanager.CertificateAdditionalCABundle), b.(*v1.CertificateAdditionalCABundle), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateAdditionalOutputFormat)(nil), (*certmanager.CertificateAdditionalOutputFormat)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(a.(*v1.CertificateAdditionalOutputFormat), b.(*certmanager.CertificateAdditionalOutputFormat), scope)
 	}); err != nil {
@@ -74,6 +94,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateClientIdentity)(nil), (*certmanager.CertificateClientIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateClientIdentity_To_certmanager_CertificateClientIdentity(a.(*v1.CertificateClientIdentity), b.(*certmanager.CertificateClientIdentity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateClientIdentity)(nil), (*v1.CertificateClientIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateClientIdentity_To_v1_CertificateClientIdentity(a.(*certmanager.CertificateClientIdentity), b.(*v1.CertificateClientIdentity), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateCondition_To_certmanager_CertificateCondition(a.(*v1.CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -84,6 +114,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateDetails)(nil), (*certmanager.CertificateDetails)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateDetails_To_certmanager_CertificateDetails(a.(*v1.CertificateDetails), b.(*certmanager.CertificateDetails), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateDetails)(nil), (*v1.CertificateDetails)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateDetails_To_v1_CertificateDetails(a.(*certmanager.CertificateDetails), b.(*v1.CertificateDetails), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateKeystores)(nil), (*certmanager.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(a.(*v1.CertificateKeystores), b.(*certmanager.CertificateKeystores), scope)
 	}); err != nil {
@@ -114,6 +154,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateQuota)(nil), (*certmanager.CertificateQuota)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateQuota_To_certmanager_CertificateQuota(a.(*v1.CertificateQuota), b.(*certmanager.CertificateQuota), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateQuota)(nil), (*v1.CertificateQuota)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateQuota_To_v1_CertificateQuota(a.(*certmanager.CertificateQuota), b.(*v1.CertificateQuota), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateQuotaIssuanceRate)(nil), (*certmanager.CertificateQuotaIssuanceRate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateQuotaIssuanceRate_To_certmanager_CertificateQuotaIssuanceRate(a.(*v1.CertificateQuotaIssuanceRate), b.(*certmanager.CertificateQuotaIssuanceRate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateQuotaIssuanceRate)(nil), (*v1.CertificateQuotaIssuanceRate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateQuotaIssuanceRate_To_v1_CertificateQuotaIssuanceRate(a.(*certmanager.CertificateQuotaIssuanceRate), b.(*v1.CertificateQuotaIssuanceRate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateQuotaList)(nil), (*certmanager.CertificateQuotaList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateQuotaList_To_certmanager_CertificateQuotaList(a.(*v1.CertificateQuotaList), b.(*certmanager.CertificateQuotaList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateQuotaList)(nil), (*v1.CertificateQuotaList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateQuotaList_To_v1_CertificateQuotaList(a.(*certmanager.CertificateQuotaList), b.(*v1.CertificateQuotaList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateQuotaSpec)(nil), (*certmanager.CertificateQuotaSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateQuotaSpec_To_certmanager_CertificateQuotaSpec(a.(*v1.CertificateQuotaSpec), b.(*certmanager.CertificateQuotaSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateQuotaSpec)(nil), (*v1.CertificateQuotaSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateQuotaSpec_To_v1_CertificateQuotaSpec(a.(*certmanager.CertificateQuotaSpec), b.(*v1.CertificateQuotaSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -164,6 +244,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateSMIME)(nil), (*certmanager.CertificateSMIME)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateSMIME_To_certmanager_CertificateSMIME(a.(*v1.CertificateSMIME), b.(*certmanager.CertificateSMIME), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSMIME)(nil), (*v1.CertificateSMIME)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSMIME_To_v1_CertificateSMIME(a.(*certmanager.CertificateSMIME), b.(*v1.CertificateSMIME), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*v1.CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -174,6 +264,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateSplitIssuance)(nil), (*certmanager.CertificateSplitIssuance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(a.(*v1.CertificateSplitIssuance), b.(*certmanager.CertificateSplitIssuance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSplitIssuance)(nil), (*v1.CertificateSplitIssuance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSplitIssuance_To_v1_CertificateSplitIssuance(a.(*certmanager.CertificateSplitIssuance), b.(*v1.CertificateSplitIssuance), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateStatus)(nil), (*certmanager.CertificateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateStatus_To_certmanager_CertificateStatus(a.(*v1.CertificateStatus), b.(*certmanager.CertificateStatus), scope)
 	}); err != nil {
@@ -184,6 +284,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateTransparencyLogEntry)(nil), (*certmanager.CertificateTransparencyLogEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry(a.(*v1.CertificateTransparencyLogEntry), b.(*certmanager.CertificateTransparencyLogEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateTransparencyLogEntry)(nil), (*v1.CertificateTransparencyLogEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateTransparencyLogEntry_To_v1_CertificateTransparencyLogEntry(a.(*certmanager.CertificateTransparencyLogEntry), b.(*v1.CertificateTransparencyLogEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateTransparencyLogInclusionProof)(nil), (*certmanager.CertificateTransparencyLogInclusionProof)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof(a.(*v1.CertificateTransparencyLogInclusionProof), b.(*certmanager.CertificateTransparencyLogInclusionProof), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateTransparencyLogInclusionProof)(nil), (*v1.CertificateTransparencyLogInclusionProof)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateTransparencyLogInclusionProof_To_v1_CertificateTransparencyLogInclusionProof(a.(*certmanager.CertificateTransparencyLogInclusionProof), b.(*v1.CertificateTransparencyLogInclusionProof), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ClusterCertificateReport)(nil), (*certmanager.ClusterCertificateReport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ClusterCertificateReport_To_certmanager_ClusterCertificateReport(a.(*v1.ClusterCertificateReport), b.(*certmanager.ClusterCertificateReport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ClusterCertificateReport)(nil), (*v1.ClusterCertificateReport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ClusterCertificateReport_To_v1_ClusterCertificateReport(a.(*certmanager.ClusterCertificateReport), b.(*v1.ClusterCertificateReport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ClusterCertificateReportIssuer)(nil), (*certmanager.ClusterCertificateReportIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ClusterCertificateReportIssuer_To_certmanager_ClusterCertificateReportIssuer(a.(*v1.ClusterCertificateReportIssuer), b.(*certmanager.ClusterCertificateReportIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ClusterCertificateReportIssuer)(nil), (*v1.ClusterCertificateReportIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ClusterCertificateReportIssuer_To_v1_ClusterCertificateReportIssuer(a.(*certmanager.ClusterCertificateReportIssuer), b.(*v1.ClusterCertificateReportIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ClusterCertificateReportList)(nil), (*certmanager.ClusterCertificateReportList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ClusterCertificateReportList_To_certmanager_ClusterCertificateReportList(a.(*v1.ClusterCertificateReportList), b.(*certmanager.ClusterCertificateReportList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ClusterCertificateReportList)(nil), (*v1.ClusterCertificateReportList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ClusterCertificateReportList_To_v1_ClusterCertificateReportList(a.(*certmanager.ClusterCertificateReportList), b.(*v1.ClusterCertificateReportList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ClusterCertificateReportSummary)(nil), (*certmanager.ClusterCertificateReportSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ClusterCertificateReportSummary_To_certmanager_ClusterCertificateReportSummary(a.(*v1.ClusterCertificateReportSummary), b.(*certmanager.ClusterCertificateReportSummary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ClusterCertificateReportSummary)(nil), (*v1.ClusterCertificateReportSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ClusterCertificateReportSummary_To_v1_ClusterCertificateReportSummary(a.(*certmanager.ClusterCertificateReportSummary), b.(*v1.ClusterCertificateReportSummary), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ClusterIssuer)(nil), (*certmanager.ClusterIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ClusterIssuer_To_certmanager_ClusterIssuer(a.(*v1.ClusterIssuer), b.(*certmanager.ClusterIssuer), scope)
 	}); err != nil {
@@ -204,6 +364,76 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.DelegateIssuer)(nil), (*certmanager.DelegateIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_DelegateIssuer_To_certmanager_DelegateIssuer(a.(*v1.DelegateIssuer), b.(*certmanager.DelegateIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.DelegateIssuer)(nil), (*v1.DelegateIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_DelegateIssuer_To_v1_DelegateIssuer(a.(*certmanager.DelegateIssuer), b.(*v1.DelegateIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.DelegateIssuerRoute)(nil), (*certmanager.DelegateIssuerRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(a.(*v1.DelegateIssuerRoute), b.(*certmanager.DelegateIssuerRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.DelegateIssuerRoute)(nil), (*v1.DelegateIssuerRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_DelegateIssuerRoute_To_v1_DelegateIssuerRoute(a.(*certmanager.DelegateIssuerRoute), b.(*v1.DelegateIssuerRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.EmailNotifier)(nil), (*certmanager.EmailNotifier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_EmailNotifier_To_certmanager_EmailNotifier(a.(*v1.EmailNotifier), b.(*certmanager.EmailNotifier), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.EmailNotifier)(nil), (*v1.EmailNotifier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_EmailNotifier_To_v1_EmailNotifier(a.(*certmanager.EmailNotifier), b.(*v1.EmailNotifier), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuanceHook)(nil), (*certmanager.IssuanceHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuanceHook_To_certmanager_IssuanceHook(a.(*v1.IssuanceHook), b.(*certmanager.IssuanceHook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuanceHook)(nil), (*v1.IssuanceHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuanceHook_To_v1_IssuanceHook(a.(*certmanager.IssuanceHook), b.(*v1.IssuanceHook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuanceHookList)(nil), (*certmanager.IssuanceHookList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuanceHookList_To_certmanager_IssuanceHookList(a.(*v1.IssuanceHookList), b.(*certmanager.IssuanceHookList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuanceHookList)(nil), (*v1.IssuanceHookList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuanceHookList_To_v1_IssuanceHookList(a.(*certmanager.IssuanceHookList), b.(*v1.IssuanceHookList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuanceHookSpec)(nil), (*certmanager.IssuanceHookSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuanceHookSpec_To_certmanager_IssuanceHookSpec(a.(*v1.IssuanceHookSpec), b.(*certmanager.IssuanceHookSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuanceHookSpec)(nil), (*v1.IssuanceHookSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuanceHookSpec_To_v1_IssuanceHookSpec(a.(*certmanager.IssuanceHookSpec), b.(*v1.IssuanceHookSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.FakeIssuer)(nil), (*certmanager.FakeIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_FakeIssuer_To_certmanager_FakeIssuer(a.(*v1.FakeIssuer), b.(*certmanager.FakeIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.FakeIssuer)(nil), (*v1.FakeIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_FakeIssuer_To_v1_FakeIssuer(a.(*certmanager.FakeIssuer), b.(*v1.FakeIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Issuer_To_certmanager_Issuer(a.(*v1.Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -214,6 +444,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerCapabilities)(nil), (*certmanager.IssuerCapabilities)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerCapabilities_To_certmanager_IssuerCapabilities(a.(*v1.IssuerCapabilities), b.(*certmanager.IssuerCapabilities), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerCapabilities)(nil), (*v1.IssuerCapabilities)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerCapabilities_To_v1_IssuerCapabilities(a.(*certmanager.IssuerCapabilities), b.(*v1.IssuerCapabilities), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerCondition)(nil), (*certmanager.IssuerCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerCondition_To_certmanager_IssuerCondition(a.(*v1.IssuerCondition), b.(*certmanager.IssuerCondition), scope)
 	}); err != nil {
@@ -234,6 +474,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerDurationTolerance)(nil), (*certmanager.IssuerDurationTolerance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance(a.(*v1.IssuerDurationTolerance), b.(*certmanager.IssuerDurationTolerance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerDurationTolerance)(nil), (*v1.IssuerDurationTolerance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerDurationTolerance_To_v1_IssuerDurationTolerance(a.(*certmanager.IssuerDurationTolerance), b.(*v1.IssuerDurationTolerance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerHTTPClient)(nil), (*certmanager.IssuerHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(a.(*v1.IssuerHTTPClient), b.(*certmanager.IssuerHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerHTTPClient)(nil), (*v1.IssuerHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient(a.(*certmanager.IssuerHTTPClient), b.(*v1.IssuerHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerIntermediateCA)(nil), (*certmanager.IssuerIntermediateCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA(a.(*v1.IssuerIntermediateCA), b.(*certmanager.IssuerIntermediateCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerIntermediateCA)(nil), (*v1.IssuerIntermediateCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerIntermediateCA_To_v1_IssuerIntermediateCA(a.(*certmanager.IssuerIntermediateCA), b.(*v1.IssuerIntermediateCA), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerList)(nil), (*certmanager.IssuerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerList_To_certmanager_IssuerList(a.(*v1.IssuerList), b.(*certmanager.IssuerList), scope)
 	}); err != nil {
@@ -244,6 +514,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerNamespaceUsage)(nil), (*certmanager.IssuerNamespaceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage(a.(*v1.IssuerNamespaceUsage), b.(*certmanager.IssuerNamespaceUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerNamespaceUsage)(nil), (*v1.IssuerNamespaceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerNamespaceUsage_To_v1_IssuerNamespaceUsage(a.(*certmanager.IssuerNamespaceUsage), b.(*v1.IssuerNamespaceUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerReferenceGrant)(nil), (*certmanager.IssuerReferenceGrant)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerReferenceGrant_To_certmanager_IssuerReferenceGrant(a.(*v1.IssuerReferenceGrant), b.(*certmanager.IssuerReferenceGrant), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerReferenceGrant)(nil), (*v1.IssuerReferenceGrant)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerReferenceGrant_To_v1_IssuerReferenceGrant(a.(*certmanager.IssuerReferenceGrant), b.(*v1.IssuerReferenceGrant), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerReferenceGrantFrom)(nil), (*certmanager.IssuerReferenceGrantFrom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerReferenceGrantFrom_To_certmanager_IssuerReferenceGrantFrom(a.(*v1.IssuerReferenceGrantFrom), b.(*certmanager.IssuerReferenceGrantFrom), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerReferenceGrantFrom)(nil), (*v1.IssuerReferenceGrantFrom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerReferenceGrantFrom_To_v1_IssuerReferenceGrantFrom(a.(*certmanager.IssuerReferenceGrantFrom), b.(*v1.IssuerReferenceGrantFrom), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerReferenceGrantList)(nil), (*certmanager.IssuerReferenceGrantList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerReferenceGrantList_To_certmanager_IssuerReferenceGrantList(a.(*v1.IssuerReferenceGrantList), b.(*certmanager.IssuerReferenceGrantList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerReferenceGrantList)(nil), (*v1.IssuerReferenceGrantList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerReferenceGrantList_To_v1_IssuerReferenceGrantList(a.(*certmanager.IssuerReferenceGrantList), b.(*v1.IssuerReferenceGrantList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerReferenceGrantSpec)(nil), (*certmanager.IssuerReferenceGrantSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerReferenceGrantSpec_To_certmanager_IssuerReferenceGrantSpec(a.(*v1.IssuerReferenceGrantSpec), b.(*certmanager.IssuerReferenceGrantSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerReferenceGrantSpec)(nil), (*v1.IssuerReferenceGrantSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerReferenceGrantSpec_To_v1_IssuerReferenceGrantSpec(a.(*certmanager.IssuerReferenceGrantSpec), b.(*v1.IssuerReferenceGrantSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerReferenceGrantTo)(nil), (*certmanager.IssuerReferenceGrantTo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerReferenceGrantTo_To_certmanager_IssuerReferenceGrantTo(a.(*v1.IssuerReferenceGrantTo), b.(*certmanager.IssuerReferenceGrantTo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerReferenceGrantTo)(nil), (*v1.IssuerReferenceGrantTo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerReferenceGrantTo_To_v1_IssuerReferenceGrantTo(a.(*certmanager.IssuerReferenceGrantTo), b.(*v1.IssuerReferenceGrantTo), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(a.(*v1.IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -264,6 +594,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerUsageStatus)(nil), (*certmanager.IssuerUsageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerUsageStatus_To_certmanager_IssuerUsageStatus(a.(*v1.IssuerUsageStatus), b.(*certmanager.IssuerUsageStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerUsageStatus)(nil), (*v1.IssuerUsageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerUsageStatus_To_v1_IssuerUsageStatus(a.(*certmanager.IssuerUsageStatus), b.(*v1.IssuerUsageStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.JKSKeystore)(nil), (*certmanager.JKSKeystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_JKSKeystore_To_certmanager_JKSKeystore(a.(*v1.JKSKeystore), b.(*certmanager.JKSKeystore), scope)
 	}); err != nil {
@@ -274,123 +614,310 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*v1.PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.Notifier)(nil), (*certmanager.Notifier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_Notifier_To_certmanager_Notifier(a.(*v1.Notifier), b.(*certmanager.Notifier), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.PKCS12Keystore)(nil), (*v1.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(a.(*certmanager.PKCS12Keystore), b.(*v1.PKCS12Keystore), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.Notifier)(nil), (*v1.Notifier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_Notifier_To_v1_Notifier(a.(*certmanager.Notifier), b.(*v1.Notifier), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.NotifierList)(nil), (*certmanager.NotifierList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NotifierList_To_certmanager_NotifierList(a.(*v1.NotifierList), b.(*certmanager.NotifierList), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.SelfSignedIssuer)(nil), (*v1.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(a.(*certmanager.SelfSignedIssuer), b.(*v1.SelfSignedIssuer), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.NotifierList)(nil), (*v1.NotifierList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NotifierList_To_v1_NotifierList(a.(*certmanager.NotifierList), b.(*v1.NotifierList), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.VaultAppRole)(nil), (*certmanager.VaultAppRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_VaultAppRole_To_certmanager_VaultAppRole(a.(*v1.VaultAppRole), b.(*certmanager.VaultAppRole), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.NotifierSpec)(nil), (*certmanager.NotifierSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NotifierSpec_To_certmanager_NotifierSpec(a.(*v1.NotifierSpec), b.(*certmanager.NotifierSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.VaultAppRole)(nil), (*v1.VaultAppRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_VaultAppRole_To_v1_VaultAppRole(a.(*certmanager.VaultAppRole), b.(*v1.VaultAppRole), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.NotifierSpec)(nil), (*v1.NotifierSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NotifierSpec_To_v1_NotifierSpec(a.(*certmanager.NotifierSpec), b.(*v1.NotifierSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.VaultAuth)(nil), (*certmanager.VaultAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_VaultAuth_To_certmanager_VaultAuth(a.(*v1.VaultAuth), b.(*certmanager.VaultAuth), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.OfflineIssuer)(nil), (*certmanager.OfflineIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_OfflineIssuer_To_certmanager_OfflineIssuer(a.(*v1.OfflineIssuer), b.(*certmanager.OfflineIssuer), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.VaultAuth)(nil), (*v1.VaultAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_VaultAuth_To_v1_VaultAuth(a.(*certmanager.VaultAuth), b.(*v1.VaultAuth), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.OfflineIssuer)(nil), (*v1.OfflineIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_OfflineIssuer_To_v1_OfflineIssuer(a.(*certmanager.OfflineIssuer), b.(*v1.OfflineIssuer), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.VaultIssuer)(nil), (*certmanager.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_VaultIssuer_To_certmanager_VaultIssuer(a.(*v1.VaultIssuer), b.(*certmanager.VaultIssuer), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*v1.PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.VaultIssuer)(nil), (*v1.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_VaultIssuer_To_v1_VaultIssuer(a.(*certmanager.VaultIssuer), b.(*v1.VaultIssuer), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.PKCS12Keystore)(nil), (*v1.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(a.(*certmanager.PKCS12Keystore), b.(*v1.PKCS12Keystore), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.VaultKubernetesAuth)(nil), (*certmanager.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(a.(*v1.VaultKubernetesAuth), b.(*certmanager.VaultKubernetesAuth), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.RenewalBlackout)(nil), (*certmanager.RenewalBlackout)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_RenewalBlackout_To_certmanager_RenewalBlackout(a.(*v1.RenewalBlackout), b.(*certmanager.RenewalBlackout), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.VaultKubernetesAuth)(nil), (*v1.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(a.(*certmanager.VaultKubernetesAuth), b.(*v1.VaultKubernetesAuth), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.RenewalBlackout)(nil), (*v1.RenewalBlackout)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_RenewalBlackout_To_v1_RenewalBlackout(a.(*certmanager.RenewalBlackout), b.(*v1.RenewalBlackout), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.RenewalWindow)(nil), (*certmanager.RenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_RenewalWindow_To_certmanager_RenewalWindow(a.(*v1.RenewalWindow), b.(*certmanager.RenewalWindow), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiCloud)(nil), (*v1.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_VenafiCloud_To_v1_VenafiCloud(a.(*certmanager.VenafiCloud), b.(*v1.VenafiCloud), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.RenewalWindow)(nil), (*v1.RenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_RenewalWindow_To_v1_RenewalWindow(a.(*certmanager.RenewalWindow), b.(*v1.RenewalWindow), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.VenafiIssuer)(nil), (*certmanager.VenafiIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(a.(*v1.VenafiIssuer), b.(*certmanager.VenafiIssuer), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.RenewalWindowList)(nil), (*certmanager.RenewalWindowList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_RenewalWindowList_To_certmanager_RenewalWindowList(a.(*v1.RenewalWindowList), b.(*certmanager.RenewalWindowList), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiIssuer)(nil), (*v1.VenafiIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(a.(*certmanager.VenafiIssuer), b.(*v1.VenafiIssuer), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.RenewalWindowList)(nil), (*v1.RenewalWindowList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_RenewalWindowList_To_v1_RenewalWindowList(a.(*certmanager.RenewalWindowList), b.(*v1.RenewalWindowList), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.VenafiTPP)(nil), (*certmanager.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_VenafiTPP_To_certmanager_VenafiTPP(a.(*v1.VenafiTPP), b.(*certmanager.VenafiTPP), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.RenewalWindowSpec)(nil), (*certmanager.RenewalWindowSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_RenewalWindowSpec_To_certmanager_RenewalWindowSpec(a.(*v1.RenewalWindowSpec), b.(*certmanager.RenewalWindowSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiTPP)(nil), (*v1.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_VenafiTPP_To_v1_VenafiTPP(a.(*certmanager.VenafiTPP), b.(*v1.VenafiTPP), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.RenewalWindowSpec)(nil), (*v1.RenewalWindowSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_RenewalWindowSpec_To_v1_RenewalWindowSpec(a.(*certmanager.RenewalWindowSpec), b.(*v1.RenewalWindowSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1.X509Subject)(nil), (*certmanager.X509Subject)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_X509Subject_To_certmanager_X509Subject(a.(*v1.X509Subject), b.(*certmanager.X509Subject), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*certmanager.X509Subject)(nil), (*v1.X509Subject)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_X509Subject_To_v1_X509Subject(a.(*certmanager.X509Subject), b.(*v1.X509Subject), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.SelfSignedIssuer)(nil), (*v1.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(a.(*certmanager.SelfSignedIssuer), b.(*v1.SelfSignedIssuer), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddConversionFunc((*certmanager.CertificateSpec)(nil), (*v1.CertificateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_certmanager_CertificateSpec_To_v1_CertificateSpec(a.(*certmanager.CertificateSpec), b.(*v1.CertificateSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*v1.SlackNotifier)(nil), (*certmanager.SlackNotifier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SlackNotifier_To_certmanager_SlackNotifier(a.(*v1.SlackNotifier), b.(*certmanager.SlackNotifier), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddConversionFunc((*v1.CertificateSpec)(nil), (*certmanager.CertificateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1_CertificateSpec_To_certmanager_CertificateSpec(a.(*v1.CertificateSpec), b.(*certmanager.CertificateSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*certmanager.SlackNotifier)(nil), (*v1.SlackNotifier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SlackNotifier_To_v1_SlackNotifier(a.(*certmanager.SlackNotifier), b.(*v1.SlackNotifier), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.TLSSecretReport)(nil), (*certmanager.TLSSecretReport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_TLSSecretReport_To_certmanager_TLSSecretReport(a.(*v1.TLSSecretReport), b.(*certmanager.TLSSecretReport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.TLSSecretReport)(nil), (*v1.TLSSecretReport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_TLSSecretReport_To_v1_TLSSecretReport(a.(*certmanager.TLSSecretReport), b.(*v1.TLSSecretReport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.TLSSecretReportEntry)(nil), (*certmanager.TLSSecretReportEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_TLSSecretReportEntry_To_certmanager_TLSSecretReportEntry(a.(*v1.TLSSecretReportEntry), b.(*certmanager.TLSSecretReportEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.TLSSecretReportEntry)(nil), (*v1.TLSSecretReportEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_TLSSecretReportEntry_To_v1_TLSSecretReportEntry(a.(*certmanager.TLSSecretReportEntry), b.(*v1.TLSSecretReportEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.TLSSecretReportList)(nil), (*certmanager.TLSSecretReportList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_TLSSecretReportList_To_certmanager_TLSSecretReportList(a.(*v1.TLSSecretReportList), b.(*certmanager.TLSSecretReportList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.TLSSecretReportList)(nil), (*v1.TLSSecretReportList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_TLSSecretReportList_To_v1_TLSSecretReportList(a.(*certmanager.TLSSecretReportList), b.(*v1.TLSSecretReportList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.TLSSecretReportSummary)(nil), (*certmanager.TLSSecretReportSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_TLSSecretReportSummary_To_certmanager_TLSSecretReportSummary(a.(*v1.TLSSecretReportSummary), b.(*certmanager.TLSSecretReportSummary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.TLSSecretReportSummary)(nil), (*v1.TLSSecretReportSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_TLSSecretReportSummary_To_v1_TLSSecretReportSummary(a.(*certmanager.TLSSecretReportSummary), b.(*v1.TLSSecretReportSummary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultAppRole)(nil), (*certmanager.VaultAppRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultAppRole_To_certmanager_VaultAppRole(a.(*v1.VaultAppRole), b.(*certmanager.VaultAppRole), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultAppRole)(nil), (*v1.VaultAppRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultAppRole_To_v1_VaultAppRole(a.(*certmanager.VaultAppRole), b.(*v1.VaultAppRole), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultAuth)(nil), (*certmanager.VaultAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultAuth_To_certmanager_VaultAuth(a.(*v1.VaultAuth), b.(*certmanager.VaultAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultAuth)(nil), (*v1.VaultAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultAuth_To_v1_VaultAuth(a.(*certmanager.VaultAuth), b.(*v1.VaultAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultIssuer)(nil), (*certmanager.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultIssuer_To_certmanager_VaultIssuer(a.(*v1.VaultIssuer), b.(*certmanager.VaultIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultClientCertificateAuth)(nil), (*certmanager.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(a.(*v1.VaultClientCertificateAuth), b.(*certmanager.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultClientCertificateAuth)(nil), (*v1.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(a.(*certmanager.VaultClientCertificateAuth), b.(*v1.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultIssuer)(nil), (*v1.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultIssuer_To_v1_VaultIssuer(a.(*certmanager.VaultIssuer), b.(*v1.VaultIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultKubernetesAuth)(nil), (*certmanager.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(a.(*v1.VaultKubernetesAuth), b.(*certmanager.VaultKubernetesAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultKubernetesAuth)(nil), (*v1.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(a.(*certmanager.VaultKubernetesAuth), b.(*v1.VaultKubernetesAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultRevocation)(nil), (*certmanager.VaultRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultRevocation_To_certmanager_VaultRevocation(a.(*v1.VaultRevocation), b.(*certmanager.VaultRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultRevocation)(nil), (*v1.VaultRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultRevocation_To_v1_VaultRevocation(a.(*certmanager.VaultRevocation), b.(*v1.VaultRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiCloud)(nil), (*v1.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiCloud_To_v1_VenafiCloud(a.(*certmanager.VenafiCloud), b.(*v1.VenafiCloud), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiIssuer)(nil), (*certmanager.VenafiIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(a.(*v1.VenafiIssuer), b.(*certmanager.VenafiIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiIssuer)(nil), (*v1.VenafiIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(a.(*certmanager.VenafiIssuer), b.(*v1.VenafiIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiTPP)(nil), (*certmanager.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiTPP_To_certmanager_VenafiTPP(a.(*v1.VenafiTPP), b.(*certmanager.VenafiTPP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiTPP)(nil), (*v1.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiTPP_To_v1_VenafiTPP(a.(*certmanager.VenafiTPP), b.(*v1.VenafiTPP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.WebhookNotifier)(nil), (*certmanager.WebhookNotifier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_WebhookNotifier_To_certmanager_WebhookNotifier(a.(*v1.WebhookNotifier), b.(*certmanager.WebhookNotifier), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.WebhookNotifier)(nil), (*v1.WebhookNotifier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_WebhookNotifier_To_v1_WebhookNotifier(a.(*certmanager.WebhookNotifier), b.(*v1.WebhookNotifier), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.X509Subject)(nil), (*certmanager.X509Subject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_X509Subject_To_certmanager_X509Subject(a.(*v1.X509Subject), b.(*certmanager.X509Subject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.X509Subject)(nil), (*v1.X509Subject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_X509Subject_To_v1_X509Subject(a.(*certmanager.X509Subject), b.(*v1.X509Subject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*certmanager.CertificateSpec)(nil), (*v1.CertificateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSpec_To_v1_CertificateSpec(a.(*certmanager.CertificateSpec), b.(*v1.CertificateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1.CertificateSpec)(nil), (*certmanager.CertificateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateSpec_To_certmanager_CertificateSpec(a.(*v1.CertificateSpec), b.(*certmanager.CertificateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1_CABundleKeySelector_To_certmanager_CABundleKeySelector(in *v1.CABundleKeySelector, out *certmanager.CABundleKeySelector, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1_CABundleKeySelector_To_certmanager_CABundleKeySelector is an autogenerated conversion function.
+func Convert_v1_CABundleKeySelector_To_certmanager_CABundleKeySelector(in *v1.CABundleKeySelector, out *certmanager.CABundleKeySelector, s conversion.Scope) error {
+	return autoConvert_v1_CABundleKeySelector_To_certmanager_CABundleKeySelector(in, out, s)
+}
+
+func autoConvert_certmanager_CABundleKeySelector_To_v1_CABundleKeySelector(in *certmanager.CABundleKeySelector, out *v1.CABundleKeySelector, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
 	return nil
 }
 
+// Convert_certmanager_CABundleKeySelector_To_v1_CABundleKeySelector is an autogenerated conversion function.
+func Convert_certmanager_CABundleKeySelector_To_v1_CABundleKeySelector(in *certmanager.CABundleKeySelector, out *v1.CABundleKeySelector, s conversion.Scope) error {
+	return autoConvert_certmanager_CABundleKeySelector_To_v1_CABundleKeySelector(in, out, s)
+}
+
 func autoConvert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.NotBeforeBackdate = (*metav1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.SerialNumberBits = in.SerialNumberBits
+	out.TrackSerialNumbers = in.TrackSerialNumbers
+	out.KeyIdentifierMethod = certmanager.KeyIdentifierMethod(in.KeyIdentifierMethod)
+	out.CrossSignedChainSecretName = in.CrossSignedChainSecretName
 	return nil
 }
 
@@ -403,6 +930,11 @@ func autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, o
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.NotBeforeBackdate = (*metav1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.SerialNumberBits = in.SerialNumberBits
+	out.TrackSerialNumbers = in.TrackSerialNumbers
+	out.KeyIdentifierMethod = v1.KeyIdentifierMethod(in.KeyIdentifierMethod)
+	out.CrossSignedChainSecretName = in.CrossSignedChainSecretName
 	return nil
 }
 
@@ -443,6 +975,28 @@ func Convert_certmanager_Certificate_To_v1_Certificate(in *certmanager.Certifica
 	return autoConvert_certmanager_Certificate_To_v1_Certificate(in, out, s)
 }
 
+func autoConvert_v1_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle(in *v1.CertificateAdditionalCABundle, out *certmanager.CertificateAdditionalCABundle, s conversion.Scope) error {
+	out.ConfigMap = (*certmanager.CABundleKeySelector)(unsafe.Pointer(in.ConfigMap))
+	out.Secret = (*certmanager.CABundleKeySelector)(unsafe.Pointer(in.Secret))
+	return nil
+}
+
+// Convert_v1_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle is an autogenerated conversion function.
+func Convert_v1_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle(in *v1.CertificateAdditionalCABundle, out *certmanager.CertificateAdditionalCABundle, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalCABundle_To_v1_CertificateAdditionalCABundle(in *certmanager.CertificateAdditionalCABundle, out *v1.CertificateAdditionalCABundle, s conversion.Scope) error {
+	out.ConfigMap = (*v1.CABundleKeySelector)(unsafe.Pointer(in.ConfigMap))
+	out.Secret = (*v1.CABundleKeySelector)(unsafe.Pointer(in.Secret))
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalCABundle_To_v1_CertificateAdditionalCABundle is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalCABundle_To_v1_CertificateAdditionalCABundle(in *certmanager.CertificateAdditionalCABundle, out *v1.CertificateAdditionalCABundle, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalCABundle_To_v1_CertificateAdditionalCABundle(in, out, s)
+}
+
 func autoConvert_v1_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(in *v1.CertificateAdditionalOutputFormat, out *certmanager.CertificateAdditionalOutputFormat, s conversion.Scope) error {
 	out.Type = certmanager.CertificateOutputFormatType(in.Type)
 	return nil
@@ -463,6 +1017,28 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAddi
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1_CertificateClientIdentity_To_certmanager_CertificateClientIdentity(in *v1.CertificateClientIdentity, out *certmanager.CertificateClientIdentity, s conversion.Scope) error {
+	out.ServiceAccountName = in.ServiceAccountName
+	out.TrustDomain = in.TrustDomain
+	return nil
+}
+
+// Convert_v1_CertificateClientIdentity_To_certmanager_CertificateClientIdentity is an autogenerated conversion function.
+func Convert_v1_CertificateClientIdentity_To_certmanager_CertificateClientIdentity(in *v1.CertificateClientIdentity, out *certmanager.CertificateClientIdentity, s conversion.Scope) error {
+	return autoConvert_v1_CertificateClientIdentity_To_certmanager_CertificateClientIdentity(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateClientIdentity_To_v1_CertificateClientIdentity(in *certmanager.CertificateClientIdentity, out *v1.CertificateClientIdentity, s conversion.Scope) error {
+	out.ServiceAccountName = in.ServiceAccountName
+	out.TrustDomain = in.TrustDomain
+	return nil
+}
+
+// Convert_certmanager_CertificateClientIdentity_To_v1_CertificateClientIdentity is an autogenerated conversion function.
+func Convert_certmanager_CertificateClientIdentity_To_v1_CertificateClientIdentity(in *certmanager.CertificateClientIdentity, out *v1.CertificateClientIdentity, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateClientIdentity_To_v1_CertificateClientIdentity(in, out, s)
+}
+
 func autoConvert_v1_CertificateCondition_To_certmanager_CertificateCondition(in *v1.CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -493,6 +1069,38 @@ func Convert_certmanager_CertificateCondition_To_v1_CertificateCondition(in *cer
 	return autoConvert_certmanager_CertificateCondition_To_v1_CertificateCondition(in, out, s)
 }
 
+func autoConvert_v1_CertificateDetails_To_certmanager_CertificateDetails(in *v1.CertificateDetails, out *certmanager.CertificateDetails, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.FingerprintSHA256 = in.FingerprintSHA256
+	out.NotBefore = in.NotBefore
+	out.NotAfter = in.NotAfter
+	out.Subject = in.Subject
+	out.Issuer = in.Issuer
+	out.SubjectAltNames = in.SubjectAltNames
+	return nil
+}
+
+// Convert_v1_CertificateDetails_To_certmanager_CertificateDetails is an autogenerated conversion function.
+func Convert_v1_CertificateDetails_To_certmanager_CertificateDetails(in *v1.CertificateDetails, out *certmanager.CertificateDetails, s conversion.Scope) error {
+	return autoConvert_v1_CertificateDetails_To_certmanager_CertificateDetails(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateDetails_To_v1_CertificateDetails(in *certmanager.CertificateDetails, out *v1.CertificateDetails, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.FingerprintSHA256 = in.FingerprintSHA256
+	out.NotBefore = in.NotBefore
+	out.NotAfter = in.NotAfter
+	out.Subject = in.Subject
+	out.Issuer = in.Issuer
+	out.SubjectAltNames = in.SubjectAltNames
+	return nil
+}
+
+// Convert_certmanager_CertificateDetails_To_v1_CertificateDetails is an autogenerated conversion function.
+func Convert_certmanager_CertificateDetails_To_v1_CertificateDetails(in *certmanager.CertificateDetails, out *v1.CertificateDetails, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateDetails_To_v1_CertificateDetails(in, out, s)
+}
+
 func autoConvert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(in *v1.CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
 	if in.JKS != nil {
 		in, out := &in.JKS, &out.JKS
@@ -615,6 +1223,118 @@ func Convert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *c
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1_CertificateQuota_To_certmanager_CertificateQuota(in *v1.CertificateQuota, out *certmanager.CertificateQuota, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_CertificateQuotaSpec_To_certmanager_CertificateQuotaSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CertificateQuota_To_certmanager_CertificateQuota is an autogenerated conversion function.
+func Convert_v1_CertificateQuota_To_certmanager_CertificateQuota(in *v1.CertificateQuota, out *certmanager.CertificateQuota, s conversion.Scope) error {
+	return autoConvert_v1_CertificateQuota_To_certmanager_CertificateQuota(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateQuota_To_v1_CertificateQuota(in *certmanager.CertificateQuota, out *v1.CertificateQuota, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_CertificateQuotaSpec_To_v1_CertificateQuotaSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateQuota_To_v1_CertificateQuota is an autogenerated conversion function.
+func Convert_certmanager_CertificateQuota_To_v1_CertificateQuota(in *certmanager.CertificateQuota, out *v1.CertificateQuota, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateQuota_To_v1_CertificateQuota(in, out, s)
+}
+
+func autoConvert_v1_CertificateQuotaIssuanceRate_To_certmanager_CertificateQuotaIssuanceRate(in *v1.CertificateQuotaIssuanceRate, out *certmanager.CertificateQuotaIssuanceRate, s conversion.Scope) error {
+	out.MaxIssuances = in.MaxIssuances
+	out.Period = in.Period
+	return nil
+}
+
+// Convert_v1_CertificateQuotaIssuanceRate_To_certmanager_CertificateQuotaIssuanceRate is an autogenerated conversion function.
+func Convert_v1_CertificateQuotaIssuanceRate_To_certmanager_CertificateQuotaIssuanceRate(in *v1.CertificateQuotaIssuanceRate, out *certmanager.CertificateQuotaIssuanceRate, s conversion.Scope) error {
+	return autoConvert_v1_CertificateQuotaIssuanceRate_To_certmanager_CertificateQuotaIssuanceRate(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateQuotaIssuanceRate_To_v1_CertificateQuotaIssuanceRate(in *certmanager.CertificateQuotaIssuanceRate, out *v1.CertificateQuotaIssuanceRate, s conversion.Scope) error {
+	out.MaxIssuances = in.MaxIssuances
+	out.Period = in.Period
+	return nil
+}
+
+// Convert_certmanager_CertificateQuotaIssuanceRate_To_v1_CertificateQuotaIssuanceRate is an autogenerated conversion function.
+func Convert_certmanager_CertificateQuotaIssuanceRate_To_v1_CertificateQuotaIssuanceRate(in *certmanager.CertificateQuotaIssuanceRate, out *v1.CertificateQuotaIssuanceRate, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateQuotaIssuanceRate_To_v1_CertificateQuotaIssuanceRate(in, out, s)
+}
+
+func autoConvert_v1_CertificateQuotaList_To_certmanager_CertificateQuotaList(in *v1.CertificateQuotaList, out *certmanager.CertificateQuotaList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]certmanager.CertificateQuota, len(*in))
+		for i := range *in {
+			if err := Convert_v1_CertificateQuota_To_certmanager_CertificateQuota(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1_CertificateQuotaList_To_certmanager_CertificateQuotaList is an autogenerated conversion function.
+func Convert_v1_CertificateQuotaList_To_certmanager_CertificateQuotaList(in *v1.CertificateQuotaList, out *certmanager.CertificateQuotaList, s conversion.Scope) error {
+	return autoConvert_v1_CertificateQuotaList_To_certmanager_CertificateQuotaList(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateQuotaList_To_v1_CertificateQuotaList(in *certmanager.CertificateQuotaList, out *v1.CertificateQuotaList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.CertificateQuota, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateQuota_To_v1_CertificateQuota(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateQuotaList_To_v1_CertificateQuotaList is an autogenerated conversion function.
+func Convert_certmanager_CertificateQuotaList_To_v1_CertificateQuotaList(in *certmanager.CertificateQuotaList, out *v1.CertificateQuotaList, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateQuotaList_To_v1_CertificateQuotaList(in, out, s)
+}
+
+func autoConvert_v1_CertificateQuotaSpec_To_certmanager_CertificateQuotaSpec(in *v1.CertificateQuotaSpec, out *certmanager.CertificateQuotaSpec, s conversion.Scope) error {
+	out.MaxCertificates = (*int32)(unsafe.Pointer(in.MaxCertificates))
+	out.IssuanceRate = (*certmanager.CertificateQuotaIssuanceRate)(unsafe.Pointer(in.IssuanceRate))
+	return nil
+}
+
+// Convert_v1_CertificateQuotaSpec_To_certmanager_CertificateQuotaSpec is an autogenerated conversion function.
+func Convert_v1_CertificateQuotaSpec_To_certmanager_CertificateQuotaSpec(in *v1.CertificateQuotaSpec, out *certmanager.CertificateQuotaSpec, s conversion.Scope) error {
+	return autoConvert_v1_CertificateQuotaSpec_To_certmanager_CertificateQuotaSpec(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateQuotaSpec_To_v1_CertificateQuotaSpec(in *certmanager.CertificateQuotaSpec, out *v1.CertificateQuotaSpec, s conversion.Scope) error {
+	out.MaxCertificates = (*int32)(unsafe.Pointer(in.MaxCertificates))
+	out.IssuanceRate = (*v1.CertificateQuotaIssuanceRate)(unsafe.Pointer(in.IssuanceRate))
+	return nil
+}
+
+// Convert_certmanager_CertificateQuotaSpec_To_v1_CertificateQuotaSpec is an autogenerated conversion function.
+func Convert_certmanager_CertificateQuotaSpec_To_v1_CertificateQuotaSpec(in *certmanager.CertificateQuotaSpec, out *v1.CertificateQuotaSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateQuotaSpec_To_v1_CertificateQuotaSpec(in, out, s)
+}
+
 func autoConvert_v1_CertificateRequest_To_certmanager_CertificateRequest(in *v1.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -653,6 +1373,7 @@ func autoConvert_v1_CertificateRequestCondition_To_certmanager_CertificateReques
 	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
 	return nil
 }
 
@@ -667,6 +1388,7 @@ func autoConvert_certmanager_CertificateRequestCondition_To_v1_CertificateReques
 	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
 	return nil
 }
 
@@ -762,6 +1484,9 @@ func autoConvert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestSt
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.PickupID = in.PickupID
+	out.NextPollTime = (*metav1.Time)(unsafe.Pointer(in.NextPollTime))
+	out.CrossSignedChain = *(*[]byte)(unsafe.Pointer(&in.CrossSignedChain))
 	return nil
 }
 
@@ -775,6 +1500,9 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestSt
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.PickupID = in.PickupID
+	out.NextPollTime = (*metav1.Time)(unsafe.Pointer(in.NextPollTime))
+	out.CrossSignedChain = *(*[]byte)(unsafe.Pointer(&in.CrossSignedChain))
 	return nil
 }
 
@@ -783,22 +1511,46 @@ func Convert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in, out, s)
 }
 
-func autoConvert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
-	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
-	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+func autoConvert_v1_CertificateSMIME_To_certmanager_CertificateSMIME(in *v1.CertificateSMIME, out *certmanager.CertificateSMIME, s conversion.Scope) error {
+	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
 	return nil
 }
 
-// Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate is an autogenerated conversion function.
-func Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
-	return autoConvert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in, out, s)
+// Convert_v1_CertificateSMIME_To_certmanager_CertificateSMIME is an autogenerated conversion function.
+func Convert_v1_CertificateSMIME_To_certmanager_CertificateSMIME(in *v1.CertificateSMIME, out *certmanager.CertificateSMIME, s conversion.Scope) error {
+	return autoConvert_v1_CertificateSMIME_To_certmanager_CertificateSMIME(in, out, s)
 }
 
-func autoConvert_certmanager_CertificateSecretTemplate_To_v1_CertificateSecretTemplate(in *certmanager.CertificateSecretTemplate, out *v1.CertificateSecretTemplate, s conversion.Scope) error {
-	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
-	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
-	return nil
-}
+func autoConvert_certmanager_CertificateSMIME_To_v1_CertificateSMIME(in *certmanager.CertificateSMIME, out *v1.CertificateSMIME, s conversion.Scope) error {
+	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateSMIME_To_v1_CertificateSMIME is an autogenerated conversion function.
+func Convert_certmanager_CertificateSMIME_To_v1_CertificateSMIME(in *certmanager.CertificateSMIME, out *v1.CertificateSMIME, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSMIME_To_v1_CertificateSMIME(in, out, s)
+}
+
+func autoConvert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate is an autogenerated conversion function.
+func Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
+	return autoConvert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSecretTemplate_To_v1_CertificateSecretTemplate(in *certmanager.CertificateSecretTemplate, out *v1.CertificateSecretTemplate, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
 
 // Convert_certmanager_CertificateSecretTemplate_To_v1_CertificateSecretTemplate is an autogenerated conversion function.
 func Convert_certmanager_CertificateSecretTemplate_To_v1_CertificateSecretTemplate(in *certmanager.CertificateSecretTemplate, out *v1.CertificateSecretTemplate, s conversion.Scope) error {
@@ -835,6 +1587,31 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	out.ClientIdentity = (*certmanager.CertificateClientIdentity)(unsafe.Pointer(in.ClientIdentity))
+	if in.SMIME != nil {
+		in, out := &in.SMIME, &out.SMIME
+		*out = new(certmanager.CertificateSMIME)
+		if err := Convert_v1_CertificateSMIME_To_certmanager_CertificateSMIME(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SMIME = nil
+	}
+	if in.SplitIssuances != nil {
+		in, out := &in.SplitIssuances, &out.SplitIssuances
+		*out = make([]certmanager.CertificateSplitIssuance, len(*in))
+		for i := range *in {
+			if err := Convert_v1_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SplitIssuances = nil
+	}
+	out.ControllerName = in.ControllerName
+	out.CrossSignedChain = certmanager.CrossSignedChainPolicy(in.CrossSignedChain)
+	out.SecretDeletionPolicy = certmanager.SecretDeletionPolicy(in.SecretDeletionPolicy)
+	out.AdditionalCABundle = (*certmanager.CertificateAdditionalCABundle)(unsafe.Pointer(in.AdditionalCABundle))
 	return nil
 }
 
@@ -868,18 +1645,74 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]v1.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	out.ClientIdentity = (*v1.CertificateClientIdentity)(unsafe.Pointer(in.ClientIdentity))
+	if in.SMIME != nil {
+		in, out := &in.SMIME, &out.SMIME
+		*out = new(v1.CertificateSMIME)
+		if err := Convert_certmanager_CertificateSMIME_To_v1_CertificateSMIME(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SMIME = nil
+	}
+	if in.SplitIssuances != nil {
+		in, out := &in.SplitIssuances, &out.SplitIssuances
+		*out = make([]v1.CertificateSplitIssuance, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateSplitIssuance_To_v1_CertificateSplitIssuance(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SplitIssuances = nil
+	}
+	out.ControllerName = in.ControllerName
+	out.CrossSignedChain = v1.CrossSignedChainPolicy(in.CrossSignedChain)
+	out.SecretDeletionPolicy = v1.SecretDeletionPolicy(in.SecretDeletionPolicy)
+	out.AdditionalCABundle = (*v1.CertificateAdditionalCABundle)(unsafe.Pointer(in.AdditionalCABundle))
+	return nil
+}
+
+func autoConvert_v1_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(in *v1.CertificateSplitIssuance, out *certmanager.CertificateSplitIssuance, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance is an autogenerated conversion function.
+func Convert_v1_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(in *v1.CertificateSplitIssuance, out *certmanager.CertificateSplitIssuance, s conversion.Scope) error {
+	return autoConvert_v1_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSplitIssuance_To_v1_CertificateSplitIssuance(in *certmanager.CertificateSplitIssuance, out *v1.CertificateSplitIssuance, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
 	return nil
 }
 
+// Convert_certmanager_CertificateSplitIssuance_To_v1_CertificateSplitIssuance is an autogenerated conversion function.
+func Convert_certmanager_CertificateSplitIssuance_To_v1_CertificateSplitIssuance(in *certmanager.CertificateSplitIssuance, out *v1.CertificateSplitIssuance, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSplitIssuance_To_v1_CertificateSplitIssuance(in, out, s)
+}
+
 func autoConvert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.CertificateStatus, out *certmanager.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.CertificateCondition)(unsafe.Pointer(&in.Conditions))
 	out.LastFailureTime = (*metav1.Time)(unsafe.Pointer(in.LastFailureTime))
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.SerialNumber = in.SerialNumber
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.TransparencyLogEntry = (*certmanager.CertificateTransparencyLogEntry)(unsafe.Pointer(in.TransparencyLogEntry))
+	out.Certificate = (*certmanager.CertificateDetails)(unsafe.Pointer(in.Certificate))
 	return nil
 }
 
@@ -893,10 +1726,13 @@ func autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certm
 	out.LastFailureTime = (*metav1.Time)(unsafe.Pointer(in.LastFailureTime))
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.SerialNumber = in.SerialNumber
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.TransparencyLogEntry = (*v1.CertificateTransparencyLogEntry)(unsafe.Pointer(in.TransparencyLogEntry))
+	out.Certificate = (*v1.CertificateDetails)(unsafe.Pointer(in.Certificate))
 	return nil
 }
 
@@ -905,6 +1741,174 @@ func Convert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certmanag
 	return autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in, out, s)
 }
 
+func autoConvert_v1_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry(in *v1.CertificateTransparencyLogEntry, out *certmanager.CertificateTransparencyLogEntry, s conversion.Scope) error {
+	out.LogURL = in.LogURL
+	out.UUID = in.UUID
+	out.LogIndex = in.LogIndex
+	out.Fingerprint = in.Fingerprint
+	out.IntegratedTime = (*metav1.Time)(unsafe.Pointer(in.IntegratedTime))
+	out.InclusionProof = (*certmanager.CertificateTransparencyLogInclusionProof)(unsafe.Pointer(in.InclusionProof))
+	return nil
+}
+
+// Convert_v1_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry is an autogenerated conversion function.
+func Convert_v1_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry(in *v1.CertificateTransparencyLogEntry, out *certmanager.CertificateTransparencyLogEntry, s conversion.Scope) error {
+	return autoConvert_v1_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateTransparencyLogEntry_To_v1_CertificateTransparencyLogEntry(in *certmanager.CertificateTransparencyLogEntry, out *v1.CertificateTransparencyLogEntry, s conversion.Scope) error {
+	out.LogURL = in.LogURL
+	out.UUID = in.UUID
+	out.LogIndex = in.LogIndex
+	out.Fingerprint = in.Fingerprint
+	out.IntegratedTime = (*metav1.Time)(unsafe.Pointer(in.IntegratedTime))
+	out.InclusionProof = (*v1.CertificateTransparencyLogInclusionProof)(unsafe.Pointer(in.InclusionProof))
+	return nil
+}
+
+// Convert_certmanager_CertificateTransparencyLogEntry_To_v1_CertificateTransparencyLogEntry is an autogenerated conversion function.
+func Convert_certmanager_CertificateTransparencyLogEntry_To_v1_CertificateTransparencyLogEntry(in *certmanager.CertificateTransparencyLogEntry, out *v1.CertificateTransparencyLogEntry, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateTransparencyLogEntry_To_v1_CertificateTransparencyLogEntry(in, out, s)
+}
+
+func autoConvert_v1_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof(in *v1.CertificateTransparencyLogInclusionProof, out *certmanager.CertificateTransparencyLogInclusionProof, s conversion.Scope) error {
+	out.LogIndex = in.LogIndex
+	out.RootHash = in.RootHash
+	out.TreeSize = in.TreeSize
+	out.Hashes = *(*[]string)(unsafe.Pointer(&in.Hashes))
+	return nil
+}
+
+// Convert_v1_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof is an autogenerated conversion function.
+func Convert_v1_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof(in *v1.CertificateTransparencyLogInclusionProof, out *certmanager.CertificateTransparencyLogInclusionProof, s conversion.Scope) error {
+	return autoConvert_v1_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateTransparencyLogInclusionProof_To_v1_CertificateTransparencyLogInclusionProof(in *certmanager.CertificateTransparencyLogInclusionProof, out *v1.CertificateTransparencyLogInclusionProof, s conversion.Scope) error {
+	out.LogIndex = in.LogIndex
+	out.RootHash = in.RootHash
+	out.TreeSize = in.TreeSize
+	out.Hashes = *(*[]string)(unsafe.Pointer(&in.Hashes))
+	return nil
+}
+
+// Convert_certmanager_CertificateTransparencyLogInclusionProof_To_v1_CertificateTransparencyLogInclusionProof is an autogenerated conversion function.
+func Convert_certmanager_CertificateTransparencyLogInclusionProof_To_v1_CertificateTransparencyLogInclusionProof(in *certmanager.CertificateTransparencyLogInclusionProof, out *v1.CertificateTransparencyLogInclusionProof, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateTransparencyLogInclusionProof_To_v1_CertificateTransparencyLogInclusionProof(in, out, s)
+}
+
+func autoConvert_v1_ClusterCertificateReport_To_certmanager_ClusterCertificateReport(in *v1.ClusterCertificateReport, out *certmanager.ClusterCertificateReport, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_ClusterCertificateReportSummary_To_certmanager_ClusterCertificateReportSummary(&in.Summary, &out.Summary, s); err != nil {
+		return err
+	}
+	out.Issuers = *(*[]certmanager.ClusterCertificateReportIssuer)(unsafe.Pointer(&in.Issuers))
+	return nil
+}
+
+// Convert_v1_ClusterCertificateReport_To_certmanager_ClusterCertificateReport is an autogenerated conversion function.
+func Convert_v1_ClusterCertificateReport_To_certmanager_ClusterCertificateReport(in *v1.ClusterCertificateReport, out *certmanager.ClusterCertificateReport, s conversion.Scope) error {
+	return autoConvert_v1_ClusterCertificateReport_To_certmanager_ClusterCertificateReport(in, out, s)
+}
+
+func autoConvert_certmanager_ClusterCertificateReport_To_v1_ClusterCertificateReport(in *certmanager.ClusterCertificateReport, out *v1.ClusterCertificateReport, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_ClusterCertificateReportSummary_To_v1_ClusterCertificateReportSummary(&in.Summary, &out.Summary, s); err != nil {
+		return err
+	}
+	out.Issuers = *(*[]v1.ClusterCertificateReportIssuer)(unsafe.Pointer(&in.Issuers))
+	return nil
+}
+
+// Convert_certmanager_ClusterCertificateReport_To_v1_ClusterCertificateReport is an autogenerated conversion function.
+func Convert_certmanager_ClusterCertificateReport_To_v1_ClusterCertificateReport(in *certmanager.ClusterCertificateReport, out *v1.ClusterCertificateReport, s conversion.Scope) error {
+	return autoConvert_certmanager_ClusterCertificateReport_To_v1_ClusterCertificateReport(in, out, s)
+}
+
+func autoConvert_v1_ClusterCertificateReportIssuer_To_certmanager_ClusterCertificateReportIssuer(in *v1.ClusterCertificateReportIssuer, out *certmanager.ClusterCertificateReportIssuer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Group = in.Group
+	out.Namespace = in.Namespace
+	if err := Convert_v1_ClusterCertificateReportSummary_To_certmanager_ClusterCertificateReportSummary(&in.Summary, &out.Summary, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ClusterCertificateReportIssuer_To_certmanager_ClusterCertificateReportIssuer is an autogenerated conversion function.
+func Convert_v1_ClusterCertificateReportIssuer_To_certmanager_ClusterCertificateReportIssuer(in *v1.ClusterCertificateReportIssuer, out *certmanager.ClusterCertificateReportIssuer, s conversion.Scope) error {
+	return autoConvert_v1_ClusterCertificateReportIssuer_To_certmanager_ClusterCertificateReportIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_ClusterCertificateReportIssuer_To_v1_ClusterCertificateReportIssuer(in *certmanager.ClusterCertificateReportIssuer, out *v1.ClusterCertificateReportIssuer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Group = in.Group
+	out.Namespace = in.Namespace
+	if err := Convert_certmanager_ClusterCertificateReportSummary_To_v1_ClusterCertificateReportSummary(&in.Summary, &out.Summary, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ClusterCertificateReportIssuer_To_v1_ClusterCertificateReportIssuer is an autogenerated conversion function.
+func Convert_certmanager_ClusterCertificateReportIssuer_To_v1_ClusterCertificateReportIssuer(in *certmanager.ClusterCertificateReportIssuer, out *v1.ClusterCertificateReportIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_ClusterCertificateReportIssuer_To_v1_ClusterCertificateReportIssuer(in, out, s)
+}
+
+func autoConvert_v1_ClusterCertificateReportList_To_certmanager_ClusterCertificateReportList(in *v1.ClusterCertificateReportList, out *certmanager.ClusterCertificateReportList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]certmanager.ClusterCertificateReport)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1_ClusterCertificateReportList_To_certmanager_ClusterCertificateReportList is an autogenerated conversion function.
+func Convert_v1_ClusterCertificateReportList_To_certmanager_ClusterCertificateReportList(in *v1.ClusterCertificateReportList, out *certmanager.ClusterCertificateReportList, s conversion.Scope) error {
+	return autoConvert_v1_ClusterCertificateReportList_To_certmanager_ClusterCertificateReportList(in, out, s)
+}
+
+func autoConvert_certmanager_ClusterCertificateReportList_To_v1_ClusterCertificateReportList(in *certmanager.ClusterCertificateReportList, out *v1.ClusterCertificateReportList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1.ClusterCertificateReport)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_certmanager_ClusterCertificateReportList_To_v1_ClusterCertificateReportList is an autogenerated conversion function.
+func Convert_certmanager_ClusterCertificateReportList_To_v1_ClusterCertificateReportList(in *certmanager.ClusterCertificateReportList, out *v1.ClusterCertificateReportList, s conversion.Scope) error {
+	return autoConvert_certmanager_ClusterCertificateReportList_To_v1_ClusterCertificateReportList(in, out, s)
+}
+
+func autoConvert_v1_ClusterCertificateReportSummary_To_certmanager_ClusterCertificateReportSummary(in *v1.ClusterCertificateReportSummary, out *certmanager.ClusterCertificateReportSummary, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Ready = in.Ready
+	out.ExpiringWithin7Days = in.ExpiringWithin7Days
+	out.ExpiringWithin30Days = in.ExpiringWithin30Days
+	out.Expired = in.Expired
+	out.FailedIssuances = in.FailedIssuances
+	return nil
+}
+
+// Convert_v1_ClusterCertificateReportSummary_To_certmanager_ClusterCertificateReportSummary is an autogenerated conversion function.
+func Convert_v1_ClusterCertificateReportSummary_To_certmanager_ClusterCertificateReportSummary(in *v1.ClusterCertificateReportSummary, out *certmanager.ClusterCertificateReportSummary, s conversion.Scope) error {
+	return autoConvert_v1_ClusterCertificateReportSummary_To_certmanager_ClusterCertificateReportSummary(in, out, s)
+}
+
+func autoConvert_certmanager_ClusterCertificateReportSummary_To_v1_ClusterCertificateReportSummary(in *certmanager.ClusterCertificateReportSummary, out *v1.ClusterCertificateReportSummary, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Ready = in.Ready
+	out.ExpiringWithin7Days = in.ExpiringWithin7Days
+	out.ExpiringWithin30Days = in.ExpiringWithin30Days
+	out.Expired = in.Expired
+	out.FailedIssuances = in.FailedIssuances
+	return nil
+}
+
+// Convert_certmanager_ClusterCertificateReportSummary_To_v1_ClusterCertificateReportSummary is an autogenerated conversion function.
+func Convert_certmanager_ClusterCertificateReportSummary_To_v1_ClusterCertificateReportSummary(in *certmanager.ClusterCertificateReportSummary, out *v1.ClusterCertificateReportSummary, s conversion.Scope) error {
+	return autoConvert_certmanager_ClusterCertificateReportSummary_To_v1_ClusterCertificateReportSummary(in, out, s)
+}
+
 func autoConvert_v1_ClusterIssuer_To_certmanager_ClusterIssuer(in *v1.ClusterIssuer, out *certmanager.ClusterIssuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -979,151 +1983,1073 @@ func Convert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in *certmanag
 	return autoConvert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in, out, s)
 }
 
-func autoConvert_v1_Issuer_To_certmanager_Issuer(in *v1.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
-	out.ObjectMeta = in.ObjectMeta
-	if err := Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
+func autoConvert_v1_DelegateIssuer_To_certmanager_DelegateIssuer(in *v1.DelegateIssuer, out *certmanager.DelegateIssuer, s conversion.Scope) error {
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]certmanager.DelegateIssuerRoute, len(*in))
+		for i := range *in {
+			if err := Convert_v1_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
+	return nil
+}
+
+// Convert_v1_DelegateIssuer_To_certmanager_DelegateIssuer is an autogenerated conversion function.
+func Convert_v1_DelegateIssuer_To_certmanager_DelegateIssuer(in *v1.DelegateIssuer, out *certmanager.DelegateIssuer, s conversion.Scope) error {
+	return autoConvert_v1_DelegateIssuer_To_certmanager_DelegateIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_DelegateIssuer_To_v1_DelegateIssuer(in *certmanager.DelegateIssuer, out *v1.DelegateIssuer, s conversion.Scope) error {
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]v1.DelegateIssuerRoute, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_DelegateIssuerRoute_To_v1_DelegateIssuerRoute(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_DelegateIssuer_To_v1_DelegateIssuer is an autogenerated conversion function.
+func Convert_certmanager_DelegateIssuer_To_v1_DelegateIssuer(in *certmanager.DelegateIssuer, out *v1.DelegateIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_DelegateIssuer_To_v1_DelegateIssuer(in, out, s)
+}
+
+func autoConvert_v1_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(in *v1.DelegateIssuerRoute, out *certmanager.DelegateIssuerRoute, s conversion.Scope) error {
+	out.NamespaceSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
-	if err := Convert_v1_IssuerStatus_To_certmanager_IssuerStatus(&in.Status, &out.Status, s); err != nil {
+	return nil
+}
+
+// Convert_v1_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute is an autogenerated conversion function.
+func Convert_v1_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(in *v1.DelegateIssuerRoute, out *certmanager.DelegateIssuerRoute, s conversion.Scope) error {
+	return autoConvert_v1_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(in, out, s)
+}
+
+func autoConvert_certmanager_DelegateIssuerRoute_To_v1_DelegateIssuerRoute(in *certmanager.DelegateIssuerRoute, out *v1.DelegateIssuerRoute, s conversion.Scope) error {
+	out.NamespaceSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	return nil
 }
 
-// Convert_v1_Issuer_To_certmanager_Issuer is an autogenerated conversion function.
-func Convert_v1_Issuer_To_certmanager_Issuer(in *v1.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
-	return autoConvert_v1_Issuer_To_certmanager_Issuer(in, out, s)
+// Convert_certmanager_DelegateIssuerRoute_To_v1_DelegateIssuerRoute is an autogenerated conversion function.
+func Convert_certmanager_DelegateIssuerRoute_To_v1_DelegateIssuerRoute(in *certmanager.DelegateIssuerRoute, out *v1.DelegateIssuerRoute, s conversion.Scope) error {
+	return autoConvert_certmanager_DelegateIssuerRoute_To_v1_DelegateIssuerRoute(in, out, s)
 }
 
-func autoConvert_certmanager_Issuer_To_v1_Issuer(in *certmanager.Issuer, out *v1.Issuer, s conversion.Scope) error {
+func autoConvert_v1_EmailNotifier_To_certmanager_EmailNotifier(in *v1.EmailNotifier, out *certmanager.EmailNotifier, s conversion.Scope) error {
+	out.SMTPServer = in.SMTPServer
+	out.From = in.From
+	out.To = *(*[]string)(unsafe.Pointer(&in.To))
+	out.Username = in.Username
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1_EmailNotifier_To_certmanager_EmailNotifier is an autogenerated conversion function.
+func Convert_v1_EmailNotifier_To_certmanager_EmailNotifier(in *v1.EmailNotifier, out *certmanager.EmailNotifier, s conversion.Scope) error {
+	return autoConvert_v1_EmailNotifier_To_certmanager_EmailNotifier(in, out, s)
+}
+
+func autoConvert_certmanager_EmailNotifier_To_v1_EmailNotifier(in *certmanager.EmailNotifier, out *v1.EmailNotifier, s conversion.Scope) error {
+	out.SMTPServer = in.SMTPServer
+	out.From = in.From
+	out.To = *(*[]string)(unsafe.Pointer(&in.To))
+	out.Username = in.Username
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_EmailNotifier_To_v1_EmailNotifier is an autogenerated conversion function.
+func Convert_certmanager_EmailNotifier_To_v1_EmailNotifier(in *certmanager.EmailNotifier, out *v1.EmailNotifier, s conversion.Scope) error {
+	return autoConvert_certmanager_EmailNotifier_To_v1_EmailNotifier(in, out, s)
+}
+
+func autoConvert_v1_IssuanceHook_To_certmanager_IssuanceHook(in *v1.IssuanceHook, out *certmanager.IssuanceHook, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
-	if err := Convert_certmanager_IssuerSpec_To_v1_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
+	if err := Convert_v1_IssuanceHookSpec_To_certmanager_IssuanceHookSpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
 	}
-	if err := Convert_certmanager_IssuerStatus_To_v1_IssuerStatus(&in.Status, &out.Status, s); err != nil {
+	return nil
+}
+
+// Convert_v1_IssuanceHook_To_certmanager_IssuanceHook is an autogenerated conversion function.
+func Convert_v1_IssuanceHook_To_certmanager_IssuanceHook(in *v1.IssuanceHook, out *certmanager.IssuanceHook, s conversion.Scope) error {
+	return autoConvert_v1_IssuanceHook_To_certmanager_IssuanceHook(in, out, s)
+}
+
+func autoConvert_certmanager_IssuanceHook_To_v1_IssuanceHook(in *certmanager.IssuanceHook, out *v1.IssuanceHook, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_IssuanceHookSpec_To_v1_IssuanceHookSpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
 	}
 	return nil
 }
 
-// Convert_certmanager_Issuer_To_v1_Issuer is an autogenerated conversion function.
-func Convert_certmanager_Issuer_To_v1_Issuer(in *certmanager.Issuer, out *v1.Issuer, s conversion.Scope) error {
-	return autoConvert_certmanager_Issuer_To_v1_Issuer(in, out, s)
+// Convert_certmanager_IssuanceHook_To_v1_IssuanceHook is an autogenerated conversion function.
+func Convert_certmanager_IssuanceHook_To_v1_IssuanceHook(in *certmanager.IssuanceHook, out *v1.IssuanceHook, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuanceHook_To_v1_IssuanceHook(in, out, s)
 }
 
-func autoConvert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
-	out.Type = certmanager.IssuerConditionType(in.Type)
-	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
-	out.Reason = in.Reason
-	out.Message = in.Message
-	out.ObservedGeneration = in.ObservedGeneration
+func autoConvert_v1_IssuanceHookList_To_certmanager_IssuanceHookList(in *v1.IssuanceHookList, out *certmanager.IssuanceHookList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]certmanager.IssuanceHook, len(*in))
+		for i := range *in {
+			if err := Convert_v1_IssuanceHook_To_certmanager_IssuanceHook(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
-// Convert_v1_IssuerCondition_To_certmanager_IssuerCondition is an autogenerated conversion function.
-func Convert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
-	return autoConvert_v1_IssuerCondition_To_certmanager_IssuerCondition(in, out, s)
+// Convert_v1_IssuanceHookList_To_certmanager_IssuanceHookList is an autogenerated conversion function.
+func Convert_v1_IssuanceHookList_To_certmanager_IssuanceHookList(in *v1.IssuanceHookList, out *certmanager.IssuanceHookList, s conversion.Scope) error {
+	return autoConvert_v1_IssuanceHookList_To_certmanager_IssuanceHookList(in, out, s)
 }
 
-func autoConvert_certmanager_IssuerCondition_To_v1_IssuerCondition(in *certmanager.IssuerCondition, out *v1.IssuerCondition, s conversion.Scope) error {
-	out.Type = v1.IssuerConditionType(in.Type)
-	out.Status = apismetav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
-	out.Reason = in.Reason
-	out.Message = in.Message
-	out.ObservedGeneration = in.ObservedGeneration
+func autoConvert_certmanager_IssuanceHookList_To_v1_IssuanceHookList(in *certmanager.IssuanceHookList, out *v1.IssuanceHookList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.IssuanceHook, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_IssuanceHook_To_v1_IssuanceHook(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
-// Convert_certmanager_IssuerCondition_To_v1_IssuerCondition is an autogenerated conversion function.
-func Convert_certmanager_IssuerCondition_To_v1_IssuerCondition(in *certmanager.IssuerCondition, out *v1.IssuerCondition, s conversion.Scope) error {
-	return autoConvert_certmanager_IssuerCondition_To_v1_IssuerCondition(in, out, s)
+// Convert_certmanager_IssuanceHookList_To_v1_IssuanceHookList is an autogenerated conversion function.
+func Convert_certmanager_IssuanceHookList_To_v1_IssuanceHookList(in *certmanager.IssuanceHookList, out *v1.IssuanceHookList, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuanceHookList_To_v1_IssuanceHookList(in, out, s)
+}
+
+func autoConvert_v1_IssuanceHookSpec_To_certmanager_IssuanceHookSpec(in *v1.IssuanceHookSpec, out *certmanager.IssuanceHookSpec, s conversion.Scope) error {
+	out.Phase = certmanager.IssuanceHookPhase(in.Phase)
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.FailurePolicy = certmanager.IssuanceHookFailurePolicy(in.FailurePolicy)
+	out.TimeoutSeconds = (*int32)(unsafe.Pointer(in.TimeoutSeconds))
+	return nil
+}
+
+// Convert_v1_IssuanceHookSpec_To_certmanager_IssuanceHookSpec is an autogenerated conversion function.
+func Convert_v1_IssuanceHookSpec_To_certmanager_IssuanceHookSpec(in *v1.IssuanceHookSpec, out *certmanager.IssuanceHookSpec, s conversion.Scope) error {
+	return autoConvert_v1_IssuanceHookSpec_To_certmanager_IssuanceHookSpec(in, out, s)
+}
+
+func autoConvert_certmanager_IssuanceHookSpec_To_v1_IssuanceHookSpec(in *certmanager.IssuanceHookSpec, out *v1.IssuanceHookSpec, s conversion.Scope) error {
+	out.Phase = v1.IssuanceHookPhase(in.Phase)
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.FailurePolicy = v1.IssuanceHookFailurePolicy(in.FailurePolicy)
+	out.TimeoutSeconds = (*int32)(unsafe.Pointer(in.TimeoutSeconds))
+	return nil
+}
+
+// Convert_certmanager_IssuanceHookSpec_To_v1_IssuanceHookSpec is an autogenerated conversion function.
+func Convert_certmanager_IssuanceHookSpec_To_v1_IssuanceHookSpec(in *certmanager.IssuanceHookSpec, out *v1.IssuanceHookSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuanceHookSpec_To_v1_IssuanceHookSpec(in, out, s)
+}
+
+func autoConvert_v1_FakeIssuer_To_certmanager_FakeIssuer(in *v1.FakeIssuer, out *certmanager.FakeIssuer, s conversion.Scope) error {
+	out.Latency = (*metav1.Duration)(unsafe.Pointer(in.Latency))
+	out.FailurePercentage = in.FailurePercentage
+	out.FailureMessage = in.FailureMessage
+	return nil
+}
+
+// Convert_v1_FakeIssuer_To_certmanager_FakeIssuer is an autogenerated conversion function.
+func Convert_v1_FakeIssuer_To_certmanager_FakeIssuer(in *v1.FakeIssuer, out *certmanager.FakeIssuer, s conversion.Scope) error {
+	return autoConvert_v1_FakeIssuer_To_certmanager_FakeIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_FakeIssuer_To_v1_FakeIssuer(in *certmanager.FakeIssuer, out *v1.FakeIssuer, s conversion.Scope) error {
+	out.Latency = (*metav1.Duration)(unsafe.Pointer(in.Latency))
+	out.FailurePercentage = in.FailurePercentage
+	out.FailureMessage = in.FailureMessage
+	return nil
+}
+
+// Convert_certmanager_FakeIssuer_To_v1_FakeIssuer is an autogenerated conversion function.
+func Convert_certmanager_FakeIssuer_To_v1_FakeIssuer(in *certmanager.FakeIssuer, out *v1.FakeIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_FakeIssuer_To_v1_FakeIssuer(in, out, s)
+}
+
+func autoConvert_v1_Issuer_To_certmanager_Issuer(in *v1.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_IssuerStatus_To_certmanager_IssuerStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_Issuer_To_certmanager_Issuer is an autogenerated conversion function.
+func Convert_v1_Issuer_To_certmanager_Issuer(in *v1.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
+	return autoConvert_v1_Issuer_To_certmanager_Issuer(in, out, s)
+}
+
+func autoConvert_certmanager_Issuer_To_v1_Issuer(in *certmanager.Issuer, out *v1.Issuer, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_IssuerSpec_To_v1_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_certmanager_IssuerStatus_To_v1_IssuerStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_Issuer_To_v1_Issuer is an autogenerated conversion function.
+func Convert_certmanager_Issuer_To_v1_Issuer(in *certmanager.Issuer, out *v1.Issuer, s conversion.Scope) error {
+	return autoConvert_certmanager_Issuer_To_v1_Issuer(in, out, s)
+}
+
+func autoConvert_v1_IssuerCapabilities_To_certmanager_IssuerCapabilities(in *v1.IssuerCapabilities, out *certmanager.IssuerCapabilities, s conversion.Scope) error {
+	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UnsupportedUsagesPolicy = certmanager.UnsupportedUsagesPolicy(in.UnsupportedUsagesPolicy)
+	return nil
+}
+
+// Convert_v1_IssuerCapabilities_To_certmanager_IssuerCapabilities is an autogenerated conversion function.
+func Convert_v1_IssuerCapabilities_To_certmanager_IssuerCapabilities(in *v1.IssuerCapabilities, out *certmanager.IssuerCapabilities, s conversion.Scope) error {
+	return autoConvert_v1_IssuerCapabilities_To_certmanager_IssuerCapabilities(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerCapabilities_To_v1_IssuerCapabilities(in *certmanager.IssuerCapabilities, out *v1.IssuerCapabilities, s conversion.Scope) error {
+	out.Usages = *(*[]v1.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UnsupportedUsagesPolicy = v1.UnsupportedUsagesPolicy(in.UnsupportedUsagesPolicy)
+	return nil
+}
+
+// Convert_certmanager_IssuerCapabilities_To_v1_IssuerCapabilities is an autogenerated conversion function.
+func Convert_certmanager_IssuerCapabilities_To_v1_IssuerCapabilities(in *certmanager.IssuerCapabilities, out *v1.IssuerCapabilities, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerCapabilities_To_v1_IssuerCapabilities(in, out, s)
+}
+
+func autoConvert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
+	out.Type = certmanager.IssuerConditionType(in.Type)
+	out.Status = meta.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_v1_IssuerCondition_To_certmanager_IssuerCondition is an autogenerated conversion function.
+func Convert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
+	return autoConvert_v1_IssuerCondition_To_certmanager_IssuerCondition(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerCondition_To_v1_IssuerCondition(in *certmanager.IssuerCondition, out *v1.IssuerCondition, s conversion.Scope) error {
+	out.Type = v1.IssuerConditionType(in.Type)
+	out.Status = apismetav1.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_certmanager_IssuerCondition_To_v1_IssuerCondition is an autogenerated conversion function.
+func Convert_certmanager_IssuerCondition_To_v1_IssuerCondition(in *certmanager.IssuerCondition, out *v1.IssuerCondition, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerCondition_To_v1_IssuerCondition(in, out, s)
+}
+
+func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig, out *certmanager.IssuerConfig, s conversion.Scope) error {
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = new(acme.ACMEIssuer)
+		if err := acmev1.Convert_v1_ACMEIssuer_To_acme_ACMEIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ACME = nil
+	}
+	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(certmanager.VaultIssuer)
+		if err := Convert_v1_VaultIssuer_To_certmanager_VaultIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vault = nil
+	}
+	out.SelfSigned = (*certmanager.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
+	if in.Venafi != nil {
+		in, out := &in.Venafi, &out.Venafi
+		*out = new(certmanager.VenafiIssuer)
+		if err := Convert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Venafi = nil
+	}
+	out.Fake = (*certmanager.FakeIssuer)(unsafe.Pointer(in.Fake))
+	if in.Delegate != nil {
+		in, out := &in.Delegate, &out.Delegate
+		*out = new(certmanager.DelegateIssuer)
+		if err := Convert_v1_DelegateIssuer_To_certmanager_DelegateIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Delegate = nil
+	}
+	out.Offline = (*certmanager.OfflineIssuer)(unsafe.Pointer(in.Offline))
+	return nil
+}
+
+// Convert_v1_IssuerConfig_To_certmanager_IssuerConfig is an autogenerated conversion function.
+func Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig, out *certmanager.IssuerConfig, s conversion.Scope) error {
+	return autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.IssuerConfig, out *v1.IssuerConfig, s conversion.Scope) error {
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = new(apisacmev1.ACMEIssuer)
+		if err := acmev1.Convert_acme_ACMEIssuer_To_v1_ACMEIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ACME = nil
+	}
+	out.CA = (*v1.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(v1.VaultIssuer)
+		if err := Convert_certmanager_VaultIssuer_To_v1_VaultIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vault = nil
+	}
+	out.SelfSigned = (*v1.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
+	if in.Venafi != nil {
+		in, out := &in.Venafi, &out.Venafi
+		*out = new(v1.VenafiIssuer)
+		if err := Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Venafi = nil
+	}
+	out.Fake = (*v1.FakeIssuer)(unsafe.Pointer(in.Fake))
+	if in.Delegate != nil {
+		in, out := &in.Delegate, &out.Delegate
+		*out = new(v1.DelegateIssuer)
+		if err := Convert_certmanager_DelegateIssuer_To_v1_DelegateIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Delegate = nil
+	}
+	out.Offline = (*v1.OfflineIssuer)(unsafe.Pointer(in.Offline))
+	return nil
+}
+
+// Convert_certmanager_IssuerConfig_To_v1_IssuerConfig is an autogenerated conversion function.
+func Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.IssuerConfig, out *v1.IssuerConfig, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in, out, s)
+}
+
+func autoConvert_v1_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance(in *v1.IssuerDurationTolerance, out *certmanager.IssuerDurationTolerance, s conversion.Scope) error {
+	out.MaxShortening = in.MaxShortening
+	return nil
+}
+
+// Convert_v1_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance is an autogenerated conversion function.
+func Convert_v1_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance(in *v1.IssuerDurationTolerance, out *certmanager.IssuerDurationTolerance, s conversion.Scope) error {
+	return autoConvert_v1_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerDurationTolerance_To_v1_IssuerDurationTolerance(in *certmanager.IssuerDurationTolerance, out *v1.IssuerDurationTolerance, s conversion.Scope) error {
+	out.MaxShortening = in.MaxShortening
+	return nil
+}
+
+// Convert_certmanager_IssuerDurationTolerance_To_v1_IssuerDurationTolerance is an autogenerated conversion function.
+func Convert_certmanager_IssuerDurationTolerance_To_v1_IssuerDurationTolerance(in *certmanager.IssuerDurationTolerance, out *v1.IssuerDurationTolerance, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerDurationTolerance_To_v1_IssuerDurationTolerance(in, out, s)
+}
+
+func autoConvert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in *v1.IssuerHTTPClient, out *certmanager.IssuerHTTPClient, s conversion.Scope) error {
+	out.ProxyURL = in.ProxyURL
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient is an autogenerated conversion function.
+func Convert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in *v1.IssuerHTTPClient, out *certmanager.IssuerHTTPClient, s conversion.Scope) error {
+	return autoConvert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient(in *certmanager.IssuerHTTPClient, out *v1.IssuerHTTPClient, s conversion.Scope) error {
+	out.ProxyURL = in.ProxyURL
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient is an autogenerated conversion function.
+func Convert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient(in *certmanager.IssuerHTTPClient, out *v1.IssuerHTTPClient, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient(in, out, s)
+}
+
+func autoConvert_v1_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA(in *v1.IssuerIntermediateCA, out *certmanager.IssuerIntermediateCA, s conversion.Scope) error {
+	out.NamespaceSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.IssuerName = in.IssuerName
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	return nil
+}
+
+// Convert_v1_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA is an autogenerated conversion function.
+func Convert_v1_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA(in *v1.IssuerIntermediateCA, out *certmanager.IssuerIntermediateCA, s conversion.Scope) error {
+	return autoConvert_v1_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerIntermediateCA_To_v1_IssuerIntermediateCA(in *certmanager.IssuerIntermediateCA, out *v1.IssuerIntermediateCA, s conversion.Scope) error {
+	out.NamespaceSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.IssuerName = in.IssuerName
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	return nil
+}
+
+// Convert_certmanager_IssuerIntermediateCA_To_v1_IssuerIntermediateCA is an autogenerated conversion function.
+func Convert_certmanager_IssuerIntermediateCA_To_v1_IssuerIntermediateCA(in *certmanager.IssuerIntermediateCA, out *v1.IssuerIntermediateCA, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerIntermediateCA_To_v1_IssuerIntermediateCA(in, out, s)
+}
+
+func autoConvert_v1_IssuerList_To_certmanager_IssuerList(in *v1.IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]certmanager.Issuer, len(*in))
+		for i := range *in {
+			if err := Convert_v1_Issuer_To_certmanager_Issuer(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1_IssuerList_To_certmanager_IssuerList is an autogenerated conversion function.
+func Convert_v1_IssuerList_To_certmanager_IssuerList(in *v1.IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
+	return autoConvert_v1_IssuerList_To_certmanager_IssuerList(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerList_To_v1_IssuerList(in *certmanager.IssuerList, out *v1.IssuerList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.Issuer, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_Issuer_To_v1_Issuer(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_IssuerList_To_v1_IssuerList is an autogenerated conversion function.
+func Convert_certmanager_IssuerList_To_v1_IssuerList(in *certmanager.IssuerList, out *v1.IssuerList, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerList_To_v1_IssuerList(in, out, s)
+}
+
+func autoConvert_v1_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage(in *v1.IssuerNamespaceUsage, out *certmanager.IssuerNamespaceUsage, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Certificates = in.Certificates
+	out.ReadyCertificates = in.ReadyCertificates
+	out.RecentFailures = in.RecentFailures
+	return nil
+}
+
+// Convert_v1_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage is an autogenerated conversion function.
+func Convert_v1_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage(in *v1.IssuerNamespaceUsage, out *certmanager.IssuerNamespaceUsage, s conversion.Scope) error {
+	return autoConvert_v1_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerNamespaceUsage_To_v1_IssuerNamespaceUsage(in *certmanager.IssuerNamespaceUsage, out *v1.IssuerNamespaceUsage, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Certificates = in.Certificates
+	out.ReadyCertificates = in.ReadyCertificates
+	out.RecentFailures = in.RecentFailures
+	return nil
+}
+
+// Convert_certmanager_IssuerNamespaceUsage_To_v1_IssuerNamespaceUsage is an autogenerated conversion function.
+func Convert_certmanager_IssuerNamespaceUsage_To_v1_IssuerNamespaceUsage(in *certmanager.IssuerNamespaceUsage, out *v1.IssuerNamespaceUsage, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerNamespaceUsage_To_v1_IssuerNamespaceUsage(in, out, s)
+}
+
+func autoConvert_v1_IssuerReferenceGrant_To_certmanager_IssuerReferenceGrant(in *v1.IssuerReferenceGrant, out *certmanager.IssuerReferenceGrant, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_IssuerReferenceGrantSpec_To_certmanager_IssuerReferenceGrantSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_IssuerReferenceGrant_To_certmanager_IssuerReferenceGrant is an autogenerated conversion function.
+func Convert_v1_IssuerReferenceGrant_To_certmanager_IssuerReferenceGrant(in *v1.IssuerReferenceGrant, out *certmanager.IssuerReferenceGrant, s conversion.Scope) error {
+	return autoConvert_v1_IssuerReferenceGrant_To_certmanager_IssuerReferenceGrant(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerReferenceGrant_To_v1_IssuerReferenceGrant(in *certmanager.IssuerReferenceGrant, out *v1.IssuerReferenceGrant, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_IssuerReferenceGrantSpec_To_v1_IssuerReferenceGrantSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_IssuerReferenceGrant_To_v1_IssuerReferenceGrant is an autogenerated conversion function.
+func Convert_certmanager_IssuerReferenceGrant_To_v1_IssuerReferenceGrant(in *certmanager.IssuerReferenceGrant, out *v1.IssuerReferenceGrant, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerReferenceGrant_To_v1_IssuerReferenceGrant(in, out, s)
+}
+
+func autoConvert_v1_IssuerReferenceGrantFrom_To_certmanager_IssuerReferenceGrantFrom(in *v1.IssuerReferenceGrantFrom, out *certmanager.IssuerReferenceGrantFrom, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1_IssuerReferenceGrantFrom_To_certmanager_IssuerReferenceGrantFrom is an autogenerated conversion function.
+func Convert_v1_IssuerReferenceGrantFrom_To_certmanager_IssuerReferenceGrantFrom(in *v1.IssuerReferenceGrantFrom, out *certmanager.IssuerReferenceGrantFrom, s conversion.Scope) error {
+	return autoConvert_v1_IssuerReferenceGrantFrom_To_certmanager_IssuerReferenceGrantFrom(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerReferenceGrantFrom_To_v1_IssuerReferenceGrantFrom(in *certmanager.IssuerReferenceGrantFrom, out *v1.IssuerReferenceGrantFrom, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_certmanager_IssuerReferenceGrantFrom_To_v1_IssuerReferenceGrantFrom is an autogenerated conversion function.
+func Convert_certmanager_IssuerReferenceGrantFrom_To_v1_IssuerReferenceGrantFrom(in *certmanager.IssuerReferenceGrantFrom, out *v1.IssuerReferenceGrantFrom, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerReferenceGrantFrom_To_v1_IssuerReferenceGrantFrom(in, out, s)
+}
+
+func autoConvert_v1_IssuerReferenceGrantList_To_certmanager_IssuerReferenceGrantList(in *v1.IssuerReferenceGrantList, out *certmanager.IssuerReferenceGrantList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]certmanager.IssuerReferenceGrant)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1_IssuerReferenceGrantList_To_certmanager_IssuerReferenceGrantList is an autogenerated conversion function.
+func Convert_v1_IssuerReferenceGrantList_To_certmanager_IssuerReferenceGrantList(in *v1.IssuerReferenceGrantList, out *certmanager.IssuerReferenceGrantList, s conversion.Scope) error {
+	return autoConvert_v1_IssuerReferenceGrantList_To_certmanager_IssuerReferenceGrantList(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerReferenceGrantList_To_v1_IssuerReferenceGrantList(in *certmanager.IssuerReferenceGrantList, out *v1.IssuerReferenceGrantList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1.IssuerReferenceGrant)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_certmanager_IssuerReferenceGrantList_To_v1_IssuerReferenceGrantList is an autogenerated conversion function.
+func Convert_certmanager_IssuerReferenceGrantList_To_v1_IssuerReferenceGrantList(in *certmanager.IssuerReferenceGrantList, out *v1.IssuerReferenceGrantList, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerReferenceGrantList_To_v1_IssuerReferenceGrantList(in, out, s)
+}
+
+func autoConvert_v1_IssuerReferenceGrantSpec_To_certmanager_IssuerReferenceGrantSpec(in *v1.IssuerReferenceGrantSpec, out *certmanager.IssuerReferenceGrantSpec, s conversion.Scope) error {
+	out.From = *(*[]certmanager.IssuerReferenceGrantFrom)(unsafe.Pointer(&in.From))
+	out.To = *(*[]certmanager.IssuerReferenceGrantTo)(unsafe.Pointer(&in.To))
+	return nil
+}
+
+// Convert_v1_IssuerReferenceGrantSpec_To_certmanager_IssuerReferenceGrantSpec is an autogenerated conversion function.
+func Convert_v1_IssuerReferenceGrantSpec_To_certmanager_IssuerReferenceGrantSpec(in *v1.IssuerReferenceGrantSpec, out *certmanager.IssuerReferenceGrantSpec, s conversion.Scope) error {
+	return autoConvert_v1_IssuerReferenceGrantSpec_To_certmanager_IssuerReferenceGrantSpec(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerReferenceGrantSpec_To_v1_IssuerReferenceGrantSpec(in *certmanager.IssuerReferenceGrantSpec, out *v1.IssuerReferenceGrantSpec, s conversion.Scope) error {
+	out.From = *(*[]v1.IssuerReferenceGrantFrom)(unsafe.Pointer(&in.From))
+	out.To = *(*[]v1.IssuerReferenceGrantTo)(unsafe.Pointer(&in.To))
+	return nil
+}
+
+// Convert_certmanager_IssuerReferenceGrantSpec_To_v1_IssuerReferenceGrantSpec is an autogenerated conversion function.
+func Convert_certmanager_IssuerReferenceGrantSpec_To_v1_IssuerReferenceGrantSpec(in *certmanager.IssuerReferenceGrantSpec, out *v1.IssuerReferenceGrantSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerReferenceGrantSpec_To_v1_IssuerReferenceGrantSpec(in, out, s)
+}
+
+func autoConvert_v1_IssuerReferenceGrantTo_To_certmanager_IssuerReferenceGrantTo(in *v1.IssuerReferenceGrantTo, out *certmanager.IssuerReferenceGrantTo, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1_IssuerReferenceGrantTo_To_certmanager_IssuerReferenceGrantTo is an autogenerated conversion function.
+func Convert_v1_IssuerReferenceGrantTo_To_certmanager_IssuerReferenceGrantTo(in *v1.IssuerReferenceGrantTo, out *certmanager.IssuerReferenceGrantTo, s conversion.Scope) error {
+	return autoConvert_v1_IssuerReferenceGrantTo_To_certmanager_IssuerReferenceGrantTo(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerReferenceGrantTo_To_v1_IssuerReferenceGrantTo(in *certmanager.IssuerReferenceGrantTo, out *v1.IssuerReferenceGrantTo, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_IssuerReferenceGrantTo_To_v1_IssuerReferenceGrantTo is an autogenerated conversion function.
+func Convert_certmanager_IssuerReferenceGrantTo_To_v1_IssuerReferenceGrantTo(in *certmanager.IssuerReferenceGrantTo, out *v1.IssuerReferenceGrantTo, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerReferenceGrantTo_To_v1_IssuerReferenceGrantTo(in, out, s)
+}
+
+func autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
+	if err := Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
+		return err
+	}
+	if in.HTTPClient != nil {
+		in, out := &in.HTTPClient, &out.HTTPClient
+		*out = new(certmanager.IssuerHTTPClient)
+		if err := Convert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTPClient = nil
+	}
+	out.ControllerName = in.ControllerName
+	out.DurationTolerance = (*certmanager.IssuerDurationTolerance)(unsafe.Pointer(in.DurationTolerance))
+	out.Capabilities = (*certmanager.IssuerCapabilities)(unsafe.Pointer(in.Capabilities))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IntermediateCA = (*certmanager.IssuerIntermediateCA)(unsafe.Pointer(in.IntermediateCA))
+	return nil
+}
+
+// Convert_v1_IssuerSpec_To_certmanager_IssuerSpec is an autogenerated conversion function.
+func Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
+	return autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerSpec, out *v1.IssuerSpec, s conversion.Scope) error {
+	if err := Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
+		return err
+	}
+	if in.HTTPClient != nil {
+		in, out := &in.HTTPClient, &out.HTTPClient
+		*out = new(v1.IssuerHTTPClient)
+		if err := Convert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTPClient = nil
+	}
+	out.ControllerName = in.ControllerName
+	out.DurationTolerance = (*v1.IssuerDurationTolerance)(unsafe.Pointer(in.DurationTolerance))
+	out.Capabilities = (*v1.IssuerCapabilities)(unsafe.Pointer(in.Capabilities))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IntermediateCA = (*v1.IssuerIntermediateCA)(unsafe.Pointer(in.IntermediateCA))
+	return nil
+}
+
+// Convert_certmanager_IssuerSpec_To_v1_IssuerSpec is an autogenerated conversion function.
+func Convert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerSpec, out *v1.IssuerSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in, out, s)
+}
+
+func autoConvert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
+	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Usage = (*certmanager.IssuerUsageStatus)(unsafe.Pointer(in.Usage))
+	return nil
+}
+
+// Convert_v1_IssuerStatus_To_certmanager_IssuerStatus is an autogenerated conversion function.
+func Convert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1_IssuerStatus_To_certmanager_IssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerStatus, out *v1.IssuerStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]v1.IssuerCondition)(unsafe.Pointer(&in.Conditions))
+	out.ACME = (*apisacmev1.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Usage = (*v1.IssuerUsageStatus)(unsafe.Pointer(in.Usage))
+	return nil
+}
+
+// Convert_certmanager_IssuerStatus_To_v1_IssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerStatus, out *v1.IssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerStatus_To_v1_IssuerStatus(in, out, s)
+}
+
+func autoConvert_v1_IssuerUsageStatus_To_certmanager_IssuerUsageStatus(in *v1.IssuerUsageStatus, out *certmanager.IssuerUsageStatus, s conversion.Scope) error {
+	out.Certificates = in.Certificates
+	out.ReadyCertificates = in.ReadyCertificates
+	out.RecentIssuances = in.RecentIssuances
+	out.RecentFailures = in.RecentFailures
+	out.LastRateLimitedTime = (*metav1.Time)(unsafe.Pointer(in.LastRateLimitedTime))
+	out.Namespaces = *(*[]certmanager.IssuerNamespaceUsage)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1_IssuerUsageStatus_To_certmanager_IssuerUsageStatus is an autogenerated conversion function.
+func Convert_v1_IssuerUsageStatus_To_certmanager_IssuerUsageStatus(in *v1.IssuerUsageStatus, out *certmanager.IssuerUsageStatus, s conversion.Scope) error {
+	return autoConvert_v1_IssuerUsageStatus_To_certmanager_IssuerUsageStatus(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerUsageStatus_To_v1_IssuerUsageStatus(in *certmanager.IssuerUsageStatus, out *v1.IssuerUsageStatus, s conversion.Scope) error {
+	out.Certificates = in.Certificates
+	out.ReadyCertificates = in.ReadyCertificates
+	out.RecentIssuances = in.RecentIssuances
+	out.RecentFailures = in.RecentFailures
+	out.LastRateLimitedTime = (*metav1.Time)(unsafe.Pointer(in.LastRateLimitedTime))
+	out.Namespaces = *(*[]v1.IssuerNamespaceUsage)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_certmanager_IssuerUsageStatus_To_v1_IssuerUsageStatus is an autogenerated conversion function.
+func Convert_certmanager_IssuerUsageStatus_To_v1_IssuerUsageStatus(in *certmanager.IssuerUsageStatus, out *v1.IssuerUsageStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerUsageStatus_To_v1_IssuerUsageStatus(in, out, s)
+}
+
+func autoConvert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
+	out.Create = in.Create
+	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_JKSKeystore_To_certmanager_JKSKeystore is an autogenerated conversion function.
+func Convert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
+	return autoConvert_v1_JKSKeystore_To_certmanager_JKSKeystore(in, out, s)
+}
+
+func autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeystore, out *v1.JKSKeystore, s conversion.Scope) error {
+	out.Create = in.Create
+	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_JKSKeystore_To_v1_JKSKeystore is an autogenerated conversion function.
+func Convert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeystore, out *v1.JKSKeystore, s conversion.Scope) error {
+	return autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in, out, s)
+}
+
+func autoConvert_v1_Notifier_To_certmanager_Notifier(in *v1.Notifier, out *certmanager.Notifier, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_NotifierSpec_To_certmanager_NotifierSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_Notifier_To_certmanager_Notifier is an autogenerated conversion function.
+func Convert_v1_Notifier_To_certmanager_Notifier(in *v1.Notifier, out *certmanager.Notifier, s conversion.Scope) error {
+	return autoConvert_v1_Notifier_To_certmanager_Notifier(in, out, s)
+}
+
+func autoConvert_certmanager_Notifier_To_v1_Notifier(in *certmanager.Notifier, out *v1.Notifier, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_NotifierSpec_To_v1_NotifierSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_Notifier_To_v1_Notifier is an autogenerated conversion function.
+func Convert_certmanager_Notifier_To_v1_Notifier(in *certmanager.Notifier, out *v1.Notifier, s conversion.Scope) error {
+	return autoConvert_certmanager_Notifier_To_v1_Notifier(in, out, s)
+}
+
+func autoConvert_v1_NotifierList_To_certmanager_NotifierList(in *v1.NotifierList, out *certmanager.NotifierList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]certmanager.Notifier, len(*in))
+		for i := range *in {
+			if err := Convert_v1_Notifier_To_certmanager_Notifier(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1_NotifierList_To_certmanager_NotifierList is an autogenerated conversion function.
+func Convert_v1_NotifierList_To_certmanager_NotifierList(in *v1.NotifierList, out *certmanager.NotifierList, s conversion.Scope) error {
+	return autoConvert_v1_NotifierList_To_certmanager_NotifierList(in, out, s)
+}
+
+func autoConvert_certmanager_NotifierList_To_v1_NotifierList(in *certmanager.NotifierList, out *v1.NotifierList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.Notifier, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_Notifier_To_v1_Notifier(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_NotifierList_To_v1_NotifierList is an autogenerated conversion function.
+func Convert_certmanager_NotifierList_To_v1_NotifierList(in *certmanager.NotifierList, out *v1.NotifierList, s conversion.Scope) error {
+	return autoConvert_certmanager_NotifierList_To_v1_NotifierList(in, out, s)
+}
+
+func autoConvert_v1_NotifierSpec_To_certmanager_NotifierSpec(in *v1.NotifierSpec, out *certmanager.NotifierSpec, s conversion.Scope) error {
+	out.Events = *(*[]certmanager.NotifierEvent)(unsafe.Pointer(&in.Events))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.ExpiryThreshold = (*metav1.Duration)(unsafe.Pointer(in.ExpiryThreshold))
+	out.RepeatInterval = (*metav1.Duration)(unsafe.Pointer(in.RepeatInterval))
+	out.Template = in.Template
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(certmanager.SlackNotifier)
+		if err := Convert_v1_SlackNotifier_To_certmanager_SlackNotifier(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Slack = nil
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(certmanager.WebhookNotifier)
+		if err := Convert_v1_WebhookNotifier_To_certmanager_WebhookNotifier(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Webhook = nil
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(certmanager.EmailNotifier)
+		if err := Convert_v1_EmailNotifier_To_certmanager_EmailNotifier(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Email = nil
+	}
+	return nil
+}
+
+// Convert_v1_NotifierSpec_To_certmanager_NotifierSpec is an autogenerated conversion function.
+func Convert_v1_NotifierSpec_To_certmanager_NotifierSpec(in *v1.NotifierSpec, out *certmanager.NotifierSpec, s conversion.Scope) error {
+	return autoConvert_v1_NotifierSpec_To_certmanager_NotifierSpec(in, out, s)
+}
+
+func autoConvert_certmanager_NotifierSpec_To_v1_NotifierSpec(in *certmanager.NotifierSpec, out *v1.NotifierSpec, s conversion.Scope) error {
+	out.Events = *(*[]v1.NotifierEvent)(unsafe.Pointer(&in.Events))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.ExpiryThreshold = (*metav1.Duration)(unsafe.Pointer(in.ExpiryThreshold))
+	out.RepeatInterval = (*metav1.Duration)(unsafe.Pointer(in.RepeatInterval))
+	out.Template = in.Template
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(v1.SlackNotifier)
+		if err := Convert_certmanager_SlackNotifier_To_v1_SlackNotifier(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Slack = nil
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(v1.WebhookNotifier)
+		if err := Convert_certmanager_WebhookNotifier_To_v1_WebhookNotifier(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Webhook = nil
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(v1.EmailNotifier)
+		if err := Convert_certmanager_EmailNotifier_To_v1_EmailNotifier(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Email = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_NotifierSpec_To_v1_NotifierSpec is an autogenerated conversion function.
+func Convert_certmanager_NotifierSpec_To_v1_NotifierSpec(in *certmanager.NotifierSpec, out *v1.NotifierSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_NotifierSpec_To_v1_NotifierSpec(in, out, s)
+}
+
+func autoConvert_v1_OfflineIssuer_To_certmanager_OfflineIssuer(in *v1.OfflineIssuer, out *certmanager.OfflineIssuer, s conversion.Scope) error {
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_v1_OfflineIssuer_To_certmanager_OfflineIssuer is an autogenerated conversion function.
+func Convert_v1_OfflineIssuer_To_certmanager_OfflineIssuer(in *v1.OfflineIssuer, out *certmanager.OfflineIssuer, s conversion.Scope) error {
+	return autoConvert_v1_OfflineIssuer_To_certmanager_OfflineIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_OfflineIssuer_To_v1_OfflineIssuer(in *certmanager.OfflineIssuer, out *v1.OfflineIssuer, s conversion.Scope) error {
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_certmanager_OfflineIssuer_To_v1_OfflineIssuer is an autogenerated conversion function.
+func Convert_certmanager_OfflineIssuer_To_v1_OfflineIssuer(in *certmanager.OfflineIssuer, out *v1.OfflineIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_OfflineIssuer_To_v1_OfflineIssuer(in, out, s)
+}
+
+func autoConvert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
+	out.Create = in.Create
+	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore is an autogenerated conversion function.
+func Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
+	return autoConvert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in, out, s)
+}
+
+func autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1.PKCS12Keystore, s conversion.Scope) error {
+	out.Create = in.Create
+	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore is an autogenerated conversion function.
+func Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1.PKCS12Keystore, s conversion.Scope) error {
+	return autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in, out, s)
+}
+
+func autoConvert_v1_RenewalBlackout_To_certmanager_RenewalBlackout(in *v1.RenewalBlackout, out *certmanager.RenewalBlackout, s conversion.Scope) error {
+	out.Start = in.Start
+	out.End = in.End
+	out.Reason = in.Reason
+	return nil
+}
+
+// Convert_v1_RenewalBlackout_To_certmanager_RenewalBlackout is an autogenerated conversion function.
+func Convert_v1_RenewalBlackout_To_certmanager_RenewalBlackout(in *v1.RenewalBlackout, out *certmanager.RenewalBlackout, s conversion.Scope) error {
+	return autoConvert_v1_RenewalBlackout_To_certmanager_RenewalBlackout(in, out, s)
+}
+
+func autoConvert_certmanager_RenewalBlackout_To_v1_RenewalBlackout(in *certmanager.RenewalBlackout, out *v1.RenewalBlackout, s conversion.Scope) error {
+	out.Start = in.Start
+	out.End = in.End
+	out.Reason = in.Reason
+	return nil
+}
+
+// Convert_certmanager_RenewalBlackout_To_v1_RenewalBlackout is an autogenerated conversion function.
+func Convert_certmanager_RenewalBlackout_To_v1_RenewalBlackout(in *certmanager.RenewalBlackout, out *v1.RenewalBlackout, s conversion.Scope) error {
+	return autoConvert_certmanager_RenewalBlackout_To_v1_RenewalBlackout(in, out, s)
 }
 
-func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig, out *certmanager.IssuerConfig, s conversion.Scope) error {
-	if in.ACME != nil {
-		in, out := &in.ACME, &out.ACME
-		*out = new(acme.ACMEIssuer)
-		if err := acmev1.Convert_v1_ACMEIssuer_To_acme_ACMEIssuer(*in, *out, s); err != nil {
-			return err
-		}
-	} else {
-		out.ACME = nil
-	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
-	if in.Vault != nil {
-		in, out := &in.Vault, &out.Vault
-		*out = new(certmanager.VaultIssuer)
-		if err := Convert_v1_VaultIssuer_To_certmanager_VaultIssuer(*in, *out, s); err != nil {
-			return err
-		}
-	} else {
-		out.Vault = nil
-	}
-	out.SelfSigned = (*certmanager.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
-	if in.Venafi != nil {
-		in, out := &in.Venafi, &out.Venafi
-		*out = new(certmanager.VenafiIssuer)
-		if err := Convert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(*in, *out, s); err != nil {
-			return err
-		}
-	} else {
-		out.Venafi = nil
+func autoConvert_v1_RenewalWindow_To_certmanager_RenewalWindow(in *v1.RenewalWindow, out *certmanager.RenewalWindow, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_RenewalWindowSpec_To_certmanager_RenewalWindowSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
 	}
 	return nil
 }
 
-// Convert_v1_IssuerConfig_To_certmanager_IssuerConfig is an autogenerated conversion function.
-func Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig, out *certmanager.IssuerConfig, s conversion.Scope) error {
-	return autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in, out, s)
+// Convert_v1_RenewalWindow_To_certmanager_RenewalWindow is an autogenerated conversion function.
+func Convert_v1_RenewalWindow_To_certmanager_RenewalWindow(in *v1.RenewalWindow, out *certmanager.RenewalWindow, s conversion.Scope) error {
+	return autoConvert_v1_RenewalWindow_To_certmanager_RenewalWindow(in, out, s)
 }
 
-func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.IssuerConfig, out *v1.IssuerConfig, s conversion.Scope) error {
-	if in.ACME != nil {
-		in, out := &in.ACME, &out.ACME
-		*out = new(apisacmev1.ACMEIssuer)
-		if err := acmev1.Convert_acme_ACMEIssuer_To_v1_ACMEIssuer(*in, *out, s); err != nil {
-			return err
-		}
-	} else {
-		out.ACME = nil
-	}
-	out.CA = (*v1.CAIssuer)(unsafe.Pointer(in.CA))
-	if in.Vault != nil {
-		in, out := &in.Vault, &out.Vault
-		*out = new(v1.VaultIssuer)
-		if err := Convert_certmanager_VaultIssuer_To_v1_VaultIssuer(*in, *out, s); err != nil {
-			return err
-		}
-	} else {
-		out.Vault = nil
-	}
-	out.SelfSigned = (*v1.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
-	if in.Venafi != nil {
-		in, out := &in.Venafi, &out.Venafi
-		*out = new(v1.VenafiIssuer)
-		if err := Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(*in, *out, s); err != nil {
-			return err
-		}
-	} else {
-		out.Venafi = nil
+func autoConvert_certmanager_RenewalWindow_To_v1_RenewalWindow(in *certmanager.RenewalWindow, out *v1.RenewalWindow, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_RenewalWindowSpec_To_v1_RenewalWindowSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
 	}
 	return nil
 }
 
-// Convert_certmanager_IssuerConfig_To_v1_IssuerConfig is an autogenerated conversion function.
-func Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.IssuerConfig, out *v1.IssuerConfig, s conversion.Scope) error {
-	return autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in, out, s)
+// Convert_certmanager_RenewalWindow_To_v1_RenewalWindow is an autogenerated conversion function.
+func Convert_certmanager_RenewalWindow_To_v1_RenewalWindow(in *certmanager.RenewalWindow, out *v1.RenewalWindow, s conversion.Scope) error {
+	return autoConvert_certmanager_RenewalWindow_To_v1_RenewalWindow(in, out, s)
 }
 
-func autoConvert_v1_IssuerList_To_certmanager_IssuerList(in *v1.IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
+func autoConvert_v1_RenewalWindowList_To_certmanager_RenewalWindowList(in *v1.RenewalWindowList, out *certmanager.RenewalWindowList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]certmanager.Issuer, len(*in))
+		*out = make([]certmanager.RenewalWindow, len(*in))
 		for i := range *in {
-			if err := Convert_v1_Issuer_To_certmanager_Issuer(&(*in)[i], &(*out)[i], s); err != nil {
+			if err := Convert_v1_RenewalWindow_To_certmanager_RenewalWindow(&(*in)[i], &(*out)[i], s); err != nil {
 				return err
 			}
 		}
@@ -1133,18 +3059,18 @@ func autoConvert_v1_IssuerList_To_certmanager_IssuerList(in *v1.IssuerList, out
 	return nil
 }
 
-// Convert_v1_IssuerList_To_certmanager_IssuerList is an autogenerated conversion function.
-func Convert_v1_IssuerList_To_certmanager_IssuerList(in *v1.IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
-	return autoConvert_v1_IssuerList_To_certmanager_IssuerList(in, out, s)
+// Convert_v1_RenewalWindowList_To_certmanager_RenewalWindowList is an autogenerated conversion function.
+func Convert_v1_RenewalWindowList_To_certmanager_RenewalWindowList(in *v1.RenewalWindowList, out *certmanager.RenewalWindowList, s conversion.Scope) error {
+	return autoConvert_v1_RenewalWindowList_To_certmanager_RenewalWindowList(in, out, s)
 }
 
-func autoConvert_certmanager_IssuerList_To_v1_IssuerList(in *certmanager.IssuerList, out *v1.IssuerList, s conversion.Scope) error {
+func autoConvert_certmanager_RenewalWindowList_To_v1_RenewalWindowList(in *certmanager.RenewalWindowList, out *v1.RenewalWindowList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]v1.Issuer, len(*in))
+		*out = make([]v1.RenewalWindow, len(*in))
 		for i := range *in {
-			if err := Convert_certmanager_Issuer_To_v1_Issuer(&(*in)[i], &(*out)[i], s); err != nil {
+			if err := Convert_certmanager_RenewalWindow_To_v1_RenewalWindow(&(*in)[i], &(*out)[i], s); err != nil {
 				return err
 			}
 		}
@@ -1154,127 +3080,251 @@ func autoConvert_certmanager_IssuerList_To_v1_IssuerList(in *certmanager.IssuerL
 	return nil
 }
 
-// Convert_certmanager_IssuerList_To_v1_IssuerList is an autogenerated conversion function.
-func Convert_certmanager_IssuerList_To_v1_IssuerList(in *certmanager.IssuerList, out *v1.IssuerList, s conversion.Scope) error {
-	return autoConvert_certmanager_IssuerList_To_v1_IssuerList(in, out, s)
+// Convert_certmanager_RenewalWindowList_To_v1_RenewalWindowList is an autogenerated conversion function.
+func Convert_certmanager_RenewalWindowList_To_v1_RenewalWindowList(in *certmanager.RenewalWindowList, out *v1.RenewalWindowList, s conversion.Scope) error {
+	return autoConvert_certmanager_RenewalWindowList_To_v1_RenewalWindowList(in, out, s)
 }
 
-func autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
-	if err := Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
-		return err
+func autoConvert_v1_RenewalWindowSpec_To_certmanager_RenewalWindowSpec(in *v1.RenewalWindowSpec, out *certmanager.RenewalWindowSpec, s conversion.Scope) error {
+	if in.Blackouts != nil {
+		in, out := &in.Blackouts, &out.Blackouts
+		*out = make([]certmanager.RenewalBlackout, len(*in))
+		for i := range *in {
+			if err := Convert_v1_RenewalBlackout_To_certmanager_RenewalBlackout(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Blackouts = nil
 	}
+	out.HardExpiryMargin = (*metav1.Duration)(unsafe.Pointer(in.HardExpiryMargin))
 	return nil
 }
 
-// Convert_v1_IssuerSpec_To_certmanager_IssuerSpec is an autogenerated conversion function.
-func Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
-	return autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in, out, s)
+// Convert_v1_RenewalWindowSpec_To_certmanager_RenewalWindowSpec is an autogenerated conversion function.
+func Convert_v1_RenewalWindowSpec_To_certmanager_RenewalWindowSpec(in *v1.RenewalWindowSpec, out *certmanager.RenewalWindowSpec, s conversion.Scope) error {
+	return autoConvert_v1_RenewalWindowSpec_To_certmanager_RenewalWindowSpec(in, out, s)
 }
 
-func autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerSpec, out *v1.IssuerSpec, s conversion.Scope) error {
-	if err := Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
-		return err
+func autoConvert_certmanager_RenewalWindowSpec_To_v1_RenewalWindowSpec(in *certmanager.RenewalWindowSpec, out *v1.RenewalWindowSpec, s conversion.Scope) error {
+	if in.Blackouts != nil {
+		in, out := &in.Blackouts, &out.Blackouts
+		*out = make([]v1.RenewalBlackout, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_RenewalBlackout_To_v1_RenewalBlackout(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Blackouts = nil
 	}
+	out.HardExpiryMargin = (*metav1.Duration)(unsafe.Pointer(in.HardExpiryMargin))
 	return nil
 }
 
-// Convert_certmanager_IssuerSpec_To_v1_IssuerSpec is an autogenerated conversion function.
-func Convert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerSpec, out *v1.IssuerSpec, s conversion.Scope) error {
-	return autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in, out, s)
+// Convert_certmanager_RenewalWindowSpec_To_v1_RenewalWindowSpec is an autogenerated conversion function.
+func Convert_certmanager_RenewalWindowSpec_To_v1_RenewalWindowSpec(in *certmanager.RenewalWindowSpec, out *v1.RenewalWindowSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_RenewalWindowSpec_To_v1_RenewalWindowSpec(in, out, s)
 }
 
-func autoConvert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
-	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
-	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+func autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
+	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.NotBeforeBackdate = (*metav1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.KeyIdentifierMethod = certmanager.KeyIdentifierMethod(in.KeyIdentifierMethod)
 	return nil
 }
 
-// Convert_v1_IssuerStatus_To_certmanager_IssuerStatus is an autogenerated conversion function.
-func Convert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
-	return autoConvert_v1_IssuerStatus_To_certmanager_IssuerStatus(in, out, s)
+// Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer is an autogenerated conversion function.
+func Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
+	return autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in, out, s)
 }
 
-func autoConvert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerStatus, out *v1.IssuerStatus, s conversion.Scope) error {
-	out.Conditions = *(*[]v1.IssuerCondition)(unsafe.Pointer(&in.Conditions))
-	out.ACME = (*apisacmev1.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+func autoConvert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in *certmanager.SelfSignedIssuer, out *v1.SelfSignedIssuer, s conversion.Scope) error {
+	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.NotBeforeBackdate = (*metav1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.KeyIdentifierMethod = v1.KeyIdentifierMethod(in.KeyIdentifierMethod)
 	return nil
 }
 
-// Convert_certmanager_IssuerStatus_To_v1_IssuerStatus is an autogenerated conversion function.
-func Convert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerStatus, out *v1.IssuerStatus, s conversion.Scope) error {
-	return autoConvert_certmanager_IssuerStatus_To_v1_IssuerStatus(in, out, s)
+// Convert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer is an autogenerated conversion function.
+func Convert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in *certmanager.SelfSignedIssuer, out *v1.SelfSignedIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in, out, s)
 }
 
-func autoConvert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
-	out.Create = in.Create
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+func autoConvert_v1_SlackNotifier_To_certmanager_SlackNotifier(in *v1.SlackNotifier, out *certmanager.SlackNotifier, s conversion.Scope) error {
+	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.WebhookURLSecretRef, &out.WebhookURLSecretRef, s); err != nil {
 		return err
 	}
 	return nil
 }
 
-// Convert_v1_JKSKeystore_To_certmanager_JKSKeystore is an autogenerated conversion function.
-func Convert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
-	return autoConvert_v1_JKSKeystore_To_certmanager_JKSKeystore(in, out, s)
+// Convert_v1_SlackNotifier_To_certmanager_SlackNotifier is an autogenerated conversion function.
+func Convert_v1_SlackNotifier_To_certmanager_SlackNotifier(in *v1.SlackNotifier, out *certmanager.SlackNotifier, s conversion.Scope) error {
+	return autoConvert_v1_SlackNotifier_To_certmanager_SlackNotifier(in, out, s)
 }
 
-func autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeystore, out *v1.JKSKeystore, s conversion.Scope) error {
-	out.Create = in.Create
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+func autoConvert_certmanager_SlackNotifier_To_v1_SlackNotifier(in *certmanager.SlackNotifier, out *v1.SlackNotifier, s conversion.Scope) error {
+	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.WebhookURLSecretRef, &out.WebhookURLSecretRef, s); err != nil {
 		return err
 	}
 	return nil
 }
 
-// Convert_certmanager_JKSKeystore_To_v1_JKSKeystore is an autogenerated conversion function.
-func Convert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeystore, out *v1.JKSKeystore, s conversion.Scope) error {
-	return autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in, out, s)
+// Convert_certmanager_SlackNotifier_To_v1_SlackNotifier is an autogenerated conversion function.
+func Convert_certmanager_SlackNotifier_To_v1_SlackNotifier(in *certmanager.SlackNotifier, out *v1.SlackNotifier, s conversion.Scope) error {
+	return autoConvert_certmanager_SlackNotifier_To_v1_SlackNotifier(in, out, s)
 }
 
-func autoConvert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
-	out.Create = in.Create
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+func autoConvert_v1_TLSSecretReport_To_certmanager_TLSSecretReport(in *v1.TLSSecretReport, out *certmanager.TLSSecretReport, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_TLSSecretReportSummary_To_certmanager_TLSSecretReportSummary(&in.Summary, &out.Summary, s); err != nil {
 		return err
 	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]certmanager.TLSSecretReportEntry, len(*in))
+		for i := range *in {
+			if err := Convert_v1_TLSSecretReportEntry_To_certmanager_TLSSecretReportEntry(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Secrets = nil
+	}
 	return nil
 }
 
-// Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore is an autogenerated conversion function.
-func Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
-	return autoConvert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in, out, s)
+// Convert_v1_TLSSecretReport_To_certmanager_TLSSecretReport is an autogenerated conversion function.
+func Convert_v1_TLSSecretReport_To_certmanager_TLSSecretReport(in *v1.TLSSecretReport, out *certmanager.TLSSecretReport, s conversion.Scope) error {
+	return autoConvert_v1_TLSSecretReport_To_certmanager_TLSSecretReport(in, out, s)
 }
 
-func autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1.PKCS12Keystore, s conversion.Scope) error {
-	out.Create = in.Create
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+func autoConvert_certmanager_TLSSecretReport_To_v1_TLSSecretReport(in *certmanager.TLSSecretReport, out *v1.TLSSecretReport, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_TLSSecretReportSummary_To_v1_TLSSecretReportSummary(&in.Summary, &out.Summary, s); err != nil {
 		return err
 	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]v1.TLSSecretReportEntry, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_TLSSecretReportEntry_To_v1_TLSSecretReportEntry(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Secrets = nil
+	}
 	return nil
 }
 
-// Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore is an autogenerated conversion function.
-func Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1.PKCS12Keystore, s conversion.Scope) error {
-	return autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in, out, s)
+// Convert_certmanager_TLSSecretReport_To_v1_TLSSecretReport is an autogenerated conversion function.
+func Convert_certmanager_TLSSecretReport_To_v1_TLSSecretReport(in *certmanager.TLSSecretReport, out *v1.TLSSecretReport, s conversion.Scope) error {
+	return autoConvert_certmanager_TLSSecretReport_To_v1_TLSSecretReport(in, out, s)
 }
 
-func autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
-	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+func autoConvert_v1_TLSSecretReportEntry_To_certmanager_TLSSecretReportEntry(in *v1.TLSSecretReportEntry, out *certmanager.TLSSecretReportEntry, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Managed = in.Managed
+	out.CertificateName = in.CertificateName
+	out.CommonName = in.CommonName
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.Issuer = in.Issuer
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.Error = in.Error
 	return nil
 }
 
-// Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer is an autogenerated conversion function.
-func Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
-	return autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in, out, s)
+// Convert_v1_TLSSecretReportEntry_To_certmanager_TLSSecretReportEntry is an autogenerated conversion function.
+func Convert_v1_TLSSecretReportEntry_To_certmanager_TLSSecretReportEntry(in *v1.TLSSecretReportEntry, out *certmanager.TLSSecretReportEntry, s conversion.Scope) error {
+	return autoConvert_v1_TLSSecretReportEntry_To_certmanager_TLSSecretReportEntry(in, out, s)
 }
 
-func autoConvert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in *certmanager.SelfSignedIssuer, out *v1.SelfSignedIssuer, s conversion.Scope) error {
-	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+func autoConvert_certmanager_TLSSecretReportEntry_To_v1_TLSSecretReportEntry(in *certmanager.TLSSecretReportEntry, out *v1.TLSSecretReportEntry, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Managed = in.Managed
+	out.CertificateName = in.CertificateName
+	out.CommonName = in.CommonName
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.Issuer = in.Issuer
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.Error = in.Error
 	return nil
 }
 
-// Convert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer is an autogenerated conversion function.
-func Convert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in *certmanager.SelfSignedIssuer, out *v1.SelfSignedIssuer, s conversion.Scope) error {
-	return autoConvert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in, out, s)
+// Convert_certmanager_TLSSecretReportEntry_To_v1_TLSSecretReportEntry is an autogenerated conversion function.
+func Convert_certmanager_TLSSecretReportEntry_To_v1_TLSSecretReportEntry(in *certmanager.TLSSecretReportEntry, out *v1.TLSSecretReportEntry, s conversion.Scope) error {
+	return autoConvert_certmanager_TLSSecretReportEntry_To_v1_TLSSecretReportEntry(in, out, s)
+}
+
+func autoConvert_v1_TLSSecretReportList_To_certmanager_TLSSecretReportList(in *v1.TLSSecretReportList, out *certmanager.TLSSecretReportList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]certmanager.TLSSecretReport, len(*in))
+		for i := range *in {
+			if err := Convert_v1_TLSSecretReport_To_certmanager_TLSSecretReport(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1_TLSSecretReportList_To_certmanager_TLSSecretReportList is an autogenerated conversion function.
+func Convert_v1_TLSSecretReportList_To_certmanager_TLSSecretReportList(in *v1.TLSSecretReportList, out *certmanager.TLSSecretReportList, s conversion.Scope) error {
+	return autoConvert_v1_TLSSecretReportList_To_certmanager_TLSSecretReportList(in, out, s)
+}
+
+func autoConvert_certmanager_TLSSecretReportList_To_v1_TLSSecretReportList(in *certmanager.TLSSecretReportList, out *v1.TLSSecretReportList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.TLSSecretReport, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_TLSSecretReport_To_v1_TLSSecretReport(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_TLSSecretReportList_To_v1_TLSSecretReportList is an autogenerated conversion function.
+func Convert_certmanager_TLSSecretReportList_To_v1_TLSSecretReportList(in *certmanager.TLSSecretReportList, out *v1.TLSSecretReportList, s conversion.Scope) error {
+	return autoConvert_certmanager_TLSSecretReportList_To_v1_TLSSecretReportList(in, out, s)
+}
+
+func autoConvert_v1_TLSSecretReportSummary_To_certmanager_TLSSecretReportSummary(in *v1.TLSSecretReportSummary, out *certmanager.TLSSecretReportSummary, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Unmanaged = in.Unmanaged
+	out.ExpiringSoon = in.ExpiringSoon
+	out.Expired = in.Expired
+	out.Invalid = in.Invalid
+	return nil
+}
+
+// Convert_v1_TLSSecretReportSummary_To_certmanager_TLSSecretReportSummary is an autogenerated conversion function.
+func Convert_v1_TLSSecretReportSummary_To_certmanager_TLSSecretReportSummary(in *v1.TLSSecretReportSummary, out *certmanager.TLSSecretReportSummary, s conversion.Scope) error {
+	return autoConvert_v1_TLSSecretReportSummary_To_certmanager_TLSSecretReportSummary(in, out, s)
+}
+
+func autoConvert_certmanager_TLSSecretReportSummary_To_v1_TLSSecretReportSummary(in *certmanager.TLSSecretReportSummary, out *v1.TLSSecretReportSummary, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Unmanaged = in.Unmanaged
+	out.ExpiringSoon = in.ExpiringSoon
+	out.Expired = in.Expired
+	out.Invalid = in.Invalid
+	return nil
+}
+
+// Convert_certmanager_TLSSecretReportSummary_To_v1_TLSSecretReportSummary is an autogenerated conversion function.
+func Convert_certmanager_TLSSecretReportSummary_To_v1_TLSSecretReportSummary(in *certmanager.TLSSecretReportSummary, out *v1.TLSSecretReportSummary, s conversion.Scope) error {
+	return autoConvert_certmanager_TLSSecretReportSummary_To_v1_TLSSecretReportSummary(in, out, s)
 }
 
 func autoConvert_v1_VaultAppRole_To_certmanager_VaultAppRole(in *v1.VaultAppRole, out *certmanager.VaultAppRole, s conversion.Scope) error {
@@ -1333,6 +3383,7 @@ func autoConvert_v1_VaultAuth_To_certmanager_VaultAuth(in *v1.VaultAuth, out *ce
 	} else {
 		out.Kubernetes = nil
 	}
+	out.ClientCertificate = (*certmanager.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1369,6 +3420,7 @@ func autoConvert_certmanager_VaultAuth_To_v1_VaultAuth(in *certmanager.VaultAuth
 	} else {
 		out.Kubernetes = nil
 	}
+	out.ClientCertificate = (*v1.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1377,6 +3429,30 @@ func Convert_certmanager_VaultAuth_To_v1_VaultAuth(in *certmanager.VaultAuth, ou
 	return autoConvert_certmanager_VaultAuth_To_v1_VaultAuth(in, out, s)
 }
 
+func autoConvert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(in, out, s)
+}
+
 func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, out *certmanager.VaultIssuer, s conversion.Scope) error {
 	if err := Convert_v1_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
 		return err
@@ -1394,6 +3470,7 @@ func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, o
 	} else {
 		out.CABundleSecretRef = nil
 	}
+	out.Revocation = (*certmanager.VaultRevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -1419,6 +3496,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.Vault
 	} else {
 		out.CABundleSecretRef = nil
 	}
+	out.Revocation = (*v1.VaultRevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -1455,11 +3533,37 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certm
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1_VaultRevocation_To_certmanager_VaultRevocation(in *v1.VaultRevocation, out *certmanager.VaultRevocation, s conversion.Scope) error {
+	out.RevokeOnDelete = in.RevokeOnDelete
+	out.TidyInterval = (*metav1.Duration)(unsafe.Pointer(in.TidyInterval))
+	out.TidySafetyBuffer = (*metav1.Duration)(unsafe.Pointer(in.TidySafetyBuffer))
+	return nil
+}
+
+// Convert_v1_VaultRevocation_To_certmanager_VaultRevocation is an autogenerated conversion function.
+func Convert_v1_VaultRevocation_To_certmanager_VaultRevocation(in *v1.VaultRevocation, out *certmanager.VaultRevocation, s conversion.Scope) error {
+	return autoConvert_v1_VaultRevocation_To_certmanager_VaultRevocation(in, out, s)
+}
+
+func autoConvert_certmanager_VaultRevocation_To_v1_VaultRevocation(in *certmanager.VaultRevocation, out *v1.VaultRevocation, s conversion.Scope) error {
+	out.RevokeOnDelete = in.RevokeOnDelete
+	out.TidyInterval = (*metav1.Duration)(unsafe.Pointer(in.TidyInterval))
+	out.TidySafetyBuffer = (*metav1.Duration)(unsafe.Pointer(in.TidySafetyBuffer))
+	return nil
+}
+
+// Convert_certmanager_VaultRevocation_To_v1_VaultRevocation is an autogenerated conversion function.
+func Convert_certmanager_VaultRevocation_To_v1_VaultRevocation(in *certmanager.VaultRevocation, out *v1.VaultRevocation, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultRevocation_To_v1_VaultRevocation(in, out, s)
+}
+
 func autoConvert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
+	out.Application = in.Application
+	out.IssuingTemplate = in.IssuingTemplate
 	return nil
 }
 
@@ -1473,6 +3577,8 @@ func autoConvert_certmanager_VenafiCloud_To_v1_VenafiCloud(in *certmanager.Venaf
 	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
+	out.Application = in.Application
+	out.IssuingTemplate = in.IssuingTemplate
 	return nil
 }
 
@@ -1565,6 +3671,28 @@ func Convert_certmanager_VenafiTPP_To_v1_VenafiTPP(in *certmanager.VenafiTPP, ou
 	return autoConvert_certmanager_VenafiTPP_To_v1_VenafiTPP(in, out, s)
 }
 
+func autoConvert_v1_WebhookNotifier_To_certmanager_WebhookNotifier(in *v1.WebhookNotifier, out *certmanager.WebhookNotifier, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_v1_WebhookNotifier_To_certmanager_WebhookNotifier is an autogenerated conversion function.
+func Convert_v1_WebhookNotifier_To_certmanager_WebhookNotifier(in *v1.WebhookNotifier, out *certmanager.WebhookNotifier, s conversion.Scope) error {
+	return autoConvert_v1_WebhookNotifier_To_certmanager_WebhookNotifier(in, out, s)
+}
+
+func autoConvert_certmanager_WebhookNotifier_To_v1_WebhookNotifier(in *certmanager.WebhookNotifier, out *v1.WebhookNotifier, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_certmanager_WebhookNotifier_To_v1_WebhookNotifier is an autogenerated conversion function.
+func Convert_certmanager_WebhookNotifier_To_v1_WebhookNotifier(in *certmanager.WebhookNotifier, out *v1.WebhookNotifier, s conversion.Scope) error {
+	return autoConvert_certmanager_WebhookNotifier_To_v1_WebhookNotifier(in, out, s)
+}
+
 func autoConvert_v1_X509Subject_To_certmanager_X509Subject(in *v1.X509Subject, out *certmanager.X509Subject, s conversion.Scope) error {
 	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
 	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
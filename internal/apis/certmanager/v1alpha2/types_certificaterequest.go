@@ -153,6 +153,26 @@ type CertificateRequestStatus struct {
 	// used to influence garbage collection and back-off.
 	// +optional
 	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+
+	// PickupID is the identifier assigned to the request by an issuer that
+	// issues certificates asynchronously, such as Venafi. It is used to
+	// retrieve the certificate once it has been issued, rather than
+	// submitting the request to the issuer again.
+	// +optional
+	PickupID string `json:"pickupID,omitempty"`
+
+	// NextPollTime is the time at which the issuer will next check whether
+	// the certificate requested with PickupID has been issued.
+	// +optional
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
+
+	// CrossSignedChain is the PEM encoded chain from the CA of the issued
+	// certificate to another root which cross-signed it: the cross-signed
+	// certificates followed by the other root. It is set by issuers whose
+	// root is cross-signed, and written to the Secret of a Certificate
+	// depending on its `crossSignedChain` field.
+	// +optional
+	CrossSignedChain []byte `json:"crossSignedChain,omitempty"`
 }
 
 // CertificateRequestCondition contains condition information for a CertificateRequest.
@@ -178,6 +198,14 @@ type CertificateRequestCondition struct {
 	// transition, complementing reason.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// If set, this represents the .metadata.generation that the condition was
+	// set based upon.
+	// For instance, if .metadata.generation is currently 12, but the
+	// .status.condition[x].observedGeneration is 9, the condition is out of date
+	// with respect to the current state of the CertificateRequest.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // CertificateRequestConditionType represents an Certificate condition value.
@@ -206,4 +234,12 @@ const (
 	// `False`, and cannot be modified once set. Cannot be set alongside
 	// `Approved`.
 	CertificateRequestConditionDenied CertificateRequestConditionType = "Denied"
+
+	// CertificateRequestConditionPendingApproval indicates that a certificate
+	// request has been submitted to an issuer that issues certificates
+	// asynchronously, and is waiting for the certificate to be issued, for
+	// example because the issuer requires the request to be approved manually.
+	// Unlike `Approved`, this condition is set by the issuer rather than by an
+	// approver of the CertificateRequest.
+	CertificateRequestConditionPendingApproval CertificateRequestConditionType = "PendingApproval"
 )
@@ -42,6 +42,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*CABundleKeySelector)(nil), (*certmanager.CABundleKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CABundleKeySelector_To_certmanager_CABundleKeySelector(a.(*CABundleKeySelector), b.(*certmanager.CABundleKeySelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CABundleKeySelector)(nil), (*CABundleKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CABundleKeySelector_To_v1alpha2_CABundleKeySelector(a.(*certmanager.CABundleKeySelector), b.(*CABundleKeySelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(a.(*CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -62,6 +72,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateAdditionalCABundle)(nil), (*certmanager.CertificateAdditionalCABundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle(a.(*CertificateAdditionalCABundle), b.(*certmanager.CertificateAdditionalCABundle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalCABundle)(nil), (*CertificateAdditionalCABundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalCABundle_To_v1alpha2_CertificateAdditionalCABundle(a.(*certmanager.CertificateAdditionalCABundle), b.(*CertificateAdditionalCABundle), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateAdditionalOutputFormat)(nil), (*certmanager.CertificateAdditionalOutputFormat)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(a.(*CertificateAdditionalOutputFormat), b.(*certmanager.CertificateAdditionalOutputFormat), scope)
 	}); err != nil {
@@ -72,6 +92,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateClientIdentity)(nil), (*certmanager.CertificateClientIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateClientIdentity_To_certmanager_CertificateClientIdentity(a.(*CertificateClientIdentity), b.(*certmanager.CertificateClientIdentity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateClientIdentity)(nil), (*CertificateClientIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateClientIdentity_To_v1alpha2_CertificateClientIdentity(a.(*certmanager.CertificateClientIdentity), b.(*CertificateClientIdentity), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateCondition_To_certmanager_CertificateCondition(a.(*CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -82,6 +112,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateDetails)(nil), (*certmanager.CertificateDetails)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateDetails_To_certmanager_CertificateDetails(a.(*CertificateDetails), b.(*certmanager.CertificateDetails), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateDetails)(nil), (*CertificateDetails)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateDetails_To_v1alpha2_CertificateDetails(a.(*certmanager.CertificateDetails), b.(*CertificateDetails), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateKeystores)(nil), (*certmanager.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateKeystores_To_certmanager_CertificateKeystores(a.(*CertificateKeystores), b.(*certmanager.CertificateKeystores), scope)
 	}); err != nil {
@@ -147,6 +187,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateSMIME)(nil), (*certmanager.CertificateSMIME)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateSMIME_To_certmanager_CertificateSMIME(a.(*CertificateSMIME), b.(*certmanager.CertificateSMIME), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSMIME)(nil), (*CertificateSMIME)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSMIME_To_v1alpha2_CertificateSMIME(a.(*certmanager.CertificateSMIME), b.(*CertificateSMIME), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -157,6 +207,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateSplitIssuance)(nil), (*certmanager.CertificateSplitIssuance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(a.(*CertificateSplitIssuance), b.(*certmanager.CertificateSplitIssuance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSplitIssuance)(nil), (*CertificateSplitIssuance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSplitIssuance_To_v1alpha2_CertificateSplitIssuance(a.(*certmanager.CertificateSplitIssuance), b.(*CertificateSplitIssuance), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateStatus)(nil), (*certmanager.CertificateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateStatus_To_certmanager_CertificateStatus(a.(*CertificateStatus), b.(*certmanager.CertificateStatus), scope)
 	}); err != nil {
@@ -167,6 +227,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateTransparencyLogEntry)(nil), (*certmanager.CertificateTransparencyLogEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry(a.(*CertificateTransparencyLogEntry), b.(*certmanager.CertificateTransparencyLogEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateTransparencyLogEntry)(nil), (*CertificateTransparencyLogEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateTransparencyLogEntry_To_v1alpha2_CertificateTransparencyLogEntry(a.(*certmanager.CertificateTransparencyLogEntry), b.(*CertificateTransparencyLogEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateTransparencyLogInclusionProof)(nil), (*certmanager.CertificateTransparencyLogInclusionProof)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof(a.(*CertificateTransparencyLogInclusionProof), b.(*certmanager.CertificateTransparencyLogInclusionProof), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateTransparencyLogInclusionProof)(nil), (*CertificateTransparencyLogInclusionProof)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateTransparencyLogInclusionProof_To_v1alpha2_CertificateTransparencyLogInclusionProof(a.(*certmanager.CertificateTransparencyLogInclusionProof), b.(*CertificateTransparencyLogInclusionProof), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterIssuer)(nil), (*certmanager.ClusterIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ClusterIssuer_To_certmanager_ClusterIssuer(a.(*ClusterIssuer), b.(*certmanager.ClusterIssuer), scope)
 	}); err != nil {
@@ -187,6 +267,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DelegateIssuer)(nil), (*certmanager.DelegateIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DelegateIssuer_To_certmanager_DelegateIssuer(a.(*DelegateIssuer), b.(*certmanager.DelegateIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.DelegateIssuer)(nil), (*DelegateIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_DelegateIssuer_To_v1alpha2_DelegateIssuer(a.(*certmanager.DelegateIssuer), b.(*DelegateIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DelegateIssuerRoute)(nil), (*certmanager.DelegateIssuerRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(a.(*DelegateIssuerRoute), b.(*certmanager.DelegateIssuerRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.DelegateIssuerRoute)(nil), (*DelegateIssuerRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_DelegateIssuerRoute_To_v1alpha2_DelegateIssuerRoute(a.(*certmanager.DelegateIssuerRoute), b.(*DelegateIssuerRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FakeIssuer)(nil), (*certmanager.FakeIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_FakeIssuer_To_certmanager_FakeIssuer(a.(*FakeIssuer), b.(*certmanager.FakeIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.FakeIssuer)(nil), (*FakeIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_FakeIssuer_To_v1alpha2_FakeIssuer(a.(*certmanager.FakeIssuer), b.(*FakeIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Issuer_To_certmanager_Issuer(a.(*Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -197,6 +307,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerCapabilities)(nil), (*certmanager.IssuerCapabilities)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_IssuerCapabilities_To_certmanager_IssuerCapabilities(a.(*IssuerCapabilities), b.(*certmanager.IssuerCapabilities), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerCapabilities)(nil), (*IssuerCapabilities)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerCapabilities_To_v1alpha2_IssuerCapabilities(a.(*certmanager.IssuerCapabilities), b.(*IssuerCapabilities), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IssuerCondition)(nil), (*certmanager.IssuerCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_IssuerCondition_To_certmanager_IssuerCondition(a.(*IssuerCondition), b.(*certmanager.IssuerCondition), scope)
 	}); err != nil {
@@ -217,6 +337,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerDurationTolerance)(nil), (*certmanager.IssuerDurationTolerance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance(a.(*IssuerDurationTolerance), b.(*certmanager.IssuerDurationTolerance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerDurationTolerance)(nil), (*IssuerDurationTolerance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerDurationTolerance_To_v1alpha2_IssuerDurationTolerance(a.(*certmanager.IssuerDurationTolerance), b.(*IssuerDurationTolerance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*IssuerHTTPClient)(nil), (*certmanager.IssuerHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(a.(*IssuerHTTPClient), b.(*certmanager.IssuerHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerHTTPClient)(nil), (*IssuerHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient(a.(*certmanager.IssuerHTTPClient), b.(*IssuerHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*IssuerIntermediateCA)(nil), (*certmanager.IssuerIntermediateCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA(a.(*IssuerIntermediateCA), b.(*certmanager.IssuerIntermediateCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerIntermediateCA)(nil), (*IssuerIntermediateCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerIntermediateCA_To_v1alpha2_IssuerIntermediateCA(a.(*certmanager.IssuerIntermediateCA), b.(*IssuerIntermediateCA), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IssuerList)(nil), (*certmanager.IssuerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_IssuerList_To_certmanager_IssuerList(a.(*IssuerList), b.(*certmanager.IssuerList), scope)
 	}); err != nil {
@@ -227,6 +377,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerNamespaceUsage)(nil), (*certmanager.IssuerNamespaceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage(a.(*IssuerNamespaceUsage), b.(*certmanager.IssuerNamespaceUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerNamespaceUsage)(nil), (*IssuerNamespaceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerNamespaceUsage_To_v1alpha2_IssuerNamespaceUsage(a.(*certmanager.IssuerNamespaceUsage), b.(*IssuerNamespaceUsage), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_IssuerSpec_To_certmanager_IssuerSpec(a.(*IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -247,6 +407,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerUsageStatus)(nil), (*certmanager.IssuerUsageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_IssuerUsageStatus_To_certmanager_IssuerUsageStatus(a.(*IssuerUsageStatus), b.(*certmanager.IssuerUsageStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerUsageStatus)(nil), (*IssuerUsageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerUsageStatus_To_v1alpha2_IssuerUsageStatus(a.(*certmanager.IssuerUsageStatus), b.(*IssuerUsageStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*JKSKeystore)(nil), (*certmanager.JKSKeystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_JKSKeystore_To_certmanager_JKSKeystore(a.(*JKSKeystore), b.(*certmanager.JKSKeystore), scope)
 	}); err != nil {
@@ -257,6 +427,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*OfflineIssuer)(nil), (*certmanager.OfflineIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OfflineIssuer_To_certmanager_OfflineIssuer(a.(*OfflineIssuer), b.(*certmanager.OfflineIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.OfflineIssuer)(nil), (*OfflineIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_OfflineIssuer_To_v1alpha2_OfflineIssuer(a.(*certmanager.OfflineIssuer), b.(*OfflineIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -302,6 +482,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VaultClientCertificateAuth)(nil), (*certmanager.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(a.(*VaultClientCertificateAuth), b.(*certmanager.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultClientCertificateAuth)(nil), (*VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth(a.(*certmanager.VaultClientCertificateAuth), b.(*VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*certmanager.VaultIssuer)(nil), (*VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_certmanager_VaultIssuer_To_v1alpha2_VaultIssuer(a.(*certmanager.VaultIssuer), b.(*VaultIssuer), scope)
 	}); err != nil {
@@ -322,6 +512,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VaultRevocation)(nil), (*certmanager.VaultRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VaultRevocation_To_certmanager_VaultRevocation(a.(*VaultRevocation), b.(*certmanager.VaultRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultRevocation)(nil), (*VaultRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultRevocation_To_v1alpha2_VaultRevocation(a.(*certmanager.VaultRevocation), b.(*VaultRevocation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiCloud)(nil), (*VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_certmanager_VenafiCloud_To_v1alpha2_VenafiCloud(a.(*certmanager.VenafiCloud), b.(*VenafiCloud), scope)
 	}); err != nil {
@@ -385,10 +585,37 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_CABundleKeySelector_To_certmanager_CABundleKeySelector(in *CABundleKeySelector, out *certmanager.CABundleKeySelector, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1alpha2_CABundleKeySelector_To_certmanager_CABundleKeySelector is an autogenerated conversion function.
+func Convert_v1alpha2_CABundleKeySelector_To_certmanager_CABundleKeySelector(in *CABundleKeySelector, out *certmanager.CABundleKeySelector, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CABundleKeySelector_To_certmanager_CABundleKeySelector(in, out, s)
+}
+
+func autoConvert_certmanager_CABundleKeySelector_To_v1alpha2_CABundleKeySelector(in *certmanager.CABundleKeySelector, out *CABundleKeySelector, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_certmanager_CABundleKeySelector_To_v1alpha2_CABundleKeySelector is an autogenerated conversion function.
+func Convert_certmanager_CABundleKeySelector_To_v1alpha2_CABundleKeySelector(in *certmanager.CABundleKeySelector, out *CABundleKeySelector, s conversion.Scope) error {
+	return autoConvert_certmanager_CABundleKeySelector_To_v1alpha2_CABundleKeySelector(in, out, s)
+}
+
 func autoConvert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.NotBeforeBackdate = (*v1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.SerialNumberBits = in.SerialNumberBits
+	out.TrackSerialNumbers = in.TrackSerialNumbers
+	out.KeyIdentifierMethod = certmanager.KeyIdentifierMethod(in.KeyIdentifierMethod)
+	out.CrossSignedChainSecretName = in.CrossSignedChainSecretName
 	return nil
 }
 
@@ -401,6 +628,11 @@ func autoConvert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in *certmanager.CAIss
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.NotBeforeBackdate = (*v1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.SerialNumberBits = in.SerialNumberBits
+	out.TrackSerialNumbers = in.TrackSerialNumbers
+	out.KeyIdentifierMethod = KeyIdentifierMethod(in.KeyIdentifierMethod)
+	out.CrossSignedChainSecretName = in.CrossSignedChainSecretName
 	return nil
 }
 
@@ -441,6 +673,28 @@ func Convert_certmanager_Certificate_To_v1alpha2_Certificate(in *certmanager.Cer
 	return autoConvert_certmanager_Certificate_To_v1alpha2_Certificate(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle(in *CertificateAdditionalCABundle, out *certmanager.CertificateAdditionalCABundle, s conversion.Scope) error {
+	out.ConfigMap = (*certmanager.CABundleKeySelector)(unsafe.Pointer(in.ConfigMap))
+	out.Secret = (*certmanager.CABundleKeySelector)(unsafe.Pointer(in.Secret))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle(in *CertificateAdditionalCABundle, out *certmanager.CertificateAdditionalCABundle, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateAdditionalCABundle_To_certmanager_CertificateAdditionalCABundle(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalCABundle_To_v1alpha2_CertificateAdditionalCABundle(in *certmanager.CertificateAdditionalCABundle, out *CertificateAdditionalCABundle, s conversion.Scope) error {
+	out.ConfigMap = (*CABundleKeySelector)(unsafe.Pointer(in.ConfigMap))
+	out.Secret = (*CABundleKeySelector)(unsafe.Pointer(in.Secret))
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalCABundle_To_v1alpha2_CertificateAdditionalCABundle is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalCABundle_To_v1alpha2_CertificateAdditionalCABundle(in *certmanager.CertificateAdditionalCABundle, out *CertificateAdditionalCABundle, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalCABundle_To_v1alpha2_CertificateAdditionalCABundle(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(in *CertificateAdditionalOutputFormat, out *certmanager.CertificateAdditionalOutputFormat, s conversion.Scope) error {
 	out.Type = certmanager.CertificateOutputFormatType(in.Type)
 	return nil
@@ -461,6 +715,28 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha2_Certifica
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha2_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateClientIdentity_To_certmanager_CertificateClientIdentity(in *CertificateClientIdentity, out *certmanager.CertificateClientIdentity, s conversion.Scope) error {
+	out.ServiceAccountName = in.ServiceAccountName
+	out.TrustDomain = in.TrustDomain
+	return nil
+}
+
+// Convert_v1alpha2_CertificateClientIdentity_To_certmanager_CertificateClientIdentity is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateClientIdentity_To_certmanager_CertificateClientIdentity(in *CertificateClientIdentity, out *certmanager.CertificateClientIdentity, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateClientIdentity_To_certmanager_CertificateClientIdentity(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateClientIdentity_To_v1alpha2_CertificateClientIdentity(in *certmanager.CertificateClientIdentity, out *CertificateClientIdentity, s conversion.Scope) error {
+	out.ServiceAccountName = in.ServiceAccountName
+	out.TrustDomain = in.TrustDomain
+	return nil
+}
+
+// Convert_certmanager_CertificateClientIdentity_To_v1alpha2_CertificateClientIdentity is an autogenerated conversion function.
+func Convert_certmanager_CertificateClientIdentity_To_v1alpha2_CertificateClientIdentity(in *certmanager.CertificateClientIdentity, out *CertificateClientIdentity, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateClientIdentity_To_v1alpha2_CertificateClientIdentity(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateCondition_To_certmanager_CertificateCondition(in *CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -491,6 +767,38 @@ func Convert_certmanager_CertificateCondition_To_v1alpha2_CertificateCondition(i
 	return autoConvert_certmanager_CertificateCondition_To_v1alpha2_CertificateCondition(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateDetails_To_certmanager_CertificateDetails(in *CertificateDetails, out *certmanager.CertificateDetails, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.FingerprintSHA256 = in.FingerprintSHA256
+	out.NotBefore = in.NotBefore
+	out.NotAfter = in.NotAfter
+	out.Subject = in.Subject
+	out.Issuer = in.Issuer
+	out.SubjectAltNames = in.SubjectAltNames
+	return nil
+}
+
+// Convert_v1alpha2_CertificateDetails_To_certmanager_CertificateDetails is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateDetails_To_certmanager_CertificateDetails(in *CertificateDetails, out *certmanager.CertificateDetails, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateDetails_To_certmanager_CertificateDetails(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateDetails_To_v1alpha2_CertificateDetails(in *certmanager.CertificateDetails, out *CertificateDetails, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.FingerprintSHA256 = in.FingerprintSHA256
+	out.NotBefore = in.NotBefore
+	out.NotAfter = in.NotAfter
+	out.Subject = in.Subject
+	out.Issuer = in.Issuer
+	out.SubjectAltNames = in.SubjectAltNames
+	return nil
+}
+
+// Convert_certmanager_CertificateDetails_To_v1alpha2_CertificateDetails is an autogenerated conversion function.
+func Convert_certmanager_CertificateDetails_To_v1alpha2_CertificateDetails(in *certmanager.CertificateDetails, out *CertificateDetails, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateDetails_To_v1alpha2_CertificateDetails(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateKeystores_To_certmanager_CertificateKeystores(in *CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
 	if in.JKS != nil {
 		in, out := &in.JKS, &out.JKS
@@ -643,6 +951,7 @@ func autoConvert_v1alpha2_CertificateRequestCondition_To_certmanager_Certificate
 	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
 	return nil
 }
 
@@ -657,6 +966,7 @@ func autoConvert_certmanager_CertificateRequestCondition_To_v1alpha2_Certificate
 	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
 	return nil
 }
 
@@ -742,6 +1052,9 @@ func autoConvert_v1alpha2_CertificateRequestStatus_To_certmanager_CertificateReq
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.PickupID = in.PickupID
+	out.NextPollTime = (*v1.Time)(unsafe.Pointer(in.NextPollTime))
+	out.CrossSignedChain = *(*[]byte)(unsafe.Pointer(&in.CrossSignedChain))
 	return nil
 }
 
@@ -755,6 +1068,9 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateReq
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.PickupID = in.PickupID
+	out.NextPollTime = (*v1.Time)(unsafe.Pointer(in.NextPollTime))
+	out.CrossSignedChain = *(*[]byte)(unsafe.Pointer(&in.CrossSignedChain))
 	return nil
 }
 
@@ -763,6 +1079,30 @@ func Convert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateRequest
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateSMIME_To_certmanager_CertificateSMIME(in *CertificateSMIME, out *certmanager.CertificateSMIME, s conversion.Scope) error {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CertificateSMIME_To_certmanager_CertificateSMIME is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateSMIME_To_certmanager_CertificateSMIME(in *CertificateSMIME, out *certmanager.CertificateSMIME, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateSMIME_To_certmanager_CertificateSMIME(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSMIME_To_v1alpha2_CertificateSMIME(in *certmanager.CertificateSMIME, out *CertificateSMIME, s conversion.Scope) error {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateSMIME_To_v1alpha2_CertificateSMIME is an autogenerated conversion function.
+func Convert_certmanager_CertificateSMIME_To_v1alpha2_CertificateSMIME(in *certmanager.CertificateSMIME, out *CertificateSMIME, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSMIME_To_v1alpha2_CertificateSMIME(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -835,6 +1175,31 @@ func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	out.ClientIdentity = (*certmanager.CertificateClientIdentity)(unsafe.Pointer(in.ClientIdentity))
+	if in.SMIME != nil {
+		in, out := &in.SMIME, &out.SMIME
+		*out = new(certmanager.CertificateSMIME)
+		if err := Convert_v1alpha2_CertificateSMIME_To_certmanager_CertificateSMIME(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SMIME = nil
+	}
+	if in.SplitIssuances != nil {
+		in, out := &in.SplitIssuances, &out.SplitIssuances
+		*out = make([]certmanager.CertificateSplitIssuance, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SplitIssuances = nil
+	}
+	out.ControllerName = in.ControllerName
+	out.CrossSignedChain = certmanager.CrossSignedChainPolicy(in.CrossSignedChain)
+	out.SecretDeletionPolicy = certmanager.SecretDeletionPolicy(in.SecretDeletionPolicy)
+	out.AdditionalCABundle = (*certmanager.CertificateAdditionalCABundle)(unsafe.Pointer(in.AdditionalCABundle))
 	return nil
 }
 
@@ -884,18 +1249,74 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha2_CertificateSpec(in *cer
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	out.ClientIdentity = (*CertificateClientIdentity)(unsafe.Pointer(in.ClientIdentity))
+	if in.SMIME != nil {
+		in, out := &in.SMIME, &out.SMIME
+		*out = new(CertificateSMIME)
+		if err := Convert_certmanager_CertificateSMIME_To_v1alpha2_CertificateSMIME(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SMIME = nil
+	}
+	if in.SplitIssuances != nil {
+		in, out := &in.SplitIssuances, &out.SplitIssuances
+		*out = make([]CertificateSplitIssuance, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateSplitIssuance_To_v1alpha2_CertificateSplitIssuance(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SplitIssuances = nil
+	}
+	out.ControllerName = in.ControllerName
+	out.CrossSignedChain = CrossSignedChainPolicy(in.CrossSignedChain)
+	out.SecretDeletionPolicy = SecretDeletionPolicy(in.SecretDeletionPolicy)
+	out.AdditionalCABundle = (*CertificateAdditionalCABundle)(unsafe.Pointer(in.AdditionalCABundle))
+	return nil
+}
+
+func autoConvert_v1alpha2_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(in *CertificateSplitIssuance, out *certmanager.CertificateSplitIssuance, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(in *CertificateSplitIssuance, out *certmanager.CertificateSplitIssuance, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateSplitIssuance_To_certmanager_CertificateSplitIssuance(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSplitIssuance_To_v1alpha2_CertificateSplitIssuance(in *certmanager.CertificateSplitIssuance, out *CertificateSplitIssuance, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
 	return nil
 }
 
+// Convert_certmanager_CertificateSplitIssuance_To_v1alpha2_CertificateSplitIssuance is an autogenerated conversion function.
+func Convert_certmanager_CertificateSplitIssuance_To_v1alpha2_CertificateSplitIssuance(in *certmanager.CertificateSplitIssuance, out *CertificateSplitIssuance, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSplitIssuance_To_v1alpha2_CertificateSplitIssuance(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateStatus_To_certmanager_CertificateStatus(in *CertificateStatus, out *certmanager.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.CertificateCondition)(unsafe.Pointer(&in.Conditions))
 	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.SerialNumber = in.SerialNumber
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.TransparencyLogEntry = (*certmanager.CertificateTransparencyLogEntry)(unsafe.Pointer(in.TransparencyLogEntry))
+	out.Certificate = (*certmanager.CertificateDetails)(unsafe.Pointer(in.Certificate))
 	return nil
 }
 
@@ -909,10 +1330,13 @@ func autoConvert_certmanager_CertificateStatus_To_v1alpha2_CertificateStatus(in
 	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.SerialNumber = in.SerialNumber
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.TransparencyLogEntry = (*CertificateTransparencyLogEntry)(unsafe.Pointer(in.TransparencyLogEntry))
+	out.Certificate = (*CertificateDetails)(unsafe.Pointer(in.Certificate))
 	return nil
 }
 
@@ -921,6 +1345,62 @@ func Convert_certmanager_CertificateStatus_To_v1alpha2_CertificateStatus(in *cer
 	return autoConvert_certmanager_CertificateStatus_To_v1alpha2_CertificateStatus(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry(in *CertificateTransparencyLogEntry, out *certmanager.CertificateTransparencyLogEntry, s conversion.Scope) error {
+	out.LogURL = in.LogURL
+	out.UUID = in.UUID
+	out.LogIndex = in.LogIndex
+	out.Fingerprint = in.Fingerprint
+	out.IntegratedTime = (*v1.Time)(unsafe.Pointer(in.IntegratedTime))
+	out.InclusionProof = (*certmanager.CertificateTransparencyLogInclusionProof)(unsafe.Pointer(in.InclusionProof))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry(in *CertificateTransparencyLogEntry, out *certmanager.CertificateTransparencyLogEntry, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateTransparencyLogEntry_To_certmanager_CertificateTransparencyLogEntry(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateTransparencyLogEntry_To_v1alpha2_CertificateTransparencyLogEntry(in *certmanager.CertificateTransparencyLogEntry, out *CertificateTransparencyLogEntry, s conversion.Scope) error {
+	out.LogURL = in.LogURL
+	out.UUID = in.UUID
+	out.LogIndex = in.LogIndex
+	out.Fingerprint = in.Fingerprint
+	out.IntegratedTime = (*v1.Time)(unsafe.Pointer(in.IntegratedTime))
+	out.InclusionProof = (*CertificateTransparencyLogInclusionProof)(unsafe.Pointer(in.InclusionProof))
+	return nil
+}
+
+// Convert_certmanager_CertificateTransparencyLogEntry_To_v1alpha2_CertificateTransparencyLogEntry is an autogenerated conversion function.
+func Convert_certmanager_CertificateTransparencyLogEntry_To_v1alpha2_CertificateTransparencyLogEntry(in *certmanager.CertificateTransparencyLogEntry, out *CertificateTransparencyLogEntry, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateTransparencyLogEntry_To_v1alpha2_CertificateTransparencyLogEntry(in, out, s)
+}
+
+func autoConvert_v1alpha2_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof(in *CertificateTransparencyLogInclusionProof, out *certmanager.CertificateTransparencyLogInclusionProof, s conversion.Scope) error {
+	out.LogIndex = in.LogIndex
+	out.RootHash = in.RootHash
+	out.TreeSize = in.TreeSize
+	out.Hashes = *(*[]string)(unsafe.Pointer(&in.Hashes))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof(in *CertificateTransparencyLogInclusionProof, out *certmanager.CertificateTransparencyLogInclusionProof, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateTransparencyLogInclusionProof_To_certmanager_CertificateTransparencyLogInclusionProof(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateTransparencyLogInclusionProof_To_v1alpha2_CertificateTransparencyLogInclusionProof(in *certmanager.CertificateTransparencyLogInclusionProof, out *CertificateTransparencyLogInclusionProof, s conversion.Scope) error {
+	out.LogIndex = in.LogIndex
+	out.RootHash = in.RootHash
+	out.TreeSize = in.TreeSize
+	out.Hashes = *(*[]string)(unsafe.Pointer(&in.Hashes))
+	return nil
+}
+
+// Convert_certmanager_CertificateTransparencyLogInclusionProof_To_v1alpha2_CertificateTransparencyLogInclusionProof is an autogenerated conversion function.
+func Convert_certmanager_CertificateTransparencyLogInclusionProof_To_v1alpha2_CertificateTransparencyLogInclusionProof(in *certmanager.CertificateTransparencyLogInclusionProof, out *CertificateTransparencyLogInclusionProof, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateTransparencyLogInclusionProof_To_v1alpha2_CertificateTransparencyLogInclusionProof(in, out, s)
+}
+
 func autoConvert_v1alpha2_ClusterIssuer_To_certmanager_ClusterIssuer(in *ClusterIssuer, out *certmanager.ClusterIssuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -995,6 +1475,98 @@ func Convert_certmanager_ClusterIssuerList_To_v1alpha2_ClusterIssuerList(in *cer
 	return autoConvert_certmanager_ClusterIssuerList_To_v1alpha2_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1alpha2_DelegateIssuer_To_certmanager_DelegateIssuer(in *DelegateIssuer, out *certmanager.DelegateIssuer, s conversion.Scope) error {
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]certmanager.DelegateIssuerRoute, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_DelegateIssuer_To_certmanager_DelegateIssuer is an autogenerated conversion function.
+func Convert_v1alpha2_DelegateIssuer_To_certmanager_DelegateIssuer(in *DelegateIssuer, out *certmanager.DelegateIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DelegateIssuer_To_certmanager_DelegateIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_DelegateIssuer_To_v1alpha2_DelegateIssuer(in *certmanager.DelegateIssuer, out *DelegateIssuer, s conversion.Scope) error {
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]DelegateIssuerRoute, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_DelegateIssuerRoute_To_v1alpha2_DelegateIssuerRoute(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_DelegateIssuer_To_v1alpha2_DelegateIssuer is an autogenerated conversion function.
+func Convert_certmanager_DelegateIssuer_To_v1alpha2_DelegateIssuer(in *certmanager.DelegateIssuer, out *DelegateIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_DelegateIssuer_To_v1alpha2_DelegateIssuer(in, out, s)
+}
+
+func autoConvert_v1alpha2_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(in *DelegateIssuerRoute, out *certmanager.DelegateIssuerRoute, s conversion.Scope) error {
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute is an autogenerated conversion function.
+func Convert_v1alpha2_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(in *DelegateIssuerRoute, out *certmanager.DelegateIssuerRoute, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DelegateIssuerRoute_To_certmanager_DelegateIssuerRoute(in, out, s)
+}
+
+func autoConvert_certmanager_DelegateIssuerRoute_To_v1alpha2_DelegateIssuerRoute(in *certmanager.DelegateIssuerRoute, out *DelegateIssuerRoute, s conversion.Scope) error {
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_DelegateIssuerRoute_To_v1alpha2_DelegateIssuerRoute is an autogenerated conversion function.
+func Convert_certmanager_DelegateIssuerRoute_To_v1alpha2_DelegateIssuerRoute(in *certmanager.DelegateIssuerRoute, out *DelegateIssuerRoute, s conversion.Scope) error {
+	return autoConvert_certmanager_DelegateIssuerRoute_To_v1alpha2_DelegateIssuerRoute(in, out, s)
+}
+
+func autoConvert_v1alpha2_FakeIssuer_To_certmanager_FakeIssuer(in *FakeIssuer, out *certmanager.FakeIssuer, s conversion.Scope) error {
+	out.Latency = (*v1.Duration)(unsafe.Pointer(in.Latency))
+	out.FailurePercentage = in.FailurePercentage
+	out.FailureMessage = in.FailureMessage
+	return nil
+}
+
+// Convert_v1alpha2_FakeIssuer_To_certmanager_FakeIssuer is an autogenerated conversion function.
+func Convert_v1alpha2_FakeIssuer_To_certmanager_FakeIssuer(in *FakeIssuer, out *certmanager.FakeIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha2_FakeIssuer_To_certmanager_FakeIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_FakeIssuer_To_v1alpha2_FakeIssuer(in *certmanager.FakeIssuer, out *FakeIssuer, s conversion.Scope) error {
+	out.Latency = (*v1.Duration)(unsafe.Pointer(in.Latency))
+	out.FailurePercentage = in.FailurePercentage
+	out.FailureMessage = in.FailureMessage
+	return nil
+}
+
+// Convert_certmanager_FakeIssuer_To_v1alpha2_FakeIssuer is an autogenerated conversion function.
+func Convert_certmanager_FakeIssuer_To_v1alpha2_FakeIssuer(in *certmanager.FakeIssuer, out *FakeIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_FakeIssuer_To_v1alpha2_FakeIssuer(in, out, s)
+}
+
 func autoConvert_v1alpha2_Issuer_To_certmanager_Issuer(in *Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1027,6 +1599,28 @@ func Convert_certmanager_Issuer_To_v1alpha2_Issuer(in *certmanager.Issuer, out *
 	return autoConvert_certmanager_Issuer_To_v1alpha2_Issuer(in, out, s)
 }
 
+func autoConvert_v1alpha2_IssuerCapabilities_To_certmanager_IssuerCapabilities(in *IssuerCapabilities, out *certmanager.IssuerCapabilities, s conversion.Scope) error {
+	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UnsupportedUsagesPolicy = certmanager.UnsupportedUsagesPolicy(in.UnsupportedUsagesPolicy)
+	return nil
+}
+
+// Convert_v1alpha2_IssuerCapabilities_To_certmanager_IssuerCapabilities is an autogenerated conversion function.
+func Convert_v1alpha2_IssuerCapabilities_To_certmanager_IssuerCapabilities(in *IssuerCapabilities, out *certmanager.IssuerCapabilities, s conversion.Scope) error {
+	return autoConvert_v1alpha2_IssuerCapabilities_To_certmanager_IssuerCapabilities(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerCapabilities_To_v1alpha2_IssuerCapabilities(in *certmanager.IssuerCapabilities, out *IssuerCapabilities, s conversion.Scope) error {
+	out.Usages = *(*[]KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UnsupportedUsagesPolicy = UnsupportedUsagesPolicy(in.UnsupportedUsagesPolicy)
+	return nil
+}
+
+// Convert_certmanager_IssuerCapabilities_To_v1alpha2_IssuerCapabilities is an autogenerated conversion function.
+func Convert_certmanager_IssuerCapabilities_To_v1alpha2_IssuerCapabilities(in *certmanager.IssuerCapabilities, out *IssuerCapabilities, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerCapabilities_To_v1alpha2_IssuerCapabilities(in, out, s)
+}
+
 func autoConvert_v1alpha2_IssuerCondition_To_certmanager_IssuerCondition(in *IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
 	out.Type = certmanager.IssuerConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -1087,6 +1681,17 @@ func autoConvert_v1alpha2_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerCon
 	} else {
 		out.Venafi = nil
 	}
+	out.Fake = (*certmanager.FakeIssuer)(unsafe.Pointer(in.Fake))
+	if in.Delegate != nil {
+		in, out := &in.Delegate, &out.Delegate
+		*out = new(certmanager.DelegateIssuer)
+		if err := Convert_v1alpha2_DelegateIssuer_To_certmanager_DelegateIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Delegate = nil
+	}
+	out.Offline = (*certmanager.OfflineIssuer)(unsafe.Pointer(in.Offline))
 	return nil
 }
 
@@ -1125,6 +1730,17 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(in *certmanag
 	} else {
 		out.Venafi = nil
 	}
+	out.Fake = (*FakeIssuer)(unsafe.Pointer(in.Fake))
+	if in.Delegate != nil {
+		in, out := &in.Delegate, &out.Delegate
+		*out = new(DelegateIssuer)
+		if err := Convert_certmanager_DelegateIssuer_To_v1alpha2_DelegateIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Delegate = nil
+	}
+	out.Offline = (*OfflineIssuer)(unsafe.Pointer(in.Offline))
 	return nil
 }
 
@@ -1133,6 +1749,94 @@ func Convert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(in *certmanager.I
 	return autoConvert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance(in *IssuerDurationTolerance, out *certmanager.IssuerDurationTolerance, s conversion.Scope) error {
+	out.MaxShortening = in.MaxShortening
+	return nil
+}
+
+// Convert_v1alpha2_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance is an autogenerated conversion function.
+func Convert_v1alpha2_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance(in *IssuerDurationTolerance, out *certmanager.IssuerDurationTolerance, s conversion.Scope) error {
+	return autoConvert_v1alpha2_IssuerDurationTolerance_To_certmanager_IssuerDurationTolerance(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerDurationTolerance_To_v1alpha2_IssuerDurationTolerance(in *certmanager.IssuerDurationTolerance, out *IssuerDurationTolerance, s conversion.Scope) error {
+	out.MaxShortening = in.MaxShortening
+	return nil
+}
+
+// Convert_certmanager_IssuerDurationTolerance_To_v1alpha2_IssuerDurationTolerance is an autogenerated conversion function.
+func Convert_certmanager_IssuerDurationTolerance_To_v1alpha2_IssuerDurationTolerance(in *certmanager.IssuerDurationTolerance, out *IssuerDurationTolerance, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerDurationTolerance_To_v1alpha2_IssuerDurationTolerance(in, out, s)
+}
+
+func autoConvert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in *IssuerHTTPClient, out *certmanager.IssuerHTTPClient, s conversion.Scope) error {
+	out.ProxyURL = in.ProxyURL
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient is an autogenerated conversion function.
+func Convert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in *IssuerHTTPClient, out *certmanager.IssuerHTTPClient, s conversion.Scope) error {
+	return autoConvert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient(in *certmanager.IssuerHTTPClient, out *IssuerHTTPClient, s conversion.Scope) error {
+	out.ProxyURL = in.ProxyURL
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient is an autogenerated conversion function.
+func Convert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient(in *certmanager.IssuerHTTPClient, out *IssuerHTTPClient, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient(in, out, s)
+}
+
+func autoConvert_v1alpha2_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA(in *IssuerIntermediateCA, out *certmanager.IssuerIntermediateCA, s conversion.Scope) error {
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.IssuerName = in.IssuerName
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	return nil
+}
+
+// Convert_v1alpha2_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA is an autogenerated conversion function.
+func Convert_v1alpha2_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA(in *IssuerIntermediateCA, out *certmanager.IssuerIntermediateCA, s conversion.Scope) error {
+	return autoConvert_v1alpha2_IssuerIntermediateCA_To_certmanager_IssuerIntermediateCA(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerIntermediateCA_To_v1alpha2_IssuerIntermediateCA(in *certmanager.IssuerIntermediateCA, out *IssuerIntermediateCA, s conversion.Scope) error {
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.IssuerName = in.IssuerName
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	return nil
+}
+
+// Convert_certmanager_IssuerIntermediateCA_To_v1alpha2_IssuerIntermediateCA is an autogenerated conversion function.
+func Convert_certmanager_IssuerIntermediateCA_To_v1alpha2_IssuerIntermediateCA(in *certmanager.IssuerIntermediateCA, out *IssuerIntermediateCA, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerIntermediateCA_To_v1alpha2_IssuerIntermediateCA(in, out, s)
+}
+
 func autoConvert_v1alpha2_IssuerList_To_certmanager_IssuerList(in *IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1175,10 +1879,50 @@ func Convert_certmanager_IssuerList_To_v1alpha2_IssuerList(in *certmanager.Issue
 	return autoConvert_certmanager_IssuerList_To_v1alpha2_IssuerList(in, out, s)
 }
 
+func autoConvert_v1alpha2_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage(in *IssuerNamespaceUsage, out *certmanager.IssuerNamespaceUsage, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Certificates = in.Certificates
+	out.ReadyCertificates = in.ReadyCertificates
+	out.RecentFailures = in.RecentFailures
+	return nil
+}
+
+// Convert_v1alpha2_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage is an autogenerated conversion function.
+func Convert_v1alpha2_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage(in *IssuerNamespaceUsage, out *certmanager.IssuerNamespaceUsage, s conversion.Scope) error {
+	return autoConvert_v1alpha2_IssuerNamespaceUsage_To_certmanager_IssuerNamespaceUsage(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerNamespaceUsage_To_v1alpha2_IssuerNamespaceUsage(in *certmanager.IssuerNamespaceUsage, out *IssuerNamespaceUsage, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Certificates = in.Certificates
+	out.ReadyCertificates = in.ReadyCertificates
+	out.RecentFailures = in.RecentFailures
+	return nil
+}
+
+// Convert_certmanager_IssuerNamespaceUsage_To_v1alpha2_IssuerNamespaceUsage is an autogenerated conversion function.
+func Convert_certmanager_IssuerNamespaceUsage_To_v1alpha2_IssuerNamespaceUsage(in *certmanager.IssuerNamespaceUsage, out *IssuerNamespaceUsage, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerNamespaceUsage_To_v1alpha2_IssuerNamespaceUsage(in, out, s)
+}
+
 func autoConvert_v1alpha2_IssuerSpec_To_certmanager_IssuerSpec(in *IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
 	if err := Convert_v1alpha2_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	if in.HTTPClient != nil {
+		in, out := &in.HTTPClient, &out.HTTPClient
+		*out = new(certmanager.IssuerHTTPClient)
+		if err := Convert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTPClient = nil
+	}
+	out.ControllerName = in.ControllerName
+	out.DurationTolerance = (*certmanager.IssuerDurationTolerance)(unsafe.Pointer(in.DurationTolerance))
+	out.Capabilities = (*certmanager.IssuerCapabilities)(unsafe.Pointer(in.Capabilities))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IntermediateCA = (*certmanager.IssuerIntermediateCA)(unsafe.Pointer(in.IntermediateCA))
 	return nil
 }
 
@@ -1191,6 +1935,20 @@ func autoConvert_certmanager_IssuerSpec_To_v1alpha2_IssuerSpec(in *certmanager.I
 	if err := Convert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	if in.HTTPClient != nil {
+		in, out := &in.HTTPClient, &out.HTTPClient
+		*out = new(IssuerHTTPClient)
+		if err := Convert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTPClient = nil
+	}
+	out.ControllerName = in.ControllerName
+	out.DurationTolerance = (*IssuerDurationTolerance)(unsafe.Pointer(in.DurationTolerance))
+	out.Capabilities = (*IssuerCapabilities)(unsafe.Pointer(in.Capabilities))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IntermediateCA = (*IssuerIntermediateCA)(unsafe.Pointer(in.IntermediateCA))
 	return nil
 }
 
@@ -1202,6 +1960,7 @@ func Convert_certmanager_IssuerSpec_To_v1alpha2_IssuerSpec(in *certmanager.Issue
 func autoConvert_v1alpha2_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Usage = (*certmanager.IssuerUsageStatus)(unsafe.Pointer(in.Usage))
 	return nil
 }
 
@@ -1213,6 +1972,7 @@ func Convert_v1alpha2_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus,
 func autoConvert_certmanager_IssuerStatus_To_v1alpha2_IssuerStatus(in *certmanager.IssuerStatus, out *IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acmev1alpha2.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Usage = (*IssuerUsageStatus)(unsafe.Pointer(in.Usage))
 	return nil
 }
 
@@ -1221,6 +1981,36 @@ func Convert_certmanager_IssuerStatus_To_v1alpha2_IssuerStatus(in *certmanager.I
 	return autoConvert_certmanager_IssuerStatus_To_v1alpha2_IssuerStatus(in, out, s)
 }
 
+func autoConvert_v1alpha2_IssuerUsageStatus_To_certmanager_IssuerUsageStatus(in *IssuerUsageStatus, out *certmanager.IssuerUsageStatus, s conversion.Scope) error {
+	out.Certificates = in.Certificates
+	out.ReadyCertificates = in.ReadyCertificates
+	out.RecentIssuances = in.RecentIssuances
+	out.RecentFailures = in.RecentFailures
+	out.LastRateLimitedTime = (*v1.Time)(unsafe.Pointer(in.LastRateLimitedTime))
+	out.Namespaces = *(*[]certmanager.IssuerNamespaceUsage)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1alpha2_IssuerUsageStatus_To_certmanager_IssuerUsageStatus is an autogenerated conversion function.
+func Convert_v1alpha2_IssuerUsageStatus_To_certmanager_IssuerUsageStatus(in *IssuerUsageStatus, out *certmanager.IssuerUsageStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_IssuerUsageStatus_To_certmanager_IssuerUsageStatus(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerUsageStatus_To_v1alpha2_IssuerUsageStatus(in *certmanager.IssuerUsageStatus, out *IssuerUsageStatus, s conversion.Scope) error {
+	out.Certificates = in.Certificates
+	out.ReadyCertificates = in.ReadyCertificates
+	out.RecentIssuances = in.RecentIssuances
+	out.RecentFailures = in.RecentFailures
+	out.LastRateLimitedTime = (*v1.Time)(unsafe.Pointer(in.LastRateLimitedTime))
+	out.Namespaces = *(*[]IssuerNamespaceUsage)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_certmanager_IssuerUsageStatus_To_v1alpha2_IssuerUsageStatus is an autogenerated conversion function.
+func Convert_certmanager_IssuerUsageStatus_To_v1alpha2_IssuerUsageStatus(in *certmanager.IssuerUsageStatus, out *IssuerUsageStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerUsageStatus_To_v1alpha2_IssuerUsageStatus(in, out, s)
+}
+
 func autoConvert_v1alpha2_JKSKeystore_To_certmanager_JKSKeystore(in *JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
 	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
@@ -1247,6 +2037,26 @@ func Convert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(in *certmanager.JKS
 	return autoConvert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1alpha2_OfflineIssuer_To_certmanager_OfflineIssuer(in *OfflineIssuer, out *certmanager.OfflineIssuer, s conversion.Scope) error {
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_v1alpha2_OfflineIssuer_To_certmanager_OfflineIssuer is an autogenerated conversion function.
+func Convert_v1alpha2_OfflineIssuer_To_certmanager_OfflineIssuer(in *OfflineIssuer, out *certmanager.OfflineIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OfflineIssuer_To_certmanager_OfflineIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_OfflineIssuer_To_v1alpha2_OfflineIssuer(in *certmanager.OfflineIssuer, out *OfflineIssuer, s conversion.Scope) error {
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_certmanager_OfflineIssuer_To_v1alpha2_OfflineIssuer is an autogenerated conversion function.
+func Convert_certmanager_OfflineIssuer_To_v1alpha2_OfflineIssuer(in *certmanager.OfflineIssuer, out *OfflineIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_OfflineIssuer_To_v1alpha2_OfflineIssuer(in, out, s)
+}
+
 func autoConvert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
 	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
@@ -1275,6 +2085,8 @@ func Convert_certmanager_PKCS12Keystore_To_v1alpha2_PKCS12Keystore(in *certmanag
 
 func autoConvert_v1alpha2_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.NotBeforeBackdate = (*v1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.KeyIdentifierMethod = certmanager.KeyIdentifierMethod(in.KeyIdentifierMethod)
 	return nil
 }
 
@@ -1285,6 +2097,8 @@ func Convert_v1alpha2_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *SelfS
 
 func autoConvert_certmanager_SelfSignedIssuer_To_v1alpha2_SelfSignedIssuer(in *certmanager.SelfSignedIssuer, out *SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.NotBeforeBackdate = (*v1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.KeyIdentifierMethod = KeyIdentifierMethod(in.KeyIdentifierMethod)
 	return nil
 }
 
@@ -1349,6 +2163,7 @@ func autoConvert_v1alpha2_VaultAuth_To_certmanager_VaultAuth(in *VaultAuth, out
 	} else {
 		out.Kubernetes = nil
 	}
+	out.ClientCertificate = (*certmanager.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1385,6 +2200,7 @@ func autoConvert_certmanager_VaultAuth_To_v1alpha2_VaultAuth(in *certmanager.Vau
 	} else {
 		out.Kubernetes = nil
 	}
+	out.ClientCertificate = (*VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1393,6 +2209,30 @@ func Convert_certmanager_VaultAuth_To_v1alpha2_VaultAuth(in *certmanager.VaultAu
 	return autoConvert_certmanager_VaultAuth_To_v1alpha2_VaultAuth(in, out, s)
 }
 
+func autoConvert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth(in, out, s)
+}
+
 func autoConvert_v1alpha2_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer, out *certmanager.VaultIssuer, s conversion.Scope) error {
 	if err := Convert_v1alpha2_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
 		return err
@@ -1410,6 +2250,7 @@ func autoConvert_v1alpha2_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer
 	} else {
 		out.CABundleSecretRef = nil
 	}
+	out.Revocation = (*certmanager.VaultRevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -1435,6 +2276,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha2_VaultIssuer(in *certmanager
 	} else {
 		out.CABundleSecretRef = nil
 	}
+	out.Revocation = (*VaultRevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -1471,11 +2313,37 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1alpha2_VaultKubernetesAuth(in
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1alpha2_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1alpha2_VaultRevocation_To_certmanager_VaultRevocation(in *VaultRevocation, out *certmanager.VaultRevocation, s conversion.Scope) error {
+	out.RevokeOnDelete = in.RevokeOnDelete
+	out.TidyInterval = (*v1.Duration)(unsafe.Pointer(in.TidyInterval))
+	out.TidySafetyBuffer = (*v1.Duration)(unsafe.Pointer(in.TidySafetyBuffer))
+	return nil
+}
+
+// Convert_v1alpha2_VaultRevocation_To_certmanager_VaultRevocation is an autogenerated conversion function.
+func Convert_v1alpha2_VaultRevocation_To_certmanager_VaultRevocation(in *VaultRevocation, out *certmanager.VaultRevocation, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VaultRevocation_To_certmanager_VaultRevocation(in, out, s)
+}
+
+func autoConvert_certmanager_VaultRevocation_To_v1alpha2_VaultRevocation(in *certmanager.VaultRevocation, out *VaultRevocation, s conversion.Scope) error {
+	out.RevokeOnDelete = in.RevokeOnDelete
+	out.TidyInterval = (*v1.Duration)(unsafe.Pointer(in.TidyInterval))
+	out.TidySafetyBuffer = (*v1.Duration)(unsafe.Pointer(in.TidySafetyBuffer))
+	return nil
+}
+
+// Convert_certmanager_VaultRevocation_To_v1alpha2_VaultRevocation is an autogenerated conversion function.
+func Convert_certmanager_VaultRevocation_To_v1alpha2_VaultRevocation(in *certmanager.VaultRevocation, out *VaultRevocation, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultRevocation_To_v1alpha2_VaultRevocation(in, out, s)
+}
+
 func autoConvert_v1alpha2_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
+	out.Application = in.Application
+	out.IssuingTemplate = in.IssuingTemplate
 	return nil
 }
 
@@ -1489,6 +2357,8 @@ func autoConvert_certmanager_VenafiCloud_To_v1alpha2_VenafiCloud(in *certmanager
 	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
+	out.Application = in.Application
+	out.IssuingTemplate = in.IssuingTemplate
 	return nil
 }
 
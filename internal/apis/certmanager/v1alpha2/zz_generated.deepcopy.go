@@ -28,6 +28,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleKeySelector) DeepCopyInto(out *CABundleKeySelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleKeySelector.
+func (in *CABundleKeySelector) DeepCopy() *CABundleKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CAIssuer) DeepCopyInto(out *CAIssuer) {
 	*out = *in
@@ -41,6 +57,11 @@ func (in *CAIssuer) DeepCopyInto(out *CAIssuer) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NotBeforeBackdate != nil {
+		in, out := &in.NotBeforeBackdate, &out.NotBeforeBackdate
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -82,6 +103,32 @@ func (in *Certificate) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAdditionalCABundle) DeepCopyInto(out *CertificateAdditionalCABundle) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(CABundleKeySelector)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(CABundleKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAdditionalCABundle.
+func (in *CertificateAdditionalCABundle) DeepCopy() *CertificateAdditionalCABundle {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAdditionalCABundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateAdditionalOutputFormat) DeepCopyInto(out *CertificateAdditionalOutputFormat) {
 	*out = *in
@@ -98,6 +145,22 @@ func (in *CertificateAdditionalOutputFormat) DeepCopy() *CertificateAdditionalOu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateClientIdentity) DeepCopyInto(out *CertificateClientIdentity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateClientIdentity.
+func (in *CertificateClientIdentity) DeepCopy() *CertificateClientIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateClientIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateCondition) DeepCopyInto(out *CertificateCondition) {
 	*out = *in
@@ -118,6 +181,24 @@ func (in *CertificateCondition) DeepCopy() *CertificateCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateDetails) DeepCopyInto(out *CertificateDetails) {
+	*out = *in
+	in.NotBefore.DeepCopyInto(&out.NotBefore)
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateDetails.
+func (in *CertificateDetails) DeepCopy() *CertificateDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateKeystores) DeepCopyInto(out *CertificateKeystores) {
 	*out = *in
@@ -350,6 +431,15 @@ func (in *CertificateRequestStatus) DeepCopyInto(out *CertificateRequestStatus)
 		in, out := &in.FailureTime, &out.FailureTime
 		*out = (*in).DeepCopy()
 	}
+	if in.NextPollTime != nil {
+		in, out := &in.NextPollTime, &out.NextPollTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CrossSignedChain != nil {
+		in, out := &in.CrossSignedChain, &out.CrossSignedChain
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -363,6 +453,23 @@ func (in *CertificateRequestStatus) DeepCopy() *CertificateRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateSMIME) DeepCopyInto(out *CertificateSMIME) {
+	*out = *in
+	out.PasswordSecretRef = in.PasswordSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSMIME.
+func (in *CertificateSMIME) DeepCopy() *CertificateSMIME {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSMIME)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateSecretTemplate) DeepCopyInto(out *CertificateSecretTemplate) {
 	*out = *in
@@ -472,6 +579,28 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 		*out = make([]CertificateAdditionalOutputFormat, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClientIdentity != nil {
+		in, out := &in.ClientIdentity, &out.ClientIdentity
+		*out = new(CertificateClientIdentity)
+		**out = **in
+	}
+	if in.SMIME != nil {
+		in, out := &in.SMIME, &out.SMIME
+		*out = new(CertificateSMIME)
+		**out = **in
+	}
+	if in.SplitIssuances != nil {
+		in, out := &in.SplitIssuances, &out.SplitIssuances
+		*out = make([]CertificateSplitIssuance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalCABundle != nil {
+		in, out := &in.AdditionalCABundle, &out.AdditionalCABundle
+		*out = new(CertificateAdditionalCABundle)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -485,6 +614,28 @@ func (in *CertificateSpec) DeepCopy() *CertificateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateSplitIssuance) DeepCopyInto(out *CertificateSplitIssuance) {
+	*out = *in
+	if in.DNSZones != nil {
+		in, out := &in.DNSZones, &out.DNSZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.IssuerRef = in.IssuerRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSplitIssuance.
+func (in *CertificateSplitIssuance) DeepCopy() *CertificateSplitIssuance {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSplitIssuance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 	*out = *in
@@ -526,6 +677,16 @@ func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.TransparencyLogEntry != nil {
+		in, out := &in.TransparencyLogEntry, &out.TransparencyLogEntry
+		*out = new(CertificateTransparencyLogEntry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(CertificateDetails)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -539,6 +700,52 @@ func (in *CertificateStatus) DeepCopy() *CertificateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateTransparencyLogEntry) DeepCopyInto(out *CertificateTransparencyLogEntry) {
+	*out = *in
+	if in.IntegratedTime != nil {
+		in, out := &in.IntegratedTime, &out.IntegratedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.InclusionProof != nil {
+		in, out := &in.InclusionProof, &out.InclusionProof
+		*out = new(CertificateTransparencyLogInclusionProof)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateTransparencyLogEntry.
+func (in *CertificateTransparencyLogEntry) DeepCopy() *CertificateTransparencyLogEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateTransparencyLogEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateTransparencyLogInclusionProof) DeepCopyInto(out *CertificateTransparencyLogInclusionProof) {
+	*out = *in
+	if in.Hashes != nil {
+		in, out := &in.Hashes, &out.Hashes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateTransparencyLogInclusionProof.
+func (in *CertificateTransparencyLogInclusionProof) DeepCopy() *CertificateTransparencyLogInclusionProof {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateTransparencyLogInclusionProof)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterIssuer) DeepCopyInto(out *ClusterIssuer) {
 	*out = *in
@@ -600,6 +807,77 @@ func (in *ClusterIssuerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DelegateIssuer) DeepCopyInto(out *DelegateIssuer) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]DelegateIssuerRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelegateIssuer.
+func (in *DelegateIssuer) DeepCopy() *DelegateIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(DelegateIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DelegateIssuerRoute) DeepCopyInto(out *DelegateIssuerRoute) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSZones != nil {
+		in, out := &in.DNSZones, &out.DNSZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.IssuerRef = in.IssuerRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelegateIssuerRoute.
+func (in *DelegateIssuerRoute) DeepCopy() *DelegateIssuerRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(DelegateIssuerRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FakeIssuer) DeepCopyInto(out *FakeIssuer) {
+	*out = *in
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FakeIssuer.
+func (in *FakeIssuer) DeepCopy() *FakeIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(FakeIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Issuer) DeepCopyInto(out *Issuer) {
 	*out = *in
@@ -628,6 +906,27 @@ func (in *Issuer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerCapabilities) DeepCopyInto(out *IssuerCapabilities) {
+	*out = *in
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]KeyUsage, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerCapabilities.
+func (in *IssuerCapabilities) DeepCopy() *IssuerCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssuerCondition) DeepCopyInto(out *IssuerCondition) {
 	*out = *in
@@ -676,6 +975,21 @@ func (in *IssuerConfig) DeepCopyInto(out *IssuerConfig) {
 		*out = new(VenafiIssuer)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Fake != nil {
+		in, out := &in.Fake, &out.Fake
+		*out = new(FakeIssuer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delegate != nil {
+		in, out := &in.Delegate, &out.Delegate
+		*out = new(DelegateIssuer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Offline != nil {
+		in, out := &in.Offline, &out.Offline
+		*out = new(OfflineIssuer)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -689,6 +1003,85 @@ func (in *IssuerConfig) DeepCopy() *IssuerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerDurationTolerance) DeepCopyInto(out *IssuerDurationTolerance) {
+	*out = *in
+	out.MaxShortening = in.MaxShortening
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerDurationTolerance.
+func (in *IssuerDurationTolerance) DeepCopy() *IssuerDurationTolerance {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerDurationTolerance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerHTTPClient) DeepCopyInto(out *IssuerHTTPClient) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(metav1.SecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerHTTPClient.
+func (in *IssuerHTTPClient) DeepCopy() *IssuerHTTPClient {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerHTTPClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerIntermediateCA) DeepCopyInto(out *IssuerIntermediateCA) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerIntermediateCA.
+func (in *IssuerIntermediateCA) DeepCopy() *IssuerIntermediateCA {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerIntermediateCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssuerList) DeepCopyInto(out *IssuerList) {
 	*out = *in
@@ -722,10 +1115,51 @@ func (in *IssuerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerNamespaceUsage) DeepCopyInto(out *IssuerNamespaceUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerNamespaceUsage.
+func (in *IssuerNamespaceUsage) DeepCopy() *IssuerNamespaceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerNamespaceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssuerSpec) DeepCopyInto(out *IssuerSpec) {
 	*out = *in
 	in.IssuerConfig.DeepCopyInto(&out.IssuerConfig)
+	if in.HTTPClient != nil {
+		in, out := &in.HTTPClient, &out.HTTPClient
+		*out = new(IssuerHTTPClient)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DurationTolerance != nil {
+		in, out := &in.DurationTolerance, &out.DurationTolerance
+		*out = new(IssuerDurationTolerance)
+		**out = **in
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(IssuerCapabilities)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.IntermediateCA != nil {
+		in, out := &in.IntermediateCA, &out.IntermediateCA
+		*out = new(IssuerIntermediateCA)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -752,7 +1186,12 @@ func (in *IssuerStatus) DeepCopyInto(out *IssuerStatus) {
 	if in.ACME != nil {
 		in, out := &in.ACME, &out.ACME
 		*out = new(acmev1alpha2.ACMEIssuerStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(IssuerUsageStatus)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -767,6 +1206,31 @@ func (in *IssuerStatus) DeepCopy() *IssuerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerUsageStatus) DeepCopyInto(out *IssuerUsageStatus) {
+	*out = *in
+	if in.LastRateLimitedTime != nil {
+		in, out := &in.LastRateLimitedTime, &out.LastRateLimitedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]IssuerNamespaceUsage, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerUsageStatus.
+func (in *IssuerUsageStatus) DeepCopy() *IssuerUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JKSKeystore) DeepCopyInto(out *JKSKeystore) {
 	*out = *in
@@ -784,6 +1248,27 @@ func (in *JKSKeystore) DeepCopy() *JKSKeystore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OfflineIssuer) DeepCopyInto(out *OfflineIssuer) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OfflineIssuer.
+func (in *OfflineIssuer) DeepCopy() *OfflineIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(OfflineIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PKCS12Keystore) DeepCopyInto(out *PKCS12Keystore) {
 	*out = *in
@@ -809,6 +1294,11 @@ func (in *SelfSignedIssuer) DeepCopyInto(out *SelfSignedIssuer) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NotBeforeBackdate != nil {
+		in, out := &in.NotBeforeBackdate, &out.NotBeforeBackdate
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -857,6 +1347,11 @@ func (in *VaultAuth) DeepCopyInto(out *VaultAuth) {
 		*out = new(VaultKubernetesAuth)
 		**out = **in
 	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(VaultClientCertificateAuth)
+		**out = **in
+	}
 	return
 }
 
@@ -870,6 +1365,22 @@ func (in *VaultAuth) DeepCopy() *VaultAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultClientCertificateAuth) DeepCopyInto(out *VaultClientCertificateAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultClientCertificateAuth.
+func (in *VaultClientCertificateAuth) DeepCopy() *VaultClientCertificateAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultClientCertificateAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultIssuer) DeepCopyInto(out *VaultIssuer) {
 	*out = *in
@@ -884,6 +1395,11 @@ func (in *VaultIssuer) DeepCopyInto(out *VaultIssuer) {
 		*out = new(metav1.SecretKeySelector)
 		**out = **in
 	}
+	if in.Revocation != nil {
+		in, out := &in.Revocation, &out.Revocation
+		*out = new(VaultRevocation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -914,6 +1430,32 @@ func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultRevocation) DeepCopyInto(out *VaultRevocation) {
+	*out = *in
+	if in.TidyInterval != nil {
+		in, out := &in.TidyInterval, &out.TidyInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TidySafetyBuffer != nil {
+		in, out := &in.TidySafetyBuffer, &out.TidySafetyBuffer
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRevocation.
+func (in *VaultRevocation) DeepCopy() *VaultRevocation {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultRevocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiCloud) DeepCopyInto(out *VenafiCloud) {
 	*out = *in
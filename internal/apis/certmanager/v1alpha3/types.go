@@ -156,6 +156,7 @@ const (
 // "server auth",
 // "client auth",
 // "code signing",
+// "document signing",
 // "email protection",
 // "s/mime",
 // "ipsec end system",
@@ -165,7 +166,7 @@ const (
 // "ocsp signing",
 // "microsoft sgc",
 // "netscape sgc"
-// +kubebuilder:validation:Enum="signing";"digital signature";"content commitment";"key encipherment";"key agreement";"data encipherment";"cert sign";"crl sign";"encipher only";"decipher only";"any";"server auth";"client auth";"code signing";"email protection";"s/mime";"ipsec end system";"ipsec tunnel";"ipsec user";"timestamping";"ocsp signing";"microsoft sgc";"netscape sgc"
+// +kubebuilder:validation:Enum="signing";"digital signature";"content commitment";"key encipherment";"key agreement";"data encipherment";"cert sign";"crl sign";"encipher only";"decipher only";"any";"server auth";"client auth";"code signing";"document signing";"email protection";"s/mime";"ipsec end system";"ipsec tunnel";"ipsec user";"timestamping";"ocsp signing";"microsoft sgc";"netscape sgc"
 type KeyUsage string
 
 const (
@@ -183,6 +184,7 @@ const (
 	UsageServerAuth        KeyUsage = "server auth"
 	UsageClientAuth        KeyUsage = "client auth"
 	UsageCodeSigning       KeyUsage = "code signing"
+	UsageDocumentSigning   KeyUsage = "document signing"
 	UsageEmailProtection   KeyUsage = "email protection"
 	UsageSMIME             KeyUsage = "s/mime"
 	UsageIPsecEndSystem    KeyUsage = "ipsec end system"
@@ -221,8 +221,110 @@ type CertificateSpec struct {
 	// the controller and webhook components.
 	// +optional
 	AdditionalOutputFormats []CertificateAdditionalOutputFormat `json:"additionalOutputFormats,omitempty"`
+
+	// ClientIdentity configures this Certificate to be a client certificate
+	// identifying a ServiceAccount, for use in mutual TLS.
+	// When set, `usages` defaults to `digital signature`, `key encipherment`
+	// and `client auth`, a SPIFFE URI SAN is added for the ServiceAccount, the
+	// common name defaults to the ServiceAccount's username, and the target
+	// Secret contains a `tls-client-bundle.pem` entry with the private key and
+	// signed certificate chain.
+	// +optional
+	ClientIdentity *CertificateClientIdentity `json:"clientIdentity,omitempty"`
+
+	// SMIME configures this Certificate to be an S/MIME certificate, used to
+	// sign and encrypt email, for example for the mailboxes of users managed
+	// through Kubernetes.
+	// When set, `usages` defaults to `digital signature`, `key encipherment`
+	// and `email protection`, at least one of `emailAddresses` must be set,
+	// and the target Secret contains a `smime.p12` PKCS#12 bundle with the
+	// private key and signed certificate chain, which can be imported into
+	// mail clients. S/MIME certificates can be issued by the CA and Venafi
+	// issuers.
+	// +optional
+	SMIME *CertificateSMIME `json:"smime,omitempty"`
+
+	// SplitIssuances issues the DNS names of this Certificate which are in
+	// the given DNS zones from other issuers, for example to obtain
+	// certificates for mesh-internal names from a private CA while the public
+	// names are issued by `issuerRef`. The DNS names of a split issuance are
+	// removed from the certificate issued by `issuerRef`, and the certificate
+	// of each split issuance is stored in the target Secret alongside it,
+	// using the same private key.
+	// This is an Alpha Feature and is only enabled with the
+	// `--feature-gates=SplitIssuance=true` option on both the controller and
+	// webhook components.
+	// +optional
+	SplitIssuances []CertificateSplitIssuance `json:"splitIssuances,omitempty"`
+
+	// ControllerName is the class of the cert-manager controller which
+	// should manage this Certificate, for running several installations of
+	// cert-manager in one cluster. It is matched against the
+	// `--controller-class` flag of the controller; if unset, the Certificate
+	// is only managed by controllers which have no class configured.
+	// +optional
+	ControllerName string `json:"controllerName,omitempty"`
+
+	// CrossSignedChain configures which chain is written to the target
+	// Secret if the issuer returns a chain to another root, which cross-signed
+	// the root of its CA, alongside the certificate. One of `Primary`, where
+	// `tls.crt` and `ca.crt` hold the chain to the root of the CA, `CrossSigned`,
+	// where they hold the chain to the other root, or `Both`, where `tls.crt`
+	// holds the chain to the other root and `ca.crt` holds both roots, so that
+	// clients trusting either root can verify the certificate during a root
+	// rotation. Changes take effect on the next issuance. Defaults to `Primary`.
+	// +optional
+	CrossSignedChain CrossSignedChainPolicy `json:"crossSignedChain,omitempty"`
+
+	// SecretDeletionPolicy controls whether the Secrets managed by this
+	// Certificate are deleted when the Certificate is deleted. One of
+	// `Retain`, where the Secrets are left in place, or `Delete`, where they
+	// are deleted by the `certificates-secret-cleanup` controller, which must
+	// be enabled. Defaults to `Retain`.
+	// +optional
+	SecretDeletionPolicy SecretDeletionPolicy `json:"secretDeletionPolicy,omitempty"`
+
+	// AdditionalCABundle is a reference to a key of a ConfigMap or Secret in
+	// the namespace of the Certificate holding PEM encoded CA certificates,
+	// which are appended to the `ca.crt` entry of the target Secret whenever
+	// it is written. This is for applications which use `ca.crt` as their
+	// only trust store, and need to trust other roots than the issuing CA.
+	// Changes to the bundle are picked up the next time the Secret is written.
+	// +optional
+	AdditionalCABundle *CertificateAdditionalCABundle `json:"additionalCABundle,omitempty"`
 }
 
+// CrossSignedChainPolicy configures which chain is written to the Secret of a
+// Certificate whose issuer returns a cross-signed chain.
+// +kubebuilder:validation:Enum=Primary;CrossSigned;Both
+type CrossSignedChainPolicy string
+
+const (
+	// WritePrimaryChain writes the chain to the root of the CA of the issuer.
+	WritePrimaryChain CrossSignedChainPolicy = "Primary"
+
+	// WriteCrossSignedChain writes the chain to the root which
+	// cross-signed the root of the CA of the issuer.
+	WriteCrossSignedChain CrossSignedChainPolicy = "CrossSigned"
+
+	// WriteBothChains writes the chain to the root which cross-signed the
+	// root of the CA of the issuer, and both roots as the CA.
+	WriteBothChains CrossSignedChainPolicy = "Both"
+)
+
+// SecretDeletionPolicy controls whether the Secrets managed by a Certificate
+// are deleted when the Certificate is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type SecretDeletionPolicy string
+
+const (
+	// RetainSecret leaves the Secrets of the Certificate in place.
+	RetainSecret SecretDeletionPolicy = "Retain"
+
+	// DeleteSecret deletes the Secrets of the Certificate.
+	DeleteSecret SecretDeletionPolicy = "Delete"
+)
+
 // CertificatePrivateKey contains configuration options for private keys
 // used by the Certificate controller.
 // This allows control of how private keys are rotated.
@@ -256,6 +358,70 @@ var (
 	RotationPolicyAlways PrivateKeyRotationPolicy = "Always"
 )
 
+// CertificateClientIdentity defines the ServiceAccount identified by a client
+// certificate.
+type CertificateClientIdentity struct {
+	// ServiceAccountName is the name of the ServiceAccount, in the namespace
+	// of the Certificate, that the client certificate identifies.
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// TrustDomain is the SPIFFE trust domain used in the URI SAN of the
+	// client certificate, which is
+	// `spiffe://<trustDomain>/ns/<namespace>/sa/<serviceAccountName>`.
+	// Defaults to `cluster.local`.
+	// +optional
+	TrustDomain string `json:"trustDomain,omitempty"`
+}
+
+// CertificateSMIME configures the PKCS#12 bundle of an S/MIME certificate.
+type CertificateSMIME struct {
+	// PasswordSecretRef is a reference to a key in a Secret resource
+	// containing the password used to encrypt the `smime.p12` bundle.
+	PasswordSecretRef cmmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// CertificateAdditionalCABundle references the CA certificates appended to
+// the `ca.crt` entry of the target Secret of a Certificate. Exactly one of
+// `configMap` or `secret` must be set.
+type CertificateAdditionalCABundle struct {
+	// ConfigMap is a reference to a key of a ConfigMap holding the bundle.
+	// +optional
+	ConfigMap *CABundleKeySelector `json:"configMap,omitempty"`
+
+	// Secret is a reference to a key of a Secret holding the bundle.
+	// +optional
+	Secret *CABundleKeySelector `json:"secret,omitempty"`
+}
+
+// CABundleKeySelector selects a key of a ConfigMap or Secret resource in the
+// namespace of the Certificate.
+type CABundleKeySelector struct {
+	// Name of the resource.
+	Name string `json:"name"`
+
+	// Key of the entry holding the PEM encoded CA certificates.
+	Key string `json:"key"`
+}
+
+// CertificateSplitIssuance defines a set of DNS names of a Certificate which
+// are issued by a different issuer than the rest of the Certificate.
+type CertificateSplitIssuance struct {
+	// Name of the split issuance. The signed certificate chain is stored in
+	// the `<name>.crt` entry of the target Secret, and the CA certificate, if
+	// known, in the `<name>-ca.crt` entry. The private key is the one stored
+	// in the `tls.key` entry.
+	Name string `json:"name"`
+
+	// DNSZones is the list of DNS zones whose names are issued by IssuerRef.
+	// A DNS name of the Certificate belongs to a zone if it is equal to, or a
+	// subdomain of, the zone. If a name belongs to the zones of several split
+	// issuances, it is issued by the one with the longest matching zone.
+	DNSZones []string `json:"dnsZones"`
+
+	// IssuerRef is a reference to the issuer of the DNS names in DNSZones.
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+}
+
 // X509Subject Full X509 name specification
 type X509Subject struct {
 	// Organizations to be used on the Certificate.
@@ -359,6 +525,11 @@ type CertificateStatus struct {
 	// +optional
 	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 
+	// The hex encoded serial number of the certificate stored in the
+	// Secret of the Certificate.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
 	// RenewalTime is the time at which the certificate will be next
 	// renewed.
 	// If not set, no upcoming renewal is scheduled.
@@ -398,6 +569,95 @@ type CertificateStatus struct {
 	// time.Hour * 2 ^ (failedIssuanceAttempts - 1).
 	// +optional
 	FailedIssuanceAttempts *int `json:"failedIssuanceAttempts,omitempty"`
+
+	// TransparencyLogEntry is the entry of the current certificate in the
+	// transparency log that issued certificates are published to, if the
+	// controller has been configured with a transparency log.
+	// +optional
+	TransparencyLogEntry *CertificateTransparencyLogEntry `json:"transparencyLogEntry,omitempty"`
+
+	// Certificate describes the X.509 certificate currently stored in the
+	// Secret of this Certificate, so that it can be inspected without
+	// decoding the Secret.
+	// +optional
+	Certificate *CertificateDetails `json:"certificate,omitempty"`
+}
+
+// CertificateTransparencyLogEntry is the entry of an issued certificate in a
+// Rekor compatible transparency log.
+type CertificateTransparencyLogEntry struct {
+	// LogURL is the URL of the transparency log that the certificate was
+	// published to.
+	LogURL string `json:"logURL"`
+
+	// UUID is the unique identifier of the entry in the transparency log.
+	UUID string `json:"uuid"`
+
+	// LogIndex is the index of the entry in the transparency log.
+	LogIndex int64 `json:"logIndex"`
+
+	// Fingerprint is the hex encoded SHA-256 fingerprint of the DER encoded
+	// certificate that was published.
+	Fingerprint string `json:"fingerprint"`
+
+	// IntegratedTime is the time at which the entry was added to the
+	// transparency log.
+	// +optional
+	IntegratedTime *metav1.Time `json:"integratedTime,omitempty"`
+
+	// InclusionProof proves that the entry is included in the Merkle tree of
+	// the transparency log.
+	// +optional
+	InclusionProof *CertificateTransparencyLogInclusionProof `json:"inclusionProof,omitempty"`
+}
+
+// CertificateTransparencyLogInclusionProof is the proof that an entry is
+// included in the Merkle tree of a transparency log.
+type CertificateTransparencyLogInclusionProof struct {
+	// LogIndex is the index of the entry in the Merkle tree.
+	LogIndex int64 `json:"logIndex"`
+
+	// RootHash is the hex encoded root hash of the Merkle tree that the
+	// proof was computed against.
+	RootHash string `json:"rootHash"`
+
+	// TreeSize is the size of the Merkle tree that the proof was computed
+	// against.
+	TreeSize int64 `json:"treeSize"`
+
+	// Hashes are the hex encoded hashes of the audit path from the entry to
+	// the root hash.
+	Hashes []string `json:"hashes"`
+}
+
+// CertificateDetails describes an issued X.509 certificate.
+type CertificateDetails struct {
+	// SerialNumber is the hex encoded serial number of the certificate.
+	SerialNumber string `json:"serialNumber"`
+
+	// FingerprintSHA256 is the hex encoded SHA-256 fingerprint of the DER
+	// encoded certificate.
+	FingerprintSHA256 string `json:"fingerprintSHA256"`
+
+	// NotBefore is the time from which the certificate is valid.
+	NotBefore metav1.Time `json:"notBefore"`
+
+	// NotAfter is the time until which the certificate is valid.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// Subject is the distinguished name of the subject of the certificate,
+	// formatted as described in RFC 4514.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Issuer is the distinguished name of the issuer of the certificate,
+	// formatted as described in RFC 4514.
+	Issuer string `json:"issuer"`
+
+	// SubjectAltNames is a summary of the subject alternative names of the
+	// certificate, e.g. `DNS:example.com, IP:10.0.0.1 and 3 more`.
+	// +optional
+	SubjectAltNames string `json:"subjectAltNames,omitempty"`
 }
 
 // CertificateCondition contains condition information for an Certificate.
@@ -87,6 +87,159 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig `json:",inline"`
+
+	// HTTPClient configures the HTTP client used when communicating with the
+	// ACME server, Vault or Venafi.
+	// This can be used to connect through a proxy, or to trust a private CA,
+	// without having to change the environment of the cert-manager controller.
+	// +optional
+	HTTPClient *IssuerHTTPClient `json:"httpClient,omitempty"`
+
+	// ControllerName is the class of the cert-manager controller which
+	// should manage this issuer, for running several installations of
+	// cert-manager in one cluster. It is matched against the
+	// `--controller-class` flag of the controller; if unset, the issuer is
+	// only managed by controllers which have no class configured.
+	// +optional
+	ControllerName string `json:"controllerName,omitempty"`
+
+	// DurationTolerance configures how much shorter than requested the
+	// lifetime of certificates issued by this issuer may be, for CAs which
+	// clamp the lifetime of the certificates they issue, such as many ACME
+	// servers or Venafi policies. If the lifetime of an issued certificate
+	// falls short of the Certificate's spec.duration by no more than the
+	// tolerance, the Certificate is given an IssuerClampedDuration condition
+	// instead of a mismatch being reported.
+	// +optional
+	DurationTolerance *IssuerDurationTolerance `json:"durationTolerance,omitempty"`
+
+	// Capabilities declares the key usages which the CA of this issuer
+	// supports, and what to do when a Certificate requests others. This
+	// prevents the CA from silently stripping unsupported usages, which would
+	// cause the Certificate to be re-issued endlessly.
+	// +optional
+	Capabilities *IssuerCapabilities `json:"capabilities,omitempty"`
+
+	// CABundle is a PEM encoded bundle of root certificates which the
+	// certificates issued by this ACME, Vault or Venafi issuer must chain up
+	// to. If set, a certificate returned by the CA which doesn't chain up to
+	// one of these roots is rejected before it is written to the target
+	// Secret, which protects against a compromised or misconfigured CA
+	// endpoint returning unexpected chains.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// IntermediateCA configures this ClusterIssuer to provision an
+	// intermediate CA in each of the namespaces it selects: a Certificate
+	// with isCA set which is issued by this ClusterIssuer, and a CA Issuer in
+	// the namespace which signs using it. This gives each tenant its own
+	// issuing CA, which can only be used by Certificates in its namespace.
+	// It may only be set on ClusterIssuers.
+	// +optional
+	IntermediateCA *IssuerIntermediateCA `json:"intermediateCA,omitempty"`
+}
+
+// IssuerCapabilities declares the capabilities of the CA of an issuer.
+type IssuerCapabilities struct {
+	// Usages is the set of key usages and extended key usages which the CA of
+	// this issuer includes in the certificates it issues. If empty, all usages
+	// are assumed to be supported.
+	// +optional
+	Usages []KeyUsage `json:"usages,omitempty"`
+
+	// UnsupportedUsagesPolicy controls what happens when a Certificate
+	// requests usages which are not listed in Usages. If set to `Drop`, the
+	// unsupported usages are left out of the CertificateRequest and the
+	// Certificate is given an UsagesDropped condition. If set to `Fail`, the
+	// issuance fails without a CertificateRequest being created.
+	// Defaults to `Drop`.
+	// +optional
+	UnsupportedUsagesPolicy UnsupportedUsagesPolicy `json:"unsupportedUsagesPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Drop;Fail
+type UnsupportedUsagesPolicy string
+
+const (
+	// DropUnsupportedUsages leaves usages which are not supported by the
+	// issuer out of CertificateRequests.
+	DropUnsupportedUsages UnsupportedUsagesPolicy = "Drop"
+
+	// FailUnsupportedUsages fails the issuance of Certificates which request
+	// usages which are not supported by the issuer.
+	FailUnsupportedUsages UnsupportedUsagesPolicy = "Fail"
+)
+
+// IssuerDurationTolerance configures by how much the lifetime of certificates
+// issued by an issuer may fall short of the requested duration.
+type IssuerDurationTolerance struct {
+	// MaxShortening is the maximum amount by which the lifetime of an issued
+	// certificate may be shorter than the spec.duration of its Certificate,
+	// e.g. '6480h' for a CA which issues certificates valid for 90 days when
+	// one year is requested.
+	MaxShortening metav1.Duration `json:"maxShortening"`
+}
+
+// IssuerIntermediateCA configures the intermediate CAs which a ClusterIssuer
+// provisions in the namespaces it selects.
+type IssuerIntermediateCA struct {
+	// NamespaceSelector selects the namespaces in which an intermediate CA is
+	// provisioned. If not set, an intermediate CA is provisioned in every
+	// namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// IssuerName is the name of the CA Issuer provisioned in each namespace,
+	// which is also the name of the Certificate of its intermediate CA. The
+	// intermediate CA is stored in the Secret `<issuerName>-ca`.
+	// Defaults to the name of the ClusterIssuer.
+	// +optional
+	IssuerName string `json:"issuerName,omitempty"`
+
+	// Duration is the requested lifetime of the intermediate CA certificates.
+	// If not set, the default duration of Certificates is used.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before the intermediate CA certificates expire
+	// that they are renewed. If not set, the default of Certificates is used.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// IssuerHTTPClient configures the HTTP client used by an issuer for all
+// outbound requests.
+type IssuerHTTPClient struct {
+	// ProxyURL is the URL of the proxy to use for requests made by this
+	// issuer, e.g. 'http://proxy.example.com:3128'. If not set, the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the
+	// cert-manager controller are used.
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// NoProxy is a list of hosts, domains, IP addresses or CIDRs for which the
+	// proxy should not be used, with the same format as the NO_PROXY
+	// environment variable. Only used if ProxyURL is set.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// CABundle is a PEM encoded bundle of CA certificates which are trusted, in
+	// addition to the cert-manager controller system root certificates, when
+	// connecting using HTTPS.
+	// Mutually exclusive with CABundleSecretRef.
+	// Any CA bundle configured directly on the Vault or Venafi issuer
+	// takes precedence.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// CABundleSecretRef is a reference to a Secret which contains a PEM encoded
+	// bundle of CA certificates which are trusted, in addition to the
+	// cert-manager controller system root certificates, when connecting using
+	// HTTPS.
+	// Mutually exclusive with CABundle.
+	// If no key for the Secret is specified, cert-manager will default to 'ca.crt'.
+	// +optional
+	CABundleSecretRef *cmmeta.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
 }
 
 // The configuration for the issuer.
@@ -117,6 +270,95 @@ type IssuerConfig struct {
 	// or Venafi Cloud policy zone.
 	// +optional
 	Venafi *VenafiIssuer `json:"venafi,omitempty"`
+
+	// Fake configures this issuer to sign certificates instantly using an
+	// ephemeral CA which is generated in memory by the controller, with
+	// optional artificial latency and failures. It is meant for testing and
+	// demos only and requires the FakeIssuer feature gate to be enabled.
+	// +optional
+	Fake *FakeIssuer `json:"fake,omitempty"`
+
+	// Delegate configures this issuer to pass CertificateRequests on to one
+	// of several backing issuers, chosen by the labels of the namespace of
+	// the request or by the DNS names it requests. It requires the
+	// DelegateIssuer feature gate to be enabled.
+	// +optional
+	Delegate *DelegateIssuer `json:"delegate,omitempty"`
+
+	// Offline configures this issuer to have certificates signed by a CA
+	// which cannot be reached from the cluster. CertificateRequests are
+	// exported using `cmctl x offline export`, signed by the offline CA, and
+	// the signed certificates are imported using `cmctl x offline import`.
+	// It requires the OfflineIssuer feature gate to be enabled.
+	// +optional
+	Offline *OfflineIssuer `json:"offline,omitempty"`
+}
+
+// Configures an issuer to sign certificates using an ephemeral CA which is
+// generated in memory by the controller. A new CA is generated each time the
+// controller starts.
+type FakeIssuer struct {
+	// Latency is how long the issuer waits after a CertificateRequest has been
+	// created before signing it. If not set, requests are signed straight away.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+
+	// FailurePercentage is the percentage of CertificateRequests, between 0
+	// and 100, which are failed instead of being signed. Whether a request
+	// fails is decided from its UID, so retrying the same request has the
+	// same outcome.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	FailurePercentage int32 `json:"failurePercentage,omitempty"`
+
+	// FailureMessage is the message with which failed CertificateRequests
+	// are marked as failed. Defaults to "Injected failure".
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+}
+
+// Configures an issuer to delegate signing to one of several backing issuers.
+// For each CertificateRequest, a CertificateRequest for the backing issuer of
+// the first matching route is created, and its result is copied back once it
+// has been signed.
+type DelegateIssuer struct {
+	// Routes are the candidate backing issuers, in order of precedence.
+	// A CertificateRequest matching none of the routes is failed.
+	Routes []DelegateIssuerRoute `json:"routes"`
+}
+
+// DelegateIssuerRoute selects the backing issuer used for the
+// CertificateRequests it matches. A route without any selector matches all
+// CertificateRequests.
+type DelegateIssuerRoute struct {
+	// NamespaceSelector matches CertificateRequests in namespaces with
+	// matching labels.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// DNSZones matches CertificateRequests for which every requested DNS
+	// name is equal to, or a subdomain of, one of the listed zones.
+	// CertificateRequests without DNS names never match.
+	// +optional
+	DNSZones []string `json:"dnsZones,omitempty"`
+
+	// IssuerRef references the backing issuer which signs the
+	// CertificateRequests matching this route. Issuers are looked up in the
+	// namespace of the CertificateRequest, unless a namespace is set.
+	// Delegate issuers may not be used as backing issuers.
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+}
+
+// Configures an issuer to have certificates signed by an offline CA. The
+// certificates of CertificateRequests for this issuer are imported by setting
+// the `offline.cert-manager.io/certificate` annotation, and are only accepted
+// if they match the request and chain up to the CA bundle.
+type OfflineIssuer struct {
+	// CABundle is a PEM encoded bundle of the CA certificates of the offline
+	// CA. Imported certificates must chain up to one of these certificates,
+	// and the bundle is used as the CA of the issued certificates.
+	CABundle []byte `json:"caBundle"`
 }
 
 // Configures an issuer to sign certificates using a Venafi TPP
@@ -169,6 +411,17 @@ type VenafiCloud struct {
 
 	// APITokenSecretRef is a secret key selector for the Venafi Cloud API token.
 	APITokenSecretRef cmmeta.SecretKeySelector `json:"apiTokenSecretRef"`
+
+	// Application is the name of the Venafi Cloud application that
+	// certificates are requested for. It must be set together with
+	// IssuingTemplate, in which case the zone of the issuer must not be set.
+	// +optional
+	Application string `json:"application,omitempty"`
+
+	// IssuingTemplate is the alias of the Venafi Cloud issuing template, of
+	// the application, used to issue certificates.
+	// +optional
+	IssuingTemplate string `json:"issuingTemplate,omitempty"`
 }
 
 // Configures an issuer to 'self sign' certificates using the
@@ -179,6 +432,24 @@ type SelfSignedIssuer struct {
 	// If not set certificate will be issued without CDP. Values are strings.
 	// +optional
 	CRLDistributionPoints []string `json:"crlDistributionPoints,omitempty"`
+
+	// NotBeforeBackdate is the amount of time by which the NotBefore of
+	// certificates issued by this issuer is set before the time of issuance,
+	// so that they aren't rejected by clients with slightly skewed clocks.
+	// It can be overridden for a Certificate using the
+	// `cert-manager.io/not-before-backdate` annotation. If not set,
+	// certificates are valid from the time of issuance.
+	// +optional
+	NotBeforeBackdate *metav1.Duration `json:"notBeforeBackdate,omitempty"`
+
+	// KeyIdentifierMethod is the method used to derive the subject and
+	// authority key identifiers of certificates issued by this issuer. One of
+	// `SHA1`, the SHA-1 hash of the subject public key as described in RFC
+	// 5280, or `TruncatedSHA256`, the leftmost 160 bits of its SHA-256 hash
+	// as described in RFC 7093. If not set, only CA certificates get a
+	// subject key identifier.
+	// +optional
+	KeyIdentifierMethod KeyIdentifierMethod `json:"keyIdentifierMethod,omitempty"`
 }
 
 // Configures an issuer to sign certificates using a HashiCorp Vault
@@ -215,10 +486,39 @@ type VaultIssuer struct {
 	// If no key for the Secret is specified, cert-manager will default to 'ca.crt'.
 	// +optional
 	CABundleSecretRef *cmmeta.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
+	// Revocation configures the revocation of certificates signed by this
+	// issuer, and the periodic tidying of the PKI backend that Path belongs
+	// to, so that Vault's CRL stays accurate.
+	// Requires the `certificates-vault-revocation` controller to be enabled.
+	// +optional
+	Revocation *VaultRevocation `json:"revocation,omitempty"`
+}
+
+// VaultRevocation configures the revocation of certificates signed by a Vault
+// issuer using the `revoke` and `tidy` endpoints of its PKI backend.
+// Certificates annotated with `cert-manager.io/revoke: "true"` are always
+// revoked when this is set.
+type VaultRevocation struct {
+	// RevokeOnDelete revokes the certificate stored in the Secret of a
+	// Certificate when the Certificate is deleted.
+	// +optional
+	RevokeOnDelete bool `json:"revokeOnDelete,omitempty"`
+
+	// TidyInterval is how often the `tidy` endpoint of the PKI backend is
+	// called to remove expired certificates from its storage and CRL.
+	// If unset, cert-manager does not tidy the PKI backend.
+	// +optional
+	TidyInterval *metav1.Duration `json:"tidyInterval,omitempty"`
+
+	// TidySafetyBuffer is how long after their expiry certificates are kept
+	// by the `tidy` endpoint. If unset, Vault's default of 72h is used.
+	// +optional
+	TidySafetyBuffer *metav1.Duration `json:"tidySafetyBuffer,omitempty"`
 }
 
 // Configuration used to authenticate with a Vault server.
-// Only one of `tokenSecretRef`, `appRole` or `kubernetes` may be specified.
+// Only one of `tokenSecretRef`, `appRole`, `kubernetes` or `clientCertificate` may be specified.
 type VaultAuth struct {
 	// TokenSecretRef authenticates with Vault by presenting a token.
 	// +optional
@@ -233,6 +533,12 @@ type VaultAuth struct {
 	// token stored in the named Secret resource to the Vault server.
 	// +optional
 	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+
+	// ClientCertificate authenticates with Vault by presenting a client
+	// certificate stored in a Kubernetes Secret resource, using the TLS
+	// Certificates auth method.
+	// +optional
+	ClientCertificate *VaultClientCertificateAuth `json:"clientCertificate,omitempty"`
 }
 
 // VaultAppRole authenticates with Vault using the App Role auth mechanism,
@@ -273,6 +579,28 @@ type VaultKubernetesAuth struct {
 	Role string `json:"role"`
 }
 
+// VaultClientCertificateAuth authenticates with Vault using the TLS
+// Certificates auth method, presenting the certificate and private key stored
+// in a Kubernetes Secret resource. The Secret may be managed by a cert-manager
+// Certificate, so that no static credentials are required.
+type VaultClientCertificateAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. For example, setting a value to `/v1/auth/foo`, will use the path
+	// `/v1/auth/foo/login` to authenticate with Vault. If unspecified, the
+	// default value "/v1/auth/cert" will be used.
+	// +optional
+	Path string `json:"mountPath,omitempty"`
+
+	// SecretName is the name of a Secret of type `kubernetes.io/tls`, whose
+	// `tls.crt` and `tls.key` are used as the client certificate.
+	SecretName string `json:"secretName"`
+
+	// Name of the certificate role to authenticate against. If unspecified,
+	// Vault attempts to match the client certificate against all roles.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
 type CAIssuer struct {
 	// SecretName is the name of the secret used to sign Certificates issued
 	// by this Issuer.
@@ -291,8 +619,65 @@ type CAIssuer struct {
 	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
 	// +optional
 	OCSPServers []string `json:"ocspServers,omitempty"`
+
+	// NotBeforeBackdate is the amount of time by which the NotBefore of
+	// certificates issued by this issuer is set before the time of issuance,
+	// so that they aren't rejected by clients with slightly skewed clocks.
+	// It can be overridden for a Certificate using the
+	// `cert-manager.io/not-before-backdate` annotation. If not set,
+	// certificates are valid from the time of issuance.
+	// +optional
+	NotBeforeBackdate *metav1.Duration `json:"notBeforeBackdate,omitempty"`
+
+	// SerialNumberBits is the number of random bits in the serial numbers of
+	// certificates issued by this issuer, between 64 and 159. If not set,
+	// serial numbers have 128 random bits.
+	// +optional
+	SerialNumberBits int `json:"serialNumberBits,omitempty"`
+
+	// TrackSerialNumbers enables recording the serial numbers of the
+	// certificates issued by this issuer in a ConfigMap named
+	// `<secretName>-serial-numbers`, in the namespace of the CA Secret, so that
+	// a serial number is never issued twice. The ConfigMap also serves as a
+	// record of all issued serial numbers for audits.
+	// +optional
+	TrackSerialNumbers bool `json:"trackSerialNumbers,omitempty"`
+
+	// KeyIdentifierMethod is the method used to derive the subject key
+	// identifier of certificates issued by this issuer, and their authority
+	// key identifier if the CA certificate doesn't have a subject key
+	// identifier. One of `SHA1`, the SHA-1 hash of the subject public key as
+	// described in RFC 5280, or `TruncatedSHA256`, the leftmost 160 bits of
+	// its SHA-256 hash as described in RFC 7093. If not set, only CA
+	// certificates get a subject key identifier.
+	// +optional
+	KeyIdentifierMethod KeyIdentifierMethod `json:"keyIdentifierMethod,omitempty"`
+
+	// CrossSignedChainSecretName is the name of a Secret, in the same namespace
+	// as the CA Secret, whose `tls.crt` holds the PEM encoded chain from the
+	// root of the CA to another root which cross-signed it: the cross-signed
+	// certificates followed by the other root. If set, the chain is returned
+	// alongside issued certificates, so that Certificates can publish a chain
+	// to either root while clients move from one root to the other.
+	// +optional
+	CrossSignedChainSecretName string `json:"crossSignedChainSecretName,omitempty"`
 }
 
+// KeyIdentifierMethod is a method used to derive the key identifiers of
+// certificates from their public key.
+// +kubebuilder:validation:Enum=SHA1;TruncatedSHA256
+type KeyIdentifierMethod string
+
+const (
+	// SHA1KeyIdentifierMethod derives key identifiers from the SHA-1 hash of
+	// the subject public key.
+	SHA1KeyIdentifierMethod KeyIdentifierMethod = "SHA1"
+
+	// TruncatedSHA256KeyIdentifierMethod derives key identifiers from the
+	// leftmost 160 bits of the SHA-256 hash of the subject public key.
+	TruncatedSHA256KeyIdentifierMethod KeyIdentifierMethod = "TruncatedSHA256"
+)
+
 // IssuerStatus contains status information about an Issuer
 type IssuerStatus struct {
 	// List of status conditions to indicate the status of a CertificateRequest.
@@ -307,6 +692,66 @@ type IssuerStatus struct {
 	// server to issue certificates.
 	// +optional
 	ACME *cmacme.ACMEIssuerStatus `json:"acme,omitempty"`
+
+	// Usage summarises the Certificates and CertificateRequests which
+	// reference this issuer, so that the impact of changing it can be judged.
+	// This field is only set on ClusterIssuers, by the clusterissuers-usage
+	// controller.
+	// +optional
+	Usage *IssuerUsageStatus `json:"usage,omitempty"`
+}
+
+// IssuerUsageStatus summarises the resources which reference a ClusterIssuer.
+// Recent counts cover the CertificateRequests which completed within the
+// last 24 hours.
+type IssuerUsageStatus struct {
+	// Certificates is the number of Certificates which reference the issuer.
+	Certificates int32 `json:"certificates"`
+
+	// ReadyCertificates is the number of Certificates which reference the
+	// issuer and are Ready.
+	ReadyCertificates int32 `json:"readyCertificates"`
+
+	// RecentIssuances is the number of CertificateRequests referencing the
+	// issuer which were issued within the last 24 hours.
+	RecentIssuances int32 `json:"recentIssuances"`
+
+	// RecentFailures is the number of CertificateRequests referencing the
+	// issuer which failed within the last 24 hours.
+	RecentFailures int32 `json:"recentFailures"`
+
+	// LastRateLimitedTime is the most recent time within the last 24 hours at
+	// which a CertificateRequest referencing the issuer failed because the
+	// issuer, such as an ACME server, rate limited it. It is unset if no
+	// request was rate limited within the last 24 hours.
+	// +optional
+	LastRateLimitedTime *metav1.Time `json:"lastRateLimitedTime,omitempty"`
+
+	// Namespaces breaks down the usage of the issuer by the namespace of the
+	// Certificates and CertificateRequests which reference it.
+	// +listType=map
+	// +listMapKey=namespace
+	// +optional
+	Namespaces []IssuerNamespaceUsage `json:"namespaces,omitempty"`
+}
+
+// IssuerNamespaceUsage summarises the resources in a single namespace which
+// reference a ClusterIssuer.
+type IssuerNamespaceUsage struct {
+	// Namespace is the namespace of the resources.
+	Namespace string `json:"namespace"`
+
+	// Certificates is the number of Certificates in the namespace which
+	// reference the issuer.
+	Certificates int32 `json:"certificates"`
+
+	// ReadyCertificates is the number of Certificates in the namespace which
+	// reference the issuer and are Ready.
+	ReadyCertificates int32 `json:"readyCertificates"`
+
+	// RecentFailures is the number of CertificateRequests in the namespace
+	// referencing the issuer which failed within the last 24 hours.
+	RecentFailures int32 `json:"recentFailures"`
 }
 
 // IssuerCondition contains condition information for an Issuer.
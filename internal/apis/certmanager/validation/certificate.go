@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 	"net/mail"
+	"net/url"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -27,6 +28,7 @@ import (
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
@@ -105,25 +107,18 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		el = append(el, validateIPAddresses(crt, fldPath)...)
 	}
 
+	el = append(el, validateSANTemplates(crt, fldPath)...)
+
+	if len(crt.URISANs) > 0 {
+		el = append(el, validateURIs(crt, fldPath)...)
+	}
+
 	if len(crt.EmailSANs) > 0 {
 		el = append(el, validateEmailAddresses(crt, fldPath)...)
 	}
 
 	if crt.PrivateKey != nil {
-		switch crt.PrivateKey.Algorithm {
-		case "", internalcmapi.RSAKeyAlgorithm:
-			if crt.PrivateKey.Size > 0 && (crt.PrivateKey.Size < 2048 || crt.PrivateKey.Size > 8192) {
-				el = append(el, field.Invalid(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
-			}
-		case internalcmapi.ECDSAKeyAlgorithm:
-			if crt.PrivateKey.Size > 0 && crt.PrivateKey.Size != 256 && crt.PrivateKey.Size != 384 && crt.PrivateKey.Size != 521 {
-				el = append(el, field.NotSupported(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, []string{"256", "384", "521"}))
-			}
-		case internalcmapi.Ed25519KeyAlgorithm:
-			break
-		default:
-			el = append(el, field.Invalid(fldPath.Child("privateKey", "algorithm"), crt.PrivateKey.Algorithm, "must be either empty or one of rsa or ecdsa"))
-		}
+		el = append(el, validatePrivateKey(crt.PrivateKey, fldPath.Child("privateKey"))...)
 	}
 
 	if crt.Duration != nil || crt.RenewBefore != nil {
@@ -147,21 +142,152 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 
 	el = append(el, validateAdditionalOutputFormats(crt, fldPath)...)
 
+	if crt.ClientIdentity != nil {
+		el = append(el, validateClientIdentity(crt, fldPath)...)
+	}
+
+	if crt.SMIME != nil {
+		el = append(el, validateSMIME(crt, fldPath)...)
+	}
+
+	el = append(el, validateSplitIssuances(crt, fldPath)...)
+
+	if crt.AdditionalCABundle != nil {
+		el = append(el, validateAdditionalCABundle(crt.AdditionalCABundle, fldPath.Child("additionalCABundle"))...)
+	}
+
+	el = append(el, ValidateControllerName(crt.ControllerName, fldPath.Child("controllerName"))...)
+
+	switch crt.CrossSignedChain {
+	case "", internalcmapi.WritePrimaryChain, internalcmapi.WriteCrossSignedChain, internalcmapi.WriteBothChains:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("crossSignedChain"), crt.CrossSignedChain,
+			[]string{string(internalcmapi.WritePrimaryChain), string(internalcmapi.WriteCrossSignedChain), string(internalcmapi.WriteBothChains)}))
+	}
+
+	switch crt.SecretDeletionPolicy {
+	case "", internalcmapi.RetainSecret, internalcmapi.DeleteSecret:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("secretDeletionPolicy"), crt.SecretDeletionPolicy,
+			[]string{string(internalcmapi.RetainSecret), string(internalcmapi.DeleteSecret)}))
+	}
+
 	return el
 }
 
 func ValidateCertificate(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	crt := obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateSecretName(crt.Spec.SecretName, field.NewPath("spec", "secretName"))...)
+	allErrs = append(allErrs, validateFIPSPrivateKey(crt.Spec.PrivateKey, field.NewPath("spec", "privateKey"))...)
 	return allErrs, nil
 }
 
 func ValidateUpdateCertificate(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
 	crt := obj.(*internalcmapi.Certificate)
+	oldCrt := oldObj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
+	// secretName is only validated when it is changed, so that Certificates
+	// created before it was validated can still be updated.
+	if crt.Spec.SecretName != oldCrt.Spec.SecretName {
+		allErrs = append(allErrs, validateSecretName(crt.Spec.SecretName, field.NewPath("spec", "secretName"))...)
+	}
+	// The private key is only checked against FIPS mode when it is changed,
+	// so that Certificates created before FIPS mode was enabled can still be
+	// updated.
+	if privateKeyAlgorithm(crt.Spec.PrivateKey) != privateKeyAlgorithm(oldCrt.Spec.PrivateKey) ||
+		privateKeySize(crt.Spec.PrivateKey) != privateKeySize(oldCrt.Spec.PrivateKey) {
+		allErrs = append(allErrs, validateFIPSPrivateKey(crt.Spec.PrivateKey, field.NewPath("spec", "privateKey"))...)
+	}
+	allErrs = append(allErrs, validateImmutableFields(oldCrt, crt)...)
 	return allErrs, nil
 }
 
+// validateFIPSPrivateKey rejects private keys whose algorithm is not approved
+// when FIPS mode is enabled.
+func validateFIPSPrivateKey(pk *internalcmapi.CertificatePrivateKey, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if err := pki.CheckFIPSKeyAlgorithm(cmapi.PrivateKeyAlgorithm(privateKeyAlgorithm(pk)), privateKeySize(pk)); err != nil {
+		el = append(el, field.Forbidden(fldPath, err.Error()))
+	}
+	return el
+}
+
+// validateImmutableFields rejects changes to the fields of a Certificate
+// which would orphan its Secret or the private key used by consumers, unless
+// the update sets the allow-immutable-field-updates annotation to `true`.
+func validateImmutableFields(oldCrt, crt *internalcmapi.Certificate) field.ErrorList {
+	el := field.ErrorList{}
+	if crt.Annotations[cmapi.AllowImmutableFieldUpdatesAnnotationKey] == "true" {
+		return el
+	}
+
+	msg := fmt.Sprintf("field is immutable, set the %s annotation to \"true\" to change it", cmapi.AllowImmutableFieldUpdatesAnnotationKey)
+	if crt.Spec.SecretName != oldCrt.Spec.SecretName {
+		el = append(el, field.Forbidden(field.NewPath("spec", "secretName"), msg))
+	}
+	if privateKeyAlgorithm(crt.Spec.PrivateKey) != privateKeyAlgorithm(oldCrt.Spec.PrivateKey) {
+		el = append(el, field.Forbidden(field.NewPath("spec", "privateKey", "algorithm"), msg))
+	}
+	return el
+}
+
+// privateKeyAlgorithm returns the algorithm of the private key of a
+// Certificate, which defaults to RSA.
+func privateKeyAlgorithm(pk *internalcmapi.CertificatePrivateKey) internalcmapi.PrivateKeyAlgorithm {
+	if pk == nil || pk.Algorithm == "" {
+		return internalcmapi.RSAKeyAlgorithm
+	}
+	return pk.Algorithm
+}
+
+// privateKeySize returns the size of the private key of a Certificate, or 0
+// for the default size of its algorithm.
+func privateKeySize(pk *internalcmapi.CertificatePrivateKey) int {
+	if pk == nil {
+		return 0
+	}
+	return pk.Size
+}
+
+// validateSecretName validates that the given Secret name is a DNS-1123
+// subdomain. An empty name is reported by ValidateCertificateSpec.
+func validateSecretName(name string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if name == "" {
+		return el
+	}
+	for _, msg := range apivalidation.NameIsDNSSubdomain(name, false) {
+		el = append(el, field.Invalid(fldPath, name, msg))
+	}
+	return el
+}
+
+func validatePrivateKey(pk *internalcmapi.CertificatePrivateKey, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+	switch pk.Algorithm {
+	case "", internalcmapi.RSAKeyAlgorithm:
+		if pk.Size > 0 && (pk.Size < 2048 || pk.Size > 8192) {
+			el = append(el, field.Invalid(fldPath.Child("size"), pk.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
+		}
+	case internalcmapi.ECDSAKeyAlgorithm:
+		if pk.Size > 0 && pk.Size != 256 && pk.Size != 384 && pk.Size != 521 {
+			el = append(el, field.NotSupported(fldPath.Child("size"), pk.Size, []string{"256", "384", "521"}))
+		}
+	case internalcmapi.Ed25519KeyAlgorithm:
+		break
+	case internalcmapi.MLDSAKeyAlgorithm, internalcmapi.CompositeMLDSAKeyAlgorithm:
+		if !utilfeature.DefaultFeatureGate.Enabled(feature.PluggableKeyAlgorithms) {
+			el = append(el, field.Forbidden(fldPath.Child("algorithm"), "feature gate PluggableKeyAlgorithms must be enabled"))
+		} else if pk.Size > 0 && pk.Size != 44 && pk.Size != 65 && pk.Size != 87 {
+			el = append(el, field.NotSupported(fldPath.Child("size"), pk.Size, []string{"44", "65", "87"}))
+		}
+	default:
+		el = append(el, field.Invalid(fldPath.Child("algorithm"), pk.Algorithm, "must be either empty or one of rsa or ecdsa"))
+	}
+	return el
+}
+
 func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -177,6 +303,53 @@ func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) fi
 			el = append(el, field.Invalid(issuerRefPath.Child("kind"), issuerRef.Kind, "must be one of Issuer or ClusterIssuer"))
 		}
 	}
+	if issuerRef.Namespace != "" {
+		el = append(el, validateIssuerRefNamespace(issuerRef, issuerRefPath)...)
+	}
+	return el
+}
+
+// validateIssuerRefNamespace validates a cross-namespace issuer reference,
+// which may only reference an Issuer. Whether the reference is allowed by an
+// IssuerReferenceGrant is only checked once the Issuer is used.
+func validateIssuerRefNamespace(issuerRef cmmeta.ObjectReference, issuerRefPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	namespacePath := issuerRefPath.Child("namespace")
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.IssuerReferenceGrants) {
+		return append(el, field.Forbidden(namespacePath, "Feature gate IssuerReferenceGrants must be enabled on both webhook and controller to reference an Issuer in another namespace"))
+	}
+	for _, msg := range apivalidation.ValidateNamespaceName(issuerRef.Namespace, false) {
+		el = append(el, field.Invalid(namespacePath, issuerRef.Namespace, msg))
+	}
+	if issuerRef.Group != "" && issuerRef.Group != internalcmapi.SchemeGroupVersion.Group {
+		el = append(el, field.Forbidden(namespacePath, "only Issuers may be referenced in another namespace"))
+	} else if issuerRef.Kind != "" && issuerRef.Kind != cmapi.IssuerKind {
+		el = append(el, field.Forbidden(namespacePath, "only Issuers may be referenced in another namespace"))
+	}
+	return el
+}
+
+// validateSANTemplates rejects dnsNames and uris containing template
+// variables which have not been substituted when the Certificate was
+// defaulted.
+func validateSANTemplates(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	msg := "contains a template variable which could not be resolved"
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.CertificateSANTemplates) {
+		msg = "template variables require the CertificateSANTemplates feature gate to be enabled on the webhook"
+	}
+
+	var el field.ErrorList
+	for i, dnsName := range crt.DNSNames {
+		if strings.Contains(dnsName, "${") {
+			el = append(el, field.Invalid(fldPath.Child("dnsNames").Index(i), dnsName, msg))
+		}
+	}
+	for i, uri := range crt.URISANs {
+		if strings.Contains(uri, "${") {
+			el = append(el, field.Invalid(fldPath.Child("uris").Index(i), uri, msg))
+		}
+	}
 	return el
 }
 
@@ -194,6 +367,22 @@ func validateIPAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path)
 	return el
 }
 
+func validateURIs(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, u := range a.URISANs {
+		// Template variables are reported by validateSANTemplates.
+		if strings.Contains(u, "${") {
+			continue
+		}
+		// Catch URIs which cannot be encoded into a CSR here rather than
+		// failing later on during issuance.
+		if _, err := url.Parse(u); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("uris").Index(i), u, fmt.Sprintf("invalid URI: %s", err)))
+		}
+	}
+	return el
+}
+
 func validateEmailAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	if len(a.EmailSANs) <= 0 {
 		return nil
@@ -213,12 +402,19 @@ func validateEmailAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Pat
 }
 
 func validateUsages(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	return validateKeyUsages(a.Usages, fldPath.Child("usages"))
+}
+
+// validateKeyUsages validates that each of the given usages is a known key
+// usage or extended key usage.
+func validateKeyUsages(usages []internalcmapi.KeyUsage, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
-	for i, u := range a.Usages {
+	for i, u := range usages {
 		_, kok := util.KeyUsageType(cmapi.KeyUsage(u))
 		_, ekok := util.ExtKeyUsageType(cmapi.KeyUsage(u))
-		if !kok && !ekok {
-			el = append(el, field.Invalid(fldPath.Child("usages").Index(i), u, "unknown keyusage"))
+		_, uekok := util.UnknownExtKeyUsageOID(cmapi.KeyUsage(u))
+		if !kok && !ekok && !uekok {
+			el = append(el, field.Invalid(fldPath.Index(i), u, "unknown keyusage"))
 		}
 	}
 	return el
@@ -260,6 +456,179 @@ func ValidateDuration(crt *internalcmapi.CertificateSpec, fldPath *field.Path) f
 	return el
 }
 
+func validateClientIdentity(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+	idPath := fldPath.Child("clientIdentity")
+
+	if len(crt.ClientIdentity.ServiceAccountName) == 0 {
+		el = append(el, field.Required(idPath.Child("serviceAccountName"), "must be specified"))
+	} else {
+		for _, msg := range apivalidation.NameIsDNSSubdomain(crt.ClientIdentity.ServiceAccountName, false) {
+			el = append(el, field.Invalid(idPath.Child("serviceAccountName"), crt.ClientIdentity.ServiceAccountName, msg))
+		}
+	}
+
+	// SPIFFE trust domains may only contain lowercase letters, digits, dots,
+	// dashes and underscores.
+	for _, r := range crt.ClientIdentity.TrustDomain {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '.' && r != '-' && r != '_' {
+			el = append(el, field.Invalid(idPath.Child("trustDomain"), crt.ClientIdentity.TrustDomain, "must only contain lowercase letters, digits, dots, dashes and underscores"))
+			break
+		}
+	}
+
+	if crt.IsCA {
+		el = append(el, field.Invalid(fldPath.Child("isCA"), crt.IsCA, "must not be true for a client certificate"))
+	}
+
+	return el
+}
+
+func validateSplitIssuances(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+	if len(crt.SplitIssuances) == 0 {
+		return el
+	}
+
+	splitsPath := fldPath.Child("splitIssuances")
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.SplitIssuance) {
+		return append(el, field.Forbidden(splitsPath, "feature gate SplitIssuance must be enabled"))
+	}
+
+	inSplitZone := func(dnsName string) bool {
+		for _, split := range crt.SplitIssuances {
+			for _, zone := range split.DNSZones {
+				if util.DNSNameInZone(dnsName, zone) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	names := sets.NewString()
+	for i, split := range crt.SplitIssuances {
+		splitPath := splitsPath.Index(i)
+
+		switch {
+		case len(split.Name) == 0:
+			el = append(el, field.Required(splitPath.Child("name"), "must be specified"))
+		case names.Has(split.Name):
+			el = append(el, field.Duplicate(splitPath.Child("name"), split.Name))
+		case split.Name == "tls" || split.Name == "ca":
+			// The Secret entries of these split issuances would replace
+			// the tls.crt and ca.crt entries of the Certificate.
+			el = append(el, field.Invalid(splitPath.Child("name"), split.Name, "must not be tls or ca"))
+		default:
+			for _, msg := range apivalidation.NameIsDNSLabel(split.Name, false) {
+				el = append(el, field.Invalid(splitPath.Child("name"), split.Name, msg))
+			}
+		}
+		names.Insert(split.Name)
+
+		if len(split.DNSZones) == 0 {
+			el = append(el, field.Required(splitPath.Child("dnsZones"), "at least one DNS zone must be specified"))
+		}
+		matched := false
+		for j, zone := range split.DNSZones {
+			for _, msg := range validation.IsDNS1123Subdomain(strings.TrimSuffix(strings.ToLower(zone), ".")) {
+				el = append(el, field.Invalid(splitPath.Child("dnsZones").Index(j), zone, msg))
+			}
+			for _, dnsName := range crt.DNSNames {
+				matched = matched || util.DNSNameInZone(dnsName, zone)
+			}
+		}
+		if len(split.DNSZones) > 0 && !matched {
+			el = append(el, field.Invalid(splitPath.Child("dnsZones"), split.DNSZones, "must contain at least one of the dnsNames"))
+		}
+
+		el = append(el, validateIssuerRef(split.IssuerRef, splitPath)...)
+	}
+
+	if len(crt.CommonName) > 0 && inSplitZone(crt.CommonName) {
+		el = append(el, field.Invalid(fldPath.Child("commonName"), crt.CommonName, "must not be in the dnsZones of a split issuance, as it is issued by issuerRef"))
+	}
+
+	var primaryDNSNames int
+	for _, dnsName := range crt.DNSNames {
+		if !inSplitZone(dnsName) {
+			primaryDNSNames++
+		}
+	}
+	if len(crt.CommonName) == 0 && len(crt.LiteralSubject) == 0 && primaryDNSNames == 0 && len(crt.URISANs) == 0 && len(crt.EmailSANs) == 0 && len(crt.IPAddresses) == 0 {
+		el = append(el, field.Invalid(splitsPath, "", "at least one of commonName, dnsNames, uris, ipAddresses, or emailAddresses must be issued by issuerRef rather than a split issuance"))
+	}
+
+	return el
+}
+
+func validateSMIME(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+	refPath := fldPath.Child("smime", "passwordSecretRef")
+
+	if len(crt.SMIME.PasswordSecretRef.Name) == 0 {
+		el = append(el, field.Required(refPath.Child("name"), "must be specified"))
+	}
+	if len(crt.SMIME.PasswordSecretRef.Key) == 0 {
+		el = append(el, field.Required(refPath.Child("key"), "must be specified"))
+	}
+
+	if len(crt.EmailSANs) == 0 {
+		el = append(el, field.Required(fldPath.Child("emailAddresses"), "must be specified for an S/MIME certificate"))
+	}
+
+	// Mail clients only use certificates which are valid for email protection.
+	if len(crt.Usages) > 0 {
+		hasEmailProtection := false
+		for _, usage := range crt.Usages {
+			if usage == internalcmapi.UsageEmailProtection || usage == internalcmapi.UsageSMIME {
+				hasEmailProtection = true
+				break
+			}
+		}
+		if !hasEmailProtection {
+			el = append(el, field.Invalid(fldPath.Child("usages"), crt.Usages, "must contain email protection for an S/MIME certificate"))
+		}
+	}
+
+	if crt.IsCA {
+		el = append(el, field.Invalid(fldPath.Child("isCA"), crt.IsCA, "must not be true for an S/MIME certificate"))
+	}
+
+	return el
+}
+
+func validateAdditionalCABundle(bundle *internalcmapi.CertificateAdditionalCABundle, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	switch {
+	case bundle.ConfigMap != nil && bundle.Secret != nil:
+		el = append(el, field.Forbidden(fldPath, "only one of configMap or secret may be specified"))
+	case bundle.ConfigMap == nil && bundle.Secret == nil:
+		el = append(el, field.Required(fldPath, "one of configMap or secret must be specified"))
+	}
+
+	if bundle.ConfigMap != nil {
+		el = append(el, validateCABundleKeySelector(bundle.ConfigMap, fldPath.Child("configMap"))...)
+	}
+	if bundle.Secret != nil {
+		el = append(el, validateCABundleKeySelector(bundle.Secret, fldPath.Child("secret"))...)
+	}
+
+	return el
+}
+
+func validateCABundleKeySelector(ref *internalcmapi.CABundleKeySelector, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+	if len(ref.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("name"), "must be specified"))
+	}
+	if len(ref.Key) == 0 {
+		el = append(el, field.Required(fldPath.Child("key"), "must be specified"))
+	}
+	return el
+}
+
 func validateAdditionalOutputFormats(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	var el field.ErrorList
 
@@ -116,9 +116,6 @@ func TestValidateCertificateForIssuer(t *testing.T) {
 				},
 			},
 			issuer: acmeIssuer,
-			errs: []*field.Error{
-				field.Invalid(fldPath.Child("ipAddresses"), []string{"127.0.0.1"}, "ACME does not support certificate ip addresses"),
-			},
 		},
 		"acme certificate with renewBefore set": {
 			crt: &cmapi.Certificate{
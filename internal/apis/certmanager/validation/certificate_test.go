@@ -24,6 +24,7 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
 
@@ -48,6 +49,7 @@ var (
 		},
 	}
 	maxSecretTemplateAnnotationsBytesLimit = 256 * (1 << 10) // 256 kB
+	invalidSecretNameErrors                = validation.IsDNS1123Subdomain("Invalid_Name")
 )
 
 func strPtr(s string) *string {
@@ -490,6 +492,29 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 		},
+		"valid certificate with only SPIFFE URI SAN": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					URISANs:    []string{"spiffe://cluster.local/ns/sandbox/sa/foo"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with unparsable URI SAN": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					URISANs:    []string{"spiffe://cluster local/ns/sandbox"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("uris").Index(0), "spiffe://cluster local/ns/sandbox", `invalid URI: parse "spiffe://cluster local/ns/sandbox": invalid character " " in host name`),
+			},
+		},
 		"valid certificate with only email SAN": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -666,6 +691,140 @@ func TestValidateCertificate(t *testing.T) {
 						"alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"),
 			},
 		},
+		"valid with clientIdentity": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "system:serviceaccount:default:client",
+					URISANs:    []string{"spiffe://cluster.local/ns/default/sa/client"},
+					SecretName: "abc",
+					ClientIdentity: &internalcmapi.CertificateClientIdentity{
+						ServiceAccountName: "client",
+						TrustDomain:        "cluster.local",
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid clientIdentity": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IsCA:       true,
+					ClientIdentity: &internalcmapi.CertificateClientIdentity{
+						TrustDomain: "Cluster.Local",
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("clientIdentity", "serviceAccountName"), "must be specified"),
+				field.Invalid(fldPath.Child("clientIdentity", "trustDomain"), "Cluster.Local", "must only contain lowercase letters, digits, dots, dashes and underscores"),
+				field.Invalid(fldPath.Child("isCA"), true, "must not be true for a client certificate"),
+			},
+		},
+		"valid S/MIME certificate": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					EmailSANs:  []string{"alice@example.com"},
+					SecretName: "abc",
+					Usages:     []internalcmapi.KeyUsage{internalcmapi.UsageDigitalSignature, internalcmapi.UsageKeyEncipherment, internalcmapi.UsageEmailProtection},
+					SMIME: &internalcmapi.CertificateSMIME{
+						PasswordSecretRef: cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "smime-password"},
+							Key:                  "password",
+						},
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid S/MIME certificate": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "alice",
+					SecretName: "abc",
+					IsCA:       true,
+					Usages:     []internalcmapi.KeyUsage{internalcmapi.UsageDigitalSignature, internalcmapi.UsageServerAuth},
+					SMIME:      &internalcmapi.CertificateSMIME{},
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("smime", "passwordSecretRef", "name"), "must be specified"),
+				field.Required(fldPath.Child("smime", "passwordSecretRef", "key"), "must be specified"),
+				field.Required(fldPath.Child("emailAddresses"), "must be specified for an S/MIME certificate"),
+				field.Invalid(fldPath.Child("usages"), []internalcmapi.KeyUsage{internalcmapi.UsageDigitalSignature, internalcmapi.UsageServerAuth}, "must contain email protection for an S/MIME certificate"),
+				field.Invalid(fldPath.Child("isCA"), true, "must not be true for an S/MIME certificate"),
+			},
+		},
+		"valid with a cross-signed chain": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:       "testcn",
+					SecretName:       "abc",
+					IssuerRef:        validIssuerRef,
+					CrossSignedChain: internalcmapi.WriteBothChains,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid cross-signed chain": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:       "testcn",
+					SecretName:       "abc",
+					IssuerRef:        validIssuerRef,
+					CrossSignedChain: "Oldest",
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("crossSignedChain"), internalcmapi.CrossSignedChainPolicy("Oldest"), []string{"Primary", "CrossSigned", "Both"}),
+			},
+		},
+		"valid with a secret deletion policy": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:           "testcn",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+					SecretDeletionPolicy: internalcmapi.DeleteSecret,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid secret deletion policy": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:           "testcn",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+					SecretDeletionPolicy: "Orphan",
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("secretDeletionPolicy"), internalcmapi.SecretDeletionPolicy("Orphan"), []string{"Retain", "Delete"}),
+			},
+		},
+		"invalid secretName": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "Invalid_Name",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("secretName"), "Invalid_Name", invalidSecretNameErrors[0]),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -676,6 +835,79 @@ func TestValidateCertificate(t *testing.T) {
 	}
 }
 
+func TestValidateUpdateCertificate(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	certificate := func(secretName string) *internalcmapi.Certificate {
+		return &internalcmapi.Certificate{
+			Spec: internalcmapi.CertificateSpec{
+				CommonName: "testcn",
+				SecretName: secretName,
+				IssuerRef:  validIssuerRef,
+			},
+		}
+	}
+
+	allowImmutableFieldUpdates := func(crt *internalcmapi.Certificate) *internalcmapi.Certificate {
+		crt.Annotations = map[string]string{cmapi.AllowImmutableFieldUpdatesAnnotationKey: "true"}
+		return crt
+	}
+	withPrivateKeyAlgorithm := func(crt *internalcmapi.Certificate, algorithm internalcmapi.PrivateKeyAlgorithm) *internalcmapi.Certificate {
+		crt.Spec.PrivateKey = &internalcmapi.CertificatePrivateKey{Algorithm: algorithm}
+		return crt
+	}
+	immutableMsg := `field is immutable, set the cert-manager.io/allow-immutable-field-updates annotation to "true" to change it`
+
+	scenarios := map[string]struct {
+		old, new *internalcmapi.Certificate
+		errs     []*field.Error
+	}{
+		"unchanged invalid secretName is allowed": {
+			old: certificate("Invalid_Name"),
+			new: certificate("Invalid_Name"),
+		},
+		"changed invalid secretName is rejected": {
+			old: certificate("abc"),
+			new: allowImmutableFieldUpdates(certificate("Invalid_Name")),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("secretName"), "Invalid_Name", invalidSecretNameErrors[0]),
+			},
+		},
+		"changing secretName is forbidden": {
+			old: certificate("abc"),
+			new: certificate("def"),
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("secretName"), immutableMsg),
+			},
+		},
+		"changing secretName is allowed with the annotation": {
+			old: certificate("abc"),
+			new: allowImmutableFieldUpdates(certificate("def")),
+		},
+		"changing the private key algorithm is forbidden": {
+			old: certificate("abc"),
+			new: withPrivateKeyAlgorithm(certificate("abc"), internalcmapi.ECDSAKeyAlgorithm),
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("privateKey", "algorithm"), immutableMsg),
+			},
+		},
+		"setting the default private key algorithm is allowed": {
+			old: certificate("abc"),
+			new: withPrivateKeyAlgorithm(certificate("abc"), internalcmapi.RSAKeyAlgorithm),
+		},
+		"changing the private key algorithm is allowed with the annotation": {
+			old: certificate("abc"),
+			new: allowImmutableFieldUpdates(withPrivateKeyAlgorithm(certificate("abc"), internalcmapi.ECDSAKeyAlgorithm)),
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs, warnings := ValidateUpdateCertificate(someAdmissionRequest, s.old, s.new)
+			assert.ElementsMatch(t, errs, s.errs)
+			assert.Empty(t, warnings)
+		})
+	}
+}
+
 func TestValidateDuration(t *testing.T) {
 	usefulDurations := map[string]*metav1.Duration{
 		"one second":  {Duration: time.Second},
@@ -1031,3 +1263,265 @@ func Test_validateLiteralSubject(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateIssuerRefNamespace(t *testing.T) {
+	fldPath := field.NewPath("spec", "issuerRef", "namespace")
+	tests := map[string]struct {
+		featureEnabled bool
+		issuerRef      cmmeta.ObjectReference
+		errs           []*field.Error
+	}{
+		"featureGate should be enabled to reference an Issuer in another namespace": {
+			featureEnabled: false,
+			issuerRef:      cmmeta.ObjectReference{Name: "issuer", Namespace: "other"},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "Feature gate IssuerReferenceGrants must be enabled on both webhook and controller to reference an Issuer in another namespace"),
+			},
+		},
+		"valid reference to an Issuer in another namespace": {
+			featureEnabled: true,
+			issuerRef:      cmmeta.ObjectReference{Name: "issuer", Kind: "Issuer", Group: "cert-manager.io", Namespace: "other"},
+		},
+		"invalid namespace name": {
+			featureEnabled: true,
+			issuerRef:      cmmeta.ObjectReference{Name: "issuer", Namespace: "Other_Namespace"},
+			errs: []*field.Error{
+				field.Invalid(fldPath, "Other_Namespace", `a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`),
+			},
+		},
+		"a ClusterIssuer cannot be referenced in another namespace": {
+			featureEnabled: true,
+			issuerRef:      cmmeta.ObjectReference{Name: "issuer", Kind: "ClusterIssuer", Namespace: "other"},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "only Issuers may be referenced in another namespace"),
+			},
+		},
+		"an external issuer cannot be referenced in another namespace": {
+			featureEnabled: true,
+			issuerRef:      cmmeta.ObjectReference{Name: "issuer", Kind: "Issuer", Group: "example.com", Namespace: "other"},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "only Issuers may be referenced in another namespace"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.IssuerReferenceGrants, test.featureEnabled)()
+			errs := validateIssuerRef(test.issuerRef, field.NewPath("spec"))
+			assert.ElementsMatch(t, errs, test.errs)
+		})
+	}
+}
+
+func Test_validateSplitIssuances(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	splitsPath := fldPath.Child("splitIssuances")
+	meshCA := cmmeta.ObjectReference{Name: "mesh-ca", Kind: "ClusterIssuer"}
+
+	tests := map[string]struct {
+		featureEnabled bool
+		spec           *internalcmapi.CertificateSpec
+		errs           []*field.Error
+	}{
+		"no split issuances are valid without the feature gate": {
+			spec: &internalcmapi.CertificateSpec{DNSNames: []string{"example.com"}},
+		},
+		"featureGate should be enabled to use split issuances": {
+			spec: &internalcmapi.CertificateSpec{
+				DNSNames:       []string{"example.com", "api.svc.cluster.local"},
+				SplitIssuances: []internalcmapi.CertificateSplitIssuance{{Name: "mesh", DNSZones: []string{"svc.cluster.local"}, IssuerRef: meshCA}},
+			},
+			errs: []*field.Error{
+				field.Forbidden(splitsPath, "feature gate SplitIssuance must be enabled"),
+			},
+		},
+		"valid split issuance": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				CommonName:     "example.com",
+				DNSNames:       []string{"example.com", "api.svc.cluster.local"},
+				SplitIssuances: []internalcmapi.CertificateSplitIssuance{{Name: "mesh", DNSZones: []string{"svc.cluster.local."}, IssuerRef: meshCA}},
+			},
+		},
+		"invalid names and zones": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				DNSNames: []string{"example.com", "api.svc.cluster.local", "db.mesh.local"},
+				SplitIssuances: []internalcmapi.CertificateSplitIssuance{
+					{Name: "mesh", DNSZones: []string{"svc.cluster.local"}, IssuerRef: meshCA},
+					{Name: "mesh", DNSZones: []string{"mesh.local"}, IssuerRef: meshCA},
+					{Name: "tls", DNSZones: []string{"example.org"}, IssuerRef: meshCA},
+					{Name: "Mesh_2", DNSZones: []string{"-invalid"}, IssuerRef: meshCA},
+					{Name: "", IssuerRef: cmmeta.ObjectReference{}},
+				},
+			},
+			errs: []*field.Error{
+				field.Duplicate(splitsPath.Index(1).Child("name"), "mesh"),
+				field.Invalid(splitsPath.Index(2).Child("name"), "tls", "must not be tls or ca"),
+				field.Invalid(splitsPath.Index(2).Child("dnsZones"), []string{"example.org"}, "must contain at least one of the dnsNames"),
+				field.Invalid(splitsPath.Index(3).Child("name"), "Mesh_2", `a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`),
+				field.Invalid(splitsPath.Index(3).Child("dnsZones").Index(0), "-invalid", `a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`),
+				field.Invalid(splitsPath.Index(3).Child("dnsZones"), []string{"-invalid"}, "must contain at least one of the dnsNames"),
+				field.Required(splitsPath.Index(4).Child("name"), "must be specified"),
+				field.Required(splitsPath.Index(4).Child("dnsZones"), "at least one DNS zone must be specified"),
+				field.Required(splitsPath.Index(4).Child("issuerRef", "name"), "must be specified"),
+			},
+		},
+		"the common name must not be split": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				CommonName:     "api.svc.cluster.local",
+				DNSNames:       []string{"example.com", "api.svc.cluster.local"},
+				SplitIssuances: []internalcmapi.CertificateSplitIssuance{{Name: "mesh", DNSZones: []string{"svc.cluster.local"}, IssuerRef: meshCA}},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("commonName"), "api.svc.cluster.local", "must not be in the dnsZones of a split issuance, as it is issued by issuerRef"),
+			},
+		},
+		"some names must be left to the issuer of the Certificate": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				DNSNames:       []string{"api.svc.cluster.local"},
+				SplitIssuances: []internalcmapi.CertificateSplitIssuance{{Name: "mesh", DNSZones: []string{"svc.cluster.local"}, IssuerRef: meshCA}},
+			},
+			errs: []*field.Error{
+				field.Invalid(splitsPath, "", "at least one of commonName, dnsNames, uris, ipAddresses, or emailAddresses must be issued by issuerRef rather than a split issuance"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.SplitIssuance, test.featureEnabled)()
+			errs := validateSplitIssuances(test.spec, fldPath)
+			assert.ElementsMatch(t, errs, test.errs)
+		})
+	}
+}
+
+func Test_validateSANTemplates(t *testing.T) {
+	fldPath := field.NewPath("spec")
+
+	tests := map[string]struct {
+		featureEnabled bool
+		spec           *internalcmapi.CertificateSpec
+		errs           []*field.Error
+	}{
+		"names without variables are valid": {
+			spec: &internalcmapi.CertificateSpec{
+				DNSNames: []string{"example.com"},
+				URISANs:  []string{"spiffe://cluster.local/ns/default/sa/app"},
+			},
+		},
+		"featureGate should be enabled to use template variables": {
+			spec: &internalcmapi.CertificateSpec{
+				DNSNames: []string{"app.${NAMESPACE}.svc"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(0), "app.${NAMESPACE}.svc", "template variables require the CertificateSANTemplates feature gate to be enabled on the webhook"),
+			},
+		},
+		"unresolved template variables are invalid": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				DNSNames: []string{"example.com", "${SERVICE_NAME}.default.svc"},
+				URISANs:  []string{"spiffe://cluster.local/ns/default/sa/${SERVICE_ACCOUNT}"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(1), "${SERVICE_NAME}.default.svc", "contains a template variable which could not be resolved"),
+				field.Invalid(fldPath.Child("uris").Index(0), "spiffe://cluster.local/ns/default/sa/${SERVICE_ACCOUNT}", "contains a template variable which could not be resolved"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.CertificateSANTemplates, test.featureEnabled)()
+			errs := validateSANTemplates(test.spec, fldPath)
+			assert.ElementsMatch(t, errs, test.errs)
+		})
+	}
+}
+
+func Test_validatePrivateKey(t *testing.T) {
+	fldPath := field.NewPath("spec", "privateKey")
+
+	tests := map[string]struct {
+		featureEnabled bool
+		pk             *internalcmapi.CertificatePrivateKey
+		errs           []*field.Error
+	}{
+		"ecdsa with a supported size is valid": {
+			pk: &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm, Size: 384},
+		},
+		"featureGate should be enabled to use MLDSA": {
+			pk: &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.MLDSAKeyAlgorithm},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("algorithm"), "feature gate PluggableKeyAlgorithms must be enabled"),
+			},
+		},
+		"MLDSA with a supported parameter set is valid": {
+			featureEnabled: true,
+			pk:             &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.MLDSAKeyAlgorithm, Size: 65},
+		},
+		"CompositeMLDSA with an unsupported parameter set is invalid": {
+			featureEnabled: true,
+			pk:             &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.CompositeMLDSAKeyAlgorithm, Size: 256},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("size"), 256, []string{"44", "65", "87"}),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.PluggableKeyAlgorithms, test.featureEnabled)()
+			errs := validatePrivateKey(test.pk, fldPath)
+			assert.ElementsMatch(t, errs, test.errs)
+		})
+	}
+}
+
+func Test_validateAdditionalCABundle(t *testing.T) {
+	fldPath := field.NewPath("spec", "additionalCABundle")
+	ref := &internalcmapi.CABundleKeySelector{Name: "roots", Key: "ca.crt"}
+
+	tests := map[string]struct {
+		bundle *internalcmapi.CertificateAdditionalCABundle
+		errs   []*field.Error
+	}{
+		"ConfigMap reference is valid": {
+			bundle: &internalcmapi.CertificateAdditionalCABundle{ConfigMap: ref},
+		},
+		"Secret reference is valid": {
+			bundle: &internalcmapi.CertificateAdditionalCABundle{Secret: ref},
+		},
+		"a reference must be specified": {
+			bundle: &internalcmapi.CertificateAdditionalCABundle{},
+			errs: []*field.Error{
+				field.Required(fldPath, "one of configMap or secret must be specified"),
+			},
+		},
+		"only one reference may be specified": {
+			bundle: &internalcmapi.CertificateAdditionalCABundle{ConfigMap: ref, Secret: ref},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "only one of configMap or secret may be specified"),
+			},
+		},
+		"name and key must be specified": {
+			bundle: &internalcmapi.CertificateAdditionalCABundle{ConfigMap: &internalcmapi.CABundleKeySelector{}},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("configMap", "name"), "must be specified"),
+				field.Required(fldPath.Child("configMap", "key"), "must be specified"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := validateAdditionalCABundle(test.bundle, fldPath)
+			assert.ElementsMatch(t, errs, test.errs)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+// Validation functions for cert-manager CertificateQuota types.
+
+func ValidateCertificateQuota(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	quota := obj.(*cmapi.CertificateQuota)
+	return ValidateCertificateQuotaSpec(&quota.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateUpdateCertificateQuota(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	quota := obj.(*cmapi.CertificateQuota)
+	return ValidateCertificateQuotaSpec(&quota.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateCertificateQuotaSpec(spec *cmapi.CertificateQuotaSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if spec.MaxCertificates == nil && spec.IssuanceRate == nil {
+		el = append(el, field.Required(fldPath, "at least one of maxCertificates or issuanceRate must be set"))
+	}
+	if spec.MaxCertificates != nil && *spec.MaxCertificates < 0 {
+		el = append(el, field.Invalid(fldPath.Child("maxCertificates"), *spec.MaxCertificates, "must not be negative"))
+	}
+	if rate := spec.IssuanceRate; rate != nil {
+		ratePath := fldPath.Child("issuanceRate")
+		if rate.MaxIssuances < 0 {
+			el = append(el, field.Invalid(ratePath.Child("maxIssuances"), rate.MaxIssuances, "must not be negative"))
+		}
+		if rate.Period.Duration <= 0 {
+			el = append(el, field.Invalid(ratePath.Child("period"), rate.Period.Duration.String(), "must be greater than zero"))
+		}
+	}
+
+	return el
+}
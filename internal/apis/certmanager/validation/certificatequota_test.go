@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+func TestValidateCertificateQuotaSpec(t *testing.T) {
+	fldPath := field.NewPath("spec")
+
+	scenarios := map[string]struct {
+		spec *cmapi.CertificateQuotaSpec
+		errs []*field.Error
+	}{
+		"valid maxCertificates": {
+			spec: &cmapi.CertificateQuotaSpec{MaxCertificates: pointer.Int32(10)},
+		},
+		"valid maxCertificates of zero": {
+			spec: &cmapi.CertificateQuotaSpec{MaxCertificates: pointer.Int32(0)},
+		},
+		"valid issuanceRate": {
+			spec: &cmapi.CertificateQuotaSpec{
+				IssuanceRate: &cmapi.CertificateQuotaIssuanceRate{MaxIssuances: 5, Period: metav1.Duration{Duration: time.Hour}},
+			},
+		},
+		"no limits set": {
+			spec: &cmapi.CertificateQuotaSpec{},
+			errs: []*field.Error{
+				field.Required(fldPath, "at least one of maxCertificates or issuanceRate must be set"),
+			},
+		},
+		"negative maxCertificates": {
+			spec: &cmapi.CertificateQuotaSpec{MaxCertificates: pointer.Int32(-1)},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("maxCertificates"), int32(-1), "must not be negative"),
+			},
+		},
+		"invalid issuanceRate": {
+			spec: &cmapi.CertificateQuotaSpec{
+				IssuanceRate: &cmapi.CertificateQuotaIssuanceRate{MaxIssuances: -1},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuanceRate", "maxIssuances"), int32(-1), "must not be negative"),
+				field.Invalid(fldPath.Child("issuanceRate", "period"), "0s", "must be greater than zero"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateCertificateQuotaSpec(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected errors %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
@@ -18,7 +18,6 @@ package validation
 
 import (
 	"crypto/x509"
-	"encoding/asn1"
 	"fmt"
 	"reflect"
 	"strings"
@@ -95,6 +94,10 @@ func ValidateCertificateRequestSpec(crSpec *cmapi.CertificateRequestSpec, fldPat
 		if err != nil {
 			el = append(el, field.Invalid(fldPath.Child("request"), crSpec.Request, fmt.Sprintf("failed to decode csr: %s", err)))
 		} else {
+			if err := pki.CheckFIPSPublicKey(csr.PublicKey); err != nil {
+				el = append(el, field.Forbidden(fldPath.Child("request"), err.Error()))
+			}
+
 			// only compare usages if set on CR and in the CSR
 			if len(crSpec.Usages) > 0 && len(csr.Extensions) > 0 && validateCSRContent && !reflect.DeepEqual(crSpec.Usages, defaultInternalKeyUsages) {
 				if crSpec.IsCA {
@@ -192,45 +195,14 @@ func ValidateUpdateCertificateRequestApprovalCondition(oldCRConds, newCRConds []
 }
 
 func getCSRKeyUsage(crSpec *cmapi.CertificateRequestSpec, fldPath *field.Path, csr *x509.CertificateRequest, el field.ErrorList) ([]cmapi.KeyUsage, field.ErrorList) {
-	var ekus []x509.ExtKeyUsage
-	var ku x509.KeyUsage
-
-	for _, extension := range csr.Extensions {
-		if extension.Id.String() == asn1.ObjectIdentifier(pki.OIDExtensionExtendedKeyUsage).String() {
-			var asn1ExtendedUsages []asn1.ObjectIdentifier
-			_, err := asn1.Unmarshal(extension.Value, &asn1ExtendedUsages)
-			if err != nil {
-				el = append(el, field.Invalid(fldPath.Child("request"), crSpec.Request, fmt.Sprintf("failed to decode csr extended usages: %s", err)))
-			} else {
-				for _, asnExtUsage := range asn1ExtendedUsages {
-					eku, ok := pki.ExtKeyUsageFromOID(asnExtUsage)
-					if ok {
-						ekus = append(ekus, eku)
-					}
-				}
-			}
-		}
-		if extension.Id.String() == asn1.ObjectIdentifier(pki.OIDExtensionKeyUsage).String() {
-			// RFC 5280, 4.2.1.3
-			var asn1bits asn1.BitString
-			_, err := asn1.Unmarshal(extension.Value, &asn1bits)
-			if err != nil {
-				el = append(el, field.Invalid(fldPath.Child("request"), crSpec.Request, fmt.Sprintf("failed to decode csr usages: %s", err)))
-			} else {
-				var usage int
-				for i := 0; i < 9; i++ {
-					if asn1bits.At(i) != 0 {
-						usage |= 1 << uint(i)
-					}
-				}
-				ku = x509.KeyUsage(usage)
-			}
-		}
+	usages, err := pki.CSRKeyUsages(csr)
+	if err != nil {
+		return nil, append(el, field.Invalid(fldPath.Child("request"), crSpec.Request, err.Error()))
 	}
 
 	// convert usages to the internal API
 	var out []cmapi.KeyUsage
-	for _, usage := range pki.BuildCertManagerKeyUsages(ku, ekus) {
+	for _, usage := range usages {
 		out = append(out, cmapi.KeyUsage(usage))
 	}
 	return out, el
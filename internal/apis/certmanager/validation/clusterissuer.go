@@ -29,11 +29,17 @@ import (
 func ValidateClusterIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*cmapi.ClusterIssuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	if iss.Spec.IntermediateCA != nil {
+		allErrs = append(allErrs, ValidateIssuerIntermediateCA(iss.Spec.IntermediateCA, iss.Name, field.NewPath("spec", "intermediateCA"))...)
+	}
 	return allErrs, warnings
 }
 
 func ValidateUpdateClusterIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*cmapi.ClusterIssuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	if iss.Spec.IntermediateCA != nil {
+		allErrs = append(allErrs, ValidateIssuerIntermediateCA(iss.Spec.IntermediateCA, iss.Name, field.NewPath("spec", "intermediateCA"))...)
+	}
 	return allErrs, warnings
 }
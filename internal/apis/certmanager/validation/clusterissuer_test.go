@@ -19,8 +19,10 @@ package validation
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
@@ -32,7 +34,49 @@ func TestValidateClusterIssuer(t *testing.T) {
 		a         *admissionv1.AdmissionRequest
 		expectedE []*field.Error
 		expectedW []string
-	}{}
+	}{
+		"valid intermediate CA provisioning": {
+			cfg: &cmapi.ClusterIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{CA: &cmapi.CAIssuer{SecretName: "abc"}},
+					IntermediateCA: &cmapi.IssuerIntermediateCA{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+						IssuerName:        "tenant-ca",
+						Duration:          &metav1.Duration{Duration: 30 * 24 * time.Hour},
+						RenewBefore:       &metav1.Duration{Duration: 10 * 24 * time.Hour},
+					},
+				},
+			},
+		},
+		"intermediate CA provisioning with an invalid issuer name": {
+			cfg: &cmapi.ClusterIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig:   cmapi.IssuerConfig{CA: &cmapi.CAIssuer{SecretName: "abc"}},
+					IntermediateCA: &cmapi.IssuerIntermediateCA{IssuerName: "Tenant_CA"},
+				},
+			},
+			expectedE: []*field.Error{
+				field.Invalid(field.NewPath("spec", "intermediateCA", "issuerName"), "Tenant_CA", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+		"intermediate CA provisioning with renewBefore longer than the duration": {
+			cfg: &cmapi.ClusterIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{CA: &cmapi.CAIssuer{SecretName: "abc"}},
+					IntermediateCA: &cmapi.IssuerIntermediateCA{
+						Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+						RenewBefore: &metav1.Duration{Duration: 48 * time.Hour},
+					},
+				},
+			},
+			expectedE: []*field.Error{
+				field.Invalid(field.NewPath("spec", "intermediateCA", "renewBefore"), 48*time.Hour, "certificate duration 24h0m0s must be greater than renewBefore 48h0m0s"),
+			},
+		},
+	}
 
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
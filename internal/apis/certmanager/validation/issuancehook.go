@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"net/url"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Validation functions for cert-manager IssuanceHook types.
+
+func ValidateIssuanceHook(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	hook := obj.(*cmapi.IssuanceHook)
+	return ValidateIssuanceHookSpec(&hook.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateUpdateIssuanceHook(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	hook := obj.(*cmapi.IssuanceHook)
+	return ValidateIssuanceHookSpec(&hook.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateIssuanceHookSpec(spec *cmapi.IssuanceHookSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	switch spec.Phase {
+	case cmapi.IssuanceHookPhasePreIssuance, cmapi.IssuanceHookPhasePostIssuance:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("phase"), spec.Phase,
+			[]string{string(cmapi.IssuanceHookPhasePreIssuance), string(cmapi.IssuanceHookPhasePostIssuance)}))
+	}
+
+	if len(spec.URL) == 0 {
+		el = append(el, field.Required(fldPath.Child("url"), ""))
+	} else if u, err := url.Parse(spec.URL); err != nil {
+		el = append(el, field.Invalid(fldPath.Child("url"), spec.URL, err.Error()))
+	} else if u.Scheme != "https" || len(u.Host) == 0 {
+		el = append(el, field.Invalid(fldPath.Child("url"), spec.URL, "must be an https URL"))
+	}
+
+	if len(spec.CABundle) > 0 {
+		if _, err := pki.DecodeX509CertificateChainBytes(spec.CABundle); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "must be a PEM encoded CA bundle"))
+		}
+	}
+
+	switch spec.FailurePolicy {
+	case "", cmapi.IssuanceHookFailurePolicyFail, cmapi.IssuanceHookFailurePolicyIgnore:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("failurePolicy"), spec.FailurePolicy,
+			[]string{string(cmapi.IssuanceHookFailurePolicyFail), string(cmapi.IssuanceHookFailurePolicyIgnore)}))
+	}
+
+	if t := spec.TimeoutSeconds; t != nil && (*t < 1 || *t > 30) {
+		el = append(el, field.Invalid(fldPath.Child("timeoutSeconds"), *t, "must be between 1 and 30"))
+	}
+
+	return el
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+func TestValidateIssuanceHookSpec(t *testing.T) {
+	fldPath := field.NewPath("spec")
+
+	scenarios := map[string]struct {
+		spec *cmapi.IssuanceHookSpec
+		errs []*field.Error
+	}{
+		"valid pre-issuance hook": {
+			spec: &cmapi.IssuanceHookSpec{
+				Phase:          cmapi.IssuanceHookPhasePreIssuance,
+				URL:            "https://hooks.example.com/pre",
+				FailurePolicy:  cmapi.IssuanceHookFailurePolicyIgnore,
+				TimeoutSeconds: pointer.Int32(5),
+			},
+		},
+		"valid post-issuance hook": {
+			spec: &cmapi.IssuanceHookSpec{
+				Phase:      cmapi.IssuanceHookPhasePostIssuance,
+				URL:        "https://hooks.example.com/post",
+				Namespaces: []string{"team-a"},
+			},
+		},
+		"missing phase and url": {
+			spec: &cmapi.IssuanceHookSpec{},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("phase"), cmapi.IssuanceHookPhase(""), []string{"PreIssuance", "PostIssuance"}),
+				field.Required(fldPath.Child("url"), ""),
+			},
+		},
+		"http url": {
+			spec: &cmapi.IssuanceHookSpec{
+				Phase: cmapi.IssuanceHookPhasePreIssuance,
+				URL:   "http://hooks.example.com",
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("url"), "http://hooks.example.com", "must be an https URL"),
+			},
+		},
+		"invalid caBundle": {
+			spec: &cmapi.IssuanceHookSpec{
+				Phase:    cmapi.IssuanceHookPhasePreIssuance,
+				URL:      "https://hooks.example.com",
+				CABundle: []byte("not a certificate"),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), "", "must be a PEM encoded CA bundle"),
+			},
+		},
+		"invalid failurePolicy and timeoutSeconds": {
+			spec: &cmapi.IssuanceHookSpec{
+				Phase:          cmapi.IssuanceHookPhasePreIssuance,
+				URL:            "https://hooks.example.com",
+				FailurePolicy:  "Retry",
+				TimeoutSeconds: pointer.Int32(60),
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("failurePolicy"), cmapi.IssuanceHookFailurePolicy("Retry"), []string{"Fail", "Ignore"}),
+				field.Invalid(fldPath.Child("timeoutSeconds"), int32(60), "must be between 1 and 30"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateIssuanceHookSpec(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected errors %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
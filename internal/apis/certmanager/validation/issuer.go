@@ -19,17 +19,24 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager/validation/util"
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 // Validation functions for cert-manager Issuer types.
@@ -37,18 +44,148 @@ import (
 func ValidateIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateNamespacedIssuerSpec(&iss.Spec, field.NewPath("spec"))...)
 	return allErrs, warnings
 }
 
 func ValidateUpdateIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateNamespacedIssuerSpec(&iss.Spec, field.NewPath("spec"))...)
 	// Admission request should never be nil
 	return allErrs, warnings
 }
 
+// validateNamespacedIssuerSpec rejects the fields of the IssuerSpec which are
+// only meaningful on ClusterIssuers.
+func validateNamespacedIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if iss.IntermediateCA != nil {
+		el = append(el, field.Forbidden(fldPath.Child("intermediateCA"), "may only be set on ClusterIssuers"))
+	}
+	return el
+}
+
 func ValidateIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) (field.ErrorList, []string) {
-	return ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el, warnings := ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	if iss.HTTPClient != nil {
+		el = append(el, ValidateIssuerHTTPClient(iss.HTTPClient, fldPath.Child("httpClient"))...)
+	}
+	el = append(el, ValidateControllerName(iss.ControllerName, fldPath.Child("controllerName"))...)
+	if iss.DurationTolerance != nil {
+		el = append(el, ValidateIssuerDurationTolerance(iss.DurationTolerance, fldPath.Child("durationTolerance"))...)
+	}
+	if iss.Capabilities != nil {
+		el = append(el, ValidateIssuerCapabilities(iss.Capabilities, fldPath.Child("capabilities"))...)
+	}
+	if len(iss.CABundle) > 0 {
+		el = append(el, validatePinnedCABundle(iss, fldPath.Child("caBundle"))...)
+	}
+	return el, warnings
+}
+
+// validatePinnedCABundle validates the spec.caBundle of an issuer, which must
+// hold PEM encoded certificates and is only used by ACME, Vault and Venafi
+// issuers.
+func validatePinnedCABundle(iss *certmanager.IssuerSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if iss.ACME == nil && iss.Vault == nil && iss.Venafi == nil {
+		el = append(el, field.Forbidden(fldPath, "may only be set for ACME, Vault and Venafi issuers"))
+	}
+	if ok := x509.NewCertPool().AppendCertsFromPEM(iss.CABundle); !ok {
+		el = append(el, field.Invalid(fldPath, "", "Specified CA bundle is invalid"))
+	}
+	return el
+}
+
+// ValidateIssuerIntermediateCA validates the spec.intermediateCA of the
+// ClusterIssuer with the given name. The names of the Issuers, Certificates
+// and Secrets provisioned for it must be valid, as well as the duration of
+// the intermediate CA certificates.
+func ValidateIssuerIntermediateCA(ica *certmanager.IssuerIntermediateCA, clusterIssuerName string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if ica.NamespaceSelector != nil {
+		el = append(el, metav1validation.ValidateLabelSelector(ica.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+	}
+	issuerName := ica.IssuerName
+	if len(issuerName) == 0 {
+		issuerName = clusterIssuerName
+	}
+	// The intermediate CA is stored in the Secret <issuerName>-ca, whose
+	// name is the longest of the provisioned resources.
+	for _, msg := range validation.IsDNS1123Subdomain(issuerName + "-ca") {
+		el = append(el, field.Invalid(fldPath.Child("issuerName"), issuerName, msg))
+	}
+	el = append(el, ValidateDuration(&certmanager.CertificateSpec{
+		Duration:    ica.Duration,
+		RenewBefore: ica.RenewBefore,
+	}, fldPath)...)
+	return el
+}
+
+// ValidateIssuerCapabilities validates the spec.capabilities of an issuer.
+func ValidateIssuerCapabilities(caps *certmanager.IssuerCapabilities, fldPath *field.Path) field.ErrorList {
+	el := validateKeyUsages(caps.Usages, fldPath.Child("usages"))
+	switch caps.UnsupportedUsagesPolicy {
+	case "", certmanager.DropUnsupportedUsages, certmanager.FailUnsupportedUsages:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("unsupportedUsagesPolicy"), caps.UnsupportedUsagesPolicy,
+			[]string{string(certmanager.DropUnsupportedUsages), string(certmanager.FailUnsupportedUsages)}))
+	}
+	return el
+}
+
+// ValidateIssuerDurationTolerance validates the spec.durationTolerance of an
+// issuer, whose maxShortening must be positive.
+func ValidateIssuerDurationTolerance(tol *certmanager.IssuerDurationTolerance, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if tol.MaxShortening.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("maxShortening"), tol.MaxShortening.Duration, "must be greater than zero"))
+	}
+	return el
+}
+
+// ValidateControllerName validates the spec.controllerName of a Certificate
+// or issuer, which must be a valid value of the controller's
+// --controller-class flag if set.
+func ValidateControllerName(name string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(name) == 0 {
+		return el
+	}
+	for _, msg := range validation.IsDNS1123Label(name) {
+		el = append(el, field.Invalid(fldPath, name, msg))
+	}
+	return el
+}
+
+func ValidateIssuerHTTPClient(cfg *certmanager.IssuerHTTPClient, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if len(cfg.ProxyURL) > 0 {
+		u, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			el = append(el, field.Invalid(fldPath.Child("proxyURL"), cfg.ProxyURL, err.Error()))
+		} else if len(u.Scheme) == 0 || len(u.Host) == 0 {
+			el = append(el, field.Invalid(fldPath.Child("proxyURL"), cfg.ProxyURL, "must be an absolute URL, e.g. 'http://proxy.example.com:3128'"))
+		}
+	} else if len(cfg.NoProxy) > 0 {
+		el = append(el, field.Forbidden(fldPath.Child("noProxy"), "may only be specified if proxyURL is specified"))
+	}
+
+	if len(cfg.CABundle) > 0 {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM(cfg.CABundle); !ok {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+		}
+	}
+
+	if len(cfg.CABundle) > 0 && cfg.CABundleSecretRef != nil {
+		el = append(el, field.Invalid(fldPath.Child("caBundle"), cfg.CABundle, "specified caBundle and caBundleSecretRef cannot be used together"))
+		el = append(el, field.Invalid(fldPath.Child("caBundleSecretRef"), cfg.CABundleSecretRef.Name, "specified caBundleSecretRef and caBundle cannot be used together"))
+	}
+
+	return el
 }
 
 func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (field.ErrorList, []string) {
@@ -96,6 +233,30 @@ func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (f
 			el = append(el, ValidateVenafiIssuerConfig(iss.Venafi, fldPath.Child("venafi"))...)
 		}
 	}
+	if iss.Fake != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("fake"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateFakeIssuerConfig(iss.Fake, fldPath.Child("fake"))...)
+		}
+	}
+	if iss.Delegate != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("delegate"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateDelegateIssuerConfig(iss.Delegate, fldPath.Child("delegate"))...)
+		}
+	}
+	if iss.Offline != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("offline"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateOfflineIssuerConfig(iss.Offline, fldPath.Child("offline"))...)
+		}
+	}
 	if numConfigs == 0 {
 		el = append(el, field.Required(fldPath, "at least one issuer must be configured"))
 	}
@@ -149,9 +310,50 @@ func ValidateACMEIssuerChallengeSolverConfig(sol *cmacme.ACMEChallengeSolver, fl
 			el = append(el, ValidateACMEChallengeSolverDNS01(sol.DNS01, fldPath.Child("dns01"))...)
 		}
 	}
+	if sol.TLSALPN01 != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath, "may not specify more than one solver type in a single solver"))
+		} else {
+			numProviders++
+			el = append(el, ValidateACMEIssuerChallengeSolverTLSALPN01Config(sol.TLSALPN01, fldPath.Child("tlsALPN01"))...)
+		}
+	}
 	if numProviders == 0 {
 		el = append(el, field.Required(fldPath, "no solver type configured"))
 	}
+	switch sol.SelfCheckIPFamily {
+	case "", corev1.IPv4Protocol, corev1.IPv6Protocol:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("selfCheckIPFamily"), sol.SelfCheckIPFamily, []string{string(corev1.IPv4Protocol), string(corev1.IPv6Protocol)}))
+	}
+	if sol.SelfCheck != nil {
+		el = append(el, ValidateACMEChallengeSolverSelfCheck(sol.SelfCheck, fldPath.Child("selfCheck"))...)
+	}
+
+	return el
+}
+
+func ValidateACMEChallengeSolverSelfCheck(selfCheck *cmacme.ACMEChallengeSolverSelfCheck, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	for _, d := range []struct {
+		name     string
+		duration *metav1.Duration
+	}{
+		{"initialInterval", selfCheck.InitialInterval},
+		{"maxInterval", selfCheck.MaxInterval},
+		{"maxDuration", selfCheck.MaxDuration},
+	} {
+		if d.duration != nil && d.duration.Duration <= 0 {
+			el = append(el, field.Invalid(fldPath.Child(d.name), d.duration.Duration.String(), "must be greater than zero"))
+		}
+	}
+	if selfCheck.Multiplier != nil && *selfCheck.Multiplier < 1 {
+		el = append(el, field.Invalid(fldPath.Child("multiplier"), *selfCheck.Multiplier, "must be at least 1"))
+	}
+	if selfCheck.InitialInterval != nil && selfCheck.MaxInterval != nil && selfCheck.MaxInterval.Duration < selfCheck.InitialInterval.Duration {
+		el = append(el, field.Invalid(fldPath.Child("maxInterval"), selfCheck.MaxInterval.Duration.String(), "must not be less than initialInterval"))
+	}
 
 	return el
 }
@@ -181,8 +383,21 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 func ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(ingress *cmacme.ACMEChallengeSolverHTTP01Ingress, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
-	if ingress.Class != nil && len(ingress.Name) > 0 {
-		el = append(el, field.Forbidden(fldPath, "only one of 'name' or 'class' should be specified"))
+	numDefined := 0
+	if ingress.Class != nil {
+		numDefined++
+	}
+	if ingress.IngressClassName != nil {
+		numDefined++
+	}
+	if len(ingress.Name) > 0 {
+		numDefined++
+	}
+	if numDefined > 1 {
+		el = append(el, field.Forbidden(fldPath, "only one of 'name', 'class' or 'ingressClassName' should be specified"))
+	}
+	if ingress.IngressClassName != nil && len(*ingress.IngressClassName) == 0 {
+		el = append(el, field.Invalid(fldPath.Child("ingressClassName"), *ingress.IngressClassName, "must not be empty"))
 	}
 	switch ingress.ServiceType {
 	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
@@ -207,6 +422,27 @@ func ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(gateway *cmacme.ACMECh
 	return el
 }
 
+func ValidateACMEIssuerChallengeSolverTLSALPN01Config(tlsALPN01 *cmacme.ACMEChallengeSolverTLSALPN01, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch tlsALPN01.Mode {
+	case "", cmacme.ACMEChallengeSolverTLSALPN01ModeService:
+		switch tlsALPN01.ServiceType {
+		case "", corev1.ServiceTypeLoadBalancer, corev1.ServiceTypeClusterIP:
+		default:
+			el = append(el, field.Invalid(fldPath.Child("serviceType"), tlsALPN01.ServiceType, `must be empty, "LoadBalancer" or "ClusterIP"`))
+		}
+	case cmacme.ACMEChallengeSolverTLSALPN01ModeHostPort:
+		if tlsALPN01.ServiceType != "" {
+			el = append(el, field.Forbidden(fldPath.Child("serviceType"), "may only be specified when using the Service mode"))
+		}
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("mode"), tlsALPN01.Mode, []string{string(cmacme.ACMEChallengeSolverTLSALPN01ModeHostPort), string(cmacme.ACMEChallengeSolverTLSALPN01ModeService)}))
+	}
+
+	return el
+}
+
 func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if len(iss.SecretName) == 0 {
@@ -217,11 +453,91 @@ func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) fiel
 			el = append(el, field.Invalid(fldPath.Child("ocspServer").Index(i), ocspURL, "must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org"))
 		}
 	}
+	if iss.SerialNumberBits != 0 && (iss.SerialNumberBits < 64 || iss.SerialNumberBits > 159) {
+		el = append(el, field.Invalid(fldPath.Child("serialNumberBits"), iss.SerialNumberBits, "must be between 64 and 159"))
+	}
+	el = append(el, validateNotBeforeBackdate(iss.NotBeforeBackdate, fldPath.Child("notBeforeBackdate"))...)
+	el = append(el, validateKeyIdentifierMethod(iss.KeyIdentifierMethod, fldPath.Child("keyIdentifierMethod"))...)
 	return el
 }
 
 func ValidateSelfSignedIssuerConfig(iss *certmanager.SelfSignedIssuer, fldPath *field.Path) field.ErrorList {
-	return nil
+	el := validateNotBeforeBackdate(iss.NotBeforeBackdate, fldPath.Child("notBeforeBackdate"))
+	el = append(el, validateKeyIdentifierMethod(iss.KeyIdentifierMethod, fldPath.Child("keyIdentifierMethod"))...)
+	return el
+}
+
+// validateKeyIdentifierMethod validates the keyIdentifierMethod of a CA or
+// SelfSigned issuer.
+func validateKeyIdentifierMethod(method certmanager.KeyIdentifierMethod, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	switch method {
+	case "", certmanager.SHA1KeyIdentifierMethod, certmanager.TruncatedSHA256KeyIdentifierMethod:
+	default:
+		el = append(el, field.NotSupported(fldPath, method,
+			[]string{string(certmanager.SHA1KeyIdentifierMethod), string(certmanager.TruncatedSHA256KeyIdentifierMethod)}))
+	}
+	return el
+}
+
+// validateNotBeforeBackdate validates the notBeforeBackdate of a CA or
+// SelfSigned issuer, which must not be negative.
+func validateNotBeforeBackdate(backdate *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if backdate != nil && backdate.Duration < 0 {
+		el = append(el, field.Invalid(fldPath, backdate.Duration.String(), "must not be negative"))
+	}
+	return el
+}
+
+func ValidateFakeIssuerConfig(iss *certmanager.FakeIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.FakeIssuer) {
+		return append(el, field.Forbidden(fldPath, "feature gate FakeIssuer must be enabled"))
+	}
+	if iss.Latency != nil && iss.Latency.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("latency"), iss.Latency.Duration.String(), "must not be negative"))
+	}
+	if iss.FailurePercentage < 0 || iss.FailurePercentage > 100 {
+		el = append(el, field.Invalid(fldPath.Child("failurePercentage"), iss.FailurePercentage, "must be between 0 and 100"))
+	}
+	return el
+}
+
+func ValidateDelegateIssuerConfig(iss *certmanager.DelegateIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.DelegateIssuer) {
+		return append(el, field.Forbidden(fldPath, "feature gate DelegateIssuer must be enabled"))
+	}
+	if len(iss.Routes) == 0 {
+		return append(el, field.Required(fldPath.Child("routes"), "at least one route must be configured"))
+	}
+	for i, route := range iss.Routes {
+		routePath := fldPath.Child("routes").Index(i)
+		if route.NamespaceSelector != nil {
+			el = append(el, metav1validation.ValidateLabelSelector(route.NamespaceSelector, routePath.Child("namespaceSelector"))...)
+		}
+		for j, zone := range route.DNSZones {
+			for _, msg := range validation.IsDNS1123Subdomain(strings.TrimSuffix(strings.ToLower(zone), ".")) {
+				el = append(el, field.Invalid(routePath.Child("dnsZones").Index(j), zone, msg))
+			}
+		}
+		el = append(el, validateIssuerRef(route.IssuerRef, routePath)...)
+	}
+	return el
+}
+
+func ValidateOfflineIssuerConfig(iss *certmanager.OfflineIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.OfflineIssuer) {
+		return append(el, field.Forbidden(fldPath, "feature gate OfflineIssuer must be enabled"))
+	}
+	if len(iss.CABundle) == 0 {
+		el = append(el, field.Required(fldPath.Child("caBundle"), "the CA certificates of the offline CA must be specified"))
+	} else if ok := x509.NewCertPool().AppendCertsFromPEM(iss.CABundle); !ok {
+		el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+	}
+	return el
 }
 
 func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path) field.ErrorList {
@@ -248,10 +564,29 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		el = append(el, field.Invalid(fldPath.Child("caBundleSecretRef"), iss.CABundleSecretRef.Name, "specified caBundleSecretRef and caBundle cannot be used together"))
 	}
 
+	if iss.Revocation != nil {
+		el = append(el, ValidateVaultRevocation(iss.Revocation, fldPath.Child("revocation"))...)
+	}
+
 	return el
 	// TODO: add validation for Vault authentication types
 }
 
+func ValidateVaultRevocation(rev *certmanager.VaultRevocation, fldPath *field.Path) (el field.ErrorList) {
+	if rev.TidyInterval != nil && rev.TidyInterval.Duration < time.Hour {
+		el = append(el, field.Invalid(fldPath.Child("tidyInterval"), rev.TidyInterval.Duration, "must be at least 1h"))
+	}
+	if rev.TidySafetyBuffer != nil {
+		if rev.TidyInterval == nil {
+			el = append(el, field.Forbidden(fldPath.Child("tidySafetyBuffer"), "may only be set when tidyInterval is set"))
+		}
+		if rev.TidySafetyBuffer.Duration <= 0 {
+			el = append(el, field.Invalid(fldPath.Child("tidySafetyBuffer"), rev.TidySafetyBuffer.Duration, "must be greater than 0"))
+		}
+	}
+	return el
+}
+
 func ValidateVenafiTPP(tpp *certmanager.VenafiTPP, fldPath *field.Path) (el field.ErrorList) {
 	if tpp.URL == "" {
 		el = append(el, field.Required(fldPath.Child("url"), ""))
@@ -260,12 +595,22 @@ func ValidateVenafiTPP(tpp *certmanager.VenafiTPP, fldPath *field.Path) (el fiel
 }
 
 func ValidateVenafiCloud(c *certmanager.VenafiCloud, fldPath *field.Path) (el field.ErrorList) {
+	if c.Application != "" && c.IssuingTemplate == "" {
+		el = append(el, field.Required(fldPath.Child("issuingTemplate"), "must be set when application is set"))
+	}
+	if c.IssuingTemplate != "" && c.Application == "" {
+		el = append(el, field.Required(fldPath.Child("application"), "must be set when issuingTemplate is set"))
+	}
 	return el
 }
 
 func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Path) (el field.ErrorList) {
-	if iss.Zone == "" {
+	cloudApplicationSet := iss.Cloud != nil && iss.Cloud.Application != ""
+	switch {
+	case iss.Zone == "" && !cloudApplicationSet:
 		el = append(el, field.Required(fldPath.Child("zone"), ""))
+	case iss.Zone != "" && cloudApplicationSet:
+		el = append(el, field.Forbidden(fldPath.Child("zone"), "zone may not be set when cloud.application is set"))
 	}
 	unionCount := 0
 	if iss.TPP != nil {
@@ -437,6 +782,22 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, ValidateSecretKeySelector(&p.DigitalOcean.Token, fldPath.Child("digitalocean", "tokenSecretRef"))...)
 		}
 	}
+	if p.Linode != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("linode"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			el = append(el, ValidateSecretKeySelector(&p.Linode.Token, fldPath.Child("linode", "tokenSecretRef"))...)
+		}
+	}
+	if p.Vultr != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("vultr"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			el = append(el, ValidateSecretKeySelector(&p.Vultr.APIKey, fldPath.Child("vultr", "apiKeySecretRef"))...)
+		}
+	}
 	if p.RFC2136 != nil {
 		if numProviders > 0 {
 			el = append(el, field.Forbidden(fldPath.Child("rfc2136"), "may not specify more than one provider type"))
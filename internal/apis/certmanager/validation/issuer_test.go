@@ -19,18 +19,24 @@ package validation
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/pointer"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
 	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
 	pubcmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	unitcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 )
 
@@ -112,6 +118,42 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
 			},
 		},
+		"vault issuer with valid revocation": {
+			spec: &cmapi.VaultIssuer{
+				Server: "https://vault.example.com",
+				Path:   "pki/sign/role",
+				Revocation: &cmapi.VaultRevocation{
+					RevokeOnDelete:   true,
+					TidyInterval:     &metav1.Duration{Duration: 24 * time.Hour},
+					TidySafetyBuffer: &metav1.Duration{Duration: 72 * time.Hour},
+				},
+			},
+		},
+		"vault issuer with invalid revocation": {
+			spec: &cmapi.VaultIssuer{
+				Server: "https://vault.example.com",
+				Path:   "pki/sign/role",
+				Revocation: &cmapi.VaultRevocation{
+					TidySafetyBuffer: &metav1.Duration{Duration: -time.Hour},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("revocation", "tidySafetyBuffer"), "may only be set when tidyInterval is set"),
+				field.Invalid(fldPath.Child("revocation", "tidySafetyBuffer"), -time.Hour, "must be greater than 0"),
+			},
+		},
+		"vault issuer with too short tidy interval": {
+			spec: &cmapi.VaultIssuer{
+				Server: "https://vault.example.com",
+				Path:   "pki/sign/role",
+				Revocation: &cmapi.VaultRevocation{
+					TidyInterval: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("revocation", "tidyInterval"), time.Minute, "must be at least 1h"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -130,6 +172,89 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 	}
 }
 
+func TestValidateIssuerHTTPClient(t *testing.T) {
+	caBundle := unitcrypto.MustCreateCryptoBundle(t,
+		&pubcmapi.Certificate{Spec: pubcmapi.CertificateSpec{CommonName: "test"}},
+		clock.RealClock{},
+	).CertBytes
+
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		spec *cmapi.IssuerHTTPClient
+		errs []*field.Error
+	}{
+		"valid proxy and CA bundle": {
+			spec: &cmapi.IssuerHTTPClient{
+				ProxyURL: "http://proxy.example.com:3128",
+				NoProxy:  []string{"example.com", "10.0.0.0/8"},
+				CABundle: caBundle,
+			},
+		},
+		"valid CA bundle secret reference": {
+			spec: &cmapi.IssuerHTTPClient{
+				CABundleSecretRef: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "test-secret",
+					},
+				},
+			},
+		},
+		"relative proxy URL": {
+			spec: &cmapi.IssuerHTTPClient{
+				ProxyURL: "proxy.example.com",
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("proxyURL"), "proxy.example.com", "must be an absolute URL, e.g. 'http://proxy.example.com:3128'"),
+			},
+		},
+		"noProxy without proxyURL": {
+			spec: &cmapi.IssuerHTTPClient{
+				NoProxy: []string{"example.com"},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("noProxy"), "may only be specified if proxyURL is specified"),
+			},
+		},
+		"invalid CA bundle": {
+			spec: &cmapi.IssuerHTTPClient{
+				CABundle: []byte("invalid"),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+			},
+		},
+		"both caBundle and caBundleSecretRef": {
+			spec: &cmapi.IssuerHTTPClient{
+				CABundle: caBundle,
+				CABundleSecretRef: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "test-secret",
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), caBundle, "specified caBundle and caBundleSecretRef cannot be used together"),
+				field.Invalid(fldPath.Child("caBundleSecretRef"), "test-secret", "specified caBundleSecretRef and caBundle cannot be used together"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateIssuerHTTPClient(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateACMEIssuerConfig(t *testing.T) {
 	fldPath := field.NewPath("")
 	scenarios := map[string]struct {
@@ -174,6 +299,84 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				},
 			},
 		},
+		"acme solver with valid selfCheckIPFamily": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						SelfCheckIPFamily: corev1.IPv6Protocol,
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+		},
+		"acme solver with invalid selfCheckIPFamily": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						SelfCheckIPFamily: "IPv5",
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("solvers").Index(0).Child("selfCheckIPFamily"), corev1.IPFamily("IPv5"), []string{"IPv4", "IPv6"}),
+			},
+		},
+		"acme solver with valid selfCheck": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						SelfCheck: &cmacme.ACMEChallengeSolverSelfCheck{
+							InitialInterval: &metav1.Duration{Duration: 2 * time.Second},
+							Multiplier:      pointer.Int32(2),
+							MaxInterval:     &metav1.Duration{Duration: time.Minute},
+							MaxDuration:     &metav1.Duration{Duration: 10 * time.Minute},
+						},
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+		},
+		"acme solver with invalid selfCheck": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						SelfCheck: &cmacme.ACMEChallengeSolverSelfCheck{
+							InitialInterval: &metav1.Duration{Duration: time.Minute},
+							Multiplier:      pointer.Int32(0),
+							MaxInterval:     &metav1.Duration{Duration: time.Second},
+							MaxDuration:     &metav1.Duration{Duration: -time.Second},
+						},
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("solvers").Index(0).Child("selfCheck", "maxDuration"), "-1s", "must be greater than zero"),
+				field.Invalid(fldPath.Child("solvers").Index(0).Child("selfCheck", "multiplier"), int32(0), "must be at least 1"),
+				field.Invalid(fldPath.Child("solvers").Index(0).Child("selfCheck", "maxInterval"), "1s", "must not be less than initialInterval"),
+			},
+		},
 		"acme solver with external account binding missing required fields": {
 			spec: &cmacme.ACMEIssuer{
 				Email:                  "valid-email",
@@ -432,6 +635,11 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 }
 
 func TestValidateIssuerSpec(t *testing.T) {
+	caBundle := unitcrypto.MustCreateCryptoBundle(t,
+		&pubcmapi.Certificate{Spec: pubcmapi.CertificateSpec{CommonName: "test"}},
+		clock.RealClock{},
+	).CertBytes
+
 	fldPath := field.NewPath("")
 	scenarios := map[string]struct {
 		spec     *cmapi.IssuerSpec
@@ -464,6 +672,149 @@ func TestValidateIssuerSpec(t *testing.T) {
 			},
 			errs: []*field.Error{},
 		},
+		"valid issuer with a controller name": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				ControllerName: "team-a",
+			},
+			errs: []*field.Error{},
+		},
+		"issuer with an invalid controller name": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				ControllerName: "Team_A",
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("controllerName"), "Team_A", "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+			},
+		},
+		"valid issuer with a duration tolerance": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				DurationTolerance: &cmapi.IssuerDurationTolerance{MaxShortening: metav1.Duration{Duration: 24 * time.Hour}},
+			},
+			errs: []*field.Error{},
+		},
+		"valid issuer with capabilities": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				Capabilities: &cmapi.IssuerCapabilities{
+					Usages:                  []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+					UnsupportedUsagesPolicy: cmapi.FailUnsupportedUsages,
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"issuer with invalid capabilities": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				Capabilities: &cmapi.IssuerCapabilities{
+					Usages:                  []cmapi.KeyUsage{cmapi.UsageServerAuth, "teleportation"},
+					UnsupportedUsagesPolicy: "Ignore",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("capabilities", "usages").Index(1), cmapi.KeyUsage("teleportation"), "unknown keyusage"),
+				field.NotSupported(fldPath.Child("capabilities", "unsupportedUsagesPolicy"), cmapi.UnsupportedUsagesPolicy("Ignore"), []string{"Drop", "Fail"}),
+			},
+		},
+		"issuer with a duration tolerance which is not positive": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				DurationTolerance: &cmapi.IssuerDurationTolerance{},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("durationTolerance", "maxShortening"), time.Duration(0), "must be greater than zero"),
+			},
+		},
+		"valid issuer with a notBefore backdate": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{NotBeforeBackdate: &metav1.Duration{Duration: 5 * time.Minute}},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"issuer with a negative notBefore backdate": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "abc", NotBeforeBackdate: &metav1.Duration{Duration: -time.Minute}},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "notBeforeBackdate"), "-1m0s", "must not be negative"),
+			},
+		},
+		"valid ca issuer with tracked serial numbers": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "abc", SerialNumberBits: 64, TrackSerialNumbers: true},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer with too many serial number bits": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "abc", SerialNumberBits: 160},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "serialNumberBits"), 160, "must be between 64 and 159"),
+			},
+		},
+		"valid ca issuer with a key identifier method": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "abc", KeyIdentifierMethod: cmapi.TruncatedSHA256KeyIdentifierMethod},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"selfsigned issuer with an unsupported key identifier method": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{KeyIdentifierMethod: "MD5"},
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("selfSigned", "keyIdentifierMethod"), cmapi.KeyIdentifierMethod("MD5"),
+					[]string{string(cmapi.SHA1KeyIdentifierMethod), string(cmapi.TruncatedSHA256KeyIdentifierMethod)}),
+			},
+		},
+		"valid vault issuer with a pinned CA bundle": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					Vault: &validVaultIssuer,
+				},
+				CABundle: caBundle,
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer with an invalid pinned CA bundle": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "abc"},
+				},
+				CABundle: []byte("invalid"),
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("caBundle"), "may only be set for ACME, Vault and Venafi issuers"),
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+			},
+		},
 		"valid acme issuer": {
 			spec: &cmapi.IssuerSpec{
 				IssuerConfig: cmapi.IssuerConfig{
@@ -552,6 +903,30 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("abc")},
 			},
 		},
+		"ingressClassName field specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{IngressClassName: strPtr("abc")},
+			},
+		},
+		"ingressClassName and class fields specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					Class:            strPtr("abc"),
+					IngressClassName: strPtr("abc"),
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("ingress"), "only one of 'name', 'class' or 'ingressClassName' should be specified"),
+			},
+		},
+		"empty ingressClassName field specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{IngressClassName: strPtr("")},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ingress", "ingressClassName"), "", "must not be empty"),
+			},
+		},
 		"neither field specified": {
 			cfg: &cmacme.ACMEChallengeSolverHTTP01{
 				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
@@ -571,7 +946,7 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				},
 			},
 			errs: []*field.Error{
-				field.Forbidden(fldPath.Child("ingress"), "only one of 'name' or 'class' should be specified"),
+				field.Forbidden(fldPath.Child("ingress"), "only one of 'name', 'class' or 'ingressClassName' should be specified"),
 			},
 		},
 		"acme issuer with valid http01 service config serviceType ClusterIP": {
@@ -623,6 +998,70 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 	}
 }
 
+func TestValidateACMEIssuerTLSALPN01Config(t *testing.T) {
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		cfg  *cmacme.ACMEChallengeSolverTLSALPN01
+		errs []*field.Error
+	}{
+		"no fields specified": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{},
+		},
+		"HostPort mode": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Mode: cmacme.ACMEChallengeSolverTLSALPN01ModeHostPort,
+			},
+		},
+		"Service mode with serviceType ClusterIP": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Mode:        cmacme.ACMEChallengeSolverTLSALPN01ModeService,
+				ServiceType: corev1.ServiceTypeClusterIP,
+			},
+		},
+		"Service mode with serviceType NodePort": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Mode:        cmacme.ACMEChallengeSolverTLSALPN01ModeService,
+				ServiceType: corev1.ServiceTypeNodePort,
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("serviceType"), corev1.ServiceTypeNodePort, `must be empty, "LoadBalancer" or "ClusterIP"`),
+			},
+		},
+		"HostPort mode with serviceType": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Mode:        cmacme.ACMEChallengeSolverTLSALPN01ModeHostPort,
+				ServiceType: corev1.ServiceTypeLoadBalancer,
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("serviceType"), "may only be specified when using the Service mode"),
+			},
+		},
+		"unknown mode": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Mode: "Ingress",
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("mode"), cmacme.ACMEChallengeSolverTLSALPN01Mode("Ingress"), []string{"HostPort", "Service"}),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateACMEIssuerChallengeSolverTLSALPN01Config(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 	fldPath := field.NewPath("test")
 	scenarios := map[string]struct {
@@ -1302,6 +1741,36 @@ func TestValidateVenafiIssuerConfig(t *testing.T) {
 				field.Required(fldPath.Child("zone"), ""),
 			},
 		},
+		"valid cloud application and issuing template": {
+			cfg: &cmapi.VenafiIssuer{
+				Cloud: &cmapi.VenafiCloud{
+					Application:     "app",
+					IssuingTemplate: "template",
+				},
+			},
+		},
+		"zone and cloud application": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "app\\template",
+				Cloud: &cmapi.VenafiCloud{
+					Application:     "app",
+					IssuingTemplate: "template",
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("zone"), "zone may not be set when cloud.application is set"),
+			},
+		},
+		"cloud application without issuing template": {
+			cfg: &cmapi.VenafiIssuer{
+				Cloud: &cmapi.VenafiCloud{
+					Application: "app",
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("cloud", "issuingTemplate"), "must be set when application is set"),
+			},
+		},
 		"missing configuration": {
 			cfg: &cmapi.VenafiIssuer{
 				Zone: "a\\b\\c",
@@ -1375,13 +1844,208 @@ func TestValidateVenafiTPP(t *testing.T) {
 	}
 }
 
+func TestValidateFakeIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("test")
+	scenarios := map[string]struct {
+		featureEnabled bool
+		cfg            *cmapi.FakeIssuer
+		errs           []*field.Error
+	}{
+		"valid": {
+			featureEnabled: true,
+			cfg: &cmapi.FakeIssuer{
+				Latency:           &metav1.Duration{Duration: 5 * time.Second},
+				FailurePercentage: 10,
+			},
+		},
+		"feature gate disabled": {
+			cfg: &cmapi.FakeIssuer{},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "feature gate FakeIssuer must be enabled"),
+			},
+		},
+		"negative latency": {
+			featureEnabled: true,
+			cfg: &cmapi.FakeIssuer{
+				Latency: &metav1.Duration{Duration: -time.Second},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("latency"), "-1s", "must not be negative"),
+			},
+		},
+		"failure percentage out of range": {
+			featureEnabled: true,
+			cfg: &cmapi.FakeIssuer{
+				FailurePercentage: 101,
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("failurePercentage"), int32(101), "must be between 0 and 100"),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.FakeIssuer, s.featureEnabled)()
+			errs := ValidateFakeIssuerConfig(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDelegateIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("test")
+	routesPath := fldPath.Child("routes")
+	scenarios := map[string]struct {
+		featureEnabled bool
+		cfg            *cmapi.DelegateIssuer
+		errs           []*field.Error
+	}{
+		"valid": {
+			featureEnabled: true,
+			cfg: &cmapi.DelegateIssuer{
+				Routes: []cmapi.DelegateIssuerRoute{
+					{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+						IssuerRef:         cmmeta.ObjectReference{Name: "payments-ca", Kind: "Issuer"},
+					},
+					{
+						DNSZones:  []string{"example.com", "example.org."},
+						IssuerRef: cmmeta.ObjectReference{Name: "public-ca", Kind: "ClusterIssuer"},
+					},
+				},
+			},
+		},
+		"feature gate disabled": {
+			cfg: &cmapi.DelegateIssuer{},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "feature gate DelegateIssuer must be enabled"),
+			},
+		},
+		"no routes": {
+			featureEnabled: true,
+			cfg:            &cmapi.DelegateIssuer{},
+			errs: []*field.Error{
+				field.Required(routesPath, "at least one route must be configured"),
+			},
+		},
+		"invalid route": {
+			featureEnabled: true,
+			cfg: &cmapi.DelegateIssuer{
+				Routes: []cmapi.DelegateIssuerRoute{
+					{
+						DNSZones:  []string{"*.example.com"},
+						IssuerRef: cmmeta.ObjectReference{Kind: "Certificate"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(routesPath.Index(0).Child("dnsZones").Index(0), "*.example.com", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+				field.Required(routesPath.Index(0).Child("issuerRef", "name"), "must be specified"),
+				field.Invalid(routesPath.Index(0).Child("issuerRef", "kind"), "Certificate", "must be one of Issuer or ClusterIssuer"),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.DelegateIssuer, s.featureEnabled)()
+			errs := ValidateDelegateIssuerConfig(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateOfflineIssuerConfig(t *testing.T) {
+	caBundle := unitcrypto.MustCreateCryptoBundle(t,
+		&pubcmapi.Certificate{Spec: pubcmapi.CertificateSpec{CommonName: "test", IsCA: true}},
+		clock.RealClock{},
+	).CertBytes
+
+	fldPath := field.NewPath("test")
+	scenarios := map[string]struct {
+		featureEnabled bool
+		cfg            *cmapi.OfflineIssuer
+		errs           []*field.Error
+	}{
+		"valid": {
+			featureEnabled: true,
+			cfg:            &cmapi.OfflineIssuer{CABundle: caBundle},
+		},
+		"feature gate disabled": {
+			cfg: &cmapi.OfflineIssuer{CABundle: caBundle},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "feature gate OfflineIssuer must be enabled"),
+			},
+		},
+		"missing CA bundle": {
+			featureEnabled: true,
+			cfg:            &cmapi.OfflineIssuer{},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("caBundle"), "the CA certificates of the offline CA must be specified"),
+			},
+		},
+		"invalid CA bundle": {
+			featureEnabled: true,
+			cfg:            &cmapi.OfflineIssuer{CABundle: []byte("not a certificate")},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.OfflineIssuer, s.featureEnabled)()
+			errs := ValidateOfflineIssuerConfig(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateIssuer(t *testing.T) {
 	scenarios := map[string]struct {
 		cfg       *cmapi.Issuer
 		a         *admissionv1.AdmissionRequest
 		expectedE []*field.Error
 		expectedW []string
-	}{}
+	}{
+		"issuer with intermediate CA provisioning": {
+			cfg: &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig:   cmapi.IssuerConfig{CA: &cmapi.CAIssuer{SecretName: "abc"}},
+					IntermediateCA: &cmapi.IssuerIntermediateCA{},
+				},
+			},
+			expectedE: []*field.Error{
+				field.Forbidden(field.NewPath("spec", "intermediateCA"), "may only be set on ClusterIssuers"),
+			},
+		},
+	}
 
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	admissionv1 "k8s.io/api/admission/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+// Validation functions for cert-manager IssuerReferenceGrant types.
+
+func ValidateIssuerReferenceGrant(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	grant := obj.(*cmapi.IssuerReferenceGrant)
+	return ValidateIssuerReferenceGrantSpec(&grant.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateUpdateIssuerReferenceGrant(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	grant := obj.(*cmapi.IssuerReferenceGrant)
+	return ValidateIssuerReferenceGrantSpec(&grant.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateIssuerReferenceGrantSpec(spec *cmapi.IssuerReferenceGrantSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if len(spec.From) == 0 {
+		el = append(el, field.Required(fldPath.Child("from"), "at least one namespace must be set"))
+	}
+	for i, from := range spec.From {
+		for _, msg := range apivalidation.ValidateNamespaceName(from.Namespace, false) {
+			el = append(el, field.Invalid(fldPath.Child("from").Index(i).Child("namespace"), from.Namespace, msg))
+		}
+	}
+
+	for i, to := range spec.To {
+		if to.Name == "" {
+			el = append(el, field.Required(fldPath.Child("to").Index(i).Child("name"), "must be specified"))
+		}
+	}
+
+	return el
+}
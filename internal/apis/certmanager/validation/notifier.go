@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"text/template"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Validation functions for cert-manager Notifier types.
+
+func ValidateNotifier(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	notifier := obj.(*cmapi.Notifier)
+	return ValidateNotifierSpec(&notifier.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateUpdateNotifier(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	notifier := obj.(*cmapi.Notifier)
+	return ValidateNotifierSpec(&notifier.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateNotifierSpec(spec *cmapi.NotifierSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	for i, event := range spec.Events {
+		switch event {
+		case cmapi.NotifierEventFailed, cmapi.NotifierEventRenewalMissed, cmapi.NotifierEventExpiring:
+		default:
+			el = append(el, field.NotSupported(fldPath.Child("events").Index(i), event,
+				[]string{string(cmapi.NotifierEventFailed), string(cmapi.NotifierEventRenewalMissed), string(cmapi.NotifierEventExpiring)}))
+		}
+	}
+
+	if d := spec.ExpiryThreshold; d != nil && d.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("expiryThreshold"), d.Duration.String(), "must be greater than zero"))
+	}
+	if d := spec.RepeatInterval; d != nil && d.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("repeatInterval"), d.Duration.String(), "must be greater than zero"))
+	}
+
+	if len(spec.Template) > 0 {
+		if _, err := template.New("").Parse(spec.Template); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("template"), spec.Template, err.Error()))
+		}
+	}
+
+	var configs []string
+	if spec.Slack != nil {
+		configs = append(configs, "slack")
+		el = append(el, ValidateSecretKeySelector(&spec.Slack.WebhookURLSecretRef, fldPath.Child("slack", "webhookURLSecretRef"))...)
+	}
+	if spec.Webhook != nil {
+		configs = append(configs, "webhook")
+		el = append(el, validateWebhookNotifier(spec.Webhook, fldPath.Child("webhook"))...)
+	}
+	if spec.Email != nil {
+		configs = append(configs, "email")
+		el = append(el, validateEmailNotifier(spec.Email, fldPath.Child("email"))...)
+	}
+	switch len(configs) {
+	case 0:
+		el = append(el, field.Required(fldPath, "one of slack, webhook or email must be set"))
+	case 1:
+	default:
+		el = append(el, field.Forbidden(fldPath, "only one of slack, webhook or email may be set"))
+	}
+
+	return el
+}
+
+func validateWebhookNotifier(webhook *cmapi.WebhookNotifier, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if len(webhook.URL) == 0 {
+		el = append(el, field.Required(fldPath.Child("url"), ""))
+	} else if u, err := url.Parse(webhook.URL); err != nil {
+		el = append(el, field.Invalid(fldPath.Child("url"), webhook.URL, err.Error()))
+	} else if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		el = append(el, field.Invalid(fldPath.Child("url"), webhook.URL, "must be an http or https URL"))
+	}
+
+	if len(webhook.CABundle) > 0 {
+		if _, err := pki.DecodeX509CertificateChainBytes(webhook.CABundle); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "must be a PEM encoded CA bundle"))
+		}
+	}
+
+	return el
+}
+
+func validateEmailNotifier(email *cmapi.EmailNotifier, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if len(email.SMTPServer) == 0 {
+		el = append(el, field.Required(fldPath.Child("smtpServer"), ""))
+	} else if _, _, err := net.SplitHostPort(email.SMTPServer); err != nil {
+		el = append(el, field.Invalid(fldPath.Child("smtpServer"), email.SMTPServer, "must be a host:port address"))
+	}
+
+	if len(email.From) == 0 {
+		el = append(el, field.Required(fldPath.Child("from"), ""))
+	} else if _, err := mail.ParseAddress(email.From); err != nil {
+		el = append(el, field.Invalid(fldPath.Child("from"), email.From, err.Error()))
+	}
+
+	if len(email.To) == 0 {
+		el = append(el, field.Required(fldPath.Child("to"), ""))
+	}
+	for i, to := range email.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("to").Index(i), to, err.Error()))
+		}
+	}
+
+	if email.PasswordSecretRef != nil {
+		if len(email.Username) == 0 {
+			el = append(el, field.Required(fldPath.Child("username"), "must be set if passwordSecretRef is set"))
+		}
+		el = append(el, ValidateSecretKeySelector(email.PasswordSecretRef, fldPath.Child("passwordSecretRef"))...)
+	}
+
+	return el
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+func TestValidateNotifierSpec(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	webhook := &cmapi.WebhookNotifier{URL: "https://alerts.example.com"}
+
+	scenarios := map[string]struct {
+		spec *cmapi.NotifierSpec
+		errs []*field.Error
+	}{
+		"valid slack notifier": {
+			spec: &cmapi.NotifierSpec{
+				Events:          []cmapi.NotifierEvent{cmapi.NotifierEventExpiring},
+				ExpiryThreshold: &metav1.Duration{Duration: 72 * time.Hour},
+				Template:        "{{ .Message }}",
+				Slack: &cmapi.SlackNotifier{
+					WebhookURLSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "slack"}, Key: "url"},
+				},
+			},
+		},
+		"valid email notifier": {
+			spec: &cmapi.NotifierSpec{
+				Email: &cmapi.EmailNotifier{
+					SMTPServer: "smtp.example.com:587",
+					From:       "cert-manager@example.com",
+					To:         []string{"Team <team@example.com>"},
+				},
+			},
+		},
+		"no channel": {
+			spec: &cmapi.NotifierSpec{},
+			errs: []*field.Error{
+				field.Required(fldPath, "one of slack, webhook or email must be set"),
+			},
+		},
+		"multiple channels": {
+			spec: &cmapi.NotifierSpec{
+				Webhook: webhook,
+				Email: &cmapi.EmailNotifier{
+					SMTPServer: "smtp.example.com:587",
+					From:       "cert-manager@example.com",
+					To:         []string{"team@example.com"},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "only one of slack, webhook or email may be set"),
+			},
+		},
+		"invalid event, durations and template": {
+			spec: &cmapi.NotifierSpec{
+				Events:          []cmapi.NotifierEvent{"Renewed"},
+				ExpiryThreshold: &metav1.Duration{},
+				RepeatInterval:  &metav1.Duration{Duration: -time.Hour},
+				Template:        "{{ .Message",
+				Webhook:         webhook,
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("events").Index(0), cmapi.NotifierEvent("Renewed"), []string{"Failed", "RenewalMissed", "Expiring"}),
+				field.Invalid(fldPath.Child("expiryThreshold"), "0s", "must be greater than zero"),
+				field.Invalid(fldPath.Child("repeatInterval"), "-1h0m0s", "must be greater than zero"),
+				field.Invalid(fldPath.Child("template"), "{{ .Message", "template: :1: unclosed action"),
+			},
+		},
+		"invalid webhook url": {
+			spec: &cmapi.NotifierSpec{
+				Webhook: &cmapi.WebhookNotifier{URL: "ftp://alerts.example.com"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("webhook", "url"), "ftp://alerts.example.com", "must be an http or https URL"),
+			},
+		},
+		"invalid email config": {
+			spec: &cmapi.NotifierSpec{
+				Email: &cmapi.EmailNotifier{
+					SMTPServer:        "smtp.example.com",
+					PasswordSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "smtp"}, Key: "password"},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("email", "smtpServer"), "smtp.example.com", "must be a host:port address"),
+				field.Required(fldPath.Child("email", "from"), ""),
+				field.Required(fldPath.Child("email", "to"), ""),
+				field.Required(fldPath.Child("email", "username"), "must be set if passwordSecretRef is set"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateNotifierSpec(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected errors %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
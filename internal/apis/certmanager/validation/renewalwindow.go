@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+// Validation functions for cert-manager RenewalWindow types.
+
+func ValidateRenewalWindow(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	window := obj.(*cmapi.RenewalWindow)
+	return ValidateRenewalWindowSpec(&window.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateUpdateRenewalWindow(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	window := obj.(*cmapi.RenewalWindow)
+	return ValidateRenewalWindowSpec(&window.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateRenewalWindowSpec(spec *cmapi.RenewalWindowSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if len(spec.Blackouts) == 0 {
+		el = append(el, field.Required(fldPath.Child("blackouts"), "at least one blackout must be set"))
+	}
+	for i, blackout := range spec.Blackouts {
+		if !blackout.End.After(blackout.Start.Time) {
+			el = append(el, field.Invalid(fldPath.Child("blackouts").Index(i).Child("end"), blackout.End.UTC().String(), "must be after start"))
+		}
+	}
+
+	if m := spec.HardExpiryMargin; m != nil && m.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("hardExpiryMargin"), m.Duration.String(), "must be greater than zero"))
+	}
+
+	return el
+}
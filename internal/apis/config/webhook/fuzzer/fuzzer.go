@@ -36,6 +36,12 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 			if s.SecurePort == nil {
 				s.SecurePort = pointer.Int(123)
 			}
+			if s.KubernetesAPIQPS == nil {
+				s.KubernetesAPIQPS = pointer.Float32(5)
+			}
+			if s.KubernetesAPIBurst == nil {
+				s.KubernetesAPIBurst = pointer.Int(10)
+			}
 			if s.PprofAddress == "" {
 				s.PprofAddress = "something:1234"
 			}
@@ -44,6 +44,16 @@ type WebhookConfiguration struct {
 	// Deprecated: use `kubeConfig` instead.
 	APIServerHost string
 
+	// kubernetesAPIQPS is the maximum queries-per-second of requests sent to
+	// the Kubernetes apiserver.
+	// Defaults to 5.
+	KubernetesAPIQPS *float32
+
+	// kubernetesAPIBurst is the maximum burst of requests sent to the
+	// Kubernetes apiserver.
+	// Defaults to 10.
+	KubernetesAPIBurst *int
+
 	// enablePprof configures whether pprof is enabled.
 	EnablePprof bool
 
@@ -51,6 +61,13 @@ type WebhookConfiguration struct {
 	// Defaults to 'localhost:6060'.
 	PprofAddress string
 
+	// shutdownDelay is how long the webhook keeps serving requests after it
+	// has been asked to shut down, while reporting itself as not ready so
+	// that it is removed from the endpoints of its Service first. This
+	// allows replicas to be drained without failing in-flight admission
+	// requests. Defaults to 0s, which shuts down immediately.
+	ShutdownDelay metav1.Duration
+
 	// featureGates is a map of feature names to bools that enable or disable experimental
 	// features.
 	// Default: nil
@@ -34,6 +34,12 @@ func SetDefaults_WebhookConfiguration(obj *v1alpha1.WebhookConfiguration) {
 	if obj.HealthzPort == nil {
 		obj.HealthzPort = pointer.Int(6080)
 	}
+	if obj.KubernetesAPIQPS == nil {
+		obj.KubernetesAPIQPS = pointer.Float32(5)
+	}
+	if obj.KubernetesAPIBurst == nil {
+		obj.KubernetesAPIBurst = pointer.Int(10)
+	}
 	if obj.PprofAddress == "" {
 		obj.PprofAddress = "localhost:6060"
 	}
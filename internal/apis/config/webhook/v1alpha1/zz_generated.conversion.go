@@ -168,8 +168,11 @@ func autoConvert_v1alpha1_WebhookConfiguration_To_webhook_WebhookConfiguration(i
 	}
 	out.KubeConfig = in.KubeConfig
 	out.APIServerHost = in.APIServerHost
+	out.KubernetesAPIQPS = (*float32)(unsafe.Pointer(in.KubernetesAPIQPS))
+	out.KubernetesAPIBurst = (*int)(unsafe.Pointer(in.KubernetesAPIBurst))
 	out.EnablePprof = in.EnablePprof
 	out.PprofAddress = in.PprofAddress
+	out.ShutdownDelay = in.ShutdownDelay
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	return nil
 }
@@ -187,8 +190,11 @@ func autoConvert_webhook_WebhookConfiguration_To_v1alpha1_WebhookConfiguration(i
 	}
 	out.KubeConfig = in.KubeConfig
 	out.APIServerHost = in.APIServerHost
+	out.KubernetesAPIQPS = (*float32)(unsafe.Pointer(in.KubernetesAPIQPS))
+	out.KubernetesAPIBurst = (*int)(unsafe.Pointer(in.KubernetesAPIBurst))
 	out.EnablePprof = in.EnablePprof
 	out.PprofAddress = in.PprofAddress
+	out.ShutdownDelay = in.ShutdownDelay
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	return nil
 }
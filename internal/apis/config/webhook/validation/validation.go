@@ -54,5 +54,14 @@ func ValidateWebhookConfiguration(cfg *config.WebhookConfiguration) error {
 	if cfg.SecurePort == nil {
 		allErrors = append(allErrors, fmt.Errorf("invalid configuration: securePort must be specified"))
 	}
+	if cfg.KubernetesAPIQPS != nil && *cfg.KubernetesAPIQPS <= 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: kubernetesAPIQPS (--kube-api-qps) must be higher than 0"))
+	}
+	if cfg.KubernetesAPIBurst != nil && cfg.KubernetesAPIQPS != nil && float32(*cfg.KubernetesAPIBurst) < *cfg.KubernetesAPIQPS {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: kubernetesAPIBurst (--kube-api-burst) must be higher or equal to kubernetesAPIQPS (--kube-api-qps)"))
+	}
+	if cfg.ShutdownDelay.Duration < 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: shutdownDelay (--shutdown-delay) must not be negative"))
+	}
 	return utilerrors.NewAggregate(allErrors)
 }
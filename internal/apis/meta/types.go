@@ -55,6 +55,11 @@ type ObjectReference struct {
 	Kind string
 	// Group of the resource being referred to.
 	Group string
+	// Namespace of the resource being referred to. Only Issuers may be
+	// referenced in another namespace, and only if that namespace contains
+	// an IssuerReferenceGrant allowing it.
+	// If not set, the namespace of the referring resource is used.
+	Namespace string
 }
 
 // A reference to a specific 'key' within a Secret resource.
@@ -72,4 +77,9 @@ type SecretKeySelector struct {
 const (
 	// Used as a data key in Secret resources to store a CA certificate.
 	TLSCAKey = "ca.crt"
+
+	// Used as a data key in Secret resources to store previous CA
+	// certificates which should continue to be trusted alongside the one
+	// stored under TLSCAKey, e.g. during a rotation of the CA.
+	TLSPreviousCAKey = "previous-ca.crt"
 )
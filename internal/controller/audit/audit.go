@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the issuance decisions made for CertificateRequests
+// to a Sink, giving security teams a ledger of issued certificates which is
+// independent of the audit log of the Kubernetes API server.
+package audit
+
+import (
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Decision is the outcome of a CertificateRequest.
+type Decision string
+
+const (
+	// DecisionIssued is recorded when a certificate has been issued.
+	DecisionIssued Decision = "Issued"
+
+	// DecisionDenied is recorded when a CertificateRequest has been denied by
+	// an approver.
+	DecisionDenied Decision = "Denied"
+
+	// DecisionFailed is recorded when an issuer failed to issue a certificate.
+	DecisionFailed Decision = "Failed"
+)
+
+// Record is a single entry of the audit log.
+type Record struct {
+	// Time is the time at which the decision was recorded.
+	Time time.Time `json:"time"`
+
+	// Decision is the outcome of the CertificateRequest.
+	Decision Decision `json:"decision"`
+
+	// Message explains the decision, for example why issuance failed.
+	Message string `json:"message,omitempty"`
+
+	Namespace          string `json:"namespace"`
+	CertificateRequest string `json:"certificateRequest"`
+
+	// Certificate is the name of the Certificate that the CertificateRequest
+	// was created for, if any.
+	Certificate string `json:"certificate,omitempty"`
+
+	// Requester is the user that created the CertificateRequest.
+	Requester Requester `json:"requester"`
+
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+
+	// The subject and SANs that were requested in the CSR.
+	CommonName     string   `json:"commonName,omitempty"`
+	DNSNames       []string `json:"dnsNames,omitempty"`
+	IPAddresses    []string `json:"ipAddresses,omitempty"`
+	URIs           []string `json:"uris,omitempty"`
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// Approval is the approval decision of the CertificateRequest, if any.
+	Approval *Approval `json:"approval,omitempty"`
+
+	// Serial is the hex encoded serial number of the issued certificate.
+	Serial string `json:"serial,omitempty"`
+}
+
+// Requester identifies the user that created a CertificateRequest.
+type Requester struct {
+	Username string   `json:"username,omitempty"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// Approval is the approval decision of a CertificateRequest.
+type Approval struct {
+	// Type is either Approved or Denied.
+	Type    cmapi.CertificateRequestConditionType `json:"type"`
+	Reason  string                                `json:"reason,omitempty"`
+	Message string                                `json:"message,omitempty"`
+}
+
+// RecordFor builds the audit Record of the given decision for cr. The CSR and
+// certificate of cr are parsed on a best effort basis, as a Record should be
+// written even if they are invalid.
+func RecordFor(cr *cmapi.CertificateRequest, decision Decision, message string, now time.Time) Record {
+	rec := Record{
+		Time:               now,
+		Decision:           decision,
+		Message:            message,
+		Namespace:          cr.Namespace,
+		CertificateRequest: cr.Name,
+		Certificate:        cr.Annotations[cmapi.CertificateNameKey],
+		Requester: Requester{
+			Username: cr.Spec.Username,
+			UID:      cr.Spec.UID,
+			Groups:   cr.Spec.Groups,
+		},
+		IssuerRef: cr.Spec.IssuerRef,
+	}
+
+	if csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request); err == nil {
+		rec.CommonName = csr.Subject.CommonName
+		rec.DNSNames = csr.DNSNames
+		rec.IPAddresses = pki.IPAddressesToString(csr.IPAddresses)
+		rec.URIs = pki.URLsToString(csr.URIs)
+		rec.EmailAddresses = csr.EmailAddresses
+	}
+
+	for _, condType := range []cmapi.CertificateRequestConditionType{cmapi.CertificateRequestConditionDenied, cmapi.CertificateRequestConditionApproved} {
+		if cond := apiutil.GetCertificateRequestCondition(cr, condType); cond != nil && cond.Status == cmmeta.ConditionTrue {
+			rec.Approval = &Approval{Type: condType, Reason: cond.Reason, Message: cond.Message}
+			break
+		}
+	}
+
+	if decision == DecisionIssued {
+		if cert, err := pki.DecodeX509CertificateBytes(cr.Status.Certificate); err == nil {
+			rec.Serial = cert.SerialNumber.Text(16)
+		}
+	}
+
+	return rec
+}
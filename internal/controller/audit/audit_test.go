@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRecordFor(t *testing.T) {
+	csr, sk, err := gen.CSR(x509.ECDSA,
+		gen.SetCSRCommonName("example.com"),
+		gen.SetCSRDNSNames("example.com", "www.example.com"),
+		gen.SetCSRIPAddressesFromStrings("10.0.0.1"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(0xabc),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, sk.Public(), sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	now := time.Unix(1000, 0)
+	cr := gen.CertificateRequest("test",
+		gen.SetCertificateRequestNamespace("ns"),
+		gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateNameKey: "crt"}),
+		gen.SetCertificateRequestCSR(csr),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "Issuer"}),
+		gen.SetCertificateRequestUsername("alice"),
+		gen.SetCertificateRequestGroups([]string{"team"}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+			Reason: "policy",
+		}),
+		gen.SetCertificateRequestCertificate(certPEM),
+	)
+
+	got := RecordFor(cr, DecisionIssued, "issued", now)
+	exp := Record{
+		Time:               now,
+		Decision:           DecisionIssued,
+		Message:            "issued",
+		Namespace:          "ns",
+		CertificateRequest: "test",
+		Certificate:        "crt",
+		Requester:          Requester{Username: "alice", Groups: []string{"team"}},
+		IssuerRef:          cmmeta.ObjectReference{Name: "ca", Kind: "Issuer"},
+		CommonName:         "example.com",
+		DNSNames:           []string{"example.com", "www.example.com"},
+		IPAddresses:        []string{"10.0.0.1"},
+		Approval:           &Approval{Type: cmapi.CertificateRequestConditionApproved, Reason: "policy"},
+		Serial:             "abc",
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected record:\nexp=%+v\ngot=%+v", exp, got)
+	}
+
+	// The serial is only recorded for issued certificates.
+	if got := RecordFor(cr, DecisionFailed, "", now); got.Serial != "" {
+		t.Errorf("expected no serial for a failed request, got %q", got.Serial)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Sink writes audit Records.
+type Sink interface {
+	Write(ctx context.Context, rec Record) error
+}
+
+// writerSink writes Records as JSON lines to an io.Writer.
+type writerSink struct {
+	lock sync.Mutex
+	enc  *json.Encoder
+}
+
+// NewWriterSink returns a Sink which writes each Record as a line of JSON to
+// w, for example a file or stdout.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{enc: json.NewEncoder(w)}
+}
+
+func (s *writerSink) Write(_ context.Context, rec Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.enc.Encode(rec)
+}
+
+// webhookSink POSTs Records to a URL.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a Sink which sends each Record as a JSON encoded
+// POST request to url. Any response status other than 2xx is treated as an
+// error.
+func NewWebhookSink(url string, client *http.Client) Sink {
+	return &webhookSink{url: url, client: client}
+}
+
+func (s *webhookSink) Write(ctx context.Context, rec Record) error {
+	body, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit webhook %q returned unexpected status code %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// MultiSink writes each Record to all of its Sinks.
+type MultiSink []Sink
+
+func (m MultiSink) Write(ctx context.Context, rec Record) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Write(ctx, rec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
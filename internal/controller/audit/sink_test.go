@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewWriterSink(&buf)
+
+	for _, name := range []string{"a", "b"} {
+		if err := s.Write(context.Background(), Record{CertificateRequest: name, Decision: DecisionIssued}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	var rec Record
+	if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+	if rec.CertificateRequest != "b" || rec.Decision != DecisionIssued {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got Record
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %q", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode record: %v", err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := NewWebhookSink(srv.URL, srv.Client())
+	if err := s.Write(context.Background(), Record{CertificateRequest: "test", Decision: DecisionDenied}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.CertificateRequest != "test" || got.Decision != DecisionDenied {
+		t.Errorf("unexpected record: %+v", got)
+	}
+
+	status = http.StatusInternalServerError
+	if err := s.Write(context.Background(), Record{}); err == nil {
+		t.Errorf("expected an error for a non-2xx response")
+	}
+}
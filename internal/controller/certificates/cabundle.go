@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// AdditionalCABundle returns the PEM encoded CA certificates referenced by the
+// additionalCABundle of the Certificate. ConfigMaps are read from the
+// apiserver, and Secrets from the given lister.
+func AdditionalCABundle(ctx context.Context, configMaps coreclient.ConfigMapsGetter, secrets corelisters.SecretLister, crt *cmapi.Certificate) ([]byte, error) {
+	ref := crt.Spec.AdditionalCABundle
+	switch {
+	case ref.ConfigMap != nil:
+		cm, err := configMaps.ConfigMaps(crt.Namespace).Get(ctx, ref.ConfigMap.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("fetching additional CA bundle from ConfigMap: %w", err)
+		}
+		if data, ok := cm.Data[ref.ConfigMap.Key]; ok {
+			return []byte(data), nil
+		}
+		if data, ok := cm.BinaryData[ref.ConfigMap.Key]; ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf("additional CA bundle ConfigMap %q contains no data for key %q", ref.ConfigMap.Name, ref.ConfigMap.Key)
+
+	case ref.Secret != nil:
+		secret, err := secrets.Secrets(crt.Namespace).Get(ref.Secret.Name)
+		if err != nil {
+			return nil, fmt.Errorf("fetching additional CA bundle from Secret: %w", err)
+		}
+		data, ok := secret.Data[ref.Secret.Key]
+		if !ok {
+			return nil, fmt.Errorf("additional CA bundle Secret %q contains no data for key %q", ref.Secret.Name, ref.Secret.Key)
+		}
+		return data, nil
+
+	default:
+		return nil, errors.New("additional CA bundle references neither a ConfigMap nor a Secret")
+	}
+}
+
+// AppendCABundle appends the certificates of the additional PEM bundle which
+// are not already in ca to it. Certificates which are already present are
+// skipped, so that writing the data of a Secret back, which already holds
+// the additional certificates, doesn't duplicate them.
+func AppendCABundle(ca, additional []byte) ([]byte, error) {
+	certs, err := utilpki.DecodeX509CertificateChainBytes(additional)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[string]bool)
+	if existing, err := utilpki.DecodeX509CertificateChainBytes(ca); err == nil {
+		for _, cert := range existing {
+			present[string(cert.Raw)] = true
+		}
+	}
+
+	bundle := append([]byte(nil), ca...)
+	if len(bundle) > 0 && !bytes.HasSuffix(bundle, []byte("\n")) {
+		bundle = append(bundle, '\n')
+	}
+	for _, cert := range certs {
+		if present[string(cert.Raw)] {
+			continue
+		}
+		present[string(cert.Raw)] = true
+		bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testcorelisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+func Test_AdditionalCABundle(t *testing.T) {
+	crt := gen.Certificate("test", gen.SetCertificateNamespace(gen.DefaultTestNamespace))
+	crt.Spec.AdditionalCABundle = &cmapi.CertificateAdditionalCABundle{
+		Secret: &cmapi.CABundleKeySelector{Name: "roots", Key: "roots.pem"},
+	}
+	secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "roots"},
+		Data:       map[string][]byte{"roots.pem": []byte("roots")},
+	}, nil))
+	configMaps := fake.NewSimpleClientset().CoreV1()
+
+	data, err := AdditionalCABundle(context.TODO(), configMaps, secretLister, crt)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("roots"), data)
+
+	crt.Spec.AdditionalCABundle = &cmapi.CertificateAdditionalCABundle{
+		ConfigMap: &cmapi.CABundleKeySelector{Name: "roots", Key: "roots.pem"},
+	}
+	_, err = AdditionalCABundle(context.TODO(), configMaps, secretLister, crt)
+	assert.Error(t, err, "expected an error for a missing ConfigMap")
+}
+
+func Test_AppendCABundle(t *testing.T) {
+	crt := gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))
+	clock := fakeclock.NewFakeClock(time.Now())
+	ca := testcrypto.MustCreateCryptoBundle(t, crt, clock).CertBytes
+	root := testcrypto.MustCreateCryptoBundle(t, crt, clock).CertBytes
+
+	bundle, err := AppendCABundle(ca, root)
+	assert.NoError(t, err)
+	assert.Equal(t, string(ca)+string(root), string(bundle))
+
+	// Certificates which are already present are not appended again.
+	bundle, err = AppendCABundle(bundle, append(append([]byte(nil), root...), ca...))
+	assert.NoError(t, err)
+	assert.Equal(t, string(ca)+string(root), string(bundle))
+
+	// An empty CA is replaced by the additional bundle.
+	bundle, err = AppendCABundle(nil, root)
+	assert.NoError(t, err)
+	assert.Equal(t, string(root), string(bundle))
+
+	_, err = AppendCABundle(ca, []byte("not a certificate"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// LabelsForCertificate returns the labels which are set on the resources
+// created for the given revision of a Certificate, so that all resources of an
+// issuance can be selected together. The revision label is omitted if revision
+// is nil.
+// Labels whose value is not a valid label value, such as names longer than 63
+// characters, are omitted.
+func LabelsForCertificate(crt *cmapi.Certificate, revision *int) map[string]string {
+	labels := map[string]string{
+		cmapi.CertificateNameLabelKey: crt.Name,
+		cmapi.IssuerNameLabelKey:      crt.Spec.IssuerRef.Name,
+		cmapi.IssuerKindLabelKey:      apiutil.IssuerKind(crt.Spec.IssuerRef),
+	}
+	if revision != nil {
+		labels[cmapi.CertificateRevisionLabelKey] = strconv.Itoa(*revision)
+	}
+
+	for k, v := range labels {
+		if len(validation.IsValidLabelValue(v)) > 0 {
+			delete(labels, k)
+		}
+	}
+
+	return labels
+}
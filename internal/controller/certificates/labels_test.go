@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func Test_LabelsForCertificate(t *testing.T) {
+	tests := map[string]struct {
+		crt       *cmapi.Certificate
+		revision  *int
+		expLabels map[string]string
+	}{
+		"if revision is nil, expect all labels but the revision to be present": {
+			crt: gen.Certificate("test-certificate",
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "ClusterIssuer"}),
+			),
+			expLabels: map[string]string{
+				"cert-manager.io/certificate-name": "test-certificate",
+				"cert-manager.io/issuer-name":      "test-issuer",
+				"cert-manager.io/issuer-kind":      "ClusterIssuer",
+			},
+		},
+		"if revision is set, expect all labels to be present": {
+			crt: gen.Certificate("test-certificate",
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer"}),
+			),
+			revision: pointer.Int(3),
+			expLabels: map[string]string{
+				"cert-manager.io/certificate-name":     "test-certificate",
+				"cert-manager.io/certificate-revision": "3",
+				"cert-manager.io/issuer-name":          "test-issuer",
+				"cert-manager.io/issuer-kind":          "Issuer",
+			},
+		},
+		"if the Certificate name is not a valid label value, expect the name label to be omitted": {
+			crt: gen.Certificate(strings.Repeat("a", 64),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer"}),
+			),
+			revision: pointer.Int(1),
+			expLabels: map[string]string{
+				"cert-manager.io/certificate-revision": "1",
+				"cert-manager.io/issuer-name":          "test-issuer",
+				"cert-manager.io/issuer-kind":          "Issuer",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expLabels, LabelsForCertificate(test.crt, test.revision))
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"math/big"
 	"strings"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 	"sigs.k8s.io/structured-merge-diff/v4/value"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -117,7 +119,7 @@ func CurrentCertificateRequestNotValidForSpec(input Input) (string, string, bool
 		return currentSecretValidForSpec(input)
 	}
 
-	violations, err := certificates.RequestMatchesSpec(input.CurrentRevisionRequest, input.Certificate.Spec)
+	violations, err := certificates.RequestMatchesSpec(input.CurrentRevisionRequest, input.Certificate.Spec, input.IssuerCapabilities)
 	if err != nil {
 		// If parsing the request fails, we don't immediately trigger a re-issuance as
 		// the existing certificate stored in the Secret may still be valid/up to date.
@@ -171,6 +173,7 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 		notAfter := metav1.NewTime(x509cert.NotAfter)
 		crt := input.Certificate
 		renewalTime := certificates.RenewalTime(notBefore.Time, notAfter.Time, crt.Spec.RenewBefore)
+		renewalTime = certificates.AlignRenewalTime(crt, notBefore.Time, renewalTime)
 
 		renewIn := renewalTime.Time.Sub(c.Now())
 		if renewIn > 0 {
@@ -206,6 +209,56 @@ func CurrentCertificateHasExpired(c clock.Clock) Func {
 	}
 }
 
+// CurrentCertificateNotBackdated returns a policy function which checks that
+// the certificate currently stored in the Secret is valid for clients whose
+// clocks lag behind by the backdate requested by the Certificate's
+// `cert-manager.io/not-before-backdate` annotation. This is not the case
+// while a certificate issued without backdating, or by an issuer whose clock
+// is ahead, is younger than the backdate.
+func CurrentCertificateNotBackdated(c clock.Clock) Func {
+	return func(input Input) (string, string, bool) {
+		backdate, err := apiutil.NotBeforeBackdate(input.Certificate.Annotations, nil)
+		if err != nil || backdate == 0 {
+			// An invalid annotation causes the issuance to fail instead.
+			return "", "", false
+		}
+		cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			return InvalidCertificate, fmt.Sprintf("Failed to decode stored certificate: %v", err), true
+		}
+
+		if validFrom := cert.NotBefore.Add(backdate); validFrom.After(c.Now()) {
+			return NotBackdated, fmt.Sprintf("Certificate is valid from %s, so is not yet valid for clients whose clocks lag behind by %s", cert.NotBefore.Format(time.RFC1123), backdate), true
+		}
+		return "", "", false
+	}
+}
+
+// CurrentCertificateMarkedForEmergency is a policy function that checks
+// whether the certificate currently stored in the Secret has been marked for
+// emergency re-issuance, i.e. whether the Certificate's
+// `cert-manager.io/emergency` annotation is the serial number of that
+// certificate. Since the annotation names a specific certificate, it has no
+// effect once a new certificate has been issued.
+func CurrentCertificateMarkedForEmergency(input Input) (string, string, bool) {
+	serial := input.Certificate.Annotations[cmapi.EmergencyReissuanceAnnotationKey]
+	if serial == "" || input.Secret == nil {
+		return "", "", false
+	}
+	x509cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		// A missing or invalid certificate is re-issued by the trigger
+		// policy chain anyway.
+		return "", "", false
+	}
+	// Serial numbers are commonly written with colons between their bytes.
+	marked, ok := new(big.Int).SetString(strings.ReplaceAll(serial, ":", ""), 16)
+	if !ok || marked.Cmp(x509cert.SerialNumber) != 0 {
+		return "", "", false
+	}
+	return Emergency, fmt.Sprintf("Re-issuing certificate as the certificate with serial number %s has been marked for emergency re-issuance", serial), true
+}
+
 func formatIssuerRef(name, kind, group string) string {
 	if group == "" {
 		group = "cert-manager.io"
@@ -288,6 +341,7 @@ func SecretTemplateMismatchesSecretManagedFields(fieldManager string) Func {
 		}
 
 		baseAnnotations := internalcertificates.AnnotationsForCertificateSecret(input.Certificate, x509cert)
+		baseLabels := internalcertificates.LabelsForCertificate(input.Certificate, input.Certificate.Status.Revision)
 
 		managedLabels, managedAnnotations := sets.NewString(), sets.NewString()
 
@@ -329,6 +383,10 @@ func SecretTemplateMismatchesSecretManagedFields(fieldManager string) Func {
 		for k := range baseAnnotations {
 			managedAnnotations = managedAnnotations.Delete(k)
 		}
+		// Likewise for the base Labels.
+		for k := range baseLabels {
+			managedLabels = managedLabels.Delete(k)
+		}
 
 		// Check early for Secret Template being nil, and whether managed
 		// labels/annotations are not.
@@ -401,6 +459,51 @@ func SecretAdditionalOutputFormatsDataMismatch(input Input) (string, string, boo
 	return "", "", false
 }
 
+// SecretLabelsMismatchesCertificate validates that the Secret has the labels
+// which identify the Certificate, revision and issuer it was issued for.
+// Returns true (violation) if any of these labels are missing or incorrect.
+func SecretLabelsMismatchesCertificate(input Input) (string, string, bool) {
+	for k, v := range internalcertificates.LabelsForCertificate(input.Certificate, input.Certificate.Status.Revision) {
+		if input.Secret.Labels[k] != v {
+			return SecretLabelsMismatch, fmt.Sprintf("Secret label %q missing or incorrect value", k), true
+		}
+	}
+	return "", "", false
+}
+
+// SecretClientBundleDataMismatch validates that the Secret of a Certificate
+// with a ClientIdentity contains the client bundle.
+// Returns true (violation) if the Certificate has a ClientIdentity and the
+// client bundle in the Secret is missing or incorrect.
+func SecretClientBundleDataMismatch(input Input) (string, string, bool) {
+	if input.Certificate.Spec.ClientIdentity == nil {
+		return "", "", false
+	}
+	v, ok := input.Secret.Data[cmapi.CertificateClientBundleKey]
+	if !ok || !bytes.Equal(v, internalcertificates.OutputFormatCombinedPEM(
+		input.Secret.Data[corev1.TLSPrivateKeyKey],
+		input.Secret.Data[corev1.TLSCertKey],
+	)) {
+		return ClientBundleMismatch, "Certificate's ClientIdentity client bundle doesn't match Secret Data", true
+	}
+	return "", "", false
+}
+
+// SecretSMIMEBundleMissing validates that the Secret of an S/MIME Certificate
+// contains the PKCS#12 bundle. As the bundle is encrypted with a random salt,
+// only its presence can be checked.
+// Returns true (violation) if the Certificate is an S/MIME certificate and the
+// bundle is missing from the Secret.
+func SecretSMIMEBundleMissing(input Input) (string, string, bool) {
+	if input.Certificate.Spec.SMIME == nil {
+		return "", "", false
+	}
+	if len(input.Secret.Data[cmapi.CertificateSMIMEBundleKey]) == 0 {
+		return SMIMEBundleMissing, "Certificate's S/MIME bundle is missing from the Secret Data", true
+	}
+	return "", "", false
+}
+
 // SecretAdditionalOutputFormatsOwnerMismatch validates that the field manager
 // owns the correct Certificate's AdditionalOutputFormats in the Secret.
 // Returns true (violation) if:
@@ -18,6 +18,7 @@ package policies
 
 import (
 	"encoding/pem"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/stretchr/testify/assert"
@@ -253,7 +255,7 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				}}),
 			}},
 			reason:  RequestChanged,
-			message: "Fields on existing CertificateRequest resource not up to date: [spec.commonName]",
+			message: `Fields on existing CertificateRequest resource not up to date: [spec.commonName: expected "new.example.com", got "old.example.com"]`,
 			reissue: true,
 		},
 		"do nothing if CertificateRequest matches spec": {
@@ -965,6 +967,26 @@ func Test_SecretTemplateMismatchesSecretManagedFields(t *testing.T) {
 			expMessage:   "",
 			expViolation: false,
 		},
+		"if managed fields matches template and base cert-manager labels are present, should return false": {
+			tmpl: &cmapi.CertificateSecretTemplate{
+				Labels: map[string]string{"abc": "123"},
+			},
+			secretManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{
+					Raw: []byte(`{"f:metadata": {
+							"f:labels": {
+								"f:abc": {},
+								"f:cert-manager.io/certificate-name": {},
+								"f:cert-manager.io/issuer-name": {},
+								"f:cert-manager.io/issuer-kind": {}
+							}
+						}}`),
+				}},
+			},
+			expReason:    "",
+			expMessage:   "",
+			expViolation: false,
+		},
 		"if managed fields matches template and base cert-manager annotations are present with certificate data, should return false": {
 			tmpl: &cmapi.CertificateSecretTemplate{
 				Annotations: map[string]string{"foo1": "bar1", "foo2": "bar2"},
@@ -1297,6 +1319,265 @@ func Test_SecretAdditionalOutputFormatsDataMismatch(t *testing.T) {
 	}
 }
 
+func Test_SecretClientBundleDataMismatch(t *testing.T) {
+	cert := []byte("a")
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	bundle := append(append(pk, '\n'), cert...)
+	clientCrt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		ClientIdentity: &cmapi.CertificateClientIdentity{ServiceAccountName: "client"},
+	}}
+
+	tests := map[string]struct {
+		input        Input
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if the Certificate has no client identity, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{},
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk}},
+			},
+		},
+		"if the Certificate has a client identity and the Secret has no client bundle, should return true": {
+			input: Input{
+				Certificate: clientCrt,
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk}},
+			},
+			expReason:    "ClientBundleMismatch",
+			expMessage:   "Certificate's ClientIdentity client bundle doesn't match Secret Data",
+			expViolation: true,
+		},
+		"if the Certificate has a client identity and the Secret has a wrong client bundle, should return true": {
+			input: Input{
+				Certificate: clientCrt,
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "tls-client-bundle.pem": []byte("wrong")}},
+			},
+			expReason:    "ClientBundleMismatch",
+			expMessage:   "Certificate's ClientIdentity client bundle doesn't match Secret Data",
+			expViolation: true,
+		},
+		"if the Certificate has a client identity and the Secret has the correct client bundle, should return false": {
+			input: Input{
+				Certificate: clientCrt,
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "tls-client-bundle.pem": bundle}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretClientBundleDataMismatch(test.input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_CurrentCertificateMarkedForEmergency(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	certData := testcrypto.MustCreateCert(t, pk, gen.Certificate("something", gen.SetCertificateCommonName("example.com")))
+	cert, err := pki.DecodeX509CertificateBytes(certData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial := cert.SerialNumber.Text(16)
+	secret := &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: certData, corev1.TLSPrivateKeyKey: pk}}
+	marked := func(serial string) *cmapi.Certificate {
+		return gen.Certificate("something", gen.AddCertificateAnnotations(map[string]string{
+			cmapi.EmergencyReissuanceAnnotationKey: serial,
+		}))
+	}
+
+	tests := map[string]struct {
+		input        Input
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if the Certificate is not marked for emergency re-issuance, should return false": {
+			input: Input{Certificate: gen.Certificate("something"), Secret: secret},
+		},
+		"if the Certificate is marked with the serial number of the current certificate, should return true": {
+			input:        Input{Certificate: marked(serial), Secret: secret},
+			expReason:    Emergency,
+			expMessage:   "Re-issuing certificate as the certificate with serial number " + serial + " has been marked for emergency re-issuance",
+			expViolation: true,
+		},
+		"if the Certificate is marked with the colon separated, upper case serial number of the current certificate, should return true": {
+			input:        Input{Certificate: marked("00:" + strings.ToUpper(serial)), Secret: secret},
+			expReason:    Emergency,
+			expMessage:   "Re-issuing certificate as the certificate with serial number 00:" + strings.ToUpper(serial) + " has been marked for emergency re-issuance",
+			expViolation: true,
+		},
+		"if the Certificate is marked with the serial number of a previous certificate, should return false": {
+			input: Input{Certificate: marked("1234"), Secret: secret},
+		},
+		"if the Certificate is marked with an invalid serial number, should return false": {
+			input: Input{Certificate: marked("not-a-serial"), Secret: secret},
+		},
+		"if the Secret does not exist, should return false": {
+			input: Input{Certificate: marked(serial)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := CurrentCertificateMarkedForEmergency(test.input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_CurrentCertificateNotBackdated(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	certData := testcrypto.MustCreateCert(t, pk, gen.Certificate("something", gen.SetCertificateCommonName("example.com")))
+	cert, err := pki.DecodeX509CertificateBytes(certData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: certData, corev1.TLSPrivateKeyKey: pk}}
+	backdated := func(backdate string) *cmapi.Certificate {
+		return gen.Certificate("something", gen.AddCertificateAnnotations(map[string]string{
+			cmapi.NotBeforeBackdateAnnotationKey: backdate,
+		}))
+	}
+
+	tests := map[string]struct {
+		input        Input
+		now          time.Time
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if the Certificate does not request a backdate, should return false": {
+			input: Input{Certificate: gen.Certificate("something"), Secret: secret},
+			now:   cert.NotBefore,
+		},
+		"if the certificate is younger than the backdate, should return true": {
+			input:        Input{Certificate: backdated("5m"), Secret: secret},
+			now:          cert.NotBefore.Add(time.Minute),
+			expReason:    NotBackdated,
+			expMessage:   "Certificate is valid from " + cert.NotBefore.Format(time.RFC1123) + ", so is not yet valid for clients whose clocks lag behind by 5m0s",
+			expViolation: true,
+		},
+		"if the certificate is older than the backdate, should return false": {
+			input: Input{Certificate: backdated("5m"), Secret: secret},
+			now:   cert.NotBefore.Add(5 * time.Minute),
+		},
+		"if the backdate is invalid, should return false": {
+			input: Input{Certificate: backdated("not-a-duration"), Secret: secret},
+			now:   cert.NotBefore,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := CurrentCertificateNotBackdated(fakeclock.NewFakeClock(test.now))(test.input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretLabelsMismatchesCertificate(t *testing.T) {
+	crt := gen.Certificate("test-certificate",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "ClusterIssuer"}),
+		gen.SetCertificateRevision(2),
+	)
+	labels := map[string]string{
+		"cert-manager.io/certificate-name":     "test-certificate",
+		"cert-manager.io/certificate-revision": "2",
+		"cert-manager.io/issuer-name":          "ca-issuer",
+		"cert-manager.io/issuer-kind":          "ClusterIssuer",
+	}
+
+	tests := map[string]struct {
+		labels       map[string]string
+		expViolation bool
+	}{
+		"if the Secret has no labels, should return true": {
+			labels:       nil,
+			expViolation: true,
+		},
+		"if the Secret has a label with a wrong value, should return true": {
+			labels: map[string]string{
+				"cert-manager.io/certificate-name":     "test-certificate",
+				"cert-manager.io/certificate-revision": "1",
+				"cert-manager.io/issuer-name":          "ca-issuer",
+				"cert-manager.io/issuer-kind":          "ClusterIssuer",
+			},
+			expViolation: true,
+		},
+		"if the Secret has all labels, should return false": {
+			labels:       labels,
+			expViolation: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, _, gotViolation := SecretLabelsMismatchesCertificate(Input{
+				Certificate: crt,
+				Secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: test.labels}},
+			})
+			assert.Equal(t, test.expViolation, gotViolation)
+			if test.expViolation {
+				assert.Equal(t, SecretLabelsMismatch, gotReason)
+			}
+		})
+	}
+}
+
+func Test_SecretSMIMEBundleMissing(t *testing.T) {
+	smimeCrt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		EmailAddresses: []string{"alice@example.com"},
+		SMIME:          &cmapi.CertificateSMIME{},
+	}}
+
+	tests := map[string]struct {
+		input        Input
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if the Certificate is not an S/MIME certificate, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{},
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": []byte("a")}},
+			},
+		},
+		"if the Certificate is an S/MIME certificate and the Secret has no bundle, should return true": {
+			input: Input{
+				Certificate: smimeCrt,
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": []byte("a")}},
+			},
+			expReason:    "SMIMEBundleMissing",
+			expMessage:   "Certificate's S/MIME bundle is missing from the Secret Data",
+			expViolation: true,
+		},
+		"if the Certificate is an S/MIME certificate and the Secret has a bundle, should return false": {
+			input: Input{
+				Certificate: smimeCrt,
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": []byte("a"), "smime.p12": []byte("bundle")}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretSMIMEBundleMissing(test.input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
 func Test_SecretAdditionalOutputFormatsOwnerMismatch(t *testing.T) {
 	const fieldManager = "cert-manager-test"
 
@@ -44,14 +44,31 @@ const (
 	// Expired is a policy violation reason for a scenario where Certificate has
 	// expired.
 	Expired string = "Expired"
+	// NotBackdated is a policy violation reason for a scenario where the
+	// certificate is not yet valid for clients whose clocks lag behind by the
+	// backdate requested for the Certificate.
+	NotBackdated string = "NotBackdated"
+	// Emergency is a policy violation reason for a scenario where the
+	// certificate currently stored in the Secret has been marked for
+	// emergency re-issuance, e.g. because it has been revoked.
+	Emergency string = "Emergency"
 	// SecretTemplateMisMatch is a policy violation whereby the Certificate's
 	// SecretTemplate is not reflected on the target Secret, either by having
 	// extra, missing, or wrong Annotations or Labels.
 	SecretTemplateMismatch string = "SecretTemplateMismatch"
+	// SecretLabelsMismatch is a policy violation whereby the labels which
+	// identify the Certificate of a Secret are missing or wrong.
+	SecretLabelsMismatch string = "SecretLabelsMismatch"
 	// AdditionalOutputFormatsMismatch is a policy violation whereby the
 	// Certificate's AdditionalOutputFormats is not reflected on the target
 	// Secret, either by having extra, missing, or wrong values.
 	AdditionalOutputFormatsMismatch string = "AdditionalOutputFormatsMismatch"
+	// ClientBundleMismatch is a policy violation whereby the Secret of a
+	// Certificate with a ClientIdentity has a missing or wrong client bundle.
+	ClientBundleMismatch string = "ClientBundleMismatch"
+	// SMIMEBundleMissing is a policy violation whereby the Secret of an
+	// S/MIME Certificate has no PKCS#12 bundle.
+	SMIMEBundleMissing string = "SMIMEBundleMissing"
 	// ManagedFieldsParseError is a policy violation whereby cert-manager was
 	// unable to decode the managed fields on a resource.
 	ManagedFieldsParseError string = "ManagedFieldsParseError"
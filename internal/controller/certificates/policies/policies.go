@@ -40,6 +40,11 @@ type Input struct {
 	// Take a look at the gatherer package's documentation to see more about why
 	// we care about the "next" certificate request.
 	NextRevisionRequest *cmapi.CertificateRequest
+
+	// IssuerCapabilities are the current capabilities of the issuer of the
+	// certificate, used to check whether usages which were dropped from the
+	// certificate requests are now supported. Nil if they are not known.
+	IssuerCapabilities *cmapi.IssuerCapabilities
 }
 
 // A Func evaluates the given input data and decides whether a check has passed
@@ -86,6 +91,7 @@ func NewReadinessPolicyChain(c clock.Clock) Chain {
 		SecretPublicKeysDiffer,
 		CurrentCertificateRequestNotValidForSpec,
 		CurrentCertificateHasExpired(c),
+		CurrentCertificateNotBackdated(c),
 	}
 }
 
@@ -96,8 +102,11 @@ func NewSecretPostIssuancePolicyChain(ownerRefEnabled bool, fieldManager string)
 	return Chain{
 		SecretTemplateMismatchesSecret,
 		SecretTemplateMismatchesSecretManagedFields(fieldManager),
+		SecretLabelsMismatchesCertificate,
 		SecretAdditionalOutputFormatsDataMismatch,
 		SecretAdditionalOutputFormatsOwnerMismatch(fieldManager),
+		SecretClientBundleDataMismatch,
+		SecretSMIMEBundleMissing,
 		SecretOwnerReferenceManagedFieldMismatch(ownerRefEnabled, fieldManager),
 		SecretOwnerReferenceValueMismatch(ownerRefEnabled),
 	}
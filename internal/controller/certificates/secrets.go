@@ -31,7 +31,7 @@ import (
 // Certificate Secret's Annotations when issued. These annotations contain
 // information about the Issuer and Certificate.
 // If the X.509 certificate is not-nil, additional annotations will be added
-// relating to its Common Name and Subject Alternative Names.
+// relating to its Common Name, Subject Alternative Names and serial number.
 func AnnotationsForCertificateSecret(crt *cmapi.Certificate, certificate *x509.Certificate) map[string]string {
 	annotations := make(map[string]string)
 
@@ -46,6 +46,9 @@ func AnnotationsForCertificateSecret(crt *cmapi.Certificate, certificate *x509.C
 		annotations[cmapi.AltNamesAnnotationKey] = strings.Join(certificate.DNSNames, ",")
 		annotations[cmapi.IPSANAnnotationKey] = strings.Join(utilpki.IPAddressesToString(certificate.IPAddresses), ",")
 		annotations[cmapi.URISANAnnotationKey] = strings.Join(utilpki.URLsToString(certificate.URIs), ",")
+		if certificate.SerialNumber != nil {
+			annotations[cmapi.SerialNumberAnnotationKey] = certificate.SerialNumber.Text(16)
+		}
 	}
 
 	return annotations
@@ -19,6 +19,7 @@ package certificates
 import (
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"math/big"
 	"net"
 	"net/url"
 	"testing"
@@ -51,9 +52,10 @@ func Test_AnnotationsForCertificateSecret(t *testing.T) {
 				Subject: pkix.Name{
 					CommonName: "cert-manager",
 				},
-				DNSNames:    []string{"example.com", "cert-manager.io"},
-				IPAddresses: []net.IP{{1, 1, 1, 1}, {1, 2, 3, 4}},
-				URIs:        urls,
+				DNSNames:     []string{"example.com", "cert-manager.io"},
+				IPAddresses:  []net.IP{{1, 1, 1, 1}, {1, 2, 3, 4}},
+				URIs:         urls,
+				SerialNumber: big.NewInt(0xabc123),
 			},
 			expAnnotations: map[string]string{
 				"cert-manager.io/certificate-name": "test-certificate",
@@ -64,6 +66,7 @@ func Test_AnnotationsForCertificateSecret(t *testing.T) {
 				"cert-manager.io/alt-names":        "example.com,cert-manager.io",
 				"cert-manager.io/ip-sans":          "1.1.1.1,1.2.3.4",
 				"cert-manager.io/uri-sans":         "spiffe.io//cert-manager.io/test,spiffe.io//hello.world",
+				"cert-manager.io/serial-number":    "abc123",
 			},
 		},
 		"if pass non-nil certificate with only CommonName, expect all Annotations to be present": {
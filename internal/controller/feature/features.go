@@ -60,7 +60,8 @@ const (
 	LiteralCertificateSubject featuregate.Feature = "LiteralCertificateSubject"
 
 	// Alpha: v1.10
-	// StableCertificateRequestName will enable generation of CertificateRequest resources with a fixed name. The name of the CertificateRequest will be a function of Certificate resource name and its revision
+	// Beta: v1.11
+	// StableCertificateRequestName will enable generation of CertificateRequest resources with a fixed name. The name of the CertificateRequest will be a function of the Certificate resource name, its UID and its revision, so that a controller restart whilst a CertificateRequest is being created doesn't result in duplicate CertificateRequests for the same revision.
 	// This feature gate will disable auto-generated CertificateRequest name
 	// Github Issue: https://github.com/cert-manager/cert-manager/issues/4956
 	StableCertificateRequestName featuregate.Feature = "StableCertificateRequestName"
@@ -70,6 +71,64 @@ const (
 	// This feature will add BasicConstraints section with CA field defaulting to false; CA field will be set true if the Certificate resource spec has isCA as true
 	// Github Issue: https://github.com/cert-manager/cert-manager/issues/5539
 	UseCertificateRequestBasicConstraints featuregate.Feature = "UseCertificateRequestBasicConstraints"
+
+	// Alpha: v1.11
+	// DNS01OrphanRecordReaper enables a periodic job in the challenges controller
+	// that removes ACME DNS01 challenge TXT records which were left behind by a
+	// clean up that never completed (e.g. a Challenge whose finalizer was
+	// removed by hand), for DNS providers that are able to list records.
+	// Only records marked with an owner record by this installation of
+	// cert-manager are removed.
+	DNS01OrphanRecordReaper featuregate.Feature = "DNS01OrphanRecordReaper"
+
+	// Alpha: v1.11
+	// FakeIssuer enables the "fake" issuer type, which signs certificates
+	// using an ephemeral CA generated in memory, with optional artificial
+	// latency and failures. It is meant for CI pipelines and demos.
+	// This feature gate must be used together with the FakeIssuer webhook
+	// feature gate.
+	FakeIssuer featuregate.Feature = "FakeIssuer"
+
+	// Alpha: v1.11
+	// IssuerReferenceGrants allows Certificates and CertificateRequests to
+	// reference an Issuer in another namespace, if that namespace contains an
+	// IssuerReferenceGrant allowing it.
+	// This feature gate must be used together with the IssuerReferenceGrants
+	// webhook feature gate.
+	IssuerReferenceGrants featuregate.Feature = "IssuerReferenceGrants"
+
+	// Alpha: v1.11
+	// DelegateIssuer enables the "delegate" issuer type, which passes
+	// CertificateRequests on to one of several backing issuers chosen by the
+	// namespace labels or DNS names of the request.
+	// This feature gate must be used together with the DelegateIssuer
+	// webhook feature gate.
+	DelegateIssuer featuregate.Feature = "DelegateIssuer"
+
+	// Alpha: v1.11
+	// SplitIssuance enables the certificates-split-issuance controller, which
+	// issues the DNS names of a Certificate listed in its splitIssuances from
+	// other issuers, and removes them from the certificate requested from the
+	// Certificate's issuer.
+	// This feature gate must be used together with the SplitIssuance webhook
+	// feature gate.
+	SplitIssuance featuregate.Feature = "SplitIssuance"
+
+	// Alpha: v1.11
+	// PluggableKeyAlgorithms enables the private key algorithms registered
+	// with pki.RegisterKeyAlgorithm, such as the experimental MLDSA and
+	// CompositeMLDSA algorithms, when generating and using private keys.
+	// This feature gate must be used together with the
+	// PluggableKeyAlgorithms webhook feature gate.
+	PluggableKeyAlgorithms featuregate.Feature = "PluggableKeyAlgorithms"
+
+	// Alpha: v1.11
+	// OfflineIssuer enables the "offline" issuer type, for which
+	// CertificateRequests are exported and signed by a CA across an air gap,
+	// and completed once the signed certificates are imported.
+	// This feature gate must be used together with the OfflineIssuer
+	// webhook feature gate.
+	OfflineIssuer featuregate.Feature = "OfflineIssuer"
 )
 
 func init() {
@@ -86,6 +145,13 @@ var defaultCertManagerFeatureGates = map[featuregate.Feature]featuregate.Feature
 	AdditionalCertificateOutputFormats:               {Default: false, PreRelease: featuregate.Alpha},
 	ServerSideApply:                                  {Default: false, PreRelease: featuregate.Alpha},
 	LiteralCertificateSubject:                        {Default: false, PreRelease: featuregate.Alpha},
-	StableCertificateRequestName:                     {Default: false, PreRelease: featuregate.Alpha},
+	StableCertificateRequestName:                     {Default: true, PreRelease: featuregate.Beta},
 	UseCertificateRequestBasicConstraints:            {Default: false, PreRelease: featuregate.Alpha},
+	DNS01OrphanRecordReaper:                          {Default: false, PreRelease: featuregate.Alpha},
+	FakeIssuer:                                       {Default: false, PreRelease: featuregate.Alpha},
+	IssuerReferenceGrants:                            {Default: false, PreRelease: featuregate.Alpha},
+	DelegateIssuer:                                   {Default: false, PreRelease: featuregate.Alpha},
+	SplitIssuance:                                    {Default: false, PreRelease: featuregate.Alpha},
+	PluggableKeyAlgorithms:                           {Default: false, PreRelease: featuregate.Alpha},
+	OfflineIssuer:                                    {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,305 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package issuancehook calls the IssuanceHooks that apply to a
+// CertificateRequest before it is signed and after it has been issued.
+//
+// Each hook is sent a Request as a JSON encoded POST request. Pre-issuance
+// hooks must respond with a JSON encoded Response, which can deny the
+// CertificateRequest or override the duration and key usages of the
+// certificate which is signed. The response of post-issuance hooks is
+// ignored, other than its status code.
+package issuancehook
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// defaultTimeout is the timeout of calls to hooks which don't set
+// timeoutSeconds.
+const defaultTimeout = 10 * time.Second
+
+// Request is sent to IssuanceHooks.
+type Request struct {
+	// Phase is the phase of the hook that is called.
+	Phase cmapi.IssuanceHookPhase `json:"phase"`
+
+	Namespace          string `json:"namespace"`
+	CertificateRequest string `json:"certificateRequest"`
+
+	// Certificate is the name of the Certificate that the CertificateRequest
+	// was created for, if any.
+	Certificate string `json:"certificate,omitempty"`
+
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+
+	// The user that created the CertificateRequest.
+	Username string   `json:"username,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+
+	// The subject and SANs that were requested in the CSR.
+	CommonName     string   `json:"commonName,omitempty"`
+	DNSNames       []string `json:"dnsNames,omitempty"`
+	IPAddresses    []string `json:"ipAddresses,omitempty"`
+	URIs           []string `json:"uris,omitempty"`
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// The requested duration, usages and CA flag of the certificate,
+	// including any overrides of earlier pre-issuance hooks.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	Usages   []cmapi.KeyUsage `json:"usages,omitempty"`
+	IsCA     bool             `json:"isCA,omitempty"`
+
+	// The serial number and validity of the issued certificate. Only set for
+	// post-issuance hooks.
+	Serial    string       `json:"serial,omitempty"`
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+	NotAfter  *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// Response is returned by pre-issuance IssuanceHooks.
+type Response struct {
+	// Allowed is false if the CertificateRequest must not be signed.
+	Allowed bool `json:"allowed"`
+
+	// Reason explains why the CertificateRequest was denied.
+	Reason string `json:"reason,omitempty"`
+
+	// Duration, if set, overrides the duration of the certificate.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Usages, if set, overrides the key usages of the certificate.
+	Usages []cmapi.KeyUsage `json:"usages,omitempty"`
+}
+
+// DeniedError is returned by PreIssuance if a hook denied the
+// CertificateRequest.
+type DeniedError struct {
+	Hook   string
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("IssuanceHook %q denied the request: %s", e.Hook, e.Reason)
+}
+
+// Runner calls the IssuanceHooks that apply to CertificateRequests.
+type Runner struct {
+	lister cmlisters.IssuanceHookLister
+
+	// transport returns the transport used to call the given hook.
+	transport func(hook *cmapi.IssuanceHook) (http.RoundTripper, error)
+}
+
+// NewRunner returns a Runner which calls the IssuanceHooks in lister.
+func NewRunner(lister cmlisters.IssuanceHookLister) *Runner {
+	return &Runner{lister: lister, transport: transportFor}
+}
+
+// PreIssuance calls the pre-issuance hooks that apply to cr in order of their
+// name, and applies the overrides returned by the hooks to the spec of cr.
+// If a hook denies cr, a *DeniedError is returned. Errors calling hooks with
+// the Ignore failure policy are logged and otherwise ignored.
+func (r *Runner) PreIssuance(ctx context.Context, cr *cmapi.CertificateRequest) error {
+	hooks, err := r.hooksFor(cr, cmapi.IssuanceHookPhasePreIssuance)
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		var resp Response
+		if err := r.call(ctx, hook, requestFor(cr, cmapi.IssuanceHookPhasePreIssuance), &resp); err != nil {
+			if hook.Spec.FailurePolicy == cmapi.IssuanceHookFailurePolicyIgnore {
+				logf.FromContext(ctx).Error(err, "ignoring error calling pre-issuance hook", "hook", hook.Name)
+				continue
+			}
+			return fmt.Errorf("failed to call IssuanceHook %q: %w", hook.Name, err)
+		}
+
+		if !resp.Allowed {
+			return &DeniedError{Hook: hook.Name, Reason: resp.Reason}
+		}
+		if resp.Duration != nil {
+			cr.Spec.Duration = resp.Duration
+		}
+		if resp.Usages != nil {
+			cr.Spec.Usages = resp.Usages
+		}
+	}
+
+	return nil
+}
+
+// PostIssuance calls the post-issuance hooks that apply to cr, which must have
+// been issued. All hooks are called, even if calling one of them fails.
+func (r *Runner) PostIssuance(ctx context.Context, cr *cmapi.CertificateRequest) error {
+	hooks, err := r.hooksFor(cr, cmapi.IssuanceHookPhasePostIssuance)
+	if err != nil {
+		return err
+	}
+
+	req := requestFor(cr, cmapi.IssuanceHookPhasePostIssuance)
+	if cert, err := pki.DecodeX509CertificateBytes(cr.Status.Certificate); err == nil {
+		notBefore, notAfter := metav1.NewTime(cert.NotBefore), metav1.NewTime(cert.NotAfter)
+		req.Serial = cert.SerialNumber.Text(16)
+		req.NotBefore = &notBefore
+		req.NotAfter = &notAfter
+	}
+
+	var errs []error
+	for _, hook := range hooks {
+		if err := r.call(ctx, hook, req, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to call IssuanceHook %q: %w", hook.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// hooksFor returns the hooks of the given phase which apply to cr, sorted by
+// name.
+func (r *Runner) hooksFor(cr *cmapi.CertificateRequest, phase cmapi.IssuanceHookPhase) ([]*cmapi.IssuanceHook, error) {
+	all, err := r.lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var hooks []*cmapi.IssuanceHook
+	for _, hook := range all {
+		if hook.Spec.Phase != phase || !appliesToNamespace(hook, cr.Namespace) {
+			continue
+		}
+		hooks = append(hooks, hook)
+	}
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].Name < hooks[j].Name })
+	return hooks, nil
+}
+
+func appliesToNamespace(hook *cmapi.IssuanceHook, namespace string) bool {
+	if len(hook.Spec.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range hook.Spec.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// call sends req to hook, and decodes the response into resp if it is not
+// nil.
+func (r *Runner) call(ctx context.Context, hook *cmapi.IssuanceHook, req *Request, resp *Response) error {
+	transport, err := r.transport(hook)
+	if err != nil {
+		return err
+	}
+
+	timeout := defaultTimeout
+	if hook.Spec.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.Spec.TimeoutSeconds) * time.Second
+	}
+	client := &http.Client{Transport: transport, Timeout: timeout}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Spec.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, httpResp.Body)
+		return fmt.Errorf("unexpected status code %d", httpResp.StatusCode)
+	}
+	if resp == nil {
+		_, _ = io.Copy(io.Discard, httpResp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func requestFor(cr *cmapi.CertificateRequest, phase cmapi.IssuanceHookPhase) *Request {
+	req := &Request{
+		Phase:              phase,
+		Namespace:          cr.Namespace,
+		CertificateRequest: cr.Name,
+		Certificate:        cr.Annotations[cmapi.CertificateNameKey],
+		IssuerRef:          cr.Spec.IssuerRef,
+		Username:           cr.Spec.Username,
+		Groups:             cr.Spec.Groups,
+		Duration:           cr.Spec.Duration,
+		Usages:             cr.Spec.Usages,
+		IsCA:               cr.Spec.IsCA,
+	}
+
+	if csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request); err == nil {
+		req.CommonName = csr.Subject.CommonName
+		req.DNSNames = csr.DNSNames
+		req.IPAddresses = pki.IPAddressesToString(csr.IPAddresses)
+		req.URIs = pki.URLsToString(csr.URIs)
+		req.EmailAddresses = csr.EmailAddresses
+	}
+
+	return req
+}
+
+// transportFor returns a transport which trusts the CA bundle of hook, or the
+// system root certificates if it has none.
+func transportFor(hook *cmapi.IssuanceHook) (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(hook.Spec.CABundle) == 0 {
+		return transport, nil
+	}
+
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(hook.Spec.CABundle); !ok {
+		return nil, fmt.Errorf("no CA certificates could be loaded from the caBundle of IssuanceHook %q", hook.Name)
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return transport, nil
+}
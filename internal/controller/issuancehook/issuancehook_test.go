@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuancehook
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+)
+
+// hookServer is a TLS server which records the requests sent to it and
+// responds with the given response.
+type hookServer struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	requests []Request
+}
+
+func newHookServer(t *testing.T, status int, resp *Response) *hookServer {
+	s := &hookServer{}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		s.lock.Lock()
+		s.requests = append(s.requests, req)
+		s.lock.Unlock()
+
+		w.WriteHeader(status)
+		if resp != nil {
+			_ = json.NewEncoder(w).Encode(resp)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *hookServer) hook(name string, phase cmapi.IssuanceHookPhase) *cmapi.IssuanceHook {
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+	return &cmapi.IssuanceHook{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: cmapi.IssuanceHookSpec{
+			Phase:    phase,
+			URL:      s.URL,
+			CABundle: caBundle,
+		},
+	}
+}
+
+func newRunner(t *testing.T, hooks ...*cmapi.IssuanceHook) *Runner {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, hook := range hooks {
+		if err := indexer.Add(hook); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewRunner(cmlisters.NewIssuanceHookLister(indexer))
+}
+
+func newCertificateRequest() *cmapi.CertificateRequest {
+	return &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default-unit-test-ns", Name: "test-cr"},
+		Spec: cmapi.CertificateRequestSpec{
+			Duration: &metav1.Duration{Duration: 90 * 24 * time.Hour},
+			Usages:   []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+		},
+	}
+}
+
+func TestPreIssuanceOverrides(t *testing.T) {
+	duration := &metav1.Duration{Duration: 24 * time.Hour}
+	usages := []cmapi.KeyUsage{cmapi.UsageDigitalSignature}
+	first := newHookServer(t, http.StatusOK, &Response{Allowed: true, Duration: duration})
+	second := newHookServer(t, http.StatusOK, &Response{Allowed: true, Usages: usages})
+
+	runner := newRunner(t,
+		second.hook("b", cmapi.IssuanceHookPhasePreIssuance),
+		first.hook("a", cmapi.IssuanceHookPhasePreIssuance),
+	)
+	cr := newCertificateRequest()
+	if err := runner.PreIssuance(context.Background(), cr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(cr.Spec.Duration, duration) {
+		t.Errorf("expected duration to be overridden to %v, got %v", duration, cr.Spec.Duration)
+	}
+	if !reflect.DeepEqual(cr.Spec.Usages, usages) {
+		t.Errorf("expected usages to be overridden to %v, got %v", usages, cr.Spec.Usages)
+	}
+
+	// Hooks are called in order of their name, so the second hook sees the
+	// override of the first.
+	if len(second.requests) != 1 || !reflect.DeepEqual(second.requests[0].Duration, duration) {
+		t.Errorf("expected second hook to be called with the duration of the first, got %+v", second.requests)
+	}
+}
+
+func TestPreIssuanceDenied(t *testing.T) {
+	server := newHookServer(t, http.StatusOK, &Response{Allowed: false, Reason: "not allowed"})
+	runner := newRunner(t, server.hook("deny", cmapi.IssuanceHookPhasePreIssuance))
+
+	err := runner.PreIssuance(context.Background(), newCertificateRequest())
+	var denied *DeniedError
+	if !errors.As(err, &denied) {
+		t.Fatalf("expected DeniedError, got: %v", err)
+	}
+	if denied.Hook != "deny" || denied.Reason != "not allowed" {
+		t.Errorf("unexpected DeniedError: %+v", denied)
+	}
+}
+
+func TestPreIssuanceFailurePolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy    cmapi.IssuanceHookFailurePolicy
+		expectErr bool
+	}{
+		"default policy fails": {expectErr: true},
+		"Fail policy fails":    {policy: cmapi.IssuanceHookFailurePolicyFail, expectErr: true},
+		"Ignore policy":        {policy: cmapi.IssuanceHookFailurePolicyIgnore},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newHookServer(t, http.StatusInternalServerError, nil)
+			hook := server.hook("broken", cmapi.IssuanceHookPhasePreIssuance)
+			hook.Spec.FailurePolicy = test.policy
+
+			err := newRunner(t, hook).PreIssuance(context.Background(), newCertificateRequest())
+			if test.expectErr != (err != nil) {
+				t.Errorf("expected error=%t, got: %v", test.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestPreIssuanceUntrustedServer(t *testing.T) {
+	server := newHookServer(t, http.StatusOK, &Response{Allowed: true})
+	hook := server.hook("untrusted", cmapi.IssuanceHookPhasePreIssuance)
+	hook.Spec.CABundle = nil
+
+	if err := newRunner(t, hook).PreIssuance(context.Background(), newCertificateRequest()); err == nil {
+		t.Errorf("expected error calling hook with an untrusted certificate")
+	}
+}
+
+func TestHooksForPhaseAndNamespace(t *testing.T) {
+	post := newHookServer(t, http.StatusOK, nil)
+	pre := newHookServer(t, http.StatusOK, &Response{Allowed: false})
+	other := newHookServer(t, http.StatusOK, nil)
+
+	otherHook := other.hook("other-namespace", cmapi.IssuanceHookPhasePostIssuance)
+	otherHook.Spec.Namespaces = []string{"other"}
+	nsHook := post.hook("namespace", cmapi.IssuanceHookPhasePostIssuance)
+	nsHook.Spec.Namespaces = []string{"other", "default-unit-test-ns"}
+
+	runner := newRunner(t,
+		pre.hook("pre", cmapi.IssuanceHookPhasePreIssuance),
+		post.hook("all", cmapi.IssuanceHookPhasePostIssuance),
+		nsHook,
+		otherHook,
+	)
+	if err := runner.PostIssuance(context.Background(), newCertificateRequest()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pre.requests) != 0 {
+		t.Errorf("expected pre-issuance hook not to be called")
+	}
+	if len(other.requests) != 0 {
+		t.Errorf("expected hook for another namespace not to be called")
+	}
+	if len(post.requests) != 2 {
+		t.Fatalf("expected post-issuance hooks to be called twice, got %d", len(post.requests))
+	}
+	for _, req := range post.requests {
+		if req.Phase != cmapi.IssuanceHookPhasePostIssuance || req.CertificateRequest != "test-cr" {
+			t.Errorf("unexpected request: %+v", req)
+		}
+	}
+}
+
+func TestPostIssuanceErrors(t *testing.T) {
+	broken := newHookServer(t, http.StatusInternalServerError, nil)
+	working := newHookServer(t, http.StatusOK, nil)
+
+	runner := newRunner(t,
+		broken.hook("a", cmapi.IssuanceHookPhasePostIssuance),
+		working.hook("b", cmapi.IssuanceHookPhasePostIssuance),
+	)
+	if err := runner.PostIssuance(context.Background(), newCertificateRequest()); err == nil {
+		t.Errorf("expected error")
+	}
+	if len(working.requests) != 1 {
+		t.Errorf("expected all hooks to be called even if one fails")
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statuswriter provides a rate limited writer for the status of
+// cert-manager resources. During mass events, such as a CA rotation causing
+// thousands of Certificates to be re-issued at once, status writes are
+// throttled using a rate limiter that is separate from the rate limiter of the
+// rest of the controller, so that status updates do not starve other
+// requests to the API server.
+// Writes of the status of the same resource which wait for the rate limiter
+// at the same time are coalesced, so that only the latest is performed.
+package statuswriter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+)
+
+// WriteFunc writes the status of a resource using the given client.
+type WriteFunc func(ctx context.Context, client cmclient.Interface) error
+
+// Writer performs rate limited status writes.
+type Writer struct {
+	client  cmclient.Interface
+	limiter flowcontrol.RateLimiter
+
+	lock sync.Mutex
+	// pending holds the writes which are waiting for the rate limiter, keyed
+	// by the resource they write.
+	pending map[string]*write
+}
+
+// write is a status write which is waiting for the rate limiter.
+type write struct {
+	fn WriteFunc
+	// done is closed once the write has been performed, after which err
+	// holds its result.
+	done chan struct{}
+	err  error
+}
+
+// New returns a Writer which writes using the given client. If limiter is
+// nil, writes are performed straight away and only the rate limiter of the
+// client applies.
+func New(client cmclient.Interface, limiter flowcontrol.RateLimiter) *Writer {
+	return &Writer{
+		client:  client,
+		limiter: limiter,
+		pending: make(map[string]*write),
+	}
+}
+
+// Write calls fn to write the status of obj once the rate limiter of the
+// Writer allows it.
+// If another write of the status of obj is already waiting for the rate
+// limiter, fn replaces it, and both calls return the result of fn once it
+// has been performed. Status writes contain the complete status owned by the
+// caller, so only the latest of them needs to be sent to the API server.
+func (w *Writer) Write(ctx context.Context, obj metav1.Object, fn WriteFunc) error {
+	if w.limiter == nil {
+		return fn(ctx, w.client)
+	}
+
+	key := fmt.Sprintf("%T %s/%s", obj, obj.GetNamespace(), obj.GetName())
+	w.lock.Lock()
+	if p, ok := w.pending[key]; ok {
+		p.fn = fn
+		w.lock.Unlock()
+		select {
+		case <-p.done:
+			return p.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	p := &write{fn: fn, done: make(chan struct{})}
+	w.pending[key] = p
+	w.lock.Unlock()
+
+	err := w.limiter.Wait(ctx)
+
+	// Writes which arrive from now on wait for the rate limiter again, as
+	// this write may already have been sent.
+	w.lock.Lock()
+	delete(w.pending, key)
+	fn = p.fn
+	w.lock.Unlock()
+
+	if err == nil {
+		err = fn(ctx, w.client)
+	}
+	p.err = err
+	close(p.done)
+	return err
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statuswriter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+// blockingRateLimiter blocks Wait until a token is sent on tokens.
+type blockingRateLimiter struct {
+	flowcontrol.RateLimiter
+	waiting chan struct{}
+	tokens  chan struct{}
+}
+
+func (b *blockingRateLimiter) Wait(ctx context.Context) error {
+	b.waiting <- struct{}{}
+	select {
+	case <-b.tokens:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func certificate(name string) *cmapi.Certificate {
+	return &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name}}
+}
+
+func TestWriteWithoutLimiter(t *testing.T) {
+	client := cmfake.NewSimpleClientset()
+	w := New(client, nil)
+
+	var got cmclient.Interface
+	if err := w.Write(context.Background(), certificate("a"), func(_ context.Context, cl cmclient.Interface) error {
+		got = cl
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != client {
+		t.Errorf("expected write to be called with the client of the writer")
+	}
+}
+
+func TestWriteWaitsForLimiter(t *testing.T) {
+	limiter := &blockingRateLimiter{waiting: make(chan struct{}), tokens: make(chan struct{})}
+	w := New(cmfake.NewSimpleClientset(), limiter)
+
+	var lock sync.Mutex
+	called := false
+	errs := make(chan error, 1)
+	go func() {
+		errs <- w.Write(context.Background(), certificate("a"), func(context.Context, cmclient.Interface) error {
+			lock.Lock()
+			defer lock.Unlock()
+			called = true
+			return nil
+		})
+	}()
+	<-limiter.waiting
+
+	lock.Lock()
+	if called {
+		t.Errorf("expected write not to be performed before the rate limiter allows it")
+	}
+	lock.Unlock()
+
+	limiter.tokens <- struct{}{}
+	if err := <-errs; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Errorf("expected write to be performed")
+	}
+}
+
+func TestWriteContextCancelled(t *testing.T) {
+	limiter := &blockingRateLimiter{waiting: make(chan struct{}, 1), tokens: make(chan struct{})}
+	w := New(cmfake.NewSimpleClientset(), limiter)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := w.Write(ctx, certificate("a"), func(context.Context, cmclient.Interface) error {
+		t.Errorf("expected write not to be performed")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context cancelled error, got: %v", err)
+	}
+}
+
+func TestWriteCoalescesWritesOfTheSameResource(t *testing.T) {
+	limiter := &blockingRateLimiter{waiting: make(chan struct{}, 2), tokens: make(chan struct{})}
+	w := New(cmfake.NewSimpleClientset(), limiter)
+
+	var lock sync.Mutex
+	var written []string
+	record := func(name string) {
+		lock.Lock()
+		defer lock.Unlock()
+		written = append(written, name)
+	}
+	a1 := func(context.Context, cmclient.Interface) error { record("a-1"); return nil }
+	a2 := func(context.Context, cmclient.Interface) error { record("a-2"); return nil }
+	a3 := func(context.Context, cmclient.Interface) error { record("a-3"); return nil }
+	b1 := func(context.Context, cmclient.Interface) error { record("b-1"); return nil }
+
+	errs := make(chan error, 4)
+	go func() { errs <- w.Write(context.Background(), certificate("a"), a1) }()
+	<-limiter.waiting
+	go func() { errs <- w.Write(context.Background(), certificate("b"), b1) }()
+	<-limiter.waiting
+
+	// Writes of the status of a resource which is already waiting for the
+	// rate limiter replace the pending write, and don't wait themselves.
+	go func() { errs <- w.Write(context.Background(), certificate("a"), a2) }()
+	waitForPending(t, w, "a", a2)
+	go func() { errs <- w.Write(context.Background(), certificate("a"), a3) }()
+	waitForPending(t, w, "a", a3)
+
+	limiter.tokens <- struct{}{}
+	limiter.tokens <- struct{}{}
+	for i := 0; i < 4; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(written) != 2 || !contains(written, "a-3") || !contains(written, "b-1") {
+		t.Errorf("expected only the latest write of each resource to be performed, got: %v", written)
+	}
+}
+
+func TestWriteCoalescedReturnsError(t *testing.T) {
+	limiter := &blockingRateLimiter{waiting: make(chan struct{}, 1), tokens: make(chan struct{})}
+	w := New(cmfake.NewSimpleClientset(), limiter)
+
+	writeErr := errors.New("write failed")
+	latest := func(context.Context, cmclient.Interface) error { return writeErr }
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- w.Write(context.Background(), certificate("a"), func(context.Context, cmclient.Interface) error {
+			t.Errorf("expected the replaced write not to be performed")
+			return nil
+		})
+	}()
+	<-limiter.waiting
+	go func() { errs <- w.Write(context.Background(), certificate("a"), latest) }()
+	waitForPending(t, w, "a", latest)
+
+	limiter.tokens <- struct{}{}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; !errors.Is(err, writeErr) {
+			t.Errorf("expected the error of the performed write, got: %v", err)
+		}
+	}
+}
+
+// waitForPending waits until fn is the pending write of the Certificate
+// with the given name.
+func waitForPending(t *testing.T, w *Writer, name string, fn WriteFunc) {
+	t.Helper()
+	key := fmt.Sprintf("%T %s/%s", certificate(name), "testns", name)
+	for i := 0; i < 100; i++ {
+		w.lock.Lock()
+		p, ok := w.pending[key]
+		replaced := ok && reflect.ValueOf(p.fn).Pointer() == reflect.ValueOf(fn).Pointer()
+		w.lock.Unlock()
+		if replaced {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for the pending write of %s to be replaced", name)
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
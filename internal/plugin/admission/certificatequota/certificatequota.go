@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatequota
+
+// CertificateQuota is a plugin that rejects the creation of Certificates which
+// would exceed the `spec.maxCertificates` of any CertificateQuota in the
+// namespace of the Certificate.
+// Like the Kubernetes ResourceQuota admission controller, Certificates are
+// counted when they are created, so the limit may be exceeded by Certificates
+// that are created concurrently.
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "CertificateQuota"
+
+type certificateQuota struct {
+	*admission.Handler
+
+	cmClient cmclient.Interface
+}
+
+var _ admission.ValidationInterface = &certificateQuota{}
+var _ initializer.WantsExternalCMClientSet = &certificateQuota{}
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &certificateQuota{
+		Handler: admission.NewHandler(admissionv1.Create),
+	}
+}
+
+func (c *certificateQuota) Validate(ctx context.Context, request admissionv1.AdmissionRequest, _, _ runtime.Object) ([]string, error) {
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.RequestSubResource != "" {
+		return nil, nil
+	}
+
+	// Reading from the watch cache of the apiserver is good enough here, as
+	// the limit is not strictly enforced anyway.
+	listOpts := metav1.ListOptions{ResourceVersion: "0"}
+
+	quotas, err := c.cmClient.CertmanagerV1().CertificateQuotas(request.Namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CertificateQuotas: %w", err)
+	}
+	var limited bool
+	for _, quota := range quotas.Items {
+		if quota.Spec.MaxCertificates != nil {
+			limited = true
+			break
+		}
+	}
+	if !limited {
+		return nil, nil
+	}
+
+	crts, err := c.cmClient.CertmanagerV1().Certificates(request.Namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Certificates: %w", err)
+	}
+	for _, quota := range quotas.Items {
+		if max := quota.Spec.MaxCertificates; max != nil && int32(len(crts.Items)) >= *max {
+			return nil, field.Forbidden(field.NewPath("metadata", "namespace"),
+				fmt.Sprintf("exceeded CertificateQuota %q: namespace %q may contain at most %d Certificates", quota.Name, request.Namespace, *max))
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *certificateQuota) SetExternalCMClientSet(client cmclient.Interface) {
+	c.cmClient = client
+}
+
+func (c *certificateQuota) ValidateInitialization() error {
+	if c.cmClient == nil {
+		return fmt.Errorf("cert-manager client not set")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatequota
+
+import (
+	"context"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+func TestValidate(t *testing.T) {
+	crt := func(namespace, name string) *cmapi.Certificate {
+		return &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	quota := func(max *int32) *cmapi.CertificateQuota {
+		return &cmapi.CertificateQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "quota"},
+			Spec:       cmapi.CertificateQuotaSpec{MaxCertificates: max},
+		}
+	}
+	createCertificate := &admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Namespace: "testns",
+		RequestResource: &metav1.GroupVersionResource{
+			Group:    "cert-manager.io",
+			Resource: "certificates",
+		},
+	}
+
+	tests := map[string]struct {
+		req     *admissionv1.AdmissionRequest
+		objects []runtime.Object
+		expErr  bool
+	}{
+		"if the request is not for a Certificate, allow it": {
+			req: &admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Namespace: "testns",
+				RequestResource: &metav1.GroupVersionResource{
+					Group:    "cert-manager.io",
+					Resource: "issuers",
+				},
+			},
+			objects: []runtime.Object{quota(pointer.Int32(0))},
+		},
+		"if there are no CertificateQuotas in the namespace, allow it": {
+			req:     createCertificate,
+			objects: []runtime.Object{crt("testns", "a")},
+		},
+		"if the CertificateQuota does not limit the number of Certificates, allow it": {
+			req:     createCertificate,
+			objects: []runtime.Object{quota(nil), crt("testns", "a")},
+		},
+		"if the namespace contains fewer Certificates than the CertificateQuota allows, allow it": {
+			req:     createCertificate,
+			objects: []runtime.Object{quota(pointer.Int32(2)), crt("testns", "a"), crt("other", "b"), crt("other", "c")},
+		},
+		"if the namespace contains as many Certificates as the CertificateQuota allows, reject it": {
+			req:     createCertificate,
+			objects: []runtime.Object{quota(pointer.Int32(2)), crt("testns", "a"), crt("testns", "b")},
+			expErr:  true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPlugin().(*certificateQuota)
+			p.SetExternalCMClientSet(cmfake.NewSimpleClientset(test.objects...))
+
+			_, err := p.Validate(context.Background(), *test.req, nil, nil)
+			if test.expErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+		})
+	}
+}
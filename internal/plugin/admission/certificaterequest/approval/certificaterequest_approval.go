@@ -108,7 +108,13 @@ func (c *certificateRequestApproval) Validate(ctx context.Context, request admis
 		return nil, err
 	}
 
-	signerName := signerNameForAPIResource(cr.Spec.IssuerRef.Name, cr.Namespace, *apiResource)
+	// Issuers referenced in another namespace are approved for by users
+	// with permissions for that namespace.
+	namespace := cr.Namespace
+	if cr.Spec.IssuerRef.Namespace != "" {
+		namespace = cr.Spec.IssuerRef.Namespace
+	}
+	signerName := signerNameForAPIResource(cr.Spec.IssuerRef.Name, namespace, *apiResource)
 	if !isAuthorizedForSignerName(ctx, c.authorizer, userInfoForRequest(request), signerName) {
 		return nil, field.Forbidden(field.NewPath("status.conditions"),
 			fmt.Sprintf("user %q does not have permissions to set approved/denied conditions for issuer %v", request.UserInfo.Username, cr.Spec.IssuerRef))
@@ -113,7 +113,7 @@ func TestValidate(t *testing.T) {
 					return &metav1.APIGroupList{}, nil
 				}),
 			expErr: field.Forbidden(field.NewPath("spec.issuerRef"),
-				"referenced signer resource does not exist: {my-issuer Issuer example.io}"),
+				"referenced signer resource does not exist: {my-issuer Issuer example.io }"),
 		},
 		"if the CertificateRequest references a signer that the approver doesn't have permissions for, error": {
 			req: &admissionv1.AdmissionRequest{
@@ -159,7 +159,7 @@ func TestValidate(t *testing.T) {
 				decision:    authorizer.DecisionNoOpinion,
 			},
 			expErr: field.Forbidden(field.NewPath("status.conditions"),
-				`user "user-1" does not have permissions to set approved/denied conditions for issuer {my-issuer Issuer example.io}`),
+				`user "user-1" does not have permissions to set approved/denied conditions for issuer {my-issuer Issuer example.io }`),
 		},
 		"if the CertificateRequest references a signer that the approver has permissions for, return nil": {
 			req: &admissionv1.AdmissionRequest{
@@ -291,7 +291,7 @@ func TestValidate(t *testing.T) {
 				err: fmt.Errorf("authorizer error"),
 			},
 			expErr: field.Forbidden(field.NewPath("status.conditions"),
-				`user "user-1" does not have permissions to set approved/denied conditions for issuer {my-issuer Issuer example.io}`),
+				`user "user-1" does not have permissions to set approved/denied conditions for issuer {my-issuer Issuer example.io }`),
 		},
 	}
 
@@ -49,9 +49,14 @@ func Register(plugins *admission.Plugins) {
 var _ admission.ValidationInterface = &resourceValidation{}
 
 var certificateGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificates")
+var certificateQuotaGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificatequotas")
 var certificateRequestGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificaterequests")
 var issuerGVR = certmanagerv1.SchemeGroupVersion.WithResource("issuers")
 var clusterIssuerGVR = certmanagerv1.SchemeGroupVersion.WithResource("clusterissuers")
+var issuanceHookGVR = certmanagerv1.SchemeGroupVersion.WithResource("issuancehooks")
+var notifierGVR = certmanagerv1.SchemeGroupVersion.WithResource("notifiers")
+var renewalWindowGVR = certmanagerv1.SchemeGroupVersion.WithResource("renewalwindows")
+var issuerReferenceGrantGVR = certmanagerv1.SchemeGroupVersion.WithResource("issuerreferencegrants")
 var orderGVR = acmev1.SchemeGroupVersion.WithResource("orders")
 var challengeGVR = acmev1.SchemeGroupVersion.WithResource("challenges")
 
@@ -68,12 +73,17 @@ func newValidationPair(create validateCreateFunc, update validateUpdateFunc) val
 }
 
 var validationMapping = map[schema.GroupVersionResource]validationPair{
-	certificateGVR:        newValidationPair(cmvalidation.ValidateCertificate, cmvalidation.ValidateUpdateCertificate),
-	certificateRequestGVR: newValidationPair(cmvalidation.ValidateCertificateRequest, cmvalidation.ValidateUpdateCertificateRequest),
-	issuerGVR:             newValidationPair(cmvalidation.ValidateIssuer, cmvalidation.ValidateUpdateIssuer),
-	clusterIssuerGVR:      newValidationPair(cmvalidation.ValidateClusterIssuer, cmvalidation.ValidateUpdateClusterIssuer),
-	orderGVR:              newValidationPair(acmevalidation.ValidateOrder, acmevalidation.ValidateOrderUpdate),
-	challengeGVR:          newValidationPair(acmevalidation.ValidateChallenge, acmevalidation.ValidateChallengeUpdate),
+	certificateGVR:          newValidationPair(cmvalidation.ValidateCertificate, cmvalidation.ValidateUpdateCertificate),
+	certificateQuotaGVR:     newValidationPair(cmvalidation.ValidateCertificateQuota, cmvalidation.ValidateUpdateCertificateQuota),
+	certificateRequestGVR:   newValidationPair(cmvalidation.ValidateCertificateRequest, cmvalidation.ValidateUpdateCertificateRequest),
+	issuerGVR:               newValidationPair(cmvalidation.ValidateIssuer, cmvalidation.ValidateUpdateIssuer),
+	clusterIssuerGVR:        newValidationPair(cmvalidation.ValidateClusterIssuer, cmvalidation.ValidateUpdateClusterIssuer),
+	issuanceHookGVR:         newValidationPair(cmvalidation.ValidateIssuanceHook, cmvalidation.ValidateUpdateIssuanceHook),
+	notifierGVR:             newValidationPair(cmvalidation.ValidateNotifier, cmvalidation.ValidateUpdateNotifier),
+	renewalWindowGVR:        newValidationPair(cmvalidation.ValidateRenewalWindow, cmvalidation.ValidateUpdateRenewalWindow),
+	issuerReferenceGrantGVR: newValidationPair(cmvalidation.ValidateIssuerReferenceGrant, cmvalidation.ValidateUpdateIssuerReferenceGrant),
+	orderGVR:                newValidationPair(acmevalidation.ValidateOrder, acmevalidation.ValidateOrderUpdate),
+	challengeGVR:            newValidationPair(acmevalidation.ValidateChallenge, acmevalidation.ValidateChallengeUpdate),
 }
 
 func NewPlugin() admission.Interface {
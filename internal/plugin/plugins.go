@@ -18,6 +18,7 @@ package plugin
 
 import (
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/apideprecation"
+	"github.com/cert-manager/cert-manager/internal/plugin/admission/certificatequota"
 	certificaterequestapproval "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/approval"
 	certificaterequestidentity "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/identity"
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/resourcevalidation"
@@ -30,6 +31,7 @@ var AllOrderedPlugins = []string{
 	resourcevalidation.PluginName,
 	certificaterequestidentity.PluginName,
 	certificaterequestapproval.PluginName,
+	certificatequota.PluginName,
 }
 
 func RegisterAllPlugins(plugins *admission.Plugins) {
@@ -37,6 +39,7 @@ func RegisterAllPlugins(plugins *admission.Plugins) {
 	certificaterequestidentity.Register(plugins)
 	certificaterequestapproval.Register(plugins)
 	resourcevalidation.Register(plugins)
+	certificatequota.Register(plugins)
 }
 
 func DefaultOnAdmissionPlugins() sets.String {
@@ -45,6 +48,7 @@ func DefaultOnAdmissionPlugins() sets.String {
 		resourcevalidation.PluginName,
 		certificaterequestidentity.PluginName,
 		certificaterequestapproval.PluginName,
+		certificatequota.PluginName,
 	)
 }
 
@@ -0,0 +1,233 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package transparencylog publishes issued certificates to a transparency log
+// with a Rekor compatible API.
+//
+// Certificates are published as "hashedrekord" entries: the hash of the
+// to-be-signed part of the certificate together with the signature and the
+// certificate of its issuer. The log verifies the signature before accepting
+// the entry, so each entry proves that the issuer signed the certificate.
+package transparencylog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// entriesPath is the path of the Rekor API used to create log entries.
+const entriesPath = "/api/v1/log/entries"
+
+// Interface publishes certificates to a transparency log.
+type Interface interface {
+	// Publish adds cert, which has been signed by issuer, to the
+	// transparency log and returns the resulting entry. If the certificate
+	// has already been published, the existing entry is returned.
+	Publish(ctx context.Context, cert, issuer *x509.Certificate) (*cmapi.CertificateTransparencyLogEntry, error)
+}
+
+var _ Interface = &Client{}
+
+// Client publishes certificates to a Rekor compatible transparency log.
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// New returns a Client for the transparency log at the given base URL.
+func New(url string, client *http.Client) *Client {
+	return &Client{url: strings.TrimSuffix(url, "/"), client: client}
+}
+
+type hashedRekord struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Spec       hashedRekordSpec `json:"spec"`
+}
+
+type hashedRekordSpec struct {
+	Data struct {
+		Hash struct {
+			Algorithm string `json:"algorithm"`
+			Value     string `json:"value"`
+		} `json:"hash"`
+	} `json:"data"`
+	Signature struct {
+		Content   []byte `json:"content"`
+		PublicKey struct {
+			Content []byte `json:"content"`
+		} `json:"publicKey"`
+	} `json:"signature"`
+}
+
+// logEntry is a single entry as returned by the Rekor API, which returns
+// entries as a map keyed by their UUID.
+type logEntry struct {
+	IntegratedTime int64 `json:"integratedTime"`
+	LogIndex       int64 `json:"logIndex"`
+	Verification   *struct {
+		InclusionProof *struct {
+			Hashes   []string `json:"hashes"`
+			LogIndex int64    `json:"logIndex"`
+			RootHash string   `json:"rootHash"`
+			TreeSize int64    `json:"treeSize"`
+		} `json:"inclusionProof"`
+	} `json:"verification"`
+}
+
+func (c *Client) Publish(ctx context.Context, cert, issuer *x509.Certificate) (*cmapi.CertificateTransparencyLogEntry, error) {
+	alg, h, err := signatureHash(cert.SignatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(cert.RawTBSCertificate)
+
+	issuerPEM, err := pki.EncodeX509(issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	rekord := hashedRekord{APIVersion: "0.0.1", Kind: "hashedrekord"}
+	rekord.Spec.Data.Hash.Algorithm = alg
+	rekord.Spec.Data.Hash.Value = hex.EncodeToString(h.Sum(nil))
+	rekord.Spec.Signature.Content = cert.Signature
+	rekord.Spec.Signature.PublicKey.Content = issuerPEM
+
+	body, err := json.Marshal(rekord)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+entriesPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish certificate to transparency log: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated, http.StatusOK:
+	case http.StatusConflict:
+		// The certificate has already been published, so fetch the existing
+		// entry from the location returned by the log.
+		loc, err := resp.Location()
+		if err != nil {
+			return nil, fmt.Errorf("transparency log returned a conflict without the location of the existing entry: %w", err)
+		}
+		return c.get(ctx, loc, cert)
+	default:
+		return nil, unexpectedStatus(resp)
+	}
+
+	return c.decodeEntry(resp.Body, cert)
+}
+
+func (c *Client) get(ctx context.Context, loc *url.URL, cert *x509.Certificate) (*cmapi.CertificateTransparencyLogEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loc.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry from transparency log: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(resp)
+	}
+	return c.decodeEntry(resp.Body, cert)
+}
+
+func (c *Client) decodeEntry(r io.Reader, cert *x509.Certificate) (*cmapi.CertificateTransparencyLogEntry, error) {
+	var entries map[string]logEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode transparency log entry: %w", err)
+	}
+	if len(entries) != 1 {
+		return nil, fmt.Errorf("expected transparency log to return 1 entry, got %d", len(entries))
+	}
+
+	// Take the only entry of the map.
+	var uuid string
+	var e logEntry
+	for uuid, e = range entries {
+	}
+
+	fingerprint := sha256.Sum256(cert.Raw)
+	integratedTime := metav1.NewTime(time.Unix(e.IntegratedTime, 0))
+	entry := &cmapi.CertificateTransparencyLogEntry{
+		LogURL:         c.url,
+		UUID:           uuid,
+		LogIndex:       e.LogIndex,
+		Fingerprint:    hex.EncodeToString(fingerprint[:]),
+		IntegratedTime: &integratedTime,
+	}
+	if e.Verification != nil && e.Verification.InclusionProof != nil {
+		p := e.Verification.InclusionProof
+		entry.InclusionProof = &cmapi.CertificateTransparencyLogInclusionProof{
+			LogIndex: p.LogIndex,
+			RootHash: p.RootHash,
+			TreeSize: p.TreeSize,
+			Hashes:   p.Hashes,
+		}
+	}
+	return entry, nil
+}
+
+// signatureHash returns the hash function that was used to sign a
+// certificate with the given signature algorithm, and its name in the Rekor
+// API.
+func signatureHash(alg x509.SignatureAlgorithm) (string, hash.Hash, error) {
+	switch alg {
+	case x509.SHA256WithRSA, x509.SHA256WithRSAPSS, x509.ECDSAWithSHA256:
+		return "sha256", sha256.New(), nil
+	case x509.SHA384WithRSA, x509.SHA384WithRSAPSS, x509.ECDSAWithSHA384:
+		return "sha384", sha512.New384(), nil
+	case x509.SHA512WithRSA, x509.SHA512WithRSAPSS, x509.ECDSAWithSHA512:
+		return "sha512", sha512.New(), nil
+	default:
+		return "", nil, fmt.Errorf("certificates signed using %s cannot be published to a transparency log", alg)
+	}
+}
+
+func unexpectedStatus(resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("transparency log returned unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transparencylog
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const testEntry = `{"24296fb24b8ad77a": {"integratedTime": 1000, "logIndex": 7, "verification": {"inclusionProof": {"hashes": ["aa", "bb"], "logIndex": 6, "rootHash": "cc", "treeSize": 8}}}}`
+
+func TestPublish(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	certPEM := testcrypto.MustCreateCert(t, pk, gen.Certificate("test", gen.SetCertificateDNSNames("example.com")))
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conflict := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == entriesPath:
+			var rekord hashedRekord
+			if err := json.NewDecoder(r.Body).Decode(&rekord); err != nil {
+				t.Errorf("failed to decode entry: %v", err)
+			}
+			verifyRekord(t, rekord)
+			if conflict {
+				w.Header().Set("Location", entriesPath+"/24296fb24b8ad77a")
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, testEntry)
+		case r.Method == http.MethodGet && r.URL.Path == entriesPath+"/24296fb24b8ad77a":
+			fmt.Fprint(w, testEntry)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	fingerprint := sha256.Sum256(cert.Raw)
+	client := New(srv.URL+"/", srv.Client())
+
+	for _, conflict = range []bool{false, true} {
+		entry, err := client.Publish(context.Background(), cert, cert)
+		if err != nil {
+			t.Fatalf("unexpected error (conflict=%t): %v", conflict, err)
+		}
+		if entry.LogURL != srv.URL || entry.UUID != "24296fb24b8ad77a" || entry.LogIndex != 7 ||
+			entry.Fingerprint != hex.EncodeToString(fingerprint[:]) || entry.IntegratedTime.Unix() != 1000 {
+			t.Errorf("unexpected entry (conflict=%t): %+v", conflict, entry)
+		}
+		exp := cmapi.CertificateTransparencyLogInclusionProof{LogIndex: 6, RootHash: "cc", TreeSize: 8, Hashes: []string{"aa", "bb"}}
+		if !reflect.DeepEqual(entry.InclusionProof, &exp) {
+			t.Errorf("unexpected inclusion proof (conflict=%t): %+v", conflict, entry.InclusionProof)
+		}
+	}
+}
+
+func TestPublishError(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	certPEM := testcrypto.MustCreateCert(t, pk, gen.Certificate("test", gen.SetCertificateDNSNames("example.com")))
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid signature")
+	}))
+	defer srv.Close()
+
+	if _, err := New(srv.URL, srv.Client()).Publish(context.Background(), cert, cert); err == nil {
+		t.Errorf("expected an error if the log rejects the entry")
+	}
+}
+
+// verifyRekord checks that the signature of the entry can be verified using
+// the hash and public key of the entry, as the transparency log does.
+func verifyRekord(t *testing.T, rekord hashedRekord) {
+	t.Helper()
+
+	if rekord.Kind != "hashedrekord" || rekord.Spec.Data.Hash.Algorithm != "sha256" {
+		t.Errorf("unexpected entry kind %q or hash algorithm %q", rekord.Kind, rekord.Spec.Data.Hash.Algorithm)
+		return
+	}
+	issuer, err := pki.DecodeX509CertificateBytes(rekord.Spec.Signature.PublicKey.Content)
+	if err != nil {
+		t.Errorf("failed to decode public key of entry: %v", err)
+		return
+	}
+	digest, err := hex.DecodeString(rekord.Spec.Data.Hash.Value)
+	if err != nil {
+		t.Errorf("failed to decode hash of entry: %v", err)
+		return
+	}
+	if err := rsa.VerifyPKCS1v15(issuer.PublicKey.(*rsa.PublicKey), crypto.SHA256, digest, rekord.Spec.Signature.Content); err != nil {
+		t.Errorf("signature of entry could not be verified: %v", err)
+	}
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package fake
 
 import (
+	"math/big"
 	"time"
 
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -29,6 +30,8 @@ import (
 type Vault struct {
 	NewFn                           func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)
 	SignFn                          func([]byte, time.Duration) ([]byte, []byte, error)
+	RevokeFn                        func(*big.Int) error
+	TidyFn                          func(time.Duration) error
 	IsVaultInitializedAndUnsealedFn func() error
 }
 
@@ -38,6 +41,12 @@ func New() *Vault {
 		SignFn: func([]byte, time.Duration) ([]byte, []byte, error) {
 			return nil, nil, nil
 		},
+		RevokeFn: func(*big.Int) error {
+			return nil
+		},
+		TidyFn: func(time.Duration) error {
+			return nil
+		},
 		IsVaultInitializedAndUnsealedFn: func() error {
 			return nil
 		},
@@ -63,6 +72,28 @@ func (v *Vault) WithSign(certPEM, caPEM []byte, err error) *Vault {
 	return v
 }
 
+// Revoke implements `vault.Interface`.
+func (v *Vault) Revoke(serialNumber *big.Int) error {
+	return v.RevokeFn(serialNumber)
+}
+
+// WithRevoke sets the fake Vault's Revoke function.
+func (v *Vault) WithRevoke(f func(*big.Int) error) *Vault {
+	v.RevokeFn = f
+	return v
+}
+
+// Tidy implements `vault.Interface`.
+func (v *Vault) Tidy(safetyBuffer time.Duration) error {
+	return v.TidyFn(safetyBuffer)
+}
+
+// WithTidy sets the fake Vault's Tidy function.
+func (v *Vault) WithTidy(f func(time.Duration) error) *Vault {
+	v.TidyFn = f
+	return v
+}
+
 // WithNew sets the fake Vault's New function.
 func (v *Vault) WithNew(f func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)) *Vault {
 	v.NewFn = f
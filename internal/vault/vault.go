@@ -17,9 +17,11 @@ limitations under the License.
 package vault
 
 import (
+	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"path"
 	"path/filepath"
@@ -28,10 +30,12 @@ import (
 
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
+	corev1 "k8s.io/api/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/httpclient"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -47,6 +51,8 @@ type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister
 // Vault's certificate.
 type Interface interface {
 	Sign(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, err error)
+	Revoke(serialNumber *big.Int) error
+	Tidy(safetyBuffer time.Duration) error
 	IsVaultInitializedAndUnsealed() error
 }
 
@@ -134,9 +140,13 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 		return nil, nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
 	}
 
+	// Vault accepts both DNS names and email addresses as alt_names, which
+	// allows email-only certificates to be signed without a common name.
+	altNames := append(append([]string(nil), csr.DNSNames...), csr.EmailAddresses...)
+
 	parameters := map[string]string{
 		"common_name": csr.Subject.CommonName,
-		"alt_names":   strings.Join(csr.DNSNames, ","),
+		"alt_names":   strings.Join(altNames, ","),
 		"ip_sans":     strings.Join(pki.IPAddressesToString(csr.IPAddresses), ","),
 		"uri_sans":    strings.Join(pki.URLsToString(csr.URIs), ","),
 		"ttl":         duration.String(),
@@ -170,6 +180,82 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 	return extractCertificatesFromVaultCertificateSecret(&vaultResult)
 }
 
+// Revoke will connect to a Vault instance to revoke the certificate with the
+// given serial number, using the `revoke` endpoint of the PKI backend that
+// the issuer's path belongs to.
+func (v *Vault) Revoke(serialNumber *big.Int) error {
+	mount, err := pkiMountPath(v.issuer.GetSpec().Vault.Path)
+	if err != nil {
+		return err
+	}
+
+	parameters := map[string]string{
+		"serial_number": certutil.GetHexFormatted(serialNumber.Bytes(), ":"),
+	}
+
+	return v.postPKI(path.Join("/v1", mount, "revoke"), parameters)
+}
+
+// Tidy will connect to a Vault instance to remove expired certificates from
+// the storage and CRL of the PKI backend that the issuer's path belongs to.
+// Certificates which expired less than safetyBuffer ago are kept. If
+// safetyBuffer is 0, Vault's default is used.
+func (v *Vault) Tidy(safetyBuffer time.Duration) error {
+	mount, err := pkiMountPath(v.issuer.GetSpec().Vault.Path)
+	if err != nil {
+		return err
+	}
+
+	parameters := map[string]interface{}{
+		"tidy_cert_store":    true,
+		"tidy_revoked_certs": true,
+	}
+	if safetyBuffer > 0 {
+		parameters["safety_buffer"] = safetyBuffer.String()
+	}
+
+	return v.postPKI(path.Join("/v1", mount, "tidy"), parameters)
+}
+
+func (v *Vault) postPKI(url string, parameters interface{}) error {
+	request := v.client.NewRequest("POST", url)
+
+	if err := request.SetJSONBody(parameters); err != nil {
+		return fmt.Errorf("failed to build vault request: %s", err)
+	}
+
+	resp, err := v.client.RawRequest(request)
+	if err != nil {
+		return fmt.Errorf("error calling Vault %s: %w", url, err)
+	}
+
+	return resp.Body.Close()
+}
+
+// pkiMountPath returns the mount path of the PKI backend that the given
+// `sign` or `sign-verbatim` endpoint path belongs to, e.g. "my_pki_mount" for
+// "my_pki_mount/sign/my-role-name" or "my_pki_mount/issuer/my-issuer/sign/my-role-name".
+func pkiMountPath(signPath string) (string, error) {
+	segments := strings.Split(strings.Trim(signPath, "/"), "/")
+
+	end := -1
+	switch {
+	case len(segments) >= 3 && (segments[len(segments)-2] == "sign" || segments[len(segments)-2] == "sign-verbatim"):
+		end = len(segments) - 2
+	case len(segments) >= 2 && segments[len(segments)-1] == "sign-verbatim":
+		end = len(segments) - 1
+	default:
+		return "", fmt.Errorf("unable to determine the PKI mount path from %q: expected a `sign` or `sign-verbatim` endpoint", signPath)
+	}
+
+	// Signing with a specific issuer uses <mount>/issuer/<issuer_ref>/sign/<role>.
+	if end >= 3 && segments[end-2] == "issuer" {
+		end -= 2
+	}
+
+	return strings.Join(segments[:end], "/"), nil
+}
+
 func (v *Vault) setToken(client Client) error {
 	tokenRef := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	if tokenRef != nil {
@@ -203,13 +289,37 @@ func (v *Vault) setToken(client Client) error {
 		return nil
 	}
 
-	return fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set")
+	clientCertificate := v.issuer.GetSpec().Vault.Auth.ClientCertificate
+	if clientCertificate != nil {
+		token, err := v.requestTokenWithClientCertificate(client, clientCertificate)
+		if err != nil {
+			return err
+		}
+		client.SetToken(token)
+		return nil
+	}
+
+	return fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role or client certificate not set")
 }
 
 func (v *Vault) newConfig() (*vault.Config, error) {
 	cfg := vault.DefaultConfig()
 	cfg.Address = v.issuer.GetSpec().Vault.Server
 
+	httpClientConfig, err := httpclient.Load(v.issuer, v.namespace, v.secretsLister)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load HTTP client configuration: %w", err)
+	}
+	httpClientConfig.ApplyTo(cfg.HttpClient.Transport.(*http.Transport))
+
+	if clientCertificate := v.issuer.GetSpec().Vault.Auth.ClientCertificate; clientCertificate != nil {
+		cert, err := v.clientCertificate(clientCertificate.SecretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load vault client certificate: %w", err)
+		}
+		cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
 	caBundle, err := v.caBundle()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load vault CA bundle: %w", err)
@@ -269,6 +379,23 @@ func (v *Vault) caBundle() ([]byte, error) {
 	return certBytes, nil
 }
 
+// clientCertificate returns the certificate and private key stored in the
+// `tls.crt` and `tls.key` of the named Secret, which is typically the Secret
+// of a cert-manager Certificate.
+func (v *Vault) clientCertificate(secretName string) (tls.Certificate, error) {
+	secret, err := v.secretsLister.Secrets(v.namespace).Get(secretName)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("invalid client certificate in secret '%s/%s': %s", v.namespace, secretName, err)
+	}
+
+	return cert, nil
+}
+
 func (v *Vault) tokenRef(name, namespace, key string) (string, error) {
 	secret, err := v.secretsLister.Secrets(namespace).Get(name)
 	if err != nil {
@@ -415,6 +542,46 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 	return token, nil
 }
 
+// requestTokenWithClientCertificate logs in to the TLS Certificates auth
+// method. The client certificate itself is presented during the TLS handshake,
+// as configured by newConfig.
+func (v *Vault) requestTokenWithClientCertificate(client Client, clientCertificate *v1.VaultClientCertificateAuth) (string, error) {
+	parameters := map[string]string{
+		"name": clientCertificate.Name,
+	}
+
+	mountPath := clientCertificate.Path
+	if mountPath == "" {
+		mountPath = v1.DefaultVaultClientCertificateAuthMountPath
+	}
+
+	url := filepath.Join(mountPath, "login")
+	request := client.NewRequest("POST", url)
+	err := request.SetJSONBody(parameters)
+	if err != nil {
+		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+	}
+
+	resp, err := client.RawRequest(request)
+	if err != nil {
+		return "", fmt.Errorf("error calling Vault server: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+	vaultResult := vault.Secret{}
+	err = resp.DecodeJSON(&vaultResult)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	token, err := vaultResult.TokenID()
+	if err != nil {
+		return "", fmt.Errorf("unable to read token: %s", err.Error())
+	}
+
+	return token, nil
+}
+
 func extractCertificatesFromVaultCertificateSecret(secret *certutil.Secret) ([]byte, []byte, error) {
 	parsedBundle, err := certutil.ParsePKIMap(secret.Data)
 	if err != nil {
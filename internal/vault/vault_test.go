@@ -21,9 +21,11 @@ import (
 	"crypto"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -403,7 +405,7 @@ func TestSetToken(t *testing.T) {
 			fakeClient:    vaultfake.NewFakeClient(),
 			expectedToken: "",
 			expectedErr: errors.New(
-				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role or client certificate not set",
 			),
 		},
 
@@ -614,6 +616,48 @@ func TestSetToken(t *testing.T) {
 			expectedErr:   nil,
 		},
 
+		"if client certificate auth set but errors with a raw request should error": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					CABundle: []byte(testLeafCertificate),
+					Auth: cmapi.VaultAuth{
+						ClientCertificate: &cmapi.VaultClientCertificateAuth{
+							SecretName: "client-tls",
+						},
+					},
+				}),
+			),
+			fakeLister:    listers.FakeSecretListerFrom(listers.NewFakeSecretLister()),
+			fakeClient:    vaultfake.NewFakeClient().WithRawRequest(nil, errors.New("raw request error")),
+			expectedToken: "",
+			expectedErr:   errors.New("error calling Vault server: raw request error"),
+		},
+
+		"if client certificate auth set, return client using the token from the login": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					CABundle: []byte(testLeafCertificate),
+					Auth: cmapi.VaultAuth{
+						ClientCertificate: &cmapi.VaultClientCertificateAuth{
+							SecretName: "client-tls",
+							Name:       "my-cert-role",
+						},
+					},
+				}),
+			),
+			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister()),
+			fakeClient: vaultfake.NewFakeClient().WithRawRequest(&vault.Response{
+				Response: &http.Response{
+					Body: io.NopCloser(
+						strings.NewReader(
+							`{"request_id":"","lease_id":"","lease_duration":0,"renewable":false,"data":null,"warnings":null,"auth":{"client_token":"my-cert-token"}}`),
+					),
+				},
+			}, nil),
+			expectedToken: "my-cert-token",
+			expectedErr:   nil,
+		},
+
 		"if app role secret ref and token secret set, take preference on token secret": {
 			issuer: gen.Issuer("vault-issuer",
 				gen.SetIssuerVault(cmapi.VaultIssuer{
@@ -894,6 +938,20 @@ func TestNewConfig(t *testing.T) {
 			}
 		})
 	}
+	clientKey := generateRSAPrivateKey(t)
+	clientTemplate, err := pki.GenerateTemplate(gen.Certificate("client", gen.SetCertificateCommonName("client")))
+	require.NoError(t, err)
+	clientCertPEM, clientCert, err := pki.SignCertificate(clientTemplate, clientTemplate, clientKey.Public(), clientKey)
+	require.NoError(t, err)
+	clientCertificateSecretLister := listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+		listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       clientCertPEM,
+				corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(clientKey),
+			},
+		}, nil),
+	)
+
 	tests := map[string]testNewConfigT{
 		"no CA bundle set in issuer should return nil": {
 			issuer: gen.Issuer("vault-issuer",
@@ -1009,6 +1067,28 @@ func TestNewConfig(t *testing.T) {
 			},
 			fakeLister: caBundleSecretRefFakeSecretLister("test-namespace", "bundle", "ca.crt", testLeafCertificate),
 		},
+		"a client certificate should be added to the config": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					Auth: cmapi.VaultAuth{
+						ClientCertificate: &cmapi.VaultClientCertificateAuth{
+							SecretName: "client-tls",
+						},
+					},
+				}),
+			),
+			checkFunc: func(cfg *vault.Config, err error) error {
+				if err != nil {
+					return err
+				}
+				certs := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.Certificates
+				if len(certs) != 1 || !bytes.Equal(certs[0].Certificate[0], clientCert.Raw) {
+					return fmt.Errorf("expected the client certificate to be set in the config, got %d certificates", len(certs))
+				}
+				return nil
+			},
+			fakeLister: clientCertificateSecretLister,
+		},
 		"a bad bundle from a caBundleSecretRef should error": {
 			issuer: gen.Issuer("vault-issuer",
 				gen.SetIssuerVault(cmapi.VaultIssuer{
@@ -1356,3 +1436,100 @@ func TestSignIntegration(t *testing.T) {
 	require.NotEmpty(t, certPEM)
 	require.NotEmpty(t, caPEM)
 }
+
+func TestPKIMountPath(t *testing.T) {
+	tests := map[string]struct {
+		path      string
+		mount     string
+		expectErr bool
+	}{
+		"sign endpoint":                         {path: "my_pki_mount/sign/my-role-name", mount: "my_pki_mount"},
+		"sign endpoint with leading slash":      {path: "/pki/sign/role", mount: "pki"},
+		"nested mount":                          {path: "team/pki_int/sign/role", mount: "team/pki_int"},
+		"sign endpoint of a specific issuer":    {path: "pki/issuer/my-issuer/sign/role", mount: "pki"},
+		"sign-verbatim endpoint":                {path: "pki/sign-verbatim", mount: "pki"},
+		"sign-verbatim endpoint with a role":    {path: "pki/sign-verbatim/role", mount: "pki"},
+		"role named sign":                       {path: "pki/sign/sign", mount: "pki"},
+		"path which isn't a signing endpoint":   {path: "pki/issue/role", expectErr: true},
+		"path with too few segments to resolve": {path: "sign/role", expectErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mount, err := pkiMountPath(test.path)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.mount, mount)
+		})
+	}
+}
+
+// TestRevokeAndTidyIntegration demonstrates that Revoke and Tidy call the
+// endpoints of the PKI backend that the issuer's path belongs to.
+func TestRevokeAndTidyIntegration(t *testing.T) {
+	const (
+		vaultToken     = "token1"
+		vaultNamespace = "vault-ns-1"
+	)
+
+	var revokeBody, tidyBody map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/my_pki_mount/revoke", func(response http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, vaultNamespace, request.Header.Get("X-Vault-Namespace"), "Expected Vault namespace header for namespaced API path")
+		assert.Equal(t, vaultToken, request.Header.Get("X-Vault-Token"), "Expected the Vault token")
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&revokeBody))
+	})
+	mux.HandleFunc("/v1/my_pki_mount/tidy", func(response http.ResponseWriter, request *http.Request) {
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&tidyBody))
+		response.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	v, err := New(
+		"k8s-ns1",
+		listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+			listers.SetFakeSecretNamespaceListerGet(
+				&corev1.Secret{
+					Data: map[string][]byte{
+						"key1": []byte(vaultToken),
+					},
+				}, nil),
+		),
+		&cmapi.Issuer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "issuer1",
+				Namespace: "k8s-ns1",
+			},
+			Spec: v1.IssuerSpec{
+				IssuerConfig: v1.IssuerConfig{
+					Vault: &v1.VaultIssuer{
+						Server:    server.URL,
+						Path:      "my_pki_mount/sign/my-role-name",
+						Namespace: vaultNamespace,
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "secret1",
+								},
+								Key: "key1",
+							},
+						},
+					},
+				},
+			},
+		})
+	require.NoError(t, err)
+
+	require.NoError(t, v.Revoke(big.NewInt(0x1a2b3c)))
+	assert.Equal(t, map[string]interface{}{"serial_number": "1a:2b:3c"}, revokeBody)
+
+	require.NoError(t, v.Tidy(48*time.Hour))
+	assert.Equal(t, map[string]interface{}{
+		"tidy_cert_store":    true,
+		"tidy_revoked_certs": true,
+		"safety_buffer":      "48h0m0s",
+	}, tidyBody)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cert-manager/cert-manager/cmd/webhook/app/options"
+	internalcmapiv1 "github.com/cert-manager/cert-manager/internal/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// regionLabel is the well-known label set on Nodes by cloud providers to the
+// region the Node is running in.
+const regionLabel = "topology.kubernetes.io/region"
+
+var metadataVariable = regexp.MustCompile(`\$\{[A-Z_]+\}`)
+
+// clusterMetadata is the metadata of the cluster the webhook is running in,
+// used to expand the --default-* flags.
+type clusterMetadata struct {
+	// ClusterName is the value of the --cluster-name-label label on the
+	// kube-system Namespace.
+	ClusterName string
+
+	// CloudProvider is the scheme of the provider ID of the Nodes, such as
+	// aws, azure or gce.
+	CloudProvider string
+
+	// Region is the value of the topology.kubernetes.io/region label on the
+	// Nodes.
+	Region string
+}
+
+// discoverClusterMetadata discovers the metadata of the cluster from the
+// kube-system Namespace and the first Node of the cluster. Metadata which
+// cannot be found is left empty.
+func discoverClusterMetadata(ctx context.Context, cl kubernetes.Interface, clusterNameLabel string) (clusterMetadata, error) {
+	var md clusterMetadata
+
+	if len(clusterNameLabel) > 0 {
+		ns, err := cl.CoreV1().Namespaces().Get(ctx, metav1.NamespaceSystem, metav1.GetOptions{})
+		if err != nil {
+			return md, fmt.Errorf("error getting the %s namespace: %w", metav1.NamespaceSystem, err)
+		}
+		md.ClusterName = ns.Labels[clusterNameLabel]
+	}
+
+	nodes, err := cl.CoreV1().Nodes().List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return md, fmt.Errorf("error listing nodes: %w", err)
+	}
+	if len(nodes.Items) > 0 {
+		md.CloudProvider, md.Region = nodeMetadata(&nodes.Items[0])
+	}
+
+	return md, nil
+}
+
+// nodeMetadata returns the cloud provider and region of a Node. The cloud
+// provider is the scheme of the provider ID, e.g. "aws" for
+// aws:///eu-west-1a/i-0123456789abcdef0.
+func nodeMetadata(node *corev1.Node) (string, string) {
+	var provider string
+	if i := strings.Index(node.Spec.ProviderID, "://"); i > 0 {
+		provider = node.Spec.ProviderID[:i]
+	}
+	return provider, node.Labels[regionLabel]
+}
+
+// expandMetadataDefaults substitutes the ${CLUSTER_NAME}, ${CLOUD_PROVIDER}
+// and ${REGION} variables in the given default values. Values which
+// reference a variable which could not be discovered, or an unknown
+// variable, are dropped rather than defaulted to a partial value.
+func expandMetadataDefaults(log logr.Logger, md clusterMetadata, values []string) []string {
+	known := map[string]string{
+		"${CLUSTER_NAME}":   md.ClusterName,
+		"${CLOUD_PROVIDER}": md.CloudProvider,
+		"${REGION}":         md.Region,
+	}
+	var vars []string
+	for variable, value := range known {
+		vars = append(vars, variable, value)
+	}
+	replacer := strings.NewReplacer(vars...)
+
+	var expanded []string
+	for _, value := range values {
+		resolved := true
+		for _, variable := range metadataVariable.FindAllString(value, -1) {
+			if len(known[variable]) == 0 {
+				log.V(logf.WarnLevel).Info("dropping default value referencing unknown cluster metadata", "value", value, "variable", variable)
+				resolved = false
+				break
+			}
+		}
+		if resolved {
+			expanded = append(expanded, replacer.Replace(value))
+		}
+	}
+	return expanded
+}
+
+// setMetadataDefaults discovers the metadata of the cluster and sets the
+// defaults of Certificates and ClusterIssuers from the --default-* flags.
+// Nothing is discovered when no defaults are configured.
+func setMetadataDefaults(ctx context.Context, log logr.Logger, cl kubernetes.Interface, flags options.WebhookFlags) error {
+	if len(flags.DefaultSubjectOrganizations) == 0 && len(flags.DefaultSubjectCountries) == 0 && len(flags.DefaultDNSZones) == 0 {
+		return nil
+	}
+
+	md, err := discoverClusterMetadata(ctx, cl, flags.ClusterNameLabel)
+	if err != nil {
+		return fmt.Errorf("error discovering cluster metadata: %w", err)
+	}
+	log.V(logf.InfoLevel).Info("discovered cluster metadata", "cluster_name", md.ClusterName, "cloud_provider", md.CloudProvider, "region", md.Region)
+
+	internalcmapiv1.MetadataDefaults = internalcmapiv1.ClusterMetadataDefaults{
+		Organizations: expandMetadataDefaults(log, md, flags.DefaultSubjectOrganizations),
+		Countries:     expandMetadataDefaults(log, md, flags.DefaultSubjectCountries),
+		DNSZones:      expandMetadataDefaults(log, md, flags.DefaultDNSZones),
+	}
+	return nil
+}
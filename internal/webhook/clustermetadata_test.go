@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+func TestDiscoverClusterMetadata(t *testing.T) {
+	cl := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "kube-system",
+			Labels: map[string]string{"example.com/cluster-name": "prod-eu"},
+		}},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{regionLabel: "eu-west-1"}},
+			Spec:       corev1.NodeSpec{ProviderID: "aws:///eu-west-1a/i-0123456789abcdef0"},
+		},
+	)
+
+	md, err := discoverClusterMetadata(context.TODO(), cl, "example.com/cluster-name")
+	assert.NoError(t, err)
+	assert.Equal(t, clusterMetadata{ClusterName: "prod-eu", CloudProvider: "aws", Region: "eu-west-1"}, md)
+
+	md, err = discoverClusterMetadata(context.TODO(), fake.NewSimpleClientset(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, clusterMetadata{}, md)
+}
+
+func TestExpandMetadataDefaults(t *testing.T) {
+	md := clusterMetadata{ClusterName: "prod-eu", CloudProvider: "gce"}
+	values := []string{
+		"${CLUSTER_NAME}.${CLOUD_PROVIDER}.example.com",
+		"${REGION}.example.com",
+		"${UNKNOWN}.example.com",
+		"example.com",
+	}
+
+	assert.Equal(t, []string{"prod-eu.gce.example.com", "example.com"}, expandMetadataDefaults(logf.Log, md, values))
+}
@@ -43,6 +43,50 @@ const (
 	// This feature gate must be used together with LiteralCertificateSubject webhook feature gate.
 	// See https://github.com/cert-manager/cert-manager/issues/3203 and https://github.com/cert-manager/cert-manager/issues/4424 for context.
 	LiteralCertificateSubject featuregate.Feature = "LiteralCertificateSubject"
+
+	// Alpha: v1.11
+	// FakeIssuer allows Issuers and ClusterIssuers of the "fake" issuer type
+	// to be created. This feature gate must be used together with the
+	// FakeIssuer controller feature gate.
+	FakeIssuer featuregate.Feature = "FakeIssuer"
+
+	// Alpha: v1.11
+	// IssuerReferenceGrants allows the issuerRef of Certificates and
+	// CertificateRequests to reference an Issuer in another namespace. This
+	// feature gate must be used together with the IssuerReferenceGrants
+	// controller feature gate.
+	IssuerReferenceGrants featuregate.Feature = "IssuerReferenceGrants"
+
+	// Alpha: v1.11
+	// DelegateIssuer allows Issuers and ClusterIssuers of the "delegate"
+	// issuer type to be created. This feature gate must be used together
+	// with the DelegateIssuer controller feature gate.
+	DelegateIssuer featuregate.Feature = "DelegateIssuer"
+
+	// Alpha: v1.11
+	// SplitIssuance allows the splitIssuances field to be set on
+	// Certificates. This feature gate must be used together with the
+	// SplitIssuance controller feature gate.
+	SplitIssuance featuregate.Feature = "SplitIssuance"
+
+	// Alpha: v1.11
+	// CertificateSANTemplates substitutes the ${NAMESPACE},
+	// ${CLUSTER_DOMAIN} and ${SERVICE_NAME} variables in the dnsNames and
+	// uris of Certificates when they are defaulted.
+	CertificateSANTemplates featuregate.Feature = "CertificateSANTemplates"
+
+	// Alpha: v1.11
+	// PluggableKeyAlgorithms allows Certificates to request the
+	// experimental MLDSA and CompositeMLDSA private key algorithms. This
+	// feature gate must be used together with the PluggableKeyAlgorithms
+	// controller feature gate.
+	PluggableKeyAlgorithms featuregate.Feature = "PluggableKeyAlgorithms"
+
+	// Alpha: v1.11
+	// OfflineIssuer allows Issuers and ClusterIssuers of the "offline"
+	// issuer type to be created. This feature gate must be used together
+	// with the OfflineIssuer controller feature gate.
+	OfflineIssuer featuregate.Feature = "OfflineIssuer"
 )
 
 func init() {
@@ -59,4 +103,11 @@ func init() {
 var webhookFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AdditionalCertificateOutputFormats: {Default: false, PreRelease: featuregate.Alpha},
 	LiteralCertificateSubject:          {Default: false, PreRelease: featuregate.Alpha},
+	FakeIssuer:                         {Default: false, PreRelease: featuregate.Alpha},
+	IssuerReferenceGrants:              {Default: false, PreRelease: featuregate.Alpha},
+	DelegateIssuer:                     {Default: false, PreRelease: featuregate.Alpha},
+	SplitIssuance:                      {Default: false, PreRelease: featuregate.Alpha},
+	CertificateSANTemplates:            {Default: false, PreRelease: featuregate.Alpha},
+	PluggableKeyAlgorithms:             {Default: false, PreRelease: featuregate.Alpha},
+	OfflineIssuer:                      {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package webhook
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
 	metainstall "github.com/cert-manager/cert-manager/internal/apis/meta/install"
 	"github.com/cert-manager/cert-manager/internal/plugin"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
@@ -54,19 +56,34 @@ func WithConversionHandler(handler handlers.ConversionHook) func(*server.Server)
 
 // NewCertManagerWebhookServer creates a new webhook server configured with all cert-manager
 // resource types, validation, defaulting and conversion functions.
-func NewCertManagerWebhookServer(log logr.Logger, _ options.WebhookFlags, opts config.WebhookConfiguration, optionFunctions ...func(*server.Server)) (*server.Server, error) {
+func NewCertManagerWebhookServer(log logr.Logger, flags options.WebhookFlags, opts config.WebhookConfiguration, optionFunctions ...func(*server.Server)) (*server.Server, error) {
 	restcfg, err := clientcmd.BuildConfigFromFlags(opts.APIServerHost, opts.KubeConfig)
 	if err != nil {
 		return nil, err
 	}
+	if opts.KubernetesAPIQPS != nil {
+		restcfg.QPS = *opts.KubernetesAPIQPS
+	}
+	if opts.KubernetesAPIBurst != nil {
+		restcfg.Burst = *opts.KubernetesAPIBurst
+	}
 
 	cl, err := kubernetes.NewForConfig(restcfg)
 	if err != nil {
 		return nil, fmt.Errorf("error creating kubernetes client: %s", err)
 	}
 
+	if err := setMetadataDefaults(context.TODO(), log, cl, flags); err != nil {
+		return nil, err
+	}
+
+	cmClient, err := cmclient.NewForConfig(restcfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cert-manager client: %s", err)
+	}
+
 	// Set up the admission chain
-	admissionHandler, err := buildAdmissionChain(cl)
+	admissionHandler, err := buildAdmissionChain(cl, cmClient)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +93,7 @@ func NewCertManagerWebhookServer(log logr.Logger, _ options.WebhookFlags, opts c
 		HealthzAddr:       fmt.Sprintf(":%d", *opts.HealthzPort),
 		EnablePprof:       opts.EnablePprof,
 		PprofAddr:         opts.PprofAddress,
+		ShutdownDelay:     opts.ShutdownDelay.Duration,
 		CertificateSource: buildCertificateSource(log, opts.TLSConfig, restcfg),
 		CipherSuites:      opts.TLSConfig.CipherSuites,
 		MinTLSVersion:     opts.TLSConfig.MinTLSVersion,
@@ -89,7 +107,7 @@ func NewCertManagerWebhookServer(log logr.Logger, _ options.WebhookFlags, opts c
 	return s, nil
 }
 
-func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler, error) {
+func buildAdmissionChain(client kubernetes.Interface, cmClient cmclient.Interface) (*admission.RequestHandler, error) {
 	// Set up the admission chain
 	pluginHandler := admission.NewPlugins(Scheme)
 	plugin.RegisterAllPlugins(pluginHandler)
@@ -109,7 +127,7 @@ func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler
 	if err != nil {
 		return nil, fmt.Errorf("error creating authorization handler: %v", err)
 	}
-	pluginInitializer := initializer.New(client, nil, authorizer, nil)
+	pluginInitializer := initializer.New(client, cmClient, nil, authorizer, nil)
 	pluginChain, err := pluginHandler.NewFromPlugins(plugin.DefaultOnAdmissionPlugins().List(), pluginInitializer)
 	if err != nil {
 		return nil, fmt.Errorf("error building admission chain: %v", err)
@@ -2,7 +2,7 @@
 BASE_IMAGE_TYPE:=STATIC
 
 ARCHS = amd64 arm64 s390x ppc64le arm
-BINS = controller acmesolver cainjector webhook ctl
+BINS = controller acmesolver cainjector webhook ctl nodeagent tsa
 
 BASE_IMAGE_controller-linux-amd64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_amd64)
 BASE_IMAGE_controller-linux-arm64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm64)
@@ -22,12 +22,26 @@ BASE_IMAGE_acmesolver-linux-s390x:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_s390x)
 BASE_IMAGE_acmesolver-linux-ppc64le:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_ppc64le)
 BASE_IMAGE_acmesolver-linux-arm:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm)
 
+BASE_IMAGE_acmesolver-windows-amd64:=mcr.microsoft.com/windows/nanoserver:ltsc2022
+
 BASE_IMAGE_cainjector-linux-amd64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_amd64)
 BASE_IMAGE_cainjector-linux-arm64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm64)
 BASE_IMAGE_cainjector-linux-s390x:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_s390x)
 BASE_IMAGE_cainjector-linux-ppc64le:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_ppc64le)
 BASE_IMAGE_cainjector-linux-arm:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm)
 
+BASE_IMAGE_nodeagent-linux-amd64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_amd64)
+BASE_IMAGE_nodeagent-linux-arm64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm64)
+BASE_IMAGE_nodeagent-linux-s390x:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_s390x)
+BASE_IMAGE_nodeagent-linux-ppc64le:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_ppc64le)
+BASE_IMAGE_nodeagent-linux-arm:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm)
+
+BASE_IMAGE_tsa-linux-amd64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_amd64)
+BASE_IMAGE_tsa-linux-arm64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm64)
+BASE_IMAGE_tsa-linux-s390x:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_s390x)
+BASE_IMAGE_tsa-linux-ppc64le:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_ppc64le)
+BASE_IMAGE_tsa-linux-arm:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm)
+
 BASE_IMAGE_cmctl-linux-amd64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_amd64)
 BASE_IMAGE_cmctl-linux-arm64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm64)
 BASE_IMAGE_cmctl-linux-s390x:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_s390x)
@@ -35,7 +49,7 @@ BASE_IMAGE_cmctl-linux-ppc64le:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_ppc64le)
 BASE_IMAGE_cmctl-linux-arm:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm)
 
 .PHONY: all-containers
-all-containers: cert-manager-controller-linux cert-manager-webhook-linux cert-manager-acmesolver-linux cert-manager-cainjector-linux cert-manager-ctl-linux
+all-containers: cert-manager-controller-linux cert-manager-webhook-linux cert-manager-acmesolver-linux cert-manager-cainjector-linux cert-manager-ctl-linux cert-manager-nodeagent-linux cert-manager-tsa-linux
 
 .PHONY: cert-manager-controller-linux
 cert-manager-controller-linux: $(BINDIR)/containers/cert-manager-controller-linux-amd64.tar.gz $(BINDIR)/containers/cert-manager-controller-linux-arm64.tar.gz $(BINDIR)/containers/cert-manager-controller-linux-s390x.tar.gz $(BINDIR)/containers/cert-manager-controller-linux-ppc64le.tar.gz $(BINDIR)/containers/cert-manager-controller-linux-arm.tar.gz
@@ -76,6 +90,32 @@ $(BINDIR)/containers/cert-manager-cainjector-linux-amd64.tar $(BINDIR)/container
 		$(dir $<) >/dev/null
 	$(CTR) save $(TAG) -o $@ >/dev/null
 
+.PHONY: cert-manager-nodeagent-linux
+cert-manager-nodeagent-linux: $(BINDIR)/containers/cert-manager-nodeagent-linux-amd64.tar.gz $(BINDIR)/containers/cert-manager-nodeagent-linux-arm64.tar.gz $(BINDIR)/containers/cert-manager-nodeagent-linux-s390x.tar.gz $(BINDIR)/containers/cert-manager-nodeagent-linux-ppc64le.tar.gz $(BINDIR)/containers/cert-manager-nodeagent-linux-arm.tar.gz
+
+$(BINDIR)/containers/cert-manager-nodeagent-linux-amd64.tar $(BINDIR)/containers/cert-manager-nodeagent-linux-arm64.tar $(BINDIR)/containers/cert-manager-nodeagent-linux-s390x.tar $(BINDIR)/containers/cert-manager-nodeagent-linux-ppc64le.tar $(BINDIR)/containers/cert-manager-nodeagent-linux-arm.tar: $(BINDIR)/containers/cert-manager-nodeagent-linux-%.tar: $(BINDIR)/scratch/build-context/cert-manager-nodeagent-linux-%/nodeagent hack/containers/Containerfile.nodeagent $(BINDIR)/scratch/build-context/cert-manager-nodeagent-linux-%/cert-manager.license $(BINDIR)/scratch/build-context/cert-manager-nodeagent-linux-%/cert-manager.licenses_notice $(BINDIR)/release-version | $(BINDIR)/containers
+	@$(eval TAG := cert-manager-nodeagent-$*:$(RELEASE_VERSION))
+	@$(eval BASE := BASE_IMAGE_nodeagent-linux-$*)
+	$(CTR) build --quiet \
+		-f hack/containers/Containerfile.nodeagent \
+		--build-arg BASE_IMAGE=$($(BASE)) \
+		-t $(TAG) \
+		$(dir $<) >/dev/null
+	$(CTR) save $(TAG) -o $@ >/dev/null
+
+.PHONY: cert-manager-tsa-linux
+cert-manager-tsa-linux: $(BINDIR)/containers/cert-manager-tsa-linux-amd64.tar.gz $(BINDIR)/containers/cert-manager-tsa-linux-arm64.tar.gz $(BINDIR)/containers/cert-manager-tsa-linux-s390x.tar.gz $(BINDIR)/containers/cert-manager-tsa-linux-ppc64le.tar.gz $(BINDIR)/containers/cert-manager-tsa-linux-arm.tar.gz
+
+$(BINDIR)/containers/cert-manager-tsa-linux-amd64.tar $(BINDIR)/containers/cert-manager-tsa-linux-arm64.tar $(BINDIR)/containers/cert-manager-tsa-linux-s390x.tar $(BINDIR)/containers/cert-manager-tsa-linux-ppc64le.tar $(BINDIR)/containers/cert-manager-tsa-linux-arm.tar: $(BINDIR)/containers/cert-manager-tsa-linux-%.tar: $(BINDIR)/scratch/build-context/cert-manager-tsa-linux-%/tsa hack/containers/Containerfile.tsa $(BINDIR)/scratch/build-context/cert-manager-tsa-linux-%/cert-manager.license $(BINDIR)/scratch/build-context/cert-manager-tsa-linux-%/cert-manager.licenses_notice $(BINDIR)/release-version | $(BINDIR)/containers
+	@$(eval TAG := cert-manager-tsa-$*:$(RELEASE_VERSION))
+	@$(eval BASE := BASE_IMAGE_tsa-linux-$*)
+	$(CTR) build --quiet \
+		-f hack/containers/Containerfile.tsa \
+		--build-arg BASE_IMAGE=$($(BASE)) \
+		-t $(TAG) \
+		$(dir $<) >/dev/null
+	$(CTR) save $(TAG) -o $@ >/dev/null
+
 .PHONY: cert-manager-acmesolver-linux
 cert-manager-acmesolver-linux: $(BINDIR)/containers/cert-manager-acmesolver-linux-amd64.tar.gz $(BINDIR)/containers/cert-manager-acmesolver-linux-arm64.tar.gz $(BINDIR)/containers/cert-manager-acmesolver-linux-s390x.tar.gz $(BINDIR)/containers/cert-manager-acmesolver-linux-ppc64le.tar.gz $(BINDIR)/containers/cert-manager-acmesolver-linux-arm.tar.gz
 
@@ -89,6 +129,21 @@ $(BINDIR)/containers/cert-manager-acmesolver-linux-amd64.tar $(BINDIR)/container
 		$(dir $<) >/dev/null
 	$(CTR) save $(TAG) -o $@ >/dev/null
 
+.PHONY: cert-manager-acmesolver-windows
+cert-manager-acmesolver-windows: $(BINDIR)/containers/cert-manager-acmesolver-windows-amd64.tar.gz
+
+# Windows images can only contain COPY instructions when they're built on
+# Linux, so no packages are installed in the Windows acmesolver image.
+$(BINDIR)/containers/cert-manager-acmesolver-windows-amd64.tar: $(BINDIR)/scratch/build-context/cert-manager-acmesolver-windows-amd64/acmesolver.exe hack/containers/Containerfile.acmesolver-windows $(BINDIR)/scratch/build-context/cert-manager-acmesolver-windows-amd64/cert-manager.license $(BINDIR)/scratch/build-context/cert-manager-acmesolver-windows-amd64/cert-manager.licenses_notice $(BINDIR)/release-version | $(BINDIR)/containers
+	@$(eval TAG := cert-manager-acmesolver-windows-amd64:$(RELEASE_VERSION))
+	$(CTR) build --quiet \
+		--platform windows/amd64 \
+		-f hack/containers/Containerfile.acmesolver-windows \
+		--build-arg BASE_IMAGE=$(BASE_IMAGE_acmesolver-windows-amd64) \
+		-t $(TAG) \
+		$(dir $<) >/dev/null
+	$(CTR) save $(TAG) -o $@ >/dev/null
+
 .PHONY: cert-manager-ctl-linux
 cert-manager-ctl-linux: $(BINDIR)/containers/cert-manager-ctl-linux-amd64.tar.gz $(BINDIR)/containers/cert-manager-ctl-linux-arm64.tar.gz $(BINDIR)/containers/cert-manager-ctl-linux-s390x.tar.gz $(BINDIR)/containers/cert-manager-ctl-linux-ppc64le.tar.gz $(BINDIR)/containers/cert-manager-ctl-linux-arm.tar.gz
 
@@ -131,7 +186,13 @@ $(BINDIR)/scratch/build-context/cert-manager-%/cert-manager.license: $(BINDIR)/s
 $(BINDIR)/scratch/build-context/cert-manager-%/cert-manager.licenses_notice: $(BINDIR)/scratch/cert-manager.licenses_notice | $(BINDIR)/scratch/build-context/cert-manager-%
 	@ln -f $< $@
 
-$(BINDIR)/scratch/build-context/cert-manager-%/controller $(BINDIR)/scratch/build-context/cert-manager-%/acmesolver $(BINDIR)/scratch/build-context/cert-manager-%/cainjector $(BINDIR)/scratch/build-context/cert-manager-%/webhook: $(BINDIR)/server/% | $(BINDIR)/scratch/build-context/cert-manager-%
+$(BINDIR)/scratch/build-context/cert-manager-%/controller $(BINDIR)/scratch/build-context/cert-manager-%/acmesolver $(BINDIR)/scratch/build-context/cert-manager-%/cainjector $(BINDIR)/scratch/build-context/cert-manager-%/webhook $(BINDIR)/scratch/build-context/cert-manager-%/nodeagent $(BINDIR)/scratch/build-context/cert-manager-%/tsa: $(BINDIR)/server/% | $(BINDIR)/scratch/build-context/cert-manager-%
+	@ln -f $< $@
+
+$(BINDIR)/scratch/build-context/cert-manager-acmesolver-windows-amd64:
+	@mkdir -p $@
+
+$(BINDIR)/scratch/build-context/cert-manager-acmesolver-windows-amd64/acmesolver.exe: $(BINDIR)/server/acmesolver-windows-amd64.exe | $(BINDIR)/scratch/build-context/cert-manager-acmesolver-windows-amd64
 	@ln -f $< $@
 
 $(BINDIR)/scratch/build-context/cert-manager-ctl-%/ctl: $(BINDIR)/cmctl/cmctl-% | $(BINDIR)/scratch/build-context/cert-manager-ctl-%
@@ -0,0 +1,29 @@
+.PHONY: devenv
+## Create a local development environment in which ACME flows can be tested
+## fully offline. It creates a kind cluster if needed, installs cert-manager,
+## ingress-nginx and the Pebble ACME server, and creates the ClusterIssuers
+## "selfsigned", "ca" and "pebble". Pebble resolves every domain to
+## ingress-nginx, so the "pebble" ClusterIssuer can solve HTTP-01 challenges
+## for any domain.
+##
+##	make [KIND_CLUSTER_NAME=name] [K8S_VERSION=<kubernetes_version>] devenv
+##
+## @category Development
+devenv: devenv-pebble | $(NEEDS_GO)
+	$(GO) run ./hack/devenv
+	@printf "✅  \033[0;32mReady\033[0;0m. Certificates can now reference the above ClusterIssuers.\n" >&2
+
+# devenv-pebble installs Pebble together with challtestsrv, which answers the
+# DNS queries of Pebble with the address of ingress-nginx. It replaces the
+# Pebble installed by e2e-setup-pebble, which resolves domains using the
+# cluster DNS.
+.PHONY: devenv-pebble
+devenv-pebble: load-$(BINDIR)/downloaded/containers/$(CRI_ARCH)/pebble.tar e2e-setup-certmanager e2e-setup-ingressnginx $(BINDIR)/scratch/kind-exists | $(NEEDS_HELM)
+	$(HELM) upgrade \
+		--install \
+		--wait \
+		--namespace pebble \
+		--create-namespace \
+		--set challtestsrv.enabled=true \
+		--set challtestsrv.defaultIPv4=$(SERVICE_IP_PREFIX).15 \
+		pebble make/config/pebble/chart >/dev/null
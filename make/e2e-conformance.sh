@@ -0,0 +1,109 @@
+#!/usr/bin/env bash
+
+# Copyright 2022 The cert-manager Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# shellcheck disable=SC2059
+
+here=$(dirname "${BASH_SOURCE[0]}")
+source "$here/config/lib.sh"
+cd "$here/.." || exit 1
+set -e
+
+_default_bindir=$(make print-bindir)
+
+BINDIR=${BINDIR:-$_default_bindir}
+
+nodes=5
+feature_gates=
+artifacts="./$BINDIR/artifacts"
+help() {
+  cat <<EOF | color ""
+Runs the Certificate conformance tests against an issuer which already exists
+in the cluster of the current kubeconfig context, in which cert-manager is
+already installed. Nothing else is installed in the cluster.
+
+Usage:
+  ${bold}$(basename "$0") [--help] [args-for-ginkgo]${end}
+
+Examples:
+  ${bold}CONFORMANCE_ISSUER_NAME=my-ca $(basename "$0")${end}
+  ${bold}make e2e-conformance CONFORMANCE_ISSUER_NAME=my-ca CONFORMANCE_ISSUER_KIND=MyClusterIssuer CONFORMANCE_ISSUER_GROUP=example.com${end}
+
+Environment variables:
+  ${green}CONFORMANCE_ISSUER_NAME${end}
+      The name of the issuer to test. Required.
+  ${green}CONFORMANCE_ISSUER_KIND${end}
+      The kind of the issuer to test, which must be cluster scoped. Defaults
+      to ClusterIssuer.
+  ${green}CONFORMANCE_ISSUER_GROUP${end}
+      The API group of the issuer to test, if it is an external issuer.
+  ${green}CONFORMANCE_DOMAIN_SUFFIX${end}
+      The domain under which the DNS names of the requested certificates are
+      created. Defaults to example.com.
+  ${green}CONFORMANCE_UNSUPPORTED_FEATURES${end}
+      Comma separated list of the features that the issuer does not support,
+      whose tests are skipped, e.g. ${bold}IPAddresses,Ed25519,IssueCA${end}. See
+      test/e2e/framework/helper/featureset for the list of features.
+  ${green}NODES${end}
+      Ginkgo's parallelism. The default is $nodes.
+  ${green}FEATURE_GATES${end}
+      The feature gates that cert-manager is currently running with.
+  ${green}ARTIFACTS${end}
+      The path to a directory where the JUnit XML files will be stored. By
+      default, the JUnit XML files are saved to $artifacts
+EOF
+  exit 0
+}
+
+if [ $# -gt 0 ]; then
+  case "$1" in
+  -h | --help)
+    help
+    ;;
+  esac
+fi
+
+for v in FEATURE_GATES NODES ARTIFACTS; do
+  if printenv "$v" >/dev/null && [ -n "${!v}" ]; then
+    eval "$(tr '[:upper:]' '[:lower:]' <<<"$v")=\"${!v}\""
+  fi
+done
+
+if [[ -z "$CONFORMANCE_ISSUER_NAME" ]]; then
+  printf "${red}${redcross}Error${end}: CONFORMANCE_ISSUER_NAME must be set, see --help.\n" >&2
+  exit 1
+fi
+
+mkdir -p "$artifacts"
+
+export CGO_ENABLED=0
+
+trace ginkgo \
+  -tags=e2e_test \
+  -procs="$nodes" \
+  -output-dir="$artifacts" \
+  -junit-report="junit__conformance.xml" \
+  -timeout="24h" \
+  -v \
+  -randomize-all \
+  -progress \
+  -trace \
+  ./test/e2e/ \
+  -- \
+  --repo-root="$PWD" \
+  --report-dir="$artifacts" \
+  --feature-gates="$feature_gates" \
+  --ginkgo.focus='\[Conformance\] Certificates with issuer type Configured' \
+  "$@"
@@ -1,5 +1,5 @@
 .PHONY: server-binaries
-server-binaries: controller acmesolver webhook cainjector
+server-binaries: controller acmesolver webhook cainjector nodeagent tsa
 
 $(BINDIR)/server:
 	@mkdir -p $@
@@ -40,6 +40,14 @@ $(BINDIR)/server/acmesolver-linux-ppc64le: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/se
 $(BINDIR)/server/acmesolver-linux-arm: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
 	GOOS=linux GOARCH=arm GOARM=7 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/acmesolver/main.go
 
+# The acmesolver is also built for Windows, so that HTTP01 challenges can be
+# solved by solver pods scheduled on Windows nodes.
+.PHONY: acmesolver-windows
+acmesolver-windows: $(BINDIR)/server/acmesolver-windows-amd64.exe | $(NEEDS_GO) $(BINDIR)/server
+
+$(BINDIR)/server/acmesolver-windows-amd64.exe: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=windows GOARCH=amd64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/acmesolver/main.go
+
 .PHONY: webhook
 webhook: $(BINDIR)/server/webhook-linux-amd64 $(BINDIR)/server/webhook-linux-arm64 $(BINDIR)/server/webhook-linux-s390x $(BINDIR)/server/webhook-linux-ppc64le $(BINDIR)/server/webhook-linux-arm | $(NEEDS_GO) $(BINDIR)/server
 
@@ -75,3 +83,39 @@ $(BINDIR)/server/cainjector-linux-ppc64le: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/se
 
 $(BINDIR)/server/cainjector-linux-arm: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
 	GOOS=linux GOARCH=arm GOARM=7 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/cainjector/main.go
+
+.PHONY: nodeagent
+nodeagent: $(BINDIR)/server/nodeagent-linux-amd64 $(BINDIR)/server/nodeagent-linux-arm64 $(BINDIR)/server/nodeagent-linux-s390x $(BINDIR)/server/nodeagent-linux-ppc64le $(BINDIR)/server/nodeagent-linux-arm | $(NEEDS_GO) $(BINDIR)/server
+
+$(BINDIR)/server/nodeagent-linux-amd64: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=amd64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/nodeagent/main.go
+
+$(BINDIR)/server/nodeagent-linux-arm64: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=arm64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/nodeagent/main.go
+
+$(BINDIR)/server/nodeagent-linux-s390x: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=s390x $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/nodeagent/main.go
+
+$(BINDIR)/server/nodeagent-linux-ppc64le: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=ppc64le $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/nodeagent/main.go
+
+$(BINDIR)/server/nodeagent-linux-arm: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=arm GOARM=7 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/nodeagent/main.go
+
+.PHONY: tsa
+tsa: $(BINDIR)/server/tsa-linux-amd64 $(BINDIR)/server/tsa-linux-arm64 $(BINDIR)/server/tsa-linux-s390x $(BINDIR)/server/tsa-linux-ppc64le $(BINDIR)/server/tsa-linux-arm | $(NEEDS_GO) $(BINDIR)/server
+
+$(BINDIR)/server/tsa-linux-amd64: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=amd64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/tsa/main.go
+
+$(BINDIR)/server/tsa-linux-arm64: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=arm64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/tsa/main.go
+
+$(BINDIR)/server/tsa-linux-s390x: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=s390x $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/tsa/main.go
+
+$(BINDIR)/server/tsa-linux-ppc64le: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=ppc64le $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/tsa/main.go
+
+$(BINDIR)/server/tsa-linux-arm: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=arm GOARM=7 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/tsa/main.go
@@ -72,6 +72,19 @@ integration-test: setup-integration-tests | $(NEEDS_GOTESTSUM) $(NEEDS_ETCD) $(N
 e2e: $(BINDIR)/scratch/kind-exists | $(NEEDS_KUBECTL) $(NEEDS_GINKGO)
 	make/e2e.sh
 
+.PHONY: e2e-conformance
+## Run the Certificate conformance tests against an issuer which already
+## exists in the cluster of the current kubeconfig context. Unlike `make e2e`,
+## this does not need a kind cluster and can be run against a live cluster:
+##
+##     make e2e-conformance CONFORMANCE_ISSUER_NAME=my-ca
+##
+## For the other settings, see "make/e2e-conformance.sh --help".
+##
+## @category Development
+e2e-conformance: | $(NEEDS_GINKGO)
+	make/e2e-conformance.sh
+
 .PHONY: e2e-ci
 e2e-ci: e2e-setup-kind e2e-setup
 	make/e2e-ci.sh
@@ -24,11 +24,13 @@ import (
 	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
+	"k8s.io/client-go/util/flowcontrol"
 
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	"github.com/cert-manager/cert-manager/pkg/acme/client/middleware"
 	acmeutil "github.com/cert-manager/cert-manager/pkg/acme/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/httpclient"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
@@ -39,16 +41,24 @@ const (
 	defaultACMEHTTPTimeout = time.Second * 90
 )
 
+// faultInjection configures the injection of faults into the requests sent to
+// ACME servers. It is only ever set in binaries built with the
+// acme_fault_injection build tag, see faults.go.
+var faultInjection *acmecl.FaultInjectionConfig
+
 // NewClientFunc is a function type for building a new ACME client.
 type NewClientFunc func(*http.Client, cmacme.ACMEIssuer, *rsa.PrivateKey, string) acmecl.Interface
 
 var _ NewClientFunc = NewClient
 
 // NewClient is an implementation of NewClientFunc that returns a real ACME client.
+// The client supports requesting certificate profiles using
+// acmecl.WithOrderProfile, and records Retry-After headers of responses using
+// acmecl.WithRetryAfterRecorder.
 func NewClient(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, userAgent string) acmecl.Interface {
 	return middleware.NewLogger(&acmeapi.Client{
 		Key:          privateKey,
-		HTTPClient:   client,
+		HTTPClient:   acmecl.NewRetryAfterClient(acmecl.NewProfileClient(client, privateKey)),
 		DirectoryURL: config.Server,
 		UserAgent:    userAgent,
 		RetryBackoff: acmeutil.RetryBackoff,
@@ -63,20 +73,58 @@ func NewClient(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.Pr
 // In future, we may change to having two global HTTP clients - one that ignores
 // TLS connection errors, and the other that does not.
 func BuildHTTPClient(metrics *metrics.Metrics, skipTLSVerify bool) *http.Client {
+	return BuildHTTPClientWithConfig(metrics, skipTLSVerify, nil)
+}
+
+// BuildHTTPClientWithConfig returns an instrumented HTTP client to be used by
+// the ACME client, using the proxy and root CAs of the given issuer HTTP
+// client configuration. If httpClientConfig is nil, the proxy environment
+// variables and system root certificates are used.
+func BuildHTTPClientWithConfig(metrics *metrics.Metrics, skipTLSVerify bool, httpClientConfig *httpclient.Config) *http.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       &tls.Config{InsecureSkipVerify: skipTLSVerify},
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if httpClientConfig != nil {
+		httpClientConfig.ApplyTo(transport)
+	}
+
+	var rt http.RoundTripper = transport
+	if faultInjection != nil {
+		rt = acmecl.NewFaultInjectingTransport(rt, *faultInjection)
+	}
+
 	return acmecl.NewInstrumentedClient(metrics,
 		&http.Client{
-			Transport: &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
-				DialContext: (&net.Dialer{
-					Timeout:   30 * time.Second,
-					KeepAlive: 30 * time.Second,
-				}).DialContext,
-				TLSClientConfig:       &tls.Config{InsecureSkipVerify: skipTLSVerify},
-				MaxIdleConns:          100,
-				IdleConnTimeout:       90 * time.Second,
-				TLSHandshakeTimeout:   10 * time.Second,
-				ExpectContinueTimeout: 1 * time.Second,
-			},
-			Timeout: defaultACMEHTTPTimeout,
+			Transport: rt,
+			Timeout:   defaultACMEHTTPTimeout,
 		})
 }
+
+// NewRateLimitedTransport returns a http.RoundTripper which waits for the
+// given rate limiter before sending each request using next. The rate limiter
+// may be shared between transports to limit the rate of requests sent to all
+// ACME servers.
+func NewRateLimitedTransport(next http.RoundTripper, limiter flowcontrol.RateLimiter) http.RoundTripper {
+	return &rateLimitedTransport{next: next, limiter: limiter}
+}
+
+type rateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter flowcontrol.RateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,47 @@
+//go:build acme_fault_injection
+
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounts
+
+import (
+	"fmt"
+	"os"
+
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+)
+
+// FaultInjectionEnvVar is the environment variable which configures the
+// faults injected into the requests sent to ACME servers, in the format
+// accepted by acmecl.ParseFaultInjectionConfig, e.g.
+// "serverError=0.1,badNonce=0.2,rateLimited=0.05,seed=42".
+// It is only read by binaries built with the acme_fault_injection build tag,
+// so that faults can never be injected in production.
+const FaultInjectionEnvVar = "CERT_MANAGER_ACME_FAULT_INJECTION"
+
+func init() {
+	value, ok := os.LookupEnv(FaultInjectionEnvVar)
+	if !ok {
+		return
+	}
+
+	cfg, err := acmecl.ParseFaultInjectionConfig(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s: %v", FaultInjectionEnvVar, err))
+	}
+	faultInjection = &cfg
+}
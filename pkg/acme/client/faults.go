@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// This file implements a http.RoundTripper which injects ACME error responses
+// in place of a fraction of the requests sent to an ACME server. It is only
+// meant for testing how cert-manager retries and backs off when an ACME server
+// misbehaves, without having to depend on a flaky external CA.
+
+// FaultInjectionConfig configures the rate at which each kind of fault is
+// injected by a fault injecting transport. Each rate is the probability,
+// between 0 and 1, that a request fails with that fault. The sum of the rates
+// must not be greater than 1.
+type FaultInjectionConfig struct {
+	// ServerError is the rate of requests which fail with a 500 response
+	// and the serverInternal problem type.
+	ServerError float64
+	// BadNonce is the rate of POST requests which fail with a 400 response
+	// and the badNonce problem type. Other requests don't carry a nonce.
+	BadNonce float64
+	// RateLimited is the rate of requests which fail with a 429 response,
+	// the rateLimited problem type and a Retry-After header.
+	RateLimited float64
+	// Seed is the seed of the random number generator deciding which
+	// requests fail, so that a sequence of faults can be reproduced.
+	Seed int64
+}
+
+// ParseFaultInjectionConfig parses a fault injection configuration from a
+// comma separated list of key=value pairs, e.g.
+// "serverError=0.1,badNonce=0.2,rateLimited=0.05,seed=42".
+func ParseFaultInjectionConfig(s string) (FaultInjectionConfig, error) {
+	var cfg FaultInjectionConfig
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return cfg, fmt.Errorf("invalid fault injection setting %q, expected key=value", kv)
+		}
+
+		var err error
+		switch strings.TrimSpace(key) {
+		case "serverError":
+			cfg.ServerError, err = parseRate(value)
+		case "badNonce":
+			cfg.BadNonce, err = parseRate(value)
+		case "rateLimited":
+			cfg.RateLimited, err = parseRate(value)
+		case "seed":
+			cfg.Seed, err = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		default:
+			return cfg, fmt.Errorf("unknown fault injection setting %q", key)
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("invalid value for fault injection setting %q: %w", key, err)
+		}
+	}
+
+	if sum := cfg.ServerError + cfg.BadNonce + cfg.RateLimited; sum > 1 {
+		return cfg, fmt.Errorf("the sum of the fault injection rates must not be greater than 1, got %v", sum)
+	}
+	return cfg, nil
+}
+
+func parseRate(s string) (float64, error) {
+	rate, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("rate must be between 0 and 1, got %v", rate)
+	}
+	return rate, nil
+}
+
+// NewFaultInjectingTransport returns a http.RoundTripper which fails requests
+// with ACME error responses at the rates of the given configuration, and
+// sends all other requests using next.
+func NewFaultInjectingTransport(next http.RoundTripper, cfg FaultInjectionConfig) http.RoundTripper {
+	return &faultInjectingTransport{
+		next: next,
+		cfg:  cfg,
+		rand: rand.New(rand.NewSource(cfg.Seed)),
+	}
+}
+
+type faultInjectingTransport struct {
+	next http.RoundTripper
+	cfg  FaultInjectionConfig
+
+	// lock guards rand, which is not safe for concurrent use
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+func (t *faultInjectingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.lock.Lock()
+	r := t.rand.Float64()
+	t.lock.Unlock()
+
+	switch {
+	case r < t.cfg.ServerError:
+		return problemResponse(req, http.StatusInternalServerError, "serverInternal", "injected server error"), nil
+	case r < t.cfg.ServerError+t.cfg.RateLimited:
+		resp := problemResponse(req, http.StatusTooManyRequests, "rateLimited", "injected rate limit")
+		resp.Header.Set("Retry-After", "1")
+		return resp, nil
+	case req.Method == http.MethodPost && r < t.cfg.ServerError+t.cfg.RateLimited+t.cfg.BadNonce:
+		return problemResponse(req, http.StatusBadRequest, "badNonce", "injected bad nonce"), nil
+	}
+	return t.next.RoundTrip(req)
+}
+
+// problemResponse returns a response with the given status code, carrying an
+// RFC 7807 problem document of the given ACME error type.
+func problemResponse(req *http.Request, statusCode int, errorType, detail string) *http.Response {
+	// A RoundTripper must always close the request body, even if the
+	// request is not sent.
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	body := fmt.Sprintf(`{"type":"urn:ietf:params:acme:error:%s","detail":%q}`, errorType, detail)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/problem+json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFaultInjectionConfig(t *testing.T) {
+	tests := map[string]struct {
+		value  string
+		exp    FaultInjectionConfig
+		expErr bool
+	}{
+		"empty": {
+			value: "",
+		},
+		"all settings": {
+			value: "serverError=0.1, badNonce=0.2,rateLimited=0.05,seed=42",
+			exp:   FaultInjectionConfig{ServerError: 0.1, BadNonce: 0.2, RateLimited: 0.05, Seed: 42},
+		},
+		"missing value": {
+			value:  "serverError",
+			expErr: true,
+		},
+		"unknown setting": {
+			value:  "timeout=0.1",
+			expErr: true,
+		},
+		"rate out of range": {
+			value:  "badNonce=1.5",
+			expErr: true,
+		},
+		"rates summing to more than 1": {
+			value:  "serverError=0.5,badNonce=0.3,rateLimited=0.3",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := ParseFaultInjectionConfig(test.value)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.exp, cfg)
+		})
+	}
+}
+
+func TestFaultInjectingTransport(t *testing.T) {
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	})
+
+	tests := map[string]struct {
+		cfg           FaultInjectionConfig
+		method        string
+		expStatusCode int
+		expType       string
+	}{
+		"no faults": {
+			method:        http.MethodPost,
+			expStatusCode: http.StatusOK,
+		},
+		"server error": {
+			cfg:           FaultInjectionConfig{ServerError: 1},
+			method:        http.MethodGet,
+			expStatusCode: http.StatusInternalServerError,
+			expType:       "urn:ietf:params:acme:error:serverInternal",
+		},
+		"rate limited": {
+			cfg:           FaultInjectionConfig{RateLimited: 1},
+			method:        http.MethodGet,
+			expStatusCode: http.StatusTooManyRequests,
+			expType:       "urn:ietf:params:acme:error:rateLimited",
+		},
+		"bad nonce": {
+			cfg:           FaultInjectionConfig{BadNonce: 1},
+			method:        http.MethodPost,
+			expStatusCode: http.StatusBadRequest,
+			expType:       "urn:ietf:params:acme:error:badNonce",
+		},
+		"no bad nonce for requests without a nonce": {
+			cfg:           FaultInjectionConfig{BadNonce: 1},
+			method:        http.MethodGet,
+			expStatusCode: http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport := NewFaultInjectingTransport(next, test.cfg)
+			req, err := http.NewRequest(test.method, "https://acme.example.com/new-order", strings.NewReader("{}"))
+			require.NoError(t, err)
+
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, test.expStatusCode, resp.StatusCode)
+			if test.expType != "" {
+				assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Contains(t, string(body), `"type":"`+test.expType+`"`)
+			}
+		})
+	}
+}
+
+func TestFaultInjectingTransportIsReproducible(t *testing.T) {
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	})
+	cfg := FaultInjectionConfig{ServerError: 0.2, BadNonce: 0.2, RateLimited: 0.2, Seed: 42}
+
+	statusCodes := func() []int {
+		transport := NewFaultInjectingTransport(next, cfg)
+		var codes []int
+		for i := 0; i < 50; i++ {
+			req, err := http.NewRequest(http.MethodPost, "https://acme.example.com/new-order", nil)
+			require.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			codes = append(codes, resp.StatusCode)
+		}
+		return codes
+	}
+
+	first := statusCodes()
+	assert.Equal(t, first, statusCodes(), "expected the same seed to inject the same faults")
+	assert.Contains(t, first, http.StatusOK)
+	assert.Contains(t, first, http.StatusTooManyRequests)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// This file implements support for the ACME profiles extension
+// (draft-aaron-acme-profiles), which adds a 'profile' field to newOrder
+// requests.
+//
+// The ACME library does not allow adding fields to the newOrder request, so
+// the profile is instead added by a RoundTripper which rewrites the payload
+// of the signed request and re-signs it using the account key.
+
+type orderProfileKey struct{}
+
+// WithOrderProfile returns a copy of ctx which causes orders created using it
+// to request the given certificate profile, if the client's HTTP client was
+// built using NewProfileClient.
+func WithOrderProfile(ctx context.Context, profile string) context.Context {
+	return context.WithValue(ctx, orderProfileKey{}, profile)
+}
+
+// OrderProfileFromContext returns the certificate profile stored in ctx by
+// WithOrderProfile, or an empty string if none was stored.
+func OrderProfileFromContext(ctx context.Context) string {
+	profile, _ := ctx.Value(orderProfileKey{}).(string)
+	return profile
+}
+
+// ProfileTransport is a http.RoundTripper that adds the certificate profile
+// stored in a request's context to newOrder requests.
+type ProfileTransport struct {
+	key *rsa.PrivateKey
+
+	wrappedRT http.RoundTripper
+}
+
+// NewProfileClient takes a *http.Client and returns a copy of it that has its
+// RoundTripper wrapped with a ProfileTransport which signs rewritten requests
+// using the given ACME account key.
+// Unlike NewInstrumentedClient, the given client is not modified because it
+// may be shared by ACME clients using different account keys.
+func NewProfileClient(client *http.Client, key *rsa.PrivateKey) *http.Client {
+	// If next client is not defined we'll use http.DefaultClient.
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	profileClient := *client
+	if profileClient.Transport == nil {
+		profileClient.Transport = http.DefaultTransport
+	}
+
+	profileClient.Transport = &ProfileTransport{
+		key:       key,
+		wrappedRT: profileClient.Transport,
+	}
+
+	return &profileClient
+}
+
+// jsonWebSignature is a JWS in the flattened JSON serialization used by ACME.
+type jsonWebSignature struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// RoundTrip implements http.RoundTripper. If the request's context holds a
+// certificate profile and the request is a newOrder request, the profile is
+// added to the request payload before forwarding the request to the wrapped
+// RoundTripper. All other requests are forwarded unmodified.
+func (pt *ProfileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	profile := OrderProfileFromContext(req.Context())
+	if profile == "" || req.Method != http.MethodPost || req.Body == nil {
+		return pt.wrappedRT.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	newBody, err := pt.addProfile(body, profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add profile %q to ACME request: %w", profile, err)
+	}
+
+	// The RoundTripper must not modify the original request.
+	newReq := req.Clone(req.Context())
+	newReq.Body = io.NopCloser(bytes.NewReader(newBody))
+	newReq.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(newBody)), nil
+	}
+	newReq.ContentLength = int64(len(newBody))
+
+	return pt.wrappedRT.RoundTrip(newReq)
+}
+
+// addProfile returns the given JWS request body with the profile added to its
+// payload and signed again, if the payload is a newOrder request. Otherwise
+// the body is returned unmodified.
+func (pt *ProfileTransport) addProfile(body []byte, profile string) ([]byte, error) {
+	var jws jsonWebSignature
+	if err := json.Unmarshal(body, &jws); err != nil {
+		return nil, err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return nil, err
+	}
+	// POST-as-GET requests have an empty payload.
+	if len(payload) == 0 {
+		return body, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		// Not a JSON object, so cannot be a newOrder request.
+		return body, nil
+	}
+	// newOrder is the only request with an 'identifiers' field.
+	if _, ok := fields["identifiers"]; !ok {
+		return body, nil
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		return nil, err
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != "RS256" {
+		return nil, fmt.Errorf("unsupported JWS algorithm %q", header.Algorithm)
+	}
+
+	fields["profile"], err = json.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	payload, err = json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	jws.Payload = base64.RawURLEncoding.EncodeToString(payload)
+
+	hash := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, pt.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	jws.Signature = base64.RawURLEncoding.EncodeToString(sig)
+
+	return json.Marshal(jws)
+}
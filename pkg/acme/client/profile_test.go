@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func signJWS(t *testing.T, key *rsa.PrivateKey, payload string) []byte {
+	jws := jsonWebSignature{
+		Protected: base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"https://example.com/acct/1","nonce":"abc","url":"https://example.com/new-order"}`)),
+		Payload:   base64.RawURLEncoding.EncodeToString([]byte(payload)),
+	}
+	hash := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	jws.Signature = base64.RawURLEncoding.EncodeToString(sig)
+	body, err := json.Marshal(jws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestProfileTransport(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newOrderPayload := `{"identifiers":[{"type":"dns","value":"example.com"}]}`
+	tests := map[string]struct {
+		profile string
+		payload string
+
+		expectModified bool
+	}{
+		"newOrder request with a profile has the profile added": {
+			profile:        "shortlived",
+			payload:        newOrderPayload,
+			expectModified: true,
+		},
+		"newOrder request without a profile is not modified": {
+			payload: newOrderPayload,
+		},
+		"POST-as-GET request with a profile is not modified": {
+			profile: "shortlived",
+			payload: "",
+		},
+		"other request with a profile is not modified": {
+			profile: "shortlived",
+			payload: `{"csr":"abc"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body := signJWS(t, key, test.payload)
+
+			var sentBody []byte
+			client := NewProfileClient(&http.Client{
+				Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					var err error
+					sentBody, err = io.ReadAll(req.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(&bytes.Buffer{})}, nil
+				}),
+			}, key)
+
+			ctx := context.Background()
+			if test.profile != "" {
+				ctx = WithOrderProfile(ctx, test.profile)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://example.com/new-order", bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if !test.expectModified {
+				if !bytes.Equal(body, sentBody) {
+					t.Errorf("expected request body to not be modified, got %s", sentBody)
+				}
+				return
+			}
+
+			var jws jsonWebSignature
+			if err := json.Unmarshal(sentBody, &jws); err != nil {
+				t.Fatal(err)
+			}
+			payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields struct {
+				Identifiers []struct {
+					Type  string `json:"type"`
+					Value string `json:"value"`
+				} `json:"identifiers"`
+				Profile string `json:"profile"`
+			}
+			if err := json.Unmarshal(payload, &fields); err != nil {
+				t.Fatal(err)
+			}
+			if fields.Profile != test.profile {
+				t.Errorf("expected profile %q, got %q", test.profile, fields.Profile)
+			}
+			if len(fields.Identifiers) != 1 || fields.Identifiers[0].Value != "example.com" {
+				t.Errorf("expected identifiers to be preserved, got %s", payload)
+			}
+
+			sig, err := base64.RawURLEncoding.DecodeString(jws.Signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hash := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+			if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], sig); err != nil {
+				t.Errorf("expected rewritten request to be signed by the account key: %v", err)
+			}
+		})
+	}
+}
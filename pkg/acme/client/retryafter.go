@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// This file implements recording of the Retry-After headers returned by ACME
+// servers, so that callers polling orders, authorizations and finalization can
+// wait as long as the server asks them to before sending another request.
+//
+// The ACME library does not expose the headers of successful responses, so the
+// header is instead recorded by a RoundTripper into a RetryAfterRecorder stored
+// in the request's context.
+
+// maxRetryAfter caps the delay requested by an ACME server, so that a
+// misbehaving server cannot stall processing indefinitely.
+const maxRetryAfter = time.Hour
+
+type retryAfterKey struct{}
+
+// RetryAfterRecorder records the longest delay requested by the Retry-After
+// headers of ACME server responses.
+type RetryAfterRecorder struct {
+	lock       sync.Mutex
+	retryAfter time.Duration
+}
+
+// WithRetryAfterRecorder returns a copy of ctx and a RetryAfterRecorder which
+// records the Retry-After headers of responses to requests made using the
+// returned context, if the client's HTTP client was built using
+// NewRetryAfterClient.
+func WithRetryAfterRecorder(ctx context.Context) (context.Context, *RetryAfterRecorder) {
+	r := &RetryAfterRecorder{}
+	return context.WithValue(ctx, retryAfterKey{}, r), r
+}
+
+// RetryAfter returns the longest delay requested by a Retry-After header since
+// the recorder was created, or 0 if no response contained the header.
+func (r *RetryAfterRecorder) RetryAfter() time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.retryAfter
+}
+
+// RecordRetryAfter records the given delay into the RetryAfterRecorder stored
+// in ctx by WithRetryAfterRecorder. It does nothing if ctx holds no recorder.
+func RecordRetryAfter(ctx context.Context, d time.Duration) {
+	r, ok := ctx.Value(retryAfterKey{}).(*RetryAfterRecorder)
+	if !ok {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if d > r.retryAfter {
+		r.retryAfter = d
+	}
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date, into the delay it requests relative to
+// now. It returns false if the value cannot be parsed.
+// Delays are capped at one hour and dates in the past result in no delay.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	var d time.Duration
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		if secs > int64(maxRetryAfter/time.Second) {
+			return maxRetryAfter, true
+		}
+		d = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		d = t.Sub(now)
+	} else {
+		return 0, false
+	}
+
+	if d < 0 {
+		return 0, true
+	}
+	if d > maxRetryAfter {
+		return maxRetryAfter, true
+	}
+	return d, true
+}
+
+// RetryAfterTransport is a http.RoundTripper that records the Retry-After
+// header of responses into the RetryAfterRecorder stored in the request's
+// context.
+type RetryAfterTransport struct {
+	clock clock.PassiveClock
+
+	wrappedRT http.RoundTripper
+}
+
+// NewRetryAfterClient takes a *http.Client and returns a copy of it that has
+// its RoundTripper wrapped with a RetryAfterTransport.
+func NewRetryAfterClient(client *http.Client) *http.Client {
+	return NewRetryAfterClientWithClock(client, clock.RealClock{})
+}
+
+// NewRetryAfterClientWithClock is like NewRetryAfterClient, but Retry-After
+// headers holding an HTTP date are converted to a delay relative to the time
+// of the given clock.
+func NewRetryAfterClientWithClock(client *http.Client, clk clock.PassiveClock) *http.Client {
+	// If next client is not defined we'll use http.DefaultClient.
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	retryAfterClient := *client
+	if retryAfterClient.Transport == nil {
+		retryAfterClient.Transport = http.DefaultTransport
+	}
+
+	retryAfterClient.Transport = &RetryAfterTransport{
+		clock:     clk,
+		wrappedRT: retryAfterClient.Transport,
+	}
+
+	return &retryAfterClient
+}
+
+// RoundTrip implements http.RoundTripper. It forwards the request to the
+// wrapped RoundTripper and records the Retry-After header of the response
+// into the RetryAfterRecorder stored in the request's context, if any.
+func (rt *RetryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.wrappedRT.RoundTrip(req)
+	if resp == nil {
+		return resp, err
+	}
+
+	if d, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), rt.clock.Now()); ok {
+		RecordRetryAfter(req.Context(), d)
+	}
+
+	return resp, err
+}
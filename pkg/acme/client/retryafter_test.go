@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		value string
+		exp   time.Duration
+		expOK bool
+	}{
+		"empty": {
+			value: "",
+		},
+		"seconds": {
+			value: "120",
+			exp:   2 * time.Minute,
+			expOK: true,
+		},
+		"negative seconds": {
+			value: "-1",
+		},
+		"seconds are capped": {
+			value: "86400",
+			exp:   time.Hour,
+			expOK: true,
+		},
+		"http date": {
+			value: "Sat, 01 Oct 2022 12:00:30 GMT",
+			exp:   30 * time.Second,
+			expOK: true,
+		},
+		"http date in the past": {
+			value: "Sat, 01 Oct 2022 11:00:00 GMT",
+			exp:   0,
+			expOK: true,
+		},
+		"http date is capped": {
+			value: "Sun, 02 Oct 2022 12:00:00 GMT",
+			exp:   time.Hour,
+			expOK: true,
+		},
+		"invalid": {
+			value: "soon",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, ok := ParseRetryAfter(test.value, now)
+			assert.Equal(t, test.expOK, ok)
+			assert.Equal(t, test.exp, d)
+		})
+	}
+}
+
+func TestRetryAfterTransport(t *testing.T) {
+	retryAfters := map[string]string{
+		"/order/1": "5",
+		"/authz/1": "30",
+		"/authz/2": "",
+	}
+	client := NewRetryAfterClient(&http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			if v := retryAfters[req.URL.Path]; v != "" {
+				header.Set("Retry-After", v)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		}),
+	})
+
+	get := func(ctx context.Context, path string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://acme.example.com"+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	ctx, recorder := WithRetryAfterRecorder(context.Background())
+	assert.Equal(t, time.Duration(0), recorder.RetryAfter())
+
+	get(ctx, "/authz/2")
+	assert.Equal(t, time.Duration(0), recorder.RetryAfter())
+
+	get(ctx, "/authz/1")
+	get(ctx, "/order/1")
+	assert.Equal(t, 30*time.Second, recorder.RetryAfter(), "expected the longest Retry-After to be recorded")
+
+	// Requests without a recorder in their context are forwarded as usual.
+	get(context.Background(), "/order/1")
+}
+
+func TestRetryAfterTransportUsesClock(t *testing.T) {
+	fakeClock := fakeclock.NewFakeClock(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	client := NewRetryAfterClientWithClock(&http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			header.Set("Retry-After", "Sat, 01 Oct 2022 12:02:00 GMT")
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		}),
+	}, fakeClock)
+
+	get := func() time.Duration {
+		ctx, recorder := WithRetryAfterRecorder(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://acme.example.com/order/1", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return recorder.RetryAfter()
+	}
+
+	assert.Equal(t, 2*time.Minute, get())
+
+	fakeClock.Step(90 * time.Second)
+	assert.Equal(t, 30*time.Second, get(), "expected the delay to be relative to the time of the clock")
+}
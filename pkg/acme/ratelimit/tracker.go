@@ -0,0 +1,266 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit estimates how much of the rate limits of ACME servers
+// remains available to cert-manager, so that users can be warned before
+// issuance is throttled.
+//
+// ACME servers don't advertise the remaining budget of their rate limits, so
+// it is estimated by counting the orders and certificates requested by this
+// instance of cert-manager within the window of each limit. rateLimited errors
+// returned by the server exhaust the budget of the account until the delay of
+// their Retry-After header has elapsed.
+package ratelimit
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+	"k8s.io/utils/clock"
+)
+
+// defaultRateLimitedDelay is how long the budget of an account is exhausted
+// after a rateLimited error without a Retry-After header.
+const defaultRateLimitedDelay = time.Hour
+
+// Limit is a number of requests allowed within a sliding window.
+type Limit struct {
+	Count  int
+	Window time.Duration
+}
+
+// low returns true if remaining is low enough for the limit to be reached
+// imminently, i.e. at most a tenth of the limit and at least 1.
+func (l Limit) low(remaining int) bool {
+	watermark := l.Count / 10
+	if watermark < 1 {
+		watermark = 1
+	}
+	return remaining <= watermark
+}
+
+// Limits are the rate limits of an ACME server.
+type Limits struct {
+	// NewOrders limits the number of orders created by an account.
+	NewOrders Limit
+	// CertificatesPerDomain limits the number of certificates issued for
+	// each registered domain, e.g. example.com for www.example.com.
+	CertificatesPerDomain Limit
+	// DuplicateCertificates limits the number of certificates issued for
+	// the exact same set of identifiers.
+	DuplicateCertificates Limit
+}
+
+// DefaultLimits are the rate limits of the Let's Encrypt production
+// environment.
+var DefaultLimits = Limits{
+	NewOrders:             Limit{Count: 300, Window: 3 * time.Hour},
+	CertificatesPerDomain: Limit{Count: 50, Window: 7 * 24 * time.Hour},
+	DuplicateCertificates: Limit{Count: 5, Window: 7 * 24 * time.Hour},
+}
+
+// Budget is the estimated remaining budget of the rate limits for an order.
+type Budget struct {
+	// NewOrders is the number of orders the account can still create.
+	NewOrders int
+	// CertificatesPerDomain is the number of certificates which can still
+	// be issued for each registered domain of the identifiers of the order.
+	CertificatesPerDomain map[string]int
+	// DuplicateCertificates is the number of certificates which can still be
+	// issued for the identifiers of the order.
+	DuplicateCertificates int
+	// RateLimitedUntil is set if the server returned a rateLimited error to
+	// the account, and is the time until which it asked not to retry.
+	RateLimitedUntil *time.Time
+}
+
+// Tracker counts the orders and certificates requested from ACME servers to
+// estimate the remaining budget of their rate limits. It is safe for
+// concurrent use.
+type Tracker struct {
+	clock  clock.PassiveClock
+	limits Limits
+
+	lock sync.Mutex
+	// orders holds the creation times of the orders of each account.
+	orders map[string][]time.Time
+	// certificates holds the issuance times of the certificates issued by
+	// each server for each registered domain.
+	certificates map[string][]time.Time
+	// duplicates holds the issuance times of the certificates issued by
+	// each server for each set of identifiers.
+	duplicates map[string][]time.Time
+	// rateLimitedUntil holds the time until which each account was asked
+	// not to retry after a rateLimited error.
+	rateLimitedUntil map[string]time.Time
+}
+
+// NewTracker returns a Tracker estimating the budget of the given limits.
+func NewTracker(clock clock.PassiveClock, limits Limits) *Tracker {
+	return &Tracker{
+		clock:            clock,
+		limits:           limits,
+		orders:           make(map[string][]time.Time),
+		certificates:     make(map[string][]time.Time),
+		duplicates:       make(map[string][]time.Time),
+		rateLimitedUntil: make(map[string]time.Time),
+	}
+}
+
+// Limits returns the rate limits which the budgets are estimated for.
+func (t *Tracker) Limits() Limits {
+	return t.limits
+}
+
+// RecordOrder records the creation of an order by the given account.
+func (t *Tracker) RecordOrder(account string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.orders[account] = append(t.orders[account], t.clock.Now())
+}
+
+// RecordCertificate records the issuance of a certificate by the ACME server
+// with the given URL for the given DNS names and IP addresses.
+func (t *Tracker) RecordCertificate(server string, identifiers []string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.clock.Now()
+	for _, domain := range RegisteredDomains(identifiers) {
+		key := server + "/" + domain
+		t.certificates[key] = append(t.certificates[key], now)
+	}
+	key := duplicateKey(server, identifiers)
+	t.duplicates[key] = append(t.duplicates[key], now)
+}
+
+// RecordRateLimited records that the server returned a rateLimited error to
+// the given account, and asked it not to retry for retryAfter. A default
+// delay of one hour is used if retryAfter is not positive.
+func (t *Tracker) RecordRateLimited(account string, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		retryAfter = defaultRateLimitedDelay
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.rateLimitedUntil[account] = t.clock.Now().Add(retryAfter)
+}
+
+// Budget returns the estimated remaining budget of the given account of the
+// ACME server with the given URL for an order of the given identifiers.
+func (t *Tracker) Budget(account, server string, identifiers []string) Budget {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.clock.Now()
+
+	budget := Budget{
+		NewOrders:             t.remaining(t.orders, account, t.limits.NewOrders, now),
+		CertificatesPerDomain: make(map[string]int),
+		DuplicateCertificates: t.remaining(t.duplicates, duplicateKey(server, identifiers), t.limits.DuplicateCertificates, now),
+	}
+	for _, domain := range RegisteredDomains(identifiers) {
+		budget.CertificatesPerDomain[domain] = t.remaining(t.certificates, server+"/"+domain, t.limits.CertificatesPerDomain, now)
+	}
+
+	if until, ok := t.rateLimitedUntil[account]; ok {
+		if now.Before(until) {
+			budget.RateLimitedUntil = &until
+		} else {
+			delete(t.rateLimitedUntil, account)
+		}
+	}
+	return budget
+}
+
+// remaining prunes the times of the given key which are outside of the
+// window of the limit, and returns the number of requests remaining within
+// it.
+func (t *Tracker) remaining(times map[string][]time.Time, key string, limit Limit, now time.Time) int {
+	recorded := times[key]
+	i := 0
+	for i < len(recorded) && !recorded[i].After(now.Add(-limit.Window)) {
+		i++
+	}
+	recorded = recorded[i:]
+	if len(recorded) == 0 {
+		delete(times, key)
+	} else {
+		times[key] = recorded
+	}
+
+	if remaining := limit.Count - len(recorded); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// Low returns the names of the limits whose remaining budget is low enough
+// for issuance to be throttled imminently, sorted by name.
+func (b Budget) Low(limits Limits) []string {
+	var low []string
+	if limits.NewOrders.low(b.NewOrders) {
+		low = append(low, "new orders")
+	}
+	for domain, remaining := range b.CertificatesPerDomain {
+		if limits.CertificatesPerDomain.low(remaining) {
+			low = append(low, "certificates per domain for "+domain)
+		}
+	}
+	if limits.DuplicateCertificates.low(b.DuplicateCertificates) {
+		low = append(low, "duplicate certificates")
+	}
+	sort.Strings(low)
+	return low
+}
+
+// RegisteredDomains returns the registered domains of the given DNS names,
+// i.e. the public suffix of each name plus one label, sorted and without
+// duplicates. IP addresses are ignored.
+func RegisteredDomains(identifiers []string) []string {
+	seen := make(map[string]struct{})
+	var domains []string
+	for _, id := range identifiers {
+		if net.ParseIP(id) != nil {
+			continue
+		}
+		domain, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(strings.TrimPrefix(id, "*.")))
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[domain]; !ok {
+			seen[domain] = struct{}{}
+			domains = append(domains, domain)
+		}
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+func duplicateKey(server string, identifiers []string) string {
+	set := make(map[string]struct{}, len(identifiers))
+	for _, id := range identifiers {
+		set[strings.ToLower(id)] = struct{}{}
+	}
+	sorted := make([]string, 0, len(set))
+	for id := range set {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return server + "/" + strings.Join(sorted, ",")
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestTrackerBudget(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	limits := Limits{
+		NewOrders:             Limit{Count: 3, Window: time.Hour},
+		CertificatesPerDomain: Limit{Count: 20, Window: 24 * time.Hour},
+		DuplicateCertificates: Limit{Count: 2, Window: 24 * time.Hour},
+	}
+	tracker := NewTracker(clock, limits)
+	identifiers := []string{"www.example.com", "*.example.com", "example.co.uk", "10.0.0.1"}
+
+	budget := tracker.Budget("account", "server", identifiers)
+	expected := Budget{
+		NewOrders:             3,
+		CertificatesPerDomain: map[string]int{"example.co.uk": 20, "example.com": 20},
+		DuplicateCertificates: 2,
+	}
+	if !reflect.DeepEqual(budget, expected) {
+		t.Errorf("expected budget %+v, got %+v", expected, budget)
+	}
+	if low := budget.Low(limits); len(low) != 0 {
+		t.Errorf("expected no low budget, got %v", low)
+	}
+
+	tracker.RecordOrder("account")
+	tracker.RecordOrder("other-account")
+	tracker.RecordCertificate("server", identifiers)
+	clock.Step(30 * time.Minute)
+	tracker.RecordOrder("account")
+	// The identifiers of duplicate certificates are compared as a set.
+	tracker.RecordCertificate("server", []string{"10.0.0.1", "example.co.uk", "*.example.com", "WWW.example.com"})
+	tracker.RecordCertificate("other-server", identifiers)
+
+	budget = tracker.Budget("account", "server", identifiers)
+	expected = Budget{
+		NewOrders:             1,
+		CertificatesPerDomain: map[string]int{"example.co.uk": 18, "example.com": 18},
+		DuplicateCertificates: 0,
+	}
+	if !reflect.DeepEqual(budget, expected) {
+		t.Errorf("expected budget %+v, got %+v", expected, budget)
+	}
+	if low, expectedLow := budget.Low(limits), []string{"duplicate certificates", "new orders"}; !reflect.DeepEqual(low, expectedLow) {
+		t.Errorf("expected low budgets %v, got %v", expectedLow, low)
+	}
+
+	// Requests recorded before the window of a limit no longer count.
+	clock.Step(45 * time.Minute)
+	if budget := tracker.Budget("account", "server", identifiers); budget.NewOrders != 2 {
+		t.Errorf("expected 2 new orders to remain, got %d", budget.NewOrders)
+	}
+
+	tracker.RecordRateLimited("account", time.Minute)
+	budget = tracker.Budget("account", "server", identifiers)
+	if budget.RateLimitedUntil == nil || !budget.RateLimitedUntil.Equal(clock.Now().Add(time.Minute)) {
+		t.Errorf("expected the account to be rate limited for a minute, got %v", budget.RateLimitedUntil)
+	}
+	clock.Step(time.Minute)
+	if budget := tracker.Budget("account", "server", identifiers); budget.RateLimitedUntil != nil {
+		t.Errorf("expected the account to no longer be rate limited, got %v", budget.RateLimitedUntil)
+	}
+}
+
+func TestRegisteredDomains(t *testing.T) {
+	domains := RegisteredDomains([]string{"a.b.example.com", "*.Example.com", "foo.github.io", "com", "::1"})
+	expected := []string{"example.com", "foo.github.io"}
+	if !reflect.DeepEqual(domains, expected) {
+		t.Errorf("expected registered domains %v, got %v", expected, domains)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conditions contains helpers for getting and setting the status
+// conditions of cert-manager resources, which handle the lastTransitionTime
+// and observedGeneration of conditions consistently across resource types.
+package conditions
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// Condition is implemented by pointers to the status condition types of
+// cert-manager resources, such as *CertificateCondition.
+type Condition[C any] interface {
+	*C
+
+	GetType() string
+	GetStatus() cmmeta.ConditionStatus
+	GetLastTransitionTime() *metav1.Time
+	SetLastTransitionTime(*metav1.Time)
+	GetObservedGeneration() int64
+}
+
+// Get returns the condition of the given type in conditions, or nil if there
+// is no such condition. The returned condition points into conditions.
+func Get[C any, PC Condition[C]](conditions []C, conditionType string) *C {
+	for i := range conditions {
+		if PC(&conditions[i]).GetType() == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// Has returns true if conditions contains a condition of the given type with
+// the given status.
+func Has[C any, PC Condition[C]](conditions []C, conditionType string, status cmmeta.ConditionStatus) bool {
+	cond := Get[C, PC](conditions, conditionType)
+	return cond != nil && PC(cond).GetStatus() == status
+}
+
+// HasObserved returns true if conditions contains a condition of the given
+// type with the given status, which was set for the given generation of the
+// resource or a later one. This matches the semantics of `kubectl wait`,
+// which only considers conditions that are up to date with the resource.
+func HasObserved[C any, PC Condition[C]](conditions []C, conditionType string, status cmmeta.ConditionStatus, generation int64) bool {
+	cond := Get[C, PC](conditions, conditionType)
+	return cond != nil && PC(cond).GetStatus() == status && PC(cond).GetObservedGeneration() >= generation
+}
+
+// Set sets condition in conditions, replacing any existing condition of the
+// same type, and returns the updated conditions.
+// If condition has no lastTransitionTime, it is set to now when the status of
+// the condition changes, and otherwise kept from the existing condition.
+// The returned bool is true if the status of the condition changed, including
+// when the condition was added.
+func Set[C any, PC Condition[C]](conditions []C, condition C, now time.Time) ([]C, bool) {
+	existing := Get[C, PC](conditions, PC(&condition).GetType())
+	transitioned := existing == nil || PC(existing).GetStatus() != PC(&condition).GetStatus()
+
+	if PC(&condition).GetLastTransitionTime() == nil {
+		if transitioned {
+			nowTime := metav1.NewTime(now)
+			PC(&condition).SetLastTransitionTime(&nowTime)
+		} else {
+			PC(&condition).SetLastTransitionTime(PC(existing).GetLastTransitionTime())
+		}
+	}
+
+	if existing == nil {
+		return append(conditions, condition), transitioned
+	}
+	*existing = condition
+	return conditions, transitioned
+}
+
+// Remove returns conditions without the conditions of the given type.
+func Remove[C any, PC Condition[C]](conditions []C, conditionType string) []C {
+	var updated []C
+	for i := range conditions {
+		if PC(&conditions[i]).GetType() != conditionType {
+			updated = append(updated, conditions[i])
+		}
+	}
+	return updated
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestSet(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	past := metav1.NewTime(now.Add(-time.Hour))
+	nowTime := metav1.NewTime(now)
+
+	tests := map[string]struct {
+		conditions      []cmapi.CertificateCondition
+		condition       cmapi.CertificateCondition
+		expConditions   []cmapi.CertificateCondition
+		expTransitioned bool
+	}{
+		"adds a condition which doesn't exist": {
+			conditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, LastTransitionTime: &past},
+			},
+			condition: cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 2},
+			expConditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, LastTransitionTime: &past},
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, LastTransitionTime: &nowTime, ObservedGeneration: 2},
+			},
+			expTransitioned: true,
+		},
+		"keeps the lastTransitionTime if the status doesn't change": {
+			conditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, Reason: "Old", LastTransitionTime: &past, ObservedGeneration: 1},
+			},
+			condition: cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, Reason: "New", ObservedGeneration: 2},
+			expConditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, Reason: "New", LastTransitionTime: &past, ObservedGeneration: 2},
+			},
+		},
+		"updates the lastTransitionTime if the status changes": {
+			conditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, LastTransitionTime: &past},
+			},
+			condition: cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue},
+			expConditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, LastTransitionTime: &nowTime},
+			},
+			expTransitioned: true,
+		},
+		"uses the given lastTransitionTime": {
+			conditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, LastTransitionTime: &nowTime},
+			},
+			condition: cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, LastTransitionTime: &past},
+			expConditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, LastTransitionTime: &past},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			conditions, transitioned := Set(test.conditions, test.condition, now)
+			assert.Equal(t, test.expConditions, conditions)
+			assert.Equal(t, test.expTransitioned, transitioned)
+		})
+	}
+}
+
+func TestGetHasRemove(t *testing.T) {
+	conditions := []cmapi.IssuerCondition{
+		{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 2},
+		{Type: cmapi.IssuerConditionRolledOut, Status: cmmeta.ConditionFalse},
+	}
+
+	assert.Equal(t, &conditions[1], Get(conditions, string(cmapi.IssuerConditionRolledOut)))
+	assert.Nil(t, Get(conditions, "Unknown"))
+
+	assert.True(t, Has(conditions, string(cmapi.IssuerConditionReady), cmmeta.ConditionTrue))
+	assert.False(t, Has(conditions, string(cmapi.IssuerConditionReady), cmmeta.ConditionFalse))
+	assert.False(t, Has(conditions, "Unknown", cmmeta.ConditionTrue))
+
+	assert.True(t, HasObserved(conditions, string(cmapi.IssuerConditionReady), cmmeta.ConditionTrue, 2))
+	assert.False(t, HasObserved(conditions, string(cmapi.IssuerConditionReady), cmmeta.ConditionTrue, 3))
+
+	assert.Equal(t, []cmapi.IssuerCondition{conditions[1]}, Remove(conditions, string(cmapi.IssuerConditionReady)))
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// BootstrapAfter returns the Certificates named in the
+// `cert-manager.io/bootstrap-after` annotation of crt, which must be Ready
+// before crt is issued for the first time. Only Certificates in the
+// namespace of crt can be named, so that a Certificate cannot be used to
+// observe or wait on the Certificates of other namespaces.
+func BootstrapAfter(crt *v1.Certificate) ([]types.NamespacedName, error) {
+	value, ok := crt.Annotations[v1.BootstrapAfterAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var deps []types.NamespacedName
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if strings.Contains(entry, "/") {
+			return nil, fmt.Errorf("invalid %q annotation: %q must be the name of a Certificate in the namespace %q", v1.BootstrapAfterAnnotationKey, entry, crt.Namespace)
+		}
+		if errs := validation.IsDNS1123Subdomain(entry); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid %q annotation: %q is not a valid Certificate name: %s", v1.BootstrapAfterAnnotationKey, entry, strings.Join(errs, ", "))
+		}
+		deps = append(deps, types.NamespacedName{Namespace: crt.Namespace, Name: entry})
+	}
+	return deps, nil
+}
@@ -17,9 +17,9 @@ limitations under the License.
 package util
 
 import (
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 
+	"github.com/cert-manager/cert-manager/pkg/api/conditions"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -37,13 +37,7 @@ func IssuerHasCondition(i cmapi.GenericIssuer, c cmapi.IssuerCondition) bool {
 	if i == nil {
 		return false
 	}
-	existingConditions := i.GetStatus().Conditions
-	for _, cond := range existingConditions {
-		if c.Type == cond.Type && c.Status == cond.Status {
-			return true
-		}
-	}
-	return false
+	return conditions.Has(i.GetStatus().Conditions, string(c.Type), c.Status)
 }
 
 // SetIssuerCondition will set a 'condition' on the given GenericIssuer.
@@ -57,43 +51,18 @@ func IssuerHasCondition(i cmapi.GenericIssuer, c cmapi.IssuerCondition) bool {
 //
 // This function works with both Issuer and ClusterIssuer resources.
 func SetIssuerCondition(i cmapi.GenericIssuer, observedGeneration int64, conditionType cmapi.IssuerConditionType, status cmmeta.ConditionStatus, reason, message string) {
-	newCondition := cmapi.IssuerCondition{
-		Type:    conditionType,
-		Status:  status,
-		Reason:  reason,
-		Message: message,
-	}
-
-	nowTime := metav1.NewTime(Clock.Now())
-	newCondition.LastTransitionTime = &nowTime
-
-	// Set the condition generation
-	newCondition.ObservedGeneration = observedGeneration
-
-	// Search through existing conditions
-	for idx, cond := range i.GetStatus().Conditions {
-		// Skip unrelated conditions
-		if cond.Type != conditionType {
-			continue
-		}
-
-		// If this update doesn't contain a state transition, we don't update
-		// the conditions LastTransitionTime to Now()
-		if cond.Status == status {
-			newCondition.LastTransitionTime = cond.LastTransitionTime
-		} else {
-			logf.V(logf.InfoLevel).Infof("Found status change for Issuer %q condition %q: %q -> %q; setting lastTransitionTime to %v", i.GetObjectMeta().Name, conditionType, cond.Status, status, nowTime.Time)
-		}
-
-		// Overwrite the existing condition
-		i.GetStatus().Conditions[idx] = newCondition
-		return
+	var transitioned bool
+	i.GetStatus().Conditions, transitioned = conditions.Set(i.GetStatus().Conditions, cmapi.IssuerCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: observedGeneration,
+	}, Clock.Now())
+
+	if transitioned {
+		logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for Issuer %q condition %q with status %q to %v", i.GetObjectMeta().Name, conditionType, status, Clock.Now())
 	}
-
-	// If we've not found an existing condition of this type, we simply insert
-	// the new condition into the slice.
-	i.GetStatus().Conditions = append(i.GetStatus().Conditions, newCondition)
-	logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for Issuer %q condition %q to %v", i.GetObjectMeta().Name, conditionType, nowTime.Time)
 }
 
 // CertificateHasCondition will return true if the given Certificate has a
@@ -105,13 +74,7 @@ func CertificateHasCondition(crt *cmapi.Certificate, c cmapi.CertificateConditio
 	if crt == nil {
 		return false
 	}
-	existingConditions := crt.Status.Conditions
-	for _, cond := range existingConditions {
-		if c.Type == cond.Type && c.Status == cond.Status {
-			return true
-		}
-	}
-	return false
+	return conditions.Has(crt.Status.Conditions, string(c.Type), c.Status)
 }
 
 // CertificateHasConditionWithObservedGeneration will return true if the given Certificate has a
@@ -124,29 +87,25 @@ func CertificateHasConditionWithObservedGeneration(crt *cmapi.Certificate, c cma
 	if crt == nil {
 		return false
 	}
-	existingConditions := crt.Status.Conditions
-	for _, cond := range existingConditions {
-		if c.Type == cond.Type && c.Status == cond.Status && c.ObservedGeneration <= cond.ObservedGeneration {
-			return true
-		}
-	}
-	return false
+	return conditions.HasObserved(crt.Status.Conditions, string(c.Type), c.Status, c.ObservedGeneration)
 }
 
+// GetCertificateCondition returns a copy of the condition of the given type
+// of the Certificate, or nil if it has no such condition.
 func GetCertificateCondition(crt *cmapi.Certificate, conditionType cmapi.CertificateConditionType) *cmapi.CertificateCondition {
-	for _, cond := range crt.Status.Conditions {
-		if cond.Type == conditionType {
-			return &cond
-		}
+	if cond := conditions.Get(crt.Status.Conditions, string(conditionType)); cond != nil {
+		cond := *cond
+		return &cond
 	}
 	return nil
 }
 
+// GetCertificateRequestCondition returns a copy of the condition of the given
+// type of the CertificateRequest, or nil if it has no such condition.
 func GetCertificateRequestCondition(req *cmapi.CertificateRequest, conditionType cmapi.CertificateRequestConditionType) *cmapi.CertificateRequestCondition {
-	for _, cond := range req.Status.Conditions {
-		if cond.Type == conditionType {
-			return &cond
-		}
+	if cond := conditions.Get(req.Status.Conditions, string(conditionType)); cond != nil {
+		cond := *cond
+		return &cond
 	}
 	return nil
 }
@@ -164,58 +123,23 @@ func GetCertificateRequestCondition(req *cmapi.CertificateRequest, conditionType
 // lastTransitionTime is modified or not.
 func SetCertificateCondition(crt *cmapi.Certificate, observedGeneration int64, conditionType cmapi.CertificateConditionType,
 	status cmmeta.ConditionStatus, reason, message string) {
-	newCondition := cmapi.CertificateCondition{
-		Type:    conditionType,
-		Status:  status,
-		Reason:  reason,
-		Message: message,
+	var transitioned bool
+	crt.Status.Conditions, transitioned = conditions.Set(crt.Status.Conditions, cmapi.CertificateCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: observedGeneration,
+	}, Clock.Now())
+
+	if transitioned {
+		logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for Certificate %q condition %q with status %q to %v", crt.Name, conditionType, status, Clock.Now())
 	}
-
-	nowTime := metav1.NewTime(Clock.Now())
-	newCondition.LastTransitionTime = &nowTime
-
-	// Set the condition generation
-	newCondition.ObservedGeneration = observedGeneration
-
-	// Search through existing conditions
-	for idx, cond := range crt.Status.Conditions {
-		// Skip unrelated conditions
-		if cond.Type != conditionType {
-			continue
-		}
-
-		// If this update doesn't contain a state transition, we don't update the
-		// conditions LastTransitionTime to Now()
-		if cond.Status == status {
-			newCondition.LastTransitionTime = cond.LastTransitionTime
-		} else {
-			logf.V(logf.InfoLevel).Infof("Found status change for Certificate %q condition %q: %q -> %q; setting lastTransitionTime to %v", crt.Name, conditionType, cond.Status, status, nowTime.Time)
-		}
-
-		// Overwrite the existing condition
-		crt.Status.Conditions[idx] = newCondition
-		return
-	}
-
-	// If we've not found an existing condition of this type, we simply insert
-	// the new condition into the slice.
-	crt.Status.Conditions = append(crt.Status.Conditions, newCondition)
-	logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for Certificate %q condition %q to %v", crt.Name, conditionType, nowTime.Time)
 }
 
 // RemoveCertificateCondition will remove any condition with this condition type
 func RemoveCertificateCondition(crt *cmapi.Certificate, conditionType cmapi.CertificateConditionType) {
-	var updatedConditions []cmapi.CertificateCondition
-
-	// Search through existing conditions
-	for _, cond := range crt.Status.Conditions {
-		// Only add unrelated conditions
-		if cond.Type != conditionType {
-			updatedConditions = append(updatedConditions, cond)
-		}
-	}
-
-	crt.Status.Conditions = updatedConditions
+	crt.Status.Conditions = conditions.Remove(crt.Status.Conditions, string(conditionType))
 }
 
 // SetCertificateRequestCondition will set a 'condition' on the given CertificateRequest.
@@ -226,41 +150,22 @@ func RemoveCertificateCondition(crt *cmapi.Certificate, conditionType cmapi.Cert
 //   - If a condition of the same type and different state already exists, the
 //     condition will be updated and the LastTransitionTime set to the current
 //     time.
+//
+// The ObservedGeneration of the condition is set to the generation of the
+// CertificateRequest.
 func SetCertificateRequestCondition(cr *cmapi.CertificateRequest, conditionType cmapi.CertificateRequestConditionType, status cmmeta.ConditionStatus, reason, message string) {
-	newCondition := cmapi.CertificateRequestCondition{
-		Type:    conditionType,
-		Status:  status,
-		Reason:  reason,
-		Message: message,
+	var transitioned bool
+	cr.Status.Conditions, transitioned = conditions.Set(cr.Status.Conditions, cmapi.CertificateRequestCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cr.Generation,
+	}, Clock.Now())
+
+	if transitioned {
+		logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for CertificateRequest %q condition %q with status %q to %v", cr.Name, conditionType, status, Clock.Now())
 	}
-
-	nowTime := metav1.NewTime(Clock.Now())
-	newCondition.LastTransitionTime = &nowTime
-
-	// Search through existing conditions
-	for idx, cond := range cr.Status.Conditions {
-		// Skip unrelated conditions
-		if cond.Type != conditionType {
-			continue
-		}
-
-		// If this update doesn't contain a state transition, we don't update
-		// the conditions LastTransitionTime to Now()
-		if cond.Status == status {
-			newCondition.LastTransitionTime = cond.LastTransitionTime
-		} else {
-			logf.V(logf.InfoLevel).Infof("Found status change for CertificateRequest %q condition %q: %q -> %q; setting lastTransitionTime to %v", cr.Name, conditionType, cond.Status, status, nowTime.Time)
-		}
-
-		// Overwrite the existing condition
-		cr.Status.Conditions[idx] = newCondition
-		return
-	}
-
-	// If we've not found an existing condition of this type, we simply insert
-	// the new condition into the slice.
-	cr.Status.Conditions = append(cr.Status.Conditions, newCondition)
-	logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for CertificateRequest %q condition %q to %v", cr.Name, conditionType, nowTime.Time)
 }
 
 // CertificateRequestHasCondition will return true if the given
@@ -327,15 +232,7 @@ func CertificateRequestHasInvalidRequest(cr *cmapi.CertificateRequest) bool {
 	if cr == nil {
 		return false
 	}
-
-	for _, con := range cr.Status.Conditions {
-		if con.Type == cmapi.CertificateRequestConditionInvalidRequest &&
-			con.Status == cmmeta.ConditionTrue {
-			return true
-		}
-	}
-
-	return false
+	return conditions.Has(cr.Status.Conditions, string(cmapi.CertificateRequestConditionInvalidRequest), cmmeta.ConditionTrue)
 }
 
 // CertificateRequestIsApproved returns true if the CertificateRequest is
@@ -345,15 +242,7 @@ func CertificateRequestIsApproved(cr *cmapi.CertificateRequest) bool {
 	if cr == nil {
 		return false
 	}
-
-	for _, con := range cr.Status.Conditions {
-		if con.Type == cmapi.CertificateRequestConditionApproved &&
-			con.Status == cmmeta.ConditionTrue {
-			return true
-		}
-	}
-
-	return false
+	return conditions.Has(cr.Status.Conditions, string(cmapi.CertificateRequestConditionApproved), cmmeta.ConditionTrue)
 }
 
 // CertificateRequestIsDenied returns true if the CertificateRequest is denied
@@ -362,13 +251,5 @@ func CertificateRequestIsDenied(cr *cmapi.CertificateRequest) bool {
 	if cr == nil {
 		return false
 	}
-
-	for _, con := range cr.Status.Conditions {
-		if con.Type == cmapi.CertificateRequestConditionDenied &&
-			con.Status == cmmeta.ConditionTrue {
-			return true
-		}
-	}
-
-	return false
+	return conditions.Has(cr.Status.Conditions, string(cmapi.CertificateRequestConditionDenied), cmmeta.ConditionTrue)
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,3 +35,26 @@ func DefaultCertDuration(d *metav1.Duration) time.Duration {
 
 	return certDuration
 }
+
+// NotBeforeBackdate returns the amount of time by which the NotBefore of a
+// certificate should be set before the time of issuance. The
+// `cert-manager.io/not-before-backdate` annotation of the request takes
+// precedence over the given notBeforeBackdate of the issuer.
+func NotBeforeBackdate(annotations map[string]string, issuerBackdate *metav1.Duration) (time.Duration, error) {
+	value, ok := annotations[v1.NotBeforeBackdateAnnotationKey]
+	if !ok {
+		if issuerBackdate == nil {
+			return 0, nil
+		}
+		return issuerBackdate.Duration, nil
+	}
+
+	backdate, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %q annotation: %w", v1.NotBeforeBackdateAnnotationKey, err)
+	}
+	if backdate < 0 {
+		return 0, fmt.Errorf("invalid %q annotation: %q must not be negative", v1.NotBeforeBackdateAnnotationKey, value)
+	}
+	return backdate, nil
+}
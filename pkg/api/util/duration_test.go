@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestNotBeforeBackdate(t *testing.T) {
+	issuerBackdate := &metav1.Duration{Duration: time.Minute}
+	annotated := func(backdate string) map[string]string {
+		return map[string]string{cmapi.NotBeforeBackdateAnnotationKey: backdate}
+	}
+
+	tests := map[string]struct {
+		annotations    map[string]string
+		issuerBackdate *metav1.Duration
+		exp            time.Duration
+		expErr         bool
+	}{
+		"no backdate":                          {},
+		"issuer backdate":                      {issuerBackdate: issuerBackdate, exp: time.Minute},
+		"annotation overrides issuer backdate": {annotations: annotated("5m"), issuerBackdate: issuerBackdate, exp: 5 * time.Minute},
+		"annotation disables issuer backdate":  {annotations: annotated("0s"), issuerBackdate: issuerBackdate},
+		"invalid annotation":                   {annotations: annotated("five minutes"), expErr: true},
+		"negative annotation":                  {annotations: annotated("-5m"), expErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NotBeforeBackdate(test.annotations, test.issuerBackdate)
+			assert.Equal(t, test.expErr, err != nil, "unexpected error: %v", err)
+			assert.Equal(t, test.exp, got)
+		})
+	}
+}
@@ -34,6 +34,12 @@ const (
 	IssuerSelfSigned string = "selfsigned"
 	// IssuerVenafi uses Venafi Trust Protection Platform and Venafi Cloud
 	IssuerVenafi string = "venafi"
+	// IssuerFake signs certificates using an ephemeral in-memory CA
+	IssuerFake string = "fake"
+	// IssuerDelegate passes requests on to one of several backing issuers
+	IssuerDelegate string = "delegate"
+	// IssuerOffline has certificates signed by an offline CA
+	IssuerOffline string = "offline"
 )
 
 // NameForIssuer determines the name of the Issuer implementation given an
@@ -50,6 +56,12 @@ func NameForIssuer(i cmapi.GenericIssuer) (string, error) {
 		return IssuerSelfSigned, nil
 	case i.GetSpec().Venafi != nil:
 		return IssuerVenafi, nil
+	case i.GetSpec().Fake != nil:
+		return IssuerFake, nil
+	case i.GetSpec().Delegate != nil:
+		return IssuerDelegate, nil
+	case i.GetSpec().Offline != nil:
+		return IssuerOffline, nil
 	}
 	return "", fmt.Errorf("no issuer specified for Issuer '%s/%s'", i.GetObjectMeta().Namespace, i.GetObjectMeta().Name)
 }
@@ -61,3 +73,13 @@ func IssuerKind(ref cmmeta.ObjectReference) string {
 	}
 	return ref.Kind
 }
+
+// IssuerNamespace returns the namespace of the Issuer referenced by ref from
+// a resource in the given namespace. This is the namespace of the reference
+// if it is set, and the namespace of the referring resource otherwise.
+func IssuerNamespace(ref cmmeta.ObjectReference, namespace string) string {
+	if ref.Namespace != "" {
+		return ref.Namespace
+	}
+	return namespace
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// DNSNameInZone returns true if the given DNS name is equal to, or a
+// subdomain of, the given DNS zone. The comparison is case-insensitive and
+// ignores trailing dots.
+func DNSNameInZone(name, zone string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+// SplitIssuanceForDNSName returns the split issuance of the given Certificate
+// spec which issues the given DNS name, or nil if the name is issued by the
+// issuer of the Certificate. If the name is in the zones of several split
+// issuances, the one with the longest matching zone is returned.
+func SplitIssuanceForDNSName(spec *cmapi.CertificateSpec, name string) *cmapi.CertificateSplitIssuance {
+	var (
+		split   *cmapi.CertificateSplitIssuance
+		longest = -1
+	)
+	for i := range spec.SplitIssuances {
+		for _, zone := range spec.SplitIssuances[i].DNSZones {
+			zone = strings.TrimSuffix(zone, ".")
+			if DNSNameInZone(name, zone) && len(zone) > longest {
+				split, longest = &spec.SplitIssuances[i], len(zone)
+			}
+		}
+	}
+	return split
+}
+
+// CertificatePrimaryDNSNames returns the DNS names of the given Certificate
+// spec which are issued by the issuer of the Certificate, rather than by one
+// of its split issuances.
+func CertificatePrimaryDNSNames(spec *cmapi.CertificateSpec) []string {
+	if len(spec.SplitIssuances) == 0 {
+		return spec.DNSNames
+	}
+	var names []string
+	for _, name := range spec.DNSNames {
+		if SplitIssuanceForDNSName(spec, name) == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SplitIssuanceDNSNames returns the DNS names of the given Certificate spec
+// which are issued by the split issuance with the given name.
+func SplitIssuanceDNSNames(spec *cmapi.CertificateSpec, splitName string) []string {
+	var names []string
+	for _, name := range spec.DNSNames {
+		if split := SplitIssuanceForDNSName(spec, name); split != nil && split.Name == splitName {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SplitIssuanceCertificateKey returns the name of the data entry in the Secret
+// of a Certificate used to store the signed certificate chain of the split
+// issuance with the given name.
+func SplitIssuanceCertificateKey(splitName string) string {
+	return splitName + ".crt"
+}
+
+// SplitIssuanceCAKey returns the name of the data entry in the Secret of a
+// Certificate used to store the CA certificate of the split issuance with the
+// given name.
+func SplitIssuanceCAKey(splitName string) string {
+	return splitName + "-ca.crt"
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestDNSNameInZone(t *testing.T) {
+	tests := map[string]struct {
+		name, zone string
+		exp        bool
+	}{
+		"zone apex":                  {name: "example.com", zone: "example.com", exp: true},
+		"subdomain":                  {name: "www.example.com", zone: "example.com", exp: true},
+		"case and trailing dots":     {name: "WWW.Example.com.", zone: "example.COM.", exp: true},
+		"different zone":             {name: "www.example.org", zone: "example.com", exp: false},
+		"suffix without a label dot": {name: "notexample.com", zone: "example.com", exp: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, DNSNameInZone(test.name, test.zone))
+		})
+	}
+}
+
+func TestSplitIssuanceDNSNames(t *testing.T) {
+	spec := &cmapi.CertificateSpec{
+		DNSNames: []string{"example.com", "www.example.com", "api.svc.cluster.local", "db.internal.svc.cluster.local", "mesh.local"},
+		SplitIssuances: []cmapi.CertificateSplitIssuance{
+			{Name: "mesh", DNSZones: []string{"svc.cluster.local", "mesh.local"}, IssuerRef: cmmeta.ObjectReference{Name: "mesh-ca"}},
+			{Name: "internal", DNSZones: []string{"internal.svc.cluster.local."}, IssuerRef: cmmeta.ObjectReference{Name: "internal-ca"}},
+		},
+	}
+
+	assert.Equal(t, []string{"example.com", "www.example.com"}, CertificatePrimaryDNSNames(spec))
+	assert.Equal(t, []string{"api.svc.cluster.local", "mesh.local"}, SplitIssuanceDNSNames(spec, "mesh"))
+	assert.Equal(t, []string{"db.internal.svc.cluster.local"}, SplitIssuanceDNSNames(spec, "internal"))
+	assert.Nil(t, SplitIssuanceDNSNames(spec, "unknown"))
+
+	unsplit := &cmapi.CertificateSpec{DNSNames: []string{"example.com"}}
+	assert.Equal(t, unsplit.DNSNames, CertificatePrimaryDNSNames(unsplit))
+}
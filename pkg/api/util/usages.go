@@ -18,6 +18,7 @@ package util
 
 import (
 	"crypto/x509"
+	"encoding/asn1"
 	"math/bits"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -52,6 +53,14 @@ var extKeyUsages = map[cmapi.KeyUsage]x509.ExtKeyUsage{
 	cmapi.UsageNetscapeSGC:     x509.ExtKeyUsageNetscapeServerGatedCrypto,
 }
 
+// unknownExtKeyUsages contains extended key usages which have no
+// x509.ExtKeyUsage equivalent in the standard library, and so must be encoded
+// using their OID directly.
+var unknownExtKeyUsages = map[cmapi.KeyUsage]asn1.ObjectIdentifier{
+	// RFC 9336, id-kp-documentSigning
+	cmapi.UsageDocumentSigning: {1, 3, 6, 1, 5, 5, 7, 3, 36},
+}
+
 // KeyUsageType returns the relevant x509.KeyUsage or false if not found
 func KeyUsageType(usage cmapi.KeyUsage) (x509.KeyUsage, bool) {
 	u, ok := keyUsages[usage]
@@ -64,6 +73,13 @@ func ExtKeyUsageType(usage cmapi.KeyUsage) (x509.ExtKeyUsage, bool) {
 	return eu, ok
 }
 
+// UnknownExtKeyUsageOID returns the OID of an extended key usage that has no
+// x509.ExtKeyUsage equivalent, or false if not found
+func UnknownExtKeyUsageOID(usage cmapi.KeyUsage) (asn1.ObjectIdentifier, bool) {
+	oid, ok := unknownExtKeyUsages[usage]
+	return oid, ok
+}
+
 // KeyUsageStrings returns the cmapi.KeyUsage and "unknown" if not found
 func KeyUsageStrings(usage x509.KeyUsage) []cmapi.KeyUsage {
 	var usageStr []cmapi.KeyUsage
@@ -112,3 +128,19 @@ func extKeyUsageString(usage x509.ExtKeyUsage) cmapi.KeyUsage {
 
 	return "unknown"
 }
+
+// UnknownExtKeyUsageStrings returns the cmapi.KeyUsage for each of the given
+// OIDs. OIDs which do not correspond to a cmapi.KeyUsage are ignored.
+func UnknownExtKeyUsageStrings(oids []asn1.ObjectIdentifier) []cmapi.KeyUsage {
+	var usageStr []cmapi.KeyUsage
+
+	for _, oid := range oids {
+		for k, v := range unknownExtKeyUsages {
+			if oid.Equal(v) {
+				usageStr = append(usageStr, k)
+			}
+		}
+	}
+
+	return usageStr
+}
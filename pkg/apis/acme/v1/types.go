@@ -38,6 +38,21 @@ const (
 	// of ingress on the created Certificate resource
 	IngressEditInPlaceAnnotationKey = "acme.cert-manager.io/http01-edit-in-place"
 
+	// ACMECertificateProfileAnnotationKey is annotation to override the ACME
+	// certificate profile.
+	// If this annotation is specified on a Certificate resource, orders for
+	// the Certificate will request the profile given here instead of the
+	// profile configured on the ACME issuer.
+	ACMECertificateProfileAnnotationKey = "acme.cert-manager.io/profile"
+
+	// ACMEAccountURIAnnotationKey holds the URI of an existing ACME account
+	// for an ACME Issuer or ClusterIssuer which was restored from a backup.
+	// If set and the issuer has not yet registered an account, the account
+	// is looked up using the restored private key instead of being
+	// registered again, so that external account bindings don't have to be
+	// valid for a second registration.
+	ACMEAccountURIAnnotationKey = "acme.cert-manager.io/account-uri"
+
 	// DomainLabelKey is added to the labels of a Pod serving an ACME challenge.
 	// Its value will be the hash of the domain name that is being verified.
 	DomainLabelKey = "acme.cert-manager.io/http-domain"
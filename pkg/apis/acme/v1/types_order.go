@@ -28,6 +28,13 @@ import (
 
 // Order is a type to represent an Order with an ACME server
 // +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Domains",type="string",JSONPath=".spec.dnsNames"
+// +kubebuilder:printcolumn:name="Issuer",type="string",JSONPath=".spec.issuerRef.name",priority=1
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=orders
 type Order struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata"`
@@ -82,6 +89,11 @@ type OrderSpec struct {
 	// this is set on order creation as pe the ACME spec.
 	// +optional
 	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Profile is the name of the certificate profile requested when creating
+	// the order, for ACME servers implementing the ACME profiles extension.
+	// +optional
+	Profile string `json:"profile,omitempty"`
 }
 
 type OrderStatus struct {
@@ -133,7 +145,8 @@ type ACMEAuthorization struct {
 	// URL is the URL of the Authorization that must be completed
 	URL string `json:"url"`
 
-	// Identifier is the DNS name to be validated as part of this authorization
+	// Identifier is the DNS name or IP address to be validated as part of this
+	// authorization
 	// +optional
 	Identifier string `json:"identifier,omitempty"`
 
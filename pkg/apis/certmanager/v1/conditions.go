@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// The accessors in this file allow the condition types of the different
+// resources to be handled by the same helpers, such as those in the
+// github.com/cert-manager/cert-manager/pkg/api/conditions package.
+
+func (c *CertificateCondition) GetType() string {
+	return string(c.Type)
+}
+
+func (c *CertificateCondition) GetStatus() cmmeta.ConditionStatus {
+	return c.Status
+}
+
+func (c *CertificateCondition) GetLastTransitionTime() *metav1.Time {
+	return c.LastTransitionTime
+}
+
+func (c *CertificateCondition) SetLastTransitionTime(t *metav1.Time) {
+	c.LastTransitionTime = t
+}
+
+func (c *CertificateCondition) GetObservedGeneration() int64 {
+	return c.ObservedGeneration
+}
+
+func (c *CertificateRequestCondition) GetType() string {
+	return string(c.Type)
+}
+
+func (c *CertificateRequestCondition) GetStatus() cmmeta.ConditionStatus {
+	return c.Status
+}
+
+func (c *CertificateRequestCondition) GetLastTransitionTime() *metav1.Time {
+	return c.LastTransitionTime
+}
+
+func (c *CertificateRequestCondition) SetLastTransitionTime(t *metav1.Time) {
+	c.LastTransitionTime = t
+}
+
+func (c *CertificateRequestCondition) GetObservedGeneration() int64 {
+	return c.ObservedGeneration
+}
+
+func (c *IssuerCondition) GetType() string {
+	return string(c.Type)
+}
+
+func (c *IssuerCondition) GetStatus() cmmeta.ConditionStatus {
+	return c.Status
+}
+
+func (c *IssuerCondition) GetLastTransitionTime() *metav1.Time {
+	return c.LastTransitionTime
+}
+
+func (c *IssuerCondition) SetLastTransitionTime(t *metav1.Time) {
+	c.LastTransitionTime = t
+}
+
+func (c *IssuerCondition) GetObservedGeneration() int64 {
+	return c.ObservedGeneration
+}
@@ -54,8 +54,22 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&IssuerList{},
 		&ClusterIssuer{},
 		&ClusterIssuerList{},
+		&ClusterCertificateReport{},
+		&ClusterCertificateReportList{},
 		&CertificateRequest{},
 		&CertificateRequestList{},
+		&CertificateQuota{},
+		&CertificateQuotaList{},
+		&IssuanceHook{},
+		&IssuanceHookList{},
+		&IssuerReferenceGrant{},
+		&IssuerReferenceGrantList{},
+		&Notifier{},
+		&NotifierList{},
+		&RenewalWindow{},
+		&RenewalWindowList{},
+		&TLSSecretReport{},
+		&TLSSecretReportList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -30,6 +30,11 @@ const (
 	// Annotation key for certificate common name.
 	CommonNameAnnotationKey = "cert-manager.io/common-name"
 
+	// Annotation key for the hex encoded serial number of the certificate
+	// stored in a Secret. It is used to verify that a Secret restored from a
+	// backup still holds the certificate it was annotated with.
+	SerialNumberAnnotationKey = "cert-manager.io/serial-number"
+
 	// Duration key for certificate duration.
 	DurationAnnotationKey = "cert-manager.io/duration"
 
@@ -55,6 +60,10 @@ const (
 	// as a 'next private key' Secret resource.
 	IsNextPrivateKeySecretLabelKey = "cert-manager.io/next-private-key"
 
+	// Label key used to denote that a Secret is a snapshot of the Secret of a
+	// Certificate annotated with `cert-manager.io/secret-snapshot-limit`.
+	SecretSnapshotLabelKey = "cert-manager.io/secret-snapshot"
+
 	// Annotation key used to limit the number of CertificateRequests to be kept for a Certificate.
 	// Minimum value is 1.
 	// If unset all CertificateRequests will be kept.
@@ -86,6 +95,216 @@ const (
 	// Annotation key used to set the PrivateKeyRotationPolicy for a Certificate.
 	// If unset a policy `Never` will be used.
 	PrivateKeyRotationPolicyAnnotationKey = "cert-manager.io/private-key-rotation-policy"
+
+	// Annotation key used to allow a Certificate to overwrite the certificate
+	// in an existing Secret which is not managed by cert-manager, or which is
+	// managed for a different Certificate.
+	// If unset, or set to any value other than `true`, cert-manager will not
+	// write to such a Secret.
+	AllowSecretOverwriteAnnotationKey = "cert-manager.io/allow-secret-overwrite"
+
+	// Annotation key used to allow an update of a Certificate to change
+	// fields which are otherwise immutable, i.e. `spec.secretName` and
+	// `spec.privateKey.algorithm`. Changing these fields leaves the Secret or
+	// the private key used by consumers behind, so the annotation must be
+	// set to `true` on the update to confirm the change is intended.
+	AllowImmutableFieldUpdatesAnnotationKey = "cert-manager.io/allow-immutable-field-updates"
+
+	// Annotation key used to opt a Certificate in to restarting the
+	// Deployments and StatefulSets in its namespace which consume its Secret,
+	// so that workloads which don't reload certificates pick up renewals.
+	// If unset, or set to any value other than `true`, workloads are not
+	// restarted.
+	RestartWorkloadsAnnotationKey = "cert-manager.io/restart-workloads"
+
+	// Annotation key set on Deployments and StatefulSets restarted for
+	// Certificates annotated with `cert-manager.io/restart-workloads`. It
+	// records the revision of each Certificate that the workload was last
+	// restarted for, as a comma separated list of `<name>=<revision>`.
+	RestartedForCertificatesAnnotationKey = "cert-manager.io/restarted-for-certificates"
+
+	// Annotation key used to opt a Certificate in to being written to the
+	// host filesystem by the cert-manager node agent. The value is the
+	// absolute path, without extension, that the certificate and private key
+	// are written to as `<path>.crt` and `<path>.key`, e.g.
+	// `/etc/kubernetes/pki/etcd/peer`.
+	HostPathAnnotationKey = "cert-manager.io/host-path"
+
+	// Annotation key used to write the CA certificate of a Certificate
+	// annotated with `cert-manager.io/host-path` to the given absolute path.
+	HostPathCAAnnotationKey = "cert-manager.io/host-path-ca"
+
+	// Annotation key used to restrict the nodes that a Certificate annotated
+	// with `cert-manager.io/host-path` is written to to the named node. If
+	// unset, the Certificate is written by the node agents on all nodes.
+	HostPathNodeAnnotationKey = "cert-manager.io/host-path-node"
+
+	// Annotation key used to set the owner of the files written for a
+	// Certificate annotated with `cert-manager.io/host-path`, as
+	// `<uid>:<gid>`. If unset, the files are owned by the node agent.
+	HostPathOwnerAnnotationKey = "cert-manager.io/host-path-owner"
+
+	// Annotation key used to set the octal permissions of the files written
+	// for a Certificate annotated with `cert-manager.io/host-path`. Defaults
+	// to `0600`.
+	HostPathModeAnnotationKey = "cert-manager.io/host-path-mode"
+
+	// Annotation key used to send SIGHUP to the host processes with the given
+	// command name once the files of a Certificate annotated with
+	// `cert-manager.io/host-path` have been updated, e.g. `etcd`.
+	HostPathReloadProcessAnnotationKey = "cert-manager.io/host-path-reload-process"
+
+	// Annotation key used to request the revocation of the certificate
+	// currently stored in the Secret of a Certificate. If set to `true`, and
+	// the Certificate is issued by a Vault issuer with `revocation`
+	// configured, the certificate is revoked and the annotation is removed.
+	// The Certificate is not renewed; use `cmctl renew` to issue a new
+	// certificate.
+	RevokeCertificateAnnotationKey = "cert-manager.io/revoke"
+
+	// Annotation key used to set the endpoints that the certificate of a
+	// Certificate is expected to be served on, as a comma separated list of
+	// `<host>:<port>`. If the drift controller is enabled, it periodically
+	// connects to these endpoints, as well as to the hosts of Ingresses and
+	// Gateways using the Certificate's Secret, and sets the `Drifted`
+	// condition if any of them serves a different certificate.
+	DriftCheckEndpointsAnnotationKey = "cert-manager.io/drift-check-endpoints"
+
+	// Annotation key set on the Secret of a Certificate by the split issuance
+	// controller, to record the comma separated names of the split issuances
+	// whose certificates are stored in the Secret. Entries of split issuances
+	// which are no longer listed on the Certificate are removed.
+	SplitIssuancesAnnotationKey = "cert-manager.io/split-issuances"
+
+	// Annotation key used to request the emergency re-issuance of a
+	// Certificate, whose value is the hex encoded serial number of the
+	// compromised certificate. As long as the certificate stored in the
+	// Secret of the Certificate has this serial number, it is re-issued
+	// immediately, regardless of its renewal time, RenewalWindows and the
+	// backoff after failed issuances. The annotation is set by the revocation
+	// check controller when the OCSP responder of a certificate reports it
+	// as revoked, and can also be set by hand.
+	EmergencyReissuanceAnnotationKey = "cert-manager.io/emergency"
+
+	// Annotation key used to opt a Certificate in to keeping snapshots of
+	// the contents of its Secret for the given number of issued revisions.
+	// Each snapshot is stored in a Secret named `<secretName>-<revision>`,
+	// which is owned by the Certificate.
+	SecretSnapshotLimitAnnotationKey = "cert-manager.io/secret-snapshot-limit"
+
+	// Annotation key used to request that the Secret of a Certificate is
+	// rolled back to the snapshot of the given revision. Once the snapshot
+	// has been restored, the annotation is removed. The Certificate is not
+	// re-issued, so the restored certificate is renewed as usual.
+	RollbackRevisionAnnotationKey = "cert-manager.io/rollback-revision"
+
+	// Annotation key used to set the amount of time by which the NotBefore
+	// of the certificates issued for a Certificate is set before the time of
+	// issuance, e.g. `5m`, overriding the notBeforeBackdate of a CA or
+	// SelfSigned issuer. The annotation is copied to the CertificateRequests
+	// of the Certificate, and is also honoured on CertificateSigningRequests.
+	NotBeforeBackdateAnnotationKey = "cert-manager.io/not-before-backdate"
+
+	// Annotation key used to align the expiry and renewal of the certificates
+	// issued for a Certificate to a time of day, e.g. `03:00` or
+	// `03:00 Europe/Berlin`, so that they happen outside of traffic peaks.
+	// The requested duration is shortened by less than a day so that the
+	// certificate expires at that time, and the renewal time is moved back
+	// to the previous occurrence of that time. The annotation is copied to
+	// the CertificateRequests of the Certificate.
+	ValidityAlignmentAnnotationKey = "cert-manager.io/validity-alignment"
+
+	// Annotation key used to declare the Certificates which must be Ready
+	// before a Certificate is issued for the first time, as a comma
+	// separated list of the names of Certificates in the same namespace. It
+	// is used to sequence the initial issuance of a self-bootstrapped PKI,
+	// e.g. an intermediate CA after the self-signed root CA whose Secret
+	// backs the CA issuer of the intermediate. Renewals are never held back.
+	BootstrapAfterAnnotationKey = "cert-manager.io/bootstrap-after"
+)
+
+const (
+	// VaultRevocationFinalizer is added to Certificates issued by a Vault
+	// issuer with `revocation.revokeOnDelete` enabled, so that their
+	// certificate can be revoked before the Certificate is deleted.
+	VaultRevocationFinalizer = "finalizer.vault.cert-manager.io"
+
+	// SecretCleanupFinalizer is added to Certificates with a
+	// `secretDeletionPolicy` of `Delete`, so that the Secrets they managed
+	// can be deleted before the Certificate is deleted.
+	SecretCleanupFinalizer = "finalizer.secret-cleanup.cert-manager.io"
+)
+
+// Annotation names for Issuers and ClusterIssuers
+const (
+	// Annotation key used to opt an Issuer in to canary rollouts. When the
+	// spec of the Issuer changes, only the Certificates referencing it which
+	// match this label selector are renewed first.
+	RolloutCanarySelectorAnnotationKey = "cert-manager.io/rollout-canary-selector"
+
+	// Annotation key used to set how long the canary Certificates of a
+	// rollout must stay Ready before the remaining Certificates are renewed.
+	// If unset, a soak period of 24 hours is used.
+	RolloutSoakPeriodAnnotationKey = "cert-manager.io/rollout-soak-period"
+
+	// Annotation key used to manually approve the rollout of an Issuer spec.
+	// If set to the current metadata.generation of the Issuer, the remaining
+	// Certificates are renewed without waiting for the soak period.
+	RolloutApprovedGenerationAnnotationKey = "cert-manager.io/rollout-approved-generation"
+
+	// Annotation key used to opt a CA Issuer in to the fan-out of CA
+	// rotations. When the Secret of the CA changes, the Certificates issued
+	// by the Issuer are updated according to the action, one of `RefreshCA`
+	// or `Reissue`.
+	CARotationActionAnnotationKey = "cert-manager.io/ca-rotation-action"
+
+	// Annotation key used to set the maximum number of Certificates updated
+	// at once after a CA rotation. If unset, 10 Certificates are updated at
+	// once.
+	CARotationBatchSizeAnnotationKey = "cert-manager.io/ca-rotation-batch-size"
+
+	// Annotation key used to set how long to wait between two batches of
+	// Certificates updated after a CA rotation. If unset, batches are one
+	// minute apart.
+	CARotationIntervalAnnotationKey = "cert-manager.io/ca-rotation-interval"
+
+	// Annotation key used to opt an Issuer in to the deduplication of
+	// CertificateRequests, e.g. `10m`. A CertificateRequest whose CSR and
+	// requested duration, usages and isCA are identical to those of a
+	// CertificateRequest in the same namespace issued by the Issuer within
+	// this window is given the certificate issued for that request, rather
+	// than being signed again. This avoids consuming the quota of the CA
+	// when requests are repeated, e.g. after a controller restart.
+	RequestDedupWindowAnnotationKey = "cert-manager.io/request-dedup-window"
+)
+
+// Values of the ca-rotation-action annotation of CA Issuers.
+const (
+	// CARotationActionRefreshCA replaces the `ca.crt` of the Secrets of the
+	// Certificates whose certificate is still signed by the key of the
+	// rotated CA. Certificates signed by another key are left as is.
+	CARotationActionRefreshCA = "RefreshCA"
+
+	// CARotationActionReissue re-issues the Certificates whose certificate
+	// was not signed by the current CA certificate.
+	CARotationActionReissue = "Reissue"
+)
+
+// Common label keys added to the resources created for a Certificate, i.e.
+// its CertificateRequests, Orders, Challenges and Secret. All resources of a
+// single issuance can be selected with a selector on these labels.
+const (
+	// Label key for the name of the Certificate that a resource belongs to.
+	CertificateNameLabelKey = "cert-manager.io/certificate-name"
+
+	// Label key for the revision of the Certificate that a resource belongs to.
+	CertificateRevisionLabelKey = "cert-manager.io/certificate-revision"
+
+	// Label key for the name of the issuer of the Certificate.
+	IssuerNameLabelKey = "cert-manager.io/issuer-name"
+
+	// Label key for the kind of the issuer of the Certificate.
+	IssuerKindLabelKey = "cert-manager.io/issuer-kind"
 )
 
 const (
@@ -104,6 +323,13 @@ const (
 	// controller only processes Ingresses with this annotation either unset, or
 	// set to either the configured value or the empty string.
 	IngressClassAnnotationKey = "kubernetes.io/ingress.class"
+
+	// IngressControllerNameAnnotationKey picks the cert-manager installation
+	// which manages the Certificates created for an Ingress or Gateway. Its
+	// value is copied to the spec.controllerName of the Certificates, and the
+	// Ingress or Gateway is ignored by installations with a different
+	// `--controller-class`.
+	IngressControllerNameAnnotationKey = "cert-manager.io/controller-name"
 )
 
 // Annotation names for CertificateRequests
@@ -117,6 +343,16 @@ const (
 
 	// Annotation to declare the CertificateRequest "revision", belonging to a Certificate Resource
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// Annotation added to CertificateRequest resources created for a split
+	// issuance of a Certificate, to denote the name of the split issuance.
+	// These requests do not have a revision.
+	CertificateRequestSplitIssuanceAnnotationKey = "cert-manager.io/split-issuance-name"
+
+	// Annotation added to CertificateRequest resources to list the usages of
+	// the Certificate which were left out of the request because its issuer
+	// does not support them, as a comma-separated list.
+	CertificateRequestDroppedUsagesAnnotationKey = "cert-manager.io/dropped-usages"
 )
 
 const (
@@ -169,10 +405,30 @@ const (
 	// for example: `[{"name": "custom-field", "value": "custom-value"}]`
 	VenafiCustomFieldsAnnotationKey = "venafi.cert-manager.io/custom-fields"
 
+	// VenafiCustomFieldAnnotationPrefix is the prefix of annotations which
+	// each pass on a single plain custom field to the Venafi issuer, named
+	// after the rest of the annotation key.
+	// For example, `custom-field.venafi.cert-manager.io/cost-center: "1234"`.
+	// As the annotations of Certificates are copied to their
+	// CertificateRequests, custom fields can be set on Certificates.
+	// Custom fields with names that aren't valid annotation keys must be set
+	// using VenafiCustomFieldsAnnotationKey.
+	VenafiCustomFieldAnnotationPrefix = "custom-field.venafi.cert-manager.io/"
+
 	// VenafiPickupIDAnnotationKey is the annotation key used to record the
 	// Venafi Pickup ID of a certificate signing request that has been submitted
 	// to the Venafi API for collection later.
+	// Deprecated: the pickup ID is now recorded in the status of
+	// CertificateRequests. The annotation is only read to resume requests
+	// submitted by earlier versions of cert-manager.
 	VenafiPickupIDAnnotationKey = "venafi.cert-manager.io/pickup-id"
+
+	// OfflineCertificateAnnotationKey is the annotation used to import the
+	// PEM encoded certificate chain signed by an offline CA into a
+	// CertificateRequest of an offline issuer. It is set by
+	// `cmctl x offline import`, and is only accepted if the certificate
+	// matches the request and chains up to the CA bundle of the issuer.
+	OfflineCertificateAnnotationKey = "offline.cert-manager.io/certificate"
 )
 
 // KeyUsage specifies valid usage contexts for keys.
@@ -195,6 +451,7 @@ const (
 // "server auth",
 // "client auth",
 // "code signing",
+// "document signing",
 // "email protection",
 // "s/mime",
 // "ipsec end system",
@@ -204,7 +461,7 @@ const (
 // "ocsp signing",
 // "microsoft sgc",
 // "netscape sgc"
-// +kubebuilder:validation:Enum="signing";"digital signature";"content commitment";"key encipherment";"key agreement";"data encipherment";"cert sign";"crl sign";"encipher only";"decipher only";"any";"server auth";"client auth";"code signing";"email protection";"s/mime";"ipsec end system";"ipsec tunnel";"ipsec user";"timestamping";"ocsp signing";"microsoft sgc";"netscape sgc"
+// +kubebuilder:validation:Enum="signing";"digital signature";"content commitment";"key encipherment";"key agreement";"data encipherment";"cert sign";"crl sign";"encipher only";"decipher only";"any";"server auth";"client auth";"code signing";"document signing";"email protection";"s/mime";"ipsec end system";"ipsec tunnel";"ipsec user";"timestamping";"ocsp signing";"microsoft sgc";"netscape sgc"
 type KeyUsage string
 
 const (
@@ -222,6 +479,7 @@ const (
 	UsageServerAuth        KeyUsage = "server auth"
 	UsageClientAuth        KeyUsage = "client auth"
 	UsageCodeSigning       KeyUsage = "code signing"
+	UsageDocumentSigning   KeyUsage = "document signing"
 	UsageEmailProtection   KeyUsage = "email protection"
 	UsageSMIME             KeyUsage = "s/mime"
 	UsageIPsecEndSystem    KeyUsage = "ipsec end system"
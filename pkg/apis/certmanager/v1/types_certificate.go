@@ -53,7 +53,7 @@ type CertificateList struct {
 	Items []Certificate `json:"items"`
 }
 
-// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519;MLDSA;CompositeMLDSA
 type PrivateKeyAlgorithm string
 
 const (
@@ -65,6 +65,15 @@ const (
 
 	// Denotes the Ed25519 private key type.
 	Ed25519KeyAlgorithm PrivateKeyAlgorithm = "Ed25519"
+
+	// Denotes the experimental ML-DSA (FIPS 204) private key type. The size
+	// is the ML-DSA parameter set: 44, 65 or 87.
+	MLDSAKeyAlgorithm PrivateKeyAlgorithm = "MLDSA"
+
+	// Denotes the experimental composite ML-DSA private key type, which
+	// pairs an ML-DSA key with a traditional key. The size is the ML-DSA
+	// parameter set: 44, 65 or 87.
+	CompositeMLDSAKeyAlgorithm PrivateKeyAlgorithm = "CompositeMLDSA"
 )
 
 // +kubebuilder:validation:Enum=PKCS1;PKCS8
@@ -86,6 +95,14 @@ const (
 // CertificateSpec defines the desired state of Certificate.
 // A valid Certificate requires at least one of a CommonName, DNSName, or
 // URISAN to be valid.
+// These rules are also checked by the webhook; they are enforced by the API
+// server so that basic errors are rejected on update when the webhook is
+// unavailable. They are transition rules which only reject a Certificate
+// whose previous spec satisfied them, so that Certificates created before
+// they were enforced can still be updated. API servers older than Kubernetes
+// 1.25 ignore them.
+// +kubebuilder:validation:XValidation:rule="!((has(oldSelf.commonName) && size(oldSelf.commonName) > 0) || (has(oldSelf.literalSubject) && size(oldSelf.literalSubject) > 0) || (has(oldSelf.dnsNames) && size(oldSelf.dnsNames) > 0) || (has(oldSelf.uris) && size(oldSelf.uris) > 0) || (has(oldSelf.ipAddresses) && size(oldSelf.ipAddresses) > 0) || (has(oldSelf.emailAddresses) && size(oldSelf.emailAddresses) > 0)) || (has(self.commonName) && size(self.commonName) > 0) || (has(self.literalSubject) && size(self.literalSubject) > 0) || (has(self.dnsNames) && size(self.dnsNames) > 0) || (has(self.uris) && size(self.uris) > 0) || (has(self.ipAddresses) && size(self.ipAddresses) > 0) || (has(self.emailAddresses) && size(self.emailAddresses) > 0)",message="at least one of commonName, literalSubject, dnsNames, uris, ipAddresses, or emailAddresses must be set"
+// +kubebuilder:validation:XValidation:rule="(has(oldSelf.renewBefore) && duration(oldSelf.renewBefore) >= (has(oldSelf.duration) ? duration(oldSelf.duration) : duration('2160h'))) || !has(self.renewBefore) || duration(self.renewBefore) < (has(self.duration) ? duration(self.duration) : duration('2160h'))",message="renewBefore must be less than duration"
 type CertificateSpec struct {
 	// Full X509 name specification (https://golang.org/pkg/crypto/x509/pkix/#Name).
 	// +optional
@@ -110,7 +127,11 @@ type CertificateSpec struct {
 	// 90 days. Certificate will be renewed either 2/3 through its duration or
 	// `renewBefore` period before its expiry, whichever is later. Minimum
 	// accepted duration is 1 hour. Value must be in units accepted by Go
-	// time.ParseDuration https://golang.org/pkg/time/#ParseDuration
+	// time.ParseDuration https://golang.org/pkg/time/#ParseDuration, or in
+	// days (d), weeks (w) or years (y) of 24 hours, 7 days and 365 days, e.g.
+	// "90d" or "1y". Durations using days, weeks or years are converted by the
+	// cert-manager webhook, and are rejected if it is not installed.
+	// +kubebuilder:validation:Pattern=`^[-+]?(0|(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|μs|ms|s|m|h))+)$`
 	// +optional
 	Duration *metav1.Duration `json:"duration,omitempty"`
 
@@ -118,7 +139,11 @@ type CertificateSpec struct {
 	// cert-manager should renew the certificate. The default is 2/3 of the
 	// issued certificate's duration. Minimum accepted value is 5 minutes.
 	// Value must be in units accepted by Go time.ParseDuration
-	// https://golang.org/pkg/time/#ParseDuration
+	// https://golang.org/pkg/time/#ParseDuration, or in days (d), weeks (w) or
+	// years (y) of 24 hours, 7 days and 365 days, e.g. "30d". Durations using
+	// days, weeks or years are converted by the cert-manager webhook, and are
+	// rejected if it is not installed.
+	// +kubebuilder:validation:Pattern=`^[-+]?(0|(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|μs|ms|s|m|h))+)$`
 	// +optional
 	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
 
@@ -202,8 +227,110 @@ type CertificateSpec struct {
 	// the controller and webhook components.
 	// +optional
 	AdditionalOutputFormats []CertificateAdditionalOutputFormat `json:"additionalOutputFormats,omitempty"`
+
+	// ClientIdentity configures this Certificate to be a client certificate
+	// identifying a ServiceAccount, for use in mutual TLS.
+	// When set, `usages` defaults to `digital signature`, `key encipherment`
+	// and `client auth`, a SPIFFE URI SAN is added for the ServiceAccount, the
+	// common name defaults to the ServiceAccount's username, and the target
+	// Secret contains a `tls-client-bundle.pem` entry with the private key and
+	// signed certificate chain.
+	// +optional
+	ClientIdentity *CertificateClientIdentity `json:"clientIdentity,omitempty"`
+
+	// SMIME configures this Certificate to be an S/MIME certificate, used to
+	// sign and encrypt email, for example for the mailboxes of users managed
+	// through Kubernetes.
+	// When set, `usages` defaults to `digital signature`, `key encipherment`
+	// and `email protection`, at least one of `emailAddresses` must be set,
+	// and the target Secret contains a `smime.p12` PKCS#12 bundle with the
+	// private key and signed certificate chain, which can be imported into
+	// mail clients. S/MIME certificates can be issued by the CA and Venafi
+	// issuers.
+	// +optional
+	SMIME *CertificateSMIME `json:"smime,omitempty"`
+
+	// SplitIssuances issues the DNS names of this Certificate which are in
+	// the given DNS zones from other issuers, for example to obtain
+	// certificates for mesh-internal names from a private CA while the public
+	// names are issued by `issuerRef`. The DNS names of a split issuance are
+	// removed from the certificate issued by `issuerRef`, and the certificate
+	// of each split issuance is stored in the target Secret alongside it,
+	// using the same private key.
+	// This is an Alpha Feature and is only enabled with the
+	// `--feature-gates=SplitIssuance=true` option on both the controller and
+	// webhook components.
+	// +optional
+	SplitIssuances []CertificateSplitIssuance `json:"splitIssuances,omitempty"`
+
+	// ControllerName is the class of the cert-manager controller which
+	// should manage this Certificate, for running several installations of
+	// cert-manager in one cluster. It is matched against the
+	// `--controller-class` flag of the controller; if unset, the Certificate
+	// is only managed by controllers which have no class configured.
+	// +optional
+	ControllerName string `json:"controllerName,omitempty"`
+
+	// CrossSignedChain configures which chain is written to the target
+	// Secret if the issuer returns a chain to another root, which cross-signed
+	// the root of its CA, alongside the certificate. One of `Primary`, where
+	// `tls.crt` and `ca.crt` hold the chain to the root of the CA, `CrossSigned`,
+	// where they hold the chain to the other root, or `Both`, where `tls.crt`
+	// holds the chain to the other root and `ca.crt` holds both roots, so that
+	// clients trusting either root can verify the certificate during a root
+	// rotation. Changes take effect on the next issuance. Defaults to `Primary`.
+	// +optional
+	CrossSignedChain CrossSignedChainPolicy `json:"crossSignedChain,omitempty"`
+
+	// SecretDeletionPolicy controls whether the Secrets managed by this
+	// Certificate are deleted when the Certificate is deleted. One of
+	// `Retain`, where the Secrets are left in place, or `Delete`, where they
+	// are deleted by the `certificates-secret-cleanup` controller, which must
+	// be enabled. Defaults to `Retain`.
+	// +optional
+	SecretDeletionPolicy SecretDeletionPolicy `json:"secretDeletionPolicy,omitempty"`
+
+	// AdditionalCABundle is a reference to a key of a ConfigMap or Secret in
+	// the namespace of the Certificate holding PEM encoded CA certificates,
+	// which are appended to the `ca.crt` entry of the target Secret whenever
+	// it is written. This is for applications which use `ca.crt` as their
+	// only trust store, and need to trust other roots than the issuing CA.
+	// Changes to the bundle are picked up the next time the Secret is written.
+	// +optional
+	AdditionalCABundle *CertificateAdditionalCABundle `json:"additionalCABundle,omitempty"`
 }
 
+// CrossSignedChainPolicy configures which chain is written to the Secret of a
+// Certificate whose issuer returns a cross-signed chain.
+// +kubebuilder:validation:Enum=Primary;CrossSigned;Both
+type CrossSignedChainPolicy string
+
+const (
+	// WritePrimaryChain writes the chain to the root of the CA of the issuer.
+	WritePrimaryChain CrossSignedChainPolicy = "Primary"
+
+	// WriteCrossSignedChain writes the chain to the root which
+	// cross-signed the root of the CA of the issuer.
+	WriteCrossSignedChain CrossSignedChainPolicy = "CrossSigned"
+
+	// WriteBothChains writes the chain to the root which cross-signed the
+	// root of the CA of the issuer, and both roots as the CA.
+	WriteBothChains CrossSignedChainPolicy = "Both"
+)
+
+// SecretDeletionPolicy controls whether the Secrets managed by a Certificate
+// are deleted when the Certificate is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type SecretDeletionPolicy string
+
+const (
+	// RetainSecret leaves the Secrets of the Certificate in place.
+	RetainSecret SecretDeletionPolicy = "Retain"
+
+	// DeleteSecret deletes the Secrets of the Certificate.
+	DeleteSecret SecretDeletionPolicy = "Delete"
+)
+
 // CertificatePrivateKey contains configuration options for private keys
 // used by the Certificate controller.
 // This allows control of how private keys are rotated.
@@ -235,6 +362,10 @@ type CertificatePrivateKey struct {
 	// key size of 256 will be used for `ECDSA` key algorithm and
 	// key size of 2048 will be used for `RSA` key algorithm.
 	// key size is ignored when using the `Ed25519` key algorithm.
+	// The experimental `MLDSA` and `CompositeMLDSA` key algorithms are only
+	// supported by cert-manager installations where the
+	// PluggableKeyAlgorithms feature gate is enabled on both cert-manager
+	// controller and webhook, and an implementation is registered.
 	// +optional
 	Algorithm PrivateKeyAlgorithm `json:"algorithm,omitempty"`
 
@@ -307,6 +438,80 @@ type CertificateAdditionalOutputFormat struct {
 	Type CertificateOutputFormatType `json:"type"`
 }
 
+// CertificateClientIdentity defines the ServiceAccount identified by a client
+// certificate.
+type CertificateClientIdentity struct {
+	// ServiceAccountName is the name of the ServiceAccount, in the namespace
+	// of the Certificate, that the client certificate identifies.
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// TrustDomain is the SPIFFE trust domain used in the URI SAN of the
+	// client certificate, which is
+	// `spiffe://<trustDomain>/ns/<namespace>/sa/<serviceAccountName>`.
+	// Defaults to `cluster.local`.
+	// +optional
+	TrustDomain string `json:"trustDomain,omitempty"`
+}
+
+// CertificateClientBundleKey is the name of the data entry in the Secret
+// resource of a Certificate with a ClientIdentity used to store the client
+// bundle. The bundle contains the PEM formatted private key followed by the
+// signed certificate chain.
+const CertificateClientBundleKey string = "tls-client-bundle.pem"
+
+// CertificateSMIME configures the PKCS#12 bundle of an S/MIME certificate.
+type CertificateSMIME struct {
+	// PasswordSecretRef is a reference to a key in a Secret resource
+	// containing the password used to encrypt the `smime.p12` bundle.
+	PasswordSecretRef cmmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// CertificateSMIMEBundleKey is the name of the data entry in the Secret
+// resource of an S/MIME Certificate used to store the PKCS#12 bundle.
+const CertificateSMIMEBundleKey string = "smime.p12"
+
+// CertificateAdditionalCABundle references the CA certificates appended to
+// the `ca.crt` entry of the target Secret of a Certificate. Exactly one of
+// `configMap` or `secret` must be set.
+type CertificateAdditionalCABundle struct {
+	// ConfigMap is a reference to a key of a ConfigMap holding the bundle.
+	// +optional
+	ConfigMap *CABundleKeySelector `json:"configMap,omitempty"`
+
+	// Secret is a reference to a key of a Secret holding the bundle.
+	// +optional
+	Secret *CABundleKeySelector `json:"secret,omitempty"`
+}
+
+// CABundleKeySelector selects a key of a ConfigMap or Secret resource in the
+// namespace of the Certificate.
+type CABundleKeySelector struct {
+	// Name of the resource.
+	Name string `json:"name"`
+
+	// Key of the entry holding the PEM encoded CA certificates.
+	Key string `json:"key"`
+}
+
+// CertificateSplitIssuance defines a set of DNS names of a Certificate which
+// are issued by a different issuer than the rest of the Certificate.
+type CertificateSplitIssuance struct {
+	// Name of the split issuance. The signed certificate chain is stored in
+	// the `<name>.crt` entry of the target Secret, and the CA certificate, if
+	// known, in the `<name>-ca.crt` entry. The private key is the one stored
+	// in the `tls.key` entry.
+	Name string `json:"name"`
+
+	// DNSZones is the list of DNS zones whose names are issued by IssuerRef.
+	// A DNS name of the Certificate belongs to a zone if it is equal to, or a
+	// subdomain of, the zone. If a name belongs to the zones of several split
+	// issuances, it is issued by the one with the longest matching zone.
+	DNSZones []string `json:"dnsZones"`
+
+	// IssuerRef is a reference to the issuer of the DNS names in DNSZones.
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+}
+
 // X509Subject Full X509 name specification
 type X509Subject struct {
 	// Organizations to be used on the Certificate.
@@ -388,7 +593,7 @@ type PKCS12Keystore struct {
 // CertificateStatus defines the observed state of Certificate
 type CertificateStatus struct {
 	// List of status conditions to indicate the status of certificates.
-	// Known condition types are `Ready` and `Issuing`.
+	// Known condition types are `Ready`, `Issuing` and `Drifted`.
 	// +listType=map
 	// +listMapKey=type
 	// +optional
@@ -412,6 +617,11 @@ type CertificateStatus struct {
 	// +optional
 	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 
+	// The hex encoded serial number of the certificate stored in the
+	// Secret of the Certificate.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
 	// RenewalTime is the time at which the certificate will be next
 	// renewed.
 	// If not set, no upcoming renewal is scheduled.
@@ -451,11 +661,100 @@ type CertificateStatus struct {
 	// time.Hour * 2 ^ (failedIssuanceAttempts - 1).
 	// +optional
 	FailedIssuanceAttempts *int `json:"failedIssuanceAttempts,omitempty"`
+
+	// TransparencyLogEntry is the entry of the current certificate in the
+	// transparency log that issued certificates are published to, if the
+	// controller has been configured with a transparency log.
+	// +optional
+	TransparencyLogEntry *CertificateTransparencyLogEntry `json:"transparencyLogEntry,omitempty"`
+
+	// Certificate describes the X.509 certificate currently stored in the
+	// Secret of this Certificate, so that it can be inspected without
+	// decoding the Secret.
+	// +optional
+	Certificate *CertificateDetails `json:"certificate,omitempty"`
+}
+
+// CertificateTransparencyLogEntry is the entry of an issued certificate in a
+// Rekor compatible transparency log.
+type CertificateTransparencyLogEntry struct {
+	// LogURL is the URL of the transparency log that the certificate was
+	// published to.
+	LogURL string `json:"logURL"`
+
+	// UUID is the unique identifier of the entry in the transparency log.
+	UUID string `json:"uuid"`
+
+	// LogIndex is the index of the entry in the transparency log.
+	LogIndex int64 `json:"logIndex"`
+
+	// Fingerprint is the hex encoded SHA-256 fingerprint of the DER encoded
+	// certificate that was published.
+	Fingerprint string `json:"fingerprint"`
+
+	// IntegratedTime is the time at which the entry was added to the
+	// transparency log.
+	// +optional
+	IntegratedTime *metav1.Time `json:"integratedTime,omitempty"`
+
+	// InclusionProof proves that the entry is included in the Merkle tree of
+	// the transparency log.
+	// +optional
+	InclusionProof *CertificateTransparencyLogInclusionProof `json:"inclusionProof,omitempty"`
+}
+
+// CertificateTransparencyLogInclusionProof is the proof that an entry is
+// included in the Merkle tree of a transparency log.
+type CertificateTransparencyLogInclusionProof struct {
+	// LogIndex is the index of the entry in the Merkle tree.
+	LogIndex int64 `json:"logIndex"`
+
+	// RootHash is the hex encoded root hash of the Merkle tree that the
+	// proof was computed against.
+	RootHash string `json:"rootHash"`
+
+	// TreeSize is the size of the Merkle tree that the proof was computed
+	// against.
+	TreeSize int64 `json:"treeSize"`
+
+	// Hashes are the hex encoded hashes of the audit path from the entry to
+	// the root hash.
+	Hashes []string `json:"hashes"`
+}
+
+// CertificateDetails describes an issued X.509 certificate.
+type CertificateDetails struct {
+	// SerialNumber is the hex encoded serial number of the certificate.
+	SerialNumber string `json:"serialNumber"`
+
+	// FingerprintSHA256 is the hex encoded SHA-256 fingerprint of the DER
+	// encoded certificate.
+	FingerprintSHA256 string `json:"fingerprintSHA256"`
+
+	// NotBefore is the time from which the certificate is valid.
+	NotBefore metav1.Time `json:"notBefore"`
+
+	// NotAfter is the time until which the certificate is valid.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// Subject is the distinguished name of the subject of the certificate,
+	// formatted as described in RFC 4514.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Issuer is the distinguished name of the issuer of the certificate,
+	// formatted as described in RFC 4514.
+	Issuer string `json:"issuer"`
+
+	// SubjectAltNames is a summary of the subject alternative names of the
+	// certificate, e.g. `DNS:example.com, IP:10.0.0.1 and 3 more`.
+	// +optional
+	SubjectAltNames string `json:"subjectAltNames,omitempty"`
 }
 
 // CertificateCondition contains condition information for an Certificate.
 type CertificateCondition struct {
-	// Type of the condition, known values are (`Ready`, `Issuing`).
+	// Type of the condition, known values are (`Ready`, `Issuing`, `Drifted`).
 	Type CertificateConditionType `json:"type"`
 
 	// Status of the condition, one of (`True`, `False`, `Unknown`).
@@ -514,6 +813,25 @@ const (
 	//
 	// It will be removed by the 'issuing' controller upon completing issuance.
 	CertificateConditionIssuing CertificateConditionType = "Issuing"
+
+	// A condition added to Certificate resources by the drift controller if
+	// it is enabled. It is set to true if any of the endpoints that the
+	// certificate is expected to be served on is serving a different
+	// certificate than the one stored in the Secret, e.g. because a load
+	// balancer or pod has not reloaded a renewed certificate.
+	CertificateConditionDrifted CertificateConditionType = "Drifted"
+
+	// A condition added to Certificate resources by the trigger controller if
+	// the lifetime of the issued certificate is shorter than the requested
+	// spec.duration, but within the durationTolerance configured on the
+	// issuer. It is removed once the issued lifetime matches spec.duration.
+	CertificateConditionIssuerClampedDuration CertificateConditionType = "IssuerClampedDuration"
+
+	// A condition added to Certificate resources by the request manager
+	// controller if usages of the Certificate were left out of its latest
+	// CertificateRequest because they are not supported by the issuer, as
+	// declared by the issuer's spec.capabilities.
+	CertificateConditionUsagesDropped CertificateConditionType = "UsagesDropped"
 )
 
 // CertificateSecretTemplate defines the default labels and annotations
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+
+// A ClusterCertificateReport summarises the Certificates in the cluster, so
+// that dashboards can show their state without scraping metrics.
+// ClusterCertificateReports are written by the certificates report
+// controller, which maintains a single ClusterCertificateReport named
+// `certificates` and updates it periodically.
+type ClusterCertificateReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Summary counts the Certificates in the cluster.
+	Summary ClusterCertificateReportSummary `json:"summary"`
+
+	// Issuers counts the Certificates referencing each issuer, sorted by
+	// group, kind, namespace and name.
+	// +optional
+	Issuers []ClusterCertificateReportIssuer `json:"issuers,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterCertificateReportList is a list of ClusterCertificateReports
+type ClusterCertificateReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterCertificateReport `json:"items"`
+}
+
+// ClusterCertificateReportSummary counts Certificates.
+type ClusterCertificateReportSummary struct {
+	// Total is the number of Certificates.
+	Total int `json:"total"`
+
+	// Ready is the number of Certificates with a Ready condition of True.
+	Ready int `json:"ready"`
+
+	// ExpiringWithin7Days is the number of Certificates whose certificate
+	// expires within 7 days.
+	ExpiringWithin7Days int `json:"expiringWithin7Days"`
+
+	// ExpiringWithin30Days is the number of Certificates whose certificate
+	// expires within 30 days, including those expiring within 7 days.
+	ExpiringWithin30Days int `json:"expiringWithin30Days"`
+
+	// Expired is the number of Certificates whose certificate has expired.
+	Expired int `json:"expired"`
+
+	// FailedIssuances is the number of Certificates whose last issuance
+	// failed.
+	FailedIssuances int `json:"failedIssuances"`
+}
+
+// ClusterCertificateReportIssuer counts the Certificates referencing an
+// issuer.
+type ClusterCertificateReportIssuer struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+
+	// Kind of the issuer.
+	Kind string `json:"kind"`
+
+	// Group of the issuer, empty for the cert-manager.io group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Namespace of the issuer, empty for ClusterIssuers.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Summary counts the Certificates referencing the issuer.
+	Summary ClusterCertificateReportSummary `json:"summary"`
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+
+// A TLSSecretReport lists the TLS Secrets in a namespace and when their
+// certificates expire, including Secrets which are not managed by
+// cert-manager.
+// TLSSecretReports are written by the secret watchdog controller, which
+// maintains a single TLSSecretReport named `tls-secrets` in each namespace
+// that it watches.
+type TLSSecretReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Summary counts the TLS Secrets in the namespace.
+	Summary TLSSecretReportSummary `json:"summary"`
+
+	// Secrets is the list of TLS Secrets in the namespace, sorted by name.
+	// +optional
+	Secrets []TLSSecretReportEntry `json:"secrets,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TLSSecretReportList is a list of TLSSecretReports
+type TLSSecretReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TLSSecretReport `json:"items"`
+}
+
+// TLSSecretReportSummary counts the TLS Secrets in a namespace.
+type TLSSecretReportSummary struct {
+	// Total is the number of TLS Secrets.
+	Total int `json:"total"`
+
+	// Unmanaged is the number of TLS Secrets which are not managed by a
+	// Certificate.
+	Unmanaged int `json:"unmanaged"`
+
+	// ExpiringSoon is the number of TLS Secrets whose certificate expires
+	// within 30 days.
+	ExpiringSoon int `json:"expiringSoon"`
+
+	// Expired is the number of TLS Secrets whose certificate has expired.
+	Expired int `json:"expired"`
+
+	// Invalid is the number of TLS Secrets whose certificate could not be
+	// decoded.
+	Invalid int `json:"invalid"`
+}
+
+// TLSSecretReportEntry describes the certificate of a TLS Secret.
+type TLSSecretReportEntry struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+
+	// Managed is true if the Secret is managed by a Certificate.
+	Managed bool `json:"managed"`
+
+	// CertificateName is the name of the Certificate managing the Secret.
+	// +optional
+	CertificateName string `json:"certificateName,omitempty"`
+
+	// CommonName is the common name of the certificate.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// DNSNames is the list of DNS subjectAltNames of the certificate.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// Issuer is the distinguished name of the issuer of the certificate.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// NotAfter is the time at which the certificate expires.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// Error describes why the certificate of the Secret could not be
+	// decoded.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
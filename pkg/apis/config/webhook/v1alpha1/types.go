@@ -42,6 +42,16 @@ type WebhookConfiguration struct {
 	// Deprecated: use `kubeConfig` instead.
 	APIServerHost string `json:"apiServerHost,omitempty"`
 
+	// kubernetesAPIQPS is the maximum queries-per-second of requests sent to
+	// the Kubernetes apiserver.
+	// Defaults to 5.
+	KubernetesAPIQPS *float32 `json:"kubernetesAPIQPS,omitempty"`
+
+	// kubernetesAPIBurst is the maximum burst of requests sent to the
+	// Kubernetes apiserver.
+	// Defaults to 10.
+	KubernetesAPIBurst *int `json:"kubernetesAPIBurst,omitempty"`
+
 	// enablePprof configures whether pprof is enabled.
 	EnablePprof bool `json:"enablePprof"`
 
@@ -49,6 +59,13 @@ type WebhookConfiguration struct {
 	// Defaults to 'localhost:6060'.
 	PprofAddress string `json:"pprofAddress,omitempty"`
 
+	// shutdownDelay is how long the webhook keeps serving requests after it
+	// has been asked to shut down, while reporting itself as not ready so
+	// that it is removed from the endpoints of its Service first. This
+	// allows replicas to be drained without failing in-flight admission
+	// requests. Defaults to 0s, which shuts down immediately.
+	ShutdownDelay metav1.Duration `json:"shutdownDelay,omitempty"`
+
 	// featureGates is a map of feature names to bools that enable or disable experimental
 	// features.
 	// Default: nil
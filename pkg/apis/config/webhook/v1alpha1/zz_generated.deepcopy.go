@@ -100,6 +100,17 @@ func (in *WebhookConfiguration) DeepCopyInto(out *WebhookConfiguration) {
 		**out = **in
 	}
 	in.TLSConfig.DeepCopyInto(&out.TLSConfig)
+	if in.KubernetesAPIQPS != nil {
+		in, out := &in.KubernetesAPIQPS, &out.KubernetesAPIQPS
+		*out = new(float32)
+		**out = **in
+	}
+	if in.KubernetesAPIBurst != nil {
+		in, out := &in.KubernetesAPIBurst, &out.KubernetesAPIBurst
+		*out = new(int)
+		**out = **in
+	}
+	out.ShutdownDelay = in.ShutdownDelay
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = make(map[string]bool, len(*in))
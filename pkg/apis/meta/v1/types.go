@@ -58,6 +58,13 @@ type ObjectReference struct {
 	// Group of the resource being referred to.
 	// +optional
 	Group string `json:"group,omitempty"`
+	// Namespace of the resource being referred to. Only Issuers may be
+	// referenced in another namespace, and only if that namespace contains
+	// an IssuerReferenceGrant allowing it. This requires the
+	// IssuerReferenceGrants feature gate to be enabled.
+	// If not set, the namespace of the referring resource is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // A reference to a specific 'key' within a Secret resource.
@@ -76,4 +83,9 @@ type SecretKeySelector struct {
 const (
 	// Used as a data key in Secret resources to store a CA certificate.
 	TLSCAKey = "ca.crt"
+
+	// Used as a data key in Secret resources to store previous CA
+	// certificates which should continue to be trusted alongside the one
+	// stored under TLSCAKey, e.g. during a rotation of the CA.
+	TLSPreviousCAKey = "previous-ca.crt"
 )
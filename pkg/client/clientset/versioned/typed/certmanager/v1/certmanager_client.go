@@ -29,9 +29,16 @@ import (
 type CertmanagerV1Interface interface {
 	RESTClient() rest.Interface
 	CertificatesGetter
+	CertificateQuotasGetter
 	CertificateRequestsGetter
+	ClusterCertificateReportsGetter
 	ClusterIssuersGetter
+	IssuanceHooksGetter
 	IssuersGetter
+	IssuerReferenceGrantsGetter
+	NotifiersGetter
+	RenewalWindowsGetter
+	TLSSecretReportsGetter
 }
 
 // CertmanagerV1Client is used to interact with features provided by the cert-manager.io group.
@@ -43,18 +50,46 @@ func (c *CertmanagerV1Client) Certificates(namespace string) CertificateInterfac
 	return newCertificates(c, namespace)
 }
 
+func (c *CertmanagerV1Client) CertificateQuotas(namespace string) CertificateQuotaInterface {
+	return newCertificateQuotas(c, namespace)
+}
+
 func (c *CertmanagerV1Client) CertificateRequests(namespace string) CertificateRequestInterface {
 	return newCertificateRequests(c, namespace)
 }
 
+func (c *CertmanagerV1Client) ClusterCertificateReports() ClusterCertificateReportInterface {
+	return newClusterCertificateReports(c)
+}
+
 func (c *CertmanagerV1Client) ClusterIssuers() ClusterIssuerInterface {
 	return newClusterIssuers(c)
 }
 
+func (c *CertmanagerV1Client) IssuanceHooks() IssuanceHookInterface {
+	return newIssuanceHooks(c)
+}
+
 func (c *CertmanagerV1Client) Issuers(namespace string) IssuerInterface {
 	return newIssuers(c, namespace)
 }
 
+func (c *CertmanagerV1Client) IssuerReferenceGrants(namespace string) IssuerReferenceGrantInterface {
+	return newIssuerReferenceGrants(c, namespace)
+}
+
+func (c *CertmanagerV1Client) Notifiers() NotifierInterface {
+	return newNotifiers(c)
+}
+
+func (c *CertmanagerV1Client) RenewalWindows(namespace string) RenewalWindowInterface {
+	return newRenewalWindows(c, namespace)
+}
+
+func (c *CertmanagerV1Client) TLSSecretReports(namespace string) TLSSecretReportInterface {
+	return newTLSSecretReports(c, namespace)
+}
+
 // NewForConfig creates a new CertmanagerV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
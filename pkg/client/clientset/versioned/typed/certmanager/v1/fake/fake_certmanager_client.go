@@ -32,18 +32,46 @@ func (c *FakeCertmanagerV1) Certificates(namespace string) v1.CertificateInterfa
 	return &FakeCertificates{c, namespace}
 }
 
+func (c *FakeCertmanagerV1) CertificateQuotas(namespace string) v1.CertificateQuotaInterface {
+	return &FakeCertificateQuotas{c, namespace}
+}
+
 func (c *FakeCertmanagerV1) CertificateRequests(namespace string) v1.CertificateRequestInterface {
 	return &FakeCertificateRequests{c, namespace}
 }
 
+func (c *FakeCertmanagerV1) ClusterCertificateReports() v1.ClusterCertificateReportInterface {
+	return &FakeClusterCertificateReports{c}
+}
+
 func (c *FakeCertmanagerV1) ClusterIssuers() v1.ClusterIssuerInterface {
 	return &FakeClusterIssuers{c}
 }
 
+func (c *FakeCertmanagerV1) IssuanceHooks() v1.IssuanceHookInterface {
+	return &FakeIssuanceHooks{c}
+}
+
 func (c *FakeCertmanagerV1) Issuers(namespace string) v1.IssuerInterface {
 	return &FakeIssuers{c, namespace}
 }
 
+func (c *FakeCertmanagerV1) IssuerReferenceGrants(namespace string) v1.IssuerReferenceGrantInterface {
+	return &FakeIssuerReferenceGrants{c, namespace}
+}
+
+func (c *FakeCertmanagerV1) Notifiers() v1.NotifierInterface {
+	return &FakeNotifiers{c}
+}
+
+func (c *FakeCertmanagerV1) RenewalWindows(namespace string) v1.RenewalWindowInterface {
+	return &FakeRenewalWindows{c, namespace}
+}
+
+func (c *FakeCertmanagerV1) TLSSecretReports(namespace string) v1.TLSSecretReportInterface {
+	return &FakeTLSSecretReports{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCertmanagerV1) RESTClient() rest.Interface {
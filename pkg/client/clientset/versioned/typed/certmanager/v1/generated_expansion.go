@@ -20,8 +20,22 @@ package v1
 
 type CertificateExpansion interface{}
 
+type CertificateQuotaExpansion interface{}
+
 type CertificateRequestExpansion interface{}
 
+type ClusterCertificateReportExpansion interface{}
+
 type ClusterIssuerExpansion interface{}
 
+type IssuanceHookExpansion interface{}
+
 type IssuerExpansion interface{}
+
+type IssuerReferenceGrantExpansion interface{}
+
+type NotifierExpansion interface{}
+
+type RenewalWindowExpansion interface{}
+
+type TLSSecretReportExpansion interface{}
@@ -26,12 +26,26 @@ import (
 type Interface interface {
 	// Certificates returns a CertificateInformer.
 	Certificates() CertificateInformer
+	// CertificateQuotas returns a CertificateQuotaInformer.
+	CertificateQuotas() CertificateQuotaInformer
 	// CertificateRequests returns a CertificateRequestInformer.
 	CertificateRequests() CertificateRequestInformer
+	// ClusterCertificateReports returns a ClusterCertificateReportInformer.
+	ClusterCertificateReports() ClusterCertificateReportInformer
 	// ClusterIssuers returns a ClusterIssuerInformer.
 	ClusterIssuers() ClusterIssuerInformer
+	// IssuanceHooks returns a IssuanceHookInformer.
+	IssuanceHooks() IssuanceHookInformer
 	// Issuers returns a IssuerInformer.
 	Issuers() IssuerInformer
+	// IssuerReferenceGrants returns a IssuerReferenceGrantInformer.
+	IssuerReferenceGrants() IssuerReferenceGrantInformer
+	// Notifiers returns a NotifierInformer.
+	Notifiers() NotifierInformer
+	// RenewalWindows returns a RenewalWindowInformer.
+	RenewalWindows() RenewalWindowInformer
+	// TLSSecretReports returns a TLSSecretReportInformer.
+	TLSSecretReports() TLSSecretReportInformer
 }
 
 type version struct {
@@ -50,17 +64,52 @@ func (v *version) Certificates() CertificateInformer {
 	return &certificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CertificateQuotas returns a CertificateQuotaInformer.
+func (v *version) CertificateQuotas() CertificateQuotaInformer {
+	return &certificateQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // CertificateRequests returns a CertificateRequestInformer.
 func (v *version) CertificateRequests() CertificateRequestInformer {
 	return &certificateRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterCertificateReports returns a ClusterCertificateReportInformer.
+func (v *version) ClusterCertificateReports() ClusterCertificateReportInformer {
+	return &clusterCertificateReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterIssuers returns a ClusterIssuerInformer.
 func (v *version) ClusterIssuers() ClusterIssuerInformer {
 	return &clusterIssuerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// IssuanceHooks returns a IssuanceHookInformer.
+func (v *version) IssuanceHooks() IssuanceHookInformer {
+	return &issuanceHookInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Issuers returns a IssuerInformer.
 func (v *version) Issuers() IssuerInformer {
 	return &issuerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// IssuerReferenceGrants returns a IssuerReferenceGrantInformer.
+func (v *version) IssuerReferenceGrants() IssuerReferenceGrantInformer {
+	return &issuerReferenceGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Notifiers returns a NotifierInformer.
+func (v *version) Notifiers() NotifierInformer {
+	return &notifierInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// RenewalWindows returns a RenewalWindowInformer.
+func (v *version) RenewalWindows() RenewalWindowInformer {
+	return &renewalWindowInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TLSSecretReports returns a TLSSecretReportInformer.
+func (v *version) TLSSecretReports() TLSSecretReportInformer {
+	return &tLSSecretReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -62,12 +62,26 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=cert-manager.io, Version=v1
 	case certmanagerv1.SchemeGroupVersion.WithResource("certificates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().Certificates().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("certificatequotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().CertificateQuotas().Informer()}, nil
 	case certmanagerv1.SchemeGroupVersion.WithResource("certificaterequests"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().CertificateRequests().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("clustercertificatereports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().ClusterCertificateReports().Informer()}, nil
 	case certmanagerv1.SchemeGroupVersion.WithResource("clusterissuers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().ClusterIssuers().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("issuancehooks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().IssuanceHooks().Informer()}, nil
 	case certmanagerv1.SchemeGroupVersion.WithResource("issuers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().Issuers().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("issuerreferencegrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().IssuerReferenceGrants().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("notifiers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().Notifiers().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("renewalwindows"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().RenewalWindows().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("tlssecretreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().TLSSecretReports().Informer()}, nil
 
 	}
 
@@ -26,6 +26,14 @@ type CertificateListerExpansion interface{}
 // CertificateNamespaceLister.
 type CertificateNamespaceListerExpansion interface{}
 
+// CertificateQuotaListerExpansion allows custom methods to be added to
+// CertificateQuotaLister.
+type CertificateQuotaListerExpansion interface{}
+
+// CertificateQuotaNamespaceListerExpansion allows custom methods to be added to
+// CertificateQuotaNamespaceLister.
+type CertificateQuotaNamespaceListerExpansion interface{}
+
 // CertificateRequestListerExpansion allows custom methods to be added to
 // CertificateRequestLister.
 type CertificateRequestListerExpansion interface{}
@@ -34,10 +42,18 @@ type CertificateRequestListerExpansion interface{}
 // CertificateRequestNamespaceLister.
 type CertificateRequestNamespaceListerExpansion interface{}
 
+// ClusterCertificateReportListerExpansion allows custom methods to be added to
+// ClusterCertificateReportLister.
+type ClusterCertificateReportListerExpansion interface{}
+
 // ClusterIssuerListerExpansion allows custom methods to be added to
 // ClusterIssuerLister.
 type ClusterIssuerListerExpansion interface{}
 
+// IssuanceHookListerExpansion allows custom methods to be added to
+// IssuanceHookLister.
+type IssuanceHookListerExpansion interface{}
+
 // IssuerListerExpansion allows custom methods to be added to
 // IssuerLister.
 type IssuerListerExpansion interface{}
@@ -45,3 +61,31 @@ type IssuerListerExpansion interface{}
 // IssuerNamespaceListerExpansion allows custom methods to be added to
 // IssuerNamespaceLister.
 type IssuerNamespaceListerExpansion interface{}
+
+// IssuerReferenceGrantListerExpansion allows custom methods to be added to
+// IssuerReferenceGrantLister.
+type IssuerReferenceGrantListerExpansion interface{}
+
+// IssuerReferenceGrantNamespaceListerExpansion allows custom methods to be added to
+// IssuerReferenceGrantNamespaceLister.
+type IssuerReferenceGrantNamespaceListerExpansion interface{}
+
+// NotifierListerExpansion allows custom methods to be added to
+// NotifierLister.
+type NotifierListerExpansion interface{}
+
+// RenewalWindowListerExpansion allows custom methods to be added to
+// RenewalWindowLister.
+type RenewalWindowListerExpansion interface{}
+
+// RenewalWindowNamespaceListerExpansion allows custom methods to be added to
+// RenewalWindowNamespaceLister.
+type RenewalWindowNamespaceListerExpansion interface{}
+
+// TLSSecretReportListerExpansion allows custom methods to be added to
+// TLSSecretReportLister.
+type TLSSecretReportListerExpansion interface{}
+
+// TLSSecretReportNamespaceListerExpansion allows custom methods to be added to
+// TLSSecretReportNamespaceLister.
+type TLSSecretReportNamespaceListerExpansion interface{}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clientutil contains helpers for programs which request certificates
+// from cert-manager, such as operators building on top of cert-manager.
+package clientutil
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// generateName is the prefix of the names of the CertificateRequests created
+// by RequestCertificate.
+const generateName = "certificate-"
+
+// RequestCertificate requests a certificate matching spec, for the public key
+// of key, from the issuer referenced by spec. It creates a CertificateRequest
+// using client, waits until the CertificateRequest has been issued and returns
+// the PEM encoded signed certificate chain.
+// The fields of spec which configure the Secret or the private key are
+// ignored, as the private key is managed by the caller. An error is returned if the CertificateRequest is denied, fails or
+// is deleted, or if ctx is done before it is issued.
+func RequestCertificate(ctx context.Context, client cmclient.CertificateRequestInterface, spec *cmapi.CertificateSpec, key crypto.Signer) ([]byte, error) {
+	req, err := NewCertificateRequest(spec, key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err = client.Create(ctx, req, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CertificateRequest: %w", err)
+	}
+
+	req, err = WaitForCertificateRequest(ctx, client, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return req.Status.Certificate, nil
+}
+
+// NewCertificateRequest returns a CertificateRequest for a certificate
+// matching spec, for the public key of key. The returned CertificateRequest
+// has no namespace, and its name is generated by the API server on creation.
+func NewCertificateRequest(spec *cmapi.CertificateSpec, key crypto.Signer) (*cmapi.CertificateRequest, error) {
+	csr, err := pki.GenerateCSR(&cmapi.Certificate{Spec: *spec})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CSR: %w", err)
+	}
+	// The signature algorithm is chosen based on the given key rather than on
+	// the private key settings of spec.
+	csr.SignatureAlgorithm = x509.UnknownSignatureAlgorithm
+
+	csrDER, err := pki.EncodeCSR(csr, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign CSR: %w", err)
+	}
+
+	return &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: generateName,
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			Request:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}),
+			Duration:  spec.Duration,
+			IssuerRef: spec.IssuerRef,
+			IsCA:      spec.IsCA,
+			Usages:    spec.Usages,
+		},
+	}, nil
+}
+
+// WaitForCertificateRequest watches the CertificateRequest with the given name
+// using client, and returns it once it has been issued. An error is returned
+// if the CertificateRequest is denied, fails or is deleted, or if ctx is done
+// before it is issued.
+func WaitForCertificateRequest(ctx context.Context, client cmclient.CertificateRequestInterface, name string) (*cmapi.CertificateRequest, error) {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return client.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return client.Watch(ctx, options)
+		},
+	}
+
+	event, err := watchtools.UntilWithSync(ctx, lw, &cmapi.CertificateRequest{}, nil, func(event watch.Event) (bool, error) {
+		req, ok := event.Object.(*cmapi.CertificateRequest)
+		if !ok || req.Name != name {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("CertificateRequest %q was deleted", name)
+		}
+		return certificateRequestIssued(req)
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return nil, fmt.Errorf("timed out waiting for CertificateRequest %q to be issued", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return event.Object.(*cmapi.CertificateRequest), nil
+}
+
+// certificateRequestIssued returns true if req has been issued, or an error if
+// req can no longer be issued.
+func certificateRequestIssued(req *cmapi.CertificateRequest) (bool, error) {
+	if cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied); cond != nil && cond.Status == cmmeta.ConditionTrue {
+		return false, fmt.Errorf("CertificateRequest %q was denied: %s", req.Name, cond.Message)
+	}
+	if cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionInvalidRequest); cond != nil && cond.Status == cmmeta.ConditionTrue {
+		return false, fmt.Errorf("CertificateRequest %q is invalid: %s", req.Name, cond.Message)
+	}
+
+	cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+	if cond == nil {
+		return false, nil
+	}
+	if cond.Status == cmmeta.ConditionFalse && cond.Reason == cmapi.CertificateRequestReasonFailed {
+		return false, fmt.Errorf("CertificateRequest %q failed: %s", req.Name, cond.Message)
+	}
+
+	return cond.Status == cmmeta.ConditionTrue && len(req.Status.Certificate) > 0, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestRequestCertificate(t *testing.T) {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := &cmapi.CertificateSpec{
+		DNSNames:  []string{"example.com"},
+		Usages:    []cmapi.KeyUsage{cmapi.UsageClientAuth},
+		IssuerRef: cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"},
+	}
+
+	tests := map[string]struct {
+		status   cmapi.CertificateRequestStatus
+		expChain []byte
+		expErr   string
+	}{
+		"returns the chain once the CertificateRequest is issued": {
+			status: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue, Reason: cmapi.CertificateRequestReasonIssued},
+				},
+				Certificate: []byte("chain"),
+			},
+			expChain: []byte("chain"),
+		},
+		"returns an error if the CertificateRequest is denied": {
+			status: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue, Message: "not allowed"},
+				},
+			},
+			expErr: `CertificateRequest "certificate-test" was denied: not allowed`,
+		},
+		"returns an error if the CertificateRequest fails": {
+			status: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: cmapi.CertificateRequestReasonFailed, Message: "issuer error"},
+				},
+			},
+			expErr: `CertificateRequest "certificate-test" failed: issuer error`,
+		},
+		"returns an error if the CertificateRequest is not issued in time": {
+			status: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: cmapi.CertificateRequestReasonPending},
+				},
+			},
+			expErr: `timed out waiting for CertificateRequest "certificate-test" to be issued`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Requests which are expected to fail may time out, so use a
+			// shorter timeout for them.
+			timeout := 5 * time.Second
+			if test.expErr != "" {
+				timeout = time.Second
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			cl := fake.NewSimpleClientset()
+			// The fake clientset doesn't generate names, so set one on creation.
+			cl.PrependReactor("create", "certificaterequests", func(action coretesting.Action) (bool, runtime.Object, error) {
+				req := action.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest)
+				req.Name = req.GenerateName + "test"
+				return false, nil, nil
+			})
+
+			// Update the status of the CertificateRequest once it has been
+			// created, as an issuer would. The fake clientset doesn't replay
+			// events missed between listing and watching, so the status is
+			// updated until the test finishes.
+			done := make(chan struct{})
+			defer func() {
+				cancel()
+				<-done
+			}()
+			go func() {
+				defer close(done)
+				client := cl.CertmanagerV1().CertificateRequests("default")
+				for ; ctx.Err() == nil; time.Sleep(10 * time.Millisecond) {
+					req, err := client.Get(ctx, "certificate-test", metav1.GetOptions{})
+					if err != nil {
+						continue
+					}
+
+					assert.Equal(t, spec.IssuerRef, req.Spec.IssuerRef)
+					assert.Equal(t, spec.Usages, req.Spec.Usages)
+					csr, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+					if assert.NoError(t, err) {
+						assert.Equal(t, spec.DNSNames, csr.DNSNames)
+					}
+
+					req.Status = test.status
+					if _, err := client.UpdateStatus(ctx, req, metav1.UpdateOptions{}); err != nil {
+						t.Errorf("failed to update CertificateRequest status: %v", err)
+					}
+				}
+			}()
+
+			chain, err := RequestCertificate(ctx, cl.CertmanagerV1().CertificateRequests("default"), spec, key)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expChain, chain)
+		})
+	}
+}
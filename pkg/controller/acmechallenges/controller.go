@@ -28,7 +28,9 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -36,7 +38,9 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/tlsalpn"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 type controller struct {
@@ -55,8 +59,15 @@ type controller struct {
 	// ACME challenge solvers are instantiated once at the time of controller
 	// construction.
 	// This also allows for easy mocking of the different challenge mechanisms.
-	dnsSolver  solver
-	httpSolver solver
+	dnsSolver     solver
+	httpSolver    solver
+	tlsALPNSolver solver
+	// dnsOrphanReaper removes DNS01 challenge records that are no longer
+	// owned by any Challenge resource.
+	dnsOrphanReaper orphanReaper
+	// orphanCandidates is the set of issuers and domains that are checked
+	// for orphaned DNS01 challenge records.
+	orphanCandidates orphanCandidates
 	// scheduler marks challenges as Processing=true if they can be scheduled
 	// for processing. This job runs periodically every N seconds, so it cannot
 	// be constructed as a traditional controller.
@@ -76,9 +87,18 @@ type controller struct {
 
 	DNS01CheckRetryPeriod time.Duration
 
+	// selfCheckBackoff computes how long to wait before retrying a failed
+	// self check.
+	selfCheckBackoff *selfCheckBackoff
+
 	// objectUpdater implements the updateObject function which is used to save
 	// changes to the Challenge.Status and Challenge.Finalizers
 	objectUpdater
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Challenges referencing an issuer with a different spec.controllerName
+	// are ignored.
+	controllerClass string
 }
 
 func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
@@ -86,14 +106,14 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.log = logf.FromContext(ctx.RootContext, ControllerName)
 
 	// create a queue used to queue up items to be processed
-	c.queue = workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*30), ControllerName)
+	c.queue = controllerpkg.NewRateLimitingQueue(ctx.Clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*30), ControllerName)
 
 	// obtain references to all the informers used by this controller
 	challengeInformer := ctx.SharedInformerFactory.Acme().V1().Challenges()
 	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
 	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
-	// we register these informers here so the HTTP01 solver has a synced
-	// cache when managing pod/service/ingress resources
+	// we register these informers here so the HTTP01 and TLS-ALPN-01 solvers
+	// have a synced cache when managing pod/service/ingress resources
 	podInformer := ctx.KubeSharedInformerFactory.Core().V1().Pods()
 	serviceInformer := ctx.KubeSharedInformerFactory.Core().V1().Services()
 	ingressInformer := ctx.KubeSharedInformerFactory.Networking().V1().Ingresses()
@@ -129,8 +149,12 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 
 	// register handler functions
 	challengeInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	challengeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: c.handleDeletedChallenge})
 
-	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
+	grantLister, grantsSynced := issuer.IssuerReferenceGrantLister(ctx.SharedInformerFactory)
+	mustSync = append(mustSync, grantsSynced...)
+
+	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister, grantLister)
 	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges)
 	c.recorder = ctx.Recorder
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
@@ -140,14 +164,22 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	if err != nil {
 		return nil, nil, err
 	}
-	c.dnsSolver, err = dns.NewSolver(ctx)
+	c.tlsALPNSolver, err = tlsalpn.NewSolver(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
+	dnsSolver, err := dns.NewSolver(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.dnsSolver = dnsSolver
+	c.dnsOrphanReaper = dnsSolver
 
 	// read options from context
 	c.dns01Nameservers = ctx.ACMEOptions.DNS01Nameservers
+	c.controllerClass = ctx.ControllerClass
 	c.DNS01CheckRetryPeriod = ctx.ACMEOptions.DNS01CheckRetryPeriod
+	c.selfCheckBackoff = newSelfCheckBackoff(ctx.Clock, c.DNS01CheckRetryPeriod)
 
 	// Construct an objectUpdater which is used to save changes to the Challenge
 	// object, either using Update or using Patch + Server Side Apply.
@@ -212,10 +244,26 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !c.managesIssuerOf(ch) {
+		log.V(logf.DebugLevel).Info("issuer is managed by a different controller class, skipping")
+		return nil
+	}
+
 	ctx = logf.NewContext(ctx, logf.WithResource(log, ch))
 	return c.Sync(ctx, ch)
 }
 
+// managesIssuerOf returns whether the issuer referenced by a Challenge is managed by
+// this installation of cert-manager. If the issuer can't be read, the Challenge is
+// processed as usual so that the error is surfaced by Sync.
+func (c *controller) managesIssuerOf(ch *cmacme.Challenge) bool {
+	iss, err := c.helper.GetGenericIssuer(ch.Spec.IssuerRef, ch.Namespace)
+	if err != nil {
+		return true
+	}
+	return controllerpkg.ManagesControllerName(c.controllerClass, iss.GetSpec().ControllerName)
+}
+
 const (
 	ControllerName = "challenges"
 )
@@ -223,9 +271,12 @@ const (
 func init() {
 	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		c := &controller{}
-		return controllerpkg.NewBuilder(ctx, ControllerName).
+		b := controllerpkg.NewBuilder(ctx, ControllerName).
 			For(c).
-			With(c.runScheduler, time.Second).
-			Complete()
+			With(c.runScheduler, time.Second)
+		if utilfeature.DefaultFeatureGate.Enabled(feature.DNS01OrphanRecordReaper) {
+			b = b.With(c.runOrphanReaper, orphanReapInterval)
+		}
+		return b.Complete()
 	})
 }
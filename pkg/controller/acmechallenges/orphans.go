@@ -0,0 +1,202 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// orphanReapInterval is how often the orphaned DNS01 record reaper runs.
+const orphanReapInterval = 30 * time.Minute
+
+// orphanReaper removes challenge records that were presented for a domain
+// but never cleaned up, for example because a Challenge's finalizer was
+// removed before the controller was able to call CleanUp.
+type orphanReaper interface {
+	// CleanUpOrphans removes any challenge records at the name used to
+	// solve the given challenge for which isLive returns false.
+	CleanUpOrphans(ctx context.Context, issuer cmapi.GenericIssuer, ch *cmacme.Challenge, isLive func(key string) bool) (int, error)
+}
+
+// orphanCandidates is the set of issuers and domains for which DNS01
+// challenge records may have been presented by this controller. It is
+// tracked independently of the Challenges that currently exist, so that
+// records for a domain are still removed after the last Challenge for that
+// domain has been deleted.
+// The set is held in memory, so only domains for which a Challenge has been
+// observed since the controller started are considered.
+type orphanCandidates struct {
+	lock sync.Mutex
+	// challenges holds the most recently observed Challenge for each issuer
+	// and domain, which is used to construct a DNS provider.
+	challenges map[string]*cmacme.Challenge
+}
+
+// add records the issuer and domain of the given Challenge, replacing any
+// Challenge previously recorded for them.
+func (o *orphanCandidates) add(ch *cmacme.Challenge) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if o.challenges == nil {
+		o.challenges = make(map[string]*cmacme.Challenge)
+	}
+	o.challenges[orphanCandidateKey(ch)] = ch
+}
+
+// remove removes the issuer and domain of the given Challenge, but only if
+// no other Challenge has been recorded for them in the meantime.
+func (o *orphanCandidates) remove(ch *cmacme.Challenge) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	key := orphanCandidateKey(ch)
+	if o.challenges[key] == ch {
+		delete(o.challenges, key)
+	}
+}
+
+// list returns a Challenge for each recorded issuer and domain.
+func (o *orphanCandidates) list() []*cmacme.Challenge {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	challenges := make([]*cmacme.Challenge, 0, len(o.challenges))
+	for _, ch := range o.challenges {
+		challenges = append(challenges, ch)
+	}
+	return challenges
+}
+
+// orphanCandidateKey returns the key under which the issuer and domain of the
+// given Challenge are recorded.
+func orphanCandidateKey(ch *cmacme.Challenge) string {
+	namespace := ch.Namespace
+	if ch.Spec.IssuerRef.Kind == cmapi.ClusterIssuerKind {
+		namespace = ""
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", namespace, ch.Spec.IssuerRef.Kind, ch.Spec.IssuerRef.Name, ch.Spec.DNSName)
+}
+
+// isDNS01Challenge returns true if the given Challenge is solved using a
+// DNS01 solver.
+func isDNS01Challenge(ch *cmacme.Challenge) bool {
+	return ch.Spec.Type == cmacme.ACMEChallengeTypeDNS01 && ch.Spec.Solver.DNS01 != nil
+}
+
+// handleDeletedChallenge records the issuer and domain of a deleted DNS01
+// Challenge, so that any records left behind by it are removed on the next
+// run of the orphan reaper.
+func (c *controller) handleDeletedChallenge(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	ch, ok := obj.(*cmacme.Challenge)
+	if !ok {
+		c.log.V(logf.ErrorLevel).Info("Non-Challenge type resource passed to handleDeletedChallenge")
+		return
+	}
+	if isDNS01Challenge(ch) {
+		c.orphanCandidates.add(ch)
+	}
+}
+
+// runOrphanReaper looks for DNS01 challenge records that were presented by
+// this installation of cert-manager but are not owned by any Challenge
+// resource that currently exists, and removes them.
+// Providers are constructed using the solver configuration of the most
+// recently observed Challenge for each issuer and domain. An issuer and
+// domain are forgotten once no Challenge exists for them and their records
+// have been cleaned up successfully.
+func (c *controller) runOrphanReaper(ctx context.Context) {
+	log := logf.FromContext(ctx, "orphan-reaper")
+
+	if c.dnsOrphanReaper == nil {
+		return
+	}
+
+	challenges, err := c.challengeLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing challenges")
+		return
+	}
+
+	// Only a single Challenge is needed for each issuer and domain in order
+	// to construct a DNS provider that is able to list records.
+	live := make(map[string]bool)
+	for _, ch := range challenges {
+		if !isDNS01Challenge(ch) {
+			continue
+		}
+		live[orphanCandidateKey(ch)] = true
+		c.orphanCandidates.add(ch)
+	}
+
+	for _, ch := range c.orphanCandidates.list() {
+		log := logf.WithResource(log, ch).WithValues("domain", ch.Spec.DNSName)
+
+		genericIssuer, err := c.helper.GetGenericIssuer(ch.Spec.IssuerRef, ch.Namespace)
+		if err != nil {
+			log.Error(err, "error reading (cluster)issuer", "issuer", ch.Spec.IssuerRef.Name)
+			continue
+		}
+
+		removed, err := c.dnsOrphanReaper.CleanUpOrphans(logf.NewContext(ctx, log), genericIssuer, ch, c.isLiveDNS01Key(ch.Spec.DNSName))
+		if err != nil {
+			log.Error(err, "error cleaning up orphaned challenge records")
+			continue
+		}
+		if removed > 0 {
+			log.V(logf.InfoLevel).Info("removed orphaned challenge records", "number_removed", removed)
+		}
+		if !live[orphanCandidateKey(ch)] {
+			c.orphanCandidates.remove(ch)
+		}
+	}
+}
+
+// isLiveDNS01Key returns a function which reports whether the given key is in
+// use by any DNS01 Challenge for dnsName. Challenges are re-listed on each
+// call so that Challenges which have been created since the reaper started
+// are taken into account.
+func (c *controller) isLiveDNS01Key(dnsName string) func(string) bool {
+	return func(key string) bool {
+		challenges, err := c.challengeLister.List(labels.Everything())
+		if err != nil {
+			// If the state of the world cannot be determined, err on the side
+			// of caution so that no record is removed.
+			return true
+		}
+
+		for _, ch := range challenges {
+			if ch.Spec.Type == cmacme.ACMEChallengeTypeDNS01 &&
+				ch.Spec.DNSName == dnsName &&
+				ch.Spec.Key == key {
+				return true
+			}
+		}
+
+		return false
+	}
+}
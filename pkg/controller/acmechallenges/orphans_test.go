@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+type fakeOrphanReaper struct {
+	calls []orphanReaperCall
+}
+
+type orphanReaperCall struct {
+	challenge *cmacme.Challenge
+	isLive    func(string) bool
+}
+
+func (f *fakeOrphanReaper) CleanUpOrphans(ctx context.Context, issuer cmapi.GenericIssuer, ch *cmacme.Challenge, isLive func(key string) bool) (int, error) {
+	f.calls = append(f.calls, orphanReaperCall{challenge: ch, isLive: isLive})
+	return 0, nil
+}
+
+func TestRunOrphanReaper(t *testing.T) {
+	issuer := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+	dns01 := cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{}}
+	challenge := func(name string, mods ...gen.ChallengeModifier) *cmacme.Challenge {
+		return gen.Challenge(name, append([]gen.ChallengeModifier{
+			gen.SetChallengeIssuer(cmmeta.ObjectReference{Name: "testissuer"}),
+			gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			gen.SetChallengeSolver(dns01),
+		}, mods...)...)
+	}
+
+	builder := &testpkg.Builder{
+		T: t,
+		CertManagerObjects: []runtime.Object{
+			issuer,
+			challenge("apex",
+				gen.SetChallengeDNSName("example.com"),
+				gen.SetChallengeKey("apex-key"),
+			),
+			challenge("wildcard",
+				gen.SetChallengeDNSName("example.com"),
+				gen.SetChallengeWildcard(true),
+				gen.SetChallengeKey("wildcard-key"),
+			),
+			challenge("other",
+				gen.SetChallengeDNSName("other.example.com"),
+				gen.SetChallengeKey("other-key"),
+			),
+			challenge("http",
+				gen.SetChallengeDNSName("http.example.com"),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengeSolver(cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}}),
+			),
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	c := &controller{}
+	_, _, err := c.Register(builder.Context)
+	require.NoError(t, err)
+	reaper := &fakeOrphanReaper{}
+	c.dnsOrphanReaper = reaper
+	builder.Start()
+
+	c.runOrphanReaper(context.Background())
+
+	// One call per issuer and domain; the HTTP01 challenge is ignored.
+	require.Len(t, reaper.calls, 2)
+	for _, call := range reaper.calls {
+		switch call.challenge.Spec.DNSName {
+		case "example.com":
+			assert.True(t, call.isLive("apex-key"))
+			assert.True(t, call.isLive("wildcard-key"))
+			assert.False(t, call.isLive("other-key"), "keys for other domains must not be considered live")
+			assert.False(t, call.isLive("orphaned-key"))
+		case "other.example.com":
+			assert.True(t, call.isLive("other-key"))
+			assert.False(t, call.isLive("apex-key"))
+		default:
+			t.Errorf("unexpected call for domain %q", call.challenge.Spec.DNSName)
+		}
+	}
+
+	builder.CheckAndFinish()
+}
+
+func TestRunOrphanReaperDeletedChallenges(t *testing.T) {
+	issuer := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+	deleted := gen.Challenge("deleted",
+		gen.SetChallengeIssuer(cmmeta.ObjectReference{Name: "testissuer"}),
+		gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+		gen.SetChallengeSolver(cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{}}),
+		gen.SetChallengeDNSName("deleted.example.com"),
+		gen.SetChallengeKey("deleted-key"),
+	)
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		CertManagerObjects: []runtime.Object{issuer},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	c := &controller{}
+	_, _, err := c.Register(builder.Context)
+	require.NoError(t, err)
+	reaper := &fakeOrphanReaper{}
+	c.dnsOrphanReaper = reaper
+	builder.Start()
+
+	c.handleDeletedChallenge(deleted)
+
+	// Records for the domain of the deleted Challenge are still cleaned up,
+	// even though no Challenge exists for the domain anymore.
+	c.runOrphanReaper(context.Background())
+	require.Len(t, reaper.calls, 1)
+	assert.Equal(t, "deleted.example.com", reaper.calls[0].challenge.Spec.DNSName)
+	assert.False(t, reaper.calls[0].isLive("deleted-key"))
+
+	// Once cleaned up successfully, the domain is forgotten.
+	c.runOrphanReaper(context.Background())
+	assert.Len(t, reaper.calls, 1)
+
+	builder.CheckAndFinish()
+}
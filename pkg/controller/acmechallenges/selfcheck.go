@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+// selfCheckBackoff computes how long to wait before retrying the failing self
+// check of a Challenge, according to the selfCheck configuration of the
+// Challenge's solver. It remembers when the self check of each Challenge
+// started failing, so the backoff restarts from the initial interval if the
+// controller restarts.
+type selfCheckBackoff struct {
+	clock clock.Clock
+
+	// defaultInterval is the interval used if the solver doesn't configure
+	// an initial interval.
+	defaultInterval time.Duration
+
+	lock         sync.Mutex
+	failingSince map[types.UID]time.Time
+}
+
+func newSelfCheckBackoff(clock clock.Clock, defaultInterval time.Duration) *selfCheckBackoff {
+	return &selfCheckBackoff{
+		clock:           clock,
+		defaultInterval: defaultInterval,
+		failingSince:    make(map[types.UID]time.Time),
+	}
+}
+
+// next records a failed self check of the Challenge, and returns how long to
+// wait before retrying it. It returns false if the self check has been
+// failing for longer than the max duration configured on the solver, in which
+// case it should not be retried.
+func (b *selfCheckBackoff) next(ch *cmacme.Challenge) (time.Duration, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Now()
+	since, ok := b.failingSince[ch.UID]
+	if !ok {
+		since = now
+		b.failingSince[ch.UID] = since
+	}
+	elapsed := now.Sub(since)
+
+	cfg := ch.Spec.Solver.SelfCheck
+	if cfg == nil {
+		return b.defaultInterval, true
+	}
+
+	if cfg.MaxDuration != nil && elapsed >= cfg.MaxDuration.Duration {
+		return 0, false
+	}
+
+	interval := b.defaultInterval
+	if cfg.InitialInterval != nil {
+		interval = cfg.InitialInterval.Duration
+	}
+	// After n retries, the time spent waiting is the sum of a geometric
+	// series, initial * (multiplier^n - 1) / (multiplier - 1), so the next
+	// interval, initial * multiplier^n, can be computed from the time that
+	// has elapsed since the first failure without counting retries.
+	if cfg.Multiplier != nil && *cfg.Multiplier > 1 {
+		interval += elapsed * time.Duration(*cfg.Multiplier-1)
+	}
+	if cfg.MaxInterval != nil && interval > cfg.MaxInterval.Duration {
+		interval = cfg.MaxInterval.Duration
+	}
+	// Don't wait past the max duration before giving up.
+	if cfg.MaxDuration != nil && elapsed+interval > cfg.MaxDuration.Duration {
+		interval = cfg.MaxDuration.Duration - elapsed
+	}
+
+	return interval, true
+}
+
+// forget removes the record of the failed self checks of the Challenge with
+// the given UID, once its self check has passed or it is no longer processed.
+func (b *selfCheckBackoff) forget(uid types.UID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.failingSince, uid)
+}
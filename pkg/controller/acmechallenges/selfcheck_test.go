@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestSelfCheckBackoff(t *testing.T) {
+	type retry struct {
+		// after is the time since the first failed self check
+		after    time.Duration
+		expWait  time.Duration
+		expRetry bool
+	}
+
+	tests := map[string]struct {
+		selfCheck *cmacme.ACMEChallengeSolverSelfCheck
+		retries   []retry
+	}{
+		"no self check configuration retries at the default interval": {
+			retries: []retry{
+				{after: 0, expWait: 10 * time.Second, expRetry: true},
+				{after: time.Hour, expWait: 10 * time.Second, expRetry: true},
+			},
+		},
+		"initial interval only retries at a constant interval": {
+			selfCheck: &cmacme.ACMEChallengeSolverSelfCheck{
+				InitialInterval: &metav1.Duration{Duration: 2 * time.Second},
+			},
+			retries: []retry{
+				{after: 0, expWait: 2 * time.Second, expRetry: true},
+				{after: 2 * time.Second, expWait: 2 * time.Second, expRetry: true},
+			},
+		},
+		"multiplier grows the interval exponentially": {
+			selfCheck: &cmacme.ACMEChallengeSolverSelfCheck{
+				InitialInterval: &metav1.Duration{Duration: time.Second},
+				Multiplier:      pointer.Int32(2),
+			},
+			retries: []retry{
+				{after: 0, expWait: time.Second, expRetry: true},
+				{after: time.Second, expWait: 2 * time.Second, expRetry: true},
+				{after: 3 * time.Second, expWait: 4 * time.Second, expRetry: true},
+				{after: 7 * time.Second, expWait: 8 * time.Second, expRetry: true},
+			},
+		},
+		"max interval caps the interval": {
+			selfCheck: &cmacme.ACMEChallengeSolverSelfCheck{
+				InitialInterval: &metav1.Duration{Duration: time.Second},
+				Multiplier:      pointer.Int32(3),
+				MaxInterval:     &metav1.Duration{Duration: 5 * time.Second},
+			},
+			retries: []retry{
+				{after: 0, expWait: time.Second, expRetry: true},
+				{after: time.Second, expWait: 3 * time.Second, expRetry: true},
+				{after: 4 * time.Second, expWait: 5 * time.Second, expRetry: true},
+				{after: 9 * time.Second, expWait: 5 * time.Second, expRetry: true},
+			},
+		},
+		"max duration stops the retries": {
+			selfCheck: &cmacme.ACMEChallengeSolverSelfCheck{
+				InitialInterval: &metav1.Duration{Duration: 4 * time.Second},
+				MaxDuration:     &metav1.Duration{Duration: 10 * time.Second},
+			},
+			retries: []retry{
+				{after: 0, expWait: 4 * time.Second, expRetry: true},
+				{after: 4 * time.Second, expWait: 4 * time.Second, expRetry: true},
+				{after: 8 * time.Second, expWait: 2 * time.Second, expRetry: true},
+				{after: 10 * time.Second, expRetry: false},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			clock := fakeclock.NewFakeClock(start)
+			backoff := newSelfCheckBackoff(clock, 10*time.Second)
+
+			ch := &cmacme.Challenge{
+				ObjectMeta: metav1.ObjectMeta{UID: "uid"},
+				Spec: cmacme.ChallengeSpec{
+					Solver: cmacme.ACMEChallengeSolver{SelfCheck: test.selfCheck},
+				},
+			}
+			for _, r := range test.retries {
+				clock.SetTime(start.Add(r.after))
+				wait, retry := backoff.next(ch)
+				assert.Equal(t, r.expRetry, retry, "after %s", r.after)
+				if r.expRetry {
+					assert.Equal(t, r.expWait, wait, "after %s", r.after)
+				}
+			}
+
+			// Once forgotten, the backoff starts again from the initial
+			// interval.
+			backoff.forget(ch.UID)
+			if len(test.retries) > 0 {
+				wait, retry := backoff.next(ch)
+				assert.True(t, retry)
+				assert.Equal(t, test.retries[0].expWait, wait)
+			}
+		})
+	}
+}
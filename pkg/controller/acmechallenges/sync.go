@@ -37,11 +37,12 @@ import (
 )
 
 const (
-	reasonDomainVerified = "DomainVerified"
-	reasonCleanUpError   = "CleanUpError"
-	reasonPresentError   = "PresentError"
-	reasonPresented      = "Presented"
-	reasonFailed         = "Failed"
+	reasonDomainVerified   = "DomainVerified"
+	reasonCleanUpError     = "CleanUpError"
+	reasonPresentError     = "PresentError"
+	reasonPresented        = "Presented"
+	reasonFailed           = "Failed"
+	reasonSelfCheckTimeout = "SelfCheckTimeout"
 )
 
 // solver solves ACME challenges by presenting the given token and key in an
@@ -119,6 +120,7 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		}
 
 		ch.Status.Processing = false
+		c.selfCheckBackoff.forget(ch.UID)
 
 		return nil
 	}
@@ -187,19 +189,26 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 
 	err = solver.Check(ctx, genericIssuer, ch)
 	if err != nil {
-		log.Error(err, "propagation check failed")
-		ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
+		retryAfter, retry := c.selfCheckBackoff.next(ch)
+		if retry {
+			log.Error(err, "propagation check failed")
+			ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
 
-		key, err := controllerpkg.KeyFunc(ch)
-		// This is an unexpected edge case and should never occur
-		if err != nil {
-			return err
-		}
+			key, err := controllerpkg.KeyFunc(ch)
+			// This is an unexpected edge case and should never occur
+			if err != nil {
+				return err
+			}
 
-		c.queue.AddAfter(key, c.DNS01CheckRetryPeriod)
+			c.queue.AddAfter(key, retryAfter)
 
-		return nil
+			return nil
+		}
+
+		log.Error(err, "propagation check failed for longer than the max duration of the self check, accepting the challenge anyway")
+		c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonSelfCheckTimeout, "Self check did not pass within %s, asking the ACME server to validate the challenge anyway: %v", ch.Spec.Solver.SelfCheck.MaxDuration.Duration, err)
 	}
+	c.selfCheckBackoff.forget(ch.UID)
 
 	err = c.acceptChallenge(ctx, cl, ch)
 	if err != nil {
@@ -260,6 +269,8 @@ func (c *controller) handleFinalizer(ctx context.Context, ch *cmacme.Challenge)
 		ch.Finalizers = ch.Finalizers[1:]
 	}()
 
+	c.selfCheckBackoff.forget(ch.UID)
+
 	if !ch.Status.Processing {
 		return nil
 	}
@@ -414,6 +425,8 @@ func (c *controller) solverFor(challengeType cmacme.ACMEChallengeType) (solver,
 		return c.httpSolver, nil
 	case cmacme.ACMEChallengeTypeDNS01:
 		return c.dnsSolver, nil
+	case cmacme.ACMEChallengeTypeTLSALPN01:
+		return c.tlsALPNSolver, nil
 	}
 	return nil, fmt.Errorf("no solver for %q implemented", challengeType)
 }
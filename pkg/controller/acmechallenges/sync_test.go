@@ -587,6 +587,7 @@ func runTest(t *testing.T, test testT) {
 	c.helper = issuer.NewHelper(
 		test.builder.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
 		test.builder.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+		nil,
 	)
 	c.accountRegistry = &accountstest.FakeRegistry{
 		GetClientFunc: func(_ string) (acmecl.Interface, error) {
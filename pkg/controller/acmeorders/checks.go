@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/util/workqueue"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
@@ -70,7 +71,7 @@ func ordersForGenericIssuer(iss cmapi.GenericIssuer, orderLister cmacmelisters.O
 			continue
 		}
 		if !isClusterIssuer {
-			if o.Namespace != iss.GetObjectMeta().Namespace {
+			if apiutil.IssuerNamespace(o.Spec.IssuerRef, o.Namespace) != iss.GetObjectMeta().Namespace {
 				continue
 			}
 		}
@@ -30,6 +30,8 @@ import (
 	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	"github.com/cert-manager/cert-manager/pkg/acme/ratelimit"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
@@ -37,6 +39,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
 )
 
@@ -49,6 +52,13 @@ type controller struct {
 	// used to fetch ACME clients used in the controller
 	accountRegistry accounts.Getter
 
+	// rateLimitTracker estimates the remaining rate limit budget of ACME
+	// issuers. Rate limits are not tracked if it is nil.
+	rateLimitTracker *ratelimit.Tracker
+
+	// metrics exposes the estimated rate limit budget of ACME issuers.
+	metrics *metrics.Metrics
+
 	// all the listers used by this controller
 	orderLister         cmacmelisters.OrderLister
 	challengeLister     cmacmelisters.ChallengeLister
@@ -75,6 +85,11 @@ type controller struct {
 
 	// logger to be used by this controller
 	log logr.Logger
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Orders referencing an issuer with a different spec.controllerName
+	// are ignored.
+	controllerClass string
 }
 
 // NewController constructs an orders controller using the provided options.
@@ -91,7 +106,8 @@ func NewController(
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 
 	// Create a queue used to queue up Orders to be processed.
-	queue := workqueue.NewNamedRateLimitingQueue(
+	queue := controllerpkg.NewRateLimitingQueue(
+		clock,
 		workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*30),
 		ControllerName,
 	)
@@ -133,6 +149,9 @@ func NewController(
 		)
 	}
 
+	grantLister, grantsSynced := issuer.IssuerReferenceGrantLister(cmInformerFactory)
+	mustSync = append(mustSync, grantsSynced...)
+
 	// register handler functions
 	orderInformer.Informer().AddEventHandler(
 		&controllerpkg.QueuingEventHandler{Queue: queue},
@@ -153,7 +172,7 @@ func NewController(
 		challengeLister:     challengeLister,
 		secretLister:        secretLister,
 		clusterIssuerLister: clusterIssuerLister,
-		helper:              issuer.NewHelper(issuerLister, clusterIssuerLister),
+		helper:              issuer.NewHelper(issuerLister, clusterIssuerLister, grantLister),
 		recorder:            recorder,
 		cmClient:            cmClient,
 		accountRegistry:     accountRegistry,
@@ -180,10 +199,26 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !c.managesIssuerOf(order) {
+		log.V(logf.DebugLevel).Info("issuer is managed by a different controller class, skipping")
+		return nil
+	}
+
 	ctx = logf.NewContext(ctx, logf.WithResource(log, order))
 	return c.Sync(ctx, order)
 }
 
+// managesIssuerOf returns whether the issuer referenced by an Order is managed by
+// this installation of cert-manager. If the issuer can't be read, the Order is
+// processed as usual so that the error is surfaced by Sync.
+func (c *controller) managesIssuerOf(o *cmacme.Order) bool {
+	iss, err := c.helper.GetGenericIssuer(o.Spec.IssuerRef, o.Namespace)
+	if err != nil {
+		return true
+	}
+	return controllerpkg.ManagesControllerName(c.controllerClass, iss.GetSpec().ControllerName)
+}
+
 // Returns a function that finds a named Order in a particular namespace.
 func orderGetterFunc(orderLister cmacmelisters.OrderLister) func(string, string) (interface{}, error) {
 	return func(namespace, name string) (interface{}, error) {
@@ -224,6 +259,9 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		isNamespaced,
 		ctx.FieldManager,
 	)
+	ctrl.controllerClass = ctx.ControllerClass
+	ctrl.rateLimitTracker = ctx.ACMEOptions.RateLimitTracker
+	ctrl.metrics = ctx.Metrics
 	c.controller = ctrl
 
 	return queue, mustSync, nil
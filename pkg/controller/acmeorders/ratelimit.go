@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	acmeapi "golang.org/x/crypto/acme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
+	"github.com/cert-manager/cert-manager/pkg/api/conditions"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+const (
+	reasonRateLimited        = "RateLimited"
+	reasonRateLimitBudgetLow = "BudgetLow"
+	reasonRateLimitBudgetOK  = "BudgetAvailable"
+)
+
+// orderIdentifiers returns the DNS names and IP addresses requested by o.
+func orderIdentifiers(o *cmacme.Order) []string {
+	identifiers := append([]string{}, o.Spec.DNSNames...)
+	identifiers = append(identifiers, o.Spec.IPAddresses...)
+	if o.Spec.CommonName != "" {
+		identifiers = append(identifiers, o.Spec.CommonName)
+	}
+	return identifiers
+}
+
+// rateLimitAccount returns the key of the ACME account of issuer in the rate
+// limit tracker, which is the UID of the issuer until the account is
+// registered.
+func rateLimitAccount(issuer cmapi.GenericIssuer) string {
+	if status := issuer.GetStatus(); status != nil && status.ACME != nil && status.ACME.URI != "" {
+		return status.ACME.URI
+	}
+	return string(issuer.GetUID())
+}
+
+// recordOrder records the creation of an order by the account of issuer.
+func (c *controller) recordOrder(issuer cmapi.GenericIssuer) {
+	if c.rateLimitTracker != nil {
+		c.rateLimitTracker.RecordOrder(rateLimitAccount(issuer))
+	}
+}
+
+// recordCertificate records the issuance of a certificate for o by the ACME
+// server of issuer.
+func (c *controller) recordCertificate(issuer cmapi.GenericIssuer, o *cmacme.Order) {
+	if c.rateLimitTracker != nil && issuer.GetSpec().ACME != nil {
+		c.rateLimitTracker.RecordCertificate(issuer.GetSpec().ACME.Server, orderIdentifiers(o))
+	}
+}
+
+// recordRateLimited records err if it is a rateLimited error returned by the
+// ACME server to the account of issuer.
+func (c *controller) recordRateLimited(issuer cmapi.GenericIssuer, err error) {
+	if c.rateLimitTracker == nil {
+		return
+	}
+	if retryAfter, ok := acmeapi.RateLimit(err); ok {
+		c.rateLimitTracker.RecordRateLimited(rateLimitAccount(issuer), retryAfter)
+	}
+}
+
+// updateRateLimitBudget exposes the estimated remaining rate limit budget of
+// issuer for the identifiers of o as metrics, and sets the RateLimited
+// condition of issuer if it changed. Failing to set the condition is only
+// logged, so that it does not hold up the Order.
+func (c *controller) updateRateLimitBudget(ctx context.Context, issuer cmapi.GenericIssuer, o *cmacme.Order) {
+	if c.rateLimitTracker == nil || issuer.GetSpec().ACME == nil {
+		return
+	}
+
+	limits := c.rateLimitTracker.Limits()
+	budget := c.rateLimitTracker.Budget(rateLimitAccount(issuer), issuer.GetSpec().ACME.Server, orderIdentifiers(o))
+
+	kind := cmapi.IssuerKind
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		kind = cmapi.ClusterIssuerKind
+	}
+	if c.metrics != nil {
+		c.metrics.UpdateACMERateLimitBudget(issuer.GetName(), issuer.GetNamespace(), kind, "new_orders", "", budget.NewOrders)
+		for domain, remaining := range budget.CertificatesPerDomain {
+			c.metrics.UpdateACMERateLimitBudget(issuer.GetName(), issuer.GetNamespace(), kind, "certificates_per_domain", domain, remaining)
+			c.metrics.UpdateACMERateLimitBudget(issuer.GetName(), issuer.GetNamespace(), kind, "duplicate_certificates", domain, budget.DuplicateCertificates)
+		}
+	}
+
+	status, reason, message := cmmeta.ConditionFalse, reasonRateLimitBudgetOK, "The estimated rate limit budget of the ACME server is sufficient"
+	if budget.RateLimitedUntil != nil {
+		status, reason = cmmeta.ConditionTrue, reasonRateLimited
+		message = fmt.Sprintf("The ACME server rate limited the account until %s", budget.RateLimitedUntil.UTC().Format(time.RFC3339))
+	} else if low := budget.Low(limits); len(low) > 0 {
+		status, reason = cmmeta.ConditionTrue, reasonRateLimitBudgetLow
+		message = fmt.Sprintf("Issuance will be rate limited soon, the estimated budget is low for: %s", strings.Join(low, ", "))
+	}
+
+	existing := conditions.Get(issuer.GetStatus().Conditions, string(cmapi.IssuerConditionRateLimited))
+	if existing != nil && existing.Status == status && existing.Reason == reason && existing.Message == message {
+		return
+	}
+	now := metav1.NewTime(c.clock.Now())
+	transitionTime := &now
+	if existing != nil && existing.Status == status {
+		transitionTime = existing.LastTransitionTime
+	}
+	err := c.setRateLimitedCondition(ctx, issuer, cmapi.IssuerCondition{
+		Type:               cmapi.IssuerConditionRateLimited,
+		Status:             status,
+		LastTransitionTime: transitionTime,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: issuer.GetGeneration(),
+	})
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to set the RateLimited condition of the issuer")
+	}
+}
+
+// setRateLimitedCondition sets the RateLimited condition of issuer.
+func (c *controller) setRateLimitedCondition(ctx context.Context, issuer cmapi.GenericIssuer, cond cmapi.IssuerCondition) error {
+	now := c.clock.Now()
+	switch iss := issuer.DeepCopyObject().(type) {
+	case *cmapi.Issuer:
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			return internalissuers.ApplyIssuerStatus(ctx, c.cmClient, c.fieldManager, &cmapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: iss.Namespace, Name: iss.Name},
+				Status:     cmapi.IssuerStatus{Conditions: []cmapi.IssuerCondition{cond}},
+			})
+		}
+		iss.Status.Conditions, _ = conditions.Set(iss.Status.Conditions, cond, now)
+		_, err := c.cmClient.CertmanagerV1().Issuers(iss.Namespace).UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+		return err
+
+	case *cmapi.ClusterIssuer:
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			return internalissuers.ApplyClusterIssuerStatus(ctx, c.cmClient, c.fieldManager, &cmapi.ClusterIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: iss.Name},
+				Status:     cmapi.IssuerStatus{Conditions: []cmapi.IssuerCondition{cond}},
+			})
+		}
+		iss.Status.Conditions, _ = conditions.Set(iss.Status.Conditions, cond, now)
+		_, err := c.cmClient.CertmanagerV1().ClusterIssuers().UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+		return err
+
+	default:
+		return fmt.Errorf("unexpected issuer type %T", iss)
+	}
+}
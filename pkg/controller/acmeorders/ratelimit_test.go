@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/ratelimit"
+	"github.com/cert-manager/cert-manager/pkg/api/conditions"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestUpdateRateLimitBudget(t *testing.T) {
+	limits := ratelimit.Limits{
+		NewOrders:             ratelimit.Limit{Count: 100, Window: time.Hour},
+		CertificatesPerDomain: ratelimit.Limit{Count: 100, Window: time.Hour},
+		DuplicateCertificates: ratelimit.Limit{Count: 3, Window: time.Hour},
+	}
+	issuer := func(mods ...gen.IssuerModifier) *cmapi.Issuer {
+		return gen.Issuer("testissuer", append(mods,
+			gen.SetIssuerNamespace("default"),
+			gen.SetIssuerACME(cmacme.ACMEIssuer{Server: "https://acme.example.com"}),
+			gen.SetIssuerACMEAccountURL("https://acme.example.com/account/1"))...)
+	}
+	order := gen.Order("testorder", gen.SetOrderNamespace("default"), gen.SetOrderDNSNames("www.example.com"))
+
+	tests := map[string]struct {
+		issuer *cmapi.Issuer
+		record func(*ratelimit.Tracker)
+
+		expectedCondition *cmapi.IssuerCondition
+	}{
+		"a sufficient budget is reported": {
+			issuer: issuer(),
+			record: func(t *ratelimit.Tracker) {
+				t.RecordCertificate("https://acme.example.com", []string{"www.example.com"})
+			},
+			expectedCondition: &cmapi.IssuerCondition{Status: cmmeta.ConditionFalse, Reason: reasonRateLimitBudgetOK},
+		},
+		"an unchanged condition is not updated": {
+			issuer: issuer(gen.AddIssuerCondition(cmapi.IssuerCondition{
+				Type:    cmapi.IssuerConditionRateLimited,
+				Status:  cmmeta.ConditionFalse,
+				Reason:  reasonRateLimitBudgetOK,
+				Message: "The estimated rate limit budget of the ACME server is sufficient",
+			})),
+			record: func(t *ratelimit.Tracker) {},
+		},
+		"a low budget is reported": {
+			issuer: issuer(),
+			record: func(t *ratelimit.Tracker) {
+				t.RecordCertificate("https://acme.example.com", []string{"www.example.com"})
+				t.RecordCertificate("https://acme.example.com", []string{"www.example.com"})
+				// certificates issued by other servers are not counted
+				t.RecordCertificate("https://other.example.com", []string{"www.example.com"})
+			},
+			expectedCondition: &cmapi.IssuerCondition{Status: cmmeta.ConditionTrue, Reason: reasonRateLimitBudgetLow},
+		},
+		"a rateLimited error is reported": {
+			issuer: issuer(),
+			record: func(t *ratelimit.Tracker) {
+				t.RecordRateLimited("https://acme.example.com/account/1", time.Minute)
+			},
+			expectedCondition: &cmapi.IssuerCondition{Status: cmmeta.ConditionTrue, Reason: reasonRateLimited},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock := fakeclock.NewFakeClock(time.Now())
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{test.issuer},
+			}
+			builder.Init()
+			defer builder.Stop()
+
+			cw := &controllerWrapper{}
+			if _, _, err := cw.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			cw.rateLimitTracker = ratelimit.NewTracker(fixedClock, limits)
+			test.record(cw.rateLimitTracker)
+			builder.Start()
+
+			cw.updateRateLimitBudget(context.Background(), test.issuer, order)
+
+			var condition *cmapi.IssuerCondition
+			for _, action := range builder.FakeCMClient().Actions() {
+				update, ok := action.(coretesting.UpdateAction)
+				if !ok || update.GetSubresource() != "status" {
+					continue
+				}
+				iss := update.GetObject().(*cmapi.Issuer)
+				condition = conditions.Get(iss.Status.Conditions, string(cmapi.IssuerConditionRateLimited))
+			}
+
+			if (condition == nil) != (test.expectedCondition == nil) {
+				t.Fatalf("expected condition %+v, got %+v", test.expectedCondition, condition)
+			}
+			if condition != nil && (condition.Status != test.expectedCondition.Status || condition.Reason != test.expectedCondition.Reason) {
+				t.Errorf("expected condition %+v, got %+v", test.expectedCondition, condition)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net"
 	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
@@ -77,6 +78,26 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		dbg.Info("updated Order resource status successfully")
 	}()
 
+	// Record the Retry-After headers returned by the ACME server, so that
+	// the Order is not polled again sooner than the server asked for.
+	ctx, retryAfter := acmecl.WithRetryAfterRecorder(ctx)
+	defer func() {
+		d := retryAfter.RetryAfter()
+		if err == nil || d == 0 {
+			return
+		}
+		key, keyErr := cache.MetaNamespaceKeyFunc(o)
+		if keyErr != nil {
+			return
+		}
+		// Re-queue the Order after the requested delay instead of returning
+		// the error, which would cause it to be retried sooner by the rate
+		// limited workqueue.
+		log.Error(err, "failed to sync Order, re-queueing after the delay requested by the ACME server", "retry_after", d)
+		c.scheduledWorkQueue.Add(key, d)
+		err = nil
+	}()
+
 	genericIssuer, err := c.helper.GetGenericIssuer(o.Spec.IssuerRef, o.Namespace)
 	if err != nil {
 		return fmt.Errorf("error reading (cluster)issuer %q: %v", o.Spec.IssuerRef.Name, err)
@@ -93,7 +114,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return nil
 	case o.Status.URL == "":
 		log.V(logf.DebugLevel).Info("Creating new ACME order as status.url is not set")
-		return c.createOrder(ctx, cl, o)
+		return c.createOrder(ctx, cl, o, genericIssuer)
 	case o.Status.FinalizeURL == "":
 		log.V(logf.DebugLevel).Info("Updating Order status as status.finalizeURL is not set")
 		_, err := c.updateOrderStatus(ctx, cl, o)
@@ -219,8 +240,9 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 			// as failed here.
 			return nil
 		}
-		// Re-queue the Order to be processed again after 5 seconds.
-		c.scheduledWorkQueue.Add(key, RequeuePeriod)
+		// Re-queue the Order to be processed again after 5 seconds, or
+		// later if the ACME server asked for it.
+		c.scheduledWorkQueue.Add(key, requeuePeriod(retryAfter))
 		return nil
 
 	case !anyChallengesFailed(challenges) && allChallengesFinal(challenges):
@@ -242,7 +264,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	return nil
 }
 
-func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) error {
+func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx)
 
 	if o.Status.URL != "" {
@@ -251,13 +273,18 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	log.V(logf.DebugLevel).Info("order URL not set, submitting Order to ACME server")
 
 	dnsIdentifierSet := sets.NewString(o.Spec.DNSNames...)
+	ipIdentifierSet := sets.NewString(o.Spec.IPAddresses...)
+	// A common name that is an IP address must be validated using an IP
+	// identifier (RFC 8738) rather than a DNS identifier.
 	if o.Spec.CommonName != "" {
-		dnsIdentifierSet.Insert(o.Spec.CommonName)
+		if net.ParseIP(o.Spec.CommonName) != nil {
+			ipIdentifierSet.Insert(o.Spec.CommonName)
+		} else {
+			dnsIdentifierSet.Insert(o.Spec.CommonName)
+		}
 	}
 	log.V(logf.DebugLevel).Info("build set of domains for Order", "domains", dnsIdentifierSet.List())
-
-	ipIdentifierSet := sets.NewString(o.Spec.IPAddresses...)
-	log.V(logf.DebugLevel).Info("build set of IPs for Order", "domains", dnsIdentifierSet.List())
+	log.V(logf.DebugLevel).Info("build set of IPs for Order", "ips", ipIdentifierSet.List())
 
 	authzIDs := acmeapi.DomainIDs(dnsIdentifierSet.List()...)
 	authzIDs = append(authzIDs, acmeapi.IPIDs(ipIdentifierSet.List()...)...)
@@ -267,7 +294,17 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	if o.Spec.Duration != nil {
 		options = append(options, acmeapi.WithOrderNotAfter(c.clock.Now().Add(o.Spec.Duration.Duration)))
 	}
+	if o.Spec.Profile != "" {
+		log.V(logf.DebugLevel).Info("requesting certificate profile for Order", "profile", o.Spec.Profile)
+		ctx = acmecl.WithOrderProfile(ctx, o.Spec.Profile)
+	}
 	acmeOrder, err := cl.AuthorizeOrder(ctx, authzIDs, options...)
+	if err == nil {
+		c.recordOrder(issuer)
+	} else {
+		c.recordRateLimited(issuer, err)
+	}
+	c.updateRateLimitBudget(ctx, issuer, o)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to create Order resource due to bad request, marking Order as failed")
@@ -511,6 +548,12 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 
 	// Call to CreateOrderCert finalizes the ACME order. This call can only be made once.
 	certSlice, certURL, err := cl.CreateOrderCert(ctx, o.Status.FinalizeURL, derBytes, true)
+	if err == nil {
+		c.recordCertificate(issuer, o)
+	} else {
+		c.recordRateLimited(issuer, err)
+	}
+	c.updateRateLimitBudget(ctx, issuer, o)
 
 	acmeErr, ok := err.(*acmeapi.Error)
 
@@ -666,6 +709,15 @@ func (c *controller) syncCertificateDataWithOrder(ctx context.Context, cl acmecl
 	return nil
 }
 
+// requeuePeriod returns the period after which an Order should be re-queued,
+// which is RequeuePeriod unless the ACME server requested a longer delay.
+func requeuePeriod(retryAfter *acmecl.RetryAfterRecorder) time.Duration {
+	if d := retryAfter.RetryAfter(); d > RequeuePeriod {
+		return d
+	}
+	return RequeuePeriod
+}
+
 // getACMEOrder returns the ACME Order for an Order Custom Resource.
 func getACMEOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (*acmeapi.Order, error) {
 	log := logf.FromContext(ctx)
@@ -418,6 +418,47 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			},
 			shouldSchedule: true,
 		},
+		"skip creating a Challenge for an already valid authorization, reschedule after the Retry-After requested by the ACME server": {
+			order: gen.OrderFrom(testOrder, gen.SetOrderStatus(
+				cmacme.OrderStatus{
+					State:       cmacme.Pending,
+					URL:         "http://testurl.com/abcde",
+					FinalizeURL: "http://testurl.com/abcde/finalize",
+					Authorizations: []cmacme.ACMEAuthorization{
+						{
+							URL:          "http://authzurl",
+							Identifier:   "test.com",
+							InitialState: cmacme.Valid,
+							Challenges: []cmacme.ACMEChallenge{
+								{
+									URL:   "http://chalurl",
+									Token: "token",
+									Type:  "http-01",
+								},
+							},
+						},
+					},
+				},
+			)),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending},
+				ExpectedActions:    []testpkg.Action{},
+				ExpectedEvents:     []string{},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(ctx context.Context, url string) (*acmeapi.Order, error) {
+					acmecl.RecordRetryAfter(ctx, time.Minute)
+					return &acmeapi.Order{
+						URI:         "http://testurl.com/abcde",
+						Status:      acmeapi.StatusPending,
+						FinalizeURL: "http://testurl.com/abcde/finalize",
+						CertURL:     "",
+					}, nil
+				},
+			},
+			shouldSchedule: true,
+			requeueAfter:   time.Minute,
+		},
 		"skip creating a Challenge for an already valid authorization": {
 			order: gen.OrderFrom(testOrder, gen.SetOrderStatus(
 				cmacme.OrderStatus{
@@ -589,6 +630,28 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			},
 			expectErr: true,
 		},
+		"call FinalizeOrder, reschedule if finalize fails and the ACME server requested a Retry-After": {
+			order: gen.OrderFrom(testOrderErroredWithDetail, gen.SetOrderState(cmacme.Ready)),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, gen.OrderFrom(testOrderErroredWithDetail, gen.SetOrderState(cmacme.Ready))},
+				ExpectedActions:    []testpkg.Action{},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderReady, nil
+				},
+				FakeCreateOrderCert: func(ctx context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					acmecl.RecordRetryAfter(ctx, 30*time.Second)
+					return nil, "", &acmeapi.Error{StatusCode: 503}
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+			},
+			shouldSchedule: true,
+			requeueAfter:   30 * time.Second,
+		},
 		"call FinalizeOrder, recover if finalize fails because order is already finalized": {
 			order: testOrderReady,
 			builder: &testpkg.Builder{
@@ -795,6 +858,7 @@ type testT struct {
 	builder        *testpkg.Builder
 	acmeClient     acmecl.Interface
 	shouldSchedule bool
+	requeueAfter   time.Duration
 	expectErr      bool
 }
 
@@ -816,9 +880,11 @@ func runTest(t *testing.T, test testT) {
 		},
 	}
 	gotScheduled := false
+	var gotRequeueAfter time.Duration
 	fakeScheduler := schedulertest.FakeScheduler{
 		AddFunc: func(obj interface{}, duration time.Duration) {
 			gotScheduled = true
+			gotRequeueAfter = duration
 		},
 	}
 	cw.scheduledWorkQueue = &fakeScheduler
@@ -835,6 +901,9 @@ func runTest(t *testing.T, test testT) {
 	if gotScheduled != test.shouldSchedule {
 		t.Errorf("Expected Order to be re-queued: %v got re-queued: %v", test.shouldSchedule, gotScheduled)
 	}
+	if test.requeueAfter != 0 && gotRequeueAfter != test.requeueAfter {
+		t.Errorf("Expected Order to be re-queued after %v, got re-queued after %v", test.requeueAfter, gotRequeueAfter)
+	}
 
 	test.builder.CheckAndFinish(err)
 }
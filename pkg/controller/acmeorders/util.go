@@ -19,6 +19,7 @@ package acmeorders
 import (
 	"context"
 	"fmt"
+	"net"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -70,8 +71,11 @@ func buildChallenge(ctx context.Context, cl acmecl.Interface, issuer cmapi.Gener
 
 	return &cmacme.Challenge{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            chName,
-			Namespace:       o.Namespace,
+			Name:      chName,
+			Namespace: o.Namespace,
+			// Labels are copied from the Order so that Challenges can be
+			// selected using the labels of the Certificate they belong to.
+			Labels:          o.Labels,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(o, orderGvk)},
 		},
 		Spec: *chSpec,
@@ -100,12 +104,18 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 	selectedNumDNSNamesMatch := 0
 	selectedNumDNSZonesMatch := 0
 
+	// IP identifiers (RFC 8738) cannot be validated using the dns-01
+	// challenge type, so DNS01 solvers are never selected for them.
+	isIP := net.ParseIP(authz.Identifier) != nil
+
 	challengeForSolver := func(solver *cmacme.ACMEChallengeSolver) *cmacme.ACMEChallenge {
 		for _, ch := range authz.Challenges {
 			switch {
 			case ch.Type == "http-01" && solver.HTTP01 != nil:
 				return &ch
-			case ch.Type == "dns-01" && solver.DNS01 != nil:
+			case ch.Type == "dns-01" && solver.DNS01 != nil && !isIP:
+				return &ch
+			case ch.Type == "tls-alpn-01" && solver.TLSALPN01 != nil:
 				return &ch
 			}
 		}
@@ -251,8 +261,8 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 	}
 
 	// It should never be possible for this case to be hit as earlier in this
-	// method we already assert that the challenge type is one of 'http-01'
-	// or 'dns-01'.
+	// method we already assert that the challenge type is one of 'http-01',
+	// 'dns-01' or 'tls-alpn-01'.
 	chType, err := challengeType(selectedChallenge.Type)
 	if err != nil {
 		return nil, err
@@ -290,6 +300,8 @@ func challengeType(t string) (cmacme.ACMEChallengeType, error) {
 		return cmacme.ACMEChallengeTypeHTTP01, nil
 	case "dns-01":
 		return cmacme.ACMEChallengeTypeDNS01, nil
+	case "tls-alpn-01":
+		return cmacme.ACMEChallengeTypeTLSALPN01, nil
 	default:
 		return "", fmt.Errorf("unsupported challenge type: %v", t)
 	}
@@ -310,6 +322,7 @@ func applyIngressParameterAnnotationOverrides(o *cmacme.Order, s *cmacme.ACMECha
 	// config
 	if hasManualIngressClass || hasManualIngressName {
 		s.HTTP01.Ingress.Class = nil
+		s.HTTP01.Ingress.IngressClassName = nil
 		s.HTTP01.Ingress.Name = ""
 	}
 	if hasManualIngressName {
@@ -327,6 +340,10 @@ func keyForChallenge(cl acmecl.Interface, token string, chType cmacme.ACMEChalle
 		return cl.HTTP01ChallengeResponse(token)
 	case cmacme.ACMEChallengeTypeDNS01:
 		return cl.DNS01ChallengeRecord(token)
+	case cmacme.ACMEChallengeTypeTLSALPN01:
+		// the key authorization is the same as the HTTP01 challenge response,
+		// and is hashed into the challenge certificate by the solver
+		return cl.HTTP01ChallengeResponse(token)
 	default:
 		return "", fmt.Errorf("unsupported challenge type: %v", chType)
 	}
@@ -69,6 +69,11 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 			},
 		},
 	}
+	emptySelectorSolverTLSALPN01 := cmacme.ACMEChallengeSolver{
+		TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{
+			Mode: cmacme.ACMEChallengeSolverTLSALPN01ModeHostPort,
+		},
+	}
 	exampleComDNSNameSelectorSolver := cmacme.ACMEChallengeSolver{
 		Selector: &cmacme.CertificateDNSNameSelector{
 			DNSNames: []string{"example.com"},
@@ -88,6 +93,10 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 		Type:  "dns-01",
 		Token: "dns-01-token",
 	}
+	acmeChallengeTLSALPN01 := &cmacme.ACMEChallenge{
+		Type:  "tls-alpn-01",
+		Token: "tls-alpn-01-token",
+	}
 
 	tests := map[string]struct {
 		acmeClient acmecl.Interface
@@ -176,6 +185,53 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				},
 			},
 		},
+		"should clear ingressClassName if the ingress class override annotation is specified": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								{
+									HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+										Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+											IngressClassName: pointer.StringPtr("nginx"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmacme.ACMECertificateHTTP01IngressClassOverride: "test-class-to-override",
+					},
+				},
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Key:     "http01",
+				Solver: cmacme.ACMEChallengeSolver{
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							Class: pointer.StringPtr("test-class-to-override"),
+						},
+					},
+				},
+			},
+		},
 		"should return an error if both ingress class and name override annotations are set": {
 			acmeClient: basicACMEClient,
 			issuer: &v1.Issuer{
@@ -237,6 +293,84 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				Solver:  emptySelectorSolverDNS01,
 			},
 		},
+		"should select an HTTP01 solver for an IP identifier": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverDNS01, emptySelectorSolverHTTP01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					IPAddresses: []string{"10.0.0.1"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "10.0.0.1",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeDNS01, *acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "10.0.0.1",
+				Token:   acmeChallengeHTTP01.Token,
+				Key:     "http01",
+				Solver:  emptySelectorSolverHTTP01,
+			},
+		},
+		"should not select a DNS01 solver for an IP identifier": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverDNS01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					IPAddresses: []string{"10.0.0.1"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "10.0.0.1",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeDNS01},
+			},
+			expectedError: true,
+		},
+		"should select a TLS-ALPN-01 solver if it is the only solver offered by the authorization": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverDNS01, emptySelectorSolverTLSALPN01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01, *acmeChallengeTLSALPN01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeTLSALPN01,
+				DNSName: "example.com",
+				Token:   acmeChallengeTLSALPN01.Token,
+				Key:     "http01",
+				Solver:  emptySelectorSolverTLSALPN01,
+			},
+		},
 		"should use configured default solver when no others are present": {
 			acmeClient: basicACMEClient,
 			issuer: &v1.Issuer{
@@ -216,6 +216,12 @@ func (c *secretDataSource) ReadCA(ctx context.Context, log logr.Logger, metaObj
 		return nil, nil
 	}
 
+	// previous CA certificates, e.g. those kept by the webhook's dynamic
+	// authority during a rotation of its CA, are trusted as well
+	if previousCAData := secret.Data[cmmeta.TLSPreviousCAKey]; len(previousCAData) > 0 {
+		caData = append(append([]byte{}, caData...), previousCAData...)
+	}
+
 	return caData, nil
 }
 
@@ -21,13 +21,13 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/duration"
 )
 
 var (
@@ -67,20 +67,24 @@ func translateAnnotations(crt *cmapi.Certificate, ingLikeAnnotations map[string]
 		crt.Spec.CommonName = commonName
 	}
 
-	if duration, found := ingLikeAnnotations[cmapi.DurationAnnotationKey]; found {
-		duration, err := time.ParseDuration(duration)
+	if controllerName, found := ingLikeAnnotations[cmapi.IngressControllerNameAnnotationKey]; found {
+		crt.Spec.ControllerName = controllerName
+	}
+
+	if certDuration, found := ingLikeAnnotations[cmapi.DurationAnnotationKey]; found {
+		certDuration, err := duration.Parse(certDuration)
 		if err != nil {
 			return fmt.Errorf("%w %q: %v", errInvalidIngressAnnotation, cmapi.DurationAnnotationKey, err)
 		}
-		crt.Spec.Duration = &metav1.Duration{Duration: duration}
+		crt.Spec.Duration = &metav1.Duration{Duration: certDuration}
 	}
 
 	if renewBefore, found := ingLikeAnnotations[cmapi.RenewBeforeAnnotationKey]; found {
-		duration, err := time.ParseDuration(renewBefore)
+		renewBefore, err := duration.Parse(renewBefore)
 		if err != nil {
 			return fmt.Errorf("%w %q: %v", errInvalidIngressAnnotation, cmapi.RenewBeforeAnnotationKey, err)
 		}
-		crt.Spec.RenewBefore = &metav1.Duration{Duration: duration}
+		crt.Spec.RenewBefore = &metav1.Duration{Duration: renewBefore}
 	}
 
 	if usages, found := ingLikeAnnotations[cmapi.UsagesAnnotationKey]; found {
@@ -89,7 +93,8 @@ func translateAnnotations(crt *cmapi.Certificate, ingLikeAnnotations map[string]
 			usage := cmapi.KeyUsage(strings.Trim(usageName, " "))
 			_, isKU := apiutil.KeyUsageType(usage)
 			_, isEKU := apiutil.ExtKeyUsageType(usage)
-			if !isKU && !isEKU {
+			_, isUnknownEKU := apiutil.UnknownExtKeyUsageOID(usage)
+			if !isKU && !isEKU && !isUnknownEKU {
 				return fmt.Errorf("%w %q: invalid key usage name %q", errInvalidIngressAnnotation, cmapi.UsagesAnnotationKey, usageName)
 			}
 			newUsages = append(newUsages, usage)
@@ -127,6 +127,17 @@ func Test_translateAnnotations(t *testing.T) {
 				a.Equal(cmapi.RotationPolicyAlways, crt.Spec.PrivateKey.RotationPolicy)
 			},
 		},
+		"success durations in days and weeks": {
+			crt: gen.Certificate("example-cert"),
+			annotations: map[string]string{
+				cmapi.DurationAnnotationKey:    "90d",
+				cmapi.RenewBeforeAnnotationKey: "2w",
+			},
+			check: func(a *assert.Assertions, crt *cmapi.Certificate) {
+				a.Equal(&metav1.Duration{Duration: time.Hour * 24 * 90}, crt.Spec.Duration)
+				a.Equal(&metav1.Duration{Duration: time.Hour * 24 * 14}, crt.Spec.RenewBefore)
+			},
+		},
 		"nil annotations": {
 			crt:         gen.Certificate("example-cert"),
 			annotations: nil,
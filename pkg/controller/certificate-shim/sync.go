@@ -76,6 +76,7 @@ func SyncFnFor(
 	cmLister cmlisters.CertificateLister,
 	defaults controller.IngressShimOptions,
 	fieldManager string,
+	controllerClass string,
 ) SyncFn {
 	return func(ctx context.Context, ingLike metav1.Object) error {
 		log := logf.WithResource(log, ingLike)
@@ -102,6 +103,12 @@ func SyncFnFor(
 			return nil
 		}
 
+		if !controller.ManagesControllerName(controllerClass, ingLike.GetAnnotations()[cmapi.IngressControllerNameAnnotationKey]) {
+			logf.V(logf.DebugLevel).Infof("not syncing ingress resource as its %q annotation does not match the controller class",
+				cmapi.IngressControllerNameAnnotationKey)
+			return nil
+		}
+
 		issuerName, issuerKind, issuerGroup, err := issuerForIngressLike(defaults, ingLike)
 		if err != nil {
 			log.Error(err, "failed to determine issuer to be used for ingress resource")
@@ -116,7 +123,14 @@ func SyncFnFor(
 			return nil
 		}
 
-		newCrts, updateCrts, err := buildCertificates(rec, log, cmLister, ingLike, issuerName, issuerKind, issuerGroup)
+		// The DNS zone routes only apply to resources which rely on the
+		// default issuer.
+		var zoneRoutes map[string]cmmeta.ObjectReference
+		if !hasIssuerNameAnnotation(ingLike) {
+			zoneRoutes = defaults.DefaultIssuerZoneRoutes
+		}
+
+		newCrts, updateCrts, err := buildCertificates(rec, log, cmLister, ingLike, issuerName, issuerKind, issuerGroup, zoneRoutes)
 		if err != nil {
 			return err
 		}
@@ -140,10 +154,11 @@ func SyncFnFor(
 						OwnerReferences: crt.OwnerReferences,
 					},
 					Spec: cmapi.CertificateSpec{
-						DNSNames:   crt.Spec.DNSNames,
-						SecretName: crt.Spec.SecretName,
-						IssuerRef:  crt.Spec.IssuerRef,
-						Usages:     crt.Spec.Usages,
+						DNSNames:       crt.Spec.DNSNames,
+						SecretName:     crt.Spec.SecretName,
+						IssuerRef:      crt.Spec.IssuerRef,
+						Usages:         crt.Spec.Usages,
+						ControllerName: crt.Spec.ControllerName,
 					},
 				})
 			} else {
@@ -288,6 +303,7 @@ func buildCertificates(
 	cmLister cmlisters.CertificateLister,
 	ingLike metav1.Object,
 	issuerName, issuerKind, issuerGroup string,
+	zoneRoutes map[string]cmmeta.ObjectReference,
 ) (new, update []*cmapi.Certificate, _ error) {
 
 	var newCrts []*cmapi.Certificate
@@ -348,6 +364,24 @@ func buildCertificates(
 			controllerGVK = gatewayGVK
 		}
 
+		issuerRef := cmmeta.ObjectReference{
+			Name:  issuerName,
+			Kind:  issuerKind,
+			Group: issuerGroup,
+		}
+		routedRef, routed, err := issuerForHosts(zoneRoutes, hosts)
+		if err != nil {
+			rec.Eventf(ingLike.(runtime.Object), corev1.EventTypeWarning, reasonBadConfig, "Skipped the TLS hosts of secret %q: %v", secretRef.Name, err)
+			continue
+		}
+		if routed {
+			issuerRef = routedRef
+		}
+		if len(issuerRef.Name) == 0 {
+			rec.Eventf(ingLike.(runtime.Object), corev1.EventTypeWarning, reasonBadConfig, "Skipped the TLS hosts of secret %q: no DNS zone route matches the hosts %v and no default issuer is configured", secretRef.Name, hosts)
+			continue
+		}
+
 		crt := &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:            secretRef.Name,
@@ -358,12 +392,8 @@ func buildCertificates(
 			Spec: cmapi.CertificateSpec{
 				DNSNames:   hosts,
 				SecretName: secretRef.Name,
-				IssuerRef: cmmeta.ObjectReference{
-					Name:  issuerName,
-					Kind:  issuerKind,
-					Group: issuerGroup,
-				},
-				Usages: cmapi.DefaultKeyUsages(),
+				IssuerRef:  issuerRef,
+				Usages:     cmapi.DefaultKeyUsages(),
 			},
 		}
 
@@ -497,6 +527,10 @@ func certNeedsUpdate(a, b *cmapi.Certificate) bool {
 		return true
 	}
 
+	if a.Spec.ControllerName != b.Spec.ControllerName {
+		return true
+	}
+
 	var aAlgorithm, bAlgorithm cmapi.PrivateKeyAlgorithm
 	if a.Spec.PrivateKey != nil && a.Spec.PrivateKey.Algorithm != "" {
 		aAlgorithm = a.Spec.PrivateKey.Algorithm
@@ -643,6 +677,71 @@ func hasShimAnnotation(ingLike metav1.Object, autoCertificateAnnotations []strin
 	return false
 }
 
+// hasIssuerNameAnnotation returns true if the given ingress-like resource
+// references an issuer with one of the annotations:
+//
+//	cert-manager.io/issuer
+//	cert-manager.io/cluster-issuer
+func hasIssuerNameAnnotation(ingLike metav1.Object) bool {
+	annotations := ingLike.GetAnnotations()
+	_, issuerNameOK := annotations[cmapi.IngressIssuerNameAnnotationKey]
+	_, clusterIssuerNameOK := annotations[cmapi.IngressClusterIssuerNameAnnotationKey]
+	return issuerNameOK || clusterIssuerNameOK
+}
+
+// issuerForHosts returns the issuer that the DNS zone routes route the given
+// hosts to. Each host is routed by the longest zone that it is equal to, or a
+// subdomain of. routed is false if none of the hosts are in any of the zones,
+// in which case the default issuer should be used. All the hosts must be
+// routed to the same issuer, as they are requested in a single Certificate.
+func issuerForHosts(zoneRoutes map[string]cmmeta.ObjectReference, hosts []string) (ref cmmeta.ObjectReference, routed bool, err error) {
+	if len(zoneRoutes) == 0 {
+		return cmmeta.ObjectReference{}, false, nil
+	}
+
+	var routedHosts, unroutedHosts []string
+	for _, host := range hosts {
+		hostRef, ok := issuerForHost(zoneRoutes, host)
+		if !ok {
+			unroutedHosts = append(unroutedHosts, host)
+			continue
+		}
+		if len(routedHosts) > 0 && hostRef != ref {
+			return cmmeta.ObjectReference{}, false, fmt.Errorf("the hosts %q and %q are routed to different issuers", routedHosts[0], host)
+		}
+		ref = hostRef
+		routedHosts = append(routedHosts, host)
+	}
+
+	if len(routedHosts) == 0 {
+		return cmmeta.ObjectReference{}, false, nil
+	}
+	if len(unroutedHosts) > 0 {
+		return cmmeta.ObjectReference{}, false, fmt.Errorf("the host %q is routed to issuer %q but the host %q is not in any DNS zone", routedHosts[0], ref.Name, unroutedHosts[0])
+	}
+	return ref, true, nil
+}
+
+// issuerForHost returns the issuer of the longest DNS zone that the given
+// host is equal to, or a subdomain of.
+func issuerForHost(zoneRoutes map[string]cmmeta.ObjectReference, host string) (cmmeta.ObjectReference, bool) {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	var (
+		ref     cmmeta.ObjectReference
+		longest = -1
+	)
+	for zone, zoneRef := range zoneRoutes {
+		zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+		if host != zone && !strings.HasSuffix(host, "."+zone) {
+			continue
+		}
+		if len(zone) > longest {
+			ref, longest = zoneRef, len(zone)
+		}
+	}
+	return ref, longest >= 0
+}
+
 // issuerForIngressLike determines the Issuer that should be specified on a
 // Certificate created for the given ingress-like resource. If one is not set,
 // the default issuer given to the controller is used. We look up the following
@@ -687,7 +786,10 @@ func issuerForIngressLike(defaults controller.IngressShimOptions, ingLike metav1
 		group = groupName
 	}
 
-	if len(name) == 0 {
+	// Without an issuer annotation, the issuer may instead be chosen for each
+	// set of hosts by the DNS zone routes.
+	routable := !issuerNameOK && !clusterIssuerNameOK && len(defaults.DefaultIssuerZoneRoutes) > 0
+	if len(name) == 0 && !routable {
 		errs = append(errs, "failed to determine issuer name to be used for ingress resource")
 	}
 
@@ -88,6 +88,7 @@ func TestSync(t *testing.T) {
 		DefaultIssuerName   string
 		DefaultIssuerKind   string
 		DefaultIssuerGroup  string
+		ControllerClass     string
 		Err                 bool
 		ExpectedCreate      []*cmapi.Certificate
 		ExpectedUpdate      []*cmapi.Certificate
@@ -145,6 +146,75 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:            "return a Certificate with the controller name of an ingress whose controller-name annotation matches the controller class",
+			Issuer:          acmeClusterIssuer,
+			ControllerClass: "team",
+			IngressLike: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+						cmapi.IngressControllerNameAnnotationKey:    "team",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1.IngressSpec{
+					TLS: []networkingv1.IngressTLS{
+						{
+							Hosts:      []string{"example.com"},
+							SecretName: "example-com-tls",
+						},
+					},
+				},
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedEvents:      []string{`Normal CreateCertificate Successfully created Certificate "example-com-tls"`},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildIngressOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+						Usages:         cmapi.DefaultKeyUsages(),
+						ControllerName: "team",
+					},
+				},
+			},
+		},
+		{
+			Name:   "not create a Certificate for an ingress whose controller-name annotation does not match the controller class",
+			Issuer: acmeClusterIssuer,
+			IngressLike: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+						cmapi.IngressControllerNameAnnotationKey:    "team",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1.IngressSpec{
+					TLS: []networkingv1.IngressTLS{
+						{
+							Hosts:      []string{"example.com"},
+							SecretName: "example-com-tls",
+						},
+					},
+				},
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+		},
 		{
 			Name:   "return a single HTTP01 Certificate for an ingress with a single valid TLS entry and HTTP01 annotations using edit-in-place",
 			Issuer: acmeClusterIssuer,
@@ -2846,7 +2916,7 @@ func TestSync(t *testing.T) {
 				DefaultIssuerKind:                 test.DefaultIssuerKind,
 				DefaultIssuerGroup:                test.DefaultIssuerGroup,
 				DefaultAutoCertificateAnnotations: []string{"kubernetes.io/tls-acme"},
-			}, "cert-manager-test")
+			}, "cert-manager-test", test.ControllerClass)
 			b.Start()
 
 			err := sync(context.Background(), test.IngressLike)
@@ -3020,6 +3090,68 @@ func buildIngressOwnerReferences(name, namespace string) []metav1.OwnerReference
 	}
 }
 
+func Test_issuerForHosts(t *testing.T) {
+	public := cmmeta.ObjectReference{Name: "letsencrypt", Kind: "ClusterIssuer"}
+	internal := cmmeta.ObjectReference{Name: "internal-ca", Kind: "ClusterIssuer"}
+	staging := cmmeta.ObjectReference{Name: "staging-ca", Kind: "Issuer"}
+	zoneRoutes := map[string]cmmeta.ObjectReference{
+		"example.com":         public,
+		"corp.example.com.":   internal,
+		"staging.example.net": staging,
+	}
+
+	tests := map[string]struct {
+		zoneRoutes map[string]cmmeta.ObjectReference
+		hosts      []string
+		expRef     cmmeta.ObjectReference
+		expRouted  bool
+		expErr     bool
+	}{
+		"no routes configured": {
+			hosts: []string{"www.example.com"},
+		},
+		"hosts in no zone are not routed": {
+			zoneRoutes: zoneRoutes,
+			hosts:      []string{"www.example.org", "example.net"},
+		},
+		"the zone apex is routed": {
+			zoneRoutes: zoneRoutes,
+			hosts:      []string{"example.com"},
+			expRef:     public,
+			expRouted:  true,
+		},
+		"the longest zone wins": {
+			zoneRoutes: zoneRoutes,
+			hosts:      []string{"app.corp.example.com", "API.Corp.Example.com."},
+			expRef:     internal,
+			expRouted:  true,
+		},
+		"hosts routed to different issuers": {
+			zoneRoutes: zoneRoutes,
+			hosts:      []string{"www.example.com", "app.corp.example.com"},
+			expErr:     true,
+		},
+		"routed and unrouted hosts": {
+			zoneRoutes: zoneRoutes,
+			hosts:      []string{"www.example.com", "www.example.org"},
+			expErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ref, routed, err := issuerForHosts(test.zoneRoutes, test.hosts)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expRouted, routed)
+			assert.Equal(t, test.expRef, ref)
+		})
+	}
+}
+
 // The Gateway name and UID are set to the same.
 func buildGatewayOwnerReferences(name, namespace string) []metav1.OwnerReference {
 	return []metav1.OwnerReference{
@@ -139,7 +139,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	}
 
 	// If we fail to build the order we have to hard fail.
-	expectedOrder, err := buildOrder(cr, csr, issuer.GetSpec().ACME.EnableDurationFeature)
+	expectedOrder, err := buildOrder(cr, csr, issuer.GetSpec().ACME)
 	if err != nil {
 		message := "Failed to build order"
 
@@ -239,7 +239,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 }
 
 // Build order. If we error here it is a terminating failure.
-func buildOrder(cr *cmapi.CertificateRequest, csr *x509.CertificateRequest, enableDurationFeature bool) (*cmacme.Order, error) {
+func buildOrder(cr *cmapi.CertificateRequest, csr *x509.CertificateRequest, acmeIssuer *cmacme.ACMEIssuer) (*cmacme.Order, error) {
 	var ipAddresses []string
 	for _, ip := range csr.IPAddresses {
 		ipAddresses = append(ipAddresses, ip.String())
@@ -258,10 +258,17 @@ func buildOrder(cr *cmapi.CertificateRequest, csr *x509.CertificateRequest, enab
 		IPAddresses: ipAddresses,
 	}
 
-	if enableDurationFeature {
+	if acmeIssuer.EnableDurationFeature {
 		spec.Duration = cr.Spec.Duration
 	}
 
+	// The profile annotation copied from the Certificate takes precedence
+	// over the profile configured on the issuer.
+	spec.Profile = acmeIssuer.Profile
+	if profile, ok := cr.Annotations[cmacme.ACMECertificateProfileAnnotationKey]; ok {
+		spec.Profile = profile
+	}
+
 	computeNameSpec := spec.DeepCopy()
 	// create a deep copy of the OrderSpec so we can overwrite the Request and NotAfter field
 	computeNameSpec.Request = nil
@@ -184,12 +184,12 @@ func TestSign(t *testing.T) {
 		t.Fatal(err)
 	}
 	ipBaseCR := gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestCSR(ipCSRPEM))
-	ipBaseOrder, err := buildOrder(ipBaseCR, ipCSR, baseIssuer.GetSpec().ACME.EnableDurationFeature)
+	ipBaseOrder, err := buildOrder(ipBaseCR, ipCSR, baseIssuer.GetSpec().ACME)
 	if err != nil {
 		t.Fatalf("failed to build order during testing: %s", err)
 	}
 
-	baseOrder, err := buildOrder(baseCR, csr, baseIssuer.GetSpec().ACME.EnableDurationFeature)
+	baseOrder, err := buildOrder(baseCR, csr, baseIssuer.GetSpec().ACME)
 	if err != nil {
 		t.Fatalf("failed to build order during testing: %s", err)
 	}
@@ -670,9 +670,9 @@ func Test_buildOrder(t *testing.T) {
 
 	cr := gen.CertificateRequest("test", gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}), gen.SetCertificateRequestCSR(csrPEM))
 	type args struct {
-		cr                    *v1.CertificateRequest
-		csr                   *x509.CertificateRequest
-		enableDurationFeature bool
+		cr         *v1.CertificateRequest
+		csr        *x509.CertificateRequest
+		acmeIssuer *cmacme.ACMEIssuer
 	}
 	tests := []struct {
 		name    string
@@ -683,9 +683,9 @@ func Test_buildOrder(t *testing.T) {
 		{
 			name: "Normal building of order",
 			args: args{
-				cr:                    cr,
-				csr:                   csr,
-				enableDurationFeature: false,
+				cr:         cr,
+				csr:        csr,
+				acmeIssuer: &cmacme.ACMEIssuer{},
 			},
 			want: &cmacme.Order{
 				Spec: cmacme.OrderSpec{
@@ -699,9 +699,9 @@ func Test_buildOrder(t *testing.T) {
 		{
 			name: "Building with enableDurationFeature",
 			args: args{
-				cr:                    cr,
-				csr:                   csr,
-				enableDurationFeature: true,
+				cr:         cr,
+				csr:        csr,
+				acmeIssuer: &cmacme.ACMEIssuer{EnableDurationFeature: true},
 			},
 			want: &cmacme.Order{
 				Spec: cmacme.OrderSpec{
@@ -713,10 +713,46 @@ func Test_buildOrder(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Building with the issuer's profile",
+			args: args{
+				cr:         cr,
+				csr:        csr,
+				acmeIssuer: &cmacme.ACMEIssuer{Profile: "shortlived"},
+			},
+			want: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					Request:    csrPEM,
+					CommonName: "example.com",
+					DNSNames:   []string{"example.com"},
+					Profile:    "shortlived",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Building with the profile annotation overriding the issuer's profile",
+			args: args{
+				cr: gen.CertificateRequestFrom(cr, gen.AddCertificateRequestAnnotations(map[string]string{
+					cmacme.ACMECertificateProfileAnnotationKey: "tlsserver",
+				})),
+				csr:        csr,
+				acmeIssuer: &cmacme.ACMEIssuer{Profile: "shortlived"},
+			},
+			want: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					Request:    csrPEM,
+					CommonName: "example.com",
+					DNSNames:   []string{"example.com"},
+					Profile:    "tlsserver",
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := buildOrder(tt.args.cr, tt.args.csr, tt.args.enableDurationFeature)
+			got, err := buildOrder(tt.args.cr, tt.args.csr, tt.args.acmeIssuer)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("buildOrder() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -733,7 +769,7 @@ func Test_buildOrder(t *testing.T) {
 		"test-comparison-that-is-at-the-fifty-two-character-l",
 		gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
 		gen.SetCertificateRequestCSR(csrPEM))
-	orderOne, err := buildOrder(longCrOne, csr, false)
+	orderOne, err := buildOrder(longCrOne, csr, &cmacme.ACMEIssuer{})
 	if err != nil {
 		t.Errorf("buildOrder() received error %v", err)
 		return
@@ -745,7 +781,7 @@ func Test_buildOrder(t *testing.T) {
 			gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
 			gen.SetCertificateRequestCSR(csrPEM))
 
-		orderTwo, err := buildOrder(longCrTwo, csr, false)
+		orderTwo, err := buildOrder(longCrTwo, csr, &cmacme.ACMEIssuer{})
 		if err != nil {
 			t.Errorf("buildOrder() received error %v", err)
 			return
@@ -760,13 +796,13 @@ func Test_buildOrder(t *testing.T) {
 	})
 
 	t.Run("Builds two orders from the same long CRs to guarantee same name", func(t *testing.T) {
-		orderOne, err := buildOrder(longCrOne, csr, false)
+		orderOne, err := buildOrder(longCrOne, csr, &cmacme.ACMEIssuer{})
 		if err != nil {
 			t.Errorf("buildOrder() received error %v", err)
 			return
 		}
 
-		orderTwo, err := buildOrder(longCrOne, csr, false)
+		orderTwo, err := buildOrder(longCrOne, csr, &cmacme.ACMEIssuer{})
 		if err != nil {
 			t.Errorf("buildOrder() received error %v", err)
 			return
@@ -38,9 +38,11 @@ const (
 
 // Controller is a CertificateRequest controller which manages the "Approved"
 // condition. In the absence of any automated policy engine, this controller
-// will _always_ set the "Approved" condition to True. All CertificateRequest
-// signing controllers should wait until the "Approved" condition is set to
-// True before processing.
+// will _always_ set the "Approved" condition to True, unless the
+// CertificateRequest requests a signing usage outside of the namespaces
+// configured for it, in which case the "Denied" condition is set instead.
+// All CertificateRequest signing controllers should wait until the
+// "Approved" condition is set to True before processing.
 type Controller struct {
 	// logger to be used by this controller
 	log logr.Logger
@@ -49,6 +51,11 @@ type Controller struct {
 	cmClient                 cmclient.Interface
 	fieldManager             string
 
+	// signingUsageNamespaces are the namespaces in which CertificateRequests
+	// with the code signing or document signing usages are approved. If
+	// empty, these usages are not restricted.
+	signingUsageNamespaces []string
+
 	recorder record.EventRecorder
 
 	queue workqueue.RateLimitingInterface
@@ -77,6 +84,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
 	c.recorder = ctx.Recorder
+	c.signingUsageNamespaces = ctx.CertificateOptions.SigningUsageNamespaces
 
 	c.log.V(logf.DebugLevel).Info("certificate request approver controller registered")
 
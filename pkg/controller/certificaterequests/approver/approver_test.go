@@ -18,6 +18,7 @@ package approver
 
 import (
 	"context"
+	"encoding/pem"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 func TestProcessItem(t *testing.T) {
@@ -45,6 +47,10 @@ func TestProcessItem(t *testing.T) {
 		// if not set, the 'key' will be passed to ProcessItem instead.
 		request *cmapi.CertificateRequest
 
+		// signingUsageNamespaces configures the namespaces in which signing
+		// usages are approved.
+		signingUsageNamespaces []string
+
 		// expectedEvent, if set, is an 'event string' that is expected to be fired.
 		expectedEvent string
 
@@ -165,6 +171,82 @@ func TestProcessItem(t *testing.T) {
 			},
 			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
 		},
+		"deny CertificateRequest with signing usages outside of the signing usage namespaces": {
+			signingUsageNamespaces: []string{"signers"},
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					Usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageCodeSigning, cmapi.UsageDocumentSigning},
+				},
+			},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionDenied,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            `Certificate request has been denied by cert-manager.io: usages [code signing document signing] are not permitted in namespace "testns"`,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: `Warning cert-manager.io Certificate request has been denied by cert-manager.io: usages [code signing document signing] are not permitted in namespace "testns"`,
+		},
+		"approve CertificateRequest with signing usages in a signing usage namespace": {
+			signingUsageNamespaces: []string{"signers", "testns"},
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					Usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageDocumentSigning},
+				},
+			},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+		},
+		"deny CertificateRequest with signing usages only in the CSR outside of the signing usage namespaces": {
+			signingUsageNamespaces: []string{"signers"},
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					Request: mustGenerateCSR(t, cmapi.UsageDigitalSignature, cmapi.UsageCodeSigning),
+				},
+			},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionDenied,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            `Certificate request has been denied by cert-manager.io: usages [code signing] are not permitted in namespace "testns"`,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: `Warning cert-manager.io Certificate request has been denied by cert-manager.io: usages [code signing] are not permitted in namespace "testns"`,
+		},
+		"approve CertificateRequest without signing usages outside of the signing usage namespaces": {
+			signingUsageNamespaces: []string{"signers"},
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					Usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+				},
+			},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -177,6 +259,7 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.request)
 			}
 			builder.Init()
+			builder.Context.CertificateOptions.SigningUsageNamespaces = test.signingUsageNamespaces
 
 			c := new(Controller)
 			_, _, err := c.Register(builder.Context)
@@ -238,3 +321,26 @@ func TestProcessItem(t *testing.T) {
 		})
 	}
 }
+
+// mustGenerateCSR returns a PEM encoded CSR which requests the given usages.
+func mustGenerateCSR(t *testing.T, usages ...cmapi.KeyUsage) []byte {
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := pki.GenerateCSR(&cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName: "example.com",
+			Usages:     usages,
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := pki.EncodeCSR(csr, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+}
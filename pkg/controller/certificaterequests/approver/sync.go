@@ -18,6 +18,7 @@ package approver
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,15 +30,21 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 const (
 	ApprovedMessage = "Certificate request has been approved by cert-manager.io"
 )
 
+// signingUsages are the usages which are only approved in the namespaces
+// configured with --signing-usage-namespaces.
+var signingUsages = []cmapi.KeyUsage{cmapi.UsageCodeSigning, cmapi.UsageDocumentSigning}
+
 // Sync will set the "Approved" condition to True on synced
-// CertificateRequests. If the "Denied", "Approved" or "Ready" condition
-// already exists, exit early.
+// CertificateRequests, or the "Denied" condition to True if they request a
+// signing usage which is not permitted in their namespace. If the "Denied",
+// "Approved" or "Ready" condition already exists, exit early.
 func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (err error) {
 	log := logf.FromContext(ctx, "approver")
 
@@ -55,6 +62,27 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	if usages := c.forbiddenSigningUsages(cr); len(usages) > 0 {
+		message := fmt.Sprintf("Certificate request has been denied by cert-manager.io: usages %v are not permitted in namespace %q", usages, cr.Namespace)
+
+		cr = cr.DeepCopy()
+		apiutil.SetCertificateRequestCondition(cr,
+			cmapi.CertificateRequestConditionDenied,
+			cmmeta.ConditionTrue,
+			"cert-manager.io",
+			message,
+		)
+
+		if err := c.updateStatusOrApply(ctx, cr); err != nil {
+			return err
+		}
+		c.recorder.Event(cr, corev1.EventTypeWarning, "cert-manager.io", message)
+
+		log.V(logf.DebugLevel).Info("denied certificate request", "usages", usages)
+
+		return nil
+	}
+
 	// Update the CertificateRequest approved condition to true.
 	cr = cr.DeepCopy()
 	apiutil.SetCertificateRequestCondition(cr,
@@ -75,6 +103,41 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	return nil
 }
 
+// forbiddenSigningUsages returns the signing usages of the
+// CertificateRequest which are not permitted in its namespace. Both the
+// spec.usages and the usages encoded in the CSR are checked, as spec.usages
+// may be left empty while the CSR requests signing usages.
+func (c *Controller) forbiddenSigningUsages(cr *cmapi.CertificateRequest) []cmapi.KeyUsage {
+	if len(c.signingUsageNamespaces) == 0 {
+		return nil
+	}
+	for _, ns := range c.signingUsageNamespaces {
+		if ns == cr.Namespace {
+			return nil
+		}
+	}
+
+	usages := cr.Spec.Usages
+	// A CSR which cannot be decoded is failed by the issuer controllers, so
+	// only spec.usages are checked for it.
+	if csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request); err == nil {
+		if csrUsages, err := pki.CSRKeyUsages(csr); err == nil {
+			usages = append(append([]cmapi.KeyUsage(nil), usages...), csrUsages...)
+		}
+	}
+
+	var forbidden []cmapi.KeyUsage
+	for _, signingUsage := range signingUsages {
+		for _, usage := range usages {
+			if usage == signingUsage {
+				forbidden = append(forbidden, usage)
+				break
+			}
+		}
+	}
+	return forbidden
+}
+
 func (c *Controller) updateStatusOrApply(ctx context.Context, cr *cmapi.CertificateRequest) error {
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
 		return internalcertificaterequests.ApplyStatus(ctx, c.cmClient, c.fieldManager, cr)
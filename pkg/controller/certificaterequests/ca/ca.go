@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -31,6 +32,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	caissuer "github.com/cert-manager/cert-manager/pkg/issuer/ca"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
@@ -47,6 +49,7 @@ type signingFn func([]*x509.Certificate, crypto.Signer, *x509.Certificate) (pki.
 type CA struct {
 	issuerOptions controllerpkg.IssuerOptions
 	secretsLister corelisters.SecretLister
+	kubeClient    kubernetes.Interface
 
 	reporter *crutil.Reporter
 
@@ -68,6 +71,7 @@ func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 	return &CA{
 		issuerOptions:     ctx.IssuerOptions,
 		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		kubeClient:        ctx.Client,
 		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		templateGenerator: pki.GenerateTemplateFromCertificateRequest,
 		signingFn:         pki.SignCSRTemplate,
@@ -110,6 +114,15 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 		return nil, err
 	}
 
+	crossSignedChainSecretName := issuerObj.GetSpec().CA.CrossSignedChainSecretName
+	crossSignedChain, err := caissuer.CrossSignedChain(c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA, caCerts[0])
+	if err != nil {
+		message := fmt.Sprintf("Failed to get cross-signed chain from secret %s/%s", resourceNamespace, crossSignedChainSecretName)
+		c.reporter.Pending(cr, err, "CrossSignedChainError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
 	template, err := c.templateGenerator(cr)
 	if err != nil {
 		message := "Error generating certificate template"
@@ -121,6 +134,30 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 
+	backdate, err := apiutil.NotBeforeBackdate(cr.Annotations, issuerObj.GetSpec().CA.NotBeforeBackdate)
+	if err != nil {
+		message := "Error generating certificate template"
+		c.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+	template.NotBefore = template.NotBefore.Add(-backdate)
+
+	if err := pki.SetKeyIdentifiers(template, caCerts[0], issuerObj.GetSpec().CA.KeyIdentifierMethod); err != nil {
+		message := "Error generating certificate template"
+		c.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	template.SerialNumber, err = caissuer.GenerateSerialNumber(ctx, c.kubeClient, resourceNamespace, issuerObj.GetSpec().CA, cr.Namespace+"/"+cr.Name)
+	if err != nil {
+		message := "Error generating serial number"
+		c.reporter.Pending(cr, err, "SerialNumberError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
 		message := "Error signing certificate"
@@ -132,7 +169,8 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	log.V(logf.DebugLevel).Info("certificate issued")
 
 	return &issuerpkg.IssueResponse{
-		Certificate: bundle.ChainPEM,
-		CA:          bundle.CAPEM,
+		Certificate:      bundle.ChainPEM,
+		CA:               bundle.CAPEM,
+		CrossSignedChain: crossSignedChain,
 	}, nil
 }
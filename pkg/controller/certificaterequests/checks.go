@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -52,29 +53,39 @@ func (c *Controller) handleGenericIssuer(obj interface{}) {
 }
 
 func (c *Controller) certificatesRequestsForGenericIssuer(iss cmapi.GenericIssuer) ([]*cmapi.CertificateRequest, error) {
-	crts, err := c.certificateRequestLister.List(labels.NewSelector())
-
+	objs, err := c.certificateRequestIndexer.ByIndex(controllerpkg.IssuerRefIndex, controllerpkg.IssuerRefIndexKey(iss))
 	if err != nil {
-		return nil, fmt.Errorf("error listing certificates: %s", err.Error())
+		return nil, fmt.Errorf("error listing certificate requests: %s", err.Error())
 	}
 
-	_, isClusterIssuer := iss.(*cmapi.ClusterIssuer)
-
 	var affected []*cmapi.CertificateRequest
-	for _, crt := range crts {
-		if isClusterIssuer && crt.Spec.IssuerRef.Kind != cmapi.ClusterIssuerKind {
-			continue
+	for _, obj := range objs {
+		if cr, ok := obj.(*cmapi.CertificateRequest); ok {
+			affected = append(affected, cr)
 		}
-		if !isClusterIssuer {
-			if crt.Namespace != iss.GetObjectMeta().Namespace {
-				continue
-			}
-		}
-		if crt.Spec.IssuerRef.Name != iss.GetObjectMeta().Name {
-			continue
-		}
-		affected = append(affected, crt)
 	}
 
 	return affected, nil
 }
+
+// handleIssuerReferenceGrant re-syncs the CertificateRequests which reference
+// the Issuers in the namespace of an IssuerReferenceGrant, since the grant may
+// allow or forbid their cross-namespace references.
+func (c *Controller) handleIssuerReferenceGrant(obj interface{}) {
+	log := c.log.WithName("handleIssuerReferenceGrant")
+
+	grant, ok := obj.(*cmapi.IssuerReferenceGrant)
+	if !ok {
+		log.Error(nil, "object is not an IssuerReferenceGrant")
+		return
+	}
+
+	issuers, err := c.issuerLister.Issuers(grant.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing issuers in the namespace of the grant", "namespace", grant.Namespace)
+		return
+	}
+	for _, iss := range issuers {
+		c.handleGenericIssuer(iss)
+	}
+}
@@ -28,6 +28,9 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	"github.com/cert-manager/cert-manager/internal/controller/audit"
+	"github.com/cert-manager/cert-manager/internal/controller/issuancehook"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
@@ -67,7 +70,26 @@ type Controller struct {
 	// fieldManager is the manager name used for the Apply operations.
 	fieldManager string
 
+	// controllerClass is the class of this installation of cert-manager.
+	// CertificateRequests referencing an issuer with a different
+	// spec.controllerName are ignored.
+	controllerClass string
+
+	// statusWriter is used to write the status of CertificateRequests.
+	statusWriter *statuswriter.Writer
+
+	// auditSink, if set, is used to record the issuance decisions made for
+	// CertificateRequests.
+	auditSink audit.Sink
+
+	// issuanceHooks, if set, calls the IssuanceHooks that apply to
+	// CertificateRequests before and after they are signed.
+	issuanceHooks *issuancehook.Runner
+
 	certificateRequestLister cmlisters.CertificateRequestLister
+	// certificateRequestIndexer is used to look up the CertificateRequests
+	// which reference an issuer using the controllerpkg.IssuerRefIndex index.
+	certificateRequestIndexer cache.Indexer
 
 	// we need to wait for Secrets to be synced to avoid a situation where CA issuer's Secret
 	// is not yet in cached at a time when issuance is attempted,
@@ -166,16 +188,34 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 		// register handler function for clusterissuer resources
 		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
 		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+
+		// IssuanceHooks are cluster scoped, so they are also only used when
+		// not scoped to a single namespace.
+		issuanceHookInformer := ctx.SharedInformerFactory.Certmanager().V1().IssuanceHooks()
+		c.issuanceHooks = issuancehook.NewRunner(issuanceHookInformer.Lister())
+		mustSync = append(mustSync, issuanceHookInformer.Informer().HasSynced)
 	}
 
 	// set all the references to the listers for used by the Sync function
 	c.certificateRequestLister = certificateRequestInformer.Lister()
+	if err := controllerpkg.EnsureIndexers(certificateRequestInformer.Informer(), controllerpkg.CertificateRequestIndexers); err != nil {
+		return nil, nil, err
+	}
+	c.certificateRequestIndexer = certificateRequestInformer.Informer().GetIndexer()
 
 	// register handler functions
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
 	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
+	// IssuerReferenceGrants allow CertificateRequests to reference Issuers
+	// in other namespaces. When a grant changes, the CertificateRequests
+	// referencing the Issuers in its namespace are re-synced.
+	grantLister, grantsSynced := issuer.IssuerReferenceGrantLister(ctx.SharedInformerFactory)
+	if grantLister != nil {
+		ctx.SharedInformerFactory.Certmanager().V1().IssuerReferenceGrants().Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleIssuerReferenceGrant})
+		mustSync = append(mustSync, grantsSynced...)
+	}
 	// create an issuer helper for reading generic issuers
-	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
+	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister, grantLister)
 
 	// clock is used to set the FailureTime of failed CertificateRequests
 	c.clock = ctx.Clock
@@ -184,6 +224,12 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.reporter = util.NewReporter(c.clock, c.recorder)
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
+	c.controllerClass = ctx.ControllerClass
+	c.statusWriter = ctx.StatusWriter
+	if c.statusWriter == nil {
+		c.statusWriter = statuswriter.New(ctx.CMClient, nil)
+	}
+	c.auditSink = ctx.AuditSink
 
 	// Construct the issuer implementation with the built component context.
 	c.issuer = c.issuerConstructor(ctx)
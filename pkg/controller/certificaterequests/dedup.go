@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const reasonDeduplicated = "Deduplicated"
+
+// reuseIssuedCertificate is called before a CertificateRequest is signed. If
+// the issuer sets the `cert-manager.io/request-dedup-window` annotation, and
+// an identical CertificateRequest was issued by it within that window, the
+// certificate issued for that request is copied to cr and true is returned.
+// The certificate is only reused if it hasn't expired yet.
+func (c *Controller) reuseIssuedCertificate(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) bool {
+	log := logf.FromContext(ctx)
+
+	value, ok := issuerObj.GetObjectMeta().Annotations[cmapi.RequestDedupWindowAnnotationKey]
+	if !ok {
+		return false
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil || window <= 0 {
+		log.Error(err, "ignoring invalid annotation on issuer", "annotation", cmapi.RequestDedupWindowAnnotationKey, "value", value)
+		return false
+	}
+
+	objs, err := c.certificateRequestIndexer.ByIndex(controllerpkg.CertificateRequestDedupIndex, controllerpkg.CertificateRequestDedupKey(cr))
+	if err != nil {
+		log.Error(err, "failed to look up identical certificate requests")
+		return false
+	}
+
+	now := c.clock.Now()
+	var (
+		previous *cmapi.CertificateRequest
+		issuedAt time.Time
+	)
+	for _, obj := range objs {
+		other, ok := obj.(*cmapi.CertificateRequest)
+		if !ok || other.Name == cr.Name {
+			continue
+		}
+		cond := apiutil.GetCertificateRequestCondition(other, cmapi.CertificateRequestConditionReady)
+		if cond == nil || cond.LastTransitionTime == nil || now.Sub(cond.LastTransitionTime.Time) > window {
+			continue
+		}
+		cert, err := pki.DecodeX509CertificateBytes(other.Status.Certificate)
+		if err != nil || !now.Before(cert.NotAfter) {
+			continue
+		}
+		if previous == nil || cond.LastTransitionTime.Time.After(issuedAt) {
+			previous, issuedAt = other, cond.LastTransitionTime.Time
+		}
+	}
+	if previous == nil {
+		return false
+	}
+
+	message := fmt.Sprintf("Reusing the certificate issued %s ago for the identical CertificateRequest %q instead of signing the request again",
+		now.Sub(issuedAt).Round(time.Second), previous.Name)
+	log.V(logf.InfoLevel).Info(message)
+	c.recorder.Event(cr, corev1.EventTypeNormal, reasonDeduplicated, message)
+
+	cr.Status.Certificate = previous.Status.Certificate
+	cr.Status.CA = previous.Status.CA
+	cr.Status.CrossSignedChain = previous.Status.CrossSignedChain
+	c.reporter.Ready(cr)
+
+	return true
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegate
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// CRControllerName is the string used to refer to
+	// this controller when enabling or disabling it from
+	// command line flags.
+	CRControllerName = "certificaterequests-issuer-delegate"
+)
+
+// Delegate is a controller that implements `certificaterequests.Issuer`.
+// It signs a CertificateRequest by creating a CertificateRequest for the
+// backing issuer of the first route of the delegate issuer matching the
+// request, owned by the original request, and returning its certificate once
+// it has been signed.
+type Delegate struct {
+	namespaceLister          corelisters.NamespaceLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	cmClientV                cmclientset.CertmanagerV1Interface
+
+	reporter *crutil.Reporter
+
+	// fieldManager is the manager name used for Create and Apply operations.
+	fieldManager string
+}
+
+func init() {
+	// create certificate request controller for delegate issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		// watch the delegated CertificateRequests and trigger resyncs of the
+		// CertificateRequests that own them.
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(
+				apiutil.IssuerDelegate,
+				NewDelegate,
+				func(ctx *controllerpkg.Context, log logr.Logger, queue workqueue.RateLimitingInterface) ([]cache.InformerSynced, error) {
+					namespaceInformer := ctx.KubeSharedInformerFactory.Core().V1().Namespaces().Informer()
+					certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Informer()
+					certificateRequestLister := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister()
+
+					certificateRequestInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
+						WorkFunc: controllerpkg.HandleOwnedResourceNamespacedFunc(
+							log, queue,
+							cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind),
+							func(namespace, name string) (interface{}, error) {
+								return certificateRequestLister.CertificateRequests(namespace).Get(name)
+							},
+						),
+					})
+					return []cache.InformerSynced{namespaceInformer.HasSynced, certificateRequestInformer.HasSynced}, nil
+				},
+			)).
+			Complete()
+	})
+}
+
+// NewDelegate returns a configured controller.
+func NewDelegate(ctx *controllerpkg.Context) certificaterequests.Issuer {
+	return &Delegate{
+		namespaceLister:          ctx.KubeSharedInformerFactory.Core().V1().Namespaces().Lister(),
+		certificateRequestLister: ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister(),
+		cmClientV:                ctx.CMClient.CertmanagerV1(),
+		reporter:                 crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		fieldManager:             ctx.FieldManager,
+	}
+}
+
+// Sign returns the certificate signed by the backing issuer of the first
+// route matching the CertificateRequest.
+//
+// If no delegated CertificateRequest exists for the backing issuer, one is
+// created. The CertificateRequest is then updated with the result of the
+// delegated CertificateRequest once it has been signed or has failed.
+func (d *Delegate) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cmapi.GenericIssuer) (*issuerpkg.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+
+	// Delegating a delegated request could loop forever, e.g. if a delegate
+	// issuer routes requests to itself.
+	if owner := metav1.GetControllerOf(cr); owner != nil && owner.Kind == cmapi.CertificateRequestKind {
+		err := fmt.Errorf("CertificateRequest is owned by CertificateRequest %q", owner.Name)
+		d.reporter.Failed(cr, err, "DelegationLoop", "Delegate issuers may not be used as the backing issuer of another delegate issuer")
+		return nil, nil
+	}
+
+	// If we can't decode the CSR PEM we have to hard fail
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		message := "Failed to decode CSR in spec.request"
+
+		d.reporter.Failed(cr, err, "RequestParsingError", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	namespace, err := d.namespaceLister.Get(cr.Namespace)
+	if err != nil {
+		message := fmt.Sprintf("Failed to get namespace %q", cr.Namespace)
+
+		d.reporter.Pending(cr, err, "NamespaceGetError", message)
+		log.Error(err, message)
+
+		return nil, err
+	}
+
+	route, err := matchRoute(issuer.GetSpec().Delegate, namespace.Labels, csr)
+	if err != nil {
+		message := "Failed to match a route of the delegate issuer"
+
+		d.reporter.Failed(cr, err, "NoMatchingRoute", message)
+		log.V(logf.DebugLevel).Info(fmt.Sprintf("%s: %s", message, err))
+
+		return nil, nil
+	}
+
+	expectedCR, err := buildDelegatedRequest(cr, route.IssuerRef)
+	if err != nil {
+		message := "Failed to build delegated CertificateRequest"
+
+		d.reporter.Failed(cr, err, "DelegatedRequestBuildingError", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	delegated, err := d.certificateRequestLister.CertificateRequests(expectedCR.Namespace).Get(expectedCR.Name)
+	if k8sErrors.IsNotFound(err) {
+		// Failing to create the request here is most likely network
+		// related. We should backoff and keep trying.
+		_, err = d.cmClientV.CertificateRequests(expectedCR.Namespace).Create(ctx, expectedCR, metav1.CreateOptions{FieldManager: d.fieldManager})
+		if err != nil {
+			message := fmt.Sprintf("Failed to create delegated CertificateRequest %s/%s", expectedCR.Namespace, expectedCR.Name)
+
+			d.reporter.Pending(cr, err, "DelegatedRequestCreatingError", message)
+			log.Error(err, message)
+
+			return nil, err
+		}
+
+		message := fmt.Sprintf("Created CertificateRequest %s/%s for issuer %s %q",
+			expectedCR.Namespace, expectedCR.Name, issuerKind(route.IssuerRef), route.IssuerRef.Name)
+		d.reporter.Pending(cr, nil, "DelegatedRequestCreated", message)
+		log.V(logf.DebugLevel).Info(message)
+
+		return nil, nil
+	}
+	if err != nil {
+		// We are probably in a network error here so we should backoff and retry
+		message := fmt.Sprintf("Failed to get delegated CertificateRequest %s/%s", expectedCR.Namespace, expectedCR.Name)
+
+		d.reporter.Pending(cr, err, "DelegatedRequestGetError", message)
+		log.Error(err, message)
+
+		return nil, err
+	}
+	if !metav1.IsControlledBy(delegated, cr) {
+		return nil, fmt.Errorf("found CertificateRequest %s/%s not owned by this CertificateRequest, retrying", delegated.Namespace, delegated.Name)
+	}
+
+	log = logf.WithRelatedResource(log, delegated)
+
+	// If the delegated request has failed then so too does the
+	// CertificateRequest meet the same fate.
+	if reason, failed := delegatedRequestFailure(delegated); failed {
+		message := fmt.Sprintf("Delegated CertificateRequest %s/%s was not signed", delegated.Namespace, delegated.Name)
+		d.reporter.Failed(cr, errors.New(reason), "DelegatedRequestFailed", message)
+		return nil, nil
+	}
+
+	if len(delegated.Status.Certificate) == 0 {
+		d.reporter.Pending(cr, nil, "DelegatedRequestPending",
+			fmt.Sprintf("Waiting on certificate issuance from CertificateRequest %s/%s", delegated.Namespace, delegated.Name))
+
+		log.V(logf.DebugLevel).Info("delegated CertificateRequest has not been signed, waiting...")
+		return nil, nil
+	}
+
+	log.V(logf.InfoLevel).Info("certificate issued")
+
+	return &issuerpkg.IssueResponse{
+		Certificate: delegated.Status.Certificate,
+		CA:          delegated.Status.CA,
+	}, nil
+}
+
+// matchRoute returns the first route of the delegate issuer matching a
+// request in a namespace with the given labels for the given CSR.
+func matchRoute(delegate *cmapi.DelegateIssuer, namespaceLabels map[string]string, csr *x509.CertificateRequest) (*cmapi.DelegateIssuerRoute, error) {
+	for i := range delegate.Routes {
+		route := &delegate.Routes[i]
+
+		if route.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(route.NamespaceSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid namespace selector in route %d: %w", i, err)
+			}
+			if !selector.Matches(labels.Set(namespaceLabels)) {
+				continue
+			}
+		}
+
+		if len(route.DNSZones) > 0 && !dnsNamesInZones(csr.DNSNames, route.DNSZones) {
+			continue
+		}
+
+		return route, nil
+	}
+	return nil, fmt.Errorf("no route matches the namespace labels %v and DNS names %v", namespaceLabels, csr.DNSNames)
+}
+
+// dnsNamesInZones returns true if there is at least one DNS name, and every
+// DNS name is equal to, or a subdomain of, one of the zones.
+func dnsNamesInZones(dnsNames, zones []string) bool {
+	if len(dnsNames) == 0 {
+		return false
+	}
+	for _, dnsName := range dnsNames {
+		if !dnsNameInZones(dnsName, zones) {
+			return false
+		}
+	}
+	return true
+}
+
+func dnsNameInZones(dnsName string, zones []string) bool {
+	dnsName = strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	for _, zone := range zones {
+		zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+		if dnsName == zone || strings.HasSuffix(dnsName, "."+zone) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildDelegatedRequest builds the CertificateRequest for the given backing
+// issuer which signs the given CertificateRequest. Its name is derived from
+// the name of the original request and the issuer, so that a new request is
+// created if the request is routed to another issuer.
+func buildDelegatedRequest(cr *cmapi.CertificateRequest, issuerRef cmmeta.ObjectReference) (*cmapi.CertificateRequest, error) {
+	name, err := apiutil.ComputeName(cr.Name, struct {
+		CRName    string                 `json:"certificateRequestName"`
+		IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+	}{
+		CRName:    cr.Name,
+		IssuerRef: issuerRef,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    cr.Labels,
+			// Annotations include the filtered annotations copied from the Certificate.
+			Annotations: cr.Annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cr, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind)),
+			},
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			Duration:  cr.Spec.Duration,
+			IssuerRef: issuerRef,
+			Request:   cr.Spec.Request,
+			IsCA:      cr.Spec.IsCA,
+			Usages:    cr.Spec.Usages,
+		},
+	}, nil
+}
+
+// delegatedRequestFailure returns the reason for which the delegated
+// CertificateRequest will never be signed, if any.
+func delegatedRequestFailure(cr *cmapi.CertificateRequest) (string, bool) {
+	if apiutil.CertificateRequestIsDenied(cr) {
+		return conditionMessage(cr, cmapi.CertificateRequestConditionDenied), true
+	}
+	if apiutil.CertificateRequestHasInvalidRequest(cr) {
+		return conditionMessage(cr, cmapi.CertificateRequestConditionInvalidRequest), true
+	}
+	if cr.Status.FailureTime != nil || apiutil.CertificateRequestReadyReason(cr) == cmapi.CertificateRequestReasonFailed {
+		return conditionMessage(cr, cmapi.CertificateRequestConditionReady), true
+	}
+	return "", false
+}
+
+func conditionMessage(cr *cmapi.CertificateRequest, condType cmapi.CertificateRequestConditionType) string {
+	cond := apiutil.GetCertificateRequestCondition(cr, condType)
+	if cond == nil || cond.Message == "" {
+		return fmt.Sprintf("%s condition without a message", condType)
+	}
+	return fmt.Sprintf("%s: %s", cond.Reason, cond.Message)
+}
+
+func issuerKind(ref cmmeta.ObjectReference) string {
+	if ref.Kind == "" {
+		return cmapi.IssuerKind
+	}
+	return ref.Kind
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegate
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSign(t *testing.T) {
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	require.NoError(t, err)
+	csr, err := gen.CSRWithSigner(sk, gen.SetCSRDNSNames("app.payments.example.com"))
+	require.NoError(t, err)
+
+	backingRef := cmmeta.ObjectReference{Name: "payments-ca", Kind: cmapi.IssuerKind}
+	iss := gen.ClusterIssuer("delegate", gen.SetIssuerDelegate(cmapi.DelegateIssuer{
+		Routes: []cmapi.DelegateIssuerRoute{{DNSZones: []string{"payments.example.com"}, IssuerRef: backingRef}},
+	}))
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: gen.DefaultTestNamespace}}
+
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(csr),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "delegate", Kind: cmapi.ClusterIssuerKind}),
+	)
+	baseCR.UID = "test-cr-uid"
+
+	delegatedCR, err := buildDelegatedRequest(baseCR, backingRef)
+	require.NoError(t, err)
+	delegated := func(mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		return gen.CertificateRequestFrom(delegatedCR, mods...)
+	}
+
+	tests := map[string]struct {
+		cr              *cmapi.CertificateRequest
+		cmObjects       []runtime.Object
+		expCertificate  []byte
+		expReason       string
+		expDelegatedCRs int
+	}{
+		"create a delegated request for the backing issuer": {
+			cr:              baseCR,
+			expReason:       cmapi.CertificateRequestReasonPending,
+			expDelegatedCRs: 1,
+		},
+		"wait for the delegated request to be signed": {
+			cr:              baseCR,
+			cmObjects:       []runtime.Object{delegated()},
+			expReason:       cmapi.CertificateRequestReasonPending,
+			expDelegatedCRs: 1,
+		},
+		"return the certificate of the signed delegated request": {
+			cr: baseCR,
+			cmObjects: []runtime.Object{delegated(
+				gen.SetCertificateRequestCertificate([]byte("cert")),
+				gen.SetCertificateRequestCA([]byte("ca")),
+			)},
+			expCertificate:  []byte("cert"),
+			expDelegatedCRs: 1,
+		},
+		"fail if the delegated request failed": {
+			cr: baseCR,
+			cmObjects: []runtime.Object{delegated(
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:    cmapi.CertificateRequestConditionReady,
+					Status:  cmmeta.ConditionFalse,
+					Reason:  cmapi.CertificateRequestReasonFailed,
+					Message: "CA unavailable",
+				}),
+			)},
+			expReason:       cmapi.CertificateRequestReasonFailed,
+			expDelegatedCRs: 1,
+		},
+		"fail if the delegated request was denied": {
+			cr: baseCR,
+			cmObjects: []runtime.Object{delegated(
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionDenied,
+					Status: cmmeta.ConditionTrue,
+					Reason: "PolicyViolation",
+				}),
+			)},
+			expReason:       cmapi.CertificateRequestReasonFailed,
+			expDelegatedCRs: 1,
+		},
+		"fail if no route matches": {
+			cr: gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestCSR(func() []byte {
+				csr, err := gen.CSRWithSigner(sk, gen.SetCSRDNSNames("example.org"))
+				require.NoError(t, err)
+				return csr
+			}())),
+			expReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"fail a delegated request to avoid delegation loops": {
+			cr: gen.CertificateRequestFrom(baseCR, gen.AddCertificateRequestOwnerReferences(
+				*metav1.NewControllerRef(gen.CertificateRequest("parent"), cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind)),
+			)),
+			expReason: cmapi.CertificateRequestReasonFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				KubeObjects:        []runtime.Object{namespace},
+				CertManagerObjects: test.cmObjects,
+			}
+			builder.Init()
+			d := NewDelegate(builder.Context).(*Delegate)
+			builder.Start()
+			defer builder.Stop()
+
+			cr := test.cr.DeepCopy()
+			resp, err := d.Sign(context.Background(), cr, iss)
+			require.NoError(t, err)
+
+			if test.expCertificate != nil {
+				require.NotNil(t, resp)
+				assert.Equal(t, test.expCertificate, resp.Certificate)
+				assert.Equal(t, []byte("ca"), resp.CA)
+			} else {
+				assert.Nil(t, resp)
+				assert.Equal(t, test.expReason, apiutil.CertificateRequestReadyReason(cr))
+			}
+
+			crs, err := builder.CMClient.CertmanagerV1().CertificateRequests(gen.DefaultTestNamespace).List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+			require.Len(t, crs.Items, test.expDelegatedCRs)
+			if test.expDelegatedCRs > 0 {
+				assert.Equal(t, backingRef, crs.Items[0].Spec.IssuerRef)
+				assert.True(t, metav1.IsControlledBy(&crs.Items[0], cr))
+			}
+		})
+	}
+}
+
+func TestMatchRoute(t *testing.T) {
+	paymentsRef := cmmeta.ObjectReference{Name: "payments-ca"}
+	publicRef := cmmeta.ObjectReference{Name: "public-ca", Kind: cmapi.ClusterIssuerKind}
+	defaultRef := cmmeta.ObjectReference{Name: "default-ca", Kind: cmapi.ClusterIssuerKind}
+	delegate := &cmapi.DelegateIssuer{
+		Routes: []cmapi.DelegateIssuerRoute{
+			{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				IssuerRef:         paymentsRef,
+			},
+			{
+				DNSZones:  []string{"example.com", "example.org."},
+				IssuerRef: publicRef,
+			},
+			{
+				IssuerRef: defaultRef,
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		routes          *cmapi.DelegateIssuer
+		namespaceLabels map[string]string
+		dnsNames        []string
+		expIssuerRef    *cmmeta.ObjectReference
+	}{
+		"match the namespace labels": {
+			routes:          delegate,
+			namespaceLabels: map[string]string{"team": "payments"},
+			dnsNames:        []string{"app.example.com"},
+			expIssuerRef:    &paymentsRef,
+		},
+		"match the DNS zones": {
+			routes:       delegate,
+			dnsNames:     []string{"example.com", "app.EXAMPLE.org"},
+			expIssuerRef: &publicRef,
+		},
+		"fall back to a route without selectors if a DNS name is outside the zones": {
+			routes:       delegate,
+			dnsNames:     []string{"app.example.com", "notexample.com"},
+			expIssuerRef: &defaultRef,
+		},
+		"fall back to a route without selectors without DNS names": {
+			routes:       delegate,
+			expIssuerRef: &defaultRef,
+		},
+		"no matching route": {
+			routes:   &cmapi.DelegateIssuer{Routes: delegate.Routes[:2]},
+			dnsNames: []string{"example.net"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			route, err := matchRoute(test.routes, test.namespaceLabels, &x509.CertificateRequest{DNSNames: test.dnsNames})
+			if test.expIssuerRef == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, *test.expIssuerRef, route.IssuerRef)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeissuer
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	CRControllerName = "certificaterequests-issuer-fake"
+
+	defaultFailureMessage = "Injected failure"
+
+	// caDuration is the lifetime of the ephemeral CAs. It is long enough that
+	// the CA doesn't expire before any certificate requested in practice.
+	caDuration = 10 * 365 * 24 * time.Hour
+)
+
+// Fake signs CertificateRequests using an ephemeral CA for each fake issuer,
+// which is generated in memory the first time the issuer is used. The CAs are
+// not persisted, so certificates signed before the controller restarts are
+// signed by a different CA than those signed afterwards.
+type Fake struct {
+	reporter *crutil.Reporter
+	clock    clock.Clock
+
+	// lock guards cas
+	lock sync.Mutex
+	// cas holds the ephemeral CA of each issuer, keyed by the UID of the
+	// issuer
+	cas map[types.UID]*signingCA
+}
+
+type signingCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func init() {
+	// create certificate request controller for fake issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerFake, NewFake)).
+			Complete()
+	})
+}
+
+func NewFake(ctx *controllerpkg.Context) certificaterequests.Issuer {
+	return &Fake{
+		reporter: crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		clock:    ctx.Clock,
+		cas:      make(map[types.UID]*signingCA),
+	}
+}
+
+// Sign signs a certificate request once the latency configured on the issuer
+// has passed since the request was created, unless the request is one of
+// those that the issuer is configured to fail.
+func (f *Fake) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuerpkg.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+	spec := issuerObj.GetSpec().Fake
+
+	if spec.Latency != nil {
+		signAt := cr.CreationTimestamp.Add(spec.Latency.Duration)
+		if f.clock.Now().Before(signAt) {
+			// The request is checked again once NextPollTime has passed.
+			nextPollTime := metav1.NewTime(signAt)
+			cr.Status.NextPollTime = &nextPollTime
+			f.reporter.Pending(cr, nil, "IssuancePending",
+				fmt.Sprintf("Waiting for the configured latency of %s before signing the certificate", spec.Latency.Duration))
+			return nil, nil
+		}
+	}
+	cr.Status.NextPollTime = nil
+
+	if injectFailure(cr.UID, spec.FailurePercentage) {
+		message := spec.FailureMessage
+		if message == "" {
+			message = defaultFailureMessage
+		}
+		err := fmt.Errorf("the issuer is configured to fail %d%% of requests", spec.FailurePercentage)
+		f.reporter.Failed(cr, err, "InjectedFailure", message)
+		log.V(logf.DebugLevel).Info("failing request as configured", "failurePercentage", spec.FailurePercentage)
+		return nil, nil
+	}
+
+	ca, err := f.signingCA(issuerObj)
+	if err != nil {
+		message := "Error generating ephemeral CA"
+		f.reporter.Pending(cr, err, "CAGenerationError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+	if err != nil {
+		message := "Error generating certificate template"
+		f.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	bundle, err := pki.SignCSRTemplate([]*x509.Certificate{ca.cert}, ca.key, template)
+	if err != nil {
+		message := "Error signing certificate"
+		f.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	log.V(logf.DebugLevel).Info("certificate issued")
+
+	return &issuerpkg.IssueResponse{
+		Certificate: bundle.ChainPEM,
+		CA:          bundle.CAPEM,
+	}, nil
+}
+
+// injectFailure returns whether the request with the given UID is one of the
+// given percentage of requests that should fail. The decision is derived from
+// the UID so that it doesn't change when the request is synced again.
+func injectFailure(uid types.UID, failurePercentage int32) bool {
+	if failurePercentage <= 0 {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(uid))
+	return int32(h.Sum32()%100) < failurePercentage
+}
+
+// signingCA returns the ephemeral CA of the given issuer, generating it if
+// the issuer has not been used since the controller started.
+func (f *Fake) signingCA(issuerObj cmapi.GenericIssuer) (*signingCA, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	uid := issuerObj.GetObjectMeta().UID
+	if ca, ok := f.cas[uid]; ok {
+		return ca, nil
+	}
+
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		return nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := f.clock.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName: fmt.Sprintf("cert-manager fake issuer %s", issuerObj.GetObjectMeta().Name),
+		},
+		NotBefore:             now,
+		NotAfter:              now.Add(caDuration),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	_, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &signingCA{cert: cert, key: key}
+	f.cas[uid] = ca
+	return ca, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeissuer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSign(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	created := metav1.NewTime(fixedClock.Now().Add(-time.Minute))
+
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	require.NoError(t, err)
+	csr, err := gen.CSRWithSigner(sk, gen.SetCSRCommonName("example.com"))
+	require.NoError(t, err)
+
+	baseCR := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csr))
+	baseCR.UID = "test-cr-uid"
+	baseCR.CreationTimestamp = created
+
+	issuer := func(spec cmapi.FakeIssuer) cmapi.GenericIssuer {
+		iss := gen.Issuer("test-issuer", gen.SetIssuerFake(spec))
+		iss.UID = "test-issuer-uid"
+		return iss
+	}
+
+	tests := map[string]struct {
+		spec            cmapi.FakeIssuer
+		expSigned       bool
+		expReason       string
+		expNextPollTime *metav1.Time
+	}{
+		"sign straight away": {
+			expSigned: true,
+		},
+		"sign once the latency has passed": {
+			spec:      cmapi.FakeIssuer{Latency: &metav1.Duration{Duration: time.Minute}},
+			expSigned: true,
+		},
+		"wait until the latency has passed": {
+			spec:            cmapi.FakeIssuer{Latency: &metav1.Duration{Duration: time.Hour}},
+			expReason:       cmapi.CertificateRequestReasonPending,
+			expNextPollTime: &metav1.Time{Time: created.Add(time.Hour)},
+		},
+		"fail requests": {
+			spec:      cmapi.FakeIssuer{FailurePercentage: 100, FailureMessage: "CA unavailable"},
+			expReason: cmapi.CertificateRequestReasonFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &Fake{
+				reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder)),
+				clock:    fixedClock,
+				cas:      make(map[types.UID]*signingCA),
+			}
+			cr := baseCR.DeepCopy()
+
+			resp, err := f.Sign(context.Background(), cr, issuer(test.spec))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expNextPollTime, cr.Status.NextPollTime)
+			if !test.expSigned {
+				assert.Nil(t, resp)
+				assert.Equal(t, test.expReason, apiutil.CertificateRequestReadyReason(cr))
+				return
+			}
+
+			require.NotNil(t, resp)
+			cert, err := pki.DecodeX509CertificateBytes(resp.Certificate)
+			require.NoError(t, err)
+			ca, err := pki.DecodeX509CertificateBytes(resp.CA)
+			require.NoError(t, err)
+			assert.Equal(t, "example.com", cert.Subject.CommonName)
+			assert.NoError(t, cert.CheckSignatureFrom(ca))
+
+			// The same ephemeral CA is used for all requests of the issuer.
+			next, err := f.Sign(context.Background(), baseCR.DeepCopy(), issuer(test.spec))
+			require.NoError(t, err)
+			assert.Equal(t, resp.CA, next.CA)
+		})
+	}
+}
+
+func TestInjectFailure(t *testing.T) {
+	failed := 0
+	for i := 0; i < 1000; i++ {
+		uid := types.UID(fmt.Sprintf("uid-%d", i))
+		if injectFailure(uid, 30) {
+			failed++
+		}
+		assert.Equal(t, injectFailure(uid, 30), injectFailure(uid, 30), "expected the same outcome for the same request")
+		assert.False(t, injectFailure(uid, 0))
+		assert.True(t, injectFailure(uid, 100))
+	}
+	assert.InDelta(t, 300, failed, 60, "expected roughly 30%% of requests to fail")
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"context"
+	"fmt"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	CRControllerName = "certificaterequests-issuer-offline"
+)
+
+// Offline completes the CertificateRequests of offline issuers once the
+// certificate signed by the offline CA has been imported into the
+// offline.cert-manager.io/certificate annotation. The requests stay pending
+// until then.
+type Offline struct {
+	reporter *crutil.Reporter
+}
+
+func init() {
+	// create certificate request controller for offline issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerOffline, NewOffline)).
+			Complete()
+	})
+}
+
+func NewOffline(ctx *controllerpkg.Context) certificaterequests.Issuer {
+	return &Offline{
+		reporter: crutil.NewReporter(ctx.Clock, ctx.Recorder),
+	}
+}
+
+// Sign returns the imported certificate of the request, once it has been
+// verified to be for the public key of the request and to chain up to the CA
+// bundle of the issuer. Requests without an imported certificate, or with an
+// invalid one, are left pending so that the certificate can be imported again.
+func (o *Offline) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuerpkg.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+
+	imported, ok := cr.Annotations[cmapi.OfflineCertificateAnnotationKey]
+	if !ok {
+		o.reporter.Pending(cr, nil, "OfflineSigningPending",
+			"Waiting for the certificate signed by the offline CA to be imported")
+		return nil, nil
+	}
+
+	caBundle := issuerObj.GetSpec().Offline.CABundle
+	chainPEM, err := verifyImportedCertificate(cr, []byte(imported), caBundle)
+	if err != nil {
+		o.reporter.Pending(cr, err, "InvalidImportedCertificate",
+			fmt.Sprintf("The imported certificate was rejected and must be imported again: %v", err))
+		log.Error(err, "imported certificate was rejected")
+		return nil, nil
+	}
+
+	log.V(logf.DebugLevel).Info("imported certificate accepted")
+
+	return &issuerpkg.IssueResponse{
+		Certificate: chainPEM,
+		CA:          caBundle,
+	}, nil
+}
+
+// verifyImportedCertificate checks that the leaf of the imported PEM encoded
+// chain is for the public key of the request and chains up to the CA bundle,
+// and returns the chain re-encoded without any other PEM blocks or the root.
+func verifyImportedCertificate(cr *cmapi.CertificateRequest, imported, caBundle []byte) ([]byte, error) {
+	chain, err := pki.DecodeX509CertificateChainBytes(imported)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := pki.PublicKeyMatchesCSR(chain[0].PublicKey, csr)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, fmt.Errorf("the public key of the certificate does not match the request")
+	}
+
+	chainPEM, err := pki.EncodeX509Chain(chain[1:])
+	if err != nil {
+		return nil, err
+	}
+	leafPEM, err := pki.EncodeX509(chain[0])
+	if err != nil {
+		return nil, err
+	}
+	chainPEM = append(leafPEM, chainPEM...)
+
+	if err := pki.VerifyChainToRoots(chainPEM, nil, caBundle); err != nil {
+		return nil, err
+	}
+
+	return chainPEM, nil
+}
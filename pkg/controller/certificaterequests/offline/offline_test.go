@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func generateCA(t *testing.T, name string) (*x509.Certificate, crypto.Signer) {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	_, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func TestSign(t *testing.T) {
+	caCert, caKey := generateCA(t, "offline CA")
+	otherCACert, otherCAKey := generateCA(t, "other CA")
+	caBundle, err := pki.EncodeX509(caCert)
+	require.NoError(t, err)
+
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	require.NoError(t, err)
+	csr, err := gen.CSRWithSigner(sk, gen.SetCSRCommonName("example.com"))
+	require.NoError(t, err)
+	baseCR := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csr))
+
+	sign := func(cr *cmapi.CertificateRequest, caCert *x509.Certificate, caKey crypto.Signer) []byte {
+		template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+		require.NoError(t, err)
+		bundle, err := pki.SignCSRTemplate([]*x509.Certificate{caCert}, caKey, template)
+		require.NoError(t, err)
+		return bundle.ChainPEM
+	}
+
+	otherSK, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	require.NoError(t, err)
+	otherCSR, err := gen.CSRWithSigner(otherSK, gen.SetCSRCommonName("example.com"))
+	require.NoError(t, err)
+	otherCR := gen.CertificateRequest("other-cr", gen.SetCertificateRequestCSR(otherCSR))
+
+	tests := map[string]struct {
+		imported  []byte
+		expSigned bool
+		expReason string
+	}{
+		"wait for the certificate to be imported": {
+			expReason: cmapi.CertificateRequestReasonPending,
+		},
+		"complete the request with the imported certificate": {
+			imported:  sign(baseCR, caCert, caKey),
+			expSigned: true,
+		},
+		"reject a certificate for another request": {
+			imported:  sign(otherCR, caCert, caKey),
+			expReason: cmapi.CertificateRequestReasonPending,
+		},
+		"reject a certificate signed by another CA": {
+			imported:  sign(baseCR, otherCACert, otherCAKey),
+			expReason: cmapi.CertificateRequestReasonPending,
+		},
+		"reject an invalid certificate": {
+			imported:  []byte("not a certificate"),
+			expReason: cmapi.CertificateRequestReasonPending,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &Offline{
+				reporter: crutil.NewReporter(fakeclock.NewFakeClock(time.Now()), new(controllertest.FakeRecorder)),
+			}
+			cr := baseCR.DeepCopy()
+			if test.imported != nil {
+				cr.Annotations = map[string]string{cmapi.OfflineCertificateAnnotationKey: string(test.imported)}
+			}
+			issuer := gen.Issuer("test-issuer", gen.SetIssuerOffline(cmapi.OfflineIssuer{CABundle: caBundle}))
+
+			resp, err := o.Sign(context.Background(), cr, issuer)
+			require.NoError(t, err)
+
+			if !test.expSigned {
+				assert.Nil(t, resp)
+				assert.Equal(t, test.expReason, apiutil.CertificateRequestReadyReason(cr))
+				return
+			}
+
+			require.NotNil(t, resp)
+			cert, err := pki.DecodeX509CertificateBytes(resp.Certificate)
+			require.NoError(t, err)
+			assert.Equal(t, "example.com", cert.Subject.CommonName)
+			assert.NoError(t, cert.CheckSignatureFrom(caCert))
+			assert.Equal(t, caBundle, resp.CA)
+		})
+	}
+}
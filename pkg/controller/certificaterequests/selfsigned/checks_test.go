@@ -130,6 +130,7 @@ func Test_handleSecretReferenceWorkFunc(t *testing.T) {
 			helper := issuer.NewHelper(
 				builder.Context.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
 				builder.Context.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+				nil,
 			)
 
 			builder.Start()
@@ -317,6 +318,7 @@ func Test_certificatesRequestsForSecret(t *testing.T) {
 			helper := issuer.NewHelper(
 				builder.Context.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
 				builder.Context.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+				nil,
 			)
 
 			builder.Start()
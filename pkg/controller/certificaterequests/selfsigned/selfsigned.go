@@ -74,18 +74,20 @@ func init() {
 				func(ctx *controllerpkg.Context, log logr.Logger, queue workqueue.RateLimitingInterface) ([]cache.InformerSynced, error) {
 					secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Informer()
 					certificateRequestLister := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister()
+					grantLister, grantsSynced := issuer.IssuerReferenceGrantLister(ctx.SharedInformerFactory)
 					helper := issuer.NewHelper(
 						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
 						ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+						grantLister,
 					)
 					secretInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 						WorkFunc: handleSecretReferenceWorkFunc(log, certificateRequestLister, helper, queue),
 					})
-					return []cache.InformerSynced{
+					return append([]cache.InformerSynced{
 						secretInformer.HasSynced,
 						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Informer().HasSynced,
 						ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().HasSynced,
-					}, nil
+					}, grantsSynced...), nil
 				},
 			)).
 			Complete()
@@ -157,6 +159,22 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 
 	template.CRLDistributionPoints = issuerObj.GetSpec().SelfSigned.CRLDistributionPoints
 
+	backdate, err := apiutil.NotBeforeBackdate(cr.Annotations, issuerObj.GetSpec().SelfSigned.NotBeforeBackdate)
+	if err != nil {
+		message := "Error generating certificate template"
+		s.reporter.Failed(cr, err, "ErrorGenerating", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+	template.NotBefore = template.NotBefore.Add(-backdate)
+
+	if err := pki.SetKeyIdentifiers(template, nil, issuerObj.GetSpec().SelfSigned.KeyIdentifierMethod); err != nil {
+		message := "Error generating certificate template"
+		s.reporter.Failed(cr, err, "ErrorGenerating", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
 	if template.Subject.String() == "" {
 		// RFC 5280 (https://tools.ietf.org/html/rfc5280#section-4.1.2.4) says that:
 		// "The issuer field MUST contain a non-empty distinguished name (DN)."
@@ -0,0 +1,294 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+const (
+	// ControllerName is the name of the stale CertificateRequest controller.
+	ControllerName = "certificaterequests-stale"
+
+	// reasonTimeout is the reason of the events sent when a
+	// CertificateRequest is marked as failed because it timed out.
+	reasonTimeout = "Timeout"
+)
+
+// controller marks CertificateRequests as failed if they have been pending
+// for longer than the configured timeout, for example because the issuer
+// processing them crashed or an external signer went away.
+//
+// The Ready condition of a timed out request is set to False with the
+// reason Failed, which is the reason that all other controllers watch for.
+// This causes the issuing controller to fail the current issuance of the
+// parent Certificate, after which the trigger controller re-triggers the
+// Certificate with the usual backoff.
+type controller struct {
+	certificateRequestLister cmlisters.CertificateRequestLister
+	certificateLister        cmlisters.CertificateLister
+	recorder                 record.EventRecorder
+	queue                    workqueue.RateLimitingInterface
+	clock                    clock.Clock
+
+	// timeout is the time after which a pending CertificateRequest is
+	// marked as failed.
+	timeout time.Duration
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Apply API calls.
+	fieldManager string
+
+	// statusWriter is used to write the status of CertificateRequests. It
+	// uses client unless replaced with the StatusWriter of the controller
+	// Context.
+	statusWriter *statuswriter.Writer
+}
+
+// NewController returns a new stale CertificateRequest controller.
+func NewController(
+	client cmclient.Interface,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	timeout time.Duration,
+	fieldManager string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateRequestInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		certificateLister:        certificateInformer.Lister(),
+		recorder:                 recorder,
+		queue:                    queue,
+		clock:                    clock,
+		timeout:                  timeout,
+		fieldManager:             fieldManager,
+		statusWriter:             statuswriter.New(client, nil),
+	}, queue, mustSync
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a CertificateRequest to be re-synced is pulled from the
+// workqueue. ProcessItem marks the CertificateRequest as failed if it has
+// been pending for longer than the timeout, or requeues it to be checked
+// again once the timeout expires.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	cr, err := c.certificateRequestLister.CertificateRequests(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate request not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	pendingSince, ok := pendingSince(cr)
+	if !ok {
+		return nil
+	}
+
+	if remaining := pendingSince.Add(c.timeout).Sub(c.clock.Now()); remaining > 0 {
+		log.V(logf.DebugLevel).Info("certificate request is pending, checking again once it may have timed out", "remaining", remaining)
+		c.queue.AddAfter(key, remaining)
+		return nil
+	}
+
+	return c.failRequest(logf.NewContext(ctx, logf.WithResource(log, cr)), cr)
+}
+
+// pendingSince returns the time since which the given CertificateRequest has
+// been waiting for its issuer, and false if it is not waiting for its issuer.
+// Requests which are not yet approved are waiting for an approver rather
+// than an issuer, and so are never considered to be pending.
+func pendingSince(cr *cmapi.CertificateRequest) (time.Time, bool) {
+	if len(cr.Status.Certificate) > 0 ||
+		cr.Status.FailureTime != nil ||
+		apiutil.CertificateRequestIsDenied(cr) ||
+		apiutil.CertificateRequestHasInvalidRequest(cr) {
+		return time.Time{}, false
+	}
+
+	if ready := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady); ready != nil &&
+		(ready.Status == cmmeta.ConditionTrue || ready.Reason == cmapi.CertificateRequestReasonFailed || ready.Reason == cmapi.CertificateRequestReasonDenied) {
+		return time.Time{}, false
+	}
+
+	approved := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionApproved)
+	if approved == nil || approved.Status != cmmeta.ConditionTrue {
+		return time.Time{}, false
+	}
+
+	since := cr.CreationTimestamp.Time
+	if approved.LastTransitionTime != nil && approved.LastTransitionTime.After(since) {
+		since = approved.LastTransitionTime.Time
+	}
+	// Issuers which issue certificates asynchronously set the next time
+	// they will check on the request, and so are still making progress
+	// until then.
+	if cr.Status.NextPollTime != nil && cr.Status.NextPollTime.After(since) {
+		since = cr.Status.NextPollTime.Time
+	}
+	return since, true
+}
+
+// failRequest marks the given CertificateRequest as failed because it timed
+// out, and reports that on the CertificateRequest and its parent Certificate.
+func (c *controller) failRequest(ctx context.Context, cr *cmapi.CertificateRequest) error {
+	log := logf.FromContext(ctx)
+
+	message := fmt.Sprintf("Timed out after the request was pending for longer than %s", c.timeout)
+
+	updated := cr.DeepCopy()
+	nowTime := metav1.NewTime(c.clock.Now())
+	updated.Status.FailureTime = &nowTime
+	apiutil.SetCertificateRequestCondition(updated, cmapi.CertificateRequestConditionReady, cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed, message)
+
+	if err := c.updateOrApplyStatus(ctx, updated); err != nil {
+		return err
+	}
+
+	log.V(logf.InfoLevel).Info("marked pending certificate request as failed", "timeout", c.timeout)
+	c.recorder.Event(cr, corev1.EventTypeWarning, reasonTimeout, message)
+
+	if crt := c.parentCertificate(cr); crt != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonTimeout,
+			"The CertificateRequest %q was pending for longer than %s and has been marked as failed, issuance will be retried", cr.Name, c.timeout)
+	}
+
+	return nil
+}
+
+// parentCertificate returns the Certificate which owns the given
+// CertificateRequest, or nil if it is not owned by an existing Certificate.
+func (c *controller) parentCertificate(cr *cmapi.CertificateRequest) *cmapi.Certificate {
+	owner := metav1.GetControllerOf(cr)
+	if owner == nil || owner.Kind != cmapi.CertificateKind {
+		return nil
+	}
+	crt, err := c.certificateLister.Certificates(cr.Namespace).Get(owner.Name)
+	if err != nil || crt.UID != owner.UID {
+		return nil
+	}
+	return crt
+}
+
+// updateOrApplyStatus will update the controller status. If the
+// ServerSideApply feature is enabled, the managed fields will instead get
+// applied using the relevant Patch API call.
+func (c *controller) updateOrApplyStatus(ctx context.Context, cr *cmapi.CertificateRequest) error {
+	return c.statusWriter.Write(ctx, cr, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			var conditions []cmapi.CertificateRequestCondition
+			if cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady); cond != nil {
+				conditions = []cmapi.CertificateRequestCondition{*cond}
+			}
+			return internalcertificaterequests.ApplyStatus(ctx, cl, c.fieldManager, &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: cr.Namespace, Name: cr.Name},
+				Status: cmapi.CertificateRequestStatus{
+					Conditions:  conditions,
+					FailureTime: cr.Status.FailureTime,
+				},
+			})
+		} else {
+			_, err := cl.CertmanagerV1().CertificateRequests(cr.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
+			return err
+		}
+	})
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	if ctx.CertificateRequestPendingTimeout <= 0 {
+		return nil, nil, fmt.Errorf("the %s controller requires a pending timeout to be configured", ControllerName)
+	}
+
+	ctrl, queue, mustSync := NewController(
+		ctx.CMClient,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.CertificateRequestPendingTimeout,
+		ctx.FieldManager,
+	)
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stale
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	nowMetaTime := metav1.NewTime(fixedClock.Now())
+	recent := metav1.NewTime(fixedClock.Now().Add(-time.Minute))
+	old := metav1.NewTime(fixedClock.Now().Add(-2 * time.Hour))
+	future := metav1.NewTime(fixedClock.Now().Add(time.Minute))
+
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateUID(types.UID("test-uid")),
+	)
+	approvedAt := func(at metav1.Time) gen.CertificateRequestModifier {
+		return gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionApproved,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "cert-manager.io",
+			LastTransitionTime: &at,
+		})
+	}
+	pending := gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+		Type:               cmapi.CertificateRequestConditionReady,
+		Status:             cmmeta.ConditionFalse,
+		Reason:             cmapi.CertificateRequestReasonPending,
+		LastTransitionTime: &old,
+	})
+	baseCR := gen.CertificateRequest("test-1",
+		gen.SetCertificateRequestNamespace("testns"),
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))),
+	)
+	// timedOut returns the given request with the Ready condition which is
+	// expected to be set once it timed out at the given time.
+	timedOut := func(cr *cmapi.CertificateRequest, at metav1.Time) *cmapi.CertificateRequest {
+		return gen.CertificateRequestFrom(cr,
+			gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             cmapi.CertificateRequestReasonFailed,
+				Message:            "Timed out after the request was pending for longer than 1h0m0s",
+				LastTransitionTime: &at,
+			}),
+			gen.SetCertificateRequestFailureTime(nowMetaTime),
+		)
+	}
+
+	tests := map[string]struct {
+		cr        *cmapi.CertificateRequest
+		expUpdate *cmapi.CertificateRequest
+		expEvents []string
+	}{
+		"do nothing if the request is not approved": {
+			cr: gen.CertificateRequestFrom(baseCR, pending),
+		},
+		"do nothing if the request has been issued": {
+			cr: gen.CertificateRequestFrom(baseCR, approvedAt(old), gen.SetCertificateRequestCertificate([]byte("cert"))),
+		},
+		"do nothing if the request has already failed": {
+			cr: gen.CertificateRequestFrom(baseCR, approvedAt(old), gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:   cmapi.CertificateRequestConditionReady,
+				Status: cmmeta.ConditionFalse,
+				Reason: cmapi.CertificateRequestReasonFailed,
+			}), gen.SetCertificateRequestFailureTime(old)),
+		},
+		"do nothing if the request has not been pending for longer than the timeout": {
+			cr: gen.CertificateRequestFrom(baseCR, approvedAt(recent), pending),
+		},
+		"do nothing if the issuer will poll the request again": {
+			cr: gen.CertificateRequestFrom(baseCR, approvedAt(old), pending, gen.SetCertificateRequestNextPollTime(future)),
+		},
+		"mark the request as failed if it has been pending for longer than the timeout": {
+			cr:        gen.CertificateRequestFrom(baseCR, approvedAt(old), pending),
+			expUpdate: timedOut(gen.CertificateRequestFrom(baseCR, approvedAt(old)), old),
+			expEvents: []string{
+				"Warning Timeout Timed out after the request was pending for longer than 1h0m0s",
+				`Warning Timeout The CertificateRequest "test-1" was pending for longer than 1h0m0s and has been marked as failed, issuance will be retried`,
+			},
+		},
+		"mark the request as failed if it was never picked up by an issuer": {
+			cr:        gen.CertificateRequestFrom(baseCR, approvedAt(old)),
+			expUpdate: timedOut(gen.CertificateRequestFrom(baseCR, approvedAt(old)), nowMetaTime),
+			expEvents: []string{
+				"Warning Timeout Timed out after the request was pending for longer than 1h0m0s",
+				`Warning Timeout The CertificateRequest "test-1" was pending for longer than 1h0m0s and has been marked as failed, issuance will be retried`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{crt, test.cr},
+				ExpectedEvents:     test.expEvents,
+			}
+			if test.expUpdate != nil {
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						test.expUpdate.Namespace,
+						test.expUpdate)))
+			}
+			builder.Init()
+			builder.CertificateRequestPendingTimeout = time.Hour
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.cr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
@@ -18,6 +18,7 @@ package certificaterequests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -28,12 +29,16 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
+	"github.com/cert-manager/cert-manager/internal/controller/audit"
 	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/issuancehook"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -53,7 +58,10 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	defer func() {
 		if saveErr := c.updateCertificateRequestStatusAndAnnotations(ctx, cr, crCopy); saveErr != nil {
 			err = utilerrors.NewAggregate([]error{saveErr, err})
+			return
 		}
+		c.recordAuditDecision(ctx, cr, crCopy)
+		c.runPostIssuanceHooks(ctx, cr, crCopy)
 	}()
 
 	// If CertificateRequest has been denied, mark the CertificateRequest as
@@ -89,12 +97,25 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	if k8sErrors.IsForbidden(err) {
+		c.reporter.Pending(crCopy, err, "IssuerReferenceNotGranted",
+			fmt.Sprintf("Referenced %q in namespace %q may not be used: %v", apiutil.IssuerKind(crCopy.Spec.IssuerRef), crCopy.Spec.IssuerRef.Namespace, err))
+		return nil
+	}
+
 	if err != nil {
 		log.Error(err, "failed to get issuer")
 		return err
 	}
 
 	log = logf.WithRelatedResource(log, issuerObj)
+
+	// This CertificateRequest is managed by another installation, ignore
+	if !controllerpkg.ManagesControllerName(c.controllerClass, issuerObj.GetSpec().ControllerName) {
+		dbg.Info("issuer is managed by a different controller class, ignoring")
+		return nil
+	}
+
 	dbg.Info("ensuring issuer type matches this controller")
 
 	issuerType, err := apiutil.NameForIssuer(issuerObj)
@@ -129,10 +150,40 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	// Issuers that issue certificates asynchronously set NextPollTime to back
+	// off from checking whether the certificate has been issued. As it is
+	// stored in the status, the back-off is kept across restarts.
+	if c.scheduleNextPoll(crCopy) {
+		dbg.Info("waiting until the next poll time before checking the request with the issuer", "nextPollTime", crCopy.Status.NextPollTime)
+		return nil
+	}
+
+	// Issuers may opt in to reusing the certificate issued for an identical
+	// request, so that repeated requests don't consume the quota of the CA.
+	if c.reuseIssuedCertificate(ctx, crCopy, issuerObj) {
+		return nil
+	}
+
 	dbg.Info("invoking sign function as existing certificate does not exist")
 
+	if c.issuanceHooks != nil {
+		err := c.issuanceHooks.PreIssuance(ctx, crCopy)
+		var denied *issuancehook.DeniedError
+		if errors.As(err, &denied) {
+			c.reporter.Failed(crCopy, err, "IssuanceHookDenied", "Denied by pre-issuance hook")
+			return nil
+		}
+		if err != nil {
+			c.reporter.Pending(crCopy, err, "IssuanceHookFailed", "Failed to call pre-issuance hook")
+			return err
+		}
+	}
+
 	// Attempt to call the Sign function on our issuer
 	resp, err := c.issuer.Sign(ctx, crCopy, issuerObj)
+	// The spec of CertificateRequests is immutable, so any overrides of the
+	// pre-issuance hooks are only used for signing.
+	crCopy.Spec = *cr.Spec.DeepCopy()
 	if err != nil {
 		log.Error(err, "error issuing certificate request")
 		return err
@@ -142,12 +193,23 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	// underlying issuer will have set the condition of pending or failed and we
 	// should potentially wait for a re-sync.
 	if resp == nil {
+		c.scheduleNextPoll(crCopy)
 		return nil
 	}
 
+	// Reject certificates which don't chain up to the roots pinned on the
+	// issuer, before they are stored and written to the target Secret.
+	if caBundle := issuerObj.GetSpec().CABundle; len(caBundle) > 0 {
+		if err := pki.VerifyChainToRoots(resp.Certificate, resp.CA, caBundle); err != nil {
+			c.reporter.Failed(crCopy, err, "UntrustedChain", "Returned certificate does not chain up to the CA bundle of the issuer")
+			return nil
+		}
+	}
+
 	// Update to status with the new given response.
 	crCopy.Status.Certificate = resp.Certificate
 	crCopy.Status.CA = resp.CA
+	crCopy.Status.CrossSignedChain = resp.CrossSignedChain
 
 	// invalid cert
 	_, err = pki.DecodeX509CertificateBytes(crCopy.Status.Certificate)
@@ -162,6 +224,79 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	return nil
 }
 
+// scheduleNextPoll re-queues the CertificateRequest at its NextPollTime, and
+// returns true if that time has not been reached yet.
+func (c *Controller) scheduleNextPoll(cr *cmapi.CertificateRequest) bool {
+	if cr.Status.NextPollTime == nil {
+		return false
+	}
+	wait := cr.Status.NextPollTime.Time.Sub(c.clock.Now())
+	if wait <= 0 {
+		return false
+	}
+	key, err := controllerpkg.KeyFunc(cr)
+	if err != nil {
+		return false
+	}
+	c.queue.AddAfter(key, wait)
+	return true
+}
+
+// runPostIssuanceHooks calls the post-issuance hooks if the CertificateRequest
+// has been issued since it was last synced. Failing to call a hook does not
+// fail the sync, as the CertificateRequest has already been updated.
+func (c *Controller) runPostIssuanceHooks(ctx context.Context, old, new *cmapi.CertificateRequest) {
+	if c.issuanceHooks == nil {
+		return
+	}
+
+	reason := apiutil.CertificateRequestReadyReason(new)
+	if reason != cmapi.CertificateRequestReasonIssued || reason == apiutil.CertificateRequestReadyReason(old) {
+		return
+	}
+
+	if err := c.issuanceHooks.PostIssuance(ctx, new); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to call post-issuance hooks")
+		c.recorder.Eventf(new, corev1.EventTypeWarning, "IssuanceHookFailed", "Failed to call post-issuance hooks: %v", err)
+	}
+}
+
+// recordAuditDecision writes an audit record if the CertificateRequest has
+// been issued, denied or has failed since it was last synced. Failing to write
+// the record does not fail the sync, as the CertificateRequest has already
+// been updated.
+func (c *Controller) recordAuditDecision(ctx context.Context, old, new *cmapi.CertificateRequest) {
+	if c.auditSink == nil {
+		return
+	}
+
+	reason := apiutil.CertificateRequestReadyReason(new)
+	if reason == apiutil.CertificateRequestReadyReason(old) {
+		return
+	}
+
+	var decision audit.Decision
+	switch reason {
+	case cmapi.CertificateRequestReasonIssued:
+		decision = audit.DecisionIssued
+	case cmapi.CertificateRequestReasonDenied:
+		decision = audit.DecisionDenied
+	case cmapi.CertificateRequestReasonFailed:
+		decision = audit.DecisionFailed
+	default:
+		return
+	}
+
+	var message string
+	if cond := apiutil.GetCertificateRequestCondition(new, cmapi.CertificateRequestConditionReady); cond != nil {
+		message = cond.Message
+	}
+
+	if err := c.auditSink.Write(ctx, audit.RecordFor(new, decision, message, c.clock.Now())); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to write audit record", "decision", decision)
+	}
+}
+
 func (c *Controller) updateCertificateRequestStatusAndAnnotations(ctx context.Context, old, new *cmapi.CertificateRequest) error {
 	log := logf.FromContext(ctx, "updateStatus")
 
@@ -190,10 +325,12 @@ func (c *Controller) updateOrApply(ctx context.Context, cr *cmapi.CertificateReq
 }
 
 func (c *Controller) updateStatusOrApply(ctx context.Context, cr *cmapi.CertificateRequest) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		return internalcertificaterequests.ApplyStatus(ctx, c.cmClient, c.fieldManager, cr)
-	} else {
-		_, err := c.cmClient.CertmanagerV1().CertificateRequests(cr.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
-		return err
-	}
+	return c.statusWriter.Write(ctx, cr, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			return internalcertificaterequests.ApplyStatus(ctx, cl, c.fieldManager, cr)
+		} else {
+			_, err := cl.CertmanagerV1().CertificateRequests(cr.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
+			return err
+		}
+	})
 }
@@ -157,6 +157,31 @@ func TestSync(t *testing.T) {
 	certECPEM := generateSelfSignedCert(t, baseCREC, skEC, fixedClockStart, fixedClockStart.Add(time.Hour*12))
 	certECPEMExpired := generateSelfSignedCert(t, baseCREC, skEC, fixedClockStart.Add(-time.Hour*13), fixedClockStart.Add(-time.Hour*12))
 
+	pinnedIssuer := baseIssuer.DeepCopy()
+	pinnedIssuer.Spec.CABundle = certECPEM
+	untrustedErr := pki.VerifyChainToRoots(certRSAPEM, nil, certECPEM)
+	if untrustedErr == nil {
+		t.Fatal("expected RSA certificate not to chain up to EC certificate")
+	}
+
+	dedupIssuer := gen.IssuerFrom(baseIssuer.DeepCopy(), gen.AddIssuerAnnotations(map[string]string{
+		cmapi.RequestDedupWindowAnnotationKey: "1h",
+	}))
+	issuedCR := func(issuedAgo time.Duration) *cmapi.CertificateRequest {
+		issuedAt := metav1.NewTime(fixedClockStart.Add(-issuedAgo))
+		return gen.CertificateRequestFrom(baseCR,
+			gen.SetCertificateRequestName("test-cr-previous"),
+			gen.SetCertificateRequestCertificate(certRSAPEM),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             cmapi.CertificateRequestReasonIssued,
+				Message:            "Certificate fetched from issuer successfully",
+				LastTransitionTime: &issuedAt,
+			}),
+		)
+	}
+
 	tests := map[string]testT{
 		"should return nil (no action) if group name if not 'cert-manager.io' or ''": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR,
@@ -471,6 +496,14 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		"should do nothing if the referenced issuer is managed by a different controller class": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR,
+					gen.IssuerFrom(baseIssuer, gen.SetIssuerControllerName("team")),
+				},
+			},
+		},
 		"should exit nil and set status pending if referenced issuer is not ready": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -624,6 +657,72 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		"if calling sign returns a certificate which doesn't chain up to the CA bundle of the issuer then we fail": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return &issuer.IssueResponse{
+						Certificate: certRSAPEM,
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{pinnedIssuer, baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning UntrustedChain Returned certificate does not chain up to the CA bundle of the issuer: " + untrustedErr.Error(),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            "Returned certificate does not chain up to the CA bundle of the issuer: " + untrustedErr.Error(),
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestFailureTime(nowMetaTime),
+						),
+					)),
+				},
+			},
+		},
+		"if calling sign returns a certificate which chains up to the CA bundle of the issuer then set condition Ready": {
+			certificateRequest: baseCREC.DeepCopy(),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return &issuer.IssueResponse{
+						Certificate: certECPEM,
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{pinnedIssuer, baseCREC.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCREC,
+							gen.SetCertificateRequestCertificate(certECPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
 		"if calling sign returns a response with an expired RSA certificate then set condition Ready": {
 			certificateRequest: baseCR.DeepCopy(),
 			issuerImpl: &fake.Issuer{
@@ -725,6 +824,56 @@ func TestSync(t *testing.T) {
 		},
 	}
 
+	tests["should reuse the certificate of an identical request issued within the dedup window of the issuer"] = testT{
+		certificateRequest: baseCR.DeepCopy(),
+		builder: &testpkg.Builder{
+			CertManagerObjects: []runtime.Object{dedupIssuer, baseCR.DeepCopy(), issuedCR(10 * time.Minute)},
+			ExpectedEvents: []string{
+				`Normal Deduplicated Reusing the certificate issued 10m0s ago for the identical CertificateRequest "test-cr-previous" instead of signing the request again`,
+				"Normal CertificateIssued Certificate fetched from issuer successfully",
+			},
+			ExpectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+					"status",
+					gen.DefaultTestNamespace,
+					gen.CertificateRequestFrom(baseCR,
+						gen.SetCertificateRequestCertificate(certRSAPEM),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:               cmapi.CertificateRequestConditionReady,
+							Status:             cmmeta.ConditionTrue,
+							Reason:             cmapi.CertificateRequestReasonIssued,
+							Message:            "Certificate fetched from issuer successfully",
+							LastTransitionTime: &nowMetaTime,
+						}),
+					),
+				)),
+			},
+		},
+	}
+	// The default fake issuer returns an error when Sign is called.
+	tests["should sign an identical request issued outside of the dedup window of the issuer"] = testT{
+		certificateRequest: baseCR.DeepCopy(),
+		builder: &testpkg.Builder{
+			CertManagerObjects: []runtime.Object{dedupIssuer, baseCR.DeepCopy(), issuedCR(2 * time.Hour)},
+		},
+		expectedErr: true,
+	}
+	tests["should sign an identical request if the issuer doesn't set a dedup window"] = testT{
+		certificateRequest: baseCR.DeepCopy(),
+		builder: &testpkg.Builder{
+			CertManagerObjects: []runtime.Object{baseIssuer, baseCR.DeepCopy(), issuedCR(10 * time.Minute)},
+		},
+		expectedErr: true,
+	}
+	tests["should sign a request which differs from a request issued within the dedup window of the issuer"] = testT{
+		certificateRequest: gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestIsCA(true)),
+		builder: &testpkg.Builder{
+			CertManagerObjects: []runtime.Object{dedupIssuer, baseCR.DeepCopy(), issuedCR(10 * time.Minute)},
+		},
+		expectedErr: true,
+	}
+
 	for n, test := range tests {
 		t.Run(n, func(t *testing.T) {
 			fixedClock.SetTime(fixedClockStart)
@@ -20,15 +20,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
 
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
@@ -43,6 +49,11 @@ import (
 
 const (
 	CRControllerName = "certificaterequests-issuer-venafi"
+
+	// minPollInterval and maxPollInterval bound the interval at which
+	// requests pending in Venafi are polled.
+	minPollInterval = 30 * time.Second
+	maxPollInterval = time.Hour
 )
 
 type Venafi struct {
@@ -53,6 +64,9 @@ type Venafi struct {
 
 	clientBuilder venaficlient.VenafiClientBuilder
 
+	// used for testing
+	clock clock.Clock
+
 	metrics *metrics.Metrics
 }
 
@@ -73,6 +87,7 @@ func NewVenafi(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		clientBuilder: venaficlient.New,
 		metrics:       ctx.Metrics,
 		cmClient:      ctx.CMClient,
+		clock:         ctx.Clock,
 	}
 }
 
@@ -111,11 +126,17 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 			return nil, nil
 		}
 	}
+	customFields = append(customFields, customFieldsFromAnnotations(cr.GetAnnotations())...)
 
 	duration := apiutil.DefaultCertDuration(cr.Spec.Duration)
-	pickupID := cr.ObjectMeta.Annotations[cmapi.VenafiPickupIDAnnotationKey]
+	pickupID := cr.Status.PickupID
+	if pickupID == "" {
+		// Requests submitted by earlier versions of cert-manager recorded the
+		// pickup ID using an annotation.
+		pickupID = cr.ObjectMeta.Annotations[cmapi.VenafiPickupIDAnnotationKey]
+	}
 
-	// check if the pickup ID annotation is there, if not set it up.
+	// check if the request has been submitted to Venafi, if not submit it.
 	if pickupID == "" {
 		pickupID, err = client.RequestCertificate(cr.Spec.Request, duration, customFields)
 		// Check some known error types
@@ -140,7 +161,7 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 
 		v.reporter.Pending(cr, err, "IssuancePending", "Venafi certificate is requested")
 
-		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, pickupID)
+		v.storePickupID(ctx, cr, pickupID)
 
 		return nil, nil
 	}
@@ -149,16 +170,21 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 	if err != nil {
 		switch err.(type) {
 		case endpoint.ErrCertificatePending, endpoint.ErrRetrieveCertificateTimeout:
-			message := "Venafi certificate still in a pending state, the request will be retried"
+			// The request is not submitted again, as Venafi may be waiting
+			// for it to be approved manually. Instead it is polled with an
+			// increasing interval until the certificate has been issued.
+			message := "Venafi certificate is waiting for approval, the request will be retried"
 
 			v.reporter.Pending(cr, err, "IssuancePending", message)
-			log.Error(err, message)
-			return nil, err
+			v.setPendingApproval(cr, err)
+			log.V(logf.InfoLevel).Info(message, "reason", err.Error(), "nextPollTime", cr.Status.NextPollTime)
+			return nil, nil
 
 		default:
 			message := "Failed to obtain venafi certificate"
 
 			v.reporter.Failed(cr, err, "RetrieveError", message)
+			v.clearPendingApproval(cr, cmapi.CertificateRequestReasonFailed, message)
 			log.Error(err, message)
 
 			return nil, err
@@ -175,8 +201,102 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 		return nil, err
 	}
 
+	v.clearPendingApproval(cr, cmapi.CertificateRequestReasonIssued, "Venafi certificate has been issued")
+
 	return &issuerpkg.IssueResponse{
 		Certificate: bundle.ChainPEM,
 		CA:          bundle.CAPEM,
 	}, nil
 }
+
+// storePickupID records the pickup ID of a submitted request in the status
+// of the given CertificateRequest. The status is patched straight away,
+// rather than saved with the rest of the changes at the end of the sync, so
+// that the request is resumed rather than submitted to Venafi again if the
+// controller restarts in the meantime. If the patch fails, the pickup ID is
+// still saved at the end of the sync.
+func (v *Venafi) storePickupID(ctx context.Context, cr *cmapi.CertificateRequest, pickupID string) {
+	log := logf.FromContext(ctx, "storePickupID")
+
+	cr.Status.PickupID = pickupID
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]string{
+			"pickupID": pickupID,
+		},
+	})
+	if err != nil {
+		log.Error(err, "failed to build pickup ID patch", "pickupID", pickupID)
+		return
+	}
+
+	patched, err := v.cmClient.CertmanagerV1().CertificateRequests(cr.Namespace).Patch(ctx, cr.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		log.Error(err, "failed to store pickup ID, it will be saved with the status of the request", "pickupID", pickupID)
+		return
+	}
+	// The resource version is updated so that the changes saved at the end
+	// of the sync don't conflict with the patch.
+	cr.ResourceVersion = patched.ResourceVersion
+}
+
+// setPendingApproval marks the given CertificateRequest as waiting for the
+// certificate to be issued by Venafi, and sets the time at which it will next
+// be polled.
+func (v *Venafi) setPendingApproval(cr *cmapi.CertificateRequest, err error) {
+	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionPendingApproval,
+		cmmeta.ConditionTrue, "IssuancePending", err.Error())
+
+	now := v.clock.Now()
+	pendingSince := now
+	if cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionPendingApproval); cond.LastTransitionTime != nil {
+		pendingSince = cond.LastTransitionTime.Time
+	}
+	nextPollTime := metav1.NewTime(now.Add(pollInterval(now.Sub(pendingSince))))
+	cr.Status.NextPollTime = &nextPollTime
+}
+
+// clearPendingApproval marks the given CertificateRequest as no longer
+// waiting for the certificate to be issued by Venafi.
+func (v *Venafi) clearPendingApproval(cr *cmapi.CertificateRequest, reason, message string) {
+	cr.Status.NextPollTime = nil
+	if apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionPendingApproval) == nil {
+		return
+	}
+	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionPendingApproval,
+		cmmeta.ConditionFalse, reason, message)
+}
+
+// pollInterval returns the time to wait before checking again whether a
+// request that has been pending for the given duration has been issued. The
+// interval grows with the time that the request has been pending, so that
+// requests which are approved quickly are picked up quickly, while requests
+// which wait days for approval aren't polled more than once an hour.
+func pollInterval(pending time.Duration) time.Duration {
+	interval := pending / 2
+	if interval < minPollInterval {
+		return minPollInterval
+	}
+	if interval > maxPollInterval {
+		return maxPollInterval
+	}
+	return interval
+}
+
+// customFieldsFromAnnotations returns the plain custom fields set using
+// annotations prefixed with cmapi.VenafiCustomFieldAnnotationPrefix, sorted
+// by name.
+func customFieldsFromAnnotations(annotations map[string]string) []api.CustomField {
+	var fields []api.CustomField
+	for key, value := range annotations {
+		name := strings.TrimPrefix(key, cmapi.VenafiCustomFieldAnnotationPrefix)
+		if name == key || name == "" {
+			continue
+		}
+		fields = append(fields, api.CustomField{Name: name, Value: value})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+	return fields
+}
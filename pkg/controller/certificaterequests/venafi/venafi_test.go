@@ -24,6 +24,7 @@ import (
 	"crypto/x509/pkix"
 	"errors"
 	"math/big"
+	"reflect"
 	"testing"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
@@ -263,6 +265,26 @@ func TestSign(t *testing.T) {
 		},
 	}
 
+	tppCRWaitingForNextPoll := gen.CertificateRequestFrom(tppCR,
+		gen.SetCertificateRequestPickupID("test"),
+		gen.SetCertificateRequestNextPollTime(metav1.NewTime(fixedClockStart.Add(time.Minute))),
+	)
+
+	tppCRWithPickupIDAnnotation := gen.CertificateRequestFrom(tppCR,
+		gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+	)
+
+	pickupIDPatchAction := func(cr *cmapi.CertificateRequest) controllertest.Action {
+		return controllertest.NewAction(coretesting.NewPatchSubresourceAction(
+			cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+			gen.DefaultTestNamespace,
+			cr.Name,
+			types.MergePatchType,
+			[]byte(`{"status":{"pickupID":"test"}}`),
+			"status",
+		))
+	}
+
 	tests := map[string]testT{
 		"a CertificateRequest without an approved condition should do nothing": {
 			certificateRequest: baseCRNotApproved.DeepCopy(),
@@ -432,19 +454,20 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
-		"tpp: if sign returns pending error then set pending and return err": {
+		"tpp: if sign returns pending error then set pending approval and poll later": {
 			certificateRequest: tppCR.DeepCopy(),
 			builder: &controllertest.Builder{
 				KubeObjects:        []runtime.Object{tppSecret},
 				CertManagerObjects: []runtime.Object{cloudCR.DeepCopy(), tppIssuer.DeepCopy()},
 				ExpectedEvents: []string{
 					"Normal IssuancePending Venafi certificate is requested",
-					"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+					"Normal IssuancePending Venafi certificate is waiting for approval, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 				},
 				ExpectedActions: []controllertest.Action{
+					pickupIDPatchAction(tppCR),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"",
+						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -454,7 +477,7 @@ func TestSign(t *testing.T) {
 								Message:            "Venafi certificate is requested",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -466,31 +489,40 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+								Message:            "Venafi certificate is waiting for approval, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionPendingApproval,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "IssuancePending",
+								Message:            "Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestNextPollTime(metav1.NewTime(fixedClockStart.Add(minPollInterval))),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsPending,
-			expectedErr:      true,
+			expectedErr:      false,
 		},
-		"cloud: if sign returns pending error then set pending and return err": {
+		"cloud: if sign returns pending error then set pending approval and poll later": {
 			certificateRequest: cloudCR.DeepCopy(),
 			builder: &controllertest.Builder{
 				KubeObjects:        []runtime.Object{cloudSecret},
 				CertManagerObjects: []runtime.Object{cloudCR.DeepCopy(), cloudIssuer.DeepCopy()},
 				ExpectedEvents: []string{
 					"Normal IssuancePending Venafi certificate is requested",
-					"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+					"Normal IssuancePending Venafi certificate is waiting for approval, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 				},
 				ExpectedActions: []controllertest.Action{
+					pickupIDPatchAction(cloudCR),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"",
+						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -500,7 +532,7 @@ func TestSign(t *testing.T) {
 								Message:            "Venafi certificate is requested",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -512,17 +544,66 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+								Message:            "Venafi certificate is waiting for approval, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionPendingApproval,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "IssuancePending",
+								Message:            "Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestNextPollTime(metav1.NewTime(fixedClockStart.Add(minPollInterval))),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsPending,
-			expectedErr:      true,
+			expectedErr:      false,
+		},
+		"tpp: if the next poll time has not been reached then do nothing": {
+			certificateRequest: tppCRWaitingForNextPoll.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWaitingForNextPoll.DeepCopy(), tppIssuer.DeepCopy()},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsPending,
+			skipSecondSignCall: true,
+		},
+		"tpp: if the pickup ID was recorded using the annotation then retrieve the certificate": {
+			certificateRequest: tppCRWithPickupIDAnnotation.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithPickupIDAnnotation.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithPickupIDAnnotation,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestCA(rootPEM),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsCert,
+			skipSecondSignCall: true,
 		},
 		"tpp: if sign returns generic error then set pending and return error": {
 			certificateRequest: tppCR.DeepCopy(),
@@ -596,9 +677,10 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []controllertest.Action{
+					pickupIDPatchAction(tppCR),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"",
+						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -608,7 +690,7 @@ func TestSign(t *testing.T) {
 								Message:            "Venafi certificate is requested",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -625,7 +707,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestCertificate(certPEM),
 							gen.SetCertificateRequestCA(rootPEM),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 				},
@@ -643,9 +725,10 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []controllertest.Action{
+					pickupIDPatchAction(cloudCR),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"",
+						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -655,7 +738,7 @@ func TestSign(t *testing.T) {
 								Message:            "Venafi certificate is requested",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -672,7 +755,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestCertificate(certPEM),
 							gen.SetCertificateRequestCA(rootPEM),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 				},
@@ -689,9 +772,10 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []controllertest.Action{
+					pickupIDPatchAction(tppCRWithCustomFields),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"",
+						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCRWithCustomFields,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -701,7 +785,7 @@ func TestSign(t *testing.T) {
 								Message:            "Venafi certificate is requested",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -718,7 +802,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestCertificate(certPEM),
 							gen.SetCertificateRequestCA(rootPEM),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestPickupID("test"),
 						),
 					)),
 				},
@@ -840,7 +924,7 @@ func runTest(t *testing.T, test testT) {
 
 	if err == nil && test.fakeClient != nil && test.fakeClient.RetrieveCertificateFn != nil && !test.skipSecondSignCall {
 		// request state is ok! simulating a 2nd sync to fetch the cert
-		metav1.SetMetaDataAnnotation(&test.certificateRequest.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, "test")
+		test.certificateRequest.Status.PickupID = "test"
 		err = controller.Sync(context.Background(), test.certificateRequest)
 	}
 
@@ -853,3 +937,34 @@ func runTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+func TestCustomFieldsFromAnnotations(t *testing.T) {
+	fields := customFieldsFromAnnotations(map[string]string{
+		cmapi.VenafiCustomFieldAnnotationPrefix + "environment": "production",
+		cmapi.VenafiCustomFieldAnnotationPrefix + "cost-center": "1234",
+		cmapi.VenafiCustomFieldAnnotationPrefix:                 "no name",
+		cmapi.VenafiCustomFieldsAnnotationKey:                   `[{"name": "cert-manager-test", "value": "test ok"}]`,
+		"example.com/other":                                     "ignored",
+	})
+	expected := []api.CustomField{
+		{Name: "cost-center", Value: "1234"},
+		{Name: "environment", Value: "production"},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected custom fields %v, got %v", expected, fields)
+	}
+}
+
+func TestPollInterval(t *testing.T) {
+	for pending, expected := range map[time.Duration]time.Duration{
+		0:                  minPollInterval,
+		time.Minute:        minPollInterval,
+		10 * time.Minute:   5 * time.Minute,
+		24 * time.Hour:     maxPollInterval,
+		7 * 24 * time.Hour: maxPollInterval,
+	} {
+		if interval := pollInterval(pending); interval != expected {
+			t.Errorf("expected a request pending for %s to be polled after %s, got %s", pending, expected, interval)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// ValidityAlignment is a time of day, in a time zone, at which certificates
+// expire and are renewed.
+type ValidityAlignment struct {
+	Hour, Minute int
+	Location     *time.Location
+}
+
+// ParseValidityAlignment parses the value of the
+// `cert-manager.io/validity-alignment` annotation, which is a time of day in
+// the form `HH:MM`, optionally followed by an IANA time zone name such as
+// `Europe/Berlin`. The time zone defaults to UTC.
+func ParseValidityAlignment(value string) (*ValidityAlignment, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("invalid validity alignment %q: must be a time of day, e.g. '03:00' or '03:00 Europe/Berlin'", value)
+	}
+
+	tod, err := time.Parse("15:04", fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid validity alignment %q: %w", value, err)
+	}
+
+	loc := time.UTC
+	if len(fields) == 2 {
+		loc, err = time.LoadLocation(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid validity alignment %q: %w", value, err)
+		}
+	}
+
+	return &ValidityAlignment{Hour: tod.Hour(), Minute: tod.Minute(), Location: loc}, nil
+}
+
+// ValidityAlignmentForCertificate returns the validity alignment of the given
+// Certificate, or nil if it has none.
+func ValidityAlignmentForCertificate(crt *cmapi.Certificate) (*ValidityAlignment, error) {
+	value, ok := crt.Annotations[cmapi.ValidityAlignmentAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	return ParseValidityAlignment(value)
+}
+
+// Before returns the latest time at or before t which is at the time of day of
+// the alignment.
+func (a *ValidityAlignment) Before(t time.Time) time.Time {
+	local := t.In(a.Location)
+	aligned := time.Date(local.Year(), local.Month(), local.Day(), a.Hour, a.Minute, 0, 0, a.Location)
+	if aligned.After(t) {
+		aligned = time.Date(local.Year(), local.Month(), local.Day()-1, a.Hour, a.Minute, 0, 0, a.Location)
+	}
+	return aligned
+}
+
+// Duration returns the requested duration of a certificate issued at the
+// given time, shortened so that it expires at the time of day of the
+// alignment. The duration is never lengthened, as issuers may cap it, and is
+// not shortened to less than half of the given duration, so that short lived
+// certificates are not aligned.
+func (a *ValidityAlignment) Duration(issuedAt time.Time, duration time.Duration) time.Duration {
+	aligned := a.Before(issuedAt.Add(duration)).Sub(issuedAt)
+	if aligned <= duration/2 {
+		return duration
+	}
+	return aligned
+}
+
+// AlignedDurationMatches returns true if requested is a duration that was
+// shortened from duration by a validity alignment, i.e. if it is shorter than
+// duration by less than a day.
+func AlignedDurationMatches(duration, requested time.Duration) bool {
+	return requested <= duration && duration-requested < 24*time.Hour
+}
+
+// AlignRenewalTime returns the given renewal time of a certificate, moved
+// back to the time of day of the validity alignment of crt, if it has one.
+// The renewal time is left unchanged if it would be moved to before
+// notBefore, or if the alignment is invalid.
+func AlignRenewalTime(crt *cmapi.Certificate, notBefore time.Time, renewalTime *metav1.Time) *metav1.Time {
+	alignment, err := ValidityAlignmentForCertificate(crt)
+	if err != nil || alignment == nil || renewalTime == nil {
+		return renewalTime
+	}
+	aligned := alignment.Before(renewalTime.Time)
+	if !aligned.After(notBefore) {
+		return renewalTime
+	}
+	rt := metav1.NewTime(aligned)
+	return &rt
+}
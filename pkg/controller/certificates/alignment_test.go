@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestParseValidityAlignment(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	tests := map[string]struct {
+		value  string
+		exp    *ValidityAlignment
+		expErr bool
+	}{
+		"time of day defaults to UTC": {value: "03:00", exp: &ValidityAlignment{Hour: 3, Location: time.UTC}},
+		"time of day in a time zone":  {value: "23:30 Europe/Berlin", exp: &ValidityAlignment{Hour: 23, Minute: 30, Location: berlin}},
+		"empty":                       {value: "", expErr: true},
+		"invalid time of day":         {value: "3am", expErr: true},
+		"unknown time zone":           {value: "03:00 Mars/Olympus_Mons", expErr: true},
+		"too many fields":             {value: "03:00 UTC UTC", expErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseValidityAlignment(test.value)
+			assert.Equal(t, test.expErr, err != nil, "unexpected error: %v", err)
+			assert.Equal(t, test.exp, got)
+		})
+	}
+}
+
+func TestValidityAlignmentDuration(t *testing.T) {
+	alignment := &ValidityAlignment{Hour: 3, Location: time.UTC}
+	issuedAt := time.Date(2022, 10, 1, 14, 15, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		duration time.Duration
+		exp      time.Duration
+	}{
+		"duration is shortened to expire at the time of day": {
+			duration: 90 * 24 * time.Hour,
+			exp:      90*24*time.Hour - 11*time.Hour - 15*time.Minute,
+		},
+		"duration which already expires at the time of day is unchanged": {
+			duration: 90*24*time.Hour - 11*time.Hour - 15*time.Minute,
+			exp:      90*24*time.Hour - 11*time.Hour - 15*time.Minute,
+		},
+		"short duration is not aligned": {
+			duration: 12 * time.Hour,
+			exp:      12 * time.Hour,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := alignment.Duration(issuedAt, test.duration)
+			assert.Equal(t, test.exp, got)
+			if got != test.duration {
+				assert.Equal(t, "03:00", issuedAt.Add(got).Format("15:04"))
+			}
+		})
+	}
+}
+
+func TestAlignRenewalTime(t *testing.T) {
+	notBefore := time.Date(2022, 10, 1, 14, 15, 0, 0, time.UTC)
+	renewalTime := metav1.NewTime(time.Date(2022, 12, 1, 14, 15, 0, 0, time.UTC))
+	aligned := func(value string) *cmapi.Certificate {
+		return &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{cmapi.ValidityAlignmentAnnotationKey: value},
+		}}
+	}
+
+	tests := map[string]struct {
+		crt       *cmapi.Certificate
+		notBefore time.Time
+		exp       time.Time
+	}{
+		"renewal time of a Certificate without alignment is unchanged": {
+			crt:       &cmapi.Certificate{},
+			notBefore: notBefore,
+			exp:       renewalTime.Time,
+		},
+		"renewal time is moved back to the time of day": {
+			crt:       aligned("03:00"),
+			notBefore: notBefore,
+			exp:       time.Date(2022, 12, 1, 3, 0, 0, 0, time.UTC),
+		},
+		"renewal time is moved back to the previous day": {
+			crt:       aligned("22:00"),
+			notBefore: notBefore,
+			exp:       time.Date(2022, 11, 30, 22, 0, 0, 0, time.UTC),
+		},
+		"renewal time is unchanged if the alignment is invalid": {
+			crt:       aligned("3am"),
+			notBefore: notBefore,
+			exp:       renewalTime.Time,
+		},
+		"renewal time is not moved to before the certificate is valid": {
+			crt:       aligned("03:00"),
+			notBefore: time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC),
+			exp:       renewalTime.Time,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := AlignRenewalTime(test.crt, test.notBefore, &renewalTime)
+			assert.True(t, test.exp.Equal(got.Time), "expected %s, got %s", test.exp, got.Time)
+		})
+	}
+}
+
+func TestRequestMatchesSpecWithAlignedDuration(t *testing.T) {
+	issuerRef := cmmeta.ObjectReference{Name: "issuer"}
+	spec := cmapi.CertificateSpec{
+		CommonName: "cn",
+		Duration:   &metav1.Duration{Duration: 90 * 24 * time.Hour},
+		IssuerRef:  issuerRef,
+	}
+	withDuration := func(duration time.Duration, alignment string) *cmapi.CertificateRequest {
+		req := generateRequest(t, spec, issuerRef)
+		req.Spec.Duration = &metav1.Duration{Duration: duration}
+		if len(alignment) > 0 {
+			req.Annotations = map[string]string{cmapi.ValidityAlignmentAnnotationKey: alignment}
+		}
+		return req
+	}
+
+	tests := map[string]struct {
+		request   *cmapi.CertificateRequest
+		violation bool
+	}{
+		"aligned duration shortened by less than a day matches": {
+			request: withDuration(90*24*time.Hour-11*time.Hour, "03:00"),
+		},
+		"aligned duration shortened by more than a day does not match": {
+			request:   withDuration(88*24*time.Hour, "03:00"),
+			violation: true,
+		},
+		"shortened duration without alignment does not match": {
+			request:   withDuration(90*24*time.Hour-11*time.Hour, ""),
+			violation: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			violations, err := RequestMatchesSpec(test.request, spec, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, test.violation, len(violations) > 0, "unexpected violations: %v", violations)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// defaultBatchSize is the batch size of Issuers which don't set the
+	// ca-rotation-batch-size annotation.
+	defaultBatchSize = 10
+
+	// defaultInterval is the interval between batches of Issuers which don't
+	// set the ca-rotation-interval annotation.
+	defaultInterval = time.Minute
+)
+
+// rotationConfig is the CA rotation configuration of an Issuer, read from its
+// annotations.
+type rotationConfig struct {
+	action    string
+	batchSize int
+	interval  time.Duration
+}
+
+// configFor returns the CA rotation configuration of iss, or nil if iss is not
+// a CA issuer or has not opted in to the fan-out of CA rotations.
+func configFor(iss cmapi.GenericIssuer) (*rotationConfig, error) {
+	if iss.GetSpec().CA == nil {
+		return nil, nil
+	}
+	annotations := iss.GetAnnotations()
+	action, ok := annotations[cmapi.CARotationActionAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	config := &rotationConfig{action: action, batchSize: defaultBatchSize, interval: defaultInterval}
+	switch action {
+	case cmapi.CARotationActionRefreshCA, cmapi.CARotationActionReissue:
+	default:
+		return nil, fmt.Errorf("invalid %s annotation: unsupported action %q, must be one of %s or %s",
+			cmapi.CARotationActionAnnotationKey, action, cmapi.CARotationActionRefreshCA, cmapi.CARotationActionReissue)
+	}
+
+	if batchSize, ok := annotations[cmapi.CARotationBatchSizeAnnotationKey]; ok {
+		var err error
+		if config.batchSize, err = strconv.Atoi(batchSize); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", cmapi.CARotationBatchSizeAnnotationKey, err)
+		}
+		if config.batchSize < 1 {
+			return nil, fmt.Errorf("invalid %s annotation: must be at least 1", cmapi.CARotationBatchSizeAnnotationKey)
+		}
+	}
+	if interval, ok := annotations[cmapi.CARotationIntervalAnnotationKey]; ok {
+		var err error
+		if config.interval, err = time.ParseDuration(interval); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", cmapi.CARotationIntervalAnnotationKey, err)
+		}
+	}
+	return config, nil
+}
+
+// issuerKey returns the queue key of an Issuer or ClusterIssuer. Keys are
+// prefixed with the kind, as both kinds are processed by the same queue.
+func issuerKey(kind, namespace, name string) string {
+	if len(namespace) == 0 {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+func splitIssuerKey(key string) (kind, namespace, name string, err error) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return "", "", "", fmt.Errorf("unexpected key format: %q", key)
+	}
+	namespace, name, err = cache.SplitMetaNamespaceKey(parts[1])
+	return parts[0], namespace, name, err
+}
+
+// referencesCertManagerIssuer returns true if crt references an Issuer or
+// ClusterIssuer rather than an external issuer, which the IssuerRefIndex does
+// not tell apart.
+func referencesCertManagerIssuer(crt *cmapi.Certificate) bool {
+	ref := crt.Spec.IssuerRef
+	return len(ref.Group) == 0 || ref.Group == "cert-manager.io"
+}
+
+// isIssuing returns true if the issuance of crt has been triggered and has
+// not yet completed.
+func isIssuing(crt *cmapi.Certificate) bool {
+	return apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionIssuing,
+		Status: cmmeta.ConditionTrue,
+	})
+}
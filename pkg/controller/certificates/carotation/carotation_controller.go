@@ -0,0 +1,481 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/kube"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// ControllerName is the name of the CA rotation controller.
+	ControllerName = "certificates-ca-rotation"
+
+	reasonInvalidCARotation = "InvalidCARotation"
+	reasonCARotation        = "CARotation"
+	reasonCARotationSkipped = "CARotationSkipped"
+
+	// reasonCARotated is the reason of the Issuing condition set on
+	// Certificates re-issued after a CA rotation.
+	reasonCARotated = "CARotated"
+)
+
+// controller updates the Certificates issued by a CA Issuer or ClusterIssuer
+// after the CA in its Secret changes, for Issuers which opt in using the
+// ca-rotation-action annotation. A Certificate is out of date if the ca.crt
+// of its Secret is not the CA that the Issuer would currently return for it.
+// Depending on the action, its ca.crt is refreshed or it is re-issued.
+// At most batchSize Certificates are updated at once, and the remaining ones
+// are updated after the interval of the Issuer.
+type controller struct {
+	certificateIndexer  cache.Indexer
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        corelisters.SecretLister
+	kubeClient          kubernetes.Interface
+	client              cmclient.Interface
+	recorder            record.EventRecorder
+	queue               workqueue.RateLimitingInterface
+
+	// issuerOptions is used to find the namespace of the CA Secret of
+	// ClusterIssuers.
+	issuerOptions controllerpkg.IssuerOptions
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Apply API calls.
+	fieldManager string
+
+	// statusWriter is used to write the status of Certificates. It uses
+	// client unless replaced with the StatusWriter of the controller Context.
+	statusWriter *statuswriter.Writer
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Issuers and Certificates with a different spec.controllerName are
+	// ignored.
+	controllerClass string
+}
+
+// NewController returns a new CA rotation controller. ClusterIssuers are only
+// watched if watchClusterIssuers is true, i.e. if the controller is not scoped
+// to a single namespace.
+func NewController(
+	log logr.Logger,
+	kubeClient kubernetes.Interface,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	issuerOptions controllerpkg.IssuerOptions,
+	fieldManager string,
+	watchClusterIssuers bool,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// create a queue used to queue up items to be processed
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretInformer := factory.Core().V1().Secrets()
+
+	// the Certificates of an Issuer are looked up using the IssuerRefIndex
+	if err := controllerpkg.EnsureIndexers(certificateInformer.Informer(), cache.Indexers{
+		controllerpkg.IssuerRefIndex: controllerpkg.CertificateIndexers[controllerpkg.IssuerRefIndex],
+	}); err != nil {
+		return nil, nil, nil, err
+	}
+
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueIssuer(log, queue, cmapi.IssuerKind),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+	}
+
+	ctrl := &controller{
+		certificateIndexer: certificateInformer.Informer().GetIndexer(),
+		issuerLister:       issuerInformer.Lister(),
+		secretLister:       secretInformer.Lister(),
+		kubeClient:         kubeClient,
+		client:             client,
+		recorder:           recorder,
+		queue:              queue,
+		issuerOptions:      issuerOptions,
+		fieldManager:       fieldManager,
+		statusWriter:       statuswriter.New(client, nil),
+	}
+
+	if watchClusterIssuers {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		ctrl.clusterIssuerLister = clusterIssuerInformer.Lister()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+			WorkFunc: enqueueIssuer(log, queue, cmapi.ClusterIssuerKind),
+		})
+	}
+
+	// When a Secret changes, the CA Issuers which reference it are enqueued
+	// so that the rotation of the CA is fanned out.
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: ctrl.enqueueIssuersForSecret(log),
+	})
+
+	return ctrl, queue, mustSync, nil
+}
+
+func enqueueIssuer(log logr.Logger, queue workqueue.Interface, kind string) func(obj interface{}) {
+	return func(obj interface{}) {
+		iss, ok := obj.(cmapi.GenericIssuer)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-issuer type resource passed to enqueueIssuer")
+			return
+		}
+		if iss.GetSpec().CA == nil {
+			return
+		}
+		queue.Add(issuerKey(kind, iss.GetNamespace(), iss.GetName()))
+	}
+}
+
+// enqueueIssuersForSecret enqueues the CA Issuers and ClusterIssuers whose CA
+// is stored in the Secret.
+func (c *controller) enqueueIssuersForSecret(log logr.Logger) func(obj interface{}) {
+	return func(obj interface{}) {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-Secret type resource passed to enqueueIssuersForSecret")
+			return
+		}
+
+		issuers, err := c.issuerLister.Issuers(secret.Namespace).List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list issuers", "namespace", secret.Namespace)
+			return
+		}
+		for _, iss := range issuers {
+			if iss.Spec.CA != nil && iss.Spec.CA.SecretName == secret.Name {
+				c.queue.Add(issuerKey(cmapi.IssuerKind, iss.Namespace, iss.Name))
+			}
+		}
+
+		if c.clusterIssuerLister == nil || secret.Namespace != c.issuerOptions.ClusterResourceNamespace {
+			return
+		}
+		clusterIssuers, err := c.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list clusterissuers")
+			return
+		}
+		for _, iss := range clusterIssuers {
+			if iss.Spec.CA != nil && iss.Spec.CA.SecretName == secret.Name {
+				c.queue.Add(issuerKey(cmapi.ClusterIssuerKind, "", iss.Name))
+			}
+		}
+	}
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to an Issuer or ClusterIssuer to be re-synced is pulled from
+// the workqueue. ProcessItem updates the next batch of the Certificates of
+// the Issuer which are out of date with its CA, and enqueues the Issuer again
+// after its interval if more remain.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	kind, namespace, name, err := splitIssuerKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	iss, err := c.getGenericIssuer(kind, namespace, name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("issuer not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, iss.GetSpec().ControllerName) {
+		log.V(logf.DebugLevel).Info("issuer is managed by a different controller class, skipping")
+		return nil
+	}
+
+	config, err := configFor(iss)
+	if err != nil {
+		c.recorder.Event(iss, corev1.EventTypeWarning, reasonInvalidCARotation, err.Error())
+		return nil
+	}
+	if config == nil {
+		return nil
+	}
+
+	// The CA is read the same way as the CA issuer does when signing, so that
+	// the CA of up to date Certificates matches.
+	caCerts, _, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretLister, c.issuerOptions.ResourceNamespace(iss), iss.GetSpec().CA.SecretName)
+	if apierrors.IsNotFound(err) || cmerrors.IsInvalidData(err) {
+		// The Issuer is not Ready, and is enqueued again when its Secret
+		// changes.
+		log.V(logf.DebugLevel).Info("failed to read the CA of the issuer", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	crts, err := certificates.ListCertificatesByIndexMatchingPredicates(c.certificateIndexer,
+		controllerpkg.IssuerRefIndex, controllerpkg.IssuerRefIndexKey(iss))
+	if err != nil {
+		return err
+	}
+	sort.Slice(crts, func(i, j int) bool {
+		if crts[i].Namespace != crts[j].Namespace {
+			return crts[i].Namespace < crts[j].Namespace
+		}
+		return crts[i].Name < crts[j].Name
+	})
+
+	updated, remaining := 0, 0
+	for _, crt := range crts {
+		if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) || !referencesCertManagerIssuer(crt) {
+			continue
+		}
+		// The ca.crt of Certificates which publish a cross-signed chain is
+		// not the CA of the Issuer.
+		if crt.Spec.CrossSignedChain != "" && crt.Spec.CrossSignedChain != cmapi.WritePrimaryChain {
+			continue
+		}
+		if isIssuing(crt) {
+			continue
+		}
+
+		secret, cert, err := c.issuedCertificate(crt)
+		if err != nil {
+			return err
+		}
+		if cert == nil {
+			continue
+		}
+
+		// The chain can only be built if the certificate was signed by the
+		// current key of the CA.
+		bundle, err := pki.ParseSingleCertificateChain(append(caCerts[:len(caCerts):len(caCerts)], cert))
+		signedByCA := err == nil
+		caPEM := bundle.CAPEM
+		if signedByCA && crt.Spec.AdditionalCABundle != nil {
+			// Keep the additional trust anchors of the Certificate in its
+			// ca.crt, as the secrets manager does when writing it.
+			caPEM, err = c.appendAdditionalCABundle(ctx, crt, caPEM)
+			if err != nil {
+				return err
+			}
+		}
+		if signedByCA && bytes.Equal(caPEM, secret.Data[cmmeta.TLSCAKey]) {
+			continue
+		}
+		if !signedByCA && config.action == cmapi.CARotationActionRefreshCA {
+			c.recorder.Event(crt, corev1.EventTypeWarning, reasonCARotationSkipped,
+				fmt.Sprintf("The CA of the issuer was rotated to a new key, the certificate must be re-issued to be signed by it: %v", err))
+			continue
+		}
+
+		if updated == config.batchSize {
+			remaining++
+			continue
+		}
+		updated++
+
+		switch config.action {
+		case cmapi.CARotationActionRefreshCA:
+			if err := c.refreshCA(ctx, secret, caPEM); err != nil {
+				return err
+			}
+		case cmapi.CARotationActionReissue:
+			if err := c.triggerIssuance(ctx, crt); err != nil {
+				return err
+			}
+		}
+	}
+
+	if updated > 0 {
+		c.recorder.Eventf(iss, corev1.EventTypeNormal, reasonCARotation,
+			"Applied the %s action of the CA rotation to %d Certificates, %d remaining", config.action, updated, remaining)
+	}
+	if remaining > 0 {
+		c.queue.AddAfter(key, config.interval)
+	}
+	return nil
+}
+
+// issuedCertificate returns the Secret of crt and the certificate stored in
+// it, or a nil certificate if crt has not been issued yet.
+func (c *controller) issuedCertificate(crt *cmapi.Certificate) (*corev1.Secret, *x509.Certificate, error) {
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		// Certificates with invalid data are re-issued by the trigger
+		// controller.
+		return secret, nil, nil
+	}
+	return secret, cert, nil
+}
+
+// appendAdditionalCABundle appends the additionalCABundle of crt to caPEM.
+func (c *controller) appendAdditionalCABundle(ctx context.Context, crt *cmapi.Certificate, caPEM []byte) ([]byte, error) {
+	additional, err := internalcertificates.AdditionalCABundle(ctx, c.kubeClient.CoreV1(), c.secretLister, crt)
+	if err != nil {
+		return nil, err
+	}
+	return internalcertificates.AppendCABundle(caPEM, additional)
+}
+
+// refreshCA replaces the ca.crt of the Secret of a Certificate.
+func (c *controller) refreshCA(ctx context.Context, secret *corev1.Secret, caPEM []byte) error {
+	secret = secret.DeepCopy()
+	secret.Data[cmmeta.TLSCAKey] = caPEM
+	if _, err := c.kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to refresh the CA of Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return nil
+}
+
+// triggerIssuance triggers the issuance of crt by setting its Issuing
+// condition.
+func (c *controller) triggerIssuance(ctx context.Context, crt *cmapi.Certificate) error {
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, reasonCARotated,
+		"Issuing certificate as the CA of the issuer has been rotated")
+	err := c.statusWriter.Write(ctx, crt, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			return internalcertificates.ApplyStatus(ctx, cl, c.fieldManager, &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+				Status:     cmapi.CertificateStatus{Conditions: []cmapi.CertificateCondition{*apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)}},
+			})
+		} else {
+			_, err := cl.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+			return err
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to trigger issuance of Certificate %s/%s: %w", crt.Namespace, crt.Name, err)
+	}
+	return nil
+}
+
+func (c *controller) getGenericIssuer(kind, namespace, name string) (cmapi.GenericIssuer, error) {
+	switch kind {
+	case cmapi.IssuerKind:
+		return c.issuerLister.Issuers(namespace).Get(name)
+	case cmapi.ClusterIssuerKind:
+		if c.clusterIssuerLister == nil {
+			return nil, apierrors.NewNotFound(cmapi.Resource("clusterissuers"), name)
+		}
+		return c.clusterIssuerLister.Get(name)
+	default:
+		return nil, fmt.Errorf("unknown issuer kind %q", kind)
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync, err := NewController(log,
+		ctx.Client,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.IssuerOptions,
+		ctx.FieldManager,
+		ctx.Namespace == "",
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
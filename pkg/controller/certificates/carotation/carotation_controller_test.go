@@ -0,0 +1,301 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func mustGenerateKey(t *testing.T) crypto.Signer {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func mustSign(t *testing.T, cn string, isCA bool, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, []byte) {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	pemBytes, cert, err := pki.SignCertificate(template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pemBytes
+}
+
+func TestProcessItem(t *testing.T) {
+	oldKey, newKey := mustGenerateKey(t), mustGenerateKey(t)
+	newKeyPEM, err := pki.EncodePrivateKey(newKey, cmapi.PKCS1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The CA was rotated from oldRoot to newRoot. renewedRoot is a renewal
+	// of the old root which keeps its key.
+	oldRoot, oldRootPEM := mustSign(t, "root", true, oldKey, nil, nil)
+	_, renewedRootPEM := mustSign(t, "root", true, oldKey, nil, nil)
+	newRoot, newRootPEM := mustSign(t, "root", true, newKey, nil, nil)
+
+	caSecret := func(rootPEM []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "ca-key-pair"},
+			Data:       map[string][]byte{corev1.TLSCertKey: rootPEM, corev1.TLSPrivateKeyKey: newKeyPEM},
+		}
+	}
+	// certificate returns a Certificate and its Secret, signed by the given
+	// root and with the given ca.crt.
+	certificate := func(name string, root *x509.Certificate, rootKey crypto.Signer, caPEM []byte, mods ...gen.CertificateModifier) (*cmapi.Certificate, *corev1.Secret) {
+		_, leafPEM := mustSign(t, name, false, mustGenerateKey(t), root, rootKey)
+		crt := gen.Certificate(name, append([]gen.CertificateModifier{
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}),
+			gen.SetCertificateSecretName(name + "-tls"),
+		}, mods...)...)
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name + "-tls"},
+			Data:       map[string][]byte{corev1.TLSCertKey: leafPEM, cmmeta.TLSCAKey: caPEM},
+		}
+		return crt, secret
+	}
+	caIssuer := func(annotations map[string]string) *cmapi.Issuer {
+		iss := gen.Issuer("ca", gen.SetIssuerNamespace("testns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}))
+		iss.Annotations = annotations
+		return iss
+	}
+	objects := func(crts ...func() (*cmapi.Certificate, *corev1.Secret)) ([]runtime.Object, []runtime.Object) {
+		var cmObjects, kubeObjects []runtime.Object
+		for _, f := range crts {
+			crt, secret := f()
+			cmObjects = append(cmObjects, crt)
+			kubeObjects = append(kubeObjects, secret)
+		}
+		return cmObjects, kubeObjects
+	}
+
+	staleOldKey := func() (*cmapi.Certificate, *corev1.Secret) {
+		return certificate("stale-old-key", oldRoot, oldKey, oldRootPEM)
+	}
+	staleNewKey := func() (*cmapi.Certificate, *corev1.Secret) {
+		return certificate("stale-new-key", newRoot, newKey, oldRootPEM)
+	}
+	staleNewKey2 := func() (*cmapi.Certificate, *corev1.Secret) {
+		return certificate("stale-new-key-2", newRoot, newKey, oldRootPEM)
+	}
+	current := func() (*cmapi.Certificate, *corev1.Secret) {
+		return certificate("current", newRoot, newKey, newRootPEM)
+	}
+	issuing := func() (*cmapi.Certificate, *corev1.Secret) {
+		return certificate("issuing", oldRoot, oldKey, oldRootPEM,
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}))
+	}
+	otherIssuer := func() (*cmapi.Certificate, *corev1.Secret) {
+		return certificate("other-issuer", oldRoot, oldKey, oldRootPEM,
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "other"}))
+	}
+	crossSigned := func() (*cmapi.Certificate, *corev1.Secret) {
+		return certificate("cross-signed", newRoot, newKey, oldRootPEM,
+			func(crt *cmapi.Certificate) { crt.Spec.CrossSignedChain = cmapi.WriteCrossSignedChain })
+	}
+	renewed := func() (*cmapi.Certificate, *corev1.Secret) {
+		return certificate("renewed", oldRoot, oldKey, oldRootPEM)
+	}
+
+	tests := map[string]struct {
+		issuer       *cmapi.Issuer
+		caRootPEM    []byte
+		certificates []func() (*cmapi.Certificate, *corev1.Secret)
+
+		expectedRefreshed []string
+		expectedIssued    []string
+		expectedEvent     string
+	}{
+		"issuers which have not opted in are ignored": {
+			issuer:       caIssuer(nil),
+			caRootPEM:    newRootPEM,
+			certificates: []func() (*cmapi.Certificate, *corev1.Secret){staleNewKey, staleOldKey},
+		},
+		"issuers with an invalid action are ignored": {
+			issuer:       caIssuer(map[string]string{cmapi.CARotationActionAnnotationKey: "Revoke"}),
+			caRootPEM:    newRootPEM,
+			certificates: []func() (*cmapi.Certificate, *corev1.Secret){staleNewKey, staleOldKey},
+		},
+		"RefreshCA refreshes the CA of certificates signed by the current key": {
+			issuer:            caIssuer(map[string]string{cmapi.CARotationActionAnnotationKey: cmapi.CARotationActionRefreshCA}),
+			caRootPEM:         newRootPEM,
+			certificates:      []func() (*cmapi.Certificate, *corev1.Secret){staleNewKey, staleOldKey, current, issuing, otherIssuer, crossSigned},
+			expectedRefreshed: []string{"stale-new-key-tls"},
+			expectedEvent:     "Normal CARotation Applied the RefreshCA action of the CA rotation to 1 Certificates, 0 remaining",
+		},
+		"RefreshCA refreshes the CA of certificates signed by a renewed CA": {
+			issuer: caIssuer(map[string]string{cmapi.CARotationActionAnnotationKey: cmapi.CARotationActionRefreshCA}),
+			// The CA Secret of the Issuer holds the renewed root, but the key
+			// of the Secret is only used to sign.
+			caRootPEM:         renewedRootPEM,
+			certificates:      []func() (*cmapi.Certificate, *corev1.Secret){renewed},
+			expectedRefreshed: []string{"renewed-tls"},
+			expectedEvent:     "Normal CARotation Applied the RefreshCA action of the CA rotation to 1 Certificates, 0 remaining",
+		},
+		"Reissue re-issues all out of date certificates": {
+			issuer:         caIssuer(map[string]string{cmapi.CARotationActionAnnotationKey: cmapi.CARotationActionReissue}),
+			caRootPEM:      newRootPEM,
+			certificates:   []func() (*cmapi.Certificate, *corev1.Secret){staleNewKey, staleOldKey, current, issuing, otherIssuer, crossSigned},
+			expectedIssued: []string{"stale-new-key", "stale-old-key"},
+			expectedEvent:  "Normal CARotation Applied the Reissue action of the CA rotation to 2 Certificates, 0 remaining",
+		},
+		"certificates beyond the batch size are updated after the interval": {
+			issuer: caIssuer(map[string]string{
+				cmapi.CARotationActionAnnotationKey:    cmapi.CARotationActionRefreshCA,
+				cmapi.CARotationBatchSizeAnnotationKey: "1",
+			}),
+			caRootPEM:         newRootPEM,
+			certificates:      []func() (*cmapi.Certificate, *corev1.Secret){staleNewKey, staleNewKey2, current},
+			expectedRefreshed: []string{"stale-new-key-tls"},
+			expectedEvent:     "Normal CARotation Applied the RefreshCA action of the CA rotation to 1 Certificates, 1 remaining",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmObjects, kubeObjects := objects(test.certificates...)
+			builder := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: append([]runtime.Object{test.issuer}, cmObjects...),
+				KubeObjects:        append([]runtime.Object{caSecret(test.caRootPEM)}, kubeObjects...),
+			}
+			builder.Init()
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), issuerKey(cmapi.IssuerKind, "testns", "ca")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var refreshed []string
+			for _, action := range builder.FakeKubeClient().Actions() {
+				if action.GetVerb() != "update" {
+					continue
+				}
+				secret := action.(coretesting.UpdateAction).GetObject().(*corev1.Secret)
+				if !reflect.DeepEqual(secret.Data[cmmeta.TLSCAKey], test.caRootPEM) {
+					t.Errorf("expected the CA of Secret %q to be refreshed to the CA of the issuer", secret.Name)
+				}
+				refreshed = append(refreshed, secret.Name)
+			}
+
+			var issued []string
+			for _, action := range builder.FakeCMClient().Actions() {
+				update, ok := action.(coretesting.UpdateAction)
+				if !ok || action.GetVerb() != "update" || update.GetSubresource() != "status" {
+					continue
+				}
+				crt := update.GetObject().(*cmapi.Certificate)
+				cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+				if cond == nil || cond.Reason != reasonCARotated {
+					t.Errorf("expected Certificate %q to have an Issuing condition set by the CA rotation, got %+v", crt.Name, cond)
+				}
+				issued = append(issued, crt.Name)
+			}
+
+			sort.Strings(issued)
+			if !reflect.DeepEqual(refreshed, test.expectedRefreshed) {
+				t.Errorf("expected the CA of %v to be refreshed, got %v", test.expectedRefreshed, refreshed)
+			}
+			if !reflect.DeepEqual(issued, test.expectedIssued) {
+				t.Errorf("expected issuance of %v to be triggered, got %v", test.expectedIssued, issued)
+			}
+			var event string
+			for _, e := range builder.Events() {
+				if strings.HasPrefix(e, corev1.EventTypeNormal+" "+reasonCARotation+" ") {
+					event = e
+				}
+			}
+			if event != test.expectedEvent {
+				t.Errorf("expected event %q, got %q", test.expectedEvent, event)
+			}
+		})
+	}
+}
+
+func TestConfigFor(t *testing.T) {
+	iss := gen.Issuer("ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}))
+	iss.Annotations = map[string]string{
+		cmapi.CARotationActionAnnotationKey:    cmapi.CARotationActionReissue,
+		cmapi.CARotationBatchSizeAnnotationKey: "0",
+	}
+	if _, err := configFor(iss); err == nil {
+		t.Errorf("expected an error for a batch size of 0")
+	}
+
+	iss.Annotations[cmapi.CARotationBatchSizeAnnotationKey] = "5"
+	iss.Annotations[cmapi.CARotationIntervalAnnotationKey] = "soon"
+	if _, err := configFor(iss); err == nil {
+		t.Errorf("expected an error for an invalid interval")
+	}
+
+	delete(iss.Annotations, cmapi.CARotationIntervalAnnotationKey)
+	config, err := configFor(iss)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.action != cmapi.CARotationActionReissue || config.batchSize != 5 || config.interval != defaultInterval {
+		t.Errorf("unexpected config %+v", config)
+	}
+
+	selfSigned := gen.Issuer("selfsigned", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}))
+	selfSigned.Annotations = iss.Annotations
+	if config, err := configFor(selfSigned); config != nil || err != nil {
+		t.Errorf("expected issuers other than CA issuers to be ignored, got %+v, %v", config, err)
+	}
+}
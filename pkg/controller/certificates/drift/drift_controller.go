@@ -0,0 +1,392 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
+	gwlisters "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1alpha2"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// ControllerName is the name of the certificate drift controller.
+	ControllerName = "certificates-drift"
+
+	reasonDrifted         = "Drifted"
+	reasonInSync          = "InSync"
+	reasonProbeFailed     = "ProbeFailed"
+	reasonInvalidEndpoint = "InvalidEndpoint"
+
+	// probeTimeout is the maximum time taken to retrieve the certificate
+	// served on a single endpoint.
+	probeTimeout = 10 * time.Second
+)
+
+// probeFunc returns the leaf certificate served on the given endpoint.
+type probeFunc func(ctx context.Context, ep endpoint) (*x509.Certificate, error)
+
+// controller periodically connects to the endpoints that the certificates of
+// Ready Certificates are served on, and sets the Drifted condition of a
+// Certificate if any endpoint serves a different certificate than the one
+// stored in its Secret.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	ingressLister     networkingv1listers.IngressLister
+	recorder          record.EventRecorder
+	queue             workqueue.RateLimitingInterface
+	probe             probeFunc
+
+	// gatewayLister and httpRouteLister are nil if the Gateway API is not
+	// available, in which case Gateways are not used to discover endpoints.
+	gatewayLister   gwlisters.GatewayLister
+	httpRouteLister gwlisters.HTTPRouteLister
+
+	// interval is the interval at which each Certificate is checked.
+	interval time.Duration
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Apply API calls.
+	fieldManager string
+
+	// statusWriter is used to write the status of Certificates. It uses
+	// client unless replaced with the StatusWriter of the controller Context.
+	statusWriter *statuswriter.Writer
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+// NewController returns a new certificate drift controller. If gwFactory is
+// nil, Gateways are not used to discover endpoints.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	gwFactory gwinformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	interval time.Duration,
+	fieldManager string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretsInformer := factory.Core().V1().Secrets()
+	ingressInformer := factory.Networking().V1().Ingresses()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateSecretNameIndex),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		secretsInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		ingressInformer.Informer().HasSynced,
+	}
+
+	ctrl := &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      secretsInformer.Lister(),
+		ingressLister:     ingressInformer.Lister(),
+		recorder:          recorder,
+		queue:             queue,
+		probe:             probe,
+		interval:          interval,
+		fieldManager:      fieldManager,
+		statusWriter:      statuswriter.New(client, nil),
+	}
+
+	if gwFactory != nil {
+		gatewayInformer := gwFactory.Gateway().V1alpha2().Gateways()
+		httpRouteInformer := gwFactory.Gateway().V1alpha2().HTTPRoutes()
+		mustSync = append(mustSync, gatewayInformer.Informer().HasSynced, httpRouteInformer.Informer().HasSynced)
+		ctrl.gatewayLister = gatewayInformer.Lister()
+		ctrl.httpRouteLister = httpRouteInformer.Lister()
+	}
+
+	return ctrl, queue, mustSync
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a Certificate to be re-synced is pulled from the workqueue.
+// ProcessItem compares the certificates served on the endpoints of a Ready
+// Certificate with the certificate in its Secret, updates its Drifted
+// condition, and requeues the Certificate to be checked again after the
+// configured interval.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
+	if err := c.checkDrift(ctx, crt); err != nil {
+		return err
+	}
+
+	c.queue.AddAfter(key, c.interval)
+	return nil
+}
+
+func (c *controller) checkDrift(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	// Only certificates that have been issued and are currently in use are
+	// expected to be served.
+	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return nil
+	}
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to decode certificate in secret, waiting for it to be re-issued", "error", err.Error())
+		return nil
+	}
+
+	endpoints, invalid, err := c.endpointsForCertificate(crt)
+	if err != nil {
+		return err
+	}
+	for _, value := range invalid {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonInvalidEndpoint, "Ignoring invalid endpoint %q in the %s annotation: expected <host>:<port>", value, cmapi.DriftCheckEndpointsAnnotationKey)
+	}
+
+	updated := crt.DeepCopy()
+	if len(endpoints) == 0 {
+		apiutil.RemoveCertificateCondition(updated, cmapi.CertificateConditionDrifted)
+	} else {
+		condition := c.probeEndpoints(ctx, endpoints, cert)
+		apiutil.SetCertificateCondition(updated, updated.Generation, cmapi.CertificateConditionDrifted, condition.Status, condition.Reason, condition.Message)
+		if condition.Status == cmmeta.ConditionTrue && !apiutil.CertificateHasCondition(crt, condition) {
+			c.recorder.Event(crt, corev1.EventTypeWarning, reasonDrifted, condition.Message)
+		}
+	}
+
+	if apiequality.Semantic.DeepEqual(crt.Status, updated.Status) {
+		return nil
+	}
+	return c.updateOrApplyStatus(ctx, updated)
+}
+
+// probeEndpoints returns the Drifted condition for the given endpoints, by
+// comparing the certificate they serve with cert.
+func (c *controller) probeEndpoints(ctx context.Context, endpoints []endpoint, cert *x509.Certificate) cmapi.CertificateCondition {
+	log := logf.FromContext(ctx)
+
+	var drifted, failed []string
+	for _, ep := range endpoints {
+		served, err := c.probe(ctx, ep)
+		if err != nil {
+			log.V(logf.DebugLevel).Info("failed to retrieve certificate served on endpoint", "endpoint", ep.address, "error", err.Error())
+			failed = append(failed, fmt.Sprintf("%s (%v)", ep.address, err))
+			continue
+		}
+		if !bytes.Equal(served.Raw, cert.Raw) {
+			log.V(logf.DebugLevel).Info("endpoint serves a stale certificate", "endpoint", ep.address, "serial", served.SerialNumber.String())
+			drifted = append(drifted, fmt.Sprintf("%s (serial number %s)", ep.address, served.SerialNumber.String()))
+		}
+	}
+
+	switch {
+	case len(drifted) > 0:
+		return cmapi.CertificateCondition{
+			Status:  cmmeta.ConditionTrue,
+			Reason:  reasonDrifted,
+			Message: fmt.Sprintf("Endpoints are serving a different certificate than the one in the Secret: %s", strings.Join(drifted, ", ")),
+		}
+	case len(failed) == len(endpoints):
+		return cmapi.CertificateCondition{
+			Status:  cmmeta.ConditionUnknown,
+			Reason:  reasonProbeFailed,
+			Message: fmt.Sprintf("Failed to retrieve the certificate served on any endpoint: %s", strings.Join(failed, ", ")),
+		}
+	default:
+		message := fmt.Sprintf("All %d reachable endpoints are serving the certificate in the Secret", len(endpoints)-len(failed))
+		if len(failed) > 0 {
+			message += fmt.Sprintf("; failed to retrieve the certificate served on: %s", strings.Join(failed, ", "))
+		}
+		return cmapi.CertificateCondition{
+			Status:  cmmeta.ConditionFalse,
+			Reason:  reasonInSync,
+			Message: message,
+		}
+	}
+}
+
+// probe connects to the given endpoint and returns the leaf certificate that
+// it serves. The certificate is not verified, since it is only compared with
+// the certificate in the Secret.
+func probe(ctx context.Context, ep endpoint) (*x509.Certificate, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{},
+		Config: &tls.Config{
+			ServerName:         ep.serverName,
+			InsecureSkipVerify: true,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", ep.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate was served")
+	}
+	return certs[0], nil
+}
+
+// updateOrApplyStatus will update the controller status. If the
+// ServerSideApply feature is enabled, the managed fields will instead get
+// applied using the relevant Patch API call.
+func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	return c.statusWriter.Write(ctx, crt, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			var conditions []cmapi.CertificateCondition
+			if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionDrifted); cond != nil {
+				conditions = []cmapi.CertificateCondition{*cond}
+			}
+			return internalcertificates.ApplyStatus(ctx, cl, c.fieldManager, &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+				Status:     cmapi.CertificateStatus{Conditions: conditions},
+			})
+		} else {
+			_, err := cl.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+			return err
+		}
+	})
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	if ctx.DriftCheckInterval <= 0 {
+		return nil, nil, fmt.Errorf("the %s controller requires a drift check interval to be configured", ControllerName)
+	}
+
+	var gwFactory gwinformers.SharedInformerFactory
+	if ctx.GatewaySolverEnabled {
+		gwFactory = ctx.GWShared
+	}
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		gwFactory,
+		ctx.Recorder,
+		ctx.DriftCheckInterval,
+		ctx.FieldManager,
+	)
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func mustCreateCert(t *testing.T, crt *cmapi.Certificate) ([]byte, *x509.Certificate) {
+	certPEM := testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t), crt)
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, cert
+}
+
+func TestProcessItem(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	nowMetaTime := metav1.NewTime(fixedClock.Now())
+
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+	certPEM, cert := mustCreateCert(t, baseCrt)
+	_, staleCert := mustCreateCert(t, baseCrt)
+
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certPEM}),
+	)
+	annotatedCrt := gen.CertificateFrom(baseCrt, gen.AddCertificateAnnotations(map[string]string{
+		cmapi.DriftCheckEndpointsAnnotationKey: "10.0.0.1:8443",
+	}))
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"example.com", "*.example.com"}, SecretName: "test-secret"}},
+		},
+	}
+	withDrifted := func(status cmmeta.ConditionStatus, reason, message string) gen.CertificateModifier {
+		return gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionDrifted,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: &nowMetaTime,
+		})
+	}
+
+	tests := map[string]struct {
+		crt         *cmapi.Certificate
+		kubeObjects []runtime.Object
+		served      map[endpoint]*x509.Certificate
+		expUpdate   *cmapi.Certificate
+		expEvents   []string
+	}{
+		"do nothing if the Certificate is not Ready": {
+			crt: gen.CertificateFrom(annotatedCrt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionFalse,
+			})),
+		},
+		"do nothing if the Certificate has no endpoints": {
+			crt: baseCrt,
+		},
+		"set the condition to False if the annotated endpoints serve the current certificate": {
+			crt:       annotatedCrt,
+			served:    map[endpoint]*x509.Certificate{{address: "10.0.0.1:8443", serverName: "example.com"}: cert},
+			expUpdate: gen.CertificateFrom(annotatedCrt, withDrifted(cmmeta.ConditionFalse, "InSync", "All 1 reachable endpoints are serving the certificate in the Secret")),
+		},
+		"set the condition to True if the host of an Ingress serves a stale certificate": {
+			crt:         baseCrt,
+			kubeObjects: []runtime.Object{ingress},
+			served:      map[endpoint]*x509.Certificate{{address: "example.com:443", serverName: "example.com"}: staleCert},
+			expUpdate: gen.CertificateFrom(baseCrt, withDrifted(cmmeta.ConditionTrue, "Drifted",
+				"Endpoints are serving a different certificate than the one in the Secret: example.com:443 (serial number "+staleCert.SerialNumber.String()+")")),
+			expEvents: []string{"Warning Drifted Endpoints are serving a different certificate than the one in the Secret: example.com:443 (serial number " + staleCert.SerialNumber.String() + ")"},
+		},
+		"set the condition to Unknown if no endpoint can be reached": {
+			crt:       annotatedCrt,
+			expUpdate: gen.CertificateFrom(annotatedCrt, withDrifted(cmmeta.ConditionUnknown, "ProbeFailed", "Failed to retrieve the certificate served on any endpoint: 10.0.0.1:8443 (connection refused)")),
+		},
+		"remove the condition if the Certificate no longer has endpoints": {
+			crt:       gen.CertificateFrom(baseCrt, withDrifted(cmmeta.ConditionFalse, "InSync", "All 1 reachable endpoints are serving the certificate in the Secret")),
+			expUpdate: baseCrt,
+		},
+		"ignore invalid endpoints in the annotation": {
+			crt: gen.CertificateFrom(baseCrt, gen.AddCertificateAnnotations(map[string]string{
+				cmapi.DriftCheckEndpointsAnnotationKey: "example.com",
+			})),
+			expEvents: []string{`Warning InvalidEndpoint Ignoring invalid endpoint "example.com" in the cert-manager.io/drift-check-endpoints annotation: expected <host>:<port>`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{test.crt},
+				KubeObjects:        append([]runtime.Object{secret}, test.kubeObjects...),
+				ExpectedEvents:     test.expEvents,
+			}
+			if test.expUpdate != nil {
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						test.expUpdate.Namespace,
+						test.expUpdate)))
+			}
+			builder.Init()
+			builder.DriftCheckInterval = time.Hour
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.controller.probe = func(_ context.Context, ep endpoint) (*x509.Certificate, error) {
+				if cert, ok := test.served[ep]; ok {
+					return cert, nil
+				}
+				return nil, errors.New("connection refused")
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+func TestEndpointsForCertificate(t *testing.T) {
+	hostname := func(h string) *gwapi.Hostname {
+		hn := gwapi.Hostname(h)
+		return &hn
+	}
+	sectionName := func(s string) *gwapi.SectionName {
+		sn := gwapi.SectionName(s)
+		return &sn
+	}
+
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	gateway := &gwapi.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "gateway"},
+		Spec: gwapi.GatewaySpec{
+			Listeners: []gwapi.Listener{
+				{
+					Name:     "named",
+					Hostname: hostname("named.example.com"),
+					Port:     8443,
+					TLS:      &gwapi.GatewayTLSConfig{CertificateRefs: []gwapi.SecretObjectReference{{Name: "test-secret"}}},
+				},
+				{
+					Name:     "wildcard",
+					Hostname: hostname("*.example.com"),
+					Port:     443,
+					TLS:      &gwapi.GatewayTLSConfig{CertificateRefs: []gwapi.SecretObjectReference{{Name: "test-secret"}}},
+				},
+				{
+					Name:     "other-secret",
+					Hostname: hostname("other.example.com"),
+					Port:     443,
+					TLS:      &gwapi.GatewayTLSConfig{CertificateRefs: []gwapi.SecretObjectReference{{Name: "other-secret"}}},
+				},
+			},
+		},
+	}
+	route := &gwapi.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "route"},
+		Spec: gwapi.HTTPRouteSpec{
+			CommonRouteSpec: gwapi.CommonRouteSpec{
+				ParentRefs: []gwapi.ParentReference{{Name: "gateway", SectionName: sectionName("wildcard")}},
+			},
+			Hostnames: []gwapi.Hostname{"www.example.com", "www.example.org"},
+		},
+	}
+
+	// Gateways cannot be passed as GWObjects since the fake clientset guesses
+	// their resource incorrectly, so they are created instead.
+	builder := &testpkg.Builder{
+		T:                  t,
+		CertManagerObjects: []runtime.Object{crt},
+	}
+	builder.Init()
+	if _, err := builder.GWClient.GatewayV1alpha2().Gateways("testns").Create(context.Background(), gateway, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := builder.GWClient.GatewayV1alpha2().HTTPRoutes("testns").Create(context.Background(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	builder.DriftCheckInterval = time.Hour
+	builder.GatewaySolverEnabled = true
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	got, invalid, err := w.controller.endpointsForCertificate(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invalid) > 0 {
+		t.Errorf("unexpected invalid endpoints: %v", invalid)
+	}
+	exp := []endpoint{
+		{address: "named.example.com:8443", serverName: "named.example.com"},
+		{address: "www.example.com:443", serverName: "www.example.com"},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected endpoints, exp=%v got=%v", exp, got)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// httpsPort is the port used for the hosts of Ingresses.
+const httpsPort = 443
+
+// endpoint is an address that the certificate of a Certificate is expected to
+// be served on.
+type endpoint struct {
+	// address is the `<host>:<port>` to connect to.
+	address string
+	// serverName is the SNI server name sent when connecting, if any.
+	serverName string
+}
+
+// endpointsForCertificate returns the endpoints that the certificate of crt
+// is expected to be served on, sorted by address. These are the endpoints in
+// the drift check endpoints annotation, the hosts of Ingresses using the
+// Certificate's Secret, and the hostnames of Gateway listeners using the
+// Certificate's Secret. The values of the annotation which are not valid
+// endpoints are returned separately.
+func (c *controller) endpointsForCertificate(crt *cmapi.Certificate) ([]endpoint, []string, error) {
+	seen := make(map[endpoint]bool)
+	var endpoints []endpoint
+	add := func(host string, port int) {
+		ep := endpoint{
+			address:    net.JoinHostPort(host, strconv.Itoa(port)),
+			serverName: serverNameForHost(crt, host),
+		}
+		if !seen[ep] {
+			seen[ep] = true
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	var invalid []string
+	for _, value := range strings.Split(crt.Annotations[cmapi.DriftCheckEndpointsAnnotationKey], ",") {
+		value = strings.TrimSpace(value)
+		if len(value) == 0 {
+			continue
+		}
+		host, portStr, err := net.SplitHostPort(value)
+		port, portErr := strconv.Atoi(portStr)
+		if err != nil || portErr != nil || len(host) == 0 || port <= 0 || port > 65535 {
+			invalid = append(invalid, value)
+			continue
+		}
+		add(host, port)
+	}
+
+	ingresses, err := c.ingressLister.Ingresses(crt.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, ing := range ingresses {
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName != crt.Spec.SecretName {
+				continue
+			}
+			for _, host := range tls.Hosts {
+				if !isWildcard(host) {
+					add(host, httpsPort)
+				}
+			}
+		}
+	}
+
+	if c.gatewayLister != nil {
+		listenerHosts, err := c.gatewayListenerHosts(crt)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, lh := range listenerHosts {
+			add(lh.host, lh.port)
+		}
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].address != endpoints[j].address {
+			return endpoints[i].address < endpoints[j].address
+		}
+		return endpoints[i].serverName < endpoints[j].serverName
+	})
+	return endpoints, invalid, nil
+}
+
+type listenerHost struct {
+	host string
+	port int
+}
+
+// gatewayListenerHosts returns the hosts served by the Gateway listeners
+// which use the Secret of crt. Listeners without a hostname, or with a
+// wildcard hostname, serve the hostnames of the HTTPRoutes attached to them.
+func (c *controller) gatewayListenerHosts(crt *cmapi.Certificate) ([]listenerHost, error) {
+	gateways, err := c.gatewayLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var routes []*gwapi.HTTPRoute
+	var hosts []listenerHost
+	for _, gw := range gateways {
+		for _, l := range gw.Spec.Listeners {
+			if !listenerUsesSecret(gw, l, crt.Namespace, crt.Spec.SecretName) {
+				continue
+			}
+
+			port := int(l.Port)
+			if l.Hostname != nil && !isWildcard(string(*l.Hostname)) {
+				hosts = append(hosts, listenerHost{host: string(*l.Hostname), port: port})
+				continue
+			}
+
+			if routes == nil {
+				if routes, err = c.httpRouteLister.List(labels.Everything()); err != nil {
+					return nil, err
+				}
+			}
+			for _, route := range routes {
+				if !routeAttachedToListener(route, gw, l) {
+					continue
+				}
+				for _, hostname := range route.Spec.Hostnames {
+					host := string(hostname)
+					if isWildcard(host) {
+						continue
+					}
+					if l.Hostname != nil && !strings.HasSuffix(host, strings.TrimPrefix(string(*l.Hostname), "*")) {
+						continue
+					}
+					hosts = append(hosts, listenerHost{host: host, port: port})
+				}
+			}
+		}
+	}
+	return hosts, nil
+}
+
+// listenerUsesSecret returns true if the TLS configuration of the given
+// listener references the Secret with the given namespace and name.
+func listenerUsesSecret(gw *gwapi.Gateway, l gwapi.Listener, namespace, name string) bool {
+	if l.TLS == nil {
+		return false
+	}
+	for _, ref := range l.TLS.CertificateRefs {
+		if ref.Group != nil && *ref.Group != "" {
+			continue
+		}
+		if ref.Kind != nil && *ref.Kind != "Secret" {
+			continue
+		}
+		refNamespace := gw.Namespace
+		if ref.Namespace != nil {
+			refNamespace = string(*ref.Namespace)
+		}
+		if refNamespace == namespace && string(ref.Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// routeAttachedToListener returns true if the given HTTPRoute has a parent
+// reference to the given Gateway listener.
+func routeAttachedToListener(route *gwapi.HTTPRoute, gw *gwapi.Gateway, l gwapi.Listener) bool {
+	for _, ref := range route.Spec.ParentRefs {
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+		refNamespace := route.Namespace
+		if ref.Namespace != nil {
+			refNamespace = string(*ref.Namespace)
+		}
+		if refNamespace != gw.Namespace || string(ref.Name) != gw.Name {
+			continue
+		}
+		if ref.SectionName != nil && *ref.SectionName != l.Name {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// serverNameForHost returns the SNI server name used to connect to host. IP
+// addresses cannot be sent as server names, so the first non-wildcard DNS
+// name of the Certificate is used instead.
+func serverNameForHost(crt *cmapi.Certificate, host string) string {
+	if net.ParseIP(host) == nil {
+		return host
+	}
+	for _, dnsName := range crt.Spec.DNSNames {
+		if !isWildcard(dnsName) {
+			return dnsName
+		}
+	}
+	return ""
+}
+
+func isWildcard(host string) bool {
+	return strings.HasPrefix(host, "*")
+}
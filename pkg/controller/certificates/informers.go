@@ -17,12 +17,16 @@ limitations under the License.
 package certificates
 
 import (
+	"fmt"
+
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -67,3 +71,87 @@ func EnqueueCertificatesForResourceUsingPredicates(log logr.Logger, queue workqu
 		}
 	}
 }
+
+// EnqueueCertificatesForSecretUsingIndex will return a function that can be
+// used as an OnAdd handler for the Secret SharedIndexInformer.
+// Certificates are looked up in the given Certificate informer index, which
+// must be one of the indexes keyed by controllerpkg.NamespacedIndexKey, using
+// the namespace and name of the Secret being processed. The Certificates found
+// are filtered using the given predicates before being enqueued.
+// The index is added to the Certificate informer if it has not been already.
+// If this is not possible, all Certificates in the Secret's namespace are
+// listed and filtered using the index function instead.
+func EnqueueCertificatesForSecretUsingIndex(log logr.Logger, queue workqueue.Interface, certificateInformer cache.SharedIndexInformer, indexName string, predicateBuilders ...predicate.ExtractorFunc) func(obj interface{}) {
+	if err := controllerpkg.EnsureIndexers(certificateInformer, cache.Indexers{indexName: controllerpkg.CertificateIndexers[indexName]}); err != nil {
+		log.Error(err, "Failed to add Certificate informer index, falling back to listing Certificates", "index", indexName)
+	}
+	indexer := certificateInformer.GetIndexer()
+
+	return func(obj interface{}) {
+		s, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-Object type resource passed to EnqueueCertificatesForSecretUsingIndex")
+			return
+		}
+
+		// 'Construct' the predicate functions using the given Secret
+		predicates := make(predicate.Funcs, len(predicateBuilders))
+		for i, b := range predicateBuilders {
+			predicates[i] = b(s.(runtime.Object))
+		}
+
+		objs, err := certificatesByIndex(indexer, indexName, s.GetNamespace(), s.GetName())
+		if err != nil {
+			log.Error(err, "Failed listing Certificate resources")
+			return
+		}
+
+		for _, obj := range objs {
+			crt, ok := obj.(*cmapi.Certificate)
+			if !ok || !predicates.Evaluate(crt) {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "Error determining 'key' for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
+// certificatesByIndex returns the Certificates in the given index for the
+// resource with the given namespace and name. If the index has not been
+// registered, Certificates in the namespace are filtered using the index
+// function instead.
+func certificatesByIndex(indexer cache.Indexer, indexName, namespace, name string) ([]interface{}, error) {
+	key := controllerpkg.NamespacedIndexKey(namespace, name)
+	if _, ok := indexer.GetIndexers()[indexName]; ok {
+		return indexer.ByIndex(indexName, key)
+	}
+
+	indexFunc, ok := controllerpkg.CertificateIndexers[indexName]
+	if !ok {
+		return nil, fmt.Errorf("unknown Certificate index %q", indexName)
+	}
+
+	objs, err := indexer.ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var out []interface{}
+	for _, obj := range objs {
+		values, err := indexFunc(obj)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			if v == key {
+				out = append(out, obj)
+				break
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestEnqueueCertificatesForSecretUsingIndex(t *testing.T) {
+	crt1 := gen.Certificate("crt-1", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("secret"), gen.SetCertificateUID("uid-1"))
+	crt2 := gen.Certificate("crt-2", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("secret"), gen.SetCertificateUID("uid-2"))
+	crt3 := gen.Certificate("crt-3", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("other"))
+	crt4 := gen.Certificate("crt-4", gen.SetCertificateNamespace("other-ns"), gen.SetCertificateSecretName("secret"))
+
+	secret := gen.Secret("secret", gen.SetSecretNamespace("ns"))
+	ownedSecret := gen.Secret("secret", gen.SetSecretNamespace("ns"))
+	ownedSecret.OwnerReferences = []metav1.OwnerReference{gen.CertificateRef("crt-2", "uid-2")}
+
+	tests := map[string]struct {
+		obj        interface{}
+		predicates []predicate.ExtractorFunc
+		expected   []string
+	}{
+		"Certificates with the Secret as spec.secretName in the same namespace are enqueued": {
+			obj:      secret,
+			expected: []string{"ns/crt-1", "ns/crt-2"},
+		},
+		"predicates are applied to the indexed Certificates": {
+			obj:        ownedSecret,
+			predicates: []predicate.ExtractorFunc{predicate.ResourceOwnerOf},
+			expected:   []string{"ns/crt-2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &cmapi.Certificate{}, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			queue := workqueue.New()
+			defer queue.ShutDown()
+
+			// The index is added when the handler is constructed, which
+			// must happen before the informer is populated.
+			handler := EnqueueCertificatesForSecretUsingIndex(logtesting.NewTestLogger(t), queue, informer, controllerpkg.CertificateSecretNameIndex, test.predicates...)
+			assert.Contains(t, informer.GetIndexer().GetIndexers(), controllerpkg.CertificateSecretNameIndex)
+
+			for _, crt := range []*cmapi.Certificate{crt1, crt2, crt3, crt4} {
+				require.NoError(t, informer.GetIndexer().Add(crt))
+			}
+			handler(test.obj)
+
+			var got []string
+			for queue.Len() > 0 {
+				item, _ := queue.Get()
+				got = append(got, item.(string))
+				queue.Done(item)
+			}
+			assert.ElementsMatch(t, test.expected, got)
+		})
+	}
+}
+
+func TestCertificatesByIndexFallback(t *testing.T) {
+	crt1 := gen.Certificate("crt-1", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("secret"))
+	crt2 := gen.Certificate("crt-2", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("other"))
+
+	// The index has not been registered, so the namespace index is used.
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(crt1))
+	require.NoError(t, indexer.Add(crt2))
+
+	objs, err := certificatesByIndex(indexer, controllerpkg.CertificateSecretNameIndex, "ns", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{crt1}, objs)
+
+	_, err = certificatesByIndex(indexer, "unknown", "ns", "secret")
+	assert.Error(t, err)
+}
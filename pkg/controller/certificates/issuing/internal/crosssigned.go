@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// CrossSignedSecretData returns the data to write to the Secret of a
+// Certificate with the given cross-signed chain policy, given the chain from
+// the CA of the certificate to another root which the issuer returned.
+// The data is returned unchanged for the Primary policy, or if the issuer
+// didn't return a cross-signed chain.
+func CrossSignedSecretData(policy cmapi.CrossSignedChainPolicy, data SecretData, crossSignedChain []byte) (SecretData, error) {
+	if len(crossSignedChain) == 0 || policy == "" || policy == cmapi.WritePrimaryChain {
+		return data, nil
+	}
+
+	certs, err := utilpki.DecodeX509CertificateChainBytes(data.Certificate)
+	if err != nil {
+		return data, fmt.Errorf("failed to decode certificate chain: %w", err)
+	}
+	crossSignedCerts, err := utilpki.DecodeX509CertificateChainBytes(crossSignedChain)
+	if err != nil {
+		return data, fmt.Errorf("failed to decode cross-signed chain: %w", err)
+	}
+	// The chain of the certificate ends below the root of the CA, so the
+	// cross-signed certificates take the place of that root.
+	bundle, err := utilpki.ParseSingleCertificateChain(append(certs, crossSignedCerts...))
+	if err != nil {
+		return data, fmt.Errorf("failed to build cross-signed chain: %w", err)
+	}
+
+	crossSigned := SecretData{
+		PrivateKey:  data.PrivateKey,
+		Certificate: bundle.ChainPEM,
+		CA:          bundle.CAPEM,
+	}
+	if policy == cmapi.WriteBothChains && len(data.CA) > 0 {
+		crossSigned.CA = append(append([]byte{}, data.CA...), bundle.CAPEM...)
+	}
+
+	return crossSigned, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func mustSignCrossSignedTestCert(t *testing.T, cn string, isCA bool, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, []byte) {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		PublicKey:             key.Public(),
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	pemBytes, cert, err := utilpki.SignCertificate(template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pemBytes
+}
+
+func mustGenerateCrossSignedTestKey(t *testing.T) crypto.Signer {
+	key, err := utilpki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestCrossSignedSecretData(t *testing.T) {
+	oldRootKey := mustGenerateCrossSignedTestKey(t)
+	newRootKey := mustGenerateCrossSignedTestKey(t)
+	intermediateKey := mustGenerateCrossSignedTestKey(t)
+	leafKey := mustGenerateCrossSignedTestKey(t)
+
+	oldRoot, oldRootPEM := mustSignCrossSignedTestCert(t, "old-root", true, oldRootKey, nil, nil)
+	newRoot, newRootPEM := mustSignCrossSignedTestCert(t, "new-root", true, newRootKey, nil, nil)
+	_, crossSignedPEM := mustSignCrossSignedTestCert(t, "new-root", true, newRootKey, oldRoot, oldRootKey)
+	intermediate, intermediatePEM := mustSignCrossSignedTestCert(t, "intermediate", true, intermediateKey, newRoot, newRootKey)
+	_, leafPEM := mustSignCrossSignedTestCert(t, "leaf", false, leafKey, intermediate, intermediateKey)
+
+	data := SecretData{
+		PrivateKey:  []byte("key"),
+		Certificate: append(append([]byte{}, leafPEM...), intermediatePEM...),
+		CA:          newRootPEM,
+	}
+	crossSignedChain := append(append([]byte{}, crossSignedPEM...), oldRootPEM...)
+	crossSignedCertificate := append(append([]byte{}, data.Certificate...), crossSignedPEM...)
+
+	tests := map[string]struct {
+		policy           cmapi.CrossSignedChainPolicy
+		crossSignedChain []byte
+		expData          SecretData
+	}{
+		"no cross-signed chain returned by the issuer": {
+			policy:  cmapi.WriteBothChains,
+			expData: data,
+		},
+		"default policy writes the primary chain": {
+			crossSignedChain: crossSignedChain,
+			expData:          data,
+		},
+		"Primary policy writes the primary chain": {
+			policy:           cmapi.WritePrimaryChain,
+			crossSignedChain: crossSignedChain,
+			expData:          data,
+		},
+		"CrossSigned policy writes the chain to the old root": {
+			policy:           cmapi.WriteCrossSignedChain,
+			crossSignedChain: crossSignedChain,
+			expData: SecretData{
+				PrivateKey:  data.PrivateKey,
+				Certificate: crossSignedCertificate,
+				CA:          oldRootPEM,
+			},
+		},
+		"Both policy writes the chain to the old root and both roots": {
+			policy:           cmapi.WriteBothChains,
+			crossSignedChain: crossSignedChain,
+			expData: SecretData{
+				PrivateKey:  data.PrivateKey,
+				Certificate: crossSignedCertificate,
+				CA:          append(append([]byte{}, newRootPEM...), oldRootPEM...),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := CrossSignedSecretData(test.policy, data, test.crossSignedChain)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got.PrivateKey, test.expData.PrivateKey) {
+				t.Errorf("unexpected private key %q", got.PrivateKey)
+			}
+			if !bytes.Equal(got.Certificate, test.expData.Certificate) {
+				t.Errorf("unexpected certificate chain:\n%s", got.Certificate)
+			}
+			if !bytes.Equal(got.CA, test.expData.CA) {
+				t.Errorf("unexpected CA:\n%s", got.CA)
+			}
+		})
+	}
+
+	t.Run("unrelated cross-signed chain", func(t *testing.T) {
+		if _, err := CrossSignedSecretData(cmapi.WriteCrossSignedChain, data, oldRootPEM); err == nil {
+			t.Error("expected an error for a cross-signed chain which doesn't chain up from the certificate")
+		}
+	})
+}
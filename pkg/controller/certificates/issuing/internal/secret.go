@@ -17,9 +17,12 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
+	"errors"
 	"fmt"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -47,6 +50,12 @@ type SecretsManager struct {
 	secretClient coreclient.SecretsGetter
 	secretLister corelisters.SecretLister
 
+	// configMapClient is used to read the ConfigMaps referenced by the
+	// additionalCABundle of Certificates. ConfigMaps are read from the
+	// apiserver rather than an informer, as they are only needed when the
+	// Secret is written.
+	configMapClient coreclient.ConfigMapsGetter
+
 	// fieldManager is the manager name used for the Apply operations on Secrets.
 	fieldManager string
 
@@ -57,6 +66,45 @@ type SecretsManager struct {
 	enableSecretOwnerReferences bool
 }
 
+// SecretOverwriteError is returned by UpdateData if the target Secret already
+// contains a certificate which is not managed for the Certificate, and the
+// Certificate doesn't allow it to be overwritten.
+type SecretOverwriteError struct {
+	Namespace, Name string
+}
+
+func (e *SecretOverwriteError) Error() string {
+	return fmt.Sprintf("refusing to overwrite the certificate in Secret %s/%s which is not managed by this Certificate, "+
+		"set the %q annotation on the Certificate to %q to allow it to be overwritten",
+		e.Namespace, e.Name, cmapi.AllowSecretOverwriteAnnotationKey, "true")
+}
+
+// SecretVerificationError is returned by UpdateData if the Secret read back
+// after writing it doesn't hold the data which was written, or the data is not
+// a valid private key and certificate chain. This may be caused by a partial
+// write, or by an admission webhook mutating the Secret.
+type SecretVerificationError struct {
+	Namespace, Name string
+
+	// Err describes why the verification failed.
+	Err error
+
+	// RolledBack is true if the previous data of the Secret was restored.
+	RolledBack bool
+}
+
+func (e *SecretVerificationError) Error() string {
+	msg := fmt.Sprintf("verification of Secret %s/%s failed after writing it: %v", e.Namespace, e.Name, e.Err)
+	if e.RolledBack {
+		return msg + ", the previous certificate has been restored"
+	}
+	return msg + ", no previous certificate could be restored"
+}
+
+func (e *SecretVerificationError) Unwrap() error {
+	return e.Err
+}
+
 // SecretData is a structure wrapping private key, Certificate and CA data
 type SecretData struct {
 	PrivateKey, Certificate, CA []byte
@@ -68,12 +116,14 @@ type SecretData struct {
 func NewSecretsManager(
 	secretClient coreclient.SecretsGetter,
 	secretLister corelisters.SecretLister,
+	configMapClient coreclient.ConfigMapsGetter,
 	fieldManager string,
 	enableSecretOwnerReferences bool,
 ) *SecretsManager {
 	return &SecretsManager{
 		secretClient:                secretClient,
 		secretLister:                secretLister,
+		configMapClient:             configMapClient,
 		fieldManager:                fieldManager,
 		enableSecretOwnerReferences: enableSecretOwnerReferences,
 	}
@@ -83,7 +133,26 @@ func NewSecretsManager(
 // well as appropriate metadata using an Apply call.
 // If the Secret resource does not exist, it will be created on Apply.
 // UpdateData will also update deprecated annotations if they exist.
+// Once applied, the Secret is read back and verified. If it doesn't hold the
+// given data, or the data is not a matching private key and certificate chain,
+// the previous data of the Secret is restored and a SecretVerificationError is
+// returned.
 func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate, data SecretData) error {
+	if err := s.checkOverwrite(crt, data); err != nil {
+		return err
+	}
+
+	if crt.Spec.AdditionalCABundle != nil {
+		additional, err := certificates.AdditionalCABundle(ctx, s.configMapClient, s.secretLister, crt)
+		if err != nil {
+			return err
+		}
+		data.CA, err = certificates.AppendCABundle(data.CA, additional)
+		if err != nil {
+			return fmt.Errorf("invalid additional CA bundle: %w", err)
+		}
+	}
+
 	secret, err := s.getCertificateSecret(ctx, crt)
 	if err != nil {
 		return err
@@ -92,10 +161,44 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 	log := logf.FromContext(ctx).WithName("secrets_manager")
 	log = logf.WithResource(log, secret)
 
+	// Keep the current data of the Secret, so that it can be restored if the
+	// new data doesn't verify.
+	previous, previousRevision, err := s.restorableData(crt)
+	if err != nil {
+		return err
+	}
+
 	if err := s.setValues(crt, secret, data); err != nil {
 		return err
 	}
 
+	log.V(logf.DebugLevel).Info("applying secret")
+
+	if err := s.apply(ctx, crt, secret); err != nil {
+		return err
+	}
+
+	written, err := s.secretClient.Secrets(secret.Namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read back secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	verifyErr := verifySecretData(written, data)
+	if verifyErr == nil {
+		return nil
+	}
+
+	log.Error(verifyErr, "secret failed verification after being written")
+	if previous == nil {
+		return &SecretVerificationError{Namespace: secret.Namespace, Name: secret.Name, Err: verifyErr}
+	}
+	if err := s.restore(ctx, crt, *previous, previousRevision); err != nil {
+		return fmt.Errorf("failed to restore secret %s/%s after it failed verification (%v): %w", secret.Namespace, secret.Name, verifyErr, err)
+	}
+	return &SecretVerificationError{Namespace: secret.Namespace, Name: secret.Name, Err: verifyErr, RolledBack: true}
+}
+
+// apply applies the annotations, labels, data and type of the given Secret.
+func (s *SecretsManager) apply(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret) error {
 	// Build Secret apply configuration and options.
 	applyOpts := metav1.ApplyOptions{FieldManager: s.fieldManager, Force: true}
 	applyCnf := applycorev1.Secret(secret.Name, secret.Namespace).
@@ -114,16 +217,133 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 		})
 	}
 
-	log.V(logf.DebugLevel).Info("applying secret")
+	if _, err := s.secretClient.Secrets(secret.Namespace).Apply(ctx, applyCnf, applyOpts); err != nil {
+		return fmt.Errorf("failed to apply secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
 
-	_, err = s.secretClient.Secrets(secret.Namespace).Apply(ctx, applyCnf, applyOpts)
+	return nil
+}
+
+// restorableData returns the data of the Secret of the Certificate as it
+// currently is in the informer cache, and the revision it was issued for, if
+// it holds a private key and certificate chain which pass verification.
+// Otherwise nil is returned, as there is nothing worth restoring.
+func (s *SecretsManager) restorableData(crt *cmapi.Certificate) (*SecretData, *int, error) {
+	existingSecret, err := s.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to apply secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		return nil, nil, err
+	}
+
+	data := SecretData{
+		PrivateKey:  existingSecret.Data[corev1.TLSPrivateKeyKey],
+		Certificate: existingSecret.Data[corev1.TLSCertKey],
+		CA:          existingSecret.Data[cmmeta.TLSCAKey],
+	}
+	if len(data.PrivateKey) == 0 || len(data.Certificate) == 0 || verifySecretData(existingSecret, data) != nil {
+		return nil, nil, nil
+	}
+
+	var revision *int
+	if r, err := strconv.Atoi(existingSecret.Labels[cmapi.CertificateRevisionLabelKey]); err == nil {
+		revision = &r
+	}
+	return &data, revision, nil
+}
+
+// restore writes the given data of a previous revision back to the Secret of
+// the Certificate.
+func (s *SecretsManager) restore(ctx context.Context, crt *cmapi.Certificate, data SecretData, revision *int) error {
+	crt = crt.DeepCopy()
+	crt.Status.Revision = revision
+
+	secret, err := s.getCertificateSecret(ctx, crt)
+	if err != nil {
+		return err
+	}
+	if err := s.setValues(crt, secret, data); err != nil {
+		return err
+	}
+	return s.apply(ctx, crt, secret)
+}
+
+// verifySecretData returns an error if the Secret doesn't hold the given data,
+// or if the private key doesn't match the certificate or the certificate chain
+// is not valid.
+func verifySecretData(secret *corev1.Secret, data SecretData) error {
+	if !bytes.Equal(secret.Data[corev1.TLSPrivateKeyKey], data.PrivateKey) {
+		return fmt.Errorf("%s does not hold the written private key", corev1.TLSPrivateKeyKey)
+	}
+	if !bytes.Equal(secret.Data[corev1.TLSCertKey], data.Certificate) {
+		return fmt.Errorf("%s does not hold the written certificate", corev1.TLSCertKey)
+	}
+	if len(data.CA) > 0 && !bytes.Equal(secret.Data[cmmeta.TLSCAKey], data.CA) {
+		return fmt.Errorf("%s does not hold the written CA", cmmeta.TLSCAKey)
+	}
+
+	// Secrets without a private key or certificate are not issued, so there is
+	// no pair to check.
+	if len(data.PrivateKey) == 0 || len(data.Certificate) == 0 {
+		return nil
+	}
+
+	pk, err := utilpki.DecodePrivateKeyBytes(data.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode private key: %w", err)
+	}
+	certs, err := utilpki.DecodeX509CertificateChainBytes(data.Certificate)
+	if err != nil {
+		return fmt.Errorf("failed to decode certificate chain: %w", err)
+	}
+	matches, err := utilpki.PublicKeyMatchesCertificate(pk.Public(), certs[0])
+	if err != nil {
+		return fmt.Errorf("failed to compare private key with certificate: %w", err)
+	}
+	if !matches {
+		return errors.New("private key does not match certificate")
+	}
+	if _, err := utilpki.ParseSingleCertificateChain(certs); err != nil {
+		return fmt.Errorf("invalid certificate chain: %w", err)
 	}
 
 	return nil
 }
 
+// checkOverwrite returns a SecretOverwriteError if writing data to the Secret
+// of the Certificate would replace a certificate which was not written for
+// the Certificate, for example if the Secret was created by hand or by another
+// tool before being referenced by the Certificate.
+// A Secret is managed by cert-manager if it has the certificate-name or
+// issuer-name annotation, which cert-manager sets on every Secret it writes.
+func (s *SecretsManager) checkOverwrite(crt *cmapi.Certificate, data SecretData) error {
+	if crt.Annotations[cmapi.AllowSecretOverwriteAnnotationKey] == "true" {
+		return nil
+	}
+
+	existingSecret, err := s.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, ok := existingSecret.Annotations[cmapi.CertificateNameKey]; ok {
+		return nil
+	}
+	if _, ok := existingSecret.Annotations[cmapi.IssuerNameAnnotationKey]; ok {
+		return nil
+	}
+	existingCert := existingSecret.Data[corev1.TLSCertKey]
+	if len(existingCert) == 0 || bytes.Equal(existingCert, data.Certificate) {
+		return nil
+	}
+
+	return &SecretOverwriteError{Namespace: existingSecret.Namespace, Name: existingSecret.Name}
+}
+
 // setValues will update the Secret resource 'secret' with the data contained
 // in the given secretData.
 // It will update labels and annotations on the Secret resource appropriately.
@@ -144,6 +364,18 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 		}
 	}
 
+	// Add the client bundle to the Secrets of client certificates.
+	if crt.Spec.ClientIdentity != nil {
+		secret.Data[cmapi.CertificateClientBundleKey] = certificates.OutputFormatCombinedPEM(data.PrivateKey, data.Certificate)
+	}
+
+	// Add the PKCS#12 bundle to the Secrets of S/MIME certificates.
+	if crt.Spec.SMIME != nil {
+		if err := s.setSMIMEBundle(crt, secret, data); err != nil {
+			return fmt.Errorf("failed to add S/MIME bundle to Secret: %w", err)
+		}
+	}
+
 	secret.Data[corev1.TLSPrivateKeyKey] = data.PrivateKey
 	secret.Data[corev1.TLSCertKey] = data.Certificate
 	if len(data.CA) > 0 {
@@ -164,6 +396,9 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 	if secret.Labels == nil {
 		secret.Labels = make(map[string]string)
 	}
+	for k, v := range certificates.LabelsForCertificate(crt, crt.Status.Revision) {
+		secret.Labels[k] = v
+	}
 
 	if crt.Spec.SecretTemplate != nil {
 		for k, v := range crt.Spec.SecretTemplate.Labels {
@@ -278,6 +513,26 @@ func (s *SecretsManager) setKeystores(crt *cmapi.Certificate, secret *corev1.Sec
 	return nil
 }
 
+// setSMIMEBundle will set the PKCS#12 bundle of an S/MIME certificate,
+// encrypted using the password in the referenced Secret.
+func (s *SecretsManager) setSMIMEBundle(crt *cmapi.Certificate, secret *corev1.Secret, data SecretData) error {
+	ref := crt.Spec.SMIME.PasswordSecretRef
+	pwSecret, err := s.secretLister.Secrets(crt.Namespace).Get(ref.Name)
+	if err != nil {
+		return fmt.Errorf("fetching S/MIME bundle password from Secret: %v", err)
+	}
+	if pwSecret.Data == nil || len(pwSecret.Data[ref.Key]) == 0 {
+		return fmt.Errorf("S/MIME bundle password Secret contains no data for key %q", ref.Key)
+	}
+	bundleData, err := encodePKCS12Keystore(string(pwSecret.Data[ref.Key]), data.PrivateKey, data.Certificate, data.CA)
+	if err != nil {
+		return fmt.Errorf("error encoding S/MIME bundle: %w", err)
+	}
+	// always overwrite the bundle, as it is encrypted with a random salt
+	secret.Data[cmapi.CertificateSMIMEBundleKey] = bundleData
+	return nil
+}
+
 // setAdditionalOutputFormat will set extra Secret Data keys with additional
 // output formats according to any OutputFormats which have been configured.
 func setAdditionalOutputFormats(crt *cmapi.Certificate, secret *corev1.Secret, data SecretData) error {
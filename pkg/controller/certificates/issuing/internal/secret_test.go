@@ -34,9 +34,11 @@ import (
 	apitypes "k8s.io/apimachinery/pkg/types"
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
+	"software.sslmate.com/src/go-pkcs12"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -68,6 +70,11 @@ func Test_SecretsManager(t *testing.T) {
 		gen.SetCertificateDNSNames("example.com"),
 		gen.SetCertificateUID(apitypes.UID("test-uid")),
 	)
+	baseLabels := map[string]string{
+		cmapi.CertificateNameLabelKey: "test",
+		cmapi.IssuerNameLabelKey:      "ca-issuer",
+		cmapi.IssuerKindLabelKey:      "Issuer",
+	}
 	baseCertBundle := testcrypto.MustCreateCryptoBundle(t, gen.CertificateFrom(baseCert,
 		gen.SetCertificateDNSNames("example.com"),
 	), fixedClock)
@@ -93,6 +100,9 @@ func Test_SecretsManager(t *testing.T) {
 			cmapi.CertificateAdditionalOutputFormat{Type: "CombinedPEM"},
 		),
 	)
+	baseCertWithClientIdentity := gen.CertificateFrom(baseCertBundle.Certificate,
+		gen.SetCertificateClientIdentity(cmapi.CertificateClientIdentity{ServiceAccountName: "client"}),
+	)
 	block, _ := pem.Decode(baseCertBundle.PrivateKeyBytes)
 	tlsDerContent := block.Bytes
 
@@ -124,7 +134,7 @@ func Test_SecretsManager(t *testing.T) {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
 			certificate:        baseCertBundle.Certificate,
 			existingSecret:     nil,
-			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
 			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
 				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
 					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
@@ -134,13 +144,14 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
 								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName, cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:  strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(baseLabels).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
-							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							corev1.TLSPrivateKeyKey: baseCertBundle.PrivateKeyBytes,
 							cmmeta.TLSCAKey:         []byte("test-ca"),
 						}).
 						WithType(corev1.SecretTypeTLS)
@@ -159,7 +170,7 @@ func Test_SecretsManager(t *testing.T) {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
 			certificate:        baseCertBundle.Certificate,
 			existingSecret:     nil,
-			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
 			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
 				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
 					expUID := apitypes.UID("test-uid")
@@ -170,10 +181,11 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io", cmapi.IssuerKindAnnotationKey: "Issuer",
 								cmapi.IssuerNameAnnotationKey: "ca-issuer", cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
 								cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","), cmapi.IPSANAnnotationKey: strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
-						WithData(map[string][]byte{corev1.TLSCertKey: baseCertBundle.CertBytes, corev1.TLSPrivateKeyKey: []byte("test-key"), cmmeta.TLSCAKey: []byte("test-ca")}).
+						WithLabels(baseLabels).
+						WithData(map[string][]byte{corev1.TLSCertKey: baseCertBundle.CertBytes, corev1.TLSPrivateKeyKey: baseCertBundle.PrivateKeyBytes, cmmeta.TLSCAKey: []byte("test-ca")}).
 						WithType(corev1.SecretTypeTLS).
 						WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
 							APIVersion: pointer.String("cert-manager.io/v1"), Kind: pointer.String("Certificate"),
@@ -198,13 +210,13 @@ func Test_SecretsManager(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace:   gen.DefaultTestNamespace,
 					Name:        "output",
-					Annotations: map[string]string{"my-custom": "annotation"},
+					Annotations: map[string]string{cmapi.CertificateNameKey: "test", "my-custom": "annotation"},
 					Labels:      map[string]string{},
 				},
 				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
 				Type: corev1.SecretTypeTLS,
 			},
-			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
 			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
 				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
 					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
@@ -213,15 +225,16 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(baseLabels).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
-							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							corev1.TLSPrivateKeyKey: baseCertBundle.PrivateKeyBytes,
 							cmmeta.TLSCAKey:         []byte("test-ca"),
 						}).
 						WithType(corev1.SecretTypeTLS)
@@ -242,13 +255,13 @@ func Test_SecretsManager(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace:   gen.DefaultTestNamespace,
 					Name:        "output",
-					Annotations: map[string]string{"my-custom": "annotation"},
+					Annotations: map[string]string{cmapi.CertificateNameKey: "test", "my-custom": "annotation"},
 					Labels:      map[string]string{},
 				},
 				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
 				Type: corev1.SecretTypeTLS,
 			},
-			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
 			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
 				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
 					expUID := apitypes.UID("test-uid")
@@ -258,15 +271,16 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(baseLabels).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
-							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							corev1.TLSPrivateKeyKey: baseCertBundle.PrivateKeyBytes,
 							cmmeta.TLSCAKey:         []byte("test-ca"),
 						}).
 						WithType(corev1.SecretTypeTLS).
@@ -293,13 +307,13 @@ func Test_SecretsManager(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace:   gen.DefaultTestNamespace,
 					Name:        "output",
-					Annotations: map[string]string{"my-custom": "annotation"},
+					Annotations: map[string]string{cmapi.CertificateNameKey: "test", "my-custom": "annotation"},
 					Labels:      map[string]string{},
 				},
 				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
 				Type: corev1.SecretTypeTLS,
 			},
-			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
 			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
 				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
 					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
@@ -310,15 +324,16 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(map[string]string{"template": "label"}).
+						WithLabels(baseLabels).WithLabels(map[string]string{"template": "label"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
-							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							corev1.TLSPrivateKeyKey: baseCertBundle.PrivateKeyBytes,
 							cmmeta.TLSCAKey:         []byte("test-ca"),
 						}).
 						WithType(corev1.SecretTypeTLS)
@@ -337,7 +352,7 @@ func Test_SecretsManager(t *testing.T) {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
 			certificate:        baseCertWithSecretTemplate,
 			existingSecret:     nil,
-			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
 			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
 				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
 					expUID := apitypes.UID("test-uid")
@@ -349,15 +364,16 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(map[string]string{"template": "label"}).
+						WithLabels(baseLabels).WithLabels(map[string]string{"template": "label"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
-							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							corev1.TLSPrivateKeyKey: baseCertBundle.PrivateKeyBytes,
 							cmmeta.TLSCAKey:         []byte("test-ca"),
 						}).
 						WithType(corev1.SecretTypeTLS).
@@ -390,12 +406,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(baseLabels).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                   baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:             baseCertBundle.PrivateKeyBytes,
@@ -427,12 +444,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(baseLabels).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                           baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:                     baseCertBundle.PrivateKeyBytes,
@@ -451,6 +469,44 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if secret does not exist, create new Secret with client bundle for Certificate with client identity": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertWithClientIdentity,
+			existingSecret:     nil,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
+								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
+							}).
+						WithLabels(baseLabels).
+						WithData(map[string][]byte{
+							corev1.TLSCertKey:                baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey:          baseCertBundle.PrivateKeyBytes,
+							cmmeta.TLSCAKey:                  []byte("test-ca"),
+							cmapi.CertificateClientBundleKey: []byte(strings.Join([]string{string(baseCertBundle.PrivateKeyBytes), string(baseCertBundle.CertBytes)}, "\n")),
+						}).
+						WithType(corev1.SecretTypeTLS)
+					assert.Equal(t, expCnf, gotCnf)
+
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					assert.Equal(t, expOpts, gotOpts)
+
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
 		"if secret does not exist, create new Secret with additional output format DER and CombinedPEM": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
 			certificate:        baseCertWithAdditionalOutputFormats,
@@ -464,12 +520,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(baseLabels).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                           baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:                     baseCertBundle.PrivateKeyBytes,
@@ -498,7 +555,8 @@ func Test_SecretsManager(t *testing.T) {
 					Namespace: gen.DefaultTestNamespace,
 					Name:      "output",
 					Annotations: map[string]string{
-						"my-custom": "annotation",
+						cmapi.CertificateNameKey: "test",
+						"my-custom":              "annotation",
 					},
 				},
 				Data: map[string][]byte{
@@ -519,12 +577,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(baseLabels).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey: baseCertBundle.PrivateKeyBytes,
@@ -551,7 +610,8 @@ func Test_SecretsManager(t *testing.T) {
 					Namespace: gen.DefaultTestNamespace,
 					Name:      "output",
 					Annotations: map[string]string{
-						"my-custom": "annotation",
+						cmapi.CertificateNameKey: "test",
+						"my-custom":              "annotation",
 					},
 				},
 				Data: map[string][]byte{
@@ -572,12 +632,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(baseLabels).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                   baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:             baseCertBundle.PrivateKeyBytes,
@@ -605,7 +666,8 @@ func Test_SecretsManager(t *testing.T) {
 					Namespace: gen.DefaultTestNamespace,
 					Name:      "output",
 					Annotations: map[string]string{
-						"my-custom": "annotation",
+						cmapi.CertificateNameKey: "test",
+						"my-custom":              "annotation",
 					},
 				},
 				Data: map[string][]byte{
@@ -626,12 +688,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertBundle.Cert.SerialNumber.Text(16),
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(baseLabels).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                           baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:                     baseCertBundle.PrivateKeyBytes,
@@ -649,11 +712,105 @@ func Test_SecretsManager(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if secret exists with a different certificate not managed by the Certificate, refuse to overwrite it": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "output"},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo")},
+				Type:       corev1.SecretTypeTLS,
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					t.Errorf("unexpected apply of Secret")
+					return nil, nil
+				}
+			},
+			expectedErr: true,
+		},
+		"if secret exists with a different certificate not managed by the Certificate, overwrite it if the Certificate allows it": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate: gen.CertificateFrom(baseCertBundle.Certificate,
+				gen.AddCertificateAnnotations(map[string]string{cmapi.AllowSecretOverwriteAnnotationKey: "true"}),
+			),
+			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "output"},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo")},
+				Type:       corev1.SecretTypeTLS,
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, baseCertBundle.CertBytes, gotCnf.Data[corev1.TLSCertKey])
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+		"if secret exists with a different certificate and the certificate-name annotation, treat it as managed and overwrite it": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   gen.DefaultTestNamespace,
+					Name:        "output",
+					Annotations: map[string]string{cmapi.CertificateNameKey: "another-certificate"},
+				},
+				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo")},
+				Type: corev1.SecretTypeTLS,
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, baseCertBundle.CertBytes, gotCnf.Data[corev1.TLSCertKey])
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+		"if secret exists with a different certificate and the issuer-name annotation, treat it as managed and overwrite it": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   gen.DefaultTestNamespace,
+					Name:        "output",
+					Annotations: map[string]string{cmapi.IssuerNameAnnotationKey: "ca-issuer"},
+				},
+				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo")},
+				Type: corev1.SecretTypeTLS,
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, baseCertBundle.CertBytes, gotCnf.Data[corev1.TLSCertKey])
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+		"if secret exists with the same certificate not managed by the Certificate, adopt it": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "output"},
+				Data:       map[string][]byte{corev1.TLSCertKey: baseCertBundle.CertBytes, corev1.TLSPrivateKeyKey: []byte("test-key")},
+				Type:       corev1.SecretTypeTLS,
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
 		"if apply errors, expect error response": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
 			certificate:        baseCertWithSecretTemplate,
 			existingSecret:     nil,
-			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
 			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
 				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
 					return nil, errors.New("this is an error")
@@ -667,7 +824,19 @@ func Test_SecretsManager(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			secretClient := testcoreclients.NewFakeSecretsGetter(testcoreclients.SetFakeSecretsGetterApplyFn(test.applyFn(t)))
+			// The Secret is read back after being applied, so serve the
+			// applied data.
+			applyFn := test.applyFn(t)
+			var applied *corev1.Secret
+			secretClient := testcoreclients.NewFakeSecretsGetter(
+				testcoreclients.SetFakeSecretsGetterApplyFn(func(ctx context.Context, cnf *applycorev1.SecretApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Secret, error) {
+					applied = &corev1.Secret{Data: cnf.Data}
+					return applyFn(ctx, cnf, opts)
+				}),
+				testcoreclients.SetFakeSecretsGetterGetFn(func() (*corev1.Secret, error) {
+					return applied, nil
+				}),
+			)
 
 			var mod testcorelisters.FakeSecretListerModifier
 			if test.existingSecret != nil {
@@ -678,7 +847,7 @@ func Test_SecretsManager(t *testing.T) {
 			secretLister := testcorelisters.NewFakeSecretLister(mod)
 
 			testManager := NewSecretsManager(
-				secretClient, secretLister,
+				secretClient, secretLister, nil,
 				"cert-manager-test",
 				test.certificateOptions.EnableOwnerRef,
 			)
@@ -694,6 +863,227 @@ func Test_SecretsManager(t *testing.T) {
 	}
 }
 
+func Test_SecretsManager_verification(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(2),
+	)
+	previousBundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	nextBundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+
+	previousSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   gen.DefaultTestNamespace,
+			Name:        "output",
+			Annotations: map[string]string{cmapi.CertificateNameKey: "test"},
+			Labels:      map[string]string{cmapi.CertificateRevisionLabelKey: "1"},
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       previousBundle.CertBytes,
+			corev1.TLSPrivateKeyKey: previousBundle.PrivateKeyBytes,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+	nextData := SecretData{Certificate: nextBundle.CertBytes, PrivateKey: nextBundle.PrivateKeyBytes}
+
+	tests := map[string]struct {
+		existingSecret *corev1.Secret
+		secretData     SecretData
+		// mutate changes the data of the Secret as it is read back.
+		mutate func(data map[string][]byte)
+
+		expErr        bool
+		expRolledBack bool
+	}{
+		"if the Secret holds the written data, should not error": {
+			existingSecret: previousSecret,
+			secretData:     nextData,
+		},
+		"if the Secret was mutated when written, should restore the previous data": {
+			existingSecret: previousSecret,
+			secretData:     nextData,
+			mutate: func(data map[string][]byte) {
+				data[corev1.TLSCertKey] = previousBundle.CertBytes
+			},
+			expErr:        true,
+			expRolledBack: true,
+		},
+		"if the written private key doesn't match the certificate, should restore the previous data": {
+			existingSecret: previousSecret,
+			secretData:     SecretData{Certificate: nextBundle.CertBytes, PrivateKey: previousBundle.PrivateKeyBytes},
+			expErr:         true,
+			expRolledBack:  true,
+		},
+		"if the Secret was partially written and there is no previous data, should error without restoring": {
+			secretData: nextData,
+			mutate: func(data map[string][]byte) {
+				delete(data, corev1.TLSPrivateKeyKey)
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var applied []map[string][]byte
+			secretClient := testcoreclients.NewFakeSecretsGetter(
+				testcoreclients.SetFakeSecretsGetterApplyFn(func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+					applied = append(applied, cnf.Data)
+					return nil, nil
+				}),
+				testcoreclients.SetFakeSecretsGetterGetFn(func() (*corev1.Secret, error) {
+					data := make(map[string][]byte)
+					for k, v := range applied[len(applied)-1] {
+						data[k] = v
+					}
+					if test.mutate != nil {
+						test.mutate(data)
+					}
+					return &corev1.Secret{Data: data}, nil
+				}),
+			)
+
+			mod := testcorelisters.SetFakeSecretNamespaceListerGet(test.existingSecret, nil)
+			if test.existingSecret == nil {
+				mod = testcorelisters.SetFakeSecretNamespaceListerGet(nil, apierrors.NewNotFound(corev1.Resource("secret"), "not found"))
+			}
+			testManager := NewSecretsManager(secretClient, testcorelisters.NewFakeSecretLister(mod), nil, "cert-manager-test", false)
+
+			err := testManager.UpdateData(context.Background(), crt, test.secretData)
+			if !test.expErr {
+				assert.NoError(t, err)
+				assert.Len(t, applied, 1)
+				return
+			}
+
+			var verifyErr *SecretVerificationError
+			if !assert.ErrorAs(t, err, &verifyErr) {
+				return
+			}
+			assert.Equal(t, test.expRolledBack, verifyErr.RolledBack)
+			if !test.expRolledBack {
+				assert.Len(t, applied, 1)
+				return
+			}
+			if assert.Len(t, applied, 2) {
+				assert.Equal(t, previousBundle.CertBytes, applied[1][corev1.TLSCertKey])
+				assert.Equal(t, previousBundle.PrivateKeyBytes, applied[1][corev1.TLSPrivateKeyKey])
+			}
+		})
+	}
+}
+
+func Test_SecretsManager_additionalCABundle(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	crt.Spec.AdditionalCABundle = &cmapi.CertificateAdditionalCABundle{
+		ConfigMap: &cmapi.CABundleKeySelector{Name: "roots", Key: "roots.pem"},
+	}
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	issuingCA := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock).CertBytes
+	root := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock).CertBytes
+
+	configMapClient := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "roots"},
+		Data:       map[string]string{"roots.pem": string(root)},
+	}).CoreV1()
+
+	var applied map[string][]byte
+	secretClient := testcoreclients.NewFakeSecretsGetter(
+		testcoreclients.SetFakeSecretsGetterApplyFn(func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+			applied = cnf.Data
+			return nil, nil
+		}),
+		testcoreclients.SetFakeSecretsGetterGetFn(func() (*corev1.Secret, error) {
+			return &corev1.Secret{Data: applied}, nil
+		}),
+	)
+	secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretNamespaceListerGet(nil, apierrors.NewNotFound(corev1.Resource("secret"), "not found")))
+	testManager := NewSecretsManager(secretClient, secretLister, configMapClient, "cert-manager-test", false)
+
+	data := SecretData{Certificate: bundle.CertBytes, PrivateKey: bundle.PrivateKeyBytes, CA: issuingCA}
+	assert.NoError(t, testManager.UpdateData(context.Background(), crt, data))
+	expCA := append(append([]byte(nil), issuingCA...), root...)
+	assert.Equal(t, string(expCA), string(applied[cmmeta.TLSCAKey]))
+
+	// Writing the data of the Secret back must not duplicate the additional
+	// certificates.
+	data.CA = applied[cmmeta.TLSCAKey]
+	assert.NoError(t, testManager.UpdateData(context.Background(), crt, data))
+	assert.Equal(t, string(expCA), string(applied[cmmeta.TLSCAKey]))
+
+	// A missing bundle fails the write.
+	crt.Spec.AdditionalCABundle.ConfigMap.Key = "missing.pem"
+	assert.Error(t, testManager.UpdateData(context.Background(), crt, data))
+}
+
+func Test_setSMIMEBundle(t *testing.T) {
+	chain := mustLeafWithChain(t)
+	data := SecretData{
+		PrivateKey:  chain.leaf.keyPEM,
+		Certificate: chain.leaf.certPEM,
+		CA:          chain.cas.certsToPEM(),
+	}
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateEmails("alice@example.com"),
+		gen.SetCertificateSMIME(cmapi.CertificateSMIME{
+			PasswordSecretRef: cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: "smime-password"},
+				Key:                  "password",
+			},
+		}),
+	)
+
+	tests := map[string]struct {
+		passwordSecret *corev1.Secret
+		expectedErr    bool
+	}{
+		"if the password Secret does not exist, should error": {
+			expectedErr: true,
+		},
+		"if the password Secret has no password, should error": {
+			passwordSecret: &corev1.Secret{Data: map[string][]byte{"other": []byte("password")}},
+			expectedErr:    true,
+		},
+		"if the password Secret has a password, should add a bundle encrypted with it": {
+			passwordSecret: &corev1.Secret{Data: map[string][]byte{"password": []byte("hunter2")}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mod := testcorelisters.SetFakeSecretNamespaceListerGet(test.passwordSecret, nil)
+			if test.passwordSecret == nil {
+				mod = testcorelisters.SetFakeSecretNamespaceListerGet(nil, apierrors.NewNotFound(corev1.Resource("secret"), "not found"))
+			}
+			s := SecretsManager{secretLister: testcorelisters.NewFakeSecretLister(mod)}
+
+			secret := &corev1.Secret{Data: make(map[string][]byte)}
+			err := s.setSMIMEBundle(crt, secret, data)
+			if test.expectedErr {
+				assert.Error(t, err)
+				assert.NotContains(t, secret.Data, cmapi.CertificateSMIMEBundleKey)
+				return
+			}
+			assert.NoError(t, err)
+
+			key, cert, caCerts, err := pkcs12.DecodeChain(secret.Data[cmapi.CertificateSMIMEBundleKey], "hunter2")
+			assert.NoError(t, err)
+			assert.NotNil(t, key)
+			assert.Equal(t, chain.leaf.cert.Raw, cert.Raw)
+			assert.Len(t, caCerts, chainLength-1)
+		})
+	}
+}
+
 func Test_getCertificateSecret(t *testing.T) {
 	crt := &cmapi.Certificate{
 		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-certificate"},
@@ -19,6 +19,7 @@ package issuing
 import (
 	"context"
 	"crypto"
+	"errors"
 	"fmt"
 	"time"
 
@@ -38,6 +39,7 @@ import (
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -48,6 +50,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	utilkube "github.com/cert-manager/cert-manager/pkg/util/kube"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -56,6 +59,16 @@ import (
 
 const (
 	ControllerName = "certificates-issuing"
+
+	// reasonSecretOverwriteBlocked is the reason of the Issuing condition
+	// when the Secret of a Certificate holds a certificate which was not
+	// issued for it, and which would be overwritten by issuance.
+	reasonSecretOverwriteBlocked = "SecretOverwriteBlocked"
+
+	// reasonSecretVerificationFailed is the reason of the Issuing condition
+	// when the Secret of a Certificate did not hold the issued certificate
+	// after it was written.
+	reasonSecretVerificationFailed = "SecretVerificationFailed"
 )
 
 type localTemporarySignerFn func(crt *cmapi.Certificate, pk []byte) ([]byte, error)
@@ -86,8 +99,20 @@ type controller struct {
 	// Apply API calls.
 	fieldManager string
 
+	// statusWriter is used to write the status of Certificates. It uses
+	// client unless replaced with the StatusWriter of the controller Context.
+	statusWriter *statuswriter.Writer
+
 	// localTemporarySigner signs a certificate that is stored temporarily
 	localTemporarySigner localTemporarySignerFn
+
+	// metrics is used to observe the time taken to issue Certificates. It
+	// is nil if the controller was constructed without a Context.
+	metrics *metrics.Metrics
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
 }
 
 func NewController(
@@ -116,14 +141,12 @@ func NewController(
 	})
 	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Issuer reconciles on changes to the Secret named `spec.nextPrivateKeySecretName`
-		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
-			predicate.ResourceOwnerOf,
-			predicate.ExtractResourceName(predicate.CertificateNextPrivateKeySecretName)),
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateNextPrivateKeySecretNameIndex,
+			predicate.ResourceOwnerOf),
 	})
 	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Issuer reconciles on changes to the Secret named `spec.secretName`
-		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
-			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateSecretNameIndex),
 	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
@@ -135,7 +158,7 @@ func NewController(
 	}
 
 	secretsManager := internal.NewSecretsManager(
-		kubeClient.CoreV1(), secretsInformer.Lister(),
+		kubeClient.CoreV1(), secretsInformer.Lister(), kubeClient.CoreV1(),
 		fieldManager, certificateControllerOptions.EnableOwnerRef,
 	)
 
@@ -144,6 +167,7 @@ func NewController(
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
 		client:                   client,
+		statusWriter:             statuswriter.New(client, nil),
 		recorder:                 recorder,
 		clock:                    clock,
 		secretsUpdateData:        secretsManager.UpdateData,
@@ -178,6 +202,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
 	log = logf.WithResource(log, crt)
 	ctx = logf.NewContext(ctx, log)
 
@@ -251,7 +280,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 
 	// Verify the CSR options match what is requested in certificate.spec.
 	// If there are violations in the spec, then the requestmanager will handle this.
-	requestViolations, err := certificates.RequestMatchesSpec(req, crt.Spec)
+	requestViolations, err := certificates.RequestMatchesSpec(req, crt.Spec, nil)
 	if err != nil {
 		return err
 	}
@@ -391,13 +420,37 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 		Certificate: req.Status.Certificate,
 		CA:          req.Status.CA,
 	}
+	secretData, err = internal.CrossSignedSecretData(crt.Spec.CrossSignedChain, secretData, req.Status.CrossSignedChain)
+	if err != nil {
+		return err
+	}
+
+	// Set status.revision to revision of the CertificateRequest, so that the
+	// Secret is labelled with the revision it is issued for.
+	currentRevision := crt.Status.Revision
+	crt.Status.Revision = &nextRevision
 
 	if err := c.secretsUpdateData(ctx, crt, secretData); err != nil {
+		var overwriteErr *internal.SecretOverwriteError
+		if errors.As(err, &overwriteErr) {
+			// The Certificate has not been issued, so its revision is
+			// unchanged.
+			crt.Status.Revision = currentRevision
+			return c.blockIssueCertificate(ctx, crt, reasonSecretOverwriteBlocked, overwriteErr)
+		}
+		var verificationErr *internal.SecretVerificationError
+		if errors.As(err, &verificationErr) {
+			// The Secret holds the previous revision again if it could be
+			// restored, so the revision is unchanged.
+			crt.Status.Revision = currentRevision
+			return c.blockIssueCertificate(ctx, crt, reasonSecretVerificationFailed, verificationErr)
+		}
 		return err
 	}
 
-	//Set status.revision to revision of the CertificateRequest
-	crt.Status.Revision = &nextRevision
+	// The Issuing condition was set when the issuance started, so its last
+	// transition time is the start of the issuance.
+	issuingCond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
 
 	// Remove Issuing status condition
 	// TODO @joshvanl: Once we move to only server-side apply API calls, this
@@ -414,6 +467,10 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 		return err
 	}
 
+	if c.metrics != nil && issuingCond != nil && issuingCond.LastTransitionTime != nil {
+		c.metrics.ObserveCertificateIssuance(crt, c.clock.Since(issuingCond.LastTransitionTime.Time))
+	}
+
 	message := "The certificate has been successfully issued"
 	c.recorder.Event(crt, corev1.EventTypeNormal, "Issuing", message)
 
@@ -421,6 +478,36 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 
 }
 
+// blockIssueCertificate will mark the issuance of a Certificate as blocked
+// because the issued certificate could not be stored in its Secret, for
+// example because the Secret holds a certificate which was not issued for it.
+// The Issuing condition is set to False with the given reason, and the failed
+// issuance is recorded so that issuance is retried with a backoff, or as soon
+// as the Secret or the Certificate changes.
+func (c *controller) blockIssueCertificate(ctx context.Context, crt *cmapi.Certificate, reason string, blockErr error) error {
+	logf.FromContext(ctx).V(logf.WarnLevel).Info(blockErr.Error())
+
+	nowTime := metav1.NewTime(c.clock.Now())
+	crt.Status.LastFailureTime = &nowTime
+
+	failedIssuanceAttempts := 1
+	if crt.Status.FailedIssuanceAttempts != nil {
+		failedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts + 1
+	}
+	crt.Status.FailedIssuanceAttempts = &failedIssuanceAttempts
+
+	message := blockErr.Error()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reason, message)
+
+	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
+		return err
+	}
+
+	c.recorder.Event(crt, corev1.EventTypeWarning, reason, message)
+
+	return nil
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
@@ -429,32 +516,36 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 // have been removed, the Issuing condition will be set to False before
 // applying.
 func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate, conditionRemoved bool) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		// TODO @joshvanl: Once we move to only server-side apply API calls,
-		// `conditionRemoved` can be removed and setting the Issuing condition to
-		// False can be moved to the `issueCertificate` func.
-		if conditionRemoved {
-			message := "The certificate has been successfully issued"
-			apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, "Issued", message)
-		}
-
-		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+	serverSideApply := utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply)
+
+	// TODO @joshvanl: Once we move to only server-side apply API calls,
+	// `conditionRemoved` can be removed and setting the Issuing condition to
+	// False can be moved to the `issueCertificate` func.
+	if serverSideApply && conditionRemoved {
+		message := "The certificate has been successfully issued"
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, "Issued", message)
+	}
+
+	return c.statusWriter.Write(ctx, crt, func(ctx context.Context, cl cmclient.Interface) error {
+		if serverSideApply {
+			var conditions []cmapi.CertificateCondition
+			if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
+				conditions = []cmapi.CertificateCondition{*cond}
+			}
+
+			return internalcertificates.ApplyStatus(ctx, cl, c.fieldManager, &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+				Status: cmapi.CertificateStatus{
+					Revision:        crt.Status.Revision,
+					LastFailureTime: crt.Status.LastFailureTime,
+					Conditions:      conditions,
+				},
+			})
+		} else {
+			_, err := cl.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+			return err
 		}
-
-		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
-			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status: cmapi.CertificateStatus{
-				Revision:        crt.Status.Revision,
-				LastFailureTime: crt.Status.LastFailureTime,
-				Conditions:      conditions,
-			},
-		})
-	} else {
-		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
-		return err
-	}
+	})
 }
 
 // controllerWrapper wraps the `controller` structure to make it implement
@@ -477,6 +568,11 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CertificateOptions,
 		ctx.FieldManager,
 	)
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+	ctrl.controllerClass = ctx.ControllerClass
+	ctrl.metrics = ctx.Metrics
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -18,6 +18,7 @@ package issuing
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -57,6 +58,7 @@ func TestIssuingController(t *testing.T) {
 
 		certificate             *cmapi.Certificate
 		expSecretUpdateDataCall *internal.SecretData
+		secretUpdateDataErr     error
 
 		expectedErr bool
 	}
@@ -514,6 +516,112 @@ func TestIssuingController(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if certificate is in Issuing state, one CertificateRequests, and is ready, but the secret holds a certificate not managed by cert-manager, set Issuing False and log an event": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "SecretOverwriteBlocked",
+								Message:            (&internal.SecretOverwriteError{Namespace: gen.DefaultTestNamespace, Name: "output"}).Error(),
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Warning SecretOverwriteBlocked " + (&internal.SecretOverwriteError{Namespace: gen.DefaultTestNamespace, Name: "output"}).Error(),
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			secretUpdateDataErr: &internal.SecretOverwriteError{Namespace: gen.DefaultTestNamespace, Name: "output"},
+			expectedErr:         false,
+		},
+
+		"if certificate is in Issuing state, one CertificateRequests, and is ready, but the secret fails verification after being written, set Issuing False and log an event": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "SecretVerificationFailed",
+								Message:            (&internal.SecretVerificationError{Namespace: gen.DefaultTestNamespace, Name: "output", Err: errors.New("tls.crt does not hold the written certificate"), RolledBack: true}).Error(),
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Warning SecretVerificationFailed " + (&internal.SecretVerificationError{Namespace: gen.DefaultTestNamespace, Name: "output", Err: errors.New("tls.crt does not hold the written certificate"), RolledBack: true}).Error(),
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			secretUpdateDataErr: &internal.SecretVerificationError{Namespace: gen.DefaultTestNamespace, Name: "output", Err: errors.New("tls.crt does not hold the written certificate"), RolledBack: true},
+			expectedErr:         false,
+		},
+
 		"if certificate is in Issuing state, one CertificateRequests, and is ready, store the signed certificate, ca, and private key to an existing secret, and log an event": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
@@ -1081,7 +1189,7 @@ func TestIssuingController(t *testing.T) {
 			w.controller.secretsUpdateData = func(_ context.Context, _ *cmapi.Certificate, secretData internal.SecretData) error {
 				secretsUpdateDataCalled = true
 				assert.Equal(t, *test.expSecretUpdateDataCall, secretData, "expected secretData: %#+v, got %#+v", *test.expSecretUpdateDataCall, secretData)
-				return nil
+				return test.secretUpdateDataErr
 			}
 			t.Cleanup(func() {
 				wantsSecretUpdateDataCall := test.expSecretUpdateDataCall != nil
@@ -43,6 +43,19 @@ func Test_ensureSecretData(t *testing.T) {
 	block, _ := pem.Decode(pk)
 	pkDER := block.Bytes
 	combinedPEM := append(append(pk, '\n'), cert...)
+	// certificateLabels are the labels identifying the test Certificate, which
+	// are expected on its Secret.
+	certificateLabels := map[string]string{
+		cmapi.CertificateNameLabelKey: "test-name",
+		cmapi.IssuerNameLabelKey:      "",
+		cmapi.IssuerKindLabelKey:      cmapi.IssuerKind,
+	}
+	withCertificateLabels := func(labels map[string]string) map[string]string {
+		for k, v := range certificateLabels {
+			labels[k] = v
+		}
+		return labels
+	}
 
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem.
@@ -272,7 +285,7 @@ func Test_ensureSecretData(t *testing.T) {
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "test-namespace", Name: "test-secret",
-					Annotations: map[string]string{"foo": "bar"}, Labels: map[string]string{"abc": "123"},
+					Annotations: map[string]string{"foo": "bar"}, Labels: withCertificateLabels(map[string]string{"abc": "123"}),
 					ManagedFields: []metav1.ManagedFieldsEntry{{
 						Manager: fieldManager,
 						FieldsV1: &metav1.FieldsV1{
@@ -318,6 +331,21 @@ func Test_ensureSecretData(t *testing.T) {
 			},
 			expectedAction: true,
 		},
+		"if Certificate exists in a false Issuing condition, Secret exists but does not have the Certificate labels, should apply the labels": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+				Spec:       cmapi.CertificateSpec{SecretName: "test-secret"},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse}},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret"},
+				Data:       map[string][]byte{"tls.crt": cert, "tls.key": pk},
+			},
+			expectedAction: true,
+		},
 		"if Certificate with combined pem and Secret exists, but the Secret doesn't have combined pem, should apply the combined pem": {
 			key: "test-namespace/test-name",
 			cert: &cmapi.Certificate{
@@ -393,6 +421,7 @@ func Test_ensureSecretData(t *testing.T) {
 			},
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Labels: certificateLabels,
 					ManagedFields: []metav1.ManagedFieldsEntry{{
 						Manager: fieldManager,
 						FieldsV1: &metav1.FieldsV1{
@@ -475,6 +504,7 @@ func Test_ensureSecretData(t *testing.T) {
 			},
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Labels: certificateLabels,
 					OwnerReferences: []metav1.OwnerReference{
 						{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: "test-name", UID: types.UID("uid-123"), Controller: pointer.Bool(true), BlockOwnerDeletion: pointer.Bool(true)},
 					},
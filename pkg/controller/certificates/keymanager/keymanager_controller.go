@@ -37,6 +37,7 @@ import (
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -73,6 +74,14 @@ type controller struct {
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
 	fieldManager string
+
+	// statusWriter is used to write the status of Certificates. It uses
+	// client unless replaced with the StatusWriter of the controller Context.
+	statusWriter *statuswriter.Writer
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
 }
 
 func NewController(
@@ -101,9 +110,7 @@ func NewController(
 	})
 	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to certificates named as spec.secretName
-		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
-			predicate.ExtractResourceName(predicate.CertificateSecretName),
-		),
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateSecretNameIndex),
 	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
@@ -117,6 +124,7 @@ func NewController(
 		certificateLister: certificateInformer.Lister(),
 		secretLister:      secretsInformer.Lister(),
 		client:            client,
+		statusWriter:      statuswriter.New(client, nil),
 		coreClient:        coreClient,
 		recorder:          recorder,
 		fieldManager:      fieldManager,
@@ -154,6 +162,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
 	// Discover all 'owned' secrets that have the `next-private-key` label
 	secrets, err := certificates.ListSecretsMatchingPredicates(c.secretLister.Secrets(crt.Namespace), isNextPrivateKeyLabelSelector, predicate.ResourceOwnedBy(crt))
 	if err != nil {
@@ -321,15 +334,17 @@ func (c *controller) setNextPrivateKeySecretName(ctx context.Context, crt *cmapi
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
 func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
-			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status:     cmapi.CertificateStatus{NextPrivateKeySecretName: crt.Status.NextPrivateKeySecretName},
-		})
-	} else {
-		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
-		return err
-	}
+	return c.statusWriter.Write(ctx, crt, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			return internalcertificates.ApplyStatus(ctx, cl, c.fieldManager, &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+				Status:     cmapi.CertificateStatus{NextPrivateKeySecretName: crt.Status.NextPrivateKeySecretName},
+			})
+		} else {
+			_, err := cl.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+			return err
+		}
+	})
 }
 
 func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer) (*corev1.Secret, error) {
@@ -387,6 +402,10 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.Recorder,
 		ctx.FieldManager,
 	)
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+	ctrl.controllerClass = ctx.ControllerClass
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -17,11 +17,16 @@ limitations under the License.
 package certificates
 
 import (
+	"context"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
@@ -64,6 +69,96 @@ func ListCertificatesMatchingPredicates(lister cmlisters.CertificateNamespaceLis
 	return out, nil
 }
 
+// ListCertificateRequestsPageMatchingPredicates will list a single page of
+// CertificateRequest resources in the given namespace from the API server,
+// using the limit, continue token and selectors of opts, and apply the given
+// predicate functions to filter the CertificateRequest resources returned.
+// The continue token of the next page is returned, which is empty once the
+// last page has been listed.
+// As the page is filtered after it has been listed, fewer than opts.Limit
+// CertificateRequests may be returned even if more pages remain.
+func ListCertificateRequestsPageMatchingPredicates(ctx context.Context, client cmclient.Interface, namespace string, opts metav1.ListOptions, predicates ...predicate.Func) ([]*cmapi.CertificateRequest, string, error) {
+	list, err := client.CertmanagerV1().CertificateRequests(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	funcs := predicate.Funcs(predicates)
+	out := make([]*cmapi.CertificateRequest, 0)
+	for i := range list.Items {
+		if funcs.Evaluate(&list.Items[i]) {
+			out = append(out, &list.Items[i])
+		}
+	}
+
+	return out, list.Continue, nil
+}
+
+// ListCertificatesPageMatchingPredicates will list a single page of
+// Certificate resources in the given namespace from the API server, using the
+// limit, continue token and selectors of opts, and apply the given predicate
+// functions to filter the Certificate resources returned.
+// The continue token of the next page is returned, which is empty once the
+// last page has been listed.
+// As the page is filtered after it has been listed, fewer than opts.Limit
+// Certificates may be returned even if more pages remain.
+func ListCertificatesPageMatchingPredicates(ctx context.Context, client cmclient.Interface, namespace string, opts metav1.ListOptions, predicates ...predicate.Func) ([]*cmapi.Certificate, string, error) {
+	list, err := client.CertmanagerV1().Certificates(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	funcs := predicate.Funcs(predicates)
+	out := make([]*cmapi.Certificate, 0)
+	for i := range list.Items {
+		if funcs.Evaluate(&list.Items[i]) {
+			out = append(out, &list.Items[i])
+		}
+	}
+
+	return out, list.Continue, nil
+}
+
+// ListCertificateRequestsByIndexMatchingPredicates will list the
+// CertificateRequest resources stored under the given value of an index of
+// the indexer, such as controllerpkg.IssuerRefIndex, applying the given
+// predicate functions to filter the CertificateRequest resources returned.
+func ListCertificateRequestsByIndexMatchingPredicates(indexer cache.Indexer, indexName, indexedValue string, predicates ...predicate.Func) ([]*cmapi.CertificateRequest, error) {
+	objs, err := indexer.ByIndex(indexName, indexedValue)
+	if err != nil {
+		return nil, err
+	}
+	funcs := predicate.Funcs(predicates)
+	out := make([]*cmapi.CertificateRequest, 0)
+	for _, obj := range objs {
+		req, ok := obj.(*cmapi.CertificateRequest)
+		if ok && funcs.Evaluate(req) {
+			out = append(out, req)
+		}
+	}
+
+	return out, nil
+}
+
+// ListCertificatesByIndexMatchingPredicates will list the Certificate
+// resources stored under the given value of an index of the indexer, such as
+// controllerpkg.IssuerRefIndex, applying the given predicate functions to
+// filter the Certificate resources returned.
+func ListCertificatesByIndexMatchingPredicates(indexer cache.Indexer, indexName, indexedValue string, predicates ...predicate.Func) ([]*cmapi.Certificate, error) {
+	objs, err := indexer.ByIndex(indexName, indexedValue)
+	if err != nil {
+		return nil, err
+	}
+	funcs := predicate.Funcs(predicates)
+	out := make([]*cmapi.Certificate, 0)
+	for _, obj := range objs {
+		crt, ok := obj.(*cmapi.Certificate)
+		if ok && funcs.Evaluate(crt) {
+			out = append(out, crt)
+		}
+	}
+
+	return out, nil
+}
+
 // ListSecretsMatchingPredicates will list Secret resources using
 // the provided lister, optionally applying the given predicate functions to
 // filter the Secret resources returned.
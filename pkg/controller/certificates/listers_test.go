@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestListCertificatesPageMatchingPredicates(t *testing.T) {
+	crt1 := gen.Certificate("crt-1", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("secret"))
+	crt2 := gen.Certificate("crt-2", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("other"))
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "certificates", func(action coretesting.Action) (bool, runtime.Object, error) {
+		// The fake clientset doesn't paginate, so a page is served here.
+		assert.Equal(t, "ns", action.GetNamespace())
+		return true, &cmapi.CertificateList{
+			ListMeta: metav1.ListMeta{Continue: "next-page"},
+			Items:    []cmapi.Certificate{*crt1, *crt2},
+		}, nil
+	})
+
+	crts, continueToken, err := ListCertificatesPageMatchingPredicates(context.Background(), client, "ns", metav1.ListOptions{Limit: 2}, predicate.CertificateSecretName("secret"))
+	require.NoError(t, err)
+	assert.Equal(t, []*cmapi.Certificate{crt1}, crts)
+	assert.Equal(t, "next-page", continueToken)
+}
+
+func TestListCertificatesByIndexMatchingPredicates(t *testing.T) {
+	issuerRef := gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"})
+	crt1 := gen.Certificate("crt-1", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("secret"), issuerRef)
+	crt2 := gen.Certificate("crt-2", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("other"), issuerRef)
+	crt3 := gen.Certificate("crt-3", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("secret"))
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, controllerpkg.CertificateIndexers)
+	for _, crt := range []*cmapi.Certificate{crt1, crt2, crt3} {
+		require.NoError(t, indexer.Add(crt))
+	}
+
+	key := controllerpkg.IssuerRefIndexKey(gen.Issuer("issuer", gen.SetIssuerNamespace("ns")))
+	crts, err := ListCertificatesByIndexMatchingPredicates(indexer, controllerpkg.IssuerRefIndex, key, predicate.CertificateSecretName("secret"))
+	require.NoError(t, err)
+	assert.Equal(t, []*cmapi.Certificate{crt1}, crts)
+
+	_, err = ListCertificatesByIndexMatchingPredicates(indexer, "unknown", key)
+	assert.Error(t, err)
+}
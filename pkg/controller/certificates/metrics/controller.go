@@ -49,6 +49,10 @@ type controller struct {
 	certificateLister cmlisters.CertificateLister
 
 	metrics *metrics.Metrics
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
 }
 
 func NewController(
@@ -100,6 +104,12 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		// The Certificate is exposed by the installation which manages it.
+		c.metrics.RemoveCertificate(key)
+		return nil
+	}
+
 	// Update that Certificates metrics
 	c.metrics.UpdateCertificate(ctx, crt)
 
@@ -112,6 +122,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.SharedInformerFactory,
 		ctx.Metrics,
 	)
+	ctrl.controllerClass = ctx.ControllerClass
 	c.controller = ctrl
 
 	return queue, mustSync, nil
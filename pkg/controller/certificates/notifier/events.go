@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// defaultExpiryThreshold is the expiry threshold of Notifiers which
+	// don't set expiryThreshold.
+	defaultExpiryThreshold = 7 * 24 * time.Hour
+
+	// renewalMissedAfter is how long after its renewal time a Certificate
+	// which has not been renewed is considered to have missed its renewal.
+	// Renewals usually complete within seconds or minutes, so this leaves
+	// enough time for slower issuers such as ACME with DNS01 challenges.
+	renewalMissedAfter = time.Hour
+)
+
+// event is an event that is currently occurring for a Certificate.
+type event struct {
+	name cmapi.NotifierEvent
+
+	// occurrence identifies the occurrence of the event, so that alerts are
+	// only sent once for each occurrence. For example, a Certificate that
+	// fails to be issued again after a successful renewal is a new
+	// occurrence of the Failed event.
+	occurrence string
+
+	// message describes the event.
+	message string
+}
+
+// activeEvents returns the events of notifier which are occurring for crt at
+// the given time.
+func activeEvents(crt *cmapi.Certificate, notifier *cmapi.Notifier, now time.Time) []event {
+	var events []event
+
+	if enabled(notifier, cmapi.NotifierEventFailed) && crt.Status.LastFailureTime != nil {
+		message := "failed to be issued"
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil && len(cond.Message) > 0 {
+			message = fmt.Sprintf("failed to be issued: %s", cond.Message)
+		}
+		events = append(events, event{
+			name:       cmapi.NotifierEventFailed,
+			occurrence: crt.Status.LastFailureTime.UTC().Format(time.RFC3339),
+			message:    message,
+		})
+	}
+
+	if enabled(notifier, cmapi.NotifierEventRenewalMissed) && crt.Status.RenewalTime != nil &&
+		!now.Before(crt.Status.RenewalTime.Add(renewalMissedAfter)) {
+		events = append(events, event{
+			name:       cmapi.NotifierEventRenewalMissed,
+			occurrence: crt.Status.RenewalTime.UTC().Format(time.RFC3339),
+			message:    fmt.Sprintf("has not been renewed since its renewal time %s", crt.Status.RenewalTime.UTC().Format(time.RFC3339)),
+		})
+	}
+
+	if enabled(notifier, cmapi.NotifierEventExpiring) && crt.Status.NotAfter != nil &&
+		!now.Before(crt.Status.NotAfter.Add(-expiryThreshold(notifier))) {
+		notAfter := crt.Status.NotAfter.UTC().Format(time.RFC3339)
+		message := fmt.Sprintf("expires at %s and has not been renewed", notAfter)
+		if !now.Before(crt.Status.NotAfter.Time) {
+			message = fmt.Sprintf("expired at %s and has not been renewed", notAfter)
+		}
+		events = append(events, event{
+			name:       cmapi.NotifierEventExpiring,
+			occurrence: notAfter,
+			message:    message,
+		})
+	}
+
+	return events
+}
+
+// nextEventTime returns the next time after now at which one of the events
+// of notifier starts occurring for crt, or the zero time if there is none.
+func nextEventTime(crt *cmapi.Certificate, notifier *cmapi.Notifier, now time.Time) time.Time {
+	var next time.Time
+	consider := func(t time.Time) {
+		if t.After(now) && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+
+	if enabled(notifier, cmapi.NotifierEventRenewalMissed) && crt.Status.RenewalTime != nil {
+		consider(crt.Status.RenewalTime.Add(renewalMissedAfter))
+	}
+	if enabled(notifier, cmapi.NotifierEventExpiring) && crt.Status.NotAfter != nil {
+		consider(crt.Status.NotAfter.Add(-expiryThreshold(notifier)))
+		// The message of the event changes once the certificate expires.
+		consider(crt.Status.NotAfter.Time)
+	}
+
+	return next
+}
+
+func enabled(notifier *cmapi.Notifier, name cmapi.NotifierEvent) bool {
+	if len(notifier.Spec.Events) == 0 {
+		return true
+	}
+	for _, e := range notifier.Spec.Events {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
+
+func expiryThreshold(notifier *cmapi.Notifier) time.Duration {
+	if notifier.Spec.ExpiryThreshold != nil {
+		return notifier.Spec.ExpiryThreshold.Duration
+	}
+	return defaultExpiryThreshold
+}
+
+func appliesToNamespace(notifier *cmapi.Notifier, namespace string) bool {
+	if len(notifier.Spec.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range notifier.Spec.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ControllerName is the name of the certificate notifier controller.
+	ControllerName = "certificates-notifier"
+
+	reasonNotificationFailed = "NotificationFailed"
+)
+
+// controller sends alerts to the channels configured by Notifiers when
+// Certificates fail to be issued, miss their renewal time, or are close to
+// expiry.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	notifierLister    cmlisters.NotifierLister
+	secretLister      corelisters.SecretLister
+	recorder          record.EventRecorder
+	clock             clock.Clock
+	queue             workqueue.RateLimitingInterface
+	sendMail          sendMailFunc
+
+	// clusterResourceNamespace is the namespace that Secrets referenced by
+	// Notifiers are read from.
+	clusterResourceNamespace string
+
+	// sent records the alerts that have been sent, keyed by the Certificate
+	// key and then by the Notifier name and event, so that each occurrence of
+	// an event is only sent once per RepeatInterval. It is only kept in
+	// memory, so alerts may be sent again after the controller restarts.
+	sentLock sync.Mutex
+	sent     map[string]map[string]sentAlert
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+type sentAlert struct {
+	occurrence string
+	at         time.Time
+}
+
+// NewController returns a new certificate notifier controller.
+func NewController(
+	log logr.Logger,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	clusterResourceNamespace string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	notifierInformer := cmFactory.Certmanager().V1().Notifiers()
+	secretsInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Notifier changes, all Certificates are re-evaluated against it.
+	notifierInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueAllCertificates(log, queue, certificateInformer.Lister()),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		secretsInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		notifierInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateLister:        certificateInformer.Lister(),
+		notifierLister:           notifierInformer.Lister(),
+		secretLister:             secretsInformer.Lister(),
+		recorder:                 recorder,
+		clock:                    clock,
+		queue:                    queue,
+		sendMail:                 smtp.SendMail,
+		clusterResourceNamespace: clusterResourceNamespace,
+		sent:                     make(map[string]map[string]sentAlert),
+	}, queue, mustSync
+}
+
+func enqueueAllCertificates(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		crts, err := lister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list Certificates")
+			return
+		}
+		for _, crt := range crts {
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "failed to construct key for Certificate")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a Certificate to be re-synced is pulled from the workqueue.
+// ProcessItem sends alerts for the events occurring for the Certificate that
+// have not yet been sent, and requeues the Certificate for when the next event
+// starts occurring.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		c.sentLock.Lock()
+		delete(c.sent, key)
+		c.sentLock.Unlock()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
+	notifiers, err := c.notifierLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	var next time.Time
+	schedule := func(t time.Time) {
+		if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+
+	// Keys are never processed concurrently by multiple workers, so the lock
+	// is only held while reading and writing the alerts sent for the key.
+	c.sentLock.Lock()
+	previous := c.sent[key]
+	c.sentLock.Unlock()
+	current := make(map[string]sentAlert)
+
+	var errs []error
+	for _, notifier := range notifiers {
+		if !appliesToNamespace(notifier, crt.Namespace) {
+			continue
+		}
+		schedule(nextEventTime(crt, notifier, now))
+
+		for _, e := range activeEvents(crt, notifier, now) {
+			alertKey := notifier.Name + "/" + string(e.name)
+			if prev, ok := previous[alertKey]; ok && prev.occurrence == e.occurrence {
+				repeat := notifier.Spec.RepeatInterval
+				if repeat == nil || now.Before(prev.at.Add(repeat.Duration)) {
+					current[alertKey] = prev
+					if repeat != nil {
+						schedule(prev.at.Add(repeat.Duration))
+					}
+					continue
+				}
+			}
+
+			err := c.send(ctx, notifier, &alert{
+				Event:       e.name,
+				Certificate: crt,
+				Message:     fmt.Sprintf("Certificate %s/%s %s", crt.Namespace, crt.Name, e.message),
+			})
+			if err != nil {
+				c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonNotificationFailed, "Failed to send %s alert using Notifier %q: %v", e.name, notifier.Name, err)
+				errs = append(errs, err)
+				continue
+			}
+			log.V(logf.DebugLevel).Info("sent alert", "notifier", notifier.Name, "event", e.name)
+
+			current[alertKey] = sentAlert{occurrence: e.occurrence, at: now}
+			if notifier.Spec.RepeatInterval != nil {
+				schedule(now.Add(notifier.Spec.RepeatInterval.Duration))
+			}
+		}
+	}
+
+	// Alerts for events which are no longer occurring are forgotten, so that
+	// they are sent again if the event reoccurs.
+	c.sentLock.Lock()
+	if len(current) > 0 {
+		c.sent[key] = current
+	} else {
+		delete(c.sent, key)
+	}
+	c.sentLock.Unlock()
+
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	if !next.IsZero() {
+		c.queue.AddAfter(key, next.Sub(now))
+	}
+	return nil
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.IssuerOptions.ClusterResourceNamespace,
+	)
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// webhookServer records the payloads of the alerts sent to it.
+type webhookServer struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	payloads []webhookPayload
+}
+
+func newWebhookServer(t *testing.T, status int) *webhookServer {
+	s := &webhookServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		s.lock.Lock()
+		s.payloads = append(s.payloads, payload)
+		s.lock.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newNotifier(name string, mod func(*cmapi.Notifier)) *cmapi.Notifier {
+	n := &cmapi.Notifier{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	mod(n)
+	return n
+}
+
+func setup(t *testing.T, clock *fakeclock.FakeClock, objs ...runtime.Object) (*controller, *testpkg.Builder) {
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              clock,
+		CertManagerObjects: objs,
+	}
+	builder.Init()
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	t.Cleanup(builder.Stop)
+	return w.controller, builder
+}
+
+func TestProcessItemFailed(t *testing.T) {
+	server := newWebhookServer(t, http.StatusOK)
+	failureTime := metav1.NewTime(fixedClockStart.Add(-time.Minute))
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateLastFailureTime(failureTime),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:    cmapi.CertificateConditionIssuing,
+			Status:  cmmeta.ConditionFalse,
+			Reason:  "Failed",
+			Message: "the issuer is unavailable",
+		}),
+	)
+	notifier := newNotifier("team", func(n *cmapi.Notifier) {
+		n.Spec.Webhook = &cmapi.WebhookNotifier{URL: server.URL}
+	})
+
+	clock := fakeclock.NewFakeClock(fixedClockStart)
+	c, _ := setup(t, clock, crt, notifier)
+	key, _ := controllerpkg.KeyFunc(crt)
+
+	for i := 0; i < 2; i++ {
+		if err := c.ProcessItem(context.Background(), key); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := webhookPayload{
+		Event:     cmapi.NotifierEventFailed,
+		Namespace: "testns",
+		Name:      "test",
+		Message:   "Certificate testns/test failed to be issued: the issuer is unavailable",
+	}
+	if len(server.payloads) != 1 || server.payloads[0] != expected {
+		t.Errorf("expected a single alert %+v, got %+v", expected, server.payloads)
+	}
+}
+
+func TestProcessItemExpiringRepeat(t *testing.T) {
+	server := newWebhookServer(t, http.StatusOK)
+	notAfter := metav1.NewTime(fixedClockStart.Add(48 * time.Hour))
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateNotAfter(notAfter),
+	)
+	notifier := newNotifier("team", func(n *cmapi.Notifier) {
+		n.Spec.Events = []cmapi.NotifierEvent{cmapi.NotifierEventExpiring}
+		n.Spec.ExpiryThreshold = &metav1.Duration{Duration: 24 * time.Hour}
+		n.Spec.RepeatInterval = &metav1.Duration{Duration: 6 * time.Hour}
+		n.Spec.Webhook = &cmapi.WebhookNotifier{URL: server.URL}
+	})
+
+	clock := fakeclock.NewFakeClock(fixedClockStart)
+	c, _ := setup(t, clock, crt, notifier)
+	key, _ := controllerpkg.KeyFunc(crt)
+
+	steps := []struct {
+		advance time.Duration
+		alerts  int
+	}{
+		// outside of the expiry threshold
+		{advance: 0, alerts: 0},
+		// within the expiry threshold
+		{advance: 25 * time.Hour, alerts: 1},
+		// before the repeat interval
+		{advance: time.Hour, alerts: 1},
+		// after the repeat interval
+		{advance: 6 * time.Hour, alerts: 2},
+	}
+	for i, step := range steps {
+		clock.Step(step.advance)
+		if err := c.ProcessItem(context.Background(), key); err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+		if len(server.payloads) != step.alerts {
+			t.Errorf("step %d: expected %d alerts, got %d", i, step.alerts, len(server.payloads))
+		}
+	}
+}
+
+func TestProcessItemNamespaces(t *testing.T) {
+	server := newWebhookServer(t, http.StatusOK)
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateLastFailureTime(metav1.NewTime(fixedClockStart)),
+	)
+	notifier := newNotifier("other-team", func(n *cmapi.Notifier) {
+		n.Spec.Namespaces = []string{"other"}
+		n.Spec.Webhook = &cmapi.WebhookNotifier{URL: server.URL}
+	})
+
+	c, _ := setup(t, fakeclock.NewFakeClock(fixedClockStart), crt, notifier)
+	key, _ := controllerpkg.KeyFunc(crt)
+	if err := c.ProcessItem(context.Background(), key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(server.payloads) != 0 {
+		t.Errorf("expected no alerts for a Certificate in another namespace, got %+v", server.payloads)
+	}
+}
+
+func TestProcessItemSendFailure(t *testing.T) {
+	server := newWebhookServer(t, http.StatusInternalServerError)
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateLastFailureTime(metav1.NewTime(fixedClockStart)),
+	)
+	notifier := newNotifier("team", func(n *cmapi.Notifier) {
+		n.Spec.Webhook = &cmapi.WebhookNotifier{URL: server.URL}
+	})
+
+	c, builder := setup(t, fakeclock.NewFakeClock(fixedClockStart), crt, notifier)
+	builder.ExpectedEvents = []string{`Warning NotificationFailed Failed to send Failed alert using Notifier "team": unexpected status code 500`}
+	key, _ := controllerpkg.KeyFunc(crt)
+	if err := c.ProcessItem(context.Background(), key); err == nil {
+		t.Errorf("expected error")
+	}
+	builder.CheckAndFinish()
+
+	// The alert is sent again when the Certificate is retried.
+	if err := c.ProcessItem(context.Background(), key); err == nil {
+		t.Errorf("expected error")
+	}
+	if len(server.payloads) != 2 {
+		t.Errorf("expected failed alerts to be retried, got %d alerts", len(server.payloads))
+	}
+}
+
+func TestProcessItemEmailTemplate(t *testing.T) {
+	renewalTime := metav1.NewTime(fixedClockStart.Add(-2 * time.Hour))
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateRenewalTime(renewalTime),
+	)
+	notifier := newNotifier("team", func(n *cmapi.Notifier) {
+		n.Spec.Template = "{{ .Event }} for {{ .Certificate.Name }}: {{ .Message }}"
+		n.Spec.Email = &cmapi.EmailNotifier{
+			SMTPServer: "smtp.example.com:25",
+			From:       "cert-manager@example.com",
+			To:         []string{"team@example.com"},
+		}
+	})
+
+	c, _ := setup(t, fakeclock.NewFakeClock(fixedClockStart), crt, notifier)
+	var sent []string
+	c.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		if addr != "smtp.example.com:25" || from != "cert-manager@example.com" || len(to) != 1 || a != nil {
+			t.Errorf("unexpected arguments to send mail: %s %v %s %v", addr, a, from, to)
+		}
+		sent = append(sent, string(msg))
+		return nil
+	}
+
+	key, _ := controllerpkg.KeyFunc(crt)
+	if err := c.ProcessItem(context.Background(), key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(sent))
+	}
+	for _, expected := range []string{
+		"Subject: [cert-manager] RenewalMissed: Certificate testns/test\r\n",
+		"\r\n\r\nRenewalMissed for test: Certificate testns/test has not been renewed since its renewal time 2022-10-01T10:00:00Z\r\n",
+	} {
+		if !strings.Contains(sent[0], expected) {
+			t.Errorf("expected email to contain %q, got %q", expected, sent[0])
+		}
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// sendTimeout is the timeout of requests to Slack and webhooks.
+const sendTimeout = 30 * time.Second
+
+// alert is passed to the template of a Notifier.
+type alert struct {
+	Event       cmapi.NotifierEvent
+	Certificate *cmapi.Certificate
+
+	// Message is the default message of the alert.
+	Message string
+}
+
+// webhookPayload is the JSON encoded body of alerts sent to webhooks.
+type webhookPayload struct {
+	Event     cmapi.NotifierEvent `json:"event"`
+	Namespace string              `json:"namespace"`
+	Name      string              `json:"name"`
+	Message   string              `json:"message"`
+}
+
+// sendMailFunc sends an email, and has the signature of smtp.SendMail.
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// render returns the message of a, rendered using the template of notifier if
+// it has one.
+func render(notifier *cmapi.Notifier, a *alert) (string, error) {
+	if len(notifier.Spec.Template) == 0 {
+		return a.Message, nil
+	}
+
+	tmpl, err := template.New(notifier.Name).Parse(notifier.Spec.Template)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, a); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// send sends a to the channel configured in notifier.
+func (c *controller) send(ctx context.Context, notifier *cmapi.Notifier, a *alert) error {
+	message, err := render(notifier, a)
+	if err != nil {
+		return err
+	}
+
+	switch spec := notifier.Spec; {
+	case spec.Slack != nil:
+		url, err := c.secretValue(spec.Slack.WebhookURLSecretRef)
+		if err != nil {
+			return err
+		}
+		return postJSON(ctx, &http.Client{Timeout: sendTimeout}, strings.TrimSpace(url), map[string]string{"text": message})
+
+	case spec.Webhook != nil:
+		client, err := webhookClient(spec.Webhook)
+		if err != nil {
+			return err
+		}
+		return postJSON(ctx, client, spec.Webhook.URL, webhookPayload{
+			Event:     a.Event,
+			Namespace: a.Certificate.Namespace,
+			Name:      a.Certificate.Name,
+			Message:   message,
+		})
+
+	case spec.Email != nil:
+		return c.sendEmail(spec.Email, a, message)
+
+	default:
+		return fmt.Errorf("Notifier %q does not configure slack, webhook or email", notifier.Name)
+	}
+}
+
+func (c *controller) sendEmail(email *cmapi.EmailNotifier, a *alert, message string) error {
+	var auth smtp.Auth
+	if len(email.Username) > 0 {
+		var password string
+		if email.PasswordSecretRef != nil {
+			var err error
+			if password, err = c.secretValue(*email.PasswordSecretRef); err != nil {
+				return err
+			}
+		}
+		host, _, err := net.SplitHostPort(email.SMTPServer)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", email.Username, password, host)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", email.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(email.To, ", "))
+	fmt.Fprintf(&msg, "Subject: [cert-manager] %s: Certificate %s/%s\r\n", a.Event, a.Certificate.Namespace, a.Certificate.Name)
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n", message)
+
+	return c.sendMail(email.SMTPServer, auth, email.From, email.To, msg.Bytes())
+}
+
+// secretValue returns the value of the given key of a Secret in the cluster
+// resource namespace.
+func (c *controller) secretValue(ref cmmeta.SecretKeySelector) (string, error) {
+	secret, err := c.secretLister.Secrets(c.clusterResourceNamespace).Get(ref.Name)
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, c.clusterResourceNamespace, ref.Name)
+	}
+	return string(value), nil
+}
+
+func webhookClient(webhook *cmapi.WebhookNotifier) (*http.Client, error) {
+	if len(webhook.CABundle) == 0 {
+		return &http.Client{Timeout: sendTimeout}, nil
+	}
+
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(webhook.CABundle); !ok {
+		return nil, fmt.Errorf("no CA certificates could be loaded from the webhook caBundle")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &http.Client{Transport: transport, Timeout: sendTimeout}, nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
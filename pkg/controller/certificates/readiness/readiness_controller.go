@@ -18,6 +18,11 @@ package readiness
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -30,10 +35,12 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -68,10 +75,22 @@ type controller struct {
 	// renewalTimeCalculator calculates renewal time of a certificate
 	renewalTimeCalculator certificates.RenewalTimeFunc
 
+	// queue is used to re-evaluate Certificates once they expire
+	queue workqueue.RateLimitingInterface
+	clock clock.Clock
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
 	fieldManager string
+
+	// statusWriter is used to write the status of Certificates. It uses
+	// client unless replaced with the StatusWriter of the controller Context.
+	statusWriter *statuswriter.Writer
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
 }
 
 // readyConditionFunc is custom function type that builds certificate's Ready condition
@@ -83,13 +102,14 @@ func NewController(
 	client cmclient.Interface,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
+	clock clock.Clock,
 	chain policies.Chain,
 	renewalTimeCalculator certificates.RenewalTimeFunc,
 	policyEvaluator policyEvaluatorFunc,
 	fieldManager string,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
 
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
@@ -105,8 +125,7 @@ func NewController(
 	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
 	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to the Secret named `spec.secretName`
-		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
-			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateSecretNameIndex),
 	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
@@ -123,6 +142,7 @@ func NewController(
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
 		client:                   client,
+		statusWriter:             statuswriter.New(client, nil),
 		gatherer: &policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
 			SecretLister:             secretsInformer.Lister(),
@@ -130,6 +150,8 @@ func NewController(
 		policyEvaluator:       policyEvaluator,
 		renewalTimeCalculator: renewalTimeCalculator,
 		fieldManager:          fieldManager,
+		queue:                 queue,
+		clock:                 clock,
 	}, queue, mustSync
 }
 
@@ -155,6 +177,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
 	input, err := c.gatherer.DataForCertificate(ctx, crt)
 	if err != nil {
 		return err
@@ -173,6 +200,8 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 			crt.Status.NotAfter = nil
 			crt.Status.NotBefore = nil
 			crt.Status.RenewalTime = nil
+			crt.Status.SerialNumber = ""
+			crt.Status.Certificate = nil
 			break
 		}
 
@@ -180,17 +209,35 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		notAfter := metav1.NewTime(x509cert.NotAfter)
 		renewBeforeHint := crt.Spec.RenewBefore
 		renewalTime := c.renewalTimeCalculator(x509cert.NotBefore, x509cert.NotAfter, renewBeforeHint)
+		renewalTime = certificates.AlignRenewalTime(crt, x509cert.NotBefore, renewalTime)
 
 		//update Certificate's Status
 		crt.Status.NotBefore = &notBefore
 		crt.Status.NotAfter = &notAfter
 		crt.Status.RenewalTime = renewalTime
+		crt.Status.SerialNumber = x509cert.SerialNumber.Text(16)
+		crt.Status.Certificate = certificateDetails(x509cert)
+
+		// re-evaluate the Certificate once it expires, so that it is no
+		// longer marked as Ready
+		if expiresIn := x509cert.NotAfter.Sub(c.clock.Now()); expiresIn > 0 {
+			c.queue.AddAfter(key, expiresIn)
+		}
+		// re-evaluate the Certificate once it is valid for clients whose
+		// clocks lag behind by its requested backdate
+		if backdate, err := apiutil.NotBeforeBackdate(crt.Annotations, nil); err == nil {
+			if validIn := x509cert.NotBefore.Add(backdate).Sub(c.clock.Now()); validIn > 0 {
+				c.queue.AddAfter(key, validIn)
+			}
+		}
 
 	default:
 		// clear status fields if the secret does not have any data
 		crt.Status.NotAfter = nil
 		crt.Status.NotBefore = nil
 		crt.Status.RenewalTime = nil
+		crt.Status.SerialNumber = ""
+		crt.Status.Certificate = nil
 	}
 	if !apiequality.Semantic.DeepEqual(oldCrt.Status, crt.Status) {
 		log.V(logf.DebugLevel).Info("updating status fields", "notAfter",
@@ -205,26 +252,73 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
 func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+	return c.statusWriter.Write(ctx, crt, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			var conditions []cmapi.CertificateCondition
+			if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
+				conditions = []cmapi.CertificateCondition{*cond}
+			}
+			return internalcertificates.ApplyStatus(ctx, cl, c.fieldManager, &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+				Status: cmapi.CertificateStatus{
+					NotAfter:     crt.Status.NotAfter,
+					NotBefore:    crt.Status.NotBefore,
+					RenewalTime:  crt.Status.RenewalTime,
+					SerialNumber: crt.Status.SerialNumber,
+					Certificate:  crt.Status.Certificate,
+					Conditions:   conditions,
+				},
+			})
+		} else {
+			_, err := cl.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+			return err
 		}
-		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
-			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status: cmapi.CertificateStatus{
-				NotAfter:    crt.Status.NotAfter,
-				NotBefore:   crt.Status.NotBefore,
-				RenewalTime: crt.Status.RenewalTime,
-				Conditions:  conditions,
-			},
-		})
-	} else {
-		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
-		return err
+	})
+}
+
+// maxSummarizedSANs is the number of subject alternative names listed in the
+// summary in the status of a Certificate, after which only the number of
+// remaining names is given.
+const maxSummarizedSANs = 5
+
+// certificateDetails describes the given certificate for the status of a
+// Certificate.
+func certificateDetails(cert *x509.Certificate) *cmapi.CertificateDetails {
+	fingerprint := sha256.Sum256(cert.Raw)
+	return &cmapi.CertificateDetails{
+		SerialNumber:      cert.SerialNumber.Text(16),
+		FingerprintSHA256: hex.EncodeToString(fingerprint[:]),
+		NotBefore:         metav1.NewTime(cert.NotBefore),
+		NotAfter:          metav1.NewTime(cert.NotAfter),
+		Subject:           cert.Subject.String(),
+		Issuer:            cert.Issuer.String(),
+		SubjectAltNames:   summarizeSANs(cert),
 	}
 }
 
+// summarizeSANs lists the first subject alternative names of the certificate,
+// followed by the number of names which are not listed.
+func summarizeSANs(cert *x509.Certificate) string {
+	var sans []string
+	for _, name := range cert.DNSNames {
+		sans = append(sans, "DNS:"+name)
+	}
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, "IP:"+ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, "URI:"+uri.String())
+	}
+	for _, email := range cert.EmailAddresses {
+		sans = append(sans, "email:"+email)
+	}
+
+	if len(sans) <= maxSummarizedSANs {
+		return strings.Join(sans, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(sans[:maxSummarizedSANs], ", "), len(sans)-maxSummarizedSANs)
+}
+
 // BuildReadyConditionFromChain builds Certificate's Ready condition using the result of policy chain evaluation
 func BuildReadyConditionFromChain(chain policies.Chain, input policies.Input) cmapi.CertificateCondition {
 	reason, message, violationsFound := chain.Evaluate(input)
@@ -258,11 +352,16 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
+		ctx.Clock,
 		policies.NewReadinessPolicyChain(ctx.Clock),
 		certificates.RenewalTime,
 		BuildReadyConditionFromChain,
 		ctx.FieldManager,
 	)
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+	ctrl.controllerClass = ctx.ControllerClass
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -18,12 +18,19 @@ package readiness
 
 import (
 	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
@@ -32,6 +39,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -110,6 +118,16 @@ func TestProcessItem(t *testing.T) {
 		"do nothing if a key references a Certificate that does not exist": {
 			key: "namespace/name",
 		},
+		"do nothing if the Certificate is managed by a different controller class": {
+			cert: gen.CertificateFrom(cert, gen.SetCertificateControllerName("team")),
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+		},
 		"update status for a Certificate that is evaluated as Ready and whose spec.secretName secret contains a valid X509 cert": {
 			condition: cmapi.CertificateCondition{
 				Type:               cmapi.CertificateConditionReady,
@@ -249,11 +267,19 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.cert)
 			}
 
+			var serialNumber string
+			var details *cmapi.CertificateDetails
 			if test.secretShouldExist {
 				mods := make([]gen.SecretModifier, 0)
 				// If the test scenario needs a secret with a valid X509 cert.
 				if test.notBefore != nil && test.notAfter != nil {
 					x509Bytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, test.notBefore.Time, test.notAfter.Time)
+					x509Cert, err := pki.DecodeX509CertificateBytes(x509Bytes)
+					if err != nil {
+						t.Fatal(err)
+					}
+					serialNumber = x509Cert.SerialNumber.Text(16)
+					details = certificateDetails(x509Cert)
 					mods = append(mods,
 						gen.SetSecretData(map[string][]byte{
 							"tls.crt": x509Bytes,
@@ -289,6 +315,8 @@ func TestProcessItem(t *testing.T) {
 				c.Status.NotAfter = test.notAfter
 				c.Status.NotBefore = test.notBefore
 				c.Status.RenewalTime = test.renewalTime
+				c.Status.SerialNumber = serialNumber
+				c.Status.Certificate = details
 
 				builder.ExpectedActions = append(builder.ExpectedActions,
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -326,6 +354,63 @@ func TestProcessItem(t *testing.T) {
 	}
 }
 
+func TestProcessItemRequeuesAtExpiry(t *testing.T) {
+	now := time.Now().UTC()
+	clock := fakeclock.NewFakeClock(now)
+	privKey := testcrypto.MustCreatePEMPrivateKey(t)
+	cert := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+		Spec: cmapi.CertificateSpec{
+			SecretName: "test-secret",
+			DNSNames:   []string{"example.com"},
+		},
+	}
+	x509Bytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, now.Add(-time.Hour), now.Add(time.Hour))
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{"tls.crt": x509Bytes}),
+	)
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              clock,
+		CertManagerObjects: []runtime.Object{cert},
+		KubeObjects:        []runtime.Object{secret},
+	}
+	builder.Init()
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+
+	// observe the requeues of the controller on a queue of its own, so that
+	// events from the informers do not interfere
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.DefaultControllerRateLimiter(), ControllerName)
+	defer queue.ShutDown()
+	w.controller.queue = queue
+
+	builder.Start()
+	defer builder.Stop()
+
+	if err := w.controller.ProcessItem(context.Background(), "testns/test"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The Certificate must not be re-evaluated before it expires.
+	testpkg.StepAndWaitForQueueLen(t, clock, time.Hour-time.Minute, queue, 0)
+	time.Sleep(50 * time.Millisecond)
+	if queue.Len() != 0 {
+		t.Fatalf("expected Certificate not to be re-evaluated before it expires")
+	}
+
+	testpkg.StepAndWaitForQueueLen(t, clock, time.Minute, queue, 1)
+	item, _ := queue.Get()
+	if got := item.(string); got != "testns/test" {
+		t.Errorf("expected Certificate testns/test to be re-evaluated, got %q", got)
+	}
+}
+
 // Test the evaluation of the ordered policy chain as a whole.
 func TestNewReadinessPolicyChain(t *testing.T) {
 	clock := &fakeclock.FakeClock{}
@@ -436,7 +521,7 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 						gen.Certificate("somethingelse",
 							gen.SetCertificateCommonName("old.example.com"))))),
 			reason:         policies.RequestChanged,
-			message:        "Fields on existing CertificateRequest resource not up to date: [spec.commonName]",
+			message:        `Fields on existing CertificateRequest resource not up to date: [spec.commonName: expected "new.example.com", got "old.example.com"]`,
 			violationFound: true,
 		},
 		"Certificate is not Ready when it has expired": {
@@ -532,3 +617,29 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 		})
 	}
 }
+
+func Test_certificateDetails(t *testing.T) {
+	cert := &x509.Certificate{
+		Raw:          []byte("certificate"),
+		SerialNumber: big.NewInt(0xabc),
+		NotBefore:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+		Subject:      pkix.Name{CommonName: "example.com", Organization: []string{"Example"}},
+		Issuer:       pkix.Name{CommonName: "Example CA"},
+		DNSNames:     []string{"example.com", "www.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+	}
+
+	assert.Equal(t, &cmapi.CertificateDetails{
+		SerialNumber:      "abc",
+		FingerprintSHA256: "03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72",
+		NotBefore:         metav1.NewTime(cert.NotBefore),
+		NotAfter:          metav1.NewTime(cert.NotAfter),
+		Subject:           "CN=example.com,O=Example",
+		Issuer:            "CN=Example CA",
+		SubjectAltNames:   "DNS:example.com, DNS:www.example.com, IP:10.0.0.1",
+	}, certificateDetails(cert))
+
+	cert.EmailAddresses = []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"}
+	assert.Equal(t, "DNS:example.com, DNS:www.example.com, IP:10.0.0.1, email:a@example.com, email:b@example.com and 2 more", summarizeSANs(cert))
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ControllerName is the name of the certificates report controller.
+	ControllerName = "certificates-report"
+
+	// ReportName is the name of the ClusterCertificateReport maintained by
+	// the controller.
+	ReportName = "certificates"
+
+	// resyncPeriod is the interval at which the report is rebuilt, so that
+	// Certificates move into the expiring and expired counts as time passes
+	// without changing themselves.
+	resyncPeriod = 5 * time.Minute
+
+	shortExpiryWindow = 7 * 24 * time.Hour
+	longExpiryWindow  = 30 * 24 * time.Hour
+)
+
+// controller maintains a ClusterCertificateReport summarising the
+// Certificates in the cluster, in total and for each issuer.
+// The queue only ever holds the name of the report, so that bursts of
+// changes to Certificates are coalesced into a single rebuild.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	reportLister      cmlisters.ClusterCertificateReportLister
+	cmClient          cmclient.Interface
+	clock             clock.Clock
+	queue             workqueue.RateLimitingInterface
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are not counted.
+	controllerClass string
+}
+
+// NewController returns a new certificates report controller.
+func NewController(
+	log logr.Logger,
+	cmClient cmclient.Interface,
+	cmFactory cminformers.SharedInformerFactory,
+	clock clock.Clock,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	reportInformer := cmFactory.Certmanager().V1().ClusterCertificateReports()
+
+	c := &controller{
+		certificateLister: certificateInformer.Lister(),
+		reportLister:      reportInformer.Lister(),
+		cmClient:          cmClient,
+		clock:             clock,
+		queue:             queue,
+	}
+
+	// Changes to Certificates and to the report itself cause the report to
+	// be rebuilt, so that a report which is edited or deleted is restored.
+	enqueue := func(interface{}) { queue.Add(ReportName) }
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: enqueue})
+	reportInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: enqueue})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		reportInformer.Informer().HasSynced,
+	}
+
+	return c, queue, mustSync
+}
+
+// ProcessItem is a worker function that will be called when the report is
+// pulled from the workqueue. ProcessItem rebuilds the report from the
+// Certificates in the cluster, updates it if it changed, and requeues it to
+// be rebuilt after the resync period.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+
+	if key != ReportName {
+		log.V(logf.DebugLevel).Info("ignoring unknown report")
+		return nil
+	}
+
+	crts, err := c.certificateLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	if err := c.updateReport(ctx, c.buildReport(crts)); err != nil {
+		return err
+	}
+
+	c.queue.AddAfter(key, resyncPeriod)
+	return nil
+}
+
+// buildReport counts the given Certificates, in total and for each issuer.
+func (c *controller) buildReport(crts []*cmapi.Certificate) *cmapi.ClusterCertificateReport {
+	now := c.clock.Now()
+	report := &cmapi.ClusterCertificateReport{
+		ObjectMeta: metav1.ObjectMeta{Name: ReportName},
+	}
+
+	issuers := make(map[cmapi.ClusterCertificateReportIssuer]*cmapi.ClusterCertificateReportSummary)
+	for _, crt := range crts {
+		if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+			continue
+		}
+
+		issuer := issuerFor(crt)
+		summary, ok := issuers[issuer]
+		if !ok {
+			summary = &cmapi.ClusterCertificateReportSummary{}
+			issuers[issuer] = summary
+		}
+
+		count(&report.Summary, crt, now)
+		count(summary, crt, now)
+	}
+
+	for issuer, summary := range issuers {
+		issuer.Summary = *summary
+		report.Issuers = append(report.Issuers, issuer)
+	}
+	sort.Slice(report.Issuers, func(i, j int) bool {
+		a, b := report.Issuers[i], report.Issuers[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return report
+}
+
+// issuerFor returns the issuer referenced by crt, without a summary, so that
+// it can be used as a map key.
+func issuerFor(crt *cmapi.Certificate) cmapi.ClusterCertificateReportIssuer {
+	ref := crt.Spec.IssuerRef
+	issuer := cmapi.ClusterCertificateReportIssuer{
+		Name: ref.Name,
+		Kind: apiutil.IssuerKind(ref),
+	}
+	if ref.Group != certmanager.GroupName {
+		issuer.Group = ref.Group
+	}
+	if issuer.Kind != cmapi.ClusterIssuerKind {
+		issuer.Namespace = apiutil.IssuerNamespace(ref, crt.Namespace)
+	}
+	return issuer
+}
+
+// count adds crt to summary.
+func count(summary *cmapi.ClusterCertificateReportSummary, crt *cmapi.Certificate, now time.Time) {
+	summary.Total++
+	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		summary.Ready++
+	}
+	if crt.Status.LastFailureTime != nil {
+		summary.FailedIssuances++
+	}
+	if crt.Status.NotAfter == nil {
+		return
+	}
+
+	remaining := crt.Status.NotAfter.Sub(now)
+	switch {
+	case remaining <= 0:
+		summary.Expired++
+	case remaining <= shortExpiryWindow:
+		summary.ExpiringWithin7Days++
+		summary.ExpiringWithin30Days++
+	case remaining <= longExpiryWindow:
+		summary.ExpiringWithin30Days++
+	}
+}
+
+// updateReport creates or updates the ClusterCertificateReport if it differs
+// from the desired report.
+func (c *controller) updateReport(ctx context.Context, desired *cmapi.ClusterCertificateReport) error {
+	existing, err := c.reportLister.Get(ReportName)
+	if apierrors.IsNotFound(err) {
+		_, err = c.cmClient.CertmanagerV1().ClusterCertificateReports().Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if apiequality.Semantic.DeepEqual(existing.Summary, desired.Summary) && apiequality.Semantic.DeepEqual(existing.Issuers, desired.Issuers) {
+		return nil
+	}
+
+	report := existing.DeepCopy()
+	report.Summary = desired.Summary
+	report.Issuers = desired.Issuers
+	_, err = c.cmClient.CertmanagerV1().ClusterCertificateReports().Update(ctx, report, metav1.UpdateOptions{})
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	// The report is cluster scoped and summarises every namespace, so it
+	// can't be written if only a single namespace is watched.
+	if ctx.Namespace != "" {
+		return nil, nil, fmt.Errorf("the %s controller requires cert-manager to watch all namespaces", ControllerName)
+	}
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.SharedInformerFactory,
+		ctx.Clock,
+	)
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
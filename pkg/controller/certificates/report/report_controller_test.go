@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestProcessItem(t *testing.T) {
+	notAfter := func(d time.Duration) gen.CertificateModifier {
+		return gen.SetCertificateNotAfter(metav1.NewTime(fixedClockStart.Add(d)))
+	}
+	ready := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	})
+	caIssuer := gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"})
+	acmeIssuer := gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "acme", Kind: cmapi.ClusterIssuerKind})
+	externalIssuer := gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "external", Kind: "ExternalIssuer", Group: "example.com"})
+
+	certificates := []runtime.Object{
+		gen.Certificate("ready", gen.SetCertificateNamespace("ns-a"), caIssuer, ready, notAfter(60*24*time.Hour)),
+		gen.Certificate("expiring-7d", gen.SetCertificateNamespace("ns-a"), caIssuer, ready, notAfter(3*24*time.Hour)),
+		gen.Certificate("expiring-30d", gen.SetCertificateNamespace("ns-b"), acmeIssuer, ready, notAfter(20*24*time.Hour)),
+		gen.Certificate("expired", gen.SetCertificateNamespace("ns-b"), acmeIssuer, notAfter(-time.Hour)),
+		gen.Certificate("failed", gen.SetCertificateNamespace("ns-c"), acmeIssuer, gen.SetCertificateLastFailureTime(metav1.NewTime(fixedClockStart))),
+		gen.Certificate("external", gen.SetCertificateNamespace("ns-c"), externalIssuer),
+		gen.Certificate("other-class", gen.SetCertificateNamespace("ns-c"), caIssuer, gen.SetCertificateControllerName("other")),
+	}
+
+	expected := &cmapi.ClusterCertificateReport{
+		ObjectMeta: metav1.ObjectMeta{Name: ReportName},
+		Summary:    cmapi.ClusterCertificateReportSummary{Total: 6, Ready: 3, ExpiringWithin7Days: 1, ExpiringWithin30Days: 2, Expired: 1, FailedIssuances: 1},
+		Issuers: []cmapi.ClusterCertificateReportIssuer{
+			{
+				Name:    "acme",
+				Kind:    cmapi.ClusterIssuerKind,
+				Summary: cmapi.ClusterCertificateReportSummary{Total: 3, Ready: 1, ExpiringWithin30Days: 1, Expired: 1, FailedIssuances: 1},
+			},
+			{
+				Name:      "ca",
+				Kind:      cmapi.IssuerKind,
+				Namespace: "ns-a",
+				Summary:   cmapi.ClusterCertificateReportSummary{Total: 2, Ready: 2, ExpiringWithin7Days: 1, ExpiringWithin30Days: 1},
+			},
+			{
+				Name:      "external",
+				Kind:      "ExternalIssuer",
+				Group:     "example.com",
+				Namespace: "ns-c",
+				Summary:   cmapi.ClusterCertificateReportSummary{Total: 1},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		existing *cmapi.ClusterCertificateReport
+
+		expectedVerb string
+	}{
+		"report is created if missing": {
+			expectedVerb: "create",
+		},
+		"report is updated if it changed": {
+			existing: &cmapi.ClusterCertificateReport{
+				ObjectMeta: metav1.ObjectMeta{Name: ReportName},
+				Summary:    cmapi.ClusterCertificateReportSummary{Total: 1},
+			},
+			expectedVerb: "update",
+		},
+		"report is left alone if it is up to date": {
+			existing: expected.DeepCopy(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmObjects := append([]runtime.Object{}, certificates...)
+			if test.existing != nil {
+				cmObjects = append(cmObjects, test.existing)
+			}
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(fixedClockStart),
+				CertManagerObjects: cmObjects,
+			}
+			builder.Init()
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), ReportName); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var verb string
+			var report *cmapi.ClusterCertificateReport
+			for _, action := range builder.FakeCMClient().Actions() {
+				switch a := action.(type) {
+				case coretesting.CreateAction:
+					verb, report = a.GetVerb(), a.GetObject().(*cmapi.ClusterCertificateReport)
+				case coretesting.UpdateAction:
+					verb, report = a.GetVerb(), a.GetObject().(*cmapi.ClusterCertificateReport)
+				}
+			}
+
+			if verb != test.expectedVerb {
+				t.Fatalf("expected verb %q, got %q", test.expectedVerb, verb)
+			}
+			if report == nil {
+				return
+			}
+			if !reflect.DeepEqual(report.Summary, expected.Summary) {
+				t.Errorf("expected summary %+v, got %+v", expected.Summary, report.Summary)
+			}
+			if !reflect.DeepEqual(report.Issuers, expected.Issuers) {
+				t.Errorf("expected issuers %+v, got %+v", expected.Issuers, report.Issuers)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"crypto"
 	"encoding/pem"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -38,7 +39,9 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -47,16 +50,25 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
 
 const (
-	ControllerName      = "certificates-request-manager"
-	reasonRequestFailed = "RequestFailed"
-	reasonRequested     = "Requested"
+	ControllerName                 = "certificates-request-manager"
+	reasonRequestFailed            = "RequestFailed"
+	reasonRequested                = "Requested"
+	reasonQuotaExceeded            = "QuotaExceeded"
+	reasonInvalidValidityAlignment = "InvalidValidityAlignment"
+
+	// maxCertificateRequestNameCollisions is the number of times a numbered
+	// suffix is appended to the name of a new CertificateRequest because the
+	// name is already in use, before giving up.
+	maxCertificateRequestNameCollisions = 5
 )
 
 var (
@@ -66,16 +78,33 @@ var (
 type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
+	certificateQuotaLister   cmlisters.CertificateQuotaLister
 	secretLister             corelisters.SecretLister
 	client                   cmclient.Interface
 	recorder                 record.EventRecorder
 	clock                    clock.Clock
 	copiedAnnotationPrefixes []string
 
+	// scheduledWorkQueue is used to retry Certificates once the issuance rate
+	// of a CertificateQuota allows a new CertificateRequest to be created.
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Create or Apply API calls.
 	fieldManager string
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+
+	// issuerHelper is used to look up the capabilities of the issuer of a
+	// Certificate. If nil, all usages are assumed to be supported.
+	issuerHelper issuer.Helper
+
+	// statusWriter is used to write the status of Certificates. It uses
+	// client unless replaced with the StatusWriter of the controller Context.
+	statusWriter *statuswriter.Writer
 }
 
 func NewController(
@@ -94,6 +123,7 @@ func NewController(
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
+	certificateQuotaInformer := cmFactory.Certmanager().V1().CertificateQuotas()
 	secretsInformer := factory.Core().V1().Secrets()
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
@@ -116,17 +146,21 @@ func NewController(
 		secretsInformer.Informer().HasSynced,
 		certificateRequestInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
+		certificateQuotaInformer.Informer().HasSynced,
 	}
 
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
+		certificateQuotaLister:   certificateQuotaInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
 		client:                   client,
 		recorder:                 recorder,
 		clock:                    clock,
 		copiedAnnotationPrefixes: certificateControllerOptions.CopiedAnnotationPrefixes,
+		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(clock, queue.Add),
 		fieldManager:             fieldManager,
+		statusWriter:             statuswriter.New(client, nil),
 	}, queue, mustSync
 }
 
@@ -149,6 +183,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
 	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
 		Status: cmmeta.ConditionTrue,
@@ -179,8 +218,9 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
-	// Discover all 'owned' CertificateRequests
-	requests, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt))
+	// Discover all 'owned' CertificateRequests, except for those of split
+	// issuances which are managed by the split issuance controller.
+	requests, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt), predicate.CertificateRequestSplitIssuance(""))
 	if err != nil {
 		return err
 	}
@@ -202,7 +242,12 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	requests, err = c.deleteRequestsNotMatchingSpec(ctx, crt, pk.Public(), requests...)
+	caps, err := c.issuerCapabilities(ctx, crt)
+	if err != nil {
+		return err
+	}
+
+	requests, err = c.deleteRequestsNotMatchingSpec(ctx, crt, caps, pk.Public(), requests...)
 	if err != nil {
 		return err
 	}
@@ -226,7 +271,87 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
-	return c.createNewCertificateRequest(ctx, crt, pk, nextRevision, nextPrivateKeySecret.Name)
+	delay, quotaName, err := c.issuanceQuotaDelay(crt.Namespace)
+	if err != nil {
+		return err
+	}
+	if delay > 0 {
+		log.V(logf.DebugLevel).Info("issuance rate of CertificateQuota exceeded, delaying creation of CertificateRequest", "quota", quotaName, "delay", delay)
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonQuotaExceeded, "Issuance is delayed by %s to stay within the issuance rate of CertificateQuota %q", delay.Round(time.Second), quotaName)
+		c.scheduledWorkQueue.Add(key, delay)
+		return nil
+	}
+
+	usages, dropped := filterUsages(crt, caps)
+	if len(dropped) > 0 && (caps.UnsupportedUsagesPolicy == cmapi.FailUnsupportedUsages || len(usages) == 0) {
+		return c.failUnsupportedUsages(ctx, crt, dropped)
+	}
+
+	if err := c.createNewCertificateRequest(ctx, crt, pk, nextRevision, nextPrivateKeySecret.Name, usages, dropped); err != nil {
+		return err
+	}
+	return c.setUsagesDroppedCondition(ctx, crt, dropped)
+}
+
+// issuanceQuotaDelay returns how long the creation of a new CertificateRequest
+// in the given namespace has to be delayed for to stay within the issuance
+// rate of all CertificateQuotas in the namespace, along with the name of the
+// CertificateQuota which requires the longest delay.
+// Only CertificateRequests which still exist and were created for a
+// Certificate are counted towards the issuance rate.
+func (c *controller) issuanceQuotaDelay(namespace string) (time.Duration, string, error) {
+	quotas, err := c.certificateQuotaLister.CertificateQuotas(namespace).List(labels.Everything())
+	if err != nil {
+		return 0, "", err
+	}
+
+	var requests []*cmapi.CertificateRequest
+	var delay time.Duration
+	var quotaName string
+	now := c.clock.Now()
+	for _, quota := range quotas {
+		rate := quota.Spec.IssuanceRate
+		if rate == nil {
+			continue
+		}
+		period := rate.Period.Duration
+
+		if requests == nil {
+			requests, err = c.certificateRequestLister.CertificateRequests(namespace).List(labels.Everything())
+			if err != nil {
+				return 0, "", err
+			}
+		}
+
+		var created []time.Time
+		for _, req := range requests {
+			if _, ok := req.Annotations[cmapi.CertificateNameKey]; !ok {
+				continue
+			}
+			if t := req.CreationTimestamp.Time; now.Sub(t) < period {
+				created = append(created, t)
+			}
+		}
+		if len(created) < int(rate.MaxIssuances) {
+			continue
+		}
+
+		// A new CertificateRequest can be created once enough of the
+		// CertificateRequests within the period have become older than the
+		// period. If no issuances are allowed at all, check again after a
+		// period in case the CertificateQuota has changed.
+		wait := period
+		if rate.MaxIssuances > 0 {
+			sort.Slice(created, func(i, j int) bool { return created[i].Before(created[j]) })
+			wait = created[len(created)-int(rate.MaxIssuances)].Add(period).Sub(now)
+		}
+		if wait > delay {
+			delay = wait
+			quotaName = quota.Name
+		}
+	}
+
+	return delay, quotaName, nil
 }
 
 func (c *controller) deleteCurrentFailedRequests(ctx context.Context, crt *cmapi.Certificate, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
@@ -316,12 +441,12 @@ func requestsWithRevision(reqs []*cmapi.CertificateRequest, revision int) ([]*cm
 	return remaining, nil
 }
 
-func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cmapi.Certificate, publicKey crypto.PublicKey, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
+func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cmapi.Certificate, caps *cmapi.IssuerCapabilities, publicKey crypto.PublicKey, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
 	log := logf.FromContext(ctx)
 	var remaining []*cmapi.CertificateRequest
 	for _, req := range reqs {
 		log := logf.WithRelatedResource(log, req)
-		violations, err := certificates.RequestMatchesSpec(req, crt.Spec)
+		violations, err := certificates.RequestMatchesSpec(req, crt.Spec, caps)
 		if err != nil {
 			log.Error(err, "Failed to check if CertificateRequest matches spec, deleting CertificateRequest")
 			if err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{}); err != nil {
@@ -330,7 +455,7 @@ func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cma
 			continue
 		}
 		if len(violations) > 0 {
-			log.V(logf.InfoLevel).WithValues("violations", violations).Info("CertificateRequest does not match requirements on certificate.spec, deleting CertificateRequest", "violations", violations)
+			log.V(logf.InfoLevel).WithValues("violations", violations.String()).Info("CertificateRequest does not match requirements on certificate.spec, deleting CertificateRequest")
 			if err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{}); err != nil {
 				return nil, err
 			}
@@ -357,9 +482,21 @@ func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cma
 	return remaining, nil
 }
 
-func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string) error {
+// createNewCertificateRequest creates a CertificateRequest for the given
+// revision of the Certificate, requesting the given usages. Usages which are
+// not supported by the issuer have been dropped from them, and are listed in
+// an annotation of the request.
+func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string, usages, dropped []cmapi.KeyUsage) error {
 	log := logf.FromContext(ctx)
-	x509CSR, err := pki.GenerateCSR(crt)
+
+	// The CSR is generated for a copy of the Certificate which requests only
+	// the supported usages.
+	requestCrt := crt
+	if len(dropped) > 0 {
+		requestCrt = crt.DeepCopy()
+		requestCrt.Spec.Usages = usages
+	}
+	x509CSR, err := pki.GenerateCSR(requestCrt)
 	if err != nil {
 		log.Error(err, "Failed to generate CSR - will not retry")
 		return nil
@@ -379,27 +516,53 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 	annotations[cmapi.CertificateRequestRevisionAnnotationKey] = strconv.Itoa(nextRevision)
 	annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = nextPrivateKeySecretName
 	annotations[cmapi.CertificateNameKey] = crt.Name
+	if len(dropped) > 0 {
+		annotations[cmapi.CertificateRequestDroppedUsagesAnnotationKey] = joinUsages(dropped)
+	}
+	// The backdate is always copied, as it is needed by the issuer even if
+	// annotations of the Certificate are not copied.
+	if backdate, ok := crt.Annotations[cmapi.NotBeforeBackdateAnnotationKey]; ok {
+		annotations[cmapi.NotBeforeBackdateAnnotationKey] = backdate
+	}
+
+	duration := crt.Spec.Duration
+	alignment, err := certificates.ValidityAlignmentForCertificate(crt)
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonInvalidValidityAlignment, "Not aligning the validity of the certificate: %v", err)
+	}
+	if alignment != nil {
+		requested := apiutil.DefaultCertDuration(crt.Spec.Duration)
+		duration = &metav1.Duration{Duration: alignment.Duration(c.clock.Now(), requested)}
+		annotations[cmapi.ValidityAlignmentAnnotationKey] = crt.Annotations[cmapi.ValidityAlignmentAnnotationKey]
+	}
+
+	crLabels := make(map[string]string)
+	for k, v := range crt.Labels {
+		crLabels[k] = v
+	}
+	for k, v := range internalcertificates.LabelsForCertificate(crt, &nextRevision) {
+		crLabels[k] = v
+	}
 
 	cr := &cmapi.CertificateRequest{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:       crt.Namespace,
 			GenerateName:    apiutil.DNSSafeShortenTo52Characters(crt.Name) + "-",
 			Annotations:     annotations,
-			Labels:          crt.Labels,
+			Labels:          crLabels,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)},
 		},
 		Spec: cmapi.CertificateRequestSpec{
-			Duration:  crt.Spec.Duration,
+			Duration:  duration,
 			IssuerRef: crt.Spec.IssuerRef,
 			Request:   csrPEM.Bytes(),
 			IsCA:      crt.Spec.IsCA,
-			Usages:    crt.Spec.Usages,
+			Usages:    requestCrt.Spec.Usages,
 		},
 	}
 
 	if utilfeature.DefaultFeatureGate.Enabled(feature.StableCertificateRequestName) {
-		cr.ObjectMeta.GenerateName = ""
-		cr.ObjectMeta.Name = apiutil.DNSSafeShortenTo52Characters(crt.Name) + "-" + fmt.Sprintf("%d", nextRevision)
+		return c.createStableCertificateRequest(ctx, crt, cr, nextRevision)
 	}
 
 	cr, err = c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{FieldManager: c.fieldManager})
@@ -410,19 +573,92 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 
 	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRequested, "Created new CertificateRequest resource %q", cr.Name)
 
-	// If the StableCertificateRequestName feature gate is enabled, skip waiting for our informer cache/lister to
-	// observe the creation event and instead rely on an AlreadyExists error being returned if we do attempt a
-	// CREATE for the same CertificateRequest name again early.
-	if utilfeature.DefaultFeatureGate.Enabled(feature.StableCertificateRequestName) {
-		return nil
-	}
-
 	if err := c.waitForCertificateRequestToExist(cr.Namespace, cr.Name); err != nil {
 		return fmt.Errorf("failed whilst waiting for CertificateRequest to exist - this may indicate an apiserver running slowly. Request will be retried. %w", err)
 	}
 	return nil
 }
 
+// createStableCertificateRequest creates the given CertificateRequest for the
+// given revision of the Certificate, with a name derived from the UID of the
+// Certificate and the revision.
+// As the name is the same each time a CertificateRequest is created for the
+// revision, a request created before the controller restarted, or before it
+// was observed by the informer cache, causes an AlreadyExists error rather
+// than a duplicate pending request. In that case the existing request is kept.
+// If the name is taken by any other CertificateRequest, such as a request for
+// the revision which is being deleted, a numbered suffix is appended to the
+// name until a free one is found.
+func (c *controller) createStableCertificateRequest(ctx context.Context, crt *cmapi.Certificate, cr *cmapi.CertificateRequest, revision int) error {
+	log := logf.FromContext(ctx)
+
+	cr.ObjectMeta.GenerateName = ""
+	for attempt := 0; attempt <= maxCertificateRequestNameCollisions; attempt++ {
+		name, err := certificateRequestName(crt, revision, attempt)
+		if err != nil {
+			return err
+		}
+		cr.ObjectMeta.Name = name
+
+		created, err := c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{FieldManager: c.fieldManager})
+		if err == nil {
+			c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRequested, "Created new CertificateRequest resource %q", created.Name)
+			// The informer cache/lister is not waited for to observe the
+			// creation, as creating the request again early fails with an
+			// AlreadyExists error which is handled below.
+			return nil
+		}
+		if !apierrors.IsAlreadyExists(err) {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "Failed to create CertificateRequest: "+err.Error())
+			return err
+		}
+
+		existing, err := c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// The existing request has been deleted since, so the name can
+			// be retried on the next sync.
+			return fmt.Errorf("CertificateRequest %q was deleted whilst creating a new CertificateRequest, request will be retried", name)
+		}
+		if err != nil {
+			return err
+		}
+		if isRequestForRevision(existing, crt, revision) {
+			log.V(logf.DebugLevel).Info("CertificateRequest for the revision already exists", "name", name, "revision", revision)
+			return nil
+		}
+		log.V(logf.DebugLevel).Info("CertificateRequest name is already in use, trying the next name", "name", name)
+	}
+
+	err := fmt.Errorf("all %d names for the CertificateRequest of revision %d are already in use", maxCertificateRequestNameCollisions+1, revision)
+	c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "Failed to create CertificateRequest: "+err.Error())
+	return err
+}
+
+// certificateRequestName returns the name to use for the CertificateRequest
+// of the given revision of the Certificate. attempt is the number of names
+// which have already been tried and found to be in use.
+func certificateRequestName(crt *cmapi.Certificate, revision, attempt int) (string, error) {
+	name, err := apiutil.ComputeName(crt.Name, struct {
+		UID      string
+		Revision int
+	}{string(crt.UID), revision})
+	if err != nil {
+		return "", err
+	}
+	if attempt > 0 {
+		name = fmt.Sprintf("%s-%d", name, attempt)
+	}
+	return name, nil
+}
+
+// isRequestForRevision returns whether the given CertificateRequest was
+// created for the given revision of the Certificate, and is not being deleted.
+func isRequestForRevision(req *cmapi.CertificateRequest, crt *cmapi.Certificate, revision int) bool {
+	return req.DeletionTimestamp == nil &&
+		metav1.IsControlledBy(req, crt) &&
+		req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey] == strconv.Itoa(revision)
+}
+
 func (c *controller) waitForCertificateRequestToExist(namespace, name string) error {
 	return wait.Poll(time.Millisecond*100, time.Second*5, func() (bool, error) {
 		_, err := c.certificateRequestLister.CertificateRequests(namespace).Get(name)
@@ -455,6 +691,14 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CertificateOptions,
 		ctx.FieldManager,
 	)
+	ctrl.controllerClass = ctx.ControllerClass
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+
+	var issuerSynced []cache.InformerSynced
+	ctrl.issuerHelper, issuerSynced = issuer.NewHelperFromContext(ctx)
+	mustSync = append(mustSync, issuerSynced...)
 	c.controller = ctrl
 
 	return queue, mustSync, nil
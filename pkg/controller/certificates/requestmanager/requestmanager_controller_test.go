@@ -31,10 +31,12 @@ import (
 	"k8s.io/component-base/featuregate"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -65,6 +67,17 @@ func relaxedCertificateRequestMatcher(l coretesting.Action, r coretesting.Action
 	return nil
 }
 
+// certificateRequestLabels returns the labels expected on the
+// CertificateRequests created for the given revision of the test Certificate.
+func certificateRequestLabels(revision string) map[string]string {
+	return map[string]string{
+		cmapi.CertificateNameLabelKey:     "test",
+		cmapi.CertificateRevisionLabelKey: revision,
+		cmapi.IssuerNameLabelKey:          "",
+		cmapi.IssuerKindLabelKey:          cmapi.IssuerKind,
+	}
+}
+
 func TestProcessItem(t *testing.T) {
 	bundle1 := mustCreateCryptoBundle(t, &cmapi.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
@@ -92,6 +105,29 @@ func TestProcessItem(t *testing.T) {
 	)
 	fixedNow := metav1.NewTime(time.Now())
 	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+	issuanceRateQuota := &cmapi.CertificateQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "quota"},
+		Spec: cmapi.CertificateQuotaSpec{
+			IssuanceRate: &cmapi.CertificateQuotaIssuanceRate{MaxIssuances: 1, Period: metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	otherCertificateRequest := func(created time.Time) *cmapi.CertificateRequest {
+		return &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "testns",
+				Name:              "other-1",
+				Annotations:       map[string]string{cmapi.CertificateNameKey: "other"},
+				CreationTimestamp: metav1.NewTime(created),
+			},
+		}
+	}
+	stableName := func(revision int) string {
+		name, err := certificateRequestName(bundle1.certificate, revision, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return name
+	}
 	failedCRConditionPreviousIssuance := cmapi.CertificateRequestCondition{
 		Type:               cmapi.CertificateRequestConditionReady,
 		Status:             cmmeta.ConditionFalse,
@@ -104,6 +140,37 @@ func TestProcessItem(t *testing.T) {
 		Reason:             cmapi.CertificateRequestReasonFailed,
 		LastTransitionTime: &metav1.Time{Time: fixedNow.Time.Add(1 * time.Minute)},
 	}
+	// A Certificate requesting the 'client auth' usage, which is not
+	// supported by its issuer.
+	usagesBundle := mustCreateCryptoBundle(t, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "testns",
+			Name:      "test",
+			UID:       "test",
+		},
+		Spec: cmapi.CertificateSpec{
+			CommonName: "test-usages",
+			IssuerRef:  cmmeta.ObjectReference{Name: "issuer"},
+			Usages:     []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageClientAuth},
+		}},
+	)
+	usagesCertificate := gen.CertificateFrom(usagesBundle.certificate,
+		gen.SetCertificateNextPrivateKeySecretName("exists"),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+	)
+	usagesSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "exists"},
+		Data:       map[string][]byte{corev1.TLSPrivateKeyKey: usagesBundle.privateKeyBytes},
+	}
+	issuerWithCapabilities := func(policy cmapi.UnsupportedUsagesPolicy) *cmapi.Issuer {
+		return gen.Issuer("issuer", gen.SetIssuerNamespace("testns"), func(iss cmapi.GenericIssuer) {
+			iss.GetSpec().Capabilities = &cmapi.IssuerCapabilities{
+				Usages:                  []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth},
+				UnsupportedUsagesPolicy: policy,
+			}
+		})
+	}
+
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem.
 		// if not set, the 'namespace/name' of the 'Certificate' field will be used.
@@ -111,7 +178,8 @@ func TestProcessItem(t *testing.T) {
 		key string
 
 		// Featuregates to set for a particular test.
-		featuresToEnable []featuregate.Feature
+		featuresToEnable  []featuregate.Feature
+		featuresToDisable []featuregate.Feature
 
 		// Certificate to be synced for the test.
 		// if not set, the 'key' will be passed to ProcessItem instead.
@@ -122,6 +190,12 @@ func TestProcessItem(t *testing.T) {
 		// Request, if set, will exist in the apiserver before the test is run.
 		requests []runtime.Object
 
+		// Quotas, if set, will exist in the apiserver before the test is run.
+		quotas []runtime.Object
+
+		// Issuers, if set, will exist in the apiserver before the test is run.
+		issuers []runtime.Object
+
 		expectedActions []testpkg.Action
 
 		expectedEvents []string
@@ -189,7 +263,7 @@ func TestProcessItem(t *testing.T) {
 				gen.SetCertificateNextPrivateKeySecretName("exists"),
 				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
 			),
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(1))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
 					gen.CertificateRequestFrom(bundle1.certificateRequest,
@@ -197,11 +271,112 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("1")),
+						gen.SetCertificateRequestName(stableName(1)),
+						gen.SetCertificateRequestGenerateName(""),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
+		"drop usages which are not supported by the issuer from the CertificateRequest": {
+			secrets:     []runtime.Object{usagesSecret},
+			issuers:     []runtime.Object{issuerWithCapabilities("")},
+			certificate: usagesCertificate,
+			expectedEvents: []string{
+				fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(1)),
+				`Warning UsagesDropped The usages "client auth" were left out of the CertificateRequest as the issuer does not support them`,
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(usagesBundle.certificateRequest,
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestPrivateKeyAnnotationKey:    "exists",
+							cmapi.CertificateRequestRevisionAnnotationKey:      "1",
+							cmapi.CertificateRequestDroppedUsagesAnnotationKey: "client auth",
+						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("1")),
+						gen.AddCertificateRequestLabels(map[string]string{cmapi.IssuerNameLabelKey: "issuer"}),
+						gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment),
+						gen.SetCertificateRequestName(stableName(1)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(usagesCertificate,
+						gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+							Type:               cmapi.CertificateConditionUsagesDropped,
+							Status:             cmmeta.ConditionTrue,
+							Reason:             "UsagesDropped",
+							Message:            `The usages "client auth" were left out of the CertificateRequest as the issuer does not support them`,
+							LastTransitionTime: &fixedNow,
+						}),
+					))),
+			},
+		},
+		"fail the issuance if the issuer is configured to fail on unsupported usages": {
+			secrets:     []runtime.Object{usagesSecret},
+			issuers:     []runtime.Object{issuerWithCapabilities(cmapi.FailUnsupportedUsages)},
+			certificate: usagesCertificate,
+			expectedEvents: []string{
+				`Warning UnsupportedUsages The issuer does not support the requested usages "client auth", so no CertificateRequest was created`,
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(usagesCertificate,
+						gen.SetCertificateLastFailureTime(fixedNow),
+						gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+						gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+							Type:               cmapi.CertificateConditionIssuing,
+							Status:             cmmeta.ConditionFalse,
+							Reason:             "UnsupportedUsages",
+							Message:            `The issuer does not support the requested usages "client auth", so no CertificateRequest was created`,
+							LastTransitionTime: &fixedNow,
+						}),
+					))),
 			},
 		},
-		"create a CertificateRequest if none exists and StableCertificateRequestName enabled": {
-			featuresToEnable: []featuregate.Feature{feature.StableCertificateRequestName},
+		"delay creating a CertificateRequest if the issuance rate of a CertificateQuota would be exceeded": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: bundle1.certificate.Namespace, Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle1.privateKeyBytes},
+				},
+			},
+			quotas:   []runtime.Object{issuanceRateQuota},
+			requests: []runtime.Object{otherCertificateRequest(fixedNow.Add(-10 * time.Minute))},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			expectedEvents: []string{`Warning QuotaExceeded Issuance is delayed by 50m0s to stay within the issuance rate of CertificateQuota "quota"`},
+		},
+		"create a CertificateRequest if the issuance rate of a CertificateQuota allows it": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: bundle1.certificate.Namespace, Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle1.privateKeyBytes},
+				},
+			},
+			quotas:   []runtime.Object{issuanceRateQuota},
+			requests: []runtime.Object{otherCertificateRequest(fixedNow.Add(-2 * time.Hour))},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(1))},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(bundle1.certificateRequest,
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
+						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("1")),
+						gen.SetCertificateRequestName(stableName(1)),
+						gen.SetCertificateRequestGenerateName(""),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
+		"create a CertificateRequest with a generated name if StableCertificateRequestName is disabled": {
+			featuresToDisable: []featuregate.Feature{feature.StableCertificateRequestName},
 			secrets: []runtime.Object{
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{Namespace: bundle3.certificate.Namespace, Name: "exists"},
@@ -212,16 +387,15 @@ func TestProcessItem(t *testing.T) {
 				gen.SetCertificateNextPrivateKeySecretName("exists"),
 				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
 			),
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-1"`},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
 			expectedActions: []testpkg.Action{
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
 					gen.CertificateRequestFrom(bundle3.certificateRequest,
-						gen.SetCertificateRequestName("test-1"),
-						gen.SetCertificateRequestGenerateName(""),
 						gen.SetCertificateRequestAnnotations(map[string]string{
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("1")),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -244,7 +418,7 @@ func TestProcessItem(t *testing.T) {
 					}),
 				),
 			},
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(1))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test")),
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
@@ -253,6 +427,9 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("1")),
+						gen.SetCertificateRequestName(stableName(1)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -275,7 +452,7 @@ func TestProcessItem(t *testing.T) {
 					}),
 				),
 			},
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(1))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test")),
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
@@ -284,6 +461,9 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("1")),
+						gen.SetCertificateRequestName(stableName(1)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -327,7 +507,7 @@ func TestProcessItem(t *testing.T) {
 					gen.SetCertificateRequestCSR([]byte("invalid")),
 				),
 			},
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(1))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test")),
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
@@ -336,6 +516,9 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("1")),
+						gen.SetCertificateRequestName(stableName(1)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -365,7 +548,7 @@ func TestProcessItem(t *testing.T) {
 					}),
 				),
 			},
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(1))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
 					gen.CertificateRequestFrom(bundle1.certificateRequest,
@@ -373,6 +556,9 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("1")),
+						gen.SetCertificateRequestName(stableName(1)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -404,7 +590,7 @@ func TestProcessItem(t *testing.T) {
 					}),
 				),
 			},
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(1))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test")),
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
@@ -413,6 +599,9 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("1")),
+						gen.SetCertificateRequestName(stableName(1)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -445,7 +634,7 @@ func TestProcessItem(t *testing.T) {
 					}),
 				),
 			},
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(6))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test")),
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
@@ -454,6 +643,9 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "6",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("6")),
+						gen.SetCertificateRequestName(stableName(6)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -477,7 +669,7 @@ func TestProcessItem(t *testing.T) {
 					}),
 				),
 			},
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(6))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test")),
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
@@ -486,6 +678,9 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "6",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("6")),
+						gen.SetCertificateRequestName(stableName(6)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -510,7 +705,7 @@ func TestProcessItem(t *testing.T) {
 					}),
 				),
 			},
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(6))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test")),
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
@@ -519,6 +714,9 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "6",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("6")),
+						gen.SetCertificateRequestName(stableName(6)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -593,7 +791,7 @@ func TestProcessItem(t *testing.T) {
 					gen.SetCertificateRequestFailureTime(metav1.Time{Time: fixedNow.Time.Add(time.Hour * -1)}),
 				),
 			},
-			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, stableName(6))},
 			expectedActions: []testpkg.Action{
 				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test")),
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
@@ -602,6 +800,9 @@ func TestProcessItem(t *testing.T) {
 							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
 							cmapi.CertificateRequestRevisionAnnotationKey:   "6",
 						}),
+						gen.AddCertificateRequestLabels(certificateRequestLabels("6")),
+						gen.SetCertificateRequestName(stableName(6)),
+						gen.SetCertificateRequestGenerateName(""),
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
@@ -646,6 +847,8 @@ func TestProcessItem(t *testing.T) {
 				builder.KubeObjects = append(builder.KubeObjects, test.secrets...)
 			}
 			builder.CertManagerObjects = append(builder.CertManagerObjects, test.requests...)
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.quotas...)
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.issuers...)
 			builder.Init()
 
 			// Register informers used by the controller using the registration wrapper
@@ -659,6 +862,9 @@ func TestProcessItem(t *testing.T) {
 			for _, feature := range test.featuresToEnable {
 				defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature, true)()
 			}
+			for _, feature := range test.featuresToDisable {
+				defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature, false)()
+			}
 
 			// Start the informers and begin processing updates
 			builder.Start()
@@ -697,3 +903,101 @@ func TestProcessItem(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateStableCertificateRequest(t *testing.T) {
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: "test-uid"},
+	}
+	otherCrt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: "other-uid"},
+	}
+	name := func(attempt int) string {
+		name, err := certificateRequestName(crt, 2, attempt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return name
+	}
+	request := func(name string, owner *cmapi.Certificate, revision string, mods ...gen.CertificateRequestModifier) runtime.Object {
+		return gen.CertificateRequest(name, append([]gen.CertificateRequestModifier{
+			gen.SetCertificateRequestNamespace("testns"),
+			gen.SetCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: revision}),
+			func(cr *cmapi.CertificateRequest) {
+				cr.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, certificateGvk)}
+			},
+		}, mods...)...)
+	}
+	deleting := func(cr *cmapi.CertificateRequest) {
+		now := metav1.Now()
+		cr.DeletionTimestamp = &now
+		cr.Finalizers = []string{"example.com/finalizer"}
+	}
+
+	var allNamesTaken []runtime.Object
+	for attempt := 0; attempt <= maxCertificateRequestNameCollisions; attempt++ {
+		allNamesTaken = append(allNamesTaken, request(name(attempt), otherCrt, "2"))
+	}
+
+	tests := map[string]struct {
+		existing  []runtime.Object
+		expName   string
+		expEvents []string
+		expErr    bool
+	}{
+		"create the request with the name for the revision": {
+			expName:   name(0),
+			expEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, name(0))},
+		},
+		"keep the request for the revision if it already exists": {
+			existing: []runtime.Object{request(name(0), crt, "2")},
+			expName:  name(0),
+		},
+		"append a suffix if the name is used by a request of another Certificate": {
+			existing:  []runtime.Object{request(name(0), otherCrt, "2")},
+			expName:   name(1),
+			expEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, name(1))},
+		},
+		"append a suffix if the request for the revision is being deleted": {
+			existing:  []runtime.Object{request(name(0), crt, "2", deleting)},
+			expName:   name(1),
+			expEvents: []string{fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, name(1))},
+		},
+		"keep a request with a suffix if it already exists": {
+			existing: []runtime.Object{request(name(0), otherCrt, "2"), request(name(1), crt, "2")},
+			expName:  name(1),
+		},
+		"fail if all names are in use": {
+			existing:  allNamesTaken,
+			expEvents: []string{fmt.Sprintf(`Warning RequestFailed Failed to create CertificateRequest: all %d names for the CertificateRequest of revision 2 are already in use`, maxCertificateRequestNameCollisions+1)},
+			expErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := cmfake.NewSimpleClientset(test.existing...)
+			recorder := new(testpkg.FakeRecorder)
+			c := &controller{client: client, recorder: recorder}
+
+			cr := request("", crt, "2").(*cmapi.CertificateRequest)
+			err := c.createStableCertificateRequest(context.Background(), crt, cr, 2)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(test.expEvents, recorder.Events) {
+				t.Errorf("unexpected events, exp=%v, got=%v", test.expEvents, recorder.Events)
+			}
+			if test.expName == "" {
+				return
+			}
+
+			got, err := client.CertmanagerV1().CertificateRequests("testns").Get(context.Background(), test.expName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected CertificateRequest %q to exist: %v", test.expName, err)
+			}
+			if !isRequestForRevision(got, crt, 2) {
+				t.Errorf("expected CertificateRequest %q to be for revision 2 of the Certificate", test.expName)
+			}
+		})
+	}
+}
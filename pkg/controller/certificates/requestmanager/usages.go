@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+const (
+	reasonUnsupportedUsages = "UnsupportedUsages"
+	reasonUsagesDropped     = "UsagesDropped"
+)
+
+// filterUsages splits the usages requested by crt into those which are
+// supported according to the given issuer capabilities and those which are
+// not. If the Certificate doesn't set spec.usages, the default usages are
+// checked. If no usages are declared by the capabilities, all usages are
+// supported and the requested usages are returned as they are.
+func filterUsages(crt *cmapi.Certificate, caps *cmapi.IssuerCapabilities) (supported, unsupported []cmapi.KeyUsage) {
+	if caps == nil || len(caps.Usages) == 0 {
+		return crt.Spec.Usages, nil
+	}
+
+	requested := crt.Spec.Usages
+	if len(requested) == 0 {
+		requested = cmapi.DefaultKeyUsages()
+	}
+	for _, usage := range requested {
+		if containsUsage(caps.Usages, usage) {
+			supported = append(supported, usage)
+		} else {
+			unsupported = append(unsupported, usage)
+		}
+	}
+	if len(unsupported) == 0 {
+		return crt.Spec.Usages, nil
+	}
+	return supported, unsupported
+}
+
+func containsUsage(usages []cmapi.KeyUsage, usage cmapi.KeyUsage) bool {
+	for _, u := range usages {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
+
+func joinUsages(usages []cmapi.KeyUsage) string {
+	s := make([]string, len(usages))
+	for i, usage := range usages {
+		s[i] = string(usage)
+	}
+	return strings.Join(s, ",")
+}
+
+// issuerCapabilities returns the capabilities declared by the issuer of crt,
+// which are empty if the issuer doesn't declare any. Nil is returned if the
+// issuer doesn't exist yet, so its capabilities are not known.
+func (c *controller) issuerCapabilities(ctx context.Context, crt *cmapi.Certificate) (*cmapi.IssuerCapabilities, error) {
+	if c.issuerHelper == nil {
+		return nil, nil
+	}
+	iss, err := c.issuerHelper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		logf.FromContext(ctx).V(logf.DebugLevel).Info("issuer not found, not checking the usages of the certificate against its capabilities")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if caps := iss.GetSpec().Capabilities; caps != nil {
+		return caps, nil
+	}
+	return &cmapi.IssuerCapabilities{}, nil
+}
+
+// failUnsupportedUsages fails the issuance of crt without creating a
+// CertificateRequest, as it requests usages which its issuer does not
+// support and the issuer is configured to fail such issuances, or none of the
+// requested usages are supported. The issuance is retried with the usual
+// backoff.
+func (c *controller) failUnsupportedUsages(ctx context.Context, crt *cmapi.Certificate, unsupported []cmapi.KeyUsage) error {
+	message := fmt.Sprintf("The issuer does not support the requested usages %q, so no CertificateRequest was created", joinUsages(unsupported))
+
+	crt = crt.DeepCopy()
+	now := metav1.NewTime(c.clock.Now())
+	crt.Status.LastFailureTime = &now
+	failedIssuanceAttempts := 1
+	if crt.Status.FailedIssuanceAttempts != nil {
+		failedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts + 1
+	}
+	crt.Status.FailedIssuanceAttempts = &failedIssuanceAttempts
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reasonUnsupportedUsages, message)
+	if err := c.updateOrApplyStatus(ctx, crt, true); err != nil {
+		return err
+	}
+
+	c.recorder.Event(crt, corev1.EventTypeWarning, reasonUnsupportedUsages, message)
+	return nil
+}
+
+// setUsagesDroppedCondition sets the UsagesDropped condition of crt if usages
+// were left out of its latest CertificateRequest, and removes it otherwise.
+func (c *controller) setUsagesDroppedCondition(ctx context.Context, crt *cmapi.Certificate, dropped []cmapi.KeyUsage) error {
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionUsagesDropped)
+	if len(dropped) == 0 && cond == nil {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	if len(dropped) == 0 {
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionUsagesDropped)
+	} else {
+		message := fmt.Sprintf("The usages %q were left out of the CertificateRequest as the issuer does not support them", joinUsages(dropped))
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionUsagesDropped, cmmeta.ConditionTrue, reasonUsagesDropped, message)
+		c.recorder.Event(crt, corev1.EventTypeWarning, reasonUsagesDropped, message)
+	}
+	return c.updateOrApplyStatus(ctx, crt, false)
+}
+
+// updateOrApplyStatus will update the controller status. If the
+// ServerSideApply feature is enabled, the managed fields will instead get
+// applied using the relevant Patch API call. The Issuing condition and the
+// failure fields of the status are only applied if failed is true.
+func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate, failed bool) error {
+	return c.statusWriter.Write(ctx, crt, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			var status cmapi.CertificateStatus
+			if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionUsagesDropped); cond != nil {
+				status.Conditions = append(status.Conditions, *cond)
+			}
+			if failed {
+				if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
+					status.Conditions = append(status.Conditions, *cond)
+				}
+				status.LastFailureTime = crt.Status.LastFailureTime
+				status.FailedIssuanceAttempts = crt.Status.FailedIssuanceAttempts
+			}
+			return internalcertificates.ApplyStatus(ctx, cl, c.fieldManager, &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+				Status:     status,
+			})
+		} else {
+			_, err := cl.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+			return err
+		}
+	})
+}
@@ -51,6 +51,10 @@ type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
 	client                   cmclient.Interface
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
 }
 
 type revision struct {
@@ -110,6 +114,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
 	log = logf.WithResource(log, crt)
 
 	// If RevisionHistoryLimit is nil, don't attempt to garbage collect old
@@ -126,9 +135,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
-	// Get all CertificateRequests that are owned by this Certificate
+	// Get all CertificateRequests that are owned by this Certificate, except
+	// for those of split issuances which do not have a revision.
 	requests, err := certificates.ListCertificateRequestsMatchingPredicates(
-		c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt))
+		c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt), predicate.CertificateRequestSplitIssuance(""))
 	if err != nil {
 		return err
 	}
@@ -206,6 +216,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	log := logf.FromContext(ctx.RootContext, ControllerName)
 
 	ctrl, queue, mustSync := NewController(log, ctx.CMClient, ctx.SharedInformerFactory)
+	ctrl.controllerClass = ctx.ControllerClass
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocationcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// checkTimeout is the maximum time taken by a single request to an OCSP
+	// responder.
+	checkTimeout = 10 * time.Second
+
+	// maxResponseSize is the maximum size of the responses read from OCSP
+	// responders and ACME servers.
+	maxResponseSize = 1 << 20
+)
+
+// renewalInfo is the ACME renewal information of a certificate, as defined
+// by RFC 9773.
+type renewalInfo struct {
+	SuggestedWindow struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+	} `json:"suggestedWindow"`
+	ExplanationURL string `json:"explanationURL,omitempty"`
+}
+
+// fetchOCSP requests the revocation status of cert, which was signed by
+// issuer, from the first OCSP responder named in cert.
+func fetchOCSP(ctx context.Context, client *http.Client, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, cert.OCSPServer[0], bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+
+	body, err := do(client, httpReq)
+	if err != nil {
+		return nil, err
+	}
+	return ocsp.ParseResponseForCert(body, cert, issuer)
+}
+
+// fetchRenewalInfo requests the ACME renewal information of cert from the
+// ACME server with the given directory URL.
+func fetchRenewalInfo(ctx context.Context, client *http.Client, directoryURL string, cert *x509.Certificate) (*renewalInfo, error) {
+	certID, err := renewalInfoCertID(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, directoryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := do(client, req)
+	if err != nil {
+		return nil, err
+	}
+	var directory struct {
+		RenewalInfo string `json:"renewalInfo"`
+	}
+	if err := json.Unmarshal(body, &directory); err != nil {
+		return nil, fmt.Errorf("failed to decode ACME directory: %w", err)
+	}
+	if directory.RenewalInfo == "" {
+		return nil, errors.New("the ACME server does not support renewal information")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(directory.RenewalInfo, "/")+"/"+certID, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err = do(client, req)
+	if err != nil {
+		return nil, err
+	}
+	var info renewalInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode renewal information: %w", err)
+	}
+	return &info, nil
+}
+
+// renewalInfoCertID returns the identifier of cert used in ACME renewal
+// information requests, made up of its authority key identifier and the DER
+// encoding of its serial number.
+func renewalInfoCertID(cert *x509.Certificate) (string, error) {
+	if len(cert.AuthorityKeyId) == 0 {
+		return "", errors.New("the certificate has no authority key identifier")
+	}
+	serial := cert.SerialNumber.Bytes()
+	// A DER encoded positive integer has a leading zero byte if its most
+	// significant bit is set.
+	if len(serial) == 0 || serial[0]&0x80 != 0 {
+		serial = append([]byte{0}, serial...)
+	}
+	return base64.RawURLEncoding.EncodeToString(cert.AuthorityKeyId) + "." + base64.RawURLEncoding.EncodeToString(serial), nil
+}
+
+// do sends req using client, and returns the body of a successful response.
+func do(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %q from %s", resp.Status, req.URL)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+}
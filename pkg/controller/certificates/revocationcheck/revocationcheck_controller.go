@@ -0,0 +1,362 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocationcheck
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/issuer/httpclient"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// ControllerName is the name of the revocation check controller.
+	ControllerName = "certificates-revocation-check"
+
+	reasonRevoked          = "Revoked"
+	reasonRenewalSuggested = "RenewalSuggested"
+)
+
+// controller periodically checks whether the certificates of Certificates
+// have been revoked, using the OCSP responder named in the certificate. A
+// revoked certificate is marked for emergency re-issuance using the
+// `cert-manager.io/emergency` annotation, which the trigger controller acts on
+// as soon as it observes the update.
+// For Certificates issued by an ACME issuer, the ACME renewal information
+// (ARI) of the ACME server is checked as well. A suggested renewal window
+// which has ended is only reported using an event, as it doesn't mean that
+// the certificate has been revoked.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	client            cmclient.Interface
+	recorder          record.EventRecorder
+	queue             workqueue.RateLimitingInterface
+	clock             clock.Clock
+	helper            issuer.Helper
+	issuerOptions     controllerpkg.IssuerOptions
+	metrics           *metrics.Metrics
+
+	// httpClient is used to send requests to OCSP responders.
+	httpClient *http.Client
+
+	// interval is the interval at which each Certificate is checked.
+	interval time.Duration
+
+	// The following are used for testing purposes.
+	fetchOCSP        func(ctx context.Context, client *http.Client, cert, issuer *x509.Certificate) (*ocsp.Response, error)
+	fetchRenewalInfo func(ctx context.Context, client *http.Client, directoryURL string, cert *x509.Certificate) (*renewalInfo, error)
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+// NewController returns a new revocation check controller.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	issuerOptions controllerpkg.IssuerOptions,
+	watchClusterIssuers bool,
+	interval time.Duration,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretsInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateSecretNameIndex),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if watchClusterIssuers {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+	}
+	grantLister, grantsSynced := issuer.IssuerReferenceGrantLister(cmFactory)
+	mustSync = append(mustSync, grantsSynced...)
+
+	ctrl := &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      secretsInformer.Lister(),
+		client:            client,
+		recorder:          recorder,
+		queue:             queue,
+		clock:             clock,
+		helper:            issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister, grantLister),
+		issuerOptions:     issuerOptions,
+		httpClient:        &http.Client{Timeout: checkTimeout},
+		interval:          interval,
+
+		// The following are used for testing purposes.
+		fetchOCSP:        fetchOCSP,
+		fetchRenewalInfo: fetchRenewalInfo,
+	}
+
+	return ctrl, queue, mustSync
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a Certificate to be re-synced is pulled from the workqueue.
+// ProcessItem checks whether the certificate of the Certificate has been
+// revoked, and requeues the Certificate to be checked again after the
+// configured interval.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
+	if err := c.checkRevocation(ctx, crt); err != nil {
+		return err
+	}
+
+	c.queue.AddAfter(key, c.interval)
+	return nil
+}
+
+func (c *controller) checkRevocation(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	chain, err := pki.DecodeX509CertificateChainBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to decode certificate in secret, waiting for it to be re-issued", "error", err.Error())
+		return nil
+	}
+	cert := chain[0]
+
+	// There is nothing more to do once the current certificate has been
+	// marked for emergency re-issuance.
+	if serial := markedSerial(crt); serial != nil && serial.Cmp(cert.SerialNumber) == 0 {
+		return nil
+	}
+
+	message, revoked := c.checkOCSP(ctx, cert, issuerOf(cert, chain, secret.Data[cmmeta.TLSCAKey]))
+	if !revoked {
+		if message, renew := c.checkRenewalInfo(ctx, crt, cert); renew {
+			c.recorder.Event(crt, corev1.EventTypeNormal, reasonRenewalSuggested, message)
+		}
+		return nil
+	}
+
+	log.V(logf.InfoLevel).Info("certificate has been revoked, marking it for emergency re-issuance", "serial", cert.SerialNumber.Text(16), "message", message)
+	crt = crt.DeepCopy()
+	if crt.Annotations == nil {
+		crt.Annotations = make(map[string]string)
+	}
+	crt.Annotations[cmapi.EmergencyReissuanceAnnotationKey] = cert.SerialNumber.Text(16)
+	if _, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevoked, "%s, marked the certificate for emergency re-issuance", message)
+	return nil
+}
+
+// checkOCSP returns whether the OCSP responder named in cert reports it as
+// revoked, and a message describing the revocation. Certificates which don't
+// name an OCSP responder, or whose issuer is not known, are not checked.
+func (c *controller) checkOCSP(ctx context.Context, cert, issuer *x509.Certificate) (string, bool) {
+	if len(cert.OCSPServer) == 0 || issuer == nil {
+		return "", false
+	}
+
+	resp, err := c.fetchOCSP(ctx, c.httpClient, cert, issuer)
+	if err != nil {
+		logf.FromContext(ctx).V(logf.DebugLevel).Info("failed to check certificate revocation using OCSP", "responder", cert.OCSPServer[0], "error", err.Error())
+		return "", false
+	}
+	if resp.Status != ocsp.Revoked {
+		return "", false
+	}
+	return fmt.Sprintf("The OCSP responder %s reports that the certificate with serial number %s was revoked at %s", cert.OCSPServer[0], cert.SerialNumber.Text(16), resp.RevokedAt.Format(time.RFC3339)), true
+}
+
+// checkRenewalInfo returns whether the ACME server of the issuer of crt asks
+// for cert to be renewed immediately, and a message describing the request.
+// Certificates which are not issued by an ACME issuer are not checked.
+func (c *controller) checkRenewalInfo(ctx context.Context, crt *cmapi.Certificate, cert *x509.Certificate) (string, bool) {
+	log := logf.FromContext(ctx)
+
+	if crt.Spec.IssuerRef.Group != "" && crt.Spec.IssuerRef.Group != cmapi.SchemeGroupVersion.Group {
+		return "", false
+	}
+	iss, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if err != nil || iss.GetSpec().ACME == nil {
+		return "", false
+	}
+
+	httpClientConfig, err := httpclient.Load(iss, c.issuerOptions.ResourceNamespace(iss), c.secretLister)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to load HTTP client configuration of issuer", "error", err.Error())
+		return "", false
+	}
+	client := accounts.BuildHTTPClientWithConfig(c.metrics, iss.GetSpec().ACME.SkipTLSVerify, httpClientConfig)
+
+	info, err := c.fetchRenewalInfo(ctx, client, iss.GetSpec().ACME.Server, cert)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to check certificate revocation using ACME renewal information", "server", iss.GetSpec().ACME.Server, "error", err.Error())
+		return "", false
+	}
+	if info.SuggestedWindow.End.After(c.clock.Now()) {
+		return "", false
+	}
+
+	message := fmt.Sprintf("The ACME server %s asks for the certificate with serial number %s to be renewed immediately", iss.GetSpec().ACME.Server, cert.SerialNumber.Text(16))
+	if info.ExplanationURL != "" {
+		message += fmt.Sprintf(" (see %s)", info.ExplanationURL)
+	}
+	return message, true
+}
+
+// markedSerial returns the serial number in the emergency re-issuance
+// annotation of crt, or nil if it isn't set or invalid.
+func markedSerial(crt *cmapi.Certificate) *big.Int {
+	serial, ok := new(big.Int).SetString(strings.ReplaceAll(crt.Annotations[cmapi.EmergencyReissuanceAnnotationKey], ":", ""), 16)
+	if !ok {
+		return nil
+	}
+	return serial
+}
+
+// issuerOf returns the certificate of the CA which signed cert, taken from
+// the rest of its chain or the CA certificate stored alongside it, or nil if
+// neither contains it.
+func issuerOf(cert *x509.Certificate, chain []*x509.Certificate, caData []byte) *x509.Certificate {
+	candidates := chain[1:]
+	if ca, err := pki.DecodeX509CertificateBytes(caData); err == nil {
+		candidates = append(candidates, ca)
+	}
+	for _, candidate := range candidates {
+		if cert.CheckSignatureFrom(candidate) == nil {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	if ctx.RevocationCheckInterval <= 0 {
+		return nil, nil, fmt.Errorf("the %s controller requires a revocation check interval to be configured", ControllerName)
+	}
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.IssuerOptions,
+		ctx.Namespace == "",
+		ctx.RevocationCheckInterval,
+	)
+	ctrl.metrics = ctx.Metrics
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocationcheck
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// mustCreateChain returns a PEM encoded leaf certificate with the given
+// serial number, which names an OCSP responder, and the CA certificate
+// which signed it.
+func mustCreateChain(t *testing.T, serial int64) ([]byte, *x509.Certificate, []byte) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		OCSPServer:   []string{"http://ocsp.example.com"},
+	}, ca, key.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(leafDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leafPEM, err := pki.EncodeX509(leaf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPEM, err := pki.EncodeX509(ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leafPEM, ca, caPEM
+}
+
+func TestProcessItem(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	revokedAt := fixedClock.Now().Add(-time.Hour).UTC()
+
+	leafPEM, ca, caPEM := mustCreateChain(t, 0x1234)
+
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.IssuerKind}),
+	)
+	markedCrt := gen.CertificateFrom(baseCrt, gen.AddCertificateAnnotations(map[string]string{
+		cmapi.EmergencyReissuanceAnnotationKey: "1234",
+	}))
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: leafPEM, cmmeta.TLSCAKey: caPEM}),
+	)
+	acmeIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerACME(cmacme.ACMEIssuer{Server: "https://acme.example.com/directory"}),
+	)
+	caIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}),
+	)
+
+	good := &ocsp.Response{Status: ocsp.Good}
+	revoked := &ocsp.Response{Status: ocsp.Revoked, RevokedAt: revokedAt}
+	renewalInfoEnding := func(end time.Time) *renewalInfo {
+		info := &renewalInfo{ExplanationURL: "https://acme.example.com/incident"}
+		info.SuggestedWindow.Start = end.Add(-time.Hour)
+		info.SuggestedWindow.End = end
+		return info
+	}
+
+	tests := map[string]struct {
+		crt         *cmapi.Certificate
+		issuer      *cmapi.Issuer
+		ocspResp    *ocsp.Response
+		renewalInfo *renewalInfo
+		expCheck    bool
+		expUpdate   *cmapi.Certificate
+		expEvents   []string
+	}{
+		"do nothing if the OCSP responder reports the certificate as good": {
+			crt:      baseCrt,
+			issuer:   caIssuer,
+			ocspResp: good,
+			expCheck: true,
+		},
+		"do nothing if the OCSP responder can't be reached": {
+			crt:      baseCrt,
+			issuer:   caIssuer,
+			expCheck: true,
+		},
+		"mark the certificate for emergency re-issuance if the OCSP responder reports it as revoked": {
+			crt:       baseCrt,
+			issuer:    caIssuer,
+			ocspResp:  revoked,
+			expCheck:  true,
+			expUpdate: markedCrt,
+			expEvents: []string{"Warning Revoked The OCSP responder http://ocsp.example.com reports that the certificate with serial number 1234 was revoked at " + revokedAt.Format(time.RFC3339) + ", marked the certificate for emergency re-issuance"},
+		},
+		"do nothing if the suggested renewal window of the ACME server has not ended": {
+			crt:         baseCrt,
+			issuer:      acmeIssuer,
+			ocspResp:    good,
+			renewalInfo: renewalInfoEnding(fixedClock.Now().Add(time.Hour)),
+			expCheck:    true,
+		},
+		"only record an event if the suggested renewal window of the ACME server has ended": {
+			crt:         baseCrt,
+			issuer:      acmeIssuer,
+			ocspResp:    good,
+			renewalInfo: renewalInfoEnding(fixedClock.Now().Add(-time.Minute)),
+			expCheck:    true,
+			expEvents:   []string{"Normal RenewalSuggested The ACME server https://acme.example.com/directory asks for the certificate with serial number 1234 to be renewed immediately (see https://acme.example.com/incident)"},
+		},
+		"mark the certificate for emergency re-issuance if the OCSP responder reports it as revoked and the ACME server asks for renewal": {
+			crt:       baseCrt,
+			issuer:    acmeIssuer,
+			ocspResp:  revoked,
+			expCheck:  true,
+			expUpdate: markedCrt,
+			expEvents: []string{"Warning Revoked The OCSP responder http://ocsp.example.com reports that the certificate with serial number 1234 was revoked at " + revokedAt.Format(time.RFC3339) + ", marked the certificate for emergency re-issuance"},
+		},
+		"do nothing if the certificate has already been marked for emergency re-issuance": {
+			crt:      gen.CertificateFrom(baseCrt, gen.AddCertificateAnnotations(map[string]string{cmapi.EmergencyReissuanceAnnotationKey: "12:34"})),
+			issuer:   caIssuer,
+			ocspResp: revoked,
+		},
+		"check the certificate if a previous certificate has been marked for emergency re-issuance": {
+			crt:       gen.CertificateFrom(baseCrt, gen.AddCertificateAnnotations(map[string]string{cmapi.EmergencyReissuanceAnnotationKey: "abcd"})),
+			issuer:    caIssuer,
+			ocspResp:  revoked,
+			expCheck:  true,
+			expUpdate: markedCrt,
+			expEvents: []string{"Warning Revoked The OCSP responder http://ocsp.example.com reports that the certificate with serial number 1234 was revoked at " + revokedAt.Format(time.RFC3339) + ", marked the certificate for emergency re-issuance"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{test.crt, test.issuer},
+				KubeObjects:        []runtime.Object{secret},
+				ExpectedEvents:     test.expEvents,
+			}
+			if test.expUpdate != nil {
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						test.expUpdate.Namespace,
+						test.expUpdate)))
+			}
+			builder.Init()
+			builder.RevocationCheckInterval = time.Hour
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			checked := false
+			w.controller.fetchOCSP = func(_ context.Context, _ *http.Client, _, issuer *x509.Certificate) (*ocsp.Response, error) {
+				checked = true
+				if !issuer.Equal(ca) {
+					t.Errorf("unexpected issuer certificate %q", issuer.Subject)
+				}
+				if test.ocspResp == nil {
+					return nil, errors.New("connection refused")
+				}
+				return test.ocspResp, nil
+			}
+			w.controller.fetchRenewalInfo = func(_ context.Context, _ *http.Client, directoryURL string, _ *x509.Certificate) (*renewalInfo, error) {
+				if directoryURL != "https://acme.example.com/directory" {
+					t.Errorf("unexpected directory URL %q", directoryURL)
+				}
+				if test.renewalInfo == nil {
+					t.Error("unexpected renewal information request")
+					return nil, errors.New("not found")
+				}
+				return test.renewalInfo, nil
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if checked != test.expCheck {
+				t.Errorf("unexpected OCSP check, exp=%t got=%t", test.expCheck, checked)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+func TestRenewalInfoCertID(t *testing.T) {
+	// The example from RFC 9773, section 4.1.
+	cert := &x509.Certificate{
+		AuthorityKeyId: []byte{0x69, 0x88, 0x5b, 0x6b, 0x87, 0x46, 0x40, 0x41, 0xe1, 0xb3, 0x7b, 0x84, 0x7b, 0xa0, 0xae, 0x2c, 0xde, 0x01, 0xc8, 0xd4},
+		SerialNumber:   big.NewInt(0x87654321),
+	}
+	certID, err := renewalInfoCertID(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE"; certID != exp {
+		t.Errorf("unexpected cert ID, exp=%s got=%s", exp, certID)
+	}
+
+	if _, err := renewalInfoCertID(&x509.Certificate{SerialNumber: big.NewInt(1)}); err == nil {
+		t.Error("expected an error for a certificate without an authority key identifier")
+	}
+}
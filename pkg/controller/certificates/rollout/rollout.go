@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/pkg/api/conditions"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// defaultSoakPeriod is the soak period of Issuers which don't set the
+// rollout-soak-period annotation.
+const defaultSoakPeriod = 24 * time.Hour
+
+// rolloutConfig is the canary rollout configuration of an Issuer, read from
+// its annotations.
+type rolloutConfig struct {
+	canarySelector labels.Selector
+	soakPeriod     time.Duration
+	approved       bool
+}
+
+// configFor returns the canary rollout configuration of iss, or nil if iss
+// has not opted in to canary rollouts.
+func configFor(iss cmapi.GenericIssuer) (*rolloutConfig, error) {
+	annotations := iss.GetAnnotations()
+	selector, ok := annotations[cmapi.RolloutCanarySelectorAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	config := &rolloutConfig{soakPeriod: defaultSoakPeriod}
+	var err error
+	if config.canarySelector, err = labels.Parse(selector); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", cmapi.RolloutCanarySelectorAnnotationKey, err)
+	}
+	if soakPeriod, ok := annotations[cmapi.RolloutSoakPeriodAnnotationKey]; ok {
+		if config.soakPeriod, err = time.ParseDuration(soakPeriod); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", cmapi.RolloutSoakPeriodAnnotationKey, err)
+		}
+	}
+	config.approved = annotations[cmapi.RolloutApprovedGenerationAnnotationKey] == strconv.FormatInt(iss.GetGeneration(), 10)
+	return config, nil
+}
+
+// issuerKey returns the queue key of an Issuer or ClusterIssuer. Keys are
+// prefixed with the kind, as both kinds are processed by the same queue.
+func issuerKey(kind, namespace, name string) string {
+	if len(namespace) == 0 {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+func splitIssuerKey(key string) (kind, namespace, name string, err error) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return "", "", "", fmt.Errorf("unexpected key format: %q", key)
+	}
+	namespace, name, err = cache.SplitMetaNamespaceKey(parts[1])
+	return parts[0], namespace, name, err
+}
+
+// issuerKeyFor returns the queue key of the Issuer or ClusterIssuer that crt
+// references, or false if it references an external issuer.
+func issuerKeyFor(crt *cmapi.Certificate) (string, bool) {
+	ref := crt.Spec.IssuerRef
+	if len(ref.Group) > 0 && ref.Group != "cert-manager.io" {
+		return "", false
+	}
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		return issuerKey(cmapi.IssuerKind, crt.Namespace, ref.Name), true
+	case cmapi.ClusterIssuerKind:
+		return issuerKey(cmapi.ClusterIssuerKind, "", ref.Name), true
+	default:
+		return "", false
+	}
+}
+
+// rolloutCondition returns the RolledOut condition of iss, or nil if it has
+// not been set.
+func rolloutCondition(iss cmapi.GenericIssuer) *cmapi.IssuerCondition {
+	return conditions.Get(iss.GetStatus().Conditions, string(cmapi.IssuerConditionRolledOut))
+}
+
+// isIssuing returns true if the issuance of crt has been triggered and has
+// not yet completed.
+func isIssuing(crt *cmapi.Certificate) bool {
+	return apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionIssuing,
+		Status: cmmeta.ConditionTrue,
+	})
+}
+
+// isHealthy returns true if crt has been issued and is Ready.
+func isHealthy(crt *cmapi.Certificate) bool {
+	return !isIssuing(crt) && apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	})
+}
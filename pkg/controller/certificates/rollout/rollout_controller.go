@@ -0,0 +1,442 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
+	"github.com/cert-manager/cert-manager/pkg/api/conditions"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+const (
+	// ControllerName is the name of the canary rollout controller.
+	ControllerName = "certificates-rollout"
+
+	reasonInvalidRollout  = "InvalidRollout"
+	reasonRolloutCanary   = "RolloutCanary"
+	reasonRolloutComplete = "RolloutComplete"
+
+	// reasonIssuerRollout is the reason of the Issuing condition set on
+	// Certificates renewed by a rollout.
+	reasonIssuerRollout = "IssuerRollout"
+)
+
+// controller renews the Certificates referencing an Issuer or ClusterIssuer
+// after a change to its spec, for Issuers which opt in using the
+// rollout-canary-selector annotation. The Certificates matching the canary
+// selector are renewed first, and the remaining Certificates are only renewed
+// once the canaries have been Ready for the soak period of the Issuer, or the
+// rollout has been approved using the rollout-approved-generation annotation.
+// The progress of a rollout is tracked using the RolledOut condition of the
+// Issuer, whose observedGeneration is the generation being rolled out.
+type controller struct {
+	certificateLister   cmlisters.CertificateLister
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	client              cmclient.Interface
+	recorder            record.EventRecorder
+	clock               clock.Clock
+	queue               workqueue.RateLimitingInterface
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Apply API calls.
+	fieldManager string
+
+	// statusWriter is used to write the status of Certificates. It uses
+	// client unless replaced with the StatusWriter of the controller Context.
+	statusWriter *statuswriter.Writer
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+// NewController returns a new canary rollout controller. ClusterIssuers are
+// only watched if watchClusterIssuers is true, i.e. if the controller is not
+// scoped to a single namespace.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	fieldManager string,
+	watchClusterIssuers bool,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueIssuer(log, queue, cmapi.IssuerKind),
+	})
+	// When a Certificate changes, its Issuer is enqueued so that the rollout
+	// proceeds as soon as the canary Certificates are Ready.
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueIssuerForCertificate(log, queue),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+	}
+
+	ctrl := &controller{
+		certificateLister: certificateInformer.Lister(),
+		issuerLister:      issuerInformer.Lister(),
+		client:            client,
+		recorder:          recorder,
+		clock:             clock,
+		queue:             queue,
+		fieldManager:      fieldManager,
+		statusWriter:      statuswriter.New(client, nil),
+	}
+
+	if watchClusterIssuers {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+			WorkFunc: enqueueIssuer(log, queue, cmapi.ClusterIssuerKind),
+		})
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		ctrl.clusterIssuerLister = clusterIssuerInformer.Lister()
+	}
+
+	return ctrl, queue, mustSync
+}
+
+func enqueueIssuer(log logr.Logger, queue workqueue.Interface, kind string) func(obj interface{}) {
+	return func(obj interface{}) {
+		iss, ok := obj.(cmapi.GenericIssuer)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-issuer type resource passed to enqueueIssuer")
+			return
+		}
+		queue.Add(issuerKey(kind, iss.GetNamespace(), iss.GetName()))
+	}
+}
+
+func enqueueIssuerForCertificate(log logr.Logger, queue workqueue.Interface) func(obj interface{}) {
+	return func(obj interface{}) {
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-Certificate type resource passed to enqueueIssuerForCertificate")
+			return
+		}
+		if key, ok := issuerKeyFor(crt); ok {
+			queue.Add(key)
+		}
+	}
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to an Issuer or ClusterIssuer to be re-synced is pulled from
+// the workqueue. ProcessItem starts a rollout when the generation of the
+// Issuer changes, and completes it once the soak period of the canary
+// Certificates has elapsed or the rollout has been approved.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	kind, namespace, name, err := splitIssuerKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	iss, err := c.getGenericIssuer(kind, namespace, name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("issuer not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, iss.GetSpec().ControllerName) {
+		log.V(logf.DebugLevel).Info("issuer is managed by a different controller class, skipping")
+		return nil
+	}
+
+	config, err := configFor(iss)
+	if err != nil {
+		c.recorder.Event(iss, corev1.EventTypeWarning, reasonInvalidRollout, err.Error())
+		return nil
+	}
+	if config == nil {
+		return nil
+	}
+
+	generation := iss.GetGeneration()
+	cond := rolloutCondition(iss)
+	switch {
+	case cond == nil:
+		// The current generation of an Issuer which has just opted in is
+		// assumed to have already been rolled out.
+		return c.setRolloutCondition(ctx, iss, cmmeta.ConditionTrue, reasonRolloutComplete,
+			fmt.Sprintf("Generation %d of the issuer is rolled out", generation))
+
+	case cond.ObservedGeneration != generation:
+		return c.startRollout(ctx, key, iss, config)
+
+	case cond.Status == cmmeta.ConditionTrue:
+		return nil
+	}
+
+	crts, err := c.certificatesFor(iss)
+	if err != nil {
+		return err
+	}
+
+	var remaining []*cmapi.Certificate
+	for _, crt := range crts {
+		if !config.canarySelector.Matches(labels.Set(crt.Labels)) {
+			remaining = append(remaining, crt)
+			continue
+		}
+		if !config.approved && !isHealthy(crt) {
+			// The Issuer is enqueued again when the Certificate changes.
+			log.V(logf.DebugLevel).Info("waiting for canary certificate to be ready", "certificate", crt.Name)
+			return nil
+		}
+	}
+
+	if !config.approved && cond.LastTransitionTime != nil {
+		if soakEnd := cond.LastTransitionTime.Add(config.soakPeriod); c.clock.Now().Before(soakEnd) {
+			c.queue.AddAfter(key, soakEnd.Sub(c.clock.Now()))
+			return nil
+		}
+	}
+
+	if err := c.triggerIssuance(ctx, iss, remaining); err != nil {
+		return err
+	}
+	message := fmt.Sprintf("Generation %d of the issuer is rolled out to %d Certificates", generation, len(remaining))
+	c.recorder.Event(iss, corev1.EventTypeNormal, reasonRolloutComplete, message)
+	return c.setRolloutCondition(ctx, iss, cmmeta.ConditionTrue, reasonRolloutComplete, message)
+}
+
+// startRollout renews the canary Certificates of iss and marks the current
+// generation of iss as being rolled out.
+func (c *controller) startRollout(ctx context.Context, key string, iss cmapi.GenericIssuer, config *rolloutConfig) error {
+	crts, err := c.certificatesFor(iss)
+	if err != nil {
+		return err
+	}
+
+	var canaries []*cmapi.Certificate
+	for _, crt := range crts {
+		if config.canarySelector.Matches(labels.Set(crt.Labels)) {
+			canaries = append(canaries, crt)
+		}
+	}
+	if err := c.triggerIssuance(ctx, iss, canaries); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Generation %d of the issuer is rolled out to %d canary Certificates", iss.GetGeneration(), len(canaries))
+	c.recorder.Event(iss, corev1.EventTypeNormal, reasonRolloutCanary, message)
+	if err := c.setRolloutCondition(ctx, iss, cmmeta.ConditionFalse, reasonRolloutCanary, message); err != nil {
+		return err
+	}
+
+	// The soak period is checked again once it has elapsed.
+	c.queue.AddAfter(key, config.soakPeriod)
+	return nil
+}
+
+// certificatesFor returns the Certificates managed by this controller which
+// reference iss.
+func (c *controller) certificatesFor(iss cmapi.GenericIssuer) ([]*cmapi.Certificate, error) {
+	kind := cmapi.IssuerKind
+	var crts []*cmapi.Certificate
+	var err error
+	if _, isClusterIssuer := iss.(*cmapi.ClusterIssuer); isClusterIssuer {
+		kind = cmapi.ClusterIssuerKind
+		crts, err = c.certificateLister.List(labels.Everything())
+	} else {
+		crts, err = c.certificateLister.Certificates(iss.GetNamespace()).List(labels.Everything())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := issuerKey(kind, iss.GetNamespace(), iss.GetName())
+	var matching []*cmapi.Certificate
+	for _, crt := range crts {
+		if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+			continue
+		}
+		if crtKey, ok := issuerKeyFor(crt); ok && crtKey == key {
+			matching = append(matching, crt)
+		}
+	}
+	return matching, nil
+}
+
+// triggerIssuance triggers the issuance of crts by setting their Issuing
+// condition. Certificates which are already being issued are skipped.
+func (c *controller) triggerIssuance(ctx context.Context, iss cmapi.GenericIssuer, crts []*cmapi.Certificate) error {
+	for _, crt := range crts {
+		if isIssuing(crt) {
+			continue
+		}
+
+		crt = crt.DeepCopy()
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, reasonIssuerRollout,
+			fmt.Sprintf("Issuing certificate as generation %d of the issuer is being rolled out", iss.GetGeneration()))
+		if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+			return fmt.Errorf("failed to trigger issuance of Certificate %s/%s: %w", crt.Namespace, crt.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	return c.statusWriter.Write(ctx, crt, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			return internalcertificates.ApplyStatus(ctx, cl, c.fieldManager, &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+				Status:     cmapi.CertificateStatus{Conditions: []cmapi.CertificateCondition{*apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)}},
+			})
+		} else {
+			_, err := cl.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+			return err
+		}
+	})
+}
+
+// setRolloutCondition sets the RolledOut condition of iss for its current
+// generation. The lastTransitionTime of the condition is always updated, as
+// it records when the rollout of the generation started or completed.
+func (c *controller) setRolloutCondition(ctx context.Context, iss cmapi.GenericIssuer, status cmmeta.ConditionStatus, reason, message string) error {
+	now := metav1.NewTime(c.clock.Now())
+	cond := cmapi.IssuerCondition{
+		Type:               cmapi.IssuerConditionRolledOut,
+		Status:             status,
+		LastTransitionTime: &now,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: iss.GetGeneration(),
+	}
+
+	switch iss := iss.DeepCopyObject().(type) {
+	case *cmapi.Issuer:
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			return internalissuers.ApplyIssuerStatus(ctx, c.client, c.fieldManager, &cmapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: iss.Namespace, Name: iss.Name},
+				Status:     cmapi.IssuerStatus{Conditions: []cmapi.IssuerCondition{cond}},
+			})
+		}
+		iss.Status.Conditions, _ = conditions.Set(iss.Status.Conditions, cond, now.Time)
+		_, err := c.client.CertmanagerV1().Issuers(iss.Namespace).UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+		return err
+
+	case *cmapi.ClusterIssuer:
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			return internalissuers.ApplyClusterIssuerStatus(ctx, c.client, c.fieldManager, &cmapi.ClusterIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: iss.Name},
+				Status:     cmapi.IssuerStatus{Conditions: []cmapi.IssuerCondition{cond}},
+			})
+		}
+		iss.Status.Conditions, _ = conditions.Set(iss.Status.Conditions, cond, now.Time)
+		_, err := c.client.CertmanagerV1().ClusterIssuers().UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+		return err
+
+	default:
+		return fmt.Errorf("unexpected issuer type %T", iss)
+	}
+}
+
+func (c *controller) getGenericIssuer(kind, namespace, name string) (cmapi.GenericIssuer, error) {
+	switch kind {
+	case cmapi.IssuerKind:
+		return c.issuerLister.Issuers(namespace).Get(name)
+	case cmapi.ClusterIssuerKind:
+		if c.clusterIssuerLister == nil {
+			return nil, apierrors.NewNotFound(cmapi.Resource("clusterissuers"), name)
+		}
+		return c.clusterIssuerLister.Get(name)
+	default:
+		return nil, fmt.Errorf("unknown issuer kind %q", kind)
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.FieldManager,
+		ctx.Namespace == "",
+	)
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
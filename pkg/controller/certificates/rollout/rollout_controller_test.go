@@ -0,0 +1,264 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func rolloutIssuer(generation int64, annotations map[string]string, mods ...gen.IssuerModifier) *cmapi.Issuer {
+	iss := gen.Issuer("ca", append(mods, gen.SetIssuerNamespace("testns"))...)
+	iss.Generation = generation
+	iss.Annotations = map[string]string{cmapi.RolloutCanarySelectorAnnotationKey: "canary=true"}
+	for k, v := range annotations {
+		iss.Annotations[k] = v
+	}
+	return iss
+}
+
+func rolloutCanaryCondition(generation int64, started time.Time) gen.IssuerModifier {
+	startTime := metav1.NewTime(started)
+	return gen.AddIssuerCondition(cmapi.IssuerCondition{
+		Type:               cmapi.IssuerConditionRolledOut,
+		Status:             cmmeta.ConditionFalse,
+		Reason:             reasonRolloutCanary,
+		LastTransitionTime: &startTime,
+		ObservedGeneration: generation,
+	})
+}
+
+func rolloutCertificate(name string, canary, ready bool) *cmapi.Certificate {
+	mods := []gen.CertificateModifier{
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}),
+	}
+	if canary {
+		mods = append(mods, gen.AddCertificateLabels(map[string]string{"canary": "true"}))
+	}
+	status := cmmeta.ConditionFalse
+	if ready {
+		status = cmmeta.ConditionTrue
+	}
+	mods = append(mods, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: status}))
+	return gen.Certificate(name, mods...)
+}
+
+func TestProcessItem(t *testing.T) {
+	otherIssuerCrt := gen.Certificate("other",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "other"}),
+	)
+
+	tests := map[string]struct {
+		issuer       *cmapi.Issuer
+		certificates []runtime.Object
+
+		expectedIssued    []string
+		expectedCondition *cmapi.IssuerCondition
+	}{
+		"issuers which have not opted in are ignored": {
+			issuer: func() *cmapi.Issuer {
+				iss := rolloutIssuer(2, nil)
+				iss.Annotations = nil
+				return iss
+			}(),
+			certificates: []runtime.Object{rolloutCertificate("canary", true, true)},
+		},
+		"the current generation of a newly opted in issuer is marked as rolled out": {
+			issuer:       rolloutIssuer(2, nil),
+			certificates: []runtime.Object{rolloutCertificate("canary", true, true)},
+			expectedCondition: &cmapi.IssuerCondition{
+				Status:             cmmeta.ConditionTrue,
+				Reason:             reasonRolloutComplete,
+				ObservedGeneration: 2,
+			},
+		},
+		"a new generation only renews the canary certificates": {
+			issuer: rolloutIssuer(3, nil, gen.AddIssuerCondition(cmapi.IssuerCondition{
+				Type:               cmapi.IssuerConditionRolledOut,
+				Status:             cmmeta.ConditionTrue,
+				ObservedGeneration: 2,
+			})),
+			certificates: []runtime.Object{
+				rolloutCertificate("canary", true, true),
+				rolloutCertificate("bulk", false, true),
+				otherIssuerCrt,
+			},
+			expectedIssued: []string{"canary"},
+			expectedCondition: &cmapi.IssuerCondition{
+				Status:             cmmeta.ConditionFalse,
+				Reason:             reasonRolloutCanary,
+				ObservedGeneration: 3,
+			},
+		},
+		"remaining certificates are not renewed during the soak period": {
+			issuer: rolloutIssuer(3, nil, rolloutCanaryCondition(3, fixedClockStart.Add(-time.Hour))),
+			certificates: []runtime.Object{
+				rolloutCertificate("canary", true, true),
+				rolloutCertificate("bulk", false, true),
+			},
+		},
+		"remaining certificates are not renewed while a canary is not ready": {
+			issuer: rolloutIssuer(3, nil, rolloutCanaryCondition(3, fixedClockStart.Add(-48*time.Hour))),
+			certificates: []runtime.Object{
+				rolloutCertificate("canary", true, false),
+				rolloutCertificate("bulk", false, true),
+			},
+		},
+		"remaining certificates are renewed after the soak period": {
+			issuer: rolloutIssuer(3, map[string]string{cmapi.RolloutSoakPeriodAnnotationKey: "1h"}, rolloutCanaryCondition(3, fixedClockStart.Add(-time.Hour))),
+			certificates: []runtime.Object{
+				rolloutCertificate("canary", true, true),
+				rolloutCertificate("bulk", false, true),
+				otherIssuerCrt,
+			},
+			expectedIssued: []string{"bulk"},
+			expectedCondition: &cmapi.IssuerCondition{
+				Status:             cmmeta.ConditionTrue,
+				Reason:             reasonRolloutComplete,
+				ObservedGeneration: 3,
+			},
+		},
+		"remaining certificates are renewed once the rollout is approved": {
+			issuer: rolloutIssuer(3, map[string]string{cmapi.RolloutApprovedGenerationAnnotationKey: "3"}, rolloutCanaryCondition(3, fixedClockStart)),
+			certificates: []runtime.Object{
+				rolloutCertificate("canary", true, false),
+				rolloutCertificate("bulk", false, true),
+			},
+			expectedIssued: []string{"bulk"},
+			expectedCondition: &cmapi.IssuerCondition{
+				Status:             cmmeta.ConditionTrue,
+				Reason:             reasonRolloutComplete,
+				ObservedGeneration: 3,
+			},
+		},
+		"approval of a previous generation is ignored": {
+			issuer: rolloutIssuer(3, map[string]string{cmapi.RolloutApprovedGenerationAnnotationKey: "2"}, rolloutCanaryCondition(3, fixedClockStart)),
+			certificates: []runtime.Object{
+				rolloutCertificate("canary", true, true),
+				rolloutCertificate("bulk", false, true),
+			},
+		},
+		"a change during the canary phase restarts the rollout": {
+			issuer: rolloutIssuer(4, nil, rolloutCanaryCondition(3, fixedClockStart.Add(-time.Hour))),
+			certificates: []runtime.Object{
+				rolloutCertificate("canary", true, true),
+				rolloutCertificate("bulk", false, true),
+			},
+			expectedIssued: []string{"canary"},
+			expectedCondition: &cmapi.IssuerCondition{
+				Status:             cmmeta.ConditionFalse,
+				Reason:             reasonRolloutCanary,
+				ObservedGeneration: 4,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(fixedClockStart),
+				CertManagerObjects: append([]runtime.Object{test.issuer}, test.certificates...),
+			}
+			builder.Init()
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), issuerKey(cmapi.IssuerKind, "testns", "ca")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var issued []string
+			var condition *cmapi.IssuerCondition
+			for _, action := range builder.FakeCMClient().Actions() {
+				update, ok := action.(coretesting.UpdateAction)
+				if !ok || update.GetSubresource() != "status" {
+					continue
+				}
+				switch obj := update.GetObject().(type) {
+				case *cmapi.Certificate:
+					cond := apiutil.GetCertificateCondition(obj, cmapi.CertificateConditionIssuing)
+					if cond == nil || cond.Reason != reasonIssuerRollout {
+						t.Errorf("expected Certificate %q to have an Issuing condition set by the rollout, got %+v", obj.Name, cond)
+					}
+					issued = append(issued, obj.Name)
+				case *cmapi.Issuer:
+					condition = rolloutCondition(obj)
+				}
+			}
+
+			sort.Strings(issued)
+			if !reflect.DeepEqual(issued, test.expectedIssued) {
+				t.Errorf("expected issuance of %v to be triggered, got %v", test.expectedIssued, issued)
+			}
+			if (condition == nil) != (test.expectedCondition == nil) {
+				t.Fatalf("expected condition %+v, got %+v", test.expectedCondition, condition)
+			}
+			if condition != nil {
+				if condition.Status != test.expectedCondition.Status || condition.Reason != test.expectedCondition.Reason ||
+					condition.ObservedGeneration != test.expectedCondition.ObservedGeneration {
+					t.Errorf("expected condition %+v, got %+v", test.expectedCondition, condition)
+				}
+				if condition.LastTransitionTime == nil || !condition.LastTransitionTime.Time.Equal(fixedClockStart) {
+					t.Errorf("expected lastTransitionTime to be %s, got %v", fixedClockStart, condition.LastTransitionTime)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigFor(t *testing.T) {
+	iss := rolloutIssuer(1, map[string]string{cmapi.RolloutSoakPeriodAnnotationKey: "forever"})
+	if _, err := configFor(iss); err == nil {
+		t.Errorf("expected an error for an invalid soak period")
+	}
+
+	iss = rolloutIssuer(1, map[string]string{cmapi.RolloutCanarySelectorAnnotationKey: "canary in (true"})
+	if _, err := configFor(iss); err == nil {
+		t.Errorf("expected an error for an invalid canary selector")
+	}
+
+	config, err := configFor(rolloutIssuer(1, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.soakPeriod != defaultSoakPeriod || config.approved {
+		t.Errorf("unexpected config %+v", config)
+	}
+}
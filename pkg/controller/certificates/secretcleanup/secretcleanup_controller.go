@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretcleanup
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ControllerName is the name of the Secret cleanup controller.
+	ControllerName = "certificates-secret-cleanup"
+
+	reasonSecretDeleted      = "SecretDeleted"
+	reasonSecretDeleteFailed = "SecretDeleteFailed"
+)
+
+// controller deletes the Secrets managed by Certificates with a
+// `secretDeletionPolicy` of `Delete` when the Certificate is deleted.
+// Deletion of the Certificate is deferred until its Secrets have been deleted
+// using the `finalizer.secret-cleanup.cert-manager.io` finalizer.
+// A Secret is considered to be managed by a Certificate if its
+// `cert-manager.io/certificate-name` annotation names the Certificate, which
+// includes Secrets written for a previous `spec.secretName` of the
+// Certificate. Secrets which are the target of another Certificate are kept.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	kubeClient        kubernetes.Interface
+	client            cmclient.Interface
+	recorder          record.EventRecorder
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+// NewController returns a new Secret cleanup controller.
+func NewController(
+	log logr.Logger,
+	kubeClient kubernetes.Interface,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+	}
+
+	ctrl := &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      secretInformer.Lister(),
+		kubeClient:        kubeClient,
+		client:            client,
+		recorder:          recorder,
+	}
+
+	return ctrl, queue, mustSync
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a Certificate to be re-synced is pulled from the workqueue.
+// ProcessItem manages the cleanup finalizer of the Certificate, and deletes
+// its Secrets when it is being deleted with a `secretDeletionPolicy` of
+// `Delete`.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
+	deleteSecrets := crt.Spec.SecretDeletionPolicy == cmapi.DeleteSecret
+	hasFinalizer := sets.NewString(crt.Finalizers...).Has(cmapi.SecretCleanupFinalizer)
+	if crt.DeletionTimestamp != nil {
+		if !hasFinalizer {
+			return nil
+		}
+		// If the policy was changed to Retain after the finalizer was
+		// added, the finalizer is removed without deleting the Secrets.
+		if deleteSecrets {
+			if err := c.deleteSecrets(ctx, crt); err != nil {
+				return err
+			}
+		}
+		return c.setFinalizer(ctx, crt, false)
+	}
+
+	if deleteSecrets != hasFinalizer {
+		return c.setFinalizer(ctx, crt, deleteSecrets)
+	}
+	return nil
+}
+
+// deleteSecrets deletes the Secrets managed by crt, except those which are
+// the target of another Certificate in the namespace.
+func (c *controller) deleteSecrets(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	secrets, err := c.secretLister.Secrets(crt.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	crts, err := c.certificateLister.Certificates(crt.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	inUse := sets.NewString()
+	for _, other := range crts {
+		if other.Name != crt.Name && other.DeletionTimestamp == nil {
+			inUse.Insert(other.Spec.SecretName)
+		}
+	}
+
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+	for _, secret := range secrets {
+		if secret.Annotations[cmapi.CertificateNameKey] != crt.Name {
+			continue
+		}
+		if inUse.Has(secret.Name) {
+			log.V(logf.DebugLevel).Info("secret is the target of another certificate, not deleting", "secret", secret.Name)
+			continue
+		}
+
+		// The UID precondition guards against deleting a Secret which was
+		// recreated since it was observed.
+		uid := secret.UID
+		err := c.kubeClient.CoreV1().Secrets(secret.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{UID: &uid},
+		})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonSecretDeleteFailed, "Failed to delete Secret %q: %v", secret.Name, err)
+			return err
+		}
+
+		log.V(logf.InfoLevel).Info("deleted secret", "secret", secret.Name)
+		c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonSecretDeleted, "Deleted Secret %q", secret.Name)
+	}
+	return nil
+}
+
+// setFinalizer adds or removes the cleanup finalizer of crt.
+func (c *controller) setFinalizer(ctx context.Context, crt *cmapi.Certificate, present bool) error {
+	crt = crt.DeepCopy()
+	if present {
+		crt.Finalizers = append(crt.Finalizers, cmapi.SecretCleanupFinalizer)
+	} else {
+		crt.Finalizers = sets.NewString(crt.Finalizers...).Delete(cmapi.SecretCleanupFinalizer).List()
+	}
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+	)
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		c := &controllerWrapper{}
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(c).
+			Complete()
+	})
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretcleanup
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestProcessItem(t *testing.T) {
+	fakeClock := fakeclock.NewFakeClock(fixedClockStart)
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-tls"),
+		gen.SetCertificateCommonName("example.com"),
+	)
+	secret := func(name, certificateName string) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "testns",
+				Name:      name,
+				UID:       types.UID("uid-" + name),
+			},
+		}
+		if certificateName != "" {
+			s.Annotations = map[string]string{cmapi.CertificateNameKey: certificateName}
+		}
+		return s
+	}
+	deleting := func(crt *cmapi.Certificate) *cmapi.Certificate {
+		now := metav1.NewTime(fixedClockStart)
+		crt.DeletionTimestamp = &now
+		return crt
+	}
+
+	tests := map[string]struct {
+		certificates []runtime.Object
+		secrets      []runtime.Object
+
+		expectedDeleted    []string
+		expectedFinalizers []string
+		expectedUpdate     bool
+	}{
+		"finalizer is added when the policy is Delete": {
+			certificates: []runtime.Object{
+				gen.CertificateFrom(baseCrt, gen.SetCertificateSecretDeletionPolicy(cmapi.DeleteSecret)),
+			},
+			secrets:            []runtime.Object{secret("test-tls", "test")},
+			expectedUpdate:     true,
+			expectedFinalizers: []string{cmapi.SecretCleanupFinalizer},
+		},
+		"finalizer is not added when the policy is unset": {
+			certificates: []runtime.Object{baseCrt.DeepCopy()},
+			secrets:      []runtime.Object{secret("test-tls", "test")},
+		},
+		"finalizer is removed when the policy is changed to Retain": {
+			certificates: []runtime.Object{
+				gen.CertificateFrom(baseCrt,
+					gen.SetCertificateSecretDeletionPolicy(cmapi.RetainSecret),
+					setFinalizers(cmapi.SecretCleanupFinalizer),
+				),
+			},
+			secrets:        []runtime.Object{secret("test-tls", "test")},
+			expectedUpdate: true,
+		},
+		"secrets managed by the certificate are deleted on delete": {
+			certificates: []runtime.Object{
+				deleting(gen.CertificateFrom(baseCrt,
+					gen.SetCertificateSecretDeletionPolicy(cmapi.DeleteSecret),
+					setFinalizers("other", cmapi.SecretCleanupFinalizer),
+				)),
+			},
+			secrets: []runtime.Object{
+				secret("test-tls", "test"),
+				secret("previous-tls", "test"),
+				secret("unmanaged", ""),
+				secret("other-tls", "other"),
+			},
+			expectedDeleted:    []string{"previous-tls", "test-tls"},
+			expectedUpdate:     true,
+			expectedFinalizers: []string{"other"},
+		},
+		"secrets which are the target of another certificate are kept": {
+			certificates: []runtime.Object{
+				deleting(gen.CertificateFrom(baseCrt,
+					gen.SetCertificateSecretDeletionPolicy(cmapi.DeleteSecret),
+					setFinalizers(cmapi.SecretCleanupFinalizer),
+				)),
+				gen.CertificateFrom(baseCrt, func(crt *cmapi.Certificate) { crt.Name = "other" }),
+			},
+			secrets:        []runtime.Object{secret("test-tls", "test")},
+			expectedUpdate: true,
+		},
+		"secrets are retained on delete when the policy was changed to Retain": {
+			certificates: []runtime.Object{
+				deleting(gen.CertificateFrom(baseCrt,
+					gen.SetCertificateSecretDeletionPolicy(cmapi.RetainSecret),
+					setFinalizers(cmapi.SecretCleanupFinalizer),
+				)),
+			},
+			secrets:        []runtime.Object{secret("test-tls", "test")},
+			expectedUpdate: true,
+		},
+		"certificates without the finalizer are ignored on delete": {
+			certificates: []runtime.Object{
+				deleting(gen.CertificateFrom(baseCrt,
+					gen.SetCertificateSecretDeletionPolicy(cmapi.DeleteSecret),
+					setFinalizers("other"),
+				)),
+			},
+			secrets: []runtime.Object{secret("test-tls", "test")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeClock,
+				CertManagerObjects: test.certificates,
+				KubeObjects:        test.secrets,
+			}
+			builder.Init()
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			key, _ := controllerpkg.KeyFunc(test.certificates[0])
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var deleted []string
+			for _, action := range builder.FakeKubeClient().Actions() {
+				if del, ok := action.(coretesting.DeleteAction); ok {
+					deleted = append(deleted, del.GetName())
+				}
+			}
+			if !reflect.DeepEqual(deleted, test.expectedDeleted) {
+				t.Errorf("expected deleted secrets %v, got %v", test.expectedDeleted, deleted)
+			}
+
+			var updated *cmapi.Certificate
+			for _, action := range builder.FakeCMClient().Actions() {
+				if update, ok := action.(coretesting.UpdateAction); ok {
+					updated = update.GetObject().(*cmapi.Certificate)
+				}
+			}
+			if test.expectedUpdate != (updated != nil) {
+				t.Fatalf("expected update=%t, got %v", test.expectedUpdate, updated)
+			}
+			if updated == nil {
+				return
+			}
+			if len(updated.Finalizers) > 0 || len(test.expectedFinalizers) > 0 {
+				if !reflect.DeepEqual(updated.Finalizers, test.expectedFinalizers) {
+					t.Errorf("expected finalizers %v, got %v", test.expectedFinalizers, updated.Finalizers)
+				}
+			}
+		})
+	}
+}
+
+func setFinalizers(finalizers ...string) gen.CertificateModifier {
+	return func(crt *cmapi.Certificate) {
+		crt.Finalizers = finalizers
+	}
+}
@@ -0,0 +1,351 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsnapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+const (
+	// ControllerName is the name of the Secret snapshot controller.
+	ControllerName = "certificates-secret-snapshot"
+
+	reasonRolledBack     = "RolledBack"
+	reasonRollbackFailed = "RollbackFailed"
+)
+
+var certificateGvk = cmapi.SchemeGroupVersion.WithKind("Certificate")
+
+// controller keeps snapshots of the Secret of Certificates annotated with
+// `cert-manager.io/secret-snapshot-limit`, one for each of the last issued
+// revisions, and rolls the Secret back to a snapshot when the Certificate is
+// annotated with `cert-manager.io/rollback-revision`.
+// Snapshots are stored in Secrets named `<secretName>-<revision>` which are
+// owned by the Certificate, so they are deleted along with it.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	kubeClient        kubernetes.Interface
+	client            cmclient.Interface
+	recorder          record.EventRecorder
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+// NewController returns a new Secret snapshot controller.
+func NewController(
+	log logr.Logger,
+	kubeClient kubernetes.Interface,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret changes, the Certificates naming it as spec.secretName are
+	// enqueued so that newly issued revisions are snapshotted.
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateSecretNameIndex),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+	}
+
+	ctrl := &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      secretInformer.Lister(),
+		kubeClient:        kubeClient,
+		client:            client,
+		recorder:          recorder,
+	}
+
+	return ctrl, queue, mustSync
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a Certificate to be re-synced is pulled from the workqueue.
+// ProcessItem snapshots the current revision of the Secret of the
+// Certificate, removes the snapshots beyond the limit of the Certificate, and
+// rolls the Secret back if requested.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
+	value, ok := crt.Annotations[cmapi.SecretSnapshotLimitAnnotationKey]
+	if !ok {
+		return nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		log.V(logf.WarnLevel).Info("invalid secret snapshot limit, must be a positive integer", "value", value)
+		return nil
+	}
+
+	// The current revision is always snapshotted before rolling back, so
+	// that the Secret can be rolled forward again.
+	if err := c.snapshot(ctx, crt); err != nil {
+		return err
+	}
+	if err := c.prune(ctx, crt, limit); err != nil {
+		return err
+	}
+
+	if _, ok := crt.Annotations[cmapi.RollbackRevisionAnnotationKey]; !ok {
+		return nil
+	}
+	return c.rollback(ctx, crt)
+}
+
+// snapshotName returns the name of the snapshot of the given revision of the
+// Secret of crt.
+func snapshotName(crt *cmapi.Certificate, revision int) string {
+	return fmt.Sprintf("%s-%d", crt.Spec.SecretName, revision)
+}
+
+// snapshot creates a snapshot of the Secret of crt, if it holds the current
+// revision of crt and that revision has not been snapshotted yet.
+func (c *controller) snapshot(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	if crt.Status.Revision == nil {
+		return nil
+	}
+	revision := *crt.Status.Revision
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if secret.Labels[cmapi.CertificateRevisionLabelKey] != strconv.Itoa(revision) ||
+		len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		log.V(logf.DebugLevel).Info("secret does not hold the current revision yet, not taking a snapshot")
+		return nil
+	}
+
+	name := snapshotName(crt, revision)
+	if _, err := c.secretLister.Secrets(crt.Namespace).Get(name); err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	snapshotLabels := internalcertificates.LabelsForCertificate(crt, &revision)
+	snapshotLabels[cmapi.SecretSnapshotLabelKey] = "true"
+	snapshot := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       crt.Namespace,
+			Name:            name,
+			Labels:          snapshotLabels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)},
+		},
+		Data: make(map[string][]byte, len(secret.Data)),
+		Type: secret.Type,
+	}
+	for k, v := range secret.Data {
+		snapshot.Data[k] = v
+	}
+
+	if _, err := c.kubeClient.CoreV1().Secrets(crt.Namespace).Create(ctx, snapshot, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	log.V(logf.DebugLevel).Info("created secret snapshot", "snapshot", name)
+	return nil
+}
+
+// snapshots returns the snapshots of the Secret of crt, newest first.
+func (c *controller) snapshots(crt *cmapi.Certificate) ([]*corev1.Secret, error) {
+	selector := labels.SelectorFromSet(labels.Set{cmapi.SecretSnapshotLabelKey: "true"})
+	secrets, err := certificates.ListSecretsMatchingPredicates(c.secretLister.Secrets(crt.Namespace), selector, predicate.ResourceOwnedBy(crt))
+	if err != nil {
+		return nil, err
+	}
+	revisionOf := func(secret *corev1.Secret) int {
+		revision, _ := strconv.Atoi(secret.Labels[cmapi.CertificateRevisionLabelKey])
+		return revision
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return revisionOf(secrets[i]) > revisionOf(secrets[j])
+	})
+	return secrets, nil
+}
+
+// prune deletes the snapshots of the Secret of crt beyond the newest limit
+// snapshots.
+func (c *controller) prune(ctx context.Context, crt *cmapi.Certificate, limit int) error {
+	snapshots, err := c.snapshots(crt)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) <= limit {
+		return nil
+	}
+
+	for _, snapshot := range snapshots[limit:] {
+		err := c.kubeClient.CoreV1().Secrets(snapshot.Namespace).Delete(ctx, snapshot.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		logf.FromContext(ctx).V(logf.DebugLevel).Info("deleted secret snapshot", "snapshot", snapshot.Name)
+	}
+	return nil
+}
+
+// rollback replaces the data of the Secret of crt with the snapshot of the
+// revision requested by the `cert-manager.io/rollback-revision` annotation,
+// using a single update, and removes the annotation. The annotation is also
+// removed if there is no such snapshot, as the rollback can never succeed.
+func (c *controller) rollback(ctx context.Context, crt *cmapi.Certificate) error {
+	value := crt.Annotations[cmapi.RollbackRevisionAnnotationKey]
+	if err := c.restore(ctx, crt, value); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRollbackFailed, "Cannot roll back to revision %q: %v", value, err)
+	}
+
+	crt = crt.DeepCopy()
+	delete(crt.Annotations, cmapi.RollbackRevisionAnnotationKey)
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
+// restore replaces the data of the Secret of crt with the snapshot of the
+// given revision. A NotFound error is returned if the revision is invalid, or
+// there is no snapshot of it.
+func (c *controller) restore(ctx context.Context, crt *cmapi.Certificate, value string) error {
+	revision, err := strconv.Atoi(value)
+	if err != nil {
+		return apierrors.NewNotFound(corev1.Resource("secrets"), snapshotName(crt, 0))
+	}
+
+	snapshot, err := c.secretLister.Secrets(crt.Namespace).Get(snapshotName(crt, revision))
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(snapshot, crt) {
+		return apierrors.NewNotFound(corev1.Resource("secrets"), snapshot.Name)
+	}
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil {
+		return err
+	}
+
+	secret = secret.DeepCopy()
+	secret.Data = make(map[string][]byte, len(snapshot.Data))
+	for k, v := range snapshot.Data {
+		secret.Data[k] = v
+	}
+	if _, err := c.kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	logf.FromContext(ctx).V(logf.InfoLevel).Info("rolled back secret", "revision", revision)
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRolledBack, "Rolled back Secret %q to the certificate of revision %d", secret.Name, revision)
+	return nil
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+	)
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
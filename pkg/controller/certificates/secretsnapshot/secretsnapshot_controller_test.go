@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsnapshot
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func tlsSecret(name string, revision int, cert string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "testns",
+			Name:      name,
+			Labels:    map[string]string{cmapi.CertificateRevisionLabelKey: strconv.Itoa(revision)},
+		},
+		Data: map[string][]byte{corev1.TLSCertKey: []byte(cert), corev1.TLSPrivateKeyKey: []byte("key-" + cert)},
+		Type: corev1.SecretTypeTLS,
+	}
+}
+
+func snapshotSecret(crt *cmapi.Certificate, revision int, cert string) *corev1.Secret {
+	s := tlsSecret(snapshotName(crt, revision), revision, cert)
+	s.Labels[cmapi.SecretSnapshotLabelKey] = "true"
+	s.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)}
+	return s
+}
+
+func TestProcessItem(t *testing.T) {
+	newCertificate := func(annotations map[string]string) *cmapi.Certificate {
+		crt := gen.Certificate("test",
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateUID("test-uid"),
+			gen.SetCertificateSecretName("test-tls"),
+			gen.SetCertificateRevision(3),
+		)
+		crt.Annotations = annotations
+		return crt
+	}
+
+	tests := map[string]struct {
+		crt *cmapi.Certificate
+		// existing snapshots, by revision
+		snapshots map[int]string
+
+		expectedActions []string
+		expectedData    string
+		expectedEvents  []string
+	}{
+		"certificate without snapshot limit is ignored": {
+			crt: newCertificate(nil),
+		},
+		"current revision is snapshotted": {
+			crt:             newCertificate(map[string]string{cmapi.SecretSnapshotLimitAnnotationKey: "2"}),
+			snapshots:       map[int]string{2: "cert-2"},
+			expectedActions: []string{"create secrets/test-tls-3"},
+		},
+		"snapshots beyond the limit are deleted": {
+			crt:             newCertificate(map[string]string{cmapi.SecretSnapshotLimitAnnotationKey: "2"}),
+			snapshots:       map[int]string{1: "cert-1", 2: "cert-2", 3: "cert-3"},
+			expectedActions: []string{"delete secrets/test-tls-1"},
+		},
+		"secret is rolled back and annotation removed": {
+			crt: newCertificate(map[string]string{
+				cmapi.SecretSnapshotLimitAnnotationKey: "3",
+				cmapi.RollbackRevisionAnnotationKey:    "2",
+			}),
+			snapshots:       map[int]string{2: "cert-2"},
+			expectedActions: []string{"create secrets/test-tls-3", "update secrets/test-tls", "update certificates/test"},
+			expectedData:    "cert-2",
+			expectedEvents:  []string{`Normal RolledBack Rolled back Secret "test-tls" to the certificate of revision 2`},
+		},
+		"missing snapshot removes annotation without rolling back": {
+			crt: newCertificate(map[string]string{
+				cmapi.SecretSnapshotLimitAnnotationKey: "3",
+				cmapi.RollbackRevisionAnnotationKey:    "1",
+			}),
+			snapshots:       map[int]string{2: "cert-2", 3: "cert-3"},
+			expectedActions: []string{"update certificates/test"},
+			expectedEvents:  []string{`Warning RollbackFailed Cannot roll back to revision "1": secret "test-tls-1" not found`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kubeObjects := []runtime.Object{tlsSecret("test-tls", 3, "cert-3")}
+			for revision, cert := range test.snapshots {
+				kubeObjects = append(kubeObjects, snapshotSecret(test.crt, revision, cert))
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: []runtime.Object{test.crt},
+				KubeObjects:        kubeObjects,
+			}
+			builder.Init()
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			key, _ := controllerpkg.KeyFunc(test.crt)
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var actions []string
+			var data string
+			for _, action := range append(builder.FakeKubeClient().Actions(), builder.FakeCMClient().Actions()...) {
+				if action.GetVerb() == "list" || action.GetVerb() == "watch" {
+					continue
+				}
+				var name string
+				switch a := action.(type) {
+				case coretesting.DeleteAction:
+					name = a.GetName()
+				case coretesting.CreateAction:
+					name = a.GetObject().(metav1.Object).GetName()
+					if secret, ok := a.GetObject().(*corev1.Secret); ok && action.GetVerb() == "update" {
+						data = string(secret.Data[corev1.TLSCertKey])
+					}
+				}
+				actions = append(actions, action.GetVerb()+" "+action.GetResource().Resource+"/"+name)
+			}
+			sort.Strings(actions)
+			sort.Strings(test.expectedActions)
+			if !reflect.DeepEqual(actions, test.expectedActions) {
+				t.Errorf("unexpected actions:\nexpected: %v\ngot:      %v", test.expectedActions, actions)
+			}
+			if data != test.expectedData {
+				t.Errorf("unexpected rolled back data: expected %q, got %q", test.expectedData, data)
+			}
+			if events := builder.Events(); !reflect.DeepEqual(events, test.expectedEvents) {
+				t.Errorf("unexpected events:\nexpected: %v\ngot:      %v", test.expectedEvents, events)
+			}
+		})
+	}
+}
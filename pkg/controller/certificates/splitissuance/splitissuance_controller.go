@@ -0,0 +1,537 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splitissuance
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/pem"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+const (
+	// ControllerName is the name of the split issuance controller.
+	ControllerName = "certificates-split-issuance"
+
+	// failedRequestRetryDelay is how long a failed CertificateRequest of a
+	// split issuance is kept before it is replaced by a new one.
+	failedRequestRetryDelay = time.Hour
+
+	reasonRequested = "SplitIssuanceRequested"
+	reasonIssued    = "SplitIssuanceIssued"
+	reasonFailed    = "SplitIssuanceFailed"
+)
+
+var certificateGvk = cmapi.SchemeGroupVersion.WithKind("Certificate")
+
+// controller issues the DNS names of the split issuances of Ready
+// Certificates from the issuers of the split issuances, and stores the
+// resulting certificates in the Secret of the Certificate alongside the
+// certificate issued by the issuer of the Certificate. The certificates of
+// split issuances share the private key stored in the Secret.
+type controller struct {
+	certificateLister        cmlisters.CertificateLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	secretLister             corelisters.SecretLister
+	client                   cmclient.Interface
+	coreClient               kubernetes.Interface
+	recorder                 record.EventRecorder
+	clock                    clock.Clock
+
+	// scheduledWorkQueue is used to renew the certificates of split
+	// issuances, and to retry failed CertificateRequests.
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Create or Update API calls.
+	fieldManager string
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	coreClient kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	fieldManager string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
+	secretsInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to any 'owned' CertificateRequest resources
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ResourceOwnerOf,
+		),
+	})
+	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateSecretNameIndex),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		secretsInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateLister:        certificateInformer.Lister(),
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		secretLister:             secretsInformer.Lister(),
+		client:                   client,
+		coreClient:               coreClient,
+		recorder:                 recorder,
+		clock:                    clock,
+		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		fieldManager:             fieldManager,
+	}, queue, mustSync
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a Certificate to be re-synced is pulled from the workqueue.
+// Once the Certificate is Ready, ProcessItem ensures that the Secret of the
+// Certificate contains an up to date certificate for each of its split
+// issuances, creating CertificateRequests for those which do not.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
+	requests, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt))
+	if err != nil {
+		return err
+	}
+
+	// Delete the CertificateRequests of split issuances which have been
+	// removed from the Certificate.
+	splitNames := sets.NewString()
+	for _, split := range crt.Spec.SplitIssuances {
+		splitNames.Insert(split.Name)
+	}
+	for _, req := range requests {
+		if splitName, ok := req.Annotations[cmapi.CertificateRequestSplitIssuanceAnnotationKey]; ok && !splitNames.Has(splitName) {
+			if err := c.deleteRequest(ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The certificates of split issuances are only requested once the
+	// Certificate has been issued, as they use the private key stored in
+	// its Secret.
+	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return nil
+	}
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(crt.Spec.SplitIssuances) == 0 && len(secret.Annotations[cmapi.SplitIssuancesAnnotationKey]) == 0 {
+		return nil
+	}
+
+	pk, err := pki.DecodePrivateKeyBytes(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to decode private key in secret, waiting for it to be re-issued", "error", err.Error())
+		return nil
+	}
+
+	data := make(map[string][]byte)
+	for _, split := range crt.Spec.SplitIssuances {
+		log := log.WithValues("split_issuance", split.Name)
+		ctx := logf.NewContext(ctx, log)
+
+		var splitRequests []*cmapi.CertificateRequest
+		for _, req := range requests {
+			if req.Annotations[cmapi.CertificateRequestSplitIssuanceAnnotationKey] == split.Name {
+				splitRequests = append(splitRequests, req)
+			}
+		}
+
+		certPEM, caPEM, err := c.syncSplitIssuance(ctx, key, crt, split, secret, pk, splitRequests)
+		if err != nil {
+			return err
+		}
+		if len(certPEM) > 0 {
+			data[apiutil.SplitIssuanceCertificateKey(split.Name)] = certPEM
+		}
+		if len(caPEM) > 0 {
+			data[apiutil.SplitIssuanceCAKey(split.Name)] = caPEM
+		}
+	}
+
+	return c.updateSecretData(ctx, crt, secret, data)
+}
+
+// syncSplitIssuance returns the certificate and CA of the given split
+// issuance to be stored in the Secret of the Certificate, and creates a
+// CertificateRequest for the split issuance if the certificate in the Secret
+// is missing, does not match the Certificate or is due for renewal.
+func (c *controller) syncSplitIssuance(ctx context.Context, key string, crt *cmapi.Certificate, split cmapi.CertificateSplitIssuance, secret *corev1.Secret, pk crypto.Signer, requests []*cmapi.CertificateRequest) ([]byte, []byte, error) {
+	log := logf.FromContext(ctx)
+	splitCrt := splitCertificate(crt, split)
+
+	certPEM := secret.Data[apiutil.SplitIssuanceCertificateKey(split.Name)]
+	caPEM := secret.Data[apiutil.SplitIssuanceCAKey(split.Name)]
+	upToDate, usable, renewalTime := c.certificateUpToDate(certPEM, splitCrt, pk)
+	if !usable {
+		// A certificate which does not match the private key in the Secret
+		// cannot be used, so it is removed rather than kept until a new
+		// certificate is issued.
+		certPEM, caPEM = nil, nil
+	}
+	if upToDate {
+		c.scheduledWorkQueue.Add(key, renewalTime.Sub(c.clock.Now()))
+		return certPEM, caPEM, nil
+	}
+
+	var pending []*cmapi.CertificateRequest
+	for _, req := range requests {
+		log := logf.WithRelatedResource(log, req)
+
+		violations, err := certificates.RequestMatchesSpec(req, splitCrt.Spec, nil)
+		if err != nil || len(violations) > 0 {
+			log.V(logf.DebugLevel).Info("CertificateRequest does not match the split issuance, deleting CertificateRequest", "violations", violations.String())
+			if err := c.deleteRequest(ctx, req); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		x509Req, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+		if err != nil {
+			return nil, nil, err
+		}
+		if matches, err := pki.PublicKeyMatchesCSR(pk.Public(), x509Req); err != nil || !matches {
+			log.V(logf.DebugLevel).Info("CertificateRequest does not match the private key in the Secret, deleting CertificateRequest")
+			if err := c.deleteRequest(ctx, req); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		if len(req.Status.Certificate) > 0 {
+			// Requests which have been issued are kept until the
+			// certificate is due for renewal.
+			if reqUpToDate, _, _ := c.certificateUpToDate(req.Status.Certificate, splitCrt, pk); !reqUpToDate {
+				if err := c.deleteRequest(ctx, req); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonIssued, "Issued the certificate of split issuance %q from CertificateRequest %q", split.Name, req.Name)
+			return req.Status.Certificate, req.Status.CA, nil
+		}
+
+		if failed, reason := requestFailed(req); failed {
+			retryAt := c.clock.Now()
+			if req.Status.FailureTime != nil {
+				retryAt = req.Status.FailureTime.Add(failedRequestRetryDelay)
+			}
+			if delay := retryAt.Sub(c.clock.Now()); delay > 0 {
+				c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonFailed, "The CertificateRequest %q of split issuance %q failed, retrying in %s: %s", req.Name, split.Name, delay.Round(time.Second), reason)
+				c.scheduledWorkQueue.Add(key, delay)
+				return certPEM, caPEM, nil
+			}
+			if err := c.deleteRequest(ctx, req); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		pending = append(pending, req)
+	}
+
+	if len(pending) > 0 {
+		// Wait for the request to be issued.
+		return certPEM, caPEM, nil
+	}
+
+	if err := c.createRequest(ctx, crt, splitCrt, split.Name, pk); err != nil {
+		return nil, nil, err
+	}
+	return certPEM, caPEM, nil
+}
+
+// certificateUpToDate returns whether the given PEM encoded certificate chain
+// is an up to date certificate of the given split issuance Certificate,
+// whether it is usable with the given private key, and the time at which it
+// should be renewed.
+func (c *controller) certificateUpToDate(certPEM []byte, splitCrt *cmapi.Certificate, pk crypto.Signer) (upToDate, usable bool, renewalTime time.Time) {
+	if len(certPEM) == 0 {
+		return false, false, time.Time{}
+	}
+	chain, err := pki.DecodeX509CertificateChainBytes(certPEM)
+	if err != nil {
+		return false, false, time.Time{}
+	}
+	cert := chain[0]
+
+	if matches, err := pki.PublicKeyMatchesCertificate(pk.Public(), cert); err != nil || !matches {
+		return false, false, time.Time{}
+	}
+
+	renewalTime = certificates.RenewalTime(cert.NotBefore, cert.NotAfter, splitCrt.Spec.RenewBefore).Time
+	if !sets.NewString(cert.DNSNames...).Equal(sets.NewString(splitCrt.Spec.DNSNames...)) {
+		return false, true, renewalTime
+	}
+	return c.clock.Now().Before(renewalTime), true, renewalTime
+}
+
+// requestFailed returns whether the given CertificateRequest has failed or
+// has been denied, and why.
+func requestFailed(req *cmapi.CertificateRequest) (bool, string) {
+	if apiutil.CertificateRequestIsDenied(req) {
+		cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied)
+		return true, cond.Message
+	}
+	if apiutil.CertificateRequestHasInvalidRequest(req) {
+		return true, apiutil.CertificateRequestInvalidRequestMessage(req)
+	}
+	cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+	if cond != nil && cond.Status == cmmeta.ConditionFalse && cond.Reason == cmapi.CertificateRequestReasonFailed {
+		return true, cond.Message
+	}
+	return false, ""
+}
+
+// splitCertificate returns a copy of the given Certificate which describes
+// the certificate of the given split issuance. It only contains the DNS names
+// of the split issuance, and references its issuer.
+func splitCertificate(crt *cmapi.Certificate, split cmapi.CertificateSplitIssuance) *cmapi.Certificate {
+	splitCrt := crt.DeepCopy()
+	splitCrt.Spec.DNSNames = apiutil.SplitIssuanceDNSNames(&crt.Spec, split.Name)
+	splitCrt.Spec.IssuerRef = split.IssuerRef
+	splitCrt.Spec.SplitIssuances = nil
+
+	// All other identities are issued by the issuer of the Certificate.
+	splitCrt.Spec.CommonName = ""
+	splitCrt.Spec.LiteralSubject = ""
+	splitCrt.Spec.IPAddresses = nil
+	splitCrt.Spec.URIs = nil
+	splitCrt.Spec.EmailAddresses = nil
+	return splitCrt
+}
+
+func (c *controller) createRequest(ctx context.Context, crt, splitCrt *cmapi.Certificate, splitName string, pk crypto.Signer) error {
+	log := logf.FromContext(ctx)
+
+	x509CSR, err := pki.GenerateCSR(splitCrt)
+	if err != nil {
+		log.Error(err, "Failed to generate CSR - will not retry")
+		return nil
+	}
+	csrDER, err := pki.EncodeCSR(x509CSR, pk)
+	if err != nil {
+		return err
+	}
+	csrPEM := bytes.NewBuffer([]byte{})
+	if err := pem.Encode(csrPEM, &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}); err != nil {
+		return err
+	}
+
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    crt.Namespace,
+			GenerateName: apiutil.DNSSafeShortenTo52Characters(crt.Name+"-"+splitName) + "-",
+			Annotations: map[string]string{
+				cmapi.CertificateNameKey:                           crt.Name,
+				cmapi.CertificateRequestSplitIssuanceAnnotationKey: splitName,
+				// The private key of split issuances is the one in the
+				// Secret of the Certificate.
+				cmapi.CertificateRequestPrivateKeyAnnotationKey: crt.Spec.SecretName,
+			},
+			Labels:          internalcertificates.LabelsForCertificate(splitCrt, nil),
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)},
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			Duration:  splitCrt.Spec.Duration,
+			IssuerRef: splitCrt.Spec.IssuerRef,
+			Request:   csrPEM.Bytes(),
+			IsCA:      splitCrt.Spec.IsCA,
+			Usages:    splitCrt.Spec.Usages,
+		},
+	}
+	if backdate, ok := crt.Annotations[cmapi.NotBeforeBackdateAnnotationKey]; ok {
+		cr.Annotations[cmapi.NotBeforeBackdateAnnotationKey] = backdate
+	}
+
+	_, err = c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{FieldManager: c.fieldManager})
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonFailed, "Failed to create CertificateRequest for split issuance %q: %v", splitName, err)
+		return err
+	}
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRequested, "Created new CertificateRequest resource for split issuance %q", splitName)
+	return nil
+}
+
+func (c *controller) deleteRequest(ctx context.Context, req *cmapi.CertificateRequest) error {
+	err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// updateSecretData sets the entries of the split issuances in the Secret of
+// the Certificate to the given data, and removes the entries of split
+// issuances which are no longer listed on the Certificate.
+func (c *controller) updateSecretData(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret, data map[string][]byte) error {
+	updated := secret.DeepCopy()
+	if updated.Data == nil {
+		updated.Data = make(map[string][]byte)
+	}
+
+	var names []string
+	for _, split := range crt.Spec.SplitIssuances {
+		names = append(names, split.Name)
+	}
+	// Entries are removed for all split issuances previously stored in the
+	// Secret, and then set again for the current ones.
+	for _, name := range append(strings.Split(secret.Annotations[cmapi.SplitIssuancesAnnotationKey], ","), names...) {
+		if len(name) == 0 {
+			continue
+		}
+		delete(updated.Data, apiutil.SplitIssuanceCertificateKey(name))
+		delete(updated.Data, apiutil.SplitIssuanceCAKey(name))
+	}
+	for k, v := range data {
+		updated.Data[k] = v
+	}
+
+	if len(names) > 0 {
+		if updated.Annotations == nil {
+			updated.Annotations = make(map[string]string)
+		}
+		updated.Annotations[cmapi.SplitIssuancesAnnotationKey] = strings.Join(names, ",")
+	} else {
+		delete(updated.Annotations, cmapi.SplitIssuancesAnnotationKey)
+	}
+
+	if reflect.DeepEqual(secret.Data, updated.Data) && reflect.DeepEqual(secret.Annotations, updated.Annotations) {
+		return nil
+	}
+
+	logf.FromContext(ctx).V(logf.DebugLevel).Info("updating split issuance entries of secret")
+	_, err := c.coreClient.CoreV1().Secrets(updated.Namespace).Update(ctx, updated, metav1.UpdateOptions{FieldManager: c.fieldManager})
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.FieldManager,
+	)
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
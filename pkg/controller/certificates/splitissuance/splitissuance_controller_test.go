@@ -0,0 +1,239 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splitissuance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kr/pretty"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// relaxedCertificateRequestMatcher ignores the CSR of created
+// CertificateRequests, as it is signed with a random nonce.
+func relaxedCertificateRequestMatcher(l coretesting.Action, r coretesting.Action) error {
+	objL := l.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest).DeepCopy()
+	objR := r.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest).DeepCopy()
+	objL.Spec.Request = nil
+	objR.Spec.Request = nil
+	if !reflect.DeepEqual(objL, objR) {
+		return fmt.Errorf("unexpected difference between actions: %s", pretty.Diff(objL, objR))
+	}
+	return nil
+}
+
+func TestProcessItem(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	failedAt := metav1.NewTime(fixedClock.Now().Add(-10 * time.Minute))
+
+	meshIssuer := cmmeta.ObjectReference{Name: "mesh-ca", Kind: cmapi.ClusterIssuerKind}
+	split := cmapi.CertificateSplitIssuance{
+		Name:      "mesh",
+		DNSZones:  []string{"svc.cluster.local"},
+		IssuerRef: meshIssuer,
+	}
+
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateUID("test"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com", "api.svc.cluster.local"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind}),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+	)
+	crt := gen.CertificateFrom(baseCrt, gen.SetCertificateSplitIssuances(split))
+	meshCrt := splitCertificate(crt, split)
+
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	otherPK := testcrypto.MustCreatePEMPrivateKey(t)
+	meshCert := testcrypto.MustCreateCert(t, pk, meshCrt)
+
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSPrivateKeyKey: pk,
+			corev1.TLSCertKey:       testcrypto.MustCreateCert(t, pk, gen.CertificateFrom(baseCrt, gen.SetCertificateDNSNames("example.com"))),
+		}),
+	)
+	secretWithMesh := func(certPEM, caPEM []byte) *corev1.Secret {
+		s := secret.DeepCopy()
+		s.Annotations = map[string]string{cmapi.SplitIssuancesAnnotationKey: "mesh"}
+		if certPEM != nil {
+			s.Data["mesh.crt"] = certPEM
+		}
+		if caPEM != nil {
+			s.Data["mesh-ca.crt"] = caPEM
+		}
+		return s
+	}
+
+	request := func(name string, keyPEM []byte, mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		return gen.CertificateRequest(name, append([]gen.CertificateRequestModifier{
+			gen.SetCertificateRequestNamespace("testns"),
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateNameKey:                           "test",
+				cmapi.CertificateRequestSplitIssuanceAnnotationKey: "mesh",
+				cmapi.CertificateRequestPrivateKeyAnnotationKey:    "test-secret",
+			}),
+			gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(crt, certificateGvk)),
+			gen.SetCertificateRequestIssuer(meshIssuer),
+			gen.SetCertificateRequestCSR(testcrypto.MustGenerateCSRImpl(t, keyPEM, meshCrt)),
+		}, mods...)...)
+	}
+	createdRequest := gen.CertificateRequest("",
+		gen.SetCertificateRequestNamespace("testns"),
+		gen.SetCertificateRequestGenerateName("test-mesh-"),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateNameKey:                           "test",
+			cmapi.CertificateRequestSplitIssuanceAnnotationKey: "mesh",
+			cmapi.CertificateRequestPrivateKeyAnnotationKey:    "test-secret",
+		}),
+		gen.AddCertificateRequestLabels(map[string]string{
+			cmapi.CertificateNameLabelKey: "test",
+			cmapi.IssuerNameLabelKey:      "mesh-ca",
+			cmapi.IssuerKindLabelKey:      cmapi.ClusterIssuerKind,
+		}),
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(crt, certificateGvk)),
+		gen.SetCertificateRequestIssuer(meshIssuer),
+	)
+	createRequestAction := testpkg.NewCustomMatch(coretesting.NewCreateAction(
+		cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", createdRequest), relaxedCertificateRequestMatcher)
+
+	tests := map[string]struct {
+		certificate     *cmapi.Certificate
+		secret          *corev1.Secret
+		requests        []runtime.Object
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"do nothing if the Certificate has no split issuances": {
+			certificate: baseCrt,
+			secret:      secret,
+		},
+		"do nothing if the Certificate is not Ready": {
+			certificate: gen.CertificateFrom(crt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse})),
+			secret:      secret,
+		},
+		"create a CertificateRequest if the Secret has no certificate for the split issuance": {
+			certificate: crt,
+			secret:      secret,
+			expectedActions: []testpkg.Action{
+				createRequestAction,
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", secretWithMesh(nil, nil))),
+			},
+			expectedEvents: []string{`Normal SplitIssuanceRequested Created new CertificateRequest resource for split issuance "mesh"`},
+		},
+		"store the certificate of an issued CertificateRequest in the Secret": {
+			certificate: crt,
+			secret:      secretWithMesh(nil, nil),
+			requests: []runtime.Object{
+				request("test-mesh-1", pk, gen.SetCertificateRequestCertificate(meshCert), gen.SetCertificateRequestCA([]byte("ca"))),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", secretWithMesh(meshCert, []byte("ca")))),
+			},
+			expectedEvents: []string{`Normal SplitIssuanceIssued Issued the certificate of split issuance "mesh" from CertificateRequest "test-mesh-1"`},
+		},
+		"do nothing if the certificate of the split issuance is up to date": {
+			certificate: crt,
+			secret:      secretWithMesh(meshCert, []byte("ca")),
+		},
+		"wait for a pending CertificateRequest": {
+			certificate: crt,
+			secret:      secretWithMesh(nil, nil),
+			requests:    []runtime.Object{request("test-mesh-1", pk)},
+		},
+		"replace a CertificateRequest which does not match the private key": {
+			certificate: crt,
+			secret:      secretWithMesh(nil, nil),
+			requests:    []runtime.Object{request("test-mesh-1", otherPK)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test-mesh-1")),
+				createRequestAction,
+			},
+			expectedEvents: []string{`Normal SplitIssuanceRequested Created new CertificateRequest resource for split issuance "mesh"`},
+		},
+		"keep a recently failed CertificateRequest until it is retried": {
+			certificate: crt,
+			secret:      secretWithMesh(nil, nil),
+			requests: []runtime.Object{
+				request("test-mesh-1", pk,
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:    cmapi.CertificateRequestConditionReady,
+						Status:  cmmeta.ConditionFalse,
+						Reason:  cmapi.CertificateRequestReasonFailed,
+						Message: "issuer unavailable",
+					}),
+					gen.SetCertificateRequestFailureTime(failedAt),
+				),
+			},
+			expectedEvents: []string{`Warning SplitIssuanceFailed The CertificateRequest "test-mesh-1" of split issuance "mesh" failed, retrying in 50m0s: issuer unavailable`},
+		},
+		"remove the entries and CertificateRequests of removed split issuances": {
+			certificate: baseCrt,
+			secret:      secretWithMesh(meshCert, []byte("ca")),
+			requests:    []runtime.Object{request("test-mesh-1", pk)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test-mesh-1")),
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", gen.SecretFrom(secret.DeepCopy(), gen.SetSecretAnnotations(map[string]string{})))),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: append([]runtime.Object{test.certificate}, test.requests...),
+				KubeObjects:        []runtime.Object{test.secret},
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key := test.certificate.Namespace + "/" + test.certificate.Name
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
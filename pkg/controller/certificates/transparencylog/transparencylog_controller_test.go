@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transparencylog
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const testLogURL = "https://rekor.example.com"
+
+type fakeLog struct {
+	entry *cmapi.CertificateTransparencyLogEntry
+	err   error
+}
+
+func (f *fakeLog) Publish(_ context.Context, cert, issuer *x509.Certificate) (*cmapi.CertificateTransparencyLogEntry, error) {
+	return f.entry, f.err
+}
+
+func TestProcessItem(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	certPEM := testcrypto.MustCreateCert(t, pk, baseCrt)
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certPEM}),
+	)
+	readyCrt := gen.CertificateFrom(baseCrt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}))
+	entry := &cmapi.CertificateTransparencyLogEntry{
+		LogURL:      testLogURL,
+		UUID:        "abc",
+		LogIndex:    7,
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+	}
+	publishedCrt := readyCrt.DeepCopy()
+	publishedCrt.Status.TransparencyLogEntry = entry
+
+	tests := map[string]struct {
+		crt       *cmapi.Certificate
+		log       *fakeLog
+		expUpdate *cmapi.Certificate
+		expEvents []string
+		wantsErr  bool
+	}{
+		"do nothing if the Certificate is not Ready": {
+			crt: baseCrt,
+			log: &fakeLog{entry: entry},
+		},
+		"publish the certificate if it has no entry": {
+			crt:       readyCrt,
+			log:       &fakeLog{entry: entry},
+			expUpdate: publishedCrt,
+			expEvents: []string{"Normal TransparencyLogPublished Published certificate to the transparency log at index 7"},
+		},
+		"do nothing if the certificate has already been published": {
+			crt: publishedCrt,
+			log: &fakeLog{err: errors.New("should not be called")},
+		},
+		"publish the certificate again if the entry is for a previous certificate": {
+			crt: gen.CertificateFrom(publishedCrt, func(crt *cmapi.Certificate) {
+				crt.Status.TransparencyLogEntry = &cmapi.CertificateTransparencyLogEntry{LogURL: testLogURL, Fingerprint: "old"}
+			}),
+			log:       &fakeLog{entry: entry},
+			expUpdate: publishedCrt,
+			expEvents: []string{"Normal TransparencyLogPublished Published certificate to the transparency log at index 7"},
+		},
+		"return an error if the certificate cannot be published": {
+			crt:       readyCrt,
+			log:       &fakeLog{err: errors.New("unavailable")},
+			expEvents: []string{"Warning TransparencyLogFailed Failed to publish certificate to the transparency log: unavailable"},
+			wantsErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: []runtime.Object{test.crt},
+				KubeObjects:        []runtime.Object{secret},
+				ExpectedEvents:     test.expEvents,
+			}
+			if test.expUpdate != nil {
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						test.expUpdate.Namespace,
+						test.expUpdate)))
+			}
+			builder.Init()
+			builder.TransparencyLogURL = testLogURL
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.controller.log = test.log
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = w.controller.ProcessItem(context.Background(), key)
+			if test.wantsErr != (err != nil) {
+				t.Errorf("expected error: %v, got : %v", test.wantsErr, err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
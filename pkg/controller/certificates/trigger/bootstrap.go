@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	reasonWaitingForBootstrap = "WaitingForBootstrap"
+	reasonInvalidBootstrap    = "InvalidBootstrapAfter"
+	reasonBootstrapAfterCycle = "BootstrapAfterCycle"
+)
+
+// holdForBootstrap is called before the first issuance of a Certificate. It
+// returns true if the issuance must be held back because one of the
+// Certificates listed in its `cert-manager.io/bootstrap-after` annotation is
+// not Ready yet. The Certificate is processed again when any of them
+// changes. The WaitingForBootstrap event is only recorded when the hold
+// begins, not every time the held Certificate is processed.
+//
+// A Certificate whose annotation is invalid, or which transitively depends
+// on itself, is never held back: waiting would never end, so a warning Event
+// is recorded and the Certificate is issued as if it wasn't annotated.
+func (c *controller) holdForBootstrap(ctx context.Context, crt *cmapi.Certificate) (bool, error) {
+	held, message, err := c.bootstrapHold(crt)
+	if err != nil {
+		return false, err
+	}
+	key := types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name}.String()
+	if !held {
+		c.setHeldForBootstrap(key, false)
+		return false, nil
+	}
+
+	if c.setHeldForBootstrap(key, true) {
+		logf.FromContext(ctx).V(logf.InfoLevel).Info(message)
+		c.recorder.Event(crt, corev1.EventTypeNormal, reasonWaitingForBootstrap, message)
+	}
+	return true, nil
+}
+
+// bootstrapHold returns whether the first issuance of crt must be held back,
+// and if so, a message naming the Certificates it is waiting for.
+func (c *controller) bootstrapHold(crt *cmapi.Certificate) (bool, string, error) {
+	deps, err := apiutil.BootstrapAfter(crt)
+	if err != nil {
+		c.recorder.Event(crt, corev1.EventTypeWarning, reasonInvalidBootstrap, err.Error())
+		return false, "", nil
+	}
+	if len(deps) == 0 {
+		return false, "", nil
+	}
+
+	cycle, err := c.bootstrapAfterCycle(crt)
+	if err != nil {
+		return false, "", err
+	}
+	if len(cycle) > 0 {
+		message := fmt.Sprintf("Ignoring the %q annotation as the Certificates depend on each other: %s",
+			cmapi.BootstrapAfterAnnotationKey, strings.Join(cycle, " -> "))
+		c.recorder.Event(crt, corev1.EventTypeWarning, reasonBootstrapAfterCycle, message)
+		return false, "", nil
+	}
+
+	var waiting []string
+	for _, dep := range deps {
+		ready, err := c.certificateReady(dep)
+		if err != nil {
+			return false, "", err
+		}
+		if !ready {
+			waiting = append(waiting, dep.String())
+		}
+	}
+	if len(waiting) == 0 {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf("Waiting for the Certificates %s to be Ready before issuing for the first time", strings.Join(waiting, ", ")), nil
+}
+
+// setHeldForBootstrap records whether the first issuance of the Certificate
+// with the given key is held back, and returns true if the hold begins.
+func (c *controller) setHeldForBootstrap(key string, held bool) bool {
+	c.heldForBootstrapLock.Lock()
+	defer c.heldForBootstrapLock.Unlock()
+	if !held {
+		delete(c.heldForBootstrap, key)
+		return false
+	}
+	if _, ok := c.heldForBootstrap[key]; ok {
+		return false
+	}
+	c.heldForBootstrap[key] = struct{}{}
+	return true
+}
+
+// certificateReady returns whether the given Certificate exists and is Ready.
+func (c *controller) certificateReady(name types.NamespacedName) (bool, error) {
+	crt, err := c.certificateLister.Certificates(name.Namespace).Get(name.Name)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}), nil
+}
+
+// bootstrapAfterCycle follows the `cert-manager.io/bootstrap-after`
+// annotations of the Certificates that crt depends on, and returns the path
+// back to crt if there is one.
+func (c *controller) bootstrapAfterCycle(crt *cmapi.Certificate) ([]string, error) {
+	start := types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name}
+	visited := map[types.NamespacedName]bool{start: true}
+
+	var visit func(crt *cmapi.Certificate, path []string) ([]string, error)
+	visit = func(crt *cmapi.Certificate, path []string) ([]string, error) {
+		deps, err := apiutil.BootstrapAfter(crt)
+		if err != nil {
+			// Certificates with an invalid annotation are never held back.
+			return nil, nil
+		}
+		for _, dep := range deps {
+			path := append(path[:len(path):len(path)], dep.String())
+			if dep == start {
+				return path, nil
+			}
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+
+			next, err := c.certificateLister.Certificates(dep.Namespace).Get(dep.Name)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if cycle, err := visit(next, path); err != nil || cycle != nil {
+				return cycle, err
+			}
+		}
+		return nil, nil
+	}
+
+	return visit(crt, []string{start.String()})
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func Test_controller_holdForBootstrap(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now())
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+
+	crt := gen.Certificate("intermediate",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("intermediate"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "root", Kind: "Issuer"}),
+	)
+	bootstrapAfter := func(value string) gen.CertificateModifier {
+		return gen.AddCertificateAnnotations(map[string]string{cmapi.BootstrapAfterAnnotationKey: value})
+	}
+	root := gen.Certificate("root",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("root"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "selfsigned", Kind: "Issuer"}),
+	)
+	readyRoot := gen.CertificateFrom(root, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}))
+
+	_, issuingMessage, _ := policies.SecretDoesNotExist(policies.Input{})
+	issuingCondition := cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionIssuing,
+		Status:             cmmeta.ConditionTrue,
+		Reason:             policies.DoesNotExist,
+		Message:            issuingMessage,
+		LastTransitionTime: &fixedNow,
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		existing    []runtime.Object
+		// processes is the number of times the Certificate is processed,
+		// defaulting to once.
+		processes int
+
+		wantIssuing bool
+		wantEvents  []string
+	}{
+		"Certificate without the annotation is issued": {
+			certificate: crt,
+			existing:    []runtime.Object{root},
+			wantIssuing: true,
+			wantEvents:  []string{"Normal Issuing " + issuingMessage},
+		},
+		"Certificate is held back until the Certificates it depends on are Ready": {
+			certificate: gen.CertificateFrom(crt, bootstrapAfter("root")),
+			existing:    []runtime.Object{root},
+			wantEvents:  []string{"Normal WaitingForBootstrap Waiting for the Certificates testns/root to be Ready before issuing for the first time"},
+		},
+		"Certificate is held back while the Certificates it depends on don't exist": {
+			certificate: gen.CertificateFrom(crt, bootstrapAfter("root, other-root")),
+			existing:    []runtime.Object{readyRoot},
+			wantEvents:  []string{"Normal WaitingForBootstrap Waiting for the Certificates testns/other-root to be Ready before issuing for the first time"},
+		},
+		"WaitingForBootstrap is only recorded when the hold begins": {
+			certificate: gen.CertificateFrom(crt, bootstrapAfter("root")),
+			existing:    []runtime.Object{root},
+			processes:   2,
+			wantEvents:  []string{"Normal WaitingForBootstrap Waiting for the Certificates testns/root to be Ready before issuing for the first time"},
+		},
+		"Certificate is issued once the Certificates it depends on are Ready": {
+			certificate: gen.CertificateFrom(crt, bootstrapAfter("root")),
+			existing:    []runtime.Object{readyRoot},
+			wantIssuing: true,
+			wantEvents:  []string{"Normal Issuing " + issuingMessage},
+		},
+		"renewals are not held back": {
+			certificate: gen.CertificateFrom(crt, bootstrapAfter("root"), gen.SetCertificateRevision(1)),
+			existing:    []runtime.Object{root},
+			wantIssuing: true,
+			wantEvents:  []string{"Normal Issuing " + issuingMessage},
+		},
+		"Certificate with an invalid annotation is issued": {
+			certificate: gen.CertificateFrom(crt, bootstrapAfter("Root")),
+			existing:    []runtime.Object{root},
+			wantIssuing: true,
+			wantEvents: []string{
+				`Warning InvalidBootstrapAfter invalid "cert-manager.io/bootstrap-after" annotation: "Root" is not a valid Certificate name: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+				"Normal Issuing " + issuingMessage,
+			},
+		},
+		"Certificate naming a Certificate in another namespace is issued": {
+			certificate: gen.CertificateFrom(crt, bootstrapAfter("cert-manager/cluster-root")),
+			existing:    []runtime.Object{root},
+			wantIssuing: true,
+			wantEvents: []string{
+				`Warning InvalidBootstrapAfter invalid "cert-manager.io/bootstrap-after" annotation: "cert-manager/cluster-root" must be the name of a Certificate in the namespace "testns"`,
+				"Normal Issuing " + issuingMessage,
+			},
+		},
+		"Certificates which depend on each other are issued": {
+			certificate: gen.CertificateFrom(crt, bootstrapAfter("root")),
+			existing:    []runtime.Object{gen.CertificateFrom(root, bootstrapAfter("intermediate"))},
+			wantIssuing: true,
+			wantEvents: []string{
+				`Warning BootstrapAfterCycle Ignoring the "cert-manager.io/bootstrap-after" annotation as the Certificates depend on each other: testns/intermediate -> testns/root -> testns/intermediate`,
+				"Normal Issuing " + issuingMessage,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: append([]runtime.Object{test.certificate}, test.existing...),
+				ExpectedEvents:     test.wantEvents,
+			}
+			if test.wantIssuing {
+				expectedCrt := test.certificate.DeepCopy()
+				expectedCrt.Status.Conditions = []cmapi.CertificateCondition{issuingCondition}
+				builder.ExpectedActions = append(builder.ExpectedActions, testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns", expectedCrt)))
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			for i := 0; i < test.processes || i == 0; i++ {
+				if err := w.controller.ProcessItem(context.Background(), "testns/intermediate"); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
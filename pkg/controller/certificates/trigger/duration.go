@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// durationMismatchSlack is the amount by which the lifetime of an issued
+	// certificate may be shorter than spec.duration without being considered
+	// shortened by the issuer, to allow for CAs which round the validity
+	// period of the certificates they issue.
+	durationMismatchSlack = 5 * time.Minute
+
+	reasonDurationMismatch = "DurationMismatch"
+	reasonDurationClamped  = "DurationClamped"
+)
+
+// durationShortfall returns the requested and the issued lifetime of the
+// certificate stored in the Secret of crt, and whether the issued lifetime
+// falls short of the spec.duration of crt. Certificates which don't set
+// spec.duration are never considered short.
+func durationShortfall(crt *cmapi.Certificate, secret *corev1.Secret) (time.Duration, time.Duration, bool) {
+	if crt.Spec.Duration == nil || secret == nil {
+		return 0, 0, false
+	}
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return 0, 0, false
+	}
+	requested := crt.Spec.Duration.Duration
+	issued := cert.NotAfter.Sub(cert.NotBefore)
+	if alignment, _ := certificates.ValidityAlignmentForCertificate(crt); alignment != nil && certificates.AlignedDurationMatches(requested, issued) {
+		// The duration was shortened by the request manager to align the
+		// expiry of the certificate.
+		return requested, issued, false
+	}
+	return requested, issued, requested-issued > durationMismatchSlack
+}
+
+// checkIssuedDuration compares the lifetime of the certificate of crt with its
+// spec.duration. A shorter lifetime is not a reason to re-issue the
+// certificate, as the issuer would shorten it again. If the difference is
+// within the durationTolerance of the issuer, the IssuerClampedDuration
+// condition is set on crt, otherwise a warning event is emitted. The
+// condition is removed once the lifetime matches spec.duration.
+func (c *controller) checkIssuedDuration(ctx context.Context, crt *cmapi.Certificate, input policies.Input) error {
+	if c.issuerHelper == nil {
+		return nil
+	}
+
+	var message string
+	requested, issued, short := durationShortfall(crt, input.Secret)
+	if short {
+		iss, err := c.issuerHelper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+		if apierrors.IsNotFound(err) {
+			logf.FromContext(ctx).V(logf.DebugLevel).Info("issuer not found, skipping check of the issued certificate duration")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		tolerance := iss.GetSpec().DurationTolerance
+		if tolerance == nil || requested-issued > tolerance.MaxShortening.Duration {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonDurationMismatch,
+				"Issued certificate is valid for %s, which is shorter than the requested duration of %s. "+
+					"Set spec.durationTolerance on the issuer if it is expected to shorten the lifetime of certificates", issued, requested)
+		} else {
+			message = fmt.Sprintf("Issuer shortened the lifetime of the certificate from the requested %s to %s", requested, issued)
+		}
+	}
+
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuerClampedDuration)
+	switch {
+	case len(message) > 0:
+		if cond != nil && cond.Message == message && cond.ObservedGeneration == crt.Generation {
+			return nil
+		}
+		crt = crt.DeepCopy()
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuerClampedDuration, cmmeta.ConditionTrue, reasonDurationClamped, message)
+	case cond != nil:
+		crt = crt.DeepCopy()
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuerClampedDuration)
+	default:
+		return nil
+	}
+	return c.updateOrApplyStatus(ctx, crt)
+}
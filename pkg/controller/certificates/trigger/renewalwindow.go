@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// defaultHardExpiryMargin is the hard expiry margin of RenewalWindows which
+// don't set hardExpiryMargin.
+const defaultHardExpiryMargin = 72 * time.Hour
+
+// renewalDeferral describes why the renewal of a Certificate is deferred.
+type renewalDeferral struct {
+	window *cmapi.RenewalWindow
+	reason string
+
+	// until is the time at which the renewal can proceed.
+	until time.Time
+}
+
+func (d *renewalDeferral) message() string {
+	message := fmt.Sprintf("Renewal deferred until %s by RenewalWindow %s/%s", d.until.UTC().Format(time.RFC3339), d.window.Namespace, d.window.Name)
+	if len(d.reason) > 0 {
+		message += ": " + d.reason
+	}
+	return message
+}
+
+// deferRenewal returns the deferral of the renewal of crt by the blackouts of
+// windows which are active at the given time, or nil if the renewal can
+// proceed. A renewal is never deferred past the hard expiry margin of a
+// RenewalWindow. If multiple blackouts are active, the one ending first is
+// returned, as the renewal is deferred again when it is re-checked.
+func deferRenewal(crt *cmapi.Certificate, windows []*cmapi.RenewalWindow, now time.Time) *renewalDeferral {
+	var deferral *renewalDeferral
+	for _, window := range windows {
+		margin := defaultHardExpiryMargin
+		if window.Spec.HardExpiryMargin != nil {
+			margin = window.Spec.HardExpiryMargin.Duration
+		}
+
+		var hardDeadline time.Time
+		if crt.Status.NotAfter != nil {
+			hardDeadline = crt.Status.NotAfter.Add(-margin)
+			if !now.Before(hardDeadline) {
+				continue
+			}
+		}
+
+		for _, blackout := range window.Spec.Blackouts {
+			if now.Before(blackout.Start.Time) || !now.Before(blackout.End.Time) {
+				continue
+			}
+
+			until := blackout.End.Time
+			if !hardDeadline.IsZero() && hardDeadline.Before(until) {
+				until = hardDeadline
+			}
+			if deferral == nil || until.Before(deferral.until) {
+				deferral = &renewalDeferral{window: window, reason: blackout.Reason, until: until}
+			}
+		}
+	}
+	return deferral
+}
+
+// renewalWindowsFor returns the RenewalWindows which apply to crt: the
+// RenewalWindows in its namespace and in the cluster resource namespace.
+func (c *controller) renewalWindowsFor(crt *cmapi.Certificate) ([]*cmapi.RenewalWindow, error) {
+	windows, err := c.renewalWindowLister.RenewalWindows(crt.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	if len(c.clusterResourceNamespace) == 0 || c.clusterResourceNamespace == crt.Namespace {
+		return windows, nil
+	}
+
+	clusterWindows, err := c.renewalWindowLister.RenewalWindows(c.clusterResourceNamespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return append(windows, clusterWindows...), nil
+}
+
+// enqueueCertificatesForRenewalWindow returns a work function which enqueues
+// the Certificates that a changed RenewalWindow applies to, so that deferred
+// renewals proceed as soon as a blackout is removed.
+func (c *controller) enqueueCertificatesForRenewalWindow(log logr.Logger, queue workqueue.Interface) func(obj interface{}) {
+	return func(obj interface{}) {
+		window, ok := obj.(*cmapi.RenewalWindow)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-RenewalWindow type resource passed to enqueueCertificatesForRenewalWindow")
+			return
+		}
+
+		var crts []*cmapi.Certificate
+		var err error
+		if window.Namespace == c.clusterResourceNamespace {
+			crts, err = c.certificateLister.List(labels.Everything())
+		} else {
+			crts, err = c.certificateLister.Certificates(window.Namespace).List(labels.Everything())
+		}
+		if err != nil {
+			log.Error(err, "failed listing Certificate resources")
+			return
+		}
+
+		for _, crt := range crts {
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "error determining 'key' for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
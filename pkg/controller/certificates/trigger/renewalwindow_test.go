@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestDeferRenewal(t *testing.T) {
+	now := time.Date(2022, 12, 20, 12, 0, 0, 0, time.UTC)
+	window := func(name string, margin *metav1.Duration, blackouts ...cmapi.RenewalBlackout) *cmapi.RenewalWindow {
+		return &cmapi.RenewalWindow{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name},
+			Spec:       cmapi.RenewalWindowSpec{Blackouts: blackouts, HardExpiryMargin: margin},
+		}
+	}
+	blackout := func(start, end time.Duration, reason string) cmapi.RenewalBlackout {
+		return cmapi.RenewalBlackout{
+			Start:  metav1.NewTime(now.Add(start)),
+			End:    metav1.NewTime(now.Add(end)),
+			Reason: reason,
+		}
+	}
+	crtExpiringIn := func(d time.Duration) *cmapi.Certificate {
+		return gen.Certificate("test", gen.SetCertificateNamespace("testns"), gen.SetCertificateNotAfter(metav1.NewTime(now.Add(d))))
+	}
+
+	tests := map[string]struct {
+		crt         *cmapi.Certificate
+		windows     []*cmapi.RenewalWindow
+		expUntil    time.Time
+		expDeferred bool
+		expMessage  string
+	}{
+		"no windows": {
+			crt: crtExpiringIn(30 * 24 * time.Hour),
+		},
+		"no active blackout": {
+			crt: crtExpiringIn(30 * 24 * time.Hour),
+			windows: []*cmapi.RenewalWindow{window("freeze", nil,
+				blackout(-48*time.Hour, -24*time.Hour, ""),
+				blackout(time.Hour, 24*time.Hour, ""),
+			)},
+		},
+		"active blackout defers until its end": {
+			crt:         crtExpiringIn(30 * 24 * time.Hour),
+			windows:     []*cmapi.RenewalWindow{window("freeze", nil, blackout(-time.Hour, 24*time.Hour, "end of year freeze"))},
+			expDeferred: true,
+			expUntil:    now.Add(24 * time.Hour),
+			expMessage:  "Renewal deferred until 2022-12-21T12:00:00Z by RenewalWindow testns/freeze: end of year freeze",
+		},
+		"the earliest ending active blackout is returned": {
+			crt: crtExpiringIn(30 * 24 * time.Hour),
+			windows: []*cmapi.RenewalWindow{
+				window("long", nil, blackout(-time.Hour, 48*time.Hour, "")),
+				window("short", nil, blackout(-time.Hour, 2*time.Hour, "")),
+			},
+			expDeferred: true,
+			expUntil:    now.Add(2 * time.Hour),
+			expMessage:  "Renewal deferred until 2022-12-20T14:00:00Z by RenewalWindow testns/short",
+		},
+		"deferral is capped at the hard expiry margin": {
+			crt:         crtExpiringIn(4 * 24 * time.Hour),
+			windows:     []*cmapi.RenewalWindow{window("freeze", nil, blackout(-time.Hour, 7*24*time.Hour, ""))},
+			expDeferred: true,
+			expUntil:    now.Add(24 * time.Hour),
+			expMessage:  "Renewal deferred until 2022-12-21T12:00:00Z by RenewalWindow testns/freeze",
+		},
+		"renewals within the hard expiry margin are not deferred": {
+			crt:     crtExpiringIn(2 * 24 * time.Hour),
+			windows: []*cmapi.RenewalWindow{window("freeze", nil, blackout(-time.Hour, 7*24*time.Hour, ""))},
+		},
+		"custom hard expiry margin": {
+			crt:     crtExpiringIn(10 * 24 * time.Hour),
+			windows: []*cmapi.RenewalWindow{window("freeze", &metav1.Duration{Duration: 14 * 24 * time.Hour}, blackout(-time.Hour, 7*24*time.Hour, ""))},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			deferral := deferRenewal(test.crt, test.windows, now)
+			if test.expDeferred != (deferral != nil) {
+				t.Fatalf("expected deferred=%t, got %+v", test.expDeferred, deferral)
+			}
+			if deferral == nil {
+				return
+			}
+			if !deferral.until.Equal(test.expUntil) {
+				t.Errorf("expected renewal to be deferred until %s, got %s", test.expUntil, deferral.until)
+			}
+			if msg := deferral.message(); msg != test.expMessage {
+				t.Errorf("expected message %q, got %q", test.expMessage, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	reasonRestoredSecretAdopted        = "RestoredSecretAdopted"
+	reasonRestoredSecretSerialMismatch = "RestoredSecretSerialMismatch"
+)
+
+// adoptRestoredSecret is called in restore mode for a Certificate which would
+// be re-issued because its Secret is missing the issuer annotations written
+// by cert-manager, which happens when the Secret is restored from a backup
+// taken by a tool which doesn't preserve them. If the certificate stored in
+// the Secret is otherwise up to date, the annotations are added to the Secret
+// and the certificate is adopted rather than re-issued.
+//
+// The serial number of the stored certificate must match the serial number
+// recorded on the Certificate status and on the Secret, if either is known,
+// so that a Secret restored from an older backup than the Certificate is
+// never adopted.
+//
+// It returns true if the Secret was adopted.
+func (c *controller) adoptRestoredSecret(ctx context.Context, crt *cmapi.Certificate, input policies.Input) (bool, error) {
+	log := logf.FromContext(ctx)
+	secret := input.Secret
+
+	// Secrets which have been annotated by cert-manager for another
+	// Certificate or issuer are not restore artifacts.
+	if name, ok := secret.Annotations[cmapi.CertificateNameKey]; ok && name != crt.Name {
+		return false, nil
+	}
+	for _, key := range []string{cmapi.IssuerNameAnnotationKey, cmapi.IssuerKindAnnotationKey, cmapi.IssuerGroupAnnotationKey} {
+		if _, ok := secret.Annotations[key]; ok {
+			return false, nil
+		}
+	}
+
+	x509cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return false, nil
+	}
+
+	serial := x509cert.SerialNumber.Text(16)
+	for _, recorded := range []string{crt.Status.SerialNumber, secret.Annotations[cmapi.SerialNumberAnnotationKey]} {
+		if recorded != "" && recorded != serial {
+			message := fmt.Sprintf("Not adopting restored Secret %q as it holds the certificate with serial number %s, but the certificate with serial number %s was expected", secret.Name, serial, recorded)
+			log.V(logf.InfoLevel).Info(message)
+			c.recorder.Event(crt, corev1.EventTypeWarning, reasonRestoredSecretSerialMismatch, message)
+			return false, nil
+		}
+	}
+
+	adopted := secret.DeepCopy()
+	if adopted.Annotations == nil {
+		adopted.Annotations = make(map[string]string)
+	}
+	for k, v := range internalcertificates.AnnotationsForCertificateSecret(crt, x509cert) {
+		adopted.Annotations[k] = v
+	}
+
+	// Only adopt the Secret if the missing annotations were the only reason
+	// for re-issuance.
+	input.Secret = adopted
+	if reason, _, reissue := c.shouldReissue(input); reissue {
+		log.V(logf.DebugLevel).Info("not adopting restored Secret as it must be re-issued", "reason", reason)
+		return false, nil
+	}
+
+	if _, err := c.kubeClient.CoreV1().Secrets(adopted.Namespace).Update(ctx, adopted, metav1.UpdateOptions{FieldManager: c.fieldManager}); err != nil {
+		return false, err
+	}
+
+	message := fmt.Sprintf("Adopted the certificate with serial number %s in restored Secret %q", serial, secret.Name)
+	log.V(logf.InfoLevel).Info(message)
+	c.recorder.Event(crt, corev1.EventTypeNormal, reasonRestoredSecretAdopted, message)
+
+	return true, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func Test_controller_adoptRestoredSecret(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now())
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+
+	crt := gen.Certificate("cert-1",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("secret-1"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "Issuer"}),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	serial := bundle.Cert.SerialNumber.Text(16)
+
+	// restoredSecret is the Secret of the Certificate as restored by a backup
+	// tool which didn't preserve its annotations.
+	restoredSecret := gen.Secret("secret-1",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       bundle.CertBytes,
+			corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+		}),
+	)
+	adoptedSecret := restoredSecret.DeepCopy()
+	adoptedSecret.Annotations = internalcertificates.AnnotationsForCertificateSecret(crt, bundle.Cert)
+
+	_, incorrectIssuerMessage, _ := policies.SecretIssuerAnnotationsNotUpToDate(policies.Input{Certificate: crt, Secret: restoredSecret})
+	issuingCondition := cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionIssuing,
+		Status:             cmmeta.ConditionTrue,
+		Reason:             policies.IncorrectIssuer,
+		Message:            incorrectIssuerMessage,
+		LastTransitionTime: &fixedNow,
+	}
+
+	tests := map[string]struct {
+		restoreMode bool
+		certificate *cmapi.Certificate
+		secret      *corev1.Secret
+
+		wantAdopted bool
+		wantEvents  []string
+	}{
+		"restore mode disabled, Secret missing annotations is re-issued": {
+			certificate: crt,
+			secret:      restoredSecret,
+			wantEvents:  []string{"Normal Issuing " + incorrectIssuerMessage},
+		},
+		"restore mode enabled, Secret missing annotations is adopted": {
+			restoreMode: true,
+			certificate: crt,
+			secret:      restoredSecret,
+			wantAdopted: true,
+			wantEvents:  []string{"Normal RestoredSecretAdopted Adopted the certificate with serial number " + serial + ` in restored Secret "secret-1"`},
+		},
+		"restore mode enabled, Secret with matching serial number on Certificate status is adopted": {
+			restoreMode: true,
+			certificate: gen.CertificateFrom(crt, func(crt *cmapi.Certificate) {
+				crt.Status.SerialNumber = serial
+			}),
+			secret:      restoredSecret,
+			wantAdopted: true,
+			wantEvents:  []string{"Normal RestoredSecretAdopted Adopted the certificate with serial number " + serial + ` in restored Secret "secret-1"`},
+		},
+		"restore mode enabled, Secret restored from an older backup than the Certificate is re-issued": {
+			restoreMode: true,
+			certificate: gen.CertificateFrom(crt, func(crt *cmapi.Certificate) {
+				crt.Status.SerialNumber = "abc"
+			}),
+			secret: restoredSecret,
+			wantEvents: []string{
+				"Warning RestoredSecretSerialMismatch Not adopting restored Secret \"secret-1\" as it holds the certificate with serial number " + serial + ", but the certificate with serial number abc was expected",
+				"Normal Issuing " + incorrectIssuerMessage,
+			},
+		},
+		"restore mode enabled, Secret annotated for another Certificate is re-issued": {
+			restoreMode: true,
+			certificate: crt,
+			secret: gen.SecretFrom(restoredSecret, gen.SetSecretAnnotations(map[string]string{
+				cmapi.CertificateNameKey: "cert-2",
+			})),
+			wantEvents: []string{"Normal Issuing " + incorrectIssuerMessage},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{test.certificate},
+				KubeObjects:        []runtime.Object{test.secret},
+				ExpectedEvents:     test.wantEvents,
+			}
+			if test.wantAdopted {
+				builder.ExpectedActions = append(builder.ExpectedActions, testpkg.NewAction(coretesting.NewUpdateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"), "testns", adoptedSecret)))
+			} else {
+				expectedCrt := test.certificate.DeepCopy()
+				expectedCrt.Status.Conditions = []cmapi.CertificateCondition{issuingCondition}
+				builder.ExpectedActions = append(builder.ExpectedActions, testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns", expectedCrt)))
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.restoreMode = test.restoreMode
+
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), "testns/cert-1"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
@@ -19,7 +19,9 @@ package trigger
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -28,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -37,6 +40,7 @@ import (
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -45,6 +49,7 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -53,10 +58,19 @@ import (
 
 const (
 	ControllerName = "certificates-trigger"
+	// emergencyControllerName is the name of the controller which processes
+	// the Certificates marked for emergency re-issuance using its own
+	// workqueue.
+	emergencyControllerName = "certificates-trigger-emergency"
 	// stopIncreaseBackoff is the number of issuance attempts after which the backoff period should stop to increase
 	stopIncreaseBackoff = 6 // 2 ^ (6 - 1) = 32 = maxDelay
 	// maxDelay is the maximum backoff period
 	maxDelay = 32 * time.Hour
+	// maxRenewalJitter is the maximum amount of time by which the recheck of
+	// a Certificate at its renewal time is delayed. Certificates that were
+	// issued at the same time, e.g. after a restore or a bulk import, are
+	// spread over this window instead of all being processed at once.
+	maxRenewalJitter = 5 * time.Minute
 )
 
 // This controller observes the state of the certificate's currently
@@ -68,19 +82,54 @@ type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
 	secretLister             corelisters.SecretLister
+	renewalWindowLister      cmlisters.RenewalWindowLister
 	client                   cmclient.Interface
 	recorder                 record.EventRecorder
 	scheduledWorkQueue       scheduler.ScheduledWorkQueue
 
+	// emergencyQueue holds the Certificates marked for emergency re-issuance.
+	// It is processed by runEmergencyWorker.
+	emergencyQueue workqueue.RateLimitingInterface
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
 	fieldManager string
 
+	// statusWriter is used to write the status of Certificates. It uses
+	// client unless replaced with the StatusWriter of the controller Context.
+	statusWriter *statuswriter.Writer
+
+	// clusterResourceNamespace is the namespace of the RenewalWindows which
+	// apply to Certificates in all namespaces.
+	clusterResourceNamespace string
+
+	// issuerHelper is used to look up the durationTolerance of the issuer of
+	// a Certificate. If nil, the lifetime of issued certificates is not
+	// compared with spec.duration.
+	issuerHelper issuer.Helper
+
+	// restoreMode controls whether the certificates stored in Secrets which
+	// are missing the annotations written by cert-manager, e.g. because they
+	// were restored from a backup, are adopted rather than re-issued.
+	restoreMode bool
+
+	// kubeClient is used to annotate adopted Secrets in restore mode.
+	kubeClient kubernetes.Interface
+
+	// heldForBootstrap holds the keys of the Certificates whose first
+	// issuance is currently held back by holdForBootstrap.
+	heldForBootstrapLock sync.Mutex
+	heldForBootstrap     map[string]struct{}
+
 	// The following are used for testing purposes.
 	clock              clock.Clock
 	shouldReissue      policies.Func
 	dataForCertificate func(context.Context, *cmapi.Certificate) (policies.Input, error)
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
 }
 
 func NewController(
@@ -94,25 +143,48 @@ func NewController(
 	fieldManager string,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+	// create a separate queue for the Certificates marked for emergency
+	// re-issuance, so that they don't wait behind a backlog of other
+	// Certificates
+	emergencyQueue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), emergencyControllerName)
 
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := factory.Core().V1().Secrets()
+	renewalWindowInformer := cmFactory.Certmanager().V1().RenewalWindows()
 
-	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// The event handlers of this controller are registered with a resync
+	// period of 0, which opts them out of the periodic resync of the shared
+	// informers. A Certificate does not need to be re-checked unless it, or
+	// one of its related resources, changes, or its renewal time is reached.
+	// The latter is handled by scheduling a timer for each Certificate when it
+	// is processed, which avoids periodically re-evaluating every Certificate
+	// in the cluster.
+	certificateInformer.Informer().AddEventHandlerWithResyncPeriod(&controllerpkg.QueuingEventHandler{Queue: queue}, 0)
 
 	// When a CertificateRequest resource changes, enqueue the Certificate resource that owns it.
-	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+	certificateRequestInformer.Informer().AddEventHandlerWithResyncPeriod(&controllerpkg.BlockingEventHandler{
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(), predicate.ResourceOwnerOf),
-	})
+	}, 0)
 	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
-	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+	secretsInformer.Informer().AddEventHandlerWithResyncPeriod(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to the Secret named `spec.secretName`
-		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
-			predicate.ExtractResourceName(predicate.CertificateSecretName)),
-	})
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateSecretNameIndex),
+	}, 0)
+	// When a Certificate changes, enqueue the Certificates which declare it in
+	// their bootstrap-after annotation.
+	certificateInformer.Informer().AddEventHandlerWithResyncPeriod(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForSecretUsingIndex(log, queue, certificateInformer.Informer(), controllerpkg.CertificateBootstrapAfterIndex),
+	}, 0)
+	// Certificates which are marked for emergency re-issuance are also added
+	// to the emergency queue, which is processed by workers of its own. This
+	// puts them ahead of the other Certificates waiting in the workqueue of
+	// this controller, e.g. after a restart.
+	certificateInformer.Informer().AddEventHandlerWithResyncPeriod(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueMarkedForEmergency(log, emergencyQueue),
+	}, 0)
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
@@ -120,15 +192,20 @@ func NewController(
 		certificateRequestInformer.Informer().HasSynced,
 		secretsInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
+		renewalWindowInformer.Informer().HasSynced,
 	}
 
-	return &controller{
+	ctrl := &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
+		renewalWindowLister:      renewalWindowInformer.Lister(),
 		client:                   client,
+		statusWriter:             statuswriter.New(client, nil),
 		recorder:                 recorder,
 		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		emergencyQueue:           emergencyQueue,
+		heldForBootstrap:         make(map[string]struct{}),
 		fieldManager:             fieldManager,
 
 		// The following are used for testing purposes.
@@ -138,7 +215,69 @@ func NewController(
 			CertificateRequestLister: certificateRequestInformer.Lister(),
 			SecretLister:             secretsInformer.Lister(),
 		}).DataForCertificate,
-	}, queue, mustSync
+	}
+
+	// When a RenewalWindow changes, enqueue the Certificates it applies to.
+	renewalWindowInformer.Informer().AddEventHandlerWithResyncPeriod(&controllerpkg.BlockingEventHandler{
+		WorkFunc: ctrl.enqueueCertificatesForRenewalWindow(log, queue),
+	}, 0)
+
+	return ctrl, queue, mustSync
+}
+
+// enqueueMarkedForEmergency returns a function which adds Certificates which
+// are marked for emergency re-issuance to queue, and ignores all others.
+// Whether the marked certificate is still the current one is checked when
+// the Certificate is processed.
+func enqueueMarkedForEmergency(log logr.Logger, queue workqueue.Interface) func(obj interface{}) {
+	return func(obj interface{}) {
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-certificate type resource passed to enqueueMarkedForEmergency")
+			return
+		}
+		if crt.Annotations[cmapi.EmergencyReissuanceAnnotationKey] == "" {
+			return
+		}
+		key, err := controllerpkg.KeyFunc(crt)
+		if err != nil {
+			log.Error(err, "Error determining 'key' for resource")
+			return
+		}
+		queue.Add(key)
+	}
+}
+
+// runEmergencyWorker processes the Certificates on the emergency queue until
+// ctx is cancelled.
+func (c *controller) runEmergencyWorker(ctx context.Context) {
+	log := logf.FromContext(ctx, emergencyControllerName)
+	go func() {
+		<-ctx.Done()
+		c.emergencyQueue.ShutDown()
+	}()
+	go func() {
+		for {
+			obj, shutdown := c.emergencyQueue.Get()
+			if shutdown {
+				return
+			}
+			func() {
+				defer c.emergencyQueue.Done(obj)
+				key, ok := obj.(string)
+				if !ok {
+					c.emergencyQueue.Forget(obj)
+					return
+				}
+				if err := c.ProcessItem(logf.NewContext(ctx, log), key); err != nil {
+					log.Error(err, "re-queuing item due to error processing", "key", key)
+					c.emergencyQueue.AddRateLimited(obj)
+					return
+				}
+				c.emergencyQueue.Forget(obj)
+			}()
+		}
+	}()
 }
 
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
@@ -153,11 +292,21 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	crt, err := c.certificateLister.Certificates(namespace).Get(name)
 	if apierrors.IsNotFound(err) {
 		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		// Stop any timer that was scheduled for a Certificate that has since
+		// been deleted.
+		c.scheduledWorkQueue.Forget(key)
+		c.setHeldForBootstrap(key, false)
 		return nil
 	}
 	if err != nil {
 		return err
 	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
 	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
 		Status: cmmeta.ConditionTrue,
@@ -170,9 +319,20 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	input.IssuerCapabilities, err = c.issuerCapabilities(ctx, crt)
+	if err != nil {
+		return err
+	}
+
+	// A certificate which has been marked for emergency re-issuance, e.g.
+	// because it has been revoked, is re-issued immediately. Neither the
+	// backoff after failed issuances nor RenewalWindows apply to it.
+	if reason, message, emergency := policies.CurrentCertificateMarkedForEmergency(input); emergency {
+		return c.triggerIssuance(ctx, crt, reason, message)
+	}
 
 	// Don't trigger issuance if we need to back off due to previous failures and Certificate's spec has not changed.
-	backoff, delay := shouldBackoffReissuingOnFailure(log, c.clock, input.Certificate, input.NextRevisionRequest)
+	backoff, delay := shouldBackoffReissuingOnFailure(log, c.clock, input.Certificate, input.NextRevisionRequest, input.IssuerCapabilities)
 	if backoff {
 		nextIssuanceRetry := c.clock.Now().Add(delay)
 		message := fmt.Sprintf("Backing off from issuance due to previously failed issuance(s). Issuance will next be attempted at %v", nextIssuanceRetry)
@@ -184,20 +344,61 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if crt.Status.RenewalTime != nil {
 		// ensure a resync is scheduled in the future so that we re-check
 		// Certificate resources and trigger them near expiry time
-		c.scheduleRecheckOfCertificateIfRequired(log, key, crt.Status.RenewalTime.Time.Sub(c.clock.Now()))
+		c.scheduleRecheckOfCertificateIfRequired(log, key, durationUntilRenewalRecheck(key, crt, c.clock.Now()))
 	}
 
 	reason, message, reissue := c.shouldReissue(input)
 	if !reissue {
-		// no re-issuance required, return early
-		return nil
+		// no re-issuance required, but the issuer may have shortened the
+		// lifetime of the current certificate
+		return c.checkIssuedDuration(ctx, crt, input)
 	}
 
+	if c.restoreMode && reason == policies.IncorrectIssuer {
+		adopted, err := c.adoptRestoredSecret(ctx, crt, input)
+		if err != nil || adopted {
+			return err
+		}
+	}
+
+	// The first issuance of a Certificate is held back until the
+	// Certificates it declares in the bootstrap-after annotation are Ready.
+	if crt.Status.Revision == nil {
+		held, err := c.holdForBootstrap(ctx, crt)
+		if err != nil || held {
+			return err
+		}
+	}
+
+	// Renewals due to the renewal time being reached are deferred during the
+	// blackouts of RenewalWindows. All other reasons for re-issuance mean
+	// that the current certificate is unusable or no longer matches its
+	// spec, so are never deferred.
+	if reason == policies.Renewing {
+		windows, err := c.renewalWindowsFor(crt)
+		if err != nil {
+			return err
+		}
+		if deferral := deferRenewal(crt, windows, c.clock.Now()); deferral != nil {
+			message := deferral.message()
+			log.V(logf.InfoLevel).Info(message)
+			c.recorder.Event(crt, corev1.EventTypeNormal, "RenewalDeferred", message)
+			c.scheduleRecheckOfCertificateIfRequired(log, key, deferral.until.Sub(c.clock.Now()))
+			return nil
+		}
+	}
+
+	return c.triggerIssuance(ctx, crt, reason, message)
+}
+
+// triggerIssuance sets the Issuing condition of the Certificate with the
+// given reason and message.
+func (c *controller) triggerIssuance(ctx context.Context, crt *cmapi.Certificate, reason, message string) error {
 	// Although the below recorder.Event already logs the event, the log
 	// line is quite unreadable (very long). Since this information is very
 	// important for the user and the operator, we log the following
 	// message.
-	log.V(logf.InfoLevel).Info("Certificate must be re-issued", "reason", reason, "message", message)
+	logf.FromContext(ctx).V(logf.InfoLevel).Info("Certificate must be re-issued", "reason", reason, "message", message)
 
 	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, reason, message)
@@ -213,19 +414,44 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
 func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+	return c.statusWriter.Write(ctx, crt, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			var conditions []cmapi.CertificateCondition
+			for _, condType := range []cmapi.CertificateConditionType{cmapi.CertificateConditionIssuing, cmapi.CertificateConditionIssuerClampedDuration} {
+				if cond := apiutil.GetCertificateCondition(crt, condType); cond != nil {
+					conditions = append(conditions, *cond)
+				}
+			}
+			return internalcertificates.ApplyStatus(ctx, cl, c.fieldManager, &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+				Status:     cmapi.CertificateStatus{Conditions: conditions},
+			})
+		} else {
+			_, err := cl.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+			return err
 		}
-		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
-			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status:     cmapi.CertificateStatus{Conditions: conditions},
-		})
-	} else {
-		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
-		return err
+	})
+}
+
+// issuerCapabilities returns the capabilities declared by the issuer of crt,
+// which are empty if the issuer doesn't declare any. Nil is returned if the
+// issuer doesn't exist yet, so its capabilities are not known.
+func (c *controller) issuerCapabilities(ctx context.Context, crt *cmapi.Certificate) (*cmapi.IssuerCapabilities, error) {
+	if c.issuerHelper == nil {
+		return nil, nil
+	}
+	iss, err := c.issuerHelper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		logf.FromContext(ctx).V(logf.DebugLevel).Info("issuer not found, not checking dropped usages against its capabilities")
+		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
+	if caps := iss.GetSpec().Capabilities; caps != nil {
+		return caps, nil
+	}
+	return &cmapi.IssuerCapabilities{}, nil
 }
 
 // shouldBackOffReissuingOnFailure returns true if an issuance needs to be
@@ -239,8 +465,9 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 // gets re-issued immediately).
 //
 // Note that the request can be left nil: in that case, the returned back-off
-// will be 0 since it means the CR must be created immediately.
-func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.Certificate, nextCR *cmapi.CertificateRequest) (bool, time.Duration) {
+// will be 0 since it means the CR must be created immediately. The current
+// capabilities of the issuer may be nil if they are not known.
+func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.Certificate, nextCR *cmapi.CertificateRequest, caps *cmapi.IssuerCapabilities) (bool, time.Duration) {
 	if crt.Status.LastFailureTime == nil {
 		return false, 0
 	}
@@ -258,13 +485,13 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 	if nextCR == nil {
 		log.V(logf.InfoLevel).Info("next CertificateRequest not available, skipping checking if Certificate matches the CertificateRequest")
 	} else {
-		mismatches, err := certificates.RequestMatchesSpec(nextCR, crt.Spec)
+		mismatches, err := certificates.RequestMatchesSpec(nextCR, crt.Spec, caps)
 		if err != nil {
 			log.V(logf.InfoLevel).Info("next CertificateRequest cannot be decoded, skipping checking if Certificate matches the CertificateRequest")
 			return false, 0
 		}
 		if len(mismatches) > 0 {
-			log.V(logf.ExtendedInfoLevel).WithValues("mismatches", mismatches).Info("Certificate is failing but the Certificate differs from CertificateRequest, backoff is not required")
+			log.V(logf.ExtendedInfoLevel).WithValues("mismatches", mismatches.String()).Info("Certificate is failing but the Certificate differs from CertificateRequest, backoff is not required")
 			return false, 0
 		}
 	}
@@ -328,6 +555,40 @@ func (c *controller) scheduleRecheckOfCertificateIfRequired(log logr.Logger, key
 	c.scheduledWorkQueue.Add(key, durationUntilRenewalTime)
 }
 
+// durationUntilRenewalRecheck returns the duration from now until the
+// Certificate with the given key should be rechecked at its renewal time,
+// including the jitter returned by renewalJitter. The recheck time only
+// depends on the status of the Certificate, so it is the same every time the
+// Certificate is processed until it is re-issued.
+func durationUntilRenewalRecheck(key string, crt *cmapi.Certificate, now time.Time) time.Duration {
+	recheck := crt.Status.RenewalTime.Time
+	if crt.Status.NotAfter != nil {
+		recheck = recheck.Add(renewalJitter(key, crt.Status.NotAfter.Sub(recheck)))
+	}
+	return recheck.Sub(now)
+}
+
+// renewalJitter returns the amount of time by which the recheck of the
+// Certificate with the given key should be delayed past its renewal time.
+// The jitter is derived from the key so that repeated calls for the same
+// Certificate schedule the recheck for the same time. It is at most
+// maxRenewalJitter, and at most a tenth of renewBefore, the time between the
+// renewal time and the expiry of the certificate, so that short-lived
+// certificates are not renewed noticeably late.
+func renewalJitter(key string, renewBefore time.Duration) time.Duration {
+	window := maxRenewalJitter
+	if d := renewBefore / 10; d < window {
+		window = d
+	}
+	if window <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return time.Duration(h.Sum64() % uint64(window))
+}
+
 // controllerWrapper wraps the `controller` structure to make it implement
 // the controllerpkg.queueingController interface
 type controllerWrapper struct {
@@ -347,6 +608,17 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		policies.NewTriggerPolicyChain(ctx.Clock).Evaluate,
 		ctx.FieldManager,
 	)
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+	ctrl.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
+	ctrl.controllerClass = ctx.ControllerClass
+	ctrl.restoreMode = ctx.CertificateOptions.RestoreMode
+	ctrl.kubeClient = ctx.Client
+
+	var issuerSynced []cache.InformerSynced
+	ctrl.issuerHelper, issuerSynced = issuer.NewHelperFromContext(ctx)
+	mustSync = append(mustSync, issuerSynced...)
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -354,8 +626,10 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 
 func init() {
 	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		w := &controllerWrapper{}
 		return controllerpkg.NewBuilder(ctx, ControllerName).
-			For(&controllerWrapper{}).
+			For(w).
+			First(func(ctx context.Context) { w.runEmergencyWorker(ctx) }).
 			Complete()
 	})
 }
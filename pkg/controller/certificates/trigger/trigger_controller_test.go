@@ -24,13 +24,16 @@ import (
 
 	logtesting "github.com/go-logr/logr/testing"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
@@ -46,6 +49,41 @@ func Test_controller_ProcessItem(t *testing.T) {
 		return testcrypto.MustCreateCryptoBundle(t, crt, fixedClock).CertificateRequest
 	}
 
+	// The certificate stored in the Secret of a Certificate which has been
+	// marked for emergency re-issuance.
+	revokedBundle := testcrypto.MustCreateCryptoBundle(t, gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateDNSNames("example.com"),
+	), fixedClock)
+	revokedSecret := gen.Secret("secret-1", gen.SetSecretNamespace("testns"), gen.SetSecretData(map[string][]byte{
+		corev1.TLSCertKey: revokedBundle.CertBytes,
+	}))
+
+	// The Secret of a Certificate requesting a duration of one year, which
+	// contains a certificate that is only valid for 90 days.
+	clampedKey := testcrypto.MustCreatePEMPrivateKey(t)
+	clampedCrt := gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateGeneration(42),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateDuration(365*24*time.Hour),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer-1"}),
+	)
+	clampedSecret := gen.Secret("secret-1", gen.SetSecretNamespace("testns"), gen.SetSecretData(map[string][]byte{
+		corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, clampedKey, clampedCrt, fixedNow.Time, fixedNow.Add(90*24*time.Hour)),
+	}))
+	clampedCondition := cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionIssuerClampedDuration,
+		Status:             "True",
+		Reason:             "DurationClamped",
+		Message:            "Issuer shortened the lifetime of the certificate from the requested 8760h0m0s to 2160h0m0s",
+		LastTransitionTime: &fixedNow,
+		ObservedGeneration: 42,
+	}
+	noReissue := func(*testing.T) policies.Func {
+		return func(policies.Input) (string, string, bool) {
+			return "", "", false
+		}
+	}
+
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem. If not set, the
 		// 'namespace/name' of the 'Certificate' field will be used. If neither
@@ -56,6 +94,9 @@ func Test_controller_ProcessItem(t *testing.T) {
 		// passed to ProcessItem instead.
 		existingCertificate *cmapi.Certificate
 
+		// Issuer referenced by the Certificate, if any.
+		existingIssuer *cmapi.Issuer
+
 		mockDataForCertificateReturn    policies.Input
 		mockDataForCertificateReturnErr error
 		wantDataForCertificateCalled    bool
@@ -159,6 +200,49 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should set IssuerClampedDuration=True if the issuer shortened the duration within its tolerance": {
+			existingCertificate: clampedCrt,
+			existingIssuer: gen.Issuer("issuer-1", gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerDurationTolerance(300*24*time.Hour),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{Secret: clampedSecret},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue:            noReissue,
+			wantConditions:               []cmapi.CertificateCondition{clampedCondition},
+		},
+		"should not update a Certificate which already has an up to date IssuerClampedDuration condition": {
+			existingCertificate: gen.CertificateFrom(clampedCrt, gen.SetCertificateStatusCondition(clampedCondition)),
+			existingIssuer: gen.Issuer("issuer-1", gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerDurationTolerance(300*24*time.Hour),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{Secret: clampedSecret},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue:            noReissue,
+		},
+		"should fire a warning event if the issuer shortened the duration beyond its tolerance": {
+			existingCertificate: clampedCrt,
+			existingIssuer: gen.Issuer("issuer-1", gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerDurationTolerance(24*time.Hour),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{Secret: clampedSecret},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue:            noReissue,
+			wantEvent:                    "Warning DurationMismatch Issued certificate is valid for 2160h0m0s, which is shorter than the requested duration of 8760h0m0s. Set spec.durationTolerance on the issuer if it is expected to shorten the lifetime of certificates",
+		},
+		"should remove IssuerClampedDuration once the duration is no longer shortened": {
+			existingCertificate: gen.CertificateFrom(clampedCrt,
+				gen.SetCertificateDuration(90*24*time.Hour),
+				gen.SetCertificateStatusCondition(clampedCondition),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{Secret: clampedSecret},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue:            noReissue,
+			wantConditions:               []cmapi.CertificateCondition{},
+		},
 		// The combinations of number of failed issuances and last
 		// failed issuance time that do or do not result in re-issuance
 		// are tested in Test_shouldBackoffReissuingOnFailure below
@@ -213,6 +297,38 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should set Issuing=True when the current certificate is marked for emergency re-issuance, even when issuance failed 1 minute ago": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.AddCertificateAnnotations(map[string]string{
+					cmapi.EmergencyReissuanceAnnotationKey: revokedBundle.Cert.SerialNumber.Text(16),
+				}),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-1*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				Secret: revokedSecret,
+				NextRevisionRequest: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				)),
+			},
+			wantShouldReissueCalled: false,
+			wantEvent:               "Normal Issuing Re-issuing certificate as the certificate with serial number " + revokedBundle.Cert.SerialNumber.Text(16) + " has been marked for emergency re-issuance",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             "Emergency",
+				Message:            "Re-issuing certificate as the certificate with serial number " + revokedBundle.Cert.SerialNumber.Text(16) + " has been marked for emergency re-issuance",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
 		"should set Issuing=True when issuance failed once 61 minutes ago and shouldReissue returns true": {
 			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
 				gen.SetCertificateGeneration(42),
@@ -248,6 +364,9 @@ func Test_controller_ProcessItem(t *testing.T) {
 			if test.existingCertificate != nil {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.existingCertificate)
 			}
+			if test.existingIssuer != nil {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, test.existingIssuer)
+			}
 			builder.Init()
 
 			w := &controllerWrapper{}
@@ -282,7 +401,10 @@ func Test_controller_ProcessItem(t *testing.T) {
 					t.Fatal("cannot expect an Update operation if test.certificate is nil")
 				}
 				expectedCert := test.existingCertificate.DeepCopy()
-				expectedCert.Status.Conditions = test.wantConditions
+				expectedCert.Status.Conditions = nil
+				if len(test.wantConditions) > 0 {
+					expectedCert.Status.Conditions = test.wantConditions
+				}
 				builder.ExpectedActions = append(builder.ExpectedActions,
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
@@ -672,10 +794,92 @@ func Test_shouldBackoffReissuingOnFailure(t *testing.T) {
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			gotBackoff, gotDelay := shouldBackoffReissuingOnFailure(logtesting.NewTestLogger(t), clock, test.givenCert, test.givenNextCR)
+			gotBackoff, gotDelay := shouldBackoffReissuingOnFailure(logtesting.NewTestLogger(t), clock, test.givenCert, test.givenNextCR, nil)
 			assert.Equal(t, test.wantBackoff, gotBackoff)
 			assert.Equal(t, test.wantDelay, gotDelay)
 		})
 
 	}
 }
+
+func Test_renewalJitter(t *testing.T) {
+	tests := map[string]struct {
+		renewBefore time.Duration
+		maxJitter   time.Duration
+	}{
+		"long renewBefore is jittered by at most maxRenewalJitter": {
+			renewBefore: 30 * 24 * time.Hour,
+			maxJitter:   maxRenewalJitter,
+		},
+		"short renewBefore is jittered by at most a tenth of renewBefore": {
+			renewBefore: 10 * time.Second,
+			maxJitter:   time.Second,
+		},
+		"renewal time after expiry is not jittered": {
+			renewBefore: -time.Hour,
+			maxJitter:   0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("namespace/cert-%d", i)
+				jitter := renewalJitter(key, test.renewBefore)
+				if jitter < 0 || (jitter > 0 && jitter >= test.maxJitter) {
+					t.Fatalf("jitter %s for key %q is out of range [0, %s)", jitter, key, test.maxJitter)
+				}
+				assert.Equal(t, jitter, renewalJitter(key, test.renewBefore), "jitter should be deterministic for a given key")
+			}
+		})
+	}
+
+	// Certificates with the same renewal time should be spread out rather
+	// than all being rechecked at the same instant.
+	seen := make(map[time.Duration]struct{})
+	for i := 0; i < 100; i++ {
+		seen[renewalJitter(fmt.Sprintf("namespace/cert-%d", i), time.Hour)] = struct{}{}
+	}
+	if len(seen) < 90 {
+		t.Errorf("expected jitter to be spread across certificates, got %d distinct values for 100 certificates", len(seen))
+	}
+}
+
+func Test_durationUntilRenewalRecheck(t *testing.T) {
+	renewalTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	crt := gen.Certificate("cert",
+		gen.SetCertificateRenewalTime(metav1.NewTime(renewalTime)),
+		gen.SetCertificateNotAfter(metav1.NewTime(renewalTime.Add(30*24*time.Hour))),
+	)
+
+	// The recheck must be scheduled for the same time whenever the
+	// Certificate is processed, so that it isn't pushed back by every resync.
+	now := renewalTime.Add(-60 * 24 * time.Hour)
+	later := renewalTime.Add(-time.Minute)
+	recheck := now.Add(durationUntilRenewalRecheck("namespace/cert", crt, now))
+	assert.Equal(t, recheck, later.Add(durationUntilRenewalRecheck("namespace/cert", crt, later)))
+	if recheck.Before(renewalTime) || !recheck.Before(renewalTime.Add(maxRenewalJitter)) {
+		t.Errorf("expected recheck at %s to be within maxRenewalJitter of the renewal time %s", recheck, renewalTime)
+	}
+}
+
+func Test_enqueueMarkedForEmergency(t *testing.T) {
+	queue := workqueue.New()
+	defer queue.ShutDown()
+	enqueue := enqueueMarkedForEmergency(logtesting.NewTestLogger(t), queue)
+
+	// A backlog of Certificates which are not marked is not added to the
+	// emergency queue, so the marked Certificate is processed first.
+	for i := 0; i < 100; i++ {
+		enqueue(gen.Certificate(fmt.Sprintf("cert-%d", i), gen.SetCertificateNamespace("testns")))
+	}
+	enqueue(gen.Certificate("marked", gen.SetCertificateNamespace("testns"),
+		gen.AddCertificateAnnotations(map[string]string{cmapi.EmergencyReissuanceAnnotationKey: "1234"})))
+	enqueue(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "testns"}})
+
+	if queue.Len() != 1 {
+		t.Fatalf("expected 1 item in the emergency queue, got %d", queue.Len())
+	}
+	item, _ := queue.Get()
+	assert.Equal(t, "testns/marked", item)
+}
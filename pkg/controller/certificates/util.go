@@ -26,19 +26,55 @@ import (
 
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
+// DroppedUsages returns the usages of the Certificate which were left out of
+// the given CertificateRequest because its issuer does not support them, as
+// listed in the dropped usages annotation of the request.
+func DroppedUsages(req *cmapi.CertificateRequest) []cmapi.KeyUsage {
+	value := req.Annotations[cmapi.CertificateRequestDroppedUsagesAnnotationKey]
+	if len(value) == 0 {
+		return nil
+	}
+	var usages []cmapi.KeyUsage
+	for _, usage := range strings.Split(value, ",") {
+		usages = append(usages, cmapi.KeyUsage(usage))
+	}
+	return usages
+}
+
+// issuerSupportsUsage returns true if the given capabilities of an issuer are
+// known and support the usage. Issuers which don't declare any usages support
+// all of them.
+func issuerSupportsUsage(caps *cmapi.IssuerCapabilities, usage cmapi.KeyUsage) bool {
+	if caps == nil {
+		return false
+	}
+	if len(caps.Usages) == 0 {
+		return true
+	}
+	for _, u := range caps.Usages {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
+
 // PrivateKeyMatchesSpec returns an error if the private key bit size
-// doesn't match the provided spec. RSA, Ed25519 and ECDSA are supported.
+// doesn't match the provided spec. RSA, Ed25519, ECDSA and the algorithms
+// registered using pki.RegisterKeyAlgorithm are supported.
 // If any error is returned, a list of violations will also be returned.
 func PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]string, error) {
 	spec = *spec.DeepCopy()
@@ -53,6 +89,9 @@ func PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]
 	case cmapi.ECDSAKeyAlgorithm:
 		return ecdsaPrivateKeyMatchesSpec(pk, spec)
 	default:
+		if _, ok := pki.LookupKeyAlgorithm(spec.PrivateKey.Algorithm); ok {
+			return registeredPrivateKeyMatchesSpec(pk, spec)
+		}
 		return nil, fmt.Errorf("unrecognised key algorithm type %q", spec.PrivateKey.Algorithm)
 	}
 }
@@ -108,11 +147,28 @@ func ed25519PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSp
 	return nil, nil
 }
 
+func registeredPrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]string, error) {
+	algorithm, size, ok := pki.PrivateKeyAlgorithmAndSize(pk)
+	if !ok || algorithm != spec.PrivateKey.Algorithm {
+		return []string{"spec.privateKey.algorithm"}, nil
+	}
+	// The default size of registered algorithms is chosen by their
+	// implementation, so any size matches an unset spec.privateKey.size.
+	if spec.PrivateKey.Size > 0 && size != spec.PrivateKey.Size {
+		return []string{"spec.privateKey.size"}, nil
+	}
+	return nil, nil
+}
+
 // RequestMatchesSpec compares a CertificateRequest with a CertificateSpec
-// and returns a list of field names on the Certificate that do not match their
-// counterpart fields on the CertificateRequest.
+// and returns a list of violations for the fields on the Certificate that do
+// not match their counterpart fields on the CertificateRequest.
 // If decoding the x509 certificate request fails, an error will be returned.
-func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpec) ([]string, error) {
+// Usages listed in the dropped usages annotation of the request count as
+// requested as long as the given current capabilities of the issuer still
+// don't support them. If caps is nil, the capabilities of the issuer are not
+// known and the dropped usages always count as requested.
+func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpec, caps *cmapi.IssuerCapabilities) (Violations, error) {
 	x509req, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
 	if err != nil {
 		return nil, err
@@ -123,60 +179,79 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 	if spec.Subject == nil {
 		spec.Subject = &cmapi.X509Subject{}
 	}
+	// DNS names issued by a split issuance are not requested from the issuer
+	// of the Certificate.
+	spec.DNSNames = apiutil.CertificatePrimaryDNSNames(&spec)
 
-	var violations []string
+	var violations Violations
 	if spec.LiteralSubject == "" {
 		if x509req.Subject.CommonName != spec.CommonName {
-			violations = append(violations, "spec.commonName")
+			violations = append(violations, newViolation("spec.commonName", spec.CommonName, x509req.Subject.CommonName))
 		}
 		if !util.EqualUnsorted(x509req.DNSNames, spec.DNSNames) {
-			violations = append(violations, "spec.dnsNames")
+			violations = append(violations, newViolation("spec.dnsNames", spec.DNSNames, x509req.DNSNames))
 		}
 		if !util.EqualUnsorted(pki.IPAddressesToString(x509req.IPAddresses), spec.IPAddresses) {
-			violations = append(violations, "spec.ipAddresses")
+			violations = append(violations, newViolation("spec.ipAddresses", spec.IPAddresses, pki.IPAddressesToString(x509req.IPAddresses)))
 		}
 		if !util.EqualUnsorted(pki.URLsToString(x509req.URIs), spec.URIs) {
-			violations = append(violations, "spec.uris")
+			violations = append(violations, newViolation("spec.uris", spec.URIs, pki.URLsToString(x509req.URIs)))
 		}
 		if !util.EqualUnsorted(x509req.EmailAddresses, spec.EmailAddresses) {
-			violations = append(violations, "spec.emailAddresses")
+			violations = append(violations, newViolation("spec.emailAddresses", spec.EmailAddresses, x509req.EmailAddresses))
 		}
 		if x509req.Subject.SerialNumber != spec.Subject.SerialNumber {
-			violations = append(violations, "spec.subject.serialNumber")
+			violations = append(violations, newViolation("spec.subject.serialNumber", spec.Subject.SerialNumber, x509req.Subject.SerialNumber))
 		}
 		if !util.EqualUnsorted(x509req.Subject.Organization, spec.Subject.Organizations) {
-			violations = append(violations, "spec.subject.organizations")
+			violations = append(violations, newViolation("spec.subject.organizations", spec.Subject.Organizations, x509req.Subject.Organization))
 		}
 		if !util.EqualUnsorted(x509req.Subject.Country, spec.Subject.Countries) {
-			violations = append(violations, "spec.subject.countries")
+			violations = append(violations, newViolation("spec.subject.countries", spec.Subject.Countries, x509req.Subject.Country))
 		}
 		if !util.EqualUnsorted(x509req.Subject.Locality, spec.Subject.Localities) {
-			violations = append(violations, "spec.subject.localities")
+			violations = append(violations, newViolation("spec.subject.localities", spec.Subject.Localities, x509req.Subject.Locality))
 		}
 		if !util.EqualUnsorted(x509req.Subject.OrganizationalUnit, spec.Subject.OrganizationalUnits) {
-			violations = append(violations, "spec.subject.organizationalUnits")
+			violations = append(violations, newViolation("spec.subject.organizationalUnits", spec.Subject.OrganizationalUnits, x509req.Subject.OrganizationalUnit))
 		}
 		if !util.EqualUnsorted(x509req.Subject.PostalCode, spec.Subject.PostalCodes) {
-			violations = append(violations, "spec.subject.postCodes")
+			violations = append(violations, newViolation("spec.subject.postalCodes", spec.Subject.PostalCodes, x509req.Subject.PostalCode))
 		}
 		if !util.EqualUnsorted(x509req.Subject.Province, spec.Subject.Provinces) {
-			violations = append(violations, "spec.subject.postCodes")
+			violations = append(violations, newViolation("spec.subject.provinces", spec.Subject.Provinces, x509req.Subject.Province))
 		}
 		if !util.EqualUnsorted(x509req.Subject.StreetAddress, spec.Subject.StreetAddresses) {
-			violations = append(violations, "spec.subject.streetAddresses")
+			violations = append(violations, newViolation("spec.subject.streetAddresses", spec.Subject.StreetAddresses, x509req.Subject.StreetAddress))
 		}
 		if req.Spec.IsCA != spec.IsCA {
-			violations = append(violations, "spec.isCA")
+			violations = append(violations, newViolation("spec.isCA", spec.IsCA, req.Spec.IsCA))
 		}
-		if !util.EqualKeyUsagesUnsorted(req.Spec.Usages, spec.Usages) {
-			violations = append(violations, "spec.usages")
+		reqUsages, specUsages := req.Spec.Usages, spec.Usages
+		if dropped := DroppedUsages(req); len(dropped) > 0 {
+			// Usages which are not supported by the issuer are left out of
+			// the request, so they count as requested unless the issuer has
+			// since started to support them.
+			reqUsages = append([]cmapi.KeyUsage(nil), reqUsages...)
+			for _, usage := range dropped {
+				if !issuerSupportsUsage(caps, usage) {
+					reqUsages = append(reqUsages, usage)
+				}
+			}
+			if len(specUsages) == 0 {
+				specUsages = cmapi.DefaultKeyUsages()
+			}
+		}
+		if !util.EqualKeyUsagesUnsorted(reqUsages, specUsages) {
+			violations = append(violations, newViolation("spec.usages", spec.Usages, req.Spec.Usages))
 		}
 		if spec.Duration != nil && req.Spec.Duration != nil &&
-			spec.Duration.Duration != req.Spec.Duration.Duration {
-			violations = append(violations, "spec.duration")
+			spec.Duration.Duration != req.Spec.Duration.Duration &&
+			!(isAligned(req) && AlignedDurationMatches(spec.Duration.Duration, req.Spec.Duration.Duration)) {
+			violations = append(violations, newViolation("spec.duration", spec.Duration.Duration, req.Spec.Duration.Duration))
 		}
 		if !reflect.DeepEqual(spec.IssuerRef, req.Spec.IssuerRef) {
-			violations = append(violations, "spec.issuerRef")
+			violations = append(violations, newViolation("spec.issuerRef", spec.IssuerRef, req.Spec.IssuerRef))
 		}
 	} else {
 		// we have a LiteralSubject
@@ -194,13 +269,74 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 		}
 
 		if !reflect.DeepEqual(rdnSequenceFromCertificate, rdnSequenceFromCertificateRequest) {
-			violations = append(violations, "spec.literalSubject")
+			violations = append(violations, newViolation("spec.literalSubject", spec.LiteralSubject, rdnSequenceFromCertificateRequest.String()))
 		}
 	}
 
 	return violations, nil
 }
 
+// Violation describes a field on a Certificate whose value does not match its
+// counterpart on a CertificateRequest.
+type Violation struct {
+	// Field is the path of the field on the Certificate, e.g. spec.dnsNames.
+	Field string
+
+	// Expected is the formatted value of the field on the Certificate.
+	Expected string
+
+	// Actual is the formatted value of the field on the CertificateRequest.
+	Actual string
+}
+
+// newViolation returns a Violation for the given field, formatting the
+// expected and actual values so that empty and missing values can be told
+// apart from each other.
+func newViolation(field string, expected, actual interface{}) Violation {
+	return Violation{
+		Field:    field,
+		Expected: formatViolationValue(expected),
+		Actual:   formatViolationValue(actual),
+	}
+}
+
+func formatViolationValue(v interface{}) string {
+	switch v := v.(type) {
+	case string, []string, []cmapi.KeyUsage:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprintf("%+v", v)
+	}
+}
+
+// String returns the field path followed by the expected and actual values.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", v.Field, v.Expected, v.Actual)
+}
+
+// Violations is a list of fields on a Certificate that do not match a
+// CertificateRequest.
+type Violations []Violation
+
+// Fields returns the paths of the fields that do not match.
+func (vs Violations) Fields() []string {
+	fields := make([]string, len(vs))
+	for i, v := range vs {
+		fields[i] = v.Field
+	}
+	return fields
+}
+
+// String returns the violations in a form suitable for conditions, events
+// and log messages.
+func (vs Violations) String() string {
+	strs := make([]string, len(vs))
+	for i, v := range vs {
+		strs[i] = v.String()
+	}
+	return "[" + strings.Join(strs, "; ") + "]"
+}
+
 // SecretDataAltNamesMatchSpec will compare a Secret resource containing certificate
 // data to a CertificateSpec and return a list of 'violations' for any fields that
 // do not match their counterparts.
@@ -212,6 +348,10 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 		return nil, err
 	}
 
+	// DNS names issued by a split issuance are stored in a separate
+	// certificate.
+	spec.DNSNames = apiutil.CertificatePrimaryDNSNames(&spec)
+
 	var violations []string
 
 	// Perform a 'loose' check on the x509 certificate to determine if the
@@ -300,6 +440,13 @@ func GenerateLocallySignedTemporaryCertificate(crt *cmapi.Certificate, pkData []
 	return b, nil
 }
 
+// isAligned returns true if the duration of the given CertificateRequest was
+// shortened by the validity alignment of its Certificate.
+func isAligned(req *cmapi.CertificateRequest) bool {
+	_, ok := req.Annotations[cmapi.ValidityAlignmentAnnotationKey]
+	return ok
+}
+
 // RenewalTimeFunc is a custom function type for calculating renewal time of a certificate.
 type RenewalTimeFunc func(time.Time, time.Time, *metav1.Duration) *metav1.Time
 
@@ -18,6 +18,7 @@ package certificates
 
 import (
 	"crypto"
+	"encoding/pem"
 	"fmt"
 	"reflect"
 	"testing"
@@ -28,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -276,6 +278,133 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 	}
 }
 
+func TestRequestMatchesSpec(t *testing.T) {
+	issuerRef := cmmeta.ObjectReference{Name: "issuer"}
+	spec := cmapi.CertificateSpec{
+		CommonName: "cn",
+		DNSNames:   []string{"example.com", "www.example.com"},
+		IssuerRef:  issuerRef,
+	}
+
+	tests := map[string]struct {
+		request    *cmapi.CertificateRequest
+		caps       *cmapi.IssuerCapabilities
+		violations Violations
+	}{
+		"should match if the request was generated from the same spec": {
+			request: generateRequest(t, spec, issuerRef),
+		},
+		"should report the expected and actual values of fields that differ": {
+			request: generateRequest(t, cmapi.CertificateSpec{
+				CommonName: "old-cn",
+				DNSNames:   []string{"example.com"},
+				IsCA:       true,
+			}, cmmeta.ObjectReference{Name: "old-issuer"}),
+			violations: Violations{
+				{Field: "spec.commonName", Expected: `"cn"`, Actual: `"old-cn"`},
+				{Field: "spec.dnsNames", Expected: `["example.com" "www.example.com"]`, Actual: `["example.com"]`},
+				{Field: "spec.isCA", Expected: "false", Actual: "true"},
+				{Field: "spec.issuerRef", Expected: "{Name:issuer Kind: Group: Namespace:}", Actual: "{Name:old-issuer Kind: Group: Namespace:}"},
+			},
+		},
+		"should match if usages which are not supported by the issuer were dropped": {
+			request: withUsages(generateRequest(t, spec, issuerRef), "key encipherment", cmapi.UsageDigitalSignature),
+		},
+		"should report usages which are missing but were not dropped": {
+			request: withUsages(generateRequest(t, spec, issuerRef), "server auth", cmapi.UsageDigitalSignature),
+			violations: Violations{
+				{Field: "spec.usages", Expected: "[]", Actual: `["digital signature"]`},
+			},
+		},
+		"should match if the issuer still doesn't support the dropped usages": {
+			request: withUsages(generateRequest(t, spec, issuerRef), "key encipherment", cmapi.UsageDigitalSignature),
+			caps:    &cmapi.IssuerCapabilities{Usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature}},
+		},
+		"should report dropped usages which the issuer now supports": {
+			request: withUsages(generateRequest(t, spec, issuerRef), "key encipherment", cmapi.UsageDigitalSignature),
+			caps:    &cmapi.IssuerCapabilities{Usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment}},
+			violations: Violations{
+				{Field: "spec.usages", Expected: "[]", Actual: `["digital signature"]`},
+			},
+		},
+		"should report dropped usages if the issuer no longer declares usages": {
+			request: withUsages(generateRequest(t, spec, issuerRef), "key encipherment", cmapi.UsageDigitalSignature),
+			caps:    &cmapi.IssuerCapabilities{},
+			violations: Violations{
+				{Field: "spec.usages", Expected: "[]", Actual: `["digital signature"]`},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			violations, err := RequestMatchesSpec(test.request, spec, test.caps)
+			assert.NoError(t, err)
+			assert.Equal(t, test.violations, violations)
+		})
+	}
+}
+
+func TestRequestMatchesSpecWithoutCommonNameOrDNSNames(t *testing.T) {
+	issuerRef := cmmeta.ObjectReference{Name: "issuer"}
+	specs := map[string]cmapi.CertificateSpec{
+		"uri only": {
+			URIs:      []string{"spiffe://cluster.local/ns/sandbox/sa/foo"},
+			IssuerRef: issuerRef,
+		},
+		"email only": {
+			EmailAddresses: []string{"alice@example.com"},
+			IssuerRef:      issuerRef,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			violations, err := RequestMatchesSpec(generateRequest(t, spec, issuerRef), spec, nil)
+			assert.NoError(t, err)
+			assert.Empty(t, violations)
+		})
+	}
+}
+
+func TestViolationsString(t *testing.T) {
+	violations := Violations{
+		{Field: "spec.commonName", Expected: `"cn"`, Actual: `"old-cn"`},
+		{Field: "spec.isCA", Expected: "false", Actual: "true"},
+	}
+	assert.Equal(t, `[spec.commonName: expected "cn", got "old-cn"; spec.isCA: expected false, got true]`, violations.String())
+	assert.Equal(t, []string{"spec.commonName", "spec.isCA"}, violations.Fields())
+}
+
+// withUsages sets the usages of the request, and the usages which were dropped
+// from it because they are not supported by the issuer.
+func withUsages(req *cmapi.CertificateRequest, dropped string, usages ...cmapi.KeyUsage) *cmapi.CertificateRequest {
+	req.Spec.Usages = usages
+	req.Annotations = map[string]string{cmapi.CertificateRequestDroppedUsagesAnnotationKey: dropped}
+	return req
+}
+
+func generateRequest(t *testing.T, spec cmapi.CertificateSpec, issuerRef cmmeta.ObjectReference) *cmapi.CertificateRequest {
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	csr, err := pki.GenerateCSR(&cmapi.Certificate{Spec: spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	csrDER, err := pki.EncodeCSR(csr, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+		Request:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}),
+		IssuerRef: issuerRef,
+		IsCA:      spec.IsCA,
+	}}
+}
+
 func selfSignCertificate(t *testing.T, spec cmapi.CertificateSpec) []byte {
 	pk, err := pki.GenerateRSAPrivateKey(2048)
 	if err != nil {
@@ -0,0 +1,429 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vaultrevocation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	vaultinternal "github.com/cert-manager/cert-manager/internal/vault"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// ControllerName is the name of the Vault revocation controller.
+	ControllerName = "certificates-vault-revocation"
+
+	// tidyCheckInterval is how often the Vault issuers are checked for
+	// whether their PKI backend is due to be tidied.
+	tidyCheckInterval = time.Minute
+
+	reasonRevoked      = "Revoked"
+	reasonRevokeFailed = "RevokeFailed"
+	reasonTidied       = "Tidied"
+	reasonTidyFailed   = "TidyFailed"
+)
+
+// controller revokes the certificates issued by Vault issuers which have
+// `revocation` configured, and periodically tidies their PKI backend.
+// The certificate stored in the Secret of a Certificate is revoked when the
+// Certificate is annotated with `cert-manager.io/revoke: "true"`, or, if the
+// issuer has `revocation.revokeOnDelete` set, when the Certificate is deleted.
+// Deletion is deferred until the certificate has been revoked using the
+// `finalizer.vault.cert-manager.io` finalizer.
+type controller struct {
+	certificateLister   cmlisters.CertificateLister
+	secretLister        corelisters.SecretLister
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	helper              issuer.Helper
+	issuerOptions       controllerpkg.IssuerOptions
+	client              cmclient.Interface
+	recorder            record.EventRecorder
+	clock               clock.Clock
+
+	vaultClientBuilder vaultinternal.ClientBuilder
+
+	// lastTidied is the time at which the PKI backend of each issuer was
+	// last tidied by this controller. It is not persisted, so the PKI
+	// backends are tidied once after the controller starts.
+	lastTidied map[types.UID]time.Time
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+// NewController returns a new Vault revocation controller.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	issuerOptions controllerpkg.IssuerOptions,
+	watchClusterIssuers bool,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When an Issuer changes, the Certificates referencing it are enqueued so
+	// that finalizers are added or removed when revokeOnDelete changes.
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueCertificatesForIssuer(log, queue, certificateInformer.Lister(), cmapi.IssuerKind),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+	}
+
+	ctrl := &controller{
+		certificateLister:  certificateInformer.Lister(),
+		secretLister:       secretInformer.Lister(),
+		issuerLister:       issuerInformer.Lister(),
+		issuerOptions:      issuerOptions,
+		client:             client,
+		recorder:           recorder,
+		clock:              clock,
+		vaultClientBuilder: vaultinternal.New,
+		lastTidied:         make(map[types.UID]time.Time),
+	}
+
+	if watchClusterIssuers {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+			WorkFunc: enqueueCertificatesForIssuer(log, queue, certificateInformer.Lister(), cmapi.ClusterIssuerKind),
+		})
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		ctrl.clusterIssuerLister = clusterIssuerInformer.Lister()
+	}
+	grantLister, grantsSynced := issuer.IssuerReferenceGrantLister(cmFactory)
+	mustSync = append(mustSync, grantsSynced...)
+	ctrl.helper = issuer.NewHelper(ctrl.issuerLister, ctrl.clusterIssuerLister, grantLister)
+
+	return ctrl, queue, mustSync
+}
+
+func enqueueCertificatesForIssuer(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateLister, kind string) func(obj interface{}) {
+	return func(obj interface{}) {
+		iss, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-object type resource passed to enqueueCertificatesForIssuer")
+			return
+		}
+		crts, err := lister.Certificates(iss.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list Certificates")
+			return
+		}
+		for _, crt := range crts {
+			if crt.Spec.IssuerRef.Name != iss.GetName() || apiutil.IssuerKind(crt.Spec.IssuerRef) != kind {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "failed to construct key for Certificate")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a Certificate to be re-synced is pulled from the workqueue.
+// ProcessItem manages the revocation finalizer of the Certificate, and
+// revokes its certificate when it is being deleted or annotated with
+// `cert-manager.io/revoke: "true"`.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
+	iss, revocation, err := c.revocationFor(crt)
+	if err != nil {
+		return err
+	}
+
+	hasFinalizer := sets.NewString(crt.Finalizers...).Has(cmapi.VaultRevocationFinalizer)
+	if crt.DeletionTimestamp != nil {
+		if !hasFinalizer {
+			return nil
+		}
+		// If the issuer no longer exists or no longer revokes on delete,
+		// the finalizer is removed without revoking so that the deletion
+		// isn't blocked forever.
+		if revocation != nil && revocation.RevokeOnDelete {
+			if err := c.revoke(ctx, crt, iss); err != nil {
+				return err
+			}
+		}
+		return c.setFinalizer(ctx, crt, false)
+	}
+
+	wantFinalizer := revocation != nil && revocation.RevokeOnDelete
+	if wantFinalizer != hasFinalizer {
+		return c.setFinalizer(ctx, crt, wantFinalizer)
+	}
+
+	if revocation == nil || crt.Annotations[cmapi.RevokeCertificateAnnotationKey] != "true" {
+		return nil
+	}
+	if err := c.revoke(ctx, crt, iss); err != nil {
+		return err
+	}
+
+	crt = crt.DeepCopy()
+	delete(crt.Annotations, cmapi.RevokeCertificateAnnotationKey)
+	_, err = c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
+// revocationFor returns the issuer of crt and its Vault revocation config.
+// The revocation config is nil if the issuer doesn't exist, or isn't a Vault
+// issuer with revocation configured.
+func (c *controller) revocationFor(crt *cmapi.Certificate) (cmapi.GenericIssuer, *cmapi.VaultRevocation, error) {
+	if crt.Spec.IssuerRef.Group != "" && crt.Spec.IssuerRef.Group != cmapi.SchemeGroupVersion.Group {
+		return nil, nil, nil
+	}
+	iss, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if iss.GetSpec().Vault == nil {
+		return iss, nil, nil
+	}
+	return iss, iss.GetSpec().Vault.Revocation, nil
+}
+
+// revoke revokes the certificate stored in the Secret of crt, if it was
+// issued by the issuer that crt references.
+func (c *controller) revoke(ctx context.Context, crt *cmapi.Certificate, iss cmapi.GenericIssuer) error {
+	log := logf.FromContext(ctx)
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("secret not found, nothing to revoke")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The Secret may still contain a certificate issued by a previous issuer
+	// of the Certificate, which this issuer is unable to revoke.
+	issuedBy := cmmeta.ObjectReference{
+		Name: secret.Annotations[cmapi.IssuerNameAnnotationKey],
+		Kind: secret.Annotations[cmapi.IssuerKindAnnotationKey],
+	}
+	if issuedBy.Name != crt.Spec.IssuerRef.Name || apiutil.IssuerKind(issuedBy) != apiutil.IssuerKind(crt.Spec.IssuerRef) {
+		log.V(logf.DebugLevel).Info("certificate in secret was not issued by the current issuer, not revoking")
+		return nil
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		log.V(logf.DebugLevel).Info("secret does not contain a valid certificate, nothing to revoke", "error", err.Error())
+		return nil
+	}
+
+	client, err := c.vaultClientBuilder(c.issuerOptions.ResourceNamespace(iss), c.secretLister, iss)
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevokeFailed, "Failed to initialise vault client for revocation: %v", err)
+		return err
+	}
+
+	serial := fmt.Sprintf("%x", cert.SerialNumber)
+	if err := client.Revoke(cert.SerialNumber); err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevokeFailed, "Failed to revoke certificate with serial number %s: %v", serial, err)
+		return err
+	}
+
+	log.V(logf.InfoLevel).Info("revoked certificate", "serial", serial)
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRevoked, "Revoked certificate with serial number %s", serial)
+	return nil
+}
+
+// setFinalizer adds or removes the revocation finalizer of crt.
+func (c *controller) setFinalizer(ctx context.Context, crt *cmapi.Certificate, present bool) error {
+	crt = crt.DeepCopy()
+	if present {
+		crt.Finalizers = append(crt.Finalizers, cmapi.VaultRevocationFinalizer)
+	} else {
+		crt.Finalizers = sets.NewString(crt.Finalizers...).Delete(cmapi.VaultRevocationFinalizer).List()
+	}
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
+// tidy calls the `tidy` endpoint of the PKI backend of each Vault issuer
+// with a `revocation.tidyInterval` which hasn't been tidied for that long.
+func (c *controller) tidy(ctx context.Context) {
+	log := logf.FromContext(ctx, "tidy")
+
+	var issuers []cmapi.GenericIssuer
+	iss, err := c.issuerLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "failed to list Issuers")
+		return
+	}
+	for _, i := range iss {
+		issuers = append(issuers, i)
+	}
+	if c.clusterIssuerLister != nil {
+		clusterIssuers, err := c.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list ClusterIssuers")
+			return
+		}
+		for _, i := range clusterIssuers {
+			issuers = append(issuers, i)
+		}
+	}
+
+	seen := make(map[types.UID]bool)
+	for _, iss := range issuers {
+		vault := iss.GetSpec().Vault
+		if vault == nil || vault.Revocation == nil || vault.Revocation.TidyInterval == nil {
+			continue
+		}
+		uid := iss.GetObjectMeta().UID
+		seen[uid] = true
+		if last, ok := c.lastTidied[uid]; ok && c.clock.Since(last) < vault.Revocation.TidyInterval.Duration {
+			continue
+		}
+
+		var safetyBuffer time.Duration
+		if vault.Revocation.TidySafetyBuffer != nil {
+			safetyBuffer = vault.Revocation.TidySafetyBuffer.Duration
+		}
+
+		log := logf.WithResource(log, iss)
+		client, err := c.vaultClientBuilder(c.issuerOptions.ResourceNamespace(iss), c.secretLister, iss)
+		if err == nil {
+			err = client.Tidy(safetyBuffer)
+		}
+		if err != nil {
+			log.Error(err, "failed to tidy Vault PKI backend")
+			c.recorder.Eventf(iss, corev1.EventTypeWarning, reasonTidyFailed, "Failed to tidy Vault PKI backend: %v", err)
+			continue
+		}
+
+		log.V(logf.DebugLevel).Info("tidied Vault PKI backend")
+		c.recorder.Event(iss, corev1.EventTypeNormal, reasonTidied, "Tidied Vault PKI backend")
+		c.lastTidied[uid] = c.clock.Now()
+	}
+
+	// Forget issuers which were deleted or no longer tidy their backend.
+	for uid := range c.lastTidied {
+		if !seen[uid] {
+			delete(c.lastTidied, uid)
+		}
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.IssuerOptions,
+		ctx.Namespace == "",
+	)
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		c := &controllerWrapper{}
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(c).
+			With(func(ctx context.Context) { c.tidy(ctx) }, tidyCheckInterval).
+			Complete()
+	})
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vaultrevocation
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	vaultinternal "github.com/cert-manager/cert-manager/internal/vault"
+	vaultfake "github.com/cert-manager/cert-manager/internal/vault/fake"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	unitcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func vaultIssuer(revocation *cmapi.VaultRevocation) *cmapi.Issuer {
+	iss := gen.Issuer("vault",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerVault(cmapi.VaultIssuer{Path: "pki/sign/role", Revocation: revocation}),
+	)
+	iss.UID = "vault-uid"
+	return iss
+}
+
+func TestProcessItem(t *testing.T) {
+	fakeClock := fakeclock.NewFakeClock(fixedClockStart)
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-tls"),
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "vault"}),
+	)
+	bundle := unitcrypto.MustCreateCryptoBundle(t, baseCrt, fakeClock)
+	secret := func(issuerName string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "testns",
+				Name:      "test-tls",
+				Annotations: map[string]string{
+					cmapi.IssuerNameAnnotationKey: issuerName,
+					cmapi.IssuerKindAnnotationKey: cmapi.IssuerKind,
+				},
+			},
+			Data: map[string][]byte{corev1.TLSCertKey: bundle.CertBytes},
+		}
+	}
+	deleting := func(crt *cmapi.Certificate) *cmapi.Certificate {
+		now := metav1.NewTime(fixedClockStart)
+		crt.DeletionTimestamp = &now
+		return crt
+	}
+
+	tests := map[string]struct {
+		issuer      *cmapi.Issuer
+		certificate *cmapi.Certificate
+		secret      *corev1.Secret
+
+		expectedRevoked    bool
+		expectedFinalizers []string
+		expectedUpdate     bool
+	}{
+		"finalizer is added when the issuer revokes on delete": {
+			issuer:             vaultIssuer(&cmapi.VaultRevocation{RevokeOnDelete: true}),
+			certificate:        baseCrt.DeepCopy(),
+			secret:             secret("vault"),
+			expectedUpdate:     true,
+			expectedFinalizers: []string{cmapi.VaultRevocationFinalizer},
+		},
+		"finalizer is removed when the issuer no longer revokes on delete": {
+			issuer:         vaultIssuer(&cmapi.VaultRevocation{}),
+			certificate:    gen.CertificateFrom(baseCrt, setFinalizers(cmapi.VaultRevocationFinalizer)),
+			secret:         secret("vault"),
+			expectedUpdate: true,
+		},
+		"certificate is revoked on delete": {
+			issuer:             vaultIssuer(&cmapi.VaultRevocation{RevokeOnDelete: true}),
+			certificate:        deleting(gen.CertificateFrom(baseCrt, setFinalizers("other", cmapi.VaultRevocationFinalizer))),
+			secret:             secret("vault"),
+			expectedRevoked:    true,
+			expectedUpdate:     true,
+			expectedFinalizers: []string{"other"},
+		},
+		"certificate issued by a previous issuer is not revoked on delete": {
+			issuer:         vaultIssuer(&cmapi.VaultRevocation{RevokeOnDelete: true}),
+			certificate:    deleting(gen.CertificateFrom(baseCrt, setFinalizers(cmapi.VaultRevocationFinalizer))),
+			secret:         secret("ca"),
+			expectedUpdate: true,
+		},
+		"finalizer is removed without revoking when the issuer is gone": {
+			certificate:    deleting(gen.CertificateFrom(baseCrt, setFinalizers(cmapi.VaultRevocationFinalizer))),
+			secret:         secret("vault"),
+			expectedUpdate: true,
+		},
+		"certificate annotated to be revoked is revoked and the annotation removed": {
+			issuer:          vaultIssuer(&cmapi.VaultRevocation{}),
+			certificate:     gen.CertificateFrom(baseCrt, gen.AddCertificateAnnotations(map[string]string{cmapi.RevokeCertificateAnnotationKey: "true"})),
+			secret:          secret("vault"),
+			expectedRevoked: true,
+			expectedUpdate:  true,
+		},
+		"annotation is ignored when the issuer doesn't configure revocation": {
+			issuer:      vaultIssuer(nil),
+			certificate: gen.CertificateFrom(baseCrt, gen.AddCertificateAnnotations(map[string]string{cmapi.RevokeCertificateAnnotationKey: "true"})),
+			secret:      secret("vault"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmObjects := []runtime.Object{test.certificate}
+			if test.issuer != nil {
+				cmObjects = append(cmObjects, test.issuer)
+			}
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeClock,
+				CertManagerObjects: cmObjects,
+				KubeObjects:        []runtime.Object{test.secret},
+			}
+			builder.Init()
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			var revoked *big.Int
+			w.controller.vaultClientBuilder = func(string, corelisters.SecretLister, cmapi.GenericIssuer) (vaultinternal.Interface, error) {
+				return vaultfake.New().WithRevoke(func(serial *big.Int) error {
+					revoked = serial
+					return nil
+				}), nil
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			key, _ := controllerpkg.KeyFunc(test.certificate)
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if test.expectedRevoked != (revoked != nil) {
+				t.Errorf("expected revoked=%t, got serial %v", test.expectedRevoked, revoked)
+			}
+			if revoked != nil && revoked.Cmp(bundle.Cert.SerialNumber) != 0 {
+				t.Errorf("expected serial %v to be revoked, got %v", bundle.Cert.SerialNumber, revoked)
+			}
+
+			var updated *cmapi.Certificate
+			for _, action := range builder.FakeCMClient().Actions() {
+				if update, ok := action.(coretesting.UpdateAction); ok {
+					updated = update.GetObject().(*cmapi.Certificate)
+				}
+			}
+			if test.expectedUpdate != (updated != nil) {
+				t.Fatalf("expected update=%t, got %v", test.expectedUpdate, updated)
+			}
+			if updated == nil {
+				return
+			}
+			if len(updated.Finalizers) > 0 || len(test.expectedFinalizers) > 0 {
+				if !reflect.DeepEqual(updated.Finalizers, test.expectedFinalizers) {
+					t.Errorf("expected finalizers %v, got %v", test.expectedFinalizers, updated.Finalizers)
+				}
+			}
+			if _, ok := updated.Annotations[cmapi.RevokeCertificateAnnotationKey]; ok {
+				t.Errorf("expected the %s annotation to be removed", cmapi.RevokeCertificateAnnotationKey)
+			}
+		})
+	}
+}
+
+func TestTidy(t *testing.T) {
+	fakeClock := fakeclock.NewFakeClock(fixedClockStart)
+	builder := &testpkg.Builder{
+		T:     t,
+		Clock: fakeClock,
+		CertManagerObjects: []runtime.Object{
+			vaultIssuer(&cmapi.VaultRevocation{
+				TidyInterval:     &metav1.Duration{Duration: 24 * time.Hour},
+				TidySafetyBuffer: &metav1.Duration{Duration: 48 * time.Hour},
+			}),
+			gen.Issuer("ca", gen.SetIssuerNamespace("testns"), gen.SetIssuerCA(cmapi.CAIssuer{})),
+		},
+	}
+	builder.Init()
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+
+	var tidied []time.Duration
+	w.controller.vaultClientBuilder = func(string, corelisters.SecretLister, cmapi.GenericIssuer) (vaultinternal.Interface, error) {
+		return vaultfake.New().WithTidy(func(safetyBuffer time.Duration) error {
+			tidied = append(tidied, safetyBuffer)
+			return nil
+		}), nil
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	w.controller.tidy(context.Background())
+	fakeClock.Step(time.Hour)
+	w.controller.tidy(context.Background())
+	fakeClock.Step(24 * time.Hour)
+	w.controller.tidy(context.Background())
+
+	expected := []time.Duration{48 * time.Hour, 48 * time.Hour}
+	if !reflect.DeepEqual(tidied, expected) {
+		t.Errorf("expected tidy calls %v, got %v", expected, tidied)
+	}
+}
+
+func setFinalizers(finalizers ...string) gen.CertificateModifier {
+	return func(crt *cmapi.Certificate) {
+		crt.Finalizers = finalizers
+	}
+}
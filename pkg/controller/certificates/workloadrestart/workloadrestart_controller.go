@@ -0,0 +1,309 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrestart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ControllerName is the name of the workload restart controller.
+	ControllerName = "certificates-workload-restart"
+
+	reasonWorkloadRestarted     = "WorkloadRestarted"
+	reasonWorkloadRestartFailed = "WorkloadRestartFailed"
+)
+
+// controller restarts the Deployments and StatefulSets which consume the
+// Secret of a Certificate annotated with `cert-manager.io/restart-workloads`
+// whenever a new revision of the Certificate is issued.
+// The revision that each workload was last restarted for is recorded in the
+// `cert-manager.io/restarted-for-certificates` annotation of the workload.
+// Workloads which don't have a record for a Certificate yet are assumed to
+// already be using its current revision, so that opting in a Certificate or
+// creating a workload doesn't cause a restart.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	deploymentLister  appslisters.DeploymentLister
+	statefulSetLister appslisters.StatefulSetLister
+	client            kubernetes.Interface
+	recorder          record.EventRecorder
+	clock             clock.Clock
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Certificates with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+// workload is a Deployment or StatefulSet.
+type workload struct {
+	kind string
+	meta *metav1.ObjectMeta
+}
+
+// NewController returns a new workload restart controller.
+func NewController(
+	log logr.Logger,
+	client kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	deploymentInformer := factory.Apps().V1().Deployments()
+	statefulSetInformer := factory.Apps().V1().StatefulSets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a workload changes, the Certificates in its namespace are enqueued
+	// so that new workloads are recorded as using the current revisions.
+	deploymentInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueCertificatesInNamespace(log, queue, certificateInformer.Lister()),
+	})
+	statefulSetInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueCertificatesInNamespace(log, queue, certificateInformer.Lister()),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		deploymentInformer.Informer().HasSynced,
+		statefulSetInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateLister: certificateInformer.Lister(),
+		deploymentLister:  deploymentInformer.Lister(),
+		statefulSetLister: statefulSetInformer.Lister(),
+		client:            client,
+		recorder:          recorder,
+		clock:             clock,
+	}, queue, mustSync
+}
+
+func enqueueCertificatesInNamespace(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		o, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-object type resource passed to enqueueCertificatesInNamespace")
+			return
+		}
+		crts, err := lister.Certificates(o.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list Certificates")
+			return
+		}
+		for _, crt := range crts {
+			if crt.Annotations[cmapi.RestartWorkloadsAnnotationKey] != "true" {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "failed to construct key for Certificate")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a Certificate to be re-synced is pulled from the workqueue.
+// ProcessItem restarts the workloads consuming the Secret of the Certificate
+// which were last restarted for an older revision of the Certificate.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, crt.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("certificate is managed by a different controller class, skipping")
+		return nil
+	}
+
+	if crt.Annotations[cmapi.RestartWorkloadsAnnotationKey] != "true" || crt.Status.Revision == nil {
+		return nil
+	}
+	// Workloads are only restarted once the new revision has been written
+	// to the Secret.
+	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}) {
+		return nil
+	}
+
+	workloads, err := c.workloadsFor(crt)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, w := range workloads {
+		revisions := parseRestartedFor(w.meta.Annotations[cmapi.RestartedForCertificatesAnnotationKey])
+		previous, recorded := revisions[crt.Name]
+		if recorded && previous >= *crt.Status.Revision {
+			continue
+		}
+		revisions[crt.Name] = *crt.Status.Revision
+
+		restart := recorded
+		if err := c.patch(ctx, w, formatRestartedFor(revisions), restart); err != nil {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonWorkloadRestartFailed, "Failed to restart %s %q: %v", w.kind, w.meta.Name, err)
+			errs = append(errs, err)
+			continue
+		}
+		if restart {
+			log.V(logf.DebugLevel).Info("restarted workload", "kind", w.kind, "name", w.meta.Name, "revision", *crt.Status.Revision)
+			c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonWorkloadRestarted, "Restarted %s %q to use revision %d", w.kind, w.meta.Name, *crt.Status.Revision)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// workloadsFor returns the Deployments and StatefulSets in the namespace of
+// crt which consume its Secret.
+func (c *controller) workloadsFor(crt *cmapi.Certificate) ([]workload, error) {
+	deployments, err := c.deploymentLister.Deployments(crt.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	statefulSets, err := c.statefulSetLister.StatefulSets(crt.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var workloads []workload
+	for _, d := range deployments {
+		if usesSecret(&d.Spec.Template.Spec, crt.Spec.SecretName) {
+			workloads = append(workloads, workload{kind: "Deployment", meta: &d.ObjectMeta})
+		}
+	}
+	for _, s := range statefulSets {
+		if usesSecret(&s.Spec.Template.Spec, crt.Spec.SecretName) {
+			workloads = append(workloads, workload{kind: "StatefulSet", meta: &s.ObjectMeta})
+		}
+	}
+	return workloads, nil
+}
+
+// patch records the given restarted-for-certificates annotation on w, and
+// triggers a rolling restart of w if restart is true.
+func (c *controller) patch(ctx context.Context, w workload, restartedFor string, restart bool) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{cmapi.RestartedForCertificatesAnnotationKey: restartedFor},
+		},
+	}
+	if restart {
+		patch["spec"] = map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{restartedAtAnnotationKey: c.clock.Now().UTC().Format(time.RFC3339)},
+				},
+			},
+		}
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	switch w.kind {
+	case "Deployment":
+		_, err = c.client.AppsV1().Deployments(w.meta.Namespace).Patch(ctx, w.meta.Name, types.MergePatchType, data, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = c.client.AppsV1().StatefulSets(w.meta.Namespace).Patch(ctx, w.meta.Name, types.MergePatchType, data, metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("unknown workload kind %q", w.kind)
+	}
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+	)
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
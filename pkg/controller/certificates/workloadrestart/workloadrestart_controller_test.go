@@ -0,0 +1,165 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrestart
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func secretVolumePodSpec(secretName string) corev1.PodSpec {
+	return corev1.PodSpec{
+		Volumes: []corev1.Volume{{
+			Name:         "tls",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}},
+		}},
+	}
+}
+
+func deployment(name, restartedFor string, podSpec corev1.PodSpec) *appsv1.Deployment {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name},
+		Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: podSpec}},
+	}
+	if len(restartedFor) > 0 {
+		d.Annotations = map[string]string{cmapi.RestartedForCertificatesAnnotationKey: restartedFor}
+	}
+	return d
+}
+
+func TestProcessItem(t *testing.T) {
+	revision := 2
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-tls"),
+		gen.SetCertificateRevision(revision),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+	)
+	crt.Annotations = map[string]string{cmapi.RestartWorkloadsAnnotationKey: "true"}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "testns",
+			Name:        "envfrom",
+			Annotations: map[string]string{cmapi.RestartedForCertificatesAnnotationKey: "other=5,test=1"},
+		},
+		Spec: appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "test-tls"}}}},
+			}},
+		}}},
+	}
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fakeclock.NewFakeClock(fixedClockStart),
+		CertManagerObjects: []runtime.Object{crt},
+		KubeObjects: []runtime.Object{
+			deployment("outdated", "test=1", secretVolumePodSpec("test-tls")),
+			deployment("new", "", secretVolumePodSpec("test-tls")),
+			deployment("uptodate", "test=2", secretVolumePodSpec("test-tls")),
+			deployment("unrelated", "", secretVolumePodSpec("other-tls")),
+			statefulSet,
+		},
+	}
+	builder.Init()
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	key, _ := controllerpkg.KeyFunc(crt)
+	if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	patches := make(map[string]string)
+	for _, action := range builder.FakeKubeClient().Actions() {
+		if patch, ok := action.(coretesting.PatchAction); ok {
+			patches[patch.GetResource().Resource+"/"+patch.GetName()] = string(patch.GetPatch())
+		}
+	}
+
+	restart := `"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"2022-10-01T12:00:00Z"}}}}`
+	expected := map[string]string{
+		"deployments/outdated": `{"metadata":{"annotations":{"cert-manager.io/restarted-for-certificates":"test=2"}},` + restart + `}`,
+		"deployments/new":      `{"metadata":{"annotations":{"cert-manager.io/restarted-for-certificates":"test=2"}}}`,
+		"statefulsets/envfrom": `{"metadata":{"annotations":{"cert-manager.io/restarted-for-certificates":"other=5,test=2"}},` + restart + `}`,
+	}
+	if !reflect.DeepEqual(patches, expected) {
+		t.Errorf("unexpected patches:\nexpected: %v\ngot:      %v", expected, patches)
+	}
+}
+
+func TestUsesSecret(t *testing.T) {
+	tests := map[string]struct {
+		spec     corev1.PodSpec
+		expected bool
+	}{
+		"secret volume": {
+			spec:     secretVolumePodSpec("test-tls"),
+			expected: true,
+		},
+		"projected volume": {
+			spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+					{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "test-tls"}}},
+				}}},
+			}}},
+			expected: true,
+		},
+		"env in an init container": {
+			spec: corev1.PodSpec{InitContainers: []corev1.Container{{
+				Env: []corev1.EnvVar{{ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "test-tls"},
+					Key:                  "tls.crt",
+				}}}},
+			}}},
+			expected: true,
+		},
+		"other secret": {
+			spec:     secretVolumePodSpec("other-tls"),
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := usesSecret(&test.spec, "test-tls"); got != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrestart
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// restartedAtAnnotationKey is the pod template annotation set by `kubectl
+// rollout restart`. Changing it triggers a rolling restart of the workload.
+const restartedAtAnnotationKey = "kubectl.kubernetes.io/restartedAt"
+
+// usesSecret returns true if the given pod spec mounts the named Secret as a
+// volume, or reads it using envFrom or env.
+func usesSecret(spec *corev1.PodSpec, name string) bool {
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == name {
+			return true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil && source.Secret.Name == name {
+					return true
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && envFrom.SecretRef.Name == name {
+				return true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseRestartedFor parses the value of the restarted-for-certificates
+// annotation. Malformed entries are ignored.
+func parseRestartedFor(value string) map[string]int {
+	revisions := make(map[string]int)
+	for _, entry := range strings.Split(value, ",") {
+		name, revision, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		if r, err := strconv.Atoi(revision); err == nil {
+			revisions[name] = r
+		}
+	}
+	return revisions
+}
+
+// formatRestartedFor formats the value of the restarted-for-certificates
+// annotation.
+func formatRestartedFor(revisions map[string]int) string {
+	entries := make([]string, 0, len(revisions))
+	for name, revision := range revisions {
+		entries = append(entries, name+"="+strconv.Itoa(revision))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
@@ -296,6 +296,11 @@ func (a *ACME) buildOrder(csr *certificatesv1.CertificateSigningRequest, req *x5
 		spec.Duration = &metav1.Duration{Duration: duration}
 	}
 
+	spec.Profile = iss.GetSpec().ACME.Profile
+	if profile, ok := csr.Annotations[cmacme.ACMECertificateProfileAnnotationKey]; ok {
+		spec.Profile = profile
+	}
+
 	computeNameSpec := spec.DeepCopy()
 	// Create a deep copy of the OrderSpec so we can overwrite the Request and
 	// NotAfter field.
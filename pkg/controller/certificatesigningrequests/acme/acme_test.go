@@ -411,7 +411,7 @@ func Test_ProcessItem(t *testing.T) {
 						gen.SetIssuerACME(cmacme.ACMEIssuer{EnableDurationFeature: true}),
 					)},
 				ExpectedEvents: []string{
-					`Warning ErrorParseDuration Failed to parse requested duration: failed to parse requested duration on annotation "experimental.cert-manager.io/request-duration": time: invalid duration "garbage-data"`,
+					`Warning ErrorParseDuration Failed to parse requested duration: failed to parse requested duration on annotation "experimental.cert-manager.io/request-duration": invalid duration "garbage-data"`,
 				},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewCreateAction(
@@ -451,7 +451,7 @@ func Test_ProcessItem(t *testing.T) {
 								Type:               certificatesv1.CertificateFailed,
 								Status:             corev1.ConditionTrue,
 								Reason:             "ErrorParseDuration",
-								Message:            `Failed to parse requested duration: failed to parse requested duration on annotation "experimental.cert-manager.io/request-duration": time: invalid duration "garbage-data"`,
+								Message:            `Failed to parse requested duration: failed to parse requested duration on annotation "experimental.cert-manager.io/request-duration": invalid duration "garbage-data"`,
 								LastTransitionTime: metaFixedClockStart,
 								LastUpdateTime:     metaFixedClockStart,
 							}),
@@ -952,6 +952,7 @@ func Test_buildOrder(t *testing.T) {
 
 	tests := map[string]struct {
 		enableDurationFeature bool
+		profile               string
 
 		want    *cmacme.Order
 		wantErr bool
@@ -989,6 +990,23 @@ func Test_buildOrder(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"Building with the issuer's profile": {
+			profile: "shortlived",
+			want: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					Request:    csrPEM,
+					CommonName: "example.com",
+					DNSNames:   []string{"example.com"},
+					Profile:    "shortlived",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "test-name",
+						Kind:  "Issuer",
+						Group: "cert-manager.io",
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for name, test := range tests {
@@ -998,6 +1016,7 @@ func Test_buildOrder(t *testing.T) {
 					IssuerConfig: cmapi.IssuerConfig{
 						ACME: &cmacme.ACMEIssuer{
 							EnableDurationFeature: test.enableDurationFeature,
+							Profile:               test.profile,
 						},
 					},
 				},
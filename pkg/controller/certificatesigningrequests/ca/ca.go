@@ -25,6 +25,7 @@ import (
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
 	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
@@ -34,6 +35,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	caissuer "github.com/cert-manager/cert-manager/pkg/issuer/ca"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
@@ -53,6 +55,7 @@ type signingFn func([]*x509.Certificate, crypto.Signer, *x509.Certificate) (pki.
 type CA struct {
 	issuerOptions controllerpkg.IssuerOptions
 	secretsLister corelisters.SecretLister
+	kubeClient    kubernetes.Interface
 
 	certClient certificatesclient.CertificateSigningRequestInterface
 
@@ -79,6 +82,7 @@ func NewCA(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
 	return &CA{
 		issuerOptions:     ctx.IssuerOptions,
 		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		kubeClient:        ctx.Client,
 		certClient:        ctx.Client.CertificatesV1().CertificateSigningRequests(),
 		fieldManager:      ctx.FieldManager,
 		recorder:          ctx.Recorder,
@@ -130,6 +134,31 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 
+	backdate, err := apiutil.NotBeforeBackdate(csr.Annotations, issuerObj.GetSpec().CA.NotBeforeBackdate)
+	if err != nil {
+		message := fmt.Sprintf("Error generating certificate template: %s", err)
+		c.recorder.Event(csr, corev1.EventTypeWarning, "SigningError", message)
+		util.CertificateSigningRequestSetFailed(csr, "SigningError", message)
+		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		return err
+	}
+	template.NotBefore = template.NotBefore.Add(-backdate)
+
+	if err := pki.SetKeyIdentifiers(template, caCerts[0], issuerObj.GetSpec().CA.KeyIdentifierMethod); err != nil {
+		message := fmt.Sprintf("Error generating certificate template: %s", err)
+		c.recorder.Event(csr, corev1.EventTypeWarning, "SigningError", message)
+		util.CertificateSigningRequestSetFailed(csr, "SigningError", message)
+		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		return err
+	}
+
+	template.SerialNumber, err = caissuer.GenerateSerialNumber(ctx, c.kubeClient, resourceNamespace, issuerObj.GetSpec().CA, csr.Name)
+	if err != nil {
+		message := fmt.Sprintf("Error generating serial number: %s", err)
+		c.recorder.Event(csr, corev1.EventTypeWarning, "SerialNumberError", message)
+		return err
+	}
+
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
 		message := fmt.Sprintf("Error signing certificate: %s", err)
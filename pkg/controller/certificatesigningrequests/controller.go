@@ -165,7 +165,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
 
 	// create an issuer helper for reading generic issuers
-	c.helper = issuer.NewHelper(issuerInformer.Lister(), clusterIssuerInformer.Lister())
+	c.helper = issuer.NewHelper(issuerInformer.Lister(), clusterIssuerInformer.Lister(), nil)
 
 	c.clock = ctx.Clock
 	// recorder records events about resources to the Kubernetes api
@@ -0,0 +1,359 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletserving
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
+	certificateslisters "k8s.io/client-go/listers/certificates/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
+	"github.com/cert-manager/cert-manager/pkg/util/kube"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	CSRControllerName = "certificatesigningrequests-kubelet-serving"
+
+	reasonApproved = "KubeletServingApproved"
+	reasonDenied   = "KubeletServingDenied"
+)
+
+type templateGenerator func(*certificatesv1.CertificateSigningRequest) (*x509.Certificate, error)
+type signingFn func([]*x509.Certificate, crypto.Signer, *x509.Certificate) (pki.PEMBundle, error)
+
+// IssuerRefFromSignerName returns the reference to the Issuer or
+// ClusterIssuer named by the given cert-manager signer name, and the namespace
+// of the Issuer.
+func IssuerRefFromSignerName(signerName string) (cmmeta.ObjectReference, string, error) {
+	ref, ok := util.SignerIssuerRefFromSignerName(signerName)
+	if !ok || ref.Group != certmanager.GroupName {
+		return cmmeta.ObjectReference{}, "", fmt.Errorf("%q is not a cert-manager.io signer name", signerName)
+	}
+	kind, ok := util.IssuerKindFromType(ref.Type)
+	if !ok {
+		return cmmeta.ObjectReference{}, "", fmt.Errorf("%q does not reference issuers or clusterissuers", signerName)
+	}
+	if kind == cmapi.IssuerKind && len(ref.Namespace) == 0 {
+		return cmmeta.ObjectReference{}, "", fmt.Errorf("%q must be of the form issuers.cert-manager.io/<namespace>.<name>", signerName)
+	}
+	return cmmeta.ObjectReference{Name: ref.Name, Kind: kind, Group: ref.Group}, ref.Namespace, nil
+}
+
+// controller is a Kubernetes CertificateSigningRequest controller for the
+// `kubernetes.io/kubelet-serving` signer. Requests are approved if they are
+// made by the Node that they request a certificate for, and each of the
+// requested subjectAltNames is one of the addresses of the Node. Requests
+// which don't meet these requirements are denied.
+// Approved requests are signed by the configured CA Issuer or ClusterIssuer,
+// so that the kube-controller-manager doesn't need to approve kubelet
+// serving certificates without verifying them.
+type controller struct {
+	csrLister     certificateslisters.CertificateSigningRequestLister
+	nodeLister    corelisters.NodeLister
+	secretsLister corelisters.SecretLister
+	helper        issuer.Helper
+
+	certClient certificatesclient.CertificateSigningRequestInterface
+
+	// fieldManager is the manager name used for the Apply operations.
+	fieldManager string
+
+	issuerRef       cmmeta.ObjectReference
+	issuerNamespace string
+	issuerOptions   controllerpkg.IssuerOptions
+
+	recorder record.EventRecorder
+	clock    clock.Clock
+
+	// Used for testing to get reproducible resulting certificates
+	templateGenerator templateGenerator
+	signingFn         signingFn
+}
+
+func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	if ctx.Namespace != "" {
+		return nil, nil, errors.New("the kubelet serving signer can only be run when cert-manager is not scoped to a single namespace")
+	}
+	issuerRef, issuerNamespace, err := IssuerRefFromSignerName(ctx.KubeletServingIssuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid kubelet serving issuer: %w", err)
+	}
+
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), CSRControllerName)
+
+	// obtain references to all the informers used by this controller
+	csrInformer := ctx.KubeSharedInformerFactory.Certificates().V1().CertificateSigningRequests()
+	nodeInformer := ctx.KubeSharedInformerFactory.Core().V1().Nodes()
+	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+
+	csrInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		csrInformer.Informer().HasSynced,
+		nodeInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		clusterIssuerInformer.Informer().HasSynced,
+	}
+
+	c.csrLister = csrInformer.Lister()
+	c.nodeLister = nodeInformer.Lister()
+	c.secretsLister = secretInformer.Lister()
+	c.helper = issuer.NewHelper(issuerInformer.Lister(), clusterIssuerInformer.Lister(), nil)
+	c.certClient = ctx.Client.CertificatesV1().CertificateSigningRequests()
+	c.fieldManager = ctx.FieldManager
+	c.issuerRef = issuerRef
+	c.issuerNamespace = issuerNamespace
+	c.issuerOptions = ctx.IssuerOptions
+	c.recorder = ctx.Recorder
+	c.clock = ctx.Clock
+	c.templateGenerator = pki.GenerateTemplateFromCertificateSigningRequest
+	c.signingFn = pki.SignCSRTemplate
+
+	return queue, mustSync, nil
+}
+
+// ProcessItem approves or denies the kubelet serving
+// CertificateSigningRequest with the given key, and signs it once approved.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+	dbg := log.V(logf.DebugLevel)
+
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key")
+		return nil
+	}
+
+	csr, err := c.csrLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		dbg.Info("certificate signing request in work queue no longer exists", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName {
+		return nil
+	}
+
+	// Deep copy CertificateSigningRequest to prevent writing to the shared
+	// local cache making it invalid.
+	csr = csr.DeepCopy()
+	log = logf.WithResource(log, csr)
+	ctx = logf.NewContext(ctx, log)
+
+	if util.CertificateSigningRequestIsFailed(csr) || util.CertificateSigningRequestIsDenied(csr) || len(csr.Status.Certificate) > 0 {
+		return nil
+	}
+
+	verifyErr, err := c.verify(csr)
+	if err != nil {
+		return err
+	}
+
+	if !util.CertificateSigningRequestIsApproved(csr) {
+		// The CertificateSigningRequest is signed once the approval has been
+		// observed by the informer.
+		return c.updateApproval(ctx, csr, verifyErr)
+	}
+
+	// The request may have been approved by another approver, in which case
+	// it is only signed if it passes verification.
+	if verifyErr != nil {
+		message := fmt.Sprintf("Kubelet serving certificate request failed verification: %s", verifyErr)
+		c.recorder.Event(csr, corev1.EventTypeWarning, "VerificationFailed", message)
+		util.CertificateSigningRequestSetFailed(csr, "VerificationFailed", message)
+		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		return err
+	}
+
+	return c.sign(ctx, csr)
+}
+
+// verify returns the reason that the given request may not be signed, or an
+// error if the Node of the request could not be retrieved.
+func (c *controller) verify(csr *certificatesv1.CertificateSigningRequest) (verifyErr error, err error) {
+	nodeName, req, verifyErr := nodeNameForRequest(csr)
+	if verifyErr != nil {
+		return verifyErr, nil
+	}
+
+	node, err := c.nodeLister.Get(nodeName)
+	if apierrors.IsNotFound(err) {
+		// Nodes register themselves before requesting a serving
+		// certificate, so this is retried in case the informer is lagging.
+		return nil, fmt.Errorf("node %q not found", nodeName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return verifySubjectAltNames(req, node), nil
+}
+
+// updateApproval approves the given request if verifyErr is nil, and denies
+// it otherwise.
+func (c *controller) updateApproval(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, verifyErr error) error {
+	now := metav1.NewTime(c.clock.Now())
+	cond := certificatesv1.CertificateSigningRequestCondition{
+		Type:               certificatesv1.CertificateApproved,
+		Status:             corev1.ConditionTrue,
+		Reason:             reasonApproved,
+		Message:            "The requested subjectAltNames are addresses of the requesting Node",
+		LastTransitionTime: now,
+		LastUpdateTime:     now,
+	}
+	if verifyErr != nil {
+		cond.Type = certificatesv1.CertificateDenied
+		cond.Reason = reasonDenied
+		cond.Message = verifyErr.Error()
+	}
+	csr.Status.Conditions = append(csr.Status.Conditions, cond)
+
+	if _, err := c.certClient.UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	if verifyErr != nil {
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "Denied", "Denied kubelet serving certificate request: %s", verifyErr)
+	} else {
+		c.recorder.Event(csr, corev1.EventTypeNormal, "Approved", cond.Message)
+	}
+	return nil
+}
+
+// sign signs the given approved request using the configured CA issuer.
+func (c *controller) sign(ctx context.Context, csr *certificatesv1.CertificateSigningRequest) error {
+	log := logf.FromContext(ctx, "sign")
+
+	issuerObj, err := c.helper.GetGenericIssuer(c.issuerRef, c.issuerNamespace)
+	if apierrors.IsNotFound(err) {
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "IssuerNotFound", "Referenced %s %s/%s not found", c.issuerRef.Kind, c.issuerNamespace, c.issuerRef.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if issuerObj.GetSpec().CA == nil {
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "IssuerTypeInvalid", "Referenced %s %s/%s is not a CA issuer", c.issuerRef.Kind, c.issuerNamespace, c.issuerRef.Name)
+		return nil
+	}
+	if !apiutil.IssuerHasCondition(issuerObj, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "IssuerNotReady", "Referenced %s %s/%s does not have a Ready status condition",
+			c.issuerRef.Kind, c.issuerNamespace, c.issuerRef.Name)
+		return nil
+	}
+
+	duration, err := pki.DurationFromCertificateSigningRequest(csr)
+	if err != nil || duration < experimentalapi.CertificateSigningRequestMinimumDuration {
+		message := fmt.Sprintf("CertificateSigningRequest minimum allowed duration is %s, requested %s", experimentalapi.CertificateSigningRequestMinimumDuration, duration)
+		if err != nil {
+			message = fmt.Sprintf("Failed to parse requested duration: %s", err)
+		}
+		c.recorder.Event(csr, corev1.EventTypeWarning, "InvalidDuration", message)
+		util.CertificateSigningRequestSetFailed(csr, "InvalidDuration", message)
+		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		return err
+	}
+
+	secretName := issuerObj.GetSpec().CA.SecretName
+	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
+
+	// get a copy of the CA certificate named on the Issuer
+	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, secretName)
+	if apierrors.IsNotFound(err) {
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SecretMissing", "Referenced secret %s/%s not found", resourceNamespace, secretName)
+		return nil
+	}
+	if cmerrors.IsInvalidData(err) {
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SecretInvalidData", "Failed to parse signing CA keypair from secret %s/%s: %s", resourceNamespace, secretName, err)
+		return nil
+	}
+	if err != nil {
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SecretGetError", "Failed to get certificate key pair from secret %s/%s: %s", resourceNamespace, secretName, err)
+		return err
+	}
+
+	template, err := c.templateGenerator(csr)
+	if err == nil {
+		template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
+		template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
+
+		var bundle pki.PEMBundle
+		bundle, err = c.signingFn(caCerts, caKey, template)
+		csr.Status.Certificate = bundle.ChainPEM
+	}
+	if err != nil {
+		message := fmt.Sprintf("Error signing certificate: %s", err)
+		c.recorder.Event(csr, corev1.EventTypeWarning, "SigningError", message)
+		util.CertificateSigningRequestSetFailed(csr, "SigningError", message)
+		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		return err
+	}
+
+	if _, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, "", c.fieldManager); err != nil {
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SigningError", "Error updating certificate: %s", err)
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("kubelet serving certificate issued")
+	c.recorder.Event(csr, corev1.EventTypeNormal, "CertificateIssued", "Kubelet serving certificate signed successfully")
+
+	return nil
+}
+
+func init() {
+	controllerpkg.Register(CSRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CSRControllerName).
+			For(&controller{}).
+			Complete()
+	})
+}
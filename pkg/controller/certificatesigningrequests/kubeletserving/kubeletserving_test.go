@@ -0,0 +1,222 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletserving
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func kubeletCSR(t *testing.T, username string, mods ...gen.CSRModifier) *certificatesv1.CertificateSigningRequest {
+	request, _, err := gen.CSR(x509.ECDSA, append([]gen.CSRModifier{
+		gen.SetCSRCommonName("system:node:node-1"),
+		func(req *x509.CertificateRequest) error {
+			req.Subject.Organization = []string{"system:nodes"}
+			return nil
+		},
+	}, mods...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gen.CertificateSigningRequest("csr-1",
+		gen.SetCertificateSigningRequestRequest(request),
+		gen.SetCertificateSigningRequestSignerName(certificatesv1.KubeletServingSignerName),
+		gen.SetCertificateSigningRequestUsername(username),
+		gen.SetCertificateSigningRequestGroups([]string{"system:nodes", "system:authenticated"}),
+		gen.SetCertificateSigningRequestUsages([]certificatesv1.KeyUsage{
+			certificatesv1.UsageDigitalSignature, certificatesv1.UsageKeyEncipherment, certificatesv1.UsageServerAuth,
+		}),
+	)
+}
+
+func approved(csr *certificatesv1.CertificateSigningRequest) *certificatesv1.CertificateSigningRequest {
+	return gen.CertificateSigningRequestFrom(csr, gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+		Type:   certificatesv1.CertificateApproved,
+		Status: corev1.ConditionTrue,
+	}))
+}
+
+func caSecret(t *testing.T) *corev1.Secret {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodeECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		BasicConstraintsValid: true,
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubelet-ca"},
+		NotBefore:             fixedClockStart.Add(-time.Hour),
+		NotAfter:              fixedClockStart.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:                  true,
+	}
+	certPEM, _, err := pki.SignCertificate(tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "kubelet-ca"},
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}
+}
+
+func TestProcessItem(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeHostName, Address: "node-1"},
+			{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+		}},
+	}
+	issuer := gen.ClusterIssuer("kubelet-ca",
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "kubelet-ca"}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}),
+	)
+	sans := []gen.CSRModifier{gen.SetCSRDNSNames("node-1"), gen.SetCSRIPAddressesFromStrings("10.0.0.1")}
+
+	tests := map[string]struct {
+		csr *certificatesv1.CertificateSigningRequest
+
+		expectedApproval certificatesv1.RequestConditionType
+		expectedSigned   bool
+		expectedFailed   bool
+	}{
+		"a request for the addresses of the node is approved": {
+			csr:              kubeletCSR(t, "system:node:node-1", sans...),
+			expectedApproval: certificatesv1.CertificateApproved,
+		},
+		"a request from another node is denied": {
+			csr:              kubeletCSR(t, "system:node:node-2", sans...),
+			expectedApproval: certificatesv1.CertificateDenied,
+		},
+		"a request for an address which is not an address of the node is denied": {
+			csr:              kubeletCSR(t, "system:node:node-1", gen.SetCSRDNSNames("node-1", "example.com")),
+			expectedApproval: certificatesv1.CertificateDenied,
+		},
+		"an approved request is signed": {
+			csr:            approved(kubeletCSR(t, "system:node:node-1", sans...)),
+			expectedSigned: true,
+		},
+		"an approved request which fails verification is failed": {
+			csr:            approved(kubeletCSR(t, "system:node:node-1", gen.SetCSRIPAddressesFromStrings("10.0.0.2"))),
+			expectedFailed: true,
+		},
+		"requests for other signers are ignored": {
+			csr: gen.CertificateSigningRequestFrom(kubeletCSR(t, "system:node:node-1", sans...),
+				gen.SetCertificateSigningRequestSignerName(certificatesv1.KubeAPIServerClientKubeletSignerName)),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(fixedClockStart),
+				KubeObjects:        []runtime.Object{test.csr, node, caSecret(t)},
+				CertManagerObjects: []runtime.Object{issuer},
+			}
+			builder.Init()
+			builder.Context.KubeletServingIssuer = "clusterissuers.cert-manager.io/kubelet-ca"
+			builder.Context.ClusterResourceNamespace = "cert-manager"
+			c := &controller{}
+			if _, _, err := c.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			if err := c.ProcessItem(context.Background(), test.csr.Name); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var approval certificatesv1.RequestConditionType
+			var signed, failed bool
+			for _, action := range builder.FakeKubeClient().Actions() {
+				update, ok := action.(coretesting.UpdateAction)
+				if !ok {
+					continue
+				}
+				csr, ok := update.GetObject().(*certificatesv1.CertificateSigningRequest)
+				if !ok {
+					continue
+				}
+				switch update.GetSubresource() {
+				case "approval":
+					approval = csr.Status.Conditions[len(csr.Status.Conditions)-1].Type
+				case "status":
+					failed = csr.Status.Conditions[len(csr.Status.Conditions)-1].Type == certificatesv1.CertificateFailed
+					if len(csr.Status.Certificate) > 0 {
+						cert, err := pki.DecodeX509CertificateBytes(csr.Status.Certificate)
+						if err != nil {
+							t.Fatalf("failed to decode signed certificate: %v", err)
+						}
+						if cert.Issuer.CommonName != "kubelet-ca" || cert.Subject.CommonName != "system:node:node-1" {
+							t.Errorf("unexpected signed certificate for %q issued by %q", cert.Subject.CommonName, cert.Issuer.CommonName)
+						}
+						signed = true
+					}
+				}
+			}
+
+			if approval != test.expectedApproval {
+				t.Errorf("expected approval %q, got %q", test.expectedApproval, approval)
+			}
+			if signed != test.expectedSigned {
+				t.Errorf("expected signed %t, got %t", test.expectedSigned, signed)
+			}
+			if failed != test.expectedFailed {
+				t.Errorf("expected failed %t, got %t", test.expectedFailed, failed)
+			}
+		})
+	}
+}
+
+func TestIssuerRefFromSignerName(t *testing.T) {
+	ref, namespace, err := IssuerRefFromSignerName("issuers.cert-manager.io/kube-system.kubelet-ca")
+	if err != nil || namespace != "kube-system" || ref.Name != "kubelet-ca" || ref.Kind != cmapi.IssuerKind {
+		t.Errorf("unexpected result %+v %q %v", ref, namespace, err)
+	}
+
+	for _, signerName := range []string{"kubernetes.io/kubelet-serving", "issuers.cert-manager.io/kubelet-ca", "certificaterequests.cert-manager.io/foo"} {
+		if _, _, err := IssuerRefFromSignerName(signerName); err == nil {
+			t.Errorf("expected an error for %q", signerName)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletserving
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	nodeUserPrefix = "system:node:"
+	nodesGroup     = "system:nodes"
+)
+
+// allowedUsages are the key usages that a kubelet serving certificate may be
+// requested with. The server auth usage is required.
+var allowedUsages = sets.NewString(
+	string(certificatesv1.UsageDigitalSignature),
+	string(certificatesv1.UsageKeyEncipherment),
+	string(certificatesv1.UsageServerAuth),
+)
+
+// nodeNameForRequest returns the name of the Node that the given kubelet
+// serving CertificateSigningRequest was requested for. An error is returned if
+// the request is not a well formed kubelet serving request made by that Node.
+func nodeNameForRequest(csr *certificatesv1.CertificateSigningRequest) (string, *x509.CertificateRequest, error) {
+	req, err := pki.DecodeX509CertificateRequestBytes(csr.Spec.Request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !strings.HasPrefix(req.Subject.CommonName, nodeUserPrefix) || len(req.Subject.CommonName) == len(nodeUserPrefix) {
+		return "", nil, fmt.Errorf("common name %q must be of the form %s<node name>", req.Subject.CommonName, nodeUserPrefix)
+	}
+	if len(req.Subject.Organization) != 1 || req.Subject.Organization[0] != nodesGroup {
+		return "", nil, fmt.Errorf("organization %v must be [%s]", req.Subject.Organization, nodesGroup)
+	}
+	if csr.Spec.Username != req.Subject.CommonName {
+		return "", nil, fmt.Errorf("requester %q may not request a certificate for %q", csr.Spec.Username, req.Subject.CommonName)
+	}
+	if !sets.NewString(csr.Spec.Groups...).Has(nodesGroup) {
+		return "", nil, fmt.Errorf("requester %q is not in the %s group", csr.Spec.Username, nodesGroup)
+	}
+
+	if len(req.EmailAddresses) > 0 || len(req.URIs) > 0 {
+		return "", nil, fmt.Errorf("email and URI subjectAltNames are not allowed")
+	}
+	if len(req.DNSNames) == 0 && len(req.IPAddresses) == 0 {
+		return "", nil, fmt.Errorf("at least one DNS or IP subjectAltName is required")
+	}
+
+	usages := sets.NewString()
+	for _, u := range csr.Spec.Usages {
+		usages.Insert(string(u))
+	}
+	if !usages.Has(string(certificatesv1.UsageServerAuth)) {
+		return "", nil, fmt.Errorf("the %q usage is required", certificatesv1.UsageServerAuth)
+	}
+	if extra := usages.Difference(allowedUsages); extra.Len() > 0 {
+		return "", nil, fmt.Errorf("usages %v are not allowed", extra.List())
+	}
+
+	if csr.Annotations[experimentalapi.CertificateSigningRequestIsCAAnnotationKey] == "true" {
+		return "", nil, fmt.Errorf("kubelet serving certificates may not be CA certificates")
+	}
+
+	return strings.TrimPrefix(req.Subject.CommonName, nodeUserPrefix), req, nil
+}
+
+// verifySubjectAltNames returns an error if the given request contains a DNS
+// or IP subjectAltName which is not one of the addresses of the Node.
+func verifySubjectAltNames(req *x509.CertificateRequest, node *corev1.Node) error {
+	dnsNames := sets.NewString()
+	ips := sets.NewString()
+	for _, address := range node.Status.Addresses {
+		switch address.Type {
+		case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+			dnsNames.Insert(address.Address)
+		case corev1.NodeInternalIP, corev1.NodeExternalIP:
+			// Addresses are normalised so that they compare equal to the
+			// IP addresses decoded from the request.
+			if ip := net.ParseIP(address.Address); ip != nil {
+				ips.Insert(ip.String())
+			}
+		}
+	}
+
+	for _, name := range req.DNSNames {
+		if !dnsNames.Has(name) {
+			return fmt.Errorf("DNS name %q is not an address of Node %q", name, node.Name)
+		}
+	}
+	for _, ip := range req.IPAddresses {
+		if !ips.Has(ip.String()) {
+			return fmt.Errorf("IP address %q is not an address of Node %q", ip, node.Name)
+		}
+	}
+	return nil
+}
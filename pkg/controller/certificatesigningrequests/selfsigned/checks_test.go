@@ -124,6 +124,7 @@ func Test_handleSecretReferenceWorkFunc(t *testing.T) {
 			helper := issuer.NewHelper(
 				builder.Context.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
 				builder.Context.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+				nil,
 			)
 
 			builder.Start()
@@ -334,6 +335,7 @@ func Test_certificatesRequestsForSecret(t *testing.T) {
 			helper := issuer.NewHelper(
 				builder.Context.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
 				builder.Context.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+				nil,
 			)
 
 			builder.Start()
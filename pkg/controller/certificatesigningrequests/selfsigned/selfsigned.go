@@ -85,6 +85,7 @@ func init() {
 					helper := issuer.NewHelper(
 						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
 						ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+						nil,
 					)
 					secretInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 						WorkFunc: handleSecretReferenceWorkFunc(log, certificateSigningRequestLister, helper, queue, ctx.IssuerOptions),
@@ -172,6 +173,26 @@ func (s *SelfSigned) Sign(ctx context.Context, csr *certificatesv1.CertificateSi
 
 	template.CRLDistributionPoints = issuerObj.GetSpec().SelfSigned.CRLDistributionPoints
 
+	backdate, err := apiutil.NotBeforeBackdate(csr.Annotations, issuerObj.GetSpec().SelfSigned.NotBeforeBackdate)
+	if err != nil {
+		message := fmt.Sprintf("Error generating certificate template: %s", err)
+		log.Error(err, message)
+		s.recorder.Event(csr, corev1.EventTypeWarning, "ErrorGenerating", message)
+		util.CertificateSigningRequestSetFailed(csr, "ErrorGenerating", message)
+		_, err = util.UpdateOrApplyStatus(ctx, s.certClient, csr, certificatesv1.CertificateFailed, s.fieldManager)
+		return err
+	}
+	template.NotBefore = template.NotBefore.Add(-backdate)
+
+	if err := pki.SetKeyIdentifiers(template, nil, issuerObj.GetSpec().SelfSigned.KeyIdentifierMethod); err != nil {
+		message := fmt.Sprintf("Error generating certificate template: %s", err)
+		log.Error(err, message)
+		s.recorder.Event(csr, corev1.EventTypeWarning, "ErrorGenerating", message)
+		util.CertificateSigningRequestSetFailed(csr, "ErrorGenerating", message)
+		_, err = util.UpdateOrApplyStatus(ctx, s.certClient, csr, certificatesv1.CertificateFailed, s.fieldManager)
+		return err
+	}
+
 	// extract the public component of the key
 	publickey, err := pki.PublicKeyForPrivateKey(privatekey)
 	if err != nil {
@@ -151,7 +151,7 @@ func TestController_Sync(t *testing.T) {
 						gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
 				},
 				ExpectedEvents: []string{
-					"Warning ErrorParseDuration Failed to parse requested duration: failed to parse requested duration on annotation \"experimental.cert-manager.io/request-duration\": time: invalid duration \"foo\"",
+					"Warning ErrorParseDuration Failed to parse requested duration: failed to parse requested duration on annotation \"experimental.cert-manager.io/request-duration\": invalid duration \"foo\"",
 				},
 
 				ExpectedActions: []testpkg.Action{
@@ -169,7 +169,7 @@ func TestController_Sync(t *testing.T) {
 								Type:               certificatesv1.CertificateFailed,
 								Status:             corev1.ConditionTrue,
 								Reason:             "ErrorParseDuration",
-								Message:            `Failed to parse requested duration: failed to parse requested duration on annotation "experimental.cert-manager.io/request-duration": time: invalid duration "foo"`,
+								Message:            `Failed to parse requested duration: failed to parse requested duration on annotation "experimental.cert-manager.io/request-duration": invalid duration "foo"`,
 								LastTransitionTime: metaFixedTime,
 								LastUpdateTime:     metaFixedTime,
 							})),
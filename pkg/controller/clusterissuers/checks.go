@@ -96,3 +96,25 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 
 	return affected, nil
 }
+
+// issuersForCertificate returns the CA issuers whose CA Secret is produced by
+// the given Certificate, so that they can report their bootstrap status as
+// the Certificate progresses.
+func (c *controller) issuersForCertificate(crt *v1.Certificate) ([]*v1.ClusterIssuer, error) {
+	issuers, err := c.clusterIssuerLister.List(labels.NewSelector())
+	if err != nil {
+		return nil, fmt.Errorf("error listing issuers: %s", err.Error())
+	}
+
+	var affected []*v1.ClusterIssuer
+	for _, iss := range issuers {
+		if crt.Namespace != c.clusterResourceNamespace {
+			continue
+		}
+		if iss.Spec.CA != nil && iss.Spec.CA.SecretName == crt.Spec.SecretName {
+			affected = append(affected, iss)
+		}
+	}
+
+	return affected, nil
+}
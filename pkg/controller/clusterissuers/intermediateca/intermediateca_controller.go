@@ -0,0 +1,476 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package intermediateca
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ControllerName is the name of the intermediate CA controller.
+	ControllerName = "clusterissuers-intermediate-ca"
+
+	reasonIntermediateCAProvisioned = "IntermediateCAProvisioned"
+	reasonIntermediateCARemoved     = "IntermediateCARemoved"
+	reasonIntermediateCAConflict    = "IntermediateCAConflict"
+)
+
+var clusterIssuerGVK = cmapi.SchemeGroupVersion.WithKind(cmapi.ClusterIssuerKind)
+
+// controller provisions the intermediate CAs of ClusterIssuers with a
+// spec.intermediateCA. For each namespace selected by a ClusterIssuer, it
+// maintains a Certificate with isCA set which is issued by the ClusterIssuer,
+// and a CA Issuer which signs using the Secret of that Certificate. Both are
+// controlled by the ClusterIssuer, so that they are garbage collected when it
+// is deleted, and they are deleted when their namespace is no longer
+// selected.
+type controller struct {
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	issuerLister        cmlisters.IssuerLister
+	certificateLister   cmlisters.CertificateLister
+	namespaceLister     corelisters.NamespaceLister
+	client              cmclient.Interface
+	recorder            record.EventRecorder
+	queue               workqueue.RateLimitingInterface
+
+	// controllerClass is the class of this installation of cert-manager.
+	// ClusterIssuers with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+// NewController returns a new intermediate CA controller.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	namespaceInformer := factory.Core().V1().Namespaces()
+
+	ctrl := &controller{
+		clusterIssuerLister: clusterIssuerInformer.Lister(),
+		issuerLister:        issuerInformer.Lister(),
+		certificateLister:   certificateInformer.Lister(),
+		namespaceLister:     namespaceInformer.Lister(),
+		client:              client,
+		recorder:            recorder,
+		queue:               queue,
+	}
+
+	clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a provisioned Certificate or Issuer changes, enqueue the
+	// ClusterIssuer which controls it, so that it is restored.
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: ctrl.enqueueControllingClusterIssuer(log)})
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: ctrl.enqueueControllingClusterIssuer(log)})
+	// When a namespace is created or its labels change, enqueue the
+	// ClusterIssuers which provision intermediate CAs.
+	namespaceInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: ctrl.enqueueProvisioningClusterIssuers(log)})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		clusterIssuerInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		namespaceInformer.Informer().HasSynced,
+	}
+
+	return ctrl, queue, mustSync
+}
+
+// enqueueControllingClusterIssuer enqueues the ClusterIssuer which controls
+// the given Certificate or Issuer, if any.
+func (c *controller) enqueueControllingClusterIssuer(log logr.Logger) func(obj interface{}) {
+	return func(obj interface{}) {
+		metaobj, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-object type resource passed to enqueueControllingClusterIssuer")
+			return
+		}
+		if ref := clusterIssuerControllerRef(metaobj); ref != nil {
+			c.queue.Add(ref.Name)
+		}
+	}
+}
+
+// enqueueProvisioningClusterIssuers enqueues the ClusterIssuers with a
+// spec.intermediateCA.
+func (c *controller) enqueueProvisioningClusterIssuers(log logr.Logger) func(obj interface{}) {
+	return func(obj interface{}) {
+		clusterIssuers, err := c.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list clusterissuers")
+			return
+		}
+		for _, iss := range clusterIssuers {
+			if iss.Spec.IntermediateCA != nil {
+				c.queue.Add(iss.Name)
+			}
+		}
+	}
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a ClusterIssuer to be re-synced is pulled from the
+// workqueue. ProcessItem creates or updates the Certificate and Issuer of the
+// intermediate CA in each namespace selected by the ClusterIssuer, and
+// deletes those it controls in namespaces which are no longer selected.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	iss, err := c.clusterIssuerLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		// The provisioned resources are garbage collected.
+		log.V(logf.DebugLevel).Info("clusterissuer not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, iss.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("clusterissuer is managed by a different controller class, skipping")
+		return nil
+	}
+
+	selected, err := c.selectedNamespaces(iss)
+	if err != nil {
+		// An invalid selector is rejected by the webhook, so this can only
+		// happen if the webhook was bypassed.
+		log.Error(err, "invalid namespace selector")
+		return nil
+	}
+
+	issuerName := ""
+	if iss.Spec.IntermediateCA != nil {
+		issuerName = intermediateIssuerName(iss)
+	}
+
+	if err := c.deleteStale(ctx, iss, selected, issuerName); err != nil {
+		return err
+	}
+
+	for _, namespace := range selected.List() {
+		if err := c.syncCertificate(ctx, iss, namespace, issuerName); err != nil {
+			return err
+		}
+		if err := c.syncIssuer(ctx, iss, namespace, issuerName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// selectedNamespaces returns the namespaces in which the ClusterIssuer should
+// provision an intermediate CA. Namespaces which are being deleted are not
+// selected.
+func (c *controller) selectedNamespaces(iss *cmapi.ClusterIssuer) (sets.String, error) {
+	selected := sets.NewString()
+	if iss.Spec.IntermediateCA == nil {
+		return selected, nil
+	}
+
+	selector := labels.Everything()
+	if iss.Spec.IntermediateCA.NamespaceSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(iss.Spec.IntermediateCA.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	namespaces, err := c.namespaceLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range namespaces {
+		if ns.DeletionTimestamp != nil || ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		selected.Insert(ns.Name)
+	}
+	return selected, nil
+}
+
+// deleteStale deletes the Certificates and Issuers controlled by the
+// ClusterIssuer which are not in a selected namespace or which don't have the
+// current issuerName.
+func (c *controller) deleteStale(ctx context.Context, iss *cmapi.ClusterIssuer, selected sets.String, issuerName string) error {
+	isStale := func(obj metav1.Object) bool {
+		if !isControlledBy(obj, iss.UID) {
+			return false
+		}
+		return !selected.Has(obj.GetNamespace()) || obj.GetName() != issuerName
+	}
+
+	crts, err := c.certificateLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, crt := range crts {
+		if !isStale(crt) {
+			continue
+		}
+		err := c.client.CertmanagerV1().Certificates(crt.Namespace).Delete(ctx, crt.Name, preconditions(crt.UID))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete Certificate %s/%s: %w", crt.Namespace, crt.Name, err)
+		}
+	}
+
+	issuers, err := c.issuerLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, issuer := range issuers {
+		if !isStale(issuer) {
+			continue
+		}
+		err := c.client.CertmanagerV1().Issuers(issuer.Namespace).Delete(ctx, issuer.Name, preconditions(issuer.UID))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete Issuer %s/%s: %w", issuer.Namespace, issuer.Name, err)
+		}
+		c.recorder.Eventf(iss, corev1.EventTypeNormal, reasonIntermediateCARemoved,
+			"Removed the intermediate CA Issuer %s/%s", issuer.Namespace, issuer.Name)
+	}
+	return nil
+}
+
+// syncCertificate creates or updates the Certificate of the intermediate CA
+// in the namespace.
+func (c *controller) syncCertificate(ctx context.Context, iss *cmapi.ClusterIssuer, namespace, issuerName string) error {
+	existing, err := c.certificateLister.Certificates(namespace).Get(issuerName)
+	if apierrors.IsNotFound(err) {
+		crt := &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       namespace,
+				Name:            issuerName,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(iss, clusterIssuerGVK)},
+			},
+		}
+		setCertificateSpec(crt, iss, issuerName)
+		if _, err := c.client.CertmanagerV1().Certificates(namespace).Create(ctx, crt, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create Certificate %s/%s: %w", namespace, issuerName, err)
+		}
+		c.recorder.Eventf(iss, corev1.EventTypeNormal, reasonIntermediateCAProvisioned,
+			"Created the Certificate of the intermediate CA %s/%s", namespace, issuerName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !isControlledBy(existing, iss.UID) {
+		c.recorder.Eventf(iss, corev1.EventTypeWarning, reasonIntermediateCAConflict,
+			"Not provisioning an intermediate CA in namespace %s: Certificate %s already exists and is not controlled by this ClusterIssuer", namespace, issuerName)
+		return nil
+	}
+
+	crt := existing.DeepCopy()
+	setCertificateSpec(crt, iss, issuerName)
+	if apiequality.Semantic.DeepEqual(existing.Spec, crt.Spec) {
+		return nil
+	}
+	if _, err := c.client.CertmanagerV1().Certificates(namespace).Update(ctx, crt, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update Certificate %s/%s: %w", namespace, issuerName, err)
+	}
+	return nil
+}
+
+// syncIssuer creates or updates the CA Issuer of the intermediate CA in the
+// namespace.
+func (c *controller) syncIssuer(ctx context.Context, iss *cmapi.ClusterIssuer, namespace, issuerName string) error {
+	existing, err := c.issuerLister.Issuers(namespace).Get(issuerName)
+	if apierrors.IsNotFound(err) {
+		issuer := &cmapi.Issuer{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       namespace,
+				Name:            issuerName,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(iss, clusterIssuerGVK)},
+			},
+		}
+		setIssuerSpec(issuer, iss, issuerName)
+		if _, err := c.client.CertmanagerV1().Issuers(namespace).Create(ctx, issuer, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create Issuer %s/%s: %w", namespace, issuerName, err)
+		}
+		c.recorder.Eventf(iss, corev1.EventTypeNormal, reasonIntermediateCAProvisioned,
+			"Created the intermediate CA Issuer %s/%s", namespace, issuerName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !isControlledBy(existing, iss.UID) {
+		c.recorder.Eventf(iss, corev1.EventTypeWarning, reasonIntermediateCAConflict,
+			"Not provisioning an intermediate CA in namespace %s: Issuer %s already exists and is not controlled by this ClusterIssuer", namespace, issuerName)
+		return nil
+	}
+
+	issuer := existing.DeepCopy()
+	setIssuerSpec(issuer, iss, issuerName)
+	if apiequality.Semantic.DeepEqual(existing.Spec, issuer.Spec) {
+		return nil
+	}
+	if _, err := c.client.CertmanagerV1().Issuers(namespace).Update(ctx, issuer, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update Issuer %s/%s: %w", namespace, issuerName, err)
+	}
+	return nil
+}
+
+// setCertificateSpec sets the fields of the spec of crt which are managed by
+// the ClusterIssuer. Other fields, such as the private key, may be changed by
+// users and are left as they are.
+func setCertificateSpec(crt *cmapi.Certificate, iss *cmapi.ClusterIssuer, issuerName string) {
+	ica := iss.Spec.IntermediateCA
+	crt.Spec.CommonName = crt.Namespace
+	crt.Spec.IsCA = true
+	crt.Spec.Usages = []cmapi.KeyUsage{cmapi.UsageCertSign, cmapi.UsageCRLSign, cmapi.UsageDigitalSignature}
+	crt.Spec.Duration = ica.Duration.DeepCopy()
+	crt.Spec.RenewBefore = ica.RenewBefore.DeepCopy()
+	crt.Spec.SecretName = intermediateSecretName(issuerName)
+	crt.Spec.IssuerRef = cmmeta.ObjectReference{
+		Name:  iss.Name,
+		Kind:  cmapi.ClusterIssuerKind,
+		Group: cmapi.SchemeGroupVersion.Group,
+	}
+	crt.Spec.ControllerName = iss.Spec.ControllerName
+}
+
+// setIssuerSpec sets the fields of the spec of issuer which are managed by
+// the ClusterIssuer. The other fields of an existing CA issuer are left as
+// they are.
+func setIssuerSpec(issuer *cmapi.Issuer, iss *cmapi.ClusterIssuer, issuerName string) {
+	if issuer.Spec.CA == nil {
+		issuer.Spec.IssuerConfig = cmapi.IssuerConfig{CA: &cmapi.CAIssuer{}}
+	}
+	issuer.Spec.CA.SecretName = intermediateSecretName(issuerName)
+	issuer.Spec.ControllerName = iss.Spec.ControllerName
+}
+
+// intermediateIssuerName returns the name of the Issuers and Certificates
+// provisioned by the ClusterIssuer.
+func intermediateIssuerName(iss *cmapi.ClusterIssuer) string {
+	if name := iss.Spec.IntermediateCA.IssuerName; name != "" {
+		return name
+	}
+	return iss.Name
+}
+
+// intermediateSecretName returns the name of the Secrets holding the
+// intermediate CAs provisioned for the given issuer name.
+func intermediateSecretName(issuerName string) string {
+	return issuerName + "-ca"
+}
+
+// clusterIssuerControllerRef returns the controller reference of obj if it
+// is controlled by a ClusterIssuer.
+func clusterIssuerControllerRef(obj metav1.Object) *metav1.OwnerReference {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil || ref.Kind != clusterIssuerGVK.Kind || ref.APIVersion != clusterIssuerGVK.GroupVersion().String() {
+		return nil
+	}
+	return ref
+}
+
+// isControlledBy returns true if obj is controlled by the ClusterIssuer with
+// the given UID.
+func isControlledBy(obj metav1.Object, uid types.UID) bool {
+	ref := clusterIssuerControllerRef(obj)
+	return ref != nil && ref.UID == uid
+}
+
+// preconditions returns the options used to delete a resource, which guard
+// against deleting a resource which was recreated since it was observed.
+func preconditions(uid types.UID) metav1.DeleteOptions {
+	return metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	// Intermediate CAs are provisioned across namespaces, so they can't be
+	// provisioned if only a single namespace is watched.
+	if ctx.Namespace != "" {
+		return nil, nil, fmt.Errorf("the %s controller requires cert-manager to watch all namespaces", ControllerName)
+	}
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+	)
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package intermediateca
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+
+	iss := gen.ClusterIssuer("tenants",
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "root-ca"}),
+		gen.SetIssuerIntermediateCA(cmapi.IssuerIntermediateCA{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+			IssuerName:        "tenant-ca",
+			Duration:          &metav1.Duration{Duration: 30 * 24 * time.Hour},
+		}),
+	)
+	iss.UID = types.UID("tenants-uid")
+	controllerRef := *metav1.NewControllerRef(iss, clusterIssuerGVK)
+
+	namespace := func(name string, tenant bool) runtime.Object {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if tenant {
+			ns.Labels = map[string]string{"tenant": "true"}
+		}
+		return ns
+	}
+	certificate := func(namespace string, mods ...gen.CertificateModifier) *cmapi.Certificate {
+		return gen.Certificate("tenant-ca", append([]gen.CertificateModifier{
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateCommonName(namespace),
+			gen.SetCertificateIsCA(true),
+			gen.SetCertificateKeyUsages(cmapi.UsageCertSign, cmapi.UsageCRLSign, cmapi.UsageDigitalSignature),
+			gen.SetCertificateDuration(30 * 24 * time.Hour),
+			gen.SetCertificateSecretName("tenant-ca-ca"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "tenants", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"}),
+			func(crt *cmapi.Certificate) {
+				crt.Spec.PrivateKey = nil
+				crt.OwnerReferences = []metav1.OwnerReference{controllerRef}
+			},
+		}, mods...)...)
+	}
+	issuer := func(namespace string, mods ...gen.IssuerModifier) *cmapi.Issuer {
+		return gen.Issuer("tenant-ca", append([]gen.IssuerModifier{
+			gen.SetIssuerNamespace(namespace),
+			gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "tenant-ca-ca"}),
+			func(iss cmapi.GenericIssuer) {
+				iss.GetObjectMeta().OwnerReferences = []metav1.OwnerReference{controllerRef}
+			},
+		}, mods...)...)
+	}
+	uncontrolled := func(crt *cmapi.Certificate) { crt.OwnerReferences = nil }
+	withUID := func(crt *cmapi.Certificate) { crt.UID = "crt-uid" }
+	withIssuerUID := func(iss cmapi.GenericIssuer) { iss.GetObjectMeta().UID = "issuer-uid" }
+	uid := func(s types.UID) *types.UID { return &s }
+
+	certificates := cmapi.SchemeGroupVersion.WithResource("certificates")
+	issuers := cmapi.SchemeGroupVersion.WithResource("issuers")
+
+	tests := map[string]struct {
+		iss        *cmapi.ClusterIssuer
+		kubeObjs   []runtime.Object
+		cmObjs     []runtime.Object
+		expActions []testpkg.Action
+		expEvents  []string
+	}{
+		"intermediate CAs are provisioned in the selected namespaces": {
+			iss:      iss,
+			kubeObjs: []runtime.Object{namespace("team-a", true), namespace("team-b", false)},
+			expActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(certificates, "team-a", certificate("team-a"))),
+				testpkg.NewAction(coretesting.NewCreateAction(issuers, "team-a", issuer("team-a"))),
+			},
+			expEvents: []string{
+				"Normal IntermediateCAProvisioned Created the Certificate of the intermediate CA team-a/tenant-ca",
+				"Normal IntermediateCAProvisioned Created the intermediate CA Issuer team-a/tenant-ca",
+			},
+		},
+		"nothing is done if the intermediate CA is up to date": {
+			iss:      iss,
+			kubeObjs: []runtime.Object{namespace("team-a", true)},
+			cmObjs:   []runtime.Object{certificate("team-a"), issuer("team-a")},
+		},
+		"the managed fields of an intermediate CA are restored": {
+			iss:      iss,
+			kubeObjs: []runtime.Object{namespace("team-a", true)},
+			cmObjs: []runtime.Object{
+				certificate("team-a", gen.SetCertificateDuration(time.Hour)),
+				issuer("team-a"),
+			},
+			expActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, "team-a", certificate("team-a"))),
+			},
+		},
+		"intermediate CAs in namespaces which are no longer selected are removed": {
+			iss:      iss,
+			kubeObjs: []runtime.Object{namespace("team-a", false)},
+			cmObjs: []runtime.Object{
+				certificate("team-a", withUID),
+				issuer("team-a", withIssuerUID),
+			},
+			expActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteActionWithOptions(certificates, "team-a", "tenant-ca",
+					metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: uid("crt-uid")}})),
+				testpkg.NewAction(coretesting.NewDeleteActionWithOptions(issuers, "team-a", "tenant-ca",
+					metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: uid("issuer-uid")}})),
+			},
+			expEvents: []string{
+				"Normal IntermediateCARemoved Removed the intermediate CA Issuer team-a/tenant-ca",
+			},
+		},
+		"intermediate CAs are removed when provisioning is disabled": {
+			iss:      gen.ClusterIssuerFrom(iss.DeepCopy(), func(iss cmapi.GenericIssuer) { iss.GetSpec().IntermediateCA = nil }),
+			kubeObjs: []runtime.Object{namespace("team-a", true)},
+			cmObjs: []runtime.Object{
+				certificate("team-a", withUID),
+				issuer("team-a", withIssuerUID),
+			},
+			expActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteActionWithOptions(certificates, "team-a", "tenant-ca",
+					metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: uid("crt-uid")}})),
+				testpkg.NewAction(coretesting.NewDeleteActionWithOptions(issuers, "team-a", "tenant-ca",
+					metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: uid("issuer-uid")}})),
+			},
+			expEvents: []string{
+				"Normal IntermediateCARemoved Removed the intermediate CA Issuer team-a/tenant-ca",
+			},
+		},
+		"existing resources which are not controlled by the ClusterIssuer are left alone": {
+			iss:      iss,
+			kubeObjs: []runtime.Object{namespace("team-a", true)},
+			cmObjs:   []runtime.Object{certificate("team-a", uncontrolled), issuer("team-a")},
+			expEvents: []string{
+				"Warning IntermediateCAConflict Not provisioning an intermediate CA in namespace team-a: Certificate tenant-ca already exists and is not controlled by this ClusterIssuer",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				KubeObjects:        test.kubeObjs,
+				CertManagerObjects: append([]runtime.Object{test.iss}, test.cmObjs...),
+				ExpectedActions:    test.expActions,
+				ExpectedEvents:     test.expEvents,
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), test.iss.Name); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
@@ -72,7 +72,11 @@ func (c *controller) updateIssuerStatus(ctx context.Context, old, new *cmapi.Clu
 		return nil
 	}
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		return internalissuers.ApplyClusterIssuerStatus(ctx, c.cmClient, c.fieldManager, new)
+		// The usage is owned by the clusterissuers-usage controller, so it
+		// must not be part of the status applied by this controller.
+		apply := new.DeepCopy()
+		apply.Status.Usage = nil
+		return internalissuers.ApplyClusterIssuerStatus(ctx, c.cmClient, c.fieldManager, apply)
 	} else {
 		_, err := c.cmClient.CertmanagerV1().ClusterIssuers().UpdateStatus(ctx, new, metav1.UpdateOptions{})
 		return err
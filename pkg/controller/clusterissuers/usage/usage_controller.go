@@ -0,0 +1,368 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+const (
+	// ControllerName is the name of the ClusterIssuer usage controller.
+	ControllerName = "clusterissuers-usage"
+
+	// recentWindow is the period covered by the recent issuance and failure
+	// counts of the usage.
+	recentWindow = 24 * time.Hour
+
+	// resyncPeriod is the interval at which the usage of each ClusterIssuer
+	// is recomputed, so that CertificateRequests age out of the recent
+	// counts, and Certificates which moved to another issuer are no longer
+	// counted against the old one.
+	resyncPeriod = 10 * time.Minute
+)
+
+// rateLimitedMessages are found in the failure messages of CertificateRequests
+// which were rejected because the issuer rate limited them.
+var rateLimitedMessages = []string{
+	// the problem type returned by ACME servers, RFC 8555 section 6.7
+	"urn:ietf:params:acme:error:rateLimited",
+	// the HTTP status returned by most other CAs
+	"429 Too Many Requests",
+}
+
+// controller maintains the usage in the status of ClusterIssuers, which
+// summarises the Certificates and CertificateRequests that reference each
+// ClusterIssuer.
+type controller struct {
+	clusterIssuerLister       cmlisters.ClusterIssuerLister
+	certificateIndexer        cache.Indexer
+	certificateRequestIndexer cache.Indexer
+	queue                     workqueue.RateLimitingInterface
+	clock                     clock.Clock
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Apply API calls.
+	fieldManager string
+
+	// statusWriter is used to write the status of ClusterIssuers. It uses
+	// client unless replaced with the StatusWriter of the controller Context.
+	statusWriter *statuswriter.Writer
+
+	// controllerClass is the class of this installation of cert-manager.
+	// ClusterIssuers with a different spec.controllerName are ignored.
+	controllerClass string
+}
+
+// NewController returns a new ClusterIssuer usage controller.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	cmFactory cminformers.SharedInformerFactory,
+	clock clock.Clock,
+	fieldManager string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
+
+	if err := controllerpkg.EnsureIndexers(certificateInformer.Informer(), cache.Indexers{
+		controllerpkg.IssuerRefIndex: controllerpkg.CertificateIndexers[controllerpkg.IssuerRefIndex],
+	}); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := controllerpkg.EnsureIndexers(certificateRequestInformer.Informer(), controllerpkg.CertificateRequestIndexers); err != nil {
+		return nil, nil, nil, err
+	}
+
+	ctrl := &controller{
+		clusterIssuerLister:       clusterIssuerInformer.Lister(),
+		certificateIndexer:        certificateInformer.Informer().GetIndexer(),
+		certificateRequestIndexer: certificateRequestInformer.Informer().GetIndexer(),
+		queue:                     queue,
+		clock:                     clock,
+		fieldManager:              fieldManager,
+		statusWriter:              statuswriter.New(client, nil),
+	}
+
+	clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Certificate or CertificateRequest changes, enqueue the
+	// ClusterIssuer that it references.
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: ctrl.enqueueClusterIssuer})
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: ctrl.enqueueClusterIssuer})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		clusterIssuerInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
+	}
+
+	return ctrl, queue, mustSync, nil
+}
+
+// enqueueClusterIssuer enqueues the ClusterIssuer referenced by the given
+// Certificate or CertificateRequest, if any.
+func (c *controller) enqueueClusterIssuer(obj interface{}) {
+	var ref cmmeta.ObjectReference
+	switch o := obj.(type) {
+	case *cmapi.Certificate:
+		ref = o.Spec.IssuerRef
+	case *cmapi.CertificateRequest:
+		ref = o.Spec.IssuerRef
+	default:
+		return
+	}
+
+	if isClusterIssuerRef(ref) {
+		c.queue.Add(ref.Name)
+	}
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a ClusterIssuer to be re-synced is pulled from the
+// workqueue. ProcessItem recomputes the usage of the ClusterIssuer, updates
+// its status if the usage changed, and requeues the ClusterIssuer to be
+// recomputed after the resync period.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	iss, err := c.clusterIssuerLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("clusterissuer not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.ManagesControllerName(c.controllerClass, iss.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("clusterissuer is managed by a different controller class, skipping")
+		return nil
+	}
+
+	usage, err := c.usageFor(iss)
+	if err != nil {
+		return err
+	}
+
+	if !apiequality.Semantic.DeepEqual(iss.Status.Usage, usage) {
+		log.V(logf.DebugLevel).Info("updating clusterissuer usage", "certificates", usage.Certificates, "recentFailures", usage.RecentFailures)
+		if err := c.updateOrApplyStatus(ctx, iss, usage); err != nil {
+			return err
+		}
+	}
+
+	c.queue.AddAfter(key, resyncPeriod)
+	return nil
+}
+
+// usageFor computes the usage of the given ClusterIssuer from the
+// Certificates and CertificateRequests which reference it.
+func (c *controller) usageFor(iss *cmapi.ClusterIssuer) (*cmapi.IssuerUsageStatus, error) {
+	indexKey := controllerpkg.IssuerRefIndexKey(iss)
+	crts, err := c.certificateIndexer.ByIndex(controllerpkg.IssuerRefIndex, indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("error listing certificates: %w", err)
+	}
+	crs, err := c.certificateRequestIndexer.ByIndex(controllerpkg.IssuerRefIndex, indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("error listing certificate requests: %w", err)
+	}
+
+	usage := &cmapi.IssuerUsageStatus{}
+	namespaces := make(map[string]*cmapi.IssuerNamespaceUsage)
+	namespaceUsage := func(namespace string) *cmapi.IssuerNamespaceUsage {
+		if _, ok := namespaces[namespace]; !ok {
+			namespaces[namespace] = &cmapi.IssuerNamespaceUsage{Namespace: namespace}
+		}
+		return namespaces[namespace]
+	}
+
+	for _, obj := range crts {
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok || !isClusterIssuerRef(crt.Spec.IssuerRef) {
+			continue
+		}
+
+		nsUsage := namespaceUsage(crt.Namespace)
+		usage.Certificates++
+		nsUsage.Certificates++
+		if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}) {
+			usage.ReadyCertificates++
+			nsUsage.ReadyCertificates++
+		}
+	}
+
+	now := c.clock.Now()
+	isRecent := func(t *metav1.Time) bool {
+		return t != nil && now.Sub(t.Time) <= recentWindow
+	}
+
+	for _, obj := range crs {
+		cr, ok := obj.(*cmapi.CertificateRequest)
+		if !ok || !isClusterIssuerRef(cr.Spec.IssuerRef) {
+			continue
+		}
+
+		ready := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady)
+		if ready == nil {
+			continue
+		}
+
+		switch {
+		case ready.Status == cmmeta.ConditionTrue && isRecent(ready.LastTransitionTime):
+			usage.RecentIssuances++
+
+		case ready.Reason == cmapi.CertificateRequestReasonFailed && isRecent(cr.Status.FailureTime):
+			usage.RecentFailures++
+			namespaceUsage(cr.Namespace).RecentFailures++
+
+			if isRateLimited(ready.Message) && (usage.LastRateLimitedTime == nil || cr.Status.FailureTime.After(usage.LastRateLimitedTime.Time)) {
+				usage.LastRateLimitedTime = cr.Status.FailureTime.DeepCopy()
+			}
+		}
+	}
+
+	for _, nsUsage := range namespaces {
+		usage.Namespaces = append(usage.Namespaces, *nsUsage)
+	}
+	sort.Slice(usage.Namespaces, func(i, j int) bool {
+		return usage.Namespaces[i].Namespace < usage.Namespaces[j].Namespace
+	})
+
+	return usage, nil
+}
+
+// isClusterIssuerRef returns true if the given issuer reference refers to a
+// cert-manager ClusterIssuer, rather than an external issuer.
+func isClusterIssuerRef(ref cmmeta.ObjectReference) bool {
+	return ref.Kind == cmapi.ClusterIssuerKind && (ref.Group == "" || ref.Group == certmanager.GroupName)
+}
+
+// isRateLimited returns true if the given failure message indicates that the
+// issuer rate limited the request.
+func isRateLimited(message string) bool {
+	for _, m := range rateLimitedMessages {
+		if strings.Contains(message, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateOrApplyStatus will update the usage in the ClusterIssuer's status. If
+// the ServerSideApply feature is enabled, the usage will instead get applied
+// using the relevant Patch API call.
+func (c *controller) updateOrApplyStatus(ctx context.Context, iss *cmapi.ClusterIssuer, usage *cmapi.IssuerUsageStatus) error {
+	return c.statusWriter.Write(ctx, iss, func(ctx context.Context, cl cmclient.Interface) error {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+			return internalissuers.ApplyClusterIssuerStatus(ctx, cl, c.fieldManager, &cmapi.ClusterIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: iss.Name},
+				Status:     cmapi.IssuerStatus{Usage: usage},
+			})
+		} else {
+			updated := iss.DeepCopy()
+			updated.Status.Usage = usage
+			_, err := cl.CertmanagerV1().ClusterIssuers().UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+			return err
+		}
+	})
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	// ClusterIssuers are referenced from every namespace, so their usage
+	// can't be computed if only a single namespace is watched.
+	if ctx.Namespace != "" {
+		return nil, nil, fmt.Errorf("the %s controller requires cert-manager to watch all namespaces", ControllerName)
+	}
+
+	ctrl, queue, mustSync, err := NewController(log,
+		ctx.CMClient,
+		ctx.SharedInformerFactory,
+		ctx.Clock,
+		ctx.FieldManager,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ctx.StatusWriter != nil {
+		ctrl.statusWriter = ctx.StatusWriter
+	}
+	ctrl.controllerClass = ctx.ControllerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	recent := metav1.NewTime(fixedClock.Now().Add(-time.Hour))
+	old := metav1.NewTime(fixedClock.Now().Add(-48 * time.Hour))
+
+	issuerRef := cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.ClusterIssuerKind}
+	iss := gen.ClusterIssuer("test-issuer")
+
+	certificate := func(namespace, name string, ready cmmeta.ConditionStatus, ref cmmeta.ObjectReference) runtime.Object {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateIssuer(ref),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: ready}),
+		)
+	}
+	issued := func(namespace, name string, at metav1.Time) runtime.Object {
+		return gen.CertificateRequest(name,
+			gen.SetCertificateRequestNamespace(namespace),
+			gen.SetCertificateRequestIssuer(issuerRef),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             cmapi.CertificateRequestReasonIssued,
+				LastTransitionTime: &at,
+			}),
+		)
+	}
+	failed := func(namespace, name, reason, message string, at metav1.Time) runtime.Object {
+		return gen.CertificateRequest(name,
+			gen.SetCertificateRequestNamespace(namespace),
+			gen.SetCertificateRequestIssuer(issuerRef),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: &at,
+			}),
+			gen.SetCertificateRequestFailureTime(at),
+		)
+	}
+
+	tests := map[string]struct {
+		iss       *cmapi.ClusterIssuer
+		objects   []runtime.Object
+		expUsage  *cmapi.IssuerUsageStatus
+		expUpdate bool
+	}{
+		"set an empty usage if nothing references the issuer": {
+			iss: iss,
+			objects: []runtime.Object{
+				certificate("ns1", "other-issuer", cmmeta.ConditionTrue, cmmeta.ObjectReference{Name: "other-issuer", Kind: cmapi.ClusterIssuerKind}),
+				certificate("ns1", "namespaced-issuer", cmmeta.ConditionTrue, cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.IssuerKind}),
+				certificate("ns1", "external-issuer", cmmeta.ConditionTrue, cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.ClusterIssuerKind, Group: "example.com"}),
+			},
+			expUsage:  &cmapi.IssuerUsageStatus{},
+			expUpdate: true,
+		},
+		"count the Certificates and recent CertificateRequests per namespace": {
+			iss: iss,
+			objects: []runtime.Object{
+				certificate("ns1", "ready", cmmeta.ConditionTrue, issuerRef),
+				certificate("ns1", "not-ready", cmmeta.ConditionFalse, issuerRef),
+				certificate("ns2", "ready", cmmeta.ConditionTrue, issuerRef),
+				issued("ns1", "recent", recent),
+				issued("ns1", "old", old),
+				failed("ns2", "recent", cmapi.CertificateRequestReasonFailed, "Failed to wait for order resource to become ready", recent),
+				failed("ns2", "old", cmapi.CertificateRequestReasonFailed, "Failed to wait for order resource to become ready", old),
+				failed("ns3", "denied", cmapi.CertificateRequestReasonDenied, "Denied by policy", recent),
+			},
+			expUsage: &cmapi.IssuerUsageStatus{
+				Certificates:      3,
+				ReadyCertificates: 2,
+				RecentIssuances:   1,
+				RecentFailures:    1,
+				Namespaces: []cmapi.IssuerNamespaceUsage{
+					{Namespace: "ns1", Certificates: 2, ReadyCertificates: 1},
+					{Namespace: "ns2", Certificates: 1, ReadyCertificates: 1, RecentFailures: 1},
+				},
+			},
+			expUpdate: true,
+		},
+		"set the last rate limited time if a recent request was rate limited": {
+			iss: iss,
+			objects: []runtime.Object{
+				failed("ns1", "rate-limited", cmapi.CertificateRequestReasonFailed, "Failed to create Order: 429 urn:ietf:params:acme:error:rateLimited: Error creating new order :: too many certificates already issued", recent),
+				failed("ns1", "old-rate-limited", cmapi.CertificateRequestReasonFailed, "Failed to create Order: 429 urn:ietf:params:acme:error:rateLimited: Error creating new order :: too many certificates already issued", old),
+			},
+			expUsage: &cmapi.IssuerUsageStatus{
+				RecentFailures:      1,
+				LastRateLimitedTime: &recent,
+				Namespaces: []cmapi.IssuerNamespaceUsage{
+					{Namespace: "ns1", RecentFailures: 1},
+				},
+			},
+			expUpdate: true,
+		},
+		"do nothing if the usage has not changed": {
+			iss: gen.ClusterIssuerFrom(iss.DeepCopy(), gen.SetIssuerUsage(&cmapi.IssuerUsageStatus{
+				Certificates:      1,
+				ReadyCertificates: 1,
+				Namespaces: []cmapi.IssuerNamespaceUsage{
+					{Namespace: "ns1", Certificates: 1, ReadyCertificates: 1},
+				},
+			})),
+			objects: []runtime.Object{
+				certificate("ns1", "ready", cmmeta.ConditionTrue, issuerRef),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: append([]runtime.Object{test.iss}, test.objects...),
+			}
+			if test.expUpdate {
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("clusterissuers"),
+						"status",
+						"",
+						gen.ClusterIssuerFrom(test.iss.DeepCopy(), gen.SetIssuerUsage(test.expUsage)))))
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), test.iss.Name); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
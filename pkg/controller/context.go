@@ -41,8 +41,12 @@ import (
 	gwscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
 	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
+	"github.com/cert-manager/cert-manager/internal/controller/audit"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/statuswriter"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	"github.com/cert-manager/cert-manager/pkg/acme/ratelimit"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmscheme "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/scheme"
 	informers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
@@ -85,6 +89,10 @@ type Context struct {
 	GWClient gwclient.Interface
 	// DiscoveryClient is a discovery interface. Usually set to Client.Discovery unless a fake client is in use.
 	DiscoveryClient discovery.DiscoveryInterface
+	// StatusWriter should be used to write the status of cert-manager
+	// resources. Status writes are rate limited separately from other
+	// requests if StatusUpdateQPS is set.
+	StatusWriter *statuswriter.Writer
 
 	// Recorder to record events to
 	Recorder record.EventRecorder
@@ -120,10 +128,37 @@ type ContextOptions struct {
 	// KubernetesAPIBurst is the value of the Maximum burst for throttle.
 	KubernetesAPIBurst int
 
+	// ControllerKubernetesAPIRateLimits overrides the Kubernetes API rate
+	// limit of individual controllers, keyed by controller name. Controllers
+	// without an override share the KubernetesAPIQPS and KubernetesAPIBurst
+	// rate limit.
+	ControllerKubernetesAPIRateLimits map[string]RateLimit
+
+	// KubernetesAPIPriorityAndFairness disables the shared client-side rate
+	// limit if the API server has API Priority and Fairness enabled, leaving
+	// the API server to share capacity fairly between controllers. Rate limits
+	// set in ControllerKubernetesAPIRateLimits still apply.
+	KubernetesAPIPriorityAndFairness bool
+
+	// StatusUpdateQPS is the maximum QPS of status updates sent to the API
+	// server. If zero, status updates share the KubernetesAPIQPS rate limit
+	// with all other requests.
+	StatusUpdateQPS float32
+
+	// StatusUpdateBurst is the maximum burst of status updates sent to the API
+	// server. Only used if StatusUpdateQPS is set.
+	StatusUpdateBurst int
+
 	// Namespace is the namespace to operate within.
 	// If unset, operates on all namespaces
 	Namespace string
 
+	// ControllerClass is the class of this installation of cert-manager.
+	// Only Certificates and issuers whose spec.controllerName is equal to
+	// ControllerClass are managed, so that several installations can run in
+	// the same cluster.
+	ControllerClass string
+
 	// Clock should be used to access the current time instead of relying on
 	// time.Now, to make it easier to test controllers that utilise time
 	Clock clock.Clock
@@ -131,11 +166,25 @@ type ContextOptions struct {
 	// Metrics is used for exposing Prometheus metrics across the controllers
 	Metrics *metrics.Metrics
 
+	// AuditSink, if set, is used to record the issuance decisions made for
+	// CertificateRequests.
+	AuditSink audit.Sink
+
 	IssuerOptions
 	ACMEOptions
 	IngressShimOptions
 	CertificateOptions
 	SchedulerOptions
+	CertificateSigningRequestOptions
+}
+
+// RateLimit is a client-side rate limit of requests sent to the API server.
+type RateLimit struct {
+	// QPS is the maximum sustained queries per second.
+	QPS float32
+
+	// Burst is the maximum burst of queries.
+	Burst int
 }
 
 type IssuerOptions struct {
@@ -158,6 +207,15 @@ type ACMEOptions struct {
 	// challenges
 	HTTP01SolverImage string
 
+	// HTTP01SolverWindowsImage is the image to use for solving ACME HTTP01
+	// challenges with solver pods scheduled on Windows nodes
+	HTTP01SolverWindowsImage string
+
+	// HTTP01SolverImageArchitectures are the architectures provided by the
+	// HTTP01SolverImage manifest list. Linux solver pods which do not select
+	// an architecture are only scheduled on nodes of these architectures.
+	HTTP01SolverImageArchitectures []string
+
 	// HTTP01SolverResourceRequestCPU defines the ACME pod's resource request CPU size
 	HTTP01SolverResourceRequestCPU resource.Quantity
 
@@ -191,6 +249,19 @@ type ACMEOptions struct {
 
 	// DNS01CheckRetryPeriod is the time the controller should wait between checking if a ACME dns entry exists.
 	DNS01CheckRetryPeriod time.Duration
+
+	// HTTPRateLimiter, if set, limits the rate of requests sent to ACME
+	// servers by all ACME issuers.
+	HTTPRateLimiter flowcontrol.RateLimiter
+
+	// RateLimitTracker, if set, estimates the remaining budget of the rate
+	// limits of ACME servers for all ACME issuers.
+	RateLimitTracker *ratelimit.Tracker
+
+	// SelfCheckPreferredIPFamily is the IP family whose addresses are tried
+	// first when performing ACME challenge self-checks against endpoints
+	// that have both IPv4 and IPv6 addresses.
+	SelfCheckPreferredIPFamily corev1.IPFamily
 }
 
 // IngressShimOptions contain default Issuer GVK config for the certificate-shim controllers.
@@ -201,6 +272,12 @@ type IngressShimOptions struct {
 	DefaultIssuerKind                 string
 	DefaultIssuerGroup                string
 	DefaultAutoCertificateAnnotations []string
+
+	// DefaultIssuerZoneRoutes maps DNS zones to the issuer used instead of
+	// the default issuer for the hosts in the zone, or in one of its
+	// subdomains. It only applies to resources which do not reference an
+	// issuer with an annotation.
+	DefaultIssuerZoneRoutes map[string]cmmeta.ObjectReference
 }
 
 type CertificateOptions struct {
@@ -210,6 +287,38 @@ type CertificateOptions struct {
 	// CopiedAnnotationPrefixes defines which annotations should be copied
 	// Certificate -> CertificateRequest, CertificateRequest -> Order.
 	CopiedAnnotationPrefixes []string
+	// TransparencyLogURL is the URL of a Rekor compatible transparency log
+	// that issued certificates are published to. Certificates are only
+	// published if the transparency log controller is enabled.
+	TransparencyLogURL string
+	// SecretWatchdogNamespaces are the namespaces whose TLS Secrets are
+	// reported by the secret watchdog controller. The namespace `*` selects
+	// all namespaces.
+	SecretWatchdogNamespaces []string
+	// SecretWatchdogAdopt controls whether the secret watchdog controller
+	// creates Certificates for the unmanaged TLS Secrets which are annotated
+	// with the issuer to adopt them with.
+	SecretWatchdogAdopt bool
+	// DriftCheckInterval is the interval at which the drift controller
+	// checks that the endpoints serving the certificates of Certificates
+	// serve the current certificate.
+	DriftCheckInterval time.Duration
+	// RevocationCheckInterval is the interval at which the revocation check
+	// controller checks whether the certificates of Certificates have been
+	// revoked.
+	RevocationCheckInterval time.Duration
+	// CertificateRequestPendingTimeout is the time after which the stale
+	// CertificateRequest controller marks pending CertificateRequests as
+	// failed.
+	CertificateRequestPendingTimeout time.Duration
+	// SigningUsageNamespaces are the namespaces in which the approver
+	// controller approves CertificateRequests with the code signing or
+	// document signing usages. If empty, these usages are not restricted.
+	SigningUsageNamespaces []string
+	// RestoreMode controls whether the certificates in Secrets which were
+	// restored from a backup without their cert-manager annotations are
+	// adopted by their Certificates instead of being re-issued.
+	RestoreMode bool
 }
 
 type SchedulerOptions struct {
@@ -218,6 +327,14 @@ type SchedulerOptions struct {
 	MaxConcurrentChallenges int
 }
 
+type CertificateSigningRequestOptions struct {
+	// KubeletServingIssuer is the signer name of the CA Issuer or
+	// ClusterIssuer used to sign CertificateSigningRequests for the
+	// `kubernetes.io/kubelet-serving` signer, e.g.
+	// `clusterissuers.cert-manager.io/kubelet-ca`.
+	KubeletServingIssuer string
+}
+
 // ContextFactory is used for constructing new Contexts who's clients have been
 // configured with a User Agent built from the component name.
 type ContextFactory struct {
@@ -225,6 +342,15 @@ type ContextFactory struct {
 	// the Kubernetes API server.
 	baseRestConfig *rest.Config
 
+	// controllerRateLimiters are the rate limiters of the controllers which
+	// don't share the rate limiter of baseRestConfig, keyed by controller name.
+	controllerRateLimiters map[string]flowcontrol.RateLimiter
+
+	// statusRateLimiter is the rate limiter shared by the StatusWriters of all
+	// Contexts. If nil, status writes are only limited by the client rate
+	// limiter.
+	statusRateLimiter flowcontrol.RateLimiter
+
 	// log is the factory logger which is used to construct event broadcasters.
 	log logr.Logger
 
@@ -246,6 +372,18 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 	restConfig.QPS = opts.KubernetesAPIQPS
 	restConfig.Burst = opts.KubernetesAPIBurst
 
+	if opts.KubernetesAPIPriorityAndFairness {
+		enabled, err := priorityAndFairnessEnabled(restConfig)
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			logf.FromContext(ctx).V(logf.InfoLevel).Info("API Priority and Fairness is enabled on the API server, disabling shared client-side rate limiting")
+			// A negative QPS disables client-side rate limiting.
+			restConfig.QPS = -1
+		}
+	}
+
 	// Construct a single RateLimiter used across all built Context's clients. A
 	// single rate limiter (with corresponding QPS and Burst buckets) are
 	// preserved for all Contexts.
@@ -258,6 +396,25 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 		restConfig.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(restConfig.QPS, restConfig.Burst)
 	}
 
+	controllerRateLimiters := make(map[string]flowcontrol.RateLimiter, len(opts.ControllerKubernetesAPIRateLimits))
+	for name, limit := range opts.ControllerKubernetesAPIRateLimits {
+		if limit.QPS <= 0 || limit.Burst <= 0 {
+			return nil, fmt.Errorf("QPS and burst of controller %q are required to be greater than 0", name)
+		}
+		controllerRateLimiters[name] = flowcontrol.NewTokenBucketRateLimiter(limit.QPS, limit.Burst)
+	}
+
+	// Status updates get a separate rate limiter if configured, so that a mass
+	// event like a CA rotation doesn't starve other requests to the API
+	// server.
+	var statusRateLimiter flowcontrol.RateLimiter
+	if opts.StatusUpdateQPS > 0 {
+		if opts.StatusUpdateBurst <= 0 {
+			return nil, errors.New("status update burst is required to be greater than 0 when status update QPS is set to greater than 0")
+		}
+		statusRateLimiter = flowcontrol.NewTokenBucketRateLimiter(opts.StatusUpdateQPS, opts.StatusUpdateBurst)
+	}
+
 	clients, err := buildClients(restConfig)
 	if err != nil {
 		return nil, err
@@ -268,8 +425,10 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 	gwSharedInformerFactory := gwinformers.NewSharedInformerFactoryWithOptions(clients.gwClient, resyncPeriod, gwinformers.WithNamespace(opts.Namespace))
 
 	return &ContextFactory{
-		baseRestConfig: restConfig,
-		log:            logf.FromContext(ctx),
+		baseRestConfig:         restConfig,
+		controllerRateLimiters: controllerRateLimiters,
+		statusRateLimiter:      statusRateLimiter,
+		log:                    logf.FromContext(ctx),
 		ctx: &Context{
 			RootContext:               ctx,
 			StopCh:                    ctx.Done(),
@@ -283,9 +442,16 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 }
 
 // Build builds a new controller Context who's clients have a User Agent
-// derived from the optional component name.
+// derived from the optional component name. If a rate limit has been
+// configured for the component, its clients use that rate limit instead of
+// the shared one.
 func (c *ContextFactory) Build(component ...string) (*Context, error) {
 	restConfig := util.RestConfigWithUserAgent(c.baseRestConfig, component...)
+	if len(component) > 0 {
+		if limiter, ok := c.controllerRateLimiters[component[0]]; ok {
+			restConfig.RateLimiter = limiter
+		}
+	}
 
 	clients, err := buildClients(restConfig)
 	if err != nil {
@@ -312,6 +478,19 @@ func (c *ContextFactory) Build(component ...string) (*Context, error) {
 	ctx.DiscoveryClient = clients.kubeClient.Discovery()
 	ctx.Recorder = recorder
 
+	statusClient := clients.cmClient
+	if c.statusRateLimiter != nil {
+		// The StatusWriter applies the status rate limiter before each
+		// request, so its client must not also wait on the shared rate limiter.
+		statusRestConfig := rest.CopyConfig(restConfig)
+		statusRestConfig.RateLimiter = flowcontrol.NewFakeAlwaysRateLimiter()
+		statusClient, err = clientset.NewForConfig(statusRestConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error creating status client: %w", err)
+		}
+	}
+	ctx.StatusWriter = statuswriter.New(statusClient, c.statusRateLimiter)
+
 	return &ctx, nil
 }
 
@@ -367,3 +546,23 @@ func buildClients(restConfig *rest.Config) (contextClients, error) {
 
 	return contextClients{kubeClient, cmClient, gwClient, gatewayAvailable}, nil
 }
+
+// priorityAndFairnessEnabled returns true if the API server serves the API
+// Priority and Fairness API group, which means that it applies API Priority
+// and Fairness to incoming requests.
+func priorityAndFairnessEnabled(restConfig *rest.Config) (bool, error) {
+	cl, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return false, fmt.Errorf("error creating discovery client: %w", err)
+	}
+	groups, err := cl.ServerGroups()
+	if err != nil {
+		return false, fmt.Errorf("error discovering API groups: %w", err)
+	}
+	for _, group := range groups.Groups {
+		if group.Name == "flowcontrol.apiserver.k8s.io" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
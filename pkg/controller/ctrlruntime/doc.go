@@ -0,0 +1,23 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ctrlruntime contains adapters which allow the existing informer
+// event handlers and predicates, such as those in pkg/controller/certificates
+// and pkg/util/predicate, and the sync functions of existing controllers to
+// be used with the controller-runtime builder without changing their source.
+// The controllers in this repository are built with controllerpkg.Builder and
+// do not use these adapters.
+package ctrlruntime
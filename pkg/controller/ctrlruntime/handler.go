@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrlruntime
+
+import (
+	"reflect"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	cmpredicate "github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+// IgnoreUnchanged filters out update events for objects which have not
+// changed, such as those caused by an informer resync. This matches the
+// behaviour of controllerpkg.QueuingEventHandler.
+var IgnoreUnchanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !reflect.DeepEqual(e.ObjectOld, e.ObjectNew)
+	},
+}
+
+// Predicate returns a controller-runtime predicate which only accepts events
+// for objects matching all of the given predicate functions, as evaluated by
+// predicate.Funcs.Evaluate. For update events, the new object is evaluated.
+func Predicate(fns ...cmpredicate.Func) predicate.Predicate {
+	evaluate := func(obj client.Object) bool {
+		return cmpredicate.Funcs(fns).Evaluate(obj)
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return evaluate(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return evaluate(e.ObjectNew) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return evaluate(e.Object) },
+		GenericFunc: func(e event.GenericEvent) bool { return evaluate(e.Object) },
+	}
+}
+
+// EnqueueFunc returns an event handler which calls workFunc with the object
+// of each event, in the same way as controllerpkg.BlockingEventHandler.
+// This allows the existing handler functions, which add keys to a workqueue,
+// to be used with the controller-runtime builder. workFunc must add keys to
+// queue, which is bound to the workqueue of the controller that the handler
+// is registered with.
+func EnqueueFunc(queue *Queue, workFunc func(obj interface{})) handler.EventHandler {
+	return &enqueueFunc{queue: queue, workFunc: workFunc}
+}
+
+type enqueueFunc struct {
+	queue    *Queue
+	workFunc func(obj interface{})
+}
+
+func (e *enqueueFunc) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	e.queue.bind(q)
+	e.workFunc(evt.Object)
+}
+
+func (e *enqueueFunc) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if reflect.DeepEqual(evt.ObjectOld, evt.ObjectNew) {
+		return
+	}
+	e.queue.bind(q)
+	e.workFunc(evt.ObjectNew)
+}
+
+func (e *enqueueFunc) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	e.queue.bind(q)
+	e.workFunc(evt.Object)
+}
+
+func (e *enqueueFunc) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	e.queue.bind(q)
+	e.workFunc(evt.Object)
+}
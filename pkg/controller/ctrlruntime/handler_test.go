@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrlruntime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestPredicate(t *testing.T) {
+	p := Predicate(predicate.CertificateSecretName("matching"))
+	matching := gen.Certificate("a", gen.SetCertificateSecretName("matching"))
+	other := gen.Certificate("b", gen.SetCertificateSecretName("other"))
+
+	assert.True(t, p.Create(event.CreateEvent{Object: matching}))
+	assert.False(t, p.Create(event.CreateEvent{Object: other}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: other, ObjectNew: matching}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: matching, ObjectNew: other}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: matching}))
+	assert.False(t, p.Generic(event.GenericEvent{Object: other}))
+}
+
+func TestEnqueueFunc(t *testing.T) {
+	q := NewQueue()
+	h := EnqueueFunc(q, func(obj interface{}) {
+		q.Add(obj.(*cmapi.Certificate).Namespace + "/" + obj.(*cmapi.Certificate).Name)
+	})
+
+	controllerQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer controllerQueue.ShutDown()
+
+	crt := gen.Certificate("name", gen.SetCertificateNamespace("ns"))
+	// Updates which do not change the object are ignored.
+	h.Update(event.UpdateEvent{ObjectOld: crt, ObjectNew: crt.DeepCopy()}, controllerQueue)
+	assert.Equal(t, 0, controllerQueue.Len())
+
+	h.Create(event.CreateEvent{Object: crt}, controllerQueue)
+	item, _ := controllerQueue.Get()
+	assert.Equal(t, RequestFor("ns/name"), item)
+	controllerQueue.Done(item)
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrlruntime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Queue is a workqueue of string keys, as used by controllers built with
+// controllerpkg.Builder, which is backed by the workqueue of a
+// controller-runtime controller. Keys are translated to and from
+// reconcile.Requests using RequestFor and KeyFor.
+// A Queue is bound to the workqueue of a controller by watching the source
+// returned by Source. Items added before the Queue is bound, e.g. by event
+// handlers of informers which are started before the controller, are
+// buffered and added to the workqueue of the controller once it is bound.
+type Queue struct {
+	lock  sync.Mutex
+	queue workqueue.RateLimitingInterface
+
+	// pending holds the items added before the Queue is bound.
+	pending []pendingItem
+
+	// delayed holds items added with AddAfter until they are due.
+	delayed workqueue.DelayingInterface
+}
+
+// pendingItem is an item added to a Queue before it is bound.
+type pendingItem struct {
+	item        interface{}
+	rateLimited bool
+}
+
+var _ workqueue.RateLimitingInterface = &Queue{}
+
+// NewQueue returns a Queue which is not yet bound to a controller.
+func NewQueue() *Queue {
+	return NewQueueWithClock(clock.RealClock{})
+}
+
+// NewQueueWithClock returns a Queue which is not yet bound to a controller,
+// and which uses the given clock to delay items added with AddAfter. This
+// allows tests using a fake clock to control when rechecks are processed.
+// If clk doesn't support tickers, the real clock is used.
+func NewQueueWithClock(clk clock.Clock) *Queue {
+	withTicker, ok := clk.(clock.WithTicker)
+	if !ok {
+		withTicker = clock.RealClock{}
+	}
+	return &Queue{delayed: workqueue.NewDelayingQueueWithCustomClock(withTicker, "")}
+}
+
+// RequestFor returns the reconcile.Request for a queue key. Keys which are
+// not of the form <namespace>/<name> or <name> are returned as the name of
+// the request, so that they can be recovered using KeyFor.
+func RequestFor(key string) reconcile.Request {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Name: key}}
+	}
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+}
+
+// KeyFor returns the queue key of a reconcile.Request.
+func KeyFor(req reconcile.Request) string {
+	if len(req.Namespace) == 0 {
+		return req.Name
+	}
+	return req.Namespace + "/" + req.Name
+}
+
+// bind binds the Queue to the workqueue of a controller, and adds the items
+// buffered before it was bound.
+func (q *Queue) bind(queue workqueue.RateLimitingInterface) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.queue != nil {
+		return
+	}
+	q.queue = queue
+	for _, p := range q.pending {
+		if p.rateLimited {
+			queue.AddRateLimited(toRequest(p.item))
+		} else {
+			queue.Add(toRequest(p.item))
+		}
+	}
+	q.pending = nil
+}
+
+func (q *Queue) bound() (workqueue.RateLimitingInterface, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.queue, q.queue != nil
+}
+
+// add adds item to the workqueue of the controller, or buffers it until the
+// Queue is bound.
+func (q *Queue) add(item interface{}, rateLimited bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	switch {
+	case q.queue == nil:
+		q.pending = append(q.pending, pendingItem{item: item, rateLimited: rateLimited})
+	case rateLimited:
+		q.queue.AddRateLimited(toRequest(item))
+	default:
+		q.queue.Add(toRequest(item))
+	}
+}
+
+// toRequest translates string keys to reconcile.Requests. Other items are
+// returned as is.
+func toRequest(item interface{}) interface{} {
+	if key, ok := item.(string); ok {
+		return RequestFor(key)
+	}
+	return item
+}
+
+// Add adds the request for key to the workqueue of the controller.
+func (q *Queue) Add(item interface{}) {
+	q.add(item, false)
+}
+
+// AddAfter adds the request for key to the workqueue of the controller once
+// duration has passed. Items which are due before the Queue is bound are
+// added once it is bound.
+func (q *Queue) AddAfter(item interface{}, duration time.Duration) {
+	q.delayed.AddAfter(item, duration)
+}
+
+// AddRateLimited adds the request for key to the workqueue of the controller
+// once the rate limiter of the controller allows it.
+func (q *Queue) AddRateLimited(item interface{}) {
+	q.add(item, true)
+}
+
+// Forget resets the rate limiter of the controller for key.
+func (q *Queue) Forget(item interface{}) {
+	if queue, ok := q.bound(); ok {
+		queue.Forget(toRequest(item))
+	}
+}
+
+// NumRequeues returns the number of times key has been requeued.
+func (q *Queue) NumRequeues(item interface{}) int {
+	if queue, ok := q.bound(); ok {
+		return queue.NumRequeues(toRequest(item))
+	}
+	return 0
+}
+
+// Len returns the length of the workqueue of the controller, or the number
+// of buffered items if the Queue has not been bound.
+func (q *Queue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.queue == nil {
+		return len(q.pending)
+	}
+	return q.queue.Len()
+}
+
+// Get returns the key of the next request in the workqueue of the
+// controller. Items are normally taken from the workqueue by the controller
+// itself, so this should not be used.
+func (q *Queue) Get() (interface{}, bool) {
+	queue, ok := q.bound()
+	if !ok {
+		return nil, true
+	}
+	item, shutdown := queue.Get()
+	if req, ok := item.(reconcile.Request); ok {
+		return KeyFor(req), shutdown
+	}
+	return item, shutdown
+}
+
+// Done marks the request for key as processed.
+func (q *Queue) Done(item interface{}) {
+	if queue, ok := q.bound(); ok {
+		queue.Done(toRequest(item))
+	}
+}
+
+// ShutDown is a no-op. The workqueue is shut down by the controller that
+// owns it.
+func (q *Queue) ShutDown() {}
+
+// ShutDownWithDrain is a no-op. The workqueue is shut down by the controller
+// that owns it.
+func (q *Queue) ShutDownWithDrain() {}
+
+// ShuttingDown returns true if the workqueue of the controller is shutting
+// down.
+func (q *Queue) ShuttingDown() bool {
+	if queue, ok := q.bound(); ok {
+		return queue.ShuttingDown()
+	}
+	return false
+}
+
+// Source returns a source which binds the Queue to the workqueue of the
+// controller that watches it, and which delays the start of the controller
+// until the given informers have synced. These are the informers whose
+// listers are read by the controller but which are not watched.
+// The source produces no events, so it can be watched using any handler.
+func (q *Queue) Source(mustSync ...cache.InformerSynced) source.SyncingSource {
+	return &queueSource{queue: q, mustSync: mustSync}
+}
+
+type queueSource struct {
+	queue    *Queue
+	mustSync []cache.InformerSynced
+}
+
+func (s *queueSource) Start(ctx context.Context, _ handler.EventHandler, queue workqueue.RateLimitingInterface, _ ...predicate.Predicate) error {
+	s.queue.bind(queue)
+	delayed := s.queue.delayed
+	go func() {
+		<-ctx.Done()
+		delayed.ShutDown()
+	}()
+	// forward items to the workqueue of the controller once they are due
+	go func() {
+		for {
+			item, shutdown := delayed.Get()
+			if shutdown {
+				return
+			}
+			s.queue.Add(item)
+			delayed.Done(item)
+		}
+	}()
+	return nil
+}
+
+func (s *queueSource) WaitForSync(ctx context.Context) error {
+	if !cache.WaitForCacheSync(ctx.Done(), s.mustSync...) {
+		return fmt.Errorf("error waiting for informer caches to sync")
+	}
+	return nil
+}
+
+func (s *queueSource) String() string {
+	return "queue source"
+}
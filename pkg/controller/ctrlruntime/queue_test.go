@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrlruntime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+)
+
+func TestRequestFor(t *testing.T) {
+	tests := map[string]reconcile.Request{
+		"ns/name":            {NamespacedName: types.NamespacedName{Namespace: "ns", Name: "name"}},
+		"name":               {NamespacedName: types.NamespacedName{Name: "name"}},
+		"Issuer/ns/name":     {NamespacedName: types.NamespacedName{Name: "Issuer/ns/name"}},
+		"ClusterIssuer/name": {NamespacedName: types.NamespacedName{Namespace: "ClusterIssuer", Name: "name"}},
+	}
+	for key, expected := range tests {
+		t.Run(key, func(t *testing.T) {
+			req := RequestFor(key)
+			assert.Equal(t, expected, req)
+			assert.Equal(t, key, KeyFor(req), "key should be recoverable from the request")
+		})
+	}
+}
+
+func TestQueue(t *testing.T) {
+	q := NewQueue()
+
+	// Items added before the Queue is bound are buffered.
+	q.Add("ns/unbound")
+	q.AddRateLimited("ns/unbound-rate-limited")
+	assert.Equal(t, 2, q.Len())
+	assert.False(t, q.ShuttingDown())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	controllerQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer controllerQueue.ShutDown()
+	require.NoError(t, q.Source().Start(ctx, nil, controllerQueue))
+
+	// The buffered items are added to the workqueue of the controller once
+	// the Queue is bound.
+	item, shutdown := controllerQueue.Get()
+	require.False(t, shutdown)
+	assert.Equal(t, RequestFor("ns/unbound"), item)
+	controllerQueue.Done(item)
+
+	item, shutdown = controllerQueue.Get()
+	require.False(t, shutdown)
+	assert.Equal(t, RequestFor("ns/unbound-rate-limited"), item)
+	controllerQueue.Done(item)
+
+	q.Add("ns/name")
+	q.AddAfter("ns/later", time.Millisecond)
+
+	item, shutdown = controllerQueue.Get()
+	require.False(t, shutdown)
+	assert.Equal(t, RequestFor("ns/name"), item)
+	controllerQueue.Done(item)
+
+	item, shutdown = controllerQueue.Get()
+	require.False(t, shutdown)
+	assert.Equal(t, RequestFor("ns/later"), item)
+	controllerQueue.Done(item)
+}
+
+func TestQueueAddAfterBeforeBind(t *testing.T) {
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	q := NewQueueWithClock(fakeClock)
+
+	// An item which is due before the Queue is bound is added once it is.
+	q.AddAfter("ns/due", time.Minute)
+	fakeClock.Step(time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	controllerQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer controllerQueue.ShutDown()
+	require.NoError(t, q.Source().Start(ctx, nil, controllerQueue))
+
+	testpkg.WaitForQueueLen(t, controllerQueue, 1)
+	item, shutdown := controllerQueue.Get()
+	require.False(t, shutdown)
+	assert.Equal(t, RequestFor("ns/due"), item)
+	controllerQueue.Done(item)
+}
+
+func TestQueueWithClock(t *testing.T) {
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	q := NewQueueWithClock(fakeClock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	controllerQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer controllerQueue.ShutDown()
+	require.NoError(t, q.Source().Start(ctx, nil, controllerQueue))
+
+	q.AddAfter("ns/later", time.Hour)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, controllerQueue.Len(), "item should not be added before the fake clock has stepped")
+
+	testpkg.StepAndWaitForQueueLen(t, fakeClock, time.Hour, controllerQueue, 1)
+
+	item, shutdown := controllerQueue.Get()
+	require.False(t, shutdown)
+	assert.Equal(t, RequestFor("ns/later"), item)
+	controllerQueue.Done(item)
+}
+
+func TestQueueSourceWaitsForSync(t *testing.T) {
+	synced := false
+	src := NewQueue().Source(func() bool { return synced })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	assert.Error(t, src.WaitForSync(ctx), "expected an error if the informers never sync")
+
+	synced = true
+	assert.NoError(t, src.WaitForSync(context.Background()))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrlruntime
+
+import (
+	"context"
+	"strings"
+
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+// SyncFunc processes the item with the given queue key.
+type SyncFunc func(ctx context.Context, key string) error
+
+// NewReconciler returns a reconcile.Reconciler which calls syncFunc with the
+// queue key of each reconcile.Request, in the same way as the workers of
+// controllers built with controllerpkg.Builder. This allows the sync
+// functions of existing controllers to be run by controller-runtime.
+func NewReconciler(name string, metrics *metrics.Metrics, syncFunc SyncFunc) reconcile.Reconciler {
+	return &reconciler{name: name, metrics: metrics, syncFunc: syncFunc}
+}
+
+type reconciler struct {
+	name     string
+	metrics  *metrics.Metrics
+	syncFunc SyncFunc
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	key := KeyFor(req)
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	log.V(logf.DebugLevel).Info("syncing item")
+
+	// Increase sync count for this controller
+	if r.metrics != nil {
+		r.metrics.IncrementSyncCallCount(r.name)
+	}
+
+	err := r.syncFunc(ctx, key)
+	if err == nil {
+		log.V(logf.DebugLevel).Info("finished processing work item")
+		return reconcile.Result{}, nil
+	}
+
+	if strings.Contains(err.Error(), genericregistry.OptimisticLockErrorMsg) {
+		// These errors are not counted towards the controllerSyncErrorCount
+		// metric on purpose, and are not returned so that they are not
+		// logged as errors by controller-runtime.
+		log.Info("re-queuing item due to optimistic locking on resource", "error", err.Error())
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	if r.metrics != nil {
+		r.metrics.IncrementSyncErrorCount(r.name)
+	}
+	return reconcile.Result{}, err
+}
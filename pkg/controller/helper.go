@@ -41,3 +41,11 @@ func (o IssuerOptions) CanUseAmbientCredentials(iss cmapi.GenericIssuer) bool {
 	}
 	return false
 }
+
+// ManagesControllerName returns whether a controller of the given
+// controllerClass manages resources whose spec.controllerName is
+// controllerName. Resources which don't set a controllerName are only managed
+// by controllers which have no class configured.
+func ManagesControllerName(controllerClass, controllerName string) bool {
+	return controllerClass == controllerName
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"k8s.io/client-go/tools/cache"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// CertificateSecretNameIndex is the name of the Certificate informer index
+	// which indexes Certificates by the namespaced name of `spec.secretName`.
+	CertificateSecretNameIndex = "spec.secretName"
+
+	// CertificateNextPrivateKeySecretNameIndex is the name of the Certificate
+	// informer index which indexes Certificates by the namespaced name of
+	// `status.nextPrivateKeySecretName`.
+	CertificateNextPrivateKeySecretNameIndex = "status.nextPrivateKeySecretName"
+
+	// IssuerRefIndex is the name of the Certificate and CertificateRequest
+	// informer index which indexes resources by the issuer they reference. Use
+	// IssuerRefIndexKey to build the key for a given Issuer or ClusterIssuer.
+	IssuerRefIndex = "spec.issuerRef"
+
+	// CertificateBootstrapAfterIndex is the name of the Certificate informer
+	// index which indexes Certificates by the namespaced names of the
+	// Certificates listed in their `cert-manager.io/bootstrap-after`
+	// annotation.
+	CertificateBootstrapAfterIndex = "metadata.annotations.bootstrapAfter"
+
+	// CertificateRequestDedupIndex is the name of the CertificateRequest
+	// informer index which indexes issued CertificateRequests by
+	// CertificateRequestDedupKey.
+	CertificateRequestDedupIndex = "spec.request"
+)
+
+// CertificateIndexers are the indexers which are added to the shared
+// Certificate informer.
+var CertificateIndexers = cache.Indexers{
+	CertificateSecretNameIndex:               certificateSecretNameIndexFunc,
+	CertificateNextPrivateKeySecretNameIndex: certificateNextPrivateKeySecretNameIndexFunc,
+	IssuerRefIndex:                           issuerRefIndexFunc,
+	CertificateBootstrapAfterIndex:           certificateBootstrapAfterIndexFunc,
+}
+
+// CertificateRequestIndexers are the indexers which are added to the shared
+// CertificateRequest informer.
+var CertificateRequestIndexers = cache.Indexers{
+	IssuerRefIndex:               issuerRefIndexFunc,
+	CertificateRequestDedupIndex: certificateRequestDedupIndexFunc,
+}
+
+// NamespacedIndexKey returns the key used to look up resources in the
+// CertificateSecretNameIndex and CertificateNextPrivateKeySecretNameIndex
+// indexes for a resource with the given namespace and name.
+func NamespacedIndexKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// IssuerRefIndexKey returns the key used to look up resources in the
+// IssuerRefIndex index which reference the given Issuer or ClusterIssuer.
+func IssuerRefIndexKey(iss cmapi.GenericIssuer) string {
+	if _, ok := iss.(*cmapi.ClusterIssuer); ok {
+		return cmapi.ClusterIssuerKind + "/" + iss.GetObjectMeta().Name
+	}
+	return cmapi.IssuerKind + "/" + NamespacedIndexKey(iss.GetObjectMeta().Namespace, iss.GetObjectMeta().Name)
+}
+
+// EnsureIndexers adds any of the given indexers which are not yet registered
+// to the informer. Informers are shared between controllers, so this may be
+// called by each controller that depends on an index.
+// Indexers can only be added before the informer has been started.
+func EnsureIndexers(informer cache.SharedIndexInformer, indexers cache.Indexers) error {
+	existing := informer.GetIndexer().GetIndexers()
+
+	missing := cache.Indexers{}
+	for name, fn := range indexers {
+		if _, ok := existing[name]; !ok {
+			missing[name] = fn
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := informer.AddIndexers(missing); err != nil {
+		return fmt.Errorf("failed to add indexers to informer: %w", err)
+	}
+	return nil
+}
+
+func certificateSecretNameIndexFunc(obj interface{}) ([]string, error) {
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		return nil, nil
+	}
+	return []string{NamespacedIndexKey(crt.Namespace, crt.Spec.SecretName)}, nil
+}
+
+func certificateNextPrivateKeySecretNameIndexFunc(obj interface{}) ([]string, error) {
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok || crt.Status.NextPrivateKeySecretName == nil {
+		return nil, nil
+	}
+	return []string{NamespacedIndexKey(crt.Namespace, *crt.Status.NextPrivateKeySecretName)}, nil
+}
+
+func certificateBootstrapAfterIndexFunc(obj interface{}) ([]string, error) {
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		return nil, nil
+	}
+	// Certificates with an invalid annotation are not held back, so they
+	// don't need to be indexed.
+	deps, err := apiutil.BootstrapAfter(crt)
+	if err != nil {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		keys = append(keys, NamespacedIndexKey(dep.Namespace, dep.Name))
+	}
+	return keys, nil
+}
+
+// CertificateRequestDedupKey returns the key used to look up the
+// CertificateRequests in the CertificateRequestDedupIndex index which are
+// identical to cr: they are in the same namespace, reference the same issuer,
+// and request a certificate for the same CSR with the same duration, usages
+// and isCA.
+func CertificateRequestDedupKey(cr *cmapi.CertificateRequest) string {
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	ref := cr.Spec.IssuerRef
+	write(apiutil.IssuerNamespace(ref, cr.Namespace))
+	write(ref.Group)
+	write(apiutil.IssuerKind(ref))
+	write(ref.Name)
+	write(string(cr.Spec.Request))
+	if cr.Spec.Duration != nil {
+		write(cr.Spec.Duration.Duration.String())
+	} else {
+		write("")
+	}
+	write(fmt.Sprint(cr.Spec.IsCA))
+	for _, usage := range cr.Spec.Usages {
+		write(string(usage))
+	}
+
+	return cr.Namespace + "/" + hex.EncodeToString(h.Sum(nil))
+}
+
+func certificateRequestDedupIndexFunc(obj interface{}) ([]string, error) {
+	cr, ok := obj.(*cmapi.CertificateRequest)
+	// Only issued CertificateRequests can be reused.
+	if !ok || len(cr.Status.Certificate) == 0 || apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonIssued {
+		return nil, nil
+	}
+	return []string{CertificateRequestDedupKey(cr)}, nil
+}
+
+func issuerRefIndexFunc(obj interface{}) ([]string, error) {
+	var namespace string
+	var kind, name string
+	switch o := obj.(type) {
+	case *cmapi.Certificate:
+		namespace, kind, name = apiutil.IssuerNamespace(o.Spec.IssuerRef, o.Namespace), o.Spec.IssuerRef.Kind, o.Spec.IssuerRef.Name
+	case *cmapi.CertificateRequest:
+		namespace, kind, name = apiutil.IssuerNamespace(o.Spec.IssuerRef, o.Namespace), o.Spec.IssuerRef.Kind, o.Spec.IssuerRef.Name
+	default:
+		return nil, nil
+	}
+
+	if kind == cmapi.ClusterIssuerKind {
+		return []string{cmapi.ClusterIssuerKind + "/" + name}, nil
+	}
+	// References without a kind, or with the kind of an external issuer,
+	// are indexed as namespaced references. External issuers are not
+	// handled by cert-manager so the kind only matters for ClusterIssuers.
+	return []string{cmapi.IssuerKind + "/" + NamespacedIndexKey(namespace, name)}, nil
+}
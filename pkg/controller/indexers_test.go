@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestEnsureIndexers(t *testing.T) {
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &cmapi.Certificate{}, 0, cache.Indexers{})
+
+	require.NoError(t, EnsureIndexers(informer, cache.Indexers{CertificateSecretNameIndex: CertificateIndexers[CertificateSecretNameIndex]}))
+	// Adding the same index again, e.g. from another controller sharing the
+	// informer, must not fail.
+	require.NoError(t, EnsureIndexers(informer, CertificateIndexers))
+
+	for name := range CertificateIndexers {
+		assert.Contains(t, informer.GetIndexer().GetIndexers(), name)
+	}
+}
+
+func TestCertificateIndexers(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, CertificateIndexers)
+
+	crt1 := gen.Certificate("crt-1",
+		gen.SetCertificateNamespace("ns-1"),
+		gen.SetCertificateSecretName("secret-1"),
+		gen.SetCertificateNextPrivateKeySecretName("next-1"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"}),
+	)
+	crt2 := gen.Certificate("crt-2",
+		gen.SetCertificateNamespace("ns-2"),
+		gen.SetCertificateSecretName("secret-1"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.ClusterIssuerKind}),
+	)
+	require.NoError(t, indexer.Add(crt1))
+	require.NoError(t, indexer.Add(crt2))
+
+	tests := map[string]struct {
+		index    string
+		key      string
+		expected []interface{}
+	}{
+		"secret name is scoped to the namespace": {
+			index:    CertificateSecretNameIndex,
+			key:      NamespacedIndexKey("ns-1", "secret-1"),
+			expected: []interface{}{crt1},
+		},
+		"next private key secret name": {
+			index:    CertificateNextPrivateKeySecretNameIndex,
+			key:      NamespacedIndexKey("ns-1", "next-1"),
+			expected: []interface{}{crt1},
+		},
+		"Certificates without a next private key are not indexed": {
+			index: CertificateNextPrivateKeySecretNameIndex,
+			key:   NamespacedIndexKey("ns-2", ""),
+		},
+		"Issuer reference": {
+			index:    IssuerRefIndex,
+			key:      IssuerRefIndexKey(gen.Issuer("issuer", gen.SetIssuerNamespace("ns-1"))),
+			expected: []interface{}{crt1},
+		},
+		"ClusterIssuer reference": {
+			index:    IssuerRefIndex,
+			key:      IssuerRefIndexKey(gen.ClusterIssuer("issuer")),
+			expected: []interface{}{crt2},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			objs, err := indexer.ByIndex(test.index, test.key)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, test.expected, objs)
+		})
+	}
+}
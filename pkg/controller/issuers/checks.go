@@ -98,3 +98,25 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 
 	return affected, nil
 }
+
+// issuersForCertificate returns the CA issuers whose CA Secret is produced by
+// the given Certificate, so that they can report their bootstrap status as
+// the Certificate progresses.
+func (c *controller) issuersForCertificate(crt *v1.Certificate) ([]*v1.Issuer, error) {
+	issuers, err := c.issuerLister.List(labels.NewSelector())
+	if err != nil {
+		return nil, fmt.Errorf("error listing issuers: %s", err.Error())
+	}
+
+	var affected []*v1.Issuer
+	for _, iss := range issuers {
+		if iss.Namespace != crt.Namespace {
+			continue
+		}
+		if iss.Spec.CA != nil && iss.Spec.CA.SecretName == crt.Spec.SecretName {
+			affected = append(affected, iss)
+		}
+	}
+
+	return affected, nil
+}
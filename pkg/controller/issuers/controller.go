@@ -27,6 +27,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -57,6 +58,10 @@ type controller struct {
 
 	// fieldManager is the manager name used for the Apply operations.
 	fieldManager string
+
+	// controllerClass is the class of this installation of cert-manager.
+	// Issuers with a different spec.controllerName are ignored.
+	controllerClass string
 }
 
 // Register registers and constructs the controller using the provided context.
@@ -72,11 +77,20 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// obtain references to all the informers used by this controller
 	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
 	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	// Certificates are watched so that CA issuers can report whether the
+	// Certificate producing their CA Secret can be issued.
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		issuerInformer.Informer().HasSynced,
 		secretInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+	// the CA issuer follows the issuerRef of Certificates to detect bootstrap
+	// cycles, which may reference ClusterIssuers.
+	if ctx.Namespace == "" {
+		mustSync = append(mustSync, ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().HasSynced)
 	}
 
 	// set all the references to the listers for used by the Sync function
@@ -86,11 +100,13 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// register handler functions
 	issuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
 	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.secretDeleted})
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.certificateChanged})
 
 	// instantiate additional helpers used by this controller
 	c.issuerFactory = issuer.NewFactory(ctx)
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
+	c.controllerClass = ctx.ControllerClass
 	c.recorder = ctx.Recorder
 
 	return c.queue, mustSync, nil
@@ -123,6 +139,31 @@ func (c *controller) secretDeleted(obj interface{}) {
 	}
 }
 
+func (c *controller) certificateChanged(obj interface{}) {
+	log := c.log.WithName("certificateChanged")
+
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		log.Error(nil, "object was not a Certificate object")
+		return
+	}
+	log = logf.WithResource(log, crt)
+
+	issuers, err := c.issuersForCertificate(crt)
+	if err != nil {
+		log.Error(err, "error looking up issuers whose CA is produced by certificate")
+		return
+	}
+	for _, iss := range issuers {
+		key, err := keyFunc(iss)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	log := logf.FromContext(ctx)
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
@@ -141,6 +182,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.ManagesControllerName(c.controllerClass, issuer.Spec.ControllerName) {
+		log.V(logf.DebugLevel).Info("issuer is managed by a different controller class, skipping")
+		return nil
+	}
+
 	ctx = logf.NewContext(ctx, logf.WithResource(log, issuer))
 	return c.Sync(ctx, issuer)
 }
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretwatchdog
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	reasonAdopted        = "Adopted"
+	reasonAdoptionFailed = "AdoptionFailed"
+)
+
+// issuerRefForSecret returns the issuer that the given unmanaged Secret is
+// annotated to be adopted with, using the same annotations as ingress-shim.
+func issuerRefForSecret(secret *corev1.Secret) (cmmeta.ObjectReference, bool) {
+	ref := cmmeta.ObjectReference{
+		Kind:  secret.Annotations[cmapi.IssuerKindAnnotationKey],
+		Group: secret.Annotations[cmapi.IssuerGroupAnnotationKey],
+	}
+	if name := secret.Annotations[cmapi.IngressIssuerNameAnnotationKey]; len(name) > 0 {
+		ref.Name = name
+		if len(ref.Kind) == 0 {
+			ref.Kind = cmapi.IssuerKind
+		}
+		return ref, true
+	}
+	if name := secret.Annotations[cmapi.IngressClusterIssuerNameAnnotationKey]; len(name) > 0 {
+		ref.Name = name
+		ref.Kind = cmapi.ClusterIssuerKind
+		return ref, true
+	}
+	return ref, false
+}
+
+// adoptSecret creates a Certificate managing the given Secret if the Secret
+// is unmanaged and annotated with the issuer to adopt it with. The Certificate
+// has the same name as the Secret and requests the identities of the current
+// certificate of the Secret.
+func (c *controller) adoptSecret(ctx context.Context, s inspectedSecret) error {
+	if s.managed || s.cert == nil {
+		return nil
+	}
+	issuerRef, ok := issuerRefForSecret(s.secret)
+	if !ok {
+		return nil
+	}
+	log := logf.FromContext(ctx).WithValues("secret", s.secret.Name)
+
+	existing, err := c.certificateLister.Certificates(s.secret.Namespace).Get(s.secret.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if existing != nil {
+		// The Certificate has already been created, and will annotate the
+		// Secret once it has been issued.
+		if existing.Spec.SecretName != s.secret.Name {
+			c.recorder.Eventf(s.secret, corev1.EventTypeWarning, reasonAdoptionFailed,
+				"Cannot adopt Secret as Certificate %q already exists for Secret %q", existing.Name, existing.Spec.SecretName)
+		}
+		return nil
+	}
+
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.secret.Namespace,
+			Name:      s.secret.Name,
+			Annotations: map[string]string{
+				cmapi.AllowSecretOverwriteAnnotationKey: "true",
+			},
+		},
+		Spec: cmapi.CertificateSpec{
+			SecretName:     s.secret.Name,
+			CommonName:     s.cert.Subject.CommonName,
+			DNSNames:       s.cert.DNSNames,
+			IPAddresses:    pki.IPAddressesToString(s.cert.IPAddresses),
+			URIs:           pki.URLsToString(s.cert.URIs),
+			EmailAddresses: s.cert.EmailAddresses,
+			IssuerRef:      issuerRef,
+		},
+	}
+	if _, err := c.cmClient.CertmanagerV1().Certificates(crt.Namespace).Create(ctx, crt, metav1.CreateOptions{}); err != nil {
+		c.recorder.Eventf(s.secret, corev1.EventTypeWarning, reasonAdoptionFailed, "Failed to create Certificate: %v", err)
+		return err
+	}
+
+	log.V(logf.InfoLevel).Info("adopted unmanaged secret", "issuer", issuerRef.Name, "kind", issuerRef.Kind)
+	c.recorder.Eventf(s.secret, corev1.EventTypeNormal, reasonAdopted, "Created Certificate %q to manage the Secret using %s %q", crt.Name, issuerRef.Kind, issuerRef.Name)
+	return nil
+}
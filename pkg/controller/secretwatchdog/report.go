@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretwatchdog
+
+import (
+	"crypto/x509"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// expiringSoonThreshold is the time before expiry at which the certificate
+// of a Secret is counted as expiring soon.
+const expiringSoonThreshold = 30 * 24 * time.Hour
+
+// inspectedSecret is a TLS Secret and its decoded certificate.
+type inspectedSecret struct {
+	secret *corev1.Secret
+	// managed is true if the Secret is managed by a Certificate.
+	managed bool
+	// cert is nil if the certificate of the Secret could not be decoded, in
+	// which case err is set.
+	cert *x509.Certificate
+	err  error
+}
+
+func inspectSecret(secret *corev1.Secret) inspectedSecret {
+	_, managed := secret.Annotations[cmapi.CertificateNameKey]
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	return inspectedSecret{secret: secret, managed: managed, cert: cert, err: err}
+}
+
+// buildReport returns the TLSSecretReport describing the given Secrets.
+func buildReport(namespace string, inspected []inspectedSecret, now time.Time) *cmapi.TLSSecretReport {
+	report := &cmapi.TLSSecretReport{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: ReportName},
+	}
+
+	for _, s := range inspected {
+		entry := cmapi.TLSSecretReportEntry{
+			Name:    s.secret.Name,
+			Managed: s.managed,
+		}
+		if s.managed {
+			entry.CertificateName = s.secret.Annotations[cmapi.CertificateNameKey]
+		} else {
+			report.Summary.Unmanaged++
+		}
+
+		if s.cert == nil {
+			entry.Error = s.err.Error()
+			report.Summary.Invalid++
+		} else {
+			notAfter := metav1.NewTime(s.cert.NotAfter)
+			entry.CommonName = s.cert.Subject.CommonName
+			entry.DNSNames = s.cert.DNSNames
+			entry.Issuer = s.cert.Issuer.String()
+			entry.NotAfter = &notAfter
+
+			switch {
+			case !now.Before(s.cert.NotAfter):
+				report.Summary.Expired++
+			case !now.Before(s.cert.NotAfter.Add(-expiringSoonThreshold)):
+				report.Summary.ExpiringSoon++
+			}
+		}
+
+		report.Secrets = append(report.Secrets, entry)
+		report.Summary.Total++
+	}
+
+	sort.Slice(report.Secrets, func(i, j int) bool {
+		return report.Secrets[i].Name < report.Secrets[j].Name
+	})
+
+	return report
+}
+
+// nextTransition returns the earliest time after now at which the certificate
+// of one of the given Secrets starts expiring soon or expires.
+func nextTransition(inspected []inspectedSecret, now time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, s := range inspected {
+		if s.cert == nil {
+			continue
+		}
+		for _, t := range []time.Time{s.cert.NotAfter.Add(-expiringSoonThreshold), s.cert.NotAfter} {
+			if t.After(now) && (next.IsZero() || t.Before(next)) {
+				next = t
+			}
+		}
+	}
+	return next, !next.IsZero()
+}
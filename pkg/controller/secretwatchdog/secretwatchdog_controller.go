@@ -0,0 +1,286 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretwatchdog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+const (
+	// ControllerName is the name of the secret watchdog controller.
+	ControllerName = "secret-watchdog"
+
+	// ReportName is the name of the TLSSecretReport written to each of the
+	// watched namespaces.
+	ReportName = "tls-secrets"
+
+	// allNamespaces selects all namespaces when given as a watched namespace.
+	allNamespaces = "*"
+)
+
+// controller reports the TLS Secrets in the watched namespaces, including the
+// Secrets which are not managed by cert-manager, in a TLSSecretReport in each
+// namespace and exposes the expiry of their certificates as a metric.
+// The queue is keyed by namespace.
+type controller struct {
+	secretLister      corelisters.SecretLister
+	reportLister      cmlisters.TLSSecretReportLister
+	certificateLister cmlisters.CertificateLister
+	cmClient          cmclient.Interface
+	recorder          record.EventRecorder
+	metrics           *metrics.Metrics
+	clock             clock.Clock
+	queue             workqueue.RateLimitingInterface
+
+	namespaces sets.String
+	adopt      bool
+
+	// reported holds the names of the Secrets which have an expiry metric
+	// exposed in each namespace, so that the metric can be removed once the
+	// Secret is deleted or no longer holds a valid certificate.
+	reportedLock sync.Mutex
+	reported     map[string]sets.String
+}
+
+// NewController returns a new secret watchdog controller.
+func NewController(
+	log logr.Logger,
+	cmClient cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	metrics *metrics.Metrics,
+	clock clock.Clock,
+	opts controllerpkg.CertificateOptions,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := controllerpkg.NewRateLimitingQueue(clock, workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	secretInformer := factory.Core().V1().Secrets()
+	reportInformer := cmFactory.Certmanager().V1().TLSSecretReports()
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+
+	c := &controller{
+		secretLister:      secretInformer.Lister(),
+		reportLister:      reportInformer.Lister(),
+		certificateLister: certificateInformer.Lister(),
+		cmClient:          cmClient,
+		recorder:          recorder,
+		metrics:           metrics,
+		clock:             clock,
+		queue:             queue,
+		namespaces:        sets.NewString(opts.SecretWatchdogNamespaces...),
+		adopt:             opts.SecretWatchdogAdopt,
+		reported:          make(map[string]sets.String),
+	}
+
+	// Changes to Secrets and to the reports themselves cause the namespace
+	// to be re-scanned, so that reports which are edited or deleted are
+	// restored.
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.enqueueNamespace(log)})
+	reportInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.enqueueNamespace(log)})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		secretInformer.Informer().HasSynced,
+		reportInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+
+	return c, queue, mustSync
+}
+
+// enqueueNamespace returns a function which enqueues the namespace of the
+// given object if it is watched.
+func (c *controller) enqueueNamespace(log logr.Logger) func(obj interface{}) {
+	return func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		o, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-object type resource passed to enqueueNamespace")
+			return
+		}
+		if secret, ok := obj.(*corev1.Secret); ok && secret.Type != corev1.SecretTypeTLS {
+			return
+		}
+		if c.watches(o.GetNamespace()) {
+			c.queue.Add(o.GetNamespace())
+		}
+	}
+}
+
+// watches returns true if the TLS Secrets in the given namespace are
+// reported.
+func (c *controller) watches(namespace string) bool {
+	return c.namespaces.Has(allNamespaces) || c.namespaces.Has(namespace)
+}
+
+// ProcessItem is a worker function that will be called when a new key
+// corresponding to a namespace to be re-synced is pulled from the workqueue.
+// ProcessItem inspects the TLS Secrets in the namespace, updates their expiry
+// metrics and the TLSSecretReport of the namespace, and adopts unmanaged
+// Secrets if enabled.
+func (c *controller) ProcessItem(ctx context.Context, namespace string) error {
+	log := logf.FromContext(ctx).WithValues("namespace", namespace)
+	ctx = logf.NewContext(ctx, log)
+
+	if !c.watches(namespace) {
+		return nil
+	}
+
+	secrets, err := c.secretLister.Secrets(namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	var inspected []inspectedSecret
+	for _, secret := range secrets {
+		if secret.Type != corev1.SecretTypeTLS {
+			continue
+		}
+		inspected = append(inspected, inspectSecret(secret))
+	}
+
+	c.updateMetrics(namespace, inspected)
+
+	var errs []error
+	if err := c.updateReport(ctx, namespace, buildReport(namespace, inspected, now)); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.adopt {
+		for _, s := range inspected {
+			if err := c.adoptSecret(ctx, s); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	// The report is rebuilt once the next Secret crosses the expiring soon
+	// threshold or expires, as the Secrets themselves don't change then.
+	if next, ok := nextTransition(inspected, now); ok {
+		c.queue.AddAfter(namespace, next.Sub(now))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// updateMetrics exposes the expiry of each of the valid certificates in the
+// given namespace, and removes the metrics of Secrets which are no longer
+// reported.
+func (c *controller) updateMetrics(namespace string, inspected []inspectedSecret) {
+	c.reportedLock.Lock()
+	defer c.reportedLock.Unlock()
+
+	reported := sets.NewString()
+	for _, s := range inspected {
+		if s.cert == nil {
+			continue
+		}
+		c.metrics.UpdateTLSSecret(namespace, s.secret.Name, s.managed, s.cert.NotAfter)
+		reported.Insert(s.secret.Name)
+	}
+	for _, name := range c.reported[namespace].Difference(reported).List() {
+		c.metrics.RemoveTLSSecret(namespace, name)
+	}
+	c.reported[namespace] = reported
+}
+
+// updateReport creates or updates the TLSSecretReport of the given namespace
+// if it differs from the desired report.
+func (c *controller) updateReport(ctx context.Context, namespace string, desired *cmapi.TLSSecretReport) error {
+	existing, err := c.reportLister.TLSSecretReports(namespace).Get(ReportName)
+	if apierrors.IsNotFound(err) {
+		_, err = c.cmClient.CertmanagerV1().TLSSecretReports(namespace).Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if apiequality.Semantic.DeepEqual(existing.Summary, desired.Summary) && apiequality.Semantic.DeepEqual(existing.Secrets, desired.Secrets) {
+		return nil
+	}
+
+	report := existing.DeepCopy()
+	report.Summary = desired.Summary
+	report.Secrets = desired.Secrets
+	_, err = c.cmClient.CertmanagerV1().TLSSecretReports(namespace).Update(ctx, report, metav1.UpdateOptions{})
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Metrics,
+		ctx.Clock,
+		ctx.CertificateOptions,
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretwatchdog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func tlsSecret(t *testing.T, name string, annotations map[string]string, notAfter time.Time) *corev1.Secret {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	crt := gen.Certificate(name, gen.SetCertificateCommonName(name+".example.com"), gen.SetCertificateDNSNames(name+".example.com"))
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name, Annotations: annotations},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       testcrypto.MustCreateCertWithNotBeforeAfter(t, pk, crt, notAfter.Add(-90*24*time.Hour), notAfter),
+			corev1.TLSPrivateKeyKey: pk,
+		},
+	}
+}
+
+func TestProcessItem(t *testing.T) {
+	secrets := []runtime.Object{
+		tlsSecret(t, "managed", map[string]string{cmapi.CertificateNameKey: "managed"}, fixedClockStart.Add(60*24*time.Hour)),
+		tlsSecret(t, "expiring", nil, fixedClockStart.Add(10*24*time.Hour)),
+		tlsSecret(t, "expired", nil, fixedClockStart.Add(-time.Hour)),
+		tlsSecret(t, "adoptable", map[string]string{cmapi.IngressClusterIssuerNameAnnotationKey: "ca"}, fixedClockStart.Add(60*24*time.Hour)),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "invalid"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("not a certificate")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "opaque"},
+			Type:       corev1.SecretTypeOpaque,
+		},
+	}
+
+	tests := map[string]struct {
+		adopt           bool
+		expectedAdopted bool
+	}{
+		"unmanaged secrets are only reported by default": {},
+		"annotated unmanaged secrets are adopted if enabled": {
+			adopt:           true,
+			expectedAdopted: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:           t,
+				Clock:       fakeclock.NewFakeClock(fixedClockStart),
+				KubeObjects: secrets,
+			}
+			builder.Init()
+			builder.Context.CertificateOptions.SecretWatchdogNamespaces = []string{"testns"}
+			builder.Context.CertificateOptions.SecretWatchdogAdopt = test.adopt
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), "testns"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var report *cmapi.TLSSecretReport
+			var adopted *cmapi.Certificate
+			for _, action := range builder.FakeCMClient().Actions() {
+				create, ok := action.(coretesting.CreateAction)
+				if !ok {
+					continue
+				}
+				switch obj := create.GetObject().(type) {
+				case *cmapi.TLSSecretReport:
+					report = obj
+				case *cmapi.Certificate:
+					adopted = obj
+				}
+			}
+
+			if report == nil {
+				t.Fatal("expected a TLSSecretReport to be created")
+			}
+			expectedSummary := cmapi.TLSSecretReportSummary{Total: 5, Unmanaged: 4, ExpiringSoon: 1, Expired: 1, Invalid: 1}
+			if report.Summary != expectedSummary {
+				t.Errorf("expected summary %+v, got %+v", expectedSummary, report.Summary)
+			}
+			var names []string
+			for _, entry := range report.Secrets {
+				names = append(names, entry.Name)
+			}
+			if len(names) != 5 || names[0] != "adoptable" || names[4] != "managed" {
+				t.Errorf("expected the report to list the TLS Secrets sorted by name, got %v", names)
+			}
+			if managed := report.Secrets[4]; !managed.Managed || managed.CertificateName != "managed" || managed.CommonName != "managed.example.com" {
+				t.Errorf("unexpected entry for managed Secret: %+v", managed)
+			}
+
+			if (adopted != nil) != test.expectedAdopted {
+				t.Fatalf("expected adoption %t, got Certificate %+v", test.expectedAdopted, adopted)
+			}
+			if adopted != nil {
+				if adopted.Name != "adoptable" || adopted.Spec.SecretName != "adoptable" ||
+					adopted.Spec.IssuerRef.Kind != cmapi.ClusterIssuerKind || adopted.Spec.IssuerRef.Name != "ca" ||
+					len(adopted.Spec.DNSNames) != 1 || adopted.Spec.DNSNames[0] != "adoptable.example.com" ||
+					adopted.Annotations[cmapi.AllowSecretOverwriteAnnotationKey] != "true" {
+					t.Errorf("unexpected adopted Certificate: %+v", adopted)
+				}
+			}
+		})
+	}
+}
+
+func TestNextTransition(t *testing.T) {
+	secret := tlsSecret(t, "test", nil, fixedClockStart.Add(40*24*time.Hour))
+	inspected := []inspectedSecret{inspectSecret(secret)}
+
+	next, ok := nextTransition(inspected, fixedClockStart)
+	if !ok || !next.Equal(fixedClockStart.Add(10*24*time.Hour)) {
+		t.Errorf("expected the next transition when the certificate starts expiring soon, got %s", next)
+	}
+
+	next, ok = nextTransition(inspected, fixedClockStart.Add(20*24*time.Hour))
+	if !ok || !next.Equal(fixedClockStart.Add(40*24*time.Hour)) {
+		t.Errorf("expected the next transition when the certificate expires, got %s", next)
+	}
+
+	if _, ok := nextTransition(inspected, fixedClockStart.Add(50*24*time.Hour)); ok {
+		t.Errorf("expected no transition after the certificate expired")
+	}
+}
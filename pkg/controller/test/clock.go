@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"testing"
+	"time"
+
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+// queueLengther is implemented by workqueues.
+type queueLengther interface {
+	Len() int
+}
+
+// WaitForQueueLen waits until queue contains n items, and fails the test if
+// it does not within a few seconds. Items added to a workqueue with
+// AddAfter are added asynchronously once they are due, so tests must wait
+// for them rather than checking the length of the queue straight away.
+func WaitForQueueLen(t *testing.T, queue queueLengther, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for queue.Len() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected queue to contain %d items, but it contains %d", n, queue.Len())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// StepAndWaitForQueueLen advances the fake clock by d, and waits until queue
+// contains n items. It is used to test that items added to a workqueue which
+// uses the fake clock are processed once they are due, such as retries and
+// rechecks scheduled with AddAfter.
+func StepAndWaitForQueueLen(t *testing.T, clock *fakeclock.FakeClock, d time.Duration, queue queueLengther, n int) {
+	t.Helper()
+	clock.Step(d)
+	WaitForQueueLen(t, queue, n)
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
@@ -199,3 +200,15 @@ func BuildAnnotationsToCopy(allAnnotations map[string]string, prefixes []string)
 	}
 	return filteredAnnotations
 }
+
+// NewRateLimitingQueue returns a named rate limiting work queue which uses the
+// given clock to delay items added with AddAfter and AddRateLimited. This
+// allows tests using a fake clock to control when retries and rechecks are
+// processed. If clk doesn't support tickers, the real clock is used.
+func NewRateLimitingQueue(clk clock.Clock, rateLimiter workqueue.RateLimiter, name string) workqueue.RateLimitingInterface {
+	withTicker, ok := clk.(clock.WithTicker)
+	if !ok {
+		return workqueue.NewNamedRateLimitingQueue(rateLimiter, name)
+	}
+	return workqueue.NewRateLimitingQueueWithDelayingInterface(workqueue.NewDelayingQueueWithCustomClock(withTicker, name), rateLimiter)
+}
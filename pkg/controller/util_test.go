@@ -19,6 +19,10 @@ package controller
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
 )
 
 func TestBuildAnnotationsToCopy(t *testing.T) {
@@ -63,3 +67,26 @@ func TestBuildAnnotationsToCopy(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRateLimitingQueueUsesClock(t *testing.T) {
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	queue := NewRateLimitingQueue(fakeClock, workqueue.DefaultControllerRateLimiter(), "test")
+	defer queue.ShutDown()
+
+	queue.AddAfter("key", time.Minute)
+
+	// The real clock advancing must not cause the item to be added.
+	time.Sleep(50 * time.Millisecond)
+	if queue.Len() != 0 {
+		t.Fatalf("expected item not to be added before the fake clock is stepped")
+	}
+
+	fakeClock.Step(time.Minute)
+	deadline := time.Now().Add(5 * time.Second)
+	for queue.Len() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected item to be added after the fake clock is stepped")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
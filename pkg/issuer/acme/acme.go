@@ -24,6 +24,7 @@ import (
 	core "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -41,6 +42,7 @@ type Acme struct {
 	issuer v1.GenericIssuer
 
 	secretsClient core.SecretsGetter
+	secretsLister corelisters.SecretLister
 	recorder      record.EventRecorder
 
 	// keyFromSecret returns a decoded account key from a Kubernetes secret.
@@ -60,6 +62,10 @@ type Acme struct {
 
 	// userAgent is the string used as the UserAgent when making HTTP calls.
 	userAgent string
+
+	// httpRateLimiter, if set, limits the rate of requests sent to ACME
+	// servers.
+	httpRateLimiter flowcontrol.RateLimiter
 }
 
 // New returns a new ACME issuer interface for the given issuer.
@@ -75,11 +81,13 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 		keyFromSecret:            newKeyFromSecret(secretsLister),
 		clientBuilder:            accounts.NewClient,
 		secretsClient:            ctx.Client.CoreV1(),
+		secretsLister:            secretsLister,
 		recorder:                 ctx.Recorder,
 		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
 		accountRegistry:          ctx.ACMEOptions.AccountRegistry,
 		metrics:                  ctx.Metrics,
 		userAgent:                ctx.RESTConfig.UserAgent,
+		httpRateLimiter:          ctx.ACMEOptions.HTTPRateLimiter,
 	}
 
 	return a, nil
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cpu/goacmedns"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
+)
+
+// loadAcmeDNSAccounts returns the JSON encoded acme-dns accounts stored in
+// the account Secret of the given config. If accounts are registered
+// automatically, the Secret doesn't need to exist yet.
+func (s *Solver) loadAcmeDNSAccounts(config *cmacme.ACMEIssuerDNS01ProviderAcmeDNS, namespace string) ([]byte, error) {
+	accountSecret, err := s.secretLister.Secrets(namespace).Get(config.AccountSecret.Name)
+	if config.AutoRegister && apierrors.IsNotFound(err) {
+		return []byte("{}"), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting acmedns accounts secret: %s", err)
+	}
+
+	accountSecretBytes, ok := accountSecret.Data[config.AccountSecret.Key]
+	if !ok {
+		if config.AutoRegister {
+			return []byte("{}"), nil
+		}
+		return nil, fmt.Errorf("error getting acmedns accounts secret: key '%s' not found in secret", config.AccountSecret.Key)
+	}
+	return accountSecretBytes, nil
+}
+
+// acmeDNSAccountStore returns an acmedns.AccountStore which adds the
+// credentials of registered acme-dns accounts to the given key of the account
+// Secret, creating the Secret if it doesn't exist.
+func (s *Solver) acmeDNSAccountStore(ctx context.Context, ref cmmeta.SecretKeySelector, namespace string) acmedns.AccountStore {
+	return func(domain string, account goacmedns.Account) (goacmedns.Account, error) {
+		stored := account
+		// The Secret may be updated concurrently by challenges for other
+		// domains, and created concurrently if it doesn't exist yet.
+		retriable := func(err error) bool {
+			return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+		}
+		err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+			secret, err := s.Client.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			create := apierrors.IsNotFound(err)
+			if create {
+				secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: namespace}}
+			} else if err != nil {
+				return err
+			}
+
+			accounts := make(map[string]goacmedns.Account)
+			if data := secret.Data[ref.Key]; len(data) > 0 {
+				if err := json.Unmarshal(data, &accounts); err != nil {
+					return fmt.Errorf("error unmarshalling accounts in secret %q: %s", ref.Name, err)
+				}
+			}
+			if existing, ok := accounts[domain]; ok {
+				stored = existing
+				return nil
+			}
+			accounts[domain] = account
+
+			data, err := json.Marshal(accounts)
+			if err != nil {
+				return err
+			}
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte)
+			}
+			secret.Data[ref.Key] = data
+
+			if create {
+				_, err = s.Client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+				return err
+			}
+			_, err = s.Client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+			return err
+		})
+		return stored, err
+	}
+}
@@ -37,8 +37,18 @@ type DNSProvider struct {
 	dns01Nameservers []string
 	client           goacmedns.Client
 	accounts         map[string]goacmedns.Account
+
+	// storeAccount is used to store the accounts registered for domains
+	// without credentials. If nil, accounts are not registered.
+	storeAccount AccountStore
 }
 
+// AccountStore stores the credentials of an acme-dns account registered for
+// the given domain. If credentials for the domain have been stored in the
+// meantime, e.g. by a concurrent registration, these are kept and returned
+// instead.
+type AccountStore func(domain string, account goacmedns.Account) (goacmedns.Account, error)
+
 // NewDNSProvider returns a DNSProvider instance configured for ACME DNS
 // Credentials and acme-dns server host are given in environment variables
 func NewDNSProvider(dns01Nameservers []string) (*DNSProvider, error) {
@@ -65,6 +75,13 @@ func NewDNSProviderHostBytes(host string, accountJSON []byte, dns01Nameservers [
 	}, nil
 }
 
+// EnableAccountRegistration makes the DNSProvider register an acme-dns
+// account for domains that it has no credentials for, and store the
+// credentials of the account using store.
+func (c *DNSProvider) EnableAccountRegistration(store AccountStore) {
+	c.storeAccount = store
+}
+
 // Present creates a TXT record to fulfil the dns-01 challenge
 func (c *DNSProvider) Present(domain, fqdn, value string) error {
 	if account, exists := c.accounts[domain]; exists {
@@ -72,7 +89,30 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 		return c.client.UpdateTXTRecord(account, value)
 	}
 
-	return fmt.Errorf("account credentials not found for domain %s", domain)
+	if c.storeAccount == nil {
+		return fmt.Errorf("account credentials not found for domain %s", domain)
+	}
+
+	account, err := c.client.RegisterAccount(nil)
+	if err != nil {
+		return fmt.Errorf("error registering acme-dns account for domain %s: %s", domain, err)
+	}
+	account, err = c.storeAccount(domain, account)
+	if err != nil {
+		return fmt.Errorf("error storing acme-dns account for domain %s: %s", domain, err)
+	}
+	if c.accounts == nil {
+		c.accounts = make(map[string]goacmedns.Account)
+	}
+	c.accounts[domain] = account
+
+	if err := c.client.UpdateTXTRecord(account, value); err != nil {
+		return err
+	}
+	// The challenge can't succeed until the CNAME record has been created,
+	// so the registration is reported like a failure to make sure it is
+	// noticed.
+	return fmt.Errorf("registered acme-dns account for domain %s, create a CNAME record for _acme-challenge.%s pointing to %s to complete the challenge", domain, domain, account.FullDomain)
 }
 
 // CleanUp removes the record matching the specified parameters. It is not
@@ -17,9 +17,14 @@ limitations under the License.
 package acmedns
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	"github.com/cpu/goacmedns"
+
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	"github.com/stretchr/testify/assert"
 )
@@ -77,3 +82,43 @@ func TestLiveAcmeDnsPresent(t *testing.T) {
 	err = provider.Present(acmednsDomain, "", "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE")
 	assert.NoError(t, err)
 }
+
+func TestPresentRegistersAccount(t *testing.T) {
+	registered := goacmedns.Account{FullDomain: "abc.auth.example.com", SubDomain: "abc", Username: "user", Password: "pass"}
+	var updates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/register":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(registered)
+		case "/update":
+			assert.Equal(t, "user", r.Header.Get("X-Api-User"))
+			updates = append(updates, r.Header.Get("X-Api-User"))
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewDNSProviderHostBytes(server.URL, []byte("{}"), util.RecursiveNameservers)
+	assert.NoError(t, err)
+
+	value := "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE"
+	assert.EqualError(t, provider.Present("example.com", "", value), "account credentials not found for domain example.com")
+
+	stored := map[string]goacmedns.Account{}
+	provider.EnableAccountRegistration(func(domain string, account goacmedns.Account) (goacmedns.Account, error) {
+		stored[domain] = account
+		return account, nil
+	})
+
+	err = provider.Present("example.com", "", value)
+	assert.EqualError(t, err, "registered acme-dns account for domain example.com, create a CNAME record for _acme-challenge.example.com pointing to abc.auth.example.com to complete the challenge")
+	assert.Equal(t, "abc.auth.example.com", stored["example.com"].FullDomain)
+
+	// The registered account is used for later challenges.
+	assert.NoError(t, provider.Present("example.com", "", value))
+	assert.Len(t, updates, 2)
+	assert.Len(t, stored, 1)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cpu/goacmedns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+)
+
+func TestAcmeDNSAccountStore(t *testing.T) {
+	ref := cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "acme-dns"}, Key: "accounts.json"}
+	registered := goacmedns.Account{FullDomain: "new.auth.example.com", SubDomain: "new", Username: "user", Password: "pass"}
+	existing := goacmedns.Account{FullDomain: "old.auth.example.com", SubDomain: "old", Username: "olduser", Password: "oldpass"}
+
+	secretWith := func(accounts map[string]goacmedns.Account) *corev1.Secret {
+		data, err := json.Marshal(accounts)
+		require.NoError(t, err)
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "acme-dns", Namespace: "ns"},
+			Data:       map[string][]byte{"accounts.json": data, "other": []byte("kept")},
+		}
+	}
+
+	tests := map[string]struct {
+		secret      *corev1.Secret
+		domain      string
+		expAccount  goacmedns.Account
+		expAccounts map[string]goacmedns.Account
+	}{
+		"create the Secret if it doesn't exist": {
+			domain:      "example.com",
+			expAccount:  registered,
+			expAccounts: map[string]goacmedns.Account{"example.com": registered},
+		},
+		"add the account to the accounts of other domains": {
+			secret:      secretWith(map[string]goacmedns.Account{"example.org": existing}),
+			domain:      "example.com",
+			expAccount:  registered,
+			expAccounts: map[string]goacmedns.Account{"example.com": registered, "example.org": existing},
+		},
+		"keep and return the account stored in the meantime for the same domain": {
+			secret:      secretWith(map[string]goacmedns.Account{"example.com": existing}),
+			domain:      "example.com",
+			expAccount:  existing,
+			expAccounts: map[string]goacmedns.Account{"example.com": existing},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var objects []runtime.Object
+			if test.secret != nil {
+				objects = append(objects, test.secret)
+			}
+			cl := fake.NewSimpleClientset(objects...)
+			s := &Solver{Context: &controller.Context{Client: cl}}
+
+			account, err := s.acmeDNSAccountStore(context.Background(), ref, "ns")(test.domain, registered)
+			require.NoError(t, err)
+			assert.Equal(t, test.expAccount, account)
+
+			secret, err := cl.CoreV1().Secrets("ns").Get(context.Background(), "acme-dns", metav1.GetOptions{})
+			require.NoError(t, err)
+			var accounts map[string]goacmedns.Account
+			require.NoError(t, json.Unmarshal(secret.Data["accounts.json"], &accounts))
+			assert.Equal(t, test.expAccounts, accounts)
+			if test.secret != nil {
+				assert.Equal(t, "kept", string(secret.Data["other"]))
+			}
+		})
+	}
+}
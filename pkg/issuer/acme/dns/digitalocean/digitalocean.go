@@ -115,6 +115,12 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	}
 
 	for _, record := range records {
+		// Only remove the record for this challenge, as other challenges for
+		// the same domain (e.g. a wildcard and its apex) share the same name.
+		if record.Type != "TXT" || record.Data != value {
+			continue
+		}
+
 		_, err = c.client.Domains.DeleteRecord(context.Background(), util.UnFqdn(zoneName), record.ID)
 
 		if err != nil {
@@ -125,6 +131,24 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	return nil
 }
 
+// ListTXTRecords returns the values of all TXT records that exist at the
+// given fqdn.
+func (c *DNSProvider) ListTXTRecords(fqdn string) ([]string, error) {
+	records, err := c.findTxtRecord(fqdn)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, record := range records {
+		if record.Type == "TXT" {
+			values = append(values, record.Data)
+		}
+	}
+
+	return values, nil
+}
+
 func (c *DNSProvider) findTxtRecord(fqdn string) ([]godo.DomainRecord, error) {
 
 	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
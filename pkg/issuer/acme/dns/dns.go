@@ -39,11 +39,14 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/linode"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/rfc2136"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/vultr"
 	webhookslv "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/webhook"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmutil "github.com/cert-manager/cert-manager/pkg/util"
 )
 
 // solver is the old solver type interface.
@@ -63,6 +66,8 @@ type dnsProviderConstructors struct {
 	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*azuredns.DNSProvider, error)
 	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error)
 	digitalOcean func(token string, dns01Nameservers []string) (*digitalocean.DNSProvider, error)
+	linode       func(token string, dns01Nameservers []string) (*linode.DNSProvider, error)
+	vultr        func(apiKey string, dns01Nameservers []string) (*vultr.DNSProvider, error)
 }
 
 // Solver is a solver for the acme dns01 challenge.
@@ -101,6 +106,16 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 
 	log.V(logf.DebugLevel).Info("presenting DNS01 challenge for domain")
 
+	// Records presented by solvers that can list records are marked as owned
+	// by this installation so that they can be found by CleanUpOrphans if
+	// the Challenge is removed before they are cleaned up. The owner record
+	// is presented first so that a challenge record never exists without it.
+	if _, ok := slv.(txtRecordLister); ok {
+		if err := slv.Present(ch.Spec.DNSName, fqdn, OwnerRecordValue(s.owner(), ch.Spec.Key)); err != nil {
+			return err
+		}
+	}
+
 	return slv.Present(ch.Spec.DNSName, fqdn, ch.Spec.Key)
 }
 
@@ -116,7 +131,7 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 	log.V(logf.DebugLevel).Info("checking DNS propagation", "nameservers", s.Context.DNS01Nameservers)
 
 	ok, err := util.PreCheckDNS(fqdn, ch.Spec.Key, s.Context.DNS01Nameservers,
-		s.Context.DNS01CheckAuthoritative)
+		s.Context.DNS01CheckAuthoritative, cmutil.NewIPFamilyPolicy(ch.Spec.Solver.SelfCheckIPFamily, s.Context.SelfCheckPreferredIPFamily))
 	if err != nil {
 		return err
 	}
@@ -157,7 +172,15 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	return slv.CleanUp(ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	if err := slv.CleanUp(ch.Spec.DNSName, fqdn, ch.Spec.Key); err != nil {
+		return err
+	}
+
+	if _, ok := slv.(txtRecordLister); ok {
+		return slv.CleanUp(ch.Spec.DNSName, fqdn, OwnerRecordValue(s.owner(), ch.Spec.Key))
+	}
+
+	return nil
 }
 
 func followCNAME(strategy cmacme.CNAMEStrategy) bool {
@@ -290,6 +313,32 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating digitalocean challenge solver: %s", err.Error())
 		}
+	case providerConfig.Linode != nil:
+		dbg.Info("preparing to create Linode provider")
+		apiTokenSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.Linode.Token.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting linode token: %s", err)
+		}
+
+		apiToken := string(apiTokenSecret.Data[providerConfig.Linode.Token.Key])
+
+		impl, err = s.dnsProviderConstructors.linode(strings.TrimSpace(apiToken), s.DNS01Nameservers)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error instantiating linode challenge solver: %s", err.Error())
+		}
+	case providerConfig.Vultr != nil:
+		dbg.Info("preparing to create Vultr provider")
+		apiKeySecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.Vultr.APIKey.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting vultr api key: %s", err)
+		}
+
+		apiKey := string(apiKeySecret.Data[providerConfig.Vultr.APIKey.Key])
+
+		impl, err = s.dnsProviderConstructors.vultr(strings.TrimSpace(apiKey), s.DNS01Nameservers)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error instantiating vultr challenge solver: %s", err.Error())
+		}
 	case providerConfig.Route53 != nil:
 		dbg.Info("preparing to create Route53 provider")
 
@@ -391,17 +440,12 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		}
 	case providerConfig.AcmeDNS != nil:
 		dbg.Info("preparing to create ACMEDNS provider")
-		accountSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.AcmeDNS.AccountSecret.Name)
+		accountSecretBytes, err := s.loadAcmeDNSAccounts(providerConfig.AcmeDNS, resourceNamespace)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error getting acmedns accounts secret: %s", err)
-		}
-
-		accountSecretBytes, ok := accountSecret.Data[providerConfig.AcmeDNS.AccountSecret.Key]
-		if !ok {
-			return nil, nil, fmt.Errorf("error getting acmedns accounts secret: key '%s' not found in secret", providerConfig.AcmeDNS.AccountSecret.Key)
+			return nil, nil, err
 		}
 
-		impl, err = s.dnsProviderConstructors.acmeDNS(
+		acmeDNSProvider, err := s.dnsProviderConstructors.acmeDNS(
 			providerConfig.AcmeDNS.Host,
 			accountSecretBytes,
 			s.DNS01Nameservers,
@@ -409,6 +453,10 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		if err != nil {
 			return nil, providerConfig, fmt.Errorf("error instantiating acmedns challenge solver: %s", err)
 		}
+		if providerConfig.AcmeDNS.AutoRegister {
+			acmeDNSProvider.EnableAccountRegistration(s.acmeDNSAccountStore(ctx, providerConfig.AcmeDNS.AccountSecret, resourceNamespace))
+		}
+		impl = acmeDNSProvider
 	default:
 		return nil, providerConfig, fmt.Errorf("no dns provider config specified for challenge")
 	}
@@ -518,6 +566,8 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 			azuredns.NewDNSProviderCredentials,
 			acmedns.NewDNSProviderHostBytes,
 			digitalocean.NewDNSProviderCredentials,
+			linode.NewDNSProviderCredentials,
+			vultr.NewDNSProviderCredentials,
 		},
 		webhookSolvers: initialized,
 	}, nil
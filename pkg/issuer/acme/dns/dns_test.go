@@ -352,6 +352,108 @@ func TestSolveForDigitalOcean(t *testing.T) {
 
 }
 
+func TestSolveForLinode(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("linode", "default", map[string][]byte{
+					"token": []byte("FAKE-TOKEN\n"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Linode: &cmacme.ACMEIssuerDNS01ProviderLinode{
+							Token: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "linode",
+								},
+								Key: "token",
+							},
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	s := f.Solver
+	_, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedLinodeCall := []fakeDNSProviderCall{
+		{
+			name: "linode",
+			args: []interface{}{"FAKE-TOKEN", util.RecursiveNameservers},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedLinodeCall, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedLinodeCall, f.dnsProviders.calls)
+	}
+
+}
+
+func TestSolveForVultr(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("vultr", "default", map[string][]byte{
+					"api-key": []byte("FAKE-API-KEY"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Vultr: &cmacme.ACMEIssuerDNS01ProviderVultr{
+							APIKey: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "vultr",
+								},
+								Key: "api-key",
+							},
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	s := f.Solver
+	_, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedVultrCall := []fakeDNSProviderCall{
+		{
+			name: "vultr",
+			args: []interface{}{"FAKE-API-KEY", util.RecursiveNameservers},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedVultrCall, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedVultrCall, f.dnsProviders.calls)
+	}
+
+}
+
 func TestRoute53TrimCreds(t *testing.T) {
 	f := &solverFixture{
 		Builder: &test.Builder{
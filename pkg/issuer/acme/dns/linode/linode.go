@@ -0,0 +1,298 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package linode implements a DNS provider for solving the DNS-01 challenge
+// using Linode DNS.
+package linode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// linodeAPIURL is the base URL of version 4 of the Linode API.
+const linodeAPIURL = "https://api.linode.com/v4"
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	dns01Nameservers []string
+	token            string
+	baseURL          string
+	client           *http.Client
+
+	findZoneByFqdn func(fqdn string, nameservers []string) (string, error)
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Linode.
+// The personal access token must be passed in the environment variable
+// LINODE_TOKEN.
+func NewDNSProvider(dns01Nameservers []string) (*DNSProvider, error) {
+	token := os.Getenv("LINODE_TOKEN")
+	return NewDNSProviderCredentials(token, dns01Nameservers)
+}
+
+// NewDNSProviderCredentials uses the supplied credentials to return a
+// DNSProvider instance configured for Linode.
+func NewDNSProviderCredentials(token string, dns01Nameservers []string) (*DNSProvider, error) {
+	if token == "" {
+		return nil, fmt.Errorf("Linode token missing")
+	}
+
+	return &DNSProvider{
+		dns01Nameservers: dns01Nameservers,
+		token:            token,
+		baseURL:          linodeAPIURL,
+		client:           &http.Client{Timeout: 30 * time.Second},
+		findZoneByFqdn:   util.FindZoneByFqdn,
+	}, nil
+}
+
+// domain is a Linode domain, i.e. a DNS zone. We ignore everything we don't
+// need.
+type domain struct {
+	ID     int    `json:"id"`
+	Domain string `json:"domain"`
+}
+
+// domainRecord is a record in a Linode domain. Names are relative to the
+// domain, and the name of records at the apex is empty.
+type domainRecord struct {
+	ID     int    `json:"id,omitempty"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target string `json:"target"`
+	TTLSec int    `json:"ttl_sec,omitempty"`
+}
+
+// page is a page of a paginated list returned by the Linode API.
+type page struct {
+	Data  json.RawMessage `json:"data"`
+	Page  int             `json:"page"`
+	Pages int             `json:"pages"`
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge
+func (c *DNSProvider) Present(domain, fqdn, value string) error {
+	zone, name, err := c.findDomain(fqdn)
+	if err != nil {
+		return err
+	}
+
+	records, err := c.findTxtRecords(zone.ID, name)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if record.Target == value {
+			// the record has already been created
+			return nil
+		}
+	}
+
+	// Linode rounds the TTL up to the nearest supported value, the lowest
+	// of which is 300 seconds.
+	return c.makeRequest(http.MethodPost, fmt.Sprintf("/domains/%d/records", zone.ID), nil, &domainRecord{
+		Type:   "TXT",
+		Name:   name,
+		Target: value,
+		TTLSec: 300,
+	}, nil)
+}
+
+// CleanUp removes the TXT record matching the specified parameters
+func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
+	zone, name, err := c.findDomain(fqdn)
+	if err != nil {
+		return err
+	}
+
+	records, err := c.findTxtRecords(zone.ID, name)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		// Only remove the record for this challenge, as other challenges for
+		// the same domain (e.g. a wildcard and its apex) share the same name.
+		if record.Target != value {
+			continue
+		}
+		if err := c.makeRequest(http.MethodDelete, fmt.Sprintf("/domains/%d/records/%d", zone.ID, record.ID), nil, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListTXTRecords returns the values of all TXT records that exist at the
+// given fqdn.
+func (c *DNSProvider) ListTXTRecords(fqdn string) ([]string, error) {
+	zone, name, err := c.findDomain(fqdn)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := c.findTxtRecords(zone.ID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, record := range records {
+		values = append(values, record.Target)
+	}
+	return values, nil
+}
+
+// findDomain returns the Linode domain which hosts the given fqdn, and the
+// name of the fqdn relative to that domain.
+func (c *DNSProvider) findDomain(fqdn string) (*domain, string, error) {
+	zoneName, err := c.findZoneByFqdn(fqdn, c.dns01Nameservers)
+	if err != nil {
+		return nil, "", err
+	}
+	zoneName = util.UnFqdn(zoneName)
+
+	filter, err := json.Marshal(map[string]string{"domain": zoneName})
+	if err != nil {
+		return nil, "", err
+	}
+	var domains []domain
+	if err := c.list("/domains", http.Header{"X-Filter": []string{string(filter)}}, &domains); err != nil {
+		return nil, "", err
+	}
+	for i := range domains {
+		if strings.EqualFold(domains[i].Domain, zoneName) {
+			name := strings.TrimSuffix(strings.TrimSuffix(util.UnFqdn(fqdn), zoneName), ".")
+			return &domains[i], name, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("Linode domain %q for %q not found, make sure the token has access to it", zoneName, fqdn)
+}
+
+// findTxtRecords returns the TXT records with the given name in the domain
+// with the given ID.
+func (c *DNSProvider) findTxtRecords(domainID int, name string) ([]domainRecord, error) {
+	var all []domainRecord
+	if err := c.list(fmt.Sprintf("/domains/%d/records", domainID), nil, &all); err != nil {
+		return nil, err
+	}
+
+	var records []domainRecord
+	for _, record := range all {
+		if record.Type == "TXT" && strings.EqualFold(record.Name, name) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// list fetches every page of the given paginated list into out, which must
+// be a pointer to a slice.
+func (c *DNSProvider) list(path string, header http.Header, out interface{}) error {
+	var items []json.RawMessage
+	for pageNumber := 1; ; pageNumber++ {
+		var p page
+		if err := c.makeRequest(http.MethodGet, fmt.Sprintf("%s?page=%d&page_size=500", path, pageNumber), header, nil, &p); err != nil {
+			return err
+		}
+
+		var pageItems []json.RawMessage
+		if err := json.Unmarshal(p.Data, &pageItems); err != nil {
+			return fmt.Errorf("while decoding the Linode API response for %q: %v", path, err)
+		}
+		items = append(items, pageItems...)
+
+		if p.Page >= p.Pages {
+			break
+		}
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (c *DNSProvider) makeRequest(method, path string, header http.Header, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("while querying the Linode API for %s %q: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// errors contains the details of a failed request
+		var apiErr struct {
+			Errors []struct {
+				Field  string `json:"field,omitempty"`
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || len(apiErr.Errors) == 0 {
+			return fmt.Errorf("while querying the Linode API for %s %q: unexpected status code %d", method, path, resp.StatusCode)
+		}
+		var reasons []string
+		for _, e := range apiErr.Errors {
+			if e.Field != "" {
+				reasons = append(reasons, e.Field+": "+e.Reason)
+			} else {
+				reasons = append(reasons, e.Reason)
+			}
+		}
+		return fmt.Errorf("while querying the Linode API for %s %q: %d: %s", method, path, resp.StatusCode, strings.Join(reasons, ", "))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("while decoding the Linode API response for %s %q: %v", method, path, err)
+	}
+	return nil
+}
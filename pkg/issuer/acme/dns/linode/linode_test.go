@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linode
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// fakeLinode is a fake of the parts of the Linode API used by the provider,
+// hosting the single domain example.com.
+type fakeLinode struct {
+	lock    sync.Mutex
+	nextID  int
+	records map[int]domainRecord
+}
+
+func (f *fakeLinode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors":[{"reason":"Invalid Token"}]}`)
+		return
+	}
+
+	writePage := func(data interface{}) {
+		raw, _ := json.Marshal(data)
+		_ = json.NewEncoder(w).Encode(page{Data: raw, Page: 1, Pages: 1})
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/domains":
+		var filter map[string]string
+		_ = json.Unmarshal([]byte(r.Header.Get("X-Filter")), &filter)
+		domains := []domain{}
+		if filter["domain"] == "example.com" {
+			domains = append(domains, domain{ID: 1, Domain: "example.com"})
+		}
+		writePage(domains)
+	case r.Method == http.MethodGet && r.URL.Path == "/domains/1/records":
+		records := []domainRecord{}
+		for _, record := range f.records {
+			records = append(records, record)
+		}
+		writePage(records)
+	case r.Method == http.MethodPost && r.URL.Path == "/domains/1/records":
+		var record domainRecord
+		_ = json.NewDecoder(r.Body).Decode(&record)
+		f.nextID++
+		record.ID = f.nextID
+		f.records[record.ID] = record
+		_ = json.NewEncoder(w).Encode(record)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/domains/1/records/"):
+		id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/domains/1/records/"))
+		delete(f.records, id)
+		fmt.Fprint(w, `{}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"reason":"Not found"}]}`)
+	}
+}
+
+func newTestProvider(t *testing.T, token string, records ...domainRecord) (*DNSProvider, *fakeLinode) {
+	fake := &fakeLinode{records: map[int]domainRecord{}}
+	for _, record := range records {
+		fake.nextID++
+		record.ID = fake.nextID
+		fake.records[record.ID] = record
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSProviderCredentials(token, util.RecursiveNameservers)
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+	provider.findZoneByFqdn = func(fqdn string, _ []string) (string, error) {
+		return "example.com.", nil
+	}
+	return provider, fake
+}
+
+func TestNewDNSProviderMissingCredErr(t *testing.T) {
+	_, err := NewDNSProviderCredentials("", util.RecursiveNameservers)
+	assert.EqualError(t, err, "Linode token missing")
+}
+
+func TestPresent(t *testing.T) {
+	provider, fake := newTestProvider(t, "token",
+		domainRecord{Type: "TXT", Name: "_acme-challenge.www", Target: "other"},
+	)
+
+	require.NoError(t, provider.Present("www.example.com", "_acme-challenge.www.example.com.", "value"))
+	// Presenting the same record again should be a no-op.
+	require.NoError(t, provider.Present("www.example.com", "_acme-challenge.www.example.com.", "value"))
+
+	assert.Len(t, fake.records, 2)
+	assert.Equal(t, domainRecord{ID: 2, Type: "TXT", Name: "_acme-challenge.www", Target: "value", TTLSec: 300}, fake.records[2])
+
+	values, err := provider.ListTXTRecords("_acme-challenge.www.example.com.")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"other", "value"}, values)
+}
+
+func TestCleanUp(t *testing.T) {
+	provider, fake := newTestProvider(t, "token",
+		domainRecord{Type: "TXT", Name: "_acme-challenge", Target: "value"},
+		domainRecord{Type: "TXT", Name: "_acme-challenge", Target: "wildcard-value"},
+		domainRecord{Type: "TXT", Name: "_acme-challenge.www", Target: "value"},
+	)
+
+	require.NoError(t, provider.CleanUp("example.com", "_acme-challenge.example.com.", "value"))
+
+	assert.Equal(t, map[int]domainRecord{
+		2: {ID: 2, Type: "TXT", Name: "_acme-challenge", Target: "wildcard-value"},
+		3: {ID: 3, Type: "TXT", Name: "_acme-challenge.www", Target: "value"},
+	}, fake.records)
+}
+
+func TestAPIError(t *testing.T) {
+	provider, _ := newTestProvider(t, "invalid")
+
+	err := provider.Present("example.com", "_acme-challenge.example.com.", "value")
+	assert.EqualError(t, err, `while querying the Linode API for GET "/domains?page=1&page_size=500": 401: Invalid Token`)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// txtRecordLister is an optional interface that may be implemented by a
+// solver in order to enumerate the values of the TXT records that currently
+// exist at a given FQDN. Only solvers that implement this interface can have
+// orphaned challenge records removed.
+type txtRecordLister interface {
+	ListTXTRecords(fqdn string) ([]string, error)
+}
+
+// ownerRecordPrefix is the prefix of the TXT record value that is presented
+// alongside each challenge record by solvers that are able to list records.
+// It marks the challenge record as owned by an installation of cert-manager,
+// so that records created by other ACME clients, or by other installations
+// of cert-manager, are never removed by the orphan reaper.
+const ownerRecordPrefix = "cert-manager-owner="
+
+// defaultOwner is the owner used by installations of cert-manager that have
+// no controller class configured.
+const defaultOwner = "cert-manager"
+
+// dns01RecordValueRegexp matches the value of a TXT record as created for an
+// ACME DNS01 challenge, which is the unpadded base64url encoding of a SHA-256
+// digest (RFC 8555, section 8.4).
+var dns01RecordValueRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+
+// IsDNS01RecordValue returns true if the given TXT record value has the form
+// of an ACME DNS01 challenge record.
+func IsDNS01RecordValue(value string) bool {
+	return dns01RecordValueRegexp.MatchString(value)
+}
+
+// OwnerRecordValue returns the value of the TXT record which marks the
+// challenge record with the given key as owned by owner.
+func OwnerRecordValue(owner, key string) string {
+	return fmt.Sprintf("%s%s;key=%s", ownerRecordPrefix, owner, key)
+}
+
+// parseOwnerRecordValue returns the owner and challenge key encoded in an
+// owner record value. ok is false if value is not an owner record.
+func parseOwnerRecordValue(value string) (owner, key string, ok bool) {
+	if !strings.HasPrefix(value, ownerRecordPrefix) {
+		return "", "", false
+	}
+	owner, key, ok = strings.Cut(strings.TrimPrefix(value, ownerRecordPrefix), ";key=")
+	if !ok || len(owner) == 0 || !IsDNS01RecordValue(key) {
+		return "", "", false
+	}
+	return owner, key, true
+}
+
+// owner returns the owner that is recorded against the challenge records
+// presented by this installation of cert-manager. Installations that run in
+// the same cluster are distinguished by their controller class.
+func (s *Solver) owner() string {
+	if len(s.ControllerClass) > 0 {
+		return s.ControllerClass
+	}
+	return defaultOwner
+}
+
+// orphanedRecord is a challenge record that is owned by this installation
+// of cert-manager but is no longer in use by any Challenge.
+type orphanedRecord struct {
+	// key is the value of the challenge record.
+	key string
+	// ownerValue is the value of the owner record that marks key as owned.
+	ownerValue string
+	// presented is true if the challenge record itself still exists. It may
+	// not if a previous clean up was interrupted.
+	presented bool
+}
+
+// orphanedRecords returns the orphaned challenge records in a list of TXT
+// record values. A challenge record is only considered orphaned if it is
+// marked as owned by owner and isLive returns false for its key.
+func orphanedRecords(values []string, owner string, isLive func(key string) bool) []orphanedRecord {
+	present := make(map[string]bool, len(values))
+	for _, value := range values {
+		present[value] = true
+	}
+
+	var orphaned []orphanedRecord
+	for _, value := range values {
+		recordOwner, key, ok := parseOwnerRecordValue(value)
+		if !ok || recordOwner != owner || isLive(key) {
+			continue
+		}
+		orphaned = append(orphaned, orphanedRecord{key: key, ownerValue: value, presented: present[key]})
+	}
+	return orphaned
+}
+
+// CleanUpOrphans lists the TXT records at the FQDN that is used to solve the
+// given Challenge, and removes any ACME DNS01 challenge records that are
+// owned by this installation of cert-manager and for which `isLive` returns
+// false. Records without a matching owner record are never removed.
+// isLive is called only after the records have been listed, so that records
+// that are presented concurrently by a newly observed Challenge are not
+// removed.
+// Solvers that are not able to list records are ignored. The number of
+// challenge records that were removed is returned.
+func (s *Solver) CleanUpOrphans(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge, isLive func(key string) bool) (int, error) {
+	log := logf.WithResource(logf.FromContext(ctx, "CleanUpOrphans"), ch).WithValues("domain", ch.Spec.DNSName)
+	ctx = logf.NewContext(ctx, log)
+
+	providerConfig, err := extractChallengeSolverConfig(ch)
+	if err != nil {
+		return 0, err
+	}
+
+	// Solvers implemented using the webhook.Solver interface have no way of
+	// listing the records that they manage.
+	if _, _, err := s.dns01SolverForConfig(providerConfig); err != errNotFound {
+		log.V(logf.DebugLevel).Info("solver does not support listing records, skipping orphan clean up")
+		return 0, nil
+	}
+
+	slv, providerConfig, err := s.solverForChallenge(ctx, issuer, ch)
+	if err != nil {
+		return 0, err
+	}
+
+	lister, ok := slv.(txtRecordLister)
+	if !ok {
+		log.V(logf.DebugLevel).Info("solver does not support listing records, skipping orphan clean up")
+		return 0, nil
+	}
+
+	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	if err != nil {
+		return 0, err
+	}
+
+	values, err := lister.ListTXTRecords(fqdn)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
+	var errs []error
+	for _, orphan := range orphanedRecords(values, s.owner(), isLive) {
+		if orphan.presented {
+			log.V(logf.InfoLevel).Info("removing orphaned DNS01 challenge record", "fqdn", fqdn)
+			if err := slv.CleanUp(ch.Spec.DNSName, fqdn, orphan.key); err != nil {
+				// Keep the owner record so that removal is retried.
+				errs = append(errs, err)
+				continue
+			}
+			removed++
+		}
+
+		// The owner record is removed last so that the challenge record is
+		// never left behind without one.
+		if err := slv.CleanUp(ch.Spec.DNSName, fqdn, orphan.ownerValue); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return removed, utilerrors.NewAggregate(errs)
+}
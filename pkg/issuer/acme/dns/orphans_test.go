@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsDNS01RecordValue(t *testing.T) {
+	tests := map[string]bool{
+		// base64url(sha256("")) without padding
+		"47DEQpj8HBSa-_TImW-5JCeuQeRkm5NMpJWZG3hSuFU":  true,
+		"47DEQpj8HBSa-_TImW-5JCeuQeRkm5NMpJWZG3hSuFU=": false,
+		"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU":  false,
+		"v=spf1 include:example.com ~all":              false,
+		"":                                             false,
+	}
+	for value, expected := range tests {
+		if got := IsDNS01RecordValue(value); got != expected {
+			t.Errorf("IsDNS01RecordValue(%q) = %t, expected %t", value, got, expected)
+		}
+	}
+}
+
+func TestParseOwnerRecordValue(t *testing.T) {
+	const key = "47DEQpj8HBSa-_TImW-5JCeuQeRkm5NMpJWZG3hSuFU"
+
+	owner, parsedKey, ok := parseOwnerRecordValue(OwnerRecordValue("cert-manager", key))
+	if !ok || owner != "cert-manager" || parsedKey != key {
+		t.Errorf("unexpected result parsing owner record: owner=%q key=%q ok=%t", owner, parsedKey, ok)
+	}
+
+	for _, value := range []string{
+		key,
+		"cert-manager-owner=;key=" + key,
+		"cert-manager-owner=cert-manager",
+		"cert-manager-owner=cert-manager;key=not-a-key",
+		"v=spf1 include:example.com ~all",
+	} {
+		if _, _, ok := parseOwnerRecordValue(value); ok {
+			t.Errorf("expected %q to not be parsed as an owner record", value)
+		}
+	}
+}
+
+func TestOrphanedRecords(t *testing.T) {
+	const (
+		liveKey    = "47DEQpj8HBSa-_TImW-5JCeuQeRkm5NMpJWZG3hSuFU"
+		orphanKey  = "LPJNul-wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ"
+		foreignKey = "ypeBEsobvcr6wjGzmiPcTaeG7_gUfE5yuYB3ha_uSLs"
+	)
+	isLive := func(key string) bool { return key == liveKey }
+
+	tests := map[string]struct {
+		values   []string
+		expected []orphanedRecord
+	}{
+		"records without an owner record are never orphaned": {
+			values: []string{liveKey, orphanKey, foreignKey},
+		},
+		"records owned by another installation are not orphaned": {
+			values: []string{orphanKey, OwnerRecordValue("other", orphanKey)},
+		},
+		"live records are not orphaned": {
+			values: []string{liveKey, OwnerRecordValue("cert-manager", liveKey)},
+		},
+		"owned records that are not live are orphaned": {
+			values: []string{liveKey, OwnerRecordValue("cert-manager", liveKey), orphanKey, OwnerRecordValue("cert-manager", orphanKey), foreignKey},
+			expected: []orphanedRecord{
+				{key: orphanKey, ownerValue: OwnerRecordValue("cert-manager", orphanKey), presented: true},
+			},
+		},
+		"owner records left behind by an interrupted clean up are orphaned": {
+			values: []string{OwnerRecordValue("cert-manager", orphanKey)},
+			expected: []orphanedRecord{
+				{key: orphanKey, ownerValue: OwnerRecordValue("cert-manager", orphanKey), presented: false},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := orphanedRecords(test.values, "cert-manager", isLive)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected orphaned records, expected %+v, got %+v", test.expected, got)
+			}
+		})
+	}
+}
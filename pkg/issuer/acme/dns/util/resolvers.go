@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// dohPrefix is the prefix of nameservers which are queried using
+	// DNS-over-HTTPS (RFC 8484), e.g. https://dns.google/dns-query.
+	dohPrefix = "https://"
+
+	// dotPrefix is the prefix of nameservers which are queried using
+	// DNS-over-TLS (RFC 7858), e.g. tls://1.1.1.1:853.
+	dotPrefix = "tls://"
+
+	// dohMediaType is the media type of DNS messages sent and received
+	// using DNS-over-HTTPS.
+	dohMediaType = "application/dns-message"
+
+	// maxDoHResponseSize is the maximum size of a DNS-over-HTTPS response,
+	// which is the maximum size of a DNS message.
+	maxDoHResponseSize = 65535
+)
+
+// dohTransport is used to be able to mock the transport of DNS-over-HTTPS
+// queries.
+var dohTransport http.RoundTripper = http.DefaultTransport
+
+// ValidateNameserver checks that a recursive nameserver is either the
+// address of a nameserver queried over UDP and TCP in the form host:port, the
+// URL of a DNS-over-HTTPS endpoint starting with https://, or the address of
+// a DNS-over-TLS nameserver in the form tls://host:port.
+func ValidateNameserver(nameserver string) error {
+	switch {
+	case strings.HasPrefix(nameserver, dohPrefix):
+		u, err := url.Parse(nameserver)
+		if err != nil {
+			return err
+		}
+		if u.Host == "" {
+			return fmt.Errorf("missing host in DNS-over-HTTPS URL %q", nameserver)
+		}
+		return nil
+	case strings.HasPrefix(nameserver, dotPrefix):
+		_, _, err := net.SplitHostPort(strings.TrimPrefix(nameserver, dotPrefix))
+		return err
+	default:
+		_, _, err := net.SplitHostPort(nameserver)
+		return err
+	}
+}
+
+// exchange sends the query to the given nameserver, using the transport
+// selected by the prefix of the nameserver. Plain nameservers are queried
+// over UDP, falling back to TCP if the response is truncated or the query
+// times out.
+func exchange(m *dns.Msg, nameserver string) (*dns.Msg, error) {
+	switch {
+	case strings.HasPrefix(nameserver, dohPrefix):
+		return exchangeDoH(m, nameserver)
+	case strings.HasPrefix(nameserver, dotPrefix):
+		tls := &dns.Client{Net: "tcp-tls", Timeout: DNSTimeout}
+		in, _, err := tls.Exchange(m, strings.TrimPrefix(nameserver, dotPrefix))
+		return in, err
+	}
+
+	udp := &dns.Client{Net: "udp", Timeout: DNSTimeout}
+	in, _, err := udp.Exchange(m, nameserver)
+
+	if (in != nil && in.Truncated) ||
+		(err != nil && strings.HasPrefix(err.Error(), "read udp") && strings.HasSuffix(err.Error(), "i/o timeout")) {
+		logf.V(logf.DebugLevel).Infof("UDP dns lookup failed, retrying with TCP: %v", err)
+		tcp := &dns.Client{Net: "tcp", Timeout: DNSTimeout}
+		// If the TCP request succeeds, the err will reset to nil
+		in, _, err = tcp.Exchange(m, nameserver)
+	}
+	return in, err
+}
+
+// exchangeDoH sends the query to the DNS-over-HTTPS endpoint at the given URL
+// using the POST method.
+func exchangeDoH(m *dns.Msg, endpoint string) (*dns.Msg, error) {
+	// RFC 8484 recommends a message ID of 0 to make responses cacheable.
+	query := m.Copy()
+	query.Id = 0
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dohMediaType)
+	req.Header.Set("Accept", dohMediaType)
+
+	client := &http.Client{Transport: dohTransport, Timeout: DNSTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DNS-over-HTTPS endpoint %s returned status code %d", endpoint, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDoHResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	in := new(dns.Msg)
+	if err := in.Unpack(body); err != nil {
+		return nil, fmt.Errorf("invalid response from DNS-over-HTTPS endpoint %s: %v", endpoint, err)
+	}
+	in.Id = m.Id
+	return in, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNameserver(t *testing.T) {
+	tests := map[string]struct {
+		nameserver string
+		expErr     bool
+	}{
+		"host and port": {
+			nameserver: "8.8.8.8:53",
+		},
+		"in-cluster service": {
+			nameserver: "kube-dns.kube-system.svc.cluster.local:53",
+		},
+		"missing port": {
+			nameserver: "8.8.8.8",
+			expErr:     true,
+		},
+		"DNS-over-HTTPS": {
+			nameserver: "https://dns.google/dns-query",
+		},
+		"DNS-over-HTTPS without host": {
+			nameserver: "https:///dns-query",
+			expErr:     true,
+		},
+		"DNS-over-TLS": {
+			nameserver: "tls://1.1.1.1:853",
+		},
+		"DNS-over-TLS without port": {
+			nameserver: "tls://1.1.1.1",
+			expErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateNameserver(test.nameserver)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDNSQueryDoH(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != dohMediaType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		query := new(dns.Msg)
+		require.NoError(t, query.Unpack(body))
+		assert.Equal(t, uint16(0), query.Id)
+
+		resp := new(dns.Msg)
+		resp.SetReply(query)
+		resp.Answer = append(resp.Answer, &dns.TXT{
+			Hdr: dns.RR_Header{Name: query.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60},
+			Txt: []string{"value"},
+		})
+		packed, err := resp.Pack()
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", dohMediaType)
+		_, _ = w.Write(packed)
+	}))
+	defer server.Close()
+
+	defer func(transport http.RoundTripper) { dohTransport = transport }(dohTransport)
+	dohTransport = server.Client().Transport
+
+	in, err := DNSQuery("_acme-challenge.example.com.", dns.TypeTXT, []string{server.URL + "/dns-query"}, true)
+	require.NoError(t, err)
+	require.Len(t, in.Answer, 1)
+	assert.Equal(t, []string{"value"}, in.Answer[0].(*dns.TXT).Txt)
+
+	found, err := checkNameserver("_acme-challenge.example.com.", "value", []string{server.URL + "/dns-query"})
+	require.NoError(t, err)
+	assert.True(t, found)
+}
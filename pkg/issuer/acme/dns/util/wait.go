@@ -18,10 +18,11 @@ import (
 	"github.com/miekg/dns"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmutil "github.com/cert-manager/cert-manager/pkg/util"
 )
 
 type preCheckDNSFunc func(fqdn, value string, nameservers []string,
-	useAuthoritative bool) (bool, error)
+	useAuthoritative bool, ipFamily cmutil.IPFamilyPolicy) (bool, error)
 type dnsQueryFunc func(fqdn string, rtype uint16, nameservers []string, recursive bool) (in *dns.Msg, err error)
 
 var (
@@ -101,8 +102,9 @@ func followCNAMEs(fqdn string, nameservers []string, fqdnChain ...string) (strin
 }
 
 // checkDNSPropagation checks if the expected TXT record has been propagated to all authoritative nameservers.
+// The addresses of authoritative nameservers are selected according to ipFamily.
 func checkDNSPropagation(fqdn, value string, nameservers []string,
-	useAuthoritative bool) (bool, error) {
+	useAuthoritative bool, ipFamily cmutil.IPFamilyPolicy) (bool, error) {
 
 	var err error
 	fqdn, err = followCNAMEs(fqdn, nameservers)
@@ -119,46 +121,102 @@ func checkDNSPropagation(fqdn, value string, nameservers []string,
 		return false, err
 	}
 
-	for i, ans := range authoritativeNss {
-		authoritativeNss[i] = net.JoinHostPort(ans, "53")
+	for _, ans := range authoritativeNss {
+		addrs, err := nameserverAddrs(ans, nameservers, ipFamily)
+		if err != nil {
+			return false, err
+		}
+		found, err := checkNameserver(fqdn, value, addrs)
+		if err != nil || !found {
+			return false, err
+		}
 	}
-	return checkAuthoritativeNss(fqdn, value, authoritativeNss)
+	return true, nil
 }
 
 // checkAuthoritativeNss queries each of the given nameservers for the expected TXT record.
 func checkAuthoritativeNss(fqdn, value string, nameservers []string) (bool, error) {
 	for _, ns := range nameservers {
-		r, err := DNSQuery(fqdn, dns.TypeTXT, []string{ns}, true)
-		if err != nil {
+		found, err := checkNameserver(fqdn, value, []string{ns})
+		if err != nil || !found {
 			return false, err
 		}
+	}
 
-		// NXDomain response is not really an error, just waiting for propagation to happen
-		if !(r.Rcode == dns.RcodeSuccess || r.Rcode == dns.RcodeNameError) {
-			return false, fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn)
+	return true, nil
+}
+
+// checkNameserver queries a single nameserver, reachable at any of the given
+// addresses, for the expected TXT record.
+func checkNameserver(fqdn, value string, addrs []string) (bool, error) {
+	r, err := DNSQuery(fqdn, dns.TypeTXT, addrs, true)
+	if err != nil {
+		return false, err
+	}
+
+	// NXDomain response is not really an error, just waiting for propagation to happen
+	if !(r.Rcode == dns.RcodeSuccess || r.Rcode == dns.RcodeNameError) {
+		return false, fmt.Errorf("NS %s returned %s for %s", strings.Join(addrs, ","), dns.RcodeToString[r.Rcode], fqdn)
+	}
+
+	logf.V(logf.DebugLevel).Infof("Looking up TXT records for %q", fqdn)
+	for _, rr := range r.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			if strings.Join(txt.Txt, "") == value {
+				return true, nil
+			}
 		}
+	}
+
+	return false, nil
+}
+
+// nameserverAddrs returns the addresses that the nameserver with the given
+// host name should be queried on, in the order that they should be tried.
+// Both the A and AAAA records of the host are looked up using the given
+// nameservers, and ordered according to ipFamily. If no IP family is
+// configured, the host name is returned as-is to be resolved by the system
+// resolver.
+func nameserverAddrs(host string, nameservers []string, ipFamily cmutil.IPFamilyPolicy) ([]string, error) {
+	if len(ipFamily.Family) == 0 {
+		return []string{net.JoinHostPort(host, "53")}, nil
+	}
 
-		logf.V(logf.DebugLevel).Infof("Looking up TXT records for %q", fqdn)
-		var found bool
+	var ips []net.IP
+	for _, rtype := range []uint16{dns.TypeAAAA, dns.TypeA} {
+		r, err := dnsQuery(host, rtype, nameservers, true)
+		if err != nil || r.Rcode != dns.RcodeSuccess {
+			logf.V(logf.DebugLevel).Infof("Failed to look up %s records for nameserver %q: %v", dns.TypeToString[rtype], host, err)
+			continue
+		}
 		for _, rr := range r.Answer {
-			if txt, ok := rr.(*dns.TXT); ok {
-				if strings.Join(txt.Txt, "") == value {
-					found = true
-					break
-				}
+			switch rr := rr.(type) {
+			case *dns.A:
+				ips = append(ips, rr.A)
+			case *dns.AAAA:
+				ips = append(ips, rr.AAAA)
 			}
 		}
+	}
 
-		if !found {
-			return false, nil
+	ips = ipFamily.OrderIPs(ips)
+	if len(ips) == 0 {
+		if ipFamily.Required {
+			return nil, fmt.Errorf("Could not find any %s addresses for nameserver %q", ipFamily.Family, host)
 		}
+		return []string{net.JoinHostPort(host, "53")}, nil
 	}
 
-	return true, nil
+	addrs := make([]string, len(ips))
+	for i, ip := range ips {
+		addrs[i] = net.JoinHostPort(ip.String(), "53")
+	}
+	return addrs, nil
 }
 
 // DNSQuery will query a nameserver, iterating through the supplied servers as it retries
 // The nameserver should include a port, to facilitate testing where we talk to a mock dns server.
+// Nameservers may also be DNS-over-HTTPS or DNS-over-TLS endpoints, see ValidateNameserver.
 func DNSQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (in *dns.Msg, err error) {
 	m := new(dns.Msg)
 	m.SetQuestion(fqdn, rtype)
@@ -171,17 +229,7 @@ func DNSQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (
 	// Will retry the request based on the number of servers (n+1)
 	for i := 1; i <= len(nameservers)+1; i++ {
 		ns := nameservers[i%len(nameservers)]
-		udp := &dns.Client{Net: "udp", Timeout: DNSTimeout}
-		in, _, err = udp.Exchange(m, ns)
-
-		if (in != nil && in.Truncated) ||
-			(err != nil && strings.HasPrefix(err.Error(), "read udp") && strings.HasSuffix(err.Error(), "i/o timeout")) {
-			logf.V(logf.DebugLevel).Infof("UDP dns lookup failed, retrying with TCP: %v", err)
-			tcp := &dns.Client{Net: "tcp", Timeout: DNSTimeout}
-			// If the TCP request succeeds, the err will reset to nil
-			in, _, err = tcp.Exchange(m, ns)
-		}
-
+		in, err = exchange(m, ns)
 		if err == nil {
 			break
 		}
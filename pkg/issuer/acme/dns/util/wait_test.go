@@ -10,12 +10,16 @@ package util
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"sort"
 	"strings"
 	"testing"
 
 	"github.com/miekg/dns"
+	corev1 "k8s.io/api/core/v1"
+
+	cmutil "github.com/cert-manager/cert-manager/pkg/util"
 )
 
 var lookupNameserversTestsOK = []struct {
@@ -167,7 +171,7 @@ func TestMatchCAA(t *testing.T) {
 
 func TestPreCheckDNS(t *testing.T) {
 	// TODO: find a better TXT record to use in tests
-	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"8.8.8.8:53"}, true)
+	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"8.8.8.8:53"}, true, cmutil.IPFamilyPolicy{})
 	if err != nil || !ok {
 		t.Errorf("preCheckDNS failed for acme-staging.api.letsencrypt.org: %s", err.Error())
 	}
@@ -175,7 +179,7 @@ func TestPreCheckDNS(t *testing.T) {
 
 func TestPreCheckDNSNonAuthoritative(t *testing.T) {
 	// TODO: find a better TXT record to use in tests
-	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"1.1.1.1:53"}, false)
+	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"1.1.1.1:53"}, false, cmutil.IPFamilyPolicy{})
 	if err != nil || !ok {
 		t.Errorf("preCheckDNS failed for acme-staging.api.letsencrypt.org: %s", err.Error())
 	}
@@ -391,3 +395,72 @@ func Test_followCNAMEs(t *testing.T) {
 		})
 	}
 }
+
+func Test_nameserverAddrs(t *testing.T) {
+	dnsQuery = func(fqdn string, rtype uint16, nameservers []string, recursive bool) (in *dns.Msg, err error) {
+		msg := &dns.Msg{}
+		msg.Rcode = dns.RcodeSuccess
+		switch {
+		case fqdn == "ns.example.com." && rtype == dns.TypeA:
+			msg.Answer = []dns.RR{&dns.A{A: net.ParseIP("192.0.2.1")}}
+		case fqdn == "ns.example.com." && rtype == dns.TypeAAAA:
+			msg.Answer = []dns.RR{&dns.AAAA{AAAA: net.ParseIP("2001:db8::1")}}
+		case fqdn == "ns4.example.com." && rtype == dns.TypeA:
+			msg.Answer = []dns.RR{&dns.A{A: net.ParseIP("192.0.2.2")}}
+		}
+		return msg, nil
+	}
+	defer func() {
+		// restore the mock
+		dnsQuery = DNSQuery
+	}()
+
+	tests := map[string]struct {
+		host     string
+		ipFamily cmutil.IPFamilyPolicy
+		want     []string
+		wantErr  bool
+	}{
+		"no IP family returns the host name": {
+			host: "ns.example.com.",
+			want: []string{"ns.example.com.:53"},
+		},
+		"IPv6 preferred": {
+			host:     "ns.example.com.",
+			ipFamily: cmutil.NewIPFamilyPolicy("", corev1.IPv6Protocol),
+			want:     []string{"[2001:db8::1]:53", "192.0.2.1:53"},
+		},
+		"IPv4 preferred": {
+			host:     "ns.example.com.",
+			ipFamily: cmutil.NewIPFamilyPolicy("", corev1.IPv4Protocol),
+			want:     []string{"192.0.2.1:53", "[2001:db8::1]:53"},
+		},
+		"IPv6 required": {
+			host:     "ns.example.com.",
+			ipFamily: cmutil.NewIPFamilyPolicy(corev1.IPv6Protocol, corev1.IPv4Protocol),
+			want:     []string{"[2001:db8::1]:53"},
+		},
+		"IPv6 required but nameserver only has IPv4 addresses": {
+			host:     "ns4.example.com.",
+			ipFamily: cmutil.NewIPFamilyPolicy(corev1.IPv6Protocol, ""),
+			wantErr:  true,
+		},
+		"no addresses found falls back to the host name": {
+			host:     "unknown.example.com.",
+			ipFamily: cmutil.NewIPFamilyPolicy("", corev1.IPv6Protocol),
+			want:     []string{"unknown.example.com.:53"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := nameserverAddrs(tt.host, []string{"127.0.0.1:53"}, tt.ipFamily)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nameserverAddrs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nameserverAddrs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
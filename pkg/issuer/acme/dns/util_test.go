@@ -28,8 +28,10 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/linode"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/vultr"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
@@ -155,6 +157,14 @@ func newFakeDNSProviders() *fakeDNSProviders {
 			f.call("digitalocean", token, util.RecursiveNameservers)
 			return nil, nil
 		},
+		linode: func(token string, dns01Nameservers []string) (*linode.DNSProvider, error) {
+			f.call("linode", token, util.RecursiveNameservers)
+			return nil, nil
+		},
+		vultr: func(apiKey string, dns01Nameservers []string) (*vultr.DNSProvider, error) {
+			f.call("vultr", apiKey, util.RecursiveNameservers)
+			return nil, nil
+		},
 	}
 	return f
 }
@@ -0,0 +1,252 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vultr implements a DNS provider for solving the DNS-01 challenge
+// using Vultr DNS.
+package vultr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// vultrAPIURL is the base URL of version 2 of the Vultr API.
+const vultrAPIURL = "https://api.vultr.com/v2"
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	dns01Nameservers []string
+	apiKey           string
+	baseURL          string
+	client           *http.Client
+
+	findZoneByFqdn func(fqdn string, nameservers []string) (string, error)
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Vultr.
+// The API key must be passed in the environment variable VULTR_API_KEY.
+func NewDNSProvider(dns01Nameservers []string) (*DNSProvider, error) {
+	apiKey := os.Getenv("VULTR_API_KEY")
+	return NewDNSProviderCredentials(apiKey, dns01Nameservers)
+}
+
+// NewDNSProviderCredentials uses the supplied credentials to return a
+// DNSProvider instance configured for Vultr.
+func NewDNSProviderCredentials(apiKey string, dns01Nameservers []string) (*DNSProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("Vultr API key missing")
+	}
+
+	return &DNSProvider{
+		dns01Nameservers: dns01Nameservers,
+		apiKey:           apiKey,
+		baseURL:          vultrAPIURL,
+		client:           &http.Client{Timeout: 30 * time.Second},
+		findZoneByFqdn:   util.FindZoneByFqdn,
+	}, nil
+}
+
+// domainRecord is a record in a Vultr domain. Names are relative to the
+// domain, and the name of records at the apex is empty. The data of TXT
+// records is enclosed in double quotes.
+type domainRecord struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Data string `json:"data"`
+	TTL  int    `json:"ttl,omitempty"`
+}
+
+// recordsPage is a page of the records of a Vultr domain.
+type recordsPage struct {
+	Records []domainRecord `json:"records"`
+	Meta    struct {
+		Links struct {
+			Next string `json:"next"`
+		} `json:"links"`
+	} `json:"meta"`
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge
+func (c *DNSProvider) Present(domain, fqdn, value string) error {
+	zoneName, name, err := c.findZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	records, err := c.findTxtRecords(zoneName, name)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if txtValue(record) == value {
+			// the record has already been created
+			return nil
+		}
+	}
+
+	return c.makeRequest(http.MethodPost, fmt.Sprintf("/domains/%s/records", zoneName), &domainRecord{
+		Type: "TXT",
+		Name: name,
+		Data: `"` + value + `"`,
+		TTL:  120,
+	}, nil)
+}
+
+// CleanUp removes the TXT record matching the specified parameters
+func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
+	zoneName, name, err := c.findZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	records, err := c.findTxtRecords(zoneName, name)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		// Only remove the record for this challenge, as other challenges for
+		// the same domain (e.g. a wildcard and its apex) share the same name.
+		if txtValue(record) != value {
+			continue
+		}
+		if err := c.makeRequest(http.MethodDelete, fmt.Sprintf("/domains/%s/records/%s", zoneName, record.ID), nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListTXTRecords returns the values of all TXT records that exist at the
+// given fqdn.
+func (c *DNSProvider) ListTXTRecords(fqdn string) ([]string, error) {
+	zoneName, name, err := c.findZone(fqdn)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := c.findTxtRecords(zoneName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, record := range records {
+		values = append(values, txtValue(record))
+	}
+	return values, nil
+}
+
+// findZone returns the name of the zone which hosts the given fqdn, which is
+// also the name of the Vultr domain, and the name of the fqdn relative to
+// that zone.
+func (c *DNSProvider) findZone(fqdn string) (string, string, error) {
+	zoneName, err := c.findZoneByFqdn(fqdn, c.dns01Nameservers)
+	if err != nil {
+		return "", "", err
+	}
+	zoneName = util.UnFqdn(zoneName)
+	name := strings.TrimSuffix(strings.TrimSuffix(util.UnFqdn(fqdn), zoneName), ".")
+	return zoneName, name, nil
+}
+
+// findTxtRecords returns the TXT records with the given name in the given
+// domain.
+func (c *DNSProvider) findTxtRecords(zoneName, name string) ([]domainRecord, error) {
+	var records []domainRecord
+	cursor := ""
+	for {
+		query := url.Values{"per_page": []string{"500"}}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var page recordsPage
+		if err := c.makeRequest(http.MethodGet, fmt.Sprintf("/domains/%s/records?%s", zoneName, query.Encode()), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, record := range page.Records {
+			if record.Type == "TXT" && strings.EqualFold(record.Name, name) {
+				records = append(records, record)
+			}
+		}
+
+		cursor = page.Meta.Links.Next
+		if cursor == "" {
+			return records, nil
+		}
+	}
+}
+
+// txtValue returns the value of a TXT record without its enclosing quotes.
+func txtValue(record domainRecord) string {
+	return strings.TrimSuffix(strings.TrimPrefix(record.Data, `"`), `"`)
+}
+
+func (c *DNSProvider) makeRequest(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("while querying the Vultr API for %s %q: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Error == "" {
+			return fmt.Errorf("while querying the Vultr API for %s %q: unexpected status code %d", method, path, resp.StatusCode)
+		}
+		return fmt.Errorf("while querying the Vultr API for %s %q: %d: %s", method, path, resp.StatusCode, apiErr.Error)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("while decoding the Vultr API response for %s %q: %v", method, path, err)
+	}
+	return nil
+}
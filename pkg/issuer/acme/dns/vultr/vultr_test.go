@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vultr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// fakeVultr is a fake of the parts of the Vultr API used by the provider,
+// hosting the single domain example.com. It returns one record per page to
+// exercise pagination.
+type fakeVultr struct {
+	lock    sync.Mutex
+	nextID  int
+	records []domainRecord
+}
+
+func (f *fakeVultr) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer key" {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"Invalid API token.","status":401}`)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/domains/example.com/records":
+		var page recordsPage
+		page.Records = []domainRecord{}
+		i, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		if i < len(f.records) {
+			page.Records = append(page.Records, f.records[i])
+		}
+		if i+1 < len(f.records) {
+			page.Meta.Links.Next = strconv.Itoa(i + 1)
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	case r.Method == http.MethodPost && r.URL.Path == "/domains/example.com/records":
+		var record domainRecord
+		_ = json.NewDecoder(r.Body).Decode(&record)
+		f.add(record)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]domainRecord{"record": record})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/domains/example.com/records/"):
+		id := strings.TrimPrefix(r.URL.Path, "/domains/example.com/records/")
+		for i, record := range f.records {
+			if record.ID == id {
+				f.records = append(f.records[:i], f.records[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"Not found.","status":404}`)
+	}
+}
+
+func (f *fakeVultr) add(record domainRecord) {
+	f.nextID++
+	record.ID = strconv.Itoa(f.nextID)
+	f.records = append(f.records, record)
+}
+
+func newTestProvider(t *testing.T, apiKey string, records ...domainRecord) (*DNSProvider, *fakeVultr) {
+	fake := &fakeVultr{}
+	for _, record := range records {
+		fake.add(record)
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSProviderCredentials(apiKey, util.RecursiveNameservers)
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+	provider.findZoneByFqdn = func(fqdn string, _ []string) (string, error) {
+		return "example.com.", nil
+	}
+	return provider, fake
+}
+
+func TestNewDNSProviderMissingCredErr(t *testing.T) {
+	_, err := NewDNSProviderCredentials("", util.RecursiveNameservers)
+	assert.EqualError(t, err, "Vultr API key missing")
+}
+
+func TestPresent(t *testing.T) {
+	provider, fake := newTestProvider(t, "key",
+		domainRecord{Type: "A", Name: "www", Data: "192.0.2.1"},
+		domainRecord{Type: "TXT", Name: "_acme-challenge.www", Data: `"other"`},
+	)
+
+	require.NoError(t, provider.Present("www.example.com", "_acme-challenge.www.example.com.", "value"))
+	// Presenting the same record again should be a no-op.
+	require.NoError(t, provider.Present("www.example.com", "_acme-challenge.www.example.com.", "value"))
+
+	assert.Len(t, fake.records, 3)
+	assert.Equal(t, domainRecord{ID: "3", Type: "TXT", Name: "_acme-challenge.www", Data: `"value"`, TTL: 120}, fake.records[2])
+
+	values, err := provider.ListTXTRecords("_acme-challenge.www.example.com.")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"other", "value"}, values)
+}
+
+func TestCleanUp(t *testing.T) {
+	provider, fake := newTestProvider(t, "key",
+		domainRecord{Type: "TXT", Name: "_acme-challenge", Data: `"value"`},
+		domainRecord{Type: "TXT", Name: "_acme-challenge", Data: `"wildcard-value"`},
+		domainRecord{Type: "TXT", Name: "_acme-challenge.www", Data: `"value"`},
+	)
+
+	require.NoError(t, provider.CleanUp("example.com", "_acme-challenge.example.com.", "value"))
+
+	assert.Equal(t, []domainRecord{
+		{ID: "2", Type: "TXT", Name: "_acme-challenge", Data: `"wildcard-value"`},
+		{ID: "3", Type: "TXT", Name: "_acme-challenge.www", Data: `"value"`},
+	}, fake.records)
+}
+
+func TestAPIError(t *testing.T) {
+	provider, _ := newTestProvider(t, "invalid")
+
+	err := provider.Present("example.com", "_acme-challenge.example.com.", "value")
+	assert.EqualError(t, err, `while querying the Vultr API for GET "/domains/example.com/records?per_page=500": 401: Invalid API token.`)
+}
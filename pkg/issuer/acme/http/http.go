@@ -39,6 +39,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http/solver"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmutil "github.com/cert-manager/cert-manager/pkg/util"
 )
 
 const (
@@ -68,7 +69,7 @@ type Solver struct {
 	requiredPasses   int
 }
 
-type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error
+type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string, ipFamily cmutil.IPFamilyPolicy) error
 
 // NewSolver returns a new ACME HTTP01 solver for the given *controller.Context.
 func NewSolver(ctx *controller.Context) (*Solver, error) {
@@ -159,9 +160,11 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 	log = log.WithValues("url", url)
 	ctx = logf.NewContext(ctx, log)
 
+	ipFamily := cmutil.NewIPFamilyPolicy(ch.Spec.Solver.SelfCheckIPFamily, s.SelfCheckPreferredIPFamily)
+
 	log.V(logf.DebugLevel).Info("running self check multiple times to ensure challenge has propagated", "required_passes", s.requiredPasses)
 	for i := 0; i < s.requiredPasses; i++ {
-		err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, s.Context.RESTConfig.UserAgent)
+		err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, s.Context.RESTConfig.UserAgent, ipFamily)
 		if err != nil {
 			return err
 		}
@@ -199,8 +202,10 @@ func (s *Solver) buildChallengeUrl(ch *cmacme.Challenge) *url.URL {
 }
 
 // testReachability will attempt to connect to the 'domain' with 'path' and
-// check if the returned body equals 'key'
-func testReachability(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error {
+// check if the returned body equals 'key'.
+// The addresses that are connected to are selected according to 'ipFamily',
+// so that both A and AAAA records are considered for dual-stack endpoints.
+func testReachability(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string, ipFamily cmutil.IPFamilyPolicy) error {
 	log := logf.FromContext(ctx)
 	log.V(logf.DebugLevel).Info("performing HTTP01 reachability check")
 
@@ -258,14 +263,17 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 		},
 	}
 
-	if len(dnsServers) != 0 {
+	if len(dnsServers) != 0 || len(ipFamily.Family) != 0 {
 		transport.DialContext = func(ctx context.Context, network, addr string) (conn net.Conn, err error) {
-			// we need to increment a counter to iterate through the dns servers as the dialer will not
-			// return an error if the dns server is not responding.
-			counter := 0
 			dialer := &net.Dialer{
-				Timeout: 3 * time.Second,
-				Resolver: &net.Resolver{
+				Timeout: 30 * time.Second,
+			}
+			if len(dnsServers) != 0 {
+				// we need to increment a counter to iterate through the dns servers as the dialer will not
+				// return an error if the dns server is not responding.
+				counter := 0
+				dialer.Timeout = 3 * time.Second
+				dialer.Resolver = &net.Resolver{
 					PreferGo: true,
 					Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
 						d := net.Dialer{
@@ -275,9 +283,9 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 						counter++
 						return d.DialContext(ctx, network, s)
 					},
-				},
+				}
 			}
-			return dialer.DialContext(ctx, network, addr)
+			return ipFamily.DialContext(ctx, dialer, network, addr)
 		}
 	}
 	client := &http.Client{
@@ -20,24 +20,28 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"sync/atomic"
 	"testing"
 
 	"github.com/miekg/dns"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
+	cmutil "github.com/cert-manager/cert-manager/pkg/util"
 )
 
 // countReachabilityTestCalls is a wrapper function that allows us to count the number
 // of calls to a reachabilityTest.
 func countReachabilityTestCalls(counter *int, t reachabilityTest) reachabilityTest {
-	return func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error {
+	return func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string, ipFamily cmutil.IPFamilyPolicy) error {
 		*counter++
-		return t(ctx, url, key, dnsServers, userAgent)
+		return t(ctx, url, key, dnsServers, userAgent, ipFamily)
 	}
 }
 
@@ -51,18 +55,33 @@ func TestCheck(t *testing.T) {
 	tests := []testT{
 		{
 			name: "should pass",
-			reachabilityTest: func(context.Context, *url.URL, string, []string, string) error {
+			reachabilityTest: func(context.Context, *url.URL, string, []string, string, cmutil.IPFamilyPolicy) error {
 				return nil
 			},
 			expectedErr: false,
 		},
 		{
 			name: "should error",
-			reachabilityTest: func(context.Context, *url.URL, string, []string, string) error {
+			reachabilityTest: func(context.Context, *url.URL, string, []string, string, cmutil.IPFamilyPolicy) error {
 				return fmt.Errorf("failed")
 			},
 			expectedErr: true,
 		},
+		{
+			name: "should require the IP family configured on the solver",
+			reachabilityTest: func(_ context.Context, _ *url.URL, _ string, _ []string, _ string, ipFamily cmutil.IPFamilyPolicy) error {
+				if ipFamily != (cmutil.IPFamilyPolicy{Family: corev1.IPv6Protocol, Required: true}) {
+					return fmt.Errorf("unexpected IP family policy: %+v", ipFamily)
+				}
+				return nil
+			},
+			challenge: &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					Solver: cmacme.ACMEChallengeSolver{SelfCheckIPFamily: corev1.IPv6Protocol},
+				},
+			},
+			expectedErr: false,
+		},
 	}
 
 	for i := range tests {
@@ -176,7 +195,7 @@ func TestReachabilityCustomDnsServers(t *testing.T) {
 
 	for _, tt := range tests {
 		atomic.StoreInt32(&dnsServerCalled, 0)
-		err = testReachability(context.Background(), u, key, tt.dnsServers, "cert-manager-test")
+		err = testReachability(context.Background(), u, key, tt.dnsServers, "cert-manager-test", cmutil.IPFamilyPolicy{})
 		switch {
 		case err == nil:
 			t.Errorf("Expected error for testReachability, but got none")
@@ -190,3 +209,48 @@ func TestReachabilityCustomDnsServers(t *testing.T) {
 		}
 	}
 }
+
+func TestReachabilityIPFamily(t *testing.T) {
+	const key = "key"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, key)
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The test server only listens on an IPv4 address.
+	tests := map[string]struct {
+		ipFamily    cmutil.IPFamilyPolicy
+		expectedErr bool
+	}{
+		"no preference": {
+			ipFamily: cmutil.IPFamilyPolicy{},
+		},
+		"IPv6 preferred falls back to IPv4": {
+			ipFamily: cmutil.NewIPFamilyPolicy("", corev1.IPv6Protocol),
+		},
+		"IPv4 required": {
+			ipFamily: cmutil.NewIPFamilyPolicy(corev1.IPv4Protocol, ""),
+		},
+		"IPv6 required": {
+			ipFamily:    cmutil.NewIPFamilyPolicy(corev1.IPv6Protocol, ""),
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := testReachability(context.Background(), u, key, nil, "cert-manager-test", test.ipFamily)
+			if err != nil && !test.expectedErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected an error but got none")
+			}
+		})
+	}
+}
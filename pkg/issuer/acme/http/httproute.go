@@ -19,6 +19,7 @@ package http
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -148,13 +149,17 @@ func (s *Solver) checkAndUpdateGatewayHTTPRoute(ctx context.Context, ch *cmacme.
 }
 
 func generateHTTPRouteSpec(ch *cmacme.Challenge, svcName string) gwapi.HTTPRouteSpec {
+	// HTTPRoute hostnames cannot be IP addresses, so when verifying ownership
+	// of an IP the route matches requests for all hostnames instead.
+	var hostnames []gwapi.Hostname
+	if net.ParseIP(ch.Spec.DNSName) == nil {
+		hostnames = []gwapi.Hostname{gwapi.Hostname(ch.Spec.DNSName)}
+	}
 	return gwapi.HTTPRouteSpec{
 		CommonRouteSpec: gwapi.CommonRouteSpec{
 			ParentRefs: ch.Spec.Solver.HTTP01.GatewayHTTPRoute.ParentRefs,
 		},
-		Hostnames: []gwapi.Hostname{
-			gwapi.Hostname(ch.Spec.DNSName),
-		},
+		Hostnames: hostnames,
 		Rules: []gwapi.HTTPRouteRule{
 			{
 				Matches: []gwapi.HTTPRouteMatch{
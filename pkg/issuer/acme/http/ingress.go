@@ -179,8 +179,11 @@ func buildIngressResource(ch *cmacme.Challenge, svcName string) (*networkingv1.I
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
 		},
 		Spec: networkingv1.IngressSpec{
+			// Only set when explicitly configured using ingressClassName, as
+			// the `kubernetes.io/ingress.class` annotation and this field
+			// must not both be set.
 			// https://github.com/cert-manager/cert-manager/issues/4537
-			IngressClassName: nil,
+			IngressClassName: http01IngressCfg.IngressClassName,
 			Rules: []networkingv1.IngressRule{
 				{
 					Host: httpHost,
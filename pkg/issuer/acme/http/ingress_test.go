@@ -644,3 +644,56 @@ func TestOverrideNginxIngressWhitelistAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildIngressResourceIngressClass(t *testing.T) {
+	tests := map[string]struct {
+		ingress              *cmacme.ACMEChallengeSolverHTTP01Ingress
+		expectedAnnotation   *string
+		expectedIngressClass *string
+	}{
+		"should set the ingress class annotation when class is specified": {
+			ingress:            &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+			expectedAnnotation: strPtr("nginx"),
+		},
+		"should set spec.ingressClassName when ingressClassName is specified": {
+			ingress:              &cmacme.ACMEChallengeSolverHTTP01Ingress{IngressClassName: strPtr("nginx")},
+			expectedIngressClass: strPtr("nginx"),
+		},
+		"should set neither when no class is specified": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Token:   "token",
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress: test.ingress,
+						},
+					},
+				},
+			}
+
+			ing, err := buildIngressResource(ch, "fakeservice")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			annotation, ok := ing.Annotations[annotationIngressClass]
+			switch {
+			case test.expectedAnnotation == nil && ok:
+				t.Errorf("expected no %q annotation, but got %q", annotationIngressClass, annotation)
+			case test.expectedAnnotation != nil && annotation != *test.expectedAnnotation:
+				t.Errorf("expected %q annotation to be %q, but got %q", annotationIngressClass, *test.expectedAnnotation, annotation)
+			}
+
+			if !reflect.DeepEqual(ing.Spec.IngressClassName, test.expectedIngressClass) {
+				t.Errorf("unexpected spec.ingressClassName, exp=%v got=%v", test.expectedIngressClass, ing.Spec.IngressClassName)
+			}
+		})
+	}
+}
@@ -145,16 +145,71 @@ func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
 	pod := s.buildDefaultPod(ch)
 
 	// Override defaults if they have changed in the pod template.
+	var architectureImages map[string]string
 	if ch.Spec.Solver.HTTP01 != nil {
 		if ch.Spec.Solver.HTTP01.Ingress != nil {
 			pod = s.mergePodObjectMetaWithPodTemplate(pod,
 				ch.Spec.Solver.HTTP01.Ingress.PodTemplate)
+			if ch.Spec.Solver.HTTP01.Ingress.PodTemplate != nil {
+				architectureImages = ch.Spec.Solver.HTTP01.Ingress.PodTemplate.Spec.ArchitectureImages
+			}
 		}
 	}
 
+	if solverOS(ch) == string(corev1.Linux) {
+		s.setPodArchitecture(pod, architectureImages)
+	}
+
 	return pod
 }
 
+// setPodArchitecture makes sure that the given Linux solver pod runs an image
+// built for the architecture of the node that it is scheduled on.
+// If the pod template selects an architecture using the `kubernetes.io/arch`
+// node selector, the image configured for that architecture in the pod
+// template is used. Otherwise the default solver image is used, which is a
+// manifest list, and the pod is only scheduled on nodes of the architectures
+// that it provides.
+func (s *Solver) setPodArchitecture(pod *corev1.Pod, architectureImages map[string]string) {
+	if arch, ok := pod.Spec.NodeSelector[corev1.LabelArchStable]; ok {
+		if image, ok := architectureImages[arch]; ok {
+			for i := range pod.Spec.Containers {
+				pod.Spec.Containers[i].Image = image
+			}
+		}
+		return
+	}
+
+	if len(s.ACMEOptions.HTTP01SolverImageArchitectures) == 0 {
+		return
+	}
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   s.ACMEOptions.HTTP01SolverImageArchitectures,
+	}
+
+	// The affinity may be shared with the pod template of the challenge.
+	pod.Spec.Affinity = pod.Spec.Affinity.DeepCopy()
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{requirement}}},
+		}
+		return
+	}
+	// Node selector terms are ORed, so the requirement is added to each term.
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+}
+
 // Note: this function builds pod spec using defaults and any configuration
 // options passed via flags to cert-manager controller.
 // Solver pod configuration via flags is a now deprecated
@@ -164,7 +219,7 @@ func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
 func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 	podLabels := podLabels(ch)
 
-	return &corev1.Pod{
+	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "cm-acme-http-solver-",
 			Namespace:    ch.Namespace,
@@ -224,6 +279,49 @@ func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 			},
 		},
 	}
+
+	if solverOS(ch) == string(corev1.Windows) {
+		setWindowsPodDefaults(pod, s.ACMEOptions.HTTP01SolverWindowsImage)
+	}
+
+	return pod
+}
+
+// solverOS returns the operating system of the nodes that the solver pod for
+// the given challenge will be scheduled on, as selected by the
+// `kubernetes.io/os` node selector of the solver's pod template. Solver pods
+// are scheduled on Linux nodes unless another operating system is selected.
+func solverOS(ch *cmacme.Challenge) string {
+	if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.Ingress == nil || ch.Spec.Solver.HTTP01.Ingress.PodTemplate == nil {
+		return string(corev1.Linux)
+	}
+	if os, ok := ch.Spec.Solver.HTTP01.Ingress.PodTemplate.Spec.NodeSelector[corev1.LabelOSStable]; ok {
+		return os
+	}
+	return string(corev1.Linux)
+}
+
+// setWindowsPodDefaults changes the given solver pod to run the Windows solver
+// image on Windows nodes. The Windows image is only built for amd64, so the
+// pod is scheduled on amd64 nodes unless the pod template selects otherwise.
+// Linux-only security settings are removed, as the API server rejects them in
+// Windows pods.
+func setWindowsPodDefaults(pod *corev1.Pod, image string) {
+	pod.Spec.OS = &corev1.PodOS{Name: corev1.Windows}
+	pod.Spec.NodeSelector = map[string]string{
+		corev1.LabelOSStable:   string(corev1.Windows),
+		corev1.LabelArchStable: "amd64",
+	}
+	pod.Spec.SecurityContext = &corev1.PodSecurityContext{
+		RunAsNonRoot: pod.Spec.SecurityContext.RunAsNonRoot,
+		WindowsOptions: &corev1.WindowsSecurityContextOptions{
+			RunAsUserName: pointer.String("ContainerUser"),
+		},
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Image = image
+		pod.Spec.Containers[i].SecurityContext = nil
+	}
 }
 
 // Merge object meta from the pod template. Fall back to default values.
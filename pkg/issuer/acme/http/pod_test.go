@@ -27,6 +27,7 @@ import (
 	coretesting "k8s.io/client-go/testing"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
 )
 
 func TestEnsurePod(t *testing.T) {
@@ -413,3 +414,147 @@ func TestMergePodObjectMetaWithPodTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildPodWindows(t *testing.T) {
+	s := &Solver{Context: &controller.Context{
+		ContextOptions: controller.ContextOptions{
+			ACMEOptions: controller.ACMEOptions{
+				HTTP01SolverImage:        "acmesolver:linux",
+				HTTP01SolverWindowsImage: "acmesolver:windows",
+			},
+		},
+	}}
+	ch := &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+						PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+							Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+								NodeSelector: map[string]string{
+									corev1.LabelOSStable: "windows",
+									"pool":               "win",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pod := s.buildPod(ch)
+	if pod.Spec.OS == nil || pod.Spec.OS.Name != corev1.Windows {
+		t.Errorf("expected pod OS to be windows, got %v", pod.Spec.OS)
+	}
+	expectedNodeSelector := map[string]string{
+		corev1.LabelOSStable:   "windows",
+		corev1.LabelArchStable: "amd64",
+		"pool":                 "win",
+	}
+	if !reflect.DeepEqual(pod.Spec.NodeSelector, expectedNodeSelector) {
+		t.Errorf("unexpected node selector %v", pod.Spec.NodeSelector)
+	}
+	if pod.Spec.SecurityContext.SeccompProfile != nil {
+		t.Errorf("expected no seccomp profile to be set on a windows pod")
+	}
+	container := pod.Spec.Containers[0]
+	if container.Image != "acmesolver:windows" {
+		t.Errorf("expected the windows solver image to be used, got %q", container.Image)
+	}
+	if container.SecurityContext != nil {
+		t.Errorf("expected no linux container security context to be set, got %v", container.SecurityContext)
+	}
+
+	// Solver pods without a kubernetes.io/os node selector run on Linux.
+	delete(ch.Spec.Solver.HTTP01.Ingress.PodTemplate.Spec.NodeSelector, corev1.LabelOSStable)
+	pod = s.buildPod(ch)
+	if pod.Spec.OS != nil || pod.Spec.NodeSelector[corev1.LabelOSStable] != "linux" || pod.Spec.Containers[0].Image != "acmesolver:linux" {
+		t.Errorf("expected a linux solver pod, got %v", pod.Spec)
+	}
+}
+
+func TestSetPodArchitecture(t *testing.T) {
+	archs := []string{"amd64", "arm64"}
+	s := &Solver{Context: &controller.Context{
+		ContextOptions: controller.ContextOptions{
+			ACMEOptions: controller.ACMEOptions{
+				HTTP01SolverImage:              "acmesolver",
+				HTTP01SolverImageArchitectures: archs,
+			},
+		},
+	}}
+	requirement := corev1.NodeSelectorRequirement{Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: archs}
+	zoneRequirement := corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+
+	tests := map[string]struct {
+		podSpec          cmacme.ACMEChallengeSolverHTTP01IngressPodSpec
+		expectedImage    string
+		expectedAffinity *corev1.Affinity
+	}{
+		"should only schedule pods on the architectures of the default image": {
+			expectedImage: "acmesolver",
+			expectedAffinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{requirement}}},
+				},
+			}},
+		},
+		"should add the architecture requirement to each node selector term of the template": {
+			podSpec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+				Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}}},
+					},
+				}},
+			},
+			expectedImage: "acmesolver",
+			expectedAffinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement, requirement}}},
+				},
+			}},
+		},
+		"should use the image of the architecture selected by the template": {
+			podSpec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+				NodeSelector:       map[string]string{corev1.LabelArchStable: "riscv64"},
+				ArchitectureImages: map[string]string{"riscv64": "acmesolver-riscv64"},
+			},
+			expectedImage: "acmesolver-riscv64",
+		},
+		"should use the default image if no image is configured for the selected architecture": {
+			podSpec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+				NodeSelector:       map[string]string{corev1.LabelArchStable: "arm64"},
+				ArchitectureImages: map[string]string{"riscv64": "acmesolver-riscv64"},
+			},
+			expectedImage: "acmesolver",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{Spec: test.podSpec}
+			original := podTemplate.DeepCopy()
+			ch := &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{PodTemplate: podTemplate},
+						},
+					},
+				},
+			}
+
+			pod := s.buildPod(ch)
+			if image := pod.Spec.Containers[0].Image; image != test.expectedImage {
+				t.Errorf("expected image %q, got %q", test.expectedImage, image)
+			}
+			if !reflect.DeepEqual(pod.Spec.Affinity, test.expectedAffinity) {
+				t.Errorf("unexpected affinity\nexp=%v\ngot=%v", test.expectedAffinity, pod.Spec.Affinity)
+			}
+			if !reflect.DeepEqual(podTemplate, original) {
+				t.Errorf("expected the pod template of the challenge not to be modified")
+			}
+		})
+	}
+}
@@ -33,8 +33,10 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	"github.com/cert-manager/cert-manager/pkg/acme/client"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/httpclient"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -49,6 +51,7 @@ const (
 
 	successAccountRegistered = "ACMEAccountRegistered"
 	successAccountVerified   = "ACMEAccountVerified"
+	successAccountUpdated    = "ACMEAccountUpdated"
 
 	messageAccountRegistrationFailed     = "Failed to register ACME account: "
 	messageAccountVerificationFailed     = "Failed to verify ACME account: "
@@ -63,6 +66,8 @@ const (
 	messageTemplateFailedToParseURL        = "Failed to parse existing ACME server URI %q: %v"
 	messageTemplateFailedToParseAccountURL = "Failed to parse existing ACME account URI %q: %v"
 	messageTemplateFailedToGetEABKey       = "failed to get External Account Binding key from secret: %v"
+	messageTemplateFailedToLoadHTTPClient  = "Failed to load HTTP client configuration: %v"
+	messageTemplateAccountEmailUpdated     = "Updated the email address of ACME account %q from %q to %q"
 )
 
 // Setup will verify an existing ACME registration, or create one if not
@@ -115,8 +120,8 @@ func (a *Acme) Setup(ctx context.Context) error {
 			reason = errorAccountRegistrationFailed
 			return fmt.Errorf(msg)
 		}
-		// We clear the ACME account URI as we have generated a new private key
-		a.issuer.GetStatus().ACMEStatus().URI = ""
+		// We clear the ACME account details as we have generated a new private key
+		clearAccountStatus(a.issuer.GetStatus().ACMEStatus())
 
 	case a.issuer.GetSpec().ACME.DisableAccountKeyGeneration && apierrors.IsNotFound(err):
 		wrapErr := fmt.Errorf("%s%s%v", messageAccountVerificationFailed,
@@ -155,7 +160,16 @@ func (a *Acme) Setup(ctx context.Context) error {
 	// We could therefore move the removing of the client up to the start of
 	// this function.
 	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
-	httpClient := accounts.BuildHTTPClient(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify)
+	httpClientConfig, err := httpclient.Load(a.issuer, ns, a.secretsLister)
+	if err != nil {
+		reason = errorInvalidConfig
+		msg = fmt.Sprintf(messageTemplateFailedToLoadHTTPClient, err)
+		return fmt.Errorf(msg)
+	}
+	httpClient := accounts.BuildHTTPClientWithConfig(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify, httpClientConfig)
+	if a.httpRateLimiter != nil {
+		httpClient.Transport = accounts.NewRateLimitedTransport(httpClient.Transport, a.httpRateLimiter)
+	}
 	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
 	// TODO: perform a complex check to determine whether we need to verify
@@ -218,7 +232,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	if parsedAccountURL.Host != parsedServerURL.Host {
 		log.V(logf.InfoLevel).Info("ACME server URL host and ACME private key registration " +
 			"host differ. Re-checking ACME account registration")
-		a.issuer.GetStatus().ACMEStatus().URI = ""
+		clearAccountStatus(a.issuer.GetStatus().ACMEStatus())
 	}
 
 	var eabAccount *acmeapi.ExternalAccountBinding
@@ -249,7 +263,10 @@ func (a *Acme) Setup(ctx context.Context) error {
 	}
 
 	// register an ACME account or retrieve it if it already exists.
-	account, err := a.registerAccount(ctx, cl, eabAccount)
+	account, err := a.restoredAccount(ctx, cl)
+	if err == nil && account == nil {
+		account, err = a.registerAccount(ctx, cl, eabAccount)
+	}
 	if err != nil {
 		// TODO: this error could be from an account registration or an attempt
 		// to retrieve an existing account- perhaps we should log different
@@ -280,6 +297,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	// if we got an account successfully, we must check if the registered
 	// email is the same as in the issuer spec
 	specEmail := a.issuer.GetSpec().ACME.Email
+	previousEmail := registeredEmailFromContacts(account.Contact)
 	account, registeredEmail, err := ensureEmailUpToDate(ctx, cl, account, specEmail)
 	if err != nil {
 		reason = errorAccountUpdateFailed
@@ -306,26 +324,37 @@ func (a *Acme) Setup(ctx context.Context) error {
 		return err
 	}
 
+	if registeredEmail != previousEmail {
+		a.recorder.Eventf(a.issuer, corev1.EventTypeNormal, successAccountUpdated,
+			messageTemplateAccountEmailUpdated, account.URI, previousEmail, registeredEmail)
+	}
+
 	log.V(logf.InfoLevel).Info("verified existing registration with ACME server")
 	status = cmmeta.ConditionTrue
 	reason = successAccountRegistered
 	msg = messageAccountRegistered
 	a.issuer.GetStatus().ACMEStatus().URI = account.URI
 	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = registeredEmail
+	a.issuer.GetStatus().ACMEStatus().Contacts = account.Contact
+	a.issuer.GetStatus().ACMEStatus().AccountStatus = account.Status
 	// ensure the cached client in the account registry is up to date
 	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
 	return nil
 }
 
+// clearAccountStatus clears the details of the registered ACME account from
+// the given status, leaving LastRegisteredEmail untouched.
+func clearAccountStatus(status *cmacme.ACMEIssuerStatus) {
+	status.URI = ""
+	status.Contacts = nil
+	status.AccountStatus = ""
+}
+
 func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.Account, specEmail string) (*acmeapi.Account, string, error) {
 	log := logf.FromContext(ctx)
 
-	// if no email was specified, then registeredEmail will remain empty
-	registeredEmail := ""
-	if len(acc.Contact) > 0 {
-		registeredEmail = strings.Replace(acc.Contact[0], "mailto:", "", 1)
-	}
+	registeredEmail := registeredEmailFromContacts(acc.Contact)
 
 	// if they are different, we update the account
 	if registeredEmail != specEmail {
@@ -349,6 +378,16 @@ func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.
 	return acc, registeredEmail, nil
 }
 
+// registeredEmailFromContacts returns the email address of the first contact
+// registered with an ACME account, or an empty string if no email was
+// specified.
+func registeredEmailFromContacts(contacts []string) string {
+	if len(contacts) == 0 {
+		return ""
+	}
+	return strings.Replace(contacts[0], "mailto:", "", 1)
+}
+
 // registerAccount will register a new ACME account with the server. If an
 // account with the clients private key already exists, it will attempt to look
 // up and verify the corresponding account, and will return that. If this fails
@@ -381,6 +420,28 @@ func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAcco
 	return acc, nil
 }
 
+// restoredAccount looks up the existing ACME account of an issuer which was
+// restored from a backup, as indicated by the account URI annotation. It
+// returns nil if the issuer wasn't restored, has already registered an
+// account, or if the ACME server has no account for the private key.
+func (a *Acme) restoredAccount(ctx context.Context, cl client.Interface) (*acmeapi.Account, error) {
+	uri := a.issuer.GetObjectMeta().Annotations[cmacme.ACMEAccountURIAnnotationKey]
+	if uri == "" || a.issuer.GetStatus().ACMEStatus().URI != "" {
+		return nil, nil
+	}
+
+	acc, err := cl.GetReg(ctx, uri)
+	if err == acmeapi.ErrNoAccount {
+		logf.FromContext(ctx).V(logf.InfoLevel).Info("restored ACME account does not exist, registering a new account", "uri", uri)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return acc, nil
+}
+
 func (a *Acme) getEABKey(ctx context.Context, ns string) ([]byte, error) {
 	eab := a.issuer.GetSpec().ACME.ExternalAccountBinding.Key
 	sec, err := a.secretsClient.Secrets(ns).Get(ctx, eab.Name, metav1.GetOptions{})
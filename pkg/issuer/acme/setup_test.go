@@ -82,8 +82,9 @@ func TestAcme_Setup(t *testing.T) {
 		invalidURLMessage        = fmt.Sprintf(messageTemplateFailedToParseURL, invalidURL, invalidURLErr)
 		invalidAccountURLMessage = fmt.Sprintf(messageTemplateFailedToParseAccountURL, invalidURL, invalidURLErr)
 
-		someEmail    = "test@test.com"
-		someEmailURL = fmt.Sprintf("mailto:%s", someEmail)
+		someEmail      = "test@test.com"
+		someEmailURL   = fmt.Sprintf("mailto:%s", someEmail)
+		someAccountURL = "https://acme-v02.api.letsencrypt.org/acme/acct/1"
 
 		// to be used where we don't care what value is passed
 		someString = "test"
@@ -135,6 +136,8 @@ func TestAcme_Setup(t *testing.T) {
 
 		// expected ACME account passed to cl.Register
 		expectedRegisteredAcc *acmeapi.Account
+		// expected issuer ACME status after Setup has been called, if set.
+		expectedACMEStatus *cmacme.ACMEIssuerStatus
 		// expected issuer conditions after Setup has been called.
 		expectedConditions []cmapi.IssuerCondition
 		expectedEvents     []string
@@ -266,6 +269,33 @@ func TestAcme_Setup(t *testing.T) {
 			removeClientShouldBeCalled: true,
 			addClientShouldBeCalled:    true,
 		},
+		"Issuer restored from a backup, existing ACME account is looked up instead of registered": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEEmail(someEmail),
+				gen.AddIssuerAnnotations(map[string]string{cmacme.ACMEAccountURIAnnotationKey: someAccountURL})),
+			kfsKey:                     rsaPrivKey,
+			getRegAcc:                  &acmeapi.Account{URI: someAccountURL, Contact: []string{someEmailURL}},
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedACMEStatus: &cmacme.ACMEIssuerStatus{
+				URI:                 someAccountURL,
+				LastRegisteredEmail: someEmail,
+				Contacts:            []string{someEmailURL},
+			},
+		},
+		"Issuer restored from a backup, but ACME account no longer exists so a new one is registered": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.AddIssuerAnnotations(map[string]string{cmacme.ACMEAccountURIAnnotationKey: someAccountURL})),
+			kfsKey:                     rsaPrivKey,
+			getRegErr:                  acmeapi.ErrNoAccount,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+		},
 		"EAB for issuer specified, but the corresponding secret is not found": {
 			issuer: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerACMEEAB(someString, someString)),
@@ -398,7 +428,9 @@ func TestAcme_Setup(t *testing.T) {
 				KID: someString,
 				Key: []byte(eabKey),
 			},
-				Contact: []string{"some@test.com"},
+				URI:     someAccountURL,
+				Status:  acmeapi.StatusValid,
+				Contact: []string{"mailto:some@test.com"},
 			},
 			expectedRegisteredAcc: &acmeapi.Account{ExternalAccountBinding: &acmeapi.ExternalAccountBinding{
 				KID: someString,
@@ -412,6 +444,14 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionReason(successAccountRegistered),
 					gen.SetIssuerConditionMessage(messageAccountRegistered)),
 			},
+			expectedACMEStatus: &cmacme.ACMEIssuerStatus{
+				URI:                 someAccountURL,
+				LastRegisteredEmail: someEmail,
+				Contacts:            []string{someEmailURL},
+				AccountStatus:       acmeapi.StatusValid,
+			},
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, successAccountUpdated, fmt.Sprintf(messageTemplateAccountEmailUpdated, someAccountURL, "some@test.com", someEmail))},
 		},
 		"ACME account with legacy EAB key algorithm set, spec email different from registered email and registered failed": {
 			issuer: gen.IssuerFrom(baseIssuer,
@@ -606,6 +646,12 @@ func TestAcme_Setup(t *testing.T) {
 					test.expectedConditions, gotConditions)
 			}
 
+			// Verify issuer's ACME status after Setup was called.
+			if test.expectedACMEStatus != nil && !reflect.DeepEqual(a.issuer.GetStatus().ACME, test.expectedACMEStatus) {
+				t.Errorf("Expected issuer's ACME status: %#+v\ngot: %#+v",
+					test.expectedACMEStatus, a.issuer.GetStatus().ACME)
+			}
+
 			// Verify that the expected events were recorded.
 			if !util.EqualSorted(test.expectedEvents, recorder.Events) {
 				t.Errorf("Expected events:\n%+#v\ngot:%+#v",
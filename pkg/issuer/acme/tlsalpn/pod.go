@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"fmt"
+	"hash/adler32"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/pointer"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+func podLabels(ch *cmacme.Challenge) map[string]string {
+	domainHash := fmt.Sprintf("%d", adler32.Checksum([]byte(ch.Spec.DNSName)))
+	tokenHash := fmt.Sprintf("%d", adler32.Checksum([]byte(ch.Spec.Token)))
+	return map[string]string{
+		cmacme.DomainLabelKey:               domainHash,
+		cmacme.TokenLabelKey:                tokenHash,
+		cmacme.SolverIdentificationLabelKey: "true",
+	}
+}
+
+func (s *Solver) ensurePod(ctx context.Context, ch *cmacme.Challenge) (*corev1.Pod, error) {
+	log := logf.FromContext(ctx).WithName("ensurePod")
+
+	log.V(logf.DebugLevel).Info("checking for existing TLS-ALPN-01 solver pods")
+	existingPods, err := s.getPodsForChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingPods) == 1 {
+		logf.WithRelatedResource(log, existingPods[0]).Info("found one existing TLS-ALPN-01 solver pod")
+		return existingPods[0], nil
+	}
+	if len(existingPods) > 1 {
+		log.V(logf.InfoLevel).Info("multiple challenge solver pods found for challenge. cleaning up all existing pods.")
+		err := s.cleanupPods(ctx, ch)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing challenge solver pods found and cleaned up. retrying challenge sync")
+	}
+
+	log.V(logf.InfoLevel).Info("creating TLS-ALPN-01 challenge solver pod")
+
+	return s.Client.CoreV1().Pods(ch.Namespace).Create(ctx, s.buildPod(ch), metav1.CreateOptions{})
+}
+
+// getPodsForChallenge returns a list of pods that were created to solve
+// the given challenge
+func (s *Solver) getPodsForChallenge(ctx context.Context, ch *cmacme.Challenge) ([]*corev1.Pod, error) {
+	log := logf.FromContext(ctx)
+
+	podList, err := s.podLister.Pods(ch.Namespace).List(labels.SelectorFromSet(podLabels(ch)))
+	if err != nil {
+		return nil, err
+	}
+
+	var relevantPods []*corev1.Pod
+	for _, pod := range podList {
+		if !metav1.IsControlledBy(pod, ch) {
+			logf.WithRelatedResource(log, pod).Info("found existing solver pod for this challenge resource, however " +
+				"it does not have an appropriate OwnerReference referencing this challenge. Skipping it altogether.")
+			continue
+		}
+		relevantPods = append(relevantPods, pod)
+	}
+
+	return relevantPods, nil
+}
+
+func (s *Solver) cleanupPods(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupPods")
+
+	pods, err := s.getPodsForChallenge(ctx, ch)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, pod := range pods {
+		log := logf.WithRelatedResource(log, pod)
+		log.V(logf.InfoLevel).Info("deleting pod resource")
+
+		err := s.Client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if err != nil {
+			log.V(logf.WarnLevel).Info("failed to delete pod resource", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		log.V(logf.InfoLevel).Info("successfully deleted pod resource")
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// buildPod will build a challenge solving pod for the given challenge. It
+// will not create it in the API server.
+func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
+	podLabels := podLabels(ch)
+
+	port := corev1.ContainerPort{
+		Name:          "https",
+		ContainerPort: acmeSolverListenPort,
+	}
+	if ch.Spec.Solver.TLSALPN01 != nil && ch.Spec.Solver.TLSALPN01.Mode == cmacme.ACMEChallengeSolverTLSALPN01ModeHostPort {
+		port.HostPort = challengePort
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "cm-acme-tls-alpn-solver-",
+			Namespace:    ch.Namespace,
+			Labels:       podLabels,
+			Annotations: map[string]string{
+				"sidecar.istio.io/inject": "false",
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
+		},
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{
+				corev1.LabelOSStable: string(corev1.Linux),
+			},
+			RestartPolicy: corev1.RestartPolicyOnFailure,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: pointer.BoolPtr(s.ACMEOptions.ACMEHTTP01SolverRunAsNonRoot),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            "acmesolver",
+					Image:           s.Context.HTTP01SolverImage,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Args: []string{
+						"--challenge-type=tls-alpn-01",
+						fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
+						fmt.Sprintf("--domain=%s", ch.Spec.DNSName),
+						fmt.Sprintf("--key=%s", ch.Spec.Key),
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    s.ACMEOptions.HTTP01SolverResourceRequestCPU,
+							corev1.ResourceMemory: s.ACMEOptions.HTTP01SolverResourceRequestMemory,
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    s.ACMEOptions.HTTP01SolverResourceLimitsCPU,
+							corev1.ResourceMemory: s.ACMEOptions.HTTP01SolverResourceLimitsMemory,
+						},
+					},
+					Ports: []corev1.ContainerPort{port},
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: pointer.BoolPtr(false),
+						Capabilities: &corev1.Capabilities{
+							Drop: []corev1.Capability{"ALL"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if ch.Spec.Solver.TLSALPN01 != nil {
+		mergePodTemplate(pod, ch.Spec.Solver.TLSALPN01.PodTemplate)
+	}
+
+	return pod
+}
+
+// mergePodTemplate applies the supported overrides of the pod template to
+// the given solver pod.
+func mergePodTemplate(pod *corev1.Pod, podTempl *cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate) {
+	if podTempl == nil {
+		return
+	}
+
+	for k, v := range podTempl.Labels {
+		pod.Labels[k] = v
+	}
+	for k, v := range podTempl.Annotations {
+		pod.Annotations[k] = v
+	}
+	for k, v := range podTempl.Spec.NodeSelector {
+		pod.Spec.NodeSelector[k] = v
+	}
+
+	pod.Spec.Tolerations = append(pod.Spec.Tolerations, podTempl.Spec.Tolerations...)
+
+	if podTempl.Spec.Affinity != nil {
+		pod.Spec.Affinity = podTempl.Spec.Affinity
+	}
+
+	if podTempl.Spec.PriorityClassName != "" {
+		pod.Spec.PriorityClassName = podTempl.Spec.PriorityClassName
+	}
+
+	if podTempl.Spec.ServiceAccountName != "" {
+		pod.Spec.ServiceAccountName = podTempl.Spec.ServiceAccountName
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+// ChallengeCertificate returns the self-signed certificate that must be
+// presented to complete the TLS-ALPN-01 challenge for the given domain or IP
+// address with the given key authorization, as described in RFC 8737.
+func ChallengeCertificate(domain, keyAuthorization string) (tls.Certificate, error) {
+	sum := sha256.Sum256([]byte(keyAuthorization))
+	extValue, err := asn1.Marshal(sum[:])
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "cert-manager TLS-ALPN-01 challenge"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		ExtraExtensions: []pkix.Extension{
+			{Id: idPeACMEIdentifier, Critical: true, Value: extValue},
+		},
+	}
+	if ip := net.ParseIP(domain); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{domain}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &pk.PublicKey, pk)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: pk}, nil
+}
+
+// VerifyChallengeCertificate checks that the given certificate is a valid
+// TLS-ALPN-01 challenge certificate for the given domain or IP address and
+// key authorization.
+func VerifyChallengeCertificate(cert *x509.Certificate, domain, keyAuthorization string) error {
+	if ip := net.ParseIP(domain); ip != nil {
+		if len(cert.IPAddresses) != 1 || !cert.IPAddresses[0].Equal(ip) || len(cert.DNSNames) != 0 {
+			return fmt.Errorf("challenge certificate must only contain the IP address %q, got IP addresses %v and DNS names %v", domain, cert.IPAddresses, cert.DNSNames)
+		}
+	} else if len(cert.DNSNames) != 1 || cert.DNSNames[0] != domain || len(cert.IPAddresses) != 0 {
+		return fmt.Errorf("challenge certificate must only contain the DNS name %q, got DNS names %v and IP addresses %v", domain, cert.DNSNames, cert.IPAddresses)
+	}
+
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(idPeACMEIdentifier) {
+			continue
+		}
+		if !ext.Critical {
+			return fmt.Errorf("acmeIdentifier extension of challenge certificate is not critical")
+		}
+		var value []byte
+		rest, err := asn1.Unmarshal(ext.Value, &value)
+		if err != nil {
+			return fmt.Errorf("failed to decode acmeIdentifier extension of challenge certificate: %v", err)
+		}
+		if len(rest) > 0 {
+			return fmt.Errorf("acmeIdentifier extension of challenge certificate contains trailing data")
+		}
+		sum := sha256.Sum256([]byte(keyAuthorization))
+		if !bytes.Equal(value, sum[:]) {
+			return fmt.Errorf("acmeIdentifier extension of challenge certificate does not match the expected key authorization")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("challenge certificate does not contain the acmeIdentifier extension")
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// handshakeTimeout is the maximum amount of time that a client may take to
+// complete the TLS handshake with the solver.
+const handshakeTimeout = 10 * time.Second
+
+// TLSALPN01Solver serves the TLS-ALPN-01 challenge certificate for Domain to
+// clients that negotiate the acme-tls/1 protocol.
+type TLSALPN01Solver struct {
+	ListenPort int
+
+	Domain string
+	Key    string
+
+	lock     sync.Mutex
+	listener net.Listener
+}
+
+func (t *TLSALPN01Solver) Listen(log logr.Logger) error {
+	log.Info("starting listener",
+		"expected_domain", t.Domain,
+		"expected_key", t.Key,
+		"listen_port", t.ListenPort,
+	)
+
+	cert, err := ChallengeCertificate(t.Domain, t.Key)
+	if err != nil {
+		return err
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{ACMETLS1Protocol},
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			log := log.WithValues("server_name", hello.ServerName, "protocols", hello.SupportedProtos)
+			if !supportsACMETLS1(hello.SupportedProtos) {
+				log.Info("client did not negotiate the acme-tls/1 protocol")
+				return nil, fmt.Errorf("client did not negotiate the %s protocol", ACMETLS1Protocol)
+			}
+			// IP identifiers are validated using the reverse DNS name of the
+			// address as the server name (RFC 8738), so only DNS names are
+			// compared here.
+			if net.ParseIP(t.Domain) == nil && hello.ServerName != t.Domain {
+				log.Info("invalid server name", "expected_server_name", t.Domain)
+				return nil, fmt.Errorf("unexpected server name %q", hello.ServerName)
+			}
+			log.Info("got successful challenge request, presenting challenge certificate")
+			return &cert, nil
+		},
+	}
+
+	listener, err := tls.Listen("tcp", fmt.Sprintf(":%d", t.ListenPort), config)
+	if err != nil {
+		return err
+	}
+	t.lock.Lock()
+	t.listener = listener
+	t.lock.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		go handshake(log, conn.(*tls.Conn))
+	}
+}
+
+// Shutdown stops the solver from accepting new connections.
+func (t *TLSALPN01Solver) Shutdown(ctx context.Context) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.listener == nil {
+		return nil
+	}
+	return t.listener.Close()
+}
+
+// handshake completes the TLS handshake with the client, which is all that
+// is required for the challenge to be validated, and closes the connection.
+func handshake(log logr.Logger, conn *tls.Conn) {
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	if err := conn.HandshakeContext(ctx); err != nil {
+		log.Info("TLS handshake failed", "remote_addr", conn.RemoteAddr().String(), "error", err)
+	}
+}
+
+func supportsACMETLS1(protos []string) bool {
+	for _, p := range protos {
+		if p == ACMETLS1Protocol {
+			return true
+		}
+	}
+	return false
+}
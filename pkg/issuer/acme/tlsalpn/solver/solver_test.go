@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+func TestChallengeCertificate(t *testing.T) {
+	tests := map[string]struct {
+		certDomain, certKey     string
+		verifyDomain, verifyKey string
+		expectErr               bool
+	}{
+		"DNS name": {
+			certDomain: "example.com", certKey: "token.thumbprint",
+			verifyDomain: "example.com", verifyKey: "token.thumbprint",
+		},
+		"IP address": {
+			certDomain: "10.0.0.1", certKey: "token.thumbprint",
+			verifyDomain: "10.0.0.1", verifyKey: "token.thumbprint",
+		},
+		"wrong domain": {
+			certDomain: "example.com", certKey: "token.thumbprint",
+			verifyDomain: "example.org", verifyKey: "token.thumbprint",
+			expectErr: true,
+		},
+		"wrong key authorization": {
+			certDomain: "example.com", certKey: "token.thumbprint",
+			verifyDomain: "example.com", verifyKey: "other.thumbprint",
+			expectErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cert, err := ChallengeCertificate(test.certDomain, test.certKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := x509.ParseCertificate(cert.Certificate[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = VerifyChallengeCertificate(parsed, test.verifyDomain, test.verifyKey)
+			if test.expectErr != (err != nil) {
+				t.Errorf("expected error=%t, got %v", test.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestListen(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	s := &TLSALPN01Solver{ListenPort: port, Domain: "example.com", Key: "token.thumbprint"}
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Listen(logr.Discard()) }()
+	defer func() {
+		if err := s.Shutdown(context.Background()); err != nil {
+			t.Error(err)
+		}
+		if err := <-errCh; err != nil {
+			t.Errorf("unexpected error from listener: %v", err)
+		}
+	}()
+
+	dial := func(protos []string, serverName string) (*tls.Conn, error) {
+		var conn *tls.Conn
+		var err error
+		// wait for the listener to start
+		for i := 0; i < 50; i++ {
+			conn, err = tls.DialWithDialer(&net.Dialer{Timeout: time.Second}, "tcp", fmt.Sprintf("127.0.0.1:%d", port), &tls.Config{
+				NextProtos:         protos,
+				ServerName:         serverName,
+				InsecureSkipVerify: true,
+			})
+			if _, ok := err.(*net.OpError); !ok {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		return conn, err
+	}
+
+	conn, err := dial([]string{ACMETLS1Protocol}, "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error connecting to solver: %v", err)
+	}
+	state := conn.ConnectionState()
+	conn.Close()
+	if state.NegotiatedProtocol != ACMETLS1Protocol {
+		t.Errorf("expected protocol %q to be negotiated, got %q", ACMETLS1Protocol, state.NegotiatedProtocol)
+	}
+	if err := VerifyChallengeCertificate(state.PeerCertificates[0], "example.com", "token.thumbprint"); err != nil {
+		t.Errorf("unexpected challenge certificate: %v", err)
+	}
+
+	if conn, err := dial([]string{"http/1.1"}, "example.com"); err == nil {
+		conn.Close()
+		t.Errorf("expected handshake without the %s protocol to fail", ACMETLS1Protocol)
+	}
+	if conn, err := dial([]string{ACMETLS1Protocol}, "example.org"); err == nil {
+		conn.Close()
+		t.Errorf("expected handshake for another server name to fail")
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/tlsalpn/solver"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmutil "github.com/cert-manager/cert-manager/pkg/util"
+)
+
+const (
+	// TLSALPN01Timeout is the max amount of time to wait for a TLS-ALPN-01
+	// challenge to succeed
+	TLSALPN01Timeout = time.Minute * 15
+	// acmeSolverListenPort is the port acmesolver should listen on
+	acmeSolverListenPort = 8443
+	// challengePort is the port that the ACME server connects to when
+	// validating TLS-ALPN-01 challenges
+	challengePort = 443
+
+	loggerName = "tlsalpn01"
+)
+
+var (
+	challengeGvk = cmacme.SchemeGroupVersion.WithKind("Challenge")
+)
+
+// Solver is an implementation of the acme tls-alpn-01 challenge solver
+// protocol
+type Solver struct {
+	*controller.Context
+
+	podLister     corev1listers.PodLister
+	serviceLister corev1listers.ServiceLister
+
+	testReachability reachabilityTest
+	requiredPasses   int
+}
+
+type reachabilityTest func(ctx context.Context, domain, key string, ipFamily cmutil.IPFamilyPolicy) error
+
+// NewSolver returns a new ACME TLS-ALPN-01 solver for the given
+// *controller.Context.
+func NewSolver(ctx *controller.Context) (*Solver, error) {
+	return &Solver{
+		Context:          ctx,
+		podLister:        ctx.KubeSharedInformerFactory.Core().V1().Pods().Lister(),
+		serviceLister:    ctx.KubeSharedInformerFactory.Core().V1().Services().Lister(),
+		testReachability: testReachability,
+		requiredPasses:   5,
+	}, nil
+}
+
+func tlsALPN01CfgForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverTLSALPN01, error) {
+	if ch.Spec.Solver.TLSALPN01 == nil {
+		return nil, fmt.Errorf("challenge's 'solver' field is specified but no TLS-ALPN-01 config provided. " +
+			"Ensure solvers[].tlsALPN01 is specified on your issuer resource")
+	}
+	return ch.Spec.Solver.TLSALPN01, nil
+}
+
+// Present will realise the resources required to solve the given TLS-ALPN-01
+// challenge validation in the apiserver. If those resources already exist, it
+// will return nil (i.e. this function is idempotent).
+func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx).WithName(loggerName)
+	ctx = logf.NewContext(ctx, log)
+
+	cfg, err := tlsALPN01CfgForChallenge(ch)
+	if err != nil {
+		return err
+	}
+
+	_, podErr := s.ensurePod(ctx, ch)
+	if cfg.Mode == cmacme.ACMEChallengeSolverTLSALPN01ModeHostPort {
+		return podErr
+	}
+	_, svcErr := s.ensureService(ctx, ch)
+	return utilerrors.NewAggregate([]error{podErr, svcErr})
+}
+
+func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, loggerName, "selfCheck")
+	ctx = logf.NewContext(ctx, log)
+
+	// Present is idempotent and the state of the system may have changed
+	// since present was called by the controllers (killed pods, drained
+	// nodes), so call present again to be certain.
+	// if the listers are nil, that means we're in the present checks test
+	if s.podLister != nil && s.serviceLister != nil {
+		log.V(logf.DebugLevel).Info("calling Present function before running self check to ensure required resources exist")
+		err := s.Present(ctx, issuer, ch)
+		if err != nil {
+			log.V(logf.DebugLevel).Info("failed to call Present function", "error", err)
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, TLSALPN01Timeout)
+	defer cancel()
+
+	ipFamily := cmutil.NewIPFamilyPolicy(ch.Spec.Solver.SelfCheckIPFamily, s.SelfCheckPreferredIPFamily)
+
+	log.V(logf.DebugLevel).Info("running self check multiple times to ensure challenge has propagated", "required_passes", s.requiredPasses)
+	for i := 0; i < s.requiredPasses; i++ {
+		err := s.testReachability(ctx, ch.Spec.DNSName, ch.Spec.Key, ipFamily)
+		if err != nil {
+			return err
+		}
+		log.V(logf.DebugLevel).Info("reachability test passed, re-checking in 2s time")
+		time.Sleep(time.Second * 2)
+	}
+
+	log.V(logf.DebugLevel).Info("self check succeeded")
+
+	return nil
+}
+
+// CleanUp will ensure the created service and pod are deleted.
+func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	var errs []error
+	errs = append(errs, s.cleanupPods(ctx, ch))
+	errs = append(errs, s.cleanupServices(ctx, ch))
+	return utilerrors.NewAggregate(errs)
+}
+
+// testReachability will attempt to perform a TLS handshake with 'domain' on
+// port 443 using the acme-tls/1 protocol, and check that the presented
+// certificate is the challenge certificate for 'key'.
+func testReachability(ctx context.Context, domain, key string, ipFamily cmutil.IPFamilyPolicy) error {
+	log := logf.FromContext(ctx)
+	log.V(logf.DebugLevel).Info("performing TLS-ALPN-01 reachability check")
+
+	config := &tls.Config{
+		NextProtos: []string{solver.ACMETLS1Protocol},
+		// The challenge certificate is self-signed, and is verified below
+		// instead.
+		InsecureSkipVerify: true,
+	}
+	if net.ParseIP(domain) == nil {
+		config.ServerName = domain
+	}
+
+	// The timeout is the same as used for HTTP01 reachability checks.
+	dialCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	rawConn, err := ipFamily.DialContext(dialCtx, &net.Dialer{}, "tcp", net.JoinHostPort(domain, fmt.Sprintf("%d", challengePort)))
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to connect to challenge endpoint", "error", err)
+		return fmt.Errorf("failed to perform self check connection to '%s': %v", domain, err)
+	}
+	conn := tls.Client(rawConn, config)
+	defer conn.Close()
+
+	if err := conn.HandshakeContext(dialCtx); err != nil {
+		log.V(logf.DebugLevel).Info("failed to perform TLS handshake", "error", err)
+		return fmt.Errorf("failed to perform self check TLS handshake with '%s': %v", domain, err)
+	}
+
+	state := conn.ConnectionState()
+	if state.NegotiatedProtocol != solver.ACMETLS1Protocol {
+		return fmt.Errorf("server did not negotiate the %s protocol, got %q", solver.ACMETLS1Protocol, state.NegotiatedProtocol)
+	}
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("server did not present a certificate")
+	}
+	if err := solver.VerifyChallengeCertificate(state.PeerCertificates[0], domain, key); err != nil {
+		log.V(logf.DebugLevel).Info("certificate presented by server is not the expected challenge certificate", "error", err)
+		return fmt.Errorf("did not get expected challenge certificate when connecting to '%s': %v", domain, err)
+	}
+
+	log.V(logf.DebugLevel).Info("reachability test succeeded")
+
+	return nil
+}
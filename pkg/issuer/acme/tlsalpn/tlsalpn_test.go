@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	coretesting "k8s.io/client-go/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestPresent(t *testing.T) {
+	tests := map[string]struct {
+		solver *cmacme.ACMEChallengeSolverTLSALPN01
+
+		expectedHostPort    int32
+		expectedServiceType corev1.ServiceType
+	}{
+		"HostPort mode binds port 443 of the node and creates no service": {
+			solver:           &cmacme.ACMEChallengeSolverTLSALPN01{Mode: cmacme.ACMEChallengeSolverTLSALPN01ModeHostPort},
+			expectedHostPort: challengePort,
+		},
+		"Service mode defaults to a LoadBalancer service": {
+			solver:              &cmacme.ACMEChallengeSolverTLSALPN01{},
+			expectedServiceType: corev1.ServiceTypeLoadBalancer,
+		},
+		"Service mode uses the configured service type": {
+			solver: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Mode:        cmacme.ACMEChallengeSolverTLSALPN01ModeService,
+				ServiceType: corev1.ServiceTypeClusterIP,
+			},
+			expectedServiceType: corev1.ServiceTypeClusterIP,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := gen.Challenge("test",
+				gen.SetChallengeNamespace("testns"),
+				gen.SetChallengeDNSName("example.com"),
+				gen.SetChallengeKey("token.thumbprint"),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeTLSALPN01),
+			)
+			ch.Spec.Solver.TLSALPN01 = test.solver
+
+			builder := &testpkg.Builder{T: t}
+			builder.Init()
+			s, err := NewSolver(builder.Context)
+			if err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			if err := s.Present(context.Background(), nil, ch); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var pod *corev1.Pod
+			var svc *corev1.Service
+			for _, action := range builder.FakeKubeClient().Actions() {
+				create, ok := action.(coretesting.CreateAction)
+				if !ok {
+					continue
+				}
+				switch obj := create.GetObject().(type) {
+				case *corev1.Pod:
+					pod = obj
+				case *corev1.Service:
+					svc = obj
+				}
+			}
+
+			if pod == nil {
+				t.Fatal("expected a solver pod to be created")
+			}
+			port := pod.Spec.Containers[0].Ports[0]
+			if port.ContainerPort != acmeSolverListenPort || port.HostPort != test.expectedHostPort {
+				t.Errorf("expected container port %d and host port %d, got %d and %d", acmeSolverListenPort, test.expectedHostPort, port.ContainerPort, port.HostPort)
+			}
+
+			if test.expectedServiceType == "" {
+				if svc != nil {
+					t.Errorf("expected no service to be created, got %v", svc)
+				}
+				return
+			}
+			if svc == nil {
+				t.Fatal("expected a solver service to be created")
+			}
+			if svc.Spec.Type != test.expectedServiceType {
+				t.Errorf("expected service type %q, got %q", test.expectedServiceType, svc.Spec.Type)
+			}
+			if svc.Spec.Ports[0].Port != challengePort {
+				t.Errorf("expected service port %d, got %d", challengePort, svc.Spec.Ports[0].Port)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+)
+
+const (
+	reasonBootstrapCycle   = "BootstrapCycle"
+	reasonBootstrapPending = "BootstrapPending"
+)
+
+// bootstrapChecker follows the chain of Certificates and issuers which
+// produce the CA Secret of a CA issuer. It is used when the CA Secret can't be
+// read, to tell an issuer which is waiting for its CA to be issued apart from
+// one whose CA can never be issued because it depends on the issuer itself.
+type bootstrapChecker struct {
+	secretLister        corelisters.SecretLister
+	certificateLister   cmlisters.CertificateLister
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+
+	issuerOptions   controller.IssuerOptions
+	controllerClass string
+}
+
+// check returns the reason and message of the Ready condition to set on the
+// given CA issuer. An empty reason is returned if the CA Secret of the issuer
+// is not produced by a Certificate, in which case the caller should report
+// the error it got reading the Secret.
+//
+// The chain is followed from the Certificate which produces the CA Secret to
+// the issuer it references. If that issuer is a CA issuer whose own CA Secret
+// isn't available yet, the Certificate producing that Secret is followed in
+// turn. If the chain leads back to an issuer already visited, the CA can never
+// be issued and a BootstrapCycle reason is returned. Otherwise the chain ends
+// at an issuer which may become ready by itself, such as a SelfSigned issuer,
+// and a BootstrapPending reason is returned.
+func (b *bootstrapChecker) check(iss cmapi.GenericIssuer) (string, string, error) {
+	crt, err := b.producingCertificate(b.issuerOptions.ResourceNamespace(iss), iss.GetSpec().CA.SecretName)
+	if err != nil || crt == nil {
+		return "", "", err
+	}
+
+	pending := fmt.Sprintf("Waiting for %s to issue the CA Secret %q", describeCertificate(crt), crt.Spec.SecretName)
+
+	chain := []string{describeIssuer(iss)}
+	visited := sets.NewString(describeIssuer(iss))
+	for {
+		chain = append(chain, describeCertificate(crt))
+
+		next, err := b.issuerFor(crt)
+		if err != nil {
+			return "", "", err
+		}
+		if next == nil {
+			// The chain ends at an issuer which is not known to this
+			// controller, e.g. an external issuer or one which doesn't exist
+			// yet.
+			return reasonBootstrapPending, pending, nil
+		}
+
+		desc := describeIssuer(next)
+		chain = append(chain, desc)
+		if visited.Has(desc) {
+			return reasonBootstrapCycle, fmt.Sprintf("The CA Secret %q can never be issued as the chain of issuers producing it contains a cycle: %s. "+
+				"Issue one of the CAs in the chain with an issuer which doesn't depend on it, such as a SelfSigned issuer",
+				iss.GetSpec().CA.SecretName, strings.Join(chain, " -> ")), nil
+		}
+		visited.Insert(desc)
+
+		if next.GetSpec().CA == nil {
+			return reasonBootstrapPending, pending, nil
+		}
+
+		namespace, secretName := b.issuerOptions.ResourceNamespace(next), next.GetSpec().CA.SecretName
+		ready, err := b.secretReady(namespace, secretName)
+		if err != nil {
+			return "", "", err
+		}
+		if ready {
+			return reasonBootstrapPending, pending, nil
+		}
+
+		crt, err = b.producingCertificate(namespace, secretName)
+		if err != nil {
+			return "", "", err
+		}
+		if crt == nil {
+			return reasonBootstrapPending, pending, nil
+		}
+	}
+}
+
+// producingCertificate returns the Certificate managed by this controller
+// which stores its certificate in the given Secret, or nil if there is none.
+// If there are several, the first by name is returned so that the result is
+// stable between syncs.
+func (b *bootstrapChecker) producingCertificate(namespace, secretName string) (*cmapi.Certificate, error) {
+	crts, err := b.certificateLister.Certificates(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(crts, func(i, j int) bool { return crts[i].Name < crts[j].Name })
+	for _, crt := range crts {
+		if crt.Spec.SecretName != secretName {
+			continue
+		}
+		if !controller.ManagesControllerName(b.controllerClass, crt.Spec.ControllerName) {
+			continue
+		}
+		return crt, nil
+	}
+
+	return nil, nil
+}
+
+// issuerFor returns the Issuer or ClusterIssuer referenced by the given
+// Certificate, or nil if it doesn't exist or is not a cert-manager issuer.
+func (b *bootstrapChecker) issuerFor(crt *cmapi.Certificate) (cmapi.GenericIssuer, error) {
+	ref := crt.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return nil, nil
+	}
+
+	var (
+		iss cmapi.GenericIssuer
+		err error
+	)
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		namespace := crt.Namespace
+		if ref.Namespace != "" {
+			namespace = ref.Namespace
+		}
+		iss, err = b.issuerLister.Issuers(namespace).Get(ref.Name)
+	case cmapi.ClusterIssuerKind:
+		if b.clusterIssuerLister == nil {
+			return nil, nil
+		}
+		iss, err = b.clusterIssuerLister.Get(ref.Name)
+	default:
+		return nil, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return iss, nil
+}
+
+// secretReady returns whether the given Secret exists and contains both a
+// certificate and a private key.
+func (b *bootstrapChecker) secretReady(namespace, name string) (bool, error) {
+	secret, err := b.secretLister.Secrets(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return len(secret.Data[corev1.TLSCertKey]) > 0 && len(secret.Data[corev1.TLSPrivateKeyKey]) > 0, nil
+}
+
+func describeCertificate(crt *cmapi.Certificate) string {
+	return fmt.Sprintf("Certificate %s/%s", crt.Namespace, crt.Name)
+}
+
+func describeIssuer(iss cmapi.GenericIssuer) string {
+	if ns := iss.GetObjectMeta().Namespace; ns != "" {
+		return fmt.Sprintf("Issuer %s/%s", ns, iss.GetObjectMeta().Name)
+	}
+	return fmt.Sprintf("ClusterIssuer %s", iss.GetObjectMeta().Name)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestBootstrapCheck(t *testing.T) {
+	caIssuer := func(name, secretName string) *cmapi.Issuer {
+		return gen.Issuer(name, gen.SetIssuerNamespace("ns"), gen.SetIssuerCASecretName(secretName))
+	}
+	certificate := func(namespace, name, secretName string, ref cmmeta.ObjectReference, mods ...gen.CertificateModifier) *cmapi.Certificate {
+		return gen.Certificate(name, append([]gen.CertificateModifier{
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateSecretName(secretName),
+			gen.SetCertificateIssuer(ref),
+		}, mods...)...)
+	}
+	readySecret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		}
+	}
+	selfSigned := gen.Issuer("selfsigned", gen.SetIssuerNamespace("ns"), gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}))
+
+	tests := map[string]struct {
+		issuer  cmapi.GenericIssuer
+		objects []runtime.Object
+
+		expectedReason  string
+		expectedMessage string
+	}{
+		"CA Secret which isn't produced by a Certificate is not a bootstrap": {
+			issuer:         caIssuer("ca", "ca-key-pair"),
+			expectedReason: "",
+		},
+		"Certificate managed by another controller is ignored": {
+			issuer: caIssuer("ca", "ca-key-pair"),
+			objects: []runtime.Object{
+				certificate("ns", "ca", "ca-key-pair", cmmeta.ObjectReference{Name: "ca"}, gen.SetCertificateControllerName("other")),
+			},
+			expectedReason: "",
+		},
+		"root CA issued by a SelfSigned issuer is pending": {
+			issuer: caIssuer("ca", "ca-key-pair"),
+			objects: []runtime.Object{
+				selfSigned,
+				certificate("ns", "root", "ca-key-pair", cmmeta.ObjectReference{Name: "selfsigned"}),
+			},
+			expectedReason:  reasonBootstrapPending,
+			expectedMessage: `Waiting for Certificate ns/root to issue the CA Secret "ca-key-pair"`,
+		},
+		"intermediate CA issued by a root CA which is itself bootstrapping is pending": {
+			issuer: caIssuer("intermediate", "intermediate-key-pair"),
+			objects: []runtime.Object{
+				selfSigned,
+				caIssuer("root", "root-key-pair"),
+				certificate("ns", "root", "root-key-pair", cmmeta.ObjectReference{Name: "selfsigned"}),
+				certificate("ns", "intermediate", "intermediate-key-pair", cmmeta.ObjectReference{Name: "root"}),
+			},
+			expectedReason:  reasonBootstrapPending,
+			expectedMessage: `Waiting for Certificate ns/intermediate to issue the CA Secret "intermediate-key-pair"`,
+		},
+		"intermediate CA issued by a ready root CA is pending": {
+			issuer: caIssuer("intermediate", "intermediate-key-pair"),
+			objects: []runtime.Object{
+				caIssuer("root", "root-key-pair"),
+				readySecret("ns", "root-key-pair"),
+				certificate("ns", "intermediate", "intermediate-key-pair", cmmeta.ObjectReference{Name: "root"}),
+			},
+			expectedReason:  reasonBootstrapPending,
+			expectedMessage: `Waiting for Certificate ns/intermediate to issue the CA Secret "intermediate-key-pair"`,
+		},
+		"CA issued by an external issuer is pending": {
+			issuer: caIssuer("ca", "ca-key-pair"),
+			objects: []runtime.Object{
+				certificate("ns", "ca", "ca-key-pair", cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "example.com"}),
+			},
+			expectedReason:  reasonBootstrapPending,
+			expectedMessage: `Waiting for Certificate ns/ca to issue the CA Secret "ca-key-pair"`,
+		},
+		"CA issued by itself is a cycle": {
+			issuer: caIssuer("ca", "ca-key-pair"),
+			objects: []runtime.Object{
+				certificate("ns", "ca", "ca-key-pair", cmmeta.ObjectReference{Name: "ca"}),
+			},
+			expectedReason:  reasonBootstrapCycle,
+			expectedMessage: "Issuer ns/ca -> Certificate ns/ca -> Issuer ns/ca.",
+		},
+		"CA issued through a ClusterIssuer which depends on it is a cycle": {
+			issuer: caIssuer("a", "a-key-pair"),
+			objects: []runtime.Object{
+				certificate("ns", "a", "a-key-pair", cmmeta.ObjectReference{Name: "b", Kind: cmapi.ClusterIssuerKind}),
+				gen.ClusterIssuer("b", gen.SetIssuerCASecretName("b-key-pair")),
+				certificate("cert-manager", "b", "b-key-pair", cmmeta.ObjectReference{Name: "a", Kind: cmapi.IssuerKind, Namespace: "ns"}),
+			},
+			expectedReason:  reasonBootstrapCycle,
+			expectedMessage: "Issuer ns/a -> Certificate ns/a -> ClusterIssuer b -> Certificate cert-manager/b -> Issuer ns/a.",
+		},
+		"cycle which doesn't include the issuer is reported": {
+			issuer: caIssuer("leaf", "leaf-key-pair"),
+			objects: []runtime.Object{
+				certificate("ns", "leaf", "leaf-key-pair", cmmeta.ObjectReference{Name: "a"}),
+				caIssuer("a", "a-key-pair"),
+				certificate("ns", "a", "a-key-pair", cmmeta.ObjectReference{Name: "b"}),
+				caIssuer("b", "b-key-pair"),
+				certificate("ns", "b", "b-key-pair", cmmeta.ObjectReference{Name: "a"}),
+			},
+			expectedReason:  reasonBootstrapCycle,
+			expectedMessage: "Issuer ns/leaf -> Certificate ns/leaf -> Issuer ns/a -> Certificate ns/a -> Issuer ns/b -> Certificate ns/b -> Issuer ns/a.",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secrets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			certificates := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			issuers := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			clusterIssuers := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, obj := range append([]runtime.Object{test.issuer}, test.objects...) {
+				var indexer cache.Indexer
+				switch obj.(type) {
+				case *corev1.Secret:
+					indexer = secrets
+				case *cmapi.Certificate:
+					indexer = certificates
+				case *cmapi.Issuer:
+					indexer = issuers
+				case *cmapi.ClusterIssuer:
+					indexer = clusterIssuers
+				}
+				if err := indexer.Add(obj); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			b := &bootstrapChecker{
+				secretLister:        corelisters.NewSecretLister(secrets),
+				certificateLister:   cmlisters.NewCertificateLister(certificates),
+				issuerLister:        cmlisters.NewIssuerLister(issuers),
+				clusterIssuerLister: cmlisters.NewClusterIssuerLister(clusterIssuers),
+				issuerOptions:       controller.IssuerOptions{ClusterResourceNamespace: "cert-manager"},
+			}
+			reason, message, err := b.check(test.issuer)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reason != test.expectedReason {
+				t.Errorf("expected reason %q, got %q (%s)", test.expectedReason, reason, message)
+			}
+			if !strings.Contains(message, test.expectedMessage) {
+				t.Errorf("expected message to contain %q, got %q", test.expectedMessage, message)
+			}
+		})
+	}
+}
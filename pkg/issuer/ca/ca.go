@@ -37,16 +37,34 @@ type CA struct {
 	// For Issuers, this will be the namespace of the Issuer.
 	// For ClusterIssuers, this will be the cluster resource namespace.
 	resourceNamespace string
+
+	// bootstrap is used to explain why the CA Secret isn't available yet
+	// when it is produced by a Certificate.
+	bootstrap *bootstrapChecker
 }
 
 func NewCA(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
 	secretsLister := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister()
 
+	bootstrap := &bootstrapChecker{
+		secretLister:      secretsLister,
+		certificateLister: ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
+		issuerLister:      ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
+		issuerOptions:     ctx.IssuerOptions,
+		controllerClass:   ctx.ControllerClass,
+	}
+	// ClusterIssuers are only watched when cert-manager isn't restricted
+	// to a single namespace.
+	if ctx.Namespace == "" {
+		bootstrap.clusterIssuerLister = ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister()
+	}
+
 	return &CA{
 		Context:           ctx,
 		issuer:            issuer,
 		secretsLister:     secretsLister,
 		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
+		bootstrap:         bootstrap,
 	}, nil
 }
 
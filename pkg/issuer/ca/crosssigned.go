@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"crypto/x509"
+
+	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// CrossSignedChain returns the PEM encoded cross-signed chain of the given CA
+// issuer, read from its crossSignedChainSecretName Secret in the given
+// namespace, or nil if the issuer doesn't have one. An InvalidData error is
+// returned if the chain is malformed or doesn't chain up from caCert, the
+// certificate of the CA which signs certificates.
+func CrossSignedChain(lister corelisters.SecretLister, namespace string, iss *cmapi.CAIssuer, caCert *x509.Certificate) ([]byte, error) {
+	if iss.CrossSignedChainSecretName == "" {
+		return nil, nil
+	}
+
+	secret, err := lister.Secrets(namespace).Get(iss.CrossSignedChainSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	chainPEM := secret.Data[corev1.TLSCertKey]
+	if len(chainPEM) == 0 {
+		return nil, errors.NewInvalidData("no data for %q in secret '%s/%s'", corev1.TLSCertKey, namespace, secret.Name)
+	}
+	chain, err := pki.DecodeX509CertificateChainBytes(chainPEM)
+	if err != nil {
+		return nil, errors.NewInvalidData("%v", err)
+	}
+	if _, err := pki.ParseSingleCertificateChain(append([]*x509.Certificate{caCert}, chain...)); err != nil {
+		return nil, errors.NewInvalidData("cross-signed chain does not chain up from the CA certificate: %v", err)
+	}
+
+	return chainPEM, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func mustSignTestCA(t *testing.T, cn string, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, []byte) {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	pemBytes, cert, err := pki.SignCertificate(template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pemBytes
+}
+
+func TestCrossSignedChain(t *testing.T) {
+	oldRootKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRootKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldRoot, oldRootPEM := mustSignTestCA(t, "old-root", oldRootKey, nil, nil)
+	newRoot, _ := mustSignTestCA(t, "new-root", newRootKey, nil, nil)
+	_, crossSignedPEM := mustSignTestCA(t, "new-root", newRootKey, oldRoot, oldRootKey)
+	chain := append(append([]byte{}, crossSignedPEM...), oldRootPEM...)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for name, data := range map[string][]byte{"cross-signed": chain, "unrelated": oldRootPEM, "empty": nil} {
+		if err := indexer.Add(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Data:       map[string][]byte{corev1.TLSCertKey: data},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lister := corelisters.NewSecretLister(indexer)
+
+	tests := map[string]struct {
+		secretName string
+		expChain   []byte
+		expErr     func(error) bool
+	}{
+		"issuer without a cross-signed chain": {},
+		"cross-signed chain is returned": {
+			secretName: "cross-signed",
+			expChain:   chain,
+		},
+		"chain which doesn't chain up from the CA is invalid": {
+			secretName: "unrelated",
+			expErr:     errors.IsInvalidData,
+		},
+		"empty chain is invalid": {
+			secretName: "empty",
+			expErr:     errors.IsInvalidData,
+		},
+		"missing secret": {
+			secretName: "missing",
+			expErr:     apierrors.IsNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := &cmapi.CAIssuer{SecretName: "ca", CrossSignedChainSecretName: test.secretName}
+			got, err := CrossSignedChain(lister, "ns", iss, newRoot)
+			if test.expErr != nil {
+				if err == nil || !test.expErr(err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, test.expChain) {
+				t.Errorf("unexpected chain:\n%s", got)
+			}
+		})
+	}
+}
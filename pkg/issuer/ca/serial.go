@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// defaultSerialNumberBits is the number of random bits in serial numbers
+	// if the issuer doesn't set serialNumberBits.
+	defaultSerialNumberBits = 128
+
+	// maxSerialNumberAttempts is the number of serial numbers that are
+	// generated before giving up, if they have all been issued before.
+	maxSerialNumberAttempts = 5
+)
+
+// SerialNumberConfigMapName returns the name of the ConfigMap in which the
+// serial numbers issued by the given CA issuer are tracked.
+func SerialNumberConfigMapName(iss *cmapi.CAIssuer) string {
+	return iss.SecretName + "-serial-numbers"
+}
+
+// GenerateSerialNumber returns a random serial number for a certificate
+// issued by the given CA issuer.
+// If the issuer tracks serial numbers, serial numbers which have been issued
+// before are never returned, and the returned serial number is recorded in
+// the issuer's ConfigMap in the given namespace, along with the given
+// namespaced name of the request it is issued for. The ConfigMap is updated
+// with optimistic concurrency, so a conflict with a concurrent issuance is
+// returned as an error and should be retried.
+func GenerateSerialNumber(ctx context.Context, client kubernetes.Interface, namespace string, iss *cmapi.CAIssuer, request string) (*big.Int, error) {
+	bits := iss.SerialNumberBits
+	if bits == 0 {
+		bits = defaultSerialNumberBits
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+
+	var cm *corev1.ConfigMap
+	if iss.TrackSerialNumbers {
+		var err error
+		cm, err = client.CoreV1().ConfigMaps(namespace).Get(ctx, SerialNumberConfigMapName(iss), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: SerialNumberConfigMapName(iss)}}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; attempt < maxSerialNumberAttempts; attempt++ {
+		serial, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate serial number: %w", err)
+		}
+		// Serial numbers must be positive, see RFC 5280 section 4.1.2.2.
+		if serial.Sign() == 0 {
+			continue
+		}
+		if cm == nil {
+			return serial, nil
+		}
+
+		key := serial.Text(16)
+		if _, ok := cm.Data[key]; ok {
+			continue
+		}
+		cm = cm.DeepCopy()
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[key] = request
+
+		if len(cm.ResourceVersion) == 0 {
+			_, err = client.CoreV1().ConfigMaps(namespace).Create(ctx, cm, metav1.CreateOptions{})
+		} else {
+			_, err = client.CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to record serial number in ConfigMap %s/%s: %w", namespace, cm.Name, err)
+		}
+		return serial, nil
+	}
+
+	return nil, fmt.Errorf("failed to generate a serial number which has not been issued before after %d attempts", maxSerialNumberAttempts)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestGenerateSerialNumber(t *testing.T) {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ca-serial-numbers", ResourceVersion: "1"},
+		Data:       map[string]string{"abc": "ns/previous"},
+	}
+
+	tests := map[string]struct {
+		issuer   *cmapi.CAIssuer
+		objects  []*corev1.ConfigMap
+		expBits  int
+		expVerbs []string
+		expOld   bool
+	}{
+		"untracked serial numbers have 128 bits by default": {
+			issuer:  &cmapi.CAIssuer{SecretName: "ca"},
+			expBits: 128,
+		},
+		"untracked serial numbers have the configured number of bits": {
+			issuer:  &cmapi.CAIssuer{SecretName: "ca", SerialNumberBits: 64},
+			expBits: 64,
+		},
+		"tracked serial number is recorded in a new ConfigMap": {
+			issuer:   &cmapi.CAIssuer{SecretName: "ca", TrackSerialNumbers: true},
+			expBits:  128,
+			expVerbs: []string{"get", "create"},
+		},
+		"tracked serial number is added to the existing ConfigMap": {
+			issuer:   &cmapi.CAIssuer{SecretName: "ca", TrackSerialNumbers: true},
+			objects:  []*corev1.ConfigMap{existing},
+			expBits:  128,
+			expVerbs: []string{"get", "update"},
+			expOld:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			for _, cm := range test.objects {
+				if err := client.Tracker().Add(cm); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			serial, err := GenerateSerialNumber(context.TODO(), client, "ns", test.issuer, "ns/request")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if serial.Sign() <= 0 || serial.BitLen() > test.expBits {
+				t.Errorf("expected a positive serial number of at most %d bits, got %s", test.expBits, serial.Text(16))
+			}
+
+			var verbs []string
+			for _, action := range client.Actions() {
+				verbs = append(verbs, action.GetVerb())
+			}
+			if len(verbs) != len(test.expVerbs) {
+				t.Fatalf("expected actions %v, got %v", test.expVerbs, verbs)
+			}
+			for i := range verbs {
+				if verbs[i] != test.expVerbs[i] {
+					t.Fatalf("expected actions %v, got %v", test.expVerbs, verbs)
+				}
+			}
+			if !test.issuer.TrackSerialNumbers {
+				return
+			}
+
+			cm, err := client.CoreV1().ConfigMaps("ns").Get(context.TODO(), SerialNumberConfigMapName(test.issuer), metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cm.Data[serial.Text(16)]; got != "ns/request" {
+				t.Errorf("expected serial number to be recorded for ns/request, got %q", got)
+			}
+			if _, ok := cm.Data["abc"]; ok != test.expOld {
+				t.Errorf("expected previously recorded serial number to be kept: %t", test.expOld)
+			}
+		})
+	}
+}
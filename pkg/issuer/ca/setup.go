@@ -45,6 +45,10 @@ func (c *CA) Setup(ctx context.Context) error {
 
 	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
 	if err != nil {
+		if bootstrapping, err := c.setupBootstrap(ctx); bootstrapping || err != nil {
+			return err
+		}
+
 		log.Error(err, "error getting signing CA TLS certificate")
 		s := messageErrorGetKeyPair + err.Error()
 		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
@@ -54,6 +58,10 @@ func (c *CA) Setup(ctx context.Context) error {
 
 	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
 	if err != nil {
+		if bootstrapping, err := c.setupBootstrap(ctx); bootstrapping || err != nil {
+			return err
+		}
+
 		log.Error(err, "error getting signing CA private key")
 		s := messageErrorGetKeyPair + err.Error()
 		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
@@ -77,3 +85,34 @@ func (c *CA) Setup(ctx context.Context) error {
 
 	return nil
 }
+
+// setupBootstrap sets the Ready condition of an issuer whose CA Secret is
+// missing or empty because the Certificate producing it hasn't been issued
+// yet. It returns false if the CA Secret is not produced by a Certificate.
+// No error is returned for a pending or cyclic bootstrap: the issuer is
+// synced again once the CA Secret or the Certificate producing it changes.
+func (c *CA) setupBootstrap(ctx context.Context) (bool, error) {
+	log := logf.FromContext(ctx, "setup")
+
+	ready, err := c.bootstrap.secretReady(c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	if err != nil || ready {
+		return false, err
+	}
+
+	reason, message, err := c.bootstrap.check(c.issuer)
+	if err != nil || reason == "" {
+		return false, err
+	}
+
+	eventType := corev1.EventTypeNormal
+	if reason == reasonBootstrapCycle {
+		log.Error(nil, "CA issuer depends on itself to issue its CA", "message", message)
+		eventType = corev1.EventTypeWarning
+	} else {
+		log.V(logf.DebugLevel).Info("waiting for the CA to be issued", "message", message)
+	}
+	c.Recorder.Event(c.issuer, eventType, reason, message)
+	apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, reason, message)
+
+	return true, nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package delegate implements the "delegate" issuer type, which passes
+// CertificateRequests on to one of several backing issuers.
+// The CertificateRequests are handled by the certificaterequests-issuer-delegate
+// controller.
+package delegate
+
+import (
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+)
+
+// Delegate is an issuer that passes CertificateRequests on to backing issuers.
+type Delegate struct {
+	*controller.Context
+
+	issuer v1.GenericIssuer
+}
+
+func NewDelegate(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
+	return &Delegate{
+		Context: ctx,
+		issuer:  issuer,
+	}, nil
+}
+
+// Register this Issuer with the issuer factory
+func init() {
+	issuer.RegisterIssuer(apiutil.IssuerDelegate, NewDelegate)
+}
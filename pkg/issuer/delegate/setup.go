@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegate
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+const (
+	errorFeatureGateDisabled = "FeatureGateDisabled"
+
+	successReady = "IsReady"
+
+	messageFeatureGateDisabled = "The DelegateIssuer feature gate must be enabled on the controller to use the delegate issuer"
+)
+
+// Setup marks the issuer as ready, unless the DelegateIssuer feature gate is
+// disabled, in which case no controller would sign its CertificateRequests.
+func (d *Delegate) Setup(ctx context.Context) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.DelegateIssuer) {
+		d.Recorder.Event(d.issuer, corev1.EventTypeWarning, errorFeatureGateDisabled, messageFeatureGateDisabled)
+		apiutil.SetIssuerCondition(d.issuer, d.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorFeatureGateDisabled, messageFeatureGateDisabled)
+		// Don't return an error here as there is nothing more we can do
+		return nil
+	}
+
+	apiutil.SetIssuerCondition(d.issuer, d.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successReady, "")
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakeissuer implements the "fake" issuer type, which signs
+// certificates using an ephemeral CA generated in memory by the controller.
+// The certificates are signed by the certificaterequests-issuer-fake
+// controller.
+package fakeissuer
+
+import (
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+)
+
+// Fake is an issuer that signs certificates using an ephemeral in-memory CA.
+type Fake struct {
+	*controller.Context
+
+	issuer v1.GenericIssuer
+}
+
+func NewFake(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
+	return &Fake{
+		Context: ctx,
+		issuer:  issuer,
+	}, nil
+}
+
+// Register this Issuer with the issuer factory
+func init() {
+	issuer.RegisterIssuer(apiutil.IssuerFake, NewFake)
+}
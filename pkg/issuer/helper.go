@@ -19,9 +19,17 @@ package issuer
 import (
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 // Helper is an interface that defines a method that returns an issuer for the given
@@ -36,17 +44,59 @@ type Helper interface {
 type helperImpl struct {
 	issuerLister        cmlisters.IssuerLister
 	clusterIssuerLister cmlisters.ClusterIssuerLister
+
+	// grantLister is nil if cross-namespace issuer references are not
+	// enabled.
+	grantLister cmlisters.IssuerReferenceGrantLister
 }
 
 var _ Helper = &helperImpl{}
 
 // NewHelper will construct a new instance of a Helper using values supplied on
 // the provided controller context.
-func NewHelper(issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlisters.ClusterIssuerLister) Helper {
+// If grantLister is nil, references to Issuers in other namespaces are
+// always forbidden.
+func NewHelper(issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlisters.ClusterIssuerLister, grantLister cmlisters.IssuerReferenceGrantLister) Helper {
 	return &helperImpl{
 		issuerLister:        issuerLister,
 		clusterIssuerLister: clusterIssuerLister,
+		grantLister:         grantLister,
+	}
+}
+
+// NewHelperFromContext returns a Helper which reads Issuers, ClusterIssuers
+// and IssuerReferenceGrants from the shared informer factory of the given
+// controller Context, along with the InformerSynced functions that must be
+// synced before it is used. ClusterIssuers are not read if the Context is
+// scoped to a single namespace.
+func NewHelperFromContext(ctx *controllerpkg.Context) (Helper, []cache.InformerSynced) {
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	mustSync := []cache.InformerSynced{issuerInformer.Informer().HasSynced}
+
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+	}
+
+	grantLister, grantsSynced := IssuerReferenceGrantLister(ctx.SharedInformerFactory)
+	mustSync = append(mustSync, grantsSynced...)
+
+	return NewHelper(issuerInformer.Lister(), clusterIssuerLister, grantLister), mustSync
+}
+
+// IssuerReferenceGrantLister returns a lister for the IssuerReferenceGrants
+// informed by the given factory, and the InformerSynced functions that must
+// be synced before it is used. It returns a nil lister if the
+// IssuerReferenceGrants feature gate is disabled, so that the informer is
+// never started.
+func IssuerReferenceGrantLister(factory cminformers.SharedInformerFactory) (cmlisters.IssuerReferenceGrantLister, []cache.InformerSynced) {
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.IssuerReferenceGrants) {
+		return nil, nil
 	}
+	informer := factory.Certmanager().V1().IssuerReferenceGrants()
+	return informer.Lister(), []cache.InformerSynced{informer.Informer().HasSynced}
 }
 
 // GetGenericIssuer will return an Issuer for the given IssuerRef.
@@ -54,9 +104,18 @@ func NewHelper(issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlister
 // This namespace will be used to read the Issuer resource.
 // In most cases, the ns parameter should be set to the namespace of the resource
 // that defines the IssuerRef (i.e. the namespace of the Certificate resource).
+// If the IssuerRef references an Issuer in another namespace, a Forbidden
+// error is returned unless an IssuerReferenceGrant in that namespace allows
+// references from ns.
 func (h *helperImpl) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cmapi.GenericIssuer, error) {
 	switch ref.Kind {
 	case "", cmapi.IssuerKind:
+		if ref.Namespace != "" && ref.Namespace != ns {
+			if err := h.checkReferenceGranted(ref, ns); err != nil {
+				return nil, err
+			}
+			return h.issuerLister.Issuers(ref.Namespace).Get(ref.Name)
+		}
 		return h.issuerLister.Issuers(ns).Get(ref.Name)
 	case cmapi.ClusterIssuerKind:
 		// handle edge case where the ClusterIssuerLister is not set.
@@ -72,3 +131,50 @@ func (h *helperImpl) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cm
 		return nil, fmt.Errorf(`invalid value %q for issuerRef.kind. Must be empty, %q or %q`, ref.Kind, cmapi.IssuerKind, cmapi.ClusterIssuerKind)
 	}
 }
+
+// checkReferenceGranted returns a Forbidden error unless an
+// IssuerReferenceGrant in the namespace of ref allows resources in ns to
+// reference the Issuer.
+func (h *helperImpl) checkReferenceGranted(ref cmmeta.ObjectReference, ns string) error {
+	if h.grantLister == nil {
+		return apierrors.NewForbidden(cmapi.Resource("issuers"), ref.Name,
+			fmt.Errorf("referencing an Issuer in another namespace requires the IssuerReferenceGrants feature gate to be enabled"))
+	}
+
+	grants, err := h.grantLister.IssuerReferenceGrants(ref.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, grant := range grants {
+		if grantAllowsReference(grant, ns, ref.Name) {
+			return nil
+		}
+	}
+	return apierrors.NewForbidden(cmapi.Resource("issuers"), ref.Name,
+		fmt.Errorf("no IssuerReferenceGrant in namespace %q allows references from namespace %q", ref.Namespace, ns))
+}
+
+// grantAllowsReference returns true if the given IssuerReferenceGrant allows
+// resources in namespace ns to reference the Issuer with the given name.
+func grantAllowsReference(grant *cmapi.IssuerReferenceGrant, ns, name string) bool {
+	fromAllowed := false
+	for _, from := range grant.Spec.From {
+		if from.Namespace == ns {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+
+	if len(grant.Spec.To) == 0 {
+		return true
+	}
+	for _, to := range grant.Spec.To {
+		if to.Name == name {
+			return true
+		}
+	}
+	return false
+}
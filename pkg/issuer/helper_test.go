@@ -20,6 +20,8 @@ import (
 	"reflect"
 	"testing"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -109,3 +111,84 @@ func TestGetGenericIssuer(t *testing.T) {
 		})
 	}
 }
+
+func TestGetGenericIssuerInOtherNamespace(t *testing.T) {
+	iss := gen.Issuer("shared-issuer", gen.SetIssuerNamespace("issuers"))
+	grant := func(from []string, to ...string) *v1.IssuerReferenceGrant {
+		g := &v1.IssuerReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "issuers"},
+		}
+		for _, ns := range from {
+			g.Spec.From = append(g.Spec.From, v1.IssuerReferenceGrantFrom{Namespace: ns})
+		}
+		for _, name := range to {
+			g.Spec.To = append(g.Spec.To, v1.IssuerReferenceGrantTo{Name: name})
+		}
+		return g
+	}
+
+	tests := map[string]struct {
+		cmObjects      []runtime.Object
+		nilGrantLister bool
+		expForbidden   bool
+	}{
+		"allowed by a grant for all Issuers in the namespace": {
+			cmObjects: []runtime.Object{iss, grant([]string{"other", gen.DefaultTestNamespace})},
+		},
+		"allowed by a grant for the named Issuer": {
+			cmObjects: []runtime.Object{iss, grant([]string{gen.DefaultTestNamespace}, "shared-issuer")},
+		},
+		"forbidden without a grant": {
+			cmObjects:    []runtime.Object{iss},
+			expForbidden: true,
+		},
+		"forbidden by a grant for another namespace": {
+			cmObjects:    []runtime.Object{iss, grant([]string{"other"})},
+			expForbidden: true,
+		},
+		"forbidden by a grant for another Issuer": {
+			cmObjects:    []runtime.Object{iss, grant([]string{gen.DefaultTestNamespace}, "other-issuer")},
+			expForbidden: true,
+		},
+		"forbidden if cross-namespace references are disabled": {
+			cmObjects:      []runtime.Object{iss, grant([]string{gen.DefaultTestNamespace})},
+			nilGrantLister: true,
+			expForbidden:   true,
+		},
+	}
+
+	for name, row := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := test.Builder{
+				CertManagerObjects: row.cmObjects,
+			}
+			b.Init()
+			c := &helperImpl{
+				issuerLister:        b.FakeCMInformerFactory().Certmanager().V1().Issuers().Lister(),
+				clusterIssuerLister: b.FakeCMInformerFactory().Certmanager().V1().ClusterIssuers().Lister(),
+				grantLister:         b.FakeCMInformerFactory().Certmanager().V1().IssuerReferenceGrants().Lister(),
+			}
+			b.Start()
+			defer b.Stop()
+
+			if row.nilGrantLister {
+				c.grantLister = nil
+			}
+
+			ref := cmmeta.ObjectReference{Name: "shared-issuer", Kind: "Issuer", Namespace: "issuers"}
+			actual, err := c.GetGenericIssuer(ref, gen.DefaultTestNamespace)
+			if row.expForbidden {
+				if !apierrors.IsForbidden(err) {
+					t.Errorf("Expected a Forbidden error, but got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %s", err)
+			}
+			if !reflect.DeepEqual(actual, iss) {
+				t.Errorf("Expected %#v but got %#v", iss, actual)
+			}
+		})
+	}
+}
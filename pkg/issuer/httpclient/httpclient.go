@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpclient configures the HTTP clients used by issuers according
+// to the httpClient field of an Issuer or ClusterIssuer.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// Config is the resolved HTTP client configuration of an issuer.
+type Config struct {
+	// Proxy returns the proxy to use for a given request. It has the same
+	// semantics as http.Transport.Proxy.
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// RootCAs is the pool of CAs which are trusted when connecting using
+	// HTTPS. If nil, the system root certificates are used.
+	RootCAs *x509.CertPool
+}
+
+// Load resolves the HTTP client configuration of the given issuer. Any Secret
+// referenced by the configuration is read from namespace using secretsLister.
+// If the issuer has no HTTP client configuration, the returned Config uses the
+// proxy environment variables of the controller and the system root
+// certificates.
+func Load(issuer cmapi.GenericIssuer, namespace string, secretsLister corelisters.SecretLister) (*Config, error) {
+	cfg := &Config{Proxy: http.ProxyFromEnvironment}
+
+	spec := issuer.GetSpec().HTTPClient
+	if spec == nil {
+		return cfg, nil
+	}
+
+	if len(spec.ProxyURL) > 0 {
+		proxy, err := ProxyFunc(spec.ProxyURL, spec.NoProxy)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Proxy = proxy
+	}
+
+	caBundle, err := caBundle(spec, namespace, secretsLister)
+	if err != nil {
+		return nil, err
+	}
+	if len(caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if ok := pool.AppendCertsFromPEM(caBundle); !ok {
+			return nil, fmt.Errorf("no CA certificates could be loaded from the httpClient CA bundle, check bundle contents")
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
+
+// ApplyTo configures the given transport to use the proxy and root CAs of the
+// Config. RootCAs that have already been configured on the transport are not
+// replaced, so that CA bundles that are specific to an issuer type take
+// precedence.
+func (c *Config) ApplyTo(transport *http.Transport) {
+	transport.Proxy = c.Proxy
+
+	if c.RootCAs == nil {
+		return
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	if transport.TLSClientConfig.RootCAs == nil {
+		transport.TLSClientConfig.RootCAs = c.RootCAs
+	}
+}
+
+// ProxyFunc returns a function which can be used as http.Transport.Proxy to
+// send all requests through proxyURL, except for requests to the hosts
+// matched by noProxy. The entries of noProxy have the same format as the
+// entries of the NO_PROXY environment variable.
+func ProxyFunc(proxyURL string, noProxy []string) (func(*http.Request) (*url.URL, error), error) {
+	if _, err := url.Parse(proxyURL); err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+
+	proxyCfg := &httpproxy.Config{
+		HTTPProxy:  proxyURL,
+		HTTPSProxy: proxyURL,
+		NoProxy:    strings.Join(noProxy, ","),
+	}
+	proxyForURL := proxyCfg.ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyForURL(req.URL)
+	}, nil
+}
+
+func caBundle(spec *cmapi.IssuerHTTPClient, namespace string, secretsLister corelisters.SecretLister) ([]byte, error) {
+	if len(spec.CABundle) > 0 {
+		return spec.CABundle, nil
+	}
+
+	ref := spec.CABundleSecretRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not access secret '%s/%s': %s", namespace, ref.Name, err)
+	}
+
+	key := cmmeta.TLSCAKey
+	if ref.Key != "" {
+		key = ref.Key
+	}
+
+	certBytes, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("no data for %q in secret '%s/%s'", key, namespace, ref.Name)
+	}
+
+	return certBytes, nil
+}
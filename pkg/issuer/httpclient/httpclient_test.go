@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+func generateSecretLister(s *corev1.Secret, err error) corelisters.SecretLister {
+	return &testlisters.FakeSecretLister{
+		SecretsFn: func(string) corelisters.SecretNamespaceLister {
+			return &testlisters.FakeSecretNamespaceLister{
+				GetFn: func(string) (*corev1.Secret, error) {
+					return s, err
+				},
+			}
+		},
+	}
+}
+
+func TestLoad(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	secretRef := &cmmeta.SecretKeySelector{
+		LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca"},
+	}
+
+	tests := map[string]struct {
+		spec          *cmapi.IssuerHTTPClient
+		secretsLister corelisters.SecretLister
+		expectErr     bool
+		expectRootCAs bool
+	}{
+		"no configuration uses the system defaults": {},
+		"proxy without a CA bundle": {
+			spec: &cmapi.IssuerHTTPClient{ProxyURL: "http://proxy.example.com:3128"},
+		},
+		"inline CA bundle": {
+			spec:          &cmapi.IssuerHTTPClient{CABundle: caBundle},
+			expectRootCAs: true,
+		},
+		"invalid inline CA bundle": {
+			spec:      &cmapi.IssuerHTTPClient{CABundle: []byte("invalid")},
+			expectErr: true,
+		},
+		"CA bundle from the default Secret key": {
+			spec: &cmapi.IssuerHTTPClient{CABundleSecretRef: secretRef},
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{cmmeta.TLSCAKey: caBundle},
+			}, nil),
+			expectRootCAs: true,
+		},
+		"CA bundle Secret is missing the key": {
+			spec: &cmapi.IssuerHTTPClient{CABundleSecretRef: secretRef},
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{"other": caBundle},
+			}, nil),
+			expectErr: true,
+		},
+		"CA bundle Secret cannot be read": {
+			spec:          &cmapi.IssuerHTTPClient{CABundleSecretRef: secretRef},
+			secretsLister: generateSecretLister(nil, errors.New("this is a network error")),
+			expectErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("test", gen.SetIssuerNamespace("test-namespace"))
+			if test.spec != nil {
+				iss.Spec.HTTPClient = test.spec
+			}
+
+			cfg, err := Load(iss, "test-namespace", test.secretsLister)
+			if err != nil != test.expectErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.Proxy == nil {
+				t.Errorf("expected a proxy function to always be set")
+			}
+			if (cfg.RootCAs != nil) != test.expectRootCAs {
+				t.Errorf("unexpected root CAs, exp=%t got=%v", test.expectRootCAs, cfg.RootCAs)
+			}
+			if !test.expectRootCAs {
+				return
+			}
+
+			transport := &http.Transport{}
+			cfg.ApplyTo(transport)
+			resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+			if err != nil {
+				t.Fatalf("expected the server certificate to be trusted, got: %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	proxy, err := ProxyFunc("http://proxy.example.com:3128", []string{"internal.example.com", "10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"https://acme-v02.api.letsencrypt.org/directory": "http://proxy.example.com:3128",
+		"http://vault.example.com:8200":                  "http://proxy.example.com:3128",
+		"https://internal.example.com/vedsdk":            "",
+		"https://api.internal.example.com":               "",
+		"https://10.1.2.3:8200":                          "",
+	}
+
+	for target, expected := range tests {
+		t.Run(target, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, target, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			u, err := proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if u != nil {
+				got = u.String()
+			}
+			if got != expected {
+				t.Errorf("unexpected proxy, exp=%q got=%q", expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyToKeepsExistingRootCAs(t *testing.T) {
+	existing := x509.NewCertPool()
+	cfg := &Config{Proxy: http.ProxyFromEnvironment, RootCAs: x509.NewCertPool()}
+
+	transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: existing}}
+	cfg.ApplyTo(transport)
+	if transport.TLSClientConfig.RootCAs != existing {
+		t.Errorf("expected root CAs already configured on the transport to take precedence")
+	}
+
+	transport = &http.Transport{}
+	cfg.ApplyTo(transport)
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs != cfg.RootCAs {
+		t.Errorf("expected root CAs to be set on the transport")
+	}
+}
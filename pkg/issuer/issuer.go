@@ -44,4 +44,9 @@ type IssueResponse struct {
 	// This field should only be set if the private key field is set, similar
 	// to the Certificate field.
 	CA []byte
+
+	// CrossSignedChain is the chain from the CA to another root which
+	// cross-signed it, followed by that root. It is only set by issuers whose
+	// root is cross-signed.
+	CrossSignedChain []byte
 }
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"context"
+	"crypto/x509"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+const (
+	errorFeatureGateDisabled = "FeatureGateDisabled"
+	errorInvalidCABundle     = "InvalidCABundle"
+
+	successReady = "IsReady"
+
+	messageFeatureGateDisabled = "The OfflineIssuer feature gate must be enabled on the controller to use the offline issuer"
+	messageInvalidCABundle     = "The CA bundle of the offline issuer does not contain any valid certificates"
+	messageReady               = "Waiting for CertificateRequests to be signed offline"
+)
+
+// Setup marks the issuer as ready if its CA bundle is valid, unless the
+// OfflineIssuer feature gate is disabled, in which case no controller would
+// import the certificates of its CertificateRequests.
+func (o *Offline) Setup(ctx context.Context) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.OfflineIssuer) {
+		o.Recorder.Event(o.issuer, corev1.EventTypeWarning, errorFeatureGateDisabled, messageFeatureGateDisabled)
+		apiutil.SetIssuerCondition(o.issuer, o.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorFeatureGateDisabled, messageFeatureGateDisabled)
+		// Don't return an error here as there is nothing more we can do
+		return nil
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(o.issuer.GetSpec().Offline.CABundle) {
+		o.Recorder.Event(o.issuer, corev1.EventTypeWarning, errorInvalidCABundle, messageInvalidCABundle)
+		apiutil.SetIssuerCondition(o.issuer, o.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorInvalidCABundle, messageInvalidCABundle)
+		return nil
+	}
+
+	apiutil.SetIssuerCondition(o.issuer, o.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successReady, messageReady)
+	return nil
+}
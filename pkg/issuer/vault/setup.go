@@ -37,12 +37,13 @@ const (
 	messageVaultStatusVerificationFailed = "Vault is not initialized or is sealed"
 	messageVaultConfigRequired           = "Vault config cannot be empty"
 	messageServerAndPathRequired         = "Vault server and path are required fields"
-	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, or kubernetes is required"
+	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, kubernetes, or clientCertificate is required"
 	messageMultipleAuthFieldsSet         = "Multiple auth methods cannot be set on the same Vault issuer"
 
-	messageKubeAuthFieldsRequired    = "Vault Kubernetes auth requires both role and secretRef.name"
-	messageTokenAuthNameRequired     = "Vault Token auth requires tokenSecretRef.name"
-	messageAppRoleAuthFieldsRequired = "Vault AppRole auth requires both roleId and tokenSecretRef.name"
+	messageKubeAuthFieldsRequired       = "Vault Kubernetes auth requires both role and secretRef.name"
+	messageTokenAuthNameRequired        = "Vault Token auth requires tokenSecretRef.name"
+	messageAppRoleAuthFieldsRequired    = "Vault AppRole auth requires both roleId and tokenSecretRef.name"
+	messageClientCertAuthFieldsRequired = "Vault Client Certificate auth requires clientCertificate.secretName"
 )
 
 // Setup creates a new Vault client and attempts to authenticate with the Vault instance and sets the issuer's conditions to reflect the success of the setup.
@@ -64,18 +65,24 @@ func (v *Vault) Setup(ctx context.Context) error {
 	tokenAuth := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	appRoleAuth := v.issuer.GetSpec().Vault.Auth.AppRole
 	kubeAuth := v.issuer.GetSpec().Vault.Auth.Kubernetes
+	clientCertAuth := v.issuer.GetSpec().Vault.Auth.ClientCertificate
+
+	authMethods := 0
+	for _, set := range []bool{tokenAuth != nil, appRoleAuth != nil, kubeAuth != nil, clientCertAuth != nil} {
+		if set {
+			authMethods++
+		}
+	}
 
 	// check if at least one auth method is specified.
-	if tokenAuth == nil && appRoleAuth == nil && kubeAuth == nil {
+	if authMethods == 0 {
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageAuthFieldsRequired)
 		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageAuthFieldsRequired)
 		return nil
 	}
 
 	// check only one auth method set
-	if (tokenAuth != nil && appRoleAuth != nil) ||
-		(tokenAuth != nil && kubeAuth != nil) ||
-		(appRoleAuth != nil && kubeAuth != nil) {
+	if authMethods > 1 {
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageMultipleAuthFieldsSet)
 		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageMultipleAuthFieldsSet)
 		return nil
@@ -102,6 +109,13 @@ func (v *Vault) Setup(ctx context.Context) error {
 		return nil
 	}
 
+	// check if all mandatory Vault Client Certificate fields are set.
+	if clientCertAuth != nil && len(clientCertAuth.SecretName) == 0 {
+		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageClientCertAuthFieldsRequired)
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageClientCertAuthFieldsRequired)
+		return nil
+	}
+
 	client, err := vaultinternal.New(v.resourceNamespace, v.secretsLister, v.issuer)
 	if err != nil {
 		s := messageVaultClientInitFailed + err.Error()
@@ -171,7 +171,7 @@ func newVRequest(cert *x509.Certificate) *certificate.Request {
 
 func getVcertFriendlyName(crt *x509.Certificate) (string, error) {
 	// Set the 'ObjectName' through the vcert friendly name. This is set in
-	// order of precedence CN->DNS->URI.
+	// order of precedence CN->DNS->URI->Email->IP.
 	switch {
 	case len(crt.Subject.CommonName) > 0:
 		return crt.Subject.CommonName, nil
@@ -184,6 +184,6 @@ func getVcertFriendlyName(crt *x509.Certificate) (string, error) {
 	case len(crt.IPAddresses) > 0:
 		return crt.IPAddresses[0].String(), nil
 	default:
-		return "", errors.New("certificate request contains no Common Name, DNS Name, nor URI SAN, at least one must be supplied to be used as the Venafi certificate objects name")
+		return "", errors.New("certificate request contains no Common Name, DNS Name, URI SAN, Email SAN, nor IP Address, at least one must be supplied to be used as the Venafi certificate objects name")
 	}
 }
@@ -33,6 +33,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/httpclient"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
@@ -128,6 +129,12 @@ func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.
 // that can be used to instantiate an API client.
 func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLister, namespace string) (*vcert.Config, error) {
 	venCfg := iss.GetSpec().Venafi
+
+	httpClientConfig, err := httpclient.Load(iss, namespace, secretsLister)
+	if err != nil {
+		return nil, err
+	}
+
 	switch {
 	case venCfg.TPP != nil:
 		tpp := venCfg.TPP
@@ -159,7 +166,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 				Password:    password,
 				AccessToken: accessToken,
 			},
-			Client: httpClientForVcertTPP(tpp.CABundle),
+			Client: httpClientForVcertTPP(tpp.CABundle, httpClientConfig),
 		}, nil
 	case venCfg.Cloud != nil:
 		cloud := venCfg.Cloud
@@ -174,16 +181,29 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 		}
 		apiKey := string(cloudSecret.Data[k])
 
-		return &vcert.Config{
+		// The zone of a Venafi Cloud issuer is the application and the
+		// alias of its issuing template, separated by a backslash.
+		zone := venCfg.Zone
+		if cloud.Application != "" {
+			zone = cloud.Application + "\\" + cloud.IssuingTemplate
+		}
+
+		cfg := &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeCloud,
 			BaseUrl:       cloud.URL,
-			Zone:          venCfg.Zone,
+			Zone:          zone,
 			// always enable verbose logging for now
 			LogVerbose: true,
 			Credentials: &endpoint.Authentication{
 				APIKey: apiKey,
 			},
-		}, nil
+		}
+		// Only override vcert's default HTTP client if the issuer has an
+		// HTTP client configuration.
+		if iss.GetSpec().HTTPClient != nil {
+			cfg.Client = httpClientForVcertCloud(httpClientConfig)
+		}
+		return cfg, nil
 	}
 	// API validation in webhook and in the ClusterIssuer and Issuer controller
 	// Sync functions should make this unreachable in production.
@@ -226,7 +246,10 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 //
 // [1] TLS protocol version support in Microsoft Windows: https://learn.microsoft.com/en-us/windows/win32/secauthn/protocols-in-tls-ssl--schannel-ssp-#tls-protocol-version-support
 // [2] Should I use SSL/TLS renegotiation?: https://security.stackexchange.com/a/24569
-func httpClientForVcertTPP(caBundle []byte) *http.Client {
+//
+// The proxy and root CAs of the issuer's HTTP client configuration are also
+// applied, with the TPP CA bundle taking precedence over the root CAs.
+func httpClientForVcertTPP(caBundle []byte, httpClientConfig *httpclient.Config) *http.Client {
 	// Copy vcert's default HTTP transport, which is mostly identical to the
 	// http.DefaultTransport settings in Go's stdlib.
 	// https://github.com/Venafi/vcert/blob/89645a7710a7b529765274cb60dc5e28066217a1/pkg/venafi/tpp/tpp.go#L481-L513
@@ -256,6 +279,7 @@ func httpClientForVcertTPP(caBundle []byte) *http.Client {
 		tlsClientConfig.RootCAs = rootCAs
 	}
 	transport.TLSClientConfig = tlsClientConfig
+	httpClientConfig.ApplyTo(transport)
 
 	// Enable TLS 1.2 renegotiation (see earlier comment for justification).
 	transport.TLSClientConfig.Renegotiation = tls.RenegotiateOnceAsClient
@@ -268,6 +292,37 @@ func httpClientForVcertTPP(caBundle []byte) *http.Client {
 	}
 }
 
+// httpClientForVcertCloud creates an HTTP client which is identical to the
+// default HTTP client of vcert's Cloud connector, except that the proxy and
+// root CAs of the issuer's HTTP client configuration are applied.
+// https://github.com/Venafi/vcert/blob/89645a7710a7b529765274cb60dc5e28066217a1/pkg/venafi/cloud/cloud.go#L265-L297
+func httpClientForVcertCloud(httpClientConfig *httpclient.Config) *http.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			DualStack: true,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	tlsClientConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig.Clone()
+	if tlsClientConfig == nil {
+		tlsClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig = tlsClientConfig
+	httpClientConfig.ApplyTo(transport)
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Second * 30,
+	}
+}
+
 func (v *Venafi) Ping() error {
 	return v.vcertClient.Ping()
 }
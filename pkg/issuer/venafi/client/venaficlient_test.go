@@ -178,6 +178,25 @@ func TestConfigForIssuerT(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if Cloud with application and issuing template, should use them as the zone": {
+			iss: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+					Cloud: &cmapi.VenafiCloud{
+						Application:     "test-application",
+						IssuingTemplate: "test-template",
+					},
+				}),
+			),
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					defaultAPIKeyKey: []byte(apiKey),
+				},
+			}, nil),
+			CheckFn: func(t *testing.T, cnf *vcert.Config) {
+				checkZone(t, "test-application\\test-template", cnf)
+			},
+			expectedErr: false,
+		},
 		"if TPP and Cloud, should chose TPP": {
 			iss: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerVenafi(cmapi.VenafiIssuer{
@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// RuleWildcardHTTP01 reports wildcard DNS names which would be solved
+	// using HTTP-01, which ACME servers do not allow for wildcards.
+	RuleWildcardHTTP01 = "wildcard-http01"
+
+	// RuleRenewBeforeDuration reports Certificates which would be renewed
+	// immediately after being issued.
+	RuleRenewBeforeDuration = "renew-before-duration"
+
+	// RuleCAUsages reports CA Certificates whose usages do not include
+	// 'cert sign'.
+	RuleCAUsages = "ca-usages"
+
+	// RuleVenafiSANTypes reports subject alternative names which are not
+	// supported by Venafi TLS Protect Cloud.
+	RuleVenafiSANTypes = "venafi-san-types"
+)
+
+func (l *linter) lintCertificate(crt *cmapi.Certificate) []Finding {
+	var findings []Finding
+	add := func(rule string, severity Severity, fldPath *field.Path, format string, args ...interface{}) {
+		findings = append(findings, Finding{
+			Kind:      cmapi.CertificateKind,
+			Namespace: crt.Namespace,
+			Name:      crt.Name,
+			Rule:      rule,
+			Severity:  severity,
+			Field:     fldPath.String(),
+			Message:   fmt.Sprintf(format, args...),
+		})
+	}
+	specPath := field.NewPath("spec")
+
+	if crt.Spec.RenewBefore != nil {
+		duration := cmapi.DefaultCertificateDuration
+		if crt.Spec.Duration != nil {
+			duration = crt.Spec.Duration.Duration
+		}
+		if crt.Spec.RenewBefore.Duration >= duration {
+			add(RuleRenewBeforeDuration, SeverityError, specPath.Child("renewBefore"),
+				"renewBefore (%s) must be shorter than the certificate duration (%s), otherwise the certificate is renewed as soon as it is issued",
+				crt.Spec.RenewBefore.Duration, duration)
+		}
+	}
+
+	if crt.Spec.IsCA && len(crt.Spec.Usages) > 0 && !hasUsage(crt.Spec.Usages, cmapi.UsageCertSign) {
+		add(RuleCAUsages, SeverityWarning, specPath.Child("usages"),
+			"CA certificates are always issued with the %q usage, which should be listed in usages", cmapi.UsageCertSign)
+	}
+
+	iss := l.issuerFor(crt)
+	if iss == nil {
+		return findings
+	}
+
+	issuerKind := crt.Spec.IssuerRef.Kind
+	if issuerKind == "" {
+		issuerKind = cmapi.IssuerKind
+	}
+
+	if acme := iss.GetSpec().ACME; acme != nil {
+		for i, dnsName := range crt.Spec.DNSNames {
+			if !strings.HasPrefix(dnsName, "*.") {
+				continue
+			}
+			if !anyDNS01SolverFor(acme.Solvers, crt, dnsName) {
+				add(RuleWildcardHTTP01, SeverityError, specPath.Child("dnsNames").Index(i),
+					"wildcard DNS name %q can only be validated using DNS-01, but %s %q has no DNS-01 solver matching it",
+					dnsName, issuerKind, crt.Spec.IssuerRef.Name)
+			}
+		}
+	}
+
+	if venafi := iss.GetSpec().Venafi; venafi != nil && venafi.Cloud != nil {
+		unsupported := []struct {
+			name   string
+			values []string
+		}{
+			{name: "ipAddresses", values: crt.Spec.IPAddresses},
+			{name: "emailAddresses", values: crt.Spec.EmailAddresses},
+			{name: "uris", values: crt.Spec.URIs},
+		}
+		for _, u := range unsupported {
+			if len(u.values) > 0 {
+				add(RuleVenafiSANTypes, SeverityWarning, specPath.Child(u.name),
+					"Venafi TLS Protect Cloud only supports DNS name subject alternative names and may reject the request")
+			}
+		}
+	}
+
+	return findings
+}
+
+func hasUsage(usages []cmapi.KeyUsage, usage cmapi.KeyUsage) bool {
+	for _, u := range usages {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
+
+// anyDNS01SolverFor returns true if any of the given solvers whose selector
+// matches the given DNS name of the Certificate is a DNS-01 solver.
+// Solvers without a DNS-01 configuration are ignored, so this does not
+// account for a more specific HTTP-01 solver being preferred over a DNS-01
+// solver.
+func anyDNS01SolverFor(solvers []cmacme.ACMEChallengeSolver, crt *cmapi.Certificate, dnsName string) bool {
+	for _, s := range solvers {
+		if s.DNS01 != nil && solverMatches(s, crt, dnsName) {
+			return true
+		}
+	}
+	return false
+}
+
+// solverMatches returns true if the selector of the given solver matches the
+// given DNS name of the Certificate.
+func solverMatches(s cmacme.ACMEChallengeSolver, crt *cmapi.Certificate, dnsName string) bool {
+	sel := s.Selector
+	if sel == nil {
+		return true
+	}
+
+	for k, v := range sel.MatchLabels {
+		if crt.Labels[k] != v {
+			return false
+		}
+	}
+
+	if len(sel.DNSNames) == 0 && len(sel.DNSZones) == 0 {
+		return true
+	}
+	for _, n := range sel.DNSNames {
+		if n == dnsName {
+			return true
+		}
+	}
+	domain := strings.TrimPrefix(dnsName, "*.")
+	for _, z := range sel.DNSZones {
+		if domain == z || strings.HasSuffix(domain, "."+z) {
+			return true
+		}
+	}
+	return false
+}
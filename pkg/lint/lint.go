@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint statically analyses cert-manager resources to catch common
+// misconfigurations before they are applied to a cluster.
+//
+// Unlike the validation performed by the cert-manager webhook, linting
+// considers Certificates together with the Issuers they reference, and also
+// reports configurations which are valid but are unlikely to work as intended.
+package lint
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Severity is the severity of a Finding.
+type Severity string
+
+const (
+	// SeverityError is used for findings which will prevent a certificate
+	// from being issued.
+	SeverityError Severity = "Error"
+
+	// SeverityWarning is used for findings which are likely to cause
+	// unexpected behaviour.
+	SeverityWarning Severity = "Warning"
+)
+
+// Finding is an issue found in a resource.
+type Finding struct {
+	// Kind, Namespace and Name identify the resource the finding is about.
+	Kind      string
+	Namespace string
+	Name      string
+
+	// Rule is the name of the rule which produced the finding.
+	Rule string
+
+	// Severity is the severity of the finding.
+	Severity Severity
+
+	// Field is the path of the field the finding is about.
+	Field string
+
+	// Message describes the finding.
+	Message string
+}
+
+// String returns a single line description of the finding.
+func (f Finding) String() string {
+	name := f.Name
+	if f.Namespace != "" {
+		name = f.Namespace + "/" + f.Name
+	}
+	return fmt.Sprintf("%s %s %s: %s: %s (%s)", f.Severity, f.Kind, name, f.Field, f.Message, f.Rule)
+}
+
+// Lint analyses the given resources and returns the issues found in them.
+// Certificates, Issuers and ClusterIssuers of the cert-manager.io/v1 API are
+// analysed, and all other resources are ignored.
+// Certificates are checked against the Issuers they reference when those
+// Issuers are part of the given resources.
+func Lint(objs []runtime.Object) []Finding {
+	l := &linter{
+		issuers:        make(map[string]*cmapi.Issuer),
+		clusterIssuers: make(map[string]*cmapi.ClusterIssuer),
+	}
+
+	var crts []*cmapi.Certificate
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *cmapi.Certificate:
+			crts = append(crts, o)
+		case *cmapi.Issuer:
+			l.issuers[o.Namespace+"/"+o.Name] = o
+		case *cmapi.ClusterIssuer:
+			l.clusterIssuers[o.Name] = o
+		}
+	}
+
+	var findings []Finding
+	for _, crt := range crts {
+		findings = append(findings, l.lintCertificate(crt)...)
+	}
+	return findings
+}
+
+type linter struct {
+	issuers        map[string]*cmapi.Issuer
+	clusterIssuers map[string]*cmapi.ClusterIssuer
+}
+
+// issuerFor returns the Issuer or ClusterIssuer referenced by the given
+// Certificate, or nil if it is not one of the linted resources.
+func (l *linter) issuerFor(crt *cmapi.Certificate) cmapi.GenericIssuer {
+	ref := crt.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != "cert-manager.io" {
+		return nil
+	}
+
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		if iss, ok := l.issuers[crt.Namespace+"/"+ref.Name]; ok {
+			return iss
+		}
+	case cmapi.ClusterIssuerKind:
+		if iss, ok := l.clusterIssuers[ref.Name]; ok {
+			return iss
+		}
+	}
+	return nil
+}
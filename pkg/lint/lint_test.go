@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestLint(t *testing.T) {
+	http01Solver := cmacme.ACMEChallengeSolver{
+		HTTP01: &cmacme.ACMEChallengeSolverHTTP01{Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{}},
+	}
+	dns01Solver := func(zones ...string) cmacme.ACMEChallengeSolver {
+		return cmacme.ACMEChallengeSolver{
+			Selector: &cmacme.CertificateDNSNameSelector{DNSZones: zones},
+			DNS01:    &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{}},
+		}
+	}
+	acmeIssuer := func(solvers ...cmacme.ACMEChallengeSolver) *cmapi.Issuer {
+		return gen.Issuer("acme", gen.SetIssuerACME(cmacme.ACMEIssuer{Solvers: solvers}))
+	}
+	issuerRef := gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "acme"})
+
+	tests := map[string]struct {
+		objs []runtime.Object
+		exp  []Finding
+	}{
+		"no issues": {
+			objs: []runtime.Object{
+				gen.Certificate("crt", issuerRef, gen.SetCertificateDNSNames("example.com", "*.example.com"),
+					gen.SetCertificateDuration(time.Hour*24), gen.SetCertificateRenewBefore(time.Hour)),
+				acmeIssuer(http01Solver, dns01Solver("example.com")),
+			},
+		},
+		"non cert-manager resources are ignored": {
+			objs: []runtime.Object{&cmacme.Order{}},
+		},
+		"wildcard with only a HTTP-01 solver": {
+			objs: []runtime.Object{
+				gen.Certificate("crt", issuerRef, gen.SetCertificateDNSNames("example.com", "*.example.com")),
+				acmeIssuer(http01Solver),
+			},
+			exp: []Finding{{
+				Kind: "Certificate", Namespace: gen.DefaultTestNamespace, Name: "crt",
+				Rule: RuleWildcardHTTP01, Severity: SeverityError, Field: "spec.dnsNames[1]",
+				Message: `wildcard DNS name "*.example.com" can only be validated using DNS-01, but Issuer "acme" has no DNS-01 solver matching it`,
+			}},
+		},
+		"wildcard with a DNS-01 solver for a different zone": {
+			objs: []runtime.Object{
+				gen.Certificate("crt", issuerRef, gen.SetCertificateDNSNames("*.example.com")),
+				acmeIssuer(http01Solver, dns01Solver("example.org")),
+			},
+			exp: []Finding{{
+				Kind: "Certificate", Namespace: gen.DefaultTestNamespace, Name: "crt",
+				Rule: RuleWildcardHTTP01, Severity: SeverityError, Field: "spec.dnsNames[0]",
+				Message: `wildcard DNS name "*.example.com" can only be validated using DNS-01, but Issuer "acme" has no DNS-01 solver matching it`,
+			}},
+		},
+		"wildcard with an issuer that is not linted": {
+			objs: []runtime.Object{
+				gen.Certificate("crt", issuerRef, gen.SetCertificateDNSNames("*.example.com")),
+			},
+		},
+		"renewBefore longer than the default duration": {
+			objs: []runtime.Object{
+				gen.Certificate("crt", gen.SetCertificateRenewBefore(time.Hour*24*90)),
+			},
+			exp: []Finding{{
+				Kind: "Certificate", Namespace: gen.DefaultTestNamespace, Name: "crt",
+				Rule: RuleRenewBeforeDuration, Severity: SeverityError, Field: "spec.renewBefore",
+				Message: "renewBefore (2160h0m0s) must be shorter than the certificate duration (2160h0m0s), otherwise the certificate is renewed as soon as it is issued",
+			}},
+		},
+		"CA with usages missing cert sign": {
+			objs: []runtime.Object{
+				gen.Certificate("crt", gen.SetCertificateIsCA(true), gen.SetCertificateKeyUsages(cmapi.UsageDigitalSignature)),
+			},
+			exp: []Finding{{
+				Kind: "Certificate", Namespace: gen.DefaultTestNamespace, Name: "crt",
+				Rule: RuleCAUsages, Severity: SeverityWarning, Field: "spec.usages",
+				Message: `CA certificates are always issued with the "cert sign" usage, which should be listed in usages`,
+			}},
+		},
+		"CA with default usages": {
+			objs: []runtime.Object{
+				gen.Certificate("crt", gen.SetCertificateIsCA(true)),
+			},
+		},
+		"Venafi Cloud with IP address and URI SANs": {
+			objs: []runtime.Object{
+				gen.Certificate("crt",
+					gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "venafi", Kind: cmapi.ClusterIssuerKind}),
+					gen.SetCertificateDNSNames("example.com"), gen.SetCertificateIPs("10.0.0.1"), gen.SetCertificateURIs("spiffe://example.com")),
+				gen.ClusterIssuer("venafi", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Cloud: &cmapi.VenafiCloud{}})),
+			},
+			exp: []Finding{
+				{
+					Kind: "Certificate", Namespace: gen.DefaultTestNamespace, Name: "crt",
+					Rule: RuleVenafiSANTypes, Severity: SeverityWarning, Field: "spec.ipAddresses",
+					Message: "Venafi TLS Protect Cloud only supports DNS name subject alternative names and may reject the request",
+				},
+				{
+					Kind: "Certificate", Namespace: gen.DefaultTestNamespace, Name: "crt",
+					Rule: RuleVenafiSANTypes, Severity: SeverityWarning, Field: "spec.uris",
+					Message: "Venafi TLS Protect Cloud only supports DNS name subject alternative names and may reject the request",
+				},
+			},
+		},
+		"Venafi TPP with IP address SANs": {
+			objs: []runtime.Object{
+				gen.Certificate("crt", gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "venafi"}), gen.SetCertificateIPs("10.0.0.1")),
+				gen.Issuer("venafi", gen.SetIssuerVenafi(cmapi.VenafiIssuer{TPP: &cmapi.VenafiTPP{}})),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, Lint(test.objs))
+		})
+	}
+}
+
+func TestFindingString(t *testing.T) {
+	f := Finding{
+		Kind: "Certificate", Namespace: "default", Name: "crt",
+		Rule: RuleCAUsages, Severity: SeverityWarning, Field: "spec.usages", Message: "some message",
+	}
+	assert.Equal(t, "Warning Certificate default/crt: spec.usages: some message (ca-usages)", f.String())
+}
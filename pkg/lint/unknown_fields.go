@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RuleUnknownField reports fields which are not part of the API version of a
+// resource, such as misspelt fields. The API server prunes such fields, or
+// rejects the resource when strict field validation is requested.
+const RuleUnknownField = "unknown-field"
+
+// maxSuggestionDistance is the maximum edit distance between an unknown field
+// and a known field for the known field to be suggested.
+const maxSuggestionDistance = 2
+
+var (
+	// unknownFieldRegexp matches the errors returned by strict decoding for
+	// unknown fields, and captures the path of the unknown field.
+	unknownFieldRegexp = regexp.MustCompile(`^unknown field "(.*)"$`)
+
+	// indexRegexp matches the list indices in the path of a field.
+	indexRegexp = regexp.MustCompile(`\[[0-9]+\]`)
+)
+
+// Decode converts the given unstructured resource into obj, and returns a
+// Finding for each field of the resource which is not part of the API version
+// of obj. Each Finding suggests the known field closest to the unknown field.
+func Decode(u *unstructured.Unstructured, obj runtime.Object) ([]Finding, error) {
+	err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(u.Object, obj, true)
+	if err == nil {
+		return nil, nil
+	}
+
+	strictErr, ok := runtime.AsStrictDecodingError(err)
+	if !ok {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, err := range strictErr.Errors() {
+		match := unknownFieldRegexp.FindStringSubmatch(err.Error())
+		if match == nil {
+			continue
+		}
+
+		path := match[1]
+		msg := "unknown field"
+		if suggestion := suggestField(reflect.TypeOf(obj), path); suggestion != "" {
+			msg += fmt.Sprintf(", did you mean %q?", suggestion)
+		}
+		findings = append(findings, Finding{
+			Kind:      u.GetKind(),
+			Namespace: u.GetNamespace(),
+			Name:      u.GetName(),
+			Rule:      RuleUnknownField,
+			Severity:  SeverityError,
+			Field:     path,
+			Message:   msg,
+		})
+	}
+	return findings, nil
+}
+
+// suggestField returns the path of the known field of the given type closest
+// to the unknown field at path, or the empty string if no known field is
+// close enough.
+func suggestField(t reflect.Type, path string) string {
+	segments := strings.Split(path, ".")
+	parents, name := segments[:len(segments)-1], segments[len(segments)-1]
+
+	for _, segment := range parents {
+		t = fieldType(t, indexRegexp.ReplaceAllString(segment, ""))
+		if t == nil {
+			return ""
+		}
+	}
+
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, known := range jsonFieldNames(t) {
+		distance := levenshtein(strings.ToLower(name), strings.ToLower(known))
+		if distance < bestDistance {
+			best, bestDistance = known, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+
+	return strings.Join(append(parents, best), ".")
+}
+
+// fieldType returns the type of the field with the given JSON name of the
+// given struct type, or nil if there is no such field. The element type is
+// returned for fields which are pointers, slices or arrays.
+func fieldType(t reflect.Type, name string) reflect.Type {
+	t = elemType(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName, inline := jsonName(field)
+		switch {
+		case inline:
+			if ft := fieldType(field.Type, name); ft != nil {
+				return ft
+			}
+		case jsonName == name:
+			return elemType(field.Type)
+		}
+	}
+	return nil
+}
+
+// jsonFieldNames returns the JSON names of the fields of the given struct
+// type, including those of inlined structs.
+func jsonFieldNames(t reflect.Type) []string {
+	t = elemType(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName, inline := jsonName(field)
+		switch {
+		case inline:
+			names = append(names, jsonFieldNames(field.Type)...)
+		case jsonName != "":
+			names = append(names, jsonName)
+		}
+	}
+	return names
+}
+
+// jsonName returns the JSON name of the given struct field, and whether the
+// field is inlined into its parent. The empty name is returned for fields
+// which are not encoded.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		if field.Anonymous {
+			return "", true
+		}
+		if !field.IsExported() {
+			return "", false
+		}
+		return field.Name, false
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" && (field.Anonymous || strings.Contains(opts, "inline")) {
+		return "", true
+	}
+	if name == "" {
+		return field.Name, false
+	}
+	return name, false
+}
+
+// elemType dereferences pointer, slice and array types.
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j] + 1
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if prev[j-1]+cost < curr[j] {
+				curr[j] = prev[j-1] + cost
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestSuggestField(t *testing.T) {
+	tests := map[string]struct {
+		path string
+		exp  string
+	}{
+		"suggests a misspelt spec field": {
+			path: "spec.renewBefor",
+			exp:  "spec.renewBefore",
+		},
+		"suggests a field with the wrong case": {
+			path: "spec.dnsnames",
+			exp:  "spec.dnsNames",
+		},
+		"suggests a nested field": {
+			path: "spec.privateKey.algorithim",
+			exp:  "spec.privateKey.algorithm",
+		},
+		"suggests a field of a list element": {
+			path: "spec.additionalOutputFormats[0].typ",
+			exp:  "spec.additionalOutputFormats[0].type",
+		},
+		"suggests an inlined metadata field": {
+			path: "metadata.labls",
+			exp:  "metadata.labels",
+		},
+		"suggests a top level field": {
+			path: "sepc",
+			exp:  "spec",
+		},
+		"does not suggest a field which is not close": {
+			path: "spec.somethingElse",
+			exp:  "",
+		},
+		"does not suggest a field for an unknown parent": {
+			path: "spec.unknown.foo",
+			exp:  "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := suggestField(reflect.TypeOf(&cmapi.Certificate{}), test.path); got != test.exp {
+				t.Errorf("unexpected suggestion, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]interface{}{
+			"name":      "crt",
+			"namespace": "ns",
+		},
+		"spec": map[string]interface{}{
+			"secretName":    "crt",
+			"renewBefor":    "1h",
+			"somethingElse": "abc",
+		},
+	}}
+
+	crt := &cmapi.Certificate{}
+	findings, err := Decode(u, crt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if crt.Spec.SecretName != "crt" {
+		t.Errorf("expected known fields to be decoded, got secretName=%q", crt.Spec.SecretName)
+	}
+
+	exp := []Finding{
+		{Kind: "Certificate", Namespace: "ns", Name: "crt", Rule: RuleUnknownField, Severity: SeverityError,
+			Field: "spec.renewBefor", Message: `unknown field, did you mean "spec.renewBefore"?`},
+		{Kind: "Certificate", Namespace: "ns", Name: "crt", Rule: RuleUnknownField, Severity: SeverityError,
+			Field: "spec.somethingElse", Message: "unknown field"},
+	}
+	if !reflect.DeepEqual(exp, findings) {
+		t.Errorf("unexpected findings, exp=%v got=%v", exp, findings)
+	}
+}
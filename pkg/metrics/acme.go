@@ -18,6 +18,8 @@ package metrics
 
 import (
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // ObserveACMERequestDuration increases bucket counters for that ACME client duration.
@@ -29,3 +31,16 @@ func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...s
 func (m *Metrics) IncrementACMERequestCount(labels ...string) {
 	m.acmeClientRequestCount.WithLabelValues(labels...).Inc()
 }
+
+// UpdateACMERateLimitBudget sets the estimated remaining budget of a rate
+// limit of the ACME server of an issuer. registeredDomain is empty for limits
+// which are not counted per registered domain.
+func (m *Metrics) UpdateACMERateLimitBudget(issuerName, issuerNamespace, issuerKind, limit, registeredDomain string, remaining int) {
+	m.acmeRateLimitBudgetRemaining.With(prometheus.Labels{
+		"issuer_name":       issuerName,
+		"issuer_namespace":  issuerNamespace,
+		"issuer_kind":       issuerKind,
+		"limit":             limit,
+		"registered_domain": registeredDomain,
+	}).Set(float64(remaining))
+}
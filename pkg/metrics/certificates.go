@@ -18,6 +18,7 @@ package metrics
 
 import (
 	"context"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/client-go/tools/cache"
@@ -42,6 +43,15 @@ func (m *Metrics) UpdateCertificate(ctx context.Context, crt *cmapi.Certificate)
 	m.updateCertificateRenewalTime(crt)
 }
 
+// ObserveCertificateIssuance observes the time taken to issue the given
+// Certificate.
+func (m *Metrics) ObserveCertificateIssuance(crt *cmapi.Certificate, duration time.Duration) {
+	m.certificateIssuanceDuration.With(prometheus.Labels{
+		"issuer_name":  crt.Spec.IssuerRef.Name,
+		"issuer_kind":  crt.Spec.IssuerRef.Kind,
+		"issuer_group": crt.Spec.IssuerRef.Group}).Observe(duration.Seconds())
+}
+
 // updateCertificateExpiry updates the expiry time of a certificate
 func (m *Metrics) updateCertificateExpiry(ctx context.Context, key string, crt *cmapi.Certificate) {
 	expiryTime := 0.0
@@ -23,7 +23,9 @@ import (
 	"time"
 
 	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 
@@ -364,3 +366,30 @@ func TestCertificateCache(t *testing.T) {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 }
+
+func TestObserveCertificateIssuance(t *testing.T) {
+	crt := gen.Certificate("test-certificate",
+		gen.SetCertificateNamespace("test-ns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer"}),
+	)
+
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	m.ObserveCertificateIssuance(crt, 42*time.Second)
+	m.ObserveCertificateIssuance(crt, 3*time.Second)
+
+	var metric dto.Metric
+	observer := m.certificateIssuanceDuration.With(prometheus.Labels{
+		"issuer_name":  "test-issuer",
+		"issuer_kind":  "Issuer",
+		"issuer_group": "",
+	})
+	if err := observer.(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatal(err)
+	}
+	if count := metric.GetHistogram().GetSampleCount(); count != 2 {
+		t.Errorf("expected 2 observations, got %d", count)
+	}
+	if sum := metric.GetHistogram().GetSampleSum(); sum != 45 {
+		t.Errorf("expected a sum of 45 seconds, got %v", sum)
+	}
+}
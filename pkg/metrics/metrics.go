@@ -19,8 +19,10 @@ limitations under the License.
 // certificate_expiration_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
 // certificate_renewal_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
 // certificate_ready_status{name, namespace, condition, issuer_name, issuer_kind, issuer_group}
+// certificate_issuance_duration_seconds{issuer_name, issuer_kind, issuer_group}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// acme_rate_limit_budget_remaining{"issuer_name", "issuer_namespace", "issuer_kind", "limit", "registered_domain"}
 // venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
 package metrics
@@ -57,8 +59,11 @@ type Metrics struct {
 	certificateExpiryTimeSeconds       *prometheus.GaugeVec
 	certificateRenewalTimeSeconds      *prometheus.GaugeVec
 	certificateReadyStatus             *prometheus.GaugeVec
+	certificateIssuanceDuration        *prometheus.HistogramVec
+	tlsSecretExpiryTimeSeconds         *prometheus.GaugeVec
 	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
 	acmeClientRequestCount             *prometheus.CounterVec
+	acmeRateLimitBudgetRemaining       *prometheus.GaugeVec
 	venafiClientRequestDurationSeconds *prometheus.SummaryVec
 	controllerSyncCallCount            *prometheus.CounterVec
 	controllerSyncErrorCount           *prometheus.CounterVec
@@ -127,6 +132,27 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"name", "namespace", "condition", "issuer_name", "issuer_kind", "issuer_group"},
 		)
 
+		// certificateIssuanceDuration is a Prometheus histogram of the time
+		// taken to issue Certificates.
+		certificateIssuanceDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "certificate_issuance_duration_seconds",
+				Help:      "The time from a Certificate starting to be issued until the issued certificate was stored in its Secret.",
+				Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+			},
+			[]string{"issuer_name", "issuer_kind", "issuer_group"},
+		)
+
+		tlsSecretExpiryTimeSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "tls_secret_expiration_timestamp_seconds",
+				Help:      "The date after which the certificate of a TLS Secret, including Secrets not managed by cert-manager, expires. Expressed as a Unix Epoch Time.",
+			},
+			[]string{"name", "namespace", "managed"},
+		)
+
 		// acmeClientRequestCount is a Prometheus summary to collect the number of
 		// requests made to each endpoint with the ACME client.
 		acmeClientRequestCount = prometheus.NewCounterVec(
@@ -152,6 +178,18 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"scheme", "host", "path", "method", "status"},
 		)
 
+		// acmeRateLimitBudgetRemaining is a Prometheus gauge of the
+		// estimated number of requests that ACME issuers can still make
+		// before a rate limit of their ACME server is reached.
+		acmeRateLimitBudgetRemaining = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "acme_rate_limit_budget_remaining",
+				Help:      "The estimated number of orders or certificates an ACME issuer can request before a rate limit of the ACME server is reached.",
+			},
+			[]string{"issuer_name", "issuer_namespace", "issuer_kind", "limit", "registered_domain"},
+		)
+
 		// venafiClientRequestDurationSeconds is a Prometheus summary to
 		// collect api call latencies for the Venafi client. This
 		// metric is in alpha since cert-manager 1.9. Move it to GA once
@@ -196,8 +234,11 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		certificateExpiryTimeSeconds:       certificateExpiryTimeSeconds,
 		certificateRenewalTimeSeconds:      certificateRenewalTimeSeconds,
 		certificateReadyStatus:             certificateReadyStatus,
+		certificateIssuanceDuration:        certificateIssuanceDuration,
+		tlsSecretExpiryTimeSeconds:         tlsSecretExpiryTimeSeconds,
 		acmeClientRequestCount:             acmeClientRequestCount,
 		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
+		acmeRateLimitBudgetRemaining:       acmeRateLimitBudgetRemaining,
 		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
 		controllerSyncCallCount:            controllerSyncCallCount,
 		controllerSyncErrorCount:           controllerSyncErrorCount,
@@ -213,9 +254,12 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	m.registry.MustRegister(m.certificateExpiryTimeSeconds)
 	m.registry.MustRegister(m.certificateRenewalTimeSeconds)
 	m.registry.MustRegister(m.certificateReadyStatus)
+	m.registry.MustRegister(m.certificateIssuanceDuration)
+	m.registry.MustRegister(m.tlsSecretExpiryTimeSeconds)
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
+	m.registry.MustRegister(m.acmeRateLimitBudgetRemaining)
 	m.registry.MustRegister(m.controllerSyncCallCount)
 	m.registry.MustRegister(m.controllerSyncErrorCount)
 
@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UpdateTLSSecret will update the expiry metric of the certificate stored in
+// the given TLS Secret. managed is true if the Secret is managed by a
+// Certificate.
+func (m *Metrics) UpdateTLSSecret(namespace, name string, managed bool, notAfter time.Time) {
+	// The value of the managed label may change, so the metric is removed
+	// first to ensure that a single series is exposed for each Secret.
+	m.RemoveTLSSecret(namespace, name)
+	m.tlsSecretExpiryTimeSeconds.With(prometheus.Labels{
+		"name":      name,
+		"namespace": namespace,
+		"managed":   strconv.FormatBool(managed),
+	}).Set(float64(notAfter.Unix()))
+}
+
+// RemoveTLSSecret will delete the expiry metric of the given TLS Secret from
+// continuing to be exposed.
+func (m *Metrics) RemoveTLSSecret(namespace, name string) {
+	m.tlsSecretExpiryTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
+}
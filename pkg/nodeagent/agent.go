@@ -0,0 +1,273 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodeagent implements the cert-manager node agent, which runs on
+// each node as a DaemonSet and writes the Certificates annotated with
+// `cert-manager.io/host-path` to files on the host. This allows cert-manager
+// to manage the certificates of processes which read them from disk, such as
+// etcd and other static pods of a self-hosted control plane.
+package nodeagent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	reasonHostPathWritten     = "HostPathWritten"
+	reasonHostPathWriteFailed = "HostPathWriteFailed"
+)
+
+// Options configure the node agent.
+type Options struct {
+	// NodeName is the name of the node that the agent runs on.
+	NodeName string
+	// Namespace is the namespace of the Certificates written by the agent.
+	// All namespaces are watched if empty.
+	Namespace string
+	// HostRoot is the path that the host filesystem is mounted at.
+	HostRoot string
+	// AllowedPaths are the directories on the host that files may be written
+	// to.
+	AllowedPaths []string
+	// ProcPath is the path of the procfs of the host, used to find the
+	// processes to reload.
+	ProcPath string
+	// ResyncPeriod is the period at which all Certificates are re-written,
+	// to restore files which have been changed on the host.
+	ResyncPeriod time.Duration
+}
+
+// hostFile is a file written to the host.
+type hostFile struct {
+	// hostPath is the path of the file on the host, and path is the path of
+	// the file in the mounted host filesystem.
+	hostPath, path string
+	data           []byte
+}
+
+// Agent writes Certificates annotated with `cert-manager.io/host-path` to
+// files on the host of the node that it runs on.
+type Agent struct {
+	certificateLister cmlisters.CertificateLister
+	kubeClient        kubernetes.Interface
+	recorder          record.EventRecorder
+
+	factory  cminformers.SharedInformerFactory
+	mustSync []cache.InformerSynced
+	queue    workqueue.RateLimitingInterface
+
+	nodeName string
+	resolver hostPathResolver
+	procPath string
+
+	// signal is used to reload processes, and is replaced during tests.
+	signal func(pid int) error
+}
+
+// New returns a new node agent.
+func New(kubeClient kubernetes.Interface, cmClient cmclient.Interface, recorder record.EventRecorder, opts Options) *Agent {
+	factory := cminformers.NewSharedInformerFactoryWithOptions(cmClient, opts.ResyncPeriod, cminformers.WithNamespace(opts.Namespace))
+	certificateInformer := factory.Certmanager().V1().Certificates()
+
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), "node-agent")
+
+	// The Secrets of Certificates are read when the Certificates are synced
+	// rather than watched, as a new revision of a Certificate is always
+	// accompanied by an update to its status.
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	return &Agent{
+		certificateLister: certificateInformer.Lister(),
+		kubeClient:        kubeClient,
+		recorder:          recorder,
+		factory:           factory,
+		mustSync:          []cache.InformerSynced{certificateInformer.Informer().HasSynced},
+		queue:             queue,
+		nodeName:          opts.NodeName,
+		resolver:          hostPathResolver{hostRoot: opts.HostRoot, allowedPrefixes: opts.AllowedPaths},
+		procPath:          opts.ProcPath,
+		signal:            sighup,
+	}
+}
+
+// Run starts the agent and blocks until the given context is cancelled.
+func (a *Agent) Run(ctx context.Context, workers int) error {
+	log := logf.FromContext(ctx)
+
+	a.factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), a.mustSync...) {
+		return fmt.Errorf("error waiting for informer caches to sync")
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, a.worker, time.Second)
+	}
+
+	log.V(logf.InfoLevel).Info("node agent started", "node", a.nodeName)
+	<-ctx.Done()
+	a.queue.ShutDown()
+	return nil
+}
+
+func (a *Agent) worker(ctx context.Context) {
+	log := logf.FromContext(ctx)
+	for {
+		obj, shutdown := a.queue.Get()
+		if shutdown {
+			return
+		}
+		func() {
+			defer a.queue.Done(obj)
+			key, ok := obj.(string)
+			if !ok {
+				a.queue.Forget(obj)
+				return
+			}
+			if err := a.ProcessItem(logf.NewContext(ctx, log.WithValues("key", key)), key); err != nil {
+				log.Error(err, "re-queuing item due to error processing", "key", key)
+				a.queue.AddRateLimited(obj)
+				return
+			}
+			a.queue.Forget(obj)
+		}()
+	}
+}
+
+// ProcessItem writes the Certificate with the given key to the host if it is
+// annotated to be written on this node, and reloads the configured process
+// if any of the files changed.
+func (a *Agent) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := a.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		// Files are deliberately left on the host when the Certificate is
+		// deleted, as the processes using them may still depend on them.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	config, err := hostPathConfigFor(crt, a.nodeName)
+	if err != nil {
+		a.recorder.Eventf(crt, corev1.EventTypeWarning, reasonHostPathWriteFailed, "Node %q: %v", a.nodeName, err)
+		return nil
+	}
+	if config == nil {
+		return nil
+	}
+
+	secret, err := a.kubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("secret not found, waiting for the certificate to be issued", "secret", crt.Spec.SecretName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return nil
+	}
+
+	// The private key is written before the certificate, so that a process
+	// reloading the pair doesn't load the new certificate with the old key.
+	files := []hostFile{
+		{hostPath: config.path + ".key", data: secret.Data[corev1.TLSPrivateKeyKey]},
+		{hostPath: config.path + ".crt", data: secret.Data[corev1.TLSCertKey]},
+	}
+	if len(config.caPath) > 0 && len(secret.Data[cmmeta.TLSCAKey]) > 0 {
+		files = append(files, hostFile{hostPath: config.caPath, data: secret.Data[cmmeta.TLSCAKey]})
+	}
+	// All of the paths are checked before any are written, so that a
+	// Certificate with a disallowed path doesn't leave a partial set of files.
+	for i := range files {
+		files[i].path, err = a.resolver.resolve(files[i].hostPath)
+		if err != nil {
+			a.recorder.Eventf(crt, corev1.EventTypeWarning, reasonHostPathWriteFailed, "Node %q: %v", a.nodeName, err)
+			return nil
+		}
+	}
+
+	changed := false
+	for _, f := range files {
+		fileChanged, err := writeFile(f.path, f.data, config.mode, config.uid, config.gid)
+		if err != nil {
+			a.recorder.Eventf(crt, corev1.EventTypeWarning, reasonHostPathWriteFailed, "Node %q: failed to write %s: %v", a.nodeName, f.hostPath, err)
+			return err
+		}
+		changed = changed || fileChanged
+	}
+	if !changed {
+		return nil
+	}
+
+	message := fmt.Sprintf("Node %q: wrote certificate to %s", a.nodeName, config.path)
+	if len(config.reloadProcess) > 0 {
+		reloaded, err := a.reload(config.reloadProcess)
+		if err != nil {
+			a.recorder.Eventf(crt, corev1.EventTypeWarning, reasonHostPathWriteFailed, "Node %q: failed to reload %q: %v", a.nodeName, config.reloadProcess, err)
+			return err
+		}
+		message = fmt.Sprintf("%s and reloaded %d %q process(es)", message, reloaded, config.reloadProcess)
+	}
+
+	log.V(logf.InfoLevel).Info("wrote certificate to host", "path", config.path)
+	a.recorder.Event(crt, corev1.EventTypeNormal, reasonHostPathWritten, message)
+	return nil
+}
+
+// reload sends SIGHUP to each of the host processes with the given command
+// name, and returns the number of processes signalled.
+func (a *Agent) reload(name string) (int, error) {
+	pids, err := findProcesses(a.procPath, name)
+	if err != nil {
+		return 0, err
+	}
+	var errs []error
+	for _, pid := range pids {
+		if err := a.signal(pid); err != nil {
+			errs = append(errs, fmt.Errorf("pid %d: %w", pid, err))
+		}
+	}
+	return len(pids) - len(errs), utilerrors.NewAggregate(errs)
+}
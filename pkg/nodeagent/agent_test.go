@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	hostRoot := t.TempDir()
+	procPath := t.TempDir()
+	for pid, comm := range map[string]string{"1": "systemd", "42": "etcd", "43": "etcd"} {
+		if err := os.MkdirAll(filepath.Join(procPath, pid), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(procPath, pid, "comm"), []byte(comm+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	crt := gen.Certificate("etcd-peer",
+		gen.SetCertificateNamespace("kube-system"),
+		gen.SetCertificateSecretName("etcd-peer-tls"),
+	)
+	crt.Annotations = map[string]string{
+		cmapi.HostPathAnnotationKey:              "/etc/kubernetes/pki/etcd/peer",
+		cmapi.HostPathCAAnnotationKey:            "/etc/kubernetes/pki/etcd/ca.crt",
+		cmapi.HostPathNodeAnnotationKey:          "node-1",
+		cmapi.HostPathModeAnnotationKey:          "0640",
+		cmapi.HostPathReloadProcessAnnotationKey: "etcd",
+	}
+	otherNodeCrt := crt.DeepCopy()
+	otherNodeCrt.Name = "other-node"
+	otherNodeCrt.Annotations[cmapi.HostPathNodeAnnotationKey] = "node-2"
+	disallowedCrt := crt.DeepCopy()
+	disallowedCrt.Name = "disallowed"
+	disallowedCrt.Annotations[cmapi.HostPathAnnotationKey] = "/etc/passwd"
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "etcd-peer-tls"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+			"ca.crt":                []byte("ca"),
+		},
+	}
+
+	agent := New(kubefake.NewSimpleClientset(secret), cmfake.NewSimpleClientset(crt, otherNodeCrt, disallowedCrt), record.NewFakeRecorder(10), Options{
+		NodeName:     "node-1",
+		Namespace:    "kube-system",
+		HostRoot:     hostRoot,
+		AllowedPaths: []string{"/etc/kubernetes/pki"},
+		ProcPath:     procPath,
+	})
+	var signalled []int
+	agent.signal = func(pid int) error {
+		signalled = append(signalled, pid)
+		return nil
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	agent.factory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, agent.mustSync...) {
+		t.Fatal("timed out waiting for informer caches to sync")
+	}
+
+	for _, key := range []string{"kube-system/etcd-peer", "kube-system/other-node", "kube-system/disallowed"} {
+		if err := agent.ProcessItem(context.Background(), key); err != nil {
+			t.Fatalf("unexpected error processing %q: %v", key, err)
+		}
+	}
+
+	for path, expected := range map[string]string{
+		"etc/kubernetes/pki/etcd/peer.crt": "cert",
+		"etc/kubernetes/pki/etcd/peer.key": "key",
+		"etc/kubernetes/pki/etcd/ca.crt":   "ca",
+	} {
+		data, err := os.ReadFile(filepath.Join(hostRoot, path))
+		if err != nil {
+			t.Fatalf("expected %s to be written: %v", path, err)
+		}
+		if string(data) != expected {
+			t.Errorf("expected %s to contain %q, got %q", path, expected, data)
+		}
+		info, err := os.Stat(filepath.Join(hostRoot, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0640 {
+			t.Errorf("expected %s to have mode 0640, got %o", path, info.Mode().Perm())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(hostRoot, "etc/passwd.crt")); !os.IsNotExist(err) {
+		t.Errorf("expected files outside of the allowed paths not to be written, got %v", err)
+	}
+	if !reflect.DeepEqual(signalled, []int{42, 43}) {
+		t.Errorf("expected the etcd processes to be reloaded, got %v", signalled)
+	}
+
+	// Processes are not reloaded if the files are unchanged.
+	signalled = nil
+	if err := agent.ProcessItem(context.Background(), "kube-system/etcd-peer"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(signalled) > 0 {
+		t.Errorf("expected no processes to be reloaded, got %v", signalled)
+	}
+}
+
+func TestHostPathConfigFor(t *testing.T) {
+	crt := gen.Certificate("test")
+	crt.Annotations = map[string]string{
+		cmapi.HostPathAnnotationKey:      "/etc/kubernetes/pki/apiserver-etcd-client",
+		cmapi.HostPathOwnerAnnotationKey: "0:1000",
+	}
+	config, err := hostPathConfigFor(crt, "node-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config == nil || config.uid != 0 || config.gid != 1000 || config.mode != defaultFileMode {
+		t.Errorf("unexpected config %+v", config)
+	}
+
+	for annotation, value := range map[string]string{
+		cmapi.HostPathOwnerAnnotationKey: "root",
+		cmapi.HostPathModeAnnotationKey:  "rw-------",
+	} {
+		invalid := crt.DeepCopy()
+		invalid.Annotations[annotation] = value
+		if _, err := hostPathConfigFor(invalid, "node-1"); err == nil {
+			t.Errorf("expected an error for %s=%q", annotation, value)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	r := hostPathResolver{hostRoot: "/host", allowedPrefixes: []string{"/etc/kubernetes/pki"}}
+	if path, err := r.resolve("/etc/kubernetes/pki/etcd/peer.crt"); err != nil || path != "/host/etc/kubernetes/pki/etcd/peer.crt" {
+		t.Errorf("unexpected result %q, %v", path, err)
+	}
+	for _, path := range []string{"/etc/kubernetes/pki-other/ca.crt", "/etc/kubernetes/pki/../admin.conf", "etc/kubernetes/pki/ca.crt"} {
+		if _, err := r.resolve(path); err == nil {
+			t.Errorf("expected an error for %q", path)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const defaultFileMode os.FileMode = 0600
+
+// hostPathConfig is the host path output of a Certificate, parsed from its
+// `cert-manager.io/host-path*` annotations.
+type hostPathConfig struct {
+	// path is the path, without extension, of the certificate and private
+	// key files.
+	path string
+	// caPath is the path of the CA certificate file, if set.
+	caPath string
+	// uid and gid are the owner of the files, or -1 to leave them unchanged.
+	uid, gid int
+	mode     os.FileMode
+	// reloadProcess is the command name of the processes sent SIGHUP once
+	// the files have been updated, if set.
+	reloadProcess string
+}
+
+// hostPathConfigFor parses the host path output of the given Certificate.
+// The returned config is nil if the Certificate is not annotated to be
+// written to the host, or is annotated to be written on another node.
+func hostPathConfigFor(crt *cmapi.Certificate, nodeName string) (*hostPathConfig, error) {
+	path, ok := crt.Annotations[cmapi.HostPathAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	if node, ok := crt.Annotations[cmapi.HostPathNodeAnnotationKey]; ok && node != nodeName {
+		return nil, nil
+	}
+
+	config := &hostPathConfig{
+		path:          path,
+		caPath:        crt.Annotations[cmapi.HostPathCAAnnotationKey],
+		uid:           -1,
+		gid:           -1,
+		mode:          defaultFileMode,
+		reloadProcess: crt.Annotations[cmapi.HostPathReloadProcessAnnotationKey],
+	}
+
+	if owner, ok := crt.Annotations[cmapi.HostPathOwnerAnnotationKey]; ok {
+		uid, gid, ok := strings.Cut(owner, ":")
+		var err error
+		if ok {
+			config.uid, err = strconv.Atoi(uid)
+			if err == nil {
+				config.gid, err = strconv.Atoi(gid)
+			}
+		}
+		if !ok || err != nil || config.uid < 0 || config.gid < 0 {
+			return nil, fmt.Errorf("invalid %s annotation %q: must be of the form <uid>:<gid>", cmapi.HostPathOwnerAnnotationKey, owner)
+		}
+	}
+
+	if mode, ok := crt.Annotations[cmapi.HostPathModeAnnotationKey]; ok {
+		m, err := strconv.ParseUint(mode, 8, 32)
+		if err != nil || m > 0777 {
+			return nil, fmt.Errorf("invalid %s annotation %q: must be octal file permissions, e.g. 0600", cmapi.HostPathModeAnnotationKey, mode)
+		}
+		config.mode = os.FileMode(m)
+	}
+
+	return config, nil
+}
+
+// hostPathResolver maps the paths of Certificate annotations to paths within
+// the host filesystem mounted into the node agent.
+type hostPathResolver struct {
+	// hostRoot is the path that the host filesystem is mounted at.
+	hostRoot string
+	// allowedPrefixes are the directories on the host that files may be
+	// written to.
+	allowedPrefixes []string
+}
+
+// resolve returns the path within the mounted host filesystem of the given
+// host path, or an error if the host path is not within one of the allowed
+// directories.
+func (r *hostPathResolver) resolve(hostPath string) (string, error) {
+	if !filepath.IsAbs(hostPath) || filepath.Clean(hostPath) != hostPath {
+		return "", fmt.Errorf("host path %q must be a clean absolute path", hostPath)
+	}
+	for _, prefix := range r.allowedPrefixes {
+		prefix = filepath.Clean(prefix)
+		if hostPath == prefix || strings.HasPrefix(hostPath, prefix+string(filepath.Separator)) {
+			return filepath.Join(r.hostRoot, hostPath), nil
+		}
+	}
+	return "", fmt.Errorf("host path %q is not within the allowed directories %v", hostPath, r.allowedPrefixes)
+}
+
+// writeFile writes data to path with the given permissions and owner, and
+// returns true if the contents of the file changed. The file is replaced
+// atomically, so that readers never observe a partially written file.
+func writeFile(path string, data []byte, mode os.FileMode, uid, gid int) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, data) {
+		// The permissions and owner are still reconciled in case they have
+		// been changed, but this doesn't require a reload.
+		if err := os.Chmod(path, mode); err != nil {
+			return false, err
+		}
+		if uid >= 0 {
+			if err := os.Chown(path, uid, gid); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return false, err
+	}
+	if uid >= 0 {
+		if err := os.Chown(tmp.Name(), uid, gid); err != nil {
+			return false, err
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// findProcesses returns the PIDs of the processes in the given procfs whose
+// command name is name.
+func findProcesses(procPath, name string) ([]int, error) {
+	entries, err := os.ReadDir(procPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		comm, err := os.ReadFile(filepath.Join(procPath, entry.Name(), "comm"))
+		if err != nil {
+			// The process may have exited since the directory was read.
+			continue
+		}
+		if strings.TrimSpace(string(comm)) == name {
+			pids = append(pids, pid)
+		}
+	}
+	return pids, nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsa
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"time"
+)
+
+var (
+	// RFC 5652, 5.1 and RFC 3161, 2.4.2
+	oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+
+	// RFC 5652, 11 and RFC 5035, 3
+	oidAttributeContentType          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttributeMessageDigest        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttributeSigningCertificateV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+
+	oidSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+
+	oidSHA256WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}
+	oidECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+)
+
+// RFC 3161, 2.4.2 PKIStatus values.
+const (
+	statusGranted   = 0
+	statusRejection = 2
+)
+
+// RFC 3161, 2.4.2 PKIFailureInfo bits.
+const (
+	failureBadAlg              = 0
+	failureBadRequest          = 2
+	failureBadDataFormat       = 5
+	failureUnacceptedPolicy    = 15
+	failureUnacceptedExtension = 16
+	failureSystemFailure       = 25
+)
+
+// RFC 3161, 2.4.1
+//
+//	TimeStampReq ::= SEQUENCE  {
+//	   version                      INTEGER  { v1(1) },
+//	   messageImprint               MessageImprint,
+//	   reqPolicy                    TSAPolicyId              OPTIONAL,
+//	   nonce                        INTEGER                  OPTIONAL,
+//	   certReq                      BOOLEAN                  DEFAULT FALSE,
+//	   extensions               [0] IMPLICIT Extensions      OPTIONAL  }
+type timeStampReq struct {
+	Version        int
+	MessageImprint messageImprint
+	ReqPolicy      asn1.ObjectIdentifier `asn1:"optional"`
+	Nonce          *big.Int              `asn1:"optional"`
+	CertReq        bool                  `asn1:"optional,default:false"`
+	Extensions     []pkix.Extension      `asn1:"tag:0,optional"`
+}
+
+//	MessageImprint ::= SEQUENCE  {
+//	   hashAlgorithm                AlgorithmIdentifier,
+//	   hashedMessage                OCTET STRING  }
+type messageImprint struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	HashedMessage []byte
+}
+
+// RFC 3161, 2.4.2
+//
+//	TimeStampResp ::= SEQUENCE  {
+//	   status                  PKIStatusInfo,
+//	   timeStampToken          TimeStampToken     OPTIONAL  }
+type timeStampResp struct {
+	Status         pkiStatusInfo
+	TimeStampToken contentInfo `asn1:"optional"`
+}
+
+//	PKIStatusInfo ::= SEQUENCE {
+//	   status        PKIStatus,
+//	   statusString  PKIFreeText     OPTIONAL,
+//	   failInfo      PKIFailureInfo  OPTIONAL  }
+type pkiStatusInfo struct {
+	Status       int
+	StatusString []asn1.RawValue `asn1:"optional"`
+	FailInfo     asn1.BitString  `asn1:"optional"`
+}
+
+//	TSTInfo ::= SEQUENCE  {
+//	   version                      INTEGER  { v1(1) },
+//	   policy                       TSAPolicyId,
+//	   messageImprint               MessageImprint,
+//	   serialNumber                 INTEGER,
+//	   genTime                      GeneralizedTime,
+//	   accuracy                     Accuracy                 OPTIONAL,
+//	   ordering                     BOOLEAN             DEFAULT FALSE,
+//	   nonce                        INTEGER                  OPTIONAL,
+//	   tsa                          [0] GeneralName          OPTIONAL,
+//	   extensions                   [1] IMPLICIT Extensions   OPTIONAL  }
+type tstInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint messageImprint
+	SerialNumber   *big.Int
+	GenTime        time.Time `asn1:"generalized"`
+	Accuracy       accuracy  `asn1:"optional"`
+	Ordering       bool      `asn1:"optional,default:false"`
+	Nonce          *big.Int  `asn1:"optional"`
+}
+
+//	Accuracy ::= SEQUENCE {
+//	   seconds        INTEGER              OPTIONAL,
+//	   millis     [0] INTEGER  (1..999)    OPTIONAL,
+//	   micros     [1] INTEGER  (1..999)    OPTIONAL  }
+type accuracy struct {
+	Seconds int `asn1:"optional"`
+	Millis  int `asn1:"tag:0,optional"`
+	Micros  int `asn1:"tag:1,optional"`
+}
+
+// RFC 5652, 3
+//
+//	ContentInfo ::= SEQUENCE {
+//	   contentType ContentType,
+//	   content [0] EXPLICIT ANY DEFINED BY contentType }
+//
+// Content is the [0] EXPLICIT wrapper of the content, as encoding/asn1
+// doesn't add or remove explicit tags of RawValues.
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"tag:0"`
+}
+
+// RFC 5652, 5.1
+//
+//	SignedData ::= SEQUENCE {
+//	   version CMSVersion,
+//	   digestAlgorithms DigestAlgorithmIdentifiers,
+//	   encapContentInfo EncapsulatedContentInfo,
+//	   certificates [0] IMPLICIT CertificateSet OPTIONAL,
+//	   crls [1] IMPLICIT RevocationInfoChoices OPTIONAL,
+//	   signerInfos SignerInfos }
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+//	EncapsulatedContentInfo ::= SEQUENCE {
+//	   eContentType ContentType,
+//	   eContent [0] EXPLICIT OCTET STRING OPTIONAL }
+type encapsulatedContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"explicit,tag:0"`
+}
+
+// RFC 5652, 5.3
+//
+//	SignerInfo ::= SEQUENCE {
+//	   version CMSVersion,
+//	   sid SignerIdentifier,
+//	   digestAlgorithm DigestAlgorithmIdentifier,
+//	   signedAttrs [0] IMPLICIT SignedAttributes OPTIONAL,
+//	   signatureAlgorithm SignatureAlgorithmIdentifier,
+//	   signature SignatureValue,
+//	   unsignedAttrs [1] IMPLICIT UnsignedAttributes OPTIONAL }
+type signerInfo struct {
+	Version            int
+	SID                issuerAndSerialNumber
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        []attribute `asn1:"tag:0,set"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+}
+
+//	IssuerAndSerialNumber ::= SEQUENCE {
+//	   issuer Name,
+//	   serialNumber CertificateSerialNumber }
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+//	Attribute ::= SEQUENCE {
+//	   attrType OBJECT IDENTIFIER,
+//	   attrValues SET OF AttributeValue }
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// RFC 5035, 3
+//
+//	SigningCertificateV2 ::=  SEQUENCE {
+//	   certs        SEQUENCE OF ESSCertIDv2,
+//	   policies     SEQUENCE OF PolicyInformation OPTIONAL }
+//
+//	ESSCertIDv2 ::=  SEQUENCE {
+//	   hashAlgorithm           AlgorithmIdentifier
+//	                  DEFAULT {algorithm id-sha256},
+//	   certHash                 Hash,
+//	   issuerSerial             IssuerSerial OPTIONAL }
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+type essCertIDv2 struct {
+	CertHash []byte
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsa
+
+import (
+	"io"
+	"net/http"
+)
+
+const (
+	// RFC 3161, 3.4 media types
+	contentTypeQuery = "application/timestamp-query"
+	contentTypeReply = "application/timestamp-reply"
+
+	// maxRequestSize is the maximum size of a time-stamp request. Requests
+	// only contain a hash of the data to time-stamp, so are small.
+	maxRequestSize = 64 * 1024
+)
+
+// ServeHTTP serves time-stamp requests sent using the HTTP transport
+// described in RFC 3161, 3.4.
+func (a *Authority) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "time-stamp requests must be sent using POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get("Content-Type") != contentTypeQuery {
+		http.Error(w, "time-stamp requests must have the content type "+contentTypeQuery, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	request, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize+1))
+	if err != nil {
+		http.Error(w, "failed to read time-stamp request", http.StatusBadRequest)
+		return
+	}
+	if len(request) > maxRequestSize {
+		http.Error(w, "time-stamp request is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	response, err := a.Respond(r.Context(), request)
+	if err != nil {
+		http.Error(w, "failed to encode time-stamp response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentTypeReply)
+	w.Write(response)
+}
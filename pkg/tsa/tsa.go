@@ -0,0 +1,383 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tsa implements an RFC 3161 time-stamping authority which signs
+// time-stamp tokens using a certificate managed by cert-manager. The signing
+// certificate and private key are re-read as they are renewed, so the key of
+// the authority is rotated along with its certificate.
+package tsa
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// hashAlgorithms are the hash algorithms accepted in the message imprint of
+// time-stamp requests.
+var hashAlgorithms = []struct {
+	oid  asn1.ObjectIdentifier
+	hash crypto.Hash
+}{
+	{oidSHA256, crypto.SHA256},
+	{oidSHA384, crypto.SHA384},
+	{oidSHA512, crypto.SHA512},
+}
+
+// Options configure an Authority.
+type Options struct {
+	// Policy is the TSA policy that time-stamp tokens are issued under.
+	// Requests for any other policy are rejected.
+	Policy asn1.ObjectIdentifier
+	// Accuracy is the accuracy of the time in issued time-stamp tokens. It is
+	// omitted from the tokens if zero.
+	Accuracy time.Duration
+	// GetCertificate returns the current signing certificate, its chain and
+	// private key. The leaf certificate must have the time stamping extended
+	// key usage.
+	GetCertificate func() (*tls.Certificate, error)
+}
+
+// Authority issues RFC 3161 time-stamp tokens.
+type Authority struct {
+	policy         asn1.ObjectIdentifier
+	accuracy       accuracy
+	getCertificate func() (*tls.Certificate, error)
+	clock          clock.Clock
+
+	// the signer of the most recently used certificate is cached, so that
+	// the certificate is only parsed again once it has been renewed
+	lock       sync.Mutex
+	cachedCert *tls.Certificate
+	cached     *signer
+}
+
+// signer is a parsed signing certificate.
+type signer struct {
+	leaf   *x509.Certificate
+	chain  [][]byte
+	key    crypto.Signer
+	sigAlg pkix.AlgorithmIdentifier
+}
+
+// requestError is a time-stamp request that is rejected with the given
+// PKIFailureInfo bit.
+type requestError struct {
+	failInfo int
+	msg      string
+}
+
+func (e *requestError) Error() string {
+	return e.msg
+}
+
+// New returns a new Authority.
+func New(opts Options) *Authority {
+	return &Authority{
+		policy:         opts.Policy,
+		accuracy:       accuracyFor(opts.Accuracy),
+		getCertificate: opts.GetCertificate,
+		clock:          clock.RealClock{},
+	}
+}
+
+// Respond returns the DER encoded TimeStampResp for the given DER encoded
+// TimeStampReq. Requests which cannot be granted are answered with a
+// rejection, so an error is only returned if the response cannot be encoded.
+func (a *Authority) Respond(ctx context.Context, request []byte) ([]byte, error) {
+	log := logf.FromContext(ctx)
+
+	token, err := a.timestamp(request)
+	if err != nil {
+		failInfo := failureSystemFailure
+		var reqErr *requestError
+		if errors.As(err, &reqErr) {
+			failInfo = reqErr.failInfo
+			log.V(logf.DebugLevel).Info("rejected time-stamp request", "reason", err.Error())
+		} else {
+			log.Error(err, "failed to issue time-stamp token")
+		}
+		return asn1.Marshal(timeStampResp{Status: rejection(failInfo, err.Error())})
+	}
+	return asn1.Marshal(timeStampResp{
+		Status:         pkiStatusInfo{Status: statusGranted},
+		TimeStampToken: token,
+	})
+}
+
+// Healthy returns true if the current signing certificate can be used to
+// issue time-stamp tokens.
+func (a *Authority) Healthy() bool {
+	_, err := a.signer()
+	return err == nil
+}
+
+// timestamp returns a time-stamp token for the given request.
+func (a *Authority) timestamp(request []byte) (contentInfo, error) {
+	var req timeStampReq
+	if rest, err := asn1.Unmarshal(request, &req); err != nil || len(rest) > 0 {
+		return contentInfo{}, &requestError{failureBadDataFormat, "malformed time-stamp request"}
+	}
+	if req.Version != 1 {
+		return contentInfo{}, &requestError{failureBadRequest, fmt.Sprintf("unsupported time-stamp request version %d", req.Version)}
+	}
+	hash, ok := hashFor(req.MessageImprint.HashAlgorithm.Algorithm)
+	if !ok {
+		return contentInfo{}, &requestError{failureBadAlg, fmt.Sprintf("unsupported hash algorithm %s", req.MessageImprint.HashAlgorithm.Algorithm)}
+	}
+	if len(req.MessageImprint.HashedMessage) != hash.Size() {
+		return contentInfo{}, &requestError{failureBadDataFormat, "hashed message does not match the length of the hash algorithm"}
+	}
+	if len(req.ReqPolicy) > 0 && !req.ReqPolicy.Equal(a.policy) {
+		return contentInfo{}, &requestError{failureUnacceptedPolicy, fmt.Sprintf("unsupported policy %s", req.ReqPolicy)}
+	}
+	if len(req.Extensions) > 0 {
+		return contentInfo{}, &requestError{failureUnacceptedExtension, "extensions are not supported"}
+	}
+
+	s, err := a.signer()
+	if err != nil {
+		return contentInfo{}, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return contentInfo{}, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	info, err := asn1.Marshal(tstInfo{
+		Version:        1,
+		Policy:         a.policy,
+		MessageImprint: req.MessageImprint,
+		SerialNumber:   serialNumber,
+		GenTime:        a.clock.Now().UTC(),
+		Accuracy:       a.accuracy,
+		Nonce:          req.Nonce,
+	})
+	if err != nil {
+		return contentInfo{}, fmt.Errorf("failed to encode TSTInfo: %w", err)
+	}
+
+	return s.sign(info, req.CertReq)
+}
+
+// signer returns the signer of the current signing certificate.
+func (a *Authority) signer() (*signer, error) {
+	cert, err := a.getCertificate()
+	if err != nil {
+		return nil, fmt.Errorf("signing certificate is not available: %w", err)
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if cert == a.cachedCert {
+		return a.cached, nil
+	}
+	s, err := newSigner(cert)
+	if err != nil {
+		return nil, err
+	}
+	a.cachedCert, a.cached = cert, s
+	return s, nil
+}
+
+func newSigner(cert *tls.Certificate) (*signer, error) {
+	if len(cert.Certificate) == 0 {
+		return nil, errors.New("signing certificate is empty")
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing certificate: %w", err)
+	}
+	// RFC 3161, 2.3: the certificate of a TSA must have the time stamping
+	// extended key usage
+	hasTimeStamping := false
+	for _, eku := range leaf.ExtKeyUsage {
+		if eku == x509.ExtKeyUsageTimeStamping {
+			hasTimeStamping = true
+		}
+	}
+	if !hasTimeStamping {
+		return nil, errors.New("signing certificate does not have the time stamping extended key usage")
+	}
+
+	key, ok := cert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("signing key is not a crypto.Signer")
+	}
+	s := &signer{leaf: leaf, chain: cert.Certificate, key: key}
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		s.sigAlg = pkix.AlgorithmIdentifier{Algorithm: oidSHA256WithRSA, Parameters: asn1.NullRawValue}
+	case *ecdsa.PrivateKey:
+		s.sigAlg = pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256}
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+	return s, nil
+}
+
+// sign returns a CMS SignedData ContentInfo for the given DER encoded
+// TSTInfo, as described in RFC 3161, 2.4.2. If includeChain is true, the
+// certificate chain of the signer is included.
+func (s *signer) sign(info []byte, includeChain bool) (contentInfo, error) {
+	digestAlg := pkix.AlgorithmIdentifier{Algorithm: oidSHA256, Parameters: asn1.NullRawValue}
+
+	infoDigest := sha256.Sum256(info)
+	certDigest := sha256.Sum256(s.leaf.Raw)
+
+	signedAttrs, err := signedAttributes(infoDigest[:], certDigest[:])
+	if err != nil {
+		return contentInfo{}, err
+	}
+	// RFC 5652, 5.4: the signature is calculated over the DER encoding of
+	// the signed attributes as an explicit SET OF
+	signedAttrsDER, err := asn1.MarshalWithParams(signedAttrs, "set")
+	if err != nil {
+		return contentInfo{}, fmt.Errorf("failed to encode signed attributes: %w", err)
+	}
+	attrsDigest := sha256.Sum256(signedAttrsDER)
+	signature, err := s.key.Sign(rand.Reader, attrsDigest[:], crypto.SHA256)
+	if err != nil {
+		return contentInfo{}, fmt.Errorf("failed to sign time-stamp token: %w", err)
+	}
+
+	sd := signedData{
+		Version:          3,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{digestAlg},
+		EncapContentInfo: encapsulatedContentInfo{
+			EContentType: oidTSTInfo,
+			EContent:     info,
+		},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			SID: issuerAndSerialNumber{
+				Issuer:       asn1.RawValue{FullBytes: s.leaf.RawIssuer},
+				SerialNumber: s.leaf.SerialNumber,
+			},
+			DigestAlgorithm:    digestAlg,
+			SignedAttrs:        signedAttrs,
+			SignatureAlgorithm: s.sigAlg,
+			Signature:          signature,
+		}},
+	}
+	if includeChain {
+		var certs []byte
+		for _, c := range s.chain {
+			certs = append(certs, c...)
+		}
+		sd.Certificates = asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certs}
+	}
+
+	sdDER, err := asn1.Marshal(sd)
+	if err != nil {
+		return contentInfo{}, fmt.Errorf("failed to encode SignedData: %w", err)
+	}
+	return contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sdDER},
+	}, nil
+}
+
+// signedAttributes returns the content type, message digest and signing
+// certificate attributes required by RFC 3161, 2.4.2 and RFC 5816.
+func signedAttributes(infoDigest, certDigest []byte) ([]attribute, error) {
+	contentType, err := asn1.Marshal(oidTSTInfo)
+	if err != nil {
+		return nil, err
+	}
+	messageDigest, err := asn1.Marshal(infoDigest)
+	if err != nil {
+		return nil, err
+	}
+	signingCert, err := asn1.Marshal(signingCertificateV2{Certs: []essCertIDv2{{CertHash: certDigest}}})
+	if err != nil {
+		return nil, err
+	}
+	return []attribute{
+		{Type: oidAttributeContentType, Values: []asn1.RawValue{{FullBytes: contentType}}},
+		{Type: oidAttributeMessageDigest, Values: []asn1.RawValue{{FullBytes: messageDigest}}},
+		{Type: oidAttributeSigningCertificateV2, Values: []asn1.RawValue{{FullBytes: signingCert}}},
+	}, nil
+}
+
+// rejection returns a PKIStatusInfo rejecting a request with the given
+// PKIFailureInfo bit and message.
+func rejection(failInfo int, message string) pkiStatusInfo {
+	// PKIFailureInfo is a named BIT STRING, so trailing zero bits are
+	// omitted from its DER encoding
+	bits := make([]byte, failInfo/8+1)
+	bits[failInfo/8] = 0x80 >> uint(failInfo%8)
+	return pkiStatusInfo{
+		Status:       statusRejection,
+		StatusString: []asn1.RawValue{{Tag: asn1.TagUTF8String, Bytes: []byte(message)}},
+		FailInfo:     asn1.BitString{Bytes: bits, BitLength: failInfo + 1},
+	}
+}
+
+// ParsePolicy parses a TSA policy given in dotted decimal notation, e.g.
+// "1.2.3.4".
+func ParsePolicy(policy string) (asn1.ObjectIdentifier, error) {
+	parts := strings.Split(policy, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid policy %q: must have at least two components", policy)
+	}
+	oid := make(asn1.ObjectIdentifier, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid policy %q: %q is not a non-negative integer", policy, part)
+		}
+		oid[i] = n
+	}
+	return oid, nil
+}
+
+func hashFor(oid asn1.ObjectIdentifier) (crypto.Hash, bool) {
+	for _, h := range hashAlgorithms {
+		if oid.Equal(h.oid) {
+			return h.hash, true
+		}
+	}
+	return 0, false
+}
+
+func accuracyFor(d time.Duration) accuracy {
+	return accuracy{
+		Seconds: int(d / time.Second),
+		Millis:  int(d % time.Second / time.Millisecond),
+		Micros:  int(d % time.Millisecond / time.Microsecond),
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsa
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+var testPolicy = asn1.ObjectIdentifier{1, 2, 3, 4}
+
+func mustSigningCertificate(t *testing.T, key crypto.Signer, ekus ...x509.ExtKeyUsage) *tls.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "tsa"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  ekus,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func mustRequest(t *testing.T, req timeStampReq) []byte {
+	der, err := asn1.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestRespond(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecCert := mustSigningCertificate(t, ecKey, x509.ExtKeyUsageTimeStamping)
+	rsaCert := mustSigningCertificate(t, rsaKey, x509.ExtKeyUsageTimeStamping)
+	serverAuthCert := mustSigningCertificate(t, ecKey, x509.ExtKeyUsageServerAuth)
+
+	digest := sha256.Sum256([]byte("artifact"))
+	imprint := messageImprint{
+		HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+		HashedMessage: digest[:],
+	}
+
+	tests := map[string]struct {
+		cert    *tls.Certificate
+		request []byte
+
+		// expFailInfo is the PKIFailureInfo bit of a rejected request, and
+		// is ignored for granted requests
+		expGranted  bool
+		expFailInfo int
+		expChain    bool
+	}{
+		"a request is granted using an ECDSA certificate": {
+			cert:       ecCert,
+			request:    mustRequest(t, timeStampReq{Version: 1, MessageImprint: imprint, Nonce: big.NewInt(7)}),
+			expGranted: true,
+		},
+		"a request is granted using an RSA certificate and includes the chain if requested": {
+			cert:       rsaCert,
+			request:    mustRequest(t, timeStampReq{Version: 1, MessageImprint: imprint, ReqPolicy: testPolicy, CertReq: true}),
+			expGranted: true,
+			expChain:   true,
+		},
+		"a malformed request is rejected": {
+			cert:        ecCert,
+			request:     []byte("not a request"),
+			expFailInfo: failureBadDataFormat,
+		},
+		"a request with an unsupported hash algorithm is rejected": {
+			cert: ecCert,
+			request: mustRequest(t, timeStampReq{Version: 1, MessageImprint: messageImprint{
+				HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}},
+				HashedMessage: make([]byte, 20),
+			}}),
+			expFailInfo: failureBadAlg,
+		},
+		"a request with a hashed message of the wrong length is rejected": {
+			cert: ecCert,
+			request: mustRequest(t, timeStampReq{Version: 1, MessageImprint: messageImprint{
+				HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA512},
+				HashedMessage: digest[:],
+			}}),
+			expFailInfo: failureBadDataFormat,
+		},
+		"a request for another policy is rejected": {
+			cert:        ecCert,
+			request:     mustRequest(t, timeStampReq{Version: 1, MessageImprint: imprint, ReqPolicy: asn1.ObjectIdentifier{1, 2, 3, 5}}),
+			expFailInfo: failureUnacceptedPolicy,
+		},
+		"a request with extensions is rejected": {
+			cert: ecCert,
+			request: mustRequest(t, timeStampReq{Version: 1, MessageImprint: imprint, Extensions: []pkix.Extension{
+				{Id: asn1.ObjectIdentifier{1, 2, 3, 6}, Value: []byte{0x05, 0x00}},
+			}}),
+			expFailInfo: failureUnacceptedExtension,
+		},
+		"a request is rejected if the certificate does not have the time stamping usage": {
+			cert:        serverAuthCert,
+			request:     mustRequest(t, timeStampReq{Version: 1, MessageImprint: imprint}),
+			expFailInfo: failureSystemFailure,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+			a := New(Options{
+				Policy:   testPolicy,
+				Accuracy: 1500 * time.Millisecond,
+				GetCertificate: func() (*tls.Certificate, error) {
+					return test.cert, nil
+				},
+			})
+			a.clock = fakeclock.NewFakeClock(now)
+
+			der, err := a.Respond(context.Background(), test.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var resp timeStampResp
+			if _, err := asn1.Unmarshal(der, &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if !test.expGranted {
+				if resp.Status.Status != statusRejection {
+					t.Fatalf("expected rejection, got status %d", resp.Status.Status)
+				}
+				if resp.Status.FailInfo.At(test.expFailInfo) != 1 {
+					t.Errorf("expected failure info bit %d to be set, got %v", test.expFailInfo, resp.Status.FailInfo)
+				}
+				return
+			}
+
+			if resp.Status.Status != statusGranted {
+				t.Fatalf("expected request to be granted, got status %d: %v", resp.Status.Status, resp.Status.StatusString)
+			}
+			if !resp.TimeStampToken.ContentType.Equal(oidSignedData) {
+				t.Fatalf("unexpected content type %s", resp.TimeStampToken.ContentType)
+			}
+			var sd signedData
+			if _, err := asn1.Unmarshal(resp.TimeStampToken.Content.Bytes, &sd); err != nil {
+				t.Fatalf("failed to decode SignedData: %v", err)
+			}
+			if hasChain := len(sd.Certificates.Bytes) > 0; hasChain != test.expChain {
+				t.Errorf("expected certificates to be included=%t, got %t", test.expChain, hasChain)
+			}
+
+			// verify the signature over the signed attributes, and that
+			// they match the TSTInfo
+			leaf, err := x509.ParseCertificate(test.cert.Certificate[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			si := sd.SignerInfos[0]
+			signedAttrs, err := asn1.MarshalWithParams(si.SignedAttrs, "set")
+			if err != nil {
+				t.Fatal(err)
+			}
+			attrsDigest := sha256.Sum256(signedAttrs)
+			switch pub := leaf.PublicKey.(type) {
+			case *ecdsa.PublicKey:
+				if !ecdsa.VerifyASN1(pub, attrsDigest[:], si.Signature) {
+					t.Error("invalid ECDSA signature")
+				}
+			case *rsa.PublicKey:
+				if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, attrsDigest[:], si.Signature); err != nil {
+					t.Errorf("invalid RSA signature: %v", err)
+				}
+			}
+			infoDigest := sha256.Sum256(sd.EncapContentInfo.EContent)
+			var messageDigest []byte
+			for _, attr := range si.SignedAttrs {
+				if attr.Type.Equal(oidAttributeMessageDigest) {
+					if _, err := asn1.Unmarshal(attr.Values[0].FullBytes, &messageDigest); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			if string(messageDigest) != string(infoDigest[:]) {
+				t.Error("message digest attribute does not match the TSTInfo")
+			}
+
+			var info tstInfo
+			if _, err := asn1.Unmarshal(sd.EncapContentInfo.EContent, &info); err != nil {
+				t.Fatalf("failed to decode TSTInfo: %v", err)
+			}
+			var req timeStampReq
+			if _, err := asn1.Unmarshal(test.request, &req); err != nil {
+				t.Fatal(err)
+			}
+			if !info.Policy.Equal(testPolicy) {
+				t.Errorf("unexpected policy %s", info.Policy)
+			}
+			if string(info.MessageImprint.HashedMessage) != string(digest[:]) {
+				t.Error("TSTInfo does not contain the message imprint of the request")
+			}
+			if (req.Nonce == nil) != (info.Nonce == nil) || (req.Nonce != nil && req.Nonce.Cmp(info.Nonce) != 0) {
+				t.Errorf("expected nonce %v, got %v", req.Nonce, info.Nonce)
+			}
+			if !info.GenTime.Equal(now) {
+				t.Errorf("expected genTime %s, got %s", now, info.GenTime)
+			}
+			if info.Accuracy != (accuracy{Seconds: 1, Millis: 500}) {
+				t.Errorf("unexpected accuracy %+v", info.Accuracy)
+			}
+		})
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy  string
+		exp     asn1.ObjectIdentifier
+		wantErr bool
+	}{
+		"a valid policy is parsed": {
+			policy: "1.3.6.1.4.1.99999.1",
+			exp:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1},
+		},
+		"an empty policy is rejected": {
+			policy:  "",
+			wantErr: true,
+		},
+		"a policy with a single component is rejected": {
+			policy:  "1",
+			wantErr: true,
+		},
+		"a policy with a non-numeric component is rejected": {
+			policy:  "1.2.a",
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			oid, err := ParsePolicy(test.policy)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error=%t, got %v", test.wantErr, err)
+			}
+			if !oid.Equal(test.exp) {
+				t.Errorf("expected %s, got %s", test.exp, oid)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package duration parses durations which, in addition to the units accepted
+// by Go's time.ParseDuration, may be expressed in days, weeks and years.
+package duration
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	// Day is 24 hours. Certificate durations don't take daylight saving time
+	// into account.
+	Day = 24 * time.Hour
+	// Week is 7 days.
+	Week = 7 * Day
+	// Year is 365 days. Leap years are not taken into account.
+	Year = 365 * Day
+)
+
+// extendedUnits are the units which are not supported by time.ParseDuration.
+var extendedUnits = map[string]time.Duration{
+	"d": Day,
+	"w": Week,
+	"y": Year,
+}
+
+// Parse parses a duration string such as "90d", "1y" or "1w2d12h30m".
+// In addition to the units accepted by time.ParseDuration, the units "d"
+// (24 hours), "w" (7 days) and "y" (365 days) are accepted. Extended units
+// only accept integer values.
+func Parse(s string) (time.Duration, error) {
+	orig := s
+
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", orig)
+	}
+
+	var d time.Duration
+	for s != "" {
+		// Consume the number, which may contain a decimal point.
+		i := 0
+		for i < len(s) && (s[i] == '.' || ('0' <= s[i] && s[i] <= '9')) {
+			i++
+		}
+		number := s[:i]
+		s = s[i:]
+		if number == "" {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+
+		// Consume the unit.
+		i = 0
+		for i < len(s) && s[i] != '.' && (s[i] < '0' || s[i] > '9') {
+			i++
+		}
+		unit := s[:i]
+		s = s[i:]
+		if unit == "" {
+			return 0, fmt.Errorf("missing unit in duration %q", orig)
+		}
+
+		var v time.Duration
+		if scale, ok := extendedUnits[unit]; ok {
+			n, err := strconv.ParseInt(number, 10, 64)
+			if err != nil || n > math.MaxInt64/int64(scale) {
+				return 0, fmt.Errorf("invalid duration %q", orig)
+			}
+			v = time.Duration(n) * scale
+		} else {
+			var err error
+			v, err = time.ParseDuration(number + unit)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", orig)
+			}
+		}
+
+		if d > math.MaxInt64-v {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+		d += v
+	}
+
+	if neg {
+		return -d, nil
+	}
+	return d, nil
+}
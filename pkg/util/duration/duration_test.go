@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package duration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		in     string
+		exp    time.Duration
+		expErr bool
+	}{
+		"zero":                          {in: "0", exp: 0},
+		"go units":                      {in: "1h30m", exp: 90 * time.Minute},
+		"fractional go units":           {in: "1.5h", exp: 90 * time.Minute},
+		"sub-second go units":           {in: "10ms5µs", exp: 10*time.Millisecond + 5*time.Microsecond},
+		"days":                          {in: "90d", exp: 90 * Day},
+		"weeks":                         {in: "2w", exp: 14 * Day},
+		"years":                         {in: "1y", exp: 365 * Day},
+		"mixed units":                   {in: "1y2w3d4h5m", exp: Year + 2*Week + 3*Day + 4*time.Hour + 5*time.Minute},
+		"negative":                      {in: "-1d", exp: -Day},
+		"explicit positive":             {in: "+1d", exp: Day},
+		"empty":                         {in: "", expErr: true},
+		"sign only":                     {in: "-", expErr: true},
+		"missing unit":                  {in: "10", expErr: true},
+		"missing number":                {in: "d", expErr: true},
+		"unknown unit":                  {in: "1x", expErr: true},
+		"fractional extended unit":      {in: "1.5d", expErr: true},
+		"overflowing extended unit":     {in: "300y", expErr: true},
+		"overflowing sum of units":      {in: "292y5000h", expErr: true},
+		"whitespace is not allowed":     {in: "1d 2h", expErr: true},
+		"upper case units are rejected": {in: "1D", expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, err := Parse(test.in)
+			if test.expErr {
+				if err == nil {
+					t.Errorf("expected an error parsing %q but got %s", test.in, d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %v", test.in, err)
+			}
+			if d != test.exp {
+				t.Errorf("expected %q to parse as %s but got %s", test.in, test.exp, d)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	k8snet "k8s.io/utils/net"
+)
+
+// IPFamilyPolicy describes which IP family should be used when connecting to
+// an endpoint that may have both IPv4 and IPv6 addresses.
+type IPFamilyPolicy struct {
+	// Family is the IP family to use. If empty, no preference is applied and
+	// addresses are used in the order that they are resolved.
+	Family corev1.IPFamily
+
+	// Required, if true, means that only addresses of Family may be used.
+	// Otherwise, addresses of Family are tried before those of the other
+	// family.
+	Required bool
+}
+
+// NewIPFamilyPolicy returns the IPFamilyPolicy to use given an IP family that
+// is required, e.g. configured on a challenge solver, and the preferred IP
+// family of the cluster. The required family takes precedence if set.
+func NewIPFamilyPolicy(required, preferred corev1.IPFamily) IPFamilyPolicy {
+	if len(required) > 0 {
+		return IPFamilyPolicy{Family: required, Required: true}
+	}
+	return IPFamilyPolicy{Family: preferred}
+}
+
+// ValidateIPFamily returns an error if the given IP family is not one of
+// IPv4, IPv6 or empty.
+func ValidateIPFamily(family corev1.IPFamily) error {
+	switch family {
+	case "", corev1.IPv4Protocol, corev1.IPv6Protocol:
+		return nil
+	default:
+		return fmt.Errorf("invalid IP family %q, must be one of %q or %q", family, corev1.IPv4Protocol, corev1.IPv6Protocol)
+	}
+}
+
+// OrderIPs returns the given IPs ordered according to the policy, with
+// addresses of the policy's family first. If the family is required, addresses
+// of the other family are removed.
+func (p IPFamilyPolicy) OrderIPs(ips []net.IP) []net.IP {
+	if len(p.Family) == 0 {
+		return ips
+	}
+
+	var preferred, other []net.IP
+	for _, ip := range ips {
+		if ipFamilyOf(ip) == p.Family {
+			preferred = append(preferred, ip)
+		} else {
+			other = append(other, ip)
+		}
+	}
+
+	if p.Required {
+		return preferred
+	}
+	return append(preferred, other...)
+}
+
+// DialContext connects to the address on the named network using the given
+// dialer, selecting the IP address to connect to according to the policy.
+// Host names are resolved using the dialer's resolver, looking up both A and
+// AAAA records, and each resolved address is tried in turn.
+func (p IPFamilyPolicy) DialContext(ctx context.Context, dialer *net.Dialer, network, addr string) (net.Conn, error) {
+	if len(p.Family) == 0 {
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		resolver := dialer.Resolver
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+		addrs, err := resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range addrs {
+			ips = append(ips, a.IP)
+		}
+	}
+
+	ips = p.OrderIPs(ips)
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no %s addresses found for host %q", p.Family, host)
+	}
+
+	var errs []error
+	for _, ip := range ips {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+
+	return nil, utilerrors.NewAggregate(errs)
+}
+
+// DetectIPFamily returns the IP family of the host's network interfaces.
+// IPv6 is returned if the host only has global unicast IPv6 addresses, and
+// IPv4 is returned otherwise.
+func DetectIPFamily() corev1.IPFamily {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return corev1.IPv4Protocol
+	}
+	return ipFamilyForAddrs(addrs)
+}
+
+func ipFamilyForAddrs(addrs []net.Addr) corev1.IPFamily {
+	hasIPv6 := false
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+		if ipFamilyOf(ipNet.IP) == corev1.IPv4Protocol {
+			return corev1.IPv4Protocol
+		}
+		hasIPv6 = true
+	}
+
+	if hasIPv6 {
+		return corev1.IPv6Protocol
+	}
+	return corev1.IPv4Protocol
+}
+
+func ipFamilyOf(ip net.IP) corev1.IPFamily {
+	if k8snet.IsIPv6(ip) {
+		return corev1.IPv6Protocol
+	}
+	return corev1.IPv4Protocol
+}
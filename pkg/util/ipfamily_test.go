@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestIPFamilyPolicyOrderIPs(t *testing.T) {
+	v4 := net.ParseIP("192.0.2.1")
+	v6 := net.ParseIP("2001:db8::1")
+
+	tests := map[string]struct {
+		policy   IPFamilyPolicy
+		ips      []net.IP
+		expected []net.IP
+	}{
+		"no preference leaves order unchanged": {
+			policy:   IPFamilyPolicy{},
+			ips:      []net.IP{v4, v6},
+			expected: []net.IP{v4, v6},
+		},
+		"preferred IPv6 is ordered first": {
+			policy:   NewIPFamilyPolicy("", corev1.IPv6Protocol),
+			ips:      []net.IP{v4, v6},
+			expected: []net.IP{v6, v4},
+		},
+		"preferred IPv4 is ordered first": {
+			policy:   NewIPFamilyPolicy("", corev1.IPv4Protocol),
+			ips:      []net.IP{v6, v4},
+			expected: []net.IP{v4, v6},
+		},
+		"required IPv6 removes IPv4 addresses": {
+			policy:   NewIPFamilyPolicy(corev1.IPv6Protocol, corev1.IPv4Protocol),
+			ips:      []net.IP{v4, v6},
+			expected: []net.IP{v6},
+		},
+		"required IPv4 with only IPv6 addresses returns none": {
+			policy:   NewIPFamilyPolicy(corev1.IPv4Protocol, ""),
+			ips:      []net.IP{v6},
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := test.policy.OrderIPs(test.ips)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected result, exp=%v got=%v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestIPFamilyPolicyDialContext(t *testing.T) {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	dialer := &net.Dialer{}
+
+	conn, err := NewIPFamilyPolicy("", corev1.IPv6Protocol).DialContext(context.Background(), dialer, "tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("expected falling back to IPv4 to succeed, got: %v", err)
+	}
+	conn.Close()
+
+	if _, err := NewIPFamilyPolicy(corev1.IPv6Protocol, "").DialContext(context.Background(), dialer, "tcp", l.Addr().String()); err == nil {
+		t.Errorf("expected dialing an IPv4 address to fail when IPv6 is required")
+	}
+}
+
+func TestIPFamilyForAddrs(t *testing.T) {
+	ipNet := func(s string) net.Addr {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	tests := map[string]struct {
+		addrs    []net.Addr
+		expected corev1.IPFamily
+	}{
+		"no addresses": {
+			expected: corev1.IPv4Protocol,
+		},
+		"loopback and link local addresses are ignored": {
+			addrs:    []net.Addr{ipNet("127.0.0.1/8"), ipNet("fe80::1/64"), ipNet("2001:db8::1/64")},
+			expected: corev1.IPv6Protocol,
+		},
+		"dual-stack": {
+			addrs:    []net.Addr{ipNet("2001:db8::1/64"), ipNet("10.0.0.1/24")},
+			expected: corev1.IPv4Protocol,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ipFamilyForAddrs(test.addrs); got != test.expected {
+				t.Errorf("unexpected IP family, exp=%q got=%q", test.expected, got)
+			}
+		})
+	}
+}
@@ -65,7 +65,9 @@ func DNSNamesForCertificate(crt *v1.Certificate) ([]string, error) {
 		return nil, fmt.Errorf("failed to parse DNSNames: %s", err)
 	}
 
-	return crt.Spec.DNSNames, nil
+	// DNS names issued by a split issuance are not requested from the issuer
+	// of the Certificate.
+	return apiutil.CertificatePrimaryDNSNames(&crt.Spec), nil
 }
 
 func URLsFromStrings(urlStrs []string) ([]*url.URL, error) {
@@ -158,6 +160,9 @@ func BuildKeyUsages(usages []v1.KeyUsage, isCA bool) (ku x509.KeyUsage, eku []x5
 			ku |= kuse
 		} else if ekuse, ok := apiutil.ExtKeyUsageType(u); ok {
 			eku = append(eku, ekuse)
+		} else if _, ok := apiutil.UnknownExtKeyUsageOID(u); ok {
+			// encoded separately by BuildUnknownExtKeyUsages
+			continue
 		} else {
 			unk = append(unk, u)
 		}
@@ -168,6 +173,19 @@ func BuildKeyUsages(usages []v1.KeyUsage, isCA bool) (ku x509.KeyUsage, eku []x5
 	return
 }
 
+// BuildUnknownExtKeyUsages returns the OIDs of the given usages which are
+// extended key usages without an x509.ExtKeyUsage equivalent, such as
+// document signing. These must be set as UnknownExtKeyUsage on a template.
+func BuildUnknownExtKeyUsages(usages []v1.KeyUsage) []asn1.ObjectIdentifier {
+	var oids []asn1.ObjectIdentifier
+	for _, u := range usages {
+		if oid, ok := apiutil.UnknownExtKeyUsageOID(u); ok {
+			oids = append(oids, oid)
+		}
+	}
+	return oids
+}
+
 func BuildCertManagerKeyUsages(ku x509.KeyUsage, eku []x509.ExtKeyUsage) []v1.KeyUsage {
 	usages := apiutil.KeyUsageStrings(ku)
 	usages = append(usages, apiutil.ExtKeyUsageStrings(eku)...)
@@ -175,6 +193,49 @@ func BuildCertManagerKeyUsages(ku x509.KeyUsage, eku []x509.ExtKeyUsage) []v1.Ke
 	return usages
 }
 
+// CSRKeyUsages returns the usages requested by the key usage and extended key
+// usage extensions of the given CSR. Extended key usages which are unknown to
+// the x509 package are included if they correspond to a cert-manager usage.
+func CSRKeyUsages(csr *x509.CertificateRequest) ([]v1.KeyUsage, error) {
+	var ekus []x509.ExtKeyUsage
+	var unknownEKUs []asn1.ObjectIdentifier
+	var ku x509.KeyUsage
+
+	for _, extension := range csr.Extensions {
+		if extension.Id.Equal(OIDExtensionExtendedKeyUsage) {
+			var asn1ExtendedUsages []asn1.ObjectIdentifier
+			if _, err := asn1.Unmarshal(extension.Value, &asn1ExtendedUsages); err != nil {
+				return nil, fmt.Errorf("failed to decode csr extended usages: %s", err)
+			}
+			for _, asnExtUsage := range asn1ExtendedUsages {
+				eku, ok := ExtKeyUsageFromOID(asnExtUsage)
+				if ok {
+					ekus = append(ekus, eku)
+				} else {
+					unknownEKUs = append(unknownEKUs, asnExtUsage)
+				}
+			}
+		}
+		if extension.Id.Equal(OIDExtensionKeyUsage) {
+			// RFC 5280, 4.2.1.3
+			var asn1bits asn1.BitString
+			if _, err := asn1.Unmarshal(extension.Value, &asn1bits); err != nil {
+				return nil, fmt.Errorf("failed to decode csr usages: %s", err)
+			}
+			var usage int
+			for i := 0; i < 9; i++ {
+				if asn1bits.At(i) != 0 {
+					usage |= 1 << uint(i)
+				}
+			}
+			ku = x509.KeyUsage(usage)
+		}
+	}
+
+	usages := BuildCertManagerKeyUsages(ku, ekus)
+	return append(usages, apiutil.UnknownExtKeyUsageStrings(unknownEKUs)...), nil
+}
+
 // GenerateCSR will generate a new *x509.CertificateRequest template to be used
 // by issuers that utilise CSRs to obtain Certificates.
 // The CSR will not be signed, and should be passed to either EncodeCSR or
@@ -245,6 +306,32 @@ func GenerateCSR(crt *v1.Certificate) (*x509.CertificateRequest, error) {
 			ExtraExtensions:    extraExtensions,
 		}, nil
 	} else {
+		subjectName := pkix.Name{
+			Country:            subject.Countries,
+			Organization:       organization,
+			OrganizationalUnit: subject.OrganizationalUnits,
+			Locality:           subject.Localities,
+			Province:           subject.Provinces,
+			StreetAddress:      subject.StreetAddresses,
+			PostalCode:         subject.PostalCodes,
+			SerialNumber:       subject.SerialNumber,
+			CommonName:         commonName,
+		}
+
+		// Certificates with only URI or email SANs (such as SPIFFE or S/MIME
+		// certificates) have an empty subject, in which case RFC 5280 requires
+		// the subjectAltName extension to be marked critical. Go only does
+		// this when creating certificates, so the extension is built here
+		// for the request too.
+		// https://datatracker.ietf.org/doc/html/rfc5280#section-4.2.1.6
+		if len(subjectName.ToRDNSequence()) == 0 {
+			extension, err := buildSubjectAltNameExtension(dnsNames, crt.Spec.EmailAddresses, iPAddresses, uriNames, true)
+			if err != nil {
+				return nil, err
+			}
+			extraExtensions = append(extraExtensions, extension)
+		}
+
 		return &x509.CertificateRequest{
 			// Version 0 is the only one defined in the PKCS#10 standard, RFC2986.
 			// This value isn't used by Go at the time of writing.
@@ -253,17 +340,7 @@ func GenerateCSR(crt *v1.Certificate) (*x509.CertificateRequest, error) {
 			SignatureAlgorithm: sigAlgo,
 			PublicKeyAlgorithm: pubKeyAlgo,
 
-			Subject: pkix.Name{
-				Country:            subject.Countries,
-				Organization:       organization,
-				OrganizationalUnit: subject.OrganizationalUnits,
-				Locality:           subject.Localities,
-				Province:           subject.Provinces,
-				StreetAddress:      subject.StreetAddresses,
-				PostalCode:         subject.PostalCodes,
-				SerialNumber:       subject.SerialNumber,
-				CommonName:         commonName,
-			},
+			Subject:         subjectName,
 			DNSNames:        dnsNames,
 			IPAddresses:     iPAddresses,
 			URIs:            uriNames,
@@ -290,9 +367,10 @@ func buildKeyUsagesExtensionsForCertificate(crt *v1.Certificate) ([]pkix.Extensi
 			asn1ExtendedUsages = append(asn1ExtendedUsages, oid)
 		}
 	}
+	asn1ExtendedUsages = append(asn1ExtendedUsages, BuildUnknownExtKeyUsages(crt.Spec.Usages)...)
 
 	extraExtensions := []pkix.Extension{usage}
-	if len(ekus) > 0 {
+	if len(asn1ExtendedUsages) > 0 {
 		extendedUsage := pkix.Extension{
 			Id: OIDExtensionExtendedKeyUsage,
 		}
@@ -337,7 +415,7 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 		return nil, err
 	}
 
-	dnsNames := crt.Spec.DNSNames
+	dnsNames := apiutil.CertificatePrimaryDNSNames(&crt.Spec)
 	ipAddresses := IPAddressesForCertificate(crt)
 	organization := OrganizationForCertificate(crt)
 	subject := SubjectForCertificate(crt)
@@ -349,6 +427,7 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 	if err != nil {
 		return nil, err
 	}
+	unknownExtKeyUsages := BuildUnknownExtKeyUsages(crt.Spec.Usages)
 
 	if len(commonName) == 0 && len(dnsNames) == 0 && len(ipAddresses) == 0 && len(uris) == 0 && len(crt.Spec.EmailAddresses) == 0 {
 		return nil, fmt.Errorf("no common name or subject alt names requested on certificate")
@@ -386,12 +465,13 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 			NotBefore:             time.Now(),
 			NotAfter:              time.Now().Add(certDuration),
 			// see http://golang.org/pkg/crypto/x509/#KeyUsage
-			KeyUsage:       keyUsages,
-			ExtKeyUsage:    extKeyUsages,
-			DNSNames:       dnsNames,
-			IPAddresses:    ipAddresses,
-			URIs:           uris,
-			EmailAddresses: crt.Spec.EmailAddresses,
+			KeyUsage:           keyUsages,
+			ExtKeyUsage:        extKeyUsages,
+			UnknownExtKeyUsage: unknownExtKeyUsages,
+			DNSNames:           dnsNames,
+			IPAddresses:        ipAddresses,
+			URIs:               uris,
+			EmailAddresses:     crt.Spec.EmailAddresses,
 		}, nil
 	} else {
 
@@ -419,12 +499,13 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 			NotBefore: time.Now(),
 			NotAfter:  time.Now().Add(certDuration),
 			// see http://golang.org/pkg/crypto/x509/#KeyUsage
-			KeyUsage:       keyUsages,
-			ExtKeyUsage:    extKeyUsages,
-			DNSNames:       dnsNames,
-			IPAddresses:    ipAddresses,
-			URIs:           uris,
-			EmailAddresses: crt.Spec.EmailAddresses,
+			KeyUsage:           keyUsages,
+			ExtKeyUsage:        extKeyUsages,
+			UnknownExtKeyUsage: unknownExtKeyUsages,
+			DNSNames:           dnsNames,
+			IPAddresses:        ipAddresses,
+			URIs:               uris,
+			EmailAddresses:     crt.Spec.EmailAddresses,
 		}, nil
 	}
 }
@@ -437,7 +518,12 @@ func GenerateTemplateFromCertificateRequest(cr *v1.CertificateRequest) (*x509.Ce
 	if err != nil {
 		return nil, err
 	}
-	return GenerateTemplateFromCSRPEMWithUsages(cr.Spec.Request, certDuration, cr.Spec.IsCA, keyUsage, extKeyUsage)
+	template, err := GenerateTemplateFromCSRPEMWithUsages(cr.Spec.Request, certDuration, cr.Spec.IsCA, keyUsage, extKeyUsage)
+	if err != nil {
+		return nil, err
+	}
+	template.UnknownExtKeyUsage = BuildUnknownExtKeyUsages(cr.Spec.Usages)
+	return template, nil
 }
 
 func GenerateTemplateFromCSRPEM(csrPEM []byte, duration time.Duration, isCA bool) (*x509.Certificate, error) {
@@ -500,6 +586,20 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 // It returns a PEM encoded copy of the Certificate as well as a *x509.Certificate
 // which can be used for reading the encoded values.
 func SignCertificate(template *x509.Certificate, issuerCert *x509.Certificate, publicKey crypto.PublicKey, signerKey interface{}) ([]byte, *x509.Certificate, error) {
+	if err := CheckFIPSPublicKey(publicKey); err != nil {
+		return nil, nil, err
+	}
+	if signer, ok := signerKey.(crypto.Signer); ok {
+		if err := CheckFIPSPublicKey(signer.Public()); err != nil {
+			return nil, nil, fmt.Errorf("signing key: %w", err)
+		}
+	}
+
+	template, err := withCriticalTimeStampingExtKeyUsage(template)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	derBytes, err := x509.CreateCertificate(rand.Reader, template, issuerCert, publicKey, signerKey)
 
 	if err != nil {
@@ -547,7 +647,15 @@ func SignCSRTemplate(caCerts []*x509.Certificate, caKey crypto.Signer, template
 // EncodeCSR calls x509.CreateCertificateRequest to sign the given CSR template.
 // It returns a DER encoded signed CSR.
 func EncodeCSR(template *x509.CertificateRequest, key crypto.Signer) ([]byte, error) {
-	derBytes, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	createCertificateRequest := func(template *x509.CertificateRequest, key crypto.Signer) ([]byte, error) {
+		return x509.CreateCertificateRequest(rand.Reader, template, key)
+	}
+	if name, _, ok := PrivateKeyAlgorithmAndSize(key); ok {
+		alg, _ := LookupKeyAlgorithm(name)
+		createCertificateRequest = alg.CreateCertificateRequest
+	}
+
+	derBytes, err := createCertificateRequest(template, key)
 	if err != nil {
 		return nil, fmt.Errorf("error creating x509 certificate: %s", err.Error())
 	}
@@ -641,6 +749,11 @@ func SignatureAlgorithm(crt *v1.Certificate) (x509.PublicKeyAlgorithm, x509.Sign
 			return x509.UnknownPublicKeyAlgorithm, x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported ecdsa keysize specified: %d", crt.Spec.PrivateKey.Size)
 		}
 	default:
+		// The signature algorithm of registered key algorithms is chosen by
+		// their implementation when the request is signed.
+		if _, ok := LookupKeyAlgorithm(crt.Spec.PrivateKey.Algorithm); ok {
+			return x509.UnknownPublicKeyAlgorithm, x509.UnknownSignatureAlgorithm, nil
+		}
 		return x509.UnknownPublicKeyAlgorithm, x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported algorithm specified: %s. should be either 'ecdsa' or 'rsa", crt.Spec.PrivateKey.Algorithm)
 	}
 	return pubKeyAlgo, sigAlgo, nil
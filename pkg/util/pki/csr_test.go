@@ -23,6 +23,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"math/big"
+	"net/url"
 	"reflect"
 	"testing"
 	"time"
@@ -98,6 +99,13 @@ func TestBuildUsages(t *testing.T) {
 			expectedExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection, x509.ExtKeyUsageEmailProtection},
 			expectedError:       false,
 		},
+		{
+			name:                "document signing is encoded as an unknown extkeyusage",
+			usages:              []cmapi.KeyUsage{"digital signature", "code signing", "document signing"},
+			expectedKeyUsage:    x509.KeyUsageDigitalSignature,
+			expectedExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+			expectedError:       false,
+		},
 	}
 	testFn := func(test testT) func(*testing.T) {
 		return func(t *testing.T) {
@@ -452,6 +460,16 @@ func TestGenerateCSR(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// A subject alternative name extension is marked critical when the
+	// subject is empty.
+	criticalSANExtension := func(rawValues ...asn1.RawValue) pkix.Extension {
+		value, err := asn1.Marshal(rawValues)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkix.Extension{Id: OIDExtensionSubjectAltName, Critical: true, Value: value}
+	}
+
 	tests := []struct {
 		name                                    string
 		crt                                     *cmapi.Certificate
@@ -468,7 +486,35 @@ func TestGenerateCSR(t *testing.T) {
 				SignatureAlgorithm: x509.SHA256WithRSA,
 				PublicKeyAlgorithm: x509.RSA,
 				DNSNames:           []string{"example.org"},
-				ExtraExtensions:    defaultExtraExtensions,
+				ExtraExtensions: append(defaultExtraExtensions,
+					criticalSANExtension(asn1.RawValue{Tag: nameTypeDNS, Class: asn1.ClassContextSpecific, Bytes: []byte("example.org")}),
+				),
+			},
+		},
+		{
+			name: "Generate CSR from certificate with only URI",
+			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{URIs: []string{"spiffe://cluster.local/ns/sandbox/sa/foo"}}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				URIs:               []*url.URL{{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/sandbox/sa/foo"}},
+				ExtraExtensions: append(defaultExtraExtensions,
+					criticalSANExtension(asn1.RawValue{Tag: nameTypeURI, Class: asn1.ClassContextSpecific, Bytes: []byte("spiffe://cluster.local/ns/sandbox/sa/foo")}),
+				),
+			},
+		},
+		{
+			name: "Generate CSR from certificate with only email address",
+			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{EmailAddresses: []string{"alice@example.org"}}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				EmailAddresses:     []string{"alice@example.org"},
+				ExtraExtensions: append(defaultExtraExtensions,
+					criticalSANExtension(asn1.RawValue{Tag: nameTypeEmail, Class: asn1.ClassContextSpecific, Bytes: []byte("alice@example.org")}),
+				),
 			},
 		},
 		{
@@ -631,6 +677,11 @@ func Test_buildKeyUsagesExtensionsForCertificate(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	asn1CodeDocumentSigning, err := asn1.Marshal([]asn1.ObjectIdentifier{oidExtKeyUsageCodeSigning, {1, 3, 6, 1, 5, 5, 7, 3, 36}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []struct {
 		name    string
 		crt     *cmapi.Certificate
@@ -686,6 +737,25 @@ func Test_buildKeyUsagesExtensionsForCertificate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Test code + document signing extended usage set",
+			crt: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					Usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageCodeSigning, cmapi.UsageDocumentSigning},
+				},
+			},
+			want: []pkix.Extension{
+				{
+					Id:    OIDExtensionKeyUsage,
+					Value: asn1DefaultKeyUsage,
+				},
+				{
+					Id:    OIDExtensionExtendedKeyUsage,
+					Value: asn1CodeDocumentSigning,
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -821,6 +891,52 @@ func TestSignCSRTemplate(t *testing.T) {
 	}
 }
 
+func TestSignCertificateTimeStampingExtKeyUsage(t *testing.T) {
+	pk, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		extKeyUsage  []x509.ExtKeyUsage
+		wantCritical bool
+	}{
+		"time stamping only is marked critical": {
+			extKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+			wantCritical: true,
+		},
+		"time stamping with other usages is not marked critical": {
+			extKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping, x509.ExtKeyUsageCodeSigning},
+			wantCritical: false,
+		},
+		"other usages are not marked critical": {
+			extKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			wantCritical: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl := &x509.Certificate{
+				Version:      2,
+				SerialNumber: big.NewInt(0),
+				Subject:      pkix.Name{CommonName: "tsa"},
+				NotBefore:    time.Now(),
+				NotAfter:     time.Now().Add(time.Minute),
+				KeyUsage:     x509.KeyUsageDigitalSignature,
+				ExtKeyUsage:  test.extKeyUsage,
+			}
+			_, cert, err := SignCertificate(tmpl, tmpl, pk.Public(), pk)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.extKeyUsage, cert.ExtKeyUsage)
+			assert.Empty(t, tmpl.ExtraExtensions, "template should not be modified")
+			for _, ext := range cert.Extensions {
+				if ext.Id.Equal(OIDExtensionExtendedKeyUsage) {
+					assert.Equal(t, test.wantCritical, ext.Critical)
+				}
+			}
+		})
+	}
+}
+
 func TestEncodeX509Chain(t *testing.T) {
 	root := mustCreateBundle(t, nil, "root")
 	intA1 := mustCreateBundle(t, root, "intA-1")
@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var (
+	// fipsMode is true if FIPS mode was enabled with EnableFIPSMode.
+	fipsMode bool
+
+	// fipsAllowP521 is false if P-521 keys are rejected in FIPS mode.
+	fipsAllowP521 = true
+)
+
+// EnableFIPSMode restricts the private keys generated by this package, and
+// the keys of the certificates it signs, to the algorithms approved for use
+// in FIPS 140 mode: RSA keys of at least 2048 bits and ECDSA keys on the NIST
+// P-256, P-384 and, if allowP521 is true, P-521 curves. Ed25519 keys are
+// rejected.
+// It must be called before any key is generated, typically from the flags of
+// a component.
+func EnableFIPSMode(allowP521 bool) {
+	fipsMode = true
+	fipsAllowP521 = allowP521
+}
+
+// FIPSModeEnabled returns true if FIPS mode was enabled with EnableFIPSMode,
+// or the binary was built with a FIPS 140 validated crypto module which is
+// enabled.
+func FIPSModeEnabled() bool {
+	return fipsMode || fipsModuleEnabled()
+}
+
+// FIPSError is returned when a key algorithm which is not approved in FIPS
+// mode is used while FIPS mode is enabled.
+type FIPSError struct {
+	// Algorithm describes the rejected key algorithm, e.g. `RSA 1024`.
+	Algorithm string
+}
+
+func (e *FIPSError) Error() string {
+	return fmt.Sprintf("%s keys are not approved in FIPS mode", e.Algorithm)
+}
+
+// CheckFIPSKeyAlgorithm returns a FIPSError if FIPS mode is enabled and keys of
+// the given algorithm and size are not approved. A size of 0 is the default
+// size of the algorithm.
+func CheckFIPSKeyAlgorithm(algorithm v1.PrivateKeyAlgorithm, size int) error {
+	if !FIPSModeEnabled() {
+		return nil
+	}
+
+	switch algorithm {
+	case v1.PrivateKeyAlgorithm(""), v1.RSAKeyAlgorithm:
+		if size > 0 && size < MinRSAKeySize {
+			return &FIPSError{Algorithm: fmt.Sprintf("RSA %d", size)}
+		}
+	case v1.ECDSAKeyAlgorithm:
+		if size == ECCurve521 && !fipsAllowP521 {
+			return &FIPSError{Algorithm: "ECDSA P-521"}
+		}
+	case v1.Ed25519KeyAlgorithm:
+		return &FIPSError{Algorithm: "Ed25519"}
+	default:
+		// Registered key algorithms are implemented outside of the
+		// validated cryptographic module.
+		if _, ok := LookupKeyAlgorithm(algorithm); ok {
+			return &FIPSError{Algorithm: string(algorithm)}
+		}
+	}
+	return nil
+}
+
+// CheckFIPSPublicKey returns a FIPSError if FIPS mode is enabled and the given
+// public key is not approved.
+func CheckFIPSPublicKey(pub crypto.PublicKey) error {
+	if !FIPSModeEnabled() {
+		return nil
+	}
+
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return CheckFIPSKeyAlgorithm(v1.RSAKeyAlgorithm, pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256(), elliptic.P384():
+			return nil
+		case elliptic.P521():
+			return CheckFIPSKeyAlgorithm(v1.ECDSAKeyAlgorithm, ECCurve521)
+		default:
+			return &FIPSError{Algorithm: fmt.Sprintf("ECDSA %s", pub.Curve.Params().Name)}
+		}
+	case ed25519.PublicKey:
+		return &FIPSError{Algorithm: "Ed25519"}
+	default:
+		return &FIPSError{Algorithm: fmt.Sprintf("%T", pub)}
+	}
+}
@@ -0,0 +1,30 @@
+//go:build boringcrypto
+
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	// Restrict TLS to FIPS approved settings when built with BoringCrypto.
+	_ "crypto/tls/fipsonly"
+)
+
+// fipsModuleEnabled returns true as binaries built with BoringCrypto always
+// use its FIPS 140 validated module.
+func fipsModuleEnabled() bool {
+	return true
+}
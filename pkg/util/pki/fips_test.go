@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// withFIPSMode enables FIPS mode for the duration of a test.
+func withFIPSMode(t *testing.T, allowP521 bool) {
+	EnableFIPSMode(allowP521)
+	t.Cleanup(func() {
+		fipsMode = false
+		fipsAllowP521 = true
+	})
+}
+
+func TestCheckFIPSKeyAlgorithm(t *testing.T) {
+	assert.NoError(t, CheckFIPSKeyAlgorithm(v1.Ed25519KeyAlgorithm, 0), "FIPS mode is disabled by default")
+
+	withFIPSMode(t, false)
+
+	tests := map[string]struct {
+		algorithm v1.PrivateKeyAlgorithm
+		size      int
+		expErr    bool
+	}{
+		"default algorithm":  {},
+		"RSA 2048":           {algorithm: v1.RSAKeyAlgorithm, size: 2048},
+		"RSA 1024":           {algorithm: v1.RSAKeyAlgorithm, size: 1024, expErr: true},
+		"ECDSA default size": {algorithm: v1.ECDSAKeyAlgorithm},
+		"ECDSA P-384":        {algorithm: v1.ECDSAKeyAlgorithm, size: 384},
+		"ECDSA P-521":        {algorithm: v1.ECDSAKeyAlgorithm, size: 521, expErr: true},
+		"Ed25519":            {algorithm: v1.Ed25519KeyAlgorithm, expErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := CheckFIPSKeyAlgorithm(test.algorithm, test.size)
+			if !test.expErr {
+				assert.NoError(t, err)
+				return
+			}
+			var fipsErr *FIPSError
+			assert.True(t, errors.As(err, &fipsErr), "expected a FIPSError, got: %v", err)
+		})
+	}
+}
+
+func TestCheckFIPSPublicKey(t *testing.T) {
+	withFIPSMode(t, true)
+
+	p521, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	assert.NoError(t, err)
+	assert.NoError(t, CheckFIPSPublicKey(p521.Public()), "P-521 is allowed")
+
+	weakRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+	assert.EqualError(t, CheckFIPSPublicKey(weakRSA.Public()), "RSA 1024 keys are not approved in FIPS mode")
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.EqualError(t, CheckFIPSPublicKey(edPub), "Ed25519 keys are not approved in FIPS mode")
+}
+
+func TestFIPSModeEnforcement(t *testing.T) {
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	caKey, err := GenerateECPrivateKey(ECCurve256)
+	assert.NoError(t, err)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), IsCA: true, BasicConstraintsValid: true}
+
+	withFIPSMode(t, true)
+
+	_, err = GenerateEd25519PrivateKey()
+	assert.Error(t, err, "Ed25519 keys must not be generated in FIPS mode")
+
+	_, err = GeneratePrivateKeyForCertificate(&v1.Certificate{Spec: v1.CertificateSpec{
+		PrivateKey: &v1.CertificatePrivateKey{Algorithm: v1.Ed25519KeyAlgorithm},
+	}})
+	assert.Error(t, err, "Ed25519 keys must not be generated for Certificates in FIPS mode")
+
+	_, _, err = SignCertificate(template, template, edPub, caKey)
+	assert.Error(t, err, "certificates for Ed25519 keys must not be signed in FIPS mode")
+
+	_, _, err = SignCertificate(template, template, caKey.Public(), edKey)
+	assert.Error(t, err, "certificates must not be signed by Ed25519 keys in FIPS mode")
+
+	_, _, err = SignCertificate(template, template, caKey.Public(), caKey)
+	assert.NoError(t, err)
+}
@@ -50,7 +50,8 @@ const (
 // GeneratePrivateKeyForCertificate will generate a private key suitable for
 // the provided cert-manager Certificate resource, taking into account the
 // parameters on the provided resource.
-// The returned key will either be RSA or ECDSA.
+// The returned key will either be RSA, ECDSA, Ed25519 or of an algorithm
+// registered using RegisterKeyAlgorithm.
 func GeneratePrivateKeyForCertificate(crt *v1.Certificate) (crypto.Signer, error) {
 	crt = crt.DeepCopy()
 	if crt.Spec.PrivateKey == nil {
@@ -76,6 +77,12 @@ func GeneratePrivateKeyForCertificate(crt *v1.Certificate) (crypto.Signer, error
 	case v1.Ed25519KeyAlgorithm:
 		return GenerateEd25519PrivateKey()
 	default:
+		if alg, ok := LookupKeyAlgorithm(crt.Spec.PrivateKey.Algorithm); ok {
+			if err := CheckFIPSKeyAlgorithm(crt.Spec.PrivateKey.Algorithm, crt.Spec.PrivateKey.Size); err != nil {
+				return nil, err
+			}
+			return alg.GenerateKey(crt.Spec.PrivateKey.Size)
+		}
 		return nil, fmt.Errorf("unsupported private key algorithm specified: %s", crt.Spec.PrivateKey.Algorithm)
 	}
 }
@@ -98,6 +105,10 @@ func GenerateRSAPrivateKey(keySize int) (*rsa.PrivateKey, error) {
 // GenerateECPrivateKey will generate an ECDSA private key of the given size.
 // It can be used to generate 256, 384 and 521 sized keys.
 func GenerateECPrivateKey(keySize int) (*ecdsa.PrivateKey, error) {
+	if err := CheckFIPSKeyAlgorithm(v1.ECDSAKeyAlgorithm, keySize); err != nil {
+		return nil, err
+	}
+
 	var ecCurve elliptic.Curve
 
 	switch keySize {
@@ -116,6 +127,9 @@ func GenerateECPrivateKey(keySize int) (*ecdsa.PrivateKey, error) {
 
 // GenerateEd25519PrivateKey will generate an Ed25519 private key
 func GenerateEd25519PrivateKey() (ed25519.PrivateKey, error) {
+	if err := CheckFIPSKeyAlgorithm(v1.Ed25519KeyAlgorithm, 0); err != nil {
+		return nil, err
+	}
 
 	_, prvkey, err := ed25519.GenerateKey(rand.Reader)
 
@@ -136,6 +150,10 @@ func EncodePrivateKey(pk crypto.PrivateKey, keyEncoding v1.PrivateKeyEncoding) (
 		case ed25519.PrivateKey:
 			return EncodePKCS8PrivateKey(k)
 		default:
+			// Keys of registered algorithms have no PKCS#1 encoding.
+			if _, _, ok := PrivateKeyAlgorithmAndSize(pk); ok {
+				return EncodePKCS8PrivateKey(pk)
+			}
 			return nil, fmt.Errorf("error encoding private key: unknown key type: %T", pk)
 		}
 	case v1.PKCS8:
@@ -154,6 +172,11 @@ func EncodePKCS1PrivateKey(pk *rsa.PrivateKey) []byte {
 
 // EncodePKCS8PrivateKey will marshal a private key into x509 PEM format.
 func EncodePKCS8PrivateKey(pk interface{}) ([]byte, error) {
+	if name, _, ok := PrivateKeyAlgorithmAndSize(pk); ok {
+		alg, _ := LookupKeyAlgorithm(name)
+		return encodeRegisteredPKCS8PrivateKey(alg, pk)
+	}
+
 	keyBytes, err := x509.MarshalPKCS8PrivateKey(pk)
 	if err != nil {
 		return nil, err
@@ -185,6 +208,11 @@ func PublicKeyForPrivateKey(pk crypto.PrivateKey) (crypto.PublicKey, error) {
 	case ed25519.PrivateKey:
 		return k.Public(), nil
 	default:
+		if signer, ok := pk.(crypto.Signer); ok {
+			if _, _, ok := PrivateKeyAlgorithmAndSize(pk); ok {
+				return signer.Public(), nil
+			}
+		}
 		return nil, fmt.Errorf("unknown private key type: %T", pk)
 	}
 }
@@ -195,6 +223,11 @@ func PublicKeyForPrivateKey(pk crypto.PrivateKey) (crypto.PublicKey, error) {
 // Returns true and no error if the public key *is* the same as the certificate's key
 // Returns an error if the certificate's key type cannot be determined (i.e. non RSA/ECDSA keys)
 func PublicKeyMatchesCertificate(check crypto.PublicKey, crt *x509.Certificate) (bool, error) {
+	if !isBuiltinPublicKey(check) {
+		if matches, ok := registeredPublicKeyMatches(check, crt.RawSubjectPublicKeyInfo); ok {
+			return matches, nil
+		}
+	}
 	return PublicKeysEqual(crt.PublicKey, check)
 }
 
@@ -204,6 +237,11 @@ func PublicKeyMatchesCertificate(check crypto.PublicKey, crt *x509.Certificate)
 // Returns true and no error if the given public key *is* the same as the CSR's key
 // Returns an error if the CSR's key type cannot be determined (i.e. non RSA/ECDSA keys)
 func PublicKeyMatchesCSR(check crypto.PublicKey, csr *x509.CertificateRequest) (bool, error) {
+	if !isBuiltinPublicKey(check) {
+		if matches, ok := registeredPublicKeyMatches(check, csr.RawSubjectPublicKeyInfo); ok {
+			return matches, nil
+		}
+	}
 	return PublicKeysEqual(csr.PublicKey, check)
 }
 
@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sync"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+// KeyAlgorithm is the extension point for private key algorithms that are
+// not built in to cert-manager, such as the experimental ML-DSA and composite
+// ML-DSA signature algorithms. The standard library cannot generate, encode
+// or sign with keys of these algorithms, so an implementation must provide
+// each of those operations.
+// Implementations are registered using RegisterKeyAlgorithm and are only
+// used when the PluggableKeyAlgorithms feature gate is enabled.
+type KeyAlgorithm interface {
+	// GenerateKey generates a new private key of the given size. The size
+	// is the value of spec.privateKey.size and is 0 if it is not set.
+	GenerateKey(size int) (crypto.Signer, error)
+
+	// KeySize returns the size of the given private key, in the same units
+	// as spec.privateKey.size. It returns false if the key is not of this
+	// algorithm.
+	KeySize(key crypto.PrivateKey) (int, bool)
+
+	// MarshalPKCS8PrivateKey returns the PKCS#8 DER encoding of the given
+	// private key.
+	MarshalPKCS8PrivateKey(key crypto.PrivateKey) ([]byte, error)
+
+	// ParsePKCS8PrivateKey parses a PKCS#8 DER encoded private key. It
+	// returns an error if the key is not of this algorithm.
+	ParsePKCS8PrivateKey(der []byte) (crypto.Signer, error)
+
+	// MarshalPKIXPublicKey returns the DER encoded SubjectPublicKeyInfo of
+	// the given public key. It returns an error if the key is not of this
+	// algorithm.
+	MarshalPKIXPublicKey(pub crypto.PublicKey) ([]byte, error)
+
+	// CreateCertificateRequest returns a DER encoded CSR for the given
+	// template, signed by the given private key.
+	CreateCertificateRequest(template *x509.CertificateRequest, key crypto.Signer) ([]byte, error)
+}
+
+var (
+	keyAlgorithms     = make(map[v1.PrivateKeyAlgorithm]KeyAlgorithm)
+	keyAlgorithmsLock sync.RWMutex
+)
+
+// RegisterKeyAlgorithm registers an implementation of the named private key
+// algorithm, so that Certificates with that spec.privateKey.algorithm can be
+// issued. It panics if the name is one of the built in algorithms.
+func RegisterKeyAlgorithm(name v1.PrivateKeyAlgorithm, alg KeyAlgorithm) {
+	switch name {
+	case "", v1.RSAKeyAlgorithm, v1.ECDSAKeyAlgorithm, v1.Ed25519KeyAlgorithm:
+		panic(fmt.Sprintf("cannot register built in private key algorithm %q", name))
+	}
+
+	keyAlgorithmsLock.Lock()
+	defer keyAlgorithmsLock.Unlock()
+	keyAlgorithms[name] = alg
+}
+
+// LookupKeyAlgorithm returns the registered implementation of the named
+// private key algorithm. It returns false if no implementation is registered
+// or if the PluggableKeyAlgorithms feature gate is disabled.
+func LookupKeyAlgorithm(name v1.PrivateKeyAlgorithm) (KeyAlgorithm, bool) {
+	if !pluggableKeyAlgorithmsEnabled() {
+		return nil, false
+	}
+
+	keyAlgorithmsLock.RLock()
+	defer keyAlgorithmsLock.RUnlock()
+	alg, ok := keyAlgorithms[name]
+	return alg, ok
+}
+
+// PrivateKeyAlgorithmAndSize returns the name and size of the registered
+// algorithm that the given private key belongs to. It returns false for keys
+// of the built in algorithms, and for keys of unknown algorithms.
+func PrivateKeyAlgorithmAndSize(key crypto.PrivateKey) (v1.PrivateKeyAlgorithm, int, bool) {
+	if !pluggableKeyAlgorithmsEnabled() {
+		return "", 0, false
+	}
+
+	keyAlgorithmsLock.RLock()
+	defer keyAlgorithmsLock.RUnlock()
+	for name, alg := range keyAlgorithms {
+		if size, ok := alg.KeySize(key); ok {
+			return name, size, true
+		}
+	}
+	return "", 0, false
+}
+
+// parseRegisteredPKCS8PrivateKey tries each registered algorithm in turn to
+// parse a PKCS#8 encoded private key that the standard library could not.
+func parseRegisteredPKCS8PrivateKey(der []byte) (crypto.Signer, bool) {
+	if !pluggableKeyAlgorithmsEnabled() {
+		return nil, false
+	}
+
+	keyAlgorithmsLock.RLock()
+	defer keyAlgorithmsLock.RUnlock()
+	for _, alg := range keyAlgorithms {
+		if key, err := alg.ParsePKCS8PrivateKey(der); err == nil {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// registeredPublicKeyMatches reports whether the given public key, of a
+// registered algorithm, is encoded as the given SubjectPublicKeyInfo.
+func registeredPublicKeyMatches(check crypto.PublicKey, rawSubjectPublicKeyInfo []byte) (bool, bool) {
+	if !pluggableKeyAlgorithmsEnabled() {
+		return false, false
+	}
+
+	keyAlgorithmsLock.RLock()
+	defer keyAlgorithmsLock.RUnlock()
+	for _, alg := range keyAlgorithms {
+		if der, err := alg.MarshalPKIXPublicKey(check); err == nil {
+			return bytes.Equal(der, rawSubjectPublicKeyInfo), true
+		}
+	}
+	return false, false
+}
+
+// isBuiltinPublicKey reports whether the given public key is of one of the
+// key algorithms supported by the standard library.
+func isBuiltinPublicKey(pub crypto.PublicKey) bool {
+	switch pub.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return true
+	}
+	return false
+}
+
+// encodeRegisteredPKCS8PrivateKey encodes a private key of a registered
+// algorithm into PKCS#8 PEM format.
+func encodeRegisteredPKCS8PrivateKey(alg KeyAlgorithm, pk crypto.PrivateKey) ([]byte, error) {
+	keyBytes, err := alg.MarshalPKCS8PrivateKey(pk)
+	if err != nil {
+		return nil, err
+	}
+	block := &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
+
+	return pem.EncodeToMemory(block), nil
+}
+
+func pluggableKeyAlgorithmsEnabled() bool {
+	return utilfeature.DefaultFeatureGate.Enabled(feature.PluggableKeyAlgorithms)
+}
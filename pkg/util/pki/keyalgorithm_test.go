@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+// fakeKey is a private key of the fake registered algorithm. It signs with
+// an Ed25519 key, so that the standard library can verify its signatures.
+type fakeKey struct {
+	ed25519.PrivateKey
+	size int
+}
+
+type fakePublicKey struct {
+	ed25519.PublicKey
+}
+
+func (k *fakeKey) Public() crypto.PublicKey {
+	return fakePublicKey{k.PrivateKey.Public().(ed25519.PublicKey)}
+}
+
+func (k *fakeKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return k.PrivateKey.Sign(rand, digest, opts)
+}
+
+var fakeKeyPrefix = []byte("fake")
+
+type fakeKeyAlgorithm struct{}
+
+func (fakeKeyAlgorithm) GenerateKey(size int) (crypto.Signer, error) {
+	if size == 0 {
+		size = 65
+	}
+	_, pk, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeKey{PrivateKey: pk, size: size}, nil
+}
+
+func (fakeKeyAlgorithm) KeySize(key crypto.PrivateKey) (int, bool) {
+	k, ok := key.(*fakeKey)
+	if !ok {
+		return 0, false
+	}
+	return k.size, true
+}
+
+func (fakeKeyAlgorithm) MarshalPKCS8PrivateKey(key crypto.PrivateKey) ([]byte, error) {
+	k := key.(*fakeKey)
+	return append(append(append([]byte{}, fakeKeyPrefix...), byte(k.size)), k.PrivateKey.Seed()...), nil
+}
+
+func (fakeKeyAlgorithm) ParsePKCS8PrivateKey(der []byte) (crypto.Signer, error) {
+	if !bytes.HasPrefix(der, fakeKeyPrefix) || len(der) != len(fakeKeyPrefix)+1+ed25519.SeedSize {
+		return nil, errors.New("not a fake key")
+	}
+	return &fakeKey{
+		PrivateKey: ed25519.NewKeyFromSeed(der[len(fakeKeyPrefix)+1:]),
+		size:       int(der[len(fakeKeyPrefix)]),
+	}, nil
+}
+
+func (fakeKeyAlgorithm) MarshalPKIXPublicKey(pub crypto.PublicKey) ([]byte, error) {
+	k, ok := pub.(fakePublicKey)
+	if !ok {
+		return nil, errors.New("not a fake public key")
+	}
+	return x509.MarshalPKIXPublicKey(k.PublicKey)
+}
+
+func (fakeKeyAlgorithm) CreateCertificateRequest(template *x509.CertificateRequest, key crypto.Signer) ([]byte, error) {
+	return x509.CreateCertificateRequest(rand.Reader, template, key.(*fakeKey).PrivateKey)
+}
+
+func TestRegisteredKeyAlgorithm(t *testing.T) {
+	RegisterKeyAlgorithm(v1.MLDSAKeyAlgorithm, fakeKeyAlgorithm{})
+	t.Cleanup(func() {
+		keyAlgorithmsLock.Lock()
+		defer keyAlgorithmsLock.Unlock()
+		delete(keyAlgorithms, v1.MLDSAKeyAlgorithm)
+	})
+
+	crt := &v1.Certificate{
+		Spec: v1.CertificateSpec{
+			CommonName: "example.com",
+			PrivateKey: &v1.CertificatePrivateKey{Algorithm: v1.MLDSAKeyAlgorithm, Size: 87},
+		},
+	}
+
+	_, err := GeneratePrivateKeyForCertificate(crt)
+	assert.Error(t, err, "registered algorithms must not be used when the feature gate is disabled")
+
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.PluggableKeyAlgorithms, true)()
+
+	pk, err := GeneratePrivateKeyForCertificate(crt)
+	require.NoError(t, err)
+
+	algorithm, size, ok := PrivateKeyAlgorithmAndSize(pk)
+	assert.True(t, ok)
+	assert.Equal(t, v1.MLDSAKeyAlgorithm, algorithm)
+	assert.Equal(t, 87, size)
+
+	for _, encoding := range []v1.PrivateKeyEncoding{v1.PKCS1, v1.PKCS8} {
+		pkPEM, err := EncodePrivateKey(pk, encoding)
+		require.NoError(t, err)
+		decoded, err := DecodePrivateKeyBytes(pkPEM)
+		require.NoError(t, err)
+		assert.Equal(t, pk, decoded)
+	}
+
+	pub, err := PublicKeyForPrivateKey(pk)
+	require.NoError(t, err)
+	assert.Equal(t, pk.Public(), pub)
+
+	template, err := GenerateCSR(crt)
+	require.NoError(t, err)
+	csrDER, err := EncodeCSR(template, pk)
+	require.NoError(t, err)
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	require.NoError(t, err)
+	assert.NoError(t, csr.CheckSignature())
+
+	matches, err := PublicKeyMatchesCSR(pk.Public(), csr)
+	require.NoError(t, err)
+	assert.True(t, matches)
+
+	other, err := fakeKeyAlgorithm{}.GenerateKey(0)
+	require.NoError(t, err)
+	matches, err = PublicKeyMatchesCSR(other.Public(), csr)
+	require.NoError(t, err)
+	assert.False(t, matches)
+
+	_, _, ok = PrivateKeyAlgorithmAndSize(ed25519.PrivateKey(make([]byte, ed25519.PrivateKeySize)))
+	assert.False(t, ok, "built in keys must not belong to a registered algorithm")
+
+	assert.Panics(t, func() { RegisterKeyAlgorithm(v1.RSAKeyAlgorithm, fakeKeyAlgorithm{}) })
+
+	withFIPSMode(t, true)
+	_, err = GeneratePrivateKeyForCertificate(crt)
+	assert.ErrorAs(t, err, new(*FIPSError))
+}
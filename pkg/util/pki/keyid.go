@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// KeyIdentifier derives the key identifier of a public key using the given
+// method. Both methods hash the value of the subjectPublicKey BIT STRING of
+// the key, as described in RFC 5280 section 4.2.1.2 and RFC 7093 section 2.
+func KeyIdentifier(publicKey crypto.PublicKey, method v1.KeyIdentifierMethod) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling public key: %w", err)
+	}
+
+	var spki struct {
+		Algorithm        pkix.AlgorithmIdentifier
+		SubjectPublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, fmt.Errorf("error unmarshalling public key: %w", err)
+	}
+
+	switch method {
+	case v1.SHA1KeyIdentifierMethod:
+		sum := sha1.Sum(spki.SubjectPublicKey.Bytes)
+		return sum[:], nil
+	case v1.TruncatedSHA256KeyIdentifierMethod:
+		sum := sha256.Sum256(spki.SubjectPublicKey.Bytes)
+		return sum[:20], nil
+	default:
+		return nil, fmt.Errorf("unsupported key identifier method %q", method)
+	}
+}
+
+// SetKeyIdentifiers sets the subject key identifier of the template using the
+// given method. For a certificate signed by issuerCert, the authority key
+// identifier is derived from the issuer's public key in the same way if
+// issuerCert doesn't have a subject key identifier; otherwise it is copied
+// from issuerCert when signing. For a self-signed certificate, issuerCert is
+// nil and the authority key identifier is the subject key identifier.
+// It does nothing if method is empty.
+func SetKeyIdentifiers(template *x509.Certificate, issuerCert *x509.Certificate, method v1.KeyIdentifierMethod) error {
+	if method == "" {
+		return nil
+	}
+
+	ski, err := KeyIdentifier(template.PublicKey, method)
+	if err != nil {
+		return err
+	}
+	template.SubjectKeyId = ski
+
+	if issuerCert == nil {
+		template.AuthorityKeyId = ski
+		return nil
+	}
+
+	if len(issuerCert.SubjectKeyId) == 0 {
+		aki, err := KeyIdentifier(issuerCert.PublicKey, method)
+		if err != nil {
+			return fmt.Errorf("error deriving authority key identifier: %w", err)
+		}
+		template.AuthorityKeyId = aki
+	}
+
+	return nil
+}
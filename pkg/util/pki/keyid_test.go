@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestKeyIdentifier(t *testing.T) {
+	pk, err := GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// x509.CreateCertificate derives the subject key identifier of CA
+	// certificates using the SHA-1 method.
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	_, ca, err := SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sha1KeyID, err := KeyIdentifier(pk.Public(), v1.SHA1KeyIdentifierMethod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sha1KeyID, ca.SubjectKeyId) {
+		t.Errorf("expected SHA1 key identifier %x, got %x", ca.SubjectKeyId, sha1KeyID)
+	}
+
+	sha256KeyID, err := KeyIdentifier(pk.Public(), v1.TruncatedSHA256KeyIdentifierMethod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sha256KeyID) != 20 || bytes.Equal(sha256KeyID, sha1KeyID) {
+		t.Errorf("expected a distinct 20 byte TruncatedSHA256 key identifier, got %x", sha256KeyID)
+	}
+
+	if _, err := KeyIdentifier(pk.Public(), "MD5"); err == nil {
+		t.Error("expected an error for an unsupported method")
+	}
+}
+
+func TestSetKeyIdentifiers(t *testing.T) {
+	caKey, err := GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafKey, err := GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newTemplate := func(cn string, pub interface{}) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+			PublicKey:    pub,
+		}
+	}
+	method := v1.TruncatedSHA256KeyIdentifierMethod
+	caKeyID, err := KeyIdentifier(caKey.Public(), method)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafKeyID, err := KeyIdentifier(leafKey.Public(), method)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("self-signed certificate", func(t *testing.T) {
+		template := newTemplate("ca", caKey.Public())
+		if err := SetKeyIdentifiers(template, nil, method); err != nil {
+			t.Fatal(err)
+		}
+		_, cert, err := SignCertificate(template, template, caKey.Public(), caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(cert.SubjectKeyId, caKeyID) || !bytes.Equal(cert.AuthorityKeyId, caKeyID) {
+			t.Errorf("expected subject and authority key identifiers %x, got %x and %x", caKeyID, cert.SubjectKeyId, cert.AuthorityKeyId)
+		}
+	})
+
+	t.Run("issuer certificate without a subject key identifier", func(t *testing.T) {
+		// Non-CA certificates don't get a subject key identifier by default.
+		caTemplate := newTemplate("ca", caKey.Public())
+		_, ca, err := SignCertificate(caTemplate, caTemplate, caKey.Public(), caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ca.SubjectKeyId) != 0 {
+			t.Fatalf("expected issuer certificate without a subject key identifier, got %x", ca.SubjectKeyId)
+		}
+
+		template := newTemplate("leaf", leafKey.Public())
+		if err := SetKeyIdentifiers(template, ca, method); err != nil {
+			t.Fatal(err)
+		}
+		_, cert, err := SignCertificate(template, ca, leafKey.Public(), caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(cert.SubjectKeyId, leafKeyID) || !bytes.Equal(cert.AuthorityKeyId, caKeyID) {
+			t.Errorf("expected subject key identifier %x and authority key identifier %x, got %x and %x", leafKeyID, caKeyID, cert.SubjectKeyId, cert.AuthorityKeyId)
+		}
+	})
+
+	t.Run("no method leaves the template unchanged", func(t *testing.T) {
+		template := newTemplate("leaf", leafKey.Public())
+		if err := SetKeyIdentifiers(template, nil, ""); err != nil {
+			t.Fatal(err)
+		}
+		if template.SubjectKeyId != nil || template.AuthorityKeyId != nil {
+			t.Errorf("expected no key identifiers, got %x and %x", template.SubjectKeyId, template.AuthorityKeyId)
+		}
+	})
+}
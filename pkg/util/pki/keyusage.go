@@ -20,6 +20,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"fmt"
 )
 
 // Copied from x509.go
@@ -98,6 +99,34 @@ func ExtKeyUsageFromOID(oid asn1.ObjectIdentifier) (eku x509.ExtKeyUsage, ok boo
 	return
 }
 
+// withCriticalTimeStampingExtKeyUsage returns a copy of the template with a
+// critical extended key usage extension if time stamping is its only
+// extended key usage. RFC 3161, 2.3 requires the extension to be critical in
+// the certificate of a time-stamping authority, which the x509 package
+// otherwise never marks it as.
+func withCriticalTimeStampingExtKeyUsage(template *x509.Certificate) (*x509.Certificate, error) {
+	if len(template.ExtKeyUsage) != 1 || template.ExtKeyUsage[0] != x509.ExtKeyUsageTimeStamping || len(template.UnknownExtKeyUsage) > 0 {
+		return template, nil
+	}
+	for _, ext := range template.ExtraExtensions {
+		if ext.Id.Equal(OIDExtensionExtendedKeyUsage) {
+			return template, nil
+		}
+	}
+
+	value, err := asn1.Marshal([]asn1.ObjectIdentifier{oidExtKeyUsageTimeStamping})
+	if err != nil {
+		return nil, fmt.Errorf("failed to asn1 encode extended usages: %w", err)
+	}
+	out := *template
+	out.ExtraExtensions = append(append([]pkix.Extension(nil), template.ExtraExtensions...), pkix.Extension{
+		Id:       OIDExtensionExtendedKeyUsage,
+		Critical: true,
+		Value:    value,
+	})
+	return &out, nil
+}
+
 // asn1BitLength returns the bit-length of bitString by considering the
 // most-significant bit in a byte to be the "first" bit. This convention
 // matches ASN.1, but differs from almost everything else.
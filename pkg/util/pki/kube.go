@@ -26,6 +26,7 @@ import (
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
+	cmduration "github.com/cert-manager/cert-manager/pkg/util/duration"
 )
 
 // GenerateTemplateFromCertificateSigningRequest will create an
@@ -65,7 +66,7 @@ func DurationFromCertificateSigningRequest(csr *certificatesv1.CertificateSignin
 		return cmapi.DefaultCertificateDuration, nil
 	}
 
-	duration, err := time.ParseDuration(requestedDuration)
+	duration, err := cmduration.Parse(requestedDuration)
 	if err != nil {
 		return -1, fmt.Errorf("failed to parse requested duration on annotation %q: %w",
 			experimentalapi.CertificateSigningRequestDurationAnnotationKey, err)
@@ -41,6 +41,9 @@ func DecodePrivateKeyBytes(keyBytes []byte) (crypto.Signer, error) {
 	case "PRIVATE KEY":
 		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
 		if err != nil {
+			if signer, ok := parseRegisteredPKCS8PrivateKey(block.Bytes); ok {
+				return signer, nil
+			}
 			return nil, errors.NewInvalidData("error parsing pkcs#8 private key: %s", err.Error())
 		}
 
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+)
+
+// VerifyChainToRoots verifies that the leaf of the PEM encoded certificate
+// chain chains up to one of the PEM encoded roots. The rest of the chain and
+// the PEM encoded CA, which may be empty, are used as intermediates.
+// Key usages and the current time are not checked, as only the chain is
+// verified: the chain is verified at the start of the validity of the leaf.
+func VerifyChainToRoots(chainPEM, caPEM, rootsPEM []byte) error {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootsPEM) {
+		return errors.NewInvalidData("no valid certificates in the root bundle")
+	}
+
+	chain, err := DecodeX509CertificateChainBytes(chainPEM)
+	if err != nil {
+		return err
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if len(caPEM) > 0 {
+		// The CA returned by an issuer may be a root, which is only trusted
+		// if it is one of the given roots.
+		intermediates.AppendCertsFromPEM(caPEM)
+	}
+
+	if _, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		CurrentTime:   chain[0].NotBefore,
+	}); err != nil {
+		return fmt.Errorf("certificate does not chain up to a trusted root: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func mustSignVerifyTestCert(t *testing.T, cn string, isCA bool, notBefore time.Time, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, []byte) {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	pemBytes, cert, err := SignCertificate(template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pemBytes
+}
+
+func TestVerifyChainToRoots(t *testing.T) {
+	var keys []crypto.Signer
+	for i := 0; i < 4; i++ {
+		key, err := GenerateECPrivateKey(256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+
+	// The chain is verified at the start of its validity, so it may have
+	// expired since.
+	notBefore := time.Now().Add(-48 * time.Hour)
+	root, rootPEM := mustSignVerifyTestCert(t, "root", true, notBefore, keys[0], nil, nil)
+	_, otherRootPEM := mustSignVerifyTestCert(t, "root", true, notBefore, keys[1], nil, nil)
+	intermediate, intermediatePEM := mustSignVerifyTestCert(t, "intermediate", true, notBefore, keys[2], root, keys[0])
+	_, leafPEM := mustSignVerifyTestCert(t, "leaf", false, notBefore, keys[3], intermediate, keys[2])
+	chainPEM := append(append([]byte{}, leafPEM...), intermediatePEM...)
+
+	tests := map[string]struct {
+		chainPEM, caPEM, rootsPEM []byte
+		expErr                    bool
+	}{
+		"chain up to a pinned root": {
+			chainPEM: chainPEM,
+			caPEM:    rootPEM,
+			rootsPEM: rootPEM,
+		},
+		"chain up to one of several pinned roots": {
+			chainPEM: chainPEM,
+			rootsPEM: append(append([]byte{}, otherRootPEM...), rootPEM...),
+		},
+		"intermediate returned as the CA": {
+			chainPEM: leafPEM,
+			caPEM:    intermediatePEM,
+			rootsPEM: rootPEM,
+		},
+		"chain up to a different root with the same name": {
+			chainPEM: chainPEM,
+			caPEM:    rootPEM,
+			rootsPEM: otherRootPEM,
+			expErr:   true,
+		},
+		"incomplete chain": {
+			chainPEM: leafPEM,
+			rootsPEM: rootPEM,
+			expErr:   true,
+		},
+		"invalid roots": {
+			chainPEM: chainPEM,
+			rootsPEM: []byte("not a certificate"),
+			expErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyChainToRoots(test.chainPEM, test.caPEM, test.rootsPEM)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error: %t, got %v", test.expErr, err)
+			}
+		})
+	}
+}
@@ -35,3 +35,14 @@ func CertificateRequestRevision(revision int) Func {
 		return req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey] == fmt.Sprintf("%d", revision)
 	}
 }
+
+// CertificateRequestSplitIssuance returns a predicate that used to filter
+// CertificateRequest to only those created for the split issuance with the
+// given name. If name is empty, only CertificateRequests which were not
+// created for a split issuance are returned.
+func CertificateRequestSplitIssuance(name string) Func {
+	return func(obj runtime.Object) bool {
+		req := obj.(*cmapi.CertificateRequest)
+		return req.Annotations[cmapi.CertificateRequestSplitIssuanceAnnotationKey] == name
+	}
+}
@@ -76,3 +76,54 @@ func TestCertificateRequestRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateRequestSplitIssuance(t *testing.T) {
+	requestWithSplitIssuance := func(name string) *cmapi.CertificateRequest {
+		return &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					cmapi.CertificateRequestSplitIssuanceAnnotationKey: name,
+				},
+			},
+		}
+	}
+	tests := map[string]struct {
+		name     string
+		request  *cmapi.CertificateRequest
+		expected bool
+	}{
+		"returns true if name matches": {
+			name:     "mesh",
+			request:  requestWithSplitIssuance("mesh"),
+			expected: true,
+		},
+		"returns false if name does not match": {
+			name:     "internal",
+			request:  requestWithSplitIssuance("mesh"),
+			expected: false,
+		},
+		"returns false if request is not for a split issuance": {
+			name:     "mesh",
+			request:  &cmapi.CertificateRequest{},
+			expected: false,
+		},
+		"returns true for requests which are not for a split issuance if name is empty": {
+			name:     "",
+			request:  &cmapi.CertificateRequest{},
+			expected: true,
+		},
+		"returns false for requests for a split issuance if name is empty": {
+			name:     "",
+			request:  requestWithSplitIssuance("mesh"),
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := CertificateRequestSplitIssuance(test.name)(test.request)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apijson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+
+	"github.com/cert-manager/cert-manager/pkg/util/duration"
+)
+
+var durationType = reflect.TypeOf(metav1.Duration{})
+
+// normalizeDurations rewrites the duration fields of the given encoded object
+// which use units that are not supported by Go's time.ParseDuration, such as
+// "90d" or "1y", into the equivalent duration in the units that are, so that
+// the object can be decoded.
+// The given bytes are returned unchanged if the object does not contain any
+// such duration, or if its kind is not registered in the scheme.
+func (rh *RequestHandler) normalizeDurations(raw []byte) ([]byte, error) {
+	gvk, err := apijson.DefaultMetaFactory.Interpret(raw)
+	if err != nil {
+		return raw, nil
+	}
+	obj, err := rh.scheme.New(*gvk)
+	if err != nil {
+		return raw, nil
+	}
+
+	var content interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&content); err != nil {
+		return nil, err
+	}
+
+	content, changed := normalizeDurationFields(reflect.TypeOf(obj), content)
+	if !changed {
+		return raw, nil
+	}
+
+	return json.Marshal(content)
+}
+
+// normalizeDurationFields walks the given decoded JSON value alongside the
+// type it is decoded into, and returns the value with any duration using
+// extended units rewritten, along with whether anything was rewritten.
+func normalizeDurationFields(t reflect.Type, value interface{}) (interface{}, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == durationType {
+		s, ok := value.(string)
+		if !ok {
+			return value, false
+		}
+		if _, err := time.ParseDuration(s); err == nil {
+			return value, false
+		}
+		d, err := duration.Parse(s)
+		if err != nil {
+			// leave the value as is so that decoding reports the error
+			return value, false
+		}
+		return d.String(), true
+	}
+
+	changed := false
+	switch t.Kind() {
+	case reflect.Struct:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return value, false
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, inline := jsonName(field)
+			if inline {
+				_, c := normalizeDurationFields(field.Type, fields)
+				changed = changed || c
+				continue
+			}
+			v, ok := fields[name]
+			if name == "" || !ok {
+				continue
+			}
+			if v, c := normalizeDurationFields(field.Type, v); c {
+				fields[name] = v
+				changed = true
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]interface{})
+		if !ok {
+			return value, false
+		}
+		for i, item := range items {
+			if v, c := normalizeDurationFields(t.Elem(), item); c {
+				items[i] = v
+				changed = true
+			}
+		}
+
+	case reflect.Map:
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			return value, false
+		}
+		for k, entry := range entries {
+			if v, c := normalizeDurationFields(t.Elem(), entry); c {
+				entries[k] = v
+				changed = true
+			}
+		}
+	}
+
+	return value, changed
+}
+
+// jsonName returns the JSON name of the given struct field, and whether the
+// field is inlined into its parent. The empty name is returned for fields
+// which are not encoded.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		if field.Anonymous {
+			return "", true
+		}
+		if !field.IsExported() {
+			return "", false
+		}
+		return field.Name, false
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" && (field.Anonymous || strings.Contains(opts, "inline")) {
+		return "", true
+	}
+	if name == "" {
+		return field.Name, false
+	}
+	return name, false
+}
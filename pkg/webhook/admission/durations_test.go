@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestNormalizeDurations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, cmapi.AddToScheme(scheme))
+	rh := &RequestHandler{scheme: scheme}
+
+	tests := map[string]struct {
+		raw string
+		exp string
+	}{
+		"rewrites durations using extended units": {
+			raw: `{"apiVersion":"cert-manager.io/v1","kind":"Certificate","spec":{"duration":"90d","renewBefore":"1w","revisionHistoryLimit":9007199254740993}}`,
+			exp: `{"apiVersion":"cert-manager.io/v1","kind":"Certificate","spec":{"duration":"2160h0m0s","renewBefore":"168h0m0s","revisionHistoryLimit":9007199254740993}}`,
+		},
+		"rewrites durations in CertificateRequests": {
+			raw: `{"apiVersion":"cert-manager.io/v1","kind":"CertificateRequest","spec":{"duration":"1y"}}`,
+			exp: `{"apiVersion":"cert-manager.io/v1","kind":"CertificateRequest","spec":{"duration":"8760h0m0s"}}`,
+		},
+		"does not change durations using Go units": {
+			raw: `{"apiVersion":"cert-manager.io/v1", "kind":"Certificate", "spec":{"duration":"2160h", "renewBefore":"1w"}}`,
+			exp: `{"apiVersion":"cert-manager.io/v1","kind":"Certificate","spec":{"duration":"2160h","renewBefore":"168h0m0s"}}`,
+		},
+		"does not change objects without extended durations": {
+			raw: `{"apiVersion":"cert-manager.io/v1", "kind":"Certificate", "spec":{"duration":"2160h"}}`,
+			exp: `{"apiVersion":"cert-manager.io/v1", "kind":"Certificate", "spec":{"duration":"2160h"}}`,
+		},
+		"does not change invalid durations": {
+			raw: `{"apiVersion":"cert-manager.io/v1", "kind":"Certificate", "spec":{"duration":"1.5d"}}`,
+			exp: `{"apiVersion":"cert-manager.io/v1", "kind":"Certificate", "spec":{"duration":"1.5d"}}`,
+		},
+		"does not change objects of unknown kinds": {
+			raw: `{"apiVersion":"example.com/v1", "kind":"Unknown", "spec":{"duration":"90d"}}`,
+			exp: `{"apiVersion":"example.com/v1", "kind":"Unknown", "spec":{"duration":"90d"}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := rh.normalizeDurations([]byte(test.raw))
+			require.NoError(t, err)
+			assert.Equal(t, test.exp, string(out))
+		})
+	}
+}
@@ -34,6 +34,8 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
 )
@@ -41,7 +43,7 @@ import (
 // TestWantsFeature ensures that the feature gates are injected
 // when the WantsFeatures interface is implemented by a plugin.
 func TestWantsFeatures(t *testing.T) {
-	target := initializer.New(nil, nil, nil, featuregate.NewFeatureGate())
+	target := initializer.New(nil, nil, nil, nil, featuregate.NewFeatureGate())
 	wantFeaturesAdmission := &WantsFeaturesAdmission{}
 	target.Initialize(wantFeaturesAdmission)
 	if wantFeaturesAdmission.features == nil {
@@ -52,7 +54,7 @@ func TestWantsFeatures(t *testing.T) {
 // TestWantsAuthorizer ensures that the authorizer is injected
 // when the WantsAuthorizer interface is implemented by a plugin.
 func TestWantsAuthorizer(t *testing.T) {
-	target := initializer.New(nil, nil, &TestAuthorizer{}, nil)
+	target := initializer.New(nil, nil, nil, &TestAuthorizer{}, nil)
 	wantAuthorizerAdmission := &WantAuthorizerAdmission{}
 	target.Initialize(wantAuthorizerAdmission)
 	if wantAuthorizerAdmission.auth == nil {
@@ -64,7 +66,7 @@ func TestWantsAuthorizer(t *testing.T) {
 // when the WantsExternalKubeClientSet interface is implemented by a plugin.
 func TestWantsExternalKubeClientSet(t *testing.T) {
 	cs := &fake.Clientset{}
-	target := initializer.New(cs, nil, &TestAuthorizer{}, nil)
+	target := initializer.New(cs, nil, nil, &TestAuthorizer{}, nil)
 	wantExternalKubeClientSet := &WantExternalKubeClientSet{}
 	target.Initialize(wantExternalKubeClientSet)
 	if wantExternalKubeClientSet.cs != cs {
@@ -72,12 +74,24 @@ func TestWantsExternalKubeClientSet(t *testing.T) {
 	}
 }
 
+// TestWantsExternalCMClientSet ensures that the cert-manager clientset is
+// injected when the WantsExternalCMClientSet interface is implemented by a plugin.
+func TestWantsExternalCMClientSet(t *testing.T) {
+	cs := &cmfake.Clientset{}
+	target := initializer.New(nil, cs, nil, &TestAuthorizer{}, nil)
+	wantExternalCMClientSet := &WantExternalCMClientSet{}
+	target.Initialize(wantExternalCMClientSet)
+	if wantExternalCMClientSet.cs != cs {
+		t.Errorf("expected clientset to be initialized")
+	}
+}
+
 // TestWantsExternalKubeInformerFactory ensures that the informer factory is injected
 // when the WantsExternalKubeInformerFactory interface is implemented by a plugin.
 func TestWantsExternalKubeInformerFactory(t *testing.T) {
 	cs := &fake.Clientset{}
 	sf := informers.NewSharedInformerFactory(cs, time.Duration(1)*time.Second)
-	target := initializer.New(cs, sf, &TestAuthorizer{}, nil)
+	target := initializer.New(cs, nil, sf, &TestAuthorizer{}, nil)
 	wantExternalKubeInformerFactory := &WantExternalKubeInformerFactory{}
 	target.Initialize(wantExternalKubeInformerFactory)
 	if wantExternalKubeInformerFactory.sf != sf {
@@ -119,6 +133,23 @@ func (self *WantExternalKubeClientSet) ValidateInitialization() error        { r
 var _ admission.Interface = &WantExternalKubeClientSet{}
 var _ initializer.WantsExternalKubeClientSet = &WantExternalKubeClientSet{}
 
+// WantExternalCMClientSet is a test stub that fulfills the WantsExternalCMClientSet interface
+type WantExternalCMClientSet struct {
+	cs cmclient.Interface
+}
+
+func (self *WantExternalCMClientSet) SetExternalCMClientSet(cs cmclient.Interface) {
+	self.cs = cs
+}
+func (self *WantExternalCMClientSet) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	return nil, nil
+}
+func (self *WantExternalCMClientSet) Handles(o admissionv1.Operation) bool { return false }
+func (self *WantExternalCMClientSet) ValidateInitialization() error        { return nil }
+
+var _ admission.Interface = &WantExternalCMClientSet{}
+var _ initializer.WantsExternalCMClientSet = &WantExternalCMClientSet{}
+
 // WantAuthorizerAdmission is a test stub that fulfills the WantsAuthorizer interface.
 type WantAuthorizerAdmission struct {
 	auth authorizer.Authorizer
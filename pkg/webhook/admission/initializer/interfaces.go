@@ -28,6 +28,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 )
 
@@ -37,6 +38,12 @@ type WantsExternalKubeClientSet interface {
 	admission.InitializationValidator
 }
 
+// WantsExternalCMClientSet defines a function which sets the cert-manager ClientSet for admission plugins that need it
+type WantsExternalCMClientSet interface {
+	SetExternalCMClientSet(cmclient.Interface)
+	admission.InitializationValidator
+}
+
 // WantsExternalKubeInformerFactory defines a function which sets InformerFactory for admission plugins that need it
 type WantsExternalKubeInformerFactory interface {
 	SetExternalKubeInformerFactory(informers.SharedInformerFactory)
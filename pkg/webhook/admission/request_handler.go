@@ -95,8 +95,14 @@ func (rh *RequestHandler) Validate(ctx context.Context, admissionSpec *admission
 		return status
 	}
 
+	// rewrite durations using units such as days into units which can be decoded
+	raw, err := rh.normalizeDurations(admissionSpec.Object.Raw)
+	if err != nil {
+		return badRequestError(status, err)
+	}
+
 	// decode new version of object
-	obj, err := rh.deseralizeToInternalVersion(admissionSpec.Object.Raw)
+	obj, err := rh.deseralizeToInternalVersion(raw)
 	if err != nil {
 		return badRequestError(status, err)
 	}
@@ -142,7 +148,14 @@ func (rh *RequestHandler) Mutate(ctx context.Context, admissionSpec *admissionv1
 	// we must take special steps to ensure the correct defaults are applied to the resource (as
 	// defaults are applied by the decoder when the resource is decoded in the version of the
 	// encoded resource).
-	obj, errResponse := rh.decodeRequestObject(status, admissionSpec.Kind, *admissionSpec.RequestKind, admissionSpec.Object.Raw)
+	// Durations using units such as days are rewritten before decoding. As the
+	// patch is generated against the original object, the rewritten durations
+	// are persisted.
+	raw, err := rh.normalizeDurations(admissionSpec.Object.Raw)
+	if err != nil {
+		return badRequestError(status, err)
+	}
+	obj, errResponse := rh.decodeRequestObject(status, admissionSpec.Kind, *admissionSpec.RequestKind, raw)
 	if errResponse != nil {
 		return errResponse
 	}
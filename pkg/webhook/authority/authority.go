@@ -24,6 +24,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"math/big"
 	"sync"
@@ -41,6 +42,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
 
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -52,6 +54,12 @@ import (
 // and provides methods to obtain signed leaf certificates.
 // The private key and certificate will be automatically generated, and when
 // nearing expiry, the private key and root certificate will be rotated.
+// Previous root certificates are kept in the Secret under the previous-ca.crt
+// key until they expire, so that serving certificates signed by them, e.g. by
+// other replicas which have not yet observed the rotation, continue to be
+// trusted. The ca.crt key always holds only the current root certificate, as
+// earlier versions of the webhook regenerate the CA whenever ca.crt and tls.crt
+// differ.
 type DynamicAuthority struct {
 	// Namespace and Name of the Secret resource used to store the authority.
 	SecretNamespace, SecretName string
@@ -70,6 +78,11 @@ type DynamicAuthority struct {
 	// Defaults to 7d.
 	LeafDuration time.Duration
 
+	// Clock is used to determine when the CA needs to be rotated and the
+	// validity period of issued certificates.
+	// Defaults to the real clock.
+	Clock clock.Clock
+
 	// Logger to write messages to.
 	log logr.Logger
 
@@ -78,6 +91,9 @@ type DynamicAuthority struct {
 
 	// PEM-encoded CA certificate and private key bytes
 	currentCertData, currentPrivateKeyData []byte
+	// PEM-encoded bundle of the current and previous, still valid, CA
+	// certificates
+	currentCABundleData []byte
 	// signMutex gates access to the certificate and private key data
 	signMutex sync.Mutex
 	// ensureMutex gates the 'ensureCA' method
@@ -189,7 +205,7 @@ func (d *DynamicAuthority) Sign(template *x509.Certificate) (*x509.Certificate,
 	template.Version = 3
 	template.SerialNumber = serialNumber
 	template.BasicConstraintsValid = true
-	template.NotBefore = time.Now()
+	template.NotBefore = d.now()
 	template.NotAfter = template.NotBefore.Add(d.LeafDuration)
 	// explicitly handle the case of the root CA certificate being expired
 	if caCert.NotAfter.Before(template.NotBefore) {
@@ -208,6 +224,15 @@ func (d *DynamicAuthority) Sign(template *x509.Certificate) (*x509.Certificate,
 	return cert, nil
 }
 
+// CABundle returns the PEM-encoded bundle of CA certificates which are
+// trusted to have signed serving certificates. This contains the current CA
+// certificate followed by any previous CA certificates which have not expired.
+func (d *DynamicAuthority) CABundle() []byte {
+	d.signMutex.Lock()
+	defer d.signMutex.Unlock()
+	return d.currentCABundleData
+}
+
 // WatchRotation will returns a channel that fires notifications if the CA
 // certificate is rotated/updated.
 // This can be used to automatically trigger rotation of leaf certificates
@@ -246,18 +271,35 @@ func (d *DynamicAuthority) ensureCA(ctx context.Context) error {
 	if d.caRequiresRegeneration(s) {
 		return d.regenerateCA(ctx, s.DeepCopy())
 	}
-	d.notifyWatches(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey])
+	d.notifyWatches(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey], caBundle(s))
 	return nil
 }
 
-func (d *DynamicAuthority) notifyWatches(newCertData, newPrivateKeyData []byte) {
-	if bytes.Equal(d.currentCertData, newCertData) && bytes.Equal(d.currentPrivateKeyData, newPrivateKeyData) {
+// caBundle returns the current CA certificate stored in the given Secret
+// followed by any previous CA certificates.
+func caBundle(s *corev1.Secret) []byte {
+	return append(append([]byte{}, s.Data[cmmeta.TLSCAKey]...), s.Data[cmmeta.TLSPreviousCAKey]...)
+}
+
+func (d *DynamicAuthority) notifyWatches(newCertData, newPrivateKeyData, newCABundleData []byte) {
+	d.signMutex.Lock()
+	changed := !bytes.Equal(d.currentCertData, newCertData) || !bytes.Equal(d.currentPrivateKeyData, newPrivateKeyData)
+	// the bundle is updated even if the keypair has not changed, e.g. when
+	// an expired CA certificate has been pruned from it
+	d.currentCertData = newCertData
+	d.currentPrivateKeyData = newPrivateKeyData
+	d.currentCABundleData = newCABundleData
+	d.signMutex.Unlock()
+
+	if !changed {
 		// do nothing if the data has not changed
 		return
 	}
 
 	d.log.V(logf.DebugLevel).Info("Detected change in CA secret data, notifying watchers...")
 
+	// watchers are notified once the new data is in place, so that they
+	// sign leaf certificates using the new CA
 	d.watchMutex.Lock()
 	defer d.watchMutex.Unlock()
 	for _, ch := range d.watches {
@@ -268,11 +310,6 @@ func (d *DynamicAuthority) notifyWatches(newCertData, newPrivateKeyData []byte)
 		default:
 		}
 	}
-
-	d.signMutex.Lock()
-	defer d.signMutex.Unlock()
-	d.currentCertData = newCertData
-	d.currentPrivateKeyData = newPrivateKeyData
 }
 
 // caRequiresRegeneration will check data in a Secret resource and return true
@@ -308,13 +345,20 @@ func (d *DynamicAuthority) caRequiresRegeneration(s *corev1.Secret) bool {
 		return true
 	}
 	// renew the root CA when the current one is 2/3 of the way through its life
-	if x509Cert.NotAfter.Sub(time.Now()) < (d.CADuration / 3) {
+	if x509Cert.NotAfter.Sub(d.now()) < (d.CADuration / 3) {
 		d.log.V(logf.InfoLevel).Info("Root CA certificate is nearing expiry. Regenerating...")
 		return true
 	}
 	return false
 }
 
+func (d *DynamicAuthority) now() time.Time {
+	if d.Clock == nil {
+		return time.Now()
+	}
+	return d.Clock.Now()
+}
+
 var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
 
 // regenerateCA will regenerate and store a new CA.
@@ -344,8 +388,8 @@ func (d *DynamicAuthority) regenerateCA(ctx context.Context, s *corev1.Secret) e
 			CommonName: "cert-manager-webhook-ca",
 		},
 		IsCA:      true,
-		NotBefore: time.Now(),
-		NotAfter:  time.Now().Add(d.CADuration),
+		NotBefore: d.now(),
+		NotAfter:  d.now().Add(d.CADuration),
 		KeyUsage:  x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
 	}
 	// self sign the root CA
@@ -357,6 +401,9 @@ func (d *DynamicAuthority) regenerateCA(ctx context.Context, s *corev1.Secret) e
 	if err != nil {
 		return err
 	}
+	// keep trusting the previous CA certificates until they expire, so that
+	// serving certificates signed by them remain valid during the rotation
+	previousCAs := d.previousCAs(s)
 
 	if s == nil {
 		_, err := d.client.Create(ctx, &corev1.Secret{
@@ -382,6 +429,11 @@ func (d *DynamicAuthority) regenerateCA(ctx context.Context, s *corev1.Secret) e
 	s.Data[corev1.TLSCertKey] = certBytes
 	s.Data[corev1.TLSPrivateKeyKey] = pkBytes
 	s.Data[cmmeta.TLSCAKey] = certBytes
+	if len(previousCAs) > 0 {
+		s.Data[cmmeta.TLSPreviousCAKey] = previousCAs
+	} else {
+		delete(s.Data, cmmeta.TLSPreviousCAKey)
+	}
 	if _, err := d.client.Update(ctx, s, metav1.UpdateOptions{}); err != nil {
 		return err
 	}
@@ -409,3 +461,36 @@ func (d *DynamicAuthority) handleDelete(obj interface{}) {
 		d.log.Error(err, "error ensuring CA")
 	}
 }
+
+// previousCAs returns the PEM-encoded CA certificates stored under the ca.crt
+// and previous-ca.crt keys of the given Secret which have not yet expired.
+// Certificates which can't be parsed, are not CAs or are duplicates are
+// dropped.
+func (d *DynamicAuthority) previousCAs(s *corev1.Secret) []byte {
+	if s == nil {
+		return nil
+	}
+	var bundle []byte
+	seen := make(map[string]struct{})
+	rest := caBundle(s)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || !cert.IsCA || !cert.NotAfter.After(d.now()) {
+			continue
+		}
+		if _, ok := seen[string(block.Bytes)]; ok {
+			continue
+		}
+		seen[string(block.Bytes)] = struct{}{}
+		bundle = append(bundle, pem.EncodeToMemory(block)...)
+	}
+	return bundle
+}
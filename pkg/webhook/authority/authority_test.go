@@ -17,3 +17,159 @@ limitations under the License.
 package authority
 
 // Integration tests for the authority can be found in `test/integration/webhook/dynamic_authority_test.go`.
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestPreviousCAs(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+
+	selfSigned := func(t *testing.T, isCA bool, notAfter time.Time) []byte {
+		pk, err := pki.GenerateECPrivateKey(256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "test"},
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+			NotBefore:             fixedClock.Now().Add(-time.Hour),
+			NotAfter:              notAfter,
+		}
+		_, cert, err := pki.SignCertificate(template, template, pk.Public(), pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		certBytes, err := pki.EncodeX509(cert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return certBytes
+	}
+
+	valid := selfSigned(t, true, fixedClock.Now().Add(time.Hour))
+	otherValid := selfSigned(t, true, fixedClock.Now().Add(2*time.Hour))
+	expired := selfSigned(t, true, fixedClock.Now().Add(-time.Minute))
+	notCA := selfSigned(t, false, fixedClock.Now().Add(time.Hour))
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})
+
+	join := func(data ...[]byte) []byte {
+		return bytes.Join(data, nil)
+	}
+
+	tests := map[string]struct {
+		secret *corev1.Secret
+		exp    []byte
+	}{
+		"no existing Secret": {},
+		"no existing bundle": {
+			secret: &corev1.Secret{},
+		},
+		"keep all valid CA certificates": {
+			secret: &corev1.Secret{Data: map[string][]byte{cmmeta.TLSCAKey: join(valid, otherValid)}},
+			exp:    join(valid, otherValid),
+		},
+		"drop expired CA certificates": {
+			secret: &corev1.Secret{Data: map[string][]byte{cmmeta.TLSCAKey: join(expired, valid)}},
+			exp:    valid,
+		},
+		"drop certificates which are not CAs and other PEM blocks": {
+			secret: &corev1.Secret{Data: map[string][]byte{cmmeta.TLSCAKey: join(notCA, privateKey, otherValid)}},
+			exp:    otherValid,
+		},
+		"keep the current CA certificate followed by previous CA certificates": {
+			secret: &corev1.Secret{Data: map[string][]byte{
+				cmmeta.TLSCAKey:         valid,
+				cmmeta.TLSPreviousCAKey: join(expired, otherValid),
+			}},
+			exp: join(valid, otherValid),
+		},
+		"drop duplicate CA certificates": {
+			secret: &corev1.Secret{Data: map[string][]byte{
+				cmmeta.TLSCAKey:         join(valid, otherValid),
+				cmmeta.TLSPreviousCAKey: otherValid,
+			}},
+			exp: join(valid, otherValid),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := &DynamicAuthority{Clock: fixedClock, log: logr.Discard()}
+			if got := d.previousCAs(test.secret); !bytes.Equal(got, test.exp) {
+				t.Errorf("unexpected bundle, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+// Earlier versions of the webhook regenerate the CA whenever ca.crt and
+// tls.crt differ, so replicas of both versions must accept the Secret written
+// by the other during a rolling upgrade.
+func TestRegenerateCAIsCompatibleWithEarlierVersions(t *testing.T) {
+	ctx := context.Background()
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	d := &DynamicAuthority{
+		SecretNamespace: "cert-manager",
+		SecretName:      "cert-manager-webhook-ca",
+		CADuration:      time.Hour * 24 * 365,
+		Clock:           fixedClock,
+		log:             logr.Discard(),
+		client:          fake.NewSimpleClientset().CoreV1().Secrets("cert-manager"),
+	}
+	getSecret := func(t *testing.T) *corev1.Secret {
+		s, err := d.client.Get(ctx, d.SecretName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	// a Secret written by an earlier version has the same layout as a newly
+	// created one: ca.crt equal to tls.crt and no previous CA certificates
+	if err := d.regenerateCA(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	initial := getSecret(t)
+	if _, ok := initial.Data[cmmeta.TLSPreviousCAKey]; ok {
+		t.Errorf("expected no previous CA certificates in a new Secret, got %q", initial.Data[cmmeta.TLSPreviousCAKey])
+	}
+	if d.caRequiresRegeneration(initial) {
+		t.Errorf("expected a Secret with ca.crt equal to tls.crt to not require regeneration")
+	}
+
+	if err := d.regenerateCA(ctx, initial.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	rotated := getSecret(t)
+	if !bytes.Equal(rotated.Data[cmmeta.TLSCAKey], rotated.Data[corev1.TLSCertKey]) {
+		t.Errorf("expected ca.crt to equal tls.crt after rotation so that earlier versions don't regenerate the CA")
+	}
+	if d.caRequiresRegeneration(rotated) {
+		t.Errorf("expected the rotated Secret to not require regeneration")
+	}
+	if !bytes.Equal(rotated.Data[cmmeta.TLSPreviousCAKey], initial.Data[cmmeta.TLSCAKey]) {
+		t.Errorf("expected the previous CA certificate to be kept, exp=%q got=%q", initial.Data[cmmeta.TLSCAKey], rotated.Data[cmmeta.TLSPreviousCAKey])
+	}
+	if exp, got := append(append([]byte{}, rotated.Data[cmmeta.TLSCAKey]...), initial.Data[cmmeta.TLSCAKey]...), caBundle(rotated); !bytes.Equal(exp, got) {
+		t.Errorf("unexpected CA bundle, exp=%q got=%q", exp, got)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -111,7 +112,14 @@ type Server struct {
 	// Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants).
 	MinTLSVersion string
 
-	listener net.Listener
+	// ShutdownDelay is how long the server keeps serving webhook requests
+	// once the context passed to Run has been cancelled. During this time
+	// the healthz endpoint reports the server as not ready, so that it is
+	// removed from the endpoints of its Service before it stops serving.
+	ShutdownDelay time.Duration
+
+	listener     net.Listener
+	shuttingDown atomic.Bool
 }
 
 type handleFunc func(context.Context, runtime.Object) (runtime.Object, error)
@@ -120,6 +128,23 @@ func (s *Server) Run(ctx context.Context) error {
 	s.log = logf.FromContext(ctx)
 	g, gctx := errgroup.WithContext(ctx)
 
+	// stopCh is closed once the listeners should be shut down. If Run was
+	// asked to stop, this is delayed by ShutdownDelay whilst the healthz
+	// endpoint reports the server as not ready. If one of the listeners
+	// failed, the remaining ones are shut down straight away.
+	stopCh := make(chan struct{})
+	g.Go(func() error {
+		defer close(stopCh)
+		<-gctx.Done()
+		s.shuttingDown.Store(true)
+		if ctx.Err() == nil || s.ShutdownDelay <= 0 {
+			return nil
+		}
+		s.log.V(logf.InfoLevel).Info("delaying shutdown to allow in-flight requests to complete", "delay", s.ShutdownDelay)
+		<-time.After(s.ShutdownDelay)
+		return nil
+	})
+
 	// if a HealthzAddr is provided, start the healthz listener
 	if s.HealthzAddr != "" {
 		healthzListener, err := net.Listen("tcp", s.HealthzAddr)
@@ -135,7 +160,7 @@ func (s *Server) Run(ctx context.Context) error {
 			Handler: healthMux,
 		}
 		g.Go(func() error {
-			<-gctx.Done()
+			<-stopCh
 			// allow a timeout for graceful shutdown
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
@@ -168,7 +193,7 @@ func (s *Server) Run(ctx context.Context) error {
 			Handler: profilerMux,
 		}
 		g.Go(func() error {
-			<-gctx.Done()
+			<-stopCh
 			// allow a timeout for graceful shutdown
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
@@ -228,7 +253,7 @@ func (s *Server) Run(ctx context.Context) error {
 		Handler: serverMux,
 	}
 	g.Go(func() error {
-		<-gctx.Done()
+		<-stopCh
 		// allow a timeout for graceful shutdown
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -336,6 +361,11 @@ func (s *Server) handle(inner handleFunc) func(w http.ResponseWriter, req *http.
 func (s *Server) handleHealthz(w http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 
+	if s.shuttingDown.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
 	if s.CertificateSource != nil && !s.CertificateSource.Healthy() {
 		s.log.V(logf.WarnLevel).Info("Health check failed as CertificateSource is unhealthy")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -18,6 +18,8 @@ package server
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	logtesting "github.com/go-logr/logr/testing"
@@ -80,3 +82,29 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleHealthz(t *testing.T) {
+	tests := map[string]struct {
+		shuttingDown bool
+		expCode      int
+	}{
+		"healthy whilst running": {
+			expCode: http.StatusOK,
+		},
+		"not ready whilst shutting down": {
+			shuttingDown: true,
+			expCode:      http.StatusServiceUnavailable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &Server{log: logtesting.NewTestLogger(t)}
+			s.shuttingDown.Store(test.shuttingDown)
+
+			rec := httptest.NewRecorder()
+			s.handleHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			assert.Equal(t, test.expCode, rec.Code)
+		})
+	}
+}
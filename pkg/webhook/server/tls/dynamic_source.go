@@ -28,6 +28,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -44,9 +45,22 @@ type DynamicSource struct {
 	// The authority used to sign certificate templates.
 	Authority *authority.DynamicAuthority
 
+	// Clock is used to schedule the renewal of the serving certificate.
+	// Defaults to the real clock.
+	Clock clock.Clock
+
+	// RotationDelay is how long the serving certificate is kept after the
+	// root CA has been rotated, if it is still trusted by the CA bundle.
+	// This gives the cainjector time to inject the new CA bundle before the
+	// serving certificate signed by the new root CA is served, so that
+	// replicas can roll over to the new root CA independently.
+	// Defaults to 30s.
+	RotationDelay time.Duration
+
 	log logr.Logger
 
 	cachedCertificate *tls.Certificate
+	cachedLeaf        *x509.Certificate
 	lock              sync.Mutex
 }
 
@@ -115,13 +129,13 @@ func (f *DynamicSource) Run(ctx context.Context) error {
 			}
 
 			for {
-				timer := time.NewTimer(time.Until(renewMoment))
+				timer := f.clock().NewTimer(renewMoment.Sub(f.clock().Now()))
 				defer timer.Stop()
 
 				select {
 				case <-ctx.Done():
 					return
-				case <-timer.C:
+				case <-timer.C():
 					// Try to send a message on ch, but also allow for a stop signal or
 					// a new renewMoment to be received
 					select {
@@ -157,6 +171,11 @@ func (f *DynamicSource) Run(ctx context.Context) error {
 			if !ok {
 				return true, context.Canceled
 			}
+			if f.trustedAfterRotationDelay() {
+				f.log.V(logf.InfoLevel).Info("Detected root CA rotation - regenerating serving certificates once the CA bundle has propagated", "delay", f.rotationDelay())
+				nextRenewCh <- f.clock().Now().Add(f.rotationDelay())
+				return false, nil
+			}
 			f.log.V(logf.InfoLevel).Info("Detected root CA rotation - regenerating serving certificates")
 			if err := f.regenerateCertificate(nextRenewCh); err != nil {
 				f.log.Error(err, "Failed to regenerate serving certificate")
@@ -207,6 +226,37 @@ func (f *DynamicSource) Healthy() bool {
 	return f.cachedCertificate != nil
 }
 
+func (f *DynamicSource) rotationDelay() time.Duration {
+	if f.RotationDelay == 0 {
+		return 30 * time.Second
+	}
+	return f.RotationDelay
+}
+
+// trustedAfterRotationDelay returns true if the cached serving certificate
+// will still be trusted by the authority's current CA bundle once the
+// rotation delay has passed, in which case it can continue to be served
+// whilst the new CA bundle propagates.
+func (f *DynamicSource) trustedAfterRotationDelay() bool {
+	f.lock.Lock()
+	leaf := f.cachedLeaf
+	f.lock.Unlock()
+	if leaf == nil {
+		return false
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(f.Authority.CABundle()) {
+		return false
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: f.clock().Now().Add(f.rotationDelay()),
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	return err == nil
+}
+
 // regenerateCertificate will trigger the cached certificate and private key to
 // be regenerated by requesting a new certificate from the authority.
 func (f *DynamicSource) regenerateCertificate(nextRenew chan<- time.Time) error {
@@ -260,6 +310,7 @@ func (f *DynamicSource) updateCertificate(pk crypto.Signer, cert *x509.Certifica
 	}
 
 	f.cachedCertificate = &bundle
+	f.cachedLeaf = cert
 	certDuration := cert.NotAfter.Sub(cert.NotBefore)
 	// renew the certificate 1/3 of the time before its expiry
 	nextRenew <- cert.NotAfter.Add(certDuration / -3)
@@ -267,3 +318,12 @@ func (f *DynamicSource) updateCertificate(pk crypto.Signer, cert *x509.Certifica
 
 	return nil
 }
+
+// clock returns the Clock of the DynamicSource, or the real clock if none is
+// set.
+func (f *DynamicSource) clock() clock.Clock {
+	if f.Clock == nil {
+		return clock.RealClock{}
+	}
+	return f.Clock
+}
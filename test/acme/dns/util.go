@@ -31,6 +31,7 @@ import (
 
 	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	cmutil "github.com/cert-manager/cert-manager/pkg/util"
 )
 
 var (
@@ -115,7 +116,7 @@ func closingStopCh(t time.Duration) <-chan struct{} {
 
 func (f *fixture) recordHasPropagatedCheck(fqdn, value string) func() (bool, error) {
 	return func() (bool, error) {
-		return util.PreCheckDNS(fqdn, value, []string{f.testDNSServer}, *f.useAuthoritative)
+		return util.PreCheckDNS(fqdn, value, []string{f.testDNSServer}, *f.useAuthoritative, cmutil.IPFamilyPolicy{})
 	}
 }
 
@@ -17,13 +17,17 @@ limitations under the License.
 package config
 
 import (
-	"os"
-
 	"flag"
+	"fmt"
+	"os"
 )
 
 type Suite struct {
 	ACME ACME
+
+	// Conformance configures the conformance tests run against an issuer
+	// which already exists in the cluster under test.
+	Conformance Conformance
 }
 
 type ACME struct {
@@ -36,16 +40,72 @@ type Cloudflare struct {
 	APIKey string
 }
 
+// Conformance describes an existing issuer to run the Certificate
+// conformance tests against, e.g. to certify a custom CA integration before
+// rolling it out.
+type Conformance struct {
+	// IssuerName is the name of the issuer. If not specified, the conformance
+	// tests for a configured issuer are skipped.
+	IssuerName string
+	// IssuerKind is the kind of the issuer, which must be cluster scoped as
+	// every test runs in its own namespace.
+	IssuerKind string
+	// IssuerGroup is the API group of the issuer, if it is an external issuer.
+	IssuerGroup string
+	// DomainSuffix is the domain under which the DNS names of the requested
+	// certificates are created.
+	DomainSuffix string
+	// UnsupportedFeatures is a comma separated list of the features which
+	// the issuer does not support, and whose tests are skipped.
+	UnsupportedFeatures string
+}
+
 func (f *Suite) AddFlags(fs *flag.FlagSet) {
 	f.ACME.AddFlags(fs)
+	f.Conformance.AddFlags(fs)
 }
 
 func (c *Suite) Validate() []error {
 	var errs []error
 	errs = append(errs, c.ACME.Validate()...)
+	errs = append(errs, c.Conformance.Validate()...)
+	return errs
+}
+
+func (c *Conformance) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.IssuerName, "suite.conformance-issuer-name", os.Getenv("CONFORMANCE_ISSUER_NAME"), ""+
+		"The name of an existing issuer to run the conformance tests against. If not specified, these tests will be skipped")
+	fs.StringVar(&c.IssuerKind, "suite.conformance-issuer-kind", envOrDefault("CONFORMANCE_ISSUER_KIND", "ClusterIssuer"), ""+
+		"The kind of the existing issuer to run the conformance tests against. It must be cluster scoped")
+	fs.StringVar(&c.IssuerGroup, "suite.conformance-issuer-group", os.Getenv("CONFORMANCE_ISSUER_GROUP"), ""+
+		"The API group of the existing issuer to run the conformance tests against, if it is an external issuer")
+	fs.StringVar(&c.DomainSuffix, "suite.conformance-domain-suffix", envOrDefault("CONFORMANCE_DOMAIN_SUFFIX", "example.com"), ""+
+		"The domain under which the DNS names of the certificates requested during the conformance tests are created")
+	fs.StringVar(&c.UnsupportedFeatures, "suite.conformance-unsupported-features", os.Getenv("CONFORMANCE_UNSUPPORTED_FEATURES"), ""+
+		"Comma separated list of the features that the existing issuer does not support, e.g. 'IPAddresses,Ed25519'")
+}
+
+func (c *Conformance) Validate() []error {
+	if c.IssuerName == "" {
+		return nil
+	}
+	var errs []error
+	if c.IssuerKind == "" {
+		errs = append(errs, fmt.Errorf("--suite.conformance-issuer-kind must be specified"))
+	}
+	if c.IssuerKind == "Issuer" && (c.IssuerGroup == "" || c.IssuerGroup == "cert-manager.io") {
+		errs = append(errs, fmt.Errorf("--suite.conformance-issuer-kind must be a cluster scoped kind, such as ClusterIssuer"))
+	}
 	return errs
 }
 
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
 func (c *ACME) AddFlags(fs *flag.FlagSet) {
 	c.Cloudflare.AddFlags(fs)
 }
@@ -27,6 +27,18 @@ func NewFeatureSet(feats ...Feature) FeatureSet {
 	return fs
 }
 
+// ParseFeatureSet parses a comma separated list of feature names, such as the
+// one returned by FeatureSet.String, into a feature set.
+func ParseFeatureSet(s string) FeatureSet {
+	fs := make(FeatureSet)
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fs.Add(Feature(f))
+		}
+	}
+	return fs
+}
+
 // FeatureSet represents a set of features.
 // This type does not indicate whether or not features are enabled, rather it
 // just defines a grouping of features (i.e. a 'set').
@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package configured runs the Certificate conformance tests against an issuer
+// which already exists in the cluster under test, rather than one created by
+// the tests. This allows operators to certify a custom CA integration, such
+// as an external issuer, against a live cluster before rolling it out:
+//
+//	make e2e-conformance CONFORMANCE_ISSUER_NAME=my-ca CONFORMANCE_ISSUER_KIND=MyClusterIssuer CONFORMANCE_ISSUER_GROUP=example.com
+package configured
+
+import (
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/e2e/framework"
+	"github.com/cert-manager/cert-manager/test/e2e/framework/helper/featureset"
+	"github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificates"
+)
+
+var _ = framework.ConformanceDescribe("Certificates", func() {
+	// The body of a top level container is run once the flags have been
+	// parsed, so the configuration is available here.
+	cfg := framework.DefaultConfig.Suite.Conformance
+	if cfg.IssuerName == "" {
+		return
+	}
+
+	issuerRef := cmmeta.ObjectReference{
+		Name:  cfg.IssuerName,
+		Kind:  cfg.IssuerKind,
+		Group: cfg.IssuerGroup,
+	}
+
+	(&certificates.Suite{
+		Name: "Configured " + cfg.IssuerKind,
+		CreateIssuerFunc: func(*framework.Framework) cmmeta.ObjectReference {
+			return issuerRef
+		},
+		DomainSuffix:        cfg.DomainSuffix,
+		UnsupportedFeatures: featureset.ParseFeatureSet(cfg.UnsupportedFeatures),
+	}).Define()
+})
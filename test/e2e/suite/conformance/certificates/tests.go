@@ -18,6 +18,7 @@ package certificates
 
 import (
 	"context"
+	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/base64"
@@ -32,12 +33,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
@@ -724,6 +727,137 @@ func (s *Suite) Define() {
 			}
 		}, featureset.ReusePrivateKeyFeature, featureset.OnlySAN)
 
+		s.it(f, "should renew a certificate when a renewal is triggered", func(issuerRef cmmeta.ObjectReference) {
+			testCertificate := &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testcert",
+					Namespace: f.Namespace.Name,
+				},
+				Spec: cmapi.CertificateSpec{
+					SecretName: "testcert-tls",
+					DNSNames:   []string{e2eutil.RandomSubdomain(s.DomainSuffix)},
+					IssuerRef:  issuerRef,
+				},
+			}
+			validations := validation.CertificateSetForUnsupportedFeatureSet(s.UnsupportedFeatures)
+
+			By("Creating a Certificate")
+			err := f.CRClient.Create(ctx, testCertificate)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Waiting for the Certificate to be issued...")
+			testCertificate, err = f.Helper().WaitForCertificateReadyAndDoneIssuing(testCertificate, time.Minute*8)
+			Expect(err).NotTo(HaveOccurred())
+
+			crt1 := readSignedCertificate(f, testCertificate)
+
+			By("Triggering a renewal of the Certificate")
+			triggerRenewal(f, testCertificate)
+
+			By("Waiting for the Certificate to be renewed...")
+			testCertificate, err = f.Helper().WaitForCertificateReadyAndDoneIssuing(testCertificate, time.Minute*8)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Validating the renewed Certificate...")
+			err = f.Helper().ValidateCertificate(testCertificate, validations...)
+			Expect(err).NotTo(HaveOccurred())
+
+			crt2 := readSignedCertificate(f, testCertificate)
+			Expect(crt2.SerialNumber).NotTo(Equal(crt1.SerialNumber), "expected the renewed certificate to have a new serial number")
+		}, featureset.OnlySAN)
+
+		s.it(f, "should rotate the private key on renewal when the rotation policy is Always", func(issuerRef cmmeta.ObjectReference) {
+			testCertificate := &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testcert",
+					Namespace: f.Namespace.Name,
+				},
+				Spec: cmapi.CertificateSpec{
+					SecretName: "testcert-tls",
+					DNSNames:   []string{e2eutil.RandomSubdomain(s.DomainSuffix)},
+					IssuerRef:  issuerRef,
+					PrivateKey: &cmapi.CertificatePrivateKey{RotationPolicy: cmapi.RotationPolicyAlways},
+				},
+			}
+			validations := validation.CertificateSetForUnsupportedFeatureSet(s.UnsupportedFeatures)
+
+			By("Creating a Certificate")
+			err := f.CRClient.Create(ctx, testCertificate)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Waiting for the Certificate to be issued...")
+			testCertificate, err = f.Helper().WaitForCertificateReadyAndDoneIssuing(testCertificate, time.Minute*8)
+			Expect(err).NotTo(HaveOccurred())
+
+			crt1 := readSignedCertificate(f, testCertificate)
+
+			By("Triggering a renewal of the Certificate")
+			triggerRenewal(f, testCertificate)
+
+			By("Waiting for the Certificate to be renewed...")
+			testCertificate, err = f.Helper().WaitForCertificateReadyAndDoneIssuing(testCertificate, time.Minute*8)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Validating the renewed Certificate...")
+			err = f.Helper().ValidateCertificate(testCertificate, validations...)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Ensuring the renewed certificate is signed for a new private key")
+			crt2 := readSignedCertificate(f, testCertificate)
+			match, err := pki.PublicKeysEqual(crt1.PublicKey, crt2.PublicKey)
+			Expect(err).NotTo(HaveOccurred(), "failed to check public keys of both signed certificates")
+			Expect(match).To(BeFalse(), "expected the private key to be rotated on renewal")
+		}, featureset.OnlySAN)
+
+		s.it(f, "should revoke a certificate when it is deleted, if the issuer is configured to", func(issuerRef cmmeta.ObjectReference) {
+			revocation := vaultRevocationFor(f, issuerRef)
+			if revocation == nil || !revocation.RevokeOnDelete {
+				Skip("Skipping as the issuer is not configured to revoke certificates when they are deleted")
+			}
+
+			testCertificate := &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testcert",
+					Namespace: f.Namespace.Name,
+				},
+				Spec: cmapi.CertificateSpec{
+					SecretName: "testcert-tls",
+					DNSNames:   []string{e2eutil.RandomSubdomain(s.DomainSuffix)},
+					IssuerRef:  issuerRef,
+				},
+			}
+			By("Creating a Certificate")
+			err := f.CRClient.Create(ctx, testCertificate)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Waiting for the Certificate to be issued...")
+			testCertificate, err = f.Helper().WaitForCertificateReadyAndDoneIssuing(testCertificate, time.Minute*8)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Waiting for the revocation finalizer to be added to the Certificate")
+			Eventually(func() []string {
+				crt, err := f.CertManagerClientSet.CertmanagerV1().Certificates(f.Namespace.Name).Get(ctx, testCertificate.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				return crt.Finalizers
+			}, time.Minute, time.Second).Should(ContainElement(cmapi.VaultRevocationFinalizer))
+
+			By("Deleting the Certificate")
+			err = f.CertManagerClientSet.CertmanagerV1().Certificates(f.Namespace.Name).Delete(ctx, testCertificate.Name, metav1.DeleteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Waiting for the Certificate to be revoked and removed")
+			Eventually(func() bool {
+				_, err := f.CertManagerClientSet.CertmanagerV1().Certificates(f.Namespace.Name).Get(ctx, testCertificate.Name, metav1.GetOptions{})
+				return apierrors.IsNotFound(err)
+			}, time.Minute*2, time.Second).Should(BeTrue(), "expected the Certificate to be removed once it has been revoked")
+
+			events, err := f.KubeClientSet.CoreV1().Events(f.Namespace.Name).List(ctx, metav1.ListOptions{
+				FieldSelector: "involvedObject.kind=Certificate,involvedObject.name=" + testCertificate.Name + ",reason=Revoked",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events.Items).NotTo(BeEmpty(), "expected a Revoked event for the Certificate")
+		}, featureset.OnlySAN)
+
 		s.it(f, "should issue a certificate for a single distinct DNS Name defined by an ingress with annotations", func(issuerRef cmmeta.ObjectReference) {
 			if s.HTTP01TestType != "Ingress" {
 				// TODO @jakexks: remove this skip once either haproxy or traefik fully support gateway API
@@ -1033,3 +1167,56 @@ func (s *Suite) Define() {
 		}, featureset.WildcardsFeature, featureset.OnlySAN)
 	})
 }
+
+// readSignedCertificate returns the signed certificate stored in the Secret of
+// the given Certificate.
+func readSignedCertificate(f *framework.Framework, crt *cmapi.Certificate) *x509.Certificate {
+	sec, err := f.KubeClientSet.CoreV1().Secrets(crt.Namespace).Get(context.TODO(), crt.Spec.SecretName, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred(), "failed to get secret containing signed certificate key pair data")
+
+	cert, err := pki.DecodeX509CertificateBytes(sec.Data[corev1.TLSCertKey])
+	Expect(err).NotTo(HaveOccurred(), "failed to decode signed certificate data")
+	return cert
+}
+
+// triggerRenewal triggers the renewal of the given Certificate the same way as
+// `cmctl renew`, by setting its Issuing condition.
+func triggerRenewal(f *framework.Framework, crt *cmapi.Certificate) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := f.CertManagerClientSet.CertmanagerV1().Certificates(crt.Namespace).Get(context.TODO(), crt.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		apiutil.SetCertificateCondition(latest, latest.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, "ManuallyTriggered", "Certificate re-issuance manually triggered by the conformance tests")
+		_, err = f.CertManagerClientSet.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(context.TODO(), latest, metav1.UpdateOptions{})
+		return err
+	})
+	Expect(err).NotTo(HaveOccurred(), "failed to trigger a renewal of the certificate")
+}
+
+// vaultRevocationFor returns the revocation configuration of the referenced
+// issuer, or nil if it is not a Vault issuer configured to revoke certificates.
+func vaultRevocationFor(f *framework.Framework, issuerRef cmmeta.ObjectReference) *cmapi.VaultRevocation {
+	if issuerRef.Group != "" && issuerRef.Group != "cert-manager.io" {
+		return nil
+	}
+
+	var spec *cmapi.IssuerSpec
+	switch issuerRef.Kind {
+	case "", cmapi.IssuerKind:
+		iss, err := f.CertManagerClientSet.CertmanagerV1().Issuers(f.Namespace.Name).Get(context.TODO(), issuerRef.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		spec = &iss.Spec
+	case cmapi.ClusterIssuerKind:
+		iss, err := f.CertManagerClientSet.CertmanagerV1().ClusterIssuers().Get(context.TODO(), issuerRef.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		spec = &iss.Spec
+	default:
+		return nil
+	}
+
+	if spec.Vault == nil {
+		return nil
+	}
+	return spec.Vault.Revocation
+}
@@ -19,6 +19,7 @@ package conformance
 import (
 	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificates/acme"
 	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificates/ca"
+	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificates/configured"
 	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificates/external"
 	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificates/selfsigned"
 	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificates/vault"
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/integration/framework"
+)
+
+// Test_ValidationRules ensures that basic errors in a Certificate's spec are
+// rejected by the API server itself, without the webhook, and that
+// Certificates which were created before the rules were enforced can still be
+// updated.
+func Test_ValidationRules(t *testing.T) {
+	const namespace = "test-validation-rules"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*40)
+	defer cancel()
+
+	restConfig, stopFn := framework.RunControlPlane(t, ctx)
+	defer stopFn()
+
+	kubeClient, _, cmClient, _ := framework.NewClients(t, restConfig)
+
+	// Remove the webhooks so that only the API server validates Certificates.
+	require.NoError(t, kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(ctx, "cert-manager-webhook", metav1.DeleteOptions{}))
+	require.NoError(t, kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(ctx, "cert-manager-webhook", metav1.DeleteOptions{}))
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	validSpec := func() cmapi.CertificateSpec {
+		return cmapi.CertificateSpec{
+			SecretName: "test",
+			DNSNames:   []string{"example.com"},
+			IssuerRef:  cmmeta.ObjectReference{Name: "test"},
+		}
+	}
+	noSubject := func(spec *cmapi.CertificateSpec) {
+		spec.DNSNames = nil
+	}
+	renewBeforeGreaterThanDuration := func(spec *cmapi.CertificateSpec) {
+		spec.Duration = &metav1.Duration{Duration: time.Hour * 24}
+		spec.RenewBefore = &metav1.Duration{Duration: time.Hour * 48}
+	}
+
+	tests := map[string]struct {
+		// existing is applied to the spec of the Certificate when it is
+		// created, and update when it is updated.
+		existing, update func(*cmapi.CertificateSpec)
+		expErr           bool
+	}{
+		"valid spec": {
+			update: func(*cmapi.CertificateSpec) {},
+		},
+		"renewBefore less than the default duration": {
+			update: func(spec *cmapi.CertificateSpec) {
+				spec.RenewBefore = &metav1.Duration{Duration: time.Hour * 24 * 30}
+			},
+		},
+		"no common name or subject alternative names": {
+			update: noSubject,
+			expErr: true,
+		},
+		"renewBefore greater than duration": {
+			update: renewBeforeGreaterThanDuration,
+			expErr: true,
+		},
+		"renewBefore greater than the default duration": {
+			update: func(spec *cmapi.CertificateSpec) {
+				spec.RenewBefore = &metav1.Duration{Duration: time.Hour * 24 * 100}
+			},
+			expErr: true,
+		},
+		"existing Certificate with no common name or subject alternative names can be updated": {
+			existing: noSubject,
+			update: func(spec *cmapi.CertificateSpec) {
+				noSubject(spec)
+				spec.SecretName = "updated"
+			},
+		},
+		"existing Certificate with renewBefore greater than duration can be updated": {
+			existing: renewBeforeGreaterThanDuration,
+			update: func(spec *cmapi.CertificateSpec) {
+				renewBeforeGreaterThanDuration(spec)
+				spec.SecretName = "updated"
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Transition rules are not evaluated on create, so existing
+			// non-conforming Certificates can be created without the webhook.
+			spec := validSpec()
+			if test.existing != nil {
+				test.existing(&spec)
+			}
+			crt := &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "test-", Namespace: namespace},
+				Spec:       spec,
+			}
+			crt, err := cmClient.CertmanagerV1().Certificates(namespace).Create(ctx, crt, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			spec = validSpec()
+			test.update(&spec)
+			crt.Spec = spec
+			_, err = cmClient.CertmanagerV1().Certificates(namespace).Update(ctx, crt, metav1.UpdateOptions{})
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cert-manager/cert-manager/pkg/api"
+	"github.com/cert-manager/cert-manager/test/integration/framework"
+)
+
+// TestValidationCertificateExtendedDurations ensures that durations using
+// days, weeks or years are converted by the webhook, and are rejected by the
+// CRD schema rather than stored when the webhook is not installed, as
+// controllers are not able to decode them.
+func TestValidationCertificateExtendedDurations(t *testing.T) {
+	tests := map[string]struct {
+		removeWebhooks bool
+		expDuration    string
+		errorContains  string
+	}{
+		"durations are converted when the webhook is installed": {
+			expDuration: "2160h0m0s",
+		},
+		"durations are rejected when the webhook is not installed": {
+			removeWebhooks: true,
+			errorContains:  "spec.duration",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*40)
+			defer cancel()
+
+			config, stop := framework.RunControlPlane(t, ctx)
+			defer stop()
+
+			framework.WaitForOpenAPIResourcesToBeLoaded(t, ctx, config, certificateGVK)
+
+			cl, err := client.New(config, client.Options{Scheme: api.Scheme})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.removeWebhooks {
+				objMeta := metav1.ObjectMeta{Name: "cert-manager-webhook"}
+				if err := cl.Delete(ctx, &admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: objMeta}); err != nil {
+					t.Fatal(err)
+				}
+				if err := cl.Delete(ctx, &admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: objMeta}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Use an unstructured object, as the typed Certificate can only
+			// encode durations in Go units.
+			cert := &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":      "testing",
+					"namespace": "default",
+				},
+				"spec": map[string]interface{}{
+					"secretName": "testing-tls",
+					"dnsNames":   []interface{}{"myhostname.com"},
+					"duration":   "90d",
+					"issuerRef": map[string]interface{}{
+						"name": "letsencrypt-staging",
+					},
+				},
+			}}
+			cert.SetGroupVersionKind(certificateGVK)
+
+			err = cl.Create(ctx, cert)
+			if test.errorContains != "" {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				if !strings.Contains(err.Error(), test.errorContains) {
+					t.Errorf("expected error containing %q, got error: %q", test.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			duration, _, err := unstructured.NestedString(cert.Object, "spec", "duration")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if duration != test.expDuration {
+				t.Errorf("expected duration %q, got %q", test.expDuration, duration)
+			}
+		})
+	}
+}
@@ -75,6 +75,14 @@ func SetFakeSecretsGetterGet(s *corev1.Secret, err error) FakeSecretsGetterModif
 	}
 }
 
+// SetFakeSecretsGetterGetFn is a function that can be used to inject code
+// when FakeSecretsGetter(<namespace>).Get(<context>,<uid>,<opts>) is called.
+func SetFakeSecretsGetterGetFn(fn func() (*corev1.Secret, error)) FakeSecretsGetterModifier {
+	return func(f *FakeSecretsGetter) {
+		f.c.GetFn = fn
+	}
+}
+
 // SetFakeSecretsGetterApplyFn is a function that can be used to inject code
 // when the FakeSecretsGetter is Applied.
 func SetFakeSecretsGetterApplyFn(fn ApplyFn) FakeSecretsGetterModifier {
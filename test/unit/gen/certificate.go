@@ -273,8 +273,38 @@ func SetCertificateRevisionHistoryLimit(limit int32) CertificateModifier {
 	}
 }
 
+func SetCertificateControllerName(name string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.ControllerName = name
+	}
+}
+
 func SetCertificateAdditionalOutputFormats(additionalOutputFormats ...v1.CertificateAdditionalOutputFormat) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.AdditionalOutputFormats = additionalOutputFormats
 	}
 }
+
+func SetCertificateSMIME(smime v1.CertificateSMIME) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.SMIME = &smime
+	}
+}
+
+func SetCertificateClientIdentity(clientIdentity v1.CertificateClientIdentity) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.ClientIdentity = &clientIdentity
+	}
+}
+
+func SetCertificateSplitIssuances(splitIssuances ...v1.CertificateSplitIssuance) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.SplitIssuances = splitIssuances
+	}
+}
+
+func SetCertificateSecretDeletionPolicy(policy v1.SecretDeletionPolicy) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.SecretDeletionPolicy = policy
+	}
+}
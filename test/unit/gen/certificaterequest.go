@@ -157,6 +157,17 @@ func SetCertificateRequestAnnotations(annotations map[string]string) Certificate
 	}
 }
 
+func AddCertificateRequestLabels(labels map[string]string) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		if cr.Labels == nil {
+			cr.Labels = make(map[string]string)
+		}
+		for k, v := range labels {
+			cr.Labels[k] = v
+		}
+	}
+}
+
 func DeleteCertificateRequestAnnotation(key string) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		if cr.Annotations == nil {
@@ -172,6 +183,18 @@ func SetCertificateRequestFailureTime(p metav1.Time) CertificateRequestModifier
 	}
 }
 
+func SetCertificateRequestPickupID(pickupID string) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.Status.PickupID = pickupID
+	}
+}
+
+func SetCertificateRequestNextPollTime(p metav1.Time) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.Status.NextPollTime = &p
+	}
+}
+
 func SetCertificateRequestTypeMeta(tm metav1.TypeMeta) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.TypeMeta = tm
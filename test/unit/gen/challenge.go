@@ -73,6 +73,18 @@ func SetChallengeDNSName(dnsName string) ChallengeModifier {
 	}
 }
 
+func SetChallengeKey(key string) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Spec.Key = key
+	}
+}
+
+func SetChallengeSolver(s cmacme.ACMEChallengeSolver) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Spec.Solver = s
+	}
+}
+
 func SetChallengePresented(p bool) ChallengeModifier {
 	return func(ch *cmacme.Challenge) {
 		ch.Status.Presented = p
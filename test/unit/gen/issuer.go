@@ -17,6 +17,8 @@ limitations under the License.
 package gen
 
 import (
+	"time"
+
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -350,20 +352,82 @@ func SetIssuerSelfSigned(a v1.SelfSignedIssuer) IssuerModifier {
 	}
 }
 
+func SetIssuerFake(a v1.FakeIssuer) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().Fake = &a
+	}
+}
+
+func SetIssuerDelegate(a v1.DelegateIssuer) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().Delegate = &a
+	}
+}
+
+func SetIssuerOffline(a v1.OfflineIssuer) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().Offline = &a
+	}
+}
+
 func SetIssuerVenafi(a v1.VenafiIssuer) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetSpec().Venafi = &a
 	}
 }
 
+func SetIssuerHTTPClient(c v1.IssuerHTTPClient) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().HTTPClient = &c
+	}
+}
+
+func SetIssuerControllerName(name string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().ControllerName = name
+	}
+}
+
+func SetIssuerDurationTolerance(maxShortening time.Duration) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().DurationTolerance = &v1.IssuerDurationTolerance{
+			MaxShortening: metav1.Duration{Duration: maxShortening},
+		}
+	}
+}
+
+func SetIssuerIntermediateCA(ica v1.IssuerIntermediateCA) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().IntermediateCA = &ica
+	}
+}
+
 func AddIssuerCondition(c v1.IssuerCondition) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetStatus().Conditions = append(iss.GetStatus().Conditions, c)
 	}
 }
 
+func SetIssuerUsage(u *v1.IssuerUsageStatus) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetStatus().Usage = u
+	}
+}
+
 func SetIssuerNamespace(namespace string) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetObjectMeta().Namespace = namespace
 	}
 }
+
+func AddIssuerAnnotations(annotations map[string]string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		meta := iss.GetObjectMeta()
+		if meta.Annotations == nil {
+			meta.Annotations = make(map[string]string)
+		}
+		for k, v := range annotations {
+			meta.Annotations[k] = v
+		}
+	}
+}